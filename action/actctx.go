@@ -8,8 +8,11 @@ package action
 import (
 	"math/big"
 
+	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action/actionpb"
 )
 
 // AbstractAction is an abstract implementation of Action interface
@@ -21,6 +24,9 @@ type AbstractAction struct {
 	gasPrice  *big.Int
 	gasTipCap *big.Int
 	gasFeeCap *big.Int
+	gasPayer  address.Address
+	// the version of the action hash, 0 for HashV1
+	hashVersion HashVersion
 }
 
 // Version returns the version
@@ -75,6 +81,17 @@ func (act *AbstractAction) GasFeeCap() *big.Int {
 	return new(big.Int).Set(act.gasFeeCap)
 }
 
+// GasPayer returns the address which pays the gas, nil if the gas is paid by the sender
+func (act *AbstractAction) GasPayer() address.Address { return act.gasPayer }
+
+// HashVersion returns the version of the algorithm computing the action hash
+func (act *AbstractAction) HashVersion() HashVersion {
+	if act.hashVersion == 0 {
+		return HashV1
+	}
+	return act.hashVersion
+}
+
 // BasicActionSize returns the basic size of action
 func (act *AbstractAction) BasicActionSize() uint32 {
 	// VersionSizeInBytes + NonceSizeInBytes + GasSizeInBytes
@@ -134,6 +151,15 @@ func (act *AbstractAction) toProto() *iotextypes.ActionCore {
 	if act.gasFeeCap != nil {
 		actCore.GasFeeCap = act.gasFeeCap.String()
 	}
+	ext := actionpb.ActionCoreExt{}
+	if act.gasPayer != nil {
+		ext.GasPayer = act.gasPayer.Bytes()
+	}
+	if act.HashVersion() != HashV1 {
+		hv := uint64(act.hashVersion)
+		ext.HashVersion = &hv
+	}
+	MustAppendUnknownFields(&actCore, &ext)
 	return &actCore
 }
 
@@ -165,5 +191,25 @@ func (act *AbstractAction) fromProto(pb *iotextypes.ActionCore) error {
 			return errors.Errorf("invalid gasFeeCap %s", gasFee)
 		}
 	}
+	ext := actionpb.ActionCoreExt{}
+	if err := LoadUnknownFields(pb, &ext); err != nil {
+		return err
+	}
+	act.gasPayer = nil
+	if b := ext.GetGasPayer(); len(b) > 0 {
+		var err error
+		if act.gasPayer, err = address.FromBytes(b); err != nil {
+			return errors.Wrap(err, "invalid gas payer")
+		}
+	}
+	act.hashVersion = 0
+	if ext.HashVersion != nil {
+		v := ext.GetHashVersion()
+		// an explicit version must not be HashV1, so that an action has a single encoding
+		if hv := HashVersion(v); uint64(hv) != v || hv == HashV1 || !hv.IsValid() {
+			return errors.Wrapf(ErrUnsupportedHashVersion, "hash version %d", v)
+		}
+		act.hashVersion = HashVersion(v)
+	}
 	return nil
 }
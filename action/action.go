@@ -13,6 +13,8 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/pkg/errors"
+
+	cp "github.com/iotexproject/iotex-core/crypto"
 )
 
 type (
@@ -44,8 +46,8 @@ type (
 	}
 )
 
-// Sign signs the action using sender's private key
-func Sign(act Envelope, sk crypto.PrivateKey) (*SealedEnvelope, error) {
+// Sign signs the action using sender's private key, or the signer of the key
+func Sign(act Envelope, sk cp.Signer) (*SealedEnvelope, error) {
 	sealed := &SealedEnvelope{
 		Envelope:  act,
 		srcPubkey: sk.PublicKey(),
@@ -74,7 +76,8 @@ func FakeSeal(act Envelope, pubk crypto.PublicKey) *SealedEnvelope {
 }
 
 // AssembleSealedEnvelope assembles a SealedEnvelope use Envelope, Sender Address and Signature.
-// This method should be only used in tests.
+// This method should be only used in tests, or for the signature made outside, e.g. by a hardware wallet, which
+// should be verified by VerifySignature.
 func AssembleSealedEnvelope(act Envelope, pk crypto.PublicKey, sig []byte) *SealedEnvelope {
 	sealed := &SealedEnvelope{
 		Envelope:  act,
@@ -0,0 +1,1290 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+// To compile the proto, run:
+//      protoc --go_out=plugins=grpc:. *.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.19.4
+// source: action.proto
+
+package actionpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ActionCoreExt is the fields added to iotextypes.ActionCore, which are carried in its unknown fields until
+// iotex-proto defines them. The messages with the suffix Ext are the registry of the fields added to the messages
+// of iotex-proto: a number must not collide with the fields of the message in iotex-proto, and must not be reused
+type ActionCoreExt struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StakeTransferLock      *StakeTransferLock      `protobuf:"bytes,54,opt,name=stakeTransferLock,proto3" json:"stakeTransferLock,omitempty"`
+	ReportMisbehavior      *ReportMisbehavior      `protobuf:"bytes,55,opt,name=reportMisbehavior,proto3" json:"reportMisbehavior,omitempty"`
+	GasPayer               []byte                  `protobuf:"bytes,56,opt,name=gasPayer,proto3" json:"gasPayer,omitempty"`
+	UpdateCandidateProfile *UpdateCandidateProfile `protobuf:"bytes,57,opt,name=updateCandidateProfile,proto3" json:"updateCandidateProfile,omitempty"`
+	HashVersion            *uint64                 `protobuf:"varint,58,opt,name=hashVersion,proto3,oneof" json:"hashVersion,omitempty"`
+	TimeLockedTransfer     *TimeLockedTransfer     `protobuf:"bytes,59,opt,name=timeLockedTransfer,proto3" json:"timeLockedTransfer,omitempty"`
+}
+
+func (x *ActionCoreExt) Reset() {
+	*x = ActionCoreExt{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_action_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActionCoreExt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionCoreExt) ProtoMessage() {}
+
+func (x *ActionCoreExt) ProtoReflect() protoreflect.Message {
+	mi := &file_action_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionCoreExt.ProtoReflect.Descriptor instead.
+func (*ActionCoreExt) Descriptor() ([]byte, []int) {
+	return file_action_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ActionCoreExt) GetStakeTransferLock() *StakeTransferLock {
+	if x != nil {
+		return x.StakeTransferLock
+	}
+	return nil
+}
+
+func (x *ActionCoreExt) GetReportMisbehavior() *ReportMisbehavior {
+	if x != nil {
+		return x.ReportMisbehavior
+	}
+	return nil
+}
+
+func (x *ActionCoreExt) GetGasPayer() []byte {
+	if x != nil {
+		return x.GasPayer
+	}
+	return nil
+}
+
+func (x *ActionCoreExt) GetUpdateCandidateProfile() *UpdateCandidateProfile {
+	if x != nil {
+		return x.UpdateCandidateProfile
+	}
+	return nil
+}
+
+func (x *ActionCoreExt) GetHashVersion() uint64 {
+	if x != nil && x.HashVersion != nil {
+		return *x.HashVersion
+	}
+	return 0
+}
+
+func (x *ActionCoreExt) GetTimeLockedTransfer() *TimeLockedTransfer {
+	if x != nil {
+		return x.TimeLockedTransfer
+	}
+	return nil
+}
+
+// ActionExt is the fields added to iotextypes.Action
+type ActionExt struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GasPayerSignature *GasPayerSignature `protobuf:"bytes,56,opt,name=gasPayerSignature,proto3" json:"gasPayerSignature,omitempty"`
+}
+
+func (x *ActionExt) Reset() {
+	*x = ActionExt{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_action_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActionExt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionExt) ProtoMessage() {}
+
+func (x *ActionExt) ProtoReflect() protoreflect.Message {
+	mi := &file_action_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionExt.ProtoReflect.Descriptor instead.
+func (*ActionExt) Descriptor() ([]byte, []int) {
+	return file_action_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ActionExt) GetGasPayerSignature() *GasPayerSignature {
+	if x != nil {
+		return x.GasPayerSignature
+	}
+	return nil
+}
+
+// CandidateBasicInfoExt is the fields added to iotextypes.CandidateBasicInfo
+type CandidateBasicInfoExt struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PayoutSplit []*PayoutShare `protobuf:"bytes,4,rep,name=payoutSplit,proto3" json:"payoutSplit,omitempty"`
+}
+
+func (x *CandidateBasicInfoExt) Reset() {
+	*x = CandidateBasicInfoExt{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_action_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CandidateBasicInfoExt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CandidateBasicInfoExt) ProtoMessage() {}
+
+func (x *CandidateBasicInfoExt) ProtoReflect() protoreflect.Message {
+	mi := &file_action_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CandidateBasicInfoExt.ProtoReflect.Descriptor instead.
+func (*CandidateBasicInfoExt) Descriptor() ([]byte, []int) {
+	return file_action_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CandidateBasicInfoExt) GetPayoutSplit() []*PayoutShare {
+	if x != nil {
+		return x.PayoutSplit
+	}
+	return nil
+}
+
+// ReceiptExt is the fields added to iotextypes.Receipt
+type ReceiptExt struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GasPayer string `protobuf:"bytes,56,opt,name=gasPayer,proto3" json:"gasPayer,omitempty"`
+	// the contract changes are not covered by the hash of the receipt
+	CreatedContracts    []*ContractChange `protobuf:"bytes,57,rep,name=createdContracts,proto3" json:"createdContracts,omitempty"`
+	DestructedContracts []*ContractChange `protobuf:"bytes,58,rep,name=destructedContracts,proto3" json:"destructedContracts,omitempty"`
+	// set by the api only, not stored with the receipt
+	StatusMessage string `protobuf:"bytes,59,opt,name=statusMessage,proto3" json:"statusMessage,omitempty"`
+	SystemGas     uint64 `protobuf:"varint,60,opt,name=systemGas,proto3" json:"systemGas,omitempty"`
+}
+
+func (x *ReceiptExt) Reset() {
+	*x = ReceiptExt{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_action_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReceiptExt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiptExt) ProtoMessage() {}
+
+func (x *ReceiptExt) ProtoReflect() protoreflect.Message {
+	mi := &file_action_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiptExt.ProtoReflect.Descriptor instead.
+func (*ReceiptExt) Descriptor() ([]byte, []int) {
+	return file_action_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ReceiptExt) GetGasPayer() string {
+	if x != nil {
+		return x.GasPayer
+	}
+	return ""
+}
+
+func (x *ReceiptExt) GetCreatedContracts() []*ContractChange {
+	if x != nil {
+		return x.CreatedContracts
+	}
+	return nil
+}
+
+func (x *ReceiptExt) GetDestructedContracts() []*ContractChange {
+	if x != nil {
+		return x.DestructedContracts
+	}
+	return nil
+}
+
+func (x *ReceiptExt) GetStatusMessage() string {
+	if x != nil {
+		return x.StatusMessage
+	}
+	return ""
+}
+
+func (x *ReceiptExt) GetSystemGas() uint64 {
+	if x != nil {
+		return x.SystemGas
+	}
+	return 0
+}
+
+// CandidateV2Ext is the fields added to iotextypes.CandidateV2
+type CandidateV2Ext struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PayoutSplit          []*PayoutShare `protobuf:"bytes,9,rep,name=payoutSplit,proto3" json:"payoutSplit,omitempty"`
+	NextPayoutSplit      []*PayoutShare `protobuf:"bytes,10,rep,name=nextPayoutSplit,proto3" json:"nextPayoutSplit,omitempty"`
+	NextPayoutSplitEpoch uint64         `protobuf:"varint,11,opt,name=nextPayoutSplitEpoch,proto3" json:"nextPayoutSplitEpoch,omitempty"`
+	// the serialized stakingpb.CandidateProfile
+	Profile                []byte `protobuf:"bytes,12,opt,name=profile,proto3" json:"profile,omitempty"`
+	NextRewardAddress      string `protobuf:"bytes,14,opt,name=nextRewardAddress,proto3" json:"nextRewardAddress,omitempty"`
+	NextRewardAddressEpoch uint64 `protobuf:"varint,15,opt,name=nextRewardAddressEpoch,proto3" json:"nextRewardAddressEpoch,omitempty"`
+}
+
+func (x *CandidateV2Ext) Reset() {
+	*x = CandidateV2Ext{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_action_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CandidateV2Ext) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CandidateV2Ext) ProtoMessage() {}
+
+func (x *CandidateV2Ext) ProtoReflect() protoreflect.Message {
+	mi := &file_action_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CandidateV2Ext.ProtoReflect.Descriptor instead.
+func (*CandidateV2Ext) Descriptor() ([]byte, []int) {
+	return file_action_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CandidateV2Ext) GetPayoutSplit() []*PayoutShare {
+	if x != nil {
+		return x.PayoutSplit
+	}
+	return nil
+}
+
+func (x *CandidateV2Ext) GetNextPayoutSplit() []*PayoutShare {
+	if x != nil {
+		return x.NextPayoutSplit
+	}
+	return nil
+}
+
+func (x *CandidateV2Ext) GetNextPayoutSplitEpoch() uint64 {
+	if x != nil {
+		return x.NextPayoutSplitEpoch
+	}
+	return 0
+}
+
+func (x *CandidateV2Ext) GetProfile() []byte {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+func (x *CandidateV2Ext) GetNextRewardAddress() string {
+	if x != nil {
+		return x.NextRewardAddress
+	}
+	return ""
+}
+
+func (x *CandidateV2Ext) GetNextRewardAddressEpoch() uint64 {
+	if x != nil {
+		return x.NextRewardAddressEpoch
+	}
+	return 0
+}
+
+// AccountMetaExt is the fields added to iotextypes.AccountMeta
+type AccountMetaExt struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LockedBalance string `protobuf:"bytes,8,opt,name=lockedBalance,proto3" json:"lockedBalance,omitempty"`
+}
+
+func (x *AccountMetaExt) Reset() {
+	*x = AccountMetaExt{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_action_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AccountMetaExt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountMetaExt) ProtoMessage() {}
+
+func (x *AccountMetaExt) ProtoReflect() protoreflect.Message {
+	mi := &file_action_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountMetaExt.ProtoReflect.Descriptor instead.
+func (*AccountMetaExt) Descriptor() ([]byte, []int) {
+	return file_action_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AccountMetaExt) GetLockedBalance() string {
+	if x != nil {
+		return x.LockedBalance
+	}
+	return ""
+}
+
+// BlockHeaderCoreExt is the fields added to iotextypes.BlockHeaderCore
+type BlockHeaderCoreExt struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RandomnessProof []byte `protobuf:"bytes,20,opt,name=randomnessProof,proto3" json:"randomnessProof,omitempty"`
+}
+
+func (x *BlockHeaderCoreExt) Reset() {
+	*x = BlockHeaderCoreExt{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_action_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockHeaderCoreExt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockHeaderCoreExt) ProtoMessage() {}
+
+func (x *BlockHeaderCoreExt) ProtoReflect() protoreflect.Message {
+	mi := &file_action_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockHeaderCoreExt.ProtoReflect.Descriptor instead.
+func (*BlockHeaderCoreExt) Descriptor() ([]byte, []int) {
+	return file_action_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BlockHeaderCoreExt) GetRandomnessProof() []byte {
+	if x != nil {
+		return x.RandomnessProof
+	}
+	return nil
+}
+
+type StakeTransferLock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Op        uint32   `protobuf:"varint,1,opt,name=op,proto3" json:"op,omitempty"`
+	Addresses []string `protobuf:"bytes,2,rep,name=addresses,proto3" json:"addresses,omitempty"`
+}
+
+func (x *StakeTransferLock) Reset() {
+	*x = StakeTransferLock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_action_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StakeTransferLock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StakeTransferLock) ProtoMessage() {}
+
+func (x *StakeTransferLock) ProtoReflect() protoreflect.Message {
+	mi := &file_action_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StakeTransferLock.ProtoReflect.Descriptor instead.
+func (*StakeTransferLock) Descriptor() ([]byte, []int) {
+	return file_action_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StakeTransferLock) GetOp() uint32 {
+	if x != nil {
+		return x.Op
+	}
+	return 0
+}
+
+func (x *StakeTransferLock) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+// ReportMisbehavior keeps the consensus messages as serialized, so that the hash of the action is stable
+type ReportMisbehavior struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	First  []byte `protobuf:"bytes,1,opt,name=first,proto3" json:"first,omitempty"`
+	Second []byte `protobuf:"bytes,2,opt,name=second,proto3" json:"second,omitempty"`
+}
+
+func (x *ReportMisbehavior) Reset() {
+	*x = ReportMisbehavior{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_action_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportMisbehavior) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportMisbehavior) ProtoMessage() {}
+
+func (x *ReportMisbehavior) ProtoReflect() protoreflect.Message {
+	mi := &file_action_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportMisbehavior.ProtoReflect.Descriptor instead.
+func (*ReportMisbehavior) Descriptor() ([]byte, []int) {
+	return file_action_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReportMisbehavior) GetFirst() []byte {
+	if x != nil {
+		return x.First
+	}
+	return nil
+}
+
+func (x *ReportMisbehavior) GetSecond() []byte {
+	if x != nil {
+		return x.Second
+	}
+	return nil
+}
+
+// UpdateCandidateProfile keeps the text fields in bytes, so that invalid utf-8 is rejected by the sanity check of
+// the action rather than its serialization
+type UpdateCandidateProfile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url             []byte `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Description     []byte `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	IconHash        []byte `protobuf:"bytes,3,opt,name=iconHash,proto3" json:"iconHash,omitempty"`
+	SecurityContact []byte `protobuf:"bytes,4,opt,name=securityContact,proto3" json:"securityContact,omitempty"`
+}
+
+func (x *UpdateCandidateProfile) Reset() {
+	*x = UpdateCandidateProfile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_action_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateCandidateProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCandidateProfile) ProtoMessage() {}
+
+func (x *UpdateCandidateProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_action_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCandidateProfile.ProtoReflect.Descriptor instead.
+func (*UpdateCandidateProfile) Descriptor() ([]byte, []int) {
+	return file_action_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateCandidateProfile) GetUrl() []byte {
+	if x != nil {
+		return x.Url
+	}
+	return nil
+}
+
+func (x *UpdateCandidateProfile) GetDescription() []byte {
+	if x != nil {
+		return x.Description
+	}
+	return nil
+}
+
+func (x *UpdateCandidateProfile) GetIconHash() []byte {
+	if x != nil {
+		return x.IconHash
+	}
+	return nil
+}
+
+func (x *UpdateCandidateProfile) GetSecurityContact() []byte {
+	if x != nil {
+		return x.SecurityContact
+	}
+	return nil
+}
+
+type TimeLockedTransfer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Amount       string `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Recipient    string `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	UnlockHeight uint64 `protobuf:"varint,3,opt,name=unlockHeight,proto3" json:"unlockHeight,omitempty"`
+	// unix timestamp in seconds
+	UnlockTimestamp uint64 `protobuf:"varint,4,opt,name=unlockTimestamp,proto3" json:"unlockTimestamp,omitempty"`
+}
+
+func (x *TimeLockedTransfer) Reset() {
+	*x = TimeLockedTransfer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_action_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TimeLockedTransfer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeLockedTransfer) ProtoMessage() {}
+
+func (x *TimeLockedTransfer) ProtoReflect() protoreflect.Message {
+	mi := &file_action_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeLockedTransfer.ProtoReflect.Descriptor instead.
+func (*TimeLockedTransfer) Descriptor() ([]byte, []int) {
+	return file_action_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TimeLockedTransfer) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *TimeLockedTransfer) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *TimeLockedTransfer) GetUnlockHeight() uint64 {
+	if x != nil {
+		return x.UnlockHeight
+	}
+	return 0
+}
+
+func (x *TimeLockedTransfer) GetUnlockTimestamp() uint64 {
+	if x != nil {
+		return x.UnlockTimestamp
+	}
+	return 0
+}
+
+type GasPayerSignature struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PubKey    []byte `protobuf:"bytes,1,opt,name=pubKey,proto3" json:"pubKey,omitempty"`
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *GasPayerSignature) Reset() {
+	*x = GasPayerSignature{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_action_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GasPayerSignature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GasPayerSignature) ProtoMessage() {}
+
+func (x *GasPayerSignature) ProtoReflect() protoreflect.Message {
+	mi := &file_action_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GasPayerSignature.ProtoReflect.Descriptor instead.
+func (*GasPayerSignature) Descriptor() ([]byte, []int) {
+	return file_action_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GasPayerSignature) GetPubKey() []byte {
+	if x != nil {
+		return x.PubKey
+	}
+	return nil
+}
+
+func (x *GasPayerSignature) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type PayoutShare struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address     string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	BasisPoints uint32 `protobuf:"varint,2,opt,name=basisPoints,proto3" json:"basisPoints,omitempty"`
+}
+
+func (x *PayoutShare) Reset() {
+	*x = PayoutShare{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_action_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PayoutShare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayoutShare) ProtoMessage() {}
+
+func (x *PayoutShare) ProtoReflect() protoreflect.Message {
+	mi := &file_action_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayoutShare.ProtoReflect.Descriptor instead.
+func (*PayoutShare) Descriptor() ([]byte, []int) {
+	return file_action_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PayoutShare) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *PayoutShare) GetBasisPoints() uint32 {
+	if x != nil {
+		return x.BasisPoints
+	}
+	return 0
+}
+
+// ContractChange is a contract created or self-destructed in an execution, where counterparty is the creator of the
+// contract or the beneficiary of the self-destruct
+type ContractChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address      string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Counterparty string `protobuf:"bytes,2,opt,name=counterparty,proto3" json:"counterparty,omitempty"`
+	Depth        uint32 `protobuf:"varint,3,opt,name=depth,proto3" json:"depth,omitempty"`
+}
+
+func (x *ContractChange) Reset() {
+	*x = ContractChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_action_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ContractChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContractChange) ProtoMessage() {}
+
+func (x *ContractChange) ProtoReflect() protoreflect.Message {
+	mi := &file_action_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContractChange.ProtoReflect.Descriptor instead.
+func (*ContractChange) Descriptor() ([]byte, []int) {
+	return file_action_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ContractChange) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ContractChange) GetCounterparty() string {
+	if x != nil {
+		return x.Counterparty
+	}
+	return ""
+}
+
+func (x *ContractChange) GetDepth() uint32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+var File_action_proto protoreflect.FileDescriptor
+
+var file_action_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x70, 0x62, 0x22, 0xa0, 0x03, 0x0a, 0x0d, 0x41, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x72, 0x65, 0x45, 0x78, 0x74, 0x12, 0x49, 0x0a, 0x11, 0x73, 0x74,
+	0x61, 0x6b, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4c, 0x6f, 0x63, 0x6b, 0x18,
+	0x36, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x70, 0x62,
+	0x2e, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4c, 0x6f,
+	0x63, 0x6b, 0x52, 0x11, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x4c, 0x6f, 0x63, 0x6b, 0x12, 0x49, 0x0a, 0x11, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x4d,
+	0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x18, 0x37, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x4d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x52, 0x11, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x4d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72,
+	0x12, 0x1a, 0x0a, 0x08, 0x67, 0x61, 0x73, 0x50, 0x61, 0x79, 0x65, 0x72, 0x18, 0x38, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x08, 0x67, 0x61, 0x73, 0x50, 0x61, 0x79, 0x65, 0x72, 0x12, 0x58, 0x0a, 0x16,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x39, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x70, 0x62, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x61,
+	0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x16,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x25, 0x0a, 0x0b, 0x68, 0x61, 0x73, 0x68, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x3a, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x0b, 0x68,
+	0x61, 0x73, 0x68, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x4c, 0x0a,
+	0x12, 0x74, 0x69, 0x6d, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x18, 0x3b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x70, 0x62, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x12, 0x74, 0x69, 0x6d, 0x65, 0x4c, 0x6f, 0x63,
+	0x6b, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x0e, 0x0a, 0x0c, 0x5f,
+	0x68, 0x61, 0x73, 0x68, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x56, 0x0a, 0x09, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x78, 0x74, 0x12, 0x49, 0x0a, 0x11, 0x67, 0x61, 0x73, 0x50,
+	0x61, 0x79, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x38, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x70, 0x62, 0x2e, 0x47,
+	0x61, 0x73, 0x50, 0x61, 0x79, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x52, 0x11, 0x67, 0x61, 0x73, 0x50, 0x61, 0x79, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x22, 0x50, 0x0a, 0x15, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x42, 0x61, 0x73, 0x69, 0x63, 0x49, 0x6e, 0x66, 0x6f, 0x45, 0x78, 0x74, 0x12, 0x37, 0x0a, 0x0b,
+	0x70, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x70, 0x62, 0x2e, 0x50, 0x61, 0x79,
+	0x6f, 0x75, 0x74, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x0b, 0x70, 0x61, 0x79, 0x6f, 0x75, 0x74,
+	0x53, 0x70, 0x6c, 0x69, 0x74, 0x22, 0xfe, 0x01, 0x0a, 0x0a, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70,
+	0x74, 0x45, 0x78, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x67, 0x61, 0x73, 0x50, 0x61, 0x79, 0x65, 0x72,
+	0x18, 0x38, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x61, 0x73, 0x50, 0x61, 0x79, 0x65, 0x72,
+	0x12, 0x44, 0x0a, 0x10, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x72,
+	0x61, 0x63, 0x74, 0x73, 0x18, 0x39, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x52, 0x10, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x6e,
+	0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x12, 0x4a, 0x0a, 0x13, 0x64, 0x65, 0x73, 0x74, 0x72, 0x75,
+	0x63, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x18, 0x3a, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x70, 0x62, 0x2e, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x13, 0x64,
+	0x65, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63,
+	0x74, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x3b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x47, 0x61, 0x73, 0x18, 0x3c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x73, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x47, 0x61, 0x73, 0x22, 0xbe, 0x02, 0x0a, 0x0e, 0x43, 0x61, 0x6e, 0x64, 0x69,
+	0x64, 0x61, 0x74, 0x65, 0x56, 0x32, 0x45, 0x78, 0x74, 0x12, 0x37, 0x0a, 0x0b, 0x70, 0x61, 0x79,
+	0x6f, 0x75, 0x74, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x70, 0x62, 0x2e, 0x50, 0x61, 0x79, 0x6f, 0x75, 0x74,
+	0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x0b, 0x70, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x53, 0x70, 0x6c,
+	0x69, 0x74, 0x12, 0x3f, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x79, 0x6f, 0x75, 0x74,
+	0x53, 0x70, 0x6c, 0x69, 0x74, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x70, 0x62, 0x2e, 0x50, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x53, 0x68, 0x61,
+	0x72, 0x65, 0x52, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x53, 0x70,
+	0x6c, 0x69, 0x74, 0x12, 0x32, 0x0a, 0x14, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x79, 0x6f, 0x75,
+	0x74, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x14, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x53, 0x70, 0x6c,
+	0x69, 0x74, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x12, 0x2c, 0x0a, 0x11, 0x6e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x6e, 0x65,
+	0x78, 0x74, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x36, 0x0a, 0x16, 0x6e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x16, 0x6e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x22, 0x36, 0x0a, 0x0e, 0x41, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x45, 0x78, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x6c, 0x6f, 0x63,
+	0x6b, 0x65, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x22,
+	0x3e, 0x0a, 0x12, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x43, 0x6f,
+	0x72, 0x65, 0x45, 0x78, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x6e,
+	0x65, 0x73, 0x73, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f,
+	0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x6e, 0x65, 0x73, 0x73, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22,
+	0x41, 0x0a, 0x11, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x4c, 0x6f, 0x63, 0x6b, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x02, 0x6f, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x65, 0x73, 0x22, 0x41, 0x0a, 0x11, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x4d, 0x69, 0x73, 0x62,
+	0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x72, 0x73, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x66, 0x69, 0x72, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x22, 0x92, 0x01, 0x0a, 0x16, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x75,
+	0x72, 0x6c, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x63, 0x6f, 0x6e, 0x48, 0x61, 0x73, 0x68,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x69, 0x63, 0x6f, 0x6e, 0x48, 0x61, 0x73, 0x68,
+	0x12, 0x28, 0x0a, 0x0f, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x43, 0x6f, 0x6e, 0x74,
+	0x61, 0x63, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x73, 0x65, 0x63, 0x75, 0x72,
+	0x69, 0x74, 0x79, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x22, 0x98, 0x01, 0x0a, 0x12, 0x54,
+	0x69, 0x6d, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63,
+	0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65,
+	0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x75, 0x6e, 0x6c, 0x6f, 0x63,
+	0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x75,
+	0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x75,
+	0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x75, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x49, 0x0a, 0x11, 0x47, 0x61, 0x73, 0x50, 0x61, 0x79, 0x65,
+	0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75,
+	0x62, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x75, 0x62, 0x4b,
+	0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x22, 0x49, 0x0a, 0x0b, 0x50, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x53, 0x68, 0x61, 0x72, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x62, 0x61, 0x73,
+	0x69, 0x73, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b,
+	0x62, 0x61, 0x73, 0x69, 0x73, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22, 0x64, 0x0a, 0x0e, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x65, 0x72, 0x70, 0x61, 0x72, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x70, 0x61, 0x72, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x64,
+	0x65, 0x70, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x64, 0x65, 0x70, 0x74,
+	0x68, 0x42, 0x34, 0x5a, 0x32, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x69, 0x6f, 0x74, 0x65, 0x78, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x2f, 0x69, 0x6f, 0x74,
+	0x65, 0x78, 0x2d, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_action_proto_rawDescOnce sync.Once
+	file_action_proto_rawDescData = file_action_proto_rawDesc
+)
+
+func file_action_proto_rawDescGZIP() []byte {
+	file_action_proto_rawDescOnce.Do(func() {
+		file_action_proto_rawDescData = protoimpl.X.CompressGZIP(file_action_proto_rawDescData)
+	})
+	return file_action_proto_rawDescData
+}
+
+var file_action_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_action_proto_goTypes = []any{
+	(*ActionCoreExt)(nil),          // 0: actionpb.ActionCoreExt
+	(*ActionExt)(nil),              // 1: actionpb.ActionExt
+	(*CandidateBasicInfoExt)(nil),  // 2: actionpb.CandidateBasicInfoExt
+	(*ReceiptExt)(nil),             // 3: actionpb.ReceiptExt
+	(*CandidateV2Ext)(nil),         // 4: actionpb.CandidateV2Ext
+	(*AccountMetaExt)(nil),         // 5: actionpb.AccountMetaExt
+	(*BlockHeaderCoreExt)(nil),     // 6: actionpb.BlockHeaderCoreExt
+	(*StakeTransferLock)(nil),      // 7: actionpb.StakeTransferLock
+	(*ReportMisbehavior)(nil),      // 8: actionpb.ReportMisbehavior
+	(*UpdateCandidateProfile)(nil), // 9: actionpb.UpdateCandidateProfile
+	(*TimeLockedTransfer)(nil),     // 10: actionpb.TimeLockedTransfer
+	(*GasPayerSignature)(nil),      // 11: actionpb.GasPayerSignature
+	(*PayoutShare)(nil),            // 12: actionpb.PayoutShare
+	(*ContractChange)(nil),         // 13: actionpb.ContractChange
+}
+var file_action_proto_depIdxs = []int32{
+	7,  // 0: actionpb.ActionCoreExt.stakeTransferLock:type_name -> actionpb.StakeTransferLock
+	8,  // 1: actionpb.ActionCoreExt.reportMisbehavior:type_name -> actionpb.ReportMisbehavior
+	9,  // 2: actionpb.ActionCoreExt.updateCandidateProfile:type_name -> actionpb.UpdateCandidateProfile
+	10, // 3: actionpb.ActionCoreExt.timeLockedTransfer:type_name -> actionpb.TimeLockedTransfer
+	11, // 4: actionpb.ActionExt.gasPayerSignature:type_name -> actionpb.GasPayerSignature
+	12, // 5: actionpb.CandidateBasicInfoExt.payoutSplit:type_name -> actionpb.PayoutShare
+	13, // 6: actionpb.ReceiptExt.createdContracts:type_name -> actionpb.ContractChange
+	13, // 7: actionpb.ReceiptExt.destructedContracts:type_name -> actionpb.ContractChange
+	12, // 8: actionpb.CandidateV2Ext.payoutSplit:type_name -> actionpb.PayoutShare
+	12, // 9: actionpb.CandidateV2Ext.nextPayoutSplit:type_name -> actionpb.PayoutShare
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_action_proto_init() }
+func file_action_proto_init() {
+	if File_action_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_action_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*ActionCoreExt); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_action_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ActionExt); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_action_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*CandidateBasicInfoExt); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_action_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*ReceiptExt); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_action_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*CandidateV2Ext); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_action_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*AccountMetaExt); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_action_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*BlockHeaderCoreExt); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_action_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*StakeTransferLock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_action_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*ReportMisbehavior); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_action_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateCandidateProfile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_action_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*TimeLockedTransfer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_action_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*GasPayerSignature); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_action_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*PayoutShare); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_action_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*ContractChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_action_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_action_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_action_proto_goTypes,
+		DependencyIndexes: file_action_proto_depIdxs,
+		MessageInfos:      file_action_proto_msgTypes,
+	}.Build()
+	File_action_proto = out.File
+	file_action_proto_rawDesc = nil
+	file_action_proto_goTypes = nil
+	file_action_proto_depIdxs = nil
+}
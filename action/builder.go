@@ -12,6 +12,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
 	"github.com/iotexproject/iotex-core/pkg/version"
 )
@@ -131,6 +132,18 @@ func (b *EnvelopeBuilder) SetChainID(chainID uint32) *EnvelopeBuilder {
 	return b
 }
 
+// SetGasPayer sets the address which pays the gas of the action.
+func (b *EnvelopeBuilder) SetGasPayer(payer address.Address) *EnvelopeBuilder {
+	b.elp.gasPayer = payer
+	return b
+}
+
+// SetHashVersion sets the version of the algorithm computing the action hash.
+func (b *EnvelopeBuilder) SetHashVersion(v HashVersion) *EnvelopeBuilder {
+	b.elp.hashVersion = v
+	return b
+}
+
 // Build builds a new action.
 func (b *EnvelopeBuilder) Build() Envelope {
 	return b.build()
@@ -143,6 +156,10 @@ func (b *EnvelopeBuilder) build() Envelope {
 	if b.elp.version == 0 {
 		b.elp.version = version.ProtocolVersion
 	}
+	if b.elp.hashVersion == HashV1 {
+		// HashV1 is implicit, not carried in the proto
+		b.elp.hashVersion = 0
+	}
 	if b.elp.payload == nil {
 		panic("cannot build Envelope w/o a valid payload")
 	}
@@ -152,7 +169,7 @@ func (b *EnvelopeBuilder) build() Envelope {
 
 // BuildTransfer loads transfer action into envelope
 func (b *EnvelopeBuilder) BuildTransfer(tx *types.Transaction) (Envelope, error) {
-	if tx.To() == nil {
+	if tx.To() == nil || len(tx.AccessList()) > 0 {
 		return nil, ErrInvalidAct
 	}
 	b.setEnvelopeCommonFields(tx)
@@ -168,6 +185,10 @@ func (b *EnvelopeBuilder) setEnvelopeCommonFields(tx *types.Transaction) {
 	b.elp.nonce = tx.Nonce()
 	b.elp.gasPrice = new(big.Int).Set(tx.GasPrice())
 	b.elp.gasLimit = tx.Gas()
+	if tx.Type() == types.DynamicFeeTxType {
+		b.elp.gasTipCap = new(big.Int).Set(tx.GasTipCap())
+		b.elp.gasFeeCap = new(big.Int).Set(tx.GasFeeCap())
+	}
 }
 
 func getRecipientAddr(addr *common.Address) string {
@@ -191,7 +212,7 @@ func (b *EnvelopeBuilder) BuildExecution(tx *types.Transaction) (Envelope, error
 
 // BuildStakingAction loads staking action into envelope from abi-encoded data
 func (b *EnvelopeBuilder) BuildStakingAction(tx *types.Transaction) (Envelope, error) {
-	if !bytes.Equal(tx.To().Bytes(), _stakingProtocolEthAddr.Bytes()) {
+	if !bytes.Equal(tx.To().Bytes(), _stakingProtocolEthAddr.Bytes()) || len(tx.AccessList()) > 0 {
 		return nil, ErrInvalidAct
 	}
 	b.setEnvelopeCommonFields(tx)
@@ -205,7 +226,7 @@ func (b *EnvelopeBuilder) BuildStakingAction(tx *types.Transaction) (Envelope, e
 
 // BuildRewardingAction loads rewarding action into envelope from abi-encoded data
 func (b *EnvelopeBuilder) BuildRewardingAction(tx *types.Transaction) (Envelope, error) {
-	if !bytes.Equal(tx.To().Bytes(), _rewardingProtocolEthAddr.Bytes()) {
+	if !bytes.Equal(tx.To().Bytes(), _rewardingProtocolEthAddr.Bytes()) || len(tx.AccessList()) > 0 {
 		return nil, ErrInvalidAct
 	}
 	b.setEnvelopeCommonFields(tx)
@@ -217,6 +238,28 @@ func (b *EnvelopeBuilder) BuildRewardingAction(tx *types.Transaction) (Envelope,
 	return b.build(), nil
 }
 
+// BuildAccountAction loads account action into envelope from abi-encoded data
+func (b *EnvelopeBuilder) BuildAccountAction(tx *types.Transaction) (Envelope, error) {
+	if !bytes.Equal(tx.To().Bytes(), _accountProtocolEthAddr.Bytes()) || len(tx.AccessList()) > 0 {
+		return nil, ErrInvalidAct
+	}
+	b.setEnvelopeCommonFields(tx)
+	act, err := newAccountActionFromABIBinary(tx.Data(), tx.Value())
+	if err != nil {
+		return nil, err
+	}
+	b.elp.payload = act
+	return b.build(), nil
+}
+
+// BuildFromProto loads the action core, e.g. of an unsigned action, into envelope
+func (b *EnvelopeBuilder) BuildFromProto(pbAct *iotextypes.ActionCore) (Envelope, error) {
+	if err := b.elp.LoadProto(pbAct); err != nil {
+		return nil, err
+	}
+	return &b.elp, nil
+}
+
 func newStakingActionFromABIBinary(data []byte) (actionPayload, error) {
 	if len(data) <= 4 {
 		return nil, ErrInvalidABI
@@ -260,6 +303,15 @@ func newStakingActionFromABIBinary(data []byte) (actionPayload, error) {
 	if act, err := NewMigrateStakeFromABIBinary(data); err == nil {
 		return act, nil
 	}
+	if act, err := NewStakeTransferLockFromABIBinary(data); err == nil {
+		return act, nil
+	}
+	if act, err := NewReportMisbehaviorFromABIBinary(data); err == nil {
+		return act, nil
+	}
+	if act, err := NewUpdateCandidateProfileFromABIBinary(data); err == nil {
+		return act, nil
+	}
 	return nil, ErrInvalidABI
 }
 
@@ -275,3 +327,13 @@ func newRewardingActionFromABIBinary(data []byte) (actionPayload, error) {
 	}
 	return nil, ErrInvalidABI
 }
+
+func newAccountActionFromABIBinary(data []byte, value *big.Int) (actionPayload, error) {
+	if len(data) <= 4 {
+		return nil, ErrInvalidABI
+	}
+	if act, err := NewTimeLockedTransferFromABIBinary(data, value); err == nil {
+		return act, nil
+	}
+	return nil, ErrInvalidABI
+}
@@ -15,12 +15,15 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	ethercrypto "github.com/ethereum/go-ethereum/crypto"
 	iotexcrypto "github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
 	. "github.com/iotexproject/iotex-core/pkg/util/assertions"
 	"github.com/iotexproject/iotex-core/pkg/version"
+	"github.com/iotexproject/iotex-core/test/identityset"
 )
 
 func TestBuilderEthAddr(t *testing.T) {
@@ -140,6 +143,44 @@ func TestBuildRewardingAction(t *testing.T) {
 	})
 }
 
+func TestBuildFromProto(t *testing.T) {
+	r := require.New(t)
+
+	tsf, err := NewTransfer(1, big.NewInt(10), identityset.Address(28).String(), []byte("test"), 0, nil)
+	r.NoError(err)
+	elp := (&EnvelopeBuilder{}).SetNonce(1).SetGasLimit(10000).SetGasPrice(big.NewInt(100)).
+		SetChainID(2).SetAction(tsf).Build()
+	core, err := proto.Marshal(elp.Proto())
+	r.NoError(err)
+
+	// the envelope loaded from the serialized core is signed with the same hash
+	pbAct := &iotextypes.ActionCore{}
+	r.NoError(proto.Unmarshal(core, pbAct))
+	elp2, err := (&EnvelopeBuilder{}).BuildFromProto(pbAct)
+	r.NoError(err)
+	r.Equal(elp.Proto(), elp2.Proto())
+	selp, err := Sign(elp2, identityset.PrivateKey(27))
+	r.NoError(err)
+	h, err := selp.envelopeHash()
+	r.NoError(err)
+	r.Equal(hash.Hash256b(core), h)
+
+	// the sealed envelope is loaded by the deserializer as signed
+	selp2, err := (&Deserializer{}).ActionToSealedEnvelope(selp.Proto())
+	r.NoError(err)
+	r.NoError(selp2.VerifySignature())
+	h1, err := selp.Hash()
+	r.NoError(err)
+	h2, err := selp2.Hash()
+	r.NoError(err)
+	r.Equal(h1, h2)
+
+	_, err = (&EnvelopeBuilder{}).BuildFromProto(&iotextypes.ActionCore{})
+	r.Error(err)
+	_, err = (&EnvelopeBuilder{}).BuildFromProto(nil)
+	r.Equal(ErrNilProto, err)
+}
+
 func TestEthTxUtils(t *testing.T) {
 	r := require.New(t)
 	var (
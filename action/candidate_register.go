@@ -280,6 +280,11 @@ func (cr *CandidateRegister) IntrinsicGas() (uint64, error) {
 	return CalculateIntrinsicGas(CandidateRegisterBaseIntrinsicGas, CandidateRegisterPayloadGas, payloadSize)
 }
 
+// payloadData returns the payload data of a CandidateRegister charged in the intrinsic gas
+func (cr *CandidateRegister) payloadData() ([]byte, uint64) {
+	return cr.Payload(), CandidateRegisterPayloadGas
+}
+
 // Cost returns the total cost of a CandidateRegister
 func (cr *CandidateRegister) Cost() (*big.Int, error) {
 	intrinsicGas, err := cr.IntrinsicGas()
@@ -124,6 +124,11 @@ func (act *CandidateTransferOwnership) IntrinsicGas() (uint64, error) {
 	return CalculateIntrinsicGas(CandidateTransferOwnershipBaseIntrinsicGas, CandidateTransferOwnershipPayloadGas, payloadSize)
 }
 
+// payloadData returns the payload data of a CandidateTransferOwnership charged in the intrinsic gas
+func (act *CandidateTransferOwnership) payloadData() ([]byte, uint64) {
+	return act.Payload(), CandidateTransferOwnershipPayloadGas
+}
+
 // Cost returns the total cost of a CandidateTransferOwnership
 func (act *CandidateTransferOwnership) Cost() (*big.Int, error) {
 	intrinsicGas, _ := act.IntrinsicGas()
@@ -16,6 +16,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-core/action/actionpb"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/pkg/version"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
@@ -24,6 +25,8 @@ import (
 const (
 	// CandidateUpdateBaseIntrinsicGas represents the base intrinsic gas for CandidateUpdate
 	CandidateUpdateBaseIntrinsicGas = uint64(10000)
+	// CandidateUpdatePayoutShareGas represents the intrinsic gas for each share of the payout split
+	CandidateUpdatePayoutShareGas = uint64(1000)
 
 	_candidateUpdateInterfaceABI = `[
 		{
@@ -48,6 +51,39 @@ const (
 			"outputs": [],
 			"stateMutability": "nonpayable",
 			"type": "function"
+		},
+		{
+			"inputs": [
+				{
+					"internalType": "string",
+					"name": "name",
+					"type": "string"
+				},
+				{
+					"internalType": "address",
+					"name": "operatorAddress",
+					"type": "address"
+				},
+				{
+					"internalType": "address",
+					"name": "rewardAddress",
+					"type": "address"
+				},
+				{
+					"internalType": "address[]",
+					"name": "payoutAddresses",
+					"type": "address[]"
+				},
+				{
+					"internalType": "uint32[]",
+					"name": "payoutBasisPoints",
+					"type": "uint32[]"
+				}
+			],
+			"name": "candidateUpdateWithPayoutSplit",
+			"outputs": [],
+			"stateMutability": "nonpayable",
+			"type": "function"
 		}
 	]`
 )
@@ -55,7 +91,9 @@ const (
 var (
 	// _candidateUpdateMethod is the interface of the abi encoding of stake action
 	_candidateUpdateMethod abi.Method
-	_                      EthCompatibleAction = (*CandidateUpdate)(nil)
+	// _candidateUpdateWithPayoutSplitMethod is the interface of the abi encoding of stake action with payout split
+	_candidateUpdateWithPayoutSplitMethod abi.Method
+	_                                     EthCompatibleAction = (*CandidateUpdate)(nil)
 )
 
 // CandidateUpdate is the action to update a candidate
@@ -65,6 +103,7 @@ type CandidateUpdate struct {
 	name            string
 	operatorAddress address.Address
 	rewardAddress   address.Address
+	payoutSplit     []PayoutShare
 }
 
 func init() {
@@ -77,6 +116,10 @@ func init() {
 	if !ok {
 		panic("fail to load the method")
 	}
+	_candidateUpdateWithPayoutSplitMethod, ok = _candidateUpdateInterface.Methods["candidateUpdateWithPayoutSplit"]
+	if !ok {
+		panic("fail to load the method")
+	}
 }
 
 // NewCandidateUpdate creates a CandidateUpdate instance
@@ -113,6 +156,23 @@ func NewCandidateUpdate(
 	return cu, nil
 }
 
+// NewCandidateUpdateWithPayoutSplit creates a CandidateUpdate instance which also sets the payout split of the
+// candidate's rewards
+func NewCandidateUpdateWithPayoutSplit(
+	nonce uint64,
+	name, operatorAddrStr, rewardAddrStr string,
+	split []PayoutShare,
+	gasLimit uint64,
+	gasPrice *big.Int,
+) (*CandidateUpdate, error) {
+	cu, err := NewCandidateUpdate(nonce, name, operatorAddrStr, rewardAddrStr, gasLimit, gasPrice)
+	if err != nil {
+		return nil, err
+	}
+	cu.payoutSplit = split
+	return cu, nil
+}
+
 // Name returns candidate name to update
 func (cu *CandidateUpdate) Name() string { return cu.name }
 
@@ -122,6 +182,9 @@ func (cu *CandidateUpdate) OperatorAddress() address.Address { return cu.operato
 // RewardAddress returns candidate rewardAddress to update
 func (cu *CandidateUpdate) RewardAddress() address.Address { return cu.rewardAddress }
 
+// PayoutSplit returns the payout split to update, nil if the payout split is not updated
+func (cu *CandidateUpdate) PayoutSplit() []PayoutShare { return cu.payoutSplit }
+
 // Serialize returns a raw byte stream of the CandidateUpdate struct
 func (cu *CandidateUpdate) Serialize() []byte {
 	return byteutil.Must(proto.Marshal(cu.Proto()))
@@ -141,6 +204,9 @@ func (cu *CandidateUpdate) Proto() *iotextypes.CandidateBasicInfo {
 		act.RewardAddress = cu.rewardAddress.String()
 	}
 
+	if len(cu.payoutSplit) > 0 {
+		MustAppendUnknownFields(act, &actionpb.CandidateBasicInfoExt{PayoutSplit: PayoutSplitToProto(cu.payoutSplit)})
+	}
 	return act
 }
 
@@ -167,12 +233,22 @@ func (cu *CandidateUpdate) LoadProto(pbAct *iotextypes.CandidateBasicInfo) error
 		}
 		cu.rewardAddress = rewardAddr
 	}
+
+	ext := actionpb.CandidateBasicInfoExt{}
+	if err := LoadUnknownFields(pbAct, &ext); err != nil {
+		return err
+	}
+	split, err := PayoutSplitFromProto(ext.GetPayoutSplit())
+	if err != nil {
+		return err
+	}
+	cu.payoutSplit = split
 	return nil
 }
 
 // IntrinsicGas returns the intrinsic gas of a CandidateUpdate
 func (cu *CandidateUpdate) IntrinsicGas() (uint64, error) {
-	return CandidateUpdateBaseIntrinsicGas, nil
+	return CandidateUpdateBaseIntrinsicGas + uint64(len(cu.payoutSplit))*CandidateUpdatePayoutShareGas, nil
 }
 
 // Cost returns the total cost of a CandidateUpdate
@@ -190,6 +266,11 @@ func (cu *CandidateUpdate) SanityCheck() error {
 	if !IsValidCandidateName(cu.Name()) {
 		return ErrInvalidCanName
 	}
+	if len(cu.payoutSplit) > 0 {
+		if err := ValidatePayoutSplit(cu.payoutSplit); err != nil {
+			return err
+		}
+	}
 
 	return cu.AbstractAction.SanityCheck()
 }
@@ -202,6 +283,25 @@ func (cu *CandidateUpdate) EthData() ([]byte, error) {
 	if cu.rewardAddress == nil {
 		return nil, ErrAddress
 	}
+	if len(cu.payoutSplit) > 0 {
+		addrs := make([]common.Address, len(cu.payoutSplit))
+		bps := make([]uint32, len(cu.payoutSplit))
+		for i, share := range cu.payoutSplit {
+			if share.Address == nil {
+				return nil, ErrAddress
+			}
+			addrs[i] = common.BytesToAddress(share.Address.Bytes())
+			bps[i] = share.BasisPoints
+		}
+		data, err := _candidateUpdateWithPayoutSplitMethod.Inputs.Pack(cu.name,
+			common.BytesToAddress(cu.operatorAddress.Bytes()),
+			common.BytesToAddress(cu.rewardAddress.Bytes()),
+			addrs, bps)
+		if err != nil {
+			return nil, err
+		}
+		return append(_candidateUpdateWithPayoutSplitMethod.ID, data...), nil
+	}
 	data, err := _candidateUpdateMethod.Inputs.Pack(cu.name,
 		common.BytesToAddress(cu.operatorAddress.Bytes()),
 		common.BytesToAddress(cu.rewardAddress.Bytes()))
@@ -218,12 +318,21 @@ func NewCandidateUpdateFromABIBinary(data []byte) (*CandidateUpdate, error) {
 		ok        bool
 		err       error
 		cu        CandidateUpdate
+		method    abi.Method
 	)
 	// sanity check
-	if len(data) <= 4 || !bytes.Equal(_candidateUpdateMethod.ID, data[:4]) {
+	if len(data) <= 4 {
+		return nil, errDecodeFailure
+	}
+	switch {
+	case bytes.Equal(_candidateUpdateMethod.ID, data[:4]):
+		method = _candidateUpdateMethod
+	case bytes.Equal(_candidateUpdateWithPayoutSplitMethod.ID, data[:4]):
+		method = _candidateUpdateWithPayoutSplitMethod
+	default:
 		return nil, errDecodeFailure
 	}
-	if err := _candidateUpdateMethod.Inputs.UnpackIntoMap(paramsMap, data[4:]); err != nil {
+	if err := method.Inputs.UnpackIntoMap(paramsMap, data[4:]); err != nil {
 		return nil, err
 	}
 	if cu.name, ok = paramsMap["name"].(string); !ok {
@@ -235,5 +344,22 @@ func NewCandidateUpdateFromABIBinary(data []byte) (*CandidateUpdate, error) {
 	if cu.rewardAddress, err = ethAddrToNativeAddr(paramsMap["rewardAddress"]); err != nil {
 		return nil, err
 	}
+	if method.RawName == _candidateUpdateWithPayoutSplitMethod.RawName {
+		addrs, ok := paramsMap["payoutAddresses"].([]common.Address)
+		if !ok {
+			return nil, errDecodeFailure
+		}
+		bps, ok := paramsMap["payoutBasisPoints"].([]uint32)
+		if !ok || len(bps) != len(addrs) {
+			return nil, errDecodeFailure
+		}
+		cu.payoutSplit = make([]PayoutShare, len(addrs))
+		for i := range addrs {
+			if cu.payoutSplit[i].Address, err = ethAddrToNativeAddr(addrs[i]); err != nil {
+				return nil, err
+			}
+			cu.payoutSplit[i].BasisPoints = bps[i]
+		}
+	}
 	return &cu, nil
 }
@@ -0,0 +1,252 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action/actionpb"
+	"github.com/iotexproject/iotex-core/pkg/version"
+)
+
+const (
+	// UpdateCandidateProfileBaseIntrinsicGas represents the base intrinsic gas for UpdateCandidateProfile
+	UpdateCandidateProfileBaseIntrinsicGas = uint64(10000)
+	// UpdateCandidateProfileByteGas represents the intrinsic gas for each byte of the profile
+	UpdateCandidateProfileByteGas = uint64(100)
+	// CandidateProfileURLSizeLimit is the max size of the url of a candidate profile
+	CandidateProfileURLSizeLimit = 256
+	// CandidateProfileDescriptionSizeLimit is the max size of the description of a candidate profile
+	CandidateProfileDescriptionSizeLimit = 512
+	// CandidateProfileIconHashSize is the size of the icon hash of a candidate profile, if set
+	CandidateProfileIconHashSize = 32
+	// CandidateProfileSecurityContactSizeLimit is the max size of the security contact of a candidate profile
+	CandidateProfileSecurityContactSizeLimit = 128
+	// CandidateProfileSizeLimit is the max total size of the fields of a candidate profile
+	CandidateProfileSizeLimit = 768
+
+	updateCandidateProfileInterfaceABI = `[
+		{
+			"inputs": [
+				{
+					"internalType": "string",
+					"name": "url",
+					"type": "string"
+				},
+				{
+					"internalType": "string",
+					"name": "description",
+					"type": "string"
+				},
+				{
+					"internalType": "bytes",
+					"name": "iconHash",
+					"type": "bytes"
+				},
+				{
+					"internalType": "string",
+					"name": "securityContact",
+					"type": "string"
+				}
+			],
+			"name": "updateCandidateProfile",
+			"outputs": [],
+			"stateMutability": "nonpayable",
+			"type": "function"
+		}
+	]`
+)
+
+var (
+	// ErrInvalidCandidateProfile indicates the candidate profile is invalid
+	ErrInvalidCandidateProfile = errors.New("invalid candidate profile")
+
+	updateCandidateProfileMethod abi.Method
+	_                            EthCompatibleAction = (*UpdateCandidateProfile)(nil)
+)
+
+// UpdateCandidateProfile is the action for the owner of a candidate to set the profile of the candidate, which
+// replaces the profile set before as a whole, and an empty profile removes it
+type UpdateCandidateProfile struct {
+	AbstractAction
+	stake_common
+	url             string
+	description     string
+	iconHash        []byte
+	securityContact string
+}
+
+func init() {
+	updateCandidateProfileInterface, err := abi.JSON(strings.NewReader(updateCandidateProfileInterfaceABI))
+	if err != nil {
+		panic(err)
+	}
+	var ok bool
+	updateCandidateProfileMethod, ok = updateCandidateProfileInterface.Methods["updateCandidateProfile"]
+	if !ok {
+		panic("fail to load the updateCandidateProfile method")
+	}
+}
+
+// NewUpdateCandidateProfile returns an UpdateCandidateProfile action
+func NewUpdateCandidateProfile(
+	nonce, gasLimit uint64,
+	gasPrice *big.Int,
+	url, description string,
+	iconHash []byte,
+	securityContact string,
+) *UpdateCandidateProfile {
+	return &UpdateCandidateProfile{
+		AbstractAction: AbstractAction{
+			version:  version.ProtocolVersion,
+			nonce:    nonce,
+			gasLimit: gasLimit,
+			gasPrice: gasPrice,
+		},
+		url:             url,
+		description:     description,
+		iconHash:        iconHash,
+		securityContact: securityContact,
+	}
+}
+
+// URL returns the url of the profile
+func (act *UpdateCandidateProfile) URL() string { return act.url }
+
+// Description returns the description of the profile
+func (act *UpdateCandidateProfile) Description() string { return act.description }
+
+// IconHash returns the hash of the icon of the profile
+func (act *UpdateCandidateProfile) IconHash() []byte { return act.iconHash }
+
+// SecurityContact returns the security contact of the profile
+func (act *UpdateCandidateProfile) SecurityContact() string { return act.securityContact }
+
+// Size returns the total size of the fields of the profile
+func (act *UpdateCandidateProfile) Size() uint64 {
+	return uint64(len(act.url) + len(act.description) + len(act.iconHash) + len(act.securityContact))
+}
+
+// IntrinsicGas returns the intrinsic gas of an UpdateCandidateProfile, charged by the bytes of the profile
+func (act *UpdateCandidateProfile) IntrinsicGas() (uint64, error) {
+	return CalculateIntrinsicGas(UpdateCandidateProfileBaseIntrinsicGas, UpdateCandidateProfileByteGas, act.Size())
+}
+
+// Cost returns the total cost of an UpdateCandidateProfile
+func (act *UpdateCandidateProfile) Cost() (*big.Int, error) {
+	intrinsicGas, err := act.IntrinsicGas()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get intrinsic gas for the UpdateCandidateProfile")
+	}
+	fee := big.NewInt(0).Mul(act.GasPrice(), big.NewInt(0).SetUint64(intrinsicGas))
+	return fee, nil
+}
+
+// SanityCheck validates the variables in the action
+func (act *UpdateCandidateProfile) SanityCheck() error {
+	if err := ValidateCandidateProfile(act.url, act.description, act.iconHash, act.securityContact); err != nil {
+		return err
+	}
+	return act.AbstractAction.SanityCheck()
+}
+
+// ValidateCandidateProfile checks the fields of a candidate profile are within the size limits, and the text fields
+// are valid utf-8
+func ValidateCandidateProfile(url, description string, iconHash []byte, securityContact string) error {
+	for _, f := range []struct {
+		name  string
+		value string
+		limit int
+	}{
+		{"url", url, CandidateProfileURLSizeLimit},
+		{"description", description, CandidateProfileDescriptionSizeLimit},
+		{"security contact", securityContact, CandidateProfileSecurityContactSizeLimit},
+	} {
+		if len(f.value) > f.limit {
+			return errors.Wrapf(ErrOversizedData, "%s of %d bytes exceeds %d", f.name, len(f.value), f.limit)
+		}
+		if !utf8.ValidString(f.value) {
+			return errors.Wrapf(ErrInvalidCandidateProfile, "%s is not valid utf-8", f.name)
+		}
+	}
+	if len(iconHash) != 0 && len(iconHash) != CandidateProfileIconHashSize {
+		return errors.Wrapf(ErrInvalidCandidateProfile, "icon hash of %d bytes", len(iconHash))
+	}
+	if size := len(url) + len(description) + len(iconHash) + len(securityContact); size > CandidateProfileSizeLimit {
+		return errors.Wrapf(ErrOversizedData, "profile of %d bytes exceeds %d", size, CandidateProfileSizeLimit)
+	}
+	return nil
+}
+
+// Proto converts UpdateCandidateProfile to protobuf
+func (act *UpdateCandidateProfile) Proto() *actionpb.UpdateCandidateProfile {
+	return &actionpb.UpdateCandidateProfile{
+		Url:             []byte(act.url),
+		Description:     []byte(act.description),
+		IconHash:        act.iconHash,
+		SecurityContact: []byte(act.securityContact),
+	}
+}
+
+// LoadProto converts protobuf to UpdateCandidateProfile
+func (act *UpdateCandidateProfile) LoadProto(pb *actionpb.UpdateCandidateProfile) error {
+	if pb == nil {
+		return ErrNilProto
+	}
+	act.url = string(pb.GetUrl())
+	act.description = string(pb.GetDescription())
+	act.iconHash = pb.GetIconHash()
+	act.securityContact = string(pb.GetSecurityContact())
+	return nil
+}
+
+// EthData returns the ABI-encoded data for converting to eth tx
+func (act *UpdateCandidateProfile) EthData() ([]byte, error) {
+	iconHash := act.iconHash
+	if iconHash == nil {
+		iconHash = []byte{}
+	}
+	data, err := updateCandidateProfileMethod.Inputs.Pack(act.url, act.description, iconHash, act.securityContact)
+	if err != nil {
+		return nil, err
+	}
+	return append(updateCandidateProfileMethod.ID, data...), nil
+}
+
+// NewUpdateCandidateProfileFromABIBinary parses the smart contract input and creates an action
+func NewUpdateCandidateProfileFromABIBinary(data []byte) (*UpdateCandidateProfile, error) {
+	if len(data) <= 4 || !bytes.Equal(updateCandidateProfileMethod.ID, data[:4]) {
+		return nil, errDecodeFailure
+	}
+	paramsMap := map[string]any{}
+	if err := updateCandidateProfileMethod.Inputs.UnpackIntoMap(paramsMap, data[4:]); err != nil {
+		return nil, err
+	}
+	act := &UpdateCandidateProfile{}
+	var ok bool
+	if act.url, ok = paramsMap["url"].(string); !ok {
+		return nil, errDecodeFailure
+	}
+	if act.description, ok = paramsMap["description"].(string); !ok {
+		return nil, errDecodeFailure
+	}
+	if act.iconHash, ok = paramsMap["iconHash"].([]byte); !ok {
+		return nil, errDecodeFailure
+	}
+	if len(act.iconHash) == 0 {
+		act.iconHash = nil
+	}
+	if act.securityContact, ok = paramsMap["securityContact"].(string); !ok {
+		return nil, errDecodeFailure
+	}
+	return act, nil
+}
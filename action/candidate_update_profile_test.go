@@ -0,0 +1,97 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestUpdateCandidateProfile(t *testing.T) {
+	r := require.New(t)
+	var (
+		url         = "https://delegate.example"
+		description = "a delegate"
+		iconHash    = make([]byte, CandidateProfileIconHashSize)
+		contact     = "security@delegate.example"
+	)
+	iconHash[0] = 1
+
+	t.Run("SanityCheck", func(t *testing.T) {
+		r.NoError(NewUpdateCandidateProfile(1, 100000, big.NewInt(1), url, description, iconHash, contact).SanityCheck())
+		r.NoError(NewUpdateCandidateProfile(1, 100000, big.NewInt(1), "", "", nil, "").SanityCheck())
+		for _, c := range []struct {
+			url, description string
+			iconHash         []byte
+			contact          string
+			err              error
+		}{
+			{strings.Repeat("u", CandidateProfileURLSizeLimit+1), "", nil, "", ErrOversizedData},
+			{"", strings.Repeat("d", CandidateProfileDescriptionSizeLimit+1), nil, "", ErrOversizedData},
+			{"", "", nil, strings.Repeat("c", CandidateProfileSecurityContactSizeLimit+1), ErrOversizedData},
+			{strings.Repeat("u", CandidateProfileURLSizeLimit), strings.Repeat("d", CandidateProfileDescriptionSizeLimit), nil, "c", ErrOversizedData},
+			{"", "", []byte{1, 2, 3}, "", ErrInvalidCandidateProfile},
+			{"", "\xff\xfe", nil, "", ErrInvalidCandidateProfile},
+		} {
+			r.ErrorIs(NewUpdateCandidateProfile(1, 100000, big.NewInt(1), c.url, c.description, c.iconHash, c.contact).SanityCheck(), c.err)
+		}
+	})
+
+	t.Run("Gas", func(t *testing.T) {
+		act := NewUpdateCandidateProfile(1, 100000, big.NewInt(10), url, description, iconHash, contact)
+		gas, err := act.IntrinsicGas()
+		r.NoError(err)
+		size := uint64(len(url) + len(description) + len(iconHash) + len(contact))
+		r.Equal(size, act.Size())
+		r.Equal(UpdateCandidateProfileBaseIntrinsicGas+size*UpdateCandidateProfileByteGas, gas)
+		cost, err := act.Cost()
+		r.NoError(err)
+		r.Equal(new(big.Int).SetUint64(gas*10), cost)
+	})
+
+	t.Run("Proto", func(t *testing.T) {
+		for _, act := range []*UpdateCandidateProfile{
+			NewUpdateCandidateProfile(1, 100000, big.NewInt(1), url, description, iconHash, contact),
+			NewUpdateCandidateProfile(1, 100000, big.NewInt(1), "", description, nil, ""),
+		} {
+			// the profile is carried along with the gas payer in the unknown fields
+			elp := (&EnvelopeBuilder{}).SetNonce(act.Nonce()).SetGasLimit(act.GasLimit()).SetGasPrice(act.GasPrice()).
+				SetGasPayer(identityset.Address(28)).SetAction(act).Build()
+			b, err := proto.Marshal(elp.Proto())
+			r.NoError(err)
+			pb := &iotextypes.ActionCore{}
+			r.NoError(proto.Unmarshal(b, pb))
+			elp2, err := (&EnvelopeBuilder{}).BuildFromProto(pb)
+			r.NoError(err)
+			act2, ok := elp2.Action().(*UpdateCandidateProfile)
+			r.True(ok)
+			r.Equal(act.URL(), act2.URL())
+			r.Equal(act.Description(), act2.Description())
+			r.Equal(act.IconHash(), act2.IconHash())
+			r.Equal(act.SecurityContact(), act2.SecurityContact())
+			r.Equal(identityset.Address(28).String(), elp2.GasPayer().String())
+		}
+	})
+
+	t.Run("ABI", func(t *testing.T) {
+		act := NewUpdateCandidateProfile(1, 100000, big.NewInt(1), url, description, iconHash, contact)
+		data, err := act.EthData()
+		r.NoError(err)
+		act2, err := NewUpdateCandidateProfileFromABIBinary(data)
+		r.NoError(err)
+		r.Equal(act.Proto(), act2.Proto())
+		payload, err := newStakingActionFromABIBinary(data)
+		r.NoError(err)
+		r.IsType(&UpdateCandidateProfile{}, payload)
+	})
+}
@@ -10,8 +10,11 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
 )
 
 var (
@@ -98,3 +101,42 @@ func TestCandidateUpdateABIEncodeAndDecode(t *testing.T) {
 	_, err = stake.EthData()
 	require.Equal(ErrAddress, err)
 }
+
+func TestCandidateUpdatePayoutSplit(t *testing.T) {
+	require := require.New(t)
+	split := []PayoutShare{
+		{Address: identityset.Address(1), BasisPoints: 7000},
+		{Address: identityset.Address(2), BasisPoints: 3000},
+	}
+	cu, err := NewCandidateUpdateWithPayoutSplit(_cuNonce, _cuName, _cuOperatorAddrStr, _cuRewardAddrStr, split, _cuGasLimit, _cuGasPrice)
+	require.NoError(err)
+	require.NoError(cu.SanityCheck())
+	gas, err := cu.IntrinsicGas()
+	require.NoError(err)
+	require.Equal(CandidateUpdateBaseIntrinsicGas+2*CandidateUpdatePayoutShareGas, gas)
+
+	// the split is carried in the unknown field of the proto, and survives serialization
+	pb := &iotextypes.CandidateBasicInfo{}
+	require.NoError(proto.Unmarshal(cu.Serialize(), pb))
+	cu2 := &CandidateUpdate{}
+	require.NoError(cu2.LoadProto(pb))
+	require.Equal(split, cu2.PayoutSplit())
+	require.Equal(cu.Serialize(), cu2.Serialize())
+
+	data, err := cu.EthData()
+	require.NoError(err)
+	require.Equal(_candidateUpdateWithPayoutSplitMethod.ID, data[:4])
+	cu3, err := NewCandidateUpdateFromABIBinary(data)
+	require.NoError(err)
+	require.Equal(_cuName, cu3.Name())
+	require.Equal(split, cu3.PayoutSplit())
+
+	// the candidate update without split is not changed
+	cu4, err := NewCandidateUpdate(_cuNonce, _cuName, _cuOperatorAddrStr, _cuRewardAddrStr, _cuGasLimit, _cuGasPrice)
+	require.NoError(err)
+	require.NoError(cu2.LoadProto(cu4.Proto()))
+	require.Nil(cu2.PayoutSplit())
+
+	cu.payoutSplit[1].BasisPoints = 2999
+	require.ErrorIs(cu.SanityCheck(), ErrInvalidPayoutSplit)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+)
+
+// DataCostSchedule is the schedule of the gas charged for the payload data of an action
+type DataCostSchedule uint8
+
+const (
+	// DataCostFlat charges every byte of the payload data the gas per byte of the action
+	DataCostFlat DataCostSchedule = iota
+	// DataCostZeroByteDiscount charges a zero byte of the payload data a quarter of the gas per byte of the action, as
+	// Ethereum charges 4 gas for a zero byte and 16 gas for a non-zero byte
+	DataCostZeroByteDiscount
+)
+
+type (
+	// hasPayloadData is implemented by the actions whose intrinsic gas charges the payload data per byte
+	hasPayloadData interface {
+		payloadData() (data []byte, gasPerByte uint64)
+	}
+)
+
+// ZeroByteGas returns the gas charged for a zero byte of the payload data, where a non-zero byte costs the gas per
+// byte
+func (s DataCostSchedule) ZeroByteGas(gasPerByte uint64) uint64 {
+	if s == DataCostZeroByteDiscount {
+		return gasPerByte / 4
+	}
+	return gasPerByte
+}
+
+// DataGasDiscount returns the gas discounted by the schedule from the flat charge of the data at the gas per byte
+func (s DataCostSchedule) DataGasDiscount(data []byte, gasPerByte uint64) uint64 {
+	if s == DataCostFlat {
+		return 0
+	}
+	return uint64(bytes.Count(data, []byte{0})) * (gasPerByte - s.ZeroByteGas(gasPerByte))
+}
+
+// IntrinsicGasBySchedule returns the intrinsic gas of the action payload, with its payload data charged by the
+// schedule. IntrinsicGas of the action is the intrinsic gas under the flat schedule
+func IntrinsicGasBySchedule(act Action, schedule DataCostSchedule) (uint64, error) {
+	payload, ok := act.(actionPayload)
+	if !ok {
+		return 0, errors.Wrapf(ErrInvalidAct, "intrinsic gas is not supported by %T", act)
+	}
+	gas, err := payload.IntrinsicGas()
+	if err != nil {
+		return 0, err
+	}
+	if p, ok := act.(hasPayloadData); ok {
+		data, gasPerByte := p.payloadData()
+		gas -= schedule.DataGasDiscount(data, gasPerByte)
+	}
+	return gas, nil
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestDataCostSchedule(t *testing.T) {
+	r := require.New(t)
+	r.Equal(uint64(100), DataCostFlat.ZeroByteGas(100))
+	r.Equal(uint64(25), DataCostZeroByteDiscount.ZeroByteGas(100))
+	data := []byte{0, 1, 0, 2}
+	r.Zero(DataCostFlat.DataGasDiscount(data, 100))
+	r.Equal(uint64(150), DataCostZeroByteDiscount.DataGasDiscount(data, 100))
+	r.Zero(DataCostZeroByteDiscount.DataGasDiscount(nil, 100))
+}
+
+func TestIntrinsicGasBySchedule(t *testing.T) {
+	r := require.New(t)
+	// the call of transfer(address,uint256) of an ERC20 token, of 25 non-zero bytes and 43 zero bytes
+	erc20Transfer, err := hex.DecodeString("a9059cbb" +
+		"000000000000000000000000" + "1111111111111111111111111111111111111111" +
+		"0000000000000000000000000000000000000000000000000000000000000001")
+	r.NoError(err)
+	// the intrinsic gas is pinned for consensus, do not change the golden values
+	payloads := []struct {
+		name     string
+		data     []byte
+		flat     uint64
+		discount uint64
+	}{
+		{"empty", nil, 10000, 10000},
+		{"zeros", make([]byte, 32), 13200, 10800},
+		{"nonZeros", bytes.Repeat([]byte{0xff}, 32), 13200, 13200},
+		{"erc20Transfer", erc20Transfer, 16800, 13575},
+	}
+	var (
+		addr     = identityset.Address(28).String()
+		gasPrice = big.NewInt(1)
+	)
+	acts := []struct {
+		name string
+		new  func([]byte) (Action, error)
+	}{
+		{"Transfer", func(p []byte) (Action, error) { return NewTransfer(1, big.NewInt(1), addr, p, 0, gasPrice) }},
+		{"Execution", func(p []byte) (Action, error) { return NewExecution(addr, 1, big.NewInt(1), 0, gasPrice, p) }},
+		{"CreateStake", func(p []byte) (Action, error) { return NewCreateStake(1, "cand", "100", 1, true, p, 0, gasPrice) }},
+		{"DepositToStake", func(p []byte) (Action, error) { return NewDepositToStake(1, 1, "100", p, 0, gasPrice) }},
+		{"ChangeCandidate", func(p []byte) (Action, error) { return NewChangeCandidate(1, "cand", 1, p, 0, gasPrice) }},
+		{"Unstake", func(p []byte) (Action, error) { return NewUnstake(1, 1, p, 0, gasPrice) }},
+		{"WithdrawStake", func(p []byte) (Action, error) { return NewWithdrawStake(1, 1, p, 0, gasPrice) }},
+		{"Restake", func(p []byte) (Action, error) { return NewRestake(1, 1, 1, true, p, 0, gasPrice) }},
+		{"TransferStake", func(p []byte) (Action, error) { return NewTransferStake(1, addr, 1, p, 0, gasPrice) }},
+		{"CandidateRegister", func(p []byte) (Action, error) {
+			return NewCandidateRegister(1, "cand", addr, addr, addr, "100", 1, true, p, 0, gasPrice)
+		}},
+		{"CandidateTransferOwnership", func(p []byte) (Action, error) {
+			return NewCandidateTransferOwnership(1, 0, gasPrice, addr, p)
+		}},
+	}
+	for _, act := range acts {
+		for _, p := range payloads {
+			t.Run(act.name+"/"+p.name, func(t *testing.T) {
+				a, err := act.new(p.data)
+				r.NoError(err)
+				gas, err := IntrinsicGasBySchedule(a, DataCostFlat)
+				r.NoError(err)
+				r.Equal(p.flat, gas)
+				legacy, err := a.(actionPayload).IntrinsicGas()
+				r.NoError(err)
+				r.Equal(legacy, gas)
+				gas, err = IntrinsicGasBySchedule(a, DataCostZeroByteDiscount)
+				r.NoError(err)
+				r.Equal(p.discount, gas)
+			})
+		}
+	}
+	t.Run("withoutPayloadData", func(t *testing.T) {
+		act := NewCandidateActivate(1, 0, gasPrice, 1)
+		gas, err := IntrinsicGasBySchedule(act, DataCostZeroByteDiscount)
+		r.NoError(err)
+		r.Equal(CandidateActivateBaseIntrinsicGas, gas)
+	})
+}
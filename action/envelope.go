@@ -9,9 +9,11 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/pkg/errors"
 
+	"github.com/iotexproject/iotex-core/action/actionpb"
 	"github.com/iotexproject/iotex-core/pkg/log"
 )
 
@@ -25,6 +27,8 @@ type (
 		GasPrice() *big.Int
 		GasTipCap() *big.Int
 		GasFeeCap() *big.Int
+		GasPayer() address.Address
+		HashVersion() HashVersion
 		Destination() (string, bool)
 		Cost() (*big.Int, error)
 		IntrinsicGas() (uint64, error)
@@ -52,8 +56,15 @@ func (elp *envelope) Destination() (string, bool) {
 	return r.Destination(), true
 }
 
-// Cost returns cost of actions
+// Cost returns cost of actions, which excludes the gas if it is paid by the gas payer
 func (elp *envelope) Cost() (*big.Int, error) {
+	if elp.gasPayer != nil {
+		act, ok := elp.payload.(gasPayerSupported)
+		if !ok {
+			return nil, errors.Wrapf(ErrInvalidAct, "gas payer is not supported by %T", elp.payload)
+		}
+		return act.Amount(), nil
+	}
 	return elp.payload.Cost()
 }
 
@@ -67,13 +78,20 @@ func (elp *envelope) Action() Action { return elp.payload }
 
 // ToEthTx converts to Ethereum tx
 func (elp *envelope) ToEthTx(evmNetworkID uint32, encoding iotextypes.Encoding) (*types.Transaction, error) {
+	if elp.gasPayer != nil {
+		return nil, errors.Wrap(ErrNotSupported, "action with gas payer cannot be converted to eth tx")
+	}
 	switch {
-	// TODO: handle dynamic fee tx and blob tx
+	// TODO: handle blob tx
 	case encoding == iotextypes.Encoding_IOTEX_PROTOBUF:
 		// treat native tx as EVM LegacyTx
 		fallthrough
 	case encoding == iotextypes.Encoding_ETHEREUM_EIP155 || encoding == iotextypes.Encoding_ETHEREUM_UNPROTECTED:
 		return toLegacyTx(&elp.AbstractAction, elp.Action())
+	case encoding == iotextypes.Encoding_ETHEREUM_ACCESSLIST:
+		return toAccessListTx(&elp.AbstractAction, elp.Action(), evmNetworkID)
+	case encoding == iotextypes.Encoding_ETHEREUM_DYNAMICFEE:
+		return toDynamicFeeTx(&elp.AbstractAction, elp.Action(), evmNetworkID)
 	default:
 		return nil, errors.Wrapf(ErrInvalidAct, "unsupported encoding type %v", encoding)
 	}
@@ -125,6 +143,14 @@ func (elp *envelope) Proto() *iotextypes.ActionCore {
 		actCore.Action = &iotextypes.ActionCore_TxContainer{TxContainer: act.proto()}
 	case *MigrateStake:
 		actCore.Action = &iotextypes.ActionCore_StakeMigrate{StakeMigrate: act.Proto()}
+	case *StakeTransferLock:
+		MustAppendUnknownFields(actCore, &actionpb.ActionCoreExt{StakeTransferLock: act.Proto()})
+	case *ReportMisbehavior:
+		MustAppendUnknownFields(actCore, &actionpb.ActionCoreExt{ReportMisbehavior: act.Proto()})
+	case *UpdateCandidateProfile:
+		MustAppendUnknownFields(actCore, &actionpb.ActionCoreExt{UpdateCandidateProfile: act.Proto()})
+	case *TimeLockedTransfer:
+		MustAppendUnknownFields(actCore, &actionpb.ActionCoreExt{TimeLockedTransfer: act.Proto()})
 	default:
 		log.S().Panicf("Cannot convert type of action %T.\r\n", act)
 	}
@@ -266,7 +292,14 @@ func (elp *envelope) LoadProto(pbAct *iotextypes.ActionCore) error {
 		}
 		elp.payload = act
 	default:
-		return errors.Errorf("no applicable action to handle proto type %T", pbAct.Action)
+		act, err := loadUnknownFieldAction(pbAct)
+		if err != nil {
+			return err
+		}
+		if act == nil {
+			return errors.Errorf("no applicable action to handle proto type %T", pbAct.Action)
+		}
+		elp.payload = act
 	}
 	elp.payload.SetEnvelopeContext(&elp.AbstractAction)
 	return nil
@@ -274,3 +307,39 @@ func (elp *envelope) LoadProto(pbAct *iotextypes.ActionCore) error {
 
 // SetChainID sets the chainID value
 func (elp *envelope) SetChainID(chainID uint32) { elp.chainID = chainID }
+
+// loadUnknownFieldAction loads the action carried in the unknown fields of ActionCore, nil if there is none
+func loadUnknownFieldAction(pbAct *iotextypes.ActionCore) (actionPayload, error) {
+	ext := actionpb.ActionCoreExt{}
+	if err := LoadUnknownFields(pbAct, &ext); err != nil {
+		return nil, err
+	}
+	switch {
+	case ext.StakeTransferLock != nil:
+		act := &StakeTransferLock{}
+		if err := act.LoadProto(ext.StakeTransferLock); err != nil {
+			return nil, err
+		}
+		return act, nil
+	case ext.ReportMisbehavior != nil:
+		act := &ReportMisbehavior{}
+		if err := act.LoadProto(ext.ReportMisbehavior); err != nil {
+			return nil, err
+		}
+		return act, nil
+	case ext.UpdateCandidateProfile != nil:
+		act := &UpdateCandidateProfile{}
+		if err := act.LoadProto(ext.UpdateCandidateProfile); err != nil {
+			return nil, err
+		}
+		return act, nil
+	case ext.TimeLockedTransfer != nil:
+		act := &TimeLockedTransfer{}
+		if err := act.LoadProto(ext.TimeLockedTransfer); err != nil {
+			return nil, err
+		}
+		return act, nil
+	default:
+		return nil, nil
+	}
+}
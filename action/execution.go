@@ -27,6 +27,7 @@ const (
 	ExecutionBaseIntrinsicGas uint64 = 10000 // base intrinsic gas for execution
 	TxAccessListAddressGas    uint64 = 2400  // Per address specified in EIP 2930 access list
 	TxAccessListStorageKeyGas uint64 = 1900  // Per storage key specified in EIP 2930 access list
+	InitCodeWordGas           uint64 = 2     // Per 32-byte word of the init code of contract creation in EIP 3860
 )
 
 var (
@@ -227,6 +228,25 @@ func (ex *Execution) IntrinsicGas() (uint64, error) {
 	return gas, nil
 }
 
+// payloadData returns the payload data of an execution charged in the intrinsic gas
+func (ex *Execution) payloadData() ([]byte, uint64) {
+	return ex.Data(), ExecutionDataGas
+}
+
+// InitCodeGas returns the gas charged for the init code of the execution by EIP 3860, it is zero if the execution
+// does not create a contract. The gas is not part of IntrinsicGas, as it is charged only after the activation
+func (ex *Execution) InitCodeGas() uint64 {
+	if ex.contract != EmptyAddress {
+		return 0
+	}
+	return InitCodeGas(uint64(len(ex.data)))
+}
+
+// InitCodeGas returns the gas charged for an init code of the size by EIP 3860
+func InitCodeGas(size uint64) uint64 {
+	return (size + 31) / 32 * InitCodeWordGas
+}
+
 // Cost returns the cost of an execution
 func (ex *Execution) Cost() (*big.Int, error) {
 	maxExecFee := big.NewInt(0).Mul(ex.GasPrice(), big.NewInt(0).SetUint64(ex.GasLimit()))
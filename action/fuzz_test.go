@@ -0,0 +1,275 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	. "github.com/iotexproject/iotex-core/pkg/util/assertions"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+// the fuzz targets below feed untrusted bytes to the decoders that are reachable from the network. Besides not
+// panicking, a decoder must be stable on canonical input: whatever it accepts is re-encoded into a canonical form,
+// which must decode and re-encode into exactly the same bytes
+
+var _fuzzABIDecoders = []struct {
+	name   string
+	decode func([]byte) (EthCompatibleAction, error)
+}{
+	{"CreateStake", func(data []byte) (EthCompatibleAction, error) { return NewCreateStakeFromABIBinary(data) }},
+	{"DepositToStake", func(data []byte) (EthCompatibleAction, error) { return NewDepositToStakeFromABIBinary(data) }},
+	{"ChangeCandidate", func(data []byte) (EthCompatibleAction, error) { return NewChangeCandidateFromABIBinary(data) }},
+	{"Unstake", func(data []byte) (EthCompatibleAction, error) { return NewUnstakeFromABIBinary(data) }},
+	{"WithdrawStake", func(data []byte) (EthCompatibleAction, error) { return NewWithdrawStakeFromABIBinary(data) }},
+	{"Restake", func(data []byte) (EthCompatibleAction, error) { return NewRestakeFromABIBinary(data) }},
+	{"TransferStake", func(data []byte) (EthCompatibleAction, error) { return NewTransferStakeFromABIBinary(data) }},
+	{"CandidateRegister", func(data []byte) (EthCompatibleAction, error) { return NewCandidateRegisterFromABIBinary(data) }},
+	{"CandidateUpdate", func(data []byte) (EthCompatibleAction, error) { return NewCandidateUpdateFromABIBinary(data) }},
+	{"CandidateActivate", func(data []byte) (EthCompatibleAction, error) { return NewCandidateActivateFromABIBinary(data) }},
+	{"CandidateEndorsement", func(data []byte) (EthCompatibleAction, error) { return NewCandidateEndorsementFromABIBinary(data) }},
+	{"CandidateTransferOwnership", func(data []byte) (EthCompatibleAction, error) {
+		return NewCandidateTransferOwnershipFromABIBinary(data)
+	}},
+	{"MigrateStake", func(data []byte) (EthCompatibleAction, error) { return NewMigrateStakeFromABIBinary(data) }},
+	{"ClaimFromRewardingFund", func(data []byte) (EthCompatibleAction, error) {
+		return NewClaimFromRewardingFundFromABIBinary(data)
+	}},
+	{"DepositToRewardingFund", func(data []byte) (EthCompatibleAction, error) {
+		return NewDepositToRewardingFundFromABIBinary(data)
+	}},
+	{"GrantReward", func(data []byte) (EthCompatibleAction, error) { return NewGrantRewardFromABIBinary(data) }},
+}
+
+// fuzzSeedActions returns a valid instance of each action type, to generate the seed corpora from
+func fuzzSeedActions(tb testing.TB) []actionPayload {
+	var (
+		gasPrice = big.NewInt(1000)
+		payload  = []byte("payload")
+		owner    = identityset.Address(1).String()
+		operator = identityset.Address(2).String()
+		reward   = identityset.Address(3).String()
+	)
+	claim := (&ClaimFromRewardingFundBuilder{}).SetAmount(big.NewInt(100)).SetData(payload).
+		SetAddress(identityset.Address(4)).Build()
+	deposit := (&DepositToRewardingFundBuilder{}).SetAmount(big.NewInt(100)).SetData(payload).Build()
+	grant := (&GrantRewardBuilder{}).SetRewardType(EpochReward).SetHeight(100).Build()
+	endorse, err := NewCandidateEndorsement(1, 10000, gasPrice, 5, CandidateEndorsementOpIntentToRevoke)
+	require.NoError(tb, err)
+	return []actionPayload{
+		MustNoErrorV(NewTransfer(1, big.NewInt(100), owner, payload, 10000, gasPrice)),
+		MustNoErrorV(NewExecution(owner, 1, big.NewInt(100), 10000, gasPrice, payload)),
+		MustNoErrorV(NewExecution("", 1, big.NewInt(0), 10000, gasPrice, payload)),
+		MustNoErrorV(NewCreateStake(1, "cand1", "100", 7, true, payload, 10000, gasPrice)),
+		MustNoErrorV(NewDepositToStake(1, 5, "100", payload, 10000, gasPrice)),
+		MustNoErrorV(NewChangeCandidate(1, "cand2", 5, payload, 10000, gasPrice)),
+		MustNoErrorV(NewUnstake(1, 5, payload, 10000, gasPrice)),
+		MustNoErrorV(NewWithdrawStake(1, 5, payload, 10000, gasPrice)),
+		MustNoErrorV(NewRestake(1, 5, 7, false, payload, 10000, gasPrice)),
+		MustNoErrorV(NewTransferStake(1, owner, 5, payload, 10000, gasPrice)),
+		MustNoErrorV(NewCandidateRegister(1, "cand1", operator, reward, owner, "100", 7, true, payload, 10000, gasPrice)),
+		MustNoErrorV(NewCandidateRegister(1, "cand1", operator, reward, "", "100", 7, true, payload, 10000, gasPrice)),
+		MustNoErrorV(NewCandidateUpdate(1, "cand1", operator, reward, 10000, gasPrice)),
+		NewCandidateActivate(1, 10000, gasPrice, 5),
+		NewCandidateEndorsementLegacy(1, 10000, gasPrice, 5, true),
+		endorse,
+		MustNoErrorV(NewCandidateTransferOwnership(1, 10000, gasPrice, owner, payload)),
+		MustNoErrorV(NewMigrateStake(1, 5, 10000, gasPrice)),
+		&claim,
+		&deposit,
+		&grant,
+		NewPutPollResult(1, 100, state.CandidateList{{Address: owner, Votes: big.NewInt(100), RewardAddress: reward}}),
+	}
+}
+
+func fuzzSeedEnvelopes(tb testing.TB) []*SealedEnvelope {
+	var (
+		seeds = fuzzSeedActions(tb)
+		selps = make([]*SealedEnvelope, 0, len(seeds))
+	)
+	for _, act := range seeds {
+		elp := (&EnvelopeBuilder{}).SetNonce(1).SetGasLimit(10000).SetGasPrice(big.NewInt(1000)).SetChainID(1).
+			SetAction(act).Build()
+		selp, err := Sign(elp, identityset.PrivateKey(1))
+		require.NoError(tb, err)
+		selps = append(selps, selp)
+	}
+	return selps
+}
+
+// fuzzSeedRawTxs returns the signed eth txs of the eth-compatible seed actions, and the raw txs of the RLP tests
+func fuzzSeedRawTxs(tb testing.TB) [][]byte {
+	var (
+		raws   [][]byte
+		sk     = identityset.PrivateKey(1).EcdsaPrivateKey().(*ecdsa.PrivateKey)
+		signer = types.NewEIP155Signer(big.NewInt(int64(_evmNetworkID)))
+	)
+	for _, selp := range fuzzSeedEnvelopes(tb) {
+		tx, err := selp.Envelope.ToEthTx(_evmNetworkID, iotextypes.Encoding_ETHEREUM_EIP155)
+		if err != nil {
+			continue
+		}
+		raws = append(raws, MustNoErrorV(types.MustSignNewTx(sk, signer, &types.LegacyTx{
+			Nonce:    tx.Nonce(),
+			GasPrice: tx.GasPrice(),
+			Gas:      tx.Gas(),
+			To:       tx.To(),
+			Value:    tx.Value(),
+			Data:     tx.Data(),
+		}).MarshalBinary()))
+	}
+	for _, s := range []string{deterministicDeploymentTx, accessListTx} {
+		raws = append(raws, MustNoErrorV(hex.DecodeString(s)))
+	}
+	for _, v := range rlpTests {
+		if len(v.raw) > 0 {
+			raws = append(raws, MustNoErrorV(hex.DecodeString(v.raw)))
+		}
+	}
+	return raws
+}
+
+func mustMarshalDeterministic(t *testing.T, m proto.Message) []byte {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	require.NoError(t, err)
+	return b
+}
+
+func FuzzEnvelopeLoadProto(f *testing.F) {
+	for _, selp := range fuzzSeedEnvelopes(f) {
+		f.Add(MustNoErrorV(proto.Marshal(selp.Envelope.Proto())))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		pb := &iotextypes.ActionCore{}
+		if err := proto.Unmarshal(data, pb); err != nil {
+			return
+		}
+		elp := &envelope{}
+		if err := elp.LoadProto(pb); err != nil {
+			return
+		}
+		canonical := mustMarshalDeterministic(t, elp.Proto())
+		pb = &iotextypes.ActionCore{}
+		require.NoError(t, proto.Unmarshal(canonical, pb))
+		elp = &envelope{}
+		require.NoError(t, elp.LoadProto(pb))
+		require.Equal(t, canonical, mustMarshalDeterministic(t, elp.Proto()))
+	})
+}
+
+func FuzzSealedEnvelopeLoadProto(f *testing.F) {
+	for _, selp := range fuzzSeedEnvelopes(f) {
+		f.Add(MustNoErrorV(proto.Marshal(selp.Proto())))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		pb := &iotextypes.Action{}
+		if err := proto.Unmarshal(data, pb); err != nil {
+			return
+		}
+		ad := (&Deserializer{}).SetEvmNetworkID(_evmNetworkID)
+		selp, err := ad.ActionToSealedEnvelope(pb)
+		if err != nil {
+			return
+		}
+		// the hash of an accepted action must be computable
+		_, _ = selp.Hash()
+		canonical := mustMarshalDeterministic(t, selp.Proto())
+		pb = &iotextypes.Action{}
+		require.NoError(t, proto.Unmarshal(canonical, pb))
+		selp, err = ad.ActionToSealedEnvelope(pb)
+		require.NoError(t, err)
+		require.Equal(t, canonical, mustMarshalDeterministic(t, selp.Proto()))
+	})
+}
+
+func FuzzABIDecoders(f *testing.F) {
+	for _, selp := range fuzzSeedEnvelopes(f) {
+		act, ok := selp.Action().(EthCompatibleAction)
+		if !ok {
+			continue
+		}
+		// an action may be not convertible, e.g. the candidate register without owner
+		if data, err := act.EthData(); err == nil {
+			f.Add(data)
+		}
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, d := range _fuzzABIDecoders {
+			act, err := d.decode(data)
+			if err != nil {
+				continue
+			}
+			canonical, err := act.EthData()
+			require.NoError(t, err, d.name)
+			act, err = d.decode(canonical)
+			require.NoError(t, err, d.name)
+			reencoded, err := act.EthData()
+			require.NoError(t, err, d.name)
+			require.Equal(t, canonical, reencoded, d.name)
+		}
+	})
+}
+
+func FuzzRawTxToEnvelope(f *testing.F) {
+	for _, raw := range fuzzSeedRawTxs(f) {
+		f.Add(raw)
+	}
+	// the account of a non-empty payload is regarded as a contract
+	checker := func(data []byte) func(context.Context, *common.Address) (bool, bool, bool, error) {
+		return func(_ context.Context, to *common.Address) (bool, bool, bool, error) {
+			switch {
+			case to == nil:
+				return true, false, false, nil
+			case bytes.Equal(to.Bytes(), _stakingProtocolEthAddr.Bytes()):
+				return false, true, false, nil
+			case bytes.Equal(to.Bytes(), _rewardingProtocolEthAddr.Bytes()):
+				return false, false, true, nil
+			default:
+				return len(data) > 0, false, false, nil
+			}
+		}
+	}
+	unfold := func(raw []byte) (*SealedEnvelope, *txContainer, error) {
+		etx := &txContainer{}
+		if err := etx.loadProto(&iotextypes.TxContainer{Raw: raw}); err != nil {
+			return nil, nil, err
+		}
+		selp := &SealedEnvelope{
+			Envelope: (&EnvelopeBuilder{}).SetChainID(1).SetAction(etx).Build(),
+		}
+		if err := etx.Unfold(selp, context.Background(), checker(etx.tx.Data())); err != nil {
+			return nil, nil, err
+		}
+		return selp, etx, nil
+	}
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		selp, etx, err := unfold(raw)
+		if err != nil {
+			return
+		}
+		_, _, _, _ = ExtractTypeSigPubkey(etx.tx)
+		tx, err := selp.Envelope.ToEthTx(_evmNetworkID, selp.encoding)
+		require.NoError(t, err)
+		canonical := MustNoErrorV(tx.MarshalBinary())
+		selp, _, err = unfold(canonical)
+		require.NoError(t, err)
+		tx, err = selp.Envelope.ToEthTx(_evmNetworkID, selp.encoding)
+		require.NoError(t, err)
+		require.Equal(t, canonical, MustNoErrorV(tx.MarshalBinary()))
+	})
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action/actionpb"
+)
+
+var (
+	// ErrInvalidGasPayer indicates the gas payer or its signature is invalid
+	ErrInvalidGasPayer = errors.New("invalid gas payer")
+
+	// _gasPayerDomain separates the hash signed by the gas payer from the one signed by the sender, so that the
+	// signature of either party cannot be replayed as the other's
+	_gasPayerDomain = []byte("IoTeX gas payer")
+)
+
+// gasPayerSupported is the action whose gas can be paid by a gas payer, of which the sender only pays the amount
+type gasPayerSupported interface {
+	Amount() *big.Int
+}
+
+// MaxGasFee returns the max gas fee of the envelope, which the gas payer must be able to afford
+func MaxGasFee(elp Envelope) *big.Int {
+	return new(big.Int).Mul(elp.GasFeeCap(), new(big.Int).SetUint64(elp.GasLimit()))
+}
+
+// SignAsGasPayer co-signs the sealed envelope by the gas payer, whose address must match the one in the envelope
+func SignAsGasPayer(sealed *SealedEnvelope, sk crypto.PrivateKey) error {
+	payer := sealed.GasPayer()
+	if payer == nil {
+		return errors.Wrap(ErrInvalidGasPayer, "no gas payer in the envelope")
+	}
+	if sk.PublicKey().Address().String() != payer.String() {
+		return errors.Wrapf(ErrInvalidGasPayer, "key does not match gas payer %s", payer.String())
+	}
+	h, err := sealed.gasPayerHash()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate gas payer hash")
+	}
+	sig, err := sk.Sign(h[:])
+	if err != nil {
+		return ErrInvalidGasPayer
+	}
+	sealed.payerPubkey = sk.PublicKey()
+	sealed.payerSignature = sig
+	sealed.hash = hash.ZeroHash256
+	sealed.verified.Store(false)
+	return nil
+}
+
+// gasPayerSignatureProto converts the gas payer's public key and signature to protobuf
+func gasPayerSignatureProto(pubkey crypto.PublicKey, sig []byte) *actionpb.GasPayerSignature {
+	return &actionpb.GasPayerSignature{
+		PubKey:    pubkey.Bytes(),
+		Signature: sig,
+	}
+}
+
+// loadGasPayerSignatureProto loads the gas payer's public key and signature from protobuf
+func loadGasPayerSignatureProto(pb *actionpb.GasPayerSignature) (crypto.PublicKey, []byte, error) {
+	sig := pb.GetSignature()
+	if len(sig) != 65 {
+		return nil, nil, errors.Wrapf(ErrInvalidGasPayer, "invalid signature length = %d, expecting 65", len(sig))
+	}
+	pk, err := crypto.BytesToPublicKey(pb.GetPubKey())
+	if err != nil {
+		return nil, nil, errors.Wrap(ErrInvalidGasPayer, err.Error())
+	}
+	return pk, sig, nil
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func signSponsoredTransfer(t *testing.T, nonce uint64) *SealedEnvelope {
+	r := require.New(t)
+	tsf, err := NewTransfer(nonce, big.NewInt(10), identityset.Address(29).String(), nil, 10000, big.NewInt(100))
+	r.NoError(err)
+	elp := (&EnvelopeBuilder{}).SetNonce(nonce).SetGasLimit(10000).SetGasPrice(big.NewInt(100)).
+		SetGasPayer(identityset.Address(28)).SetAction(tsf).Build()
+	selp, err := Sign(elp, identityset.PrivateKey(27))
+	r.NoError(err)
+	return selp
+}
+
+func TestGasPayer(t *testing.T) {
+	r := require.New(t)
+
+	t.Run("sign and verify", func(t *testing.T) {
+		selp := signSponsoredTransfer(t, 1)
+		r.Equal(identityset.Address(28).String(), selp.GasPayer().String())
+		r.ErrorIs(selp.VerifySignature(), ErrInvalidGasPayer)
+		r.ErrorIs(SignAsGasPayer(selp, identityset.PrivateKey(29)), ErrInvalidGasPayer)
+		r.NoError(SignAsGasPayer(selp, identityset.PrivateKey(28)))
+		r.NoError(selp.VerifySignature())
+		r.Equal(identityset.PrivateKey(28).PublicKey().HexString(), selp.PayerPubkey().HexString())
+
+		// the sender only pays the amount
+		cost, err := selp.Cost()
+		r.NoError(err)
+		r.Equal(big.NewInt(10), cost)
+		r.Equal(big.NewInt(1000000), MaxGasFee(selp.Envelope))
+	})
+	t.Run("proto", func(t *testing.T) {
+		selp := signSponsoredTransfer(t, 1)
+		r.NoError(SignAsGasPayer(selp, identityset.PrivateKey(28)))
+		h, err := selp.Hash()
+		r.NoError(err)
+
+		var selp1 SealedEnvelope
+		r.NoError(selp1.loadProto(selp.Proto(), _evmNetworkID))
+		r.Equal(selp.GasPayer().String(), selp1.GasPayer().String())
+		r.Equal(selp.PayerSignature(), selp1.PayerSignature())
+		r.NoError(selp1.VerifySignature())
+		h1, err := selp1.Hash()
+		r.NoError(err)
+		r.Equal(h, h1)
+
+		// the payer signature is part of the action hash
+		selp2 := signSponsoredTransfer(t, 1)
+		h2, err := selp2.Hash()
+		r.NoError(err)
+		r.NotEqual(h, h2)
+	})
+	t.Run("replay", func(t *testing.T) {
+		selp := signSponsoredTransfer(t, 1)
+		r.NoError(SignAsGasPayer(selp, identityset.PrivateKey(28)))
+		other := signSponsoredTransfer(t, 2)
+		other.payerPubkey = selp.PayerPubkey()
+		other.payerSignature = selp.PayerSignature()
+		r.ErrorIs(other.VerifySignature(), ErrInvalidGasPayer)
+
+		// the payer signature cannot be used as the sender signature either
+		tsf, err := NewTransfer(1, big.NewInt(10), identityset.Address(29).String(), nil, 10000, big.NewInt(100))
+		r.NoError(err)
+		elp := (&EnvelopeBuilder{}).SetNonce(1).SetGasLimit(10000).SetGasPrice(big.NewInt(100)).
+			SetGasPayer(identityset.Address(28)).SetAction(tsf).Build()
+		forged := AssembleSealedEnvelope(elp, identityset.PrivateKey(28).PublicKey(), selp.PayerSignature())
+		r.ErrorIs(forged.VerifySignature(), ErrInvalidSender)
+	})
+	t.Run("eth encoding", func(t *testing.T) {
+		selp := signSponsoredTransfer(t, 1)
+		_, err := selp.ToEthTx()
+		r.ErrorIs(err, ErrNotSupported)
+
+		pb := selp.Proto()
+		pb.Encoding = iotextypes.Encoding_ETHEREUM_EIP155
+		var selp1 SealedEnvelope
+		r.Equal(ErrInvalidGasPayer, errors.Cause(selp1.loadProto(pb, _evmNetworkID)))
+	})
+}
@@ -6,6 +6,7 @@
 package action
 
 import (
+	"bytes"
 	"math/big"
 	"strings"
 
@@ -130,6 +131,36 @@ func (g *GrantReward) EthData() ([]byte, error) {
 	return append(_grantRewardMethod.ID, data...), nil
 }
 
+// NewGrantRewardFromABIBinary decodes data into GrantReward
+func NewGrantRewardFromABIBinary(data []byte) (*GrantReward, error) {
+	var (
+		paramsMap  = map[string]interface{}{}
+		ok         bool
+		rewardType int8
+		g          GrantReward
+	)
+	// sanity check
+	if len(data) <= 4 || !bytes.Equal(_grantRewardMethod.ID, data[:4]) {
+		return nil, errDecodeFailure
+	}
+	if err := _grantRewardMethod.Inputs.UnpackIntoMap(paramsMap, data[4:]); err != nil {
+		return nil, err
+	}
+	if rewardType, ok = paramsMap["rewardType"].(int8); !ok {
+		return nil, errDecodeFailure
+	}
+	switch rewardType {
+	case BlockReward, EpochReward:
+		g.rewardType = int(rewardType)
+	default:
+		return nil, errDecodeFailure
+	}
+	if g.height, ok = paramsMap["height"].(uint64); !ok {
+		return nil, errDecodeFailure
+	}
+	return &g, nil
+}
+
 // GrantRewardBuilder is the struct to build GrantReward
 type GrantRewardBuilder struct {
 	Builder
@@ -33,3 +33,25 @@ func TestGrandReward(t *testing.T) {
 		require.Equal(big.NewInt(0), cost)
 	}
 }
+
+func TestGrantRewardABIEncodeAndDecode(t *testing.T) {
+	require := require.New(t)
+	for _, rewardType := range []int{BlockReward, EpochReward} {
+		g := (&GrantRewardBuilder{}).SetRewardType(rewardType).SetHeight(100).Build()
+		data, err := g.EthData()
+		require.NoError(err)
+		decoded, err := NewGrantRewardFromABIBinary(data)
+		require.NoError(err)
+		require.Equal(rewardType, decoded.RewardType())
+		require.EqualValues(100, decoded.Height())
+	}
+
+	// unknown reward type
+	data, err := _grantRewardMethod.Inputs.Pack(int8(2), uint64(100))
+	require.NoError(err)
+	_, err = NewGrantRewardFromABIBinary(append(_grantRewardMethod.ID, data...))
+	require.Equal(errDecodeFailure, err)
+	// wrong method signature
+	_, err = NewGrantRewardFromABIBinary(append([]byte{1, 2, 3, 4}, data...))
+	require.Equal(errDecodeFailure, err)
+}
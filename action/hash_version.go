@@ -0,0 +1,93 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+// HashVersion is the version of the algorithm computing the hash of an action
+type HashVersion uint32
+
+const (
+	// HashV1 hashes the action as it is encoded, i.e., the serialized proto of an IoTeX native action, or the
+	// signed tx of an Ethereum action. It is the version of an action without an explicit one
+	HashV1 HashVersion = 1
+	// HashV2 hashes the envelope hash, which covers all the fields of the envelope including the ones carried in the
+	// unknown fields, along with the public keys and the signatures of the sender and the gas payer, independent of
+	// how the action is serialized. It applies to the IoTeX native actions only
+	HashV2 HashVersion = 2
+)
+
+var (
+	// ErrUnsupportedHashVersion indicates the hash version is unknown, or not supported by the action
+	ErrUnsupportedHashVersion = errors.New("unsupported hash version")
+
+	// _hashV2Domain separates the hash of version 2 from the hashes of the other versions
+	_hashV2Domain = []byte("IoTeX action hash v2")
+
+	// _hashAlgorithms is the registry of the algorithms computing the action hash, keyed by hash version
+	_hashAlgorithms = map[HashVersion]func(*SealedEnvelope) (hash.Hash256, error){
+		HashV1: calcHashV1,
+		HashV2: calcHashV2,
+	}
+)
+
+// IsValid returns true if the hash version is registered
+func (v HashVersion) IsValid() bool {
+	_, ok := _hashAlgorithms[v]
+	return ok
+}
+
+func calcHashV1(sealed *SealedEnvelope) (hash.Hash256, error) {
+	switch sealed.encoding {
+	case iotextypes.Encoding_TX_CONTAINER:
+		act, ok := sealed.Action().(*txContainer)
+		if !ok {
+			return hash.ZeroHash256, ErrInvalidAct
+		}
+		return act.hash(), nil
+	case iotextypes.Encoding_ETHEREUM_EIP155, iotextypes.Encoding_ETHEREUM_UNPROTECTED,
+		iotextypes.Encoding_ETHEREUM_ACCESSLIST, iotextypes.Encoding_ETHEREUM_DYNAMICFEE:
+		tx, err := sealed.ToEthTx()
+		if err != nil {
+			return hash.ZeroHash256, err
+		}
+		signer, err := NewEthSigner(sealed.encoding, sealed.evmNetworkID)
+		if err != nil {
+			return hash.ZeroHash256, err
+		}
+		return rlpSignedHash(tx, signer, sealed.Signature())
+	case iotextypes.Encoding_IOTEX_PROTOBUF:
+		return hash.Hash256b(byteutil.Must(proto.Marshal(sealed.Proto()))), nil
+	default:
+		return hash.ZeroHash256, errors.Errorf("unknown encoding type %v", sealed.encoding)
+	}
+}
+
+func calcHashV2(sealed *SealedEnvelope) (hash.Hash256, error) {
+	if sealed.encoding != iotextypes.Encoding_IOTEX_PROTOBUF {
+		return hash.ZeroHash256, errors.Wrapf(ErrUnsupportedHashVersion, "hash version %d of encoding %v", HashV2, sealed.encoding)
+	}
+	h, err := sealed.envelopeHash()
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	b := append(append([]byte{}, _hashV2Domain...), h[:]...)
+	b = protowire.AppendBytes(b, sealed.srcPubkey.Bytes())
+	b = protowire.AppendBytes(b, sealed.signature)
+	if sealed.payerPubkey != nil {
+		b = protowire.AppendBytes(b, sealed.payerPubkey.Bytes())
+		b = protowire.AppendBytes(b, sealed.payerSignature)
+	}
+	return hash.Hash256b(b), nil
+}
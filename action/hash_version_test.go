@@ -0,0 +1,154 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/actionpb"
+	. "github.com/iotexproject/iotex-core/pkg/util/assertions"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+type hashVector struct {
+	name string
+	selp *SealedEnvelope
+}
+
+// hashVectors returns the actions of the golden vectors of the hash version, covering the encodings and the
+// envelope fields supported by the version
+func hashVectors(t *testing.T, hv HashVersion) []hashVector {
+	r := require.New(t)
+	var (
+		to      = identityset.Address(2)
+		ethTo   = common.BytesToAddress(to.Bytes())
+		chainID = big.NewInt(int64(_evmNetworkID))
+		build   = func() *EnvelopeBuilder {
+			return (&EnvelopeBuilder{}).SetGasLimit(50000).SetGasPrice(big.NewInt(100)).SetHashVersion(hv)
+		}
+		sign    = func(elp Envelope) *SealedEnvelope { return MustNoErrorV(Sign(elp, identityset.PrivateKey(1))) }
+		ethSign = func(tx types.TxData, encoding iotextypes.Encoding) *SealedEnvelope {
+			signedTx := types.MustSignNewTx(identityset.PrivateKey(1).EcdsaPrivateKey().(*ecdsa.PrivateKey), types.NewLondonSigner(chainID), tx)
+			ethTx := MustNoErrorV(DecodeEtherTx(hex.EncodeToString(MustNoErrorV(signedTx.MarshalBinary()))))
+			enc, sig, pubkey, err := ExtractTypeSigPubkey(ethTx)
+			r.NoError(err)
+			r.Equal(encoding, enc)
+			elp, err := (&EnvelopeBuilder{}).SetChainID(1).BuildTransfer(ethTx)
+			r.NoError(err)
+			selp, err := (&Deserializer{}).SetEvmNetworkID(_evmNetworkID).ActionToSealedEnvelope(&iotextypes.Action{
+				Core:         elp.Proto(),
+				SenderPubKey: pubkey.Bytes(),
+				Signature:    sig,
+				Encoding:     enc,
+			})
+			r.NoError(err)
+			return selp
+		}
+		sponsored = sign(build().SetNonce(5).SetGasPayer(identityset.Address(3)).
+				SetAction(MustNoErrorV(NewTransfer(5, big.NewInt(7), to.String(), nil, 50000, big.NewInt(100)))).Build())
+	)
+	r.NoError(SignAsGasPayer(sponsored, identityset.PrivateKey(3)))
+	vectors := []hashVector{
+		{"transfer", sign(build().SetNonce(1).
+			SetAction(MustNoErrorV(NewTransfer(1, big.NewInt(10), to.String(), []byte("payload"), 50000, big.NewInt(100)))).Build())},
+		{"execution", sign(build().SetNonce(2).SetChainID(1).
+			SetAction(MustNoErrorV(NewExecution("", 2, big.NewInt(0), 50000, big.NewInt(100), []byte{0x60, 0x80}))).Build())},
+		{"createStake", sign(build().SetNonce(3).SetChainID(2).
+			SetAction(MustNoErrorV(NewCreateStake(3, "cand1", "100", 7, true, nil, 50000, big.NewInt(100)))).Build())},
+		{"stakeTransferLock", sign(build().SetNonce(4).
+			SetAction(NewStakeTransferLock(4, 50000, big.NewInt(100), StakeTransferLockOpAllow, []address.Address{to})).Build())},
+		{"sponsoredTransfer", sponsored},
+	}
+	if hv != HashV1 {
+		return vectors
+	}
+	return append(vectors, []hashVector{
+		{"eip155Transfer", ethSign(&types.LegacyTx{
+			Nonce: 6, GasPrice: big.NewInt(100), Gas: 21000, To: &ethTo, Value: big.NewInt(3),
+		}, iotextypes.Encoding_ETHEREUM_EIP155)},
+		{"dynamicFeeTransfer", ethSign(&types.DynamicFeeTx{
+			ChainID: chainID, Nonce: 7, GasTipCap: big.NewInt(10), GasFeeCap: big.NewInt(200), Gas: 21000, To: &ethTo, Value: big.NewInt(3),
+		}, iotextypes.Encoding_ETHEREUM_DYNAMICFEE)},
+	}...)
+}
+
+func TestHashVersion(t *testing.T) {
+	r := require.New(t)
+	golden := map[HashVersion]map[string]string{
+		// the hashes of v1 are the same as before the hash versions are introduced, and must never change
+		HashV1: {
+			"transfer":           "cf5153155df31c32a7c305e23e29eee79cf22da54dce1f2afb4032fcda234a8f",
+			"execution":          "a797f11a229c056d06d2d8892d9f6c865571c63bf361b86c1e327498d88b4182",
+			"createStake":        "a808c92a220acdd3d9212ee923ec3b94e54fa215f584aa5e945e72e51df0aee8",
+			"stakeTransferLock":  "d090f1cbaa33e6de16ae7a095a794870e6686e562dc7d321b7c4d22199554aa2",
+			"sponsoredTransfer":  "bdbc577b7ce78781e6a99bbb95e6251bce928d2c4201410cb45a4a0a6629fb2e",
+			"eip155Transfer":     "aa819838a618629a91ca93b2485d66921957ee24e9f98d9ea67c3af2b7ef5ce9",
+			"dynamicFeeTransfer": "2134b68c5de712d69dfff35a04b9f016c395a49aee93d9ebf1de17e0a23eb98f",
+		},
+		HashV2: {
+			"transfer":          "2f341f989d298433573dc4b34d165fcadcc5656733749237eee72aaecedf9390",
+			"execution":         "d29b72c5006f2e12d934bb405025cde09e323621722263732b64e980c9ca859f",
+			"createStake":       "dae95a090d6b01d92f03a67ccb72b5f0df06cb2677729917fe0a3039d7a66e54",
+			"stakeTransferLock": "58a5ab3e2981deddcb361e2e0a781b11f4842c50cc1d6b8b0b4414ab8744a2d5",
+			"sponsoredTransfer": "0a19bda36d68396c96aebd87855e3d785173129f564c01f4a43fcc5962001509",
+		},
+	}
+
+	t.Run("GoldenVectors", func(t *testing.T) {
+		for hv, hashes := range golden {
+			vectors := hashVectors(t, hv)
+			r.Len(vectors, len(hashes))
+			for _, v := range vectors {
+				r.Equal(hv, v.selp.HashVersion(), v.name)
+				h, err := v.selp.Hash()
+				r.NoError(err)
+				r.Equal(hashes[v.name], hex.EncodeToString(h[:]), "hash v%d of %s", hv, v.name)
+
+				// the version survives the proto, and is signed along with the envelope
+				selp, err := (&Deserializer{}).SetEvmNetworkID(_evmNetworkID).ActionToSealedEnvelope(v.selp.Proto())
+				r.NoError(err)
+				r.NoError(selp.VerifySignature())
+				r.Equal(hv, selp.HashVersion())
+				h2, err := selp.Hash()
+				r.NoError(err)
+				r.Equal(h, h2)
+			}
+		}
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		r.True(HashV1.IsValid())
+		r.True(HashV2.IsValid())
+		r.False(HashVersion(0).IsValid())
+		r.False(HashVersion(3).IsValid())
+
+		// an explicit version must be a registered one other than v1
+		for _, v := range []uint64{0, uint64(HashV1), 3, 1 << 32} {
+			pb := hashVectors(t, HashV1)[0].selp.Proto()
+			r.NoError(AppendUnknownFields(pb.Core, &actionpb.ActionCoreExt{HashVersion: &v}))
+			_, err := (&Deserializer{}).SetEvmNetworkID(_evmNetworkID).ActionToSealedEnvelope(pb)
+			r.ErrorIs(err, ErrUnsupportedHashVersion, "version %d", v)
+		}
+
+		// v2 is not supported by the Ethereum encodings, of which the hash is the tx hash
+		for _, v := range hashVectors(t, HashV1)[5:] {
+			pb := v.selp.Proto()
+			hv := uint64(HashV2)
+			r.NoError(AppendUnknownFields(pb.Core, &actionpb.ActionCoreExt{HashVersion: &hv}))
+			_, err := (&Deserializer{}).SetEvmNetworkID(_evmNetworkID).ActionToSealedEnvelope(pb)
+			r.ErrorIs(err, ErrUnsupportedHashVersion, v.name)
+		}
+	})
+}
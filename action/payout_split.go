@@ -0,0 +1,114 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action/actionpb"
+)
+
+const (
+	// PayoutSplitBasisPoints is the sum of the basis points of the shares in a payout split
+	PayoutSplitBasisPoints = 10000
+	// MaxPayoutShares is the max number of the shares in a payout split
+	MaxPayoutShares = 16
+)
+
+// ErrInvalidPayoutSplit indicates the payout split is invalid
+var ErrInvalidPayoutSplit = errors.New("invalid payout split")
+
+// PayoutShare is the share of the rewards of a candidate paid out to an address, in basis points
+type PayoutShare struct {
+	Address     address.Address
+	BasisPoints uint32
+}
+
+// ValidatePayoutSplit checks the shares are paid to distinct addresses, and their basis points sum to
+// PayoutSplitBasisPoints
+func ValidatePayoutSplit(split []PayoutShare) error {
+	if len(split) == 0 || len(split) > MaxPayoutShares {
+		return errors.Wrapf(ErrInvalidPayoutSplit, "%d shares, expecting 1 to %d", len(split), MaxPayoutShares)
+	}
+	var (
+		total uint32
+		addrs = make(map[string]struct{}, len(split))
+	)
+	for _, share := range split {
+		if share.Address == nil {
+			return errors.Wrap(ErrInvalidPayoutSplit, "missing address")
+		}
+		if _, ok := addrs[share.Address.String()]; ok {
+			return errors.Wrapf(ErrInvalidPayoutSplit, "duplicate address %s", share.Address.String())
+		}
+		addrs[share.Address.String()] = struct{}{}
+		if share.BasisPoints == 0 || share.BasisPoints > PayoutSplitBasisPoints {
+			return errors.Wrapf(ErrInvalidPayoutSplit, "invalid basis points %d", share.BasisPoints)
+		}
+		total += share.BasisPoints
+	}
+	if total != PayoutSplitBasisPoints {
+		return errors.Wrapf(ErrInvalidPayoutSplit, "basis points sum to %d", total)
+	}
+	return nil
+}
+
+// SplitPayout splits the amount by the shares. Each share is rounded down, and the rounding residue goes to the
+// first address, so that the amounts sum to the amount exactly
+func SplitPayout(amount *big.Int, split []PayoutShare) []*big.Int {
+	amounts := make([]*big.Int, len(split))
+	if len(split) == 0 {
+		return amounts
+	}
+	residue := new(big.Int).Set(amount)
+	for i := 1; i < len(split); i++ {
+		amounts[i] = new(big.Int).Mul(amount, new(big.Int).SetUint64(uint64(split[i].BasisPoints)))
+		amounts[i].Div(amounts[i], big.NewInt(PayoutSplitBasisPoints))
+		residue.Sub(residue, amounts[i])
+	}
+	amounts[0] = residue
+	return amounts
+}
+
+// PayoutSplitToProto converts the shares to protobuf
+func PayoutSplitToProto(split []PayoutShare) []*actionpb.PayoutShare {
+	if len(split) == 0 {
+		return nil
+	}
+	pb := make([]*actionpb.PayoutShare, len(split))
+	for i, share := range split {
+		pb[i] = &actionpb.PayoutShare{
+			Address:     share.Address.String(),
+			BasisPoints: share.BasisPoints,
+		}
+	}
+	return pb
+}
+
+// PayoutSplitFromProto converts protobuf to the shares
+func PayoutSplitFromProto(pb []*actionpb.PayoutShare) ([]PayoutShare, error) {
+	if len(pb) == 0 {
+		return nil, nil
+	}
+	split := make([]PayoutShare, len(pb))
+	for i, share := range pb {
+		addr, err := address.FromString(share.GetAddress())
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidPayoutSplit, err.Error())
+		}
+		if share.GetBasisPoints() > PayoutSplitBasisPoints {
+			return nil, errors.Wrapf(ErrInvalidPayoutSplit, "invalid basis points %d", share.GetBasisPoints())
+		}
+		split[i] = PayoutShare{
+			Address:     addr,
+			BasisPoints: share.GetBasisPoints(),
+		}
+	}
+	return split, nil
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/actionpb"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestValidatePayoutSplit(t *testing.T) {
+	r := require.New(t)
+	for _, c := range []struct {
+		split []PayoutShare
+		err   bool
+	}{
+		{nil, true},
+		{[]PayoutShare{{identityset.Address(0), 10000}}, false},
+		{[]PayoutShare{{identityset.Address(0), 5000}, {identityset.Address(1), 5000}}, false},
+		{[]PayoutShare{{identityset.Address(0), 5000}, {identityset.Address(1), 4999}}, true},
+		{[]PayoutShare{{identityset.Address(0), 5000}, {identityset.Address(0), 5000}}, true},
+		{[]PayoutShare{{identityset.Address(0), 10000}, {identityset.Address(1), 0}}, true},
+		{[]PayoutShare{{nil, 10000}}, true},
+	} {
+		err := ValidatePayoutSplit(c.split)
+		if c.err {
+			r.ErrorIs(err, ErrInvalidPayoutSplit)
+		} else {
+			r.NoError(err)
+		}
+	}
+	split := make([]PayoutShare, MaxPayoutShares+1)
+	for i := range split {
+		split[i] = PayoutShare{identityset.Address(i), 1}
+	}
+	split[0].BasisPoints = PayoutSplitBasisPoints - MaxPayoutShares
+	r.ErrorIs(ValidatePayoutSplit(split), ErrInvalidPayoutSplit)
+	r.NoError(ValidatePayoutSplit(append(split[1:2:2], PayoutShare{identityset.Address(0), PayoutSplitBasisPoints - 1})))
+}
+
+func TestSplitPayout(t *testing.T) {
+	r := require.New(t)
+	split := []PayoutShare{
+		{identityset.Address(0), 3333},
+		{identityset.Address(1), 3333},
+		{identityset.Address(2), 3334},
+	}
+	// 10 * 3333 / 10000 and 10 * 3334 / 10000 round down to 3, the residue goes to the first address
+	for _, c := range []struct {
+		amount   int64
+		expected []string
+	}{
+		{10, []string{"4", "3", "3"}},
+		{1, []string{"1", "0", "0"}},
+		{20000, []string{"6666", "6666", "6668"}},
+	} {
+		amounts := SplitPayout(big.NewInt(c.amount), split)
+		r.Len(amounts, len(c.expected))
+		for i := range amounts {
+			r.Equal(c.expected[i], amounts[i].String())
+		}
+	}
+	r.Empty(SplitPayout(big.NewInt(1), nil))
+}
+
+func TestPayoutSplitProto(t *testing.T) {
+	r := require.New(t)
+	split := []PayoutShare{
+		{identityset.Address(0), 2500},
+		{identityset.Address(1), 7500},
+	}
+	parsed, err := PayoutSplitFromProto(PayoutSplitToProto(split))
+	r.NoError(err)
+	r.Equal(split, parsed)
+	r.Nil(PayoutSplitToProto(nil))
+	parsed, err = PayoutSplitFromProto(nil)
+	r.NoError(err)
+	r.Nil(parsed)
+
+	_, err = PayoutSplitFromProto([]*actionpb.PayoutShare{{Address: "io1invalid", BasisPoints: 10000}})
+	r.ErrorIs(err, ErrInvalidPayoutSplit)
+	_, err = PayoutSplitFromProto([]*actionpb.PayoutShare{{Address: identityset.Address(0).String(), BasisPoints: 10001}})
+	r.ErrorIs(err, ErrInvalidPayoutSplit)
+}
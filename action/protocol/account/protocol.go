@@ -68,10 +68,21 @@ func (p *Protocol) Handle(ctx context.Context, act action.Action, sm protocol.St
 	switch act := act.(type) {
 	case *action.Transfer:
 		return p.handleTransfer(ctx, act, sm)
+	case *action.TimeLockedTransfer:
+		return p.handleTimeLockedTransfer(ctx, act, sm)
 	}
 	return nil, nil
 }
 
+// PreHandle releases the amounts locked to the caller which are unlocked, ahead of the handlers of the action
+func (p *Protocol) PreHandle(ctx context.Context, _ action.Action, sm protocol.StateManager) error {
+	if !protocol.MustGetFeatureCtx(ctx).EnableTimeLockedTransfer {
+		return nil
+	}
+	_, err := releaseTimeLocks(ctx, sm, protocol.MustGetActionCtx(ctx).Caller)
+	return err
+}
+
 // Validate validates an account action
 func (p *Protocol) Validate(ctx context.Context, act action.Action, sr protocol.StateReader) error {
 	switch act := act.(type) {
@@ -79,13 +90,20 @@ func (p *Protocol) Validate(ctx context.Context, act action.Action, sr protocol.
 		if err := p.validateTransfer(ctx, act); err != nil {
 			return errors.Wrap(err, "error when validating transfer action")
 		}
+	case *action.TimeLockedTransfer:
+		return p.validateTimeLockedTransfer(ctx, act)
 	}
 	return nil
 }
 
 // ReadState read the state on blockchain via protocol
-func (p *Protocol) ReadState(context.Context, protocol.StateReader, []byte, ...[]byte) ([]byte, uint64, error) {
-	return nil, uint64(0), protocol.ErrUnimplemented
+func (p *Protocol) ReadState(ctx context.Context, sr protocol.StateReader, method []byte, args ...[]byte) ([]byte, uint64, error) {
+	switch string(method) {
+	case ReadStateTimeLocks:
+		return p.readStateTimeLocks(ctx, sr, args...)
+	default:
+		return nil, uint64(0), protocol.ErrUnimplemented
+	}
 }
 
 // Register registers the protocol with a unique ID
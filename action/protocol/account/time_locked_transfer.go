@@ -0,0 +1,254 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+const (
+	// HandleTimeLockedTransfer is the topic of the receipt log of a time-locked transfer, the other topics are the
+	// recipient, the unlock height and the unlock timestamp
+	HandleTimeLockedTransfer = "timeLockedTransfer"
+	// ReadStateTimeLocks is the ReadState method of the amounts locked to an account
+	ReadStateTimeLocks = "TimeLocks"
+)
+
+type (
+	// TimeLocksStatus is the amounts locked to an account, as of the block next to the height
+	TimeLocksStatus struct {
+		Address string `json:"address"`
+		Height  uint64 `json:"height"`
+		// Unlocked is the total of the amounts unlocked but not released to the balance yet
+		Unlocked string `json:"unlocked"`
+		// Locked is the total of the amounts still locked
+		Locked string           `json:"locked"`
+		Locks  []TimeLockStatus `json:"locks"`
+	}
+
+	// TimeLockStatus is an amount locked to an account
+	TimeLockStatus struct {
+		Amount          string `json:"amount"`
+		Sender          string `json:"sender"`
+		UnlockHeight    uint64 `json:"unlockHeight,omitempty"`
+		UnlockTimestamp uint64 `json:"unlockTimestamp,omitempty"`
+		CreateHeight    uint64 `json:"createHeight"`
+		Unlocked        bool   `json:"unlocked"`
+	}
+)
+
+// handleTimeLockedTransfer moves the amount from the sender to the locks of the recipient
+func (p *Protocol) handleTimeLockedTransfer(ctx context.Context, act *action.TimeLockedTransfer, sm protocol.StateManager) (*action.Receipt, error) {
+	var (
+		fCtx      = protocol.MustGetFeatureCtx(ctx)
+		actionCtx = protocol.MustGetActionCtx(ctx)
+		blkCtx    = protocol.MustGetBlockCtx(ctx)
+		g         = genesis.MustExtractGenesisContext(ctx)
+	)
+	accountCreationOpts := []state.AccountCreationOption{}
+	if fCtx.CreateLegacyNonceAccount {
+		accountCreationOpts = append(accountCreationOpts, state.LegacyNonceAccountTypeOption())
+	}
+	sender, err := accountutil.LoadOrCreateAccount(sm, actionCtx.Caller, accountCreationOpts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load or create the account of sender %s", actionCtx.Caller.String())
+	}
+	gasFee, baseFee, err := protocol.SplitGas(ctx, &act.AbstractAction, actionCtx.IntrinsicGas)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to split gas")
+	}
+	gas := new(big.Int).Set(gasFee)
+	if baseFee != nil {
+		gas.Add(gas, baseFee)
+	}
+	if actionCtx.GasPayer != nil {
+		// the gas is paid by the gas payer, and the sender only pays the amount
+		payer, err := accountutil.LoadAccount(sm, actionCtx.GasPayer, accountCreationOpts...)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load the account of gas payer %s", actionCtx.GasPayer.String())
+		}
+		if !payer.HasSufficientBalance(gas) {
+			return nil, protocol.NewAdmissionError(errors.Wrapf(
+				state.ErrNotEnoughBalance,
+				"gas payer %s balance %s, required amount %s",
+				actionCtx.GasPayer.String(),
+				payer.Balance,
+				gas,
+			))
+		}
+		gas.SetInt64(0)
+	}
+	if total := new(big.Int).Add(gas, act.Amount()); !sender.HasSufficientBalance(total) {
+		err := errors.Wrapf(
+			state.ErrNotEnoughBalance,
+			"sender %s balance %s, required amount %s",
+			actionCtx.Caller.String(),
+			sender.Balance,
+			total,
+		)
+		if sender.HasSufficientBalance(gas) {
+			return nil, action.NewReceiptStatusError(action.ReceiptStatus(iotextypes.ReceiptStatus_ErrNotEnoughBalance), err)
+		}
+		return nil, protocol.NewAdmissionError(err)
+	}
+	if act.Amount().Cmp(g.TimeLockMinAmount()) < 0 {
+		return nil, action.NewReceiptStatusError(action.ReceiptStatusErrTimeLockAmount,
+			errors.Errorf("amount %s is less than the min amount %s", act.Amount(), g.TimeLockMinAmount()),
+		)
+	}
+	lock := &TimeLock{
+		Amount:          new(big.Int).Set(act.Amount()),
+		Sender:          actionCtx.Caller,
+		UnlockHeight:    act.UnlockHeight(),
+		UnlockTimestamp: act.UnlockTimestamp(),
+		CreateHeight:    blkCtx.BlockHeight,
+	}
+	if lock.Unlocked(blkCtx.BlockHeight, blkCtx.BlockTimeStamp) {
+		return nil, action.NewReceiptStatusError(action.ReceiptStatusErrTimeLockUnlocked,
+			errors.Errorf("unlock point of the time lock has passed at height %d", blkCtx.BlockHeight))
+	}
+	recipient := act.Recipient()
+	recipientAcct, err := accountutil.LoadAccount(sm, recipient, accountCreationOpts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load the account of recipient %s", recipient.String())
+	}
+	if recipientAcct.IsContract() {
+		// a contract never sends an action to release the locks
+		return nil, action.NewReceiptStatusError(action.ReceiptStatusErrTransferToContract,
+			errors.Errorf("recipient %s is a contract", recipient.String()))
+	}
+	tls, err := LoadTimeLocks(sm, recipient)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load time locks of %s", recipient.String())
+	}
+	// the unlocked locks are released below, leaving room for the new lock
+	if _, locked := tls.Split(blkCtx.BlockHeight, blkCtx.BlockTimeStamp); uint64(len(locked)) >= g.MaxTimeLocks {
+		return nil, action.NewReceiptStatusError(action.ReceiptStatusErrTooManyTimeLocks,
+			errors.Errorf("recipient %s holds %d time locks", recipient.String(), len(locked)))
+	}
+
+	if err := sender.SubBalance(act.Amount()); err != nil {
+		return nil, errors.Wrapf(err, "failed to update the balance of sender %s", actionCtx.Caller.String())
+	}
+	if err := sender.SetPendingNonce(act.Nonce() + 1); err != nil {
+		return nil, errors.Wrapf(err, "failed to update pending nonce of sender %s", actionCtx.Caller.String())
+	}
+	if err := accountutil.StoreAccount(sm, actionCtx.Caller, sender); err != nil {
+		return nil, errors.Wrap(err, "failed to update pending account changes to trie")
+	}
+	if tls, err = releaseTimeLocks(ctx, sm, recipient); err != nil {
+		return nil, err
+	}
+	if err := putTimeLocks(sm, recipient, append(tls, lock)); err != nil {
+		return nil, errors.Wrapf(err, "failed to put time locks of %s", recipient.String())
+	}
+
+	var depositLog []*action.TransactionLog
+	if p.depositGas != nil {
+		depositLog, err = p.depositGas(ctx, sm, gasFee, protocol.BurnGasOption(baseFee), protocol.PayerOption(actionCtx.GasPayer))
+		if err != nil {
+			return nil, err
+		}
+	}
+	receipt := &action.Receipt{
+		Status:          uint64(iotextypes.ReceiptStatus_Success),
+		BlockHeight:     blkCtx.BlockHeight,
+		ActionHash:      actionCtx.ActionHash,
+		GasConsumed:     actionCtx.IntrinsicGas,
+		ContractAddress: p.addr.String(),
+	}
+	receipt.AddLogs(&action.Log{
+		Address: p.addr.String(),
+		Topics: action.Topics{
+			hash.BytesToHash256([]byte(HandleTimeLockedTransfer)),
+			hash.BytesToHash256(recipient.Bytes()),
+			hash.BytesToHash256(byteutil.Uint64ToBytesBigEndian(act.UnlockHeight())),
+			hash.BytesToHash256(byteutil.Uint64ToBytesBigEndian(act.UnlockTimestamp())),
+		},
+		Data:        act.Amount().Bytes(),
+		BlockHeight: blkCtx.BlockHeight,
+		ActionHash:  actionCtx.ActionHash,
+	})
+	receipt.AddTransactionLogs(&action.TransactionLog{
+		Type:      iotextypes.TransactionLogType_NATIVE_TRANSFER,
+		Sender:    actionCtx.Caller.String(),
+		Recipient: p.addr.String(),
+		Amount:    act.Amount(),
+	})
+	receipt.AddTransactionLogs(depositLog...)
+	return receipt, nil
+}
+
+// validateTimeLockedTransfer validates a time-locked transfer
+func (p *Protocol) validateTimeLockedTransfer(ctx context.Context, act *action.TimeLockedTransfer) error {
+	if !protocol.MustGetFeatureCtx(ctx).EnableTimeLockedTransfer {
+		return errors.Wrap(action.ErrInvalidAct, "time-locked transfer is disabled")
+	}
+	return nil
+}
+
+// readStateTimeLocks reads the amounts locked to the account in the arg. Whether a lock is unlocked is judged at
+// the next block and the current time if the block time is not in the context, while the amount is released when
+// the account sends an action
+func (p *Protocol) readStateTimeLocks(ctx context.Context, sr protocol.StateReader, args ...[]byte) ([]byte, uint64, error) {
+	if len(args) != 1 {
+		return nil, uint64(0), errors.Errorf("invalid number of arguments %d", len(args))
+	}
+	addr, err := address.FromString(string(args[0]))
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	height, err := sr.Height()
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	tls, err := LoadTimeLocks(sr, addr)
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	now := protocol.MustGetBlockCtx(ctx).BlockTimeStamp
+	if now.IsZero() {
+		now = time.Now()
+	}
+	unlocked, locked := tls.Split(height+1, now)
+	status := TimeLocksStatus{
+		Address:  addr.String(),
+		Height:   height,
+		Unlocked: unlocked.String(),
+		Locked:   locked.Total().String(),
+		Locks:    make([]TimeLockStatus, 0, len(tls)),
+	}
+	for _, tl := range tls {
+		status.Locks = append(status.Locks, TimeLockStatus{
+			Amount:          tl.Amount.String(),
+			Sender:          tl.Sender.String(),
+			UnlockHeight:    tl.UnlockHeight,
+			UnlockTimestamp: tl.UnlockTimestamp,
+			CreateHeight:    tl.CreateHeight,
+			Unlocked:        tl.Unlocked(height+1, now),
+		})
+	}
+	data, err := json.Marshal(status)
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	return data, height, nil
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil/testdb"
+)
+
+func TestTimeLocks(t *testing.T) {
+	r := require.New(t)
+	now := time.Unix(1700000000, 0)
+	tls := TimeLocks{
+		{Amount: big.NewInt(1), Sender: identityset.Address(1), UnlockHeight: 10, CreateHeight: 1},
+		{Amount: big.NewInt(2), Sender: identityset.Address(2), UnlockTimestamp: uint64(now.Unix()), CreateHeight: 2},
+		{Amount: big.NewInt(4), Sender: identityset.Address(1), UnlockHeight: 20, CreateHeight: 3},
+	}
+	for _, v := range []struct {
+		height   uint64
+		ts       time.Time
+		unlocked int64
+		locked   int
+	}{
+		{9, now.Add(-time.Second), 0, 3},
+		{10, now.Add(-time.Second), 1, 2},
+		{10, now, 3, 1},
+		// a timestamp lock is never unlocked without the block time
+		{20, time.Time{}, 5, 1},
+		{20, now, 7, 0},
+	} {
+		unlocked, locked := tls.Split(v.height, v.ts)
+		r.EqualValues(v.unlocked, unlocked.Int64())
+		r.Len(locked, v.locked)
+		r.EqualValues(7-v.unlocked, locked.Total().Int64())
+	}
+
+	b, err := tls.Serialize()
+	r.NoError(err)
+	var tls2 TimeLocks
+	r.NoError(tls2.Deserialize(b))
+	r.Equal(tls, tls2)
+
+	meta := &iotextypes.AccountMeta{Address: identityset.Address(1).String()}
+	locked, err := AccountMetaLockedBalance(meta)
+	r.NoError(err)
+	r.Zero(locked.Sign())
+	SetAccountMetaLockedBalance(meta, big.NewInt(7))
+	locked, err = AccountMetaLockedBalance(meta)
+	r.NoError(err)
+	r.EqualValues(7, locked.Int64())
+}
+
+func TestProtocol_HandleTimeLockedTransfer(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+	p := NewProtocol(rewarding.DepositGas)
+	reward := rewarding.NewProtocol(genesis.Default.Rewarding)
+	registry := protocol.NewRegistry()
+	r.NoError(reward.Register(registry))
+
+	g := genesis.Default
+	g.ToBeEnabledBlockHeight = 1
+	g.MaxTimeLocks = 3
+	var (
+		alfa    = identityset.Address(28)
+		bravo   = identityset.Address(29)
+		charlie = identityset.Address(30)
+		now     = time.Unix(1700000000, 0)
+		oneIotx = unit.ConvertIotxToRau(1)
+	)
+	chainCtx := genesis.WithGenesisContext(protocol.WithRegistry(context.Background(), registry), g)
+	r.NoError(reward.CreateGenesisStates(protocol.WithFeatureCtx(protocol.WithBlockCtx(chainCtx, protocol.BlockCtx{})), sm))
+	acct, err := state.NewAccount()
+	r.NoError(err)
+	r.NoError(acct.AddBalance(unit.ConvertIotxToRau(100)))
+	r.NoError(accountutil.StoreAccount(sm, alfa, acct))
+	contract, err := state.NewAccount()
+	r.NoError(err)
+	contract.CodeHash = []byte("codeHash")
+	r.NoError(accountutil.StoreAccount(sm, charlie, contract))
+
+	blockCtx := func(height uint64, ts time.Time) context.Context {
+		ctx := protocol.WithBlockCtx(chainCtx, protocol.BlockCtx{BlockHeight: height, BlockTimeStamp: ts})
+		return protocol.WithFeatureCtx(protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{}))
+	}
+	handle := func(height uint64, nonce uint64, act *action.TimeLockedTransfer) (*action.Receipt, error) {
+		ctx := protocol.WithActionCtx(blockCtx(height, now), protocol.ActionCtx{
+			Caller:       alfa,
+			IntrinsicGas: action.TimeLockedTransferIntrinsicGas,
+			Nonce:        nonce,
+		})
+		if err := p.Validate(ctx, act, sm); err != nil {
+			return nil, err
+		}
+		return p.Handle(ctx, act, sm)
+	}
+	balance := func(addr address.Address) *big.Int {
+		acct, err := accountutil.LoadAccount(sm, addr)
+		r.NoError(err)
+		return acct.Balance
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		_, err := handle(0, 0, action.NewTimeLockedTransfer(0, 30000, big.NewInt(1), oneIotx, bravo, 10, 0))
+		r.ErrorIs(err, action.ErrInvalidAct)
+	})
+
+	t.Run("Lock", func(t *testing.T) {
+		before := balance(alfa)
+		receipt, err := handle(1, 0, action.NewTimeLockedTransfer(0, 30000, big.NewInt(1), oneIotx, bravo, 10, 0))
+		r.NoError(err)
+		r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+		r.Len(receipt.Logs(), 1)
+		cost := new(big.Int).Add(oneIotx, new(big.Int).SetUint64(action.TimeLockedTransferIntrinsicGas))
+		r.Equal(new(big.Int).Sub(before, cost), balance(alfa))
+		r.Zero(balance(bravo).Sign())
+
+		receipt, err = handle(2, 1, action.NewTimeLockedTransfer(1, 30000, big.NewInt(1), oneIotx, bravo, 0, uint64(now.Unix())+100))
+		r.NoError(err)
+		r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+		tls, err := LoadTimeLocks(sm, bravo)
+		r.NoError(err)
+		r.Len(tls, 2)
+		r.Equal(alfa.String(), tls[0].Sender.String())
+		r.EqualValues(2, tls[1].CreateHeight)
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		for _, v := range []struct {
+			act    *action.TimeLockedTransfer
+			status action.ReceiptStatus
+		}{
+			{action.NewTimeLockedTransfer(2, 30000, big.NewInt(1), big.NewInt(1), bravo, 10, 0), action.ReceiptStatusErrTimeLockAmount},
+			{action.NewTimeLockedTransfer(2, 30000, big.NewInt(1), oneIotx, bravo, 3, 0), action.ReceiptStatusErrTimeLockUnlocked},
+			{action.NewTimeLockedTransfer(2, 30000, big.NewInt(1), oneIotx, bravo, 0, uint64(now.Unix())), action.ReceiptStatusErrTimeLockUnlocked},
+			{action.NewTimeLockedTransfer(2, 30000, big.NewInt(1), oneIotx, charlie, 10, 0), action.ReceiptStatusErrTransferToContract},
+			{action.NewTimeLockedTransfer(2, 30000, big.NewInt(1), unit.ConvertIotxToRau(1000), bravo, 10, 0), action.ReceiptStatus(iotextypes.ReceiptStatus_ErrNotEnoughBalance)},
+		} {
+			_, err := handle(3, 2, v.act)
+			status, ok := protocol.ExecutionFailureStatus(err)
+			r.True(ok)
+			r.EqualValues(v.status, status)
+		}
+	})
+
+	t.Run("ReadState", func(t *testing.T) {
+		data, _, err := p.ReadState(blockCtx(3, now), sm, []byte(ReadStateTimeLocks), []byte(bravo.String()))
+		r.NoError(err)
+		var status TimeLocksStatus
+		r.NoError(json.Unmarshal(data, &status))
+		r.Equal(bravo.String(), status.Address)
+		r.Equal("0", status.Unlocked)
+		r.Equal(new(big.Int).Mul(oneIotx, big.NewInt(2)).String(), status.Locked)
+		r.Len(status.Locks, 2)
+		r.EqualValues(10, status.Locks[0].UnlockHeight)
+		r.False(status.Locks[0].Unlocked)
+
+		_, _, err = p.ReadState(blockCtx(3, now), sm, []byte(ReadStateTimeLocks))
+		r.Error(err)
+		_, _, err = p.ReadState(blockCtx(3, now), sm, []byte("unknown"))
+		r.Equal(protocol.ErrUnimplemented, errors.Cause(err))
+	})
+
+	t.Run("Release", func(t *testing.T) {
+		release := func(height uint64, ts time.Time) {
+			ctx := protocol.WithActionCtx(blockCtx(height, ts), protocol.ActionCtx{Caller: bravo})
+			r.NoError(p.PreHandle(ctx, nil, sm))
+		}
+		release(9, now)
+		r.Zero(balance(bravo).Sign())
+		unlocked, locked, err := TimeLockBalances(sm, bravo, 10, now)
+		r.NoError(err)
+		r.Equal(oneIotx, unlocked)
+		r.Equal(oneIotx, locked)
+
+		release(10, now)
+		r.Equal(oneIotx, balance(bravo))
+		tls, err := LoadTimeLocks(sm, bravo)
+		r.NoError(err)
+		r.Len(tls, 1)
+
+		// the locks are released only for the caller
+		release(11, now.Add(time.Hour))
+		tls, err = LoadTimeLocks(sm, bravo)
+		r.NoError(err)
+		r.Empty(tls)
+		r.Equal(new(big.Int).Mul(oneIotx, big.NewInt(2)), balance(bravo))
+	})
+
+	t.Run("TooManyLocks", func(t *testing.T) {
+		for i := uint64(0); i < g.MaxTimeLocks; i++ {
+			receipt, err := handle(12, 2+i, action.NewTimeLockedTransfer(2+i, 30000, big.NewInt(1), oneIotx, bravo, 20+i, 0))
+			r.NoError(err)
+			r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+		}
+		_, err := handle(12, 5, action.NewTimeLockedTransfer(5, 30000, big.NewInt(1), oneIotx, bravo, 30, 0))
+		status, ok := protocol.ExecutionFailureStatus(err)
+		r.True(ok)
+		r.Equal(action.ReceiptStatusErrTooManyTimeLocks, action.ReceiptStatus(status))
+		// the unlocked locks are released for the new one
+		receipt, err := handle(20, 5, action.NewTimeLockedTransfer(5, 30000, big.NewInt(1), oneIotx, bravo, 30, 0))
+		r.NoError(err)
+		r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+		r.Equal(new(big.Int).Mul(oneIotx, big.NewInt(3)), balance(bravo))
+	})
+}
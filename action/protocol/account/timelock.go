@@ -0,0 +1,212 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package account
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/actionpb"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account/accountpb"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+// TimeLockNameSpace is the namespace of the amounts locked to the accounts, keyed by the recipient address
+const TimeLockNameSpace = "TimeLock"
+
+type (
+	// TimeLock is an amount locked to an account until the unlock height or the unlock timestamp, whichever is set
+	TimeLock struct {
+		Amount          *big.Int
+		Sender          address.Address
+		UnlockHeight    uint64
+		UnlockTimestamp uint64
+		CreateHeight    uint64
+	}
+
+	// TimeLocks is the amounts locked to an account, in the order of the creation
+	TimeLocks []*TimeLock
+)
+
+// Unlocked returns true if the lock is unlocked in the block at the height and the time
+func (tl *TimeLock) Unlocked(height uint64, ts time.Time) bool {
+	if tl.UnlockHeight != 0 {
+		return height >= tl.UnlockHeight
+	}
+	return ts.Unix() >= 0 && uint64(ts.Unix()) >= tl.UnlockTimestamp
+}
+
+// Split returns the total of the amounts unlocked in the block at the height and the time, and the locks still locked
+func (tls TimeLocks) Split(height uint64, ts time.Time) (*big.Int, TimeLocks) {
+	var (
+		unlocked = big.NewInt(0)
+		locked   TimeLocks
+	)
+	for _, tl := range tls {
+		if tl.Unlocked(height, ts) {
+			unlocked.Add(unlocked, tl.Amount)
+		} else {
+			locked = append(locked, tl)
+		}
+	}
+	return unlocked, locked
+}
+
+// Total returns the total of the locked amounts
+func (tls TimeLocks) Total() *big.Int {
+	total := big.NewInt(0)
+	for _, tl := range tls {
+		total.Add(total, tl.Amount)
+	}
+	return total
+}
+
+// Serialize serializes time locks to bytes
+func (tls TimeLocks) Serialize() ([]byte, error) {
+	pb := &accountpb.TimeLocks{}
+	for _, tl := range tls {
+		pb.Locks = append(pb.Locks, &accountpb.TimeLock{
+			Amount:          tl.Amount.String(),
+			Sender:          tl.Sender.String(),
+			UnlockHeight:    tl.UnlockHeight,
+			UnlockTimestamp: tl.UnlockTimestamp,
+			CreateHeight:    tl.CreateHeight,
+		})
+	}
+	return proto.Marshal(pb)
+}
+
+// Deserialize deserializes bytes to time locks
+func (tls *TimeLocks) Deserialize(buf []byte) error {
+	pb := &accountpb.TimeLocks{}
+	if err := proto.Unmarshal(buf, pb); err != nil {
+		return errors.Wrap(err, "failed to unmarshal time locks")
+	}
+	locks := make(TimeLocks, 0, len(pb.GetLocks()))
+	for _, lock := range pb.GetLocks() {
+		amount, ok := new(big.Int).SetString(lock.GetAmount(), 10)
+		if !ok {
+			return errors.Errorf("invalid time lock amount %s", lock.GetAmount())
+		}
+		sender, err := address.FromString(lock.GetSender())
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse time lock sender %s", lock.GetSender())
+		}
+		locks = append(locks, &TimeLock{
+			Amount:          amount,
+			Sender:          sender,
+			UnlockHeight:    lock.GetUnlockHeight(),
+			UnlockTimestamp: lock.GetUnlockTimestamp(),
+			CreateHeight:    lock.GetCreateHeight(),
+		})
+	}
+	*tls = locks
+	return nil
+}
+
+// LoadTimeLocks loads the amounts locked to the account, empty if there is none
+func LoadTimeLocks(sr protocol.StateReader, addr address.Address) (TimeLocks, error) {
+	var tls TimeLocks
+	_, err := sr.State(&tls, protocol.NamespaceOption(TimeLockNameSpace), protocol.KeyOption(addr.Bytes()))
+	switch errors.Cause(err) {
+	case nil, state.ErrStateNotExist:
+		return tls, nil
+	default:
+		return nil, err
+	}
+}
+
+// TimeLockBalances returns the total of the amounts locked to the account which are unlocked in the block at the
+// height and the time but not released yet, and the total of the amounts still locked
+func TimeLockBalances(sr protocol.StateReader, addr address.Address, height uint64, ts time.Time) (*big.Int, *big.Int, error) {
+	tls, err := LoadTimeLocks(sr, addr)
+	if err != nil {
+		return nil, nil, err
+	}
+	unlocked, locked := tls.Split(height, ts)
+	return unlocked, locked.Total(), nil
+}
+
+// SetAccountMetaLockedBalance sets the locked balance in the unknown fields of the account meta
+func SetAccountMetaLockedBalance(meta *iotextypes.AccountMeta, locked *big.Int) {
+	action.MustAppendUnknownFields(meta, &actionpb.AccountMetaExt{LockedBalance: locked.String()})
+}
+
+// AccountMetaLockedBalance returns the locked balance in the unknown fields of the account meta, 0 if not set
+func AccountMetaLockedBalance(meta *iotextypes.AccountMeta) (*big.Int, error) {
+	ext := actionpb.AccountMetaExt{}
+	if err := action.LoadUnknownFields(meta, &ext); err != nil {
+		return nil, err
+	}
+	v := ext.GetLockedBalance()
+	if v == "" {
+		return big.NewInt(0), nil
+	}
+	locked, ok := new(big.Int).SetString(v, 10)
+	if !ok {
+		return nil, errors.Errorf("invalid locked balance %s", v)
+	}
+	return locked, nil
+}
+
+// putTimeLocks puts the amounts locked to the account, the state is deleted once no amount is locked
+func putTimeLocks(sm protocol.StateManager, addr address.Address, tls TimeLocks) error {
+	if len(tls) > 0 {
+		_, err := sm.PutState(tls, protocol.NamespaceOption(TimeLockNameSpace), protocol.KeyOption(addr.Bytes()))
+		return err
+	}
+	var existing TimeLocks
+	switch _, err := sm.State(&existing, protocol.NamespaceOption(TimeLockNameSpace), protocol.KeyOption(addr.Bytes())); errors.Cause(err) {
+	case nil:
+	case state.ErrStateNotExist:
+		return nil
+	default:
+		return err
+	}
+	_, err := sm.DelState(protocol.NamespaceOption(TimeLockNameSpace), protocol.KeyOption(addr.Bytes()))
+	return err
+}
+
+// releaseTimeLocks releases the amounts locked to the account which are unlocked in the block to the balance of the
+// account. It is applied when the account is touched, instead of scanning the locks at each block
+func releaseTimeLocks(ctx context.Context, sm protocol.StateManager, addr address.Address) (TimeLocks, error) {
+	blkCtx := protocol.MustGetBlockCtx(ctx)
+	tls, err := LoadTimeLocks(sm, addr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load time locks of %s", addr.String())
+	}
+	unlocked, locked := tls.Split(blkCtx.BlockHeight, blkCtx.BlockTimeStamp)
+	if unlocked.Sign() == 0 {
+		return tls, nil
+	}
+	opts := []state.AccountCreationOption{}
+	if protocol.MustGetFeatureCtx(ctx).CreateLegacyNonceAccount {
+		opts = append(opts, state.LegacyNonceAccountTypeOption())
+	}
+	acct, err := accountutil.LoadOrCreateAccount(sm, addr, opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load or create the account of %s", addr.String())
+	}
+	if err := acct.AddBalance(unlocked); err != nil {
+		return nil, errors.Wrapf(err, "failed to release %s to %s", unlocked, addr.String())
+	}
+	if err := accountutil.StoreAccount(sm, addr, acct); err != nil {
+		return nil, errors.Wrapf(err, "failed to store the account of %s", addr.String())
+	}
+	if err := putTimeLocks(sm, addr, locked); err != nil {
+		return nil, errors.Wrapf(err, "failed to put time locks of %s", addr.String())
+	}
+	return locked, nil
+}
@@ -43,18 +43,42 @@ func (p *Protocol) handleTransfer(ctx context.Context, tsf *action.Transfer, sm
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to split gas")
 	}
-	total := big.NewInt(0).Add(tsf.Amount(), gasFee)
+	total := new(big.Int).Set(gasFee)
 	if baseFee != nil {
 		total.Add(total, baseFee)
 	}
+	if actionCtx.GasPayer != nil {
+		// the gas is paid by the gas payer, and the sender only pays the amount
+		payer, err := accountutil.LoadAccount(sm, actionCtx.GasPayer, accountCreationOpts...)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load the account of gas payer %s", actionCtx.GasPayer.String())
+		}
+		if !payer.HasSufficientBalance(total) {
+			return nil, protocol.MarkAdmissionError(fCtx, errors.Wrapf(
+				state.ErrNotEnoughBalance,
+				"gas payer %s balance %s, required amount %s",
+				actionCtx.GasPayer.String(),
+				payer.Balance,
+				total,
+			))
+		}
+		total.SetInt64(0)
+	}
+	gas := new(big.Int).Set(total)
+	total.Add(total, tsf.Amount())
 	if !sender.HasSufficientBalance(total) {
-		return nil, errors.Wrapf(
+		err := errors.Wrapf(
 			state.ErrNotEnoughBalance,
 			"sender %s balance %s, required amount %s",
 			actionCtx.Caller.String(),
 			sender.Balance,
 			total,
 		)
+		if fCtx.IncludeFailedActions && sender.HasSufficientBalance(gas) {
+			// the sender affords the gas but not the amount, the transfer is included as failed
+			return nil, action.NewReceiptStatusError(action.ReceiptStatus(iotextypes.ReceiptStatus_ErrNotEnoughBalance), err)
+		}
+		return nil, protocol.MarkAdmissionError(fCtx, err)
 	}
 
 	var depositLog []*action.TransactionLog
@@ -85,7 +109,11 @@ func (p *Protocol) handleTransfer(ctx context.Context, tsf *action.Transfer, sm
 		recipientAddr, err = address.FromString(tsf.Recipient())
 	}
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to decode recipient address %s", tsf.Recipient())
+		err = errors.Wrapf(err, "failed to decode recipient address %s", tsf.Recipient())
+		if fCtx.IncludeFailedActions {
+			return nil, action.NewReceiptStatusError(action.ReceiptStatus(iotextypes.ReceiptStatus_Failure), err)
+		}
+		return nil, err
 	}
 	recipientAcct, err := accountutil.LoadAccount(sm, recipientAddr, accountCreationOpts...)
 	if err != nil {
@@ -98,14 +126,18 @@ func (p *Protocol) handleTransfer(ctx context.Context, tsf *action.Transfer, sm
 		}
 		if fCtx.FixDoubleChargeGas {
 			if p.depositGas != nil {
-				depositLog, err = p.depositGas(ctx, sm, gasFee, protocol.BurnGasOption(baseFee))
+				depositLog, err = p.depositGas(ctx, sm, gasFee, protocol.BurnGasOption(baseFee), protocol.PayerOption(actionCtx.GasPayer))
 				if err != nil {
 					return nil, err
 				}
 			}
 		}
+		status := uint64(iotextypes.ReceiptStatus_Failure)
+		if fCtx.EnableExtendedReceiptStatus {
+			status = uint64(action.ReceiptStatusErrTransferToContract)
+		}
 		receipt := &action.Receipt{
-			Status:          uint64(iotextypes.ReceiptStatus_Failure),
+			Status:          status,
 			BlockHeight:     blkCtx.BlockHeight,
 			ActionHash:      actionCtx.ActionHash,
 			GasConsumed:     actionCtx.IntrinsicGas,
@@ -140,7 +172,7 @@ func (p *Protocol) handleTransfer(ctx context.Context, tsf *action.Transfer, sm
 
 	if fCtx.FixDoubleChargeGas {
 		if p.depositGas != nil {
-			depositLog, err = p.depositGas(ctx, sm, gasFee, protocol.BurnGasOption(baseFee))
+			depositLog, err = p.depositGas(ctx, sm, gasFee, protocol.BurnGasOption(baseFee), protocol.PayerOption(actionCtx.GasPayer))
 			if err != nil {
 				return nil, err
 			}
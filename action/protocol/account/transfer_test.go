@@ -187,3 +187,48 @@ func TestProtocol_HandleTransfer(t *testing.T) {
 		}
 	}
 }
+
+func TestProtocol_HandleTransferFailure(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+	p := NewProtocol(rewarding.DepositGas)
+
+	g := genesis.Default
+	g.ToBeEnabledBlockHeight = 1
+	alfa := identityset.Address(28)
+	acct, err := state.NewAccount()
+	r.NoError(err)
+	r.NoError(acct.AddBalance(big.NewInt(15000)))
+	r.NoError(accountutil.StoreAccount(sm, alfa, acct))
+
+	for _, v := range []struct {
+		amount    *big.Int
+		gasPrice  *big.Int
+		admission bool
+	}{
+		// affords the gas but not the amount
+		{big.NewInt(10000), big.NewInt(1), false},
+		// can't afford the gas
+		{big.NewInt(1), big.NewInt(2), true},
+	} {
+		tsf, err := action.NewTransfer(0, v.amount, identityset.Address(29).String(), nil, 10000, v.gasPrice)
+		r.NoError(err)
+		gas, err := tsf.IntrinsicGas()
+		r.NoError(err)
+		ctx := protocol.WithActionCtx(genesis.WithGenesisContext(context.Background(), g), protocol.ActionCtx{
+			Caller:       alfa,
+			IntrinsicGas: gas,
+		})
+		ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: 1})
+		ctx = protocol.WithFeatureCtx(protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{}))
+		_, err = p.Handle(ctx, tsf, sm)
+		r.ErrorIs(err, state.ErrNotEnoughBalance)
+		r.Equal(v.admission, protocol.IsAdmissionError(err))
+		status, ok := protocol.ExecutionFailureStatus(err)
+		r.Equal(!v.admission, ok)
+		if ok {
+			r.EqualValues(iotextypes.ReceiptStatus_ErrNotEnoughBalance, status)
+		}
+	}
+}
@@ -33,6 +33,8 @@ type (
 
 	vmConfigContextKey struct{}
 
+	epochContextKey struct{}
+
 	// TipInfo contains the tip block information
 	TipInfo struct {
 		Height    uint64
@@ -56,12 +58,16 @@ type (
 	BlockCtx struct {
 		// height of block containing those actions
 		BlockHeight uint64
-		// timestamp of block containing those actions
+		// timestamp of block containing those actions, which is the block.timestamp seen by the contracts. Once
+		// ValidateBlockTimestamp is enabled, it is strictly after the median timestamp of the last 11 blocks
 		BlockTimeStamp time.Time
 		// gas Limit for perform those actions
 		GasLimit uint64
 		// Producer is the address of whom composes the block containing this action
 		Producer address.Address
+		// RandomnessProof is the VRF proof of the producer's randomness of the block, nil before the randomness
+		// beacon is enabled
+		RandomnessProof []byte
 	}
 
 	// ActionCtx provides action auxiliary information.
@@ -76,6 +82,18 @@ type (
 		IntrinsicGas uint64
 		// Nonce is the nonce of the action
 		Nonce uint64
+		// GasPayer is the address of whom pays the gas of this action, nil if the gas is paid by the caller
+		GasPayer address.Address
+	}
+
+	// EpochCtx provides the epoch of the block containing those actions
+	EpochCtx struct {
+		// EpochNum is the number of the epoch
+		EpochNum uint64
+		// EpochStartHeight is the height of the first block of the epoch
+		EpochStartHeight uint64
+		// EpochLastHeight is the height of the last block of the epoch
+		EpochLastHeight uint64
 	}
 
 	// CheckFunc is function type to check by height.
@@ -92,7 +110,6 @@ type (
 		AsyncContractTrie                       bool
 		AddOutOfGasToTransactionLog             bool
 		AddChainIDToConfig                      bool
-		UseV2Storage                            bool
 		CannotUnstakeAgain                      bool
 		SkipStakingIndexer                      bool
 		ReturnFetchError                        bool
@@ -115,7 +132,6 @@ type (
 		AllowCorrectChainIDOnly                 bool
 		AddContractStakingVotes                 bool
 		FixContractStakingWeightedVotes         bool
-		ExecutionSizeLimit32KB                  bool
 		UseZeroNonceForFreshAccount             bool
 		CandidateRegisterMustWithStake          bool
 		DisableDelegateEndorsement              bool
@@ -126,9 +142,22 @@ type (
 		UseTxContainer                          bool
 		LimitedStakingContract                  bool
 		MigrateNativeStake                      bool
-		AddClaimRewardAddress                   bool
 		EnforceLegacyEndorsement                bool
 		EnableDynamicFeeTx                      bool
+		EnableInitCodeGas                       bool
+		ValidateBlockTimestamp                  bool
+		EnableGasPayer                          bool
+		EnableStakingPrecompile                 bool
+		EnableExtendedReceiptStatus             bool
+		EnableActionHashV2                      bool
+		EnableRewardAddressDelay                bool
+		EnableRandomnessBeacon                  bool
+		EnableStakingEventLogs                  bool
+		IncludeFailedActions                    bool
+		EnableBucketMaturityLogs                bool
+		CorrectEVMBlockContext                  bool
+		EnableTimeLockedTransfer                bool
+		EnableZeroByteDataDiscount              bool
 	}
 
 	// FeatureWithHeightCtx provides feature check functions.
@@ -206,6 +235,27 @@ func MustGetBlockCtx(ctx context.Context) BlockCtx {
 	return blk
 }
 
+// WithEpochCtx adds EpochCtx into context.
+func WithEpochCtx(ctx context.Context, epoch EpochCtx) context.Context {
+	return context.WithValue(ctx, epochContextKey{}, epoch)
+}
+
+// GetEpochCtx gets EpochCtx
+func GetEpochCtx(ctx context.Context) (EpochCtx, bool) {
+	epoch, ok := ctx.Value(epochContextKey{}).(EpochCtx)
+	return epoch, ok
+}
+
+// MustGetEpochCtx must get EpochCtx.
+// If context doesn't exist, this function panic.
+func MustGetEpochCtx(ctx context.Context) EpochCtx {
+	epoch, ok := ctx.Value(epochContextKey{}).(EpochCtx)
+	if !ok {
+		log.S().Panic("Miss epoch context")
+	}
+	return epoch
+}
+
 // WithActionCtx add ActionCtx into context.
 func WithActionCtx(ctx context.Context, ac ActionCtx) context.Context {
 	return context.WithValue(ctx, actionContextKey{}, ac)
@@ -244,7 +294,6 @@ func WithFeatureCtx(ctx context.Context) context.Context {
 			AsyncContractTrie:                       g.IsGreenland(height),
 			AddOutOfGasToTransactionLog:             !g.IsGreenland(height),
 			AddChainIDToConfig:                      g.IsIceland(height),
-			UseV2Storage:                            g.IsGreenland(height),
 			CannotUnstakeAgain:                      g.IsGreenland(height),
 			SkipStakingIndexer:                      !g.IsFairbank(height),
 			ReturnFetchError:                        !g.IsGreenland(height),
@@ -267,7 +316,6 @@ func WithFeatureCtx(ctx context.Context) context.Context {
 			AllowCorrectChainIDOnly:                 g.IsQuebec(height),
 			AddContractStakingVotes:                 g.IsQuebec(height),
 			FixContractStakingWeightedVotes:         g.IsRedsea(height),
-			ExecutionSizeLimit32KB:                  !g.IsSumatra(height),
 			UseZeroNonceForFreshAccount:             g.IsSumatra(height),
 			CandidateRegisterMustWithStake:          !g.IsTsunami(height),
 			DisableDelegateEndorsement:              !g.IsTsunami(height),
@@ -278,9 +326,22 @@ func WithFeatureCtx(ctx context.Context) context.Context {
 			UseTxContainer:                          g.IsUpernavik(height),
 			LimitedStakingContract:                  !g.IsUpernavik(height),
 			MigrateNativeStake:                      g.IsUpernavik(height),
-			AddClaimRewardAddress:                   g.IsUpernavik(height),
 			EnforceLegacyEndorsement:                !g.IsUpernavik(height),
 			EnableDynamicFeeTx:                      g.IsVanuatu(height),
+			EnableInitCodeGas:                       g.IsToBeEnabled(height),
+			ValidateBlockTimestamp:                  g.IsToBeEnabled(height),
+			EnableGasPayer:                          g.IsToBeEnabled(height),
+			EnableStakingPrecompile:                 g.IsToBeEnabled(height),
+			EnableExtendedReceiptStatus:             g.IsToBeEnabled(height),
+			EnableActionHashV2:                      g.IsToBeEnabled(height),
+			EnableRewardAddressDelay:                g.IsToBeEnabled(height),
+			EnableRandomnessBeacon:                  g.IsToBeEnabled(height),
+			EnableStakingEventLogs:                  g.IsToBeEnabled(height),
+			IncludeFailedActions:                    g.IsToBeEnabled(height),
+			EnableBucketMaturityLogs:                g.IsToBeEnabled(height),
+			CorrectEVMBlockContext:                  g.IsToBeEnabled(height),
+			EnableTimeLockedTransfer:                g.IsToBeEnabled(height),
+			EnableZeroByteDataDiscount:              g.IsToBeEnabled(height),
 		},
 	)
 }
@@ -352,6 +413,22 @@ func MustGetFeatureWithHeightCtx(ctx context.Context) FeatureWithHeightCtx {
 	return fc
 }
 
+// WithReadCtx adds the contexts needed to read the states of the protocols at the given height, which are the
+// block and blockchain contexts at the height, the genesis, the registry and the features enabled at the height.
+// A blockchain context already in ctx is kept.
+func WithReadCtx(ctx context.Context, g genesis.Genesis, reg *Registry, height uint64) context.Context {
+	ctx = WithBlockCtx(ctx, BlockCtx{
+		BlockHeight: height,
+	})
+	if _, ok := GetBlockchainCtx(ctx); !ok {
+		ctx = WithBlockchainCtx(ctx, BlockchainCtx{
+			Tip: TipInfo{Height: height},
+		})
+	}
+	ctx = genesis.WithGenesisContext(WithRegistry(ctx, reg), g)
+	return WithFeatureCtx(WithFeatureWithHeightCtx(ctx))
+}
+
 // WithVMConfigCtx adds vm config to context
 func WithVMConfigCtx(ctx context.Context, vmConfig vm.Config) context.Context {
 	return context.WithValue(ctx, vmConfigContextKey{}, vmConfig)
@@ -15,6 +15,8 @@ import (
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
 )
 
 func TestRegistryCtx(t *testing.T) {
@@ -183,3 +185,24 @@ func TestGetVMConfigCtx(t *testing.T) {
 	require.True(ok)
 	require.True(ret.NoBaseFee)
 }
+
+func TestWithReadCtx(t *testing.T) {
+	require := require.New(t)
+	g := genesis.TestDefault()
+	g.GreenlandBlockHeight = 10
+	reg := NewRegistry()
+	for _, height := range []uint64{9, 10} {
+		ctx := WithReadCtx(context.Background(), g, reg, height)
+		require.Equal(height, MustGetBlockCtx(ctx).BlockHeight)
+		require.Equal(height, MustGetBlockchainCtx(ctx).Tip.Height)
+		require.Equal(reg, MustGetRegistry(ctx))
+		require.Equal(g.GreenlandBlockHeight, genesis.MustExtractGenesisContext(ctx).GreenlandBlockHeight)
+		require.Equal(height >= g.GreenlandBlockHeight, MustGetFeatureWithHeightCtx(ctx).ReadStateFromDB(height))
+		MustGetFeatureCtx(ctx)
+	}
+	// the blockchain context in the context is kept
+	ctx := WithBlockchainCtx(context.Background(), BlockchainCtx{Tip: TipInfo{Height: 20}, ChainID: 2})
+	bcCtx := MustGetBlockchainCtx(WithReadCtx(ctx, g, reg, 10))
+	require.Equal(uint64(20), bcCtx.Tip.Height)
+	require.Equal(uint32(2), bcCtx.ChainID)
+}
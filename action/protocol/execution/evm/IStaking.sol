@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: Apache-2.0
+pragma solidity ^0.8.0;
+
+/// @title The staking system contract of IoTeX
+/// @notice The operations run as the native staking actions sent by the calling contract, which owns the buckets.
+/// The value sent along with createStake and depositToStake funds the bucket, and must be equal to the amount.
+/// The gas charged is the intrinsic gas of the native action, and a failed operation reverts the call.
+/// The address is evm.StakingPrecompileAddress, i.e. the hash160 of "staking precompile".
+interface IStaking {
+    /// @return bucketIndex the index of the bucket created
+    function createStake(string calldata candName, uint256 amount, uint32 duration, bool autoStake, uint8[] calldata data)
+        external
+        payable
+        returns (uint64 bucketIndex);
+
+    function depositToStake(uint64 bucketIndex, uint256 amount, uint8[] calldata data)
+        external
+        payable
+        returns (uint64);
+
+    function unstake(uint64 bucketIndex, uint8[] calldata data) external returns (uint64);
+
+    function changeCandidate(string calldata candName, uint64 bucketIndex, uint8[] calldata data)
+        external
+        returns (uint64);
+}
@@ -15,6 +15,9 @@ type (
 		GetBlockHash   GetBlockHash
 		GetBlockTime   GetBlockTime
 		DepositGasFunc protocol.DepositGas
+		// SystemCall is true for the contract call initiated by a protocol, of which the gas is paid by the system
+		// call gas budget of the block instead of the caller
+		SystemCall bool
 	}
 )
 
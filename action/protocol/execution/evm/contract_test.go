@@ -238,3 +238,62 @@ func TestSnapshot(t *testing.T) {
 		testfunc(true)
 	})
 }
+
+func TestIterateContractStorage(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm, err := initMockStateManager(ctrl)
+	require.NoError(err)
+
+	addr := identityset.Address(28)
+	_, err = accountutil.LoadOrCreateAccount(sm, addr)
+	require.NoError(err)
+	// not a contract yet
+	require.Error(IterateContractStorage(sm, addr, nil, func(k, v []byte) (bool, error) {
+		return true, nil
+	}))
+
+	stateDB, err := NewStateDBAdapter(sm, 0, hash.ZeroHash256, NotFixTopicCopyBugOption())
+	require.NoError(err)
+	evmContract := common.BytesToAddress(addr.Bytes())
+	stateDB.SetCode(evmContract, _bytecode)
+	slots := make(map[common.Hash]common.Hash)
+	for i := byte(1); i <= 10; i++ {
+		k, v := common.BytesToHash([]byte{i, i}), common.BytesToHash([]byte{i})
+		stateDB.SetState(evmContract, k, v)
+		slots[k] = v
+	}
+	require.NoError(stateDB.CommitContracts())
+
+	var (
+		keys   [][]byte
+		after  []byte
+		errEnd = errors.New("end of page")
+	)
+	for {
+		var page [][]byte
+		err := IterateContractStorage(sm, addr, after, func(k, v []byte) (bool, error) {
+			require.Equal(slots[common.BytesToHash(k)].Bytes(), v)
+			if len(page) == 3 {
+				return false, nil
+			}
+			page = append(page, k)
+			return true, nil
+		})
+		require.NoError(err)
+		if len(page) == 0 {
+			break
+		}
+		keys = append(keys, page...)
+		after = page[len(page)-1]
+	}
+	require.Len(keys, len(slots))
+	for _, k := range keys {
+		_, ok := slots[common.BytesToHash(k)]
+		require.True(ok)
+		delete(slots, common.BytesToHash(k))
+	}
+	require.Equal(errEnd, errors.Cause(IterateContractStorage(sm, addr, nil, func(k, v []byte) (bool, error) {
+		return false, errEnd
+	})))
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package evm
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/iotexproject/iotex-address/address"
+
+	"github.com/iotexproject/iotex-core/action"
+)
+
+type (
+	// contractTracker tracks the contracts created and self-destructed in an execution, including the ones created
+	// by contracts at depth, and passes the calls on to the tracer configured, if any
+	contractTracker struct {
+		tracer vm.EVMLogger
+		// eip6780 tells whether a self-destruct only destroys the contract created in the same transaction
+		eip6780 bool
+		// frames are the changes of the calls in progress, which are dropped if the call fails
+		frames     []*contractFrame
+		created    []*action.ContractChange
+		destructed []*action.ContractChange
+	}
+
+	contractFrame struct {
+		created    []*action.ContractChange
+		destructed []*action.ContractChange
+	}
+)
+
+func newContractTracker(tracer vm.EVMLogger) *contractTracker {
+	return &contractTracker{tracer: tracer}
+}
+
+// CaptureTxStart implements vm.EVMLogger
+func (t *contractTracker) CaptureTxStart(gasLimit uint64) {
+	if t.tracer != nil {
+		t.tracer.CaptureTxStart(gasLimit)
+	}
+}
+
+// CaptureTxEnd implements vm.EVMLogger
+func (t *contractTracker) CaptureTxEnd(restGas uint64) {
+	if t.tracer != nil {
+		t.tracer.CaptureTxEnd(restGas)
+	}
+}
+
+// CaptureStart implements vm.EVMLogger
+func (t *contractTracker) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	t.eip6780 = env.ChainConfig().Rules(env.Context.BlockNumber, env.Context.Random != nil, env.Context.Time).IsCancun
+	t.frames = []*contractFrame{{}}
+	if create {
+		t.frames[0].created = append(t.frames[0].created, newContractChange(to, from, 0))
+	}
+	if t.tracer != nil {
+		t.tracer.CaptureStart(env, from, to, create, input, gas, value)
+	}
+}
+
+// CaptureEnd implements vm.EVMLogger
+func (t *contractTracker) CaptureEnd(output []byte, gasUsed uint64, err error) {
+	if err == nil && len(t.frames) > 0 {
+		t.created = t.frames[0].created
+		t.destructed = t.frames[0].destructed
+	}
+	t.frames = nil
+	if t.tracer != nil {
+		t.tracer.CaptureEnd(output, gasUsed, err)
+	}
+}
+
+// CaptureEnter implements vm.EVMLogger
+func (t *contractTracker) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	var (
+		depth = uint32(len(t.frames))
+		frame = &contractFrame{}
+	)
+	switch typ {
+	case vm.CREATE, vm.CREATE2:
+		frame.created = append(frame.created, newContractChange(to, from, depth))
+	case vm.SELFDESTRUCT:
+		// the contract self-destructing runs at the depth of its caller frame
+		if t.destroys(from) {
+			frame.destructed = append(frame.destructed, newContractChange(from, to, depth-1))
+		}
+	}
+	t.frames = append(t.frames, frame)
+	if t.tracer != nil {
+		t.tracer.CaptureEnter(typ, from, to, input, gas, value)
+	}
+}
+
+// CaptureExit implements vm.EVMLogger
+func (t *contractTracker) CaptureExit(output []byte, gasUsed uint64, err error) {
+	if n := len(t.frames); n > 1 {
+		frame := t.frames[n-1]
+		t.frames = t.frames[:n-1]
+		if err == nil {
+			parent := t.frames[n-2]
+			parent.created = append(parent.created, frame.created...)
+			parent.destructed = append(parent.destructed, frame.destructed...)
+		}
+	}
+	if t.tracer != nil {
+		t.tracer.CaptureExit(output, gasUsed, err)
+	}
+}
+
+// CaptureState implements vm.EVMLogger
+func (t *contractTracker) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+	if t.tracer != nil {
+		t.tracer.CaptureState(pc, op, gas, cost, scope, rData, depth, err)
+	}
+}
+
+// CaptureFault implements vm.EVMLogger
+func (t *contractTracker) CaptureFault(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+	if t.tracer != nil {
+		t.tracer.CaptureFault(pc, op, gas, cost, scope, depth, err)
+	}
+}
+
+// destroys tells whether the self-destruct of the contract destroys it, which happens once in a transaction, and
+// only to the contract created in the same transaction since EIP-6780
+func (t *contractTracker) destroys(contract common.Address) bool {
+	addr := contractAddress(contract)
+	var created bool
+	for _, frame := range t.frames {
+		for _, c := range frame.destructed {
+			if c.Address == addr {
+				return false
+			}
+		}
+		for _, c := range frame.created {
+			created = created || c.Address == addr
+		}
+	}
+	return !t.eip6780 || created
+}
+
+func newContractChange(contract, counterparty common.Address, depth uint32) *action.ContractChange {
+	return &action.ContractChange{
+		Address:      contractAddress(contract),
+		Counterparty: contractAddress(counterparty),
+		Depth:        depth,
+	}
+}
+
+func contractAddress(addr common.Address) string {
+	ioAddr, err := address.FromBytes(addr.Bytes())
+	if err != nil {
+		return ""
+	}
+	return ioAddr.String()
+}
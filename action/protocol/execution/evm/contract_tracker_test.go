@@ -0,0 +1,77 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package evm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+)
+
+func TestContractTracker(t *testing.T) {
+	r := require.New(t)
+	var (
+		deployer    = common.HexToAddress("1000")
+		factory     = common.HexToAddress("2000")
+		child       = common.HexToAddress("3000")
+		reverted    = common.HexToAddress("4000")
+		existing    = common.HexToAddress("5000")
+		beneficiary = common.HexToAddress("6000")
+		change      = func(contract, counterparty common.Address, depth uint32) *action.ContractChange {
+			return newContractChange(contract, counterparty, depth)
+		}
+		newEVM = func(cancun bool) *vm.EVM {
+			cfg := &params.ChainConfig{ChainID: big.NewInt(1), LondonBlock: big.NewInt(0)}
+			if cancun {
+				cfg.CancunTime = new(uint64)
+			}
+			return vm.NewEVM(vm.BlockContext{BlockNumber: big.NewInt(1)}, vm.TxContext{}, nil, cfg, vm.Config{})
+		}
+		// the factory deploys the child, which self-destructs twice, and the creation of another child reverts
+		run = func(tracker *contractTracker, evm *vm.EVM, endErr error) {
+			tracker.CaptureStart(evm, deployer, factory, true, nil, 0, nil)
+			tracker.CaptureEnter(vm.CREATE, factory, child, nil, 0, nil)
+			tracker.CaptureExit(nil, 0, nil)
+			tracker.CaptureEnter(vm.CREATE2, factory, reverted, nil, 0, nil)
+			tracker.CaptureExit(nil, 0, vm.ErrExecutionReverted)
+			tracker.CaptureEnter(vm.CALL, factory, child, nil, 0, nil)
+			for i := 0; i < 2; i++ {
+				tracker.CaptureEnter(vm.SELFDESTRUCT, child, beneficiary, nil, 0, nil)
+				tracker.CaptureExit(nil, 0, nil)
+			}
+			tracker.CaptureExit(nil, 0, nil)
+			tracker.CaptureEnter(vm.CALL, factory, existing, nil, 0, nil)
+			tracker.CaptureEnter(vm.SELFDESTRUCT, existing, beneficiary, nil, 0, nil)
+			tracker.CaptureExit(nil, 0, nil)
+			tracker.CaptureExit(nil, 0, nil)
+			tracker.CaptureEnd(nil, 0, endErr)
+		}
+	)
+	created := []*action.ContractChange{change(factory, deployer, 0), change(child, factory, 1)}
+
+	tracker := newContractTracker(nil)
+	run(tracker, newEVM(false), nil)
+	r.Equal(created, tracker.created)
+	r.Equal([]*action.ContractChange{change(child, beneficiary, 1), change(existing, beneficiary, 1)}, tracker.destructed)
+
+	// since EIP-6780, only the contract created in the same transaction is destroyed
+	tracker = newContractTracker(nil)
+	run(tracker, newEVM(true), nil)
+	r.Equal(created, tracker.created)
+	r.Equal([]*action.ContractChange{change(child, beneficiary, 1)}, tracker.destructed)
+
+	// nothing is created or destroyed if the execution fails
+	tracker = newContractTracker(nil)
+	run(tracker, newEVM(false), vm.ErrExecutionReverted)
+	r.Empty(tracker.created)
+	r.Empty(tracker.destructed)
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"math"
 	"math/big"
+	"sort"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -28,10 +29,15 @@ import (
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/randomness"
+	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/db/trie"
+	"github.com/iotexproject/iotex-core/db/trie/mptrie"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/tracer"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
 )
 
 var (
@@ -77,6 +83,14 @@ func MakeTransfer(db vm.StateDB, fromHash, toHash common.Address, amount *uint25
 }
 
 type (
+	// StateOverride overrides the balance, the code and the storage slots of an account before simulations,
+	// a nil Balance or Code is not overridden
+	StateOverride struct {
+		Balance   *big.Int
+		Code      []byte
+		StateDiff map[common.Hash]common.Hash
+	}
+
 	// Params is the context and parameters
 	Params struct {
 		context     vm.BlockContext
@@ -162,10 +176,28 @@ func newParams(
 		Difficulty:  new(big.Int).SetUint64(uint64(50)),
 		BaseFee:     new(big.Int),
 	}
-	if g.IsSumatra(blkCtx.BlockHeight) {
+	if featureCtx.CorrectEVMBlockContext {
+		// the gas limit and the base fee of the block, instead of the gas limit of the tx and zero
+		context.GasLimit = blkCtx.GasLimit
+		context.BaseFee = blockBaseFee(g.Blockchain, protocol.MustGetBlockchainCtx(ctx).Tip, featureCtx)
+	}
+	switch {
+	case featureCtx.EnableRandomnessBeacon:
+		// the beacon of the block, or of the tip in a simulation which doesn't mix the block's randomness
+		beacon, err := randomness.CurrentBeacon(stateDB.sm)
+		if err != nil && errors.Cause(err) != state.ErrStateNotExist {
+			return nil, errors.Wrap(err, "failed to get the randomness beacon")
+		}
+		random := common.BytesToHash(beacon[:])
+		context.Random = &random
+	case g.IsSumatra(blkCtx.BlockHeight):
 		// Random opcode (EIP-4399) is not supported
 		context.Random = &common.Hash{}
 	}
+	if g.IsWake(blkCtx.BlockHeight) {
+		// there is no blob tx, BLOBBASEFEE (EIP-7516) returns zero
+		context.BlobBaseFee = new(big.Int)
+	}
 
 	if vmCfg, ok := protocol.GetVMConfigCtx(ctx); ok {
 		vmConfig = vmCfg
@@ -197,6 +229,23 @@ func newParams(
 	}, nil
 }
 
+// blockBaseFee returns the base fee of the block next to the tip, which is the initial base fee, i.e., the minimum gas
+// price, before the dynamic fee is enabled
+func blockBaseFee(g genesis.Blockchain, tip protocol.TipInfo, featureCtx protocol.FeatureCtx) *big.Int {
+	if !featureCtx.EnableDynamicFeeTx || tip.BaseFee == nil {
+		return new(big.Int).SetUint64(action.InitialBaseFee)
+	}
+	return block.CalcBaseFee(g, &tip)
+}
+
+// gasPayer returns the account which pays the gas, the executor unless the gas is sponsored
+func (ps *Params) gasPayer() common.Address {
+	if ps.actionCtx.GasPayer != nil {
+		return common.BytesToAddress(ps.actionCtx.GasPayer.Bytes())
+	}
+	return ps.txCtx.Origin
+}
+
 func securityDeposit(ps *Params, stateDB vm.StateDB, gasLimit uint64) error {
 	executorNonce := stateDB.GetNonce(ps.txCtx.Origin)
 	if executorNonce > ps.nonce {
@@ -208,10 +257,10 @@ func securityDeposit(ps *Params, stateDB vm.StateDB, gasLimit uint64) error {
 		return action.ErrGasLimit
 	}
 	gasConsumed := uint256.MustFromBig(new(big.Int).Mul(new(big.Int).SetUint64(ps.gas), ps.txCtx.GasPrice))
-	if stateDB.GetBalance(ps.txCtx.Origin).Cmp(gasConsumed) < 0 {
+	if stateDB.GetBalance(ps.gasPayer()).Cmp(gasConsumed) < 0 {
 		return action.ErrInsufficientFunds
 	}
-	stateDB.SubBalance(ps.txCtx.Origin, gasConsumed)
+	stateDB.SubBalance(ps.gasPayer(), gasConsumed)
 	return nil
 }
 
@@ -232,6 +281,8 @@ func ExecuteContract(
 	if err != nil {
 		return nil, nil, err
 	}
+	tracker := newContractTracker(ps.evmConfig.Tracer)
+	ps.evmConfig.Tracer = tracker
 	retval, depositGas, remainingGas, contractAddress, statusCode, err := executeInEVM(ps, stateDB)
 	if err != nil {
 		return nil, nil, err
@@ -242,6 +293,7 @@ func ExecuteContract(
 		ActionHash:      ps.actionCtx.ActionHash,
 		ContractAddress: contractAddress,
 	}
+	receipt.SetContractChanges(tracker.created, tracker.destructed)
 
 	receipt.Status = uint64(statusCode)
 	var (
@@ -251,11 +303,11 @@ func ExecuteContract(
 	)
 	if ps.featureCtx.FixDoubleChargeGas {
 		// Refund all deposit and, actual gas fee will be subtracted when depositing gas fee to the rewarding protocol
-		stateDB.AddBalance(ps.txCtx.Origin, uint256.MustFromBig(big.NewInt(0).Mul(big.NewInt(0).SetUint64(depositGas), ps.txCtx.GasPrice)))
+		stateDB.AddBalance(ps.gasPayer(), uint256.MustFromBig(big.NewInt(0).Mul(big.NewInt(0).SetUint64(depositGas), ps.txCtx.GasPrice)))
 	} else {
 		if remainingGas > 0 {
 			remainingValue := new(big.Int).Mul(new(big.Int).SetUint64(remainingGas), ps.txCtx.GasPrice)
-			stateDB.AddBalance(ps.txCtx.Origin, uint256.MustFromBig(remainingValue))
+			stateDB.AddBalance(ps.gasPayer(), uint256.MustFromBig(remainingValue))
 		}
 		if consumedGas > 0 {
 			burnLog = &action.TransactionLog{
@@ -266,12 +318,12 @@ func ExecuteContract(
 			}
 		}
 	}
-	if consumedGas > 0 {
+	if consumedGas > 0 && !ps.helperCtx.SystemCall {
 		gasFee, baseFee, err := protocol.SplitGas(ctx, execution, consumedGas)
 		if err != nil {
 			return nil, nil, errors.Wrapf(err, "failed to split gas")
 		}
-		depositLog, err = ps.helperCtx.DepositGasFunc(ctx, sm, gasFee, protocol.BurnGasOption(baseFee))
+		depositLog, err = ps.helperCtx.DepositGasFunc(ctx, sm, gasFee, protocol.BurnGasOption(baseFee), protocol.PayerOption(ps.actionCtx.GasPayer))
 		if err != nil {
 			return nil, nil, err
 		}
@@ -323,6 +375,53 @@ func ReadContractStorage(
 	return res[:], nil
 }
 
+// IterateContractStorage calls fn for each slot in the storage of the contract after the key in the order of the
+// storage trie, until fn returns false. A nil key iterates from the first slot
+func IterateContractStorage(
+	sm protocol.StateManager,
+	contractAddr address.Address,
+	after []byte,
+	fn func([]byte, []byte) (bool, error),
+) error {
+	addr := hash.BytesToHash160(contractAddr.Bytes())
+	account, err := state.NewAccount()
+	if err != nil {
+		return err
+	}
+	if _, err := sm.State(account, protocol.KeyOption(addr[:])); err != nil {
+		return errors.Wrapf(err, "failed to load account of %s", contractAddr.String())
+	}
+	if !account.IsContract() {
+		return errors.Errorf("%s is not a contract", contractAddr.String())
+	}
+	ctt, err := newContract(addr, account, sm, false)
+	if err != nil {
+		return err
+	}
+	var iter trie.Iterator
+	if after == nil {
+		iter, err = ctt.Iterator()
+	} else {
+		iter, err = mptrie.NewLeafIteratorAfter(ctt.(*contract).trie, after)
+	}
+	if err != nil {
+		return err
+	}
+	for {
+		key, value, err := iter.Next()
+		if err == trie.ErrEndOfIterator {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		next, err := fn(key, value)
+		if err != nil || !next {
+			return err
+		}
+	}
+}
+
 func prepareStateDB(ctx context.Context, sm protocol.StateManager) (*StateDBAdapter, error) {
 	actionCtx := protocol.MustGetActionCtx(ctx)
 	blkCtx := protocol.MustGetBlockCtx(ctx)
@@ -360,6 +459,9 @@ func prepareStateDB(ctx context.Context, sm protocol.StateManager) (*StateDBAdap
 	if featureCtx.PanicUnrecoverableError {
 		opts = append(opts, PanicUnrecoverableErrorOption())
 	}
+	if featureCtx.EnableStakingPrecompile {
+		opts = append(opts, stakingCallOption(ctx, sm))
+	}
 
 	return NewStateDBAdapter(
 		sm,
@@ -396,6 +498,12 @@ func getChainConfig(g genesis.Blockchain, height uint64, id uint32, getBlockTime
 	}
 	sumatraTimestamp := (uint64)(sumatraTime.Unix())
 	chainConfig.ShanghaiTime = &sumatraTimestamp
+	// enable Cancun at Wake, by the height of the block instead of the timestamp, so that replaying a block always
+	// selects the same instruction set
+	if g.IsWake(height) {
+		var cancunTimestamp uint64
+		chainConfig.CancunTime = &cancunTimestamp
+	}
 	return &chainConfig, nil
 }
 
@@ -419,10 +527,17 @@ func executeInEVM(evmParams *Params, stateDB *StateDBAdapter) ([]byte, uint64, u
 	if g.IsOkhotsk(blockHeight) {
 		accessList = evmParams.accessList
 	}
-	intriGas, err := intrinsicGas(uint64(len(evmParams.data)), accessList)
+	dataCost := action.DataCostFlat
+	if evmParams.featureCtx.EnableZeroByteDataDiscount {
+		dataCost = action.DataCostZeroByteDiscount
+	}
+	intriGas, err := intrinsicGas(evmParams.data, accessList, dataCost)
 	if err != nil {
 		return nil, evmParams.gas, remainingGas, action.EmptyAddress, iotextypes.ReceiptStatus_Failure, err
 	}
+	if evmParams.contract == nil && evmParams.featureCtx.EnableInitCodeGas {
+		intriGas += action.InitCodeGas(uint64(len(evmParams.data)))
+	}
 	if remainingGas < intriGas {
 		return nil, evmParams.gas, remainingGas, action.EmptyAddress, iotextypes.ReceiptStatus_Failure, action.ErrInsufficientFunds
 	}
@@ -462,6 +577,10 @@ func executeInEVM(evmParams *Params, stateDB *StateDBAdapter) ([]byte, uint64, u
 		// sufficient balance to make the transfer happen.
 		// Should be a hard fork (Bering)
 		if evmErr == vm.ErrInsufficientBalance && g.IsBering(blockHeight) {
+			if evmParams.featureCtx.IncludeFailedActions {
+				// the gas is afforded by the security deposit but not the amount, the execution is included as failed
+				evmErr = action.NewReceiptStatusError(action.ReceiptStatus(iotextypes.ReceiptStatus_ErrInsufficientBalance), evmErr)
+			}
 			return nil, evmParams.gas, remainingGas, action.EmptyAddress, iotextypes.ReceiptStatus_Failure, evmErr
 		}
 	}
@@ -497,6 +616,9 @@ func executeInEVM(evmParams *Params, stateDB *StateDBAdapter) ([]byte, uint64, u
 	errCode := iotextypes.ReceiptStatus_Success
 	if evmErr != nil {
 		errCode = evmErrToErrStatusCode(evmErr, g, blockHeight)
+		if errCode == iotextypes.ReceiptStatus_ErrUnknown && evmParams.featureCtx.EnableExtendedReceiptStatus {
+			errCode = extendedEvmErrToErrStatusCode(evmErr)
+		}
 		if errCode == iotextypes.ReceiptStatus_ErrUnknown {
 			var addr string
 			if evmParams.contract != nil {
@@ -567,8 +689,32 @@ func evmErrToErrStatusCode(evmErr error, g genesis.Blockchain, height uint64) io
 	return iotextypes.ReceiptStatus_Failure
 }
 
-// intrinsicGas returns the intrinsic gas of an execution
-func intrinsicGas(size uint64, list types.AccessList) (uint64, error) {
+// extendedEvmErrToErrStatusCode returns the status code of the errors not defined in iotex-proto, which are
+// ErrUnknown before the extended receipt status is enabled
+func extendedEvmErrToErrStatusCode(evmErr error) iotextypes.ReceiptStatus {
+	var (
+		invalidOpCode  *vm.ErrInvalidOpCode
+		stackUnderflow *vm.ErrStackUnderflow
+		stackOverflow  *vm.ErrStackOverflow
+		status         = action.ReceiptStatus(iotextypes.ReceiptStatus_ErrUnknown)
+	)
+	switch {
+	case errors.As(evmErr, &invalidOpCode):
+		status = action.ReceiptStatusErrInvalidOpCode
+	case errors.As(evmErr, &stackUnderflow):
+		status = action.ReceiptStatusErrStackUnderflow
+	case errors.As(evmErr, &stackOverflow):
+		status = action.ReceiptStatusErrStackOverflow
+	case errors.Is(evmErr, vm.ErrMaxInitCodeSizeExceeded):
+		status = action.ReceiptStatusErrMaxInitCodeSizeExceeded
+	case errors.Is(evmErr, vm.ErrNonceUintOverflow):
+		status = action.ReceiptStatusErrNonceUintOverflow
+	}
+	return iotextypes.ReceiptStatus(status)
+}
+
+// intrinsicGas returns the intrinsic gas of an execution, with the data charged by the schedule
+func intrinsicGas(data []byte, list types.AccessList, schedule action.DataCostSchedule) (uint64, error) {
 	if action.ExecutionDataGas == 0 {
 		panic("payload gas price cannot be zero")
 	}
@@ -578,13 +724,15 @@ func intrinsicGas(size uint64, list types.AccessList) (uint64, error) {
 		accessListGas = uint64(len(list)) * action.TxAccessListAddressGas
 		accessListGas += uint64(list.StorageKeys()) * action.TxAccessListStorageKeyGas
 	}
+	size := uint64(len(data))
 	if (math.MaxInt64-action.ExecutionBaseIntrinsicGas-accessListGas)/action.ExecutionDataGas < size {
 		return 0, action.ErrInsufficientFunds
 	}
-	return size*action.ExecutionDataGas + action.ExecutionBaseIntrinsicGas + accessListGas, nil
+	return size*action.ExecutionDataGas + action.ExecutionBaseIntrinsicGas + accessListGas - schedule.DataGasDiscount(data, action.ExecutionDataGas), nil
 }
 
-// SimulateExecution simulates the execution in evm
+// SimulateExecution simulates the execution in evm, the caller is the zero address if omitted as geth. The block is
+// synthetic next to the tip, produced by the zero address
 func SimulateExecution(
 	ctx context.Context,
 	sm protocol.StateManager,
@@ -598,6 +746,13 @@ func SimulateExecution(
 	}
 	bcCtx := protocol.MustGetBlockchainCtx(ctx)
 	g := genesis.MustExtractGenesisContext(ctx)
+	zeroAddr, err := address.FromString(address.ZeroAddress)
+	if err != nil {
+		return nil, nil, err
+	}
+	if caller == nil {
+		caller = zeroAddr
+	}
 	ctx = protocol.WithActionCtx(
 		ctx,
 		protocol.ActionCtx{
@@ -605,10 +760,6 @@ func SimulateExecution(
 			ActionHash: hash.Hash256b(byteutil.Must(proto.Marshal(ex.Proto()))),
 		},
 	)
-	zeroAddr, err := address.FromString(address.ZeroAddress)
-	if err != nil {
-		return nil, nil, err
-	}
 	ctx = protocol.WithBlockCtx(
 		ctx,
 		protocol.BlockCtx{
@@ -626,3 +777,52 @@ func SimulateExecution(
 		action.NewEvmTx(ex),
 	)
 }
+
+// OverrideStates applies the overrides of the accounts to the working set of simulations
+func OverrideStates(ctx context.Context, sm protocol.StateManager, overrides map[common.Address]*StateOverride) error {
+	bcCtx := protocol.MustGetBlockchainCtx(ctx)
+	ctx = protocol.WithFeatureCtx(protocol.WithBlockCtx(
+		protocol.WithActionCtx(ctx, protocol.ActionCtx{}),
+		protocol.BlockCtx{BlockHeight: bcCtx.Tip.Height + 1},
+	))
+	stateDB, err := prepareStateDB(ctx, sm)
+	if err != nil {
+		return err
+	}
+	addrs := make([]common.Address, 0, len(overrides))
+	for addr := range overrides {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool { return bytes.Compare(addrs[i][:], addrs[j][:]) < 0 })
+	for _, addr := range addrs {
+		o := overrides[addr]
+		if o.Balance != nil {
+			stateDB.SubBalance(addr, stateDB.GetBalance(addr))
+			stateDB.AddBalance(addr, uint256.MustFromBig(o.Balance))
+		}
+		if o.Code != nil {
+			stateDB.SetCode(addr, o.Code)
+		}
+		for k, v := range o.StateDiff {
+			stateDB.SetState(addr, k, v)
+		}
+		if err := stateDB.Error(); err != nil {
+			return errors.Wrapf(err, "failed to override the states of %s", addr.Hex())
+		}
+	}
+	return stateDB.CommitContracts()
+}
+
+// InstallSystemContract puts the code of a built-in contract at the address, without a contract creation
+func InstallSystemContract(ctx context.Context, sm protocol.StateManager, addr address.Address, code []byte) error {
+	stateDB, err := prepareStateDB(protocol.WithActionCtx(ctx, protocol.ActionCtx{}), sm)
+	if err != nil {
+		return err
+	}
+	evmAddr := common.BytesToAddress(addr.Bytes())
+	stateDB.SetCode(evmAddr, code)
+	if err := stateDB.Error(); err != nil {
+		return errors.Wrapf(err, "failed to install the code at %s", addr.String())
+	}
+	return stateDB.CommitContracts()
+}
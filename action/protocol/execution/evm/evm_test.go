@@ -12,20 +12,27 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/pkg/unit"
 	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/test/identityset"
 	"github.com/iotexproject/iotex-core/test/mock/mock_chainmanager"
 	"github.com/iotexproject/iotex-core/testutil"
+	"github.com/iotexproject/iotex-core/testutil/testdb"
 )
 
 func TestExecuteContractFailure(t *testing.T) {
@@ -76,6 +83,296 @@ func TestExecuteContractFailure(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestExecuteContractFailureStatus(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+	sm.EXPECT().Revert(gomock.Any()).Return(nil).AnyTimes()
+	g := genesis.TestDefault()
+	height := g.VanuatuBlockHeight + 10
+	g.ToBeEnabledBlockHeight = height
+	ctx := protocol.WithBlockchainCtx(genesis.WithGenesisContext(context.Background(), g), protocol.BlockchainCtx{
+		Tip:          protocol.TipInfo{Height: height - 1, BaseFee: new(big.Int).SetUint64(action.InitialBaseFee)},
+		ChainID:      1,
+		EvmNetworkID: 100,
+	})
+	ctx = WithHelperCtx(ctx, HelperContext{
+		GetBlockHash: func(uint64) (hash.Hash256, error) {
+			return hash.ZeroHash256, nil
+		},
+		GetBlockTime: func(uint64) (time.Time, error) {
+			return time.Time{}, nil
+		},
+		DepositGasFunc: func(context.Context, protocol.StateManager, *big.Int, ...protocol.Option) ([]*action.TransactionLog, error) {
+			return nil, nil
+		},
+	})
+	caller := identityset.Address(27)
+	acc, err := accountutil.LoadOrCreateAccount(sm, caller)
+	r.NoError(err)
+	r.NoError(acc.AddBalance(unit.ConvertIotxToRau(1000)))
+	r.NoError(accountutil.StoreAccount(sm, caller, acc))
+
+	for _, v := range []struct {
+		height    uint64
+		price     *big.Int
+		admission bool
+		included  bool
+	}{
+		// the caller affords the gas but not the amount, the execution is included as failed once activated
+		{height, new(big.Int).SetUint64(2 * action.InitialBaseFee), false, true},
+		{height - 1, new(big.Int).SetUint64(2 * action.InitialBaseFee), false, false},
+		// the caller can't afford the gas, the execution is skipped
+		{height, unit.ConvertIotxToRau(1), true, false},
+	} {
+		ex, err := action.NewExecution(identityset.Address(28).String(), 1, unit.ConvertIotxToRau(2000), 100000, v.price, nil)
+		r.NoError(err)
+		ctx := protocol.WithActionCtx(ctx, protocol.ActionCtx{
+			Caller:   caller,
+			GasPrice: v.price,
+			Nonce:    1,
+		})
+		ctx = protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight: v.height,
+			GasLimit:    g.BlockGasLimitByHeight(v.height),
+			Producer:    identityset.Address(28),
+		}))
+		_, _, err = ExecuteContract(ctx, sm, action.NewEvmTx(ex))
+		r.Error(err)
+		r.Equal(v.admission, protocol.IsAdmissionError(err))
+		status, ok := protocol.ExecutionFailureStatus(err)
+		r.Equal(v.included, ok)
+		if ok {
+			r.EqualValues(iotextypes.ReceiptStatus_ErrInsufficientBalance, status)
+		}
+	}
+}
+
+func TestBlockContextOpcodes(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+	g := genesis.TestDefault()
+	height := g.VanuatuBlockHeight + 10
+	g.ToBeEnabledBlockHeight = height
+	tip := protocol.TipInfo{
+		Height:    height - 1,
+		GasUsed:   1000000,
+		Timestamp: time.Now(),
+		BaseFee:   new(big.Int).SetUint64(2 * action.InitialBaseFee),
+	}
+	ctx := protocol.WithBlockchainCtx(genesis.WithGenesisContext(context.Background(), g), protocol.BlockchainCtx{
+		Tip:          tip,
+		ChainID:      1,
+		EvmNetworkID: 100,
+	})
+	ctx = WithHelperCtx(ctx, HelperContext{
+		GetBlockHash: func(uint64) (hash.Hash256, error) {
+			return hash.ZeroHash256, nil
+		},
+		GetBlockTime: func(uint64) (time.Time, error) {
+			return time.Time{}, nil
+		},
+		DepositGasFunc: func(context.Context, protocol.StateManager, *big.Int, ...protocol.Option) ([]*action.TransactionLog, error) {
+			return nil, nil
+		},
+	})
+	var (
+		caller   = identityset.Address(27)
+		producer = identityset.Address(28)
+		nonce    = uint64(0)
+		price    = new(big.Int).SetUint64(4 * action.InitialBaseFee)
+	)
+	acc, err := accountutil.LoadOrCreateAccount(sm, caller)
+	r.NoError(err)
+	r.NoError(acc.AddBalance(unit.ConvertIotxToRau(1000)))
+	r.NoError(accountutil.StoreAccount(sm, caller, acc))
+	execute := func(height uint64, contract string, data []byte) ([]byte, *action.Receipt) {
+		nonce++
+		ex, err := action.NewExecution(contract, nonce, big.NewInt(0), 1000000, price, data)
+		r.NoError(err)
+		ctx := protocol.WithActionCtx(ctx, protocol.ActionCtx{
+			Caller:   caller,
+			GasPrice: price,
+			Nonce:    nonce,
+		})
+		ctx = protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight:    height,
+			BlockTimeStamp: tip.Timestamp.Add(g.BlockInterval),
+			GasLimit:       g.BlockGasLimitByHeight(height),
+			Producer:       producer,
+		}))
+		retval, receipt, err := ExecuteContract(ctx, sm, action.NewEvmTx(ex))
+		r.NoError(err)
+		r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+		return retval, receipt
+	}
+	// the contract returns COINBASE, GASLIMIT and BASEFEE
+	_, receipt := execute(height, action.EmptyAddress, common.FromHex("6011600c60003960116000f3"+"41600052456020524860405260606000f3"))
+	contract := receipt.ContractAddress
+	opcodes := func(retval []byte) (common.Address, uint64, *big.Int) {
+		r.Len(retval, 96)
+		return common.BytesToAddress(retval[:32]), new(big.Int).SetBytes(retval[32:64]).Uint64(), new(big.Int).SetBytes(retval[64:])
+	}
+	call := func(height uint64) []byte {
+		retval, _ := execute(height, contract, nil)
+		return retval
+	}
+	baseFee := block.CalcBaseFee(g.Blockchain, &tip)
+
+	// before the activation, the gas limit is of the tx and the base fee is zero
+	coinbase, gasLimit, fee := opcodes(call(height - 1))
+	r.Equal(common.BytesToAddress(producer.Bytes()), coinbase)
+	r.EqualValues(1000000, gasLimit)
+	r.Zero(fee.Sign())
+
+	coinbase, gasLimit, fee = opcodes(call(height))
+	r.Equal(common.BytesToAddress(producer.Bytes()), coinbase)
+	r.Equal(g.BlockGasLimitByHeight(height), gasLimit)
+	r.Equal(baseFee, fee)
+
+	// the simulation runs in the synthetic block next to the tip, produced by the zero address
+	ex, err := action.NewExecution(contract, 0, big.NewInt(0), 1000000, price, nil)
+	r.NoError(err)
+	retval, _, err := SimulateExecution(ctx, sm, caller, ex)
+	r.NoError(err)
+	coinbase, gasLimit, fee = opcodes(retval)
+	r.Equal(common.Address{}, coinbase)
+	r.Equal(g.BlockGasLimitByHeight(height), gasLimit)
+	r.Equal(baseFee, fee)
+
+	// the initial base fee before the dynamic fee
+	r.Equal(new(big.Int).SetUint64(action.InitialBaseFee), blockBaseFee(g.Blockchain, tip, protocol.FeatureCtx{}))
+	r.Equal(new(big.Int).SetUint64(action.InitialBaseFee), blockBaseFee(g.Blockchain, protocol.TipInfo{}, protocol.FeatureCtx{EnableDynamicFeeTx: true}))
+}
+
+func TestCancunOpcodes(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+	// the call failing before the activation changes no state
+	sm.EXPECT().Revert(gomock.Any()).Return(nil).AnyTimes()
+	g := genesis.TestDefault()
+	height := g.VanuatuBlockHeight + 10
+	g.WakeBlockHeight = height
+	tip := protocol.TipInfo{
+		Height:    height - 1,
+		Timestamp: time.Now(),
+		BaseFee:   new(big.Int).SetUint64(action.InitialBaseFee),
+	}
+	ctx := protocol.WithBlockchainCtx(genesis.WithGenesisContext(context.Background(), g), protocol.BlockchainCtx{
+		Tip:          tip,
+		ChainID:      1,
+		EvmNetworkID: 100,
+	})
+	ctx = WithHelperCtx(ctx, HelperContext{
+		GetBlockHash: func(uint64) (hash.Hash256, error) {
+			return hash.ZeroHash256, nil
+		},
+		GetBlockTime: func(uint64) (time.Time, error) {
+			return time.Time{}, nil
+		},
+		DepositGasFunc: func(context.Context, protocol.StateManager, *big.Int, ...protocol.Option) ([]*action.TransactionLog, error) {
+			return nil, nil
+		},
+	})
+	var (
+		caller   = identityset.Address(27)
+		producer = identityset.Address(28)
+		nonce    = uint64(0)
+		price    = new(big.Int).SetUint64(2 * action.InitialBaseFee)
+	)
+	acc, err := accountutil.LoadOrCreateAccount(sm, caller)
+	r.NoError(err)
+	r.NoError(acc.AddBalance(unit.ConvertIotxToRau(1000)))
+	r.NoError(accountutil.StoreAccount(sm, caller, acc))
+	execute := func(height uint64, contract string, amount *big.Int, data []byte) ([]byte, *action.Receipt) {
+		nonce++
+		ex, err := action.NewExecution(contract, nonce, amount, 1000000, price, data)
+		r.NoError(err)
+		ctx := protocol.WithActionCtx(ctx, protocol.ActionCtx{
+			Caller:   caller,
+			GasPrice: price,
+			Nonce:    nonce,
+		})
+		ctx = protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight:    height,
+			BlockTimeStamp: tip.Timestamp.Add(g.BlockInterval),
+			GasLimit:       g.BlockGasLimitByHeight(height),
+			Producer:       producer,
+		}))
+		retval, receipt, err := ExecuteContract(ctx, sm, action.NewEvmTx(ex))
+		r.NoError(err)
+		return retval, receipt
+	}
+	codeOf := func(contract string) hash.Hash256 {
+		addr, err := address.FromString(contract)
+		r.NoError(err)
+		acc, err := accountutil.LoadAccount(sm, addr)
+		r.NoError(err)
+		return hash.BytesToHash256(acc.CodeHash)
+	}
+
+	t.Run("transient storage and mcopy", func(t *testing.T) {
+		// the contract stores 42 by TSTORE, loads it by TLOAD into the memory, then copies it by MCOPY
+		_, receipt := execute(height-1, action.EmptyAddress, big.NewInt(0), common.FromHex("6017600c60003960176000f3"+"602a60015d60015c6000526020600060205e60406000f3"))
+		r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+		contract := receipt.ContractAddress
+
+		_, receipt = execute(height-1, contract, big.NewInt(0), nil)
+		r.EqualValues(iotextypes.ReceiptStatus_ErrUnknown, receipt.Status)
+		retval, receipt := execute(height, contract, big.NewInt(0), nil)
+		r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+		r.Len(retval, 64)
+		r.EqualValues(42, new(big.Int).SetBytes(retval[:32]).Uint64())
+		r.Equal(retval[:32], retval[32:])
+	})
+	t.Run("selfdestruct", func(t *testing.T) {
+		// the contract self-destructs to the caller
+		deploy := func() string {
+			_, receipt := execute(height-1, action.EmptyAddress, big.NewInt(100), common.FromHex("6002600c60003960026000f3"+"33ff"))
+			r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+			r.NotEqual(hash.ZeroHash256, codeOf(receipt.ContractAddress))
+			return receipt.ContractAddress
+		}
+		transfer := func(contract string) *action.TransactionLog {
+			return &action.TransactionLog{
+				Type:      iotextypes.TransactionLogType_IN_CONTRACT_TRANSFER,
+				Sender:    contract,
+				Recipient: caller.String(),
+				Amount:    big.NewInt(100),
+			}
+		}
+
+		// the contract is deleted before the activation
+		contract := deploy()
+		_, receipt := execute(height-1, contract, big.NewInt(0), nil)
+		r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+		r.Len(receipt.TransactionLogs(), 1)
+		r.Equal(hash.ZeroHash256, codeOf(contract))
+
+		// the contract only sends the balance after the activation
+		contract = deploy()
+		_, receipt = execute(height, contract, big.NewInt(0), nil)
+		r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+		r.Equal([]*action.TransactionLog{transfer(contract)}, receipt.TransactionLogs())
+		r.NotEqual(hash.ZeroHash256, codeOf(contract))
+		addr, err := address.FromString(contract)
+		r.NoError(err)
+		acc, err := accountutil.LoadAccount(sm, addr)
+		r.NoError(err)
+		r.Zero(acc.Balance.Sign())
+
+		// the contract created in the same transaction is still deleted
+		_, receipt = execute(height, action.EmptyAddress, big.NewInt(100), common.FromHex("33ff"))
+		r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+		// the value sent to create the contract, and then back to the caller
+		r.Len(receipt.TransactionLogs(), 2)
+		r.Equal(transfer(receipt.ContractAddress), receipt.TransactionLogs()[1])
+		r.Equal(hash.ZeroHash256, codeOf(receipt.ContractAddress))
+	})
+}
+
 func TestConstantinople(t *testing.T) {
 	require := require.New(t)
 
@@ -362,9 +659,12 @@ func TestConstantinople(t *testing.T) {
 		require.Equal(isSumatra, chainRules.IsMerge)
 		require.Equal(isSumatra, chainRules.IsShanghai)
 
-		// Cancun and Prague not yet enabled
-		require.False(chainRules.IsCancun)
-		require.False(evmChainConfig.IsCancun(big.NewInt(int64(e.height)), evm.Context.Time))
+		// Wake = enable Cancun
+		isWake := g.IsWake(e.height)
+		require.Equal(isWake, chainRules.IsCancun)
+		require.Equal(isWake, evmChainConfig.IsCancun(big.NewInt(int64(e.height)), evm.Context.Time))
+
+		// Prague not yet enabled
 		require.False(chainRules.IsPrague)
 		require.False(evmChainConfig.IsPrague(big.NewInt(int64(e.height)), evm.Context.Time))
 
@@ -432,6 +732,22 @@ func TestEvmError(t *testing.T) {
 		r.Equal(evmErrToErrStatusCode(v.evmError, g, g.BeringBlockHeight), iotextypes.ReceiptStatus_ErrUnknown)
 		r.Equal(evmErrToErrStatusCode(v.evmError, g, g.BeringBlockHeight-1), iotextypes.ReceiptStatus_Failure)
 	}
+
+	extendedTests := []struct {
+		evmError error
+		status   action.ReceiptStatus
+	}{
+		{&vm.ErrInvalidOpCode{}, action.ReceiptStatusErrInvalidOpCode},
+		{&vm.ErrStackUnderflow{}, action.ReceiptStatusErrStackUnderflow},
+		{&vm.ErrStackOverflow{}, action.ReceiptStatusErrStackOverflow},
+		{vm.ErrMaxInitCodeSizeExceeded, action.ReceiptStatusErrMaxInitCodeSizeExceeded},
+		{vm.ErrNonceUintOverflow, action.ReceiptStatusErrNonceUintOverflow},
+		{errors.New("unknown"), action.ReceiptStatus(iotextypes.ReceiptStatus_ErrUnknown)},
+	}
+	for _, v := range extendedTests {
+		r.Equal(iotextypes.ReceiptStatus_ErrUnknown, evmErrToErrStatusCode(v.evmError, g, g.OkhotskBlockHeight))
+		r.Equal(iotextypes.ReceiptStatus(v.status), extendedEvmErrToErrStatusCode(v.evmError))
+	}
 }
 
 func TestGasEstimate(t *testing.T) {
@@ -456,11 +772,36 @@ func TestGasEstimate(t *testing.T) {
 	}
 }
 
+func TestIntrinsicGasDataCost(t *testing.T) {
+	require := require.New(t)
+	// the selector of transfer(address,uint256) and the amount 1, of 5 non-zero bytes and 31 zero bytes
+	data := append([]byte{0xa9, 0x05, 0x9c, 0xbb}, make([]byte, 32)...)
+	data[35] = 1
+	list := types.AccessList{{Address: common.Address{1}, StorageKeys: []common.Hash{{1}}}}
+	for _, v := range []struct {
+		schedule action.DataCostSchedule
+		gas      uint64
+	}{
+		{action.DataCostFlat, 10000 + 3600 + action.TxAccessListAddressGas + action.TxAccessListStorageKeyGas},
+		{action.DataCostZeroByteDiscount, 10000 + 500 + 775 + action.TxAccessListAddressGas + action.TxAccessListStorageKeyGas},
+	} {
+		gas, err := intrinsicGas(data, list, v.schedule)
+		require.NoError(err)
+		require.Equal(v.gas, gas)
+		// the same as the intrinsic gas of the execution
+		exec, err := action.NewExecution(action.EmptyAddress, 1, big.NewInt(0), 0, big.NewInt(0), data)
+		require.NoError(err)
+		execGas, err := action.IntrinsicGasBySchedule(exec, v.schedule)
+		require.NoError(err)
+		require.Equal(v.gas-action.TxAccessListAddressGas-action.TxAccessListStorageKeyGas, execGas)
+	}
+}
+
 // gasExecuteInEVM performs gas calculation during EVM execution
 func gasExecuteInEVM(gas, consume, refund, size uint64) (uint64, uint64, error) {
 	remainingGas := gas
 
-	intriGas, err := intrinsicGas(size, nil)
+	intriGas, err := intrinsicGas(make([]byte, size), nil, action.DataCostFlat)
 	if err != nil {
 		return 0, 0, err
 	}
@@ -35,6 +35,10 @@ type (
 	// deleteAccount records the account/contract to be deleted
 	deleteAccount map[common.Address]struct{}
 
+	// createdAccount records the accounts created in a transaction, which is not reverted with the snapshots, since
+	// the contract of a reverted creation doesn't exist to self-destruct
+	createdAccount map[common.Address]struct{}
+
 	// contractMap records the contracts being changed
 	contractMap map[common.Address]Contract
 
@@ -57,6 +61,7 @@ type (
 		contractSnapshot       map[int]contractMap   // snapshots of contracts
 		selfDestructed         deleteAccount         // account/contract calling SelfDestruct
 		selfDestructedSnapshot map[int]deleteAccount // snapshots of SelfDestruct accounts
+		createdAccount         createdAccount        // accounts created in the transaction, see Selfdestruct6780
 		preimages              preimageMap
 		preimageSnapshot       map[int]preimageMap
 		accessList             *accessList // per-transaction access list
@@ -66,6 +71,9 @@ type (
 		transientStorageSnapshot   map[int]transientStorage
 		logsSnapshot               map[int]int // logs is an array, save len(logs) at time of snapshot suffices
 		txLogsSnapshot             map[int]int
+		stakingCall                stakingCallFunc
+		stakingCallUndo            []func() error // undo the staking calls, see handleStakingCall
+		stakingCallSnapshot        map[int]int
 		notFixTopicCopyBug         bool
 		asyncContractTrie          bool
 		disableSortCachedContracts bool
@@ -193,6 +201,7 @@ func NewStateDBAdapter(
 		contractSnapshot:         make(map[int]contractMap),
 		selfDestructed:           make(deleteAccount),
 		selfDestructedSnapshot:   make(map[int]deleteAccount),
+		createdAccount:           make(createdAccount),
 		preimages:                make(preimageMap),
 		preimageSnapshot:         make(map[int]preimageMap),
 		accessList:               newAccessList(),
@@ -201,6 +210,7 @@ func NewStateDBAdapter(
 		transientStorageSnapshot: make(map[int]transientStorage),
 		logsSnapshot:             make(map[int]int),
 		txLogsSnapshot:           make(map[int]int),
+		stakingCallSnapshot:      make(map[int]int),
 	}
 	for _, opt := range opts {
 		if err := opt(s); err != nil {
@@ -254,6 +264,7 @@ func (stateDB *StateDBAdapter) CreateAccount(evmAddr common.Address) {
 	if stateDB.assertError(err, "Failed to create account.", zap.Error(err), zap.String("address", evmAddr.Hex())) {
 		return
 	}
+	stateDB.createdAccount[evmAddr] = struct{}{}
 	log.L().Debug("Called CreateAccount.", log.Hex("addrHash", evmAddr[:]))
 }
 
@@ -497,10 +508,38 @@ func (stateDB *StateDBAdapter) GetTransientState(addr common.Address, key common
 	return stateDB.transientStorage.Get(addr, key)
 }
 
-// Selfdestruct6780 implements EIP-6780
+// Selfdestruct6780 implements EIP-6780, the contract is only deleted if it is created in the same transaction.
+// Unlike SelfDestruct, EVM has moved the balance of the contract to the beneficiary before calling it
 func (stateDB *StateDBAdapter) Selfdestruct6780(evmAddr common.Address) {
-	//Todo: implement EIP-6780
-	log.S().Panic("Selfdestruct6780 not implemented")
+	from, _ := address.FromBytes(evmAddr[:])
+	if stateDB.lastAddBalanceAmount.Sign() > 0 && stateDB.lastAddBalanceAddr != from.String() {
+		stateDB.addTransactionLogs(&action.TransactionLog{
+			Type:      iotextypes.TransactionLogType_IN_CONTRACT_TRANSFER,
+			Sender:    from.String(),
+			Recipient: stateDB.lastAddBalanceAddr,
+			Amount:    new(big.Int).Set(stateDB.lastAddBalanceAmount),
+		})
+	}
+	if _, ok := stateDB.createdAccount[evmAddr]; !ok {
+		return
+	}
+	s, err := stateDB.accountState(evmAddr)
+	if stateDB.assertError(err, "Failed to get account.", zap.Error(err), zap.String("address", evmAddr.Hex())) {
+		return
+	}
+	// the balance left is only of the contract being the beneficiary itself, which is burnt
+	if s.Balance.Sign() > 0 {
+		err = s.SubBalance(s.Balance)
+		if stateDB.assertError(err, "Failed to clear balance.", zap.Error(err), zap.String("address", evmAddr.Hex())) {
+			return
+		}
+		_, err = stateDB.sm.PutState(s, protocol.KeyOption(evmAddr[:]))
+		if stateDB.assertError(err, "Failed to kill contract.", zap.Error(err), zap.String("address", evmAddr.Hex())) {
+			return
+		}
+	}
+	// mark it as deleted
+	stateDB.selfDestructed[evmAddr] = struct{}{}
 }
 
 // Exist checks the existence of an address
@@ -643,6 +682,13 @@ func (stateDB *StateDBAdapter) RevertToSnapshot(snapshot int) {
 			}
 		}
 	}
+	// undo the staking calls
+	if stateDB.stakingCall != nil {
+		err := stateDB.undoStakingCalls(snapshot)
+		if stateDB.assertError(err, "Failed to undo staking calls.", zap.Error(err), zap.Int("snapshot", snapshot)) {
+			return
+		}
+	}
 	// restore logs and txLogs
 	if stateDB.revertLog {
 		stateDB.logs = stateDB.logs[:stateDB.logsSnapshot[snapshot]]
@@ -742,6 +788,8 @@ func (stateDB *StateDBAdapter) Snapshot() int {
 		stateDB.logsSnapshot[sn] = len(stateDB.logs)
 		stateDB.txLogsSnapshot[sn] = len(stateDB.transactionLogs)
 	}
+	// record the number of staking calls
+	stateDB.stakingCallSnapshot[sn] = len(stateDB.stakingCallUndo)
 	// save a copy of current SelfDestruct accounts
 	sa := make(deleteAccount)
 	for k, v := range stateDB.selfDestructed {
@@ -780,6 +828,10 @@ func (stateDB *StateDBAdapter) AddLog(evmLog *types.Log) {
 		copy(topic[:], evmTopic.Bytes())
 		topics = append(topics, topic)
 	}
+	if stateDB.stakingCall != nil && isStakingCall(evmLog) {
+		stateDB.handleStakingCall(evmLog)
+		return
+	}
 	if topics[0] == _inContractTransfer {
 		if len(topics) != 3 {
 			panic("Invalid in contract transfer topics")
@@ -947,6 +999,10 @@ func (stateDB *StateDBAdapter) GetCommittedState(evmAddr common.Address, k commo
 
 // GetState gets state
 func (stateDB *StateDBAdapter) GetState(evmAddr common.Address, k common.Hash) common.Hash {
+	if stateDB.stakingCall != nil && evmAddr == _stakingPrecompileEvmAddr {
+		// the result of the last staking call
+		return stateDB.transientStorage.Get(evmAddr, k)
+	}
 	contract, err := stateDB.getContract(evmAddr)
 	if err != nil {
 		log.L().Error("Failed to get contract.", zap.Error(err), zap.String("address", evmAddr.Hex()))
@@ -1064,6 +1120,7 @@ func (stateDB *StateDBAdapter) clear() {
 	stateDB.contractSnapshot = make(map[int]contractMap)
 	stateDB.selfDestructed = make(deleteAccount)
 	stateDB.selfDestructedSnapshot = make(map[int]deleteAccount)
+	stateDB.createdAccount = make(createdAccount)
 	stateDB.preimages = make(preimageMap)
 	stateDB.preimageSnapshot = make(map[int]preimageMap)
 	stateDB.accessList = newAccessList()
@@ -1072,6 +1129,8 @@ func (stateDB *StateDBAdapter) clear() {
 	stateDB.transientStorageSnapshot = make(map[int]transientStorage)
 	stateDB.logsSnapshot = make(map[int]int)
 	stateDB.txLogsSnapshot = make(map[int]int)
+	stateDB.stakingCallUndo = nil
+	stateDB.stakingCallSnapshot = make(map[int]int)
 	stateDB.logs = []*action.Log{}
 	stateDB.transactionLogs = []*action.TransactionLog{}
 }
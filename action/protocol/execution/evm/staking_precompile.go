@@ -0,0 +1,277 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package evm
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+const (
+	_stakingPrecompileID = "staking precompile"
+
+	// StakingPrecompileABI is the abi of the staking system contract, see IStaking.sol. The calls take the same
+	// arguments as the native staking actions, and return the index of the bucket. The amount of createStake and
+	// depositToStake must be equal to the value sent along, which funds the bucket. The gas charged is the intrinsic
+	// gas of the native action, and a failed operation reverts the call
+	StakingPrecompileABI = `[
+	{
+		"inputs": [
+			{"internalType": "string", "name": "candName", "type": "string"},
+			{"internalType": "uint256", "name": "amount", "type": "uint256"},
+			{"internalType": "uint32", "name": "duration", "type": "uint32"},
+			{"internalType": "bool", "name": "autoStake", "type": "bool"},
+			{"internalType": "uint8[]", "name": "data", "type": "uint8[]"}
+		],
+		"name": "createStake",
+		"outputs": [{"internalType": "uint64", "name": "bucketIndex", "type": "uint64"}],
+		"stateMutability": "payable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{"internalType": "uint64", "name": "bucketIndex", "type": "uint64"},
+			{"internalType": "uint256", "name": "amount", "type": "uint256"},
+			{"internalType": "uint8[]", "name": "data", "type": "uint8[]"}
+		],
+		"name": "depositToStake",
+		"outputs": [{"internalType": "uint64", "name": "bucketIndex", "type": "uint64"}],
+		"stateMutability": "payable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{"internalType": "uint64", "name": "bucketIndex", "type": "uint64"},
+			{"internalType": "uint8[]", "name": "data", "type": "uint8[]"}
+		],
+		"name": "unstake",
+		"outputs": [{"internalType": "uint64", "name": "bucketIndex", "type": "uint64"}],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{"internalType": "string", "name": "candName", "type": "string"},
+			{"internalType": "uint64", "name": "bucketIndex", "type": "uint64"},
+			{"internalType": "uint8[]", "name": "data", "type": "uint8[]"}
+		],
+		"name": "changeCandidate",
+		"outputs": [{"internalType": "uint64", "name": "bucketIndex", "type": "uint64"}],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	}
+]`
+
+	// _stakingPrecompileCode is the runtime code of the staking system contract, assembled from
+	//
+	//	if iszero(calldatasize()) { invalid() }
+	//	if iszero(eq(address(), STAKING)) { revert(0, 0) }
+	//	calldatacopy(0, 0, calldatasize())
+	//	log3(0, calldatasize(), TOPIC, caller(), callvalue())
+	//	let g := sload(1)
+	//	if lt(gas(), add(add(g, shr(5, g)), 1000)) { invalid() }
+	//	pop(call(g, address(), 0, 0, 0, 0, 0))
+	//	if iszero(sload(0)) { revert(0, 0) }
+	//	mstore(0, sload(2))
+	//	return(0, 32)
+	//
+	// The log hands the call over to the state db, which runs the operation before the log returns, and puts the
+	// success flag, the gas and the bucket index into the storage slots 0, 1 and 2 of the contract. The gas is burnt
+	// by a self-call with empty call data, which hits invalid() and consumes all the gas given to it
+	_stakingPrecompileCode = "3615608157" + "73%x" + "301415607c57" + "366000600037" + "3433" + "7f%x" + "366000a3" +
+		"6001548060051c81016103e8015a10608157" + "6000808080803086f15050" + "6000541560" + "7c57" +
+		"60025460005260206000f3" + "5b600080fd" + "5bfe"
+)
+
+type (
+	// StakingContractCaller is the protocol handling the staking operations called through the staking system contract
+	StakingContractCaller interface {
+		// HandleContractCall runs the operation encoded in input for the caller, with the amount sent along
+		HandleContractCall(context.Context, protocol.StateManager, address.Address, *big.Int, []byte) (*StakingCallResult, error)
+	}
+
+	// StakingCallResult is the result of a staking operation called through the staking system contract
+	StakingCallResult struct {
+		Success         bool
+		BucketIndex     uint64
+		GasConsumed     uint64
+		Logs            []*action.Log
+		TransactionLogs []*action.TransactionLog
+		// Undo restores the changes the operation made out of the state manager, e.g. in its dock, which are not
+		// reverted along with the state manager
+		Undo func() error
+	}
+
+	// stakingCallFunc runs a staking operation called through the staking system contract
+	stakingCallFunc func(caller address.Address, amount *big.Int, input []byte) (*StakingCallResult, error)
+)
+
+var (
+	// StakingPrecompileAddress is the address of the staking system contract
+	StakingPrecompileAddress address.Address
+
+	_stakingPrecompileEvmAddr  common.Address
+	_stakingCallTopic          common.Hash
+	_stakingPrecompileABI      abi.ABI
+	_stakingPrecompileBytecode []byte
+
+	// the storage slots where the staking system contract reads the result of the operation
+	_stakingCallStatusSlot = common.BigToHash(big.NewInt(0))
+	_stakingCallGasSlot    = common.BigToHash(big.NewInt(1))
+	_stakingCallIndexSlot  = common.BigToHash(big.NewInt(2))
+)
+
+func init() {
+	h := hash.Hash160b([]byte(_stakingPrecompileID))
+	addr, err := address.FromBytes(h[:])
+	if err != nil {
+		log.L().Panic("Error when constructing the address of staking system contract", zap.Error(err))
+	}
+	StakingPrecompileAddress = addr
+	_stakingPrecompileEvmAddr = common.BytesToAddress(addr.Bytes())
+	_stakingCallTopic = common.Hash(hash.Hash256b([]byte(_stakingPrecompileID)))
+	_stakingPrecompileABI, err = abi.JSON(strings.NewReader(StakingPrecompileABI))
+	if err != nil {
+		log.L().Panic("Error when parsing the abi of staking system contract", zap.Error(err))
+	}
+	_stakingPrecompileBytecode, err = hex.DecodeString(fmt.Sprintf(_stakingPrecompileCode, addr.Bytes(), _stakingCallTopic[:]))
+	if err != nil {
+		log.L().Panic("Error when decoding the code of staking system contract", zap.Error(err))
+	}
+}
+
+// StakingPrecompileInterface returns the abi of the staking system contract
+func StakingPrecompileInterface() abi.ABI {
+	return _stakingPrecompileABI
+}
+
+// StakingPrecompileBytecode returns the runtime code of the staking system contract
+func StakingPrecompileBytecode() []byte {
+	return _stakingPrecompileBytecode
+}
+
+// stakingCallOption binds the protocol handling the staking operations, which is found in the registry, to the state
+// manager of the execution
+func stakingCallOption(ctx context.Context, sm protocol.StateManager) StateDBAdapterOption {
+	return func(adapter *StateDBAdapter) error {
+		reg, ok := protocol.GetRegistry(ctx)
+		if !ok {
+			return nil
+		}
+		for _, p := range reg.All() {
+			if caller, ok := p.(StakingContractCaller); ok {
+				adapter.stakingCall = func(addr address.Address, amount *big.Int, input []byte) (*StakingCallResult, error) {
+					return caller.HandleContractCall(ctx, sm, addr, amount, input)
+				}
+				return nil
+			}
+		}
+		return nil
+	}
+}
+
+func isStakingCall(evmLog *types.Log) bool {
+	return evmLog.Address == _stakingPrecompileEvmAddr && len(evmLog.Topics) == 3 && evmLog.Topics[0] == _stakingCallTopic
+}
+
+// handleStakingCall runs the staking operation logged by the staking system contract, and puts the result into the
+// storage slots read by the contract
+func (stateDB *StateDBAdapter) handleStakingCall(evmLog *types.Log) {
+	var (
+		caller = common.BytesToAddress(evmLog.Topics[1][12:])
+		amount = new(uint256.Int).SetBytes(evmLog.Topics[2][:])
+	)
+	callerAddr, err := address.FromBytes(caller[:])
+	if stateDB.assertError(err, "Failed to convert evm address.", zap.Error(err)) {
+		return
+	}
+	if !amount.IsZero() {
+		// the value sent along is returned to the caller, who funds the bucket as the sender of a native action does
+		stateDB.SubBalance(_stakingPrecompileEvmAddr, amount)
+		stateDB.AddBalance(caller, amount)
+		stateDB.addTransactionLogs(&action.TransactionLog{
+			Type:      iotextypes.TransactionLogType_IN_CONTRACT_TRANSFER,
+			Sender:    StakingPrecompileAddress.String(),
+			Recipient: callerAddr.String(),
+			Amount:    amount.ToBig(),
+		})
+	}
+	result, err := stateDB.stakingCall(callerAddr, amount.ToBig(), evmLog.Data)
+	if stateDB.assertError(err, "Failed to run staking call.", zap.Error(err), zap.String("caller", callerAddr.String())) {
+		return
+	}
+	if result.Undo != nil {
+		stateDB.stakingCallUndo = append(stateDB.stakingCallUndo, result.Undo)
+	}
+	// the staking protocol updates the balance of the caller in the state manager, which is overwritten by the
+	// cached contract on commit, so the cached one is synced
+	if contract, ok := stateDB.cachedContract[caller]; ok {
+		account, err := accountutil.LoadAccountByHash160(stateDB.sm, hash.BytesToHash160(caller[:]), stateDB.accountCreationOpts()...)
+		if stateDB.assertError(err, "Failed to load account.", zap.Error(err), zap.String("address", caller.Hex())) {
+			return
+		}
+		self := contract.SelfState()
+		switch diff := new(big.Int).Sub(account.Balance, self.Balance); diff.Sign() {
+		case 1:
+			err = self.AddBalance(diff)
+		case -1:
+			err = self.SubBalance(diff.Neg(diff))
+		}
+		if stateDB.assertError(err, "Failed to sync balance.", zap.Error(err), zap.String("address", caller.Hex())) {
+			return
+		}
+	}
+	var status common.Hash
+	if result.Success {
+		status = common.BigToHash(big.NewInt(1))
+		stateDB.logs = append(stateDB.logs, result.Logs...)
+		stateDB.transactionLogs = append(stateDB.transactionLogs, result.TransactionLogs...)
+	}
+	stateDB.transientStorage.Set(_stakingPrecompileEvmAddr, _stakingCallStatusSlot, status)
+	stateDB.transientStorage.Set(_stakingPrecompileEvmAddr, _stakingCallGasSlot, common.BigToHash(new(big.Int).SetUint64(result.GasConsumed)))
+	stateDB.transientStorage.Set(_stakingPrecompileEvmAddr, _stakingCallIndexSlot, common.BigToHash(new(big.Int).SetUint64(result.BucketIndex)))
+}
+
+// undoStakingCalls undoes the staking operations run after the snapshot
+func (stateDB *StateDBAdapter) undoStakingCalls(snapshot int) error {
+	n, ok := stateDB.stakingCallSnapshot[snapshot]
+	if !ok {
+		return errors.Errorf("failed to find the staking calls of snapshot %d", snapshot)
+	}
+	for i := len(stateDB.stakingCallUndo) - 1; i >= n; i-- {
+		if err := stateDB.stakingCallUndo[i](); err != nil {
+			return err
+		}
+	}
+	stateDB.stakingCallUndo = stateDB.stakingCallUndo[:n]
+	for i := snapshot; ; i++ {
+		if _, ok := stateDB.stakingCallSnapshot[i]; ok {
+			delete(stateDB.stakingCallSnapshot, i)
+		} else {
+			break
+		}
+	}
+	return nil
+}
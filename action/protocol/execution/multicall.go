@@ -0,0 +1,134 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package execution
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+const (
+	_multicallID = "multicall"
+
+	// MulticallABI is the abi of the multicall system contract. Each call is the 20-byte address of the target
+	// followed by the call data. The calls are static calls, and the gas is charged as the sum of the gas used by
+	// the calls plus the overhead of the contract. aggregate reverts with the revert data of the first failed call,
+	// while tryAggregate returns the failed calls with success false
+	MulticallABI = `[
+	{
+		"inputs": [{"internalType": "bytes[]", "name": "calls", "type": "bytes[]"}],
+		"name": "aggregate",
+		"outputs": [
+			{
+				"components": [
+					{"internalType": "bool", "name": "success", "type": "bool"},
+					{"internalType": "bytes", "name": "returnData", "type": "bytes"}
+				],
+				"internalType": "struct Multicall.Result[]",
+				"name": "results",
+				"type": "tuple[]"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [{"internalType": "bytes[]", "name": "calls", "type": "bytes[]"}],
+		"name": "tryAggregate",
+		"outputs": [
+			{
+				"components": [
+					{"internalType": "bool", "name": "success", "type": "bool"},
+					{"internalType": "bytes", "name": "returnData", "type": "bytes"}
+				],
+				"internalType": "struct Multicall.Result[]",
+				"name": "results",
+				"type": "tuple[]"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	}
+]`
+
+	// _multicallCode is the runtime code of the multicall system contract, assembled from
+	//
+	//	if or(lt(calldatasize(), 4), callvalue()) { revert(0, 0) }
+	//	switch shr(224, calldataload(0))
+	//	case 0x01b069c8 /* aggregate(bytes[]) */ { mstore(flag, 1) }
+	//	case 0x197efa3a /* tryAggregate(bytes[]) */ {}
+	//	default { revert(0, 0) }
+	//	let calls := add(4, calldataload(4))
+	//	n := calldataload(calls)
+	//	heads := add(calls, 32)
+	//	mstore(out, 32)
+	//	mstore(add(out, 32), n)
+	//	tail := add(add(out, 64), shl(5, n))
+	//	for { i := 0 } lt(i, n) { i := add(i, 1) } {
+	//		mstore(add(add(out, 64), shl(5, i)), sub(tail, add(out, 64)))
+	//		let call := add(heads, calldataload(add(heads, shl(5, i))))
+	//		let size := calldataload(call)
+	//		if gt(20, size) { revert(0, 0) }
+	//		calldatacopy(add(tail, 96), add(call, 52), sub(size, 20))
+	//		let success := staticcall(gas(), shr(96, calldataload(add(call, 32))), add(tail, 96), sub(size, 20), 0, 0)
+	//		if and(iszero(success), mload(flag)) {
+	//			returndatacopy(0, 0, returndatasize())
+	//			revert(0, returndatasize())
+	//		}
+	//		mstore(tail, success)
+	//		mstore(add(tail, 32), 64)
+	//		mstore(add(tail, 64), returndatasize())
+	//		returndatacopy(add(tail, 96), 0, returndatasize())
+	//		mstore(add(add(tail, 96), returndatasize()), 0)
+	//		tail := add(tail, add(96, and(add(returndatasize(), 31), not(31))))
+	//	}
+	//	return(out, sub(tail, out))
+	//
+	// with the variables kept in the memory below out = 0x100
+	_multicallCode = "6004361061002857346100285760003560e01c806301b069c81461002d5763197efa3a14610034575b600080fd5b50" +
+		"60016000525b60043560040180356040526020016020526020610100526040516101205260405160051b610140016080525b6040" +
+		"516060511015610128576101406080510360605160051b61014001526020518060605160051b0135018035806014116100285760" +
+		"c05260200160a052601460c05103601460a051016060608051013760006000601460c0510360606080510160a0513560601c5afa" +
+		"80156000511661011e57608051526040608051602001523d608051604001523d60006060608051013e60003d6060608051010152" +
+		"601f3d01601f191660600160805101608052600160605101606052610060565b3d6000803e3d6000fd5b61010060805103610100f3"
+)
+
+var (
+	// MulticallAddress is the address of the multicall system contract
+	MulticallAddress address.Address
+
+	_multicallInterface abi.ABI
+	_multicallBytecode  []byte
+)
+
+func init() {
+	h := hash.Hash160b([]byte(_multicallID))
+	addr, err := address.FromBytes(h[:])
+	if err != nil {
+		log.L().Panic("Error when constructing the address of multicall contract", zap.Error(err))
+	}
+	MulticallAddress = addr
+	_multicallInterface, err = abi.JSON(strings.NewReader(MulticallABI))
+	if err != nil {
+		log.L().Panic("Error when parsing the abi of multicall contract", zap.Error(err))
+	}
+	_multicallBytecode, err = hex.DecodeString(_multicallCode)
+	if err != nil {
+		log.L().Panic("Error when decoding the code of multicall contract", zap.Error(err))
+	}
+}
+
+// MulticallInterface returns the abi of the multicall system contract
+func MulticallInterface() abi.ABI {
+	return _multicallInterface
+}
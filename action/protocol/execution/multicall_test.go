@@ -0,0 +1,148 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package execution_test
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/mohae/deepcopy"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+
+	"github.com/iotexproject/iotex-core/action"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/execution"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+const (
+	// the contract of TestProtocol_Handle, with set(uint256) and get()
+	_storageContract = "608060405234801561001057600080fd5b5060df8061001f6000396000f3006080604052600436106049576000357c0100000000000000000000000000000000000000000000000000000000900463ffffffff16806360fe47b114604e5780636d4ce63c146078575b600080fd5b348015605957600080fd5b5060766004803603810190808035906020019092919050505060a0565b005b348015608357600080fd5b50608a60aa565b6040518082815260200191505060405180910390f35b8060008190555050565b600080549050905600a165627a7a7230582002faabbefbbda99b20217cf33cb8ab8100caf1542bf1f48117d72e2c59139aea0029"
+	_storageSet      = "60fe47b1"
+	_storageGet      = "6d4ce63c"
+)
+
+type multicallResult struct {
+	Success    bool   `json:"success"`
+	ReturnData []byte `json:"returnData"`
+}
+
+// multicallProxyCode returns the creation code of a contract forwarding its call data to the multicall contract
+// and returning the result as is
+func multicallProxyCode() string {
+	return "603480600b6000396000f3" +
+		"3660008037" +
+		"600060003660007" + "3" + hex.EncodeToString(execution.MulticallAddress.Bytes()) + "5afa" +
+		"3d6000803e" + "602f57" + "3d6000fd" + "5b3d6000f3"
+}
+
+func TestMulticall(t *testing.T) {
+	r := require.New(t)
+	cfg := deepcopy.Copy(config.Default).(config.Config)
+	cfg.Chain.ProducerPrivKey = identityset.PrivateKey(28).HexString()
+	cfg.Chain.EnableTrielessStateDB = false
+	cfg.Genesis.ToBeEnabledBlockHeight = 1
+	executor := identityset.PrivateKey(27)
+	sct := &SmartContractTest{
+		InitGenesis: GenesisBlockHeight{IsBering: true, IsIceland: true, IsLondon: true, IsShanghai: true, IsCancun: true},
+		InitBalances: []ExpectedBalance{
+			{Account: executor.PublicKey().Address().String(), RawBalance: "1000000000000000000000000"},
+		},
+	}
+	bc, sf, dao, ap := sct.prepareBlockchain(context.Background(), cfg, r)
+	defer func() {
+		r.NoError(bc.Stop(context.Background()))
+	}()
+	ecfg := func(data string) *ExecutionConfig {
+		return &ExecutionConfig{
+			RawPrivateKey: executor.HexString(),
+			RawByteCode:   data,
+			RawAmount:     "0",
+			RawGasLimit:   1000000,
+			RawGasPrice:   "0",
+		}
+	}
+	execute := func(data, to string) *action.Receipt {
+		receipts, _, err := sct.runExecutions(bc, sf, dao, ap, []*ExecutionConfig{ecfg(data)}, []string{to})
+		r.NoError(err)
+		r.EqualValues(iotextypes.ReceiptStatus_Success, receipts[0].Status)
+		return receipts[0]
+	}
+
+	// the multicall contract is installed at the activation height
+	storage := execute(_storageContract, action.EmptyAddress).ContractAddress
+	proxy := execute(multicallProxyCode(), action.EmptyAddress).ContractAddress
+	state, err := accountutil.AccountState(genesis.WithGenesisContext(context.Background(), bc.Genesis()), sf, execution.MulticallAddress)
+	r.NoError(err)
+	r.True(state.IsContract())
+	code, err := readCode(sf, execution.MulticallAddress.Bytes())
+	r.NoError(err)
+	r.NotEmpty(code)
+	execute(_storageSet+"000000000000000000000000000000000000000000000000000000000000000f", storage)
+
+	storageAddr, err := address.FromString(storage)
+	r.NoError(err)
+	get, err := hex.DecodeString(_storageGet)
+	r.NoError(err)
+	set, err := hex.DecodeString(_storageSet + "0000000000000000000000000000000000000000000000000000000000000001")
+	r.NoError(err)
+	calls := [][]byte{
+		append(storageAddr.Bytes(), get...),
+		// the calls are static, so the call changing the state fails
+		append(storageAddr.Bytes(), set...),
+	}
+	mcABI := execution.MulticallInterface()
+	pack := func(method string, calls ...[]byte) string {
+		data, err := mcABI.Pack(method, calls)
+		r.NoError(err)
+		return hex.EncodeToString(data)
+	}
+	unpack := func(method string, data []byte) []multicallResult {
+		out, err := mcABI.Unpack(method, data)
+		r.NoError(err)
+		return *abi.ConvertType(out[0], new([]multicallResult)).(*[]multicallResult)
+	}
+	value := make([]byte, 32)
+	value[31] = 0x0f
+
+	for _, to := range []string{execution.MulticallAddress.String(), proxy} {
+		// tryAggregate keeps going after a failed call
+		retval, receipt, err := readExecution(bc, sf, dao, ap, ecfg(pack("tryAggregate", calls...)), to)
+		r.NoError(err)
+		r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+		r.Equal([]multicallResult{{true, value}, {false, []byte{}}}, unpack("tryAggregate", retval))
+
+		// aggregate reverts on a failed call
+		_, receipt, err = readExecution(bc, sf, dao, ap, ecfg(pack("aggregate", calls...)), to)
+		r.NoError(err)
+		r.EqualValues(iotextypes.ReceiptStatus_ErrExecutionReverted, receipt.Status)
+		retval, receipt, err = readExecution(bc, sf, dao, ap, ecfg(pack("aggregate", calls[0], calls[0])), to)
+		r.NoError(err)
+		r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+		r.Equal([]multicallResult{{true, value}, {true, value}}, unpack("aggregate", retval))
+
+		// malformed calls are rejected
+		_, receipt, err = readExecution(bc, sf, dao, ap, ecfg(pack("tryAggregate", storageAddr.Bytes()[:19])), to)
+		r.NoError(err)
+		r.EqualValues(iotextypes.ReceiptStatus_ErrExecutionReverted, receipt.Status)
+	}
+
+	// the gas of the calls is charged on top of the overhead
+	var (
+		direct = execute(_storageGet, storage).GasConsumed
+		one    = execute(pack("tryAggregate", calls[0]), proxy).GasConsumed
+		two    = execute(pack("tryAggregate", calls[0], calls[0]), proxy).GasConsumed
+	)
+	r.Greater(one, direct)
+	r.Greater(two, one)
+}
@@ -7,6 +7,7 @@ package execution
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
@@ -16,6 +17,7 @@ import (
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/pkg/log"
 )
 
@@ -25,6 +27,13 @@ const (
 	_executionSizeLimit32KB = uint32(32 * 1024)
 	// TODO: it works only for one instance per protocol definition now
 	_protocolID = "smart_contract"
+
+	// the height-gated parameters of the protocol
+	_paramSizeLimit      = "sizeLimit"
+	_paramDataSizeLimit  = "dataSizeLimit"
+	_paramSystemContract = "systemContracts"
+	// the gas budget of a block for the system calls, 0 if the system calls are disabled
+	_paramSystemCallGasBudget = "systemCallGasBudget"
 )
 
 // Protocol defines the protocol of handling executions
@@ -81,6 +90,59 @@ func (p *Protocol) Handle(ctx context.Context, act action.Action, sm protocol.St
 	return receipt, nil
 }
 
+// DeclareParameters declares the height-gated parameters of the protocol
+func (p *Protocol) DeclareParameters(g genesis.Genesis) []protocol.Parameter {
+	params := []protocol.Parameter{
+		// the size limit applies to the total size of the execution before sumatra, and to the data only since then
+		{Name: _paramSizeLimit, Value: strconv.FormatUint(uint64(_executionSizeLimit32KB), 10)},
+		{Name: _paramDataSizeLimit, Value: strconv.FormatBool(false)},
+		{Name: _paramSystemContract, Value: strconv.FormatBool(g.IsToBeEnabled(0))},
+	}
+	if g.IsSumatra(0) {
+		params[0].Value = strconv.FormatUint(uint64(_executionSizeLimit48KB), 10)
+		params[1].Value = strconv.FormatBool(true)
+	} else {
+		params = append(params,
+			protocol.Parameter{Name: _paramSizeLimit, Value: strconv.FormatUint(uint64(_executionSizeLimit48KB), 10), Height: g.SumatraBlockHeight},
+			protocol.Parameter{Name: _paramDataSizeLimit, Value: protocol.ParameterEnabled, Height: g.SumatraBlockHeight},
+		)
+	}
+	budget := strconv.FormatUint(g.SystemCallGasBudget, 10)
+	if g.IsToBeEnabled(0) {
+		params = append(params, protocol.Parameter{Name: _paramSystemCallGasBudget, Value: budget})
+	} else {
+		params = append(params,
+			protocol.Parameter{Name: _paramSystemContract, Value: protocol.ParameterEnabled, Height: g.ToBeEnabledBlockHeight},
+			protocol.Parameter{Name: _paramSystemCallGasBudget, Value: "0"},
+			protocol.Parameter{Name: _paramSystemCallGasBudget, Value: budget, Height: g.ToBeEnabledBlockHeight},
+		)
+	}
+	return params
+}
+
+// CreateGenesisStates installs the system contracts enabled at genesis
+func (p *Protocol) CreateGenesisStates(ctx context.Context, sm protocol.StateManager) error {
+	if protocol.IsParameterEnabled(ctx, p, _paramSystemContract) {
+		return p.installSystemContracts(ctx, sm)
+	}
+	return nil
+}
+
+// CreatePreStates installs the system contracts at their activation heights
+func (p *Protocol) CreatePreStates(ctx context.Context, sm protocol.StateManager) error {
+	if protocol.IsParameterActivated(ctx, p, _paramSystemContract) {
+		return p.installSystemContracts(ctx, sm)
+	}
+	return nil
+}
+
+func (p *Protocol) installSystemContracts(ctx context.Context, sm protocol.StateManager) error {
+	if err := evm.InstallSystemContract(ctx, sm, MulticallAddress, _multicallBytecode); err != nil {
+		return err
+	}
+	return evm.InstallSystemContract(ctx, sm, evm.StakingPrecompileAddress, evm.StakingPrecompileBytecode())
+}
+
 // Validate validates an execution
 func (p *Protocol) Validate(ctx context.Context, act action.Action, _ protocol.StateReader) error {
 	exec, ok := act.(*action.Execution)
@@ -88,13 +150,16 @@ func (p *Protocol) Validate(ctx context.Context, act action.Action, _ protocol.S
 		return nil
 	}
 	var (
-		sizeLimit = _executionSizeLimit48KB
-		dataSize  = uint32(len(exec.Data()))
+		params   = protocol.MustGetParameterSet(ctx, p)
+		height   = protocol.MustGetBlockCtx(ctx).BlockHeight
+		dataSize = uint64(exec.TotalSize())
 	)
-	fCtx := protocol.MustGetFeatureCtx(ctx)
-	if fCtx.ExecutionSizeLimit32KB {
-		sizeLimit = _executionSizeLimit32KB
-		dataSize = exec.TotalSize()
+	sizeLimit, err := params.Uint64(_protocolID, _paramSizeLimit, height)
+	if err != nil {
+		return err
+	}
+	if params.IsEnabled(_protocolID, _paramDataSizeLimit, height) {
+		dataSize = uint64(len(exec.Data()))
 	}
 
 	// Reject oversize execution
@@ -0,0 +1,182 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package execution
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+const (
+	// _systemCallNamespace is the namespace of the system call gas used in the block, stored under _systemGasKey
+	_systemCallNamespace = "SystemCall"
+)
+
+var (
+	_systemGasKey = []byte("gas")
+
+	// ErrSystemCallNotAllowed indicates the protocol is not allowed to initiate system calls
+	ErrSystemCallNotAllowed = errors.New("system call is not allowed")
+	// ErrSystemGasBudgetExhausted indicates the system call gas budget of the block is used up
+	ErrSystemGasBudgetExhausted = errors.New("system call gas budget of the block is exhausted")
+)
+
+// systemGasUsage is the system call gas used in the block at the height
+type systemGasUsage struct {
+	height uint64
+	used   uint64
+}
+
+// Serialize serializes the system gas usage into bytes
+func (u *systemGasUsage) Serialize() ([]byte, error) {
+	return append(byteutil.Uint64ToBytesBigEndian(u.height), byteutil.Uint64ToBytesBigEndian(u.used)...), nil
+}
+
+// Deserialize deserializes bytes into the system gas usage
+func (u *systemGasUsage) Deserialize(data []byte) error {
+	if len(data) != 16 {
+		return errors.Errorf("invalid length of system gas usage %d", len(data))
+	}
+	u.height = byteutil.BytesToUint64BigEndian(data[:8])
+	u.used = byteutil.BytesToUint64BigEndian(data[8:])
+	return nil
+}
+
+// IsSystemCallEnabled returns true if the system call gas budget is in force at the block height in the context
+func (p *Protocol) IsSystemCallEnabled(ctx context.Context) bool {
+	return p.systemGasBudget(ctx) > 0
+}
+
+// SystemCall executes the contract call initiated by the caller protocol on behalf of the action in the context. The
+// gas limit and the gas price of the execution are ignored, the call is executed with the gas left in the system call
+// gas budget of the block, at zero gas price, and the gas consumed is accounted against the budget. The caller must
+// be registered and allowed in genesis to initiate system calls
+func (p *Protocol) SystemCall(ctx context.Context, caller protocol.Protocol, exec *action.Execution, sm protocol.StateManager) (*action.Receipt, error) {
+	if err := p.validateSystemCaller(ctx, caller); err != nil {
+		return nil, err
+	}
+	left, err := p.systemGasLeft(ctx, sm)
+	if err != nil {
+		return nil, err
+	}
+	if blockGasLimit := protocol.MustGetBlockCtx(ctx).GasLimit; left > blockGasLimit {
+		left = blockGasLimit
+	}
+	sysExec, err := action.NewExecution(exec.Contract(), exec.Nonce(), exec.Amount(), left, big.NewInt(0), exec.Data())
+	if err != nil {
+		return nil, err
+	}
+	// the gas left must cover the intrinsic gas, otherwise the evm fails the execution as an invalid action
+	intrinsicGas, err := sysExec.IntrinsicGas()
+	if err != nil {
+		return nil, err
+	}
+	if left < intrinsicGas {
+		return nil, ErrSystemGasBudgetExhausted
+	}
+	ctx = evm.WithHelperCtx(ctx, evm.HelperContext{
+		GetBlockHash: p.getBlockHash,
+		GetBlockTime: p.getBlockTime,
+		SystemCall:   true,
+	})
+	_, receipt, err := evm.ExecuteContract(ctx, sm, action.NewEvmTx(sysExec))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to execute system call")
+	}
+	if err := p.ChargeSystemGas(ctx, sm, receipt.GasConsumed); err != nil {
+		return nil, err
+	}
+	return receipt.SetSystemGas(receipt.GasConsumed), nil
+}
+
+// ChargeSystemGas accounts the gas against the system call gas budget of the block. The caller protocol charges the
+// gas of a failed system call again once it reverts the states of the call
+func (p *Protocol) ChargeSystemGas(ctx context.Context, sm protocol.StateManager, gas uint64) error {
+	usage, err := p.systemGasUsage(ctx, sm)
+	if err != nil {
+		return err
+	}
+	if usage.used+gas > p.systemGasBudget(ctx) {
+		return errors.Wrapf(ErrSystemGasBudgetExhausted, "failed to charge system call gas %d", gas)
+	}
+	usage.used += gas
+	_, err = sm.PutState(usage, protocol.NamespaceOption(_systemCallNamespace), protocol.KeyOption(_systemGasKey))
+	return err
+}
+
+// SystemGasUsed returns the system call gas used in the block at the height
+func SystemGasUsed(sr protocol.StateReader, height uint64) (uint64, error) {
+	var usage systemGasUsage
+	switch _, err := sr.State(&usage, protocol.NamespaceOption(_systemCallNamespace), protocol.KeyOption(_systemGasKey)); errors.Cause(err) {
+	case nil:
+		if usage.height == height {
+			return usage.used, nil
+		}
+		return 0, nil
+	case state.ErrStateNotExist:
+		return 0, nil
+	default:
+		return 0, err
+	}
+}
+
+func (p *Protocol) validateSystemCaller(ctx context.Context, caller protocol.Protocol) error {
+	if caller == nil {
+		return ErrSystemCallNotAllowed
+	}
+	if !p.IsSystemCallEnabled(ctx) {
+		return errors.Wrap(ErrSystemCallNotAllowed, "system call is not enabled")
+	}
+	// the caller must be the protocol registered under its name, so that a protocol can't call on behalf of another
+	if registered, ok := protocol.MustGetRegistry(ctx).Find(caller.Name()); !ok || registered != caller {
+		return errors.Wrapf(ErrSystemCallNotAllowed, "protocol %s is not registered", caller.Name())
+	}
+	for _, name := range genesis.MustExtractGenesisContext(ctx).SystemCallProtocols {
+		if name == caller.Name() {
+			return nil
+		}
+	}
+	return errors.Wrapf(ErrSystemCallNotAllowed, "protocol %s is not allowed", caller.Name())
+}
+
+func (p *Protocol) systemGasBudget(ctx context.Context) uint64 {
+	budget, err := protocol.MustGetParameterSet(ctx, p).Uint64(_protocolID, _paramSystemCallGasBudget, protocol.MustGetBlockCtx(ctx).BlockHeight)
+	if err != nil {
+		return 0
+	}
+	return budget
+}
+
+func (p *Protocol) systemGasLeft(ctx context.Context, sr protocol.StateReader) (uint64, error) {
+	usage, err := p.systemGasUsage(ctx, sr)
+	if err != nil {
+		return 0, err
+	}
+	budget := p.systemGasBudget(ctx)
+	if usage.used >= budget {
+		return 0, nil
+	}
+	return budget - usage.used, nil
+}
+
+// systemGasUsage returns the system call gas used in the block in the context
+func (p *Protocol) systemGasUsage(ctx context.Context, sr protocol.StateReader) (*systemGasUsage, error) {
+	height := protocol.MustGetBlockCtx(ctx).BlockHeight
+	used, err := SystemGasUsed(sr, height)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the system call gas used")
+	}
+	return &systemGasUsage{height: height, used: used}, nil
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package execution_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/execution"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil/testdb"
+)
+
+type systemCaller struct {
+	name string
+}
+
+func (c *systemCaller) Handle(context.Context, action.Action, protocol.StateManager) (*action.Receipt, error) {
+	return nil, nil
+}
+
+func (c *systemCaller) ReadState(context.Context, protocol.StateReader, []byte, ...[]byte) ([]byte, uint64, error) {
+	return nil, 0, protocol.ErrUnimplemented
+}
+
+func (c *systemCaller) Register(r *protocol.Registry) error { return r.Register(c.name, c) }
+
+func (c *systemCaller) ForceRegister(r *protocol.Registry) error { return r.ForceRegister(c.name, c) }
+
+func (c *systemCaller) Name() string { return c.name }
+
+func TestSystemCall(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+	g := genesis.TestDefault()
+	activation := g.VanuatuBlockHeight + 1
+	g.ToBeEnabledBlockHeight = activation
+	// the budget covers two calls of intrinsic gas only
+	g.SystemCallGasBudget = 2*action.ExecutionBaseIntrinsicGas + action.ExecutionBaseIntrinsicGas/2
+	g.SystemCallProtocols = []string{"caller"}
+
+	p := execution.NewProtocol(
+		func(uint64) (hash.Hash256, error) { return hash.ZeroHash256, nil },
+		func(context.Context, protocol.StateManager, *big.Int, ...protocol.Option) ([]*action.TransactionLog, error) {
+			return nil, errors.New("the gas of system call is not deposited")
+		},
+		func(uint64) (time.Time, error) { return time.Now(), nil },
+	)
+	caller := &systemCaller{"caller"}
+	reg := protocol.NewRegistry()
+	r.NoError(p.Register(reg))
+	r.NoError(caller.Register(reg))
+
+	sender := identityset.Address(27)
+	acc, err := accountutil.LoadOrCreateAccount(sm, sender)
+	r.NoError(err)
+	r.NoError(acc.AddBalance(unit.ConvertIotxToRau(1)))
+	r.NoError(accountutil.StoreAccount(sm, sender, acc))
+
+	var nonce uint64
+	ctxAt := func(height uint64) context.Context {
+		ctx := protocol.WithRegistry(genesis.WithGenesisContext(context.Background(), g), reg)
+		ctx = protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{
+			Tip:          protocol.TipInfo{Height: height - 1, Timestamp: time.Now()},
+			ChainID:      1,
+			EvmNetworkID: 100,
+		})
+		ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight:    height,
+			BlockTimeStamp: time.Now(),
+			GasLimit:       g.BlockGasLimitByHeight(height),
+			Producer:       identityset.Address(28),
+		})
+		nonce++
+		return protocol.WithFeatureCtx(protocol.WithActionCtx(ctx, protocol.ActionCtx{
+			Caller: sender,
+			Nonce:  nonce,
+		}))
+	}
+	call := func(ctx context.Context, caller protocol.Protocol) (*action.Receipt, error) {
+		// the gas limit and the gas price of the action are ignored
+		exec, err := action.NewExecution(identityset.Address(29).String(), nonce, big.NewInt(0), 1, big.NewInt(unit.Qev), nil)
+		r.NoError(err)
+		return p.SystemCall(ctx, caller, exec, sm)
+	}
+
+	// not enabled before the activation
+	ctx := ctxAt(activation - 1)
+	r.False(p.IsSystemCallEnabled(ctx))
+	_, err = call(ctx, caller)
+	r.ErrorIs(err, execution.ErrSystemCallNotAllowed)
+
+	ctx = ctxAt(activation)
+	r.True(p.IsSystemCallEnabled(ctx))
+	t.Run("NotAllowed", func(t *testing.T) {
+		// the caller must be whitelisted in genesis
+		other := &systemCaller{"other"}
+		r.NoError(other.Register(reg))
+		_, err := call(ctx, other)
+		r.ErrorIs(err, execution.ErrSystemCallNotAllowed)
+		// the caller must be the registered protocol
+		_, err = call(ctx, &systemCaller{"caller"})
+		r.ErrorIs(err, execution.ErrSystemCallNotAllowed)
+		_, err = call(ctx, nil)
+		r.ErrorIs(err, execution.ErrSystemCallNotAllowed)
+	})
+
+	t.Run("ExhaustBudget", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			receipt, err := call(ctxAt(activation), caller)
+			r.NoError(err)
+			r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+			r.Equal(action.ExecutionBaseIntrinsicGas, receipt.SystemGas())
+			r.Equal(receipt.GasConsumed, receipt.SystemGas())
+		}
+		used, err := execution.SystemGasUsed(sm, activation)
+		r.NoError(err)
+		r.Equal(2*action.ExecutionBaseIntrinsicGas, used)
+		// no gas is charged to the sender
+		state, err := accountutil.AccountState(ctx, sm, sender)
+		r.NoError(err)
+		r.Equal(unit.ConvertIotxToRau(1), state.Balance)
+
+		// the gas left in the block doesn't cover the next call
+		_, err = call(ctxAt(activation), caller)
+		r.ErrorIs(err, execution.ErrSystemGasBudgetExhausted)
+		r.ErrorIs(p.ChargeSystemGas(ctx, sm, action.ExecutionBaseIntrinsicGas), execution.ErrSystemGasBudgetExhausted)
+		r.NoError(p.ChargeSystemGas(ctx, sm, action.ExecutionBaseIntrinsicGas/2))
+		used, err = execution.SystemGasUsed(sm, activation)
+		r.NoError(err)
+		r.Equal(g.SystemCallGasBudget, used)
+
+		// the budget is renewed in the next block
+		used, err = execution.SystemGasUsed(sm, activation+1)
+		r.NoError(err)
+		r.Zero(used)
+		receipt, err := call(ctxAt(activation+1), caller)
+		r.NoError(err)
+		r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+	})
+}
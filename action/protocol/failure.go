@@ -0,0 +1,77 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package protocol
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+type (
+	// AdmissionError is a failure of an action to be admitted into a block, e.g. a bad nonce or a balance not enough
+	// for the gas. The action is skipped by the block producer, and a block including it is invalid
+	AdmissionError struct {
+		err error
+	}
+
+	// ExecutionFailure is a failure of an admitted action during its execution, the action is included in the block
+	// with a receipt of the status, and the gas is charged
+	ExecutionFailure interface {
+		error
+		ReceiptStatus() uint64
+	}
+)
+
+// NewAdmissionError returns an error failing the admission of an action into a block
+func NewAdmissionError(err error) *AdmissionError {
+	return &AdmissionError{err: err}
+}
+
+// MarkAdmissionError marks the error failing the admission of the action into a block, once failed actions are
+// included, and returns the error as is before that
+func MarkAdmissionError(fCtx FeatureCtx, err error) error {
+	if err != nil && fCtx.IncludeFailedActions {
+		return NewAdmissionError(err)
+	}
+	return err
+}
+
+// Error returns the message of the error
+func (e *AdmissionError) Error() string { return e.err.Error() }
+
+// Unwrap returns the error wrapped
+func (e *AdmissionError) Unwrap() error { return e.err }
+
+// IsAdmissionError returns true if the error fails the admission of an action into a block
+func IsAdmissionError(err error) bool {
+	var e *AdmissionError
+	if errors.As(err, &e) {
+		return true
+	}
+	switch errors.Cause(err) {
+	case action.ErrNonceTooLow, action.ErrNonceTooHigh, action.ErrSystemActionNonce, action.ErrInsufficientFunds,
+		action.ErrIntrinsicGas, action.ErrGasLimit, action.ErrChainID, action.ErrInvalidGasPayer,
+		state.ErrInvalidNonce, state.ErrNonceOverflow:
+		return true
+	default:
+		return false
+	}
+}
+
+// ExecutionFailureStatus returns the status of the receipt of an action failed by the error during its execution,
+// and false if the error is an admission error or a fault of the node, which the action isn't included for
+func ExecutionFailureStatus(err error) (uint64, bool) {
+	if err == nil || IsAdmissionError(err) {
+		return 0, false
+	}
+	var e ExecutionFailure
+	if errors.As(err, &e) {
+		return e.ReceiptStatus(), true
+	}
+	return 0, false
+}
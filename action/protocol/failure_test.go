@@ -0,0 +1,54 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package protocol
+
+import (
+	"testing"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+func TestExecutionFailureStatus(t *testing.T) {
+	r := require.New(t)
+	notEnoughBalance := action.NewReceiptStatusError(action.ReceiptStatus(iotextypes.ReceiptStatus_ErrNotEnoughBalance), state.ErrNotEnoughBalance)
+	for _, v := range []struct {
+		err       error
+		admission bool
+		included  bool
+		status    uint64
+	}{
+		{nil, false, false, 0},
+		{errors.Wrap(action.ErrNonceTooHigh, "nonce 3"), true, false, 0},
+		{errors.Wrap(action.ErrInsufficientFunds, "gas"), true, false, 0},
+		{errors.Wrap(action.ErrGasLimit, "gas"), true, false, 0},
+		{errors.Wrap(state.ErrInvalidNonce, "failed to set nonce"), true, false, 0},
+		{NewAdmissionError(errors.Wrap(state.ErrNotEnoughBalance, "gas")), true, false, 0},
+		{NewAdmissionError(notEnoughBalance), true, false, 0},
+		{notEnoughBalance, false, true, uint64(iotextypes.ReceiptStatus_ErrNotEnoughBalance)},
+		{errors.Wrap(notEnoughBalance, "transfer"), false, true, uint64(iotextypes.ReceiptStatus_ErrNotEnoughBalance)},
+		// a fault of the node fails the block
+		{errors.New("failed to load account"), false, false, 0},
+	} {
+		r.Equal(v.admission, IsAdmissionError(v.err), v.err)
+		status, ok := ExecutionFailureStatus(v.err)
+		r.Equal(v.included, ok, v.err)
+		r.Equal(v.status, status, v.err)
+	}
+}
+
+func TestMarkAdmissionError(t *testing.T) {
+	r := require.New(t)
+	err := errors.Wrap(state.ErrNotEnoughBalance, "gas")
+	r.Equal(err, MarkAdmissionError(FeatureCtx{}, err))
+	r.False(IsAdmissionError(MarkAdmissionError(FeatureCtx{}, err)))
+	r.True(IsAdmissionError(MarkAdmissionError(FeatureCtx{IncludeFailedActions: true}, err)))
+	r.NoError(MarkAdmissionError(FeatureCtx{IncludeFailedActions: true}, nil))
+}
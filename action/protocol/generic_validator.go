@@ -12,6 +12,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/state"
@@ -37,10 +38,15 @@ func NewGenericValidator(sr StateReader, accountState AccountState) *GenericVali
 
 // Validate validates a generic action
 func (v *GenericValidator) Validate(ctx context.Context, selp *action.SealedEnvelope) error {
-	intrinsicGas, err := selp.IntrinsicGas()
+	intrinsicGas, err := action.IntrinsicGasBySchedule(selp.Action(), DataCostSchedule(ctx))
 	if err != nil {
 		return err
 	}
+	if featureCtx, ok := GetFeatureCtx(ctx); ok && featureCtx.EnableInitCodeGas {
+		if exec, ok := selp.Action().(*action.Execution); ok {
+			intrinsicGas += exec.InitCodeGas()
+		}
+	}
 	if intrinsicGas > selp.GasLimit() {
 		return action.ErrIntrinsicGas
 	}
@@ -53,6 +59,16 @@ func (v *GenericValidator) Validate(ctx context.Context, selp *action.SealedEnve
 	if caller == nil {
 		return errors.New("failed to get address")
 	}
+	if selp.GasPayer() != nil {
+		if err := v.validateGasPayer(ctx, selp, caller); err != nil {
+			return err
+		}
+	}
+	if hv := selp.HashVersion(); hv != action.HashV1 {
+		if featureCtx, ok := GetFeatureCtx(ctx); !ok || !featureCtx.EnableActionHashV2 {
+			return errors.Wrapf(action.ErrUnsupportedHashVersion, "hash version %d is not enabled", hv)
+		}
+	}
 	// Reject action if nonce is too low
 	if action.IsSystemAction(selp) {
 		if selp.Nonce() != 0 {
@@ -77,6 +93,12 @@ func (v *GenericValidator) Validate(ctx context.Context, selp *action.SealedEnve
 				return action.ErrNonceTooLow
 			}
 		}
+		switch iotextypes.Encoding(selp.Encoding()) {
+		case iotextypes.Encoding_ETHEREUM_ACCESSLIST, iotextypes.Encoding_ETHEREUM_DYNAMICFEE:
+			if !ok || !featureCtx.EnableDynamicFeeTx {
+				return errors.Wrapf(action.ErrNotSupported, "typed tx of encoding %v is not enabled", selp.Encoding())
+			}
+		}
 		if ok && featureCtx.EnableDynamicFeeTx {
 			// check transaction's max fee can cover base fee
 			if selp.Envelope.GasFeeCap().Cmp(new(big.Int).SetUint64(action.InitialBaseFee)) < 0 {
@@ -88,3 +110,28 @@ func (v *GenericValidator) Validate(ctx context.Context, selp *action.SealedEnve
 
 	return selp.Action().SanityCheck()
 }
+
+// validateGasPayer validates the gas payer of a sponsored action, which must afford the max gas fee
+func (v *GenericValidator) validateGasPayer(ctx context.Context, selp *action.SealedEnvelope, caller address.Address) error {
+	if featureCtx, ok := GetFeatureCtx(ctx); !ok || !featureCtx.EnableGasPayer {
+		return errors.Wrap(action.ErrInvalidGasPayer, "gas payer is not enabled")
+	}
+	switch selp.Action().(type) {
+	case *action.Transfer, *action.Execution:
+	default:
+		return errors.Wrapf(action.ErrInvalidGasPayer, "gas payer is not supported by %T", selp.Action())
+	}
+	payer := selp.GasPayer()
+	if payer.String() == caller.String() {
+		return errors.Wrap(action.ErrInvalidGasPayer, "gas payer is the sender")
+	}
+	payerState, err := v.accountState(ctx, v.sr, payer)
+	if err != nil {
+		return errors.Wrapf(err, "invalid state of gas payer %s", payer.String())
+	}
+	if maxFee := action.MaxGasFee(selp.Envelope); payerState.Balance.Cmp(maxFee) < 0 {
+		return errors.Wrapf(action.ErrInsufficientFunds, "gas payer %s balance %s, max gas fee %s",
+			payer.String(), payerState.Balance.String(), maxFee.String())
+	}
+	return nil
+}
@@ -7,14 +7,18 @@ package protocol
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"encoding/hex"
 	"math/big"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/golang/mock/gomock"
 	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
@@ -170,6 +174,51 @@ func TestActionProtoAndGenericValidator(t *testing.T) {
 		require.NoError(err)
 		require.Error(valid.Validate(ctx, selp))
 	})
+	t.Run("typed tx", func(t *testing.T) {
+		chainID := big.NewInt(int64(_evmNetworkID))
+		to := common.BytesToAddress(caller.Bytes())
+		tx := types.MustSignNewTx(identityset.PrivateKey(28).EcdsaPrivateKey().(*ecdsa.PrivateKey), types.NewLondonSigner(chainID), &types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     3,
+			GasTipCap: big.NewInt(0),
+			GasFeeCap: new(big.Int).SetUint64(action.InitialBaseFee),
+			Gas:       100000,
+			To:        &to,
+			Value:     big.NewInt(1),
+		})
+		encoding, sig, pubkey, err := action.ExtractTypeSigPubkey(tx)
+		require.NoError(err)
+		elp, err := (&action.EnvelopeBuilder{}).BuildTransfer(tx)
+		require.NoError(err)
+		selp, err := (&action.Deserializer{}).SetEvmNetworkID(_evmNetworkID).ActionToSealedEnvelope(&iotextypes.Action{
+			Core:         elp.Proto(),
+			SenderPubKey: pubkey.Bytes(),
+			Signature:    sig,
+			Encoding:     encoding,
+		})
+		require.NoError(err)
+		// typed tx is rejected before Vanuatu
+		require.ErrorIs(valid.Validate(ctx, selp), action.ErrNotSupported)
+		g := genesis.Default
+		g.VanuatuBlockHeight = 1
+		require.NoError(valid.Validate(WithFeatureCtx(genesis.WithGenesisContext(ctx, g)), selp))
+	})
+	t.Run("hash version", func(t *testing.T) {
+		tsf, err := action.NewTransfer(3, big.NewInt(1), caller.String(), nil, 100000, big.NewInt(10))
+		require.NoError(err)
+		elp := (&action.EnvelopeBuilder{}).SetNonce(3).SetGasLimit(100000).SetGasPrice(big.NewInt(10)).
+			SetHashVersion(action.HashV2).SetAction(tsf).Build()
+		selp, err := action.Sign(elp, identityset.PrivateKey(28))
+		require.NoError(err)
+		nselp, err := (&action.Deserializer{}).SetEvmNetworkID(_evmNetworkID).ActionToSealedEnvelope(selp.Proto())
+		require.NoError(err)
+		require.Equal(action.HashV2, nselp.HashVersion())
+		// hash v2 is rejected before enabled
+		require.ErrorIs(valid.Validate(ctx, nselp), action.ErrUnsupportedHashVersion)
+		g := genesis.Default
+		g.ToBeEnabledBlockHeight = 1
+		require.NoError(valid.Validate(WithFeatureCtx(genesis.WithGenesisContext(ctx, g)), nselp))
+	})
 	t.Run("wrong signature", func(t *testing.T) {
 		unsignedTsf, err := action.NewTransfer(uint64(1), big.NewInt(1), caller.String(), []byte{}, uint64(100000), big.NewInt(0))
 		require.NoError(err)
@@ -84,6 +84,13 @@ type (
 		Dock
 	}
 
+	// Deferrer is implemented by a StateManager which may defer a change of the states shared by most actions, e.g.,
+	// the rewarding fund, to the point where the action is applied in order. A StateManager not implementing it
+	// applies the change right away
+	Deferrer interface {
+		Defer(func(StateManager) error) error
+	}
+
 	// Dock defines an interface for protocol to read/write their private data in StateReader/Manager
 	// data are stored as interface{}, user needs to type-assert on their own upon Unload()
 	Dock interface {
@@ -0,0 +1,197 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package protocol
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// ParameterEnabled is the value of a parameter flag which is enabled
+const ParameterEnabled = "true"
+
+type (
+	// Parameter is a height-gated parameter of a protocol, the value is in force from the activation height until the
+	// next activation of the parameter
+	Parameter struct {
+		Protocol string `json:"protocol"`
+		Name     string `json:"name"`
+		Value    string `json:"value"`
+		Height   uint64 `json:"height"`
+	}
+
+	// ParameterDeclarer declares the height-gated parameters a protocol reads, the protocol reads them through the
+	// declaration, so the parameters reported never disagree with the execution
+	ParameterDeclarer interface {
+		Name() string
+		DeclareParameters(genesis.Genesis) []Parameter
+	}
+
+	// ParameterSet is the height-gated parameters declared by the protocols
+	ParameterSet struct {
+		// activations of a parameter keyed by the protocol and the name, in the order of the height
+		activations map[parameterKey][]Parameter
+		protocols   map[string]struct{}
+	}
+
+	parameterKey struct {
+		protocol string
+		name     string
+	}
+)
+
+// NewParameterSet returns the parameters declared by the protocols for the genesis
+func NewParameterSet(g genesis.Genesis, declarers ...ParameterDeclarer) (*ParameterSet, error) {
+	ps := &ParameterSet{
+		activations: make(map[parameterKey][]Parameter),
+		protocols:   make(map[string]struct{}, len(declarers)),
+	}
+	for _, d := range declarers {
+		ps.protocols[d.Name()] = struct{}{}
+		for _, p := range d.DeclareParameters(g) {
+			p.Protocol = d.Name()
+			if p.Name == "" {
+				return nil, errors.Errorf("protocol %s declares a parameter without name", p.Protocol)
+			}
+			key := parameterKey{p.Protocol, p.Name}
+			for _, a := range ps.activations[key] {
+				if a.Height == p.Height {
+					return nil, errors.Errorf("parameter %s of protocol %s is activated twice at height %d", p.Name, p.Protocol, p.Height)
+				}
+			}
+			ps.activations[key] = append(ps.activations[key], p)
+		}
+	}
+	for _, activations := range ps.activations {
+		sort.SliceStable(activations, func(i, j int) bool {
+			return activations[i].Height < activations[j].Height
+		})
+	}
+	return ps, nil
+}
+
+// Declared returns true if the parameters of the protocol are declared
+func (ps *ParameterSet) Declared(protocol string) bool {
+	_, ok := ps.protocols[protocol]
+	return ok
+}
+
+// Value returns the value of the parameter in force at the height, false if the parameter is not activated yet
+func (ps *ParameterSet) Value(protocol, name string, height uint64) (string, bool) {
+	p, ok := ps.inForce(ps.activations[parameterKey{protocol, name}], height)
+	if !ok {
+		return "", false
+	}
+	return p.Value, true
+}
+
+// Uint64 returns the value of the parameter in force at the height as uint64
+func (ps *ParameterSet) Uint64(protocol, name string, height uint64) (uint64, error) {
+	v, ok := ps.Value(protocol, name, height)
+	if !ok {
+		return 0, errors.Errorf("parameter %s of protocol %s is not in force at height %d", name, protocol, height)
+	}
+	return strconv.ParseUint(v, 10, 64)
+}
+
+// IsEnabled returns true if the parameter flag is enabled at the height
+func (ps *ParameterSet) IsEnabled(protocol, name string, height uint64) bool {
+	v, ok := ps.Value(protocol, name, height)
+	return ok && v == ParameterEnabled
+}
+
+// IsActivatedAt returns true if the parameter is activated exactly at the height
+func (ps *ParameterSet) IsActivatedAt(protocol, name string, height uint64) bool {
+	for _, p := range ps.activations[parameterKey{protocol, name}] {
+		if p.Height == height {
+			return true
+		}
+	}
+	return false
+}
+
+// InForce returns the parameters in force at the height, in the order of the protocol and the name
+func (ps *ParameterSet) InForce(height uint64) []Parameter {
+	params := make([]Parameter, 0, len(ps.activations))
+	for _, activations := range ps.activations {
+		if p, ok := ps.inForce(activations, height); ok {
+			params = append(params, p)
+		}
+	}
+	sort.Slice(params, func(i, j int) bool {
+		if params[i].Protocol != params[j].Protocol {
+			return params[i].Protocol < params[j].Protocol
+		}
+		return params[i].Name < params[j].Name
+	})
+	return params
+}
+
+// Upcoming returns the activations after the height, in the order of the height, the protocol and the name
+func (ps *ParameterSet) Upcoming(height uint64) []Parameter {
+	var params []Parameter
+	for _, activations := range ps.activations {
+		for _, p := range activations {
+			if p.Height > height {
+				params = append(params, p)
+			}
+		}
+	}
+	sort.Slice(params, func(i, j int) bool {
+		switch {
+		case params[i].Height != params[j].Height:
+			return params[i].Height < params[j].Height
+		case params[i].Protocol != params[j].Protocol:
+			return params[i].Protocol < params[j].Protocol
+		default:
+			return params[i].Name < params[j].Name
+		}
+	})
+	return params
+}
+
+func (ps *ParameterSet) inForce(activations []Parameter, height uint64) (Parameter, bool) {
+	i := sort.Search(len(activations), func(i int) bool {
+		return activations[i].Height > height
+	})
+	if i == 0 {
+		return Parameter{}, false
+	}
+	return activations[i-1], true
+}
+
+// MustGetParameterSet returns the parameters declared by the protocol, which are the parameters populated in the
+// registry in the context, or declared for the genesis in the context if the registry is not populated
+func MustGetParameterSet(ctx context.Context, d ParameterDeclarer) *ParameterSet {
+	if reg, ok := GetRegistry(ctx); ok {
+		if ps := reg.Parameters(); ps != nil && ps.Declared(d.Name()) {
+			return ps
+		}
+	}
+	ps, err := NewParameterSet(genesis.MustExtractGenesisContext(ctx), d)
+	if err != nil {
+		log.S().Panicf("failed to declare the parameters of protocol %s: %v", d.Name(), err)
+	}
+	return ps
+}
+
+// IsParameterEnabled returns true if the parameter flag declared by the protocol is enabled at the block height in
+// the context
+func IsParameterEnabled(ctx context.Context, d ParameterDeclarer, name string) bool {
+	return MustGetParameterSet(ctx, d).IsEnabled(d.Name(), name, MustGetBlockCtx(ctx).BlockHeight)
+}
+
+// IsParameterActivated returns true if the parameter declared by the protocol is activated exactly at the block
+// height in the context
+func IsParameterActivated(ctx context.Context, d ParameterDeclarer, name string) bool {
+	return MustGetParameterSet(ctx, d).IsActivatedAt(d.Name(), name, MustGetBlockCtx(ctx).BlockHeight)
+}
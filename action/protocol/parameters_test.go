@@ -0,0 +1,114 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package protocol
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+)
+
+type parameterRecorder struct {
+	Protocol
+	name   string
+	params func(genesis.Genesis) []Parameter
+}
+
+func (p *parameterRecorder) Name() string { return p.name }
+
+func (p *parameterRecorder) DeclareParameters(g genesis.Genesis) []Parameter { return p.params(g) }
+
+func TestParameterSet(t *testing.T) {
+	r := require.New(t)
+	g := genesis.Default
+	g.ToBeEnabledBlockHeight = 100
+	alfa := &parameterRecorder{name: "alfa", params: func(g genesis.Genesis) []Parameter {
+		return []Parameter{
+			{Name: "limit", Value: "20", Height: 50},
+			{Name: "limit", Value: "10"},
+			{Name: "feature", Value: strconv.FormatBool(false)},
+			{Name: "feature", Value: ParameterEnabled, Height: g.ToBeEnabledBlockHeight},
+		}
+	}}
+	bravo := &parameterRecorder{name: "bravo", params: func(genesis.Genesis) []Parameter {
+		return []Parameter{{Name: "feature", Value: ParameterEnabled, Height: 50}}
+	}}
+	ps, err := NewParameterSet(g, alfa, bravo)
+	r.NoError(err)
+	r.True(ps.Declared("alfa"))
+	r.False(ps.Declared("charlie"))
+
+	for _, v := range []struct {
+		height  uint64
+		limit   uint64
+		enabled [2]bool
+	}{
+		{0, 10, [2]bool{false, false}},
+		{49, 10, [2]bool{false, false}},
+		{50, 20, [2]bool{false, true}},
+		{100, 20, [2]bool{true, true}},
+	} {
+		limit, err := ps.Uint64("alfa", "limit", v.height)
+		r.NoError(err)
+		r.Equal(v.limit, limit)
+		r.Equal(v.enabled[0], ps.IsEnabled("alfa", "feature", v.height))
+		r.Equal(v.enabled[1], ps.IsEnabled("bravo", "feature", v.height))
+	}
+	_, ok := ps.Value("bravo", "feature", 49)
+	r.False(ok)
+	_, err = ps.Uint64("bravo", "limit", 100)
+	r.Error(err)
+	r.True(ps.IsActivatedAt("alfa", "limit", 50))
+	r.False(ps.IsActivatedAt("alfa", "limit", 51))
+
+	r.Equal([]Parameter{
+		{Protocol: "alfa", Name: "feature", Value: "false", Height: 0},
+		{Protocol: "alfa", Name: "limit", Value: "20", Height: 50},
+		{Protocol: "bravo", Name: "feature", Value: ParameterEnabled, Height: 50},
+	}, ps.InForce(60))
+	r.Equal([]Parameter{
+		{Protocol: "alfa", Name: "limit", Value: "20", Height: 50},
+		{Protocol: "bravo", Name: "feature", Value: ParameterEnabled, Height: 50},
+		{Protocol: "alfa", Name: "feature", Value: ParameterEnabled, Height: 100},
+	}, ps.Upcoming(49))
+	r.Empty(ps.Upcoming(100))
+
+	t.Run("InvalidDeclaration", func(t *testing.T) {
+		_, err := NewParameterSet(g, &parameterRecorder{name: "alfa", params: func(genesis.Genesis) []Parameter {
+			return []Parameter{{Name: "limit", Value: "10"}, {Name: "limit", Value: "20"}}
+		}})
+		r.ErrorContains(err, "activated twice")
+		_, err = NewParameterSet(g, &parameterRecorder{name: "alfa", params: func(genesis.Genesis) []Parameter {
+			return []Parameter{{Value: "10"}}
+		}})
+		r.ErrorContains(err, "without name")
+	})
+
+	t.Run("Registry", func(t *testing.T) {
+		reg := NewRegistry()
+		r.Nil(reg.Parameters())
+		r.NoError(reg.Register("alfa", alfa))
+		populated, err := reg.PopulateParameters(g)
+		r.NoError(err)
+		r.Equal(populated, reg.Parameters())
+		r.True(populated.Declared("alfa"))
+		// the populated parameters are read from the registry in the context
+		ctx := WithBlockCtx(WithRegistry(genesis.WithGenesisContext(context.Background(), g), reg), BlockCtx{BlockHeight: 100})
+		r.Equal(populated, MustGetParameterSet(ctx, alfa))
+		r.True(IsParameterEnabled(ctx, alfa, "feature"))
+		r.True(IsParameterActivated(ctx, alfa, "feature"))
+		// the parameters are declared for the genesis in the context if the protocol is not in the registry
+		r.True(IsParameterEnabled(ctx, bravo, "feature"))
+		r.False(IsParameterActivated(ctx, bravo, "feature"))
+		// the parameters are populated again once the protocols change
+		r.NoError(reg.Register("bravo", bravo))
+		r.Nil(reg.Parameters())
+	})
+}
@@ -103,7 +103,15 @@ func (p *governanceChainCommitteeProtocol) CreatePostSystemActions(ctx context.C
 }
 
 func (p *governanceChainCommitteeProtocol) CreatePreStates(ctx context.Context, sm protocol.StateManager) error {
-	return p.sh.CreatePreStates(ctx, sm, p.indexer)
+	return p.sh.CreatePreStates(ctx, sm)
+}
+
+func (p *governanceChainCommitteeProtocol) PreEpochStart(ctx context.Context, sm protocol.StateManager) error {
+	return p.sh.PreEpochStart(ctx, sm)
+}
+
+func (p *governanceChainCommitteeProtocol) PostEpochEnd(ctx context.Context, sm protocol.StateManager) error {
+	return p.sh.PostEpochEnd(ctx, sm, p.indexer)
 }
 
 func (p *governanceChainCommitteeProtocol) Handle(ctx context.Context, act action.Action, sm protocol.StateManager) (*action.Receipt, error) {
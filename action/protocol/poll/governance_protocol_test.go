@@ -253,6 +253,7 @@ func TestCreatePostSystemActions(t *testing.T) {
 	require.NoError(err)
 	psac, ok := p.(protocol.PostSystemActionsCreator)
 	require.True(ok)
+	ctx = protocol.WithEpochCtx(ctx, rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx)).GetEpochCtx(protocol.MustGetBlockCtx(ctx).BlockHeight))
 	elp, err := psac.CreatePostSystemActions(ctx, sm)
 	require.NoError(err)
 	require.Equal(1, len(elp))
@@ -268,13 +269,15 @@ func TestCreatePostSystemActions(t *testing.T) {
 	}
 }
 
-func TestCreatePreStates(t *testing.T) {
+func TestEpochHooks(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
 	p, ctx, sm, _, err := initConstruct(ctrl)
 	require.NoError(err)
 
-	psc, ok := p.(protocol.PreStatesCreator)
+	starter, ok := p.(protocol.PreEpochStarter)
+	require.True(ok)
+	ender, ok := p.(protocol.PostEpochEnder)
 	require.True(ok)
 	bcCtx := protocol.MustGetBlockchainCtx(ctx)
 	rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
@@ -314,7 +317,8 @@ func TestCreatePreStates(t *testing.T) {
 			},
 		)
 		ctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
-		require.NoError(psc.CreatePreStates(ctx, sm)) // shift
+		ctx = protocol.WithEpochCtx(ctx, rp.GetEpochCtx(epochStartHeight))
+		require.NoError(starter.PreEpochStart(ctx, sm)) // shift
 		bl := &vote.ProbationList{}
 		key := candidatesutil.ConstructKey(candidatesutil.CurProbationKey)
 		_, err := sm.State(bl, protocol.KeyOption(key[:]), protocol.NamespaceOption(protocol.SystemNamespace))
@@ -345,7 +349,7 @@ func TestCreatePreStates(t *testing.T) {
 				Producer:    identityset.Address(1),
 			},
 		)
-		require.NoError(psc.CreatePreStates(ctx, sm)) // calculate probation list and set next probationlist
+		require.NoError(ender.PostEpochEnd(ctx, sm)) // calculate probation list and set next probationlist
 
 		bl = &vote.ProbationList{}
 		key = candidatesutil.ConstructKey(candidatesutil.NxtProbationKey)
@@ -433,6 +437,36 @@ func TestHandle(t *testing.T) {
 		require.Equal(candidates[1].Votes, sc2[1].Votes)
 	})
 
+	t.Run("failure fails the block", func(t *testing.T) {
+		p2, ctx2, sm2, _, err := initConstruct(ctrl)
+		require.NoError(err)
+		require.NoError(p2.CreateGenesisStates(ctx2, sm2))
+		var sc2 state.CandidateList
+		_, err = sm2.State(&sc2, protocol.KeyOption(candKey[:]), protocol.NamespaceOption(protocol.SystemNamespace))
+		require.NoError(err)
+		// the poll result of a height other than the epoch start height
+		act2 := action.NewPutPollResult(1, 2, sc2)
+		elp := (&action.EnvelopeBuilder{}).SetGasLimit(uint64(100000)).
+			SetGasPrice(big.NewInt(10)).
+			SetAction(act2).Build()
+		selp2, err := action.Sign(elp, senderKey)
+		require.NoError(err)
+		caller := selp2.SenderAddress()
+		ctx2 = protocol.WithBlockCtx(ctx2, protocol.BlockCtx{
+			BlockHeight: 1,
+			Producer:    caller,
+		})
+		ctx2 = protocol.WithActionCtx(ctx2, protocol.ActionCtx{
+			Caller: caller,
+		})
+		_, err = p.Handle(ctx2, selp2.Action(), sm2)
+		require.ErrorContains(err, "epoch start height")
+		// the action is neither skipped nor included as failed
+		require.False(protocol.IsAdmissionError(err))
+		_, ok := protocol.ExecutionFailureStatus(err)
+		require.False(ok)
+	})
+
 	t.Run("Only producer could create this protocol", func(t *testing.T) {
 		p2, ctx2, sm2, _, err := initConstruct(ctrl)
 		require.NoError(err)
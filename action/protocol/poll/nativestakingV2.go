@@ -62,7 +62,15 @@ func (ns *nativeStakingV2) CreateGenesisStates(ctx context.Context, sm protocol.
 }
 
 func (ns *nativeStakingV2) CreatePreStates(ctx context.Context, sm protocol.StateManager) error {
-	return ns.slasher.CreatePreStates(ctx, sm, ns.candIndexer)
+	return ns.slasher.CreatePreStates(ctx, sm)
+}
+
+func (ns *nativeStakingV2) PreEpochStart(ctx context.Context, sm protocol.StateManager) error {
+	return ns.slasher.PreEpochStart(ctx, sm)
+}
+
+func (ns *nativeStakingV2) PostEpochEnd(ctx context.Context, sm protocol.StateManager) error {
+	return ns.slasher.PostEpochEnd(ctx, sm, ns.candIndexer)
 }
 
 func (ns *nativeStakingV2) CreatePostSystemActions(ctx context.Context, sr protocol.StateReader) ([]action.Envelope, error) {
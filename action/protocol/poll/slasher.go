@@ -79,45 +79,55 @@ func (sh *Slasher) CreateGenesisStates(ctx context.Context, sm protocol.StateMan
 	return nil
 }
 
-// CreatePreStates is to setup probation list
-func (sh *Slasher) CreatePreStates(ctx context.Context, sm protocol.StateManager, indexer *CandidateIndexer) error {
-	blkCtx := protocol.MustGetBlockCtx(ctx)
-	featureCtx := protocol.MustGetFeatureCtx(ctx)
-	featureWithHeightCtx := protocol.MustGetFeatureWithHeightCtx(ctx)
-	rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
-	epochNum := rp.GetEpochNum(blkCtx.BlockHeight)
-	epochStartHeight := rp.GetEpochHeight(epochNum)
-	epochLastHeight := rp.GetEpochLastBlockHeight(epochNum)
-	nextEpochStartHeight := rp.GetEpochHeight(epochNum + 1)
-	if featureCtx.UpdateBlockMeta {
+// CreatePreStates is to update the meta of the current block
+func (sh *Slasher) CreatePreStates(ctx context.Context, sm protocol.StateManager) error {
+	if protocol.MustGetFeatureCtx(ctx).UpdateBlockMeta {
 		if err := sh.updateCurrentBlockMeta(ctx, sm); err != nil {
 			return errors.Wrap(err, "faild to update current epoch meta")
 		}
 	}
-	if blkCtx.BlockHeight == epochLastHeight && featureWithHeightCtx.CalculateProbationList(nextEpochStartHeight) {
-		// if the block height is the end of epoch and next epoch is after the Easter height, calculate probation list for probation and write into state DB
-		unqualifiedList, err := sh.CalculateProbationList(ctx, sm, epochNum+1)
-		if err != nil {
-			return err
-		}
-		return setNextEpochProbationList(sm, indexer, nextEpochStartHeight, unqualifiedList)
+	return nil
+}
+
+// PreEpochStart is to shift the candidates and the probation list of the epoch into the current ones
+func (sh *Slasher) PreEpochStart(ctx context.Context, sm protocol.StateManager) error {
+	featureWithHeightCtx := protocol.MustGetFeatureWithHeightCtx(ctx)
+	epochCtx := protocol.MustGetEpochCtx(ctx)
+	if epochCtx.EpochStartHeight == epochCtx.EpochLastHeight && featureWithHeightCtx.CalculateProbationList(epochCtx.EpochLastHeight+1) {
+		// the first block of a single-block epoch only calculates the probation list of the next epoch
+		return nil
 	}
-	if blkCtx.BlockHeight == epochStartHeight && featureWithHeightCtx.CalculateProbationList(epochStartHeight) {
-		prevHeight, err := shiftCandidates(sm)
-		if err != nil {
-			return err
-		}
-		afterHeight, err := shiftProbationList(sm)
-		if err != nil {
-			return err
-		}
-		if prevHeight != afterHeight {
-			return errors.Wrap(ErrInconsistentHeight, "shifting candidate height is not same as shifting probation height")
-		}
+	if !featureWithHeightCtx.CalculateProbationList(epochCtx.EpochStartHeight) {
+		return nil
+	}
+	prevHeight, err := shiftCandidates(sm)
+	if err != nil {
+		return err
+	}
+	afterHeight, err := shiftProbationList(sm)
+	if err != nil {
+		return err
+	}
+	if prevHeight != afterHeight {
+		return errors.Wrap(ErrInconsistentHeight, "shifting candidate height is not same as shifting probation height")
 	}
 	return nil
 }
 
+// PostEpochEnd is to calculate the probation list of the next epoch and write it into state DB
+func (sh *Slasher) PostEpochEnd(ctx context.Context, sm protocol.StateManager, indexer *CandidateIndexer) error {
+	epochCtx := protocol.MustGetEpochCtx(ctx)
+	nextEpochStartHeight := epochCtx.EpochLastHeight + 1
+	if !protocol.MustGetFeatureWithHeightCtx(ctx).CalculateProbationList(nextEpochStartHeight) {
+		return nil
+	}
+	unqualifiedList, err := sh.CalculateProbationList(ctx, sm, epochCtx.EpochNum+1)
+	if err != nil {
+		return err
+	}
+	return setNextEpochProbationList(sm, indexer, nextEpochStartHeight, unqualifiedList)
+}
+
 // ReadState defines slasher's read methods.
 func (sh *Slasher) ReadState(
 	ctx context.Context,
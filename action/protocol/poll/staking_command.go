@@ -79,6 +79,30 @@ func (sc *stakingCommand) CreatePreStates(ctx context.Context, sm protocol.State
 	return nil
 }
 
+func (sc *stakingCommand) PreEpochStart(ctx context.Context, sm protocol.StateManager) error {
+	if sc.useV2(ctx, sm) {
+		if h, ok := sc.stakingV2.(protocol.PreEpochStarter); ok {
+			return h.PreEpochStart(ctx, sm)
+		}
+	}
+	if h, ok := sc.stakingV1.(protocol.PreEpochStarter); ok {
+		return h.PreEpochStart(ctx, sm)
+	}
+	return nil
+}
+
+func (sc *stakingCommand) PostEpochEnd(ctx context.Context, sm protocol.StateManager) error {
+	if sc.useV2(ctx, sm) {
+		if h, ok := sc.stakingV2.(protocol.PostEpochEnder); ok {
+			return h.PostEpochEnd(ctx, sm)
+		}
+	}
+	if h, ok := sc.stakingV1.(protocol.PostEpochEnder); ok {
+		return h.PostEpochEnd(ctx, sm)
+	}
+	return nil
+}
+
 func (sc *stakingCommand) CreatePostSystemActions(ctx context.Context, sr protocol.StateReader) ([]action.Envelope, error) {
 	// no height here,  v1 v2 has the same createPostSystemActions method, so directly use common one
 	return createPostSystemActions(ctx, sr, sc)
@@ -186,6 +186,20 @@ func (sc *stakingCommittee) CreatePreStates(ctx context.Context, sm protocol.Sta
 	return nil
 }
 
+func (sc *stakingCommittee) PreEpochStart(ctx context.Context, sm protocol.StateManager) error {
+	if h, ok := sc.governanceStaking.(protocol.PreEpochStarter); ok {
+		return h.PreEpochStart(ctx, sm)
+	}
+	return nil
+}
+
+func (sc *stakingCommittee) PostEpochEnd(ctx context.Context, sm protocol.StateManager) error {
+	if h, ok := sc.governanceStaking.(protocol.PostEpochEnder); ok {
+		return h.PostEpochEnd(ctx, sm)
+	}
+	return nil
+}
+
 func (sc *stakingCommittee) CreatePostSystemActions(ctx context.Context, sr protocol.StateReader) ([]action.Envelope, error) {
 	return createPostSystemActions(ctx, sr, sc)
 }
@@ -180,6 +180,7 @@ func TestCreatePostSystemActions_StakingCommittee(t *testing.T) {
 	psac, ok := p.(protocol.PostSystemActionsCreator)
 	require.True(ok)
 	ctx = protocol.WithFeatureWithHeightCtx(ctx)
+	ctx = protocol.WithEpochCtx(ctx, rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx)).GetEpochCtx(protocol.MustGetBlockCtx(ctx).BlockHeight))
 	elp, err := psac.CreatePostSystemActions(ctx, sr)
 	require.NoError(err)
 	require.Equal(1, len(elp))
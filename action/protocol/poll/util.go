@@ -58,6 +58,8 @@ func handle(ctx context.Context, act action.Action, sm protocol.StateManager, in
 	zap.L().Debug("Handle PutPollResult Action", zap.Uint64("height", r.Height()))
 
 	if err := setCandidates(ctx, sm, indexer, r.Candidates(), r.Height()); err != nil {
+		// the poll result is put by the block producer, so a failure is neither an admission error nor an execution
+		// failure, and the block carrying it is rejected
 		return nil, errors.Wrap(err, "failed to set candidates")
 	}
 	return &action.Receipt{
@@ -108,11 +110,11 @@ func validate(ctx context.Context, sr protocol.StateReader, p Protocol, act acti
 func createPostSystemActions(ctx context.Context, sr protocol.StateReader, p Protocol) ([]action.Envelope, error) {
 	blkCtx := protocol.MustGetBlockCtx(ctx)
 	featureCtx := protocol.MustGetFeatureWithHeightCtx(ctx)
-	rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
-	epochNum := rp.GetEpochNum(blkCtx.BlockHeight)
-	lastBlkHeight := rp.GetEpochLastBlockHeight(epochNum)
-	epochHeight := rp.GetEpochHeight(epochNum)
-	nextEpochHeight := rp.GetEpochHeight(epochNum + 1)
+	epochCtx := protocol.MustGetEpochCtx(ctx)
+	epochNum := epochCtx.EpochNum
+	lastBlkHeight := epochCtx.EpochLastHeight
+	epochHeight := epochCtx.EpochStartHeight
+	nextEpochHeight := lastBlkHeight + 1
 	// make sure that putpollresult action is created around half of each epoch
 	if blkCtx.BlockHeight < epochHeight+(nextEpochHeight-epochHeight)/2 {
 		return nil, nil
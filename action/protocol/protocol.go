@@ -21,6 +21,8 @@ import (
 var (
 	// ErrUnimplemented indicates a method is not implemented yet
 	ErrUnimplemented = errors.New("method is unimplemented")
+	// ErrViewMismatch indicates the view of a protocol differs from the states it is built from
+	ErrViewMismatch = errors.New("protocol view mismatches states")
 )
 
 const (
@@ -42,6 +44,13 @@ type Starter interface {
 	Start(context.Context, StateReader) (interface{}, error)
 }
 
+// ViewVerifier verifies the view of the protocol against the states it is built from
+type ViewVerifier interface {
+	// VerifyView returns the view rebuilt from the states, and an error wrapping ErrViewMismatch with the diff if the
+	// view differs from the rebuilt one
+	VerifyView(context.Context, StateReader, interface{}) (interface{}, error)
+}
+
 // GenesisStateCreator creates some genesis states
 type GenesisStateCreator interface {
 	CreateGenesisStates(context.Context, StateManager) error
@@ -62,6 +71,22 @@ type Committer interface {
 	Commit(context.Context, StateManager) error
 }
 
+// PreEpochStarter prepares the states of an epoch at its first block, ahead of the actions in the block
+type PreEpochStarter interface {
+	PreEpochStart(context.Context, StateManager) error
+}
+
+// PostEpochEnder settles the states of an epoch at its last block, ahead of the actions in the block
+type PostEpochEnder interface {
+	PostEpochEnd(context.Context, StateManager) error
+}
+
+// SystemLogEmitter is implemented by the state manager of a block to take the logs emitted out of any action, e.g., by
+// the epoch hooks, which are attached to the receipt of the first system action of the block
+type SystemLogEmitter interface {
+	EmitSystemLogs(...*action.Log)
+}
+
 // PostSystemActionsCreator creates a list of system actions to be appended to block actions
 type PostSystemActionsCreator interface {
 	CreatePostSystemActions(context.Context, StateReader) ([]action.Envelope, error)
@@ -72,6 +97,12 @@ type ActionValidator interface {
 	Validate(context.Context, action.Action, StateReader) error
 }
 
+// PreActionHandler prepares the states for an action ahead of the action handlers, e.g., applies the pending changes
+// of the caller. The changes are kept even if the action fails in execution
+type PreActionHandler interface {
+	PreHandle(context.Context, action.Action, StateManager) error
+}
+
 // ActionHandler is the interface for the action handlers. For each incoming action, the assembled actions will be
 // called one by one to process it. ActionHandler implementation is supposed to parse the sub-type of the action to
 // decide if it wants to handle this action or not.
@@ -82,6 +113,7 @@ type ActionHandler interface {
 type (
 	Options struct {
 		ValueBigInt *big.Int
+		Payer       address.Address
 	}
 
 	Option func(*Options)
@@ -93,6 +125,13 @@ func BurnGasOption(burnAmount *big.Int) Option {
 	}
 }
 
+// PayerOption sets the address which the deposit is paid from, the caller if payer is nil
+func PayerOption(payer address.Address) Option {
+	return func(opts *Options) {
+		opts.Payer = payer
+	}
+}
+
 // DepositGas deposits gas to rewarding pool and burns baseFee
 type DepositGas func(context.Context, StateManager, *big.Int, ...Option) ([]*action.TransactionLog, error)
 
@@ -139,3 +178,12 @@ func SplitGas(ctx context.Context, tx action.TxDynamicGas, usedGas uint64) (*big
 	base := new(big.Int).Set(baseFee)
 	return priority.Mul(priority, gas), base.Mul(base, gas), nil
 }
+
+// DataCostSchedule returns the schedule of the gas charged for the payload data of the actions, which is the flat
+// schedule without the feature context
+func DataCostSchedule(ctx context.Context) action.DataCostSchedule {
+	if fCtx, ok := GetFeatureCtx(ctx); ok && fCtx.EnableZeroByteDataDiscount {
+		return action.DataCostZeroByteDiscount
+	}
+	return action.DataCostFlat
+}
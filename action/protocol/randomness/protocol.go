@@ -0,0 +1,140 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package randomness
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+const (
+	_protocolID          = "randomness"
+	_randomnessNamespace = "Randomness"
+)
+
+var (
+	_currentKey       = []byte("cur")
+	_historyKeyPrefix = []byte("his")
+)
+
+// Protocol defines the protocol of the randomness beacon. At each block, the VRF output of the producer is mixed into
+// the beacon of the previous block, so the beacon is deterministic, but unknown before the producer reveals the proof
+// in the block, and a producer can only choose between proposing the block or not
+type Protocol struct{}
+
+// NewProtocol instantiates the protocol of the randomness beacon
+func NewProtocol() *Protocol {
+	return &Protocol{}
+}
+
+// CreatePreStates mixes the producer's randomness of the block into the beacon, ahead of the actions in the block
+func (p *Protocol) CreatePreStates(ctx context.Context, sm protocol.StateManager) error {
+	if !protocol.MustGetFeatureCtx(ctx).EnableRandomnessBeacon {
+		return nil
+	}
+	blkCtx := protocol.MustGetBlockCtx(ctx)
+	// the proof is verified against the producer in the validation of the block
+	output, err := crypto.VRFProofToHash(blkCtx.RandomnessProof)
+	if err != nil {
+		return errors.Wrapf(err, "invalid randomness proof of block %d", blkCtx.BlockHeight)
+	}
+	prev, err := CurrentBeacon(sm)
+	switch errors.Cause(err) {
+	case nil:
+	case state.ErrStateNotExist:
+		// the first block after the activation
+		prev = hash.ZeroHash256
+	default:
+		return err
+	}
+	beacon := Mix(prev, output)
+	if _, err := sm.PutState(protocol.SerializableBytes(beacon[:]), protocol.KeyOption(_currentKey), protocol.NamespaceOption(_randomnessNamespace)); err != nil {
+		return err
+	}
+	_, err = sm.PutState(protocol.SerializableBytes(beacon[:]), protocol.KeyOption(historyKey(blkCtx.BlockHeight)), protocol.NamespaceOption(_randomnessNamespace))
+	return err
+}
+
+// Handle handles nothing, the beacon is only updated by the blocks
+func (p *Protocol) Handle(context.Context, action.Action, protocol.StateManager) (*action.Receipt, error) {
+	return nil, nil
+}
+
+// ReadState reads the beacon of the block at the height
+func (p *Protocol) ReadState(ctx context.Context, sr protocol.StateReader, method []byte, args ...[]byte) ([]byte, uint64, error) {
+	tipHeight, err := sr.Height()
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	switch string(method) {
+	case "BlockRandomness":
+		if len(args) != 1 {
+			return nil, uint64(0), errors.Errorf("invalid number of arguments %d", len(args))
+		}
+		height, err := strconv.ParseUint(string(args[0]), 10, 64)
+		if err != nil {
+			return nil, uint64(0), err
+		}
+		beacon, err := Beacon(sr, height)
+		if err != nil {
+			return nil, uint64(0), err
+		}
+		return beacon[:], tipHeight, nil
+	default:
+		return nil, tipHeight, errors.New("corresponding method isn't found")
+	}
+}
+
+// Register registers the protocol with a unique ID
+func (p *Protocol) Register(r *protocol.Registry) error {
+	return r.Register(_protocolID, p)
+}
+
+// ForceRegister registers the protocol with a unique ID and force replacing the previous protocol if it exists
+func (p *Protocol) ForceRegister(r *protocol.Registry) error {
+	return r.ForceRegister(_protocolID, p)
+}
+
+// Name returns the name of protocol
+func (p *Protocol) Name() string {
+	return _protocolID
+}
+
+// Mix mixes the producer's randomness of a block into the beacon of the previous block
+func Mix(prev, output hash.Hash256) hash.Hash256 {
+	return hash.Hash256b(append(prev[:], output[:]...))
+}
+
+// Beacon returns the beacon of the block at the height, state.ErrStateNotExist if the block is before the activation
+func Beacon(sr protocol.StateReader, height uint64) (hash.Hash256, error) {
+	return readBeacon(sr, historyKey(height))
+}
+
+// CurrentBeacon returns the beacon of the latest block in the state
+func CurrentBeacon(sr protocol.StateReader) (hash.Hash256, error) {
+	return readBeacon(sr, _currentKey)
+}
+
+func readBeacon(sr protocol.StateReader, key []byte) (hash.Hash256, error) {
+	var beacon protocol.SerializableBytes
+	if _, err := sr.State(&beacon, protocol.KeyOption(key), protocol.NamespaceOption(_randomnessNamespace)); err != nil {
+		return hash.ZeroHash256, err
+	}
+	return hash.BytesToHash256(beacon), nil
+}
+
+func historyKey(height uint64) []byte {
+	return append(append([]byte{}, _historyKeyPrefix...), byteutil.Uint64ToBytesBigEndian(height)...)
+}
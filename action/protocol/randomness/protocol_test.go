@@ -0,0 +1,92 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package randomness
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/mohae/deepcopy"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil/testdb"
+)
+
+func TestProtocol(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	p := NewProtocol()
+	g := deepcopy.Copy(genesis.Default).(genesis.Genesis)
+	g.ToBeEnabledBlockHeight = 2
+
+	prevHash := hash.Hash256b([]byte("prev"))
+	mint := func(sm protocol.StateManager, producer int, height uint64) hash.Hash256 {
+		sk := identityset.PrivateKey(producer)
+		proof, err := crypto.VRFProve(sk, block.RandomnessAlpha(prevHash, height))
+		r.NoError(err)
+		ctx := protocol.WithBlockCtx(genesis.WithGenesisContext(context.Background(), g), protocol.BlockCtx{
+			BlockHeight:     height,
+			Producer:        identityset.Address(producer),
+			RandomnessProof: proof,
+		})
+		ctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
+		r.NoError(p.CreatePreStates(ctx, sm))
+		output, err := crypto.VRFVerify(sk.PublicKey(), block.RandomnessAlpha(prevHash, height), proof)
+		r.NoError(err)
+		return output
+	}
+
+	sm1 := testdb.NewMockStateManager(ctrl)
+	sm2 := testdb.NewMockStateManager(ctrl)
+	// no beacon before the activation
+	mint(sm1, 1, 1)
+	_, err := CurrentBeacon(sm1)
+	r.Equal(state.ErrStateNotExist, errors.Cause(err))
+	_, err = Beacon(sm1, 1)
+	r.Equal(state.ErrStateNotExist, errors.Cause(err))
+
+	// the beacon mixes the randomness of each block into the previous one
+	out2 := mint(sm1, 1, 2)
+	mint(sm2, 1, 2)
+	out3 := mint(sm1, 1, 3)
+	beacon2, err := Beacon(sm1, 2)
+	r.NoError(err)
+	r.Equal(Mix(hash.ZeroHash256, out2), beacon2)
+	beacon3, err := Beacon(sm1, 3)
+	r.NoError(err)
+	r.Equal(Mix(beacon2, out3), beacon3)
+	current, err := CurrentBeacon(sm1)
+	r.NoError(err)
+	r.Equal(beacon3, current)
+
+	// two producers at the same height produce different beacons
+	mint(sm2, 2, 3)
+	other, err := Beacon(sm2, 3)
+	r.NoError(err)
+	r.NotEqual(beacon3, other)
+
+	t.Run("read state", func(t *testing.T) {
+		b, _, err := p.ReadState(context.Background(), sm1, []byte("BlockRandomness"), []byte(strconv.FormatUint(3, 10)))
+		r.NoError(err)
+		r.Equal(beacon3[:], b)
+		_, _, err = p.ReadState(context.Background(), sm1, []byte("BlockRandomness"), []byte("1"))
+		r.Equal(state.ErrStateNotExist, errors.Cause(err))
+		_, _, err = p.ReadState(context.Background(), sm1, []byte("BlockRandomness"))
+		r.Error(err)
+		_, _, err = p.ReadState(context.Background(), sm1, []byte("Unknown"))
+		r.Error(err)
+	})
+}
@@ -11,6 +11,10 @@ import (
 	"sync"
 
 	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/pkg/log"
 )
 
 // Registry is the hub of all protocols deployed on the chain
@@ -18,6 +22,7 @@ type Registry struct {
 	mu        sync.RWMutex
 	ids       map[string]int
 	protocols []Protocol
+	params    *ParameterSet
 }
 
 // NewRegistry create a new Registry
@@ -29,6 +34,8 @@ func NewRegistry() *Registry {
 }
 
 func (r *Registry) register(id string, p Protocol, force bool) error {
+	// the parameters are populated again once the protocols change
+	r.params = nil
 	idx, loaded := r.ids[id]
 	if loaded {
 		if !force {
@@ -89,6 +96,35 @@ func (r *Registry) all() []Protocol {
 	return all
 }
 
+// PopulateParameters populates the height-gated parameters declared by the protocols for the genesis, which the
+// protocols read afterwards
+func (r *Registry) PopulateParameters(g genesis.Genesis) (*ParameterSet, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var declarers []ParameterDeclarer
+	for _, p := range r.protocols {
+		if d, ok := p.(ParameterDeclarer); ok {
+			declarers = append(declarers, d)
+		}
+	}
+	params, err := NewParameterSet(g, declarers...)
+	if err != nil {
+		return nil, err
+	}
+	r.params = params
+	return params, nil
+}
+
+// Parameters returns the height-gated parameters populated, nil if not populated
+func (r *Registry) Parameters() *ParameterSet {
+	if r == nil {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.params
+}
+
 // StartAll starts all protocols which are startable
 func (r *Registry) StartAll(ctx context.Context, sr StateReader) (View, error) {
 	if r == nil {
@@ -113,3 +149,76 @@ func (r *Registry) StartAll(ctx context.Context, sr StateReader) (View, error) {
 	}
 	return allView, nil
 }
+
+// VerifyAll verifies the views of the protocols against the states, the view of a protocol is replaced by the one
+// rebuilt from the states on mismatch if repair is true, otherwise the mismatches are returned
+func (r *Registry) VerifyAll(ctx context.Context, sr StateReader, view View, repair bool) error {
+	if r == nil {
+		return nil
+	}
+	var mismatches []error
+	for _, p := range r.All() {
+		verifier, ok := p.(ViewVerifier)
+		if !ok {
+			continue
+		}
+		v, err := view.Read(p.Name())
+		if err != nil {
+			continue
+		}
+		rebuilt, err := verifier.VerifyView(ctx, sr, v)
+		switch {
+		case err == nil:
+			continue
+		case errors.Cause(err) != ErrViewMismatch:
+			return errors.Wrapf(err, "failed to verify the view of protocol %s", p.Name())
+		case !repair:
+			mismatches = append(mismatches, err)
+			continue
+		}
+		log.L().Warn("Repair the protocol view from states.", zap.String("protocol", p.Name()), zap.Error(err))
+		if err := view.Write(p.Name(), rebuilt); err != nil {
+			return err
+		}
+	}
+	if len(mismatches) > 0 {
+		return errors.Wrapf(ErrViewMismatch, "%v", mismatches)
+	}
+	return nil
+}
+
+// RunEpochHooks calls PreEpochStart of the protocols at the first block of an epoch, and PostEpochEnd at the last
+// block, in the order of registration, so the hooks of a protocol see the states written by the hooks of the protocols
+// registered ahead of it, e.g., the poll protocol is registered ahead of the rewarding protocol, so the poll result is
+// settled before the reward. PreEpochStart of all protocols is called ahead of PostEpochEnd if the block is both the
+// first and the last of an epoch. Nothing is called without EpochCtx in context
+func (r *Registry) RunEpochHooks(ctx context.Context, sm StateManager) error {
+	if r == nil {
+		return nil
+	}
+	epochCtx, ok := GetEpochCtx(ctx)
+	if !ok {
+		return nil
+	}
+	height := MustGetBlockCtx(ctx).BlockHeight
+	all := r.All()
+	if height == epochCtx.EpochStartHeight {
+		for _, p := range all {
+			if h, ok := p.(PreEpochStarter); ok {
+				if err := h.PreEpochStart(ctx, sm); err != nil {
+					return errors.Wrapf(err, "failed to start epoch %d in protocol %s", epochCtx.EpochNum, reflect.TypeOf(p))
+				}
+			}
+		}
+	}
+	if height == epochCtx.EpochLastHeight {
+		for _, p := range all {
+			if h, ok := p.(PostEpochEnder); ok {
+				if err := h.PostEpochEnd(ctx, sm); err != nil {
+					return errors.Wrapf(err, "failed to end epoch %d in protocol %s", epochCtx.EpochNum, reflect.TypeOf(p))
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -6,6 +6,7 @@
 package protocol
 
 import (
+	"context"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -54,3 +55,53 @@ func TestAll(t *testing.T) {
 	require.Equal(all[0], p)
 	require.Nil(all[1])
 }
+
+type epochHookRecorder struct {
+	Protocol
+	name  string
+	calls *[]string
+}
+
+func (r *epochHookRecorder) PreEpochStart(context.Context, StateManager) error {
+	*r.calls = append(*r.calls, r.name+".PreEpochStart")
+	return nil
+}
+
+func (r *epochHookRecorder) PostEpochEnd(context.Context, StateManager) error {
+	*r.calls = append(*r.calls, r.name+".PostEpochEnd")
+	return nil
+}
+
+func TestRunEpochHooks(t *testing.T) {
+	require := require.New(t)
+	var (
+		calls []string
+		reg   = NewRegistry()
+		epoch = EpochCtx{EpochNum: 2, EpochStartHeight: 11, EpochLastHeight: 20}
+	)
+	require.NoError(reg.Register("poll", &epochHookRecorder{name: "poll", calls: &calls}))
+	require.NoError(reg.Register("account", NewMockProtocol(gomock.NewController(t))))
+	require.NoError(reg.Register("rewarding", &epochHookRecorder{name: "rewarding", calls: &calls}))
+
+	for _, test := range []struct {
+		height uint64
+		epoch  *EpochCtx
+		calls  []string
+	}{
+		{11, &epoch, []string{"poll.PreEpochStart", "rewarding.PreEpochStart"}},
+		{15, &epoch, nil},
+		{20, &epoch, []string{"poll.PostEpochEnd", "rewarding.PostEpochEnd"}},
+		{21, &EpochCtx{EpochNum: 3, EpochStartHeight: 21, EpochLastHeight: 21}, []string{
+			"poll.PreEpochStart", "rewarding.PreEpochStart", "poll.PostEpochEnd", "rewarding.PostEpochEnd",
+		}},
+		{11, nil, nil},
+	} {
+		calls = nil
+		ctx := WithBlockCtx(context.Background(), BlockCtx{BlockHeight: test.height})
+		if test.epoch != nil {
+			ctx = WithEpochCtx(ctx, *test.epoch)
+		}
+		require.NoError(reg.RunEpochHooks(ctx, nil))
+		require.Equal(test.calls, calls)
+	}
+}
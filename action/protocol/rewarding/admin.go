@@ -13,6 +13,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/rewarding/rewardingpb"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
@@ -264,7 +265,7 @@ func (p *Protocol) assertAmount(amount *big.Int) error {
 	if amount.Cmp(big.NewInt(0)) >= 0 {
 		return nil
 	}
-	return errors.Errorf("amount %s shouldn't be negative", amount.String())
+	return action.NewReceiptStatusError(action.ReceiptStatusErrRewardingInvalidAmount, errors.Errorf("amount %s shouldn't be negative", amount.String()))
 }
 
 func (p *Protocol) assertZeroBlockHeight(height uint64) error {
@@ -0,0 +1,219 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rewarding
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/poll"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+const (
+	// ReasonCandidateNotFound is the reason the estimate is zero that the candidate is not among the candidates of
+	// the current epoch
+	ReasonCandidateNotFound = "candidate not found"
+	// ReasonNotInRewardRange is the reason the estimate is zero that the candidate is not ranked high enough to share
+	// the epoch reward, block reward or foundation bonus, even with the stake
+	ReasonNotInRewardRange = "candidate not in reward range"
+)
+
+var _estimateAssumptions = []string{
+	"the votes of the other candidates and the reward parameters stay the same as in the current epoch",
+	"the candidate produces all the blocks of its time slots and is not exempted for low productivity",
+	"the candidate distributes all its rewards to the voters in proportion to the weighted votes, " +
+		"the actual distribution is decided by the candidate off chain",
+}
+
+// RewardEstimate is the projected per-epoch reward of staking an amount to a candidate, all amounts are in Rau
+type RewardEstimate struct {
+	Candidate     string `json:"candidate"`
+	Epoch         uint64 `json:"epoch"`
+	Amount        string `json:"amount"`
+	DurationDays  uint32 `json:"durationDays"`
+	AutoStake     bool   `json:"autoStake"`
+	VoteWeight    string `json:"voteWeight"`
+	Rank          uint64 `json:"rank"`
+	InRewardRange bool   `json:"inRewardRange"`
+	Reason        string `json:"reason,omitempty"`
+	// CandidateVotes is the projected weighted votes of the candidate including the stake
+	CandidateVotes string `json:"candidateVotes"`
+	// TotalEpochRewardVotes is the projected weighted votes of the candidates sharing the epoch reward
+	TotalEpochRewardVotes string   `json:"totalEpochRewardVotes"`
+	EpochReward           string   `json:"epochReward"`
+	BlockReward           string   `json:"blockReward"`
+	FoundationBonus       string   `json:"foundationBonus"`
+	Total                 string   `json:"total"`
+	Assumptions           []string `json:"assumptions"`
+}
+
+// EstimateReward projects the per-epoch reward of staking the amount for the duration to the candidate, by the
+// current candidate votes, the reward parameters and the vote weight formula. The candidate is either the name or
+// the operator address. It reads the states only
+func (p *Protocol) EstimateReward(
+	ctx context.Context,
+	sr protocol.StateReader,
+	candidate string,
+	amount *big.Int,
+	durationDays uint32,
+	autoStake bool,
+) (*RewardEstimate, uint64, error) {
+	if amount.Sign() <= 0 {
+		return nil, 0, errors.Errorf("invalid amount %s", amount.String())
+	}
+	height, err := sr.Height()
+	if err != nil {
+		return nil, 0, err
+	}
+	registry := protocol.MustGetRegistry(ctx)
+	rp := rolldpos.MustGetProtocol(registry)
+	pp := poll.MustGetProtocol(registry)
+	g := genesis.MustExtractGenesisContext(ctx)
+	a := admin{}
+	if _, err := p.state(ctx, sr, _adminKey, &a); err != nil {
+		return nil, 0, err
+	}
+	e := exempt{}
+	if _, err := p.state(ctx, sr, _exemptKey, &e); err != nil {
+		return nil, 0, err
+	}
+	exemptAddrs := make(map[string]struct{})
+	for _, addr := range e.addrs {
+		exemptAddrs[addr.String()] = struct{}{}
+	}
+
+	weight := staking.CalculateVoteWeight(g.Staking.VoteWeightCalConsts, &staking.VoteBucket{
+		StakedAmount:   amount,
+		StakedDuration: time.Duration(durationDays) * 24 * time.Hour,
+		AutoStake:      autoStake,
+	}, false)
+	epochNum := rp.GetEpochNum(height)
+	est := &RewardEstimate{
+		Candidate:             candidate,
+		Epoch:                 epochNum,
+		Amount:                amount.String(),
+		DurationDays:          durationDays,
+		AutoStake:             autoStake,
+		VoteWeight:            weight.String(),
+		CandidateVotes:        "0",
+		TotalEpochRewardVotes: "0",
+		Assumptions:           _estimateAssumptions,
+	}
+	candidates, err := pp.Candidates(ctx, sr)
+	if err != nil {
+		return nil, 0, err
+	}
+	// project the votes and the ranks with the stake added to the candidate
+	projected := make(state.CandidateList, 0, len(candidates))
+	var target *state.Candidate
+	for _, c := range candidates {
+		clone := *c
+		if c.Address == candidate || (len(c.CanName) > 0 && string(c.CanName) == candidate) {
+			clone.Votes = new(big.Int).Add(c.Votes, weight)
+			target = &clone
+		}
+		projected = append(projected, &clone)
+	}
+	if target == nil {
+		est.Reason = ReasonCandidateNotFound
+		return est.withRewards(big.NewInt(0), big.NewInt(0), big.NewInt(0)), height, nil
+	}
+	sort.SliceStable(projected, func(i, j int) bool {
+		return projected[i].Votes.Cmp(projected[j].Votes) > 0
+	})
+	est.CandidateVotes = target.Votes.String()
+
+	var (
+		epochReward, blockReward, bonus = big.NewInt(0), big.NewInt(0), big.NewInt(0)
+		totalVotes                      = big.NewInt(0)
+		epochRewardRanked               bool
+		bonusRanked                     bool
+		rank, rewardRank, bonusRank     uint64
+	)
+	for i, c := range projected {
+		if c == target {
+			rank = uint64(i) + 1
+		}
+		if _, ok := exemptAddrs[c.Address]; ok {
+			continue
+		}
+		if rewardRank < a.numDelegatesForEpochReward {
+			rewardRank++
+			totalVotes.Add(totalVotes, c.Votes)
+			epochRewardRanked = epochRewardRanked || c == target
+		}
+		if c.Votes.Sign() > 0 && bonusRank < a.numDelegatesForFoundationBonus {
+			bonusRank++
+			bonusRanked = bonusRanked || c == target
+		}
+	}
+	est.Rank = rank
+	est.TotalEpochRewardVotes = totalVotes.String()
+	if epochRewardRanked && totalVotes.Sign() > 0 {
+		epochReward.Div(new(big.Int).Mul(a.epochReward, weight), totalVotes)
+	}
+	if rank <= rp.NumDelegates() {
+		// each delegate produces a block in each sub epoch
+		blockReward.Mul(a.blockReward, new(big.Int).SetUint64(rp.NumSubEpochs(height)))
+		blockReward.Div(blockReward.Mul(blockReward, weight), target.Votes)
+	}
+	if bonusRanked && (a.grantFoundationBonus(epochNum) || (epochNum >= p.cfg.FoundationBonusP2StartEpoch && epochNum <= p.cfg.FoundationBonusP2EndEpoch)) {
+		bonus.Div(new(big.Int).Mul(a.foundationBonus, weight), target.Votes)
+	}
+	est.InRewardRange = epochRewardRanked || rank <= rp.NumDelegates() || bonusRanked
+	if !est.InRewardRange {
+		est.Reason = ReasonNotInRewardRange
+	}
+	return est.withRewards(epochReward, blockReward, bonus), height, nil
+}
+
+func (est *RewardEstimate) withRewards(epochReward, blockReward, bonus *big.Int) *RewardEstimate {
+	est.EpochReward = epochReward.String()
+	est.BlockReward = blockReward.String()
+	est.FoundationBonus = bonus.String()
+	est.Total = new(big.Int).Add(epochReward, new(big.Int).Add(blockReward, bonus)).String()
+	return est
+}
+
+// readRewardEstimate reads the estimate by the args of candidate, amount in Rau, duration in days and auto stake,
+// and returns it in json
+func (p *Protocol) readRewardEstimate(ctx context.Context, sr protocol.StateReader, args ...[]byte) ([]byte, uint64, error) {
+	if len(args) != 4 {
+		return nil, uint64(0), errors.Errorf("invalid number of arguments %d", len(args))
+	}
+	amount, ok := new(big.Int).SetString(string(args[1]), 10)
+	if !ok {
+		return nil, uint64(0), errors.Errorf("invalid amount %s", string(args[1]))
+	}
+	duration, err := strconv.ParseUint(string(args[2]), 10, 32)
+	if err != nil {
+		return nil, uint64(0), errors.Wrap(err, "invalid duration")
+	}
+	autoStake, err := strconv.ParseBool(string(args[3]))
+	if err != nil {
+		return nil, uint64(0), errors.Wrap(err, "invalid auto stake")
+	}
+	est, height, err := p.EstimateReward(ctx, sr, string(args[0]), amount, uint32(duration), autoStake)
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	data, err := json.Marshal(est)
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	return data, height, nil
+}
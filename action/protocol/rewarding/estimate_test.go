@@ -0,0 +1,91 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rewarding
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestProtocol_EstimateReward(t *testing.T) {
+	testProtocol(t, func(t *testing.T, ctx context.Context, sm protocol.StateManager, p *Protocol) {
+		r := require.New(t)
+		amount := unit.ConvertIotxToRau(1000000)
+
+		// the 4th candidate is ranked 4th with 2M votes, the top 4 have 11M votes in total
+		est, _, err := p.EstimateReward(ctx, sm, identityset.Address(30).String(), amount, 0, false)
+		r.NoError(err)
+		r.True(est.InRewardRange)
+		r.Empty(est.Reason)
+		r.Equal(uint64(4), est.Rank)
+		r.Equal(amount.String(), est.VoteWeight)
+		r.Equal(unit.ConvertIotxToRau(2000000).String(), est.CandidateVotes)
+		r.Equal(unit.ConvertIotxToRau(11000000).String(), est.TotalEpochRewardVotes)
+		// 100 * 1M / 11M
+		r.Equal("9", est.EpochReward)
+		// 10 * 2 blocks * 1M / 2M
+		r.Equal("10", est.BlockReward)
+		// 5 * 1M / 2M
+		r.Equal("2", est.FoundationBonus)
+		r.Equal("21", est.Total)
+		r.NotEmpty(est.Assumptions)
+
+		// the stake raises the rank of the 6th candidate into the epoch reward range
+		est, _, err = p.EstimateReward(ctx, sm, identityset.Address(32).String(), amount, 0, false)
+		r.NoError(err)
+		r.Equal(uint64(4), est.Rank)
+		// 100 * 1M / 10.5M
+		r.Equal("9", est.EpochReward)
+
+		// auto stake and duration increase the vote weight
+		est2, _, err := p.EstimateReward(ctx, sm, identityset.Address(32).String(), amount, 91, true)
+		r.NoError(err)
+		weight, ok := new(big.Int).SetString(est2.VoteWeight, 10)
+		r.True(ok)
+		r.Equal(1, weight.Cmp(amount))
+
+		est, _, err = p.EstimateReward(ctx, sm, "unknown", amount, 0, false)
+		r.NoError(err)
+		r.False(est.InRewardRange)
+		r.Equal(ReasonCandidateNotFound, est.Reason)
+		r.Equal("0", est.Total)
+
+		_, _, err = p.EstimateReward(ctx, sm, identityset.Address(30).String(), unit.ConvertIotxToRau(0), 0, false)
+		r.Error(err)
+
+		// read by ReadState in json
+		data, _, err := p.ReadState(ctx, sm, []byte("RewardEstimate"),
+			[]byte(identityset.Address(30).String()), []byte(amount.String()), []byte("0"), []byte("false"))
+		r.NoError(err)
+		var read RewardEstimate
+		r.NoError(json.Unmarshal(data, &read))
+		r.Equal("21", read.Total)
+		_, _, err = p.ReadState(ctx, sm, []byte("RewardEstimate"), []byte(identityset.Address(30).String()))
+		r.Error(err)
+		_, _, err = p.ReadState(ctx, sm, []byte("RewardEstimate"),
+			[]byte(identityset.Address(30).String()), []byte("abc"), []byte("0"), []byte("false"))
+		r.Error(err)
+	}, false)
+
+	testProtocol(t, func(t *testing.T, ctx context.Context, sm protocol.StateManager, p *Protocol) {
+		r := require.New(t)
+		// the exempt candidate doesn't share the epoch reward
+		est, _, err := p.EstimateReward(ctx, sm, identityset.Address(31).String(), unit.ConvertIotxToRau(1000000), 0, false)
+		r.NoError(err)
+		r.True(est.InRewardRange)
+		r.Equal("0", est.EpochReward)
+		r.Equal("0", est.FoundationBonus)
+		r.NotEqual("0", est.BlockReward)
+	}, true)
+}
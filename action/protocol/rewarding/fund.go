@@ -67,6 +67,7 @@ func (p *Protocol) Deposit(
 ) ([]*action.TransactionLog, error) {
 	var (
 		actionCtx           = protocol.MustGetActionCtx(ctx)
+		fCtx                = protocol.MustGetFeatureCtx(ctx)
 		accountCreationOpts = []state.AccountCreationOption{}
 		options             = protocol.Options{}
 	)
@@ -74,64 +75,105 @@ func (p *Protocol) Deposit(
 	for _, o := range opts {
 		o(&options)
 	}
-	if protocol.MustGetFeatureCtx(ctx).CreateLegacyNonceAccount {
+	if fCtx.CreateLegacyNonceAccount {
 		accountCreationOpts = append(accountCreationOpts, state.LegacyNonceAccountTypeOption())
 	}
-	// Subtract balance from caller
-	acc, err := accountutil.LoadAccount(sm, actionCtx.Caller, accountCreationOpts...)
+	// Subtract balance from payer, which is the caller unless specified
+	payer := actionCtx.Caller
+	if options.Payer != nil {
+		payer = options.Payer
+	}
+	acc, err := accountutil.LoadAccount(sm, payer, accountCreationOpts...)
 	if err != nil {
 		return nil, err
 	}
 	if err := acc.SubBalance(amount); err != nil {
-		return nil, err
+		return nil, subBalanceError(err)
 	}
 	burnAmount := options.ValueBigInt
 	if !isZero(burnAmount) {
 		if err := acc.SubBalance(burnAmount); err != nil {
-			return nil, err
+			return nil, subBalanceError(err)
 		}
 	}
-	if err := accountutil.StoreAccount(sm, actionCtx.Caller, acc); err != nil {
+	if err := accountutil.StoreAccount(sm, payer, acc); err != nil {
 		return nil, err
 	}
 	// Add balance to fund
 	var (
-		f           = fund{}
 		burnAddr, _ = address.FromString(address.ZeroAddress)
 		tLog        = []*action.TransactionLog{
 			{
 				Type:      transactionLogType,
-				Sender:    actionCtx.Caller.String(),
+				Sender:    payer.String(),
 				Recipient: address.RewardingPoolAddr,
 				Amount:    amount,
 			},
 		}
+		supplyTracking = protocol.IsParameterEnabled(ctx, p, _paramSupplyTracking)
+		redistribute   = supplyTracking && p.cfg.RedistributeBaseFee
 	)
-	if _, err := p.state(ctx, sm, _fundKey, &f); err != nil {
-		return nil, err
-	}
-	f.totalBalance = big.NewInt(0).Add(f.totalBalance, amount)
-	f.unclaimedBalance = big.NewInt(0).Add(f.unclaimedBalance, amount)
-	if !isZero(burnAmount) {
-		// add burnAmount to burnAddr
-		burn, err := accountutil.LoadAccount(sm, burnAddr, accountCreationOpts...)
-		if err != nil {
-			return nil, err
-		}
-		if err := burn.AddBalance(burnAmount); err != nil {
-			return nil, err
-		}
-		if err := accountutil.StoreAccount(sm, burnAddr, burn); err != nil {
-			return nil, err
-		}
+	switch {
+	case isZero(burnAmount):
+	case redistribute:
+		// the base fee is redistributed into the fund instead of being burnt
+		tLog = append(tLog, &action.TransactionLog{
+			Type:      transactionLogType,
+			Sender:    payer.String(),
+			Recipient: address.RewardingPoolAddr,
+			Amount:    burnAmount,
+		})
+	default:
 		tLog = append(tLog, &action.TransactionLog{
 			Type:      iotextypes.TransactionLogType_NATIVE_TRANSFER,
-			Sender:    actionCtx.Caller.String(),
+			Sender:    payer.String(),
 			Recipient: burnAddr.String(),
 			Amount:    burnAmount,
 		})
 	}
-	if err := p.putState(ctx, sm, _fundKey, &f); err != nil {
+	// the fund is credited by almost every action, so the credit may be deferred by the state manager
+	credit := func(sm protocol.StateManager) error {
+		f := fund{}
+		if _, err := p.state(ctx, sm, _fundKey, &f); err != nil {
+			return err
+		}
+		f.totalBalance = big.NewInt(0).Add(f.totalBalance, amount)
+		f.unclaimedBalance = big.NewInt(0).Add(f.unclaimedBalance, amount)
+		switch {
+		case isZero(burnAmount):
+		case redistribute:
+			f.totalBalance.Add(f.totalBalance, burnAmount)
+			f.unclaimedBalance.Add(f.unclaimedBalance, burnAmount)
+			if err := recordSupplyChange(ctx, sm, big.NewInt(0), burnAmount); err != nil {
+				return err
+			}
+		default:
+			// the first record starts from the balance of burnAddr, so it is recorded before burnAddr is updated
+			if supplyTracking {
+				if err := recordSupplyChange(ctx, sm, burnAmount, big.NewInt(0)); err != nil {
+					return err
+				}
+			}
+			// add burnAmount to burnAddr
+			burn, err := accountutil.LoadAccount(sm, burnAddr, accountCreationOpts...)
+			if err != nil {
+				return err
+			}
+			if err := burn.AddBalance(burnAmount); err != nil {
+				return err
+			}
+			if err := accountutil.StoreAccount(sm, burnAddr, burn); err != nil {
+				return err
+			}
+		}
+		return p.putState(ctx, sm, _fundKey, &f)
+	}
+	if d, ok := sm.(protocol.Deferrer); ok {
+		err = d.Defer(credit)
+	} else {
+		err = credit(sm)
+	}
+	if err != nil {
 		return nil, err
 	}
 	return tLog, nil
@@ -189,3 +231,15 @@ func DepositGas(ctx context.Context, sm protocol.StateManager, amount *big.Int,
 func isZero(a *big.Int) bool {
 	return a == nil || len(a.Bytes()) == 0
 }
+
+// subBalanceError attaches the status of the receipt to the error of subtracting the balance of an account
+func subBalanceError(err error) error {
+	switch errors.Cause(err) {
+	case state.ErrNotEnoughBalance:
+		return action.NewReceiptStatusError(action.ReceiptStatus(iotextypes.ReceiptStatus_ErrNotEnoughBalance), err)
+	case state.ErrInvalidAmount:
+		return action.NewReceiptStatusError(action.ReceiptStatusErrRewardingInvalidAmount, err)
+	default:
+		return err
+	}
+}
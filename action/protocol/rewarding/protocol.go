@@ -8,6 +8,7 @@ package rewarding
 import (
 	"context"
 	"math/big"
+	"strconv"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -29,6 +30,15 @@ const (
 	// TODO: it works only for one instance per protocol definition now
 	_protocolID           = "rewarding"
 	_v2RewardingNamespace = "Rewarding"
+
+	// the height-gated parameters of the protocol
+	_paramBlockReward              = "blockReward"
+	_paramEpochReward              = "epochReward"
+	_paramV2Storage                = "v2Storage"
+	_paramFoundationBonusExtension = "foundationBonusExtension"
+	_paramClaimRewardAddress       = "claimRewardAddress"
+	_paramPayoutSplit              = "payoutSplit"
+	_paramSupplyTracking           = "supplyTracking"
 )
 
 var (
@@ -98,23 +108,80 @@ func FindProtocol(registry *protocol.Registry) *Protocol {
 	return rp
 }
 
+// DeclareParameters declares the height-gated parameters of the protocol
+func (p *Protocol) DeclareParameters(g genesis.Genesis) []protocol.Parameter {
+	params := []protocol.Parameter{
+		{Name: _paramBlockReward, Value: g.BlockRewardStr},
+		{Name: _paramEpochReward, Value: g.EpochRewardStr},
+		{Name: _paramV2Storage, Value: strconv.FormatBool(g.IsGreenland(0))},
+		{Name: _paramClaimRewardAddress, Value: strconv.FormatBool(g.IsUpernavik(0))},
+		{Name: _paramPayoutSplit, Value: strconv.FormatBool(g.IsToBeEnabled(0))},
+		{Name: _paramSupplyTracking, Value: strconv.FormatBool(g.IsToBeEnabled(0))},
+	}
+	flag := func(name string, height uint64) {
+		if height > 0 {
+			params = append(params, protocol.Parameter{Name: name, Value: protocol.ParameterEnabled, Height: height})
+		}
+	}
+	flag(_paramV2Storage, g.GreenlandBlockHeight)
+	flag(_paramClaimRewardAddress, g.UpernavikBlockHeight)
+	flag(_paramPayoutSplit, g.ToBeEnabledBlockHeight)
+	flag(_paramSupplyTracking, g.ToBeEnabledBlockHeight)
+	// the states are changed in CreatePreStates at most once per block, by the first activation at the height in the
+	// order below
+	changed := map[uint64]bool{0: true}
+	for _, v := range []protocol.Parameter{
+		{Name: _paramEpochReward, Value: g.AleutianEpochRewardStr, Height: g.AleutianBlockHeight},
+		{Name: _paramBlockReward, Value: g.DardanellesBlockRewardStr, Height: g.DardanellesBlockHeight},
+		{Name: _paramV2Storage, Height: g.GreenlandBlockHeight},
+		{Name: _paramFoundationBonusExtension, Value: protocol.ParameterEnabled, Height: g.KamchatkaBlockHeight},
+	} {
+		if changed[v.Height] {
+			continue
+		}
+		changed[v.Height] = true
+		if v.Value != "" {
+			params = append(params, v)
+		}
+	}
+	return params
+}
+
 // CreatePreStates updates state manager
 func (p *Protocol) CreatePreStates(ctx context.Context, sm protocol.StateManager) error {
-	g := genesis.MustExtractGenesisContext(ctx)
-	blkCtx := protocol.MustGetBlockCtx(ctx)
-	switch blkCtx.BlockHeight {
-	case g.AleutianBlockHeight:
-		return p.SetReward(ctx, sm, g.AleutianEpochReward(), false)
-	case g.DardanellesBlockHeight:
-		return p.SetReward(ctx, sm, g.DardanellesBlockReward(), true)
-	case g.GreenlandBlockHeight:
+	var (
+		params = protocol.MustGetParameterSet(ctx, p)
+		height = protocol.MustGetBlockCtx(ctx).BlockHeight
+	)
+	switch {
+	case params.IsActivatedAt(_protocolID, _paramEpochReward, height):
+		epochReward, ok := params.Value(_protocolID, _paramEpochReward, height)
+		if !ok {
+			return errors.Errorf("epoch reward is not in force at height %d", height)
+		}
+		return p.setRewardParameter(ctx, sm, epochReward, false)
+	case params.IsActivatedAt(_protocolID, _paramBlockReward, height):
+		blockReward, ok := params.Value(_protocolID, _paramBlockReward, height)
+		if !ok {
+			return errors.Errorf("block reward is not in force at height %d", height)
+		}
+		return p.setRewardParameter(ctx, sm, blockReward, true)
+	case params.IsActivatedAt(_protocolID, _paramV2Storage, height):
 		return p.migrateValueGreenland(ctx, sm)
-	case g.KamchatkaBlockHeight:
+	case params.IsActivatedAt(_protocolID, _paramFoundationBonusExtension, height):
 		return p.setFoundationBonusExtension(ctx, sm)
 	}
 	return nil
 }
 
+func (p *Protocol) setRewardParameter(ctx context.Context, sm protocol.StateManager, value string, blockLevel bool) error {
+	amount, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return errors.Errorf("invalid reward amount %s", value)
+	}
+	return p.SetReward(ctx, sm, amount, blockLevel)
+}
+
 func (p *Protocol) migrateValueGreenland(_ context.Context, sm protocol.StateManager) error {
 	if err := p.migrateValue(sm, _adminKey, &admin{}); err != nil {
 		return err
@@ -162,8 +229,9 @@ func (p *Protocol) setFoundationBonusExtension(ctx context.Context, sm protocol.
 func (p *Protocol) CreatePostSystemActions(ctx context.Context, _ protocol.StateReader) ([]action.Envelope, error) {
 	blkCtx := protocol.MustGetBlockCtx(ctx)
 	grants := []action.Envelope{createGrantRewardAction(action.BlockReward, blkCtx.BlockHeight)}
-	rp := rolldpos.FindProtocol(protocol.MustGetRegistry(ctx))
-	if rp != nil && blkCtx.BlockHeight == rp.GetEpochLastBlockHeight(rp.GetEpochNum(blkCtx.BlockHeight)) {
+	// the epoch reward is settled at the last block of an epoch, after the system actions of the protocols registered
+	// ahead of rewarding, e.g., the poll result of the next epoch
+	if epochCtx, ok := protocol.GetEpochCtx(ctx); ok && blkCtx.BlockHeight == epochCtx.EpochLastHeight {
 		grants = append(grants, createGrantRewardAction(action.EpochReward, blkCtx.BlockHeight))
 	}
 
@@ -194,7 +262,7 @@ func (p *Protocol) Validate(ctx context.Context, act action.Action, sr protocol.
 			return errors.New("invalid gas price or intrinsic gas for reward action")
 		}
 	case *action.ClaimFromRewardingFund:
-		if !protocol.MustGetFeatureCtx(ctx).AddClaimRewardAddress && act.Address() != nil {
+		if !protocol.IsParameterEnabled(ctx, p, _paramClaimRewardAddress) && act.Address() != nil {
 			return errors.New("claim reward address not enabled yet")
 		}
 	}
@@ -220,7 +288,7 @@ func (p *Protocol) Handle(
 		rlog, err := p.Deposit(ctx, sm, act.Amount(), iotextypes.TransactionLogType_DEPOSIT_TO_REWARDING_FUND)
 		if err != nil {
 			log.L().Debug("Error when handling rewarding action", zap.Error(err))
-			return p.settleUserAction(ctx, sm, dynamicGasAct, uint64(iotextypes.ReceiptStatus_Failure), si, nil)
+			return p.settleUserAction(ctx, sm, dynamicGasAct, failureStatus(ctx, err), si, nil)
 		}
 		return p.settleUserAction(ctx, sm, dynamicGasAct, uint64(iotextypes.ReceiptStatus_Success), si, nil, rlog...)
 	case *action.ClaimFromRewardingFund:
@@ -231,26 +299,23 @@ func (p *Protocol) Handle(
 		rlog, err := p.Claim(ctx, sm, act.Amount(), addr)
 		if err != nil {
 			log.L().Debug("Error when handling rewarding action", zap.Error(err))
-			return p.settleUserAction(ctx, sm, dynamicGasAct, uint64(iotextypes.ReceiptStatus_Failure), si, nil)
+			return p.settleUserAction(ctx, sm, dynamicGasAct, failureStatus(ctx, err), si, nil)
 		}
 		return p.settleUserAction(ctx, sm, dynamicGasAct, uint64(iotextypes.ReceiptStatus_Success), si, nil, rlog)
 	case *action.GrantReward:
 		switch act.RewardType() {
 		case action.BlockReward:
-			rewardLog, err := p.GrantBlockReward(ctx, sm)
+			rewardLogs, err := p.GrantBlockReward(ctx, sm)
 			if err != nil {
 				log.L().Debug("Error when handling rewarding action", zap.Error(err))
-				return p.settleSystemAction(ctx, sm, dynamicGasAct, uint64(iotextypes.ReceiptStatus_Failure), si, nil)
+				return p.settleSystemAction(ctx, sm, dynamicGasAct, failureStatus(ctx, err), si, nil)
 			}
-			if rewardLog == nil {
-				return p.settleSystemAction(ctx, sm, dynamicGasAct, uint64(iotextypes.ReceiptStatus_Success), si, nil)
-			}
-			return p.settleSystemAction(ctx, sm, dynamicGasAct, uint64(iotextypes.ReceiptStatus_Success), si, []*action.Log{rewardLog})
+			return p.settleSystemAction(ctx, sm, dynamicGasAct, uint64(iotextypes.ReceiptStatus_Success), si, rewardLogs)
 		case action.EpochReward:
 			rewardLogs, err := p.GrantEpochReward(ctx, sm)
 			if err != nil {
 				log.L().Debug("Error when handling rewarding action", zap.Error(err))
-				return p.settleSystemAction(ctx, sm, dynamicGasAct, uint64(iotextypes.ReceiptStatus_Failure), si, nil)
+				return p.settleSystemAction(ctx, sm, dynamicGasAct, failureStatus(ctx, err), si, nil)
 			}
 			return p.settleSystemAction(ctx, sm, dynamicGasAct, uint64(iotextypes.ReceiptStatus_Success), si, rewardLogs)
 		}
@@ -258,6 +323,14 @@ func (p *Protocol) Handle(
 	return nil, nil
 }
 
+// failureStatus returns the status of the receipt of an action failed by the error
+func failureStatus(ctx context.Context, err error) uint64 {
+	if !protocol.MustGetFeatureCtx(ctx).EnableExtendedReceiptStatus {
+		return uint64(iotextypes.ReceiptStatus_Failure)
+	}
+	return uint64(action.ReceiptStatusOf(err, action.ReceiptStatus(iotextypes.ReceiptStatus_Failure)))
+}
+
 // ReadState read the state on blockchain via protocol
 func (p *Protocol) ReadState(
 	ctx context.Context,
@@ -291,6 +364,8 @@ func (p *Protocol) ReadState(
 			return nil, uint64(0), err
 		}
 		return []byte(balance.String()), height, nil
+	case "RewardEstimate":
+		return p.readRewardEstimate(ctx, sr, args...)
 	default:
 		return nil, uint64(0), errors.New("corresponding method isn't found")
 	}
@@ -312,8 +387,8 @@ func (p *Protocol) Name() string {
 }
 
 // useV2Storage return true after greenland when we start using v2 storage.
-func useV2Storage(ctx context.Context) bool {
-	return protocol.MustGetFeatureCtx(ctx).UseV2Storage
+func (p *Protocol) useV2Storage(ctx context.Context) bool {
+	return protocol.IsParameterEnabled(ctx, p, _paramV2Storage)
 }
 
 func (p *Protocol) state(ctx context.Context, sm protocol.StateReader, key []byte, value interface{}) (uint64, error) {
@@ -322,7 +397,7 @@ func (p *Protocol) state(ctx context.Context, sm protocol.StateReader, key []byt
 }
 
 func (p *Protocol) stateCheckLegacy(ctx context.Context, sm protocol.StateReader, key []byte, value interface{}) (uint64, bool, error) {
-	if useV2Storage(ctx) {
+	if p.useV2Storage(ctx) {
 		h, err := p.stateV2(sm, key, value)
 		if errors.Cause(err) != state.ErrStateNotExist {
 			return h, false, err
@@ -343,7 +418,7 @@ func (p *Protocol) stateV2(sm protocol.StateReader, key []byte, value interface{
 }
 
 func (p *Protocol) putState(ctx context.Context, sm protocol.StateManager, key []byte, value interface{}) error {
-	if useV2Storage(ctx) {
+	if p.useV2Storage(ctx) {
 		return p.putStateV2(sm, key, value)
 	}
 	return p.putStateV1(sm, key, value)
@@ -362,7 +437,7 @@ func (p *Protocol) putStateV2(sm protocol.StateManager, key []byte, value interf
 }
 
 func (p *Protocol) deleteState(ctx context.Context, sm protocol.StateManager, key []byte) error {
-	if useV2Storage(ctx) {
+	if p.useV2Storage(ctx) {
 		return p.deleteStateV2(sm, key)
 	}
 	return p.deleteStateV1(sm, key)
@@ -424,7 +499,7 @@ func (p *Protocol) settleAction(
 ) (*action.Receipt, error) {
 	actionCtx := protocol.MustGetActionCtx(ctx)
 	blkCtx := protocol.MustGetBlockCtx(ctx)
-	if status == uint64(iotextypes.ReceiptStatus_Failure) {
+	if status != uint64(iotextypes.ReceiptStatus_Success) {
 		if err := sm.Revert(si); err != nil {
 			return nil, err
 		}
@@ -437,7 +512,8 @@ func (p *Protocol) settleAction(
 		}
 		depositLog, err := DepositGas(ctx, sm, gasFee, protocol.BurnGasOption(baseFee))
 		if err != nil {
-			return nil, err
+			// the caller can't afford the gas, even though the deposit or claim is reverted
+			return nil, protocol.MarkAdmissionError(protocol.MustGetFeatureCtx(ctx), err)
 		}
 		if depositLog != nil {
 			tLogs = append(tLogs, depositLog...)
@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -520,6 +521,61 @@ func TestProtocol_Handle(t *testing.T) {
 	}).AnyTimes()
 }
 
+func TestProtocol_HandleFailure(t *testing.T) {
+	testProtocol(t, func(t *testing.T, ctx context.Context, sm protocol.StateManager, p *Protocol) {
+		r := require.New(t)
+		sm.(*mock_chainmanager.MockStateManager).EXPECT().Revert(gomock.Any()).Return(nil).AnyTimes()
+		g := genesis.MustExtractGenesisContext(ctx)
+		g.ToBeEnabledBlockHeight = 1
+		ctx = protocol.WithFeatureCtx(genesis.WithGenesisContext(ctx, g))
+
+		deposit := (&action.DepositToRewardingFundBuilder{}).SetAmount(big.NewInt(2000)).Build()
+		claim := (&action.ClaimFromRewardingFundBuilder{}).SetAmount(big.NewInt(100)).Build()
+		nonce := uint64(0)
+		for _, v := range []struct {
+			deposit  bool
+			gasPrice *big.Int
+			status   uint64
+		}{
+			// the caller of 1000 affords the gas but not the amount, the action is included as failed
+			{true, big.NewInt(0), uint64(iotextypes.ReceiptStatus_ErrNotEnoughBalance)},
+			{false, big.NewInt(0), uint64(action.ReceiptStatusErrRewardingFundNotEnough)},
+			// the caller can't afford the gas, the action is skipped
+			{true, big.NewInt(1), 0},
+			{false, big.NewInt(1), 0},
+		} {
+			eb := (&action.EnvelopeBuilder{}).SetNonce(nonce).SetGasLimit(100000).SetGasPrice(v.gasPrice)
+			if v.deposit {
+				eb.SetAction(&deposit)
+			} else {
+				eb.SetAction(&claim)
+			}
+			elp := eb.Build()
+			gas, err := elp.IntrinsicGas()
+			r.NoError(err)
+			actCtx := protocol.MustGetActionCtx(ctx)
+			actCtx.Nonce, actCtx.IntrinsicGas = nonce, gas
+			receipt, err := p.Handle(protocol.WithActionCtx(ctx, actCtx), elp.Action(), sm)
+			if v.status == 0 {
+				r.ErrorIs(err, state.ErrNotEnoughBalance)
+				r.True(protocol.IsAdmissionError(err))
+				continue
+			}
+			r.NoError(err)
+			r.Equal(v.status, receipt.Status)
+			nonce++
+		}
+		// a nonce not matching the account fails the admission
+		elp := (&action.EnvelopeBuilder{}).SetNonce(nonce + 1).SetGasLimit(100000).SetGasPrice(big.NewInt(0)).
+			SetAction(&claim).Build()
+		actCtx := protocol.MustGetActionCtx(ctx)
+		actCtx.Nonce = nonce + 1
+		_, err := p.Handle(protocol.WithActionCtx(ctx, actCtx), elp.Action(), sm)
+		r.ErrorIs(err, state.ErrInvalidNonce)
+		r.True(protocol.IsAdmissionError(err))
+	}, false)
+}
+
 func TestStateCheckLegacy(t *testing.T) {
 	require := require.New(t)
 
@@ -559,9 +615,9 @@ func TestStateCheckLegacy(t *testing.T) {
 
 	for useV2 := 0; useV2 < 2; useV2++ {
 		if useV2 == 0 {
-			require.False(useV2Storage(ctx))
+			require.False(p.useV2Storage(ctx))
 		} else {
-			require.True(useV2Storage(ctx))
+			require.True(p.useV2Storage(ctx))
 		}
 		for i := 0; i < 2; i++ {
 			_, v1, err := p.stateCheckLegacy(ctx, sm, accKey, &acc)
@@ -706,3 +762,46 @@ func TestMigrateValue(t *testing.T) {
 		}
 	}, true)
 }
+
+func TestFailureStatus(t *testing.T) {
+	r := require.New(t)
+	g := genesis.Default
+	err := errors.Wrap(action.NewReceiptStatusError(action.ReceiptStatusErrRewardNotEnough, errors.New("no enough available balance")), "failed to claim")
+	for _, v := range []struct {
+		height uint64
+		status uint64
+	}{
+		{g.ToBeEnabledBlockHeight - 1, uint64(iotextypes.ReceiptStatus_Failure)},
+		{g.ToBeEnabledBlockHeight, uint64(action.ReceiptStatusErrRewardNotEnough)},
+	} {
+		ctx := genesis.WithGenesisContext(context.Background(), g)
+		ctx = protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: v.height}))
+		r.Equal(v.status, failureStatus(ctx, err))
+		r.Equal(uint64(iotextypes.ReceiptStatus_Failure), failureStatus(ctx, errors.New("unknown")))
+	}
+}
+
+func TestDeclareParameters(t *testing.T) {
+	require := require.New(t)
+	p := NewProtocol(genesis.Default.Rewarding)
+	g := genesis.Default
+	g.AleutianBlockHeight = 5
+	g.DardanellesBlockHeight = 5
+	g.GreenlandBlockHeight = 6
+	g.KamchatkaBlockHeight = 6
+	ps, err := protocol.NewParameterSet(g, p)
+	require.NoError(err)
+	// the states are changed by the first activation at the height only
+	require.True(ps.IsActivatedAt(_protocolID, _paramEpochReward, 5))
+	require.False(ps.IsActivatedAt(_protocolID, _paramBlockReward, 5))
+	require.True(ps.IsActivatedAt(_protocolID, _paramV2Storage, 6))
+	require.False(ps.IsActivatedAt(_protocolID, _paramFoundationBonusExtension, 6))
+	v, ok := ps.Value(_protocolID, _paramEpochReward, 5)
+	require.True(ok)
+	require.Equal(g.AleutianEpochRewardStr, v)
+	v, ok = ps.Value(_protocolID, _paramBlockReward, 5)
+	require.True(ok)
+	require.Equal(g.BlockRewardStr, v)
+	require.False(ps.IsEnabled(_protocolID, _paramV2Storage, 5))
+	require.True(ps.IsEnabled(_protocolID, _paramV2Storage, 6))
+}
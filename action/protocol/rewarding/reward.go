@@ -21,6 +21,7 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol/poll"
 	"github.com/iotexproject/iotex-core/action/protocol/rewarding/rewardingpb"
 	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/action/protocol/staking"
 	"github.com/iotexproject/iotex-core/pkg/enc"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/state"
@@ -69,8 +70,7 @@ func (a *rewardAccount) Deserialize(data []byte) error {
 func (p *Protocol) GrantBlockReward(
 	ctx context.Context,
 	sm protocol.StateManager,
-) (*action.Log, error) {
-	actionCtx := protocol.MustGetActionCtx(ctx)
+) ([]*action.Log, error) {
 	blkCtx := protocol.MustGetBlockCtx(ctx)
 	if err := p.assertNoRewardYet(ctx, sm, _blockRewardHistoryKeyPrefix, blkCtx.BlockHeight); err != nil {
 		return nil, err
@@ -108,28 +108,14 @@ func (p *Protocol) GrantBlockReward(
 	if err := p.updateAvailableBalance(ctx, sm, a.blockReward); err != nil {
 		return nil, err
 	}
-	if err := p.grantToAccount(ctx, sm, rewardAddr, a.blockReward); err != nil {
+	rewardLogs, err := p.grantToCandidate(ctx, sm, producerAddrStr, rewardAddr, a.blockReward, rewardingpb.RewardLog_BLOCK_REWARD)
+	if err != nil {
 		return nil, err
 	}
 	if err := p.updateRewardHistory(ctx, sm, _blockRewardHistoryKeyPrefix, blkCtx.BlockHeight); err != nil {
 		return nil, err
 	}
-	rewardLog := rewardingpb.RewardLog{
-		Type:   rewardingpb.RewardLog_BLOCK_REWARD,
-		Addr:   rewardAddrStr,
-		Amount: a.blockReward.String(),
-	}
-	data, err := proto.Marshal(&rewardLog)
-	if err != nil {
-		return nil, err
-	}
-	return &action.Log{
-		Address:     p.addr.String(),
-		Topics:      nil,
-		Data:        data,
-		BlockHeight: blkCtx.BlockHeight,
-		ActionHash:  actionCtx.ActionHash,
-	}, nil
+	return rewardLogs, nil
 }
 
 // GrantEpochReward grants the epoch reward (token) to all beneficiaries of a epoch
@@ -137,7 +123,6 @@ func (p *Protocol) GrantEpochReward(
 	ctx context.Context,
 	sm protocol.StateManager,
 ) ([]*action.Log, error) {
-	actionCtx := protocol.MustGetActionCtx(ctx)
 	blkCtx := protocol.MustGetBlockCtx(ctx)
 	featureWithHeightCtx := protocol.MustGetFeatureWithHeightCtx(ctx)
 	rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
@@ -182,7 +167,7 @@ func (p *Protocol) GrantEpochReward(
 	if err != nil {
 		return nil, err
 	}
-	addrs, amounts, err := p.splitEpochReward(epochStartHeight, sm, candidates, a.epochReward, a.numDelegatesForEpochReward, exemptAddrs, uqdMap)
+	rewardedCandidates, addrs, amounts, err := p.splitEpochReward(epochStartHeight, sm, candidates, a.epochReward, a.numDelegatesForEpochReward, exemptAddrs, uqdMap)
 	if err != nil {
 		return nil, err
 	}
@@ -197,25 +182,11 @@ func (p *Protocol) GrantEpochReward(
 		if amounts[i].Cmp(big.NewInt(0)) == 0 {
 			continue
 		}
-		if err := p.grantToAccount(ctx, sm, addrs[i], amounts[i]); err != nil {
-			return nil, err
-		}
-		rewardLog := rewardingpb.RewardLog{
-			Type:   rewardingpb.RewardLog_EPOCH_REWARD,
-			Addr:   addrs[i].String(),
-			Amount: amounts[i].String(),
-		}
-		data, err := proto.Marshal(&rewardLog)
+		logs, err := p.grantToCandidate(ctx, sm, rewardedCandidates[i].Address, addrs[i], amounts[i], rewardingpb.RewardLog_EPOCH_REWARD)
 		if err != nil {
 			return nil, err
 		}
-		rewardLogs = append(rewardLogs, &action.Log{
-			Address:     p.addr.String(),
-			Topics:      nil,
-			Data:        data,
-			BlockHeight: blkCtx.BlockHeight,
-			ActionHash:  actionCtx.ActionHash,
-		})
+		rewardLogs = append(rewardLogs, logs...)
 		actualTotalReward = big.NewInt(0).Add(actualTotalReward, amounts[i])
 	}
 
@@ -239,25 +210,11 @@ func (p *Protocol) GrantEpochReward(
 			if err != nil {
 				return nil, err
 			}
-			if err := p.grantToAccount(ctx, sm, rewardAddr, a.foundationBonus); err != nil {
-				return nil, err
-			}
-			rewardLog := rewardingpb.RewardLog{
-				Type:   rewardingpb.RewardLog_FOUNDATION_BONUS,
-				Addr:   candidates[i].RewardAddress,
-				Amount: a.foundationBonus.String(),
-			}
-			data, err := proto.Marshal(&rewardLog)
+			logs, err := p.grantToCandidate(ctx, sm, candidates[i].Address, rewardAddr, a.foundationBonus, rewardingpb.RewardLog_FOUNDATION_BONUS)
 			if err != nil {
 				return nil, err
 			}
-			rewardLogs = append(rewardLogs, &action.Log{
-				Address:     p.addr.String(),
-				Topics:      nil,
-				Data:        data,
-				BlockHeight: blkCtx.BlockHeight,
-				ActionHash:  actionCtx.ActionHash,
-			})
+			rewardLogs = append(rewardLogs, logs...)
 			actualTotalReward = big.NewInt(0).Add(actualTotalReward, a.foundationBonus)
 		}
 	}
@@ -322,7 +279,7 @@ func (p *Protocol) updateTotalBalance(ctx context.Context, sm protocol.StateMana
 	}
 	totalBalance := big.NewInt(0).Sub(f.totalBalance, amount)
 	if totalBalance.Cmp(big.NewInt(0)) < 0 {
-		return errors.New("no enough total balance")
+		return action.NewReceiptStatusError(action.ReceiptStatusErrRewardingFundNotEnough, errors.New("no enough total balance"))
 	}
 	f.totalBalance = totalBalance
 	return p.putState(ctx, sm, _fundKey, &f)
@@ -335,7 +292,7 @@ func (p *Protocol) updateAvailableBalance(ctx context.Context, sm protocol.State
 	}
 	availableBalance := big.NewInt(0).Sub(f.unclaimedBalance, amount)
 	if availableBalance.Cmp(big.NewInt(0)) < 0 {
-		return errors.New("no enough available balance")
+		return action.NewReceiptStatusError(action.ReceiptStatusErrRewardingFundNotEnough, errors.New("no enough available balance"))
 	}
 	f.unclaimedBalance = availableBalance
 	return p.putState(ctx, sm, _fundKey, &f)
@@ -355,7 +312,7 @@ func (p *Protocol) grantToAccount(ctx context.Context, sm protocol.StateManager,
 	} else {
 		// entry exist
 		// check if from legacy, and we have started using v2, delete v1
-		if fromLegacy && useV2Storage(ctx) {
+		if fromLegacy && p.useV2Storage(ctx) {
 			if err := p.deleteStateV1(sm, accKey); err != nil {
 				return err
 			}
@@ -365,6 +322,123 @@ func (p *Protocol) grantToAccount(ctx context.Context, sm protocol.StateManager,
 	return p.putState(ctx, sm, accKey, &acc)
 }
 
+// grantToCandidate grants the reward of the candidate to its reward address, or to the addresses in its payout split
+// of the epoch if it has one
+func (p *Protocol) grantToCandidate(
+	ctx context.Context,
+	sm protocol.StateManager,
+	candidate string,
+	rewardAddr address.Address,
+	amount *big.Int,
+	typ rewardingpb.RewardLog_RewardType,
+) ([]*action.Log, error) {
+	actionCtx := protocol.MustGetActionCtx(ctx)
+	blkCtx := protocol.MustGetBlockCtx(ctx)
+	rewardAddr, appliedLog, err := p.rewardAddress(ctx, sm, candidate, rewardAddr)
+	if err != nil {
+		return nil, err
+	}
+	addrs, amounts, err := p.payouts(ctx, sm, candidate, rewardAddr, amount)
+	if err != nil {
+		return nil, err
+	}
+	rewardLogs := make([]*action.Log, 0, len(addrs)+1)
+	if appliedLog != nil {
+		rewardLogs = append(rewardLogs, appliedLog)
+	}
+	for i := range addrs {
+		if err := p.grantToAccount(ctx, sm, addrs[i], amounts[i]); err != nil {
+			return nil, err
+		}
+		rewardLog := rewardingpb.RewardLog{
+			Type:   typ,
+			Addr:   addrs[i].String(),
+			Amount: amounts[i].String(),
+		}
+		data, err := proto.Marshal(&rewardLog)
+		if err != nil {
+			return nil, err
+		}
+		rewardLogs = append(rewardLogs, &action.Log{
+			Address:     p.addr.String(),
+			Topics:      nil,
+			Data:        data,
+			BlockHeight: blkCtx.BlockHeight,
+			ActionHash:  actionCtx.ActionHash,
+		})
+	}
+	return rewardLogs, nil
+}
+
+// rewardAddress returns the reward address of the candidate in the current epoch, which differs from the one given
+// if a change of the reward address takes effect since the candidates of the epoch were read. The change is applied
+// by the first grant after it takes effect, which returns the receipt log of the change
+func (p *Protocol) rewardAddress(
+	ctx context.Context,
+	sm protocol.StateManager,
+	candidate string,
+	rewardAddr address.Address,
+) (address.Address, *action.Log, error) {
+	featureCtx, ok := protocol.GetFeatureCtx(ctx)
+	if !ok || !featureCtx.EnableRewardAddressDelay {
+		return rewardAddr, nil, nil
+	}
+	registry := protocol.MustGetRegistry(ctx)
+	sp := staking.FindProtocol(registry)
+	if sp == nil {
+		return rewardAddr, nil, nil
+	}
+	operator, err := address.FromString(candidate)
+	if err != nil {
+		return nil, nil, err
+	}
+	epoch := rolldpos.MustGetProtocol(registry).GetEpochNum(protocol.MustGetBlockCtx(ctx).BlockHeight)
+	addr, appliedLog, err := sp.SettleRewardAddress(ctx, sm, operator, epoch)
+	if err != nil {
+		return nil, nil, err
+	}
+	if addr == nil {
+		return rewardAddr, nil, nil
+	}
+	return addr, appliedLog, nil
+}
+
+// payouts splits the reward of the candidate by its payout split in the current epoch, the residue of the rounding
+// goes to the first address of the split
+func (p *Protocol) payouts(
+	ctx context.Context,
+	sm protocol.StateManager,
+	candidate string,
+	rewardAddr address.Address,
+	amount *big.Int,
+) ([]address.Address, []*big.Int, error) {
+	if _, ok := protocol.GetFeatureCtx(ctx); !ok || !protocol.IsParameterEnabled(ctx, p, _paramPayoutSplit) {
+		return []address.Address{rewardAddr}, []*big.Int{amount}, nil
+	}
+	registry := protocol.MustGetRegistry(ctx)
+	sp := staking.FindProtocol(registry)
+	if sp == nil {
+		return []address.Address{rewardAddr}, []*big.Int{amount}, nil
+	}
+	operator, err := address.FromString(candidate)
+	if err != nil {
+		return nil, nil, err
+	}
+	epoch := rolldpos.MustGetProtocol(registry).GetEpochNum(protocol.MustGetBlockCtx(ctx).BlockHeight)
+	split, err := sp.PayoutSplit(sm, operator, epoch)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(split) == 0 {
+		return []address.Address{rewardAddr}, []*big.Int{amount}, nil
+	}
+	addrs := make([]address.Address, len(split))
+	for i := range split {
+		addrs[i] = split[i].Address
+	}
+	return addrs, action.SplitPayout(amount, split), nil
+}
+
 func (p *Protocol) claimFromAccount(ctx context.Context, sm protocol.StateManager, addr address.Address, amount *big.Int) error {
 	// Update reward account
 	acc := rewardAccount{}
@@ -375,14 +449,14 @@ func (p *Protocol) claimFromAccount(ctx context.Context, sm protocol.StateManage
 	}
 	balance := big.NewInt(0).Sub(acc.balance, amount)
 	if balance.Cmp(big.NewInt(0)) < 0 {
-		return errors.New("no enough available balance")
+		return action.NewReceiptStatusError(action.ReceiptStatusErrRewardNotEnough, errors.New("no enough available balance"))
 	}
 	// TODO: we may want to delete the account when the unclaimed balance becomes 0
 	acc.balance = balance
 	if err := p.putState(ctx, sm, accKey, &acc); err != nil {
 		return err
 	}
-	if fromLegacy && useV2Storage(ctx) {
+	if fromLegacy && p.useV2Storage(ctx) {
 		if err := p.deleteStateV1(sm, accKey); err != nil {
 			return err
 		}
@@ -416,7 +490,7 @@ func (p *Protocol) splitEpochReward(
 	numDelegatesForEpochReward uint64,
 	exemptAddrs map[string]interface{},
 	uqd map[string]bool,
-) ([]address.Address, []*big.Int, error) {
+) ([]*state.Candidate, []address.Address, []*big.Int, error) {
 	filteredCandidates := make([]*state.Candidate, 0)
 	for _, candidate := range candidates {
 		if _, ok := exemptAddrs[candidate.Address]; ok {
@@ -426,7 +500,7 @@ func (p *Protocol) splitEpochReward(
 	}
 	candidates = filteredCandidates
 	if len(candidates) == 0 {
-		return nil, nil, nil
+		return nil, nil, nil, nil
 	}
 	// We at most allow numDelegatesForEpochReward delegates to get the epoch reward
 	if uint64(len(candidates)) > numDelegatesForEpochReward {
@@ -440,7 +514,7 @@ func (p *Protocol) splitEpochReward(
 		if candidate.RewardAddress != "" {
 			rewardAddr, err = address.FromString(candidate.RewardAddress)
 			if err != nil {
-				return nil, nil, err
+				return nil, nil, nil, err
 			}
 		} else {
 			log.S().Warnf("Candidate %s doesn't have a reward address", candidate.Address)
@@ -463,7 +537,7 @@ func (p *Protocol) splitEpochReward(
 		amountPerAddr = big.NewInt(0).Div(big.NewInt(0).Mul(totalAmount, candidate.Votes), totalWeight)
 		amounts = append(amounts, amountPerAddr)
 	}
-	return rewardAddrs, amounts, nil
+	return candidates, rewardAddrs, amounts, nil
 }
 
 func (p *Protocol) assertNoRewardYet(ctx context.Context, sm protocol.StateManager, prefix []byte, index uint64) error {
@@ -47,11 +47,12 @@ func TestProtocol_GrantBlockReward(t *testing.T) {
 		require.NoError(t, err)
 
 		// Grant block reward
-		rewardLog, err := p.GrantBlockReward(ctx, sm)
+		rewardLogs, err := p.GrantBlockReward(ctx, sm)
 		require.NoError(t, err)
-		require.Equal(t, p.addr.String(), rewardLog.Address)
+		require.Len(t, rewardLogs, 1)
+		require.Equal(t, p.addr.String(), rewardLogs[0].Address)
 		var rl rewardingpb.RewardLog
-		require.NoError(t, proto.Unmarshal(rewardLog.Data, &rl))
+		require.NoError(t, proto.Unmarshal(rewardLogs[0].Data, &rl))
 		require.Equal(t, rewardingpb.RewardLog_BLOCK_REWARD, rl.Type)
 		require.Equal(t, "10", rl.Amount)
 
@@ -211,11 +212,12 @@ func TestProtocol_ClaimReward(t *testing.T) {
 		require.NoError(t, err)
 
 		// Grant block reward
-		rewardLog, err := p.GrantBlockReward(ctx, sm)
+		rewardLogs, err := p.GrantBlockReward(ctx, sm)
 		require.NoError(t, err)
-		require.Equal(t, p.addr.String(), rewardLog.Address)
+		require.Len(t, rewardLogs, 1)
+		require.Equal(t, p.addr.String(), rewardLogs[0].Address)
 		var rl rewardingpb.RewardLog
-		require.NoError(t, proto.Unmarshal(rewardLog.Data, &rl))
+		require.NoError(t, proto.Unmarshal(rewardLogs[0].Data, &rl))
 		require.Equal(t, rewardingpb.RewardLog_BLOCK_REWARD, rl.Type)
 		require.Equal(t, "10", rl.Amount)
 
@@ -0,0 +1,175 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rewarding
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-address/address"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding/rewardingpb"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+const (
+	// _supplyNamespace is the namespace of the supply tracking, which stores the base fee burnt and redistributed
+	// in total under _supplyKey, and in each block under the height
+	_supplyNamespace = "Supply"
+)
+
+var (
+	_supplyKey = []byte("sup")
+)
+
+type (
+	// supply is the amount of the base fee burnt and redistributed into the rewarding fund
+	supply struct {
+		burned        *big.Int
+		redistributed *big.Int
+	}
+
+	// Supply is the supply of token at a height
+	Supply struct {
+		Height uint64
+		// Total is the genesis allocation minus the amount burnt
+		Total *big.Int
+		// Circulating is the total supply minus the available balance of the rewarding fund, as the rewards come into
+		// circulation when they are granted from the fund
+		Circulating *big.Int
+		// Burned and Redistributed are the base fee burnt and redistributed up to the height
+		Burned        *big.Int
+		Redistributed *big.Int
+		// BlockBurned and BlockRedistributed are the base fee burnt and redistributed in the block at the height
+		BlockBurned        *big.Int
+		BlockRedistributed *big.Int
+	}
+)
+
+func newSupply() *supply {
+	return &supply{
+		burned:        big.NewInt(0),
+		redistributed: big.NewInt(0),
+	}
+}
+
+// Serialize serializes supply into bytes
+func (s *supply) Serialize() ([]byte, error) {
+	return proto.Marshal(&rewardingpb.Supply{
+		Burned:        s.burned.String(),
+		Redistributed: s.redistributed.String(),
+	})
+}
+
+// Deserialize deserializes bytes into supply
+func (s *supply) Deserialize(data []byte) error {
+	gen := rewardingpb.Supply{}
+	if err := proto.Unmarshal(data, &gen); err != nil {
+		return err
+	}
+	burned, ok := new(big.Int).SetString(gen.Burned, 10)
+	if !ok {
+		return errors.New("failed to set burned amount")
+	}
+	redistributed, ok := new(big.Int).SetString(gen.Redistributed, 10)
+	if !ok {
+		return errors.New("failed to set redistributed amount")
+	}
+	s.burned = burned
+	s.redistributed = redistributed
+	return nil
+}
+
+// totalSupplyChange returns the base fee burnt and redistributed in total. Before the first record, the burnt amount
+// is the balance of the zero address, where the base fee has been burnt to
+func totalSupplyChange(ctx context.Context, sr protocol.StateReader) (*supply, error) {
+	s := newSupply()
+	_, err := sr.State(s, protocol.NamespaceOption(_supplyNamespace), protocol.KeyOption(_supplyKey))
+	switch errors.Cause(err) {
+	case nil:
+		return s, nil
+	case state.ErrStateNotExist:
+		burnAddr, _ := address.FromString(address.ZeroAddress)
+		acc, err := accountutil.AccountState(ctx, sr, burnAddr)
+		if err != nil {
+			return nil, err
+		}
+		s.burned.Set(acc.Balance)
+		return s, nil
+	default:
+		return nil, err
+	}
+}
+
+// blockSupplyChange returns the base fee burnt and redistributed in the block at height
+func blockSupplyChange(sr protocol.StateReader, height uint64) (*supply, error) {
+	s := newSupply()
+	_, err := sr.State(s, protocol.NamespaceOption(_supplyNamespace), protocol.KeyOption(byteutil.Uint64ToBytesBigEndian(height)))
+	if err != nil && errors.Cause(err) != state.ErrStateNotExist {
+		return nil, err
+	}
+	return s, nil
+}
+
+// recordSupplyChange adds the base fee burnt and redistributed to the total and the record of the current block
+func recordSupplyChange(ctx context.Context, sm protocol.StateManager, burned, redistributed *big.Int) error {
+	height := protocol.MustGetBlockCtx(ctx).BlockHeight
+	total, err := totalSupplyChange(ctx, sm)
+	if err != nil {
+		return err
+	}
+	blk, err := blockSupplyChange(sm, height)
+	if err != nil {
+		return err
+	}
+	for _, s := range []*supply{total, blk} {
+		s.burned.Add(s.burned, burned)
+		s.redistributed.Add(s.redistributed, redistributed)
+	}
+	if _, err := sm.PutState(total, protocol.NamespaceOption(_supplyNamespace), protocol.KeyOption(_supplyKey)); err != nil {
+		return err
+	}
+	_, err = sm.PutState(blk, protocol.NamespaceOption(_supplyNamespace), protocol.KeyOption(byteutil.Uint64ToBytesBigEndian(height)))
+	return err
+}
+
+// TotalSupply returns the total and circulating supply of token, along with the base fee burnt and redistributed
+func (p *Protocol) TotalSupply(ctx context.Context, sr protocol.StateReader) (*Supply, error) {
+	height, err := sr.Height()
+	if err != nil {
+		return nil, err
+	}
+	total, err := totalSupplyChange(ctx, sr)
+	if err != nil {
+		return nil, err
+	}
+	blk, err := blockSupplyChange(sr, height)
+	if err != nil {
+		return nil, err
+	}
+	available, _, err := p.AvailableBalance(ctx, sr)
+	if err != nil {
+		return nil, err
+	}
+	g := genesis.MustExtractGenesisContext(ctx)
+	s := &Supply{
+		Height:             height,
+		Total:              new(big.Int).Sub(g.TotalAllocation(), total.burned),
+		Burned:             total.burned,
+		Redistributed:      total.redistributed,
+		BlockBurned:        blk.burned,
+		BlockRedistributed: blk.redistributed,
+	}
+	s.Circulating = new(big.Int).Sub(s.Total, available)
+	return s, nil
+}
@@ -232,6 +232,16 @@ func (p *Protocol) GetEpochLastBlockHeight(epochNum uint64) uint64 {
 	return p.GetEpochHeight(epochNum+1) - 1
 }
 
+// GetEpochCtx returns the epoch of a block height
+func (p *Protocol) GetEpochCtx(height uint64) protocol.EpochCtx {
+	epochNum := p.GetEpochNum(height)
+	return protocol.EpochCtx{
+		EpochNum:         epochNum,
+		EpochStartHeight: p.GetEpochHeight(epochNum),
+		EpochLastHeight:  p.GetEpochLastBlockHeight(epochNum),
+	}
+}
+
 // GetSubEpochNum returns the sub epoch number of a block height
 func (p *Protocol) GetSubEpochNum(height uint64) uint64 {
 	return (height - p.GetEpochHeight(p.GetEpochNum(height))) / p.numDelegates
@@ -0,0 +1,184 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+// ErrBootstrapVotesMismatch indicates the votes of a bootstrap candidate differ from the expected ones
+var ErrBootstrapVotesMismatch = errors.New("votes of bootstrap candidate mismatch")
+
+// ExportBootstrap exports the candidates and the native buckets at the height of sr in the genesis bootstrap format,
+// so a new chain boots with the same candidates and votes. The buckets are renumbered in the order of their indices,
+// the endorsement expire heights are rebased to the export height, and the pending changes of the candidates, e.g.
+// of the reward address or the payout split, aren't exported
+func ExportBootstrap(sr protocol.StateReader) ([]genesis.BootstrapCandidate, []genesis.BootstrapBucket, uint64, error) {
+	csr := newCandidateStateReader(sr).(*candSR)
+	cands, height, err := csr.getAllCandidates()
+	if err != nil && errors.Cause(err) != state.ErrStateNotExist {
+		return nil, nil, 0, errors.Wrap(err, "failed to read candidates")
+	}
+	buckets, _, err := csr.getAllBuckets()
+	if err != nil && errors.Cause(err) != state.ErrStateNotExist {
+		return nil, nil, 0, errors.Wrap(err, "failed to read buckets")
+	}
+	sort.Slice(cands, func(i, j int) bool { return cands[i].Name < cands[j].Name })
+	selfStakes := make(map[uint64]struct{}, len(cands))
+	bcs := make([]genesis.BootstrapCandidate, 0, len(cands))
+	for _, c := range cands {
+		bc := genesis.BootstrapCandidate{
+			OwnerAddress:      c.Owner.String(),
+			OperatorAddress:   c.Operator.String(),
+			RewardAddress:     c.Reward.String(),
+			Name:              c.Name,
+			SelfStakingTokens: "0",
+			Votes:             c.Votes.String(),
+		}
+		if c.Identifier != nil {
+			bc.Identifier = c.Identifier.String()
+		}
+		if c.isSelfStakeBucketSettled() {
+			selfStakes[c.SelfStakeBucketIdx] = struct{}{}
+		}
+		bcs = append(bcs, bc)
+	}
+	esr := NewEndorsementStateReader(sr)
+	bbs := make([]genesis.BootstrapBucket, 0, len(buckets))
+	for _, b := range buckets {
+		_, selfStake := selfStakes[b.Index]
+		bb := genesis.BootstrapBucket{
+			OwnerAddress:     b.Owner.String(),
+			CandidateAddress: b.Candidate.String(),
+			StakedAmount:     b.StakedAmount.String(),
+			StakedDuration:   uint32(b.StakedDuration / 24 / time.Hour),
+			AutoStake:        b.AutoStake,
+			Unstaked:         b.isUnstaked(),
+			SelfStake:        selfStake,
+		}
+		endorse, err := esr.Get(b.Index)
+		switch errors.Cause(err) {
+		case nil:
+			bb.EndorseExpireHeight = rebaseEndorseExpireHeight(endorse.ExpireHeight, height)
+		case state.ErrStateNotExist:
+		default:
+			return nil, nil, 0, errors.Wrapf(err, "failed to read the endorsement of bucket %d", b.Index)
+		}
+		bbs = append(bbs, bb)
+	}
+	return bcs, bbs, height, nil
+}
+
+func rebaseEndorseExpireHeight(expireHeight, height uint64) uint64 {
+	switch {
+	case expireHeight == endorsementNotExpireHeight:
+		return expireHeight
+	case expireHeight <= height:
+		return 1
+	default:
+		return expireHeight - height
+	}
+}
+
+// bootstrapTime returns the create time of the bootstrap buckets, the timestamp of the genesis block
+func bootstrapTime(ctx context.Context) time.Time {
+	if blkCtx, ok := protocol.GetBlockCtx(ctx); ok && !blkCtx.BlockTimeStamp.IsZero() {
+		return blkCtx.BlockTimeStamp
+	}
+	return time.Now()
+}
+
+// createBootstrapBuckets creates the bootstrap buckets and adds their votes to the candidates
+func (p *Protocol) createBootstrapBuckets(ctx context.Context, csm CandidateStateManager) error {
+	ctime := bootstrapTime(ctx)
+	esm := NewEndorsementStateManager(csm.SM())
+	for i, bb := range p.config.BootstrapBuckets {
+		owner, err := address.FromString(bb.OwnerAddress)
+		if err != nil {
+			return err
+		}
+		candAddr, err := address.FromString(bb.CandidateAddress)
+		if err != nil {
+			return err
+		}
+		amount, ok := new(big.Int).SetString(bb.StakedAmount, 10)
+		if !ok {
+			return action.ErrInvalidAmount
+		}
+		cand := csm.GetByIdentifier(candAddr)
+		if cand == nil {
+			return errors.Wrapf(ErrInvalidOwner, "candidate %s of bootstrap bucket %d doesn't exist", bb.CandidateAddress, i)
+		}
+		bucket := NewVoteBucket(candAddr, owner, amount, bb.StakedDuration, ctime, bb.AutoStake)
+		if bb.Unstaked {
+			// the unstaked bucket has matured at genesis
+			bucket.CreateTime = bucket.CreateTime.Add(-bucket.StakedDuration)
+			bucket.StakeStartTime = bucket.CreateTime
+			bucket.UnstakeStartTime = ctime.UTC()
+		}
+		bucketIdx, err := csm.putBucketAndIndex(bucket)
+		if err != nil {
+			return err
+		}
+		if bb.EndorseExpireHeight != 0 {
+			if err := esm.Put(bucketIdx, &Endorsement{ExpireHeight: bb.EndorseExpireHeight}); err != nil {
+				return err
+			}
+		}
+		if !bb.Unstaked {
+			if err := cand.AddVote(p.calculateVoteWeight(bucket, bb.SelfStake)); err != nil {
+				return err
+			}
+		}
+		if bb.SelfStake {
+			cand.SelfStakeBucketIdx = bucketIdx
+			cand.SelfStake = amount
+		}
+		if err := csm.Upsert(cand); err != nil {
+			return err
+		}
+		if err := csm.DebitBucketPool(amount, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateBootstrapVotes checks the candidates have the expected votes of the genesis
+func (p *Protocol) validateBootstrapVotes(csm CandidateStateManager) error {
+	for _, bc := range p.config.BootstrapCandidates {
+		if bc.Votes == "" {
+			continue
+		}
+		id := bc.OwnerAddress
+		if bc.Identifier != "" {
+			id = bc.Identifier
+		}
+		addr, err := address.FromString(id)
+		if err != nil {
+			return err
+		}
+		cand := csm.GetByIdentifier(addr)
+		if cand == nil {
+			return errors.Wrapf(ErrInvalidOwner, "bootstrap candidate %s doesn't exist", bc.Name)
+		}
+		if cand.Votes.String() != bc.Votes {
+			return errors.Wrapf(ErrBootstrapVotesMismatch, "candidate %s, expected %s, got %s", bc.Name, bc.Votes, cand.Votes.String())
+		}
+	}
+	return nil
+}
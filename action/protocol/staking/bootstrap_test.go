@@ -0,0 +1,183 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"math"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil/testdb"
+)
+
+func TestExportBootstrap(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	ctx := protocol.WithBlockCtx(
+		genesis.WithGenesisContext(context.Background(), genesis.Default),
+		protocol.BlockCtx{
+			BlockHeight:    genesis.Default.GreenlandBlockHeight - 1,
+			BlockTimeStamp: time.Unix(genesis.Default.Timestamp, 0),
+		},
+	)
+	reg := protocol.NewRegistry()
+	r.NoError(rolldpos.NewProtocol(1, 1, 1).Register(reg))
+	ctx = protocol.WithRegistry(protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx)), reg)
+	boot := func(cfg genesis.Staking) (*Protocol, protocol.StateManager, error) {
+		sm := testdb.NewMockStateManager(ctrl)
+		p, err := NewProtocol(HelperCtx{
+			BlockInterval: getBlockInterval,
+		}, &BuilderConfig{
+			Staking:                  cfg,
+			PersistStakingPatchBlock: math.MaxUint64,
+			Revise: ReviseConfig{
+				VoteWeight: genesis.Default.Staking.VoteWeightCalConsts,
+			},
+		}, nil, nil, nil)
+		r.NoError(err)
+		v, err := p.Start(ctx, sm)
+		r.NoError(err)
+		r.NoError(sm.WriteView(_protocolID, v))
+		return p, sm, p.CreateGenesisStates(ctx, sm)
+	}
+	readState := func(p *Protocol, sm protocol.StateManager, m iotexapi.ReadStakingDataMethod_Name, req *iotexapi.ReadStakingDataRequest) []byte {
+		method, err := proto.Marshal(&iotexapi.ReadStakingDataMethod{Method: m})
+		r.NoError(err)
+		arg, err := proto.Marshal(req)
+		r.NoError(err)
+		data, _, err := p.ReadState(ctx, sm, method, arg)
+		r.NoError(err)
+		if m != iotexapi.ReadStakingDataMethod_CANDIDATES {
+			return data
+		}
+		// the candidates are listed in no particular order
+		cands := &iotextypes.CandidateListV2{}
+		r.NoError(proto.Unmarshal(data, cands))
+		sort.Slice(cands.Candidates, func(i, j int) bool { return cands.Candidates[i].Name < cands.Candidates[j].Name })
+		data, err = proto.Marshal(cands)
+		r.NoError(err)
+		return data
+	}
+	pagination := &iotexapi.PaginationParam{Offset: 0, Limit: 100}
+	requests := []struct {
+		method iotexapi.ReadStakingDataMethod_Name
+		req    *iotexapi.ReadStakingDataRequest
+	}{
+		{iotexapi.ReadStakingDataMethod_CANDIDATES, &iotexapi.ReadStakingDataRequest{
+			Request: &iotexapi.ReadStakingDataRequest_Candidates_{Candidates: &iotexapi.ReadStakingDataRequest_Candidates{Pagination: pagination}},
+		}},
+		{iotexapi.ReadStakingDataMethod_BUCKETS, &iotexapi.ReadStakingDataRequest{
+			Request: &iotexapi.ReadStakingDataRequest_Buckets{Buckets: &iotexapi.ReadStakingDataRequest_VoteBuckets{Pagination: pagination}},
+		}},
+		{iotexapi.ReadStakingDataMethod_BUCKETS_COUNT, &iotexapi.ReadStakingDataRequest{
+			Request: &iotexapi.ReadStakingDataRequest_BucketsCount_{BucketsCount: &iotexapi.ReadStakingDataRequest_BucketsCount{}},
+		}},
+		{iotexapi.ReadStakingDataMethod_TOTAL_STAKING_AMOUNT, &iotexapi.ReadStakingDataRequest{
+			Request: &iotexapi.ReadStakingDataRequest_TotalStakingAmount_{TotalStakingAmount: &iotexapi.ReadStakingDataRequest_TotalStakingAmount{}},
+		}},
+	}
+
+	// the source chain has a candidate with a self-stake bucket, and a candidate self-staked by an endorsed bucket
+	source := genesis.Default.Staking
+	source.BootstrapCandidates = []genesis.BootstrapCandidate{
+		{
+			OwnerAddress:      identityset.Address(22).String(),
+			OperatorAddress:   identityset.Address(23).String(),
+			RewardAddress:     identityset.Address(23).String(),
+			Name:              "alpha",
+			SelfStakingTokens: unit.ConvertIotxToRau(1200000).String(),
+		},
+		{
+			OwnerAddress:      identityset.Address(24).String(),
+			OperatorAddress:   identityset.Address(25).String(),
+			RewardAddress:     identityset.Address(25).String(),
+			Name:              "beta",
+			SelfStakingTokens: "0",
+		},
+	}
+	source.BootstrapBuckets = []genesis.BootstrapBucket{
+		{
+			OwnerAddress:        identityset.Address(26).String(),
+			CandidateAddress:    identityset.Address(24).String(),
+			StakedAmount:        unit.ConvertIotxToRau(1200000).String(),
+			StakedDuration:      91,
+			AutoStake:           true,
+			SelfStake:           true,
+			EndorseExpireHeight: math.MaxUint64,
+		},
+		{
+			OwnerAddress:     identityset.Address(27).String(),
+			CandidateAddress: identityset.Address(22).String(),
+			StakedAmount:     unit.ConvertIotxToRau(100).String(),
+			StakedDuration:   30,
+			AutoStake:        true,
+		},
+		{
+			OwnerAddress:     identityset.Address(28).String(),
+			CandidateAddress: identityset.Address(24).String(),
+			StakedAmount:     unit.ConvertIotxToRau(50).String(),
+			StakedDuration:   7,
+			Unstaked:         true,
+		},
+	}
+	srcP, srcSM, err := boot(source)
+	r.NoError(err)
+
+	cands, buckets, _, err := ExportBootstrap(srcSM)
+	r.NoError(err)
+	r.Len(cands, 2)
+	r.Len(buckets, 4)
+	for _, c := range cands {
+		r.Equal("0", c.SelfStakingTokens)
+		r.NotEmpty(c.Votes)
+	}
+	r.True(buckets[0].SelfStake)
+	r.Equal(source.BootstrapBuckets, buckets[1:])
+	r.NoError(genesis.ValidateGenesis(&genesis.Genesis{
+		Blockchain: genesis.Default.Blockchain,
+		Account:    genesis.Default.Account,
+		Poll:       genesis.Default.Poll,
+		Rewarding:  genesis.Default.Rewarding,
+		Staking: func() genesis.Staking {
+			s := source
+			s.BootstrapCandidates, s.BootstrapBuckets = cands, buckets
+			return s
+		}(),
+	}))
+
+	t.Run("round trip", func(t *testing.T) {
+		fork := genesis.Default.Staking
+		fork.BootstrapCandidates, fork.BootstrapBuckets = cands, buckets
+		forkP, forkSM, err := boot(fork)
+		r.NoError(err)
+		for _, req := range requests {
+			r.Equal(readState(srcP, srcSM, req.method, req.req), readState(forkP, forkSM, req.method, req.req), req.method.String())
+		}
+	})
+
+	t.Run("votes mismatch", func(t *testing.T) {
+		fork := genesis.Default.Staking
+		fork.BootstrapCandidates = append([]genesis.BootstrapCandidate{}, cands...)
+		fork.BootstrapCandidates[1].Votes = "1"
+		fork.BootstrapBuckets = buckets
+		_, _, err := boot(fork)
+		r.ErrorIs(err, ErrBootstrapVotesMismatch)
+	})
+}
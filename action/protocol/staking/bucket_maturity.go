@@ -0,0 +1,282 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+const (
+	// ReadStateMaturingBuckets is the ReadState method of the buckets maturing within the next epochs
+	ReadStateMaturingBuckets = "MaturingBuckets"
+)
+
+var (
+	_maturityCheckpointKey = append([]byte{_const}, []byte("maturityCheckpoint")...)
+)
+
+type (
+	// maturityCheckpoint is the time of the last epoch boundary the matured buckets are logged at
+	maturityCheckpoint struct {
+		time time.Time
+	}
+
+	// MaturingBuckets is the page of the buckets maturing within the next epochs, ordered by maturity
+	MaturingBuckets struct {
+		Epoch   uint64           `json:"epoch"`
+		Total   uint64           `json:"total"`
+		Buckets []MaturingBucket `json:"buckets"`
+	}
+
+	// MaturingBucket is a bucket with the estimated epoch at the start of which it is logged as matured. A contract
+	// staking bucket matures at a height, and the epoch is the first one starting at or after the height
+	MaturingBucket struct {
+		Index           uint64 `json:"index"`
+		Origin          string `json:"origin"`
+		ContractAddress string `json:"contractAddress,omitempty"`
+		Owner           string `json:"owner"`
+		Candidate       string `json:"candidate"`
+		StakedAmount    string `json:"stakedAmount"`
+		Maturity        int64  `json:"maturity"`
+		MaturityHeight  uint64 `json:"maturityHeight,omitempty"`
+		Epoch           uint64 `json:"epoch"`
+	}
+)
+
+// Serialize serializes the checkpoint into bytes
+func (mc *maturityCheckpoint) Serialize() ([]byte, error) {
+	return byteutil.Uint64ToBytesBigEndian(uint64(mc.time.UnixNano())), nil
+}
+
+// Deserialize deserializes bytes into the checkpoint
+func (mc *maturityCheckpoint) Deserialize(data []byte) error {
+	if len(data) != 8 {
+		return errors.Errorf("invalid length of maturity checkpoint %d", len(data))
+	}
+	mc.time = time.Unix(0, int64(byteutil.BytesToUint64BigEndian(data))).UTC()
+	return nil
+}
+
+func readMaturityCheckpoint(sr protocol.StateReader) (time.Time, bool, error) {
+	var mc maturityCheckpoint
+	_, err := sr.State(&mc, protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(_maturityCheckpointKey))
+	switch errors.Cause(err) {
+	case nil:
+		return mc.time, true, nil
+	case state.ErrStateNotExist:
+		return time.Time{}, false, nil
+	default:
+		return time.Time{}, false, err
+	}
+}
+
+// logMaturedBuckets emits the logs of the buckets matured since the last epoch boundary, i.e., the maturity is in
+// (last boundary, this boundary], so a bucket maturing exactly at a boundary is logged at that boundary. The first
+// boundary after the activation only sets the checkpoint
+func (p *Protocol) logMaturedBuckets(ctx context.Context, sm protocol.StateManager) error {
+	blkCtx := protocol.MustGetBlockCtx(ctx)
+	last, ok, err := readMaturityCheckpoint(sm)
+	if err != nil {
+		return errors.Wrap(err, "failed to read maturity checkpoint")
+	}
+	if ok {
+		csr, err := ConstructBaseView(sm)
+		if err != nil {
+			return err
+		}
+		buckets, _, err := csr.getAllBuckets()
+		if err != nil && errors.Cause(err) != state.ErrStateNotExist {
+			return err
+		}
+		var logs []*action.Log
+		for _, b := range buckets {
+			maturity, ok := b.maturity()
+			if !ok || !maturity.After(last) || maturity.After(blkCtx.BlockTimeStamp) {
+				continue
+			}
+			l, err := packEvent(action.StakingEventBucketMatured, b.Index, ethAddress(b.Owner), ethAddress(b.Candidate),
+				b.StakedAmount, uint64(maturity.Unix()))
+			if err != nil {
+				return errors.Wrapf(err, "failed to pack the maturity of bucket %d", b.Index)
+			}
+			logs = append(logs, &action.Log{
+				Address:     p.addr.String(),
+				Topics:      l.topics,
+				Data:        l.data,
+				BlockHeight: blkCtx.BlockHeight,
+			})
+		}
+		if emitter, ok := sm.(protocol.SystemLogEmitter); ok && len(logs) > 0 {
+			emitter.EmitSystemLogs(logs...)
+		}
+	}
+	_, err = sm.PutState(&maturityCheckpoint{time: blkCtx.BlockTimeStamp}, protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(_maturityCheckpointKey))
+	return errors.Wrap(err, "failed to put maturity checkpoint")
+}
+
+// readStateMaturingBuckets reads the native and contract staking buckets to be logged as matured within the next
+// epochs, of the voter or of all if the voter is empty. The args are the number of epochs, the voter, the offset and the limit, and the result is in json.
+// The epoch of a bucket is estimated by the block interval from the last epoch boundary
+func (p *Protocol) readStateMaturingBuckets(ctx context.Context, sr protocol.StateReader, args ...[]byte) ([]byte, uint64, error) {
+	if len(args) != 4 {
+		return nil, uint64(0), errors.Errorf("invalid number of arguments %d", len(args))
+	}
+	epochs, err := strconv.ParseUint(string(args[0]), 10, 64)
+	if err != nil {
+		return nil, uint64(0), errors.Wrap(err, "failed to parse the number of epochs")
+	}
+	offset, err := strconv.ParseUint(string(args[2]), 10, 32)
+	if err != nil {
+		return nil, uint64(0), errors.Wrap(err, "failed to parse offset")
+	}
+	limit, err := strconv.ParseUint(string(args[3]), 10, 32)
+	if err != nil {
+		return nil, uint64(0), errors.Wrap(err, "failed to parse limit")
+	}
+	height, err := sr.Height()
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	rp := rolldpos.FindProtocol(protocol.MustGetRegistry(ctx))
+	if rp == nil {
+		return nil, uint64(0), errors.New("rolldpos protocol is not registered")
+	}
+	last, ok, err := readMaturityCheckpoint(sr)
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	if !ok {
+		return nil, uint64(0), errors.New("bucket maturity logs are not activated")
+	}
+	csr, err := ConstructBaseView(sr)
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	var buckets []*VoteBucket
+	if voter := string(args[1]); voter != "" {
+		addr, err := address.FromString(voter)
+		if err != nil {
+			return nil, uint64(0), err
+		}
+		indices, _, err := csr.voterBucketIndices(addr)
+		switch errors.Cause(err) {
+		case nil:
+			if buckets, err = csr.getBucketsWithIndices(*indices); err != nil {
+				return nil, uint64(0), err
+			}
+		case state.ErrStateNotExist:
+		default:
+			return nil, uint64(0), err
+		}
+	} else {
+		buckets, _, err = csr.getAllBuckets()
+		if err != nil && errors.Cause(err) != state.ErrStateNotExist {
+			return nil, uint64(0), err
+		}
+	}
+
+	epoch := rp.GetEpochNum(height)
+	epochDuration := time.Duration(rp.GetEpochHeight(epoch+1)-rp.GetEpochHeight(epoch)) * p.helperCtx.BlockInterval(height)
+	if epochDuration <= 0 {
+		return nil, uint64(0), errors.New("invalid epoch duration")
+	}
+	maturing := []MaturingBucket{}
+	for _, b := range buckets {
+		if b == nil {
+			continue
+		}
+		maturity, ok := b.maturity()
+		if !ok || !maturity.After(last) {
+			continue
+		}
+		// the bucket is logged at the first boundary not earlier than the maturity
+		n := uint64((maturity.Sub(last) + epochDuration - 1) / epochDuration)
+		if n > epochs {
+			continue
+		}
+		maturing = append(maturing, MaturingBucket{
+			Index:        b.Index,
+			Origin:       BucketOriginNative,
+			Owner:        b.Owner.String(),
+			Candidate:    b.Candidate.String(),
+			StakedAmount: b.StakedAmount.String(),
+			Maturity:     maturity.Unix(),
+			Epoch:        epoch + n,
+		})
+	}
+	// the maturity time of a contract staking bucket is estimated by the block interval from the last epoch boundary
+	epochStartHeight := rp.GetEpochHeight(epoch)
+	for _, indexer := range p.contractStakingIndexers() {
+		bkts, err := indexer.Buckets(height)
+		if err != nil {
+			return nil, uint64(0), err
+		}
+		if voter := string(args[1]); voter != "" {
+			bkts = filterBucketsByVoter(bkts, voter)
+		}
+		origin := contractBucketOrigin(indexer)
+		for _, b := range bkts {
+			if b == nil {
+				continue
+			}
+			maturityHeight, ok := b.maturityHeight()
+			if !ok || maturityHeight <= epochStartHeight {
+				continue
+			}
+			e := rp.GetEpochNum(maturityHeight)
+			if rp.GetEpochHeight(e) < maturityHeight {
+				e++
+			}
+			if e-epoch > epochs {
+				continue
+			}
+			maturity := last.Add(time.Duration(maturityHeight-epochStartHeight) * p.helperCtx.BlockInterval(height))
+			maturing = append(maturing, MaturingBucket{
+				Index:           b.Index,
+				Origin:          origin,
+				ContractAddress: b.ContractAddress,
+				Owner:           b.Owner.String(),
+				Candidate:       b.Candidate.String(),
+				StakedAmount:    b.StakedAmount.String(),
+				Maturity:        maturity.Unix(),
+				MaturityHeight:  maturityHeight,
+				Epoch:           e,
+			})
+		}
+	}
+	sort.SliceStable(maturing, func(i, j int) bool {
+		if maturing[i].Maturity != maturing[j].Maturity {
+			return maturing[i].Maturity < maturing[j].Maturity
+		}
+		return maturing[i].Index < maturing[j].Index
+	})
+	res := MaturingBuckets{
+		Epoch:   epoch,
+		Total:   uint64(len(maturing)),
+		Buckets: getPageOfArray(maturing, int(offset), int(limit)),
+	}
+	if res.Buckets == nil {
+		res.Buckets = []MaturingBucket{}
+	}
+	data, err := json.Marshal(res)
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	return data, height, nil
+}
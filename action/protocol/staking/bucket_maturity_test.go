@@ -0,0 +1,267 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/mohae/deepcopy"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+type systemLogRecorder struct {
+	protocol.StateManager
+	logs []*action.Log
+}
+
+func (sm *systemLogRecorder) EmitSystemLogs(logs ...*action.Log) {
+	sm.logs = append(sm.logs, logs...)
+}
+
+func TestVoteBucketMaturity(t *testing.T) {
+	r := require.New(t)
+	start := time.Unix(1700000000, 0).UTC()
+	vb := NewVoteBucket(identityset.Address(1), identityset.Address(2), big.NewInt(100), 7, start, false)
+	maturity, ok := vb.maturity()
+	r.True(ok)
+	r.Equal(start.Add(7*24*time.Hour), maturity)
+
+	// a partial day counts as a whole day, as in the vote weight
+	c := genesis.Default.VoteWeightCalConsts
+	vb.StakedDuration = 36 * time.Hour
+	maturity, ok = vb.maturity()
+	r.True(ok)
+	r.Equal(start.Add(48*time.Hour), maturity)
+	weight := CalculateVoteWeight(c, vb, false)
+	vb.StakedDuration = 48 * time.Hour
+	r.Equal(weight, CalculateVoteWeight(c, vb, false))
+
+	// auto-staked, unstaked and contract buckets never mature
+	vb.AutoStake = true
+	_, ok = vb.maturity()
+	r.False(ok)
+	vb.AutoStake = false
+	vb.UnstakeStartTime = start.Add(time.Hour)
+	_, ok = vb.maturity()
+	r.False(ok)
+	vb.UnstakeStartTime = time.Unix(0, 0).UTC()
+	vb.ContractAddress = identityset.Address(3).String()
+	_, ok = vb.maturity()
+	r.False(ok)
+}
+
+func TestProtocol_BucketMaturityLogs(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm, p, candidate, _ := initAll(t, ctrl)
+	var (
+		owner0, owner1, owner2 = identityset.Address(20), identityset.Address(21), identityset.Address(22)
+		start                  = time.Unix(1700000000, 0).UTC()
+		maturity0              = start.Add(24 * time.Hour)
+		maturity2              = maturity0.Add(30 * time.Second)
+		g                      = deepcopy.Copy(genesis.Default).(genesis.Genesis)
+		// an epoch of 12 blocks lasts 1 minute
+		rp  = rolldpos.NewProtocol(12, 12, 1)
+		reg = protocol.NewRegistry()
+	)
+	r.NoError(rp.Register(reg))
+	g.ToBeEnabledBlockHeight = 0
+	// bucket 1 is auto-staked, which never matures
+	initCreateStake(t, sm, owner0, 1000, big.NewInt(unit.Qev), 10000, 1, 1, start, 10000, p, candidate, "100000000000000000000", false)
+	initCreateStake(t, sm, owner1, 1000, big.NewInt(unit.Qev), 10000, 1, 1, start, 10000, p, candidate, "100000000000000000000", true)
+	initCreateStake(t, sm, owner2, 1000, big.NewInt(unit.Qev), 10000, 1, 1, start.Add(30*time.Second), 10000, p, candidate, "200000000000000000000", false)
+
+	recorder := &systemLogRecorder{StateManager: sm}
+	epochStart := func(height uint64, now time.Time) []*action.Log {
+		ctx := protocol.WithBlockCtx(context.Background(), protocol.BlockCtx{
+			BlockHeight:    height,
+			BlockTimeStamp: now,
+		})
+		ctx = protocol.WithRegistry(genesis.WithGenesisContext(ctx, g), reg)
+		ctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
+		r.NoError(p.PreEpochStart(ctx, recorder))
+		logs := recorder.logs
+		recorder.logs = nil
+		return logs
+	}
+	readState := func(height, epochs uint64, voter string, offset, limit uint32) *MaturingBuckets {
+		ctx := protocol.WithRegistry(context.Background(), reg)
+		data, _, err := p.ReadState(ctx, &heightStateReader{sm, height}, []byte(ReadStateMaturingBuckets),
+			[]byte(strconv.FormatUint(epochs, 10)), []byte(voter),
+			[]byte(strconv.FormatUint(uint64(offset), 10)), []byte(strconv.FormatUint(uint64(limit), 10)))
+		r.NoError(err)
+		res := &MaturingBuckets{}
+		r.NoError(json.Unmarshal(data, res))
+		return res
+	}
+	checkLog := func(l *action.Log, index uint64, owner address.Address, amount *big.Int, maturity time.Time) {
+		event := _stakingEvents.Events[action.StakingEventBucketMatured]
+		r.Equal(p.addr.String(), l.Address)
+		r.Equal(action.Topics{
+			hash.Hash256(event.ID),
+			hash.BytesToHash256(byteutil.Uint64ToBytesBigEndian(index)),
+			hash.BytesToHash256(owner.Bytes()),
+			hash.BytesToHash256(candidate.GetIdentifier().Bytes()),
+		}, l.Topics)
+		args, err := event.Inputs.NonIndexed().Unpack(l.Data)
+		r.NoError(err)
+		r.Equal([]interface{}{amount, uint64(maturity.Unix())}, args)
+	}
+
+	// the first boundary after the activation sets the checkpoint only
+	_, _, err := p.ReadState(protocol.WithRegistry(context.Background(), reg), &heightStateReader{sm, 1}, []byte(ReadStateMaturingBuckets),
+		[]byte("1"), []byte(""), []byte("0"), []byte("10"))
+	r.ErrorContains(err, "not activated")
+	r.Empty(epochStart(1, maturity0.Add(-time.Minute)))
+
+	// bucket 0 matures exactly at the next boundary, and bucket 2 at the one after
+	res := readState(1, 1, "", 0, 10)
+	r.EqualValues(1, res.Epoch)
+	r.EqualValues(1, res.Total)
+	r.Equal([]MaturingBucket{{
+		Index:        0,
+		Origin:       BucketOriginNative,
+		Owner:        owner0.String(),
+		Candidate:    candidate.GetIdentifier().String(),
+		StakedAmount: "100000000000000000000",
+		Maturity:     maturity0.Unix(),
+		Epoch:        2,
+	}}, res.Buckets)
+	res = readState(1, 2, "", 1, 1)
+	r.EqualValues(2, res.Total)
+	r.Len(res.Buckets, 1)
+	r.EqualValues(2, res.Buckets[0].Index)
+	r.EqualValues(3, res.Buckets[0].Epoch)
+	res = readState(1, 2, owner2.String(), 0, 10)
+	r.EqualValues(1, res.Total)
+	r.EqualValues(2, res.Buckets[0].Index)
+	res = readState(1, 2, owner1.String(), 0, 10)
+	r.Zero(res.Total)
+	r.Empty(res.Buckets)
+	r.Zero(readState(1, 0, "", 0, 10).Total)
+
+	// the maturity exactly at the boundary is logged at that boundary
+	logs := epochStart(13, maturity0)
+	r.Len(logs, 1)
+	checkLog(logs[0], 0, owner0, unit.ConvertIotxToRau(100), maturity0)
+	r.EqualValues(13, logs[0].BlockHeight)
+	res = readState(13, 1, "", 0, 10)
+	r.EqualValues(2, res.Epoch)
+	r.EqualValues(1, res.Total)
+	r.EqualValues(2, res.Buckets[0].Index)
+	r.EqualValues(3, res.Buckets[0].Epoch)
+
+	// a bucket is logged once
+	logs = epochStart(25, maturity0.Add(time.Minute))
+	r.Len(logs, 1)
+	checkLog(logs[0], 2, owner2, unit.ConvertIotxToRau(200), maturity2)
+	r.Empty(epochStart(37, maturity0.Add(2*time.Minute)))
+	r.Zero(readState(37, 10, "", 0, 10).Total)
+}
+
+func TestProtocol_ContractBucketMaturity(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm, p, _, _ := initAll(t, ctrl)
+	var (
+		owner0, owner1 = identityset.Address(20), identityset.Address(21)
+		start          = time.Unix(1700000000, 0).UTC()
+		g              = deepcopy.Copy(genesis.Default).(genesis.Genesis)
+		// an epoch of 12 blocks lasts 1 minute
+		rp       = rolldpos.NewProtocol(12, 12, 1)
+		reg      = protocol.NewRegistry()
+		contract = identityset.Address(30).String()
+	)
+	r.NoError(rp.Register(reg))
+	g.ToBeEnabledBlockHeight = 0
+	ctx := protocol.WithBlockCtx(context.Background(), protocol.BlockCtx{
+		BlockHeight:    1,
+		BlockTimeStamp: start,
+	})
+	ctx = protocol.WithRegistry(genesis.WithGenesisContext(ctx, g), reg)
+	ctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
+	r.NoError(p.PreEpochStart(ctx, sm))
+
+	contractBucket := func(index uint64, owner address.Address, stakeStart, duration uint64) *VoteBucket {
+		return &VoteBucket{
+			Index:                     index,
+			Candidate:                 identityset.Address(1),
+			Owner:                     owner,
+			StakedAmount:              big.NewInt(100),
+			ContractAddress:           contract,
+			StakedDurationBlockNumber: duration,
+			CreateBlockHeight:         stakeStart,
+			StakeStartBlockHeight:     stakeStart,
+			UnstakeStartBlockHeight:   maxBlockNumber,
+		}
+	}
+	autoStaked := contractBucket(2, owner0, 1, 10)
+	autoStaked.AutoStake = true
+	unstaked := contractBucket(3, owner0, 1, 10)
+	unstaked.UnstakeStartBlockHeight = 5
+	indexer := NewMockContractStakingIndexer(ctrl)
+	indexer.EXPECT().Buckets(gomock.Any()).Return([]*VoteBucket{
+		// matures at height 25, the start of epoch 3
+		contractBucket(5, owner1, 5, 20),
+		// matures at height 21 in epoch 2, logged at the start of epoch 3
+		contractBucket(1, owner0, 1, 20),
+		// matures at height 13, the start of epoch 2
+		contractBucket(4, owner0, 3, 10),
+		autoStaked,
+		unstaked,
+		nil,
+	}, nil).AnyTimes()
+	p.contractStakingIndexerV2 = indexer
+
+	readState := func(epochs uint64, voter string) *MaturingBuckets {
+		data, _, err := p.ReadState(protocol.WithRegistry(context.Background(), reg), &heightStateReader{sm, 1}, []byte(ReadStateMaturingBuckets),
+			[]byte(strconv.FormatUint(epochs, 10)), []byte(voter), []byte("0"), []byte("10"))
+		r.NoError(err)
+		res := &MaturingBuckets{}
+		r.NoError(json.Unmarshal(data, res))
+		return res
+	}
+	res := readState(1, "")
+	r.EqualValues(1, res.Total)
+	r.Equal(MaturingBucket{
+		Index:           4,
+		Origin:          BucketOriginContractV2,
+		ContractAddress: contract,
+		Owner:           owner0.String(),
+		Candidate:       identityset.Address(1).String(),
+		StakedAmount:    "100",
+		Maturity:        start.Add(time.Minute).Unix(),
+		MaturityHeight:  13,
+		Epoch:           2,
+	}, res.Buckets[0])
+	res = readState(2, "")
+	r.EqualValues(3, res.Total)
+	for i, index := range []uint64{4, 1, 5} {
+		r.Equal(index, res.Buckets[i].Index)
+	}
+	r.EqualValues(3, res.Buckets[1].Epoch)
+	r.EqualValues(21, res.Buckets[1].MaturityHeight)
+	res = readState(2, owner1.String())
+	r.EqualValues(1, res.Total)
+	r.EqualValues(5, res.Buckets[0].Index)
+	r.EqualValues(3, res.Buckets[0].Epoch)
+}
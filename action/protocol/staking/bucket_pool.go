@@ -90,6 +90,15 @@ func (t *totalAmount) SubBalance(amount *big.Int) error {
 	return nil
 }
 
+// SubAmount subtracts the amount taken out of an existing bucket, the number of buckets is unchanged
+func (t *totalAmount) SubAmount(amount *big.Int) error {
+	if amount.Cmp(t.amount) == 1 {
+		return state.ErrNotEnoughBalance
+	}
+	t.amount.Sub(t.amount, amount)
+	return nil
+}
+
 // Total returns the total amount staked in bucket pool
 func (bp *BucketPool) Total() *big.Int {
 	return new(big.Int).Set(bp.total.amount)
@@ -143,6 +152,19 @@ func (bp *BucketPool) CreditPool(sm protocol.StateManager, amount *big.Int) erro
 	return sm.Load(_protocolID, _stakingBucketPool, bp.total)
 }
 
+// SlashPool subtracts the amount slashed from a bucket out of the pool
+func (bp *BucketPool) SlashPool(sm protocol.StateManager, amount *big.Int) error {
+	if err := bp.total.SubAmount(amount); err != nil {
+		return err
+	}
+
+	if bp.enableSMStorage {
+		_, err := sm.PutState(bp.total, protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(_bucketPoolAddrKey))
+		return err
+	}
+	return sm.Load(_protocolID, _stakingBucketPool, bp.total)
+}
+
 // DebitPool adds staked amount into the pool
 func (bp *BucketPool) DebitPool(sm protocol.StateManager, amount *big.Int, newBucket bool) error {
 	bp.total.AddBalance(amount, newBucket)
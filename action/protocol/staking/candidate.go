@@ -16,6 +16,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/actionpb"
 	"github.com/iotexproject/iotex-core/action/protocol/staking/stakingpb"
 	"github.com/iotexproject/iotex-core/state"
 )
@@ -31,6 +32,17 @@ type (
 		Votes              *big.Int
 		SelfStakeBucketIdx uint64
 		SelfStake          *big.Int
+		// PayoutSplit is the split of the rewards before NextPayoutSplitEpoch, the rewards are paid out to the
+		// reward address if it is empty
+		PayoutSplit []action.PayoutShare
+		// NextPayoutSplit is the split of the rewards since NextPayoutSplitEpoch, which is 0 if the split is not
+		// changed
+		NextPayoutSplit      []action.PayoutShare
+		NextPayoutSplitEpoch uint64
+		// NextReward is the reward address since NextRewardEpoch, which is 0 if no change of the reward address is
+		// pending
+		NextReward      address.Address
+		NextRewardEpoch uint64
 	}
 
 	// CandidateList is a list of candidates which is sortable
@@ -46,14 +58,19 @@ type (
 // Clone returns a copy
 func (d *Candidate) Clone() *Candidate {
 	return &Candidate{
-		Owner:              d.Owner,
-		Operator:           d.Operator,
-		Reward:             d.Reward,
-		Identifier:         d.Identifier,
-		Name:               d.Name,
-		Votes:              new(big.Int).Set(d.Votes),
-		SelfStakeBucketIdx: d.SelfStakeBucketIdx,
-		SelfStake:          new(big.Int).Set(d.SelfStake),
+		Owner:                d.Owner,
+		Operator:             d.Operator,
+		Reward:               d.Reward,
+		Identifier:           d.Identifier,
+		Name:                 d.Name,
+		Votes:                new(big.Int).Set(d.Votes),
+		SelfStakeBucketIdx:   d.SelfStakeBucketIdx,
+		SelfStake:            new(big.Int).Set(d.SelfStake),
+		PayoutSplit:          clonePayoutSplit(d.PayoutSplit),
+		NextPayoutSplit:      clonePayoutSplit(d.NextPayoutSplit),
+		NextPayoutSplitEpoch: d.NextPayoutSplitEpoch,
+		NextReward:           d.NextReward,
+		NextRewardEpoch:      d.NextRewardEpoch,
 	}
 }
 
@@ -66,7 +83,12 @@ func (d *Candidate) Equal(c *Candidate) bool {
 		address.Equal(d.Reward, c.Reward) &&
 		address.Equal(d.Identifier, c.Identifier) &&
 		d.Votes.Cmp(c.Votes) == 0 &&
-		d.SelfStake.Cmp(c.SelfStake) == 0
+		d.SelfStake.Cmp(c.SelfStake) == 0 &&
+		equalPayoutSplit(d.PayoutSplit, c.PayoutSplit) &&
+		equalPayoutSplit(d.NextPayoutSplit, c.NextPayoutSplit) &&
+		d.NextPayoutSplitEpoch == c.NextPayoutSplitEpoch &&
+		address.Equal(d.NextReward, c.NextReward) &&
+		d.NextRewardEpoch == c.NextRewardEpoch
 }
 
 // Validate does the sanity check
@@ -166,6 +188,59 @@ func (d *Candidate) SubSelfStake(amount *big.Int) error {
 	return nil
 }
 
+// PayoutSplitAt returns the payout split in the epoch, nil if the rewards are paid out to the reward address
+func (d *Candidate) PayoutSplitAt(epoch uint64) []action.PayoutShare {
+	if d.NextPayoutSplitEpoch != 0 && epoch >= d.NextPayoutSplitEpoch {
+		return d.NextPayoutSplit
+	}
+	return d.PayoutSplit
+}
+
+// SetPayoutSplit changes the payout split since the epoch, the current split is kept until then
+func (d *Candidate) SetPayoutSplit(split []action.PayoutShare, epoch uint64) {
+	if d.NextPayoutSplitEpoch != 0 && epoch > d.NextPayoutSplitEpoch {
+		d.PayoutSplit = d.NextPayoutSplit
+	}
+	d.NextPayoutSplit = clonePayoutSplit(split)
+	d.NextPayoutSplitEpoch = epoch
+}
+
+// RewardAt returns the reward address in the epoch
+func (d *Candidate) RewardAt(epoch uint64) address.Address {
+	if d.NextRewardEpoch != 0 && epoch >= d.NextRewardEpoch {
+		return d.NextReward
+	}
+	return d.Reward
+}
+
+// SetReward changes the reward address in the epoch, the change takes effect after the delay and the current address
+// is kept until then. A later change overwrites the pending one and restarts the delay, and changing back to the
+// current address cancels it. It returns the epoch the change takes effect, or 0 if the pending change is canceled
+func (d *Candidate) SetReward(reward address.Address, epoch, delay uint64) uint64 {
+	d.SettleReward(epoch)
+	if address.Equal(reward, d.Reward) {
+		d.NextReward, d.NextRewardEpoch = nil, 0
+		return 0
+	}
+	if delay == 0 {
+		d.Reward, d.NextReward, d.NextRewardEpoch = reward, nil, 0
+		return epoch
+	}
+	d.NextReward, d.NextRewardEpoch = reward, epoch+delay
+	return epoch + delay
+}
+
+// SettleReward applies the pending change of the reward address taking effect by the epoch, and returns true if it's
+// applied
+func (d *Candidate) SettleReward(epoch uint64) bool {
+	if d.NextRewardEpoch == 0 || epoch < d.NextRewardEpoch {
+		return false
+	}
+	d.Reward = d.NextReward
+	d.NextReward, d.NextRewardEpoch = nil, 0
+	return true
+}
+
 // Serialize serializes candidate to bytes
 func (d *Candidate) Serialize() ([]byte, error) {
 	pb, err := d.toProto()
@@ -202,16 +277,25 @@ func (d *Candidate) toProto() (*stakingpb.Candidate, error) {
 	if d.Identifier != nil {
 		voter = d.Identifier.String()
 	}
+	nextReward := ""
+	if d.NextReward != nil {
+		nextReward = d.NextReward.String()
+	}
 
 	return &stakingpb.Candidate{
-		OwnerAddress:       d.Owner.String(),
-		OperatorAddress:    d.Operator.String(),
-		RewardAddress:      d.Reward.String(),
-		IdentifierAddress:  voter,
-		Name:               d.Name,
-		Votes:              d.Votes.String(),
-		SelfStakeBucketIdx: d.SelfStakeBucketIdx,
-		SelfStake:          d.SelfStake.String(),
+		OwnerAddress:           d.Owner.String(),
+		OperatorAddress:        d.Operator.String(),
+		RewardAddress:          d.Reward.String(),
+		IdentifierAddress:      voter,
+		Name:                   d.Name,
+		Votes:                  d.Votes.String(),
+		SelfStakeBucketIdx:     d.SelfStakeBucketIdx,
+		SelfStake:              d.SelfStake.String(),
+		PayoutSplit:            payoutSplitToProto(d.PayoutSplit),
+		NextPayoutSplit:        payoutSplitToProto(d.NextPayoutSplit),
+		NextPayoutSplitEpoch:   d.NextPayoutSplitEpoch,
+		NextRewardAddress:      nextReward,
+		NextRewardAddressEpoch: d.NextRewardEpoch,
 	}, nil
 }
 
@@ -255,11 +339,26 @@ func (d *Candidate) fromProto(pb *stakingpb.Candidate) error {
 	if !ok {
 		return action.ErrInvalidAmount
 	}
+
+	if d.PayoutSplit, err = payoutSplitFromProto(pb.GetPayoutSplit()); err != nil {
+		return err
+	}
+	if d.NextPayoutSplit, err = payoutSplitFromProto(pb.GetNextPayoutSplit()); err != nil {
+		return err
+	}
+	d.NextPayoutSplitEpoch = pb.GetNextPayoutSplitEpoch()
+	d.NextReward = nil
+	if next := pb.GetNextRewardAddress(); len(next) > 0 {
+		if d.NextReward, err = address.FromString(next); err != nil {
+			return err
+		}
+	}
+	d.NextRewardEpoch = pb.GetNextRewardAddressEpoch()
 	return nil
 }
 
 func (d *Candidate) toIoTeXTypes() *iotextypes.CandidateV2 {
-	return &iotextypes.CandidateV2{
+	cand := &iotextypes.CandidateV2{
 		OwnerAddress:       d.Owner.String(),
 		OperatorAddress:    d.Operator.String(),
 		RewardAddress:      d.Reward.String(),
@@ -269,6 +368,17 @@ func (d *Candidate) toIoTeXTypes() *iotextypes.CandidateV2 {
 		SelfStakingTokens:  d.SelfStake.String(),
 		Id:                 d.GetIdentifier().String(),
 	}
+	ext := actionpb.CandidateV2Ext{
+		PayoutSplit:          action.PayoutSplitToProto(d.PayoutSplit),
+		NextPayoutSplit:      action.PayoutSplitToProto(d.NextPayoutSplit),
+		NextPayoutSplitEpoch: d.NextPayoutSplitEpoch,
+	}
+	if d.NextRewardEpoch != 0 {
+		ext.NextRewardAddress = d.NextReward.String()
+		ext.NextRewardAddressEpoch = d.NextRewardEpoch
+	}
+	action.MustAppendUnknownFields(cand, &ext)
+	return cand
 }
 
 func (d *Candidate) toStateCandidate() *state.Candidate {
@@ -280,6 +390,57 @@ func (d *Candidate) toStateCandidate() *state.Candidate {
 	}
 }
 
+func payoutSplitToProto(split []action.PayoutShare) []*stakingpb.PayoutShare {
+	if len(split) == 0 {
+		return nil
+	}
+	pb := make([]*stakingpb.PayoutShare, len(split))
+	for i, share := range split {
+		pb[i] = &stakingpb.PayoutShare{
+			Address:     share.Address.String(),
+			BasisPoints: share.BasisPoints,
+		}
+	}
+	return pb
+}
+
+func payoutSplitFromProto(pb []*stakingpb.PayoutShare) ([]action.PayoutShare, error) {
+	if len(pb) == 0 {
+		return nil, nil
+	}
+	split := make([]action.PayoutShare, len(pb))
+	for i, share := range pb {
+		addr, err := address.FromString(share.GetAddress())
+		if err != nil {
+			return nil, err
+		}
+		split[i] = action.PayoutShare{
+			Address:     addr,
+			BasisPoints: share.GetBasisPoints(),
+		}
+	}
+	return split, nil
+}
+
+func clonePayoutSplit(split []action.PayoutShare) []action.PayoutShare {
+	if len(split) == 0 {
+		return nil
+	}
+	return append([]action.PayoutShare{}, split...)
+}
+
+func equalPayoutSplit(a, b []action.PayoutShare) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !address.Equal(a[i].Address, b[i].Address) || a[i].BasisPoints != b[i].BasisPoints {
+			return false
+		}
+	}
+	return true
+}
+
 func (l CandidateList) Len() int      { return len(l) }
 func (l CandidateList) Swap(i, j int) { l[i], l[j] = l[j], l[i] }
 func (l CandidateList) Less(i, j int) bool {
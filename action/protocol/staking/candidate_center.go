@@ -274,6 +274,22 @@ func (m *CandidateCenter) GetByIdentifier(identifier address.Address) *Candidate
 	return nil
 }
 
+// GetByOperator returns the candidate by operator
+func (m *CandidateCenter) GetByOperator(operator address.Address) *Candidate {
+	if operator == nil {
+		return nil
+	}
+
+	if d := m.change.getByOperator(operator); d != nil {
+		return d
+	}
+
+	if d, hit := m.base.getByOperator(operator.String()); hit && !m.change.containsIdentifier(d.GetIdentifier()) {
+		return d.Clone()
+	}
+	return nil
+}
+
 // GetBySelfStakingIndex returns the candidate by self-staking index
 func (m *CandidateCenter) GetBySelfStakingIndex(index uint64) *Candidate {
 	if d := m.change.getBySelfStakingIndex(index); d != nil {
@@ -443,6 +459,15 @@ func (cc *candChange) getByIdentifier(identifier address.Address) *Candidate {
 	return nil
 }
 
+func (cc *candChange) getByOperator(operator address.Address) *Candidate {
+	for _, d := range cc.dirty {
+		if address.Equal(operator, d.Operator) {
+			return d.Clone()
+		}
+	}
+	return nil
+}
+
 func (cc *candChange) getBySelfStakingIndex(index uint64) *Candidate {
 	for _, d := range cc.dirty {
 		if d.isSelfStakeBucketSettled() && index == d.SelfStakeBucketIdx {
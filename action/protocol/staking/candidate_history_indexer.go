@@ -0,0 +1,326 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/action/protocol/staking/stakingpb"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+const (
+	// StakingCandidateHistoryNamespace is a namespace to store the candidates at the end of each epoch
+	StakingCandidateHistoryNamespace = "stakingCandidateHistory"
+)
+
+var (
+	_candHistoryHeightKey     = []byte("height")
+	_candHistoryFirstEpochKey = []byte("first")
+
+	// ErrCandidateHistoryNA indicates the candidate history of the epoch is not available, which is the epoch
+	// before the indexer was enabled and whose state could not be read
+	ErrCandidateHistoryNA = errors.New("candidate history not available")
+)
+
+type (
+	// CandidateHistory is a candidate at the end of an epoch
+	CandidateHistory struct {
+		Epoch     uint64
+		ID        address.Address
+		Name      string
+		Votes     *big.Int
+		SelfStake *big.Int
+		// BucketCount is the number of native buckets staked to the candidate
+		BucketCount uint64
+		// Rank is the rank of the candidate by votes, starting from 1
+		Rank uint32
+	}
+
+	// CandidateHistoryIndexer is an indexer to store the votes, self-stake, bucket count and rank of every
+	// candidate at the end of each epoch
+	CandidateHistoryIndexer struct {
+		mutex         sync.RWMutex
+		kvStore       db.KVStore
+		rp            *rolldpos.Protocol
+		stateReaderAt func(uint64) (protocol.StateReader, error)
+		height        uint64
+		// firstEpoch is the first epoch whose history is available
+		firstEpoch uint64
+	}
+)
+
+// NewCandidateHistoryIndexer creates a new candidate history indexer, stateReaderAt returns the state reader at
+// a height, or ErrCandidateHistoryNA if the state at the height cannot be read
+func NewCandidateHistoryIndexer(kv db.KVStore, rp *rolldpos.Protocol, stateReaderAt func(uint64) (protocol.StateReader, error)) (*CandidateHistoryIndexer, error) {
+	if kv == nil || rp == nil || stateReaderAt == nil {
+		return nil, ErrMissingField
+	}
+	return &CandidateHistoryIndexer{
+		kvStore:       kv,
+		rp:            rp,
+		stateReaderAt: stateReaderAt,
+	}, nil
+}
+
+// Start starts the indexer
+func (chi *CandidateHistoryIndexer) Start(ctx context.Context) error {
+	if err := chi.kvStore.Start(ctx); err != nil {
+		return err
+	}
+	ret, err := chi.kvStore.Get(StakingCandidateHistoryNamespace, _candHistoryHeightKey)
+	switch errors.Cause(err) {
+	case nil:
+		chi.height = byteutil.BytesToUint64BigEndian(ret)
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		chi.height = 0
+	default:
+		return err
+	}
+	ret, err = chi.kvStore.Get(StakingCandidateHistoryNamespace, _candHistoryFirstEpochKey)
+	switch errors.Cause(err) {
+	case nil:
+		chi.firstEpoch = byteutil.BytesToUint64BigEndian(ret)
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		chi.firstEpoch = 1
+	default:
+		return err
+	}
+	return nil
+}
+
+// Stop stops the indexer
+func (chi *CandidateHistoryIndexer) Stop(ctx context.Context) error {
+	return chi.kvStore.Stop(ctx)
+}
+
+// Height returns the height of the indexer
+func (chi *CandidateHistoryIndexer) Height() (uint64, error) {
+	chi.mutex.RLock()
+	defer chi.mutex.RUnlock()
+	return chi.height, nil
+}
+
+// PutBlock records the candidates if the block is the last block of an epoch. If the state of the block cannot
+// be read, which happens when the indexer is enabled mid-chain without the historical state, the epoch and the
+// ones before are marked unavailable
+func (chi *CandidateHistoryIndexer) PutBlock(ctx context.Context, blk *block.Block) error {
+	chi.mutex.Lock()
+	defer chi.mutex.Unlock()
+	height := blk.Height()
+	if height <= chi.height {
+		return nil
+	}
+	if height != chi.height+1 {
+		return errors.Wrapf(db.ErrInvalid, "invalid block height %d, expecting %d", height, chi.height+1)
+	}
+	var (
+		b          = batch.NewBatch()
+		epoch      = chi.rp.GetEpochNum(height)
+		firstEpoch = chi.firstEpoch
+	)
+	if chi.rp.GetEpochLastBlockHeight(epoch) == height {
+		sr, err := chi.stateReaderAt(height)
+		switch errors.Cause(err) {
+		case nil:
+			data, err := candidateHistoryAt(sr)
+			if err != nil {
+				return errors.Wrapf(err, "failed to read the candidates of epoch %d", epoch)
+			}
+			b.Put(StakingCandidateHistoryNamespace, byteutil.Uint64ToBytesBigEndian(epoch), data, "failed to put candidate history")
+		case ErrCandidateHistoryNA:
+			if epoch != firstEpoch {
+				return errors.Wrapf(err, "the history of epoch %d is indexed, but not epoch %d", firstEpoch, epoch)
+			}
+			firstEpoch = epoch + 1
+			b.Put(StakingCandidateHistoryNamespace, _candHistoryFirstEpochKey, byteutil.Uint64ToBytesBigEndian(firstEpoch), "failed to put first epoch")
+		default:
+			return err
+		}
+	}
+	b.Put(StakingCandidateHistoryNamespace, _candHistoryHeightKey, byteutil.Uint64ToBytesBigEndian(height), "failed to put height")
+	if err := chi.kvStore.WriteBatch(b); err != nil {
+		return err
+	}
+	chi.height, chi.firstEpoch = height, firstEpoch
+	return nil
+}
+
+// DeleteTipBlock deletes the candidates recorded by the tip block
+func (chi *CandidateHistoryIndexer) DeleteTipBlock(ctx context.Context, blk *block.Block) error {
+	chi.mutex.Lock()
+	defer chi.mutex.Unlock()
+	height := blk.Height()
+	if height != chi.height {
+		return errors.Wrapf(db.ErrInvalid, "invalid block height %d, expecting %d", height, chi.height)
+	}
+	var (
+		b          = batch.NewBatch()
+		epoch      = chi.rp.GetEpochNum(height)
+		firstEpoch = chi.firstEpoch
+	)
+	if chi.rp.GetEpochLastBlockHeight(epoch) == height {
+		if firstEpoch == epoch+1 {
+			firstEpoch = epoch
+			b.Put(StakingCandidateHistoryNamespace, _candHistoryFirstEpochKey, byteutil.Uint64ToBytesBigEndian(firstEpoch), "failed to put first epoch")
+		} else {
+			b.Delete(StakingCandidateHistoryNamespace, byteutil.Uint64ToBytesBigEndian(epoch), "failed to delete candidate history")
+		}
+	}
+	b.Put(StakingCandidateHistoryNamespace, _candHistoryHeightKey, byteutil.Uint64ToBytesBigEndian(height-1), "failed to put height")
+	if err := chi.kvStore.WriteBatch(b); err != nil {
+		return err
+	}
+	chi.height, chi.firstEpoch = height-1, firstEpoch
+	return nil
+}
+
+// CandidateHistory returns the history of the candidate from the start epoch to the end epoch, the epochs which
+// the candidate was not in are skipped, and the end epoch is capped by the last epoch indexed
+func (chi *CandidateHistoryIndexer) CandidateHistory(id address.Address, startEpoch, endEpoch uint64) ([]*CandidateHistory, error) {
+	chi.mutex.RLock()
+	defer chi.mutex.RUnlock()
+	if startEpoch > endEpoch {
+		return nil, errors.Wrapf(db.ErrInvalid, "start epoch %d > end epoch %d", startEpoch, endEpoch)
+	}
+	if startEpoch < chi.firstEpoch {
+		return nil, errors.Wrapf(ErrCandidateHistoryNA, "the history before epoch %d is not available", chi.firstEpoch)
+	}
+	if last := chi.lastEpoch(); endEpoch > last {
+		endEpoch = last
+	}
+	var (
+		histories []*CandidateHistory
+		idStr     = id.String()
+	)
+	for epoch := startEpoch; epoch <= endEpoch; epoch++ {
+		list, err := chi.epochCandidates(epoch)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range list {
+			if c.ID.String() == idStr {
+				histories = append(histories, c)
+				break
+			}
+		}
+	}
+	return histories, nil
+}
+
+// EpochRanking returns the candidates at the end of the epoch in the order of rank
+func (chi *CandidateHistoryIndexer) EpochRanking(epoch uint64) ([]*CandidateHistory, error) {
+	chi.mutex.RLock()
+	defer chi.mutex.RUnlock()
+	if epoch < chi.firstEpoch {
+		return nil, errors.Wrapf(ErrCandidateHistoryNA, "the history before epoch %d is not available", chi.firstEpoch)
+	}
+	if epoch > chi.lastEpoch() {
+		return nil, errors.Wrapf(db.ErrNotExist, "epoch %d has not been indexed", epoch)
+	}
+	return chi.epochCandidates(epoch)
+}
+
+// lastEpoch returns the last epoch indexed, must hold the mutex
+func (chi *CandidateHistoryIndexer) lastEpoch() uint64 {
+	epoch := chi.rp.GetEpochNum(chi.height)
+	if chi.rp.GetEpochLastBlockHeight(epoch) == chi.height {
+		return epoch
+	}
+	return epoch - 1
+}
+
+func (chi *CandidateHistoryIndexer) epochCandidates(epoch uint64) ([]*CandidateHistory, error) {
+	data, err := chi.kvStore.Get(StakingCandidateHistoryNamespace, byteutil.Uint64ToBytesBigEndian(epoch))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the candidates of epoch %d", epoch)
+	}
+	pb := &stakingpb.EpochCandidates{}
+	if err := proto.Unmarshal(data, pb); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal the candidates of epoch %d", epoch)
+	}
+	list := make([]*CandidateHistory, 0, len(pb.Candidates))
+	for _, cpb := range pb.Candidates {
+		c := &CandidateHistory{Epoch: epoch}
+		if err := c.fromProto(cpb); err != nil {
+			return nil, err
+		}
+		list = append(list, c)
+	}
+	return list, nil
+}
+
+// candidateHistoryAt returns the serialized candidates at the state, ranked by votes
+func candidateHistoryAt(sr protocol.StateReader) ([]byte, error) {
+	csr := newCandidateStateReader(sr)
+	all, _, err := csr.getAllCandidates()
+	if err != nil && errors.Cause(err) != state.ErrStateNotExist {
+		return nil, err
+	}
+	// the buckets are read one by one, since reading all buckets with keys is not supported by the historical state
+	total, err := csr.getTotalBucketCount()
+	if err != nil && errors.Cause(err) != state.ErrStateNotExist {
+		return nil, err
+	}
+	bucketCount := make(map[string]uint64)
+	for i := uint64(0); i < total; i++ {
+		vb, err := csr.getBucket(i)
+		switch errors.Cause(err) {
+		case nil:
+		case state.ErrStateNotExist, ErrWithdrawnBucket:
+			continue
+		default:
+			return nil, err
+		}
+		if !vb.isUnstaked() {
+			bucketCount[vb.Candidate.String()]++
+		}
+	}
+	sort.Sort(all)
+	pb := &stakingpb.EpochCandidates{Candidates: make([]*stakingpb.CandidateHistory, 0, len(all))}
+	for i, c := range all {
+		id := c.GetIdentifier().String()
+		pb.Candidates = append(pb.Candidates, &stakingpb.CandidateHistory{
+			Id:          id,
+			Name:        c.Name,
+			Votes:       c.Votes.String(),
+			SelfStake:   c.SelfStake.String(),
+			BucketCount: bucketCount[id],
+			Rank:        uint32(i + 1),
+		})
+	}
+	return proto.Marshal(pb)
+}
+
+func (c *CandidateHistory) fromProto(pb *stakingpb.CandidateHistory) error {
+	var err error
+	if c.ID, err = address.FromString(pb.GetId()); err != nil {
+		return err
+	}
+	var ok bool
+	if c.Votes, ok = new(big.Int).SetString(pb.GetVotes(), 10); !ok {
+		return action.ErrInvalidAmount
+	}
+	if c.SelfStake, ok = new(big.Int).SetString(pb.GetSelfStake(), 10); !ok {
+		return action.ErrInvalidAmount
+	}
+	c.Name, c.BucketCount, c.Rank = pb.GetName(), pb.GetBucketCount(), pb.GetRank()
+	return nil
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil/testdb"
+)
+
+func TestCandidateHistoryIndexer(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+	csm := newCandidateStateManager(sm)
+
+	cands := []*Candidate{
+		{
+			Owner:     identityset.Address(1),
+			Operator:  identityset.Address(11),
+			Reward:    identityset.Address(21),
+			Name:      "test1",
+			Votes:     big.NewInt(100),
+			SelfStake: big.NewInt(10),
+		},
+		{
+			Owner:      identityset.Address(2),
+			Operator:   identityset.Address(12),
+			Reward:     identityset.Address(22),
+			Identifier: identityset.Address(3),
+			Name:       "test2",
+			Votes:      big.NewInt(200),
+			SelfStake:  big.NewInt(20),
+		},
+	}
+	for _, c := range cands {
+		r.NoError(csm.putCandidate(c))
+	}
+	now := time.Now()
+	for _, cand := range []int{1, 3, 3} {
+		_, err := csm.putBucket(NewVoteBucket(identityset.Address(cand), identityset.Address(4), big.NewInt(10), 7, now, true))
+		r.NoError(err)
+	}
+	// the unstaked bucket is not counted
+	vb := NewVoteBucket(identityset.Address(1), identityset.Address(4), big.NewInt(10), 7, now, true)
+	vb.UnstakeStartTime = now.Add(time.Hour)
+	_, err := csm.putBucket(vb)
+	r.NoError(err)
+
+	// the state before height 3 is not available
+	stateReaderAt := func(height uint64) (protocol.StateReader, error) {
+		if height < 3 {
+			return nil, errors.Wrapf(ErrCandidateHistoryNA, "state at height %d", height)
+		}
+		return sm, nil
+	}
+	// 2 blocks per epoch
+	rp := rolldpos.NewProtocol(2, 2, 1)
+	indexer, err := NewCandidateHistoryIndexer(db.NewMemKVStore(), rp, stateReaderAt)
+	r.NoError(err)
+	r.NoError(indexer.Start(ctx))
+	defer func() {
+		r.NoError(indexer.Stop(ctx))
+	}()
+
+	blks := make([]*block.Block, 7)
+	for i := 1; i <= 6; i++ {
+		blk, err := block.NewTestingBuilder().SetHeight(uint64(i)).SignAndBuild(identityset.PrivateKey(27))
+		r.NoError(err)
+		blks[i] = &blk
+	}
+	r.Equal(db.ErrInvalid, errors.Cause(indexer.PutBlock(ctx, blks[2])))
+	for i := 1; i <= 4; i++ {
+		r.NoError(indexer.PutBlock(ctx, blks[i]))
+	}
+	// candidate 1 overtakes candidate 2 in epoch 3
+	cands[0].Votes = big.NewInt(300)
+	r.NoError(csm.putCandidate(cands[0]))
+	for i := 5; i <= 6; i++ {
+		r.NoError(indexer.PutBlock(ctx, blks[i]))
+	}
+	height, err := indexer.Height()
+	r.NoError(err)
+	r.EqualValues(6, height)
+
+	_, err = indexer.EpochRanking(1)
+	r.Equal(ErrCandidateHistoryNA, errors.Cause(err))
+	_, err = indexer.EpochRanking(4)
+	r.Equal(db.ErrNotExist, errors.Cause(err))
+	ranking, err := indexer.EpochRanking(2)
+	r.NoError(err)
+	r.Len(ranking, 2)
+	r.Equal(&CandidateHistory{
+		Epoch:       2,
+		ID:          identityset.Address(3),
+		Name:        "test2",
+		Votes:       big.NewInt(200),
+		SelfStake:   big.NewInt(20),
+		BucketCount: 2,
+		Rank:        1,
+	}, ranking[0])
+	r.Equal(identityset.Address(1).String(), ranking[1].ID.String())
+	r.EqualValues(1, ranking[1].BucketCount)
+	r.EqualValues(2, ranking[1].Rank)
+
+	histories, err := indexer.CandidateHistory(identityset.Address(1), 2, 10)
+	r.NoError(err)
+	r.Len(histories, 2)
+	r.EqualValues(2, histories[0].Epoch)
+	r.EqualValues(2, histories[0].Rank)
+	r.EqualValues(3, histories[1].Epoch)
+	r.EqualValues(1, histories[1].Rank)
+	r.Equal(big.NewInt(300), histories[1].Votes)
+	histories, err = indexer.CandidateHistory(identityset.Address(5), 2, 3)
+	r.NoError(err)
+	r.Empty(histories)
+	_, err = indexer.CandidateHistory(identityset.Address(1), 1, 3)
+	r.Equal(ErrCandidateHistoryNA, errors.Cause(err))
+	_, err = indexer.CandidateHistory(identityset.Address(1), 3, 2)
+	r.Equal(db.ErrInvalid, errors.Cause(err))
+
+	// delete the tip blocks
+	r.Equal(db.ErrInvalid, errors.Cause(indexer.DeleteTipBlock(ctx, blks[5])))
+	r.NoError(indexer.DeleteTipBlock(ctx, blks[6]))
+	_, err = indexer.EpochRanking(3)
+	r.Equal(db.ErrNotExist, errors.Cause(err))
+	for i := 5; i >= 1; i-- {
+		r.NoError(indexer.DeleteTipBlock(ctx, blks[i]))
+	}
+	height, err = indexer.Height()
+	r.NoError(err)
+	r.Zero(height)
+
+	// epoch 1 is available once the state could be read
+	indexer.stateReaderAt = func(uint64) (protocol.StateReader, error) {
+		return sm, nil
+	}
+	for i := 1; i <= 2; i++ {
+		r.NoError(indexer.PutBlock(ctx, blks[i]))
+	}
+	ranking, err = indexer.EpochRanking(1)
+	r.NoError(err)
+	r.Len(ranking, 2)
+}
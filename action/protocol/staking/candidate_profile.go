@@ -0,0 +1,87 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/actionpb"
+	"github.com/iotexproject/iotex-core/action/protocol/staking/stakingpb"
+)
+
+// CandidateProfile is the profile of a candidate shown to the voters. It is stored apart from the candidate, so it
+// takes no part in the votes and the hash of the candidates
+type CandidateProfile struct {
+	URL             string
+	Description     string
+	IconHash        []byte
+	SecurityContact string
+}
+
+// IsEmpty returns true if the profile is the same as not set
+func (cp *CandidateProfile) IsEmpty() bool {
+	return cp.URL == "" && cp.Description == "" && len(cp.IconHash) == 0 && cp.SecurityContact == ""
+}
+
+// Serialize serializes the profile into bytes
+func (cp *CandidateProfile) Serialize() ([]byte, error) {
+	return proto.Marshal(cp.toProto())
+}
+
+// Deserialize deserializes bytes into the profile
+func (cp *CandidateProfile) Deserialize(buf []byte) error {
+	pb := &stakingpb.CandidateProfile{}
+	if err := proto.Unmarshal(buf, pb); err != nil {
+		return errors.Wrap(err, "failed to unmarshal candidate profile")
+	}
+	cp.loadProto(pb)
+	return nil
+}
+
+func (cp *CandidateProfile) toProto() *stakingpb.CandidateProfile {
+	return &stakingpb.CandidateProfile{
+		Url:             cp.URL,
+		Description:     cp.Description,
+		IconHash:        cp.IconHash,
+		SecurityContact: cp.SecurityContact,
+	}
+}
+
+func (cp *CandidateProfile) loadProto(pb *stakingpb.CandidateProfile) {
+	cp.URL = pb.GetUrl()
+	cp.Description = pb.GetDescription()
+	cp.IconHash = pb.GetIconHash()
+	cp.SecurityContact = pb.GetSecurityContact()
+}
+
+// CandidateProfileFromCandidateV2 returns the profile carried in the candidate read from the ReadState methods, nil
+// if the candidate has no profile
+func CandidateProfileFromCandidateV2(c *iotextypes.CandidateV2) (*CandidateProfile, error) {
+	ext := actionpb.CandidateV2Ext{}
+	if err := action.LoadUnknownFields(c, &ext); err != nil {
+		return nil, err
+	}
+	if len(ext.GetProfile()) == 0 {
+		return nil, nil
+	}
+	cp := &CandidateProfile{}
+	if err := cp.Deserialize(ext.GetProfile()); err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
+
+// appendCandidateProfile appends the profile to the unknown fields of the candidate
+func appendCandidateProfile(c *iotextypes.CandidateV2, cp *CandidateProfile) error {
+	b, err := cp.Serialize()
+	if err != nil {
+		return err
+	}
+	return action.AppendUnknownFields(c, &actionpb.CandidateV2Ext{Profile: b})
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+type (
+	// CandidateProfileStateManager defines the interface of candidate profile state manager
+	CandidateProfileStateManager struct {
+		protocol.StateManager
+		*CandidateProfileStateReader
+	}
+	// CandidateProfileStateReader defines the interface of candidate profile state reader
+	CandidateProfileStateReader struct {
+		protocol.StateReader
+	}
+)
+
+// NewCandidateProfileStateManager creates a new candidate profile state manager
+func NewCandidateProfileStateManager(sm protocol.StateManager) *CandidateProfileStateManager {
+	return &CandidateProfileStateManager{
+		StateManager:                sm,
+		CandidateProfileStateReader: NewCandidateProfileStateReader(sm),
+	}
+}
+
+// Put puts the profile of a candidate, the profile is deleted if it is empty
+func (cpsm *CandidateProfileStateManager) Put(candidate address.Address, cp *CandidateProfile) error {
+	if cp.IsEmpty() {
+		switch _, err := cpsm.Get(candidate); errors.Cause(err) {
+		case nil:
+		case state.ErrStateNotExist:
+			return nil
+		default:
+			return err
+		}
+		_, err := cpsm.DelState(protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(candidateProfileKey(candidate)))
+		return err
+	}
+	_, err := cpsm.PutState(cp, protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(candidateProfileKey(candidate)))
+	return err
+}
+
+// NewCandidateProfileStateReader creates a new candidate profile state reader
+func NewCandidateProfileStateReader(sr protocol.StateReader) *CandidateProfileStateReader {
+	return &CandidateProfileStateReader{StateReader: sr}
+}
+
+// Get gets the profile of a candidate
+func (cpsr *CandidateProfileStateReader) Get(candidate address.Address) (*CandidateProfile, error) {
+	value := CandidateProfile{}
+	if _, err := cpsr.State(&value, protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(candidateProfileKey(candidate))); err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+func candidateProfileKey(candidate address.Address) []byte {
+	key := []byte{_candidateProfile}
+	return append(key, candidate.Bytes()...)
+}
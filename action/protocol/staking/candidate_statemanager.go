@@ -57,6 +57,7 @@ type (
 		Upsert(*Candidate) error
 		CreditBucketPool(*big.Int) error
 		DebitBucketPool(*big.Int, bool) error
+		SlashBucketPool(*big.Int) error
 		Commit(context.Context) error
 		SM() protocol.StateManager
 		SR() protocol.StateReader
@@ -183,6 +184,10 @@ func (csm *candSM) DebitBucketPool(amount *big.Int, newBucket bool) error {
 	return csm.bucketPool.DebitPool(csm, amount, newBucket)
 }
 
+func (csm *candSM) SlashBucketPool(amount *big.Int) error {
+	return csm.bucketPool.SlashPool(csm.StateManager, amount)
+}
+
 func (csm *candSM) Commit(ctx context.Context) error {
 	height, err := csm.Height()
 	if err != nil {
@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/actionpb"
 	"github.com/iotexproject/iotex-core/pkg/unit"
 	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/test/identityset"
@@ -71,6 +72,124 @@ func TestSer(t *testing.T) {
 	r.Nil(m1)
 }
 
+func TestCandidatePayoutSplit(t *testing.T) {
+	r := require.New(t)
+
+	c := &Candidate{
+		Owner:              identityset.Address(1),
+		Operator:           identityset.Address(2),
+		Reward:             identityset.Address(3),
+		Name:               "testname1",
+		Votes:              big.NewInt(100),
+		SelfStakeBucketIdx: 0,
+		SelfStake:          big.NewInt(1100000000),
+	}
+	r.Nil(c.PayoutSplitAt(1))
+
+	split1 := []action.PayoutShare{{Address: identityset.Address(4), BasisPoints: 10000}}
+	split2 := []action.PayoutShare{
+		{Address: identityset.Address(5), BasisPoints: 4000},
+		{Address: identityset.Address(6), BasisPoints: 6000},
+	}
+	// the split takes effect since the epoch
+	c.SetPayoutSplit(split1, 3)
+	r.Nil(c.PayoutSplitAt(2))
+	r.Equal(split1, c.PayoutSplitAt(3))
+	r.Equal(split1, c.PayoutSplitAt(4))
+
+	// the pending split is replaced before it takes effect
+	c.SetPayoutSplit(split2, 3)
+	r.Nil(c.PayoutSplitAt(2))
+	r.Equal(split2, c.PayoutSplitAt(3))
+
+	// the effective split is kept until the next one takes effect
+	c.SetPayoutSplit(split1, 5)
+	r.Equal(split2, c.PayoutSplitAt(4))
+	r.Equal(split1, c.PayoutSplitAt(5))
+
+	ser, err := c.Serialize()
+	r.NoError(err)
+	c1 := &Candidate{}
+	r.NoError(c1.Deserialize(ser))
+	r.Equal(c, c1)
+	r.True(c.Equal(c1))
+	c2 := c.Clone()
+	r.Equal(c, c2)
+	c2.NextPayoutSplit[0].BasisPoints = 1
+	r.False(c.Equal(c2))
+
+	// the split is returned to the clients as unknown fields
+	ext := actionpb.CandidateV2Ext{}
+	r.NoError(action.LoadUnknownFields(c.toIoTeXTypes(), &ext))
+	split, err := action.PayoutSplitFromProto(ext.GetPayoutSplit())
+	r.NoError(err)
+	r.Equal(split2, split)
+	split, err = action.PayoutSplitFromProto(ext.GetNextPayoutSplit())
+	r.NoError(err)
+	r.Equal(split1, split)
+}
+
+func TestCandidateReward(t *testing.T) {
+	r := require.New(t)
+
+	c := &Candidate{
+		Owner:              identityset.Address(1),
+		Operator:           identityset.Address(2),
+		Reward:             identityset.Address(3),
+		Name:               "testname1",
+		Votes:              big.NewInt(100),
+		SelfStakeBucketIdx: 0,
+		SelfStake:          big.NewInt(1100000000),
+	}
+	reward1, reward2 := identityset.Address(4), identityset.Address(5)
+	// the change takes effect after the delay
+	r.Equal(uint64(13), c.SetReward(reward1, 3, 10))
+	r.Equal(identityset.Address(3), c.RewardAt(12))
+	r.Equal(reward1, c.RewardAt(13))
+	r.False(c.SettleReward(12))
+	r.Equal(identityset.Address(3), c.Reward)
+
+	// a second change overwrites the pending one and restarts the delay
+	r.Equal(uint64(15), c.SetReward(reward2, 5, 10))
+	r.Equal(identityset.Address(3), c.RewardAt(14))
+	r.Equal(reward2, c.RewardAt(15))
+
+	// changing back to the current address cancels the pending change
+	r.Zero(c.SetReward(identityset.Address(3), 6, 10))
+	r.Nil(c.NextReward)
+	r.Equal(identityset.Address(3), c.RewardAt(15))
+
+	// a change is applied by the next one after it takes effect
+	r.Equal(uint64(17), c.SetReward(reward1, 7, 10))
+	r.Equal(uint64(27), c.SetReward(reward2, 17, 10))
+	r.Equal(reward1, c.Reward)
+	r.Equal(reward2, c.NextReward)
+
+	// the change without a delay takes effect immediately
+	c1 := c.Clone()
+	r.Equal(uint64(18), c1.SetReward(identityset.Address(6), 18, 0))
+	r.Equal(identityset.Address(6), c1.Reward)
+	r.Zero(c1.NextRewardEpoch)
+	r.False(c.Equal(c1))
+
+	ser, err := c.Serialize()
+	r.NoError(err)
+	c2 := &Candidate{}
+	r.NoError(c2.Deserialize(ser))
+	r.Equal(c, c2)
+	r.True(c.Equal(c2))
+	r.True(c.SettleReward(27))
+	r.Equal(reward2, c.Reward)
+	r.False(c.Equal(c2))
+
+	// the pending change is returned to the clients as unknown fields
+	ext := actionpb.CandidateV2Ext{}
+	r.NoError(action.LoadUnknownFields(c2.toIoTeXTypes(), &ext))
+	r.Equal(reward2.String(), ext.GetNextRewardAddress())
+	r.Equal(uint64(27), ext.GetNextRewardAddressEpoch())
+	r.Empty(c.toIoTeXTypes().ProtoReflect().GetUnknown())
+}
+
 func TestClone(t *testing.T) {
 	r := require.New(t)
 
@@ -0,0 +1,112 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+)
+
+const (
+	// ReadStateContractStakingReconciliation is the ReadState method comparing the totals of the contract staking
+	// indexers with the balances of the staking contracts
+	ReadStateContractStakingReconciliation = "ContractStakingReconciliation"
+)
+
+type (
+	// ContractStakingReconciliation is the totals of the buckets indexed of each staking contract
+	ContractStakingReconciliation struct {
+		Height    uint64                 `json:"height"`
+		Contracts []ContractStakingTotal `json:"contracts"`
+	}
+
+	// ContractStakingTotal is the totals of the buckets indexed of a staking contract, which holds the staked amount
+	// of the buckets until they are withdrawn. The indexer diverges from the contract if the staked amount of the
+	// buckets indexed doesn't match the balance of the contract
+	ContractStakingTotal struct {
+		Origin          string `json:"origin"`
+		ContractAddress string `json:"contractAddress"`
+		ActiveBuckets   uint64 `json:"activeBuckets"`
+		TotalBuckets    uint64 `json:"totalBuckets"`
+		StakedAmount    string `json:"stakedAmount"`
+		Balance         string `json:"balance"`
+		Matched         bool   `json:"matched"`
+	}
+)
+
+// readStateContractStakingReconciliation reads the totals of the buckets of each contract indexer along with the
+// balance of the contract, and the result is in json
+func (p *Protocol) readStateContractStakingReconciliation(ctx context.Context, sr protocol.StateReader, args ...[]byte) ([]byte, uint64, error) {
+	if len(args) != 0 {
+		return nil, uint64(0), errors.Errorf("invalid number of arguments %d", len(args))
+	}
+	height, err := sr.Height()
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	res := ContractStakingReconciliation{
+		Height:    height,
+		Contracts: []ContractStakingTotal{},
+	}
+	for _, indexer := range p.contractStakingIndexers() {
+		total, err := contractStakingTotal(ctx, sr, indexer, height)
+		if err != nil {
+			return nil, uint64(0), errors.Wrapf(err, "failed to reconcile staking contract %s", indexer.ContractAddress())
+		}
+		res.Contracts = append(res.Contracts, *total)
+	}
+	data, err := json.Marshal(res)
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	return data, height, nil
+}
+
+func contractStakingTotal(ctx context.Context, sr protocol.StateReader, indexer ContractStakingIndexer, height uint64) (*ContractStakingTotal, error) {
+	addr, err := address.FromString(indexer.ContractAddress())
+	if err != nil {
+		return nil, err
+	}
+	bkts, err := indexer.Buckets(height)
+	if err != nil {
+		return nil, err
+	}
+	total, err := indexer.TotalBucketCount(height)
+	if err != nil {
+		return nil, err
+	}
+	acc, err := accountutil.AccountState(ctx, sr, addr)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		active uint64
+		amount = big.NewInt(0)
+	)
+	for _, b := range bkts {
+		if b == nil {
+			continue
+		}
+		active++
+		amount.Add(amount, b.StakedAmount)
+	}
+	return &ContractStakingTotal{
+		Origin:          contractBucketOrigin(indexer),
+		ContractAddress: addr.String(),
+		ActiveBuckets:   active,
+		TotalBuckets:    total,
+		StakedAmount:    amount.String(),
+		Balance:         acc.Balance.String(),
+		Matched:         amount.Cmp(acc.Balance) == 0,
+	}, nil
+}
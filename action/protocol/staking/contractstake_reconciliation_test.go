@@ -0,0 +1,92 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/stretchr/testify/require"
+
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestProtocol_ContractStakingReconciliation(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm, p, _, _ := initAll(t, ctrl)
+	contract, contractV2 := identityset.Address(30), identityset.Address(31)
+	bucket := func(index uint64, amount int64) *VoteBucket {
+		return &VoteBucket{
+			Index:                   index,
+			Candidate:               identityset.Address(1),
+			Owner:                   identityset.Address(2),
+			StakedAmount:            big.NewInt(amount),
+			UnstakeStartBlockHeight: maxBlockNumber,
+		}
+	}
+	indexer := NewMockContractStakingIndexerWithBucketType(ctrl)
+	indexer.EXPECT().ContractAddress().Return(contract.String()).AnyTimes()
+	indexer.EXPECT().Buckets(gomock.Any()).Return([]*VoteBucket{bucket(1, 100), bucket(2, 200)}, nil).AnyTimes()
+	indexer.EXPECT().TotalBucketCount(gomock.Any()).Return(uint64(3), nil).AnyTimes()
+	indexerV2 := NewMockContractStakingIndexer(ctrl)
+	indexerV2.EXPECT().ContractAddress().Return(contractV2.String()).AnyTimes()
+	indexerV2.EXPECT().Buckets(gomock.Any()).Return([]*VoteBucket{bucket(1, 50), nil}, nil).AnyTimes()
+	indexerV2.EXPECT().TotalBucketCount(gomock.Any()).Return(uint64(1), nil).AnyTimes()
+	p.contractStakingIndexer = indexer
+	p.contractStakingIndexerV2 = indexerV2
+
+	ctx := genesis.WithGenesisContext(context.Background(), genesis.Default)
+	deposit := func(addr address.Address, amount int64) {
+		acc, err := accountutil.LoadOrCreateAccount(sm, addr)
+		r.NoError(err)
+		r.NoError(acc.AddBalance(big.NewInt(amount)))
+		r.NoError(accountutil.StoreAccount(sm, addr, acc))
+	}
+	reconcile := func() *ContractStakingReconciliation {
+		data, _, err := p.ReadState(ctx, sm, []byte(ReadStateContractStakingReconciliation))
+		r.NoError(err)
+		res := &ContractStakingReconciliation{}
+		r.NoError(json.Unmarshal(data, res))
+		return res
+	}
+	deposit(contract, 300)
+	deposit(contractV2, 40)
+	res := reconcile()
+	r.Equal([]ContractStakingTotal{
+		{
+			Origin:          BucketOriginContract,
+			ContractAddress: contract.String(),
+			ActiveBuckets:   2,
+			TotalBuckets:    3,
+			StakedAmount:    "300",
+			Balance:         "300",
+			Matched:         true,
+		},
+		{
+			Origin:          BucketOriginContractV2,
+			ContractAddress: contractV2.String(),
+			ActiveBuckets:   1,
+			TotalBuckets:    1,
+			StakedAmount:    "50",
+			Balance:         "40",
+			Matched:         false,
+		},
+	}, res.Contracts)
+
+	// the divergence is gone once the balance matches
+	deposit(contractV2, 10)
+	r.True(reconcile().Contracts[1].Matched)
+
+	_, _, err := p.ReadState(ctx, sm, []byte(ReadStateContractStakingReconciliation), []byte("1"))
+	r.ErrorContains(err, "invalid number of arguments")
+}
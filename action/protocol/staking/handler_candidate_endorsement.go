@@ -77,10 +77,12 @@ func (p *Protocol) handleCandidateEndorsement(ctx context.Context, act *action.C
 			if err := csm.Upsert(cand); err != nil {
 				return log, nil, csmErrorToHandleError(actCtx.Caller.String(), err)
 			}
+			log.AddVotesEvent(cand)
 		}
 		if err := esm.Delete(bucket.Index); err != nil {
 			return log, nil, errors.Wrapf(err, "failed to delete endorsement with bucket index %d", bucket.Index)
 		}
+		log.AddBucketEvent(action.StakingEventBucketUpdated, bucket)
 		return log, nil, nil
 	default:
 		return log, nil, errors.New("invalid operation")
@@ -91,6 +93,7 @@ func (p *Protocol) handleCandidateEndorsement(ctx context.Context, act *action.C
 	}); err != nil {
 		return log, nil, errors.Wrapf(err, "failed to put endorsement with bucket index %d", bucket.Index)
 	}
+	log.AddBucketEvent(action.StakingEventBucketUpdated, bucket)
 	return log, nil, nil
 }
 
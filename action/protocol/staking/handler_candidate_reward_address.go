@@ -0,0 +1,88 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+const (
+	// HandleRewardAddressScheduled is the topic of the receipt log of a change of the reward address scheduled, the
+	// other topics are the candidate, the new reward address and the epoch it takes effect
+	HandleRewardAddressScheduled = "rewardAddressScheduled"
+	// HandleRewardAddressCanceled is the topic of the receipt log of a pending change of the reward address canceled,
+	// the other topic is the candidate
+	HandleRewardAddressCanceled = "rewardAddressCanceled"
+	// HandleRewardAddressApplied is the topic of the receipt log of a change of the reward address taking effect, the
+	// other topics are the candidate and the new reward address
+	HandleRewardAddressApplied = "rewardAddressApplied"
+)
+
+// changeRewardAddress changes the reward address of the candidate, which takes effect after RewardAddressDelayEpochs,
+// so a compromised key cannot redirect the rewards right away. It returns the receipt logs of the pending change
+// applied before, and of the change scheduled, or of the pending change canceled by changing back to the current
+// reward address
+func (p *Protocol) changeRewardAddress(ctx context.Context, c *Candidate, reward address.Address) []*receiptLog {
+	var (
+		featureCtx = protocol.MustGetFeatureCtx(ctx)
+		epoch      = p.blockEpoch(ctx)
+		logs       []*receiptLog
+	)
+	if c.SettleReward(epoch) {
+		logs = append(logs, p.rewardAddressAppliedLog(featureCtx, c))
+	}
+	pending := c.NextRewardEpoch != 0
+	effectiveEpoch := c.SetReward(reward, epoch, p.config.RewardAddressDelayEpochs)
+	switch {
+	case effectiveEpoch != 0:
+		log := newReceiptLog(p.addr.String(), HandleRewardAddressScheduled, featureCtx.NewStakingReceiptFormat)
+		log.AddTopics(c.GetIdentifier().Bytes(), reward.Bytes(), byteutil.Uint64ToBytesBigEndian(effectiveEpoch))
+		logs = append(logs, log)
+	case pending:
+		log := newReceiptLog(p.addr.String(), HandleRewardAddressCanceled, featureCtx.NewStakingReceiptFormat)
+		log.AddTopics(c.GetIdentifier().Bytes())
+		logs = append(logs, log)
+	}
+	return logs
+}
+
+// SettleRewardAddress applies the pending change of the reward address of the candidate operated by the address, if
+// it takes effect by the epoch. It returns the reward address of the candidate in the epoch, nil if there's no such
+// candidate, and the receipt log of the change if it's applied
+func (p *Protocol) SettleRewardAddress(ctx context.Context, sm protocol.StateManager, operator address.Address, epoch uint64) (address.Address, *action.Log, error) {
+	height, err := sm.Height()
+	if err != nil {
+		return nil, nil, err
+	}
+	csm, err := NewCandidateStateManager(sm, protocol.MustGetFeatureWithHeightCtx(ctx).ReadStateFromDB(height))
+	if err != nil {
+		return nil, nil, err
+	}
+	c := csm.DirtyView().candCenter.GetByOperator(operator)
+	if c == nil {
+		return nil, nil, nil
+	}
+	if !c.SettleReward(epoch) {
+		return c.Reward, nil, nil
+	}
+	if err := csm.Upsert(c); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to apply the reward address of candidate %s", c.GetIdentifier().String())
+	}
+	return c.Reward, p.rewardAddressAppliedLog(protocol.MustGetFeatureCtx(ctx), c).Build(ctx, nil), nil
+}
+
+func (p *Protocol) rewardAddressAppliedLog(featureCtx protocol.FeatureCtx, c *Candidate) *receiptLog {
+	log := newReceiptLog(p.addr.String(), HandleRewardAddressApplied, featureCtx.NewStakingReceiptFormat)
+	log.AddTopics(c.GetIdentifier().Bytes(), c.Reward.Bytes())
+	return log
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/mohae/deepcopy"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestProtocol_HandleCandidateRewardAddress(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm, p, candidate, _ := initAll(t, ctrl)
+	p.config.RewardAddressDelayEpochs = 10
+	var (
+		owner   = candidate.Owner
+		oldAddr = candidate.Reward
+		reward1 = identityset.Address(21)
+		reward2 = identityset.Address(22)
+		nonce   = uint64(0)
+		g       = deepcopy.Copy(genesis.Default).(genesis.Genesis)
+		rp      = rolldpos.NewProtocol(1, 1, 1)
+		reg     = protocol.NewRegistry()
+	)
+	r.NoError(rp.Register(reg))
+	g.FbkMigrationBlockHeight = 0
+	g.ToBeEnabledBlockHeight = 0
+	r.NoError(setupAccount(sm, owner, 1000))
+
+	ctxAt := func(height uint64) context.Context {
+		ctx := protocol.WithBlockCtx(context.Background(), protocol.BlockCtx{
+			BlockHeight:    height,
+			BlockTimeStamp: time.Now(),
+			GasLimit:       1000000,
+		})
+		ctx = protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{Tip: protocol.TipInfo{Height: height - 1}})
+		ctx = protocol.WithRegistry(genesis.WithGenesisContext(ctx, g), reg)
+		return protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
+	}
+	update := func(height uint64, reward address.Address) []*action.Log {
+		nonce++
+		act, err := action.NewCandidateUpdate(nonce, "", "", reward.String(), 100000, big.NewInt(unit.Qev))
+		r.NoError(err)
+		intrinsic, err := act.IntrinsicGas()
+		r.NoError(err)
+		ctx := protocol.WithActionCtx(ctxAt(height), protocol.ActionCtx{
+			Caller:       owner,
+			GasPrice:     big.NewInt(unit.Qev),
+			IntrinsicGas: intrinsic,
+			Nonce:        nonce,
+		})
+		r.NoError(p.Validate(ctx, act, sm))
+		receipt, err := p.Handle(ctx, act, sm)
+		r.NoError(err)
+		r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+		return receipt.Logs()
+	}
+	current := func() *Candidate {
+		csm, err := NewCandidateStateManager(sm, false)
+		r.NoError(err)
+		c := csm.GetByOwner(owner)
+		r.NotNil(c)
+		return c
+	}
+	scheduled := func(reward address.Address, epoch uint64) action.Topics {
+		return action.Topics{
+			hash.BytesToHash256([]byte(HandleRewardAddressScheduled)),
+			hash.BytesToHash256(candidate.GetIdentifier().Bytes()),
+			hash.BytesToHash256(reward.Bytes()),
+			hash.BytesToHash256(byteutil.Uint64ToBytesBigEndian(epoch)),
+		}
+	}
+
+	// the change is pending in the candidate state, and logged with the epoch it takes effect, ahead of the event of
+	// the candidate update
+	logs := update(2, reward1)
+	r.Len(logs, 3)
+	r.Equal(scheduled(reward1, 12), logs[1].Topics)
+	c := current()
+	r.Equal(oldAddr, c.Reward)
+	r.Equal(reward1, c.NextReward)
+	r.Equal(uint64(12), c.NextRewardEpoch)
+
+	// the owner cancels the pending change by changing back to the current address
+	logs = update(3, oldAddr)
+	r.Len(logs, 3)
+	r.Equal(action.Topics{
+		hash.BytesToHash256([]byte(HandleRewardAddressCanceled)),
+		hash.BytesToHash256(candidate.GetIdentifier().Bytes()),
+	}, logs[1].Topics)
+	c = current()
+	r.Equal(oldAddr, c.Reward)
+	r.Zero(c.NextRewardEpoch)
+
+	// a second change overwrites the pending one and restarts the delay
+	update(4, reward1)
+	logs = update(6, reward2)
+	r.Equal(scheduled(reward2, 16), logs[1].Topics)
+	r.Equal(reward2, current().NextReward)
+
+	// the rewards of the epochs before the boundary still go to the old address
+	settle := func(epoch uint64) (address.Address, *action.Log) {
+		// the change is applied by the grant of the reward
+		ctx := protocol.WithActionCtx(ctxAt(epoch), protocol.ActionCtx{Caller: identityset.Address(27)})
+		addr, log, err := p.SettleRewardAddress(ctx, sm, candidate.Operator, epoch)
+		r.NoError(err)
+		return addr, log
+	}
+	addr, log := settle(15)
+	r.Equal(oldAddr, addr)
+	r.Nil(log)
+	r.Equal(oldAddr, current().Reward)
+	addr, log = settle(16)
+	r.Equal(reward2, addr)
+	r.Equal(action.Topics{
+		hash.BytesToHash256([]byte(HandleRewardAddressApplied)),
+		hash.BytesToHash256(candidate.GetIdentifier().Bytes()),
+		hash.BytesToHash256(reward2.Bytes()),
+	}, log.Topics)
+	c = current()
+	r.Equal(reward2, c.Reward)
+	r.Zero(c.NextRewardEpoch)
+	addr, log = settle(17)
+	r.Equal(reward2, addr)
+	r.Nil(log)
+
+	// the unknown operator has no reward address
+	addr, log, err := p.SettleRewardAddress(ctxAt(17), sm, identityset.Address(29), 17)
+	r.NoError(err)
+	r.Nil(addr)
+	r.Nil(log)
+
+	// the change is immediate before the activation
+	g.ToBeEnabledBlockHeight = 100
+	logs = update(20, reward1)
+	r.Len(logs, 1)
+	c = current()
+	r.Equal(reward1, c.Reward)
+	r.Zero(c.NextRewardEpoch)
+}
@@ -66,6 +66,7 @@ func (p *Protocol) handleCandidateActivate(ctx context.Context, act *action.Cand
 	if err := csm.Upsert(cand); err != nil {
 		return log, nil, csmErrorToHandleError(cand.GetIdentifier().String(), err)
 	}
+	log.AddVotesEvent(cand)
 	return log, nil, nil
 }
 
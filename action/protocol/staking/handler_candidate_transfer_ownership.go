@@ -32,6 +32,9 @@ func (p *Protocol) handleCandidateTransferOwnership(ctx context.Context, act *ac
 	if err := p.validateCandidateTransferOwnership(ctx, act, csm, actCtx.Caller); err != nil {
 		return log, nil, err
 	}
+	if rejectLog, err := p.checkTransferLock(ctx, csm.SR(), actCtx.Caller, act.NewOwner()); err != nil {
+		return rejectLog, nil, err
+	}
 	candidate := csm.GetByOwner(actCtx.Caller)
 	if candidate.Identifier == nil || candidate.Identifier.String() == "" {
 		candidate.Identifier = candidate.Owner
@@ -82,6 +85,8 @@ func (p *Protocol) handleCandidateTransferOwnership(ctx context.Context, act *ac
 		return log, nil, csmErrorToHandleError(candidate.GetIdentifier().String(), err)
 	}
 	log.AddTopics(actCtx.Caller.Bytes(), act.NewOwner().Bytes())
+	log.AddCandidateEvent(action.StakingEventCandidateUpdated, candidate)
+	log.AddVotesEvent(candidate)
 	return log, nil, nil
 }
 
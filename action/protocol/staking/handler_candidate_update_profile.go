@@ -0,0 +1,64 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+const handleUpdateCandidateProfile = "updateCandidateProfile"
+
+func (p *Protocol) handleUpdateCandidateProfile(ctx context.Context, act *action.UpdateCandidateProfile, csm CandidateStateManager,
+) (*receiptLog, []*action.TransactionLog, error) {
+	actCtx := protocol.MustGetActionCtx(ctx)
+	featureCtx := protocol.MustGetFeatureCtx(ctx)
+	log := newReceiptLog(p.addr.String(), handleUpdateCandidateProfile, featureCtx.NewStakingReceiptFormat)
+
+	_, fetchErr := fetchCaller(ctx, csm, big.NewInt(0))
+	if fetchErr != nil {
+		return log, nil, fetchErr
+	}
+
+	// only owner can update the profile of the candidate
+	c := csm.GetByOwner(actCtx.Caller)
+	if c == nil {
+		return log, nil, errCandNotExist
+	}
+	log.AddTopics(c.GetIdentifier().Bytes())
+
+	cp := &CandidateProfile{
+		URL:             act.URL(),
+		Description:     act.Description(),
+		IconHash:        act.IconHash(),
+		SecurityContact: act.SecurityContact(),
+	}
+	if err := NewCandidateProfileStateManager(csm.SM()).Put(c.GetIdentifier(), cp); err != nil {
+		return log, nil, errors.Wrapf(err, "failed to put profile of candidate %s", c.GetIdentifier().String())
+	}
+	log.AddAddress(actCtx.Caller)
+	log.AddCandidateEvent(action.StakingEventCandidateUpdated, c)
+	return log, nil, nil
+}
+
+// candidateProfile returns the profile of the candidate, nil if the candidate has no profile
+func candidateProfile(sr protocol.StateReader, cand *Candidate) (*CandidateProfile, error) {
+	cp, err := NewCandidateProfileStateReader(sr).Get(cand.GetIdentifier())
+	switch errors.Cause(err) {
+	case nil:
+		return cp, nil
+	case state.ErrStateNotExist:
+		return nil, nil
+	default:
+		return nil, errors.Wrapf(err, "failed to get profile of candidate %s", cand.GetIdentifier().String())
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/mohae/deepcopy"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/actionpb"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestCandidateProfile(t *testing.T) {
+	r := require.New(t)
+	cp := &CandidateProfile{}
+	r.True(cp.IsEmpty())
+	cp = &CandidateProfile{
+		URL:             "https://delegate.example",
+		Description:     "a delegate",
+		IconHash:        make([]byte, action.CandidateProfileIconHashSize),
+		SecurityContact: "security@delegate.example",
+	}
+	r.False(cp.IsEmpty())
+	b, err := cp.Serialize()
+	r.NoError(err)
+	cp2 := &CandidateProfile{}
+	r.NoError(cp2.Deserialize(b))
+	r.Equal(cp, cp2)
+
+	// the profile is carried in the unknown fields of CandidateV2, along with the payout split
+	c := testCandidates[0].d.Clone()
+	c.PayoutSplit = []action.PayoutShare{{Address: identityset.Address(1), BasisPoints: action.PayoutSplitBasisPoints}}
+	pb := c.toIoTeXTypes()
+	cp2, err = CandidateProfileFromCandidateV2(pb)
+	r.NoError(err)
+	r.Nil(cp2)
+	r.NoError(appendCandidateProfile(pb, cp))
+	b, err = proto.Marshal(pb)
+	r.NoError(err)
+	pb = &iotextypes.CandidateV2{}
+	r.NoError(proto.Unmarshal(b, pb))
+	cp2, err = CandidateProfileFromCandidateV2(pb)
+	r.NoError(err)
+	r.Equal(cp, cp2)
+	ext := actionpb.CandidateV2Ext{}
+	r.NoError(action.LoadUnknownFields(pb, &ext))
+	split, err := action.PayoutSplitFromProto(ext.GetPayoutSplit())
+	r.NoError(err)
+	r.Equal(c.PayoutSplit, split)
+}
+
+func TestProtocol_HandleUpdateCandidateProfile(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm, p, candidate, _ := initAll(t, ctrl)
+	var (
+		owner    = candidate.Owner
+		stranger = identityset.Address(20)
+		nonces   = map[string]uint64{}
+		g        = deepcopy.Copy(genesis.Default).(genesis.Genesis)
+		rp       = rolldpos.NewProtocol(1, 1, 1)
+		reg      = protocol.NewRegistry()
+		iconHash = make([]byte, action.CandidateProfileIconHashSize)
+	)
+	r.NoError(rp.Register(reg))
+	r.NoError(setupAccount(sm, owner, 1000))
+	r.NoError(setupAccount(sm, stranger, 1000))
+	g.ToBeEnabledBlockHeight = 2
+	cands, _, err := newCandidateStateReader(sm).getAllCandidates()
+	r.NoError(err)
+
+	ctxAt := func(height uint64, caller address.Address, act action.Action) context.Context {
+		intrinsic, err := act.(interface{ IntrinsicGas() (uint64, error) }).IntrinsicGas()
+		r.NoError(err)
+		ctx := protocol.WithActionCtx(context.Background(), protocol.ActionCtx{
+			Caller:       caller,
+			GasPrice:     big.NewInt(unit.Qev),
+			IntrinsicGas: intrinsic,
+			Nonce:        nonces[caller.String()],
+		})
+		ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight:    height,
+			BlockTimeStamp: time.Now(),
+			GasLimit:       1000000,
+		})
+		ctx = protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{Tip: protocol.TipInfo{Height: height - 1}})
+		ctx = protocol.WithRegistry(genesis.WithGenesisContext(ctx, g), reg)
+		return protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
+	}
+	update := func(height uint64, caller address.Address, act *action.UpdateCandidateProfile) *action.Receipt {
+		nonces[caller.String()]++
+		ctx := ctxAt(height, caller, act)
+		r.NoError(p.Validate(ctx, act, sm))
+		receipt, err := p.Handle(ctx, act, sm)
+		r.NoError(err)
+		return receipt
+	}
+	readState := func(height uint64) *iotextypes.CandidateV2 {
+		method, err := proto.Marshal(&iotexapi.ReadStakingDataMethod{Method: iotexapi.ReadStakingDataMethod_CANDIDATE_BY_NAME})
+		r.NoError(err)
+		arg, err := proto.Marshal(&iotexapi.ReadStakingDataRequest{
+			Request: &iotexapi.ReadStakingDataRequest_CandidateByName_{
+				CandidateByName: &iotexapi.ReadStakingDataRequest_CandidateByName{CandName: candidate.Name},
+			},
+		})
+		r.NoError(err)
+		data, _, err := p.ReadState(ctxAt(height, owner, &action.UpdateCandidateProfile{}), sm, method, arg)
+		r.NoError(err)
+		c := &iotextypes.CandidateV2{}
+		r.NoError(proto.Unmarshal(data, c))
+		return c
+	}
+
+	// disabled before the feature is activated
+	act := action.NewUpdateCandidateProfile(0, 100000, big.NewInt(unit.Qev), "https://delegate.example", "a delegate", iconHash, "security@delegate.example")
+	r.ErrorIs(p.Validate(ctxAt(1, owner, act), act, sm), action.ErrInvalidAct)
+
+	// only the owner of a candidate can update the profile
+	receipt := update(2, stranger, act)
+	r.EqualValues(iotextypes.ReceiptStatus_ErrCandidateNotExist, receipt.Status)
+	receipt = update(2, owner, act)
+	r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+	cp, err := CandidateProfileFromCandidateV2(readState(2))
+	r.NoError(err)
+	r.Equal(&CandidateProfile{
+		URL:             "https://delegate.example",
+		Description:     "a delegate",
+		IconHash:        iconHash,
+		SecurityContact: "security@delegate.example",
+	}, cp)
+
+	// the profile replaces the previous one as a whole
+	receipt = update(3, owner, action.NewUpdateCandidateProfile(0, 100000, big.NewInt(unit.Qev), "https://new.example", "", nil, ""))
+	r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+	cp, err = CandidateProfileFromCandidateV2(readState(3))
+	r.NoError(err)
+	r.Equal(&CandidateProfile{URL: "https://new.example"}, cp)
+
+	// the profile takes no part in the candidates
+	cands2, _, err := newCandidateStateReader(sm).getAllCandidates()
+	r.NoError(err)
+	r.ElementsMatch(cands, cands2)
+
+	// the profile is returned along with a pending change of the reward address
+	p.config.RewardAddressDelayEpochs = 10
+	reward := identityset.Address(21)
+	cu, err := action.NewCandidateUpdate(0, "", "", reward.String(), 100000, big.NewInt(unit.Qev))
+	r.NoError(err)
+	nonces[owner.String()]++
+	ctx := ctxAt(3, owner, cu)
+	r.NoError(p.Validate(ctx, cu, sm))
+	receipt, err = p.Handle(ctx, cu, sm)
+	r.NoError(err)
+	r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+	r.NoError(p.Commit(ctx, sm))
+	c := readState(3)
+	cp, err = CandidateProfileFromCandidateV2(c)
+	r.NoError(err)
+	r.Equal(&CandidateProfile{URL: "https://new.example"}, cp)
+	ext := actionpb.CandidateV2Ext{}
+	r.NoError(action.LoadUnknownFields(c, &ext))
+	r.NotEmpty(ext.GetProfile())
+	r.Equal(reward.String(), ext.GetNextRewardAddress())
+	r.Equal(uint64(13), ext.GetNextRewardAddressEpoch())
+
+	// an empty profile removes it
+	receipt = update(4, owner, action.NewUpdateCandidateProfile(0, 100000, big.NewInt(unit.Qev), "", "", nil, ""))
+	r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+	cp, err = CandidateProfileFromCandidateV2(readState(4))
+	r.NoError(err)
+	r.Nil(cp)
+	_, err = NewCandidateProfileStateReader(sm).Get(candidate.GetIdentifier())
+	r.ErrorIs(err, state.ErrStateNotExist)
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-address/address"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+// contractCall is the native staking action called by a contract
+type contractCall interface {
+	action.Action
+	IntrinsicGas() (uint64, error)
+}
+
+var errInvalidContractCall = errors.New("invalid staking contract call")
+
+// HandleContractCall handles the staking operation called by a contract through the staking system contract. The
+// operation runs as the native action sent by the contract, except that the gas is charged and the nonce is updated
+// by the execution. A failed operation is reported in the result, and reverts the call of the contract
+func (p *Protocol) HandleContractCall(ctx context.Context, sm protocol.StateManager, caller address.Address, amount *big.Int, input []byte) (*evm.StakingCallResult, error) {
+	act, err := decodeContractCall(input, amount)
+	if err != nil {
+		log.L().Debug("Invalid staking contract call", zap.Error(err), zap.String("caller", caller.String()))
+		return &evm.StakingCallResult{}, nil
+	}
+	gas, err := act.IntrinsicGas()
+	if err != nil {
+		return &evm.StakingCallResult{}, nil
+	}
+	actionCtx := protocol.MustGetActionCtx(ctx)
+	actionCtx.Caller = caller
+	actionCtx.GasPrice = big.NewInt(0)
+	actionCtx.IntrinsicGas = gas
+	ctx = protocol.WithActionCtx(ctx, actionCtx)
+	if _, ok := protocol.GetFeatureWithHeightCtx(ctx); !ok {
+		ctx = protocol.WithFeatureWithHeightCtx(ctx)
+	}
+	result := &evm.StakingCallResult{GasConsumed: gas}
+	if err := act.SanityCheck(); err != nil {
+		log.L().Debug("Invalid staking contract call", zap.Error(err), zap.String("caller", caller.String()))
+		return result, nil
+	}
+	if err := p.Validate(ctx, act, sm); err != nil {
+		log.L().Debug("Invalid staking contract call", zap.Error(err), zap.String("caller", caller.String()))
+		return result, nil
+	}
+
+	height, err := sm.Height()
+	if err != nil {
+		return nil, err
+	}
+	csm, err := NewCandidateStateManager(sm, protocol.MustGetFeatureWithHeightCtx(ctx).ReadStateFromDB(height))
+	if err != nil {
+		return nil, err
+	}
+	result.Undo = snapshotDock(csm)
+	var (
+		rLog  *receiptLog
+		tLogs []*action.TransactionLog
+	)
+	switch act := act.(type) {
+	case *action.CreateStake:
+		// the bucket created takes the next index
+		result.BucketIndex, err = newCandidateStateReader(sm).getTotalBucketCount()
+		if err != nil && errors.Cause(err) != state.ErrStateNotExist {
+			return nil, err
+		}
+		rLog, tLogs, err = p.handleCreateStake(ctx, act, csm)
+	case *action.DepositToStake:
+		result.BucketIndex = act.BucketIndex()
+		rLog, tLogs, err = p.handleDepositToStake(ctx, act, csm)
+	case *action.Unstake:
+		result.BucketIndex = act.BucketIndex()
+		rLog, err = p.handleUnstake(ctx, act, csm)
+	case *action.ChangeCandidate:
+		result.BucketIndex = act.BucketIndex()
+		rLog, err = p.handleChangeCandidate(ctx, act, csm)
+	}
+	if err != nil {
+		if _, ok := err.(ReceiptError); !ok {
+			return nil, err
+		}
+		log.L().Debug("Failed to handle staking contract call", zap.Error(err), zap.String("caller", caller.String()))
+		return result, nil
+	}
+	result.Success = true
+	if l := rLog.Build(ctx, nil); l != nil {
+		result.Logs = append(result.Logs, l)
+	}
+	result.TransactionLogs = tLogs
+	return result, nil
+}
+
+// decodeContractCall decodes the staking operation called by a contract, which funds the bucket with the amount
+// sent along
+func decodeContractCall(input []byte, amount *big.Int) (contractCall, error) {
+	if act, err := action.NewCreateStakeFromABIBinary(input); err == nil {
+		if act.Amount().Cmp(amount) != 0 {
+			return nil, errors.Wrapf(errInvalidContractCall, "amount %s does not match the value %s", act.Amount(), amount)
+		}
+		return act, nil
+	}
+	if act, err := action.NewDepositToStakeFromABIBinary(input); err == nil {
+		if act.Amount().Cmp(amount) != 0 {
+			return nil, errors.Wrapf(errInvalidContractCall, "amount %s does not match the value %s", act.Amount(), amount)
+		}
+		return act, nil
+	}
+	if amount.Sign() != 0 {
+		return nil, errors.Wrap(errInvalidContractCall, "value sent to a non-payable operation")
+	}
+	if act, err := action.NewUnstakeFromABIBinary(input); err == nil {
+		return act, nil
+	}
+	if act, err := action.NewChangeCandidateFromABIBinary(input); err == nil {
+		return act, nil
+	}
+	return nil, errInvalidContractCall
+}
+
+// snapshotDock returns the function restoring the changes of the candidate center and the bucket pool stashed in
+// the dock of sm, which is not reverted along with sm
+func snapshotDock(csm CandidateStateManager) func() error {
+	var (
+		view  = csm.DirtyView()
+		delta = view.candCenter.Delta()
+		pool  = view.bucketPool.Copy(view.bucketPool.enableSMStorage)
+	)
+	return func() error {
+		if err := csm.SM().Load(_protocolID, _stakingCandCenter, &delta); err != nil {
+			return err
+		}
+		if pool.enableSMStorage {
+			return nil
+		}
+		return csm.SM().Load(_protocolID, _stakingBucketPool, pool.total)
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+const (
+	handleReportMisbehavior = "reportMisbehavior"
+)
+
+func (p *Protocol) handleReportMisbehavior(ctx context.Context, act *action.ReportMisbehavior, csm CandidateStateManager,
+) (*receiptLog, []*action.TransactionLog, error) {
+	actCtx := protocol.MustGetActionCtx(ctx)
+	blkCtx := protocol.MustGetBlockCtx(ctx)
+	featureCtx := protocol.MustGetFeatureCtx(ctx)
+	log := newReceiptLog(p.addr.String(), handleReportMisbehavior, featureCtx.NewStakingReceiptFormat)
+
+	reporter, fetchErr := fetchCaller(ctx, csm, big.NewInt(0))
+	if fetchErr != nil {
+		return log, nil, fetchErr
+	}
+	ev, err := verifyMisbehavior(act)
+	if err != nil {
+		return log, nil, &handleError{
+			err:           err,
+			failureStatus: iotextypes.ReceiptStatus_ErrUnknown,
+		}
+	}
+	operator := ev.endorser.Address()
+	log.AddTopics(operator.Bytes(), byteutil.Uint64ToBytesBigEndian(ev.height))
+
+	// a delegate is penalized once per height, whichever conflicting messages are reported
+	msm := NewMisbehaviorStateManager(csm.SM())
+	switch _, err := msm.Get(operator, ev.height); errors.Cause(err) {
+	case nil:
+		failureStatus := iotextypes.ReceiptStatus_Failure
+		if featureCtx.EnableExtendedReceiptStatus {
+			failureStatus = iotextypes.ReceiptStatus(action.ReceiptStatusErrMisbehaviorReported)
+		}
+		return log, nil, &handleError{
+			err:           errors.Errorf("misbehavior of %s at height %d is already reported", operator.String(), ev.height),
+			failureStatus: failureStatus,
+		}
+	case state.ErrStateNotExist:
+	default:
+		return log, nil, errors.Wrapf(err, "failed to get misbehavior of %s at height %d", operator.String(), ev.height)
+	}
+	candidate := csm.DirtyView().candCenter.GetByOperator(operator)
+	if candidate == nil {
+		return log, nil, &handleError{
+			err:           errors.Errorf("no candidate operated by %s", operator.String()),
+			failureStatus: iotextypes.ReceiptStatus_ErrCandidateNotExist,
+		}
+	}
+
+	slashed, err := p.slashSelfStake(ctx, csm, candidate)
+	if err != nil {
+		return log, nil, err
+	}
+	bounty := new(big.Int).Mul(slashed, big.NewInt(int64(p.config.MisbehaviorBountyRate)))
+	bounty.Div(bounty, big.NewInt(100))
+	rest := new(big.Int).Sub(slashed, bounty)
+	// the slashed amount is paid to the reporter, who deposits the rest into the rewarding fund
+	if err := reporter.AddBalance(slashed); err != nil {
+		return log, nil, errors.Wrapf(err, "failed to add balance to reporter %s", actCtx.Caller.String())
+	}
+	if err := accountutil.StoreAccount(csm.SM(), actCtx.Caller, reporter); err != nil {
+		return log, nil, errors.Wrapf(err, "failed to store account %s", actCtx.Caller.String())
+	}
+	if rest.Sign() > 0 {
+		if _, err := p.helperCtx.DepositGas(ctx, csm.SM(), rest); err != nil {
+			return log, nil, errors.Wrap(err, "failed to deposit the slashed amount")
+		}
+	}
+
+	// the probation is counted in epochs, which do not exist on a chain without rolldpos
+	var probationEnd uint64
+	if rp := rolldpos.FindProtocol(protocol.MustGetRegistry(ctx)); rp != nil {
+		probationEnd = rp.GetEpochHeight(rp.GetEpochNum(blkCtx.BlockHeight) + p.config.MisbehaviorProbationEpochs + 1)
+		probation, err := msm.Probation(candidate.GetIdentifier())
+		if err != nil {
+			return log, nil, errors.Wrapf(err, "failed to get probation of %s", candidate.GetIdentifier().String())
+		}
+		if probation.EndHeight < probationEnd {
+			probation.EndHeight = probationEnd
+			if err := msm.PutProbation(candidate.GetIdentifier(), probation); err != nil {
+				return log, nil, errors.Wrapf(err, "failed to put probation of %s", candidate.GetIdentifier().String())
+			}
+		}
+	}
+	if err := msm.Put(&Misbehavior{
+		Candidate:          candidate.GetIdentifier(),
+		Operator:           operator,
+		Height:             ev.height,
+		Reporter:           actCtx.Caller,
+		ReportHeight:       blkCtx.BlockHeight,
+		Slashed:            slashed,
+		Bounty:             bounty,
+		ProbationEndHeight: probationEnd,
+	}); err != nil {
+		return log, nil, errors.Wrapf(err, "failed to put misbehavior of %s at height %d", operator.String(), ev.height)
+	}
+
+	log.AddAddress(candidate.GetIdentifier())
+	log.AddAddress(actCtx.Caller)
+	log.SetData(slashed.Bytes())
+	log.AddVotesEvent(candidate)
+	tLogs := []*action.TransactionLog{
+		{
+			Type:      iotextypes.TransactionLogType_WITHDRAW_BUCKET,
+			Sender:    address.StakingBucketPoolAddr,
+			Recipient: actCtx.Caller.String(),
+			Amount:    slashed,
+		},
+	}
+	if rest.Sign() > 0 {
+		tLogs = append(tLogs, &action.TransactionLog{
+			Type:      iotextypes.TransactionLogType_DEPOSIT_TO_REWARDING_FUND,
+			Sender:    actCtx.Caller.String(),
+			Recipient: address.RewardingPoolAddr,
+			Amount:    rest,
+		})
+	}
+	return log, tLogs, nil
+}
+
+// slashSelfStake slashes the self-stake bucket of the candidate by the slash rate, and returns the slashed amount
+func (p *Protocol) slashSelfStake(ctx context.Context, csm CandidateStateManager, candidate *Candidate) (*big.Int, error) {
+	featureCtx := protocol.MustGetFeatureCtx(ctx)
+	bucket, err := csm.getBucket(candidate.SelfStakeBucketIdx)
+	switch errors.Cause(err) {
+	case nil:
+	case state.ErrStateNotExist:
+		// no self-stake to slash
+		return big.NewInt(0), nil
+	default:
+		return nil, errors.Wrapf(err, "failed to get bucket %d", candidate.SelfStakeBucketIdx)
+	}
+	selfStake, err := isSelfStakeBucket(featureCtx, csm, bucket)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to check self-stake bucket %d", bucket.Index)
+	}
+	if !selfStake {
+		return big.NewInt(0), nil
+	}
+	slashed := new(big.Int).Mul(bucket.StakedAmount, big.NewInt(int64(p.config.MisbehaviorSlashRate)))
+	slashed.Div(slashed, big.NewInt(100))
+	if slashed.Sign() == 0 {
+		return slashed, nil
+	}
+
+	prevWeightedVotes := p.calculateVoteWeight(bucket, true)
+	bucket.StakedAmount.Sub(bucket.StakedAmount, slashed)
+	if err := csm.updateBucket(bucket.Index, bucket); err != nil {
+		return nil, errors.Wrapf(err, "failed to update bucket %d", bucket.Index)
+	}
+	if err := candidate.SubVote(prevWeightedVotes); err != nil {
+		return nil, errors.Wrapf(err, "failed to subtract vote for candidate %s", candidate.GetIdentifier().String())
+	}
+	if err := candidate.AddVote(p.calculateVoteWeight(bucket, true)); err != nil {
+		return nil, errors.Wrapf(err, "failed to add vote for candidate %s", candidate.GetIdentifier().String())
+	}
+	if err := candidate.SubSelfStake(slashed); err != nil {
+		return nil, errors.Wrapf(err, "failed to subtract self stake for candidate %s", candidate.GetIdentifier().String())
+	}
+	if err := csm.Upsert(candidate); err != nil {
+		return nil, csmErrorToHandleError(candidate.GetIdentifier().String(), err)
+	}
+	if err := csm.SlashBucketPool(slashed); err != nil {
+		return nil, errors.Wrap(err, "failed to update staking bucket pool")
+	}
+	return slashed, nil
+}
+
+// misbehaviorProbation applies the probation intensity to the votes of the candidate on probation at the height
+func (p *Protocol) misbehaviorProbation(sr protocol.StateReader, cand *Candidate, height uint64) error {
+	probation, err := NewMisbehaviorStateReader(sr).Probation(cand.GetIdentifier())
+	if err != nil {
+		return errors.Wrapf(err, "failed to get probation of %s", cand.GetIdentifier().String())
+	}
+	if height >= probation.EndHeight {
+		return nil
+	}
+	cand.Votes.Mul(cand.Votes, big.NewInt(int64(100-p.config.MisbehaviorProbationIntensity)))
+	cand.Votes.Div(cand.Votes, big.NewInt(100))
+	return nil
+}
@@ -0,0 +1,237 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	blake2b "github.com/minio/blake2b-simd"
+	"github.com/mohae/deepcopy"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func signedConsensusVote(r *require.Assertions, sk crypto.PrivateKey, height uint64, topic iotextypes.ConsensusVote_Topic, blkHash []byte, ts time.Time) []byte {
+	vote := &iotextypes.ConsensusVote{BlockHash: blkHash, Topic: topic}
+	ser, err := proto.Marshal(vote)
+	r.NoError(err)
+	h := blake2b.Sum256(ser)
+	en, err := endorsement.Endorse(sk, consensusDocument(h[:]), ts)
+	r.NoError(err)
+	enPb, err := en.Proto()
+	r.NoError(err)
+	b, err := proto.Marshal(&iotextypes.ConsensusMessage{
+		Height:      height,
+		Endorsement: enPb,
+		Msg:         &iotextypes.ConsensusMessage_Vote{Vote: vote},
+	})
+	r.NoError(err)
+	return b
+}
+
+func TestMisbehavior(t *testing.T) {
+	r := require.New(t)
+	m := &Misbehavior{
+		Candidate:          identityset.Address(1),
+		Operator:           identityset.Address(2),
+		Height:             10,
+		Reporter:           identityset.Address(3),
+		ReportHeight:       12,
+		Slashed:            big.NewInt(100),
+		Bounty:             big.NewInt(10),
+		ProbationEndHeight: 20,
+	}
+	b, err := m.Serialize()
+	r.NoError(err)
+	m2 := &Misbehavior{}
+	r.NoError(m2.Deserialize(b))
+	r.Equal(m, m2)
+
+	ts := time.Now()
+	report := func(first, second []byte) *action.ReportMisbehavior {
+		return action.NewReportMisbehavior(1, 100000, big.NewInt(0), first, second)
+	}
+	sk, other := identityset.PrivateKey(7), identityset.PrivateKey(8)
+	vote := func(sk crypto.PrivateKey, height uint64, topic iotextypes.ConsensusVote_Topic, blkHash []byte, ts time.Time) []byte {
+		return signedConsensusVote(r, sk, height, topic, blkHash, ts)
+	}
+	commit := func(blkHash []byte) []byte {
+		return vote(sk, 10, iotextypes.ConsensusVote_COMMIT, blkHash, ts)
+	}
+	ev, err := verifyMisbehavior(report(commit([]byte{1}), commit([]byte{2})))
+	r.NoError(err)
+	r.Equal(sk.PublicKey().Address(), ev.endorser.Address())
+	r.Equal(uint64(10), ev.height)
+
+	for _, second := range [][]byte{
+		commit([]byte{1}),
+		commit(nil),
+		vote(other, 10, iotextypes.ConsensusVote_COMMIT, []byte{2}, ts),
+		vote(sk, 11, iotextypes.ConsensusVote_COMMIT, []byte{2}, ts),
+		vote(sk, 10, iotextypes.ConsensusVote_LOCK, []byte{2}, ts),
+		vote(sk, 10, iotextypes.ConsensusVote_COMMIT, []byte{2}, ts.Add(time.Second)),
+	} {
+		_, err := verifyMisbehavior(report(commit([]byte{1}), second))
+		r.ErrorIs(err, action.ErrInvalidMisbehaviorReport)
+	}
+	// the signature does not match the message
+	msg := &iotextypes.ConsensusMessage{}
+	r.NoError(proto.Unmarshal(commit([]byte{2}), msg))
+	msg.GetVote().BlockHash = []byte{3}
+	forged, err := proto.Marshal(msg)
+	r.NoError(err)
+	_, err = verifyMisbehavior(report(commit([]byte{1}), forged))
+	r.ErrorIs(err, action.ErrInvalidMisbehaviorReport)
+}
+
+func TestProtocol_HandleReportMisbehavior(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm, p, candidate, _ := initAll(t, ctrl)
+	// keep the slashed candidate active to check the probation
+	p.config.RegistrationConsts.MinSelfStake = unit.ConvertIotxToRau(1000000)
+	var (
+		reporter = identityset.Address(20)
+		operator = identityset.PrivateKey(7)
+		nonce    = uint64(0)
+		g        = deepcopy.Copy(genesis.Default).(genesis.Genesis)
+		rp       = rolldpos.NewProtocol(1, 1, 1)
+		reg      = protocol.NewRegistry()
+	)
+	r.NoError(rp.Register(reg))
+	g.FbkMigrationBlockHeight = 0
+	g.ToBeEnabledBlockHeight = 0
+	r.Equal(operator.PublicKey().Address(), candidate.Operator)
+	// bucket 0 of another staker, and bucket 1 as the self-stake of the candidate
+	initCreateStake(t, sm, identityset.Address(2), 2000000, big.NewInt(0), 10000, 1, 1, time.Now(), 10000, p, candidate, "100000000000000000000", false)
+	initCreateStake(t, sm, candidate.Owner, 2000000, big.NewInt(0), 10000, 1, 1, time.Now(), 10000, p, candidate, candidate.SelfStake.String(), false)
+	r.NoError(setupAccount(sm, reporter, 100))
+
+	var deposited []*big.Int
+	p.helperCtx.DepositGas = func(ctx context.Context, sm protocol.StateManager, amount *big.Int, opts ...protocol.Option) ([]*action.TransactionLog, error) {
+		if amount.Sign() > 0 {
+			deposited = append(deposited, amount)
+		}
+		return depositGas(ctx, sm, amount, opts...)
+	}
+	ctxAt := func(height uint64) context.Context {
+		ctx := protocol.WithBlockCtx(context.Background(), protocol.BlockCtx{
+			BlockHeight:    height,
+			BlockTimeStamp: time.Now(),
+			GasLimit:       1000000,
+		})
+		ctx = protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{Tip: protocol.TipInfo{Height: height - 1}})
+		ctx = protocol.WithRegistry(genesis.WithGenesisContext(ctx, g), reg)
+		return protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
+	}
+	report := func(height uint64, first, second []byte) *action.Receipt {
+		nonce++
+		act := action.NewReportMisbehavior(nonce+1, 100000, big.NewInt(0), first, second)
+		intrinsic, err := act.IntrinsicGas()
+		r.NoError(err)
+		ctx := protocol.WithActionCtx(ctxAt(height), protocol.ActionCtx{
+			Caller:       reporter,
+			GasPrice:     big.NewInt(0),
+			IntrinsicGas: intrinsic,
+			Nonce:        nonce,
+		})
+		r.NoError(p.Validate(ctx, act, sm))
+		receipt, err := p.Handle(ctx, act, sm)
+		r.NoError(err)
+		return receipt
+	}
+	votes := func(height uint64) *big.Int {
+		// the candidates are read from the committed view
+		csm, err := NewCandidateStateManager(sm, false)
+		r.NoError(err)
+		r.NoError(csm.Commit(ctxAt(height)))
+		list, err := p.ActiveCandidates(ctxAt(height), &heightStateReader{sm, height}, height)
+		r.NoError(err)
+		for _, c := range list {
+			if c.Address == candidate.Operator.String() {
+				return c.Votes
+			}
+		}
+		r.FailNow("candidate is not active")
+		return nil
+	}
+	balance := func() *big.Int {
+		acc, err := accountutil.LoadAccount(sm, reporter)
+		r.NoError(err)
+		return acc.Balance
+	}
+
+	var (
+		reportHeight = uint64(20)
+		ts           = time.Now()
+		first        = signedConsensusVote(r, operator, 15, iotextypes.ConsensusVote_COMMIT, []byte{1}, ts)
+		second       = signedConsensusVote(r, operator, 15, iotextypes.ConsensusVote_COMMIT, []byte{2}, ts)
+		selfStake    = new(big.Int).Set(candidate.SelfStake)
+		prevVotes    = votes(reportHeight)
+		prevBalance  = balance()
+	)
+	receipt := report(reportHeight, first, second)
+	r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+	logs := receipt.Logs()
+	r.Len(logs, 2)
+	r.Equal(hash.Hash256(_stakingEvents.Events[action.StakingEventVotesChanged].ID), logs[1].Topics[0])
+	r.Equal(hash.BytesToHash256(candidate.GetIdentifier().Bytes()), logs[1].Topics[1])
+
+	// 10% of the self-stake is slashed, and the reporter keeps 10% of it as the bounty
+	slashed := new(big.Int).Div(selfStake, big.NewInt(10))
+	bounty := new(big.Int).Div(slashed, big.NewInt(10))
+	r.Equal(new(big.Int).Add(prevBalance, bounty), balance())
+	r.Equal([]*big.Int{new(big.Int).Sub(slashed, bounty)}, deposited)
+	csm, err := NewCandidateStateManager(sm, false)
+	r.NoError(err)
+	bucket, err := csm.getBucket(candidate.SelfStakeBucketIdx)
+	r.NoError(err)
+	r.Equal(new(big.Int).Sub(selfStake, slashed), bucket.StakedAmount)
+	cand := csm.GetByIdentifier(candidate.GetIdentifier())
+	r.Equal(new(big.Int).Sub(selfStake, slashed), cand.SelfStake)
+	m, err := NewMisbehaviorStateReader(sm).Get(candidate.Operator, 15)
+	r.NoError(err)
+	r.Equal(reporter, m.Reporter)
+	r.Equal(slashed, m.Slashed)
+	r.Equal(bounty, m.Bounty)
+	probationEnd := rp.GetEpochHeight(rp.GetEpochNum(reportHeight) + g.Staking.MisbehaviorProbationEpochs + 1)
+	r.Equal(probationEnd, m.ProbationEndHeight)
+
+	// the votes are reduced by the probation intensity until the probation ends
+	r.True(cand.Votes.Cmp(prevVotes) < 0)
+	r.Equal(new(big.Int).Div(new(big.Int).Mul(cand.Votes, big.NewInt(10)), big.NewInt(100)), votes(reportHeight+1))
+	r.Equal(cand.Votes, votes(probationEnd))
+
+	// the same incident is penalized once, whichever conflicting messages are reported
+	third := signedConsensusVote(r, operator, 15, iotextypes.ConsensusVote_COMMIT, []byte{3}, ts)
+	for _, evidence := range [][2][]byte{{second, first}, {first, third}} {
+		receipt = report(reportHeight+1, evidence[0], evidence[1])
+		r.EqualValues(action.ReceiptStatusErrMisbehaviorReported, receipt.Status)
+	}
+	r.Len(deposited, 1)
+	r.Equal(new(big.Int).Add(prevBalance, bounty), balance())
+
+	// the evidence of the messages of different delegates is rejected
+	act := action.NewReportMisbehavior(nonce+1, 100000, big.NewInt(0), first,
+		signedConsensusVote(r, identityset.PrivateKey(8), 15, iotextypes.ConsensusVote_COMMIT, []byte{2}, ts))
+	r.ErrorIs(p.Validate(protocol.WithActionCtx(ctxAt(reportHeight+1), protocol.ActionCtx{Caller: reporter}), act, sm), action.ErrInvalidMisbehaviorReport)
+}
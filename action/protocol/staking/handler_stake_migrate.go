@@ -20,35 +20,50 @@ import (
 	"github.com/iotexproject/iotex-core/state"
 )
 
-func (p *Protocol) handleStakeMigrate(ctx context.Context, act *action.MigrateStake, csm CandidateStateManager) ([]*action.Log, []*action.TransactionLog, uint64, uint64, error) {
+// handleStakeMigrate migrates the native bucket to the staking contract, and returns the gas of the system call to the
+// contract if it is executed on the system call gas budget
+func (p *Protocol) handleStakeMigrate(ctx context.Context, act *action.MigrateStake, csm CandidateStateManager) ([]*action.Log, []*action.TransactionLog, uint64, uint64, uint64, error) {
 	actLogs := make([]*action.Log, 0)
 	transferLogs := make([]*action.TransactionLog, 0)
 	insGas, err := act.IntrinsicGas()
 	if err != nil {
-		return nil, nil, 0, 0, err
+		return nil, nil, 0, 0, 0, err
 	}
 	gasConsumed := insGas
 	gasToBeDeducted := insGas
 	bucket, rErr := p.fetchBucket(csm, act.BucketIndex())
 	if rErr != nil {
-		return nil, nil, gasConsumed, gasToBeDeducted, rErr
+		return nil, nil, gasConsumed, gasToBeDeducted, 0, rErr
 	}
 	staker, rerr := fetchCaller(ctx, csm, big.NewInt(0))
 	if rerr != nil {
-		return nil, nil, gasConsumed, gasToBeDeducted, errors.Wrap(rerr, "failed to fetch caller")
+		return nil, nil, gasConsumed, gasToBeDeducted, 0, errors.Wrap(rerr, "failed to fetch caller")
 	}
 	candidate := csm.GetByIdentifier(bucket.Candidate)
 	if candidate == nil {
-		return nil, nil, gasConsumed, gasToBeDeducted, errCandNotExist
+		return nil, nil, gasConsumed, gasToBeDeducted, 0, errCandNotExist
 	}
 	duration := uint64(bucket.StakedDuration / p.helperCtx.BlockInterval(protocol.MustGetBlockCtx(ctx).BlockHeight))
 	exec, err := p.constructExecution(candidate.GetIdentifier(), bucket.StakedAmount, duration, act.Nonce(), act.GasLimit(), act.GasPrice())
 	if err != nil {
-		return nil, nil, gasConsumed, gasToBeDeducted, errors.Wrap(err, "failed to construct execution")
+		return nil, nil, gasConsumed, gasToBeDeducted, 0, errors.Wrap(err, "failed to construct execution")
 	}
 	// validate bucket index
 	if err := p.validateStakeMigrate(ctx, bucket, csm); err != nil {
-		return nil, nil, gasConsumed, gasToBeDeducted, err
+		return nil, nil, gasConsumed, gasToBeDeducted, 0, err
+	}
+	if protocol.IsParameterEnabled(ctx, p, _paramStakeTransferLock) {
+		// the migrated bucket is freely transferable in the staking contract
+		contractAddr, err := address.FromString(p.config.MigrateContractAddress)
+		if err != nil {
+			return nil, nil, gasConsumed, gasToBeDeducted, 0, errors.Wrap(err, "failed to get staking contract address")
+		}
+		if rejectLog, err := p.checkTransferLock(ctx, csm.SR(), bucket.Owner, contractAddr, byteutil.Uint64ToBytesBigEndian(bucket.Index)); err != nil {
+			if rejectLog != nil {
+				actLogs = append(actLogs, rejectLog.Build(ctx, err))
+			}
+			return actLogs, nil, gasConsumed, gasToBeDeducted, 0, err
+		}
 	}
 
 	// snapshot for sm in case of failure of hybrid protocol handling
@@ -62,21 +77,30 @@ func (p *Protocol) handleStakeMigrate(ctx context.Context, act *action.MigrateSt
 	actLog, tLog, err := p.withdrawBucket(ctx, staker, bucket, candidate, csm)
 	if err != nil {
 		revertSM()
-		return nil, nil, gasConsumed, gasToBeDeducted, err
+		return nil, nil, gasConsumed, gasToBeDeducted, 0, err
 	}
 	actLogs = append(actLogs, actLog.Build(ctx, nil))
+	actLogs = append(actLogs, actLog.BuildEvents(ctx)...)
 	transferLogs = append(transferLogs, tLog)
+	exctPtl := execution.FindProtocol(protocol.MustGetRegistry(ctx))
+	if exctPtl == nil {
+		revertSM()
+		return nil, nil, gasConsumed, gasToBeDeducted, 0, errors.New("execution protocol is not registered")
+	}
+	if exctPtl.IsSystemCallEnabled(ctx) {
+		return p.createNFTBucketBySystemCall(ctx, exctPtl, exec, csm, actLogs, transferLogs, gasConsumed, revertSM)
+	}
 	// call staking contract to stake
-	excReceipt, err := p.createNFTBucket(ctx, exec, csm.SM())
+	excReceipt, err := exctPtl.Handle(ctx, exec, csm.SM())
 	if err != nil {
 		revertSM()
-		return nil, nil, gasConsumed, gasToBeDeducted, errors.Wrap(err, "failed to handle execution action")
+		return nil, nil, gasConsumed, gasToBeDeducted, 0, errors.Wrap(err, "failed to handle execution action")
 	}
 	gasConsumed += excReceipt.GasConsumed
 	if excReceipt.Status != uint64(iotextypes.ReceiptStatus_Success) {
 		revertSM()
 		gasToBeDeducted = gasConsumed
-		return nil, nil, gasConsumed, gasToBeDeducted, &handleError{
+		return nil, nil, gasConsumed, gasToBeDeducted, 0, &handleError{
 			err:           errors.Errorf("staking contract failure: %s", excReceipt.ExecutionRevertMsg()),
 			failureStatus: iotextypes.ReceiptStatus(excReceipt.Status),
 		}
@@ -84,7 +108,49 @@ func (p *Protocol) handleStakeMigrate(ctx context.Context, act *action.MigrateSt
 	// add sub-receipts logs
 	actLogs = append(actLogs, excReceipt.Logs()...)
 	transferLogs = append(transferLogs, excReceipt.TransactionLogs()...)
-	return actLogs, transferLogs, gasConsumed, gasToBeDeducted, nil
+	return actLogs, transferLogs, gasConsumed, gasToBeDeducted, 0, nil
+}
+
+// createNFTBucketBySystemCall calls the staking contract to stake on the system call gas budget, the caller pays the
+// intrinsic gas of the action only. The gas of a failed call is charged to the budget again after the states are
+// reverted, so that failed migrations can't consume the computation for free
+func (p *Protocol) createNFTBucketBySystemCall(
+	ctx context.Context,
+	exctPtl *execution.Protocol,
+	exec *action.Execution,
+	csm CandidateStateManager,
+	actLogs []*action.Log,
+	transferLogs []*action.TransactionLog,
+	gasConsumed uint64,
+	revertSM func(),
+) ([]*action.Log, []*action.TransactionLog, uint64, uint64, uint64, error) {
+	excReceipt, err := exctPtl.SystemCall(ctx, p, exec, csm.SM())
+	switch errors.Cause(err) {
+	case nil:
+	case execution.ErrSystemGasBudgetExhausted:
+		revertSM()
+		return nil, nil, gasConsumed, gasConsumed, 0, &handleError{
+			err:           err,
+			failureStatus: iotextypes.ReceiptStatus_ErrOutOfGas,
+		}
+	default:
+		revertSM()
+		return nil, nil, gasConsumed, gasConsumed, 0, errors.Wrap(err, "failed to handle system call")
+	}
+	systemGas := excReceipt.SystemGas()
+	if excReceipt.Status != uint64(iotextypes.ReceiptStatus_Success) {
+		revertSM()
+		if err := exctPtl.ChargeSystemGas(ctx, csm.SM(), systemGas); err != nil {
+			return nil, nil, gasConsumed, gasConsumed, 0, errors.Wrap(err, "failed to charge system call gas")
+		}
+		return nil, nil, gasConsumed, gasConsumed, systemGas, &handleError{
+			err:           errors.Errorf("staking contract failure: %s", excReceipt.ExecutionRevertMsg()),
+			failureStatus: iotextypes.ReceiptStatus(excReceipt.Status),
+		}
+	}
+	actLogs = append(actLogs, excReceipt.Logs()...)
+	transferLogs = append(transferLogs, excReceipt.TransactionLogs()...)
+	return actLogs, transferLogs, gasConsumed, gasConsumed, systemGas, nil
 }
 
 func (p *Protocol) validateStakeMigrate(ctx context.Context, bucket *VoteBucket, csm CandidateStateManager) error {
@@ -149,6 +215,8 @@ func (p *Protocol) withdrawBucket(ctx context.Context, withdrawer *state.Account
 	actLog.AddTopics(byteutil.Uint64ToBytesBigEndian(bucket.Index), bucket.Candidate.Bytes())
 	actLog.AddAddress(actionCtx.Caller)
 	actLog.SetData(bucket.StakedAmount.Bytes())
+	actLog.AddBucketEvent(action.StakingEventBucketWithdrawn, bucket)
+	actLog.AddVotesEvent(cand)
 	return actLog, &action.TransactionLog{
 		Type:      iotextypes.TransactionLogType_WITHDRAW_BUCKET,
 		Amount:    bucket.StakedAmount,
@@ -191,15 +259,3 @@ func (p *Protocol) constructExecution(candidate address.Address, amount *big.Int
 		data,
 	)
 }
-
-func (p *Protocol) createNFTBucket(ctx context.Context, exeAct *action.Execution, sm protocol.StateManager) (*action.Receipt, error) {
-	exctPtl := execution.FindProtocol(protocol.MustGetRegistry(ctx))
-	if exctPtl == nil {
-		return nil, errors.New("execution protocol is not registered")
-	}
-	excReceipt, err := exctPtl.Handle(ctx, exeAct, sm)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to handle execution action")
-	}
-	return excReceipt, nil
-}
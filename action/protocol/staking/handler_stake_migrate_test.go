@@ -340,7 +340,64 @@ func TestHandleStakeMigrate(t *testing.T) {
 		r.NotNil(cand)
 		r.Equal(preVotes, cand.Votes.Add(cand.Votes, p.calculateVoteWeight(bkt, false)))
 	})
-
+	t.Run("system call exhausting the budget", func(t *testing.T) {
+		pa := NewPatches()
+		defer pa.Reset()
+		sm.EXPECT().Revert(gomock.Any()).Return(nil).AnyTimes()
+		migratorID, migratorNonce := 3, uint64(0)
+		r.NoError(initAccountBalance(sm, identityset.Address(migratorID), balance))
+		receipts, _ := runBlock(ctx, p, sm, 12, timeBlock,
+			assertions.MustNoErrorV(action.SignedCreateStake(popNonce(&migratorNonce), "cand1", stakeAmount.String(), stakeDurationDays, true, nil, gasLimit, gasPrice, identityset.PrivateKey(migratorID))),
+			assertions.MustNoErrorV(action.SignedCreateStake(popNonce(&migratorNonce), "cand1", stakeAmount.String(), stakeDurationDays, true, nil, gasLimit, gasPrice, identityset.PrivateKey(migratorID))),
+		)
+		r.Len(receipts, 2)
+		systemReceipt := &action.Receipt{
+			Status:      uint64(iotextypes.ReceiptStatus_Success),
+			BlockHeight: 13,
+			GasConsumed: 150000,
+		}
+		systemReceipt.SetSystemGas(systemReceipt.GasConsumed)
+		budgetLeft := uint64(200000)
+		pa.ApplyMethodReturn(excPrtl, "IsSystemCallEnabled", true)
+		pa.ApplyMethodFunc(excPrtl, "SystemCall", func(_ context.Context, caller protocol.Protocol, exec *action.Execution, sm protocol.StateManager) (*action.Receipt, error) {
+			r.Equal(p, caller)
+			if budgetLeft < systemReceipt.GasConsumed {
+				return nil, execution.ErrSystemGasBudgetExhausted
+			}
+			budgetLeft -= systemReceipt.GasConsumed
+			// the nonce of the caller is updated by the execution
+			acc, err := accountutil.LoadAccount(sm, identityset.Address(migratorID))
+			r.NoError(err)
+			r.NoError(acc.SetPendingNonce(acc.PendingNonce() + 1))
+			r.NoError(accountutil.StoreAccount(sm, identityset.Address(migratorID), acc))
+			return systemReceipt, nil
+		})
+		pa.ApplyMethodFunc(excPrtl, "Handle", func(context.Context, action.Action, protocol.StateManager) (*action.Receipt, error) {
+			r.FailNow("the user pays the call if the system call is enabled")
+			return nil, nil
+		})
+		acts := []*action.SealedEnvelope{
+			assertions.MustNoErrorV(action.SignedMigrateStake(popNonce(&migratorNonce), 7, gasLimit, gasPrice, identityset.PrivateKey(migratorID))),
+			assertions.MustNoErrorV(action.SignedMigrateStake(popNonce(&migratorNonce), 8, gasLimit, gasPrice, identityset.PrivateKey(migratorID))),
+		}
+		receipts, errs := runBlock(ctx, p, sm, 13, timeBlock, acts...)
+		r.Len(receipts, 2)
+		r.NoError(errs[0])
+		r.NoError(errs[1])
+		instriGas, _ := acts[0].IntrinsicGas()
+		// the first migration is paid by the system call gas budget, the caller pays the intrinsic gas only
+		r.Equal(uint64(iotextypes.ReceiptStatus_Success), receipts[0].Status)
+		r.Equal(instriGas, receipts[0].GasConsumed)
+		r.Equal(systemReceipt.GasConsumed, receipts[0].SystemGas())
+		// the second one fails once the budget is exhausted
+		r.Equal(uint64(iotextypes.ReceiptStatus_ErrOutOfGas), receipts[1].Status)
+		r.Equal(instriGas, receipts[1].GasConsumed)
+		r.Zero(receipts[1].SystemGas())
+		csm, err := NewCandidateStateManager(sm, false)
+		r.NoError(err)
+		_, err = csm.getBucket(7)
+		r.ErrorIs(err, state.ErrStateNotExist)
+	})
 }
 
 func initAccountBalance(sm protocol.StateManager, addr address.Address, initBalance *big.Int) error {
@@ -0,0 +1,146 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+const (
+	handleStakeTransferLock = "stakeTransferLock"
+	// ReadStateTransferLock is the ReadState method of the transfer lock of an account
+	ReadStateTransferLock = "TransferLock"
+	// HandleTransferLockRejected is the topic of the receipt log of a transfer rejected by the transfer lock of the
+	// owner, the other topics are the owner, the destination, and the bucket index if a bucket is transferred
+	HandleTransferLockRejected = "transferLockRejected"
+)
+
+type (
+	// TransferLockStatus is the transfer lock of an account in effect at an epoch, with the pending changes
+	TransferLockStatus struct {
+		Address        string                     `json:"address"`
+		Epoch          uint64                     `json:"epoch"`
+		Locked         bool                       `json:"locked"`
+		Allowlist      []string                   `json:"allowlist"`
+		PendingChanges []TransferLockChangeStatus `json:"pendingChanges"`
+	}
+
+	// TransferLockChangeStatus is a pending change of the transfer lock
+	TransferLockChangeStatus struct {
+		EffectiveEpoch uint64   `json:"effectiveEpoch"`
+		Op             string   `json:"op"`
+		Addresses      []string `json:"addresses,omitempty"`
+	}
+)
+
+func (p *Protocol) handleStakeTransferLock(ctx context.Context, act *action.StakeTransferLock, csm CandidateStateManager,
+) (*receiptLog, []*action.TransactionLog, error) {
+	actCtx := protocol.MustGetActionCtx(ctx)
+	featureCtx := protocol.MustGetFeatureCtx(ctx)
+	log := newReceiptLog(p.addr.String(), handleStakeTransferLock, featureCtx.NewStakingReceiptFormat)
+
+	_, fetchErr := fetchCaller(ctx, csm, big.NewInt(0))
+	if fetchErr != nil {
+		return log, nil, fetchErr
+	}
+
+	epoch := p.blockEpoch(ctx)
+	tlsm := NewTransferLockStateManager(csm.SM())
+	tl, err := tlsm.Effective(actCtx.Caller, epoch)
+	if err != nil {
+		return log, nil, errors.Wrapf(err, "failed to get transfer lock of %s", actCtx.Caller.String())
+	}
+	effectiveEpoch := tl.Change(act.Op(), act.Addresses(), epoch, p.config.TransferLockDelayEpochs)
+	if err := tlsm.Put(actCtx.Caller, tl); err != nil {
+		return log, nil, errors.Wrapf(err, "failed to put transfer lock of %s", actCtx.Caller.String())
+	}
+	log.AddTopics(actCtx.Caller.Bytes(), byteutil.Uint32ToBytesBigEndian(uint32(act.Op())), byteutil.Uint64ToBytesBigEndian(effectiveEpoch))
+	return log, nil, nil
+}
+
+// checkTransferLock checks the transfer from the owner to the destination against the transfer lock of the owner.
+// If the transfer is rejected, it returns the receipt log of the rejection with the error
+func (p *Protocol) checkTransferLock(ctx context.Context, sr protocol.StateReader, owner, dest address.Address, topics ...[]byte) (*receiptLog, error) {
+	if !protocol.IsParameterEnabled(ctx, p, _paramStakeTransferLock) {
+		return nil, nil
+	}
+	tl, err := NewTransferLockStateReader(sr).Effective(owner, p.blockEpoch(ctx))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get transfer lock of %s", owner.String())
+	}
+	if tl.Allows(dest) {
+		return nil, nil
+	}
+	log := newReceiptLog(p.addr.String(), HandleTransferLockRejected, protocol.MustGetFeatureCtx(ctx).NewStakingReceiptFormat)
+	log.AddTopics(append([][]byte{owner.Bytes(), dest.Bytes()}, topics...)...)
+	return log, &handleError{
+		err:           errors.Errorf("transfer of %s to %s is locked", owner.String(), dest.String()),
+		failureStatus: iotextypes.ReceiptStatus_ErrUnauthorizedOperator,
+	}
+}
+
+func (p *Protocol) blockEpoch(ctx context.Context) uint64 {
+	rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
+	return rp.GetEpochNum(protocol.MustGetBlockCtx(ctx).BlockHeight)
+}
+
+// readStateTransferLock reads the transfer lock of the account in the arg, in effect at the epoch of the next block,
+// and returns it in json
+func (p *Protocol) readStateTransferLock(ctx context.Context, sr protocol.StateReader, args ...[]byte) ([]byte, uint64, error) {
+	if len(args) != 1 {
+		return nil, uint64(0), errors.Errorf("invalid number of arguments %d", len(args))
+	}
+	owner, err := address.FromString(string(args[0]))
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	height, err := sr.Height()
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	rp := rolldpos.FindProtocol(protocol.MustGetRegistry(ctx))
+	if rp == nil {
+		return nil, uint64(0), errors.New("rolldpos protocol is not registered")
+	}
+	epoch := rp.GetEpochNum(height + 1)
+	tl, err := NewTransferLockStateReader(sr).Effective(owner, epoch)
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	status := TransferLockStatus{
+		Address:        owner.String(),
+		Epoch:          epoch,
+		Locked:         tl.Locked,
+		Allowlist:      addressesToStrings(tl.Allowlist),
+		PendingChanges: make([]TransferLockChangeStatus, 0, len(tl.PendingChanges)),
+	}
+	if status.Allowlist == nil {
+		status.Allowlist = []string{}
+	}
+	for _, change := range tl.PendingChanges {
+		status.PendingChanges = append(status.PendingChanges, TransferLockChangeStatus{
+			EffectiveEpoch: change.EffectiveEpoch,
+			Op:             change.Op.String(),
+			Addresses:      addressesToStrings(change.Addresses),
+		})
+	}
+	data, err := json.Marshal(status)
+	if err != nil {
+		return nil, uint64(0), err
+	}
+	return data, height, nil
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/mohae/deepcopy"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+type heightStateReader struct {
+	protocol.StateReader
+	height uint64
+}
+
+func (sr *heightStateReader) Height() (uint64, error) { return sr.height, nil }
+
+func TestTransferLock(t *testing.T) {
+	r := require.New(t)
+	var (
+		a1, a2 = identityset.Address(1), identityset.Address(2)
+		tl     = &TransferLock{}
+	)
+	// changes take effect immediately when not locked
+	r.Equal(uint64(1), tl.Change(action.StakeTransferLockOpAllow, []address.Address{a1}, 1, 10))
+	r.Equal(uint64(1), tl.Change(action.StakeTransferLockOpLock, nil, 1, 10))
+	r.True(tl.Allows(a1))
+	r.False(tl.Allows(a2))
+
+	// the changes loosening the lock are delayed while locked, and canceled by a lock
+	r.Equal(uint64(12), tl.Change(action.StakeTransferLockOpAllow, []address.Address{a2}, 2, 10))
+	r.Equal(uint64(13), tl.Change(action.StakeTransferLockOpDisallow, []address.Address{a1}, 3, 10))
+	r.Equal(uint64(14), tl.Change(action.StakeTransferLockOpUnlock, nil, 4, 10))
+	r.Len(tl.PendingChanges, 3)
+	tl.Settle(11)
+	r.False(tl.Allows(a2))
+	r.Equal(uint64(5), tl.Change(action.StakeTransferLockOpLock, nil, 5, 10))
+	r.Len(tl.PendingChanges, 1)
+	r.Equal(action.StakeTransferLockOpDisallow, tl.PendingChanges[0].Op)
+	tl.Settle(13)
+	r.False(tl.Allows(a1))
+	r.Empty(tl.PendingChanges)
+
+	// once unlocked, the rest of the pending changes take effect
+	tl.Change(action.StakeTransferLockOpUnlock, nil, 20, 10)
+	tl.Change(action.StakeTransferLockOpAllow, []address.Address{a2}, 25, 10)
+	tl.Settle(30)
+	r.False(tl.Locked)
+	r.Equal([]address.Address{a2}, tl.Allowlist)
+	r.Empty(tl.PendingChanges)
+	tl.Change(action.StakeTransferLockOpDisallow, []address.Address{a2}, 31, 10)
+	r.True(tl.IsEmpty())
+
+	// serialization
+	tl = &TransferLock{
+		Locked:    true,
+		Allowlist: []address.Address{a1},
+		PendingChanges: []*TransferLockChange{
+			{EffectiveEpoch: 3, Op: action.StakeTransferLockOpAllow, Addresses: []address.Address{a2}},
+			{EffectiveEpoch: 4, Op: action.StakeTransferLockOpUnlock},
+		},
+	}
+	b, err := tl.Serialize()
+	r.NoError(err)
+	tl2 := &TransferLock{}
+	r.NoError(tl2.Deserialize(b))
+	r.Equal(tl, tl2)
+}
+
+func TestProtocol_HandleStakeTransferLock(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm, p, candidate, _ := initAll(t, ctrl)
+	p.config.TransferLockDelayEpochs = 10
+	var (
+		owner = identityset.Address(20)
+		dest  = identityset.Address(21)
+		nonce = uint64(1)
+		g     = deepcopy.Copy(genesis.Default).(genesis.Genesis)
+		rp    = rolldpos.NewProtocol(1, 1, 1)
+		reg   = protocol.NewRegistry()
+	)
+	r.NoError(rp.Register(reg))
+	// the rejection is logged in the receipt of the new staking receipt format
+	g.FbkMigrationBlockHeight = 0
+	g.ToBeEnabledBlockHeight = 0
+	initCreateStake(t, sm, owner, 1000, big.NewInt(unit.Qev), 10000, nonce, 1, time.Now(), 10000, p, candidate, "100000000000000000000", false)
+
+	handle := func(height uint64, act action.Action) *action.Receipt {
+		nonce++
+		intrinsic, err := act.(interface{ IntrinsicGas() (uint64, error) }).IntrinsicGas()
+		r.NoError(err)
+		ctx := protocol.WithActionCtx(context.Background(), protocol.ActionCtx{
+			Caller:       owner,
+			GasPrice:     big.NewInt(unit.Qev),
+			IntrinsicGas: intrinsic,
+			Nonce:        nonce,
+		})
+		ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight:    height,
+			BlockTimeStamp: time.Now(),
+			GasLimit:       1000000,
+		})
+		ctx = protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{Tip: protocol.TipInfo{Height: height - 1}})
+		ctx = protocol.WithRegistry(genesis.WithGenesisContext(ctx, g), reg)
+		ctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
+		r.NoError(p.Validate(ctx, act, sm))
+		receipt, err := p.Handle(ctx, act, sm)
+		r.NoError(err)
+		return receipt
+	}
+	lock := func(height uint64, op action.StakeTransferLockOp, addrs ...address.Address) {
+		receipt := handle(height, action.NewStakeTransferLock(nonce+1, 100000, big.NewInt(unit.Qev), op, addrs))
+		r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+	}
+	transfer := func(height uint64, expected iotextypes.ReceiptStatus) {
+		act, err := action.NewTransferStake(nonce+1, dest.String(), 0, nil, 100000, big.NewInt(unit.Qev))
+		r.NoError(err)
+		receipt := handle(height, act)
+		r.EqualValues(expected, receipt.Status)
+		if expected == iotextypes.ReceiptStatus_ErrUnauthorizedOperator {
+			r.Len(receipt.Logs(), 1)
+			r.Equal(hash.BytesToHash256([]byte(HandleTransferLockRejected)), receipt.Logs()[0].Topics[0])
+			r.Equal(hash.BytesToHash256(owner.Bytes()), receipt.Logs()[0].Topics[1])
+			r.Equal(hash.BytesToHash256(dest.Bytes()), receipt.Logs()[0].Topics[2])
+		}
+	}
+	readState := func(height uint64) *TransferLockStatus {
+		ctx := protocol.WithRegistry(context.Background(), reg)
+		data, _, err := p.ReadState(ctx, &heightStateReader{sm, height}, []byte(ReadStateTransferLock), []byte(owner.String()))
+		r.NoError(err)
+		status := &TransferLockStatus{}
+		r.NoError(json.Unmarshal(data, status))
+		return status
+	}
+
+	lock(2, action.StakeTransferLockOpLock)
+	r.Equal(&TransferLockStatus{
+		Address:        owner.String(),
+		Epoch:          3,
+		Locked:         true,
+		Allowlist:      []string{},
+		PendingChanges: []TransferLockChangeStatus{},
+	}, readState(2))
+	transfer(3, iotextypes.ReceiptStatus_ErrUnauthorizedOperator)
+
+	// the destination is allowed after the delay
+	lock(4, action.StakeTransferLockOpAllow, dest)
+	r.Equal([]TransferLockChangeStatus{{EffectiveEpoch: 14, Op: "allow", Addresses: []string{dest.String()}}}, readState(4).PendingChanges)
+	transfer(13, iotextypes.ReceiptStatus_ErrUnauthorizedOperator)
+	status := readState(13)
+	r.Equal([]string{dest.String()}, status.Allowlist)
+	r.Empty(status.PendingChanges)
+	transfer(14, iotextypes.ReceiptStatus_Success)
+	bucket, err := newCandidateStateReader(sm).getBucket(0)
+	r.NoError(err)
+	r.Equal(dest, bucket.Owner)
+}
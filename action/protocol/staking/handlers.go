@@ -18,6 +18,7 @@ import (
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/state"
 )
@@ -118,6 +119,8 @@ func (p *Protocol) handleCreateStake(ctx context.Context, act *action.CreateStak
 	log.AddAddress(candidate.GetIdentifier())
 	log.AddAddress(actionCtx.Caller)
 	log.SetData(byteutil.Uint64ToBytesBigEndian(bucketIdx))
+	log.AddBucketEvent(action.StakingEventBucketCreated, bucket)
+	log.AddVotesEvent(candidate)
 
 	return log, []*action.TransactionLog{
 		{
@@ -207,6 +210,8 @@ func (p *Protocol) handleUnstake(ctx context.Context, act *action.Unstake, csm C
 	}
 
 	log.AddAddress(actionCtx.Caller)
+	log.AddBucketEvent(action.StakingEventBucketUnstaked, bucket)
+	log.AddVotesEvent(candidate)
 	return log, nil
 }
 
@@ -277,6 +282,7 @@ func (p *Protocol) handleWithdrawStake(ctx context.Context, act *action.Withdraw
 	if featureCtx.CannotUnstakeAgain {
 		log.SetData(bucket.StakedAmount.Bytes())
 	}
+	log.AddBucketEvent(action.StakingEventBucketWithdrawn, bucket)
 
 	return log, []*action.TransactionLog{
 		{
@@ -380,6 +386,9 @@ func (p *Protocol) handleChangeCandidate(ctx context.Context, act *action.Change
 
 	log.AddAddress(candidate.GetIdentifier())
 	log.AddAddress(actionCtx.Caller)
+	log.AddBucketEvent(action.StakingEventBucketUpdated, bucket)
+	log.AddVotesEvent(prevCandidate)
+	log.AddVotesEvent(candidate)
 	return log, nil
 }
 
@@ -418,6 +427,9 @@ func (p *Protocol) handleTransferStake(ctx context.Context, act *action.Transfer
 			failureStatus: iotextypes.ReceiptStatus_ErrInvalidBucketType,
 		}
 	}
+	if rejectLog, err := p.checkTransferLock(ctx, csm.SR(), bucket.Owner, newOwner, byteutil.Uint64ToBytesBigEndian(bucket.Index)); err != nil {
+		return rejectLog, err
+	}
 
 	// update bucket index
 	if err := csm.delVoterBucketIndex(bucket.Owner, act.BucketIndex()); err != nil {
@@ -434,6 +446,7 @@ func (p *Protocol) handleTransferStake(ctx context.Context, act *action.Transfer
 	}
 
 	log.AddAddress(actionCtx.Caller)
+	log.AddBucketEvent(action.StakingEventBucketUpdated, bucket)
 	return log, nil
 }
 
@@ -563,6 +576,8 @@ func (p *Protocol) handleDepositToStake(ctx context.Context, act *action.Deposit
 		return log, nil, errors.Wrapf(err, "failed to store account %s", actionCtx.Caller.String())
 	}
 	log.AddAddress(actionCtx.Caller)
+	log.AddBucketEvent(action.StakingEventBucketUpdated, bucket)
+	log.AddVotesEvent(candidate)
 
 	return log, []*action.TransactionLog{
 		{
@@ -655,6 +670,8 @@ func (p *Protocol) handleRestake(ctx context.Context, act *action.Restake, csm C
 	}
 
 	log.AddAddress(actionCtx.Caller)
+	log.AddBucketEvent(action.StakingEventBucketUpdated, bucket)
+	log.AddVotesEvent(candidate)
 	return log, nil
 }
 
@@ -724,6 +741,7 @@ func (p *Protocol) handleCandidateRegister(ctx context.Context, act *action.Cand
 	}
 
 	var (
+		bucket        *VoteBucket
 		bucketIdx     uint64
 		votes         *big.Int
 		withSelfStake = act.Amount().Sign() > 0
@@ -732,7 +750,7 @@ func (p *Protocol) handleCandidateRegister(ctx context.Context, act *action.Cand
 	)
 	if withSelfStake {
 		// register with self-stake
-		bucket := NewVoteBucket(candID, owner, act.Amount(), act.Duration(), blkCtx.BlockTimeStamp, act.AutoStake())
+		bucket = NewVoteBucket(candID, owner, act.Amount(), act.Duration(), blkCtx.BlockTimeStamp, act.AutoStake())
 		bucketIdx, err = csm.putBucketAndIndex(bucket)
 		if err != nil {
 			return log, nil, err
@@ -801,6 +819,11 @@ func (p *Protocol) handleCandidateRegister(ctx context.Context, act *action.Cand
 	log.AddAddress(candID)
 	log.AddAddress(actCtx.Caller)
 	log.SetData(byteutil.Uint64ToBytesBigEndian(bucketIdx))
+	log.AddCandidateEvent(action.StakingEventCandidateRegistered, c)
+	if withSelfStake {
+		log.AddBucketEvent(action.StakingEventBucketCreated, bucket)
+	}
+	log.AddVotesEvent(c)
 
 	txLogs = append(txLogs, &action.TransactionLog{
 		Type:      iotextypes.TransactionLogType_CANDIDATE_REGISTRATION_FEE,
@@ -812,20 +835,20 @@ func (p *Protocol) handleCandidateRegister(ctx context.Context, act *action.Cand
 }
 
 func (p *Protocol) handleCandidateUpdate(ctx context.Context, act *action.CandidateUpdate, csm CandidateStateManager,
-) (*receiptLog, error) {
+) (*receiptLog, []*receiptLog, error) {
 	actCtx := protocol.MustGetActionCtx(ctx)
 	featureCtx := protocol.MustGetFeatureCtx(ctx)
 	log := newReceiptLog(p.addr.String(), HandleCandidateUpdate, featureCtx.NewStakingReceiptFormat)
 
 	_, fetchErr := fetchCaller(ctx, csm, big.NewInt(0))
 	if fetchErr != nil {
-		return log, fetchErr
+		return log, nil, fetchErr
 	}
 
 	// only owner can update candidate
 	c := csm.GetByOwner(actCtx.Caller)
 	if c == nil {
-		return log, errCandNotExist
+		return log, nil, errCandNotExist
 	}
 
 	if len(act.Name()) != 0 {
@@ -836,13 +859,24 @@ func (p *Protocol) handleCandidateUpdate(ctx context.Context, act *action.Candid
 		c.Operator = act.OperatorAddress()
 	}
 
+	var rewardLogs []*receiptLog
 	if act.RewardAddress() != nil {
-		c.Reward = act.RewardAddress()
+		if featureCtx.EnableRewardAddressDelay {
+			rewardLogs = p.changeRewardAddress(ctx, c, act.RewardAddress())
+		} else {
+			c.Reward = act.RewardAddress()
+		}
+	}
+
+	if split := act.PayoutSplit(); len(split) > 0 {
+		// the split takes effect since the next epoch, the rewards of the current epoch are paid out by the current one
+		rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
+		c.SetPayoutSplit(split, rp.GetEpochNum(protocol.MustGetBlockCtx(ctx).BlockHeight)+1)
 	}
 	log.AddTopics(c.GetIdentifier().Bytes())
 
 	if err := csm.Upsert(c); err != nil {
-		return log, csmErrorToHandleError(c.GetIdentifier().String(), err)
+		return log, nil, csmErrorToHandleError(c.GetIdentifier().String(), err)
 	}
 	height, _ := csm.SM().Height()
 	if p.needToWriteCandsMap(ctx, height) {
@@ -850,7 +884,8 @@ func (p *Protocol) handleCandidateUpdate(ctx context.Context, act *action.Candid
 	}
 
 	log.AddAddress(actCtx.Caller)
-	return log, nil
+	log.AddCandidateEvent(action.StakingEventCandidateUpdated, c)
+	return log, rewardLogs, nil
 }
 
 func (p *Protocol) fetchBucket(csm BucketGetByIndex, index uint64) (*VoteBucket, ReceiptError) {
@@ -262,6 +262,64 @@ func TestProtocol_HandleCreateStake(t *testing.T) {
 	}
 }
 
+func TestProtocol_HandleFailure(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm, p, candidate, _ := initAll(t, ctrl)
+	// the gas is deposited as the rewarding protocol does, failing on a balance not enough
+	p.helperCtx.DepositGas = func(ctx context.Context, sm protocol.StateManager, gasFee *big.Int, opts ...protocol.Option) ([]*action.TransactionLog, error) {
+		actionCtx := protocol.MustGetActionCtx(ctx)
+		acc, err := accountutil.LoadAccount(sm, actionCtx.Caller)
+		if err != nil {
+			return nil, err
+		}
+		if err := acc.SubBalance(gasFee); err != nil {
+			return nil, err
+		}
+		return nil, accountutil.StoreAccount(sm, actionCtx.Caller, acc)
+	}
+	g := genesis.Default
+	g.ToBeEnabledBlockHeight = 1
+	stakerAddr := identityset.Address(1)
+	require.NoError(setupAccount(sm, stakerAddr, 10))
+
+	for _, test := range []struct {
+		gasPrice  *big.Int
+		admission bool
+	}{
+		// the staker affords the gas but not the amount, the action is included as failed
+		{big.NewInt(unit.Qev), false},
+		// the staker can't afford the gas, the action is skipped
+		{unit.ConvertIotxToRau(1), true},
+	} {
+		act, err := action.NewCreateStake(1, candidate.Name, "100000000000000000000", 1, false, nil, 10000, test.gasPrice)
+		require.NoError(err)
+		ctx := protocol.WithActionCtx(context.Background(), protocol.ActionCtx{
+			Caller:       stakerAddr,
+			GasPrice:     test.gasPrice,
+			IntrinsicGas: 10000,
+			Nonce:        1,
+		})
+		ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight:    1,
+			BlockTimeStamp: time.Now(),
+			GasLimit:       10000,
+		})
+		ctx = protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{})
+		ctx = genesis.WithGenesisContext(ctx, g)
+		ctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
+		r, err := p.Handle(ctx, act, sm)
+		if test.admission {
+			require.ErrorIs(err, state.ErrNotEnoughBalance)
+			require.True(protocol.IsAdmissionError(err))
+			continue
+		}
+		require.NoError(err)
+		require.EqualValues(iotextypes.ReceiptStatus_ErrNotEnoughBalance, r.Status)
+		require.NoError(setupAccount(sm, stakerAddr, 10))
+	}
+}
+
 func TestProtocol_HandleCandidateRegister(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -1418,11 +1476,13 @@ func TestProtocol_HandleWithdrawStake(t *testing.T) {
 			require.Equal(caller.String(), wLog.Recipient)
 			require.Equal(test.amount, wLog.Amount.String())
 
-			// test bucket index and bucket
+			// test bucket index and bucket, the withdrawn bucket leaves nothing in the state
 			_, _, err := csr.candBucketIndices(candidate.Owner)
 			require.Error(err)
 			_, _, err = csr.voterBucketIndices(candidate.Owner)
 			require.Error(err)
+			_, err = csr.getBucket(test.withdrawIndex)
+			require.ErrorIs(err, state.ErrStateNotExist)
 
 			// test staker's account
 			caller, err := accountutil.LoadAccount(sm, caller)
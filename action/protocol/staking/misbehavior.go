@@ -0,0 +1,217 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"bytes"
+	"math/big"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	blake2b "github.com/minio/blake2b-simd"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/staking/stakingpb"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+// the topic of a block proposal in the evidence, next to the topics of the consensus votes
+const _proposalEvidenceTopic = int32(-1)
+
+type (
+	// Misbehavior is the record of a delegate signing conflicting consensus messages at a height, with the penalty
+	Misbehavior struct {
+		Candidate          address.Address
+		Operator           address.Address
+		Height             uint64
+		Reporter           address.Address
+		ReportHeight       uint64
+		Slashed            *big.Int
+		Bounty             *big.Int
+		ProbationEndHeight uint64
+	}
+
+	// MisbehaviorProbation is the probation of a misbehaving candidate, whose votes are reduced before the end height
+	MisbehaviorProbation struct {
+		EndHeight uint64
+	}
+
+	// consensusEvidence is a consensus message verified to be signed by the endorser
+	consensusEvidence struct {
+		endorser  crypto.PublicKey
+		height    uint64
+		topic     int32
+		timestamp time.Time
+		blkHash   []byte
+	}
+
+	// consensusDocument is the hash of the document of a consensus message
+	consensusDocument []byte
+)
+
+// Hash returns the hash of the document
+func (doc consensusDocument) Hash() ([]byte, error) {
+	return doc, nil
+}
+
+// Serialize serializes the misbehavior into bytes
+func (m *Misbehavior) Serialize() ([]byte, error) {
+	return proto.Marshal(&stakingpb.Misbehavior{
+		Candidate:          m.Candidate.String(),
+		Operator:           m.Operator.String(),
+		Height:             m.Height,
+		Reporter:           m.Reporter.String(),
+		ReportHeight:       m.ReportHeight,
+		Slashed:            m.Slashed.String(),
+		Bounty:             m.Bounty.String(),
+		ProbationEndHeight: m.ProbationEndHeight,
+	})
+}
+
+// Deserialize deserializes bytes into the misbehavior
+func (m *Misbehavior) Deserialize(buf []byte) error {
+	pb := &stakingpb.Misbehavior{}
+	if err := proto.Unmarshal(buf, pb); err != nil {
+		return errors.Wrap(err, "failed to unmarshal misbehavior")
+	}
+	var err error
+	for _, addr := range []struct {
+		dst *address.Address
+		src string
+	}{
+		{&m.Candidate, pb.Candidate},
+		{&m.Operator, pb.Operator},
+		{&m.Reporter, pb.Reporter},
+	} {
+		if *addr.dst, err = address.FromString(addr.src); err != nil {
+			return err
+		}
+	}
+	var ok bool
+	if m.Slashed, ok = new(big.Int).SetString(pb.Slashed, 10); !ok {
+		return state.ErrStateDeserialization
+	}
+	if m.Bounty, ok = new(big.Int).SetString(pb.Bounty, 10); !ok {
+		return state.ErrStateDeserialization
+	}
+	m.Height = pb.Height
+	m.ReportHeight = pb.ReportHeight
+	m.ProbationEndHeight = pb.ProbationEndHeight
+	return nil
+}
+
+// Serialize serializes the probation into bytes
+func (mp *MisbehaviorProbation) Serialize() ([]byte, error) {
+	return proto.Marshal(&stakingpb.MisbehaviorProbation{EndHeight: mp.EndHeight})
+}
+
+// Deserialize deserializes bytes into the probation
+func (mp *MisbehaviorProbation) Deserialize(buf []byte) error {
+	pb := &stakingpb.MisbehaviorProbation{}
+	if err := proto.Unmarshal(buf, pb); err != nil {
+		return errors.Wrap(err, "failed to unmarshal misbehavior probation")
+	}
+	mp.EndHeight = pb.EndHeight
+	return nil
+}
+
+// verifyMisbehavior verifies the evidence of a misbehavior report, and returns the first consensus message of it.
+// The two messages have to be signed by the same delegate at the same height and round, which have the same
+// endorsement time, over different blocks. The empty votes are not counted as conflicts, as a delegate may vote
+// for a block after timing out on it
+func verifyMisbehavior(act *action.ReportMisbehavior) (*consensusEvidence, error) {
+	first, second, err := act.ConsensusMessages()
+	if err != nil {
+		return nil, err
+	}
+	ev1, err := parseConsensusEvidence(first)
+	if err != nil {
+		return nil, err
+	}
+	ev2, err := parseConsensusEvidence(second)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case !bytes.Equal(ev1.endorser.Bytes(), ev2.endorser.Bytes()):
+		return nil, errors.Wrap(action.ErrInvalidMisbehaviorReport, "messages of different endorsers")
+	case ev1.height != ev2.height || ev1.topic != ev2.topic || !ev1.timestamp.Equal(ev2.timestamp):
+		return nil, errors.Wrap(action.ErrInvalidMisbehaviorReport, "messages of different rounds")
+	case len(ev1.blkHash) == 0 || len(ev2.blkHash) == 0:
+		return nil, errors.Wrap(action.ErrInvalidMisbehaviorReport, "empty vote")
+	case bytes.Equal(ev1.blkHash, ev2.blkHash):
+		return nil, errors.Wrap(action.ErrInvalidMisbehaviorReport, "messages of the same block")
+	}
+	return ev1, nil
+}
+
+// parseConsensusEvidence verifies the signature of the consensus message, with the document hashed in the same way
+// as consensus/scheme/rolldpos
+func parseConsensusEvidence(msg *iotextypes.ConsensusMessage) (*consensusEvidence, error) {
+	if msg.GetEndorsement() == nil {
+		return nil, errors.Wrap(action.ErrInvalidMisbehaviorReport, "missing endorsement")
+	}
+	en := &endorsement.Endorsement{}
+	if err := en.LoadProto(msg.GetEndorsement()); err != nil {
+		return nil, errors.Wrap(action.ErrInvalidMisbehaviorReport, err.Error())
+	}
+	ev := &consensusEvidence{
+		endorser:  en.Endorser(),
+		height:    msg.GetHeight(),
+		timestamp: en.Timestamp(),
+	}
+	var doc consensusDocument
+	switch {
+	case msg.GetVote() != nil:
+		vote := msg.GetVote()
+		switch vote.GetTopic() {
+		case iotextypes.ConsensusVote_PROPOSAL, iotextypes.ConsensusVote_LOCK, iotextypes.ConsensusVote_COMMIT:
+		default:
+			return nil, errors.Wrapf(action.ErrInvalidMisbehaviorReport, "invalid topic %d", vote.GetTopic())
+		}
+		ser, err := proto.Marshal(vote)
+		if err != nil {
+			return nil, err
+		}
+		h := blake2b.Sum256(ser)
+		doc = h[:]
+		ev.topic = int32(vote.GetTopic())
+		ev.blkHash = vote.GetBlockHash()
+	case msg.GetBlockProposal() != nil:
+		header := &block.Header{}
+		if err := header.LoadFromBlockHeaderProto(msg.GetBlockProposal().GetBlock().GetHeader()); err != nil {
+			return nil, errors.Wrap(action.ErrInvalidMisbehaviorReport, err.Error())
+		}
+		if header.Height() != ev.height {
+			return nil, errors.Wrapf(action.ErrInvalidMisbehaviorReport, "proposal of block %d at height %d", header.Height(), ev.height)
+		}
+		if !bytes.Equal(header.PublicKey().Bytes(), ev.endorser.Bytes()) {
+			return nil, errors.Wrap(action.ErrInvalidMisbehaviorReport, "proposal not endorsed by the producer")
+		}
+		ser, err := proto.Marshal(msg.GetBlockProposal())
+		if err != nil {
+			return nil, err
+		}
+		h := hash.Hash256b(ser)
+		doc = h[:]
+		ev.topic = _proposalEvidenceTopic
+		blkHash := header.HashBlock()
+		ev.blkHash = blkHash[:]
+	default:
+		return nil, errors.Wrap(action.ErrInvalidMisbehaviorReport, "unknown consensus message")
+	}
+	if !endorsement.VerifyEndorsement(doc, en) {
+		return nil, errors.Wrap(action.ErrInvalidMisbehaviorReport, "invalid signature")
+	}
+	return ev, nil
+}
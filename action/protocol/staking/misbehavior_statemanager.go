@@ -0,0 +1,83 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+type (
+	// MisbehaviorStateManager defines the interface of misbehavior state manager
+	MisbehaviorStateManager struct {
+		protocol.StateManager
+		*MisbehaviorStateReader
+	}
+	// MisbehaviorStateReader defines the interface of misbehavior state reader
+	MisbehaviorStateReader struct {
+		protocol.StateReader
+	}
+)
+
+// NewMisbehaviorStateManager creates a new misbehavior state manager
+func NewMisbehaviorStateManager(sm protocol.StateManager) *MisbehaviorStateManager {
+	return &MisbehaviorStateManager{
+		StateManager:           sm,
+		MisbehaviorStateReader: NewMisbehaviorStateReader(sm),
+	}
+}
+
+// Put puts the misbehavior of the operator at the height
+func (msm *MisbehaviorStateManager) Put(m *Misbehavior) error {
+	_, err := msm.PutState(m, protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(misbehaviorKey(m.Operator, m.Height)))
+	return err
+}
+
+// PutProbation puts the probation of a candidate
+func (msm *MisbehaviorStateManager) PutProbation(candidate address.Address, mp *MisbehaviorProbation) error {
+	_, err := msm.PutState(mp, protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(misbehaviorProbationKey(candidate)))
+	return err
+}
+
+// NewMisbehaviorStateReader creates a new misbehavior state reader
+func NewMisbehaviorStateReader(sr protocol.StateReader) *MisbehaviorStateReader {
+	return &MisbehaviorStateReader{StateReader: sr}
+}
+
+// Get gets the misbehavior of the operator at the height
+func (msr *MisbehaviorStateReader) Get(operator address.Address, height uint64) (*Misbehavior, error) {
+	value := Misbehavior{}
+	if _, err := msr.State(&value, protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(misbehaviorKey(operator, height))); err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// Probation returns the probation of a candidate, which is empty if the candidate has never been on probation
+func (msr *MisbehaviorStateReader) Probation(candidate address.Address) (*MisbehaviorProbation, error) {
+	value := MisbehaviorProbation{}
+	_, err := msr.State(&value, protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(misbehaviorProbationKey(candidate)))
+	switch errors.Cause(err) {
+	case nil, state.ErrStateNotExist:
+		return &value, nil
+	default:
+		return nil, err
+	}
+}
+
+func misbehaviorKey(operator address.Address, height uint64) []byte {
+	key := append([]byte{_misbehavior}, operator.Bytes()...)
+	return append(key, byteutil.Uint64ToBytesBigEndian(height)...)
+}
+
+func misbehaviorProbationKey(candidate address.Address) []byte {
+	key := []byte{_misbehaviorProbation}
+	return append(key, candidate.Bytes()...)
+}
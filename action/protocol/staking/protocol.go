@@ -9,6 +9,7 @@ import (
 	"context"
 	"encoding/hex"
 	"math/big"
+	"strconv"
 	"time"
 
 	"github.com/pkg/errors"
@@ -41,6 +42,13 @@ const (
 
 	// CandsMapNS is the bucket name to store candidate map
 	CandsMapNS = "CandsMap"
+
+	// the height-gated parameters of the protocol
+	_paramBucketPool        = "bucketPool"
+	_paramPayoutSplit       = "payoutSplit"
+	_paramStakeTransferLock = "stakeTransferLock"
+	_paramMisbehaviorReport = "misbehaviorReport"
+	_paramCandidateProfile  = "candidateProfile"
 )
 
 const (
@@ -52,6 +60,10 @@ const (
 	_voterIndex
 	_candIndex
 	_endorsement
+	_transferLock
+	_misbehavior
+	_misbehaviorProbation
+	_candidateProfile
 )
 
 // Errors
@@ -93,9 +105,16 @@ type (
 		WithdrawWaitingPeriod            time.Duration
 		MinStakeAmount                   *big.Int
 		BootstrapCandidates              []genesis.BootstrapCandidate
+		BootstrapBuckets                 []genesis.BootstrapBucket
 		PersistStakingPatchBlock         uint64
 		EndorsementWithdrawWaitingBlocks uint64
 		MigrateContractAddress           string
+		TransferLockDelayEpochs          uint64
+		RewardAddressDelayEpochs         uint64
+		MisbehaviorSlashRate             uint32
+		MisbehaviorBountyRate            uint32
+		MisbehaviorProbationEpochs       uint64
+		MisbehaviorProbationIntensity    uint32
 	}
 	// HelperCtx is the helper context for staking protocol
 	HelperCtx struct {
@@ -166,9 +185,16 @@ func NewProtocol(
 			WithdrawWaitingPeriod:            cfg.Staking.WithdrawWaitingPeriod,
 			MinStakeAmount:                   minStakeAmount,
 			BootstrapCandidates:              cfg.Staking.BootstrapCandidates,
+			BootstrapBuckets:                 cfg.Staking.BootstrapBuckets,
 			PersistStakingPatchBlock:         cfg.PersistStakingPatchBlock,
 			EndorsementWithdrawWaitingBlocks: cfg.Staking.EndorsementWithdrawWaitingBlocks,
 			MigrateContractAddress:           migrateContractAddress,
+			TransferLockDelayEpochs:          cfg.Staking.TransferLockDelayEpochs,
+			RewardAddressDelayEpochs:         cfg.Staking.RewardAddressDelayEpochs,
+			MisbehaviorSlashRate:             cfg.Staking.MisbehaviorSlashRate,
+			MisbehaviorBountyRate:            cfg.Staking.MisbehaviorBountyRate,
+			MisbehaviorProbationEpochs:       cfg.Staking.MisbehaviorProbationEpochs,
+			MisbehaviorProbationIntensity:    cfg.Staking.MisbehaviorProbationIntensity,
 		},
 		candBucketsIndexer:       candBucketsIndexer,
 		voteReviser:              voteReviser,
@@ -186,6 +212,16 @@ func ProtocolAddr() address.Address {
 
 // Start starts the protocol
 func (p *Protocol) Start(ctx context.Context, sr protocol.StateReader) (interface{}, error) {
+	view, err := p.createView(ctx, sr, false)
+	if err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+// createView creates the view from state reader, the total of the bucket pool is summed up from all buckets if
+// sumBuckets is true, instead of read from the stored total
+func (p *Protocol) createView(ctx context.Context, sr protocol.StateReader, sumBuckets bool) (*ViewData, error) {
 	featureCtx := protocol.MustGetFeatureWithHeightCtx(ctx)
 	height, err := sr.Height()
 	if err != nil {
@@ -193,10 +229,12 @@ func (p *Protocol) Start(ctx context.Context, sr protocol.StateReader) (interfac
 	}
 
 	// load view from SR
-	c, _, err := CreateBaseView(sr, featureCtx.ReadStateFromDB(height))
+	enableSMStorage := featureCtx.ReadStateFromDB(height)
+	c, _, err := CreateBaseView(sr, enableSMStorage && !sumBuckets)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to start staking protocol")
 	}
+	c.bucketPool.enableSMStorage = enableSMStorage
 
 	if p.needToReadCandsMap(ctx, height) {
 		name, operator, owners, err := readCandCenterStateFromStateDB(sr)
@@ -247,41 +285,91 @@ func (p *Protocol) CreateGenesisStates(
 		if !ok {
 			return action.ErrInvalidAmount
 		}
-		bucket := NewVoteBucket(owner, owner, selfStake, 7, time.Now(), true)
-		bucketIdx, err := csm.putBucketAndIndex(bucket)
-		if err != nil {
-			return err
-		}
 		c := &Candidate{
 			Owner:              owner,
 			Operator:           operator,
 			Reward:             reward,
 			Name:               bc.Name,
-			Votes:              p.calculateVoteWeight(bucket, true),
-			SelfStakeBucketIdx: bucketIdx,
-			SelfStake:          selfStake,
+			Votes:              big.NewInt(0),
+			SelfStakeBucketIdx: candidateNoSelfStakeBucketIndex,
+			SelfStake:          big.NewInt(0),
+		}
+		if bc.Identifier != "" {
+			if c.Identifier, err = address.FromString(bc.Identifier); err != nil {
+				return err
+			}
+		}
+		if selfStake.Sign() > 0 {
+			bucket := NewVoteBucket(c.GetIdentifier(), owner, selfStake, 7, bootstrapTime(ctx), true)
+			if c.SelfStakeBucketIdx, err = csm.putBucketAndIndex(bucket); err != nil {
+				return err
+			}
+			c.Votes = p.calculateVoteWeight(bucket, true)
+			c.SelfStake = selfStake
 		}
 
 		// put in statedb and cand center
 		if err := csm.Upsert(c); err != nil {
 			return err
 		}
-		if err := csm.DebitBucketPool(selfStake, true); err != nil {
-			return err
+		if selfStake.Sign() > 0 {
+			if err := csm.DebitBucketPool(selfStake, true); err != nil {
+				return err
+			}
 		}
 	}
+	if err := p.createBootstrapBuckets(ctx, csm); err != nil {
+		return err
+	}
+	if err := p.validateBootstrapVotes(csm); err != nil {
+		return err
+	}
 
 	// commit updated view
 	return errors.Wrap(csm.Commit(ctx), "failed to commit candidate change in CreateGenesisStates")
 }
 
+// DeclareParameters declares the height-gated parameters of the protocol
+func (p *Protocol) DeclareParameters(g genesis.Genesis) []protocol.Parameter {
+	return _parameterDeclarer.DeclareParameters(g)
+}
+
+// parameterDeclarer declares the height-gated parameters of the protocol, for the reads out of the protocol
+type parameterDeclarer struct{}
+
+var _parameterDeclarer protocol.ParameterDeclarer = parameterDeclarer{}
+
+func (parameterDeclarer) Name() string {
+	return _protocolID
+}
+
+func (parameterDeclarer) DeclareParameters(g genesis.Genesis) []protocol.Parameter {
+	var params []protocol.Parameter
+	for _, v := range []struct {
+		name   string
+		height uint64
+	}{
+		// the total of the bucket pool is stored since greenland
+		{_paramBucketPool, g.GreenlandBlockHeight},
+		{_paramPayoutSplit, g.ToBeEnabledBlockHeight},
+		{_paramStakeTransferLock, g.ToBeEnabledBlockHeight},
+		{_paramMisbehaviorReport, g.ToBeEnabledBlockHeight},
+		{_paramCandidateProfile, g.ToBeEnabledBlockHeight},
+	} {
+		params = append(params, protocol.Parameter{Name: v.name, Value: strconv.FormatBool(v.height == 0)})
+		if v.height > 0 {
+			params = append(params, protocol.Parameter{Name: v.name, Value: protocol.ParameterEnabled, Height: v.height})
+		}
+	}
+	return params
+}
+
 // CreatePreStates updates state manager
 func (p *Protocol) CreatePreStates(ctx context.Context, sm protocol.StateManager) error {
-	g := genesis.MustExtractGenesisContext(ctx)
 	blkCtx := protocol.MustGetBlockCtx(ctx)
 	featureCtx := protocol.MustGetFeatureCtx(ctx)
 	featureWithHeightCtx := protocol.MustGetFeatureWithHeightCtx(ctx)
-	if blkCtx.BlockHeight == g.GreenlandBlockHeight {
+	if protocol.IsParameterActivated(ctx, p, _paramBucketPool) {
 		csr, err := ConstructBaseView(sm)
 		if err != nil {
 			return err
@@ -300,20 +388,26 @@ func (p *Protocol) CreatePreStates(ctx context.Context, sm protocol.StateManager
 			return err
 		}
 	}
-	if p.candBucketsIndexer == nil {
-		return nil
+	return nil
+}
+
+// PreEpochStart logs the buckets matured in the previous epoch, and writes the candidates and buckets of the previous
+// epoch into the indexer
+func (p *Protocol) PreEpochStart(ctx context.Context, sm protocol.StateManager) error {
+	if protocol.MustGetFeatureCtx(ctx).EnableBucketMaturityLogs {
+		if err := p.logMaturedBuckets(ctx, sm); err != nil {
+			return err
+		}
 	}
-	rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
-	currentEpochNum := rp.GetEpochNum(blkCtx.BlockHeight)
-	if currentEpochNum == 0 {
+	if p.candBucketsIndexer == nil || protocol.MustGetFeatureCtx(ctx).SkipStakingIndexer {
 		return nil
 	}
-	epochStartHeight := rp.GetEpochHeight(currentEpochNum)
-	if epochStartHeight != blkCtx.BlockHeight || featureCtx.SkipStakingIndexer {
+	epochCtx := protocol.MustGetEpochCtx(ctx)
+	if epochCtx.EpochNum == 0 {
 		return nil
 	}
-
-	return p.handleStakingIndexer(ctx, rp.GetEpochHeight(currentEpochNum-1), sm)
+	rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
+	return p.handleStakingIndexer(ctx, rp.GetEpochHeight(epochCtx.EpochNum-1), sm)
 }
 
 func (p *Protocol) handleStakingIndexer(ctx context.Context, epochStartHeight uint64, sm protocol.StateManager) error {
@@ -337,7 +431,7 @@ func (p *Protocol) handleStakingIndexer(ctx context.Context, epochStartHeight ui
 	if err != nil && errors.Cause(err) != state.ErrStateNotExist {
 		return err
 	}
-	candidateList, err := toIoTeXTypesCandidateListV2(csr, all, protocol.MustGetFeatureCtx(ctx))
+	candidateList, err := toIoTeXTypesCandidateListV2(ctx, csr, all)
 	if err != nil {
 		return err
 	}
@@ -410,6 +504,7 @@ func (p *Protocol) Handle(ctx context.Context, act action.Action, sm protocol.St
 func (p *Protocol) handle(ctx context.Context, act action.Action, csm CandidateStateManager) (*action.Receipt, error) {
 	var (
 		rLog              *receiptLog
+		extraLogs         []*receiptLog
 		tLogs             []*action.TransactionLog
 		err               error
 		logs              []*action.Log
@@ -417,6 +512,7 @@ func (p *Protocol) handle(ctx context.Context, act action.Action, csm CandidateS
 		actionCtx         = protocol.MustGetActionCtx(ctx)
 		gasConsumed       = actionCtx.IntrinsicGas
 		gasToBeDeducted   = gasConsumed
+		systemGas         uint64
 		dynamicGasAct, ok = act.(action.TxDynamicGas)
 	)
 	if !ok {
@@ -440,7 +536,7 @@ func (p *Protocol) handle(ctx context.Context, act action.Action, csm CandidateS
 	case *action.CandidateRegister:
 		rLog, tLogs, err = p.handleCandidateRegister(ctx, act, csm)
 	case *action.CandidateUpdate:
-		rLog, err = p.handleCandidateUpdate(ctx, act, csm)
+		rLog, extraLogs, err = p.handleCandidateUpdate(ctx, act, csm)
 	case *action.CandidateActivate:
 		rLog, tLogs, err = p.handleCandidateActivate(ctx, act, csm)
 	case *action.CandidateEndorsement:
@@ -448,10 +544,16 @@ func (p *Protocol) handle(ctx context.Context, act action.Action, csm CandidateS
 	case *action.CandidateTransferOwnership:
 		rLog, tLogs, err = p.handleCandidateTransferOwnership(ctx, act, csm)
 	case *action.MigrateStake:
-		logs, tLogs, gasConsumed, gasToBeDeducted, err = p.handleStakeMigrate(ctx, act, csm)
+		logs, tLogs, gasConsumed, gasToBeDeducted, systemGas, err = p.handleStakeMigrate(ctx, act, csm)
 		if err == nil {
 			nonceUpdateOption = noUpdateNonce
 		}
+	case *action.StakeTransferLock:
+		rLog, tLogs, err = p.handleStakeTransferLock(ctx, act, csm)
+	case *action.ReportMisbehavior:
+		rLog, tLogs, err = p.handleReportMisbehavior(ctx, act, csm)
+	case *action.UpdateCandidateProfile:
+		rLog, tLogs, err = p.handleUpdateCandidateProfile(ctx, act, csm)
 	default:
 		return nil, nil
 	}
@@ -461,16 +563,32 @@ func (p *Protocol) handle(ctx context.Context, act action.Action, csm CandidateS
 		}
 	}
 	if err == nil {
-		return p.settleAction(ctx, csm.SM(), dynamicGasAct, uint64(iotextypes.ReceiptStatus_Success), logs, tLogs, gasConsumed, gasToBeDeducted, nonceUpdateOption)
+		for _, extraLog := range extraLogs {
+			logs = append(logs, extraLog.Build(ctx, nil))
+		}
+		if rLog != nil {
+			logs = append(logs, rLog.BuildEvents(ctx)...)
+		}
 	}
-
-	if receiptErr, ok := err.(ReceiptError); ok {
-		actionCtx := protocol.MustGetActionCtx(ctx)
+	status := uint64(iotextypes.ReceiptStatus_Success)
+	if err != nil {
+		receiptErr, ok := err.(ReceiptError)
+		if !ok {
+			return nil, err
+		}
 		log.L().With(
 			zap.String("actionHash", hex.EncodeToString(actionCtx.ActionHash[:]))).Debug("Failed to commit staking action", zap.Error(err))
-		return p.settleAction(ctx, csm.SM(), dynamicGasAct, receiptErr.ReceiptStatus(), logs, tLogs, gasConsumed, gasToBeDeducted, nonceUpdateOption)
+		status = receiptErr.ReceiptStatus()
+	}
+	receipt, err := p.settleAction(ctx, csm.SM(), dynamicGasAct, status, logs, tLogs, gasConsumed, gasToBeDeducted, nonceUpdateOption)
+	if err != nil {
+		return nil, err
 	}
-	return nil, err
+	if systemGas > 0 {
+		// the receipt of the action initiating a system call is tagged with the gas of the call
+		receipt.SetSystemGas(systemGas)
+	}
+	return receipt, nil
 }
 
 // Validate validates a staking message
@@ -505,6 +623,12 @@ func (p *Protocol) Validate(ctx context.Context, act action.Action, sr protocol.
 		return p.validateCandidateTransferOwnershipAction(ctx, act)
 	case *action.MigrateStake:
 		return p.validateMigrateStake(ctx, act)
+	case *action.StakeTransferLock:
+		return p.validateStakeTransferLock(ctx, act)
+	case *action.ReportMisbehavior:
+		return p.validateReportMisbehavior(ctx, act)
+	case *action.UpdateCandidateProfile:
+		return p.validateUpdateCandidateProfile(ctx, act)
 	}
 	return nil
 }
@@ -558,15 +682,44 @@ func (p *Protocol) ActiveCandidates(ctx context.Context, sr protocol.StateReader
 		if err != nil {
 			return nil, err
 		}
-		if active {
-			cand = append(cand, list[i])
+		if !active {
+			continue
+		}
+		if protocol.IsParameterEnabled(ctx, p, _paramMisbehaviorReport) {
+			if err := p.misbehaviorProbation(sr, list[i], height); err != nil {
+				return nil, err
+			}
 		}
+		cand = append(cand, list[i])
 	}
 	return cand.toStateCandidateList()
 }
 
+// PayoutSplit returns the payout split in the epoch of the candidate operated by the address, nil if the rewards
+// of the candidate are paid out to its reward address
+func (p *Protocol) PayoutSplit(sr protocol.StateReader, operator address.Address, epoch uint64) ([]action.PayoutShare, error) {
+	c, err := ConstructBaseView(sr)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get PayoutSplit")
+	}
+	cand := c.BaseView().candCenter.GetByOperator(operator)
+	if cand == nil {
+		return nil, nil
+	}
+	return cand.PayoutSplitAt(epoch), nil
+}
+
 // ReadState read the state on blockchain via protocol
 func (p *Protocol) ReadState(ctx context.Context, sr protocol.StateReader, method []byte, args ...[]byte) ([]byte, uint64, error) {
+	// the methods not defined in iotex-proto, the result is in json
+	switch string(method) {
+	case ReadStateTransferLock:
+		return p.readStateTransferLock(ctx, sr, args...)
+	case ReadStateMaturingBuckets:
+		return p.readStateMaturingBuckets(ctx, sr, args...)
+	case ReadStateContractStakingReconciliation:
+		return p.readStateContractStakingReconciliation(ctx, sr, args...)
+	}
 	m := iotexapi.ReadStakingDataMethod{}
 	if err := proto.Unmarshal(method, &m); err != nil {
 		return nil, uint64(0), errors.Wrap(err, "failed to unmarshal method name")
@@ -580,14 +733,7 @@ func (p *Protocol) ReadState(ctx context.Context, sr protocol.StateReader, metho
 	}
 
 	// stakeSR is the stake state reader including native and contract staking
-	indexers := []ContractStakingIndexer{}
-	if p.contractStakingIndexer != nil {
-		indexers = append(indexers, p.contractStakingIndexer)
-	}
-	if p.contractStakingIndexerV2 != nil {
-		indexers = append(indexers, p.contractStakingIndexerV2)
-	}
-	stakeSR, err := newCompositeStakingStateReader(p.candBucketsIndexer, sr, p.calculateVoteWeight, indexers...)
+	stakeSR, err := newCompositeStakingStateReader(p.candBucketsIndexer, sr, p.calculateVoteWeight, p.contractStakingIndexers()...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -708,7 +854,8 @@ func (p *Protocol) settleAction(
 	}
 	depositLog, err := p.helperCtx.DepositGas(ctx, sm, gasFee, protocol.BurnGasOption(baseFee))
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to deposit gas")
+		// the caller can't afford the gas, so the action is skipped rather than included with the failure
+		return nil, protocol.MarkAdmissionError(protocol.MustGetFeatureCtx(ctx), errors.Wrap(err, "failed to deposit gas"))
 	}
 	if updateNonce {
 		accountCreationOpts := []state.AccountCreationOption{}
@@ -748,32 +895,19 @@ func (p *Protocol) needToWriteCandsMap(ctx context.Context, height uint64) bool
 }
 
 func (p *Protocol) contractStakingVotes(ctx context.Context, candidate address.Address, height uint64) (*big.Int, error) {
-	featureCtx := protocol.MustGetFeatureCtx(ctx)
-	votes := big.NewInt(0)
+	return contractStakingVotes(ctx, p.contractStakingIndexers(), candidate, height, p.calculateVoteWeight)
+}
+
+// contractStakingIndexers returns the indexers of the staking contracts
+func (p *Protocol) contractStakingIndexers() []ContractStakingIndexer {
 	indexers := []ContractStakingIndexer{}
-	if p.contractStakingIndexer != nil && featureCtx.AddContractStakingVotes {
+	if p.contractStakingIndexer != nil {
 		indexers = append(indexers, p.contractStakingIndexer)
 	}
-	if p.contractStakingIndexerV2 != nil && !featureCtx.LimitedStakingContract {
+	if p.contractStakingIndexerV2 != nil {
 		indexers = append(indexers, p.contractStakingIndexerV2)
 	}
-	for _, indexer := range indexers {
-		btks, err := indexer.BucketsByCandidate(candidate, height)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to get BucketsByCandidate from contractStakingIndexer")
-		}
-		for _, b := range btks {
-			if b.isUnstaked() {
-				continue
-			}
-			if featureCtx.FixContractStakingWeightedVotes {
-				votes.Add(votes, p.calculateVoteWeight(b, false))
-			} else {
-				votes.Add(votes, b.StakedAmount)
-			}
-		}
-	}
-	return votes, nil
+	return indexers
 }
 
 func readCandCenterStateFromStateDB(sr protocol.StateReader) (CandidateList, CandidateList, CandidateList, error) {
@@ -306,6 +306,8 @@ func Test_CreatePreStatesWithRegisterProtocol(t *testing.T) {
 	require.Error(err)
 
 	require.NoError(p.CreatePreStates(ctx, sm))
+	ctx = protocol.WithEpochCtx(ctx, rol.GetEpochCtx(genesis.Default.GreenlandBlockHeight))
+	require.NoError(p.PreEpochStart(ctx, sm))
 }
 
 func Test_CreateGenesisStates(t *testing.T) {
@@ -62,7 +62,8 @@ func getPageOfBuckets(buckets []*VoteBucket, offset, limit int) []*VoteBucket {
 	return getPageOfArray(buckets, offset, limit)
 }
 
-func toIoTeXTypesCandidateV2(csr CandidateStateReader, cand *Candidate, featureCtx protocol.FeatureCtx) (*iotextypes.CandidateV2, error) {
+func toIoTeXTypesCandidateV2(ctx context.Context, csr CandidateStateReader, cand *Candidate) (*iotextypes.CandidateV2, error) {
+	featureCtx := protocol.MustGetFeatureCtx(ctx)
 	esr := NewEndorsementStateReader(csr.SR())
 	height, _ := csr.SR().Height()
 	needClear := func(c *Candidate) (bool, error) {
@@ -95,15 +96,26 @@ func toIoTeXTypesCandidateV2(csr CandidateStateReader, cand *Candidate, featureC
 		c.SelfStakeBucketIdx = candidateNoSelfStakeBucketIndex
 		c.SelfStakingTokens = "0"
 	}
+	if protocol.IsParameterEnabled(ctx, _parameterDeclarer, _paramCandidateProfile) {
+		cp, err := candidateProfile(csr.SR(), cand)
+		if err != nil {
+			return nil, err
+		}
+		if cp != nil {
+			if err := appendCandidateProfile(c, cp); err != nil {
+				return nil, err
+			}
+		}
+	}
 	return c, nil
 }
 
-func toIoTeXTypesCandidateListV2(csr CandidateStateReader, candidates CandidateList, featureCtx protocol.FeatureCtx) (*iotextypes.CandidateListV2, error) {
+func toIoTeXTypesCandidateListV2(ctx context.Context, csr CandidateStateReader, candidates CandidateList) (*iotextypes.CandidateListV2, error) {
 	res := iotextypes.CandidateListV2{
 		Candidates: make([]*iotextypes.CandidateV2, 0, len(candidates)),
 	}
 	for _, c := range candidates {
-		cand, err := toIoTeXTypesCandidateV2(csr, c, featureCtx)
+		cand, err := toIoTeXTypesCandidateV2(ctx, csr, c)
 		if err != nil {
 			return nil, err
 		}
@@ -7,19 +7,44 @@ package staking
 
 import (
 	"context"
+	"math/big"
+	"strings"
+	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 )
 
-type receiptLog struct {
-	addr                  string
-	topics                action.Topics
-	data                  []byte
-	postFairbankMigration bool
+var _stakingEvents abi.ABI
+
+type (
+	receiptLog struct {
+		addr                  string
+		topics                action.Topics
+		data                  []byte
+		postFairbankMigration bool
+		events                []*eventLog
+	}
+
+	// eventLog is the log of a staking event in the ABI of action.StakingEventsABI
+	eventLog struct {
+		topics action.Topics
+		data   []byte
+	}
+)
+
+func init() {
+	var err error
+	_stakingEvents, err = abi.JSON(strings.NewReader(action.StakingEventsABI))
+	if err != nil {
+		panic(err)
+	}
 }
 
 func newReceiptLog(addr, topic string, postFairbankMigration bool) *receiptLog {
@@ -75,3 +100,104 @@ func (r *receiptLog) Build(ctx context.Context, err error) *action.Log {
 	}
 	return nil
 }
+
+// AddEvent adds the log of the staking event, with the arguments in the order of the inputs of the event
+func (r *receiptLog) AddEvent(name string, args ...interface{}) {
+	l, err := packEvent(name, args...)
+	if err != nil {
+		// the arguments are fixed by the handlers, so it's a bug to fail
+		panic(errors.Wrapf(err, "failed to pack staking event %s", name))
+	}
+	r.events = append(r.events, l)
+}
+
+// AddBucketEvent adds the log of the event of the bucket
+func (r *receiptLog) AddBucketEvent(name string, bucket *VoteBucket) {
+	switch name {
+	case action.StakingEventBucketCreated, action.StakingEventBucketUpdated:
+		r.AddEvent(name, bucket.Index, ethAddress(bucket.Owner), ethAddress(bucket.Candidate), bucket.StakedAmount,
+			uint32(bucket.StakedDuration/(24*time.Hour)), bucket.AutoStake)
+	default:
+		r.AddEvent(name, bucket.Index, ethAddress(bucket.Owner), ethAddress(bucket.Candidate), bucket.StakedAmount)
+	}
+}
+
+// AddCandidateEvent adds the log of the event of the candidate
+func (r *receiptLog) AddCandidateEvent(name string, c *Candidate) {
+	r.AddEvent(name, ethAddress(c.GetIdentifier()), ethAddress(c.Owner), ethAddress(c.Operator), ethAddress(c.Reward), c.Name)
+}
+
+// AddVotesEvent adds the log of the votes of the candidate
+func (r *receiptLog) AddVotesEvent(c *Candidate) {
+	selfStake := c.SelfStake
+	if selfStake == nil {
+		selfStake = big.NewInt(0)
+	}
+	r.AddEvent(action.StakingEventVotesChanged, ethAddress(c.GetIdentifier()), c.Votes, selfStake)
+}
+
+// BuildEvents builds the logs of the staking events since the activation, so that the receipts before it are kept
+func (r *receiptLog) BuildEvents(ctx context.Context) []*action.Log {
+	if !protocol.MustGetFeatureCtx(ctx).EnableStakingEventLogs || len(r.events) == 0 {
+		return nil
+	}
+	blkCtx := protocol.MustGetBlockCtx(ctx)
+	actionCtx := protocol.MustGetActionCtx(ctx)
+	logs := make([]*action.Log, 0, len(r.events))
+	for _, e := range r.events {
+		logs = append(logs, &action.Log{
+			Address:     r.addr,
+			Topics:      e.topics,
+			Data:        e.data,
+			BlockHeight: blkCtx.BlockHeight,
+			ActionHash:  actionCtx.ActionHash,
+		})
+	}
+	return logs
+}
+
+// packEvent packs the event as the topic0 of the event signature, the indexed arguments as the topics, and the rest
+// as the data
+func packEvent(name string, args ...interface{}) (*eventLog, error) {
+	event, ok := _stakingEvents.Events[name]
+	if !ok {
+		return nil, errors.Errorf("unknown event %s", name)
+	}
+	if len(args) != len(event.Inputs) {
+		return nil, errors.Errorf("invalid number of arguments %d, expecting %d", len(args), len(event.Inputs))
+	}
+	var (
+		indexed    [][]interface{}
+		nonIndexed []interface{}
+	)
+	for i, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, []interface{}{args[i]})
+		} else {
+			nonIndexed = append(nonIndexed, args[i])
+		}
+	}
+	topics, err := abi.MakeTopics(indexed...)
+	if err != nil {
+		return nil, err
+	}
+	data, err := event.Inputs.NonIndexed().Pack(nonIndexed...)
+	if err != nil {
+		return nil, err
+	}
+	l := &eventLog{
+		topics: action.Topics{hash.Hash256(event.ID)},
+		data:   data,
+	}
+	for _, topic := range topics {
+		l.topics = append(l.topics, hash.Hash256(topic[0]))
+	}
+	return l, nil
+}
+
+func ethAddress(addr address.Address) common.Address {
+	if addr == nil {
+		return common.Address{}
+	}
+	return common.BytesToAddress(addr.Bytes())
+}
@@ -7,14 +7,22 @@ package staking
 
 import (
 	"context"
+	"math/big"
 	"testing"
+	"time"
 
+	"github.com/golang/mock/gomock"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/mohae/deepcopy"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/test/identityset"
 )
@@ -175,3 +183,218 @@ func createLog(
 		ActionHash:  actionCtx.ActionHash,
 	}
 }
+
+func TestReceiptLogEvents(t *testing.T) {
+	r := require.New(t)
+	var (
+		owner  = identityset.Address(11)
+		cand   = identityset.Address(5)
+		bucket = &VoteBucket{
+			Index:          3,
+			Owner:          owner,
+			Candidate:      cand,
+			StakedAmount:   big.NewInt(1000),
+			StakedDuration: 91 * 24 * time.Hour,
+			AutoStake:      true,
+		}
+		g   = deepcopy.Copy(genesis.Default).(genesis.Genesis)
+		ctx = protocol.WithActionCtx(context.Background(), protocol.ActionCtx{
+			ActionHash: hash.Hash256b([]byte("test-action")),
+		})
+	)
+	g.ToBeEnabledBlockHeight = 6
+	ctxAt := func(height uint64) context.Context {
+		ctx := protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: height})
+		return protocol.WithFeatureCtx(genesis.WithGenesisContext(ctx, g))
+	}
+	log := newReceiptLog(address.StakingProtocolAddr, HandleCreateStake, true)
+	log.AddBucketEvent(action.StakingEventBucketCreated, bucket)
+	// the arguments are packed as the event is added
+	bucket.StakedAmount.SetInt64(2000)
+
+	// no event is emitted before the activation
+	r.Nil(log.BuildEvents(ctxAt(5)))
+	logs := log.BuildEvents(ctxAt(6))
+	r.Len(logs, 1)
+	event := _stakingEvents.Events[action.StakingEventBucketCreated]
+	r.Equal(address.StakingProtocolAddr, logs[0].Address)
+	r.Equal(uint64(6), logs[0].BlockHeight)
+	r.Equal(hash.Hash256b([]byte("test-action")), logs[0].ActionHash)
+	r.Equal(action.Topics{
+		hash.Hash256(event.ID),
+		hash.BytesToHash256(byteutil.Uint64ToBytesBigEndian(3)),
+		hash.BytesToHash256(owner.Bytes()),
+		hash.BytesToHash256(cand.Bytes()),
+	}, logs[0].Topics)
+	args, err := event.Inputs.NonIndexed().Unpack(logs[0].Data)
+	r.NoError(err)
+	r.Equal([]interface{}{big.NewInt(1000), uint32(91), true}, args)
+
+	// no log without the events, and the arguments must match the inputs of the event
+	r.Nil(newReceiptLog(address.StakingProtocolAddr, HandleCreateStake, true).BuildEvents(ctxAt(6)))
+	r.Panics(func() { log.AddEvent("Unknown") })
+	r.Panics(func() { log.AddEvent(action.StakingEventVotesChanged, ethAddress(cand)) })
+}
+
+func TestProtocol_HandleStakingEventLogs(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm, p, _, candidates := initTestState(t, ctrl, nil, []*candidateConfig{
+		{identityset.Address(1), identityset.Address(7), identityset.Address(1), "test1"},
+	})
+	var (
+		candidate = candidates[0]
+		owner     = identityset.Address(21)
+		voter     = identityset.Address(22)
+		newVoter  = identityset.Address(23)
+		owner2    = identityset.Address(24)
+		newOwner2 = identityset.Address(25)
+		nonces    = map[string]uint64{}
+		g         = deepcopy.Copy(genesis.Default).(genesis.Genesis)
+		rp        = rolldpos.NewProtocol(1, 1, 1)
+		reg       = protocol.NewRegistry()
+		height    = uint64(1)
+		t0        = time.Now()
+		selfStake = g.Staking.RegistrationConsts.MinSelfStake
+	)
+	r.NoError(rp.Register(reg))
+	g.FbkMigrationBlockHeight = 0
+	g.ToBeEnabledBlockHeight = 0
+	for _, addr := range []address.Address{owner, voter, newVoter, owner2, newOwner2} {
+		r.NoError(setupAccount(sm, addr, 5000000))
+	}
+	type stakingAction interface {
+		action.Action
+		IntrinsicGas() (uint64, error)
+	}
+	handle := func(caller address.Address, ts time.Time, act stakingAction) []*action.Log {
+		height++
+		nonces[caller.String()]++
+		intrinsic, err := act.IntrinsicGas()
+		r.NoError(err)
+		ctx := protocol.WithBlockCtx(context.Background(), protocol.BlockCtx{
+			BlockHeight:    height,
+			BlockTimeStamp: ts,
+			GasLimit:       1000000,
+		})
+		ctx = protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{Tip: protocol.TipInfo{Height: height - 1}})
+		ctx = protocol.WithRegistry(genesis.WithGenesisContext(ctx, g), reg)
+		ctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
+		ctx = protocol.WithActionCtx(ctx, protocol.ActionCtx{
+			Caller:       caller,
+			GasPrice:     big.NewInt(0),
+			IntrinsicGas: intrinsic,
+			Nonce:        nonces[caller.String()],
+		})
+		receipt, err := p.Handle(ctx, act, sm)
+		r.NoError(err)
+		r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+		return receipt.Logs()
+	}
+	// events returns the names of the events in the logs, and checks the indexed arguments in the topics
+	events := func(logs []*action.Log) []string {
+		var names []string
+		for _, l := range logs {
+			for name, event := range _stakingEvents.Events {
+				if hash.Hash256(event.ID) != l.Topics[0] {
+					continue
+				}
+				names = append(names, name)
+				indexed := 0
+				for _, input := range event.Inputs {
+					if input.Indexed {
+						indexed++
+					}
+				}
+				r.Len(l.Topics, indexed+1)
+				_, err := event.Inputs.NonIndexed().Unpack(l.Data)
+				r.NoError(err)
+			}
+		}
+		return names
+	}
+	votesOf := func(l *action.Log, cand address.Address) *big.Int {
+		event := _stakingEvents.Events[action.StakingEventVotesChanged]
+		r.Equal(hash.Hash256(event.ID), l.Topics[0])
+		r.Equal(hash.BytesToHash256(cand.Bytes()), l.Topics[1])
+		args, err := event.Inputs.NonIndexed().Unpack(l.Data)
+		r.NoError(err)
+		return args[0].(*big.Int)
+	}
+	must := func(act stakingAction, err error) stakingAction {
+		r.NoError(err)
+		return act
+	}
+
+	// bucket 0 is the self-stake of the registered candidate
+	logs := handle(owner, t0, must(action.NewCandidateRegister(0, "event", identityset.Address(26).String(), identityset.Address(26).String(), "", selfStake, 91, true, nil, 0, big.NewInt(0))))
+	r.Equal([]string{action.StakingEventCandidateRegistered, action.StakingEventBucketCreated, action.StakingEventVotesChanged}, events(logs))
+	r.Equal(hash.BytesToHash256(owner.Bytes()), logs[1].Topics[1])
+	// bucket 1 of the voter
+	logs = handle(voter, t0, must(action.NewCreateStake(0, "event", "100000000000000000000", 1, true, nil, 0, big.NewInt(0))))
+	r.Equal([]string{action.StakingEventBucketCreated, action.StakingEventVotesChanged}, events(logs))
+	created := votesOf(logs[2], owner)
+	logs = handle(voter, t0, must(action.NewDepositToStake(0, 1, "100000000000000000000", nil, 0, big.NewInt(0))))
+	r.Equal([]string{action.StakingEventBucketUpdated, action.StakingEventVotesChanged}, events(logs))
+	r.True(votesOf(logs[2], owner).Cmp(created) > 0)
+	logs = handle(voter, t0, must(action.NewRestake(0, 1, 1, false, nil, 0, big.NewInt(0))))
+	r.Equal([]string{action.StakingEventBucketUpdated, action.StakingEventVotesChanged}, events(logs))
+	logs = handle(voter, t0, must(action.NewChangeCandidate(0, candidate.Name, 1, nil, 0, big.NewInt(0))))
+	r.Equal([]string{action.StakingEventBucketUpdated, action.StakingEventVotesChanged, action.StakingEventVotesChanged}, events(logs))
+	votesOf(logs[2], owner)
+	votesOf(logs[3], candidate.GetIdentifier())
+	logs = handle(voter, t0, must(action.NewTransferStake(0, newVoter.String(), 1, nil, 0, big.NewInt(0))))
+	r.Equal([]string{action.StakingEventBucketUpdated}, events(logs))
+	r.Equal(hash.BytesToHash256(newVoter.Bytes()), logs[1].Topics[2])
+	logs = handle(newVoter, t0.Add(2*24*time.Hour), must(action.NewUnstake(0, 1, nil, 0, big.NewInt(0))))
+	r.Equal([]string{action.StakingEventBucketUnstaked, action.StakingEventVotesChanged}, events(logs))
+	logs = handle(newVoter, t0.Add(10*24*time.Hour), must(action.NewWithdrawStake(0, 1, nil, 0, big.NewInt(0))))
+	r.Equal([]string{action.StakingEventBucketWithdrawn}, events(logs))
+
+	// the candidate registered without self-stake is activated by the bucket 2 endorsed by the voter
+	logs = handle(owner2, t0, must(action.NewCandidateRegister(0, "endorsed", identityset.Address(27).String(), identityset.Address(27).String(), "", "0", 0, false, nil, 0, big.NewInt(0))))
+	r.Equal([]string{action.StakingEventCandidateRegistered, action.StakingEventVotesChanged}, events(logs))
+	logs = handle(voter, t0, must(action.NewCreateStake(0, "endorsed", selfStake, 91, true, nil, 0, big.NewInt(0))))
+	r.Equal([]string{action.StakingEventBucketCreated, action.StakingEventVotesChanged}, events(logs))
+	logs = handle(voter, t0, must(action.NewCandidateEndorsement(0, 0, big.NewInt(0), 2, action.CandidateEndorsementOpEndorse)))
+	r.Equal([]string{action.StakingEventBucketUpdated}, events(logs))
+	logs = handle(owner2, t0, action.NewCandidateActivate(0, 0, big.NewInt(0), 2))
+	r.Equal([]string{action.StakingEventVotesChanged}, events(logs))
+	activated := votesOf(logs[1], owner2)
+	logs = handle(voter, t0, must(action.NewCandidateEndorsement(0, 0, big.NewInt(0), 2, action.CandidateEndorsementOpIntentToRevoke)))
+	r.Equal([]string{action.StakingEventBucketUpdated}, events(logs))
+	logs = handle(voter, t0, must(action.NewCandidateEndorsement(0, 0, big.NewInt(0), 2, action.CandidateEndorsementOpRevoke)))
+	r.Equal([]string{action.StakingEventVotesChanged, action.StakingEventBucketUpdated}, events(logs))
+	r.True(votesOf(logs[1], owner2).Cmp(activated) < 0)
+	logs = handle(owner2, t0, must(action.NewCandidateTransferOwnership(0, 0, big.NewInt(0), newOwner2.String(), nil)))
+	r.Equal([]string{action.StakingEventCandidateUpdated, action.StakingEventVotesChanged}, events(logs))
+	r.Equal(hash.BytesToHash256(newOwner2.Bytes()), logs[1].Topics[2])
+
+	logs = handle(owner, t0, must(action.NewCandidateUpdate(0, "renamed", "", "", 0, big.NewInt(0))))
+	r.Equal([]string{action.StakingEventCandidateUpdated}, events(logs))
+	args, err := _stakingEvents.Events[action.StakingEventCandidateUpdated].Inputs.NonIndexed().Unpack(logs[1].Data)
+	r.NoError(err)
+	r.Equal("renamed", args[2])
+	logs = handle(owner, t0, action.NewUpdateCandidateProfile(0, 0, big.NewInt(0), "https://iotex.io", "", nil, ""))
+	r.Equal([]string{action.StakingEventCandidateUpdated}, events(logs))
+	// the transfer lock changes no bucket or candidate
+	logs = handle(owner, t0, action.NewStakeTransferLock(0, 0, big.NewInt(0), action.StakeTransferLockOpLock, []address.Address{voter}))
+	r.Empty(events(logs))
+
+	// the self-stake bucket withdrawn in the migration
+	csm, err := NewCandidateStateManager(sm, false)
+	r.NoError(err)
+	bucket, err := csm.getBucket(0)
+	r.NoError(err)
+	staker, err := accountutil.LoadAccount(sm, owner)
+	r.NoError(err)
+	ctx := protocol.WithBlockCtx(genesis.WithGenesisContext(context.Background(), g), protocol.BlockCtx{BlockHeight: height})
+	ctx = protocol.WithFeatureCtx(protocol.WithActionCtx(ctx, protocol.ActionCtx{Caller: owner}))
+	cand := csm.GetByIdentifier(owner)
+	prevVotes := new(big.Int).Set(cand.Votes)
+	log, _, err := p.withdrawBucket(ctx, staker, bucket, cand, csm)
+	r.NoError(err)
+	logs = log.BuildEvents(ctx)
+	r.Equal([]string{action.StakingEventBucketWithdrawn, action.StakingEventVotesChanged}, events(logs))
+	r.True(votesOf(logs[1], owner).Cmp(prevVotes) < 0)
+}
@@ -7,7 +7,10 @@ package staking
 
 import (
 	"context"
+	"math"
 	"math/big"
+	"slices"
+	"sort"
 
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-proto/golang/iotexapi"
@@ -18,6 +21,16 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
 )
 
+// the origins of the buckets in the composite view
+const (
+	// BucketOriginNative is the origin of the buckets of native staking
+	BucketOriginNative = "native"
+	// BucketOriginContract is the origin of the buckets of the staking contract of bucket types
+	BucketOriginContract = "contract"
+	// BucketOriginContractV2 is the origin of the buckets of the staking contract v2, of arbitrary amount and duration
+	BucketOriginContractV2 = "contractV2"
+)
+
 type (
 	// compositeStakingStateReader is the compositive staking state reader, which combine native and contract staking
 	compositeStakingStateReader struct {
@@ -55,15 +68,18 @@ func (c *compositeStakingStateReader) readStateBuckets(ctx context.Context, req
 		buckets *iotextypes.VoteBucketList
 		height  uint64
 	)
+	// read all native buckets, the page is taken from the merged list
 	if epochStartHeight != 0 && c.nativeIndexer != nil {
 		// read native buckets from indexer
-		buckets, height, err = c.nativeIndexer.GetBuckets(epochStartHeight, req.GetPagination().GetOffset(), req.GetPagination().GetLimit())
+		buckets, height, err = c.nativeIndexer.GetBuckets(epochStartHeight, 0, math.MaxInt32)
 		if err != nil {
 			return nil, 0, err
 		}
 	} else {
 		// read native buckets from state
-		buckets, height, err = c.nativeSR.readStateBuckets(ctx, req)
+		buckets, height, err = c.nativeSR.readStateBuckets(ctx, &iotexapi.ReadStakingDataRequest_VoteBuckets{
+			Pagination: &iotexapi.PaginationParam{Offset: 0, Limit: math.MaxInt32},
+		})
 		if err != nil {
 			return nil, 0, err
 		}
@@ -75,13 +91,11 @@ func (c *compositeStakingStateReader) readStateBuckets(ctx context.Context, req
 	}
 
 	// read nft buckets
-	nftBuckets := make([]*VoteBucket, 0)
-	for _, indexer := range c.contractIndexers {
-		bkts, err := indexer.Buckets(height)
-		if err != nil {
-			return nil, 0, err
-		}
-		nftBuckets = append(nftBuckets, bkts...)
+	nftBuckets, err := c.contractBuckets(func(indexer ContractStakingIndexer) ([]*VoteBucket, error) {
+		return indexer.Buckets(height)
+	})
+	if err != nil {
+		return nil, 0, err
 	}
 	lsdIoTeXBuckets, err := toIoTeXTypesVoteBucketList(c.nativeSR.SR(), nftBuckets)
 	if err != nil {
@@ -94,8 +108,11 @@ func (c *compositeStakingStateReader) readStateBuckets(ctx context.Context, req
 }
 
 func (c *compositeStakingStateReader) readStateBucketsByVoter(ctx context.Context, req *iotexapi.ReadStakingDataRequest_VoteBucketsByVoter) (*iotextypes.VoteBucketList, uint64, error) {
-	// read native buckets
-	buckets, height, err := c.nativeSR.readStateBucketsByVoter(ctx, req)
+	// read all native buckets, the page is taken from the merged list
+	buckets, height, err := c.nativeSR.readStateBucketsByVoter(ctx, &iotexapi.ReadStakingDataRequest_VoteBucketsByVoter{
+		VoterAddress: req.GetVoterAddress(),
+		Pagination:   &iotexapi.PaginationParam{Offset: 0, Limit: math.MaxInt32},
+	})
 	if err != nil {
 		return nil, 0, err
 	}
@@ -105,13 +122,11 @@ func (c *compositeStakingStateReader) readStateBucketsByVoter(ctx context.Contex
 	}
 
 	// read LSD buckets
-	nftBuckets := make([]*VoteBucket, 0)
-	for _, indexer := range c.contractIndexers {
-		bkts, err := indexer.Buckets(height)
-		if err != nil {
-			return nil, 0, err
-		}
-		nftBuckets = append(nftBuckets, bkts...)
+	nftBuckets, err := c.contractBuckets(func(indexer ContractStakingIndexer) ([]*VoteBucket, error) {
+		return indexer.Buckets(height)
+	})
+	if err != nil {
+		return nil, 0, err
 	}
 	nftBuckets = filterBucketsByVoter(nftBuckets, req.GetVoterAddress())
 	lsdIoTeXBuckets, err := toIoTeXTypesVoteBucketList(c.nativeSR.SR(), nftBuckets)
@@ -125,8 +140,11 @@ func (c *compositeStakingStateReader) readStateBucketsByVoter(ctx context.Contex
 }
 
 func (c *compositeStakingStateReader) readStateBucketsByCandidate(ctx context.Context, req *iotexapi.ReadStakingDataRequest_VoteBucketsByCandidate) (*iotextypes.VoteBucketList, uint64, error) {
-	// read native buckets
-	buckets, height, err := c.nativeSR.readStateBucketsByCandidate(ctx, req)
+	// read all native buckets, the page is taken from the merged list
+	buckets, height, err := c.nativeSR.readStateBucketsByCandidate(ctx, &iotexapi.ReadStakingDataRequest_VoteBucketsByCandidate{
+		CandName:   req.GetCandName(),
+		Pagination: &iotexapi.PaginationParam{Offset: 0, Limit: math.MaxInt32},
+	})
 	if err != nil {
 		return nil, 0, err
 	}
@@ -141,13 +159,11 @@ func (c *compositeStakingStateReader) readStateBucketsByCandidate(ctx context.Co
 	if candidate == nil {
 		return &iotextypes.VoteBucketList{}, height, nil
 	}
-	nftBuckets := make([]*VoteBucket, 0)
-	for _, indexer := range c.contractIndexers {
-		bkts, err := indexer.BucketsByCandidate(candidate.GetIdentifier(), height)
-		if err != nil {
-			return nil, 0, err
-		}
-		nftBuckets = append(nftBuckets, bkts...)
+	nftBuckets, err := c.contractBuckets(func(indexer ContractStakingIndexer) ([]*VoteBucket, error) {
+		return indexer.BucketsByCandidate(candidate.GetIdentifier(), height)
+	})
+	if err != nil {
+		return nil, 0, err
 	}
 	lsdIoTeXBuckets, err := toIoTeXTypesVoteBucketList(c.nativeSR.SR(), nftBuckets)
 	if err != nil {
@@ -170,13 +186,11 @@ func (c *compositeStakingStateReader) readStateBucketByIndices(ctx context.Conte
 	}
 
 	// read nft buckets
-	nftBuckets := make([]*VoteBucket, 0)
-	for _, indexer := range c.contractIndexers {
-		bkts, err := indexer.BucketsByIndices(req.GetIndex(), height)
-		if err != nil {
-			return nil, 0, err
-		}
-		nftBuckets = append(nftBuckets, bkts...)
+	nftBuckets, err := c.contractBuckets(func(indexer ContractStakingIndexer) ([]*VoteBucket, error) {
+		return indexer.BucketsByIndices(req.GetIndex(), height)
+	})
+	if err != nil {
+		return nil, 0, err
 	}
 	lsbIoTeXBuckets, err := toIoTeXTypesVoteBucketList(c.nativeSR.SR(), nftBuckets)
 	if err != nil {
@@ -237,17 +251,12 @@ func (c *compositeStakingStateReader) readStateCandidates(ctx context.Context, r
 			return nil, 0, err
 		}
 	}
-	if !protocol.MustGetFeatureCtx(ctx).AddContractStakingVotes {
-		return candidates, height, nil
-	}
 	if !c.isContractStakingEnabled() {
 		return candidates, height, nil
 	}
 	for _, candidate := range candidates.Candidates {
-		for _, indexer := range c.contractIndexers {
-			if err = c.addContractStakingVotes(ctx, candidate, indexer, height); err != nil {
-				return nil, 0, err
-			}
+		if err = c.addContractStakingVotes(ctx, candidate, height); err != nil {
+			return nil, 0, err
 		}
 	}
 	return candidates, height, nil
@@ -261,13 +270,8 @@ func (c *compositeStakingStateReader) readStateCandidateByName(ctx context.Conte
 	if !c.isContractStakingEnabled() {
 		return candidate, height, nil
 	}
-	if !protocol.MustGetFeatureCtx(ctx).AddContractStakingVotes {
-		return candidate, height, nil
-	}
-	for _, indexer := range c.contractIndexers {
-		if err := c.addContractStakingVotes(ctx, candidate, indexer, height); err != nil {
-			return nil, 0, err
-		}
+	if err := c.addContractStakingVotes(ctx, candidate, height); err != nil {
+		return nil, 0, err
 	}
 	return candidate, height, nil
 }
@@ -280,13 +284,8 @@ func (c *compositeStakingStateReader) readStateCandidateByAddress(ctx context.Co
 	if !c.isContractStakingEnabled() {
 		return candidate, height, nil
 	}
-	if !protocol.MustGetFeatureCtx(ctx).AddContractStakingVotes {
-		return candidate, height, nil
-	}
-	for _, indexer := range c.contractIndexers {
-		if err := c.addContractStakingVotes(ctx, candidate, indexer, height); err != nil {
-			return nil, 0, err
-		}
+	if err := c.addContractStakingVotes(ctx, candidate, height); err != nil {
+		return nil, 0, err
 	}
 	return candidate, height, nil
 }
@@ -354,7 +353,9 @@ func (c *compositeStakingStateReader) isContractStakingEnabled() bool {
 	return len(c.contractIndexers) > 0
 }
 
-func (c *compositeStakingStateReader) addContractStakingVotes(ctx context.Context, candidate *iotextypes.CandidateV2, contractStakingSR ContractStakingIndexer, height uint64) error {
+// addContractStakingVotes adds the votes of the contract staking buckets to the candidate, the same as the votes
+// counted in the active candidates of consensus
+func (c *compositeStakingStateReader) addContractStakingVotes(ctx context.Context, candidate *iotextypes.CandidateV2, height uint64) error {
 	votes, ok := big.NewInt(0).SetString(candidate.TotalWeightedVotes, 10)
 	if !ok {
 		return errors.Errorf("invalid total weighted votes %s", candidate.TotalWeightedVotes)
@@ -363,24 +364,76 @@ func (c *compositeStakingStateReader) addContractStakingVotes(ctx context.Contex
 	if err != nil {
 		return err
 	}
-	bkts, err := contractStakingSR.BucketsByCandidate(addr, height)
+	csVotes, err := contractStakingVotes(ctx, c.contractIndexers, addr, height, c.calculateVoteWeight)
 	if err != nil {
-		return errors.Wrap(err, "failed to get BucketsByCandidate from contractStakingIndexerV2")
+		return err
 	}
-	for _, b := range bkts {
-		if b.isUnstaked() {
-			continue
+	candidate.TotalWeightedVotes = votes.Add(votes, csVotes).String()
+	return nil
+}
+
+// contractBuckets returns the buckets read from each contract indexer, sorted by index within the contract
+func (c *compositeStakingStateReader) contractBuckets(read func(ContractStakingIndexer) ([]*VoteBucket, error)) ([]*VoteBucket, error) {
+	nftBuckets := make([]*VoteBucket, 0)
+	for _, indexer := range c.contractIndexers {
+		bkts, err := read(indexer)
+		if err != nil {
+			return nil, err
 		}
-		votes.Add(votes, c.calculateVoteWeight(b, false))
+		bkts = slices.DeleteFunc(bkts, func(b *VoteBucket) bool { return b == nil })
+		sort.SliceStable(bkts, func(i, j int) bool { return bkts[i].Index < bkts[j].Index })
+		nftBuckets = append(nftBuckets, bkts...)
 	}
-	candidate.TotalWeightedVotes = votes.String()
-	return nil
+	return nftBuckets, nil
+}
+
+// contractStakingVotes returns the weighted votes of the contract staking buckets of the candidate, the buckets of
+// each contract count after the activation of its votes
+func contractStakingVotes(ctx context.Context, indexers []ContractStakingIndexer, candidate address.Address, height uint64, calculateVoteWeight func(v *VoteBucket, selfStake bool) *big.Int) (*big.Int, error) {
+	featureCtx := protocol.MustGetFeatureCtx(ctx)
+	votes := big.NewInt(0)
+	for _, indexer := range indexers {
+		switch contractBucketOrigin(indexer) {
+		case BucketOriginContract:
+			if !featureCtx.AddContractStakingVotes {
+				continue
+			}
+		case BucketOriginContractV2:
+			if featureCtx.LimitedStakingContract {
+				continue
+			}
+		}
+		btks, err := indexer.BucketsByCandidate(candidate, height)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get BucketsByCandidate from contractStakingIndexer")
+		}
+		for _, b := range btks {
+			if b == nil || b.isUnstaked() {
+				continue
+			}
+			if featureCtx.FixContractStakingWeightedVotes {
+				votes.Add(votes, calculateVoteWeight(b, false))
+			} else {
+				votes.Add(votes, b.StakedAmount)
+			}
+		}
+	}
+	return votes, nil
+}
+
+// contractBucketOrigin returns the origin of the buckets of the contract indexer, the staking contract v1 defines the
+// bucket types, while the buckets of the staking contract v2 are of arbitrary amount and duration
+func contractBucketOrigin(indexer ContractStakingIndexer) string {
+	if _, ok := indexer.(ContractStakingIndexerWithBucketType); ok {
+		return BucketOriginContract
+	}
+	return BucketOriginContractV2
 }
 
 func filterBucketsByVoter(buckets []*VoteBucket, voterAddress string) []*VoteBucket {
 	var filtered []*VoteBucket
 	for _, bucket := range buckets {
-		if bucket.Owner.String() == voterAddress {
+		if bucket != nil && bucket.Owner.String() == voterAddress {
 			filtered = append(filtered, bucket)
 		}
 	}
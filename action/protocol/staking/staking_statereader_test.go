@@ -228,13 +228,40 @@ func TestStakingStateReader(t *testing.T) {
 		}
 	})
 
+	t.Run("readStateBucketsPagination", func(t *testing.T) {
+		sf, _, stakeSR, ctx, r := prepare(t)
+		sf.EXPECT().States(gomock.Any(), gomock.Any()).DoAndReturn(func(arg0 ...protocol.StateOption) (uint64, state.Iterator, error) {
+			iter, err := state.NewIterator(keys, states)
+			r.NoError(err)
+			return uint64(1), iter, nil
+		}).Times(2)
+		sf.EXPECT().State(gomock.Any(), gomock.Any()).Return(uint64(0), state.ErrStateNotExist).AnyTimes()
+		// the buckets of a contract are in random order and the missing ones are nil
+		indexer := NewMockContractStakingIndexer(gomock.NewController(t))
+		indexer.EXPECT().Buckets(gomock.Any()).Return([]*VoteBucket{testContractBuckets[1], nil, testContractBuckets[0]}, nil).Times(2)
+		compositeSR := *stakeSR.(*compositeStakingStateReader)
+		compositeSR.contractIndexers = []ContractStakingIndexer{indexer}
+
+		// the page is taken from the merged list of native and contract buckets sorted by index
+		for offset, expect := range []*VoteBucket{testContractBuckets[0], testContractBuckets[1]} {
+			buckets, _, err := compositeSR.readStateBuckets(ctx, &iotexapi.ReadStakingDataRequest_VoteBuckets{
+				Pagination: &iotexapi.PaginationParam{Offset: uint32(offset + 1), Limit: 1},
+			})
+			r.NoError(err)
+			r.Len(buckets.Buckets, 1)
+			iotexBucket, err := expect.toIoTeXTypes()
+			r.NoError(err)
+			r.Equal(iotexBucket, buckets.Buckets[0])
+		}
+	})
+
 	t.Run("readStateBucketsByVoter", func(t *testing.T) {
 		sf, _, stakeSR, ctx, r := prepare(t)
 		sf.EXPECT().State(gomock.AssignableToTypeOf(&BucketIndices{}), gomock.Any()).DoAndReturn(func(arg0 any, arg1 ...protocol.StateOption) (uint64, error) {
 			arg0R := arg0.(*BucketIndices)
 			*arg0R = []uint64{0}
 			return uint64(1), nil
-		}).Times(1)
+		}).Times(2)
 		sf.EXPECT().State(gomock.AssignableToTypeOf(&VoteBucket{}), gomock.Any()).DoAndReturn(func(arg0 any, arg1 ...protocol.StateOption) (uint64, error) {
 			arg0R := arg0.(*VoteBucket)
 			cfg := &protocol.StateConfig{}
@@ -244,15 +271,15 @@ func TestStakingStateReader(t *testing.T) {
 			idx := byteutil.BytesToUint64BigEndian(cfg.Key[1:])
 			*arg0R = *testNativeBuckets[idx]
 			return uint64(1), nil
-		}).Times(1)
+		}).Times(2)
 		sf.EXPECT().State(gomock.AssignableToTypeOf(&totalBucketCount{}), gomock.Any()).DoAndReturn(func(arg0 any, arg1 ...protocol.StateOption) (uint64, error) {
 			arg0R := arg0.(*totalBucketCount)
 			*arg0R = totalBucketCount{count: 1}
 			return uint64(1), nil
-		}).Times(1)
+		}).Times(2)
 		sf.EXPECT().State(gomock.AssignableToTypeOf(&Endorsement{}), gomock.Any()).DoAndReturn(func(arg0 any, arg1 ...protocol.StateOption) (uint64, error) {
 			return uint64(0), state.ErrStateNotExist
-		}).Times(1)
+		}).Times(2)
 
 		req := &iotexapi.ReadStakingDataRequest_VoteBucketsByVoter{
 			Pagination: &iotexapi.PaginationParam{
@@ -271,6 +298,13 @@ func TestStakingStateReader(t *testing.T) {
 		iotexBucket, err = testContractBuckets[0].toIoTeXTypes()
 		r.NoError(err)
 		r.Equal(iotexBucket, buckets.Buckets[1])
+
+		// the offset applies to the merged list of native and contract buckets
+		req.Pagination = &iotexapi.PaginationParam{Offset: 1, Limit: 100}
+		buckets, _, err = stakeSR.readStateBucketsByVoter(ctx, req)
+		r.NoError(err)
+		r.Len(buckets.Buckets, 1)
+		r.Equal(iotexBucket, buckets.Buckets[0])
 	})
 	t.Run("readStateBucketsByCandidate", func(t *testing.T) {
 		sf, contractIndexer, stakeSR, ctx, r := prepare(t)
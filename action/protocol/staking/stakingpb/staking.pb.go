@@ -8,7 +8,7 @@
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.33.0
+// 	protoc-gen-go v1.34.2
 // 	protoc        v3.19.4
 // source: staking.proto
 
@@ -232,14 +232,19 @@ type Candidate struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	OwnerAddress       string `protobuf:"bytes,1,opt,name=ownerAddress,proto3" json:"ownerAddress,omitempty"`
-	OperatorAddress    string `protobuf:"bytes,2,opt,name=operatorAddress,proto3" json:"operatorAddress,omitempty"`
-	RewardAddress      string `protobuf:"bytes,3,opt,name=rewardAddress,proto3" json:"rewardAddress,omitempty"`
-	Name               string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
-	Votes              string `protobuf:"bytes,5,opt,name=votes,proto3" json:"votes,omitempty"`
-	SelfStakeBucketIdx uint64 `protobuf:"varint,6,opt,name=selfStakeBucketIdx,proto3" json:"selfStakeBucketIdx,omitempty"`
-	SelfStake          string `protobuf:"bytes,7,opt,name=selfStake,proto3" json:"selfStake,omitempty"`
-	IdentifierAddress  string `protobuf:"bytes,8,opt,name=identifierAddress,proto3" json:"identifierAddress,omitempty"` //if the field is empty, set it to the old owner address
+	OwnerAddress           string         `protobuf:"bytes,1,opt,name=ownerAddress,proto3" json:"ownerAddress,omitempty"`
+	OperatorAddress        string         `protobuf:"bytes,2,opt,name=operatorAddress,proto3" json:"operatorAddress,omitempty"`
+	RewardAddress          string         `protobuf:"bytes,3,opt,name=rewardAddress,proto3" json:"rewardAddress,omitempty"`
+	Name                   string         `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Votes                  string         `protobuf:"bytes,5,opt,name=votes,proto3" json:"votes,omitempty"`
+	SelfStakeBucketIdx     uint64         `protobuf:"varint,6,opt,name=selfStakeBucketIdx,proto3" json:"selfStakeBucketIdx,omitempty"`
+	SelfStake              string         `protobuf:"bytes,7,opt,name=selfStake,proto3" json:"selfStake,omitempty"`
+	IdentifierAddress      string         `protobuf:"bytes,8,opt,name=identifierAddress,proto3" json:"identifierAddress,omitempty"` //if the field is empty, set it to the old owner address
+	PayoutSplit            []*PayoutShare `protobuf:"bytes,9,rep,name=payoutSplit,proto3" json:"payoutSplit,omitempty"`
+	NextPayoutSplit        []*PayoutShare `protobuf:"bytes,10,rep,name=nextPayoutSplit,proto3" json:"nextPayoutSplit,omitempty"`
+	NextPayoutSplitEpoch   uint64         `protobuf:"varint,11,opt,name=nextPayoutSplitEpoch,proto3" json:"nextPayoutSplitEpoch,omitempty"`
+	NextRewardAddress      string         `protobuf:"bytes,12,opt,name=nextRewardAddress,proto3" json:"nextRewardAddress,omitempty"`
+	NextRewardAddressEpoch uint64         `protobuf:"varint,13,opt,name=nextRewardAddressEpoch,proto3" json:"nextRewardAddressEpoch,omitempty"`
 }
 
 func (x *Candidate) Reset() {
@@ -330,6 +335,41 @@ func (x *Candidate) GetIdentifierAddress() string {
 	return ""
 }
 
+func (x *Candidate) GetPayoutSplit() []*PayoutShare {
+	if x != nil {
+		return x.PayoutSplit
+	}
+	return nil
+}
+
+func (x *Candidate) GetNextPayoutSplit() []*PayoutShare {
+	if x != nil {
+		return x.NextPayoutSplit
+	}
+	return nil
+}
+
+func (x *Candidate) GetNextPayoutSplitEpoch() uint64 {
+	if x != nil {
+		return x.NextPayoutSplitEpoch
+	}
+	return 0
+}
+
+func (x *Candidate) GetNextRewardAddress() string {
+	if x != nil {
+		return x.NextRewardAddress
+	}
+	return ""
+}
+
+func (x *Candidate) GetNextRewardAddressEpoch() uint64 {
+	if x != nil {
+		return x.NextRewardAddressEpoch
+	}
+	return 0
+}
+
 type Candidates struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -542,6 +582,542 @@ func (x *Endorsement) GetExpireHeight() uint64 {
 	return 0
 }
 
+type CandidateHistory struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Votes       string `protobuf:"bytes,3,opt,name=votes,proto3" json:"votes,omitempty"`
+	SelfStake   string `protobuf:"bytes,4,opt,name=selfStake,proto3" json:"selfStake,omitempty"`
+	BucketCount uint64 `protobuf:"varint,5,opt,name=bucketCount,proto3" json:"bucketCount,omitempty"`
+	Rank        uint32 `protobuf:"varint,6,opt,name=rank,proto3" json:"rank,omitempty"`
+}
+
+func (x *CandidateHistory) Reset() {
+	*x = CandidateHistory{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_staking_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CandidateHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CandidateHistory) ProtoMessage() {}
+
+func (x *CandidateHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_staking_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CandidateHistory.ProtoReflect.Descriptor instead.
+func (*CandidateHistory) Descriptor() ([]byte, []int) {
+	return file_staking_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CandidateHistory) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CandidateHistory) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CandidateHistory) GetVotes() string {
+	if x != nil {
+		return x.Votes
+	}
+	return ""
+}
+
+func (x *CandidateHistory) GetSelfStake() string {
+	if x != nil {
+		return x.SelfStake
+	}
+	return ""
+}
+
+func (x *CandidateHistory) GetBucketCount() uint64 {
+	if x != nil {
+		return x.BucketCount
+	}
+	return 0
+}
+
+func (x *CandidateHistory) GetRank() uint32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+type EpochCandidates struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Candidates []*CandidateHistory `protobuf:"bytes,1,rep,name=candidates,proto3" json:"candidates,omitempty"`
+}
+
+func (x *EpochCandidates) Reset() {
+	*x = EpochCandidates{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_staking_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EpochCandidates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EpochCandidates) ProtoMessage() {}
+
+func (x *EpochCandidates) ProtoReflect() protoreflect.Message {
+	mi := &file_staking_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EpochCandidates.ProtoReflect.Descriptor instead.
+func (*EpochCandidates) Descriptor() ([]byte, []int) {
+	return file_staking_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EpochCandidates) GetCandidates() []*CandidateHistory {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+type PayoutShare struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address     string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	BasisPoints uint32 `protobuf:"varint,2,opt,name=basisPoints,proto3" json:"basisPoints,omitempty"`
+}
+
+func (x *PayoutShare) Reset() {
+	*x = PayoutShare{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_staking_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PayoutShare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayoutShare) ProtoMessage() {}
+
+func (x *PayoutShare) ProtoReflect() protoreflect.Message {
+	mi := &file_staking_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayoutShare.ProtoReflect.Descriptor instead.
+func (*PayoutShare) Descriptor() ([]byte, []int) {
+	return file_staking_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PayoutShare) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *PayoutShare) GetBasisPoints() uint32 {
+	if x != nil {
+		return x.BasisPoints
+	}
+	return 0
+}
+
+type TransferLockChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EffectiveEpoch uint64   `protobuf:"varint,1,opt,name=effectiveEpoch,proto3" json:"effectiveEpoch,omitempty"`
+	Op             uint32   `protobuf:"varint,2,opt,name=op,proto3" json:"op,omitempty"`
+	Addresses      []string `protobuf:"bytes,3,rep,name=addresses,proto3" json:"addresses,omitempty"`
+}
+
+func (x *TransferLockChange) Reset() {
+	*x = TransferLockChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_staking_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransferLockChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferLockChange) ProtoMessage() {}
+
+func (x *TransferLockChange) ProtoReflect() protoreflect.Message {
+	mi := &file_staking_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferLockChange.ProtoReflect.Descriptor instead.
+func (*TransferLockChange) Descriptor() ([]byte, []int) {
+	return file_staking_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TransferLockChange) GetEffectiveEpoch() uint64 {
+	if x != nil {
+		return x.EffectiveEpoch
+	}
+	return 0
+}
+
+func (x *TransferLockChange) GetOp() uint32 {
+	if x != nil {
+		return x.Op
+	}
+	return 0
+}
+
+func (x *TransferLockChange) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+type TransferLock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Locked         bool                  `protobuf:"varint,1,opt,name=locked,proto3" json:"locked,omitempty"`
+	Allowlist      []string              `protobuf:"bytes,2,rep,name=allowlist,proto3" json:"allowlist,omitempty"`
+	PendingChanges []*TransferLockChange `protobuf:"bytes,3,rep,name=pendingChanges,proto3" json:"pendingChanges,omitempty"`
+}
+
+func (x *TransferLock) Reset() {
+	*x = TransferLock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_staking_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransferLock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferLock) ProtoMessage() {}
+
+func (x *TransferLock) ProtoReflect() protoreflect.Message {
+	mi := &file_staking_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferLock.ProtoReflect.Descriptor instead.
+func (*TransferLock) Descriptor() ([]byte, []int) {
+	return file_staking_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TransferLock) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
+func (x *TransferLock) GetAllowlist() []string {
+	if x != nil {
+		return x.Allowlist
+	}
+	return nil
+}
+
+func (x *TransferLock) GetPendingChanges() []*TransferLockChange {
+	if x != nil {
+		return x.PendingChanges
+	}
+	return nil
+}
+
+type Misbehavior struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Candidate          string `protobuf:"bytes,1,opt,name=candidate,proto3" json:"candidate,omitempty"`
+	Operator           string `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"`
+	Height             uint64 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	Reporter           string `protobuf:"bytes,4,opt,name=reporter,proto3" json:"reporter,omitempty"`
+	ReportHeight       uint64 `protobuf:"varint,5,opt,name=reportHeight,proto3" json:"reportHeight,omitempty"`
+	Slashed            string `protobuf:"bytes,6,opt,name=slashed,proto3" json:"slashed,omitempty"`
+	Bounty             string `protobuf:"bytes,7,opt,name=bounty,proto3" json:"bounty,omitempty"`
+	ProbationEndHeight uint64 `protobuf:"varint,8,opt,name=probationEndHeight,proto3" json:"probationEndHeight,omitempty"`
+}
+
+func (x *Misbehavior) Reset() {
+	*x = Misbehavior{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_staking_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Misbehavior) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Misbehavior) ProtoMessage() {}
+
+func (x *Misbehavior) ProtoReflect() protoreflect.Message {
+	mi := &file_staking_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Misbehavior.ProtoReflect.Descriptor instead.
+func (*Misbehavior) Descriptor() ([]byte, []int) {
+	return file_staking_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Misbehavior) GetCandidate() string {
+	if x != nil {
+		return x.Candidate
+	}
+	return ""
+}
+
+func (x *Misbehavior) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *Misbehavior) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Misbehavior) GetReporter() string {
+	if x != nil {
+		return x.Reporter
+	}
+	return ""
+}
+
+func (x *Misbehavior) GetReportHeight() uint64 {
+	if x != nil {
+		return x.ReportHeight
+	}
+	return 0
+}
+
+func (x *Misbehavior) GetSlashed() string {
+	if x != nil {
+		return x.Slashed
+	}
+	return ""
+}
+
+func (x *Misbehavior) GetBounty() string {
+	if x != nil {
+		return x.Bounty
+	}
+	return ""
+}
+
+func (x *Misbehavior) GetProbationEndHeight() uint64 {
+	if x != nil {
+		return x.ProbationEndHeight
+	}
+	return 0
+}
+
+type MisbehaviorProbation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EndHeight uint64 `protobuf:"varint,1,opt,name=endHeight,proto3" json:"endHeight,omitempty"`
+}
+
+func (x *MisbehaviorProbation) Reset() {
+	*x = MisbehaviorProbation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_staking_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MisbehaviorProbation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MisbehaviorProbation) ProtoMessage() {}
+
+func (x *MisbehaviorProbation) ProtoReflect() protoreflect.Message {
+	mi := &file_staking_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MisbehaviorProbation.ProtoReflect.Descriptor instead.
+func (*MisbehaviorProbation) Descriptor() ([]byte, []int) {
+	return file_staking_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MisbehaviorProbation) GetEndHeight() uint64 {
+	if x != nil {
+		return x.EndHeight
+	}
+	return 0
+}
+
+type CandidateProfile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url             string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Description     string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	IconHash        []byte `protobuf:"bytes,3,opt,name=iconHash,proto3" json:"iconHash,omitempty"`
+	SecurityContact string `protobuf:"bytes,4,opt,name=securityContact,proto3" json:"securityContact,omitempty"`
+}
+
+func (x *CandidateProfile) Reset() {
+	*x = CandidateProfile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_staking_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CandidateProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CandidateProfile) ProtoMessage() {}
+
+func (x *CandidateProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_staking_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CandidateProfile.ProtoReflect.Descriptor instead.
+func (*CandidateProfile) Descriptor() ([]byte, []int) {
+	return file_staking_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CandidateProfile) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CandidateProfile) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CandidateProfile) GetIconHash() []byte {
+	if x != nil {
+		return x.IconHash
+	}
+	return nil
+}
+
+func (x *CandidateProfile) GetSecurityContact() string {
+	if x != nil {
+		return x.SecurityContact
+	}
+	return ""
+}
+
 var File_staking_proto protoreflect.FileDescriptor
 
 var file_staking_proto_rawDesc = []byte{
@@ -593,7 +1169,7 @@ var file_staking_proto_rawDesc = []byte{
 	0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x29, 0x0a, 0x0d, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74,
 	0x49, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e, 0x64, 0x69, 0x63,
 	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x04, 0x52, 0x07, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65,
-	0x73, 0x22, 0xa5, 0x02, 0x0a, 0x09, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x12,
+	0x73, 0x22, 0xbb, 0x04, 0x0a, 0x09, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x12,
 	0x22, 0x0a, 0x0c, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72,
 	0x65, 0x73, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x41,
@@ -611,30 +1187,111 @@ var file_staking_proto_rawDesc = []byte{
 	0x52, 0x09, 0x73, 0x65, 0x6c, 0x66, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x12, 0x2c, 0x0a, 0x11, 0x69,
 	0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
 	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69,
-	0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x42, 0x0a, 0x0a, 0x43, 0x61, 0x6e,
-	0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69,
-	0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x74,
-	0x61, 0x6b, 0x69, 0x6e, 0x67, 0x70, 0x62, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74,
-	0x65, 0x52, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x22, 0x3b, 0x0a,
-	0x0b, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06,
-	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x6d,
-	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x62, 0x0a, 0x0a, 0x42, 0x75,
-	0x63, 0x6b, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75,
-	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
-	0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b,
-	0x61, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x0b, 0x61, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x31,
-	0x0a, 0x0b, 0x45, 0x6e, 0x64, 0x6f, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x22, 0x0a,
-	0x0c, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x0c, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x48, 0x65, 0x69, 0x67, 0x68,
-	0x74, 0x42, 0x46, 0x5a, 0x44, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x69, 0x6f, 0x74, 0x65, 0x78, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x2f, 0x69, 0x6f, 0x74,
-	0x65, 0x78, 0x2d, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2f, 0x73, 0x74, 0x61, 0x6b, 0x69, 0x6e, 0x67, 0x2f,
-	0x73, 0x74, 0x61, 0x6b, 0x69, 0x6e, 0x67, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x38, 0x0a, 0x0b, 0x70, 0x61, 0x79,
+	0x6f, 0x75, 0x74, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x73, 0x74, 0x61, 0x6b, 0x69, 0x6e, 0x67, 0x70, 0x62, 0x2e, 0x50, 0x61, 0x79, 0x6f, 0x75,
+	0x74, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x0b, 0x70, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x53, 0x70,
+	0x6c, 0x69, 0x74, 0x12, 0x40, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x79, 0x6f, 0x75,
+	0x74, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73,
+	0x74, 0x61, 0x6b, 0x69, 0x6e, 0x67, 0x70, 0x62, 0x2e, 0x50, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x53,
+	0x68, 0x61, 0x72, 0x65, 0x52, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x79, 0x6f, 0x75, 0x74,
+	0x53, 0x70, 0x6c, 0x69, 0x74, 0x12, 0x32, 0x0a, 0x14, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x79,
+	0x6f, 0x75, 0x74, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x14, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x53,
+	0x70, 0x6c, 0x69, 0x74, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x2c, 0x0a, 0x11, 0x6e, 0x65, 0x78,
+	0x74, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x6e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x36, 0x0a, 0x16, 0x6e, 0x65, 0x78, 0x74, 0x52,
+	0x65, 0x77, 0x61, 0x72, 0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x45, 0x70, 0x6f, 0x63,
+	0x68, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x04, 0x52, 0x16, 0x6e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x77,
+	0x61, 0x72, 0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x22,
+	0x42, 0x0a, 0x0a, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x34, 0x0a,
+	0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x73, 0x74, 0x61, 0x6b, 0x69, 0x6e, 0x67, 0x70, 0x62, 0x2e, 0x43, 0x61,
+	0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61,
+	0x74, 0x65, 0x73, 0x22, 0x3b, 0x0a, 0x0b, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x41, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x22, 0x62, 0x0a, 0x0a, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x61, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74,
+	0x65, 0x64, 0x41, 0x74, 0x22, 0x31, 0x0a, 0x0b, 0x45, 0x6e, 0x64, 0x6f, 0x72, 0x73, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x48, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x65, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0xa0, 0x01, 0x0a, 0x10, 0x43, 0x61, 0x6e, 0x64,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x6c, 0x66, 0x53, 0x74,
+	0x61, 0x6b, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x6c, 0x66, 0x53,
+	0x74, 0x61, 0x6b, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x22, 0x4e, 0x0a, 0x0f, 0x45, 0x70,
+	0x6f, 0x63, 0x68, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x3b, 0x0a,
+	0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x74, 0x61, 0x6b, 0x69, 0x6e, 0x67, 0x70, 0x62, 0x2e, 0x43, 0x61,
+	0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x0a,
+	0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x22, 0x49, 0x0a, 0x0b, 0x50, 0x61,
+	0x79, 0x6f, 0x75, 0x74, 0x53, 0x68, 0x61, 0x72, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x62, 0x61, 0x73, 0x69, 0x73, 0x50, 0x6f, 0x69, 0x6e,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x62, 0x61, 0x73, 0x69, 0x73, 0x50,
+	0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22, 0x6a, 0x0a, 0x12, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x4c, 0x6f, 0x63, 0x6b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x65,
+	0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0e, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x45, 0x70,
+	0x6f, 0x63, 0x68, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x02, 0x6f, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x22, 0x8b, 0x01, 0x0a, 0x0c, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4c, 0x6f,
+	0x63, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6c,
+	0x6c, 0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61,
+	0x6c, 0x6c, 0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x45, 0x0a, 0x0e, 0x70, 0x65, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x73, 0x74, 0x61, 0x6b, 0x69, 0x6e, 0x67, 0x70, 0x62, 0x2e, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x66, 0x65, 0x72, 0x4c, 0x6f, 0x63, 0x6b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52,
+	0x0e, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22,
+	0x81, 0x02, 0x0a, 0x0b, 0x4d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x12,
+	0x1c, 0x0a, 0x09, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x12, 0x22, 0x0a,
+	0x0c, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0c, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x73, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x62,
+	0x6f, 0x75, 0x6e, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x6f, 0x75,
+	0x6e, 0x74, 0x79, 0x12, 0x2e, 0x0a, 0x12, 0x70, 0x72, 0x6f, 0x62, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x45, 0x6e, 0x64, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x12, 0x70, 0x72, 0x6f, 0x62, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x64, 0x48, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x22, 0x34, 0x0a, 0x14, 0x4d, 0x69, 0x73, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69,
+	0x6f, 0x72, 0x50, 0x72, 0x6f, 0x62, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x65,
+	0x6e, 0x64, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
+	0x65, 0x6e, 0x64, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x8c, 0x01, 0x0a, 0x10, 0x43, 0x61,
+	0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c,
+	0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x63, 0x6f, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x69, 0x63, 0x6f, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x12, 0x28,
+	0x0a, 0x0f, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74,
+	0x79, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x42, 0x46, 0x5a, 0x44, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x6f, 0x74, 0x65, 0x78, 0x70, 0x72, 0x6f, 0x6a,
+	0x65, 0x63, 0x74, 0x2f, 0x69, 0x6f, 0x74, 0x65, 0x78, 0x2d, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2f, 0x73,
+	0x74, 0x61, 0x6b, 0x69, 0x6e, 0x67, 0x2f, 0x73, 0x74, 0x61, 0x6b, 0x69, 0x6e, 0x67, 0x70, 0x62,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -649,8 +1306,8 @@ func file_staking_proto_rawDescGZIP() []byte {
 	return file_staking_proto_rawDescData
 }
 
-var file_staking_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
-var file_staking_proto_goTypes = []interface{}{
+var file_staking_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_staking_proto_goTypes = []any{
 	(*Bucket)(nil),                // 0: stakingpb.Bucket
 	(*BucketIndices)(nil),         // 1: stakingpb.BucketIndices
 	(*Candidate)(nil),             // 2: stakingpb.Candidate
@@ -658,18 +1315,30 @@ var file_staking_proto_goTypes = []interface{}{
 	(*TotalAmount)(nil),           // 4: stakingpb.TotalAmount
 	(*BucketType)(nil),            // 5: stakingpb.BucketType
 	(*Endorsement)(nil),           // 6: stakingpb.Endorsement
-	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+	(*CandidateHistory)(nil),      // 7: stakingpb.CandidateHistory
+	(*EpochCandidates)(nil),       // 8: stakingpb.EpochCandidates
+	(*PayoutShare)(nil),           // 9: stakingpb.PayoutShare
+	(*TransferLockChange)(nil),    // 10: stakingpb.TransferLockChange
+	(*TransferLock)(nil),          // 11: stakingpb.TransferLock
+	(*Misbehavior)(nil),           // 12: stakingpb.Misbehavior
+	(*MisbehaviorProbation)(nil),  // 13: stakingpb.MisbehaviorProbation
+	(*CandidateProfile)(nil),      // 14: stakingpb.CandidateProfile
+	(*timestamppb.Timestamp)(nil), // 15: google.protobuf.Timestamp
 }
 var file_staking_proto_depIdxs = []int32{
-	7, // 0: stakingpb.Bucket.createTime:type_name -> google.protobuf.Timestamp
-	7, // 1: stakingpb.Bucket.stakeStartTime:type_name -> google.protobuf.Timestamp
-	7, // 2: stakingpb.Bucket.unstakeStartTime:type_name -> google.protobuf.Timestamp
-	2, // 3: stakingpb.Candidates.candidates:type_name -> stakingpb.Candidate
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	15, // 0: stakingpb.Bucket.createTime:type_name -> google.protobuf.Timestamp
+	15, // 1: stakingpb.Bucket.stakeStartTime:type_name -> google.protobuf.Timestamp
+	15, // 2: stakingpb.Bucket.unstakeStartTime:type_name -> google.protobuf.Timestamp
+	9,  // 3: stakingpb.Candidate.payoutSplit:type_name -> stakingpb.PayoutShare
+	9,  // 4: stakingpb.Candidate.nextPayoutSplit:type_name -> stakingpb.PayoutShare
+	2,  // 5: stakingpb.Candidates.candidates:type_name -> stakingpb.Candidate
+	7,  // 6: stakingpb.EpochCandidates.candidates:type_name -> stakingpb.CandidateHistory
+	10, // 7: stakingpb.TransferLock.pendingChanges:type_name -> stakingpb.TransferLockChange
+	8,  // [8:8] is the sub-list for method output_type
+	8,  // [8:8] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_staking_proto_init() }
@@ -678,7 +1347,7 @@ func file_staking_proto_init() {
 		return
 	}
 	if !protoimpl.UnsafeEnabled {
-		file_staking_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+		file_staking_proto_msgTypes[0].Exporter = func(v any, i int) any {
 			switch v := v.(*Bucket); i {
 			case 0:
 				return &v.state
@@ -690,7 +1359,7 @@ func file_staking_proto_init() {
 				return nil
 			}
 		}
-		file_staking_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+		file_staking_proto_msgTypes[1].Exporter = func(v any, i int) any {
 			switch v := v.(*BucketIndices); i {
 			case 0:
 				return &v.state
@@ -702,7 +1371,7 @@ func file_staking_proto_init() {
 				return nil
 			}
 		}
-		file_staking_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+		file_staking_proto_msgTypes[2].Exporter = func(v any, i int) any {
 			switch v := v.(*Candidate); i {
 			case 0:
 				return &v.state
@@ -714,7 +1383,7 @@ func file_staking_proto_init() {
 				return nil
 			}
 		}
-		file_staking_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+		file_staking_proto_msgTypes[3].Exporter = func(v any, i int) any {
 			switch v := v.(*Candidates); i {
 			case 0:
 				return &v.state
@@ -726,7 +1395,7 @@ func file_staking_proto_init() {
 				return nil
 			}
 		}
-		file_staking_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_staking_proto_msgTypes[4].Exporter = func(v any, i int) any {
 			switch v := v.(*TotalAmount); i {
 			case 0:
 				return &v.state
@@ -738,7 +1407,7 @@ func file_staking_proto_init() {
 				return nil
 			}
 		}
-		file_staking_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_staking_proto_msgTypes[5].Exporter = func(v any, i int) any {
 			switch v := v.(*BucketType); i {
 			case 0:
 				return &v.state
@@ -750,7 +1419,7 @@ func file_staking_proto_init() {
 				return nil
 			}
 		}
-		file_staking_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_staking_proto_msgTypes[6].Exporter = func(v any, i int) any {
 			switch v := v.(*Endorsement); i {
 			case 0:
 				return &v.state
@@ -762,6 +1431,102 @@ func file_staking_proto_init() {
 				return nil
 			}
 		}
+		file_staking_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*CandidateHistory); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_staking_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*EpochCandidates); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_staking_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*PayoutShare); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_staking_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*TransferLockChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_staking_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*TransferLock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_staking_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*Misbehavior); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_staking_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*MisbehaviorProbation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_staking_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*CandidateProfile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -769,7 +1534,7 @@ func file_staking_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_staking_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,190 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/staking/stakingpb"
+)
+
+type (
+	// TransferLock is the stake transfer lock of an account. While locked, the buckets and the candidate owned by the
+	// account can only be transferred to the addresses in the allowlist
+	TransferLock struct {
+		Locked    bool
+		Allowlist []address.Address
+		// PendingChanges are the changes waiting to take effect, in the order of the effective epochs
+		PendingChanges []*TransferLockChange
+	}
+
+	// TransferLockChange is a change of the transfer lock waiting to take effect
+	TransferLockChange struct {
+		EffectiveEpoch uint64
+		Op             action.StakeTransferLockOp
+		Addresses      []address.Address
+	}
+)
+
+// Allows returns true if the transfers to the destination are allowed
+func (tl *TransferLock) Allows(dest address.Address) bool {
+	return !tl.Locked || tl.allowlistIndex(dest) >= 0
+}
+
+// IsEmpty returns true if the transfer lock is the same as not set
+func (tl *TransferLock) IsEmpty() bool {
+	return !tl.Locked && len(tl.Allowlist) == 0 && len(tl.PendingChanges) == 0
+}
+
+// Settle applies the pending changes taking effect by the epoch. Once unlocked, the rest of the pending changes
+// take effect immediately, as the delay only protects a lock
+func (tl *TransferLock) Settle(epoch uint64) {
+	i := 0
+	for ; i < len(tl.PendingChanges); i++ {
+		change := tl.PendingChanges[i]
+		if tl.Locked && change.EffectiveEpoch > epoch {
+			break
+		}
+		tl.apply(change.Op, change.Addresses)
+	}
+	tl.PendingChanges = tl.PendingChanges[i:]
+}
+
+// Change changes the transfer lock in the epoch and returns the epoch the change takes effect. A lock takes effect
+// immediately and cancels the pending changes loosening the lock. While locked, the other changes wait for the
+// delay, including adding addresses to the allowlist, so that a compromised key cannot transfer the stake right away
+func (tl *TransferLock) Change(op action.StakeTransferLockOp, addrs []address.Address, epoch, delay uint64) uint64 {
+	tl.Settle(epoch)
+	switch {
+	case op == action.StakeTransferLockOpLock:
+		pending := tl.PendingChanges[:0]
+		for _, change := range tl.PendingChanges {
+			if change.Op == action.StakeTransferLockOpDisallow {
+				pending = append(pending, change)
+			}
+		}
+		tl.PendingChanges = pending
+	case tl.Locked:
+		tl.PendingChanges = append(tl.PendingChanges, &TransferLockChange{
+			EffectiveEpoch: epoch + delay,
+			Op:             op,
+			Addresses:      addrs,
+		})
+		return epoch + delay
+	}
+	tl.apply(op, addrs)
+	return epoch
+}
+
+func (tl *TransferLock) apply(op action.StakeTransferLockOp, addrs []address.Address) {
+	switch op {
+	case action.StakeTransferLockOpLock:
+		tl.Locked = true
+	case action.StakeTransferLockOpUnlock:
+		tl.Locked = false
+	case action.StakeTransferLockOpAllow:
+		for _, addr := range addrs {
+			if tl.allowlistIndex(addr) < 0 {
+				tl.Allowlist = append(tl.Allowlist, addr)
+			}
+		}
+	case action.StakeTransferLockOpDisallow:
+		for _, addr := range addrs {
+			if i := tl.allowlistIndex(addr); i >= 0 {
+				tl.Allowlist = append(tl.Allowlist[:i:i], tl.Allowlist[i+1:]...)
+			}
+		}
+	}
+}
+
+func (tl *TransferLock) allowlistIndex(addr address.Address) int {
+	for i := range tl.Allowlist {
+		if address.Equal(tl.Allowlist[i], addr) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Serialize serializes transfer lock to bytes
+func (tl *TransferLock) Serialize() ([]byte, error) {
+	return proto.Marshal(tl.toProto())
+}
+
+// Deserialize deserializes bytes to transfer lock
+func (tl *TransferLock) Deserialize(buf []byte) error {
+	pb := &stakingpb.TransferLock{}
+	if err := proto.Unmarshal(buf, pb); err != nil {
+		return errors.Wrap(err, "failed to unmarshal transfer lock")
+	}
+	return tl.fromProto(pb)
+}
+
+func (tl *TransferLock) toProto() *stakingpb.TransferLock {
+	pb := &stakingpb.TransferLock{
+		Locked:    tl.Locked,
+		Allowlist: addressesToStrings(tl.Allowlist),
+	}
+	for _, change := range tl.PendingChanges {
+		pb.PendingChanges = append(pb.PendingChanges, &stakingpb.TransferLockChange{
+			EffectiveEpoch: change.EffectiveEpoch,
+			Op:             uint32(change.Op),
+			Addresses:      addressesToStrings(change.Addresses),
+		})
+	}
+	return pb
+}
+
+func (tl *TransferLock) fromProto(pb *stakingpb.TransferLock) error {
+	allowlist, err := stringsToAddresses(pb.GetAllowlist())
+	if err != nil {
+		return err
+	}
+	tl.Locked = pb.GetLocked()
+	tl.Allowlist = allowlist
+	tl.PendingChanges = nil
+	for _, change := range pb.GetPendingChanges() {
+		addrs, err := stringsToAddresses(change.GetAddresses())
+		if err != nil {
+			return err
+		}
+		tl.PendingChanges = append(tl.PendingChanges, &TransferLockChange{
+			EffectiveEpoch: change.GetEffectiveEpoch(),
+			Op:             action.StakeTransferLockOp(change.GetOp()),
+			Addresses:      addrs,
+		})
+	}
+	return nil
+}
+
+func addressesToStrings(addrs []address.Address) []string {
+	if len(addrs) == 0 {
+		return nil
+	}
+	strs := make([]string, len(addrs))
+	for i := range addrs {
+		strs[i] = addrs[i].String()
+	}
+	return strs
+}
+
+func stringsToAddresses(strs []string) ([]address.Address, error) {
+	if len(strs) == 0 {
+		return nil, nil
+	}
+	addrs := make([]address.Address, len(strs))
+	for i := range strs {
+		addr, err := address.FromString(strs[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse address %s", strs[i])
+		}
+		addrs[i] = addr
+	}
+	return addrs, nil
+}
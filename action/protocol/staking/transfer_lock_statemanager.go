@@ -0,0 +1,84 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+type (
+	// TransferLockStateManager defines the interface of transfer lock state manager
+	TransferLockStateManager struct {
+		protocol.StateManager
+		*TransferLockStateReader
+	}
+	// TransferLockStateReader defines the interface of transfer lock state reader
+	TransferLockStateReader struct {
+		protocol.StateReader
+	}
+)
+
+// NewTransferLockStateManager creates a new transfer lock state manager
+func NewTransferLockStateManager(sm protocol.StateManager) *TransferLockStateManager {
+	return &TransferLockStateManager{
+		StateManager:            sm,
+		TransferLockStateReader: NewTransferLockStateReader(sm),
+	}
+}
+
+// Put puts the transfer lock of an account, the lock is deleted if it is empty
+func (tlsm *TransferLockStateManager) Put(owner address.Address, tl *TransferLock) error {
+	if tl.IsEmpty() {
+		switch _, err := tlsm.Get(owner); errors.Cause(err) {
+		case nil:
+		case state.ErrStateNotExist:
+			return nil
+		default:
+			return err
+		}
+		_, err := tlsm.DelState(protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(transferLockKey(owner)))
+		return err
+	}
+	_, err := tlsm.PutState(tl, protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(transferLockKey(owner)))
+	return err
+}
+
+// NewTransferLockStateReader creates a new transfer lock state reader
+func NewTransferLockStateReader(sr protocol.StateReader) *TransferLockStateReader {
+	return &TransferLockStateReader{StateReader: sr}
+}
+
+// Get gets the transfer lock of an account
+func (tlsr *TransferLockStateReader) Get(owner address.Address) (*TransferLock, error) {
+	value := TransferLock{}
+	if _, err := tlsr.State(&value, protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(transferLockKey(owner))); err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// Effective returns the transfer lock of an account in effect at the epoch
+// If the transfer lock does not exist, it returns an empty lock
+func (tlsr *TransferLockStateReader) Effective(owner address.Address, epoch uint64) (*TransferLock, error) {
+	tl, err := tlsr.Get(owner)
+	switch errors.Cause(err) {
+	case nil:
+		tl.Settle(epoch)
+	case state.ErrStateNotExist:
+		tl, err = &TransferLock{}, nil
+	default:
+	}
+	return tl, err
+}
+
+func transferLockKey(owner address.Address) []byte {
+	key := []byte{_transferLock}
+	return append(key, owner.Bytes()...)
+}
@@ -82,6 +82,12 @@ func (p *Protocol) validateCandidateUpdate(ctx context.Context, act *action.Cand
 			return action.ErrInvalidCanName
 		}
 	}
+	if len(act.PayoutSplit()) > 0 {
+		if !protocol.IsParameterEnabled(ctx, p, _paramPayoutSplit) {
+			return errors.Wrap(action.ErrInvalidAct, "payout split is disabled")
+		}
+		return action.ValidatePayoutSplit(act.PayoutSplit())
+	}
 	return nil
 }
 
@@ -117,3 +123,25 @@ func (p *Protocol) validateMigrateStake(ctx context.Context, act *action.Migrate
 	}
 	return nil
 }
+
+func (p *Protocol) validateStakeTransferLock(ctx context.Context, act *action.StakeTransferLock) error {
+	if !protocol.IsParameterEnabled(ctx, p, _paramStakeTransferLock) {
+		return errors.Wrap(action.ErrInvalidAct, "stake transfer lock is disabled")
+	}
+	return nil
+}
+
+func (p *Protocol) validateReportMisbehavior(ctx context.Context, act *action.ReportMisbehavior) error {
+	if !protocol.IsParameterEnabled(ctx, p, _paramMisbehaviorReport) {
+		return errors.Wrap(action.ErrInvalidAct, "misbehavior report is disabled")
+	}
+	_, err := verifyMisbehavior(act)
+	return err
+}
+
+func (p *Protocol) validateUpdateCandidateProfile(ctx context.Context, act *action.UpdateCandidateProfile) error {
+	if !protocol.IsParameterEnabled(ctx, p, _paramCandidateProfile) {
+		return errors.Wrap(action.ErrInvalidAct, "candidate profile is disabled")
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+)
+
+// VerifyView verifies the candidate center and the bucket pool in the view against the candidates and the buckets in
+// the states, and returns the view rebuilt from the states
+func (p *Protocol) VerifyView(ctx context.Context, sr protocol.StateReader, v interface{}) (interface{}, error) {
+	view, ok := v.(*ViewData)
+	if !ok {
+		return nil, errors.Wrap(ErrTypeAssertion, "expecting *ViewData")
+	}
+	height, err := sr.Height()
+	if err != nil {
+		return nil, err
+	}
+	rebuilt, err := p.createView(ctx, sr, true)
+	if err != nil {
+		return nil, err
+	}
+	if diff := diffViewData(view, rebuilt); len(diff) > 0 {
+		return rebuilt, errors.Wrapf(protocol.ErrViewMismatch, "staking view at height %d: %s", height, strings.Join(diff, "; "))
+	}
+	return rebuilt, nil
+}
+
+// diffViewData returns the differences of the view from the expected one, the name/operator maps of the candidate
+// center are not compared, which are not built from the candidates
+func diffViewData(view, expected *ViewData) []string {
+	var diff []string
+	if view.bucketPool.Count() != expected.bucketPool.Count() {
+		diff = append(diff, fmt.Sprintf("bucket pool has %d buckets, expecting %d", view.bucketPool.Count(), expected.bucketPool.Count()))
+	}
+	if view.bucketPool.Total().Cmp(expected.bucketPool.Total()) != 0 {
+		diff = append(diff, fmt.Sprintf("bucket pool has %s staked, expecting %s", view.bucketPool.Total(), expected.bucketPool.Total()))
+	}
+	cands := make(map[string]*Candidate)
+	for _, d := range view.candCenter.All() {
+		cands[d.GetIdentifier().String()] = d
+	}
+	var candDiff []string
+	for _, d := range expected.candCenter.All() {
+		id := d.GetIdentifier().String()
+		c, ok := cands[id]
+		switch {
+		case !ok:
+			candDiff = append(candDiff, fmt.Sprintf("candidate %s is missing", id))
+		case !c.Equal(d):
+			candDiff = append(candDiff, fmt.Sprintf("candidate %s differs with %d votes and %d self-stake, expecting %d and %d",
+				id, c.Votes, c.SelfStake, d.Votes, d.SelfStake))
+		}
+		delete(cands, id)
+	}
+	for id := range cands {
+		candDiff = append(candDiff, fmt.Sprintf("candidate %s is not in states", id))
+	}
+	// the candidates are in a map
+	sort.Strings(candDiff)
+	return append(diff, candDiff...)
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil/testdb"
+)
+
+func TestVerifyView(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+	_, err := sm.PutState(
+		&totalBucketCount{count: 0},
+		protocol.NamespaceOption(_stakingNameSpace),
+		protocol.KeyOption(TotalBucketKey),
+	)
+	r.NoError(err)
+	g := genesis.Default
+	// the total of the bucket pool is read from the states
+	g.GreenlandBlockHeight = 0
+	ctx := protocol.WithFeatureWithHeightCtx(genesis.WithGenesisContext(context.Background(), g))
+	ctx = protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: 1}))
+	stk, err := NewProtocol(HelperCtx{
+		DepositGas:    nil,
+		BlockInterval: getBlockInterval,
+	}, &BuilderConfig{
+		Staking:                  genesis.Default.Staking,
+		PersistStakingPatchBlock: math.MaxUint64,
+		Revise: ReviseConfig{
+			VoteWeight: genesis.Default.Staking.VoteWeightCalConsts,
+		},
+	}, nil, nil, nil)
+	r.NoError(err)
+	reg := protocol.NewRegistry()
+	r.NoError(stk.Register(reg))
+
+	// 2 buckets of a candidate, and their total in the bucket pool
+	csmTemp := newCandidateStateManager(sm)
+	total := big.NewInt(0)
+	for i, amount := range []int64{1200000, 3400000} {
+		vb := NewVoteBucket(identityset.Address(1), identityset.Address(2), big.NewInt(amount), 21, time.Now(), true)
+		index, err := csmTemp.putBucketAndIndex(vb)
+		r.NoError(err)
+		r.Equal(uint64(i), index)
+		total.Add(total, vb.StakedAmount)
+	}
+	_, err = sm.PutState(&totalAmount{amount: total, count: 2}, protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(_bucketPoolAddrKey))
+	r.NoError(err)
+	v, err := stk.Start(ctx, sm)
+	r.NoError(err)
+	r.NoError(sm.WriteView(_protocolID, v))
+	csm, err := NewCandidateStateManager(sm, true)
+	r.NoError(err)
+	cand := &Candidate{
+		Owner:              identityset.Address(1),
+		Operator:           identityset.Address(3),
+		Reward:             identityset.Address(4),
+		Name:               "test",
+		Votes:              big.NewInt(4600000),
+		SelfStakeBucketIdx: 0,
+		SelfStake:          big.NewInt(1200000),
+	}
+	r.NoError(csm.Upsert(cand))
+	r.NoError(csm.Commit(ctx))
+
+	view, err := reg.StartAll(ctx, sm)
+	r.NoError(err)
+	r.NoError(reg.VerifyAll(ctx, sm, view, false))
+
+	t.Run("corrupted bucket pool", func(t *testing.T) {
+		// one bucket is flipped out of the stored total, which the view is loaded from
+		_, err = sm.PutState(&totalAmount{amount: big.NewInt(3400000), count: 1}, protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(_bucketPoolAddrKey))
+		r.NoError(err)
+		view, err := reg.StartAll(ctx, sm)
+		r.NoError(err)
+		err = reg.VerifyAll(ctx, sm, view, false)
+		r.ErrorIs(err, protocol.ErrViewMismatch)
+		r.Contains(err.Error(), "bucket pool has 1 buckets, expecting 2")
+		r.Contains(err.Error(), "bucket pool has 3400000 staked, expecting 4600000")
+		v, err := view.Read(_protocolID)
+		r.NoError(err)
+		r.Equal(uint64(1), v.(*ViewData).bucketPool.Count())
+
+		r.NoError(reg.VerifyAll(ctx, sm, view, true))
+		v, err = view.Read(_protocolID)
+		r.NoError(err)
+		r.Equal(uint64(2), v.(*ViewData).bucketPool.Count())
+		r.Equal(total, v.(*ViewData).bucketPool.Total())
+		r.True(v.(*ViewData).bucketPool.enableSMStorage)
+		r.NoError(reg.VerifyAll(ctx, sm, view, false))
+	})
+	t.Run("drifted candidate", func(t *testing.T) {
+		v, err := stk.createView(ctx, sm, true)
+		r.NoError(err)
+		d := cand.Clone()
+		d.Votes = big.NewInt(1)
+		r.NoError(v.candCenter.Upsert(d))
+		_, err = stk.VerifyView(ctx, sm, v)
+		r.ErrorIs(err, protocol.ErrViewMismatch)
+		r.Contains(err.Error(), "candidate "+identityset.Address(1).String()+" differs with 1 votes")
+
+		v, err = stk.createView(ctx, sm, true)
+		r.NoError(err)
+		d = cand.Clone()
+		d.Owner, d.Identifier, d.Name = identityset.Address(5), identityset.Address(5), "extra"
+		d.Operator = identityset.Address(6)
+		d.SelfStakeBucketIdx = candidateNoSelfStakeBucketIndex
+		r.NoError(v.candCenter.Upsert(d))
+		_, err = stk.VerifyView(ctx, sm, v)
+		r.ErrorIs(err, protocol.ErrViewMismatch)
+		r.Contains(err.Error(), "candidate "+identityset.Address(5).String()+" is not in states")
+	})
+}
@@ -196,6 +196,29 @@ func (vb *VoteBucket) isNative() bool {
 	return vb.ContractAddress == ""
 }
 
+// stakedDays returns the staked duration in days, a partial day counts as a whole day
+func (vb *VoteBucket) stakedDays() float64 {
+	return math.Ceil(vb.StakedDuration.Seconds() / 86400)
+}
+
+// maturity returns the time the staked duration of a native bucket ends, and false if the bucket never matures, i.e.
+// the bucket is auto-staked or unstaked. The duration is counted in whole days as in the vote weight
+func (vb *VoteBucket) maturity() (time.Time, bool) {
+	if !vb.isNative() || vb.AutoStake || vb.isUnstaked() {
+		return time.Time{}, false
+	}
+	return vb.StakeStartTime.Add(time.Duration(vb.stakedDays()) * 24 * time.Hour), true
+}
+
+// maturityHeight returns the height the staked duration of a contract staking bucket ends, and false if the bucket
+// never matures, i.e. the bucket is locked or unstaked
+func (vb *VoteBucket) maturityHeight() (uint64, bool) {
+	if vb.isNative() || vb.AutoStake || vb.isUnstaked() {
+		return 0, false
+	}
+	return vb.StakeStartBlockHeight + vb.StakedDurationBlockNumber, true
+}
+
 // Deserialize deserializes bytes into bucket count
 func (tc *totalBucketCount) Deserialize(data []byte) error {
 	tc.count = byteutil.BytesToUint64BigEndian(data)
@@ -219,14 +242,13 @@ func bucketKey(index uint64) []byte {
 
 // CalculateVoteWeight calculates the vote weight
 func CalculateVoteWeight(c genesis.VoteWeightCalConsts, v *VoteBucket, selfStake bool) *big.Int {
-	remainingTime := v.StakedDuration.Seconds()
 	weight := float64(1)
 	var m float64
 	if v.AutoStake {
 		m = c.AutoStake
 	}
-	if remainingTime > 0 {
-		weight += math.Log(math.Ceil(remainingTime/86400)*(1+m)) / math.Log(c.DurationLg) / 100
+	if days := v.stakedDays(); days > 0 {
+		weight += math.Log(days*(1+m)) / math.Log(c.DurationLg) / 100
 	}
 	if selfStake && v.AutoStake && v.StakedDuration >= time.Duration(91)*24*time.Hour {
 		// self-stake extra bonus requires enable auto-stake for at least 3 months
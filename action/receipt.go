@@ -13,6 +13,7 @@ import (
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
+	"github.com/iotexproject/iotex-core/action/actionpb"
 	"github.com/iotexproject/iotex-core/pkg/log"
 )
 
@@ -22,15 +23,28 @@ type (
 
 	// Receipt represents the result of a contract
 	Receipt struct {
-		Status             uint64
-		BlockHeight        uint64
-		ActionHash         hash.Hash256
-		GasConsumed        uint64
-		ContractAddress    string
-		TxIndex            uint32
-		logs               []*Log
-		transactionLogs    []*TransactionLog
-		executionRevertMsg string
+		Status              uint64
+		BlockHeight         uint64
+		ActionHash          hash.Hash256
+		GasConsumed         uint64
+		ContractAddress     string
+		TxIndex             uint32
+		logs                []*Log
+		transactionLogs     []*TransactionLog
+		executionRevertMsg  string
+		gasPayer            string
+		systemGas           uint64
+		createdContracts    []*ContractChange
+		destructedContracts []*ContractChange
+	}
+
+	// ContractChange is a contract created or self-destructed in an execution, where counterparty is the creator of
+	// the contract, or the beneficiary of the self-destruct, and depth is the depth of the call, 0 for the execution
+	// itself
+	ContractChange struct {
+		Address      string
+		Counterparty string
+		Depth        uint32
 	}
 
 	// Log stores an evm contract event
@@ -55,6 +69,20 @@ type (
 
 // ConvertToReceiptPb converts a Receipt to protobuf's Receipt
 func (receipt *Receipt) ConvertToReceiptPb() *iotextypes.Receipt {
+	r := receipt.hashedReceiptPb()
+	ext := actionpb.ReceiptExt{}
+	for _, c := range receipt.createdContracts {
+		ext.CreatedContracts = append(ext.CreatedContracts, c.toProto())
+	}
+	for _, c := range receipt.destructedContracts {
+		ext.DestructedContracts = append(ext.DestructedContracts, c.toProto())
+	}
+	MustAppendUnknownFields(r, &ext)
+	return r
+}
+
+// hashedReceiptPb converts the fields of Receipt covered by the hash to protobuf's Receipt
+func (receipt *Receipt) hashedReceiptPb() *iotextypes.Receipt {
 	r := &iotextypes.Receipt{}
 	r.Status = receipt.Status
 	r.BlkHeight = receipt.BlockHeight
@@ -69,6 +97,10 @@ func (receipt *Receipt) ConvertToReceiptPb() *iotextypes.Receipt {
 	if receipt.executionRevertMsg != "" {
 		r.ExecutionRevertMsg = receipt.executionRevertMsg
 	}
+	MustAppendUnknownFields(r, &actionpb.ReceiptExt{
+		GasPayer:  receipt.gasPayer,
+		SystemGas: receipt.systemGas,
+	})
 	return r
 }
 
@@ -87,6 +119,22 @@ func (receipt *Receipt) ConvertFromReceiptPb(pbReceipt *iotextypes.Receipt) {
 		receipt.logs[i].ConvertFromLogPb(log)
 	}
 	receipt.executionRevertMsg = pbReceipt.GetExecutionRevertMsg()
+	receipt.gasPayer = ""
+	receipt.systemGas = 0
+	receipt.createdContracts = nil
+	receipt.destructedContracts = nil
+	ext := actionpb.ReceiptExt{}
+	if err := LoadUnknownFields(pbReceipt, &ext); err != nil {
+		return
+	}
+	receipt.gasPayer = ext.GetGasPayer()
+	receipt.systemGas = ext.GetSystemGas()
+	for _, c := range ext.GetCreatedContracts() {
+		receipt.createdContracts = append(receipt.createdContracts, contractChangeFromProto(c))
+	}
+	for _, c := range ext.GetDestructedContracts() {
+		receipt.destructedContracts = append(receipt.destructedContracts, contractChangeFromProto(c))
+	}
 }
 
 // Serialize returns a serialized byte stream for the Receipt
@@ -104,9 +152,9 @@ func (receipt *Receipt) Deserialize(buf []byte) error {
 	return nil
 }
 
-// Hash returns the hash of receipt
+// Hash returns the hash of receipt, which doesn't cover the contracts created and self-destructed
 func (receipt *Receipt) Hash() hash.Hash256 {
-	data, err := receipt.Serialize()
+	data, err := proto.Marshal(receipt.hashedReceiptPb())
 	if err != nil {
 		log.L().Panic("Error when serializing a receipt")
 	}
@@ -156,6 +204,46 @@ func (receipt *Receipt) SetExecutionRevertMsg(revertReason string) *Receipt {
 	return receipt
 }
 
+// GasPayer returns the address which paid the gas, empty if the gas was paid by the sender
+func (receipt *Receipt) GasPayer() string {
+	return receipt.gasPayer
+}
+
+// SetGasPayer sets the address which paid the gas to receipt.
+func (receipt *Receipt) SetGasPayer(payer string) *Receipt {
+	receipt.gasPayer = payer
+	return receipt
+}
+
+// SystemGas returns the gas of the system call initiated by the protocol handling the action, 0 if the action
+// didn't initiate a system call
+func (receipt *Receipt) SystemGas() uint64 {
+	return receipt.systemGas
+}
+
+// SetSystemGas sets the gas of the system call, which tags the receipt as system-initiated.
+func (receipt *Receipt) SetSystemGas(gas uint64) *Receipt {
+	receipt.systemGas = gas
+	return receipt
+}
+
+// CreatedContracts returns the contracts created in the execution, including the ones created by contracts
+func (receipt *Receipt) CreatedContracts() []*ContractChange {
+	return receipt.createdContracts
+}
+
+// DestructedContracts returns the contracts self-destructed in the execution
+func (receipt *Receipt) DestructedContracts() []*ContractChange {
+	return receipt.destructedContracts
+}
+
+// SetContractChanges sets the contracts created and self-destructed in the execution to receipt.
+func (receipt *Receipt) SetContractChanges(created, destructed []*ContractChange) *Receipt {
+	receipt.createdContracts = created
+	receipt.destructedContracts = destructed
+	return receipt
+}
+
 // UpdateIndex updates the index of receipt and logs, and returns the next log index
 func (receipt *Receipt) UpdateIndex(txIndex, logIndex uint32) uint32 {
 	receipt.TxIndex = txIndex
@@ -167,6 +255,22 @@ func (receipt *Receipt) UpdateIndex(txIndex, logIndex uint32) uint32 {
 	return logIndex
 }
 
+func (c *ContractChange) toProto() *actionpb.ContractChange {
+	return &actionpb.ContractChange{
+		Address:      c.Address,
+		Counterparty: c.Counterparty,
+		Depth:        c.Depth,
+	}
+}
+
+func contractChangeFromProto(pb *actionpb.ContractChange) *ContractChange {
+	return &ContractChange{
+		Address:      pb.GetAddress(),
+		Counterparty: pb.GetCounterparty(),
+		Depth:        pb.GetDepth(),
+	}
+}
+
 // ConvertToLogPb converts a Log to protobuf's Log
 func (log *Log) ConvertToLogPb() *iotextypes.Log {
 	l := &iotextypes.Log{}
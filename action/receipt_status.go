@@ -0,0 +1,202 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action/actionpb"
+)
+
+// ReceiptStatus is the status of a receipt. It extends iotextypes.ReceiptStatus with the failure causes not defined in
+// iotex-proto yet, and the values of iotextypes.ReceiptStatus keep their meanings, so the stored receipts read the same
+type ReceiptStatus uint64
+
+// the receipt statuses not defined in iotex-proto, 1xx for evm, 2xx for staking, 3xx for rewarding and 4xx for account
+const (
+	// ReceiptStatusErrInvalidOpCode is an evm execution running into an undefined opcode
+	ReceiptStatusErrInvalidOpCode ReceiptStatus = 117
+	// ReceiptStatusErrStackUnderflow is an evm execution popping from a stack without enough items
+	ReceiptStatusErrStackUnderflow ReceiptStatus = 118
+	// ReceiptStatusErrStackOverflow is an evm execution pushing beyond the stack limit
+	ReceiptStatusErrStackOverflow ReceiptStatus = 119
+	// ReceiptStatusErrMaxInitCodeSizeExceeded is a contract creation with the init code beyond the size limit
+	ReceiptStatusErrMaxInitCodeSizeExceeded ReceiptStatus = 120
+	// ReceiptStatusErrNonceUintOverflow is a contract creation overflowing the nonce of the creator
+	ReceiptStatusErrNonceUintOverflow ReceiptStatus = 121
+
+	// ReceiptStatusErrMisbehaviorReported is a misbehavior report of a delegate already penalized at the height
+	ReceiptStatusErrMisbehaviorReported ReceiptStatus = 216
+
+	// ReceiptStatusErrRewardingInvalidAmount is a deposit or claim of a non-positive amount
+	ReceiptStatusErrRewardingInvalidAmount ReceiptStatus = 300
+	// ReceiptStatusErrRewardingFundNotEnough is a claim or grant beyond the balance of the rewarding fund
+	ReceiptStatusErrRewardingFundNotEnough ReceiptStatus = 301
+	// ReceiptStatusErrRewardNotEnough is a claim beyond the unclaimed reward of the account
+	ReceiptStatusErrRewardNotEnough ReceiptStatus = 302
+
+	// ReceiptStatusErrTransferToContract is a native transfer to a contract, which is not executed
+	ReceiptStatusErrTransferToContract ReceiptStatus = 400
+	// ReceiptStatusErrTimeLockUnlocked is a time-locked transfer whose unlock point has passed
+	ReceiptStatusErrTimeLockUnlocked ReceiptStatus = 401
+	// ReceiptStatusErrTooManyTimeLocks is a time-locked transfer to an account holding the max number of locks
+	ReceiptStatusErrTooManyTimeLocks ReceiptStatus = 402
+	// ReceiptStatusErrTimeLockAmount is a time-locked transfer of an amount less than the min amount
+	ReceiptStatusErrTimeLockAmount ReceiptStatus = 403
+)
+
+var (
+	_receiptStatusNames = map[ReceiptStatus]string{
+		ReceiptStatusErrInvalidOpCode:           "ErrInvalidOpCode",
+		ReceiptStatusErrStackUnderflow:          "ErrStackUnderflow",
+		ReceiptStatusErrStackOverflow:           "ErrStackOverflow",
+		ReceiptStatusErrMaxInitCodeSizeExceeded: "ErrMaxInitCodeSizeExceeded",
+		ReceiptStatusErrNonceUintOverflow:       "ErrNonceUintOverflow",
+		ReceiptStatusErrMisbehaviorReported:     "ErrMisbehaviorReported",
+		ReceiptStatusErrRewardingInvalidAmount:  "ErrRewardingInvalidAmount",
+		ReceiptStatusErrRewardingFundNotEnough:  "ErrRewardingFundNotEnough",
+		ReceiptStatusErrRewardNotEnough:         "ErrRewardNotEnough",
+		ReceiptStatusErrTransferToContract:      "ErrTransferToContract",
+		ReceiptStatusErrTimeLockUnlocked:        "ErrTimeLockUnlocked",
+		ReceiptStatusErrTooManyTimeLocks:        "ErrTooManyTimeLocks",
+		ReceiptStatusErrTimeLockAmount:          "ErrTimeLockAmount",
+	}
+
+	_receiptStatusMessages = map[ReceiptStatus]string{
+		ReceiptStatus(iotextypes.ReceiptStatus_Failure):                         "failed",
+		ReceiptStatus(iotextypes.ReceiptStatus_Success):                         "succeeded",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrUnknown):                      "failed by an unknown evm error",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrOutOfGas):                     "out of gas",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrCodeStoreOutOfGas):            "out of gas to store the contract code",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrDepth):                        "max call depth exceeded",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrContractAddressCollision):     "contract address collision",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrNoCompatibleInterpreter):      "no compatible interpreter",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrExecutionReverted):            "execution reverted",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrMaxCodeSizeExceeded):          "max code size exceeded",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrWriteProtection):              "write protection",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrInvalidSubroutineEntry):       "invalid subroutine entry",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrInsufficientBalance):          "insufficient balance for transfer",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrInvalidJump):                  "invalid jump destination",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrReturnDataOutOfBounds):        "return data out of bounds",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrGasUintOverflow):              "gas uint64 overflow",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrInvalidRetsub):                "invalid retsub",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrReturnStackExceeded):          "return stack limit reached",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrInvalidCode):                  "invalid code: must not begin with 0xef",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrLoadAccount):                  "failed to load account",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrNotEnoughBalance):             "not enough balance",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrInvalidBucketIndex):           "invalid bucket index",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrUnauthorizedOperator):         "unauthorized operator",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrInvalidBucketType):            "invalid bucket type",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrCandidateNotExist):            "candidate does not exist",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrReduceDurationBeforeMaturity): "reduce duration before maturity",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrUnstakeBeforeMaturity):        "unstake before maturity",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrWithdrawBeforeUnstake):        "withdraw before unstake",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrWithdrawBeforeMaturity):       "withdraw before maturity",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrCandidateAlreadyExist):        "candidate already exists",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrCandidateConflict):            "candidate conflicts with an existing one",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrInvalidBucketAmount):          "invalid bucket amount",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrWriteAccount):                 "failed to write account",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrWriteBucket):                  "failed to write bucket",
+		ReceiptStatus(iotextypes.ReceiptStatus_ErrWriteCandidate):               "failed to write candidate",
+		ReceiptStatusErrInvalidOpCode:                                           "invalid opcode",
+		ReceiptStatusErrStackUnderflow:                                          "stack underflow",
+		ReceiptStatusErrStackOverflow:                                           "stack overflow",
+		ReceiptStatusErrMaxInitCodeSizeExceeded:                                 "max initcode size exceeded",
+		ReceiptStatusErrNonceUintOverflow:                                       "nonce uint64 overflow",
+		ReceiptStatusErrMisbehaviorReported:                                     "misbehavior already reported",
+		ReceiptStatusErrRewardingInvalidAmount:                                  "invalid rewarding amount",
+		ReceiptStatusErrRewardingFundNotEnough:                                  "not enough balance in rewarding fund",
+		ReceiptStatusErrRewardNotEnough:                                         "not enough unclaimed reward",
+		ReceiptStatusErrTransferToContract:                                      "transfer to contract",
+		ReceiptStatusErrTimeLockUnlocked:                                        "time lock already unlocked",
+		ReceiptStatusErrTooManyTimeLocks:                                        "too many time locks",
+		ReceiptStatusErrTimeLockAmount:                                          "time lock amount less than the min",
+	}
+)
+
+type (
+	// ReceiptStatusError is an error failing an action with the status of its receipt
+	ReceiptStatusError struct {
+		status ReceiptStatus
+		err    error
+	}
+)
+
+// String returns the name of the status
+func (s ReceiptStatus) String() string {
+	if name, ok := _receiptStatusNames[s]; ok {
+		return name
+	}
+	if s <= math.MaxInt32 {
+		if name, ok := iotextypes.ReceiptStatus_name[int32(s)]; ok {
+			return name
+		}
+	}
+	return fmt.Sprintf("ReceiptStatus(%d)", uint64(s))
+}
+
+// Message returns the human readable message of the status
+func (s ReceiptStatus) Message() string {
+	if msg, ok := _receiptStatusMessages[s]; ok {
+		return msg
+	}
+	return fmt.Sprintf("failed with status %d", uint64(s))
+}
+
+// IsSuccess returns true if the status is success
+func (s ReceiptStatus) IsSuccess() bool {
+	return s == ReceiptStatus(iotextypes.ReceiptStatus_Success)
+}
+
+// EthStatus returns the status of the receipt in ethereum, which is 1 for success and 0 for any failure
+func (s ReceiptStatus) EthStatus() uint64 {
+	if s.IsSuccess() {
+		return 1
+	}
+	return 0
+}
+
+// NewReceiptStatusError returns an error failing an action with the status
+func NewReceiptStatusError(status ReceiptStatus, err error) *ReceiptStatusError {
+	return &ReceiptStatusError{status: status, err: err}
+}
+
+// Error returns the message of the error
+func (e *ReceiptStatusError) Error() string { return e.err.Error() }
+
+// Unwrap returns the error wrapped
+func (e *ReceiptStatusError) Unwrap() error { return e.err }
+
+// ReceiptStatus returns the status of the receipt failed by the error
+func (e *ReceiptStatusError) ReceiptStatus() uint64 { return uint64(e.status) }
+
+// ReceiptStatusOf returns the status carried by the error, or the status given if the error carries none
+func ReceiptStatusOf(err error, status ReceiptStatus) ReceiptStatus {
+	var e *ReceiptStatusError
+	if errors.As(err, &e) {
+		return e.status
+	}
+	return status
+}
+
+// SetReceiptStatusMessage sets the message of the status into the unknown fields of the receipt returned by the api
+func SetReceiptStatusMessage(r *iotextypes.Receipt) {
+	MustAppendUnknownFields(r, &actionpb.ReceiptExt{StatusMessage: ReceiptStatus(r.GetStatus()).Message()})
+}
+
+// ReceiptStatusMessage returns the message of the status carried by the receipt returned by the api, or the message
+// of the status if it carries none
+func ReceiptStatusMessage(r *iotextypes.Receipt) string {
+	ext := actionpb.ReceiptExt{}
+	if err := LoadUnknownFields(r, &ext); err == nil && ext.GetStatusMessage() != "" {
+		return ext.GetStatusMessage()
+	}
+	return ReceiptStatus(r.GetStatus()).Message()
+}
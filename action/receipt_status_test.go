@@ -0,0 +1,84 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"testing"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestReceiptStatus(t *testing.T) {
+	r := require.New(t)
+
+	t.Run("String", func(t *testing.T) {
+		r.Equal("Success", ReceiptStatus(iotextypes.ReceiptStatus_Success).String())
+		r.Equal("ErrExecutionReverted", ReceiptStatus(iotextypes.ReceiptStatus_ErrExecutionReverted).String())
+		r.Equal("ErrRewardNotEnough", ReceiptStatusErrRewardNotEnough.String())
+		r.Equal("ReceiptStatus(999)", ReceiptStatus(999).String())
+		r.Equal("ReceiptStatus(18446744073709551615)", ReceiptStatus(1<<64-1).String())
+	})
+
+	t.Run("Message", func(t *testing.T) {
+		for name, v := range iotextypes.ReceiptStatus_value {
+			_, ok := _receiptStatusMessages[ReceiptStatus(v)]
+			r.True(ok, "no message of %s", name)
+		}
+		for s := range _receiptStatusNames {
+			_, ok := _receiptStatusMessages[s]
+			r.True(ok, "no message of %s", s)
+			_, ok = iotextypes.ReceiptStatus_name[int32(s)]
+			r.False(ok, "%s is defined in iotex-proto", s)
+		}
+		r.Equal("execution reverted", ReceiptStatus(iotextypes.ReceiptStatus_ErrExecutionReverted).Message())
+		r.Equal("failed with status 999", ReceiptStatus(999).Message())
+	})
+
+	t.Run("EthStatus", func(t *testing.T) {
+		r.True(ReceiptStatus(iotextypes.ReceiptStatus_Success).IsSuccess())
+		r.Equal(uint64(1), ReceiptStatus(iotextypes.ReceiptStatus_Success).EthStatus())
+		for _, s := range []ReceiptStatus{
+			ReceiptStatus(iotextypes.ReceiptStatus_Failure),
+			ReceiptStatus(iotextypes.ReceiptStatus_ErrOutOfGas),
+			ReceiptStatusErrTransferToContract,
+		} {
+			r.False(s.IsSuccess())
+			r.Zero(s.EthStatus())
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		cause := errors.New("no enough available balance")
+		err := errors.Wrap(NewReceiptStatusError(ReceiptStatusErrRewardNotEnough, cause), "failed to claim")
+		r.Equal("failed to claim: no enough available balance", err.Error())
+		r.ErrorIs(err, cause)
+		r.Equal(ReceiptStatusErrRewardNotEnough, ReceiptStatusOf(err, ReceiptStatus(iotextypes.ReceiptStatus_Failure)))
+		r.Equal(ReceiptStatus(iotextypes.ReceiptStatus_Failure), ReceiptStatusOf(cause, ReceiptStatus(iotextypes.ReceiptStatus_Failure)))
+	})
+
+	t.Run("StatusMessage", func(t *testing.T) {
+		receipt := &Receipt{Status: uint64(ReceiptStatusErrMisbehaviorReported)}
+		pb := receipt.ConvertToReceiptPb()
+		r.Equal("misbehavior already reported", ReceiptStatusMessage(pb))
+		SetReceiptStatusMessage(pb)
+		b, err := proto.Marshal(pb)
+		r.NoError(err)
+		pb2 := &iotextypes.Receipt{}
+		r.NoError(proto.Unmarshal(b, pb2))
+		r.Equal("misbehavior already reported", ReceiptStatusMessage(pb2))
+
+		// the message is not stored with the receipt
+		stored, err := receipt.Serialize()
+		r.NoError(err)
+		receipt2 := &Receipt{}
+		r.NoError(receipt2.Deserialize(stored))
+		r.Equal(receipt.Status, receipt2.Status)
+		r.Empty(receipt2.ConvertToReceiptPb().ProtoReflect().GetUnknown())
+	})
+}
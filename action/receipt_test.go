@@ -9,7 +9,10 @@ import (
 	"encoding/hex"
 	"testing"
 
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/iotexproject/go-pkgs/hash"
 )
@@ -45,6 +48,7 @@ func TestConvert(t *testing.T) {
 		TxIndex:            1,
 		logs:               []*Log{testLog},
 		executionRevertMsg: "balance not enough",
+		gasPayer:           "io1hp6y4eqr90j7tmul4w2wa8pm7wx462hq0mg4tw",
 	}
 
 	typeReceipt := receipt.ConvertToReceiptPb()
@@ -58,6 +62,7 @@ func TestConvert(t *testing.T) {
 	require.Equal(receipt.ContractAddress, receipt2.ContractAddress)
 	require.Equal(receipt.TxIndex, receipt2.TxIndex)
 	require.Equal(receipt.executionRevertMsg, receipt2.executionRevertMsg)
+	require.Equal(receipt.gasPayer, receipt2.GasPayer())
 	// block earlier than AleutianHeight overwrites all topics with last topic data
 	require.NotEqual(testLog, receipt2.logs[0])
 	h := receipt.Hash()
@@ -100,6 +105,76 @@ func TestSerDer(t *testing.T) {
 	require.NotEqual(oldHash, hex.EncodeToString(hash2[:]))
 }
 
+func TestReceiptContractChanges(t *testing.T) {
+	require := require.New(t)
+	receipt := &Receipt{
+		Status:      1,
+		BlockHeight: 1,
+		ActionHash:  hash.ZeroHash256,
+		GasConsumed: 1,
+	}
+	h := receipt.Hash()
+	created := []*ContractChange{
+		{Address: "io1factory", Counterparty: "io1deployer", Depth: 0},
+		{Address: "io1child", Counterparty: "io1factory", Depth: 1},
+	}
+	destructed := []*ContractChange{
+		{Address: "io1child", Counterparty: "io1beneficiary", Depth: 2},
+	}
+	receipt.SetContractChanges(created, destructed).SetGasPayer("io1payer")
+	ser, err := receipt.Serialize()
+	require.NoError(err)
+	receipt2 := &Receipt{}
+	require.NoError(receipt2.Deserialize(ser))
+	require.Equal(created, receipt2.CreatedContracts())
+	require.Equal(destructed, receipt2.DestructedContracts())
+	require.Equal("io1payer", receipt2.GasPayer())
+
+	// the contract changes are not covered by the hash
+	receipt.SetGasPayer("")
+	require.Equal(h, receipt.Hash())
+	require.Equal(h, receipt2.SetGasPayer("").Hash())
+}
+
+func TestReceiptSystemGas(t *testing.T) {
+	require := require.New(t)
+	receipt := &Receipt{
+		Status:      1,
+		BlockHeight: 1,
+		ActionHash:  hash.ZeroHash256,
+		GasConsumed: 10000,
+	}
+	h := receipt.Hash()
+	receipt.SetGasPayer("io1payer").SetSystemGas(50000)
+	ser, err := receipt.Serialize()
+	require.NoError(err)
+	receipt2 := &Receipt{}
+	require.NoError(receipt2.Deserialize(ser))
+	require.EqualValues(50000, receipt2.SystemGas())
+	require.Equal("io1payer", receipt2.GasPayer())
+	require.Equal(receipt.Hash(), receipt2.Hash())
+
+	// the system gas is covered by the hash
+	require.NotEqual(h, receipt.SetGasPayer("").Hash())
+	// so its field in Receipt is pinned
+	require.Equal(protowire.AppendVarint(protowire.AppendTag(nil, 60, protowire.VarintType), 50000),
+		[]byte(receipt.hashedReceiptPb().ProtoReflect().GetUnknown()))
+
+	// the api receipt carries the status message along with the system gas
+	pb := receipt.ConvertToReceiptPb()
+	SetReceiptStatusMessage(pb)
+	b, err := proto.Marshal(pb)
+	require.NoError(err)
+	pb2 := &iotextypes.Receipt{}
+	require.NoError(proto.Unmarshal(b, pb2))
+	require.Equal(ReceiptStatus(receipt.Status).Message(), ReceiptStatusMessage(pb2))
+	receipt2 = &Receipt{}
+	receipt2.ConvertFromReceiptPb(pb2)
+	require.EqualValues(50000, receipt2.SystemGas())
+
+	require.Equal(h, receipt.SetSystemGas(0).Hash())
+}
+
 func TestUpdateIndex(t *testing.T) {
 	require := require.New(t)
 	receipt := &Receipt{
@@ -0,0 +1,187 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action/actionpb"
+	"github.com/iotexproject/iotex-core/pkg/version"
+)
+
+const (
+	// ReportMisbehaviorBaseIntrinsicGas represents the base intrinsic gas for ReportMisbehavior
+	ReportMisbehaviorBaseIntrinsicGas = uint64(10000)
+	// ReportMisbehaviorEvidenceGas represents the intrinsic gas for each byte of the evidence
+	ReportMisbehaviorEvidenceGas = uint64(100)
+	// ReportMisbehaviorSizeLimit is the max size of the evidence of a ReportMisbehavior
+	ReportMisbehaviorSizeLimit = 64 * 1024
+
+	reportMisbehaviorInterfaceABI = `[
+		{
+			"inputs": [
+				{
+					"internalType": "bytes",
+					"name": "first",
+					"type": "bytes"
+				},
+				{
+					"internalType": "bytes",
+					"name": "second",
+					"type": "bytes"
+				}
+			],
+			"name": "reportMisbehavior",
+			"outputs": [],
+			"stateMutability": "nonpayable",
+			"type": "function"
+		}
+	]`
+)
+
+var (
+	// ErrInvalidMisbehaviorReport indicates the misbehavior report is invalid
+	ErrInvalidMisbehaviorReport = errors.New("invalid misbehavior report")
+
+	reportMisbehaviorMethod abi.Method
+	_                       EthCompatibleAction = (*ReportMisbehavior)(nil)
+)
+
+// ReportMisbehavior is the action to report a delegate signing two conflicting consensus messages. The evidence is
+// the two serialized consensus messages as signed by the delegate
+type ReportMisbehavior struct {
+	AbstractAction
+	stake_common
+	first  []byte
+	second []byte
+}
+
+func init() {
+	reportMisbehaviorInterface, err := abi.JSON(strings.NewReader(reportMisbehaviorInterfaceABI))
+	if err != nil {
+		panic(err)
+	}
+	var ok bool
+	reportMisbehaviorMethod, ok = reportMisbehaviorInterface.Methods["reportMisbehavior"]
+	if !ok {
+		panic("fail to load the reportMisbehavior method")
+	}
+}
+
+// NewReportMisbehavior returns a ReportMisbehavior action with the two serialized consensus messages
+func NewReportMisbehavior(nonce, gasLimit uint64, gasPrice *big.Int, first, second []byte) *ReportMisbehavior {
+	return &ReportMisbehavior{
+		AbstractAction: AbstractAction{
+			version:  version.ProtocolVersion,
+			nonce:    nonce,
+			gasLimit: gasLimit,
+			gasPrice: gasPrice,
+		},
+		first:  first,
+		second: second,
+	}
+}
+
+// Evidence returns the two serialized consensus messages
+func (act *ReportMisbehavior) Evidence() ([]byte, []byte) { return act.first, act.second }
+
+// ConsensusMessages returns the two consensus messages of the evidence
+func (act *ReportMisbehavior) ConsensusMessages() (*iotextypes.ConsensusMessage, *iotextypes.ConsensusMessage, error) {
+	msgs := make([]*iotextypes.ConsensusMessage, 2)
+	for i, b := range [][]byte{act.first, act.second} {
+		msgs[i] = &iotextypes.ConsensusMessage{}
+		if err := proto.Unmarshal(b, msgs[i]); err != nil {
+			return nil, nil, errors.Wrap(ErrInvalidMisbehaviorReport, err.Error())
+		}
+	}
+	return msgs[0], msgs[1], nil
+}
+
+// IntrinsicGas returns the intrinsic gas of a ReportMisbehavior
+func (act *ReportMisbehavior) IntrinsicGas() (uint64, error) {
+	return CalculateIntrinsicGas(ReportMisbehaviorBaseIntrinsicGas, ReportMisbehaviorEvidenceGas, uint64(len(act.first)+len(act.second)))
+}
+
+// Cost returns the total cost of a ReportMisbehavior
+func (act *ReportMisbehavior) Cost() (*big.Int, error) {
+	intrinsicGas, err := act.IntrinsicGas()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get intrinsic gas for the ReportMisbehavior")
+	}
+	fee := big.NewInt(0).Mul(act.GasPrice(), big.NewInt(0).SetUint64(intrinsicGas))
+	return fee, nil
+}
+
+// SanityCheck validates the variables in the action
+func (act *ReportMisbehavior) SanityCheck() error {
+	if len(act.first) == 0 || len(act.second) == 0 {
+		return errors.Wrap(ErrInvalidMisbehaviorReport, "missing consensus message")
+	}
+	if len(act.first)+len(act.second) > ReportMisbehaviorSizeLimit {
+		return errors.Wrapf(ErrOversizedData, "evidence of %d bytes", len(act.first)+len(act.second))
+	}
+	if bytes.Equal(act.first, act.second) {
+		return errors.Wrap(ErrInvalidMisbehaviorReport, "same consensus messages")
+	}
+	if _, _, err := act.ConsensusMessages(); err != nil {
+		return err
+	}
+	return act.AbstractAction.SanityCheck()
+}
+
+// Proto converts ReportMisbehavior to protobuf
+func (act *ReportMisbehavior) Proto() *actionpb.ReportMisbehavior {
+	return &actionpb.ReportMisbehavior{
+		First:  act.first,
+		Second: act.second,
+	}
+}
+
+// LoadProto converts protobuf to ReportMisbehavior
+func (act *ReportMisbehavior) LoadProto(pb *actionpb.ReportMisbehavior) error {
+	if pb == nil {
+		return ErrNilProto
+	}
+	act.first = pb.GetFirst()
+	act.second = pb.GetSecond()
+	return nil
+}
+
+// EthData returns the ABI-encoded data for converting to eth tx
+func (act *ReportMisbehavior) EthData() ([]byte, error) {
+	data, err := reportMisbehaviorMethod.Inputs.Pack(act.first, act.second)
+	if err != nil {
+		return nil, err
+	}
+	return append(reportMisbehaviorMethod.ID, data...), nil
+}
+
+// NewReportMisbehaviorFromABIBinary parses the smart contract input and creates an action
+func NewReportMisbehaviorFromABIBinary(data []byte) (*ReportMisbehavior, error) {
+	if len(data) <= 4 || !bytes.Equal(reportMisbehaviorMethod.ID, data[:4]) {
+		return nil, errDecodeFailure
+	}
+	paramsMap := map[string]any{}
+	if err := reportMisbehaviorMethod.Inputs.UnpackIntoMap(paramsMap, data[4:]); err != nil {
+		return nil, err
+	}
+	first, ok := paramsMap["first"].([]byte)
+	if !ok {
+		return nil, errDecodeFailure
+	}
+	second, ok := paramsMap["second"].([]byte)
+	if !ok {
+		return nil, errDecodeFailure
+	}
+	return &ReportMisbehavior{first: first, second: second}, nil
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestReportMisbehavior(t *testing.T) {
+	r := require.New(t)
+	message := func(blkHash byte) []byte {
+		b, err := proto.Marshal(&iotextypes.ConsensusMessage{
+			Height: 10,
+			Msg: &iotextypes.ConsensusMessage_Vote{Vote: &iotextypes.ConsensusVote{
+				BlockHash: []byte{blkHash},
+				Topic:     iotextypes.ConsensusVote_PROPOSAL,
+			}},
+		})
+		r.NoError(err)
+		return b
+	}
+	first, second := message(1), message(2)
+
+	t.Run("SanityCheck", func(t *testing.T) {
+		r.NoError(NewReportMisbehavior(1, 100000, big.NewInt(1), first, second).SanityCheck())
+		for _, c := range []struct {
+			first, second []byte
+			err           error
+		}{
+			{first, nil, ErrInvalidMisbehaviorReport},
+			{first, first, ErrInvalidMisbehaviorReport},
+			{first, []byte{1, 2, 3}, ErrInvalidMisbehaviorReport},
+			{first, make([]byte, ReportMisbehaviorSizeLimit), ErrOversizedData},
+		} {
+			r.ErrorIs(NewReportMisbehavior(1, 100000, big.NewInt(1), c.first, c.second).SanityCheck(), c.err)
+		}
+	})
+
+	t.Run("Gas", func(t *testing.T) {
+		act := NewReportMisbehavior(1, 100000, big.NewInt(10), first, second)
+		gas, err := act.IntrinsicGas()
+		r.NoError(err)
+		r.Equal(ReportMisbehaviorBaseIntrinsicGas+uint64(len(first)+len(second))*ReportMisbehaviorEvidenceGas, gas)
+		cost, err := act.Cost()
+		r.NoError(err)
+		r.Equal(new(big.Int).SetUint64(gas*10), cost)
+	})
+
+	t.Run("Proto", func(t *testing.T) {
+		act := NewReportMisbehavior(1, 100000, big.NewInt(1), first, second)
+		elp := (&EnvelopeBuilder{}).SetNonce(act.Nonce()).SetGasLimit(act.GasLimit()).SetGasPrice(act.GasPrice()).
+			SetAction(act).Build()
+		b, err := proto.Marshal(elp.Proto())
+		r.NoError(err)
+		pb := &iotextypes.ActionCore{}
+		r.NoError(proto.Unmarshal(b, pb))
+		elp2, err := (&EnvelopeBuilder{}).BuildFromProto(pb)
+		r.NoError(err)
+		act2, ok := elp2.Action().(*ReportMisbehavior)
+		r.True(ok)
+		first2, second2 := act2.Evidence()
+		r.Equal(first, first2)
+		r.Equal(second, second2)
+		msg1, msg2, err := act2.ConsensusMessages()
+		r.NoError(err)
+		r.Equal([]byte{1}, msg1.GetVote().GetBlockHash())
+		r.Equal([]byte{2}, msg2.GetVote().GetBlockHash())
+	})
+
+	t.Run("ABI", func(t *testing.T) {
+		act := NewReportMisbehavior(1, 100000, big.NewInt(1), first, second)
+		data, err := act.EthData()
+		r.NoError(err)
+		act2, err := NewReportMisbehaviorFromABIBinary(data)
+		r.NoError(err)
+		r.Equal(act.first, act2.first)
+		r.Equal(act.second, act2.second)
+		payload, err := newStakingActionFromABIBinary(data)
+		r.NoError(err)
+		r.IsType(&ReportMisbehavior{}, payload)
+	})
+}
@@ -10,13 +10,22 @@ import (
 	"math/big"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/pkg/errors"
 )
 
+var (
+	// ErrUnprotectedTx indicates a legacy eth tx with the pre-EIP155 signature, which isn't bound to a chain id
+	ErrUnprotectedTx = errors.New("only replay-protected (EIP-155) transactions allowed")
+	// ErrInvalidSignature indicates the signature values of an eth tx are malformed
+	ErrInvalidSignature = errors.New("invalid transaction signature")
+)
+
 func rlpRawHash(rawTx *types.Transaction, signer types.Signer) (hash.Hash256, error) {
 	h := signer.Hash(rawTx)
 	return hash.BytesToHash256(h[:]), nil
@@ -57,6 +66,8 @@ func NewEthSigner(txType iotextypes.Encoding, chainID uint32) (types.Signer, err
 		return types.HomesteadSigner{}, nil
 	case iotextypes.Encoding_ETHEREUM_EIP155:
 		return types.NewEIP2930Signer(big.NewInt(int64(chainID))), nil
+	case iotextypes.Encoding_ETHEREUM_ACCESSLIST, iotextypes.Encoding_ETHEREUM_DYNAMICFEE:
+		return types.NewLondonSigner(big.NewInt(int64(chainID))), nil
 	default:
 		return nil, ErrInvalidAct
 	}
@@ -81,7 +92,9 @@ func DecodeEtherTx(rawData string) (*types.Transaction, error) {
 	return &tx, nil
 }
 
-// ExtractTypeSigPubkey extracts tx type, signature, and pubkey
+// ExtractTypeSigPubkey extracts tx type, signature, and pubkey. The v value of the signature must derive exactly from
+// the y-parity, which is 27/28 for the pre-EIP155 legacy tx, chainID * 2 + 35/36 for the EIP155 legacy tx, and 0/1 for
+// the typed tx, otherwise ErrInvalidSignature is returned
 func ExtractTypeSigPubkey(tx *types.Transaction) (iotextypes.Encoding, []byte, crypto.PublicKey, error) {
 	var (
 		encoding iotextypes.Encoding
@@ -92,6 +105,9 @@ func ExtractTypeSigPubkey(tx *types.Transaction) (iotextypes.Encoding, []byte, c
 	switch tx.Type() {
 	case types.LegacyTxType:
 		if tx.Protected() {
+			if V.Cmp(big.NewInt(35)) < 0 {
+				return encoding, nil, nil, errors.Wrapf(ErrInvalidSignature, "v %s derives no chain id", V)
+			}
 			chainIDMul := tx.ChainId()
 			V = new(big.Int).Sub(V, new(big.Int).Lsh(chainIDMul, 1))
 			V.Sub(V, big.NewInt(8))
@@ -101,6 +117,15 @@ func ExtractTypeSigPubkey(tx *types.Transaction) (iotextypes.Encoding, []byte, c
 			encoding = iotextypes.Encoding_ETHEREUM_UNPROTECTED
 			signer = types.HomesteadSigner{}
 		}
+	case types.AccessListTxType, types.DynamicFeeTxType:
+		// typed tx has y-parity as V, 27 is added to keep the same signature format as legacy tx
+		if tx.Type() == types.AccessListTxType {
+			encoding = iotextypes.Encoding_ETHEREUM_ACCESSLIST
+		} else {
+			encoding = iotextypes.Encoding_ETHEREUM_DYNAMICFEE
+		}
+		signer = types.NewLondonSigner(tx.ChainId())
+		V = new(big.Int).Add(V, big.NewInt(27))
 	default:
 		return encoding, nil, nil, ErrNotSupported
 	}
@@ -109,6 +134,9 @@ func ExtractTypeSigPubkey(tx *types.Transaction) (iotextypes.Encoding, []byte, c
 	if V.BitLen() > 8 {
 		return encoding, nil, nil, ErrNotSupported
 	}
+	if v := V.Uint64(); (v != 27 && v != 28) || !ethcrypto.ValidateSignatureValues(byte(v-27), R, S, true) {
+		return encoding, nil, nil, errors.Wrapf(ErrInvalidSignature, "v %d, r %s, s %s", v, R, S)
+	}
 
 	var (
 		r, s   = R.Bytes(), S.Bytes()
@@ -126,20 +154,26 @@ func ExtractTypeSigPubkey(tx *types.Transaction) (iotextypes.Encoding, []byte, c
 	return encoding, sig, pubkey, err
 }
 
+// ValidateEthChainID validates the eth tx is signed for the evm network id. The pre-EIP155 legacy tx carries no chain
+// id, and is rejected with ErrUnprotectedTx unless allowUnprotected
+func ValidateEthChainID(tx *types.Transaction, evmNetworkID uint32, allowUnprotected bool) error {
+	if tx.Type() == types.LegacyTxType && !tx.Protected() {
+		if !allowUnprotected {
+			return ErrUnprotectedTx
+		}
+		return nil
+	}
+	if chainID := tx.ChainId(); !chainID.IsUint64() || chainID.Uint64() != uint64(evmNetworkID) {
+		return errors.Wrapf(ErrChainID, "expect chainID = %d, got %s", evmNetworkID, chainID)
+	}
+	return nil
+}
+
 // ======================================
 // utility funcs to convert native action to eth tx
 // ======================================
 func toLegacyTx(ab *AbstractAction, act Action) (*types.Transaction, error) {
-	tx, ok := act.(EthCompatibleAction)
-	if !ok {
-		// action type not supported
-		return nil, ErrInvalidAct
-	}
-	to, err := tx.EthTo()
-	if err != nil {
-		return nil, err
-	}
-	data, err := tx.EthData()
+	to, value, data, err := ethTxFields(act)
 	if err != nil {
 		return nil, err
 	}
@@ -148,7 +182,66 @@ func toLegacyTx(ab *AbstractAction, act Action) (*types.Transaction, error) {
 		GasPrice: ab.GasPrice(),
 		Gas:      ab.GasLimit(),
 		To:       to,
-		Value:    tx.Value(),
+		Value:    value,
 		Data:     data,
 	}), nil
 }
+
+func toAccessListTx(ab *AbstractAction, act Action, evmNetworkID uint32) (*types.Transaction, error) {
+	to, value, data, err := ethTxFields(act)
+	if err != nil {
+		return nil, err
+	}
+	return types.NewTx(&types.AccessListTx{
+		ChainID:    big.NewInt(int64(evmNetworkID)),
+		Nonce:      ab.Nonce(),
+		GasPrice:   ab.GasPrice(),
+		Gas:        ab.GasLimit(),
+		To:         to,
+		Value:      value,
+		Data:       data,
+		AccessList: ethAccessList(act),
+	}), nil
+}
+
+func toDynamicFeeTx(ab *AbstractAction, act Action, evmNetworkID uint32) (*types.Transaction, error) {
+	to, value, data, err := ethTxFields(act)
+	if err != nil {
+		return nil, err
+	}
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:    big.NewInt(int64(evmNetworkID)),
+		Nonce:      ab.Nonce(),
+		GasTipCap:  ab.GasTipCap(),
+		GasFeeCap:  ab.GasFeeCap(),
+		Gas:        ab.GasLimit(),
+		To:         to,
+		Value:      value,
+		Data:       data,
+		AccessList: ethAccessList(act),
+	}), nil
+}
+
+func ethTxFields(act Action) (*common.Address, *big.Int, []byte, error) {
+	tx, ok := act.(EthCompatibleAction)
+	if !ok {
+		// action type not supported
+		return nil, nil, nil, ErrInvalidAct
+	}
+	to, err := tx.EthTo()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	data, err := tx.EthData()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return to, tx.Value(), data, nil
+}
+
+func ethAccessList(act Action) types.AccessList {
+	if exec, ok := act.(*Execution); ok {
+		return exec.AccessList()
+	}
+	return nil
+}
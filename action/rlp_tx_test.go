@@ -16,6 +16,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
 	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
@@ -25,6 +26,7 @@ import (
 
 	. "github.com/iotexproject/iotex-core/pkg/util/assertions"
 	"github.com/iotexproject/iotex-core/pkg/version"
+	"github.com/iotexproject/iotex-core/test/identityset"
 )
 
 func TestGenerateRlp(t *testing.T) {
@@ -464,22 +466,157 @@ var (
 
 func TestNewEthSignerError(t *testing.T) {
 	require := require.New(t)
-	singer, err := NewEthSigner(iotextypes.Encoding_ETHEREUM_ACCESSLIST, 1)
+	singer, err := NewEthSigner(iotextypes.Encoding_ETHEREUM_BLOB, 1)
 	require.ErrorIs(err, ErrInvalidAct)
 	require.Nil(singer)
 
-	tx := types.NewTx(&types.DynamicFeeTx{
-		To:        nil,
+	tx := types.NewTx(&types.BlobTx{
 		Nonce:     4,
-		Value:     big.NewInt(4),
+		Value:     uint256.NewInt(4),
 		Gas:       4,
-		GasTipCap: big.NewInt(44),
-		GasFeeCap: big.NewInt(1045),
+		GasTipCap: uint256.NewInt(44),
+		GasFeeCap: uint256.NewInt(1045),
 	})
 	_, _, _, err = ExtractTypeSigPubkey(tx)
 	require.ErrorIs(err, ErrNotSupported)
 }
 
+func TestTypedTxDecodeVerify(t *testing.T) {
+	r := require.New(t)
+	var (
+		sk       = identityset.PrivateKey(1)
+		chainID  = big.NewInt(int64(_evmNetworkID))
+		to       = common.BytesToAddress(identityset.Address(2).Bytes())
+		contract = common.HexToAddress("0x3141df3f2e4415533bb6d6be2A351B2db9ee84EF")
+		al       = types.AccessList{{Address: contract, StorageKeys: []common.Hash{{1}}}}
+		stake    = MustNoErrorV(NewCreateStake(0, "cand1", "100", 7, true, nil, 0, nil))
+	)
+	for _, v := range []struct {
+		name     string
+		tx       types.TxData
+		encoding iotextypes.Encoding
+		build    func(*EnvelopeBuilder, *types.Transaction) (Envelope, error)
+	}{
+		{"accessListExecution", &types.AccessListTx{
+			ChainID: chainID, Nonce: 1, GasPrice: big.NewInt(100), Gas: 50000, To: &contract, Value: big.NewInt(1), Data: []byte{1, 2}, AccessList: al,
+		}, iotextypes.Encoding_ETHEREUM_ACCESSLIST, (*EnvelopeBuilder).BuildExecution},
+		{"dynamicFeeTransfer", &types.DynamicFeeTx{
+			ChainID: chainID, Nonce: 2, GasTipCap: big.NewInt(10), GasFeeCap: big.NewInt(200), Gas: 21000, To: &to, Value: big.NewInt(3),
+		}, iotextypes.Encoding_ETHEREUM_DYNAMICFEE, (*EnvelopeBuilder).BuildTransfer},
+		{"dynamicFeeExecution", &types.DynamicFeeTx{
+			ChainID: chainID, Nonce: 3, GasTipCap: big.NewInt(10), GasFeeCap: big.NewInt(200), Gas: 50000, Data: []byte{3}, AccessList: al,
+		}, iotextypes.Encoding_ETHEREUM_DYNAMICFEE, (*EnvelopeBuilder).BuildExecution},
+		{"dynamicFeeStaking", &types.DynamicFeeTx{
+			ChainID: chainID, Nonce: 4, GasTipCap: big.NewInt(10), GasFeeCap: big.NewInt(200), Gas: 50000, To: &_stakingProtocolEthAddr,
+			Data: MustNoErrorV(stake.EthData()),
+		}, iotextypes.Encoding_ETHEREUM_DYNAMICFEE, (*EnvelopeBuilder).BuildStakingAction},
+	} {
+		t.Run(v.name, func(t *testing.T) {
+			signedTx := types.MustSignNewTx(sk.EcdsaPrivateKey().(*ecdsa.PrivateKey), types.NewLondonSigner(chainID), v.tx)
+			tx, err := DecodeEtherTx(hex.EncodeToString(MustNoErrorV(signedTx.MarshalBinary())))
+			r.NoError(err)
+			encoding, sig, pubkey, err := ExtractTypeSigPubkey(tx)
+			r.NoError(err)
+			r.Equal(v.encoding, encoding)
+			r.Equal(sk.PublicKey().HexString(), pubkey.HexString())
+			elp, err := v.build((&EnvelopeBuilder{}).SetChainID(1), tx)
+			r.NoError(err)
+			selp, err := (&Deserializer{}).SetEvmNetworkID(_evmNetworkID).ActionToSealedEnvelope(&iotextypes.Action{
+				Core:         elp.Proto(),
+				SenderPubKey: pubkey.Bytes(),
+				Signature:    sig,
+				Encoding:     encoding,
+			})
+			r.NoError(err)
+			r.NoError(selp.VerifySignature())
+			h, err := selp.Hash()
+			r.NoError(err)
+			r.Equal(signedTx.Hash().Bytes(), h[:])
+			// the converted tx is the same as the original one
+			ethTx, err := selp.ToEthTx()
+			r.NoError(err)
+			signer, err := NewEthSigner(encoding, _evmNetworkID)
+			r.NoError(err)
+			r.Equal(signer.Hash(signedTx), signer.Hash(ethTx))
+		})
+	}
+	t.Run("accessListOnTransfer", func(t *testing.T) {
+		tx := types.MustSignNewTx(sk.EcdsaPrivateKey().(*ecdsa.PrivateKey), types.NewLondonSigner(chainID), &types.DynamicFeeTx{
+			ChainID: chainID, Nonce: 5, GasTipCap: big.NewInt(10), GasFeeCap: big.NewInt(200), Gas: 21000, To: &to, AccessList: al,
+		})
+		_, err := (&EnvelopeBuilder{}).BuildTransfer(tx)
+		r.ErrorIs(err, ErrInvalidAct)
+	})
+}
+
+func TestValidateEthChainID(t *testing.T) {
+	r := require.New(t)
+	var (
+		sk = identityset.PrivateKey(1).EcdsaPrivateKey().(*ecdsa.PrivateKey)
+		to = common.BytesToAddress(identityset.Address(2).Bytes())
+	)
+	sign := func(txType byte, chainID int64) *types.Transaction {
+		var (
+			id     = big.NewInt(chainID)
+			signer types.Signer
+			txData types.TxData
+		)
+		switch txType {
+		case types.LegacyTxType:
+			signer, txData = types.NewEIP155Signer(id), &types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(100), Gas: 21000, To: &to, Value: big.NewInt(1)}
+		case types.AccessListTxType:
+			signer, txData = types.NewEIP2930Signer(id), &types.AccessListTx{ChainID: id, Nonce: 1, GasPrice: big.NewInt(100), Gas: 21000, To: &to, Value: big.NewInt(1)}
+		default:
+			signer, txData = types.NewLondonSigner(id), &types.DynamicFeeTx{ChainID: id, Nonce: 1, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(100), Gas: 21000, To: &to, Value: big.NewInt(1)}
+		}
+		tx := types.MustSignNewTx(sk, signer, txData)
+		decoded, err := DecodeEtherTx(hex.EncodeToString(MustNoErrorV(tx.MarshalBinary())))
+		r.NoError(err)
+		return decoded
+	}
+	unprotected := types.MustSignNewTx(sk, types.HomesteadSigner{}, &types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(100), Gas: 21000, To: &to, Value: big.NewInt(1)})
+
+	// the node is on the evm network 4689, only the txs signed for it are accepted
+	for _, txType := range []byte{types.LegacyTxType, types.AccessListTxType, types.DynamicFeeTxType} {
+		for _, v := range []struct {
+			chainID int64
+			err     error
+		}{
+			{1, ErrChainID},
+			{4689, nil},
+			{4690, ErrChainID},
+		} {
+			tx := sign(txType, v.chainID)
+			_, _, pubkey, err := ExtractTypeSigPubkey(tx)
+			r.NoError(err)
+			r.Equal(identityset.PrivateKey(1).PublicKey().HexString(), pubkey.HexString())
+			for _, allowUnprotected := range []bool{true, false} {
+				err = ValidateEthChainID(tx, 4689, allowUnprotected)
+				if v.err == nil {
+					r.NoError(err)
+				} else {
+					r.ErrorIs(err, v.err)
+					r.Contains(err.Error(), "expect chainID = 4689")
+				}
+			}
+		}
+	}
+	r.NoError(ValidateEthChainID(unprotected, 4689, true))
+	r.ErrorIs(ValidateEthChainID(unprotected, 4689, false), ErrUnprotectedTx)
+
+	// malformed v values
+	R, S := big.NewInt(1), big.NewInt(1)
+	for _, tx := range []*types.Transaction{
+		types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(100), Gas: 21000, To: &to, V: big.NewInt(1), R: R, S: S}),
+		types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(100), Gas: 21000, To: &to, V: big.NewInt(30), R: R, S: S}),
+		types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(4689), Nonce: 1, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(100), Gas: 21000, To: &to, V: big.NewInt(2), R: R, S: S}),
+		types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(4689), Nonce: 1, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(100), Gas: 21000, To: &to, V: big.NewInt(0), R: R, S: new(big.Int)}),
+	} {
+		_, _, _, err := ExtractTypeSigPubkey(tx)
+		r.ErrorIs(err, ErrInvalidSignature)
+	}
+}
+
 func TestEthTxDecodeVerify(t *testing.T) {
 	require := require.New(t)
 	sk := MustNoErrorV(crypto.HexStringToPrivateKey("a000000000000000000000000000000000000000000000000000000000000000")).EcdsaPrivateKey().(*ecdsa.PrivateKey)
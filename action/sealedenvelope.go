@@ -2,6 +2,7 @@ package action
 
 import (
 	"encoding/hex"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/iotexproject/go-pkgs/crypto"
@@ -12,6 +13,7 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/iotexproject/iotex-core/action/actionpb"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 )
@@ -25,6 +27,11 @@ type SealedEnvelope struct {
 	signature    []byte
 	srcAddress   address.Address
 	hash         hash.Hash256
+	// the co-signature of the gas payer, if the gas is sponsored
+	payerPubkey    crypto.PublicKey
+	payerSignature []byte
+	// whether the signatures are verified by VerifySignatures
+	verified atomic.Bool
 }
 
 // envelopeHash returns the raw hash of embedded Envelope (this is the hash to be signed)
@@ -45,7 +52,8 @@ func (sealed *SealedEnvelope) envelopeHash() (hash.Hash256, error) {
 			return hash.ZeroHash256, err
 		}
 		return rlpRawHash(act.tx, signer)
-	case iotextypes.Encoding_ETHEREUM_EIP155, iotextypes.Encoding_ETHEREUM_UNPROTECTED:
+	case iotextypes.Encoding_ETHEREUM_EIP155, iotextypes.Encoding_ETHEREUM_UNPROTECTED,
+		iotextypes.Encoding_ETHEREUM_ACCESSLIST, iotextypes.Encoding_ETHEREUM_DYNAMICFEE:
 		tx, err := sealed.ToEthTx()
 		if err != nil {
 			return hash.ZeroHash256, err
@@ -62,6 +70,16 @@ func (sealed *SealedEnvelope) envelopeHash() (hash.Hash256, error) {
 	}
 }
 
+// gasPayerHash returns the hash to be signed by the gas payer, which commits to the envelope including the address
+// of the gas payer, so the signature cannot be replayed on a different action
+func (sealed *SealedEnvelope) gasPayerHash() (hash.Hash256, error) {
+	h, err := sealed.envelopeHash()
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	return hash.Hash256b(append(append([]byte{}, _gasPayerDomain...), h[:]...)), nil
+}
+
 // Hash returns the hash value of SealedEnvelope.
 // an all-0 return value means the transaction is invalid
 func (sealed *SealedEnvelope) Hash() (hash.Hash256, error) {
@@ -76,28 +94,11 @@ func (sealed *SealedEnvelope) Hash() (hash.Hash256, error) {
 }
 
 func (sealed *SealedEnvelope) calcHash() (hash.Hash256, error) {
-	switch sealed.encoding {
-	case iotextypes.Encoding_TX_CONTAINER:
-		act, ok := sealed.Action().(*txContainer)
-		if !ok {
-			return hash.ZeroHash256, ErrInvalidAct
-		}
-		return act.hash(), nil
-	case iotextypes.Encoding_ETHEREUM_EIP155, iotextypes.Encoding_ETHEREUM_UNPROTECTED:
-		tx, err := sealed.ToEthTx()
-		if err != nil {
-			return hash.ZeroHash256, err
-		}
-		signer, err := NewEthSigner(sealed.encoding, sealed.evmNetworkID)
-		if err != nil {
-			return hash.ZeroHash256, err
-		}
-		return rlpSignedHash(tx, signer, sealed.Signature())
-	case iotextypes.Encoding_IOTEX_PROTOBUF:
-		return hash.Hash256b(byteutil.Must(proto.Marshal(sealed.Proto()))), nil
-	default:
-		return hash.ZeroHash256, errors.Errorf("unknown encoding type %v", sealed.encoding)
+	calc, ok := _hashAlgorithms[sealed.HashVersion()]
+	if !ok {
+		return hash.ZeroHash256, errors.Wrapf(ErrUnsupportedHashVersion, "hash version %d", sealed.HashVersion())
 	}
+	return calc(sealed)
 }
 
 // SrcPubkey returns the source public key
@@ -118,6 +119,16 @@ func (sealed *SealedEnvelope) Signature() []byte {
 	return sig
 }
 
+// PayerPubkey returns the public key of the gas payer, nil if the gas is not sponsored
+func (sealed *SealedEnvelope) PayerPubkey() crypto.PublicKey { return sealed.payerPubkey }
+
+// PayerSignature returns the signature of the gas payer
+func (sealed *SealedEnvelope) PayerSignature() []byte {
+	sig := make([]byte, len(sealed.payerSignature))
+	copy(sig, sealed.payerSignature)
+	return sig
+}
+
 // Encoding returns the encoding
 func (sealed *SealedEnvelope) Encoding() uint32 {
 	return uint32(sealed.encoding)
@@ -130,12 +141,18 @@ func (sealed *SealedEnvelope) ToEthTx() (*types.Transaction, error) {
 
 // Proto converts it to it's proto scheme.
 func (sealed *SealedEnvelope) Proto() *iotextypes.Action {
-	return &iotextypes.Action{
+	act := &iotextypes.Action{
 		Core:         sealed.Envelope.Proto(),
 		SenderPubKey: sealed.srcPubkey.Bytes(),
 		Signature:    sealed.signature,
 		Encoding:     sealed.encoding,
 	}
+	if sealed.payerPubkey != nil {
+		MustAppendUnknownFields(act, &actionpb.ActionExt{
+			GasPayerSignature: gasPayerSignatureProto(sealed.payerPubkey, sealed.payerSignature),
+		})
+	}
+	return act
 }
 
 // loadProto loads from proto scheme.
@@ -160,7 +177,26 @@ func (sealed *SealedEnvelope) loadProto(pbAct *iotextypes.Action, evmID uint32)
 	if err != nil {
 		return err
 	}
+	var (
+		payerPub crypto.PublicKey
+		payerSig []byte
+	)
+	ext := actionpb.ActionExt{}
+	if err := LoadUnknownFields(pbAct, &ext); err != nil {
+		return err
+	}
+	if ext.GasPayerSignature != nil {
+		if payerPub, payerSig, err = loadGasPayerSignatureProto(ext.GasPayerSignature); err != nil {
+			return err
+		}
+	}
 	encoding := pbAct.GetEncoding()
+	if encoding != iotextypes.Encoding_IOTEX_PROTOBUF && (elp.GasPayer() != nil || payerPub != nil) {
+		return errors.Wrapf(ErrInvalidGasPayer, "gas payer is not supported by encoding %v", encoding)
+	}
+	if encoding != iotextypes.Encoding_IOTEX_PROTOBUF && elp.HashVersion() != HashV1 {
+		return errors.Wrapf(ErrUnsupportedHashVersion, "hash version %d is not supported by encoding %v", elp.HashVersion(), encoding)
+	}
 	switch encoding {
 	case iotextypes.Encoding_TX_CONTAINER:
 		// verify it is container format
@@ -168,7 +204,8 @@ func (sealed *SealedEnvelope) loadProto(pbAct *iotextypes.Action, evmID uint32)
 			return ErrInvalidAct
 		}
 		sealed.evmNetworkID = evmID
-	case iotextypes.Encoding_ETHEREUM_EIP155, iotextypes.Encoding_ETHEREUM_UNPROTECTED:
+	case iotextypes.Encoding_ETHEREUM_EIP155, iotextypes.Encoding_ETHEREUM_UNPROTECTED,
+		iotextypes.Encoding_ETHEREUM_ACCESSLIST, iotextypes.Encoding_ETHEREUM_DYNAMICFEE:
 		// verify action type can support RLP-encoding
 		tx, err := elp.ToEthTx(evmID, encoding)
 		if err != nil {
@@ -194,13 +231,19 @@ func (sealed *SealedEnvelope) loadProto(pbAct *iotextypes.Action, evmID uint32)
 	sealed.signature = make([]byte, sigSize)
 	copy(sealed.signature, pbAct.GetSignature())
 	sealed.encoding = encoding
+	sealed.payerPubkey = payerPub
+	sealed.payerSignature = payerSig
 	sealed.hash = hash.ZeroHash256
 	sealed.srcAddress = nil
+	sealed.verified.Store(false)
 	return nil
 }
 
 // VerifySignature verifies the action using sender's public key
 func (sealed *SealedEnvelope) VerifySignature() error {
+	if sealed.verified.Load() {
+		return nil
+	}
 	if sealed.SrcPubkey() == nil {
 		return errors.New("empty public key")
 	}
@@ -214,5 +257,33 @@ func (sealed *SealedEnvelope) VerifySignature() error {
 			zap.String("signature", hex.EncodeToString(sealed.Signature())))
 		return ErrInvalidSender
 	}
+	return sealed.verifyGasPayerSignature()
+}
+
+// verifyGasPayerSignature verifies the co-signature of the gas payer, if the gas is sponsored
+func (sealed *SealedEnvelope) verifyGasPayerSignature() error {
+	payer := sealed.GasPayer()
+	if payer == nil {
+		if sealed.payerPubkey != nil {
+			return errors.Wrap(ErrInvalidGasPayer, "gas payer signature without gas payer")
+		}
+		return nil
+	}
+	if sealed.payerPubkey == nil {
+		return errors.Wrapf(ErrInvalidGasPayer, "missing signature of gas payer %s", payer.String())
+	}
+	if sealed.payerPubkey.Address().String() != payer.String() {
+		return errors.Wrapf(ErrInvalidGasPayer, "public key does not match gas payer %s", payer.String())
+	}
+	h, err := sealed.gasPayerHash()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate gas payer hash")
+	}
+	if !sealed.payerPubkey.Verify(h[:], sealed.payerSignature) {
+		log.L().Info("failed to verify gas payer signature",
+			zap.String("hash", hex.EncodeToString(h[:])),
+			zap.String("signature", hex.EncodeToString(sealed.payerSignature)))
+		return errors.Wrapf(ErrInvalidGasPayer, "invalid signature of gas payer %s", payer.String())
+	}
 	return nil
 }
@@ -0,0 +1,65 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"crypto/ecdsa"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+
+	cp "github.com/iotexproject/iotex-core/crypto"
+)
+
+// VerifySignatures verifies the signatures of the actions at once, which costs much less than VerifySignature of the
+// actions one by one. The actions signed by secp256k1 keys are verified in batch, and the sponsored ones or those
+// signed by other keys one by one. A failed batch is bisected to find the invalid actions, and the error tells the
+// first one. The actions verified skip the verification in VerifySignature afterwards
+func VerifySignatures(selps []*SealedEnvelope) error {
+	var (
+		sigs  = make([]*cp.Secp256k1Signature, 0, len(selps))
+		batch = make([]int, 0, len(selps))
+	)
+	for i, selp := range selps {
+		if selp.verified.Load() {
+			continue
+		}
+		if !selp.batchVerifiable() {
+			if err := selp.VerifySignature(); err != nil {
+				return errors.Wrapf(err, "invalid action at index %d", i)
+			}
+			continue
+		}
+		h, err := selp.envelopeHash()
+		if err != nil {
+			return errors.Wrapf(err, "failed to generate envelope hash of action at index %d", i)
+		}
+		sigs = append(sigs, &cp.Secp256k1Signature{
+			PubKey:    selp.srcPubkey.Bytes(),
+			Hash:      h[:],
+			Signature: selp.signature,
+		})
+		batch = append(batch, i)
+	}
+	if invalid := cp.FindInvalidSecp256k1(sigs); len(invalid) > 0 {
+		i := batch[invalid[0]]
+		h, _ := selps[i].Hash()
+		return errors.Wrapf(ErrInvalidSender, "invalid signature of action %x at index %d", h, i)
+	}
+	for _, i := range batch {
+		selps[i].verified.Store(true)
+	}
+	return nil
+}
+
+// batchVerifiable returns true if the action is signed by a secp256k1 key, and not sponsored by a gas payer
+func (sealed *SealedEnvelope) batchVerifiable() bool {
+	if sealed.srcPubkey == nil || sealed.payerPubkey != nil || sealed.GasPayer() != nil {
+		return false
+	}
+	pk, ok := sealed.srcPubkey.EcdsaPublicKey().(*ecdsa.PublicKey)
+	return ok && pk.Curve == ethcrypto.S256()
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func testSignedTransfers(t *testing.T, n int) []*SealedEnvelope {
+	selps := make([]*SealedEnvelope, n)
+	for i := range selps {
+		selp, err := SignedTransfer(identityset.Address(29).String(), identityset.PrivateKey(i%10), uint64(i/10+1), big.NewInt(int64(i)), nil, 10000, big.NewInt(1))
+		require.NoError(t, err)
+		selps[i] = selp
+	}
+	return selps
+}
+
+func TestVerifySignatures(t *testing.T) {
+	r := require.New(t)
+	t.Run("valid", func(t *testing.T) {
+		selps := testSignedTransfers(t, 100)
+		r.NoError(VerifySignatures(selps))
+		for _, selp := range selps {
+			r.True(selp.verified.Load())
+		}
+		// reloading the action clears the verification
+		selp := &SealedEnvelope{}
+		r.NoError(selp.loadProto(selps[0].Proto(), selps[0].evmNetworkID))
+		r.False(selp.verified.Load())
+	})
+	t.Run("oneCorrupt", func(t *testing.T) {
+		selps := testSignedTransfers(t, 100)
+		selps[42].signature[10]++
+		err := VerifySignatures(selps)
+		r.Equal(ErrInvalidSender, errors.Cause(err))
+		r.Contains(err.Error(), "at index 42")
+		r.Equal(ErrInvalidSender, selps[42].VerifySignature())
+		for _, selp := range selps {
+			r.False(selp.verified.Load())
+		}
+	})
+	t.Run("notBatchVerifiable", func(t *testing.T) {
+		selps := testSignedTransfers(t, 20)
+		selps[7] = FakeSeal(selps[7].Envelope, nil)
+		r.ErrorContains(VerifySignatures(selps), "invalid action at index 7: empty public key")
+	})
+}
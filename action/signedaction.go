@@ -13,6 +13,8 @@ import (
 	"github.com/iotexproject/iotex-address/address"
 
 	"github.com/pkg/errors"
+
+	cp "github.com/iotexproject/iotex-core/crypto"
 )
 
 // vars
@@ -28,6 +30,12 @@ func WithChainID(chainID uint32) SignedActionOption {
 	}
 }
 
+func WithHashVersion(v HashVersion) SignedActionOption {
+	return func(b *EnvelopeBuilder) {
+		b.SetHashVersion(v)
+	}
+}
+
 // SignedTransfer return a signed transfer
 func SignedTransfer(recipientAddr string, senderPriKey crypto.PrivateKey, nonce uint64, amount *big.Int, payload []byte, gasLimit uint64, gasPrice *big.Int, options ...SignedActionOption) (*SealedEnvelope, error) {
 	transfer, err := NewTransfer(nonce, amount, recipientAddr, payload, gasLimit, gasPrice)
@@ -134,6 +142,36 @@ func SignedCandidateUpdate(
 	return selp, nil
 }
 
+// SignedCandidateUpdateWithPayoutSplit returns a signed candidate update which also sets the payout split
+func SignedCandidateUpdateWithPayoutSplit(
+	nonce uint64,
+	name, operatorAddrStr, rewardAddrStr string,
+	split []PayoutShare,
+	gasLimit uint64,
+	gasPrice *big.Int,
+	registererPriKey crypto.PrivateKey,
+	options ...SignedActionOption,
+) (*SealedEnvelope, error) {
+	cu, err := NewCandidateUpdateWithPayoutSplit(nonce, name, operatorAddrStr, rewardAddrStr, split, gasLimit, gasPrice)
+	if err != nil {
+		return nil, err
+	}
+	bd := &EnvelopeBuilder{}
+	bd = bd.SetNonce(nonce).
+		SetGasPrice(gasPrice).
+		SetGasLimit(gasLimit).
+		SetAction(cu)
+	for _, opt := range options {
+		opt(bd)
+	}
+	elp := bd.Build()
+	selp, err := Sign(elp, registererPriKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to sign candidate update %v", elp)
+	}
+	return selp, nil
+}
+
 // SignedCandidateActivate returns a signed candidate selfstake
 func SignedCandidateActivate(
 	nonce uint64,
@@ -217,6 +255,87 @@ func SignedCandidateEndorsement(
 	return selp, nil
 }
 
+// SignedStakeTransferLock returns a signed stake transfer lock
+func SignedStakeTransferLock(
+	nonce uint64,
+	op StakeTransferLockOp,
+	addresses []address.Address,
+	gasLimit uint64,
+	gasPrice *big.Int,
+	ownerPriKey crypto.PrivateKey,
+	options ...SignedActionOption,
+) (*SealedEnvelope, error) {
+	stl := NewStakeTransferLock(nonce, gasLimit, gasPrice, op, addresses)
+	bd := &EnvelopeBuilder{}
+	bd = bd.SetNonce(nonce).
+		SetGasPrice(gasPrice).
+		SetGasLimit(gasLimit).
+		SetAction(stl)
+	for _, opt := range options {
+		opt(bd)
+	}
+	elp := bd.Build()
+	selp, err := Sign(elp, ownerPriKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to sign stake transfer lock %v", elp)
+	}
+	return selp, nil
+}
+
+// SignedReportMisbehavior returns a signed misbehavior report
+func SignedReportMisbehavior(
+	nonce uint64,
+	first, second []byte,
+	gasLimit uint64,
+	gasPrice *big.Int,
+	reporterPriKey cp.Signer,
+	options ...SignedActionOption,
+) (*SealedEnvelope, error) {
+	rm := NewReportMisbehavior(nonce, gasLimit, gasPrice, first, second)
+	bd := &EnvelopeBuilder{}
+	bd = bd.SetNonce(nonce).
+		SetGasPrice(gasPrice).
+		SetGasLimit(gasLimit).
+		SetAction(rm)
+	for _, opt := range options {
+		opt(bd)
+	}
+	elp := bd.Build()
+	selp, err := Sign(elp, reporterPriKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to sign misbehavior report %v", elp)
+	}
+	return selp, nil
+}
+
+// SignedUpdateCandidateProfile returns a signed update candidate profile
+func SignedUpdateCandidateProfile(
+	nonce uint64,
+	url, description string,
+	iconHash []byte,
+	securityContact string,
+	gasLimit uint64,
+	gasPrice *big.Int,
+	ownerPriKey crypto.PrivateKey,
+	options ...SignedActionOption,
+) (*SealedEnvelope, error) {
+	ucp := NewUpdateCandidateProfile(nonce, gasLimit, gasPrice, url, description, iconHash, securityContact)
+	bd := &EnvelopeBuilder{}
+	bd = bd.SetNonce(nonce).
+		SetGasPrice(gasPrice).
+		SetGasLimit(gasLimit).
+		SetAction(ucp)
+	for _, opt := range options {
+		opt(bd)
+	}
+	elp := bd.Build()
+	selp, err := Sign(elp, ownerPriKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to sign update candidate profile %v", elp)
+	}
+	return selp, nil
+}
+
 // SignedCreateStake returns a signed create stake
 func SignedCreateStake(nonce uint64,
 	candidateName, amount string,
@@ -511,3 +630,31 @@ func SignedClaimReward(
 	}
 	return selp, nil
 }
+
+// SignedTimeLockedTransfer returns a signed time-locked transfer
+func SignedTimeLockedTransfer(
+	nonce uint64,
+	amount *big.Int,
+	recipient address.Address,
+	unlockHeight, unlockTimestamp uint64,
+	gasLimit uint64,
+	gasPrice *big.Int,
+	senderPriKey crypto.PrivateKey,
+	options ...SignedActionOption,
+) (*SealedEnvelope, error) {
+	tlt := NewTimeLockedTransfer(nonce, gasLimit, gasPrice, amount, recipient, unlockHeight, unlockTimestamp)
+	bd := &EnvelopeBuilder{}
+	bd = bd.SetNonce(nonce).
+		SetGasPrice(gasPrice).
+		SetGasLimit(gasLimit).
+		SetAction(tlt)
+	for _, opt := range options {
+		opt(bd)
+	}
+	elp := bd.Build()
+	selp, err := Sign(elp, senderPriKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to sign time-locked transfer %v", elp)
+	}
+	return selp, nil
+}
@@ -160,6 +160,11 @@ func (ds *DepositToStake) IntrinsicGas() (uint64, error) {
 	return CalculateIntrinsicGas(DepositToStakeBaseIntrinsicGas, DepositToStakePayloadGas, payloadSize)
 }
 
+// payloadData returns the payload data of a DepositToStake charged in the intrinsic gas
+func (ds *DepositToStake) payloadData() ([]byte, uint64) {
+	return ds.Payload(), DepositToStakePayloadGas
+}
+
 // Cost returns the total cost of a DepositToStake
 func (ds *DepositToStake) Cost() (*big.Int, error) {
 	intrinsicGas, err := ds.IntrinsicGas()
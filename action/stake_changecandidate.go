@@ -146,6 +146,11 @@ func (cc *ChangeCandidate) IntrinsicGas() (uint64, error) {
 	return CalculateIntrinsicGas(MoveStakeBaseIntrinsicGas, MoveStakePayloadGas, payloadSize)
 }
 
+// payloadData returns the payload data of a ChangeCandidate charged in the intrinsic gas
+func (cc *ChangeCandidate) payloadData() ([]byte, uint64) {
+	return cc.Payload(), MoveStakePayloadGas
+}
+
 // Cost returns the tstal cost of a ChangeCandidate
 func (cc *ChangeCandidate) Cost() (*big.Int, error) {
 	intrinsicGas, err := cc.IntrinsicGas()
@@ -193,6 +193,11 @@ func (cs *CreateStake) IntrinsicGas() (uint64, error) {
 	return CalculateIntrinsicGas(CreateStakeBaseIntrinsicGas, CreateStakePayloadGas, payloadSize)
 }
 
+// payloadData returns the payload data of a CreateStake charged in the intrinsic gas
+func (cs *CreateStake) payloadData() ([]byte, uint64) {
+	return cs.Payload(), CreateStakePayloadGas
+}
+
 // Cost returns the total cost of a CreateStake
 func (cs *CreateStake) Cost() (*big.Int, error) {
 	intrinsicGas, err := cs.IntrinsicGas()
@@ -164,6 +164,11 @@ func (su *Unstake) IntrinsicGas() (uint64, error) {
 	return CalculateIntrinsicGas(ReclaimStakeBaseIntrinsicGas, ReclaimStakePayloadGas, payloadSize)
 }
 
+// payloadData returns the payload data of an Unstake charged in the intrinsic gas
+func (su *Unstake) payloadData() ([]byte, uint64) {
+	return su.Payload(), ReclaimStakePayloadGas
+}
+
 // Cost returns the total cost of a Unstake
 func (su *Unstake) Cost() (*big.Int, error) {
 	intrinsicGas, err := su.IntrinsicGas()
@@ -239,6 +244,11 @@ func (sw *WithdrawStake) IntrinsicGas() (uint64, error) {
 	return CalculateIntrinsicGas(ReclaimStakeBaseIntrinsicGas, ReclaimStakePayloadGas, payloadSize)
 }
 
+// payloadData returns the payload data of a WithdrawStake charged in the intrinsic gas
+func (sw *WithdrawStake) payloadData() ([]byte, uint64) {
+	return sw.Payload(), ReclaimStakePayloadGas
+}
+
 // Cost returns the total cost of a WithdrawStake
 func (sw *WithdrawStake) Cost() (*big.Int, error) {
 	intrinsicGas, err := sw.IntrinsicGas()
@@ -158,6 +158,11 @@ func (rs *Restake) IntrinsicGas() (uint64, error) {
 	return CalculateIntrinsicGas(RestakeBaseIntrinsicGas, RestakePayloadGas, payloadSize)
 }
 
+// payloadData returns the payload data of a Restake charged in the intrinsic gas
+func (rs *Restake) payloadData() ([]byte, uint64) {
+	return rs.Payload(), RestakePayloadGas
+}
+
 // Cost returns the total cost of a Restake
 func (rs *Restake) Cost() (*big.Int, error) {
 	intrinsicGas, err := rs.IntrinsicGas()
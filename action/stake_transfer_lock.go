@@ -0,0 +1,247 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action/actionpb"
+	"github.com/iotexproject/iotex-core/pkg/version"
+)
+
+const (
+	// StakeTransferLockBaseIntrinsicGas represents the base intrinsic gas for StakeTransferLock
+	StakeTransferLockBaseIntrinsicGas = uint64(10000)
+	// StakeTransferLockAddressGas represents the intrinsic gas for each address of StakeTransferLock
+	StakeTransferLockAddressGas = uint64(1000)
+	// MaxStakeTransferLockAddresses is the max number of the addresses in a StakeTransferLock
+	MaxStakeTransferLockAddresses = 32
+
+	stakeTransferLockInterfaceABI = `[
+		{
+			"inputs": [
+				{
+					"internalType": "uint8",
+					"name": "op",
+					"type": "uint8"
+				},
+				{
+					"internalType": "address[]",
+					"name": "addresses",
+					"type": "address[]"
+				}
+			],
+			"name": "stakeTransferLock",
+			"outputs": [],
+			"stateMutability": "nonpayable",
+			"type": "function"
+		}
+	]`
+)
+
+// StakeTransferLockOp defines the operation of StakeTransferLock
+const (
+	// StakeTransferLockOpLock is the operation to lock the transfers of the buckets and the candidate owned by the sender
+	StakeTransferLockOpLock StakeTransferLockOp = iota
+	// StakeTransferLockOpUnlock is the operation to unlock the transfers
+	StakeTransferLockOpUnlock
+	// StakeTransferLockOpAllow is the operation to add the addresses to the allowlist of the transfer destinations
+	StakeTransferLockOpAllow
+	// StakeTransferLockOpDisallow is the operation to remove the addresses from the allowlist
+	StakeTransferLockOpDisallow
+)
+
+var (
+	// ErrInvalidStakeTransferLock indicates the stake transfer lock is invalid
+	ErrInvalidStakeTransferLock = errors.New("invalid stake transfer lock")
+
+	stakeTransferLockMethod abi.Method
+	_                       EthCompatibleAction = (*StakeTransferLock)(nil)
+)
+
+type (
+	// StakeTransferLock is the action to lock the transfers of the buckets and the candidate owned by the sender, or
+	// to change the allowlist of the destinations the transfers are still allowed to while locked
+	StakeTransferLock struct {
+		AbstractAction
+		stake_common
+		op        StakeTransferLockOp
+		addresses []address.Address
+	}
+
+	// StakeTransferLockOp defines the operation of StakeTransferLock
+	StakeTransferLockOp uint8
+)
+
+func init() {
+	stakeTransferLockInterface, err := abi.JSON(strings.NewReader(stakeTransferLockInterfaceABI))
+	if err != nil {
+		panic(err)
+	}
+	var ok bool
+	stakeTransferLockMethod, ok = stakeTransferLockInterface.Methods["stakeTransferLock"]
+	if !ok {
+		panic("fail to load the stakeTransferLock method")
+	}
+}
+
+// String returns the name of the operation
+func (op StakeTransferLockOp) String() string {
+	switch op {
+	case StakeTransferLockOpLock:
+		return "lock"
+	case StakeTransferLockOpUnlock:
+		return "unlock"
+	case StakeTransferLockOpAllow:
+		return "allow"
+	case StakeTransferLockOpDisallow:
+		return "disallow"
+	default:
+		return "unknown"
+	}
+}
+
+// NewStakeTransferLock returns a StakeTransferLock action
+func NewStakeTransferLock(nonce, gasLimit uint64, gasPrice *big.Int, op StakeTransferLockOp, addresses []address.Address) *StakeTransferLock {
+	return &StakeTransferLock{
+		AbstractAction: AbstractAction{
+			version:  version.ProtocolVersion,
+			nonce:    nonce,
+			gasLimit: gasLimit,
+			gasPrice: gasPrice,
+		},
+		op:        op,
+		addresses: addresses,
+	}
+}
+
+// Op returns the operation
+func (act *StakeTransferLock) Op() StakeTransferLockOp { return act.op }
+
+// Addresses returns the addresses to allow or disallow
+func (act *StakeTransferLock) Addresses() []address.Address { return act.addresses }
+
+// IntrinsicGas returns the intrinsic gas of a StakeTransferLock
+func (act *StakeTransferLock) IntrinsicGas() (uint64, error) {
+	return StakeTransferLockBaseIntrinsicGas + uint64(len(act.addresses))*StakeTransferLockAddressGas, nil
+}
+
+// Cost returns the total cost of a StakeTransferLock
+func (act *StakeTransferLock) Cost() (*big.Int, error) {
+	intrinsicGas, err := act.IntrinsicGas()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get intrinsic gas for the StakeTransferLock")
+	}
+	fee := big.NewInt(0).Mul(act.GasPrice(), big.NewInt(0).SetUint64(intrinsicGas))
+	return fee, nil
+}
+
+// SanityCheck validates the variables in the action
+func (act *StakeTransferLock) SanityCheck() error {
+	switch act.op {
+	case StakeTransferLockOpLock, StakeTransferLockOpUnlock:
+		if len(act.addresses) != 0 {
+			return errors.Wrapf(ErrInvalidStakeTransferLock, "%s with addresses", act.op)
+		}
+	case StakeTransferLockOpAllow, StakeTransferLockOpDisallow:
+		if len(act.addresses) == 0 || len(act.addresses) > MaxStakeTransferLockAddresses {
+			return errors.Wrapf(ErrInvalidStakeTransferLock, "%d addresses, expecting 1 to %d", len(act.addresses), MaxStakeTransferLockAddresses)
+		}
+		addrs := make(map[string]struct{}, len(act.addresses))
+		for _, addr := range act.addresses {
+			if addr == nil {
+				return errors.Wrap(ErrInvalidStakeTransferLock, "missing address")
+			}
+			if _, ok := addrs[addr.String()]; ok {
+				return errors.Wrapf(ErrInvalidStakeTransferLock, "duplicate address %s", addr.String())
+			}
+			addrs[addr.String()] = struct{}{}
+		}
+	default:
+		return errors.Wrapf(ErrInvalidStakeTransferLock, "invalid operation %d", act.op)
+	}
+	return act.AbstractAction.SanityCheck()
+}
+
+// Proto converts StakeTransferLock to protobuf
+func (act *StakeTransferLock) Proto() *actionpb.StakeTransferLock {
+	pb := &actionpb.StakeTransferLock{
+		Op:        uint32(act.op),
+		Addresses: make([]string, len(act.addresses)),
+	}
+	for i, addr := range act.addresses {
+		pb.Addresses[i] = addr.String()
+	}
+	return pb
+}
+
+// LoadProto converts protobuf to StakeTransferLock
+func (act *StakeTransferLock) LoadProto(pb *actionpb.StakeTransferLock) error {
+	if pb == nil {
+		return ErrNilProto
+	}
+	if v := pb.GetOp(); v > uint32(StakeTransferLockOpDisallow) {
+		return errors.Wrapf(ErrInvalidStakeTransferLock, "invalid operation %d", v)
+	}
+	act.op = StakeTransferLockOp(pb.GetOp())
+	act.addresses = nil
+	for _, v := range pb.GetAddresses() {
+		addr, err := address.FromString(v)
+		if err != nil {
+			return errors.Wrap(ErrInvalidStakeTransferLock, err.Error())
+		}
+		act.addresses = append(act.addresses, addr)
+	}
+	return nil
+}
+
+// EthData returns the ABI-encoded data for converting to eth tx
+func (act *StakeTransferLock) EthData() ([]byte, error) {
+	addrs := make([]common.Address, len(act.addresses))
+	for i, addr := range act.addresses {
+		addrs[i] = common.BytesToAddress(addr.Bytes())
+	}
+	data, err := stakeTransferLockMethod.Inputs.Pack(uint8(act.op), addrs)
+	if err != nil {
+		return nil, err
+	}
+	return append(stakeTransferLockMethod.ID, data...), nil
+}
+
+// NewStakeTransferLockFromABIBinary parses the smart contract input and creates an action
+func NewStakeTransferLockFromABIBinary(data []byte) (*StakeTransferLock, error) {
+	if len(data) <= 4 || !bytes.Equal(stakeTransferLockMethod.ID, data[:4]) {
+		return nil, errDecodeFailure
+	}
+	paramsMap := map[string]any{}
+	if err := stakeTransferLockMethod.Inputs.UnpackIntoMap(paramsMap, data[4:]); err != nil {
+		return nil, err
+	}
+	op, ok := paramsMap["op"].(uint8)
+	if !ok {
+		return nil, errDecodeFailure
+	}
+	addrs, ok := paramsMap["addresses"].([]common.Address)
+	if !ok {
+		return nil, errDecodeFailure
+	}
+	act := StakeTransferLock{op: StakeTransferLockOp(op)}
+	for i := range addrs {
+		addr, err := address.FromBytes(addrs[i].Bytes())
+		if err != nil {
+			return nil, err
+		}
+		act.addresses = append(act.addresses, addr)
+	}
+	return &act, nil
+}
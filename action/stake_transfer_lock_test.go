@@ -0,0 +1,107 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestStakeTransferLock(t *testing.T) {
+	r := require.New(t)
+	addrs := []address.Address{identityset.Address(1), identityset.Address(2)}
+
+	t.Run("SanityCheck", func(t *testing.T) {
+		tooMany := make([]address.Address, MaxStakeTransferLockAddresses+1)
+		for i := range tooMany {
+			tooMany[i] = identityset.Address(i)
+		}
+		for _, c := range []struct {
+			op    StakeTransferLockOp
+			addrs []address.Address
+			err   bool
+		}{
+			{StakeTransferLockOpLock, nil, false},
+			{StakeTransferLockOpUnlock, nil, false},
+			{StakeTransferLockOpLock, addrs, true},
+			{StakeTransferLockOpAllow, addrs, false},
+			{StakeTransferLockOpDisallow, addrs[:1], false},
+			{StakeTransferLockOpAllow, nil, true},
+			{StakeTransferLockOpAllow, tooMany, true},
+			{StakeTransferLockOpDisallow, []address.Address{addrs[0], addrs[0]}, true},
+			{StakeTransferLockOpAllow, []address.Address{nil}, true},
+			{StakeTransferLockOpDisallow + 1, nil, true},
+		} {
+			err := NewStakeTransferLock(1, 100000, big.NewInt(1), c.op, c.addrs).SanityCheck()
+			if c.err {
+				r.ErrorIs(err, ErrInvalidStakeTransferLock)
+			} else {
+				r.NoError(err)
+			}
+		}
+	})
+
+	t.Run("Gas", func(t *testing.T) {
+		act := NewStakeTransferLock(1, 100000, big.NewInt(10), StakeTransferLockOpAllow, addrs)
+		gas, err := act.IntrinsicGas()
+		r.NoError(err)
+		r.Equal(StakeTransferLockBaseIntrinsicGas+2*StakeTransferLockAddressGas, gas)
+		cost, err := act.Cost()
+		r.NoError(err)
+		r.Equal(new(big.Int).SetUint64(gas*10), cost)
+	})
+
+	t.Run("Proto", func(t *testing.T) {
+		for _, act := range []*StakeTransferLock{
+			NewStakeTransferLock(1, 100000, big.NewInt(1), StakeTransferLockOpLock, nil),
+			NewStakeTransferLock(2, 100000, big.NewInt(1), StakeTransferLockOpAllow, addrs),
+		} {
+			elp := (&EnvelopeBuilder{}).SetNonce(act.Nonce()).SetGasLimit(act.GasLimit()).SetGasPrice(act.GasPrice()).
+				SetAction(act).Build()
+			// the action is kept through the serialization of ActionCore
+			b, err := proto.Marshal(elp.Proto())
+			r.NoError(err)
+			pb := &iotextypes.ActionCore{}
+			r.NoError(proto.Unmarshal(b, pb))
+			elp2, err := (&EnvelopeBuilder{}).BuildFromProto(pb)
+			r.NoError(err)
+			act2, ok := elp2.Action().(*StakeTransferLock)
+			r.True(ok)
+			r.Equal(act.Op(), act2.Op())
+			r.Equal(act.Addresses(), act2.Addresses())
+			r.Equal(act.Nonce(), act2.Nonce())
+		}
+		// the action core without any action is still rejected
+		_, err := (&EnvelopeBuilder{}).BuildFromProto(&iotextypes.ActionCore{})
+		r.Error(err)
+	})
+
+	t.Run("ABI", func(t *testing.T) {
+		for _, act := range []*StakeTransferLock{
+			NewStakeTransferLock(1, 100000, big.NewInt(1), StakeTransferLockOpUnlock, nil),
+			NewStakeTransferLock(2, 100000, big.NewInt(1), StakeTransferLockOpDisallow, addrs),
+		} {
+			data, err := act.EthData()
+			r.NoError(err)
+			act2, err := NewStakeTransferLockFromABIBinary(data)
+			r.NoError(err)
+			r.Equal(act.Op(), act2.Op())
+			r.Equal(act.Addresses(), act2.Addresses())
+			payload, err := newStakingActionFromABIBinary(data)
+			r.NoError(err)
+			r.IsType(&StakeTransferLock{}, payload)
+		}
+		_, err := NewStakeTransferLockFromABIBinary([]byte{1, 2, 3, 4, 5})
+		r.Error(err)
+	})
+}
@@ -149,6 +149,11 @@ func (ts *TransferStake) IntrinsicGas() (uint64, error) {
 	return CalculateIntrinsicGas(MoveStakeBaseIntrinsicGas, MoveStakePayloadGas, payloadSize)
 }
 
+// payloadData returns the payload data of a TransferStake charged in the intrinsic gas
+func (ts *TransferStake) payloadData() ([]byte, uint64) {
+	return ts.Payload(), MoveStakePayloadGas
+}
+
 // Cost returns the tstal cost of a TransferStake
 func (ts *TransferStake) Cost() (*big.Int, error) {
 	intrinsicGas, err := ts.IntrinsicGas()
@@ -0,0 +1,118 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+// the events of the staking state changes
+const (
+	StakingEventBucketCreated       = "BucketCreated"
+	StakingEventBucketUpdated       = "BucketUpdated"
+	StakingEventBucketUnstaked      = "BucketUnstaked"
+	StakingEventBucketWithdrawn     = "BucketWithdrawn"
+	StakingEventBucketMatured       = "BucketMatured"
+	StakingEventCandidateRegistered = "CandidateRegistered"
+	StakingEventCandidateUpdated    = "CandidateUpdated"
+	StakingEventVotesChanged        = "VotesChanged"
+)
+
+// StakingEventsABI is the ABI of the events the native staking protocol emits in the receipt logs, which the tools for
+// the contract events can decode as the ones of the staking protocol address. The duration of a bucket is in days, and
+// the maturity is in unix seconds
+const StakingEventsABI = `[
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "internalType": "uint64", "name": "bucketIndex", "type": "uint64"},
+			{"indexed": true, "internalType": "address", "name": "owner", "type": "address"},
+			{"indexed": true, "internalType": "address", "name": "candidate", "type": "address"},
+			{"indexed": false, "internalType": "uint256", "name": "amount", "type": "uint256"},
+			{"indexed": false, "internalType": "uint32", "name": "duration", "type": "uint32"},
+			{"indexed": false, "internalType": "bool", "name": "autoStake", "type": "bool"}
+		],
+		"name": "BucketCreated",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "internalType": "uint64", "name": "bucketIndex", "type": "uint64"},
+			{"indexed": true, "internalType": "address", "name": "owner", "type": "address"},
+			{"indexed": true, "internalType": "address", "name": "candidate", "type": "address"},
+			{"indexed": false, "internalType": "uint256", "name": "amount", "type": "uint256"},
+			{"indexed": false, "internalType": "uint32", "name": "duration", "type": "uint32"},
+			{"indexed": false, "internalType": "bool", "name": "autoStake", "type": "bool"}
+		],
+		"name": "BucketUpdated",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "internalType": "uint64", "name": "bucketIndex", "type": "uint64"},
+			{"indexed": true, "internalType": "address", "name": "owner", "type": "address"},
+			{"indexed": true, "internalType": "address", "name": "candidate", "type": "address"},
+			{"indexed": false, "internalType": "uint256", "name": "amount", "type": "uint256"}
+		],
+		"name": "BucketUnstaked",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "internalType": "uint64", "name": "bucketIndex", "type": "uint64"},
+			{"indexed": true, "internalType": "address", "name": "owner", "type": "address"},
+			{"indexed": true, "internalType": "address", "name": "candidate", "type": "address"},
+			{"indexed": false, "internalType": "uint256", "name": "amount", "type": "uint256"}
+		],
+		"name": "BucketWithdrawn",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "internalType": "uint64", "name": "bucketIndex", "type": "uint64"},
+			{"indexed": true, "internalType": "address", "name": "owner", "type": "address"},
+			{"indexed": true, "internalType": "address", "name": "candidate", "type": "address"},
+			{"indexed": false, "internalType": "uint256", "name": "amount", "type": "uint256"},
+			{"indexed": false, "internalType": "uint64", "name": "maturity", "type": "uint64"}
+		],
+		"name": "BucketMatured",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "internalType": "address", "name": "candidate", "type": "address"},
+			{"indexed": true, "internalType": "address", "name": "owner", "type": "address"},
+			{"indexed": false, "internalType": "address", "name": "operator", "type": "address"},
+			{"indexed": false, "internalType": "address", "name": "reward", "type": "address"},
+			{"indexed": false, "internalType": "string", "name": "name", "type": "string"}
+		],
+		"name": "CandidateRegistered",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "internalType": "address", "name": "candidate", "type": "address"},
+			{"indexed": true, "internalType": "address", "name": "owner", "type": "address"},
+			{"indexed": false, "internalType": "address", "name": "operator", "type": "address"},
+			{"indexed": false, "internalType": "address", "name": "reward", "type": "address"},
+			{"indexed": false, "internalType": "string", "name": "name", "type": "string"}
+		],
+		"name": "CandidateUpdated",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "internalType": "address", "name": "candidate", "type": "address"},
+			{"indexed": false, "internalType": "uint256", "name": "votes", "type": "uint256"},
+			{"indexed": false, "internalType": "uint256", "name": "selfStake", "type": "uint256"}
+		],
+		"name": "VotesChanged",
+		"type": "event"
+	}
+]`
@@ -0,0 +1,251 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action/actionpb"
+	"github.com/iotexproject/iotex-core/pkg/version"
+)
+
+const (
+	// TimeLockedTransferIntrinsicGas represents the intrinsic gas for TimeLockedTransfer
+	TimeLockedTransferIntrinsicGas = uint64(20000)
+
+	timeLockedTransferInterfaceABI = `[
+		{
+			"inputs": [
+				{
+					"internalType": "address",
+					"name": "recipient",
+					"type": "address"
+				},
+				{
+					"internalType": "uint64",
+					"name": "unlockHeight",
+					"type": "uint64"
+				},
+				{
+					"internalType": "uint64",
+					"name": "unlockTimestamp",
+					"type": "uint64"
+				}
+			],
+			"name": "timeLockedTransfer",
+			"outputs": [],
+			"stateMutability": "payable",
+			"type": "function"
+		}
+	]`
+)
+
+var (
+	// ErrInvalidTimeLockedTransfer indicates the time-locked transfer is invalid
+	ErrInvalidTimeLockedTransfer = errors.New("invalid time-locked transfer")
+
+	// _accountProtocolEthAddr is the address of the account protocol, which the eth tx of TimeLockedTransfer is sent to
+	_accountProtocolEthAddr = common.Address(hash.Hash160b([]byte("account")))
+
+	timeLockedTransferMethod abi.Method
+	_                        EthCompatibleAction = (*TimeLockedTransfer)(nil)
+)
+
+// TimeLockedTransfer is the action to transfer an amount to the recipient, which is locked until the unlock height
+// or the unlock timestamp, whichever is set. The locked amount cannot be canceled or transferred
+type TimeLockedTransfer struct {
+	AbstractAction
+	amount          *big.Int
+	recipient       address.Address
+	unlockHeight    uint64
+	unlockTimestamp uint64
+}
+
+func init() {
+	timeLockedTransferInterface, err := abi.JSON(strings.NewReader(timeLockedTransferInterfaceABI))
+	if err != nil {
+		panic(err)
+	}
+	var ok bool
+	timeLockedTransferMethod, ok = timeLockedTransferInterface.Methods["timeLockedTransfer"]
+	if !ok {
+		panic("fail to load the timeLockedTransfer method")
+	}
+}
+
+// NewTimeLockedTransfer returns a TimeLockedTransfer action, the unlock timestamp is the unix time in seconds
+func NewTimeLockedTransfer(
+	nonce, gasLimit uint64,
+	gasPrice *big.Int,
+	amount *big.Int,
+	recipient address.Address,
+	unlockHeight, unlockTimestamp uint64,
+) *TimeLockedTransfer {
+	return &TimeLockedTransfer{
+		AbstractAction: AbstractAction{
+			version:  version.ProtocolVersion,
+			nonce:    nonce,
+			gasLimit: gasLimit,
+			gasPrice: gasPrice,
+		},
+		amount:          amount,
+		recipient:       recipient,
+		unlockHeight:    unlockHeight,
+		unlockTimestamp: unlockTimestamp,
+	}
+}
+
+// Amount returns the amount to lock
+func (act *TimeLockedTransfer) Amount() *big.Int { return act.amount }
+
+// Recipient returns the recipient of the locked amount
+func (act *TimeLockedTransfer) Recipient() address.Address { return act.recipient }
+
+// Destination returns the recipient address
+func (act *TimeLockedTransfer) Destination() string { return act.recipient.String() }
+
+// UnlockHeight returns the height the amount is unlocked at, 0 if locked by the timestamp
+func (act *TimeLockedTransfer) UnlockHeight() uint64 { return act.unlockHeight }
+
+// UnlockTimestamp returns the unix time in seconds the amount is unlocked at, 0 if locked by the height
+func (act *TimeLockedTransfer) UnlockTimestamp() uint64 { return act.unlockTimestamp }
+
+// IntrinsicGas returns the intrinsic gas of a TimeLockedTransfer
+func (act *TimeLockedTransfer) IntrinsicGas() (uint64, error) {
+	return TimeLockedTransferIntrinsicGas, nil
+}
+
+// Cost returns the total cost of a TimeLockedTransfer
+func (act *TimeLockedTransfer) Cost() (*big.Int, error) {
+	intrinsicGas, err := act.IntrinsicGas()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get intrinsic gas for the TimeLockedTransfer")
+	}
+	fee := big.NewInt(0).Mul(act.GasPrice(), big.NewInt(0).SetUint64(intrinsicGas))
+	return fee.Add(fee, act.amount), nil
+}
+
+// SanityCheck validates the variables in the action
+func (act *TimeLockedTransfer) SanityCheck() error {
+	if act.amount == nil || act.amount.Sign() <= 0 {
+		return errors.Wrap(ErrInvalidTimeLockedTransfer, "amount must be positive")
+	}
+	if act.recipient == nil {
+		return errors.Wrap(ErrInvalidTimeLockedTransfer, "missing recipient")
+	}
+	if (act.unlockHeight == 0) == (act.unlockTimestamp == 0) {
+		return errors.Wrap(ErrInvalidTimeLockedTransfer, "exactly one of unlock height and unlock timestamp should be set")
+	}
+	return act.AbstractAction.SanityCheck()
+}
+
+// Proto converts TimeLockedTransfer to protobuf
+func (act *TimeLockedTransfer) Proto() *actionpb.TimeLockedTransfer {
+	pb := &actionpb.TimeLockedTransfer{
+		UnlockHeight:    act.unlockHeight,
+		UnlockTimestamp: act.unlockTimestamp,
+	}
+	if act.amount != nil {
+		pb.Amount = act.amount.String()
+	}
+	if act.recipient != nil {
+		pb.Recipient = act.recipient.String()
+	}
+	return pb
+}
+
+// LoadProto converts protobuf to TimeLockedTransfer
+func (act *TimeLockedTransfer) LoadProto(pb *actionpb.TimeLockedTransfer) error {
+	if pb == nil {
+		return ErrNilProto
+	}
+	act.amount = big.NewInt(0)
+	if v := pb.GetAmount(); v != "" {
+		amount, ok := new(big.Int).SetString(v, 10)
+		if !ok {
+			return errors.Wrapf(ErrInvalidTimeLockedTransfer, "invalid amount %s", v)
+		}
+		act.amount = amount
+	}
+	act.recipient = nil
+	if v := pb.GetRecipient(); v != "" {
+		addr, err := address.FromString(v)
+		if err != nil {
+			return errors.Wrap(ErrInvalidTimeLockedTransfer, err.Error())
+		}
+		act.recipient = addr
+	}
+	act.unlockHeight = pb.GetUnlockHeight()
+	act.unlockTimestamp = pb.GetUnlockTimestamp()
+	return nil
+}
+
+// EthTo returns the address of the account protocol for converting to eth tx
+func (act *TimeLockedTransfer) EthTo() (*common.Address, error) {
+	return &_accountProtocolEthAddr, nil
+}
+
+// Value returns the amount as the value of the eth tx
+func (act *TimeLockedTransfer) Value() *big.Int { return act.amount }
+
+// EthData returns the ABI-encoded data for converting to eth tx
+func (act *TimeLockedTransfer) EthData() ([]byte, error) {
+	if act.recipient == nil {
+		return nil, errors.Wrap(ErrInvalidTimeLockedTransfer, "missing recipient")
+	}
+	data, err := timeLockedTransferMethod.Inputs.Pack(
+		common.BytesToAddress(act.recipient.Bytes()), act.unlockHeight, act.unlockTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	return append(timeLockedTransferMethod.ID, data...), nil
+}
+
+// NewTimeLockedTransferFromABIBinary parses the smart contract input and creates an action, the amount is the
+// value of the eth tx
+func NewTimeLockedTransferFromABIBinary(data []byte, value *big.Int) (*TimeLockedTransfer, error) {
+	if len(data) <= 4 || !bytes.Equal(timeLockedTransferMethod.ID, data[:4]) {
+		return nil, errDecodeFailure
+	}
+	paramsMap := map[string]any{}
+	if err := timeLockedTransferMethod.Inputs.UnpackIntoMap(paramsMap, data[4:]); err != nil {
+		return nil, err
+	}
+	recipient, ok := paramsMap["recipient"].(common.Address)
+	if !ok {
+		return nil, errDecodeFailure
+	}
+	unlockHeight, ok := paramsMap["unlockHeight"].(uint64)
+	if !ok {
+		return nil, errDecodeFailure
+	}
+	unlockTimestamp, ok := paramsMap["unlockTimestamp"].(uint64)
+	if !ok {
+		return nil, errDecodeFailure
+	}
+	addr, err := address.FromBytes(recipient.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	act := TimeLockedTransfer{
+		amount:          big.NewInt(0),
+		recipient:       addr,
+		unlockHeight:    unlockHeight,
+		unlockTimestamp: unlockTimestamp,
+	}
+	if value != nil {
+		act.amount.Set(value)
+	}
+	return &act, nil
+}
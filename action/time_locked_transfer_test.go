@@ -0,0 +1,109 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestTimeLockedTransfer(t *testing.T) {
+	r := require.New(t)
+	recipient := identityset.Address(1)
+
+	t.Run("SanityCheck", func(t *testing.T) {
+		r.NoError(NewTimeLockedTransfer(1, 30000, big.NewInt(1), big.NewInt(10), recipient, 100, 0).SanityCheck())
+		r.NoError(NewTimeLockedTransfer(1, 30000, big.NewInt(1), big.NewInt(10), recipient, 0, 1700000000).SanityCheck())
+		for _, c := range []*TimeLockedTransfer{
+			NewTimeLockedTransfer(1, 30000, big.NewInt(1), big.NewInt(0), recipient, 100, 0),
+			NewTimeLockedTransfer(1, 30000, big.NewInt(1), big.NewInt(-1), recipient, 100, 0),
+			NewTimeLockedTransfer(1, 30000, big.NewInt(1), big.NewInt(10), nil, 100, 0),
+			NewTimeLockedTransfer(1, 30000, big.NewInt(1), big.NewInt(10), recipient, 0, 0),
+			NewTimeLockedTransfer(1, 30000, big.NewInt(1), big.NewInt(10), recipient, 100, 1700000000),
+		} {
+			r.ErrorIs(c.SanityCheck(), ErrInvalidTimeLockedTransfer)
+		}
+	})
+
+	t.Run("Cost", func(t *testing.T) {
+		act := NewTimeLockedTransfer(1, 30000, big.NewInt(10), big.NewInt(7), recipient, 100, 0)
+		cost, err := act.Cost()
+		r.NoError(err)
+		r.Equal(new(big.Int).SetUint64(TimeLockedTransferIntrinsicGas*10+7), cost)
+	})
+
+	t.Run("Proto", func(t *testing.T) {
+		act := NewTimeLockedTransfer(1, 30000, big.NewInt(1), big.NewInt(10), recipient, 0, 1700000000)
+		elp := (&EnvelopeBuilder{}).SetNonce(act.Nonce()).SetGasLimit(act.GasLimit()).SetGasPrice(act.GasPrice()).
+			SetAction(act).Build()
+		b, err := proto.Marshal(elp.Proto())
+		r.NoError(err)
+		pb := &iotextypes.ActionCore{}
+		r.NoError(proto.Unmarshal(b, pb))
+		elp2, err := (&EnvelopeBuilder{}).BuildFromProto(pb)
+		r.NoError(err)
+		act2, ok := elp2.Action().(*TimeLockedTransfer)
+		r.True(ok)
+		r.Equal(act.Amount(), act2.Amount())
+		r.Equal(recipient.String(), act2.Recipient().String())
+		r.Zero(act2.UnlockHeight())
+		r.EqualValues(1700000000, act2.UnlockTimestamp())
+	})
+
+	t.Run("ABI", func(t *testing.T) {
+		act := NewTimeLockedTransfer(1, 30000, big.NewInt(1), big.NewInt(10), recipient, 100, 0)
+		data, err := act.EthData()
+		r.NoError(err)
+		to, err := act.EthTo()
+		r.NoError(err)
+		r.Equal(_accountProtocolEthAddr, *to)
+
+		eb := &EnvelopeBuilder{}
+		eb.SetChainID(2)
+		elp, err := eb.BuildAccountAction(types.NewTx(&types.LegacyTx{
+			Nonce:    1,
+			GasPrice: big.NewInt(1),
+			Gas:      30000,
+			To:       to,
+			Value:    act.Value(),
+			Data:     data,
+		}))
+		r.NoError(err)
+		act2, ok := elp.Action().(*TimeLockedTransfer)
+		r.True(ok)
+		r.Equal(act.Amount(), act2.Amount())
+		r.Equal(recipient.String(), act2.Recipient().String())
+		r.EqualValues(100, act2.UnlockHeight())
+		r.Zero(act2.UnlockTimestamp())
+
+		_, err = eb.BuildAccountAction(types.NewTx(&types.LegacyTx{
+			Nonce:    1,
+			GasPrice: big.NewInt(1),
+			Gas:      30000,
+			To:       to,
+			Value:    act.Value(),
+			Data:     []byte("InvalidMethodSig"),
+		}))
+		r.ErrorIs(err, ErrInvalidABI)
+		to = &_rewardingProtocolEthAddr
+		_, err = eb.BuildAccountAction(types.NewTx(&types.LegacyTx{
+			Nonce:    1,
+			GasPrice: big.NewInt(1),
+			Gas:      30000,
+			To:       to,
+			Value:    act.Value(),
+			Data:     data,
+		}))
+		r.ErrorIs(err, ErrInvalidAct)
+	})
+}
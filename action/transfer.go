@@ -134,6 +134,11 @@ func (tsf *Transfer) IntrinsicGas() (uint64, error) {
 	return CalculateIntrinsicGas(TransferBaseIntrinsicGas, TransferPayloadGas, payloadSize)
 }
 
+// payloadData returns the payload data of a transfer charged in the intrinsic gas
+func (tsf *Transfer) payloadData() ([]byte, uint64) {
+	return tsf.Payload(), TransferPayloadGas
+}
+
 // Cost returns the total cost of a transfer
 func (tsf *Transfer) Cost() (*big.Int, error) {
 	intrinsicGas, err := tsf.IntrinsicGas()
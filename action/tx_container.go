@@ -39,6 +39,10 @@ func (etx *txContainer) typeToEncoding() (iotextypes.Encoding, error) {
 			// tx has pre-EIP155 signature
 			return iotextypes.Encoding_ETHEREUM_UNPROTECTED, nil
 		}
+	case types.AccessListTxType:
+		return iotextypes.Encoding_ETHEREUM_ACCESSLIST, nil
+	case types.DynamicFeeTxType:
+		return iotextypes.Encoding_ETHEREUM_DYNAMICFEE, nil
 	default:
 		return 0, ErrNotSupported
 	}
@@ -92,6 +96,8 @@ func (etx *txContainer) Unfold(selp *SealedEnvelope, ctx context.Context, checke
 		elp, err = elpBuilder.BuildStakingAction(etx.tx)
 	} else if isRewarding {
 		elp, err = elpBuilder.BuildRewardingAction(etx.tx)
+	} else if to := etx.tx.To(); to != nil && *to == _accountProtocolEthAddr {
+		elp, err = elpBuilder.BuildAccountAction(etx.tx)
 	} else {
 		elp, err = elpBuilder.BuildTransfer(etx.tx)
 	}
@@ -126,6 +132,11 @@ func (etx *txContainer) IntrinsicGas() (uint64, error) {
 	return gas, nil
 }
 
+// payloadData returns the payload data of a tx container charged in the intrinsic gas
+func (etx *txContainer) payloadData() ([]byte, uint64) {
+	return etx.tx.Data(), ExecutionDataGas
+}
+
 func (etx *txContainer) SetEnvelopeContext(*AbstractAction) {}
 
 func (etx *txContainer) SanityCheck() error {
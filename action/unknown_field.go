@@ -0,0 +1,39 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// AppendUnknownFields appends ext to the unknown fields of m, where ext is one of the messages of actionpb that
+// declare the fields added to the message of iotex-proto
+func AppendUnknownFields(m, ext proto.Message) error {
+	b, err := proto.Marshal(ext)
+	if err != nil {
+		return err
+	}
+	if len(b) > 0 {
+		m.ProtoReflect().SetUnknown(append(m.ProtoReflect().GetUnknown(), b...))
+	}
+	return nil
+}
+
+// LoadUnknownFields loads ext from the unknown fields of m
+func LoadUnknownFields(m, ext proto.Message) error {
+	return proto.Unmarshal(m.ProtoReflect().GetUnknown(), ext)
+}
+
+// MustAppendUnknownFields appends ext to the unknown fields of m, and panics if ext fails to serialize, e.g., a
+// string field of invalid utf-8
+func MustAppendUnknownFields(m, ext proto.Message) {
+	if err := AppendUnknownFields(m, ext); err != nil {
+		log.L().Panic("failed to serialize unknown fields", zap.Error(err))
+	}
+}
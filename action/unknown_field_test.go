@@ -0,0 +1,70 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"testing"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action/actionpb"
+)
+
+func TestUnknownFieldNumbers(t *testing.T) {
+	r := require.New(t)
+	for _, c := range []struct {
+		msg, ext proto.Message
+	}{
+		{&iotextypes.ActionCore{}, &actionpb.ActionCoreExt{}},
+		{&iotextypes.Action{}, &actionpb.ActionExt{}},
+		{&iotextypes.CandidateBasicInfo{}, &actionpb.CandidateBasicInfoExt{}},
+		{&iotextypes.Receipt{}, &actionpb.ReceiptExt{}},
+		{&iotextypes.CandidateV2{}, &actionpb.CandidateV2Ext{}},
+		{&iotextypes.AccountMeta{}, &actionpb.AccountMetaExt{}},
+		{&iotextypes.BlockHeaderCore{}, &actionpb.BlockHeaderCoreExt{}},
+	} {
+		// the added fields must not collide with the ones defined in iotex-proto
+		desc, ext := c.msg.ProtoReflect().Descriptor(), c.ext.ProtoReflect().Descriptor()
+		fields := ext.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			n := fields.Get(i).Number()
+			r.Nil(desc.Fields().ByNumber(n), "%s field %d", ext.Name(), n)
+			r.False(desc.ReservedRanges().Has(n), "%s field %d", ext.Name(), n)
+		}
+	}
+}
+
+func TestUnknownFields(t *testing.T) {
+	r := require.New(t)
+	hv := uint64(HashV2)
+	core := &iotextypes.ActionCore{Nonce: 1}
+	r.NoError(AppendUnknownFields(core, &actionpb.ActionCoreExt{HashVersion: &hv}))
+	r.NoError(AppendUnknownFields(core, &actionpb.ActionCoreExt{
+		TimeLockedTransfer: &actionpb.TimeLockedTransfer{Amount: "1", UnlockHeight: 2},
+	}))
+	// nothing is appended for an empty ext
+	r.NoError(AppendUnknownFields(core, &actionpb.ActionCoreExt{}))
+
+	b, err := proto.Marshal(core)
+	r.NoError(err)
+	core = &iotextypes.ActionCore{}
+	r.NoError(proto.Unmarshal(b, core))
+	r.EqualValues(1, core.GetNonce())
+	ext := actionpb.ActionCoreExt{}
+	r.NoError(LoadUnknownFields(core, &ext))
+	r.Equal(hv, ext.GetHashVersion())
+	r.Equal("1", ext.GetTimeLockedTransfer().GetAmount())
+	r.EqualValues(2, ext.GetTimeLockedTransfer().GetUnlockHeight())
+	r.Nil(ext.GetStakeTransferLock())
+
+	// invalid utf-8 fails to serialize
+	r.Error(AppendUnknownFields(core, &actionpb.ReceiptExt{GasPayer: "\xff"}))
+	r.Panics(func() { MustAppendUnknownFields(core, &actionpb.ReceiptExt{GasPayer: "\xff"}) })
+	core.ProtoReflect().SetUnknown([]byte{0xff})
+	r.Error(LoadUnknownFields(core, &ext))
+}
@@ -100,6 +100,27 @@ func (ap *accountPool) PopPeek() *action.SealedEnvelope {
 	return act
 }
 
+// PopPeekWhere pops the action with the largest nonce of the account with the lowest priority, among the accounts
+// whose action with the largest nonce matches
+func (ap *accountPool) PopPeekWhere(match func(*action.SealedEnvelope) bool) *action.SealedEnvelope {
+	idx := -1
+	for i, account := range ap.priorityQueue {
+		if act := account.actQueue.ActionWithLargestNonce(); act == nil || !match(act) {
+			continue
+		}
+		if idx < 0 || ap.priorityQueue.Less(i, idx) {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		return nil
+	}
+	act := ap.priorityQueue[idx].actQueue.PopActionWithLargestNonce()
+	heap.Fix(&ap.priorityQueue, idx)
+
+	return act
+}
+
 func (ap *accountPool) Range(callback func(addr string, acct ActQueue)) {
 	for addr, account := range ap.accounts {
 		callback(addr, account.actQueue)
@@ -8,13 +8,16 @@ package actpool
 import (
 	"context"
 	"encoding/hex"
+	"math/big"
 	"sort"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/iotexproject/go-pkgs/cache/ttl"
 	"github.com/iotexproject/go-pkgs/hash"
@@ -41,12 +44,22 @@ var (
 		Name: "iotex_actpool_rejection_metrics",
 		Help: "actpool metrics.",
 	}, []string{"type"})
+	_actpoolBytesMtc = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "iotex_actpool_bytes",
+		Help: "bytes of the actions in actpool by tier.",
+	}, []string{"tier"})
 	// ErrGasTooHigh error when the intrinsic gas of an action is too high
 	ErrGasTooHigh = errors.New("action gas is too high")
+	// ErrGasLimitExceedsBlock error when the gas limit of an action exceeds the block gas limit, so that no block
+	// can include it
+	ErrGasLimitExceedsBlock = errors.New("action gas limit exceeds the block gas limit")
+	// ErrActionTooLarge error when the size of an action exceeds the share of a block body an action can take
+	ErrActionTooLarge = errors.New("action is too large for a block")
 )
 
 func init() {
 	prometheus.MustRegister(_actpoolMtc)
+	prometheus.MustRegister(_actpoolBytesMtc)
 }
 
 // ActPool is the interface of actpool
@@ -58,12 +71,21 @@ type ActPool interface {
 	PendingActionMap() map[string][]*action.SealedEnvelope
 	// Add adds an action into the pool after passing validation
 	Add(ctx context.Context, act *action.SealedEnvelope) error
+	// Check runs the validation of Add on the action against the confirmed state and the pending actions of the
+	// sender, without adding it into the pool
+	Check(ctx context.Context, act *action.SealedEnvelope) error
 	// GetPendingNonce returns pending nonce in pool given an account address
 	GetPendingNonce(addr string) (uint64, error)
 	// GetUnconfirmedActs returns unconfirmed actions in pool given an account address
 	GetUnconfirmedActs(addr string) []*action.SealedEnvelope
 	// GetActionByHash returns the pending action in pool given action's hash
 	GetActionByHash(hash hash.Hash256) (*action.SealedEnvelope, error)
+	// GetPendingActionInfo returns the pending action in pool given action's hash, along with its position in the
+	// queue of the sender, whether it is executable and when it was put into the pool
+	GetPendingActionInfo(hash hash.Hash256) (*action.SealedEnvelope, *PendingActionInfo, error)
+	// GetNonceDetail returns the confirmed nonce and the pending nonce of an account, along with the missing nonces
+	// and the actions in pool
+	GetNonceDetail(addr string) (*NonceDetail, error)
 	// GetSize returns the act pool size
 	GetSize() uint64
 	// GetCapacity returns the act pool capacity
@@ -72,6 +94,10 @@ type ActPool interface {
 	GetGasSize() uint64
 	// GetGasCapacity returns the act pool gas capacity
 	GetGasCapacity() uint64
+	// NoncePolicy returns the policy of the nonces of the actions accepted into the pool and packed into a block
+	NoncePolicy() NoncePolicy
+	// Status returns the usage of the pool along with its policies
+	Status() *ActPoolStatus
 	// DeleteAction deletes an invalid action from pool
 	DeleteAction(address.Address)
 	// ReceiveBlock will be called when a new block is committed
@@ -80,6 +106,43 @@ type ActPool interface {
 	AddActionEnvelopeValidators(...action.SealedEnvelopeValidator)
 }
 
+// PendingActionInfo is the state of an action waiting in the pool
+type PendingActionInfo struct {
+	// Position is the number of the actions of the same sender ahead of it in the order of nonce
+	Position int
+	// Executable is true if the actions ahead of it leave no nonce gap and the balance covers them, so it can be
+	// packed into the next block
+	Executable bool
+	// AddedAt is when the action was put into the pool
+	AddedAt time.Time
+	// MinReplacementGasFeeCap is the lowest gas fee cap of an action of the same nonce replacing it, or nil if an
+	// action of any gas fee cap replaces it
+	MinReplacementGasFeeCap *big.Int
+}
+
+// ActPoolStatus is the usage of the pool along with its policies
+type ActPoolStatus struct {
+	Size        uint64
+	Capacity    uint64
+	GasSize     uint64
+	GasCapacity uint64
+	// NoncePolicy is the name of the nonce policy
+	NoncePolicy string
+}
+
+// NonceDetail is the state of the nonces of an account in the pool
+type NonceDetail struct {
+	// ConfirmedNonce is the nonce of the next action of the account in the confirmed state
+	ConfirmedNonce uint64
+	// PendingNonce is the nonce next to the actions in pool which can be packed into the next block, i.e. the end of
+	// the contiguous nonces from the confirmed nonce
+	PendingNonce uint64
+	// Gaps are the missing nonces between the confirmed nonce and the largest nonce in pool, in ascending order
+	Gaps []uint64
+	// Actions are the actions of the account in pool, in the order of nonce
+	Actions []*action.SealedEnvelope
+}
+
 // SortedActions is a slice of actions that implements sort.Interface to sort by Value.
 type SortedActions []*action.SealedEnvelope
 
@@ -98,11 +161,15 @@ type actPool struct {
 	accountDesActs           *destinationMap
 	allActions               *ttl.Cache
 	gasInPool                uint64
+	blockGasLimit            uint64
+	smallBytesInPool         uint64
+	largeBytesInPool         uint64
 	actionEnvelopeValidators []action.SealedEnvelopeValidator
 	timerFactory             *prometheustimer.TimerFactory
 	senderBlackList          map[string]bool
 	jobQueue                 []chan workerJob
 	worker                   []*queueWorker
+	noncePolicy              NoncePolicy
 }
 
 // NewActPool constructs a new actpool
@@ -115,6 +182,10 @@ func NewActPool(g genesis.Genesis, sf protocol.StateReader, cfg Config, opts ...
 	for _, bannedSender := range cfg.BlackList {
 		senderBlackList[bannedSender] = true
 	}
+	noncePolicy, err := NewNoncePolicy(cfg.NoncePolicy, cfg.MaxNumActsPerAcct)
+	if err != nil {
+		return nil, err
+	}
 
 	actsMap, _ := ttl.NewCache()
 	ap := &actPool{
@@ -126,6 +197,7 @@ func NewActPool(g genesis.Genesis, sf protocol.StateReader, cfg Config, opts ...
 		allActions:      actsMap,
 		jobQueue:        make([]chan workerJob, _numWorker),
 		worker:          make([]*queueWorker, _numWorker),
+		noncePolicy:     noncePolicy,
 	}
 	for _, opt := range opts {
 		if err := opt(ap); err != nil {
@@ -186,9 +258,36 @@ func (ap *actPool) reset() {
 
 func (ap *actPool) ReceiveBlock(*block.Block) error {
 	ap.reset()
+	ap.revalidateGasLimit()
 	return nil
 }
 
+// revalidateGasLimit evicts the actions which no block can include anymore in the background, once the block gas
+// limit is lowered from the one seen at the previous block
+func (ap *actPool) revalidateGasLimit() {
+	limit := protocol.MustGetBlockCtx(ap.context(context.Background())).GasLimit
+	if prev := atomic.SwapUint64(&ap.blockGasLimit, limit); prev == 0 || limit >= prev {
+		return
+	}
+	go ap.evictGasLimitAbove(limit)
+}
+
+// evictGasLimitAbove evicts the actions of the gas limit above the limit, and returns the number of them
+func (ap *actPool) evictGasLimitAbove(limit uint64) int {
+	var evicted int
+	for _, worker := range ap.worker {
+		evicted += worker.Evict(func(act *action.SealedEnvelope) bool {
+			return act.GasLimit() > limit
+		})
+	}
+	if evicted > 0 {
+		_actpoolMtc.WithLabelValues("evictedOverBlockGasLimit").Add(float64(evicted))
+		log.Logger("actpool").Info("Evicted the actions over the block gas limit.",
+			zap.Uint64("blockGasLimit", limit), zap.Int("evicted", evicted))
+	}
+	return evicted
+}
+
 // PendingActionMap returns an action interator with all accepted actions
 func (ap *actPool) PendingActionMap() map[string][]*action.SealedEnvelope {
 	var (
@@ -221,34 +320,65 @@ func (ap *actPool) Add(ctx context.Context, act *action.SealedEnvelope) error {
 	defer span.End()
 	ctx = ap.context(ctx)
 
+	intrinsicGas, err := ap.checkAct(ctx, act)
+	if err != nil {
+		return err
+	}
+
+	return ap.enqueue(
+		ctx,
+		act,
+		atomic.LoadUint64(&ap.gasInPool) > ap.cfg.MaxGasLimitPerPool-intrinsicGas ||
+			uint64(ap.allActions.Count()) >= ap.cfg.MaxNumActsPerPool,
+	)
+}
+
+func (ap *actPool) Check(ctx context.Context, act *action.SealedEnvelope) error {
+	ctx = ap.context(ctx)
+	if _, err := ap.checkAct(ctx, act); err != nil {
+		return err
+	}
+	return ap.worker[ap.allocatedWorker(act.SenderAddress())].Check(ctx, act)
+}
+
+// checkAct checks the action without the state of the sender, and returns its intrinsic gas
+func (ap *actPool) checkAct(ctx context.Context, act *action.SealedEnvelope) (uint64, error) {
 	// system action is only added by proposer when creating a block
 	if action.IsSystemAction(act) {
-		return action.ErrInvalidAct
+		return 0, action.ErrInvalidAct
 	}
 
 	if err := checkSelpData(act); err != nil {
-		return err
+		return 0, err
 	}
 
 	if err := ap.checkSelpWithoutState(ctx, act); err != nil {
-		return err
+		return 0, err
 	}
 
 	intrinsicGas, err := act.IntrinsicGas()
 	if err != nil {
-		return err
+		return 0, err
 	}
 	if intrinsicGas > ap.cfg.MaxGasLimitPerPool {
 		_actpoolMtc.WithLabelValues("overMaxGasLimitPerPool").Inc()
-		return ErrGasTooHigh
+		return 0, ErrGasTooHigh
 	}
-
-	return ap.enqueue(
-		ctx,
-		act,
-		atomic.LoadUint64(&ap.gasInPool) > ap.cfg.MaxGasLimitPerPool-intrinsicGas ||
-			uint64(ap.allActions.Count()) >= ap.cfg.MaxNumActsPerPool,
-	)
+	// the action over the block gas limit would sit in the pool until it expires
+	if blockGasLimit := protocol.MustGetBlockCtx(ctx).GasLimit; act.GasLimit() > blockGasLimit {
+		_actpoolMtc.WithLabelValues("overBlockGasLimit").Inc()
+		return 0, errors.Wrapf(ErrGasLimitExceedsBlock, "gas limit %d, block gas limit %d", act.GasLimit(), blockGasLimit)
+	}
+	size := actionSize(act)
+	if maxSize := ap.cfg.MaxActionSize(); maxSize > 0 && size > maxSize {
+		_actpoolMtc.WithLabelValues("overMaxActionSize").Inc()
+		return 0, errors.Wrapf(ErrActionTooLarge, "size %d, max size %d", size, maxSize)
+	}
+	if _, budget, _ := ap.tier(ap.isLarge(size)); budget > 0 && size > budget {
+		_actpoolMtc.WithLabelValues("overMaxBytesPerPool").Inc()
+		return 0, action.ErrOversizedData
+	}
+	return intrinsicGas, nil
 }
 
 func checkSelpData(act *action.SealedEnvelope) error {
@@ -273,7 +403,6 @@ func checkSelpData(act *action.SealedEnvelope) error {
 func (ap *actPool) checkSelpWithoutState(ctx context.Context, selp *action.SealedEnvelope) error {
 	span := tracer.SpanFromContext(ctx)
 	span.AddEvent("actPool.checkSelpWithoutState")
-	defer span.End()
 
 	hash, _ := selp.Hash()
 	// Reject action if it already exists in pool
@@ -286,7 +415,7 @@ func (ap *actPool) checkSelpWithoutState(ctx context.Context, selp *action.Seale
 	if selp.Encoding() != uint32(iotextypes.Encoding_ETHEREUM_UNPROTECTED) && selp.GasFeeCap().Cmp(ap.cfg.MinGasPrice()) < 0 {
 		_actpoolMtc.WithLabelValues("gasPriceLower").Inc()
 		actHash, _ := selp.Hash()
-		log.L().Debug("action rejected due to low gas price",
+		log.Logger("actpool").Debug("action rejected due to low gas price",
 			zap.String("actionHash", hex.EncodeToString(actHash[:])),
 			zap.String("GasFeeCap", selp.GasFeeCap().String()))
 		return action.ErrUnderpriced
@@ -326,6 +455,32 @@ func (ap *actPool) GetPendingNonce(addrStr string) (uint64, error) {
 	return confirmedState.PendingNonce(), nil
 }
 
+// GetNonceDetail returns the state of the nonces of an account in pool, or the confirmed nonce if the account has
+// no action in pool
+func (ap *actPool) GetNonceDetail(addrStr string) (*NonceDetail, error) {
+	addr, err := address.FromString(addrStr)
+	if err != nil {
+		return nil, err
+	}
+	if detail, ok := ap.worker[ap.allocatedWorker(addr)].NonceDetail(addr); ok {
+		return detail, nil
+	}
+	ctx := ap.context(context.Background())
+	confirmedState, err := accountutil.AccountState(ctx, ap.sf, addr)
+	if err != nil {
+		return nil, err
+	}
+	nonce := confirmedState.PendingNonce()
+	if protocol.MustGetFeatureCtx(ctx).UseZeroNonceForFreshAccount {
+		nonce = confirmedState.PendingNonceConsideringFreshAccount()
+	}
+	return &NonceDetail{
+		ConfirmedNonce: nonce,
+		PendingNonce:   nonce,
+		Actions:        []*action.SealedEnvelope{},
+	}, nil
+}
+
 // GetUnconfirmedActs returns unconfirmed actions in pool given an account address
 func (ap *actPool) GetUnconfirmedActs(addrStr string) []*action.SealedEnvelope {
 	addr, err := address.FromString(addrStr)
@@ -350,6 +505,20 @@ func (ap *actPool) GetActionByHash(hash hash.Hash256) (*action.SealedEnvelope, e
 	return act.(*action.SealedEnvelope), nil
 }
 
+// GetPendingActionInfo returns the pending action in pool given action's hash, with its state in the pool
+func (ap *actPool) GetPendingActionInfo(hash hash.Hash256) (*action.SealedEnvelope, *PendingActionInfo, error) {
+	selp, err := ap.GetActionByHash(hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	sender := selp.SenderAddress()
+	info, ok := ap.worker[ap.allocatedWorker(sender)].PendingActionInfo(sender, selp.Nonce())
+	if !ok {
+		return nil, nil, errors.Wrapf(action.ErrNotFound, "action hash %x does not exist in pool", hash)
+	}
+	return selp, info, nil
+}
+
 // GetSize returns the act pool size
 func (ap *actPool) GetSize() uint64 {
 	return uint64(ap.allActions.Count())
@@ -370,6 +539,22 @@ func (ap *actPool) GetGasCapacity() uint64 {
 	return ap.cfg.MaxGasLimitPerPool
 }
 
+// NoncePolicy returns the nonce policy of the pool
+func (ap *actPool) NoncePolicy() NoncePolicy {
+	return ap.noncePolicy
+}
+
+// Status returns the usage of the pool along with its policies
+func (ap *actPool) Status() *ActPoolStatus {
+	return &ActPoolStatus{
+		Size:        ap.GetSize(),
+		Capacity:    ap.GetCapacity(),
+		GasSize:     ap.GetGasSize(),
+		GasCapacity: ap.GetGasCapacity(),
+		NoncePolicy: ap.noncePolicy.Name(),
+	}
+}
+
 func (ap *actPool) Validate(ctx context.Context, selp *action.SealedEnvelope) error {
 	return ap.validate(ctx, selp)
 }
@@ -416,38 +601,77 @@ func (ap *actPool) removeInvalidActs(acts []*action.SealedEnvelope) {
 	for _, act := range acts {
 		hash, err := act.Hash()
 		if err != nil {
-			log.L().Debug("Skipping action due to hash error", zap.Error(err))
+			log.Logger("actpool").Debug("Skipping action due to hash error", zap.Error(err))
 			continue
 		}
-		log.L().Debug("Removed invalidated action.", log.Hex("hash", hash[:]))
+		log.Logger("actpool").Debug("Removed invalidated action.", log.Hex("hash", hash[:]))
 		ap.allActions.Delete(hash)
 		intrinsicGas, _ := act.IntrinsicGas()
 		atomic.AddUint64(&ap.gasInPool, ^uint64(intrinsicGas-1))
+		ap.countBytes(actionSize(act), true)
 		ap.accountDesActs.delete(act)
 	}
 }
 
+// actionSize returns the size of the action in bytes
+func actionSize(act *action.SealedEnvelope) uint64 {
+	return uint64(proto.Size(act.Proto()))
+}
+
+// isLarge returns whether an action of the size is counted against the budget of the large actions
+func (ap *actPool) isLarge(size uint64) bool {
+	return ap.cfg.LargeActionSize > 0 && size >= ap.cfg.LargeActionSize
+}
+
+// tier returns the bytes in pool of the small or the large actions, along with the budget and the name of the tier
+func (ap *actPool) tier(large bool) (*uint64, uint64, string) {
+	if large {
+		return &ap.largeBytesInPool, ap.cfg.MaxLargeBytesPerPool, "large"
+	}
+	return &ap.smallBytesInPool, ap.cfg.MaxBytesPerPool, "small"
+}
+
+// countBytes adds the size of an action into the bytes in pool of its tier, or subtracts it if the action is removed
+func (ap *actPool) countBytes(size uint64, removed bool) {
+	bytesInPool, _, name := ap.tier(ap.isLarge(size))
+	var total uint64
+	if removed {
+		total = atomic.AddUint64(bytesInPool, ^uint64(size-1))
+	} else {
+		total = atomic.AddUint64(bytesInPool, size)
+	}
+	_actpoolBytesMtc.WithLabelValues(name).Set(float64(total))
+}
+
 func (ap *actPool) context(ctx context.Context) context.Context {
 	height, _ := ap.sf.Height()
 	return protocol.WithFeatureCtx(protocol.WithBlockCtx(
 		genesis.WithGenesisContext(ctx, ap.g), protocol.BlockCtx{
 			BlockHeight: height + 1,
+			GasLimit:    ap.g.BlockGasLimitByHeight(height + 1),
 		}))
 }
 
 func (ap *actPool) enqueue(ctx context.Context, act *action.SealedEnvelope, replace bool) error {
-	var errChan = make(chan error, 1) // unused errChan will be garbage-collected
+	var (
+		errChan  = make(chan error, 1) // unused errChan will be garbage-collected
+		queuedAt time.Time
+	)
+	if tracer.SpanFromContext(ctx).SpanContext().IsSampled() {
+		queuedAt = time.Now()
+	}
 	ap.jobQueue[ap.allocatedWorker(act.SenderAddress())] <- workerJob{
 		ctx,
 		act,
 		replace,
 		errChan,
+		queuedAt,
 	}
 
 	for {
 		select {
 		case <-ctx.Done():
-			log.L().Error("enqueue actpool fails", zap.Error(ctx.Err()))
+			log.Logger("actpool").Error("enqueue actpool fails", zap.Error(ctx.Err()))
 			return ctx.Err()
 		case ret := <-errChan:
 			return ret
@@ -508,7 +732,7 @@ func (des *destinationMap) delete(act *action.SealedEnvelope) {
 	}
 	hash, err := act.Hash()
 	if err != nil {
-		log.L().Debug("Skipping action due to hash error", zap.Error(err))
+		log.Logger("actpool").Debug("Skipping action due to hash error", zap.Error(err))
 		return
 	}
 	dst, exist := des.acts[desAddress]
@@ -9,11 +9,14 @@ import (
 	"bytes"
 	"context"
 	"math/big"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/iotexproject/iotex-address/address"
+	"github.com/mohae/deepcopy"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
@@ -885,6 +888,129 @@ func TestActPool_GetPendingNonce(t *testing.T) {
 	require.Equal(uint64(5), nonce)
 }
 
+func TestActPool_GetNonceDetail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+	sf := mock_chainmanager.NewMockStateReader(ctrl)
+	// Create actpool
+	apConfig := getActPoolCfg()
+	Ap, err := NewActPool(genesis.Default, sf, apConfig)
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(sf, accountutil.AccountState))
+
+	tsf1, err := action.SignedTransfer(_addr1, _priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := action.SignedTransfer(_addr1, _priKey1, uint64(2), big.NewInt(30), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf4, err := action.SignedTransfer(_addr1, _priKey1, uint64(4), big.NewInt(30), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf2Replaced, err := action.SignedTransfer(_addr1, _priKey1, uint64(2), big.NewInt(30), []byte{}, uint64(100000), big.NewInt(1))
+	require.NoError(err)
+	sf.EXPECT().State(gomock.Any(), gomock.Any()).DoAndReturn(func(account interface{}, opts ...protocol.StateOption) (uint64, error) {
+		acct, ok := account.(*state.Account)
+		require.True(ok)
+		require.NoError(acct.AddBalance(big.NewInt(100000000000000000)))
+
+		return 0, nil
+	}).AnyTimes()
+	sf.EXPECT().Height().Return(uint64(1), nil).AnyTimes()
+
+	ctx := genesis.WithGenesisContext(context.Background(), genesis.Default)
+	require.NoError(ap.Add(ctx, tsf1))
+	require.NoError(ap.Add(ctx, tsf2))
+	require.NoError(ap.Add(ctx, tsf4))
+
+	// account without action in pool
+	detail, err := ap.GetNonceDetail(_addr2)
+	require.NoError(err)
+	require.Equal(&NonceDetail{ConfirmedNonce: 1, PendingNonce: 1, Actions: []*action.SealedEnvelope{}}, detail)
+
+	detail, err = ap.GetNonceDetail(_addr1)
+	require.NoError(err)
+	require.Equal(uint64(1), detail.ConfirmedNonce)
+	require.Equal(uint64(3), detail.PendingNonce)
+	require.Equal([]uint64{3}, detail.Gaps)
+	require.Equal([]*action.SealedEnvelope{tsf1, tsf2, tsf4}, detail.Actions)
+	nonce, err := ap.GetPendingNonce(_addr1)
+	require.NoError(err)
+	require.Equal(detail.PendingNonce, nonce)
+
+	require.NoError(ap.Add(ctx, tsf2Replaced))
+	detail, err = ap.GetNonceDetail(_addr1)
+	require.NoError(err)
+	require.Equal([]uint64{3}, detail.Gaps)
+	require.Equal([]*action.SealedEnvelope{tsf1, tsf2Replaced, tsf4}, detail.Actions)
+
+	_, err = ap.GetNonceDetail("invalid")
+	require.Error(err)
+}
+
+func TestActPool_NoncePolicy(t *testing.T) {
+	require := require.New(t)
+	_, err := NewNoncePolicy("unknown", 10)
+	require.Error(err)
+
+	newActPool := func(policy string) (*actPool, context.Context) {
+		ctrl := gomock.NewController(t)
+		sf := mock_chainmanager.NewMockStateReader(ctrl)
+		sf.EXPECT().State(gomock.Any(), gomock.Any()).DoAndReturn(func(account interface{}, opts ...protocol.StateOption) (uint64, error) {
+			acct, ok := account.(*state.Account)
+			require.True(ok)
+			require.NoError(acct.AddBalance(big.NewInt(100000000000000000)))
+			return 0, nil
+		}).AnyTimes()
+		sf.EXPECT().Height().Return(uint64(1), nil).AnyTimes()
+		apConfig := getActPoolCfg()
+		apConfig.NoncePolicy = policy
+		Ap, err := NewActPool(genesis.Default, sf, apConfig)
+		require.NoError(err)
+		ap, ok := Ap.(*actPool)
+		require.True(ok)
+		ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(sf, accountutil.AccountState))
+		require.Equal(policy, ap.NoncePolicy().Name())
+		require.Equal(policy, ap.Status().NoncePolicy)
+		return ap, genesis.WithGenesisContext(context.Background(), genesis.Default)
+	}
+	tsf1, err := action.SignedTransfer(_addr1, _priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := action.SignedTransfer(_addr1, _priKey1, uint64(2), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf3, err := action.SignedTransfer(_addr1, _priKey1, uint64(3), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+
+	t.Run("strict", func(t *testing.T) {
+		ap, ctx := newActPool(NoncePolicyStrict)
+		require.NoError(ap.Add(ctx, tsf1))
+		// a gap of one is rejected
+		err := ap.Add(ctx, tsf3)
+		require.Equal(action.ErrNonceTooHigh, errors.Cause(err))
+		require.Equal(action.ErrNonceTooHigh, errors.Cause(ap.Check(ctx, tsf3)))
+		require.NoError(ap.Add(ctx, tsf2))
+		require.NoError(ap.Add(ctx, tsf3))
+		nonce, err := ap.GetPendingNonce(_addr1)
+		require.NoError(err)
+		require.Equal(uint64(4), nonce)
+	})
+	t.Run("tolerant", func(t *testing.T) {
+		ap, ctx := newActPool(NoncePolicyTolerant)
+		require.NoError(ap.Add(ctx, tsf1))
+		// a gap of one is queued, but not pending
+		require.NoError(ap.Add(ctx, tsf3))
+		nonce, err := ap.GetPendingNonce(_addr1)
+		require.NoError(err)
+		require.Equal(uint64(2), nonce)
+		require.Equal([]*action.SealedEnvelope{tsf1}, ap.NoncePolicy().Packable(1, ap.PendingActionMap()[_addr1]))
+		// promoted once the gap is filled
+		require.NoError(ap.Add(ctx, tsf2))
+		nonce, err = ap.GetPendingNonce(_addr1)
+		require.NoError(err)
+		require.Equal(uint64(4), nonce)
+		require.Equal([]*action.SealedEnvelope{tsf1, tsf2, tsf3}, ap.NoncePolicy().Packable(1, ap.PendingActionMap()[_addr1]))
+	})
+}
+
 func TestActPool_GetUnconfirmedActs(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	require := require.New(t)
@@ -1037,6 +1163,165 @@ func TestActPool_GetSize(t *testing.T) {
 	require.Equal(uint64(0), ap.GetGasSize())
 }
 
+func TestActPool_BytesBudget(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+	sf := mock_chainmanager.NewMockStateReader(ctrl)
+	sf.EXPECT().State(gomock.Any(), gomock.Any()).DoAndReturn(func(account interface{}, opts ...protocol.StateOption) (uint64, error) {
+		acct, ok := account.(*state.Account)
+		require.True(ok)
+		require.NoError(acct.AddBalance(big.NewInt(100000000000000000)))
+		return 0, nil
+	}).AnyTimes()
+	sf.EXPECT().Height().Return(uint64(1), nil).AnyTimes()
+	large := func(nonce uint64) *action.SealedEnvelope {
+		tsf, err := action.SignedTransfer(_addr2, _priKey1, nonce, big.NewInt(1), make([]byte, 2000), uint64(300000), big.NewInt(0))
+		require.NoError(err)
+		return tsf
+	}
+	largeSize := actionSize(large(1))
+	apConfig := getActPoolCfg()
+	apConfig.LargeActionSize = 1024
+	apConfig.MaxLargeBytesPerPool = 3*largeSize + largeSize/2
+	apConfig.MaxBytesPerPool = 1024
+	Ap, err := NewActPool(genesis.Default, sf, apConfig)
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(sf, accountutil.AccountState))
+	ctx := genesis.WithGenesisContext(context.Background(), genesis.Default)
+
+	// the large actions beyond the budget are evicted
+	for i := uint64(1); i <= 3; i++ {
+		require.NoError(ap.Add(ctx, large(i)))
+	}
+	require.ErrorIs(ap.Add(ctx, large(4)), action.ErrTxPoolOverflow)
+	require.Equal(uint64(3), ap.GetSize())
+	require.Equal(3*largeSize, atomic.LoadUint64(&ap.largeBytesInPool))
+	// the small actions are still admitted with the pool full of large ones
+	tsf, err := action.SignedTransfer(_addr1, _priKey2, uint64(1), big.NewInt(1), nil, uint64(20000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(ap.Add(ctx, tsf))
+	require.Equal(uint64(4), ap.GetSize())
+	require.Equal(actionSize(tsf), atomic.LoadUint64(&ap.smallBytesInPool))
+	// an action larger than the budget of its tier is rejected
+	oversized, err := action.SignedTransfer(_addr2, _priKey3, uint64(1), big.NewInt(1), make([]byte, 4*largeSize), uint64(2000000), big.NewInt(0))
+	require.NoError(err)
+	require.ErrorIs(ap.Add(ctx, oversized), action.ErrOversizedData)
+
+	ap.removeInvalidActs([]*action.SealedEnvelope{tsf})
+	require.Zero(atomic.LoadUint64(&ap.smallBytesInPool))
+}
+
+func TestActPool_BlockLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+	sf := mock_chainmanager.NewMockStateReader(ctrl)
+	sf.EXPECT().State(gomock.Any(), gomock.Any()).DoAndReturn(func(account interface{}, opts ...protocol.StateOption) (uint64, error) {
+		acct, ok := account.(*state.Account)
+		require.True(ok)
+		require.NoError(acct.AddBalance(big.NewInt(100000000000000000)))
+		return 0, nil
+	}).AnyTimes()
+	height := uint64(1)
+	sf.EXPECT().Height().DoAndReturn(func() (uint64, error) {
+		return atomic.LoadUint64(&height), nil
+	}).AnyTimes()
+	g := deepcopy.Copy(genesis.Default).(genesis.Genesis)
+	g.BlockGasLimit = 30000000
+	g.TsunamiBlockGasLimit = 20000000
+	g.TsunamiBlockHeight = 10
+	apConfig := getActPoolCfg()
+	apConfig.MaxGasLimitPerPool = 100000000
+	apConfig.MaxBlockBodySize = 10000
+	apConfig.MaxActionBlockShare = 0.5
+	Ap, err := NewActPool(g, sf, apConfig)
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(sf, accountutil.AccountState))
+	ctx := genesis.WithGenesisContext(context.Background(), g)
+	require.NoError(ap.ReceiveBlock(nil))
+
+	// the gas limit up to the block gas limit is admitted
+	atLimit, err := action.SignedTransfer(_addr2, _priKey1, uint64(1), big.NewInt(1), nil, g.BlockGasLimit, big.NewInt(0))
+	require.NoError(err)
+	require.NoError(ap.Add(ctx, atLimit))
+	overLimit, err := action.SignedTransfer(_addr1, _priKey2, uint64(1), big.NewInt(1), nil, g.BlockGasLimit+1, big.NewInt(0))
+	require.NoError(err)
+	require.ErrorIs(ap.Add(ctx, overLimit), ErrGasLimitExceedsBlock)
+	// the action larger than its share of the block body is rejected
+	oversized, err := action.SignedTransfer(_addr1, _priKey2, uint64(1), big.NewInt(1), make([]byte, 6000), uint64(1000000), big.NewInt(0))
+	require.NoError(err)
+	require.ErrorIs(ap.Add(ctx, oversized), ErrActionTooLarge)
+	underLimit, err := action.SignedTransfer(_addr1, _priKey2, uint64(1), big.NewInt(1), nil, g.TsunamiBlockGasLimit, big.NewInt(0))
+	require.NoError(err)
+	require.NoError(ap.Add(ctx, underLimit))
+
+	// the actions over the lowered block gas limit are evicted
+	atomic.StoreUint64(&height, g.TsunamiBlockHeight-1)
+	require.NoError(ap.ReceiveBlock(nil))
+	require.Eventually(func() bool {
+		h, _ := atLimit.Hash()
+		_, err := ap.GetActionByHash(h)
+		return err != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	h, err := underLimit.Hash()
+	require.NoError(err)
+	_, err = ap.GetActionByHash(h)
+	require.NoError(err)
+	require.Equal(uint64(1), ap.GetSize())
+	nonce, err := ap.GetPendingNonce(_addr1)
+	require.NoError(err)
+	require.Equal(uint64(1), nonce)
+	require.ErrorIs(ap.Add(ctx, atLimit), ErrGasLimitExceedsBlock)
+}
+
+func TestActPool_CandidateProfileSpam(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+	sf := mock_chainmanager.NewMockStateReader(ctrl)
+	sf.EXPECT().State(gomock.Any(), gomock.Any()).DoAndReturn(func(account interface{}, opts ...protocol.StateOption) (uint64, error) {
+		acct, ok := account.(*state.Account)
+		require.True(ok)
+		require.NoError(acct.AddBalance(big.NewInt(100000000000000000)))
+		return 0, nil
+	}).AnyTimes()
+	sf.EXPECT().Height().Return(uint64(1), nil).AnyTimes()
+	Ap, err := NewActPool(genesis.Default, sf, getActPoolCfg())
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(sf, accountutil.AccountState))
+	ctx := genesis.WithGenesisContext(context.Background(), genesis.Default)
+
+	// the profiles beyond the size limits are rejected on validation, even if the gas paid covers the bytes
+	desc := strings.Repeat("d", action.CandidateProfileDescriptionSizeLimit)
+	for i, c := range []struct {
+		url, description, contact string
+	}{
+		{strings.Repeat("u", action.CandidateProfileURLSizeLimit+1), "", ""},
+		{"", desc + "d", ""},
+		{"", "", strings.Repeat("c", action.CandidateProfileSecurityContactSizeLimit+1)},
+		{strings.Repeat("u", action.CandidateProfileSizeLimit-len(desc)), desc, "c"},
+	} {
+		selp, err := action.SignedUpdateCandidateProfile(uint64(i+1), c.url, c.description, nil, c.contact, 1000000, big.NewInt(0), _priKey1)
+		require.NoError(err)
+		require.ErrorIs(ap.Add(ctx, selp), action.ErrOversizedData)
+	}
+	require.Zero(ap.GetSize())
+	require.Zero(atomic.LoadUint64(&ap.smallBytesInPool))
+
+	// the profile at the limit is admitted, and charged by the bytes
+	selp, err := action.SignedUpdateCandidateProfile(1, strings.Repeat("u", action.CandidateProfileSizeLimit-len(desc)), desc, nil, "", action.UpdateCandidateProfileBaseIntrinsicGas, big.NewInt(0), _priKey1)
+	require.NoError(err)
+	require.ErrorIs(ap.Add(ctx, selp), action.ErrIntrinsicGas)
+	selp, err = action.SignedUpdateCandidateProfile(1, strings.Repeat("u", action.CandidateProfileSizeLimit-len(desc)), desc, nil, "", 1000000, big.NewInt(0), _priKey1)
+	require.NoError(err)
+	require.NoError(ap.Add(ctx, selp))
+	require.Equal(uint64(1), ap.GetSize())
+}
+
 func TestActPool_AddActionNotEnoughGasPrice(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	sf := mock_chainmanager.NewMockStateReader(ctrl)
@@ -1178,3 +1463,52 @@ func TestValidateMinGasPrice(t *testing.T) {
 	mgp := ap.MinGasPrice()
 	require.IsType(t, &big.Int{}, mgp)
 }
+
+func TestActPool_Check(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+	sf := mock_chainmanager.NewMockStateReader(ctrl)
+	sf.EXPECT().State(gomock.Any(), gomock.Any()).DoAndReturn(func(account interface{}, opts ...protocol.StateOption) (uint64, error) {
+		acct, ok := account.(*state.Account)
+		require.True(ok)
+		require.NoError(acct.AddBalance(big.NewInt(100)))
+		return 0, nil
+	}).AnyTimes()
+	sf.EXPECT().Height().Return(uint64(1), nil).AnyTimes()
+	ap, err := NewActPool(genesis.Default, sf, getActPoolCfg())
+	require.NoError(err)
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(sf, accountutil.AccountState))
+	ctx := genesis.WithGenesisContext(context.Background(), genesis.Default)
+
+	tsf1, err := action.SignedTransfer(_addr2, _priKey1, 1, big.NewInt(10), nil, 100000, big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := action.SignedTransfer(_addr2, _priKey1, 2, big.NewInt(20), nil, 100000, big.NewInt(0))
+	require.NoError(err)
+	// the checked action is not added into the pool
+	require.NoError(ap.Check(ctx, tsf1))
+	require.Zero(ap.GetSize())
+	require.NoError(ap.Add(ctx, tsf1))
+	require.NoError(ap.Add(ctx, tsf2))
+
+	for _, c := range []struct {
+		nonce  uint64
+		amount int64
+		err    error
+	}{
+		{1, 10, action.ErrExistedInPool},
+		{1, 5, action.ErrReplaceUnderpriced},
+		{0, 5, action.ErrNonceTooLow},
+		{1 + _maxNumActsPerAcct, 5, action.ErrNonceTooHigh},
+		// 30 of the balance is pending in the pool
+		{3, 80, action.ErrInsufficientFunds},
+		{3, 70, nil},
+	} {
+		tsf, err := action.SignedTransfer(_addr2, _priKey1, c.nonce, big.NewInt(c.amount), nil, 100000, big.NewInt(0))
+		require.NoError(err)
+		require.Equal(c.err, errors.Cause(ap.Check(ctx, tsf)))
+	}
+	require.Equal(uint64(2), ap.GetSize())
+	nonce, err := ap.GetPendingNonce(_addr1)
+	require.NoError(err)
+	require.Equal(uint64(3), nonce)
+}
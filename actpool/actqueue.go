@@ -9,6 +9,7 @@ import (
 	"container/heap"
 	"context"
 	"math/big"
+	"sort"
 	"sync"
 	"time"
 
@@ -26,6 +27,7 @@ import (
 // ActQueue is the interface of actQueue
 type ActQueue interface {
 	Put(*action.SealedEnvelope) error
+	Check(*action.SealedEnvelope) error
 	UpdateQueue() []*action.SealedEnvelope
 	UpdateAccountState(uint64, *big.Int) []*action.SealedEnvelope
 	AccountState() (uint64, *big.Int)
@@ -36,6 +38,10 @@ type ActQueue interface {
 	PendingActs(context.Context) []*action.SealedEnvelope
 	AllActs() []*action.SealedEnvelope
 	PopActionWithLargestNonce() *action.SealedEnvelope
+	RemoveActs(func(*action.SealedEnvelope) bool) []*action.SealedEnvelope
+	ActionWithLargestNonce() *action.SealedEnvelope
+	PendingActionInfo(uint64) (*PendingActionInfo, bool)
+	NonceDetail() *NonceDetail
 	Reset()
 }
 
@@ -97,27 +103,26 @@ func (q *actQueue) Put(act *action.SealedEnvelope) error {
 	defer q.mu.Unlock()
 	nonce := act.Nonce()
 
-	if cost, _ := act.Cost(); q.getPendingBalanceAtNonce(nonce).Cmp(cost) < 0 {
-		return action.ErrInsufficientFunds
+	if err := q.check(act); err != nil {
+		return err
 	}
 
-	if actInPool, exist := q.items[nonce]; exist {
-		// act of higher gas price can cut in line
-		if nonce < q.pendingNonce && act.GasFeeCap().Cmp(actInPool.GasFeeCap()) != 1 {
-			return action.ErrReplaceUnderpriced
-		}
+	if _, exist := q.items[nonce]; exist {
 		// update action in q.items and q.index
 		q.items[nonce] = act
 		for i := range q.ascQueue {
 			if q.ascQueue[i].nonce == nonce {
-				q.ascQueue[i].deadline = q.clock.Now().Add(q.ttl)
+				now := q.clock.Now()
+				q.ascQueue[i].deadline = now.Add(q.ttl)
+				q.ascQueue[i].added = now
 				break
 			}
 		}
 		q.updateFromNonce(nonce)
 		return nil
 	}
-	nttl := &nonceWithTTL{nonce: nonce, deadline: q.clock.Now().Add(q.ttl)}
+	now := q.clock.Now()
+	nttl := &nonceWithTTL{nonce: nonce, deadline: now.Add(q.ttl), added: now}
 	heap.Push(&q.ascQueue, nttl)
 	heap.Push(&q.descQueue, nttl)
 	q.items[nonce] = act
@@ -127,6 +132,35 @@ func (q *actQueue) Put(act *action.SealedEnvelope) error {
 	return nil
 }
 
+// Check checks whether the action can be put into the queue
+func (q *actQueue) Check(act *action.SealedEnvelope) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.check(act)
+}
+
+func (q *actQueue) check(act *action.SealedEnvelope) error {
+	nonce := act.Nonce()
+	if cost, _ := act.Cost(); q.getPendingBalanceAtNonce(nonce).Cmp(cost) < 0 {
+		return action.ErrInsufficientFunds
+	}
+	// act of higher gas price can cut in line
+	if minFeeCap := q.minReplacementGasFeeCap(nonce); minFeeCap != nil && act.GasFeeCap().Cmp(minFeeCap) < 0 {
+		return action.ErrReplaceUnderpriced
+	}
+	return nil
+}
+
+// minReplacementGasFeeCap returns the lowest gas fee cap of an action replacing the executable one of the nonce, or
+// nil if there is none, in which case an action of any gas fee cap takes the nonce
+func (q *actQueue) minReplacementGasFeeCap(nonce uint64) *big.Int {
+	actInPool, exist := q.items[nonce]
+	if !exist || nonce >= q.pendingNonce {
+		return nil
+	}
+	return new(big.Int).Add(actInPool.GasFeeCap(), big.NewInt(1))
+}
+
 func (q *actQueue) getPendingBalanceAtNonce(nonce uint64) *big.Int {
 	if nonce > q.pendingNonce {
 		return q.getPendingBalanceAtNonce(q.pendingNonce)
@@ -175,17 +209,49 @@ func (q *actQueue) cleanTimeout() []*action.SealedEnvelope {
 	if q.ttl == 0 {
 		return []*action.SealedEnvelope{}
 	}
+	timeNow := q.clock.Now()
+	removed := q.remove(func(nttl *nonceWithTTL) bool {
+		return timeNow.After(nttl.deadline) && nttl.nonce > q.pendingNonce
+	})
+	for _, act := range removed {
+		delete(q.pendingBalance, act.Nonce())
+	}
+	return removed
+}
+
+// RemoveActs removes the actions matching the filter. The pending nonce moves back to the first removed nonce, as the
+// actions following it cannot be packed until the nonce is filled again
+func (q *actQueue) RemoveActs(filter func(*action.SealedEnvelope) bool) []*action.SealedEnvelope {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	removed := q.remove(func(nttl *nonceWithTTL) bool {
+		return filter(q.items[nttl.nonce])
+	})
+	for _, act := range removed {
+		if nonce := act.Nonce(); nonce < q.pendingNonce {
+			q.pendingNonce = nonce
+		}
+	}
+	// the pending balance at the pending nonce still holds
+	for nonce := range q.pendingBalance {
+		if nonce > q.pendingNonce {
+			delete(q.pendingBalance, nonce)
+		}
+	}
+	return removed
+}
+
+// remove removes the actions of the nonces matching the filter, and rebuilds the nonce queues
+func (q *actQueue) remove(filter func(*nonceWithTTL) bool) []*action.SealedEnvelope {
 	var (
 		removedFromQueue = make([]*action.SealedEnvelope, 0)
-		timeNow          = q.clock.Now()
 		size             = len(q.ascQueue)
 	)
 	for i := 0; i < size; {
 		nonce := q.ascQueue[i].nonce
-		if timeNow.After(q.ascQueue[i].deadline) && nonce > q.pendingNonce {
+		if filter(q.ascQueue[i]) {
 			removedFromQueue = append(removedFromQueue, q.items[nonce])
 			delete(q.items, nonce)
-			delete(q.pendingBalance, nonce)
 			q.ascQueue[i] = q.ascQueue[size-1]
 			size--
 			continue
@@ -273,13 +339,13 @@ func (q *actQueue) PendingActs(ctx context.Context) []*action.SealedEnvelope {
 	}
 	addr, err := address.FromString(q.address)
 	if err != nil {
-		log.L().Error("Error when getting the address", zap.String("address", q.address), zap.Error(err))
+		log.Logger("actpool").Error("Error when getting the address", zap.String("address", q.address), zap.Error(err))
 		return nil
 	}
 	// TODO: no need to refetch confirmed state, leave it to block builder to validate
 	confirmedState, err := accountutil.AccountState(ctx, q.ap.sf, addr)
 	if err != nil {
-		log.L().Error("Error when getting the nonce", zap.String("address", q.address), zap.Error(err))
+		log.Logger("actpool").Error("Error when getting the nonce", zap.String("address", q.address), zap.Error(err))
 		return nil
 	}
 
@@ -326,6 +392,66 @@ func (q *actQueue) AllActs() []*action.SealedEnvelope {
 	return acts
 }
 
+// PendingActionInfo returns the position of the action of the nonce in the queue, whether it is executable, and
+// when it was put into the queue
+func (q *actQueue) PendingActionInfo(nonce uint64) (*PendingActionInfo, bool) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if _, exist := q.items[nonce]; !exist {
+		return nil, false
+	}
+	info := &PendingActionInfo{
+		Executable:              nonce < q.pendingNonce,
+		MinReplacementGasFeeCap: q.minReplacementGasFeeCap(nonce),
+	}
+	for _, nttl := range q.ascQueue {
+		switch {
+		case nttl.nonce < nonce:
+			info.Position++
+		case nttl.nonce == nonce:
+			info.AddedAt = nttl.added
+		}
+	}
+	return info, true
+}
+
+// NonceDetail returns the account nonce, the pending nonce, the missing nonces and the actions of the queue
+func (q *actQueue) NonceDetail() *NonceDetail {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	nonces := make([]uint64, 0, len(q.items))
+	for nonce := range q.items {
+		nonces = append(nonces, nonce)
+	}
+	sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+	detail := &NonceDetail{
+		ConfirmedNonce: q.accountNonce,
+		PendingNonce:   q.pendingNonce,
+		Actions:        make([]*action.SealedEnvelope, 0, len(nonces)),
+	}
+	next := q.accountNonce
+	for _, nonce := range nonces {
+		for ; next < nonce; next++ {
+			detail.Gaps = append(detail.Gaps, next)
+		}
+		if nonce >= next {
+			next = nonce + 1
+		}
+		detail.Actions = append(detail.Actions, q.items[nonce])
+	}
+	return detail
+}
+
+// ActionWithLargestNonce returns the action with the largest nonce without removing it
+func (q *actQueue) ActionWithLargestNonce() *action.SealedEnvelope {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if len(q.items) == 0 {
+		return nil
+	}
+	return q.items[q.descQueue[0].nonce]
+}
+
 func (q *actQueue) PopActionWithLargestNonce() *action.SealedEnvelope {
 	q.mu.Lock()
 	defer q.mu.Unlock()
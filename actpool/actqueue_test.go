@@ -227,6 +227,131 @@ func TestActQueueCleanTimeout(t *testing.T) {
 	require.Equal(1, len(ret))
 }
 
+func TestActQueueRemoveActs(t *testing.T) {
+	require := require.New(t)
+	q := NewActQueue(nil, "", 1, big.NewInt(maxBalance)).(*actQueue)
+	for i := uint64(1); i <= 4; i++ {
+		tsf, err := action.SignedTransfer(_addr2, _priKey1, i, big.NewInt(100), nil, i*10000, big.NewInt(0))
+		require.NoError(err)
+		require.NoError(q.Put(tsf))
+	}
+	require.Equal(uint64(5), q.PendingNonce())
+
+	removed := q.RemoveActs(func(act *action.SealedEnvelope) bool {
+		return act.GasLimit() == 30000
+	})
+	require.Len(removed, 1)
+	require.Equal(uint64(3), removed[0].Nonce())
+	require.Equal(3, q.Len())
+	require.Equal(uint64(3), q.PendingNonce())
+	require.Equal([]uint64{1, 2, 4}, nonces(q.AllActs()))
+	require.Empty(q.RemoveActs(func(*action.SealedEnvelope) bool { return false }))
+}
+
+func nonces(acts []*action.SealedEnvelope) []uint64 {
+	ret := make([]uint64, 0, len(acts))
+	for _, act := range acts {
+		ret = append(ret, act.Nonce())
+	}
+	return ret
+}
+
+func TestActQueuePendingActionInfo(t *testing.T) {
+	require := require.New(t)
+	c := clock.NewMock()
+	q := NewActQueue(nil, "", 1, big.NewInt(maxBalance), WithClock(c)).(*actQueue)
+	tsf1, err := action.SignedTransfer(_addr2, _priKey1, 1, big.NewInt(100), nil, uint64(0), big.NewInt(1))
+	require.NoError(err)
+	tsf2, err := action.SignedTransfer(_addr2, _priKey1, 2, big.NewInt(100), nil, uint64(0), big.NewInt(1))
+	require.NoError(err)
+	tsf4, err := action.SignedTransfer(_addr2, _priKey1, 4, big.NewInt(100), nil, uint64(0), big.NewInt(1))
+	require.NoError(err)
+	require.NoError(q.Put(tsf4))
+	c.Add(time.Minute)
+	require.NoError(q.Put(tsf2))
+	require.NoError(q.Put(tsf1))
+
+	_, ok := q.PendingActionInfo(3)
+	require.False(ok)
+	info, ok := q.PendingActionInfo(1)
+	require.True(ok)
+	require.Equal(&PendingActionInfo{Position: 0, Executable: true, AddedAt: c.Now(), MinReplacementGasFeeCap: big.NewInt(2)}, info)
+	info, ok = q.PendingActionInfo(2)
+	require.True(ok)
+	require.Equal(&PendingActionInfo{Position: 1, Executable: true, AddedAt: c.Now(), MinReplacementGasFeeCap: big.NewInt(2)}, info)
+	// nonce 4 waits behind the gap at nonce 3, so an action of any gas price replaces it
+	info, ok = q.PendingActionInfo(4)
+	require.True(ok)
+	require.Equal(&PendingActionInfo{Position: 2, Executable: false, AddedAt: c.Now().Add(-time.Minute)}, info)
+
+	// the replacement of an executable action must pay the minimum
+	underpriced, err := action.SignedTransfer(_addr2, _priKey1, 2, big.NewInt(100), nil, uint64(0), big.NewInt(1))
+	require.NoError(err)
+	require.Equal(action.ErrReplaceUnderpriced, q.Check(underpriced))
+	replacement, err := action.SignedTransfer(_addr2, _priKey1, 2, big.NewInt(100), nil, uint64(0), big.NewInt(2))
+	require.NoError(err)
+	require.NoError(q.Check(replacement))
+
+	// the replacement restarts the time in the pool
+	c.Add(time.Minute)
+	tsf4, err = action.SignedTransfer(_addr2, _priKey1, 4, big.NewInt(100), nil, uint64(0), big.NewInt(2))
+	require.NoError(err)
+	require.NoError(q.Put(tsf4))
+	info, ok = q.PendingActionInfo(4)
+	require.True(ok)
+	require.Equal(c.Now(), info.AddedAt)
+}
+
+func TestActQueueNonceDetail(t *testing.T) {
+	require := require.New(t)
+	q := NewActQueue(nil, "", 1, big.NewInt(maxBalance)).(*actQueue)
+	detail := q.NonceDetail()
+	require.Equal(uint64(1), detail.ConfirmedNonce)
+	require.Equal(uint64(1), detail.PendingNonce)
+	require.Empty(detail.Gaps)
+	require.Empty(detail.Actions)
+
+	tsfs := make(map[uint64]*action.SealedEnvelope)
+	for _, nonce := range []uint64{7, 2, 1, 5} {
+		tsf, err := action.SignedTransfer(_addr2, _priKey1, nonce, big.NewInt(100), nil, uint64(0), big.NewInt(1))
+		require.NoError(err)
+		require.NoError(q.Put(tsf))
+		tsfs[nonce] = tsf
+	}
+	detail = q.NonceDetail()
+	require.Equal(uint64(1), detail.ConfirmedNonce)
+	require.Equal(uint64(3), detail.PendingNonce)
+	require.Equal([]uint64{3, 4, 6}, detail.Gaps)
+	require.Equal([]*action.SealedEnvelope{tsfs[1], tsfs[2], tsfs[5], tsfs[7]}, detail.Actions)
+
+	// the replacement takes the slot of the nonce
+	tsf2, err := action.SignedTransfer(_addr2, _priKey1, 2, big.NewInt(100), nil, uint64(0), big.NewInt(2))
+	require.NoError(err)
+	require.NoError(q.Put(tsf2))
+	detail = q.NonceDetail()
+	require.Equal([]*action.SealedEnvelope{tsfs[1], tsf2, tsfs[5], tsfs[7]}, detail.Actions)
+
+	// filling the gaps moves the pending nonce forward
+	for _, nonce := range []uint64{4, 3} {
+		tsf, err := action.SignedTransfer(_addr2, _priKey1, nonce, big.NewInt(100), nil, uint64(0), big.NewInt(1))
+		require.NoError(err)
+		require.NoError(q.Put(tsf))
+	}
+	detail = q.NonceDetail()
+	require.Equal(uint64(6), detail.PendingNonce)
+	require.Equal([]uint64{6}, detail.Gaps)
+	require.Len(detail.Actions, 6)
+
+	// the confirmed actions are removed, and the pending nonce is updated with the queue
+	q.UpdateAccountState(4, big.NewInt(maxBalance))
+	q.UpdateQueue()
+	detail = q.NonceDetail()
+	require.Equal(uint64(4), detail.ConfirmedNonce)
+	require.Equal(uint64(6), detail.PendingNonce)
+	require.Equal([]uint64{6}, detail.Gaps)
+	require.Len(detail.Actions, 3)
+}
+
 // BenchmarkHeapInitAndRemove compare the heap re-establish performance between
 // using the heap.Init and the heap.Remove after remove some elements.
 // The bench result show that the performance of heap.Init is better than heap.Remove
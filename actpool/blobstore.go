@@ -99,12 +99,12 @@ func (s *blobStore) Open(onData onAction) error {
 		act, err := s.decode(blob)
 		if err != nil {
 			fails = append(fails, id)
-			log.L().Warn("Failed to decode action", zap.Error(err))
+			log.Logger("actpool").Warn("Failed to decode action", zap.Error(err))
 			return
 		}
 		if err = onData(act); err != nil {
 			fails = append(fails, id)
-			log.L().Warn("Failed to process action", zap.Error(err))
+			log.Logger("actpool").Warn("Failed to process action", zap.Error(err))
 			return
 		}
 		s.stored += uint64(size)
@@ -118,7 +118,7 @@ func (s *blobStore) Open(onData onAction) error {
 	s.store = store
 
 	if len(fails) > 0 {
-		log.L().Warn("Dropping invalidated blob transactions", zap.Int("count", len(fails)))
+		log.Logger("actpool").Warn("Dropping invalidated blob transactions", zap.Int("count", len(fails)))
 
 		for _, id := range fails {
 			if err := s.store.Delete(id); err != nil {
@@ -225,16 +225,16 @@ func (s *blobStore) Range(fn func(hash.Hash256) bool) {
 func (s *blobStore) drop() {
 	h, ok := s.evict()
 	if !ok {
-		log.L().Debug("no worst action found")
+		log.Logger("actpool").Debug("no worst action found")
 		return
 	}
 	id, ok := s.lookup[h]
 	if !ok {
-		log.L().Warn("worst action not found in lookup", zap.String("hash", hex.EncodeToString(h[:])))
+		log.Logger("actpool").Warn("worst action not found in lookup", zap.String("hash", hex.EncodeToString(h[:])))
 		return
 	}
 	if err := s.store.Delete(id); err != nil {
-		log.L().Error("failed to delete worst action", zap.Error(err))
+		log.Logger("actpool").Error("failed to delete worst action", zap.Error(err))
 	}
 	delete(s.lookup, h)
 	return
@@ -11,13 +11,19 @@ import (
 var (
 	// DefaultConfig is the default config for actpool
 	DefaultConfig = Config{
-		MaxNumActsPerPool:  32000,
-		MaxGasLimitPerPool: 320000000,
-		MaxNumActsPerAcct:  2000,
-		WorkerBufferSize:   2000,
-		ActionExpiry:       10 * time.Minute,
-		MinGasPriceStr:     big.NewInt(unit.Qev).String(),
-		BlackList:          []string{},
+		MaxNumActsPerPool:    32000,
+		MaxGasLimitPerPool:   320000000,
+		MaxNumActsPerAcct:    2000,
+		WorkerBufferSize:     2000,
+		ActionExpiry:         10 * time.Minute,
+		MinGasPriceStr:       big.NewInt(unit.Qev).String(),
+		BlackList:            []string{},
+		LargeActionSize:      16 * 1024,
+		MaxBytesPerPool:      64 * 1024 * 1024,
+		MaxLargeBytesPerPool: 16 * 1024 * 1024,
+		MaxBlockBodySize:     12 * 1024 * 1024,
+		MaxActionBlockShare:  0.25,
+		NoncePolicy:          NoncePolicyTolerant,
 	}
 )
 
@@ -37,6 +43,28 @@ type Config struct {
 	MinGasPriceStr string `yaml:"minGasPrice"`
 	// BlackList lists the account address that are banned from initiating actions
 	BlackList []string `yaml:"blackList"`
+	// LargeActionSize is the size in bytes from which an action is large, and counted against the budget of the large
+	// actions instead of the small ones. 0 means all actions are small
+	LargeActionSize uint64 `yaml:"largeActionSize"`
+	// MaxBytesPerPool indicates maximum bytes of the small actions the whole actpool can hold, 0 means no limit
+	MaxBytesPerPool uint64 `yaml:"maxBytesPerPool"`
+	// MaxLargeBytesPerPool indicates maximum bytes of the large actions the whole actpool can hold, 0 means no limit
+	MaxLargeBytesPerPool uint64 `yaml:"maxLargeBytesPerPool"`
+	// LargeActionsBlockShare is the maximum share of the bytes of a block the large actions can take, 0 means no
+	// limit. The first large action is always taken, so that large actions are not starved
+	LargeActionsBlockShare float64 `yaml:"largeActionsBlockShare"`
+	// MaxBlockBodySize is the size in bytes a block body is bounded by, which is the max size of a p2p message
+	MaxBlockBodySize uint64 `yaml:"maxBlockBodySize"`
+	// MaxActionBlockShare is the maximum share of MaxBlockBodySize a single action can take, 0 means no limit
+	MaxActionBlockShare float64 `yaml:"maxActionBlockShare"`
+	// NoncePolicy is "tolerant" queuing the actions of an account ahead of its pending nonce within MaxNumActsPerAcct
+	// nonces, or "strict" only accepting the action at the pending nonce, see NoncePolicy
+	NoncePolicy string `yaml:"noncePolicy"`
+}
+
+// MaxActionSize returns the max size in bytes of an action, 0 means no limit
+func (ap Config) MaxActionSize() uint64 {
+	return uint64(ap.MaxActionBlockShare * float64(ap.MaxBlockBodySize))
 }
 
 // MinGasPrice returns the minimal gas price threshold
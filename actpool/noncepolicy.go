@@ -0,0 +1,114 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+)
+
+// the names of the nonce policies in the config
+const (
+	// NoncePolicyTolerant queues the actions of an account ahead of its pending nonce within a window of nonces
+	NoncePolicyTolerant = "tolerant"
+	// NoncePolicyStrict only accepts the action of an account at its pending nonce, or replacing a pending action
+	NoncePolicyStrict = "strict"
+)
+
+type (
+	// NoncePolicy decides the nonces of the actions of an account accepted into the pool, and the actions of an
+	// account the block proposer packs into a block
+	NoncePolicy interface {
+		// Name returns the name of the policy in the config
+		Name() string
+		// CheckNonce checks the nonce of an action not lower than the confirmed nonce of the account, against the
+		// pending nonce of the account in the pool, i.e. the nonce next to the contiguous actions from the confirmed
+		// nonce. A nonce beyond the tolerance of the policy fails with action.ErrNonceTooHigh
+		CheckNonce(nonce, confirmedNonce, pendingNonce uint64) error
+		// Packable returns the actions of an account sorted by nonce, which could be packed into a block whose state
+		// expects the nonce of the next action of the account. None is packable if the first action is not at the
+		// nonce
+		Packable(nonce uint64, acts []*action.SealedEnvelope) []*action.SealedEnvelope
+	}
+
+	tolerantNoncePolicy struct {
+		window uint64
+	}
+
+	strictNoncePolicy struct {
+		window uint64
+	}
+)
+
+// NewNoncePolicy returns the nonce policy of the name, the actions of an account are limited to the window of nonces
+// from the confirmed nonce under either policy. The empty name is the tolerant policy
+func NewNoncePolicy(name string, window uint64) (NoncePolicy, error) {
+	switch name {
+	case "", NoncePolicyTolerant:
+		return &tolerantNoncePolicy{window: window}, nil
+	case NoncePolicyStrict:
+		return &strictNoncePolicy{window: window}, nil
+	default:
+		return nil, errors.Errorf("unknown nonce policy %s", name)
+	}
+}
+
+func (p *tolerantNoncePolicy) Name() string {
+	return NoncePolicyTolerant
+}
+
+func (p *tolerantNoncePolicy) CheckNonce(nonce, confirmedNonce, _ uint64) error {
+	return checkNonceWindow(nonce, confirmedNonce, p.window)
+}
+
+// Packable returns the actions from the nonce past the gaps, the block proposer runs the actions of an account in
+// nonce order, and stops at the first gap it meets
+func (p *tolerantNoncePolicy) Packable(nonce uint64, acts []*action.SealedEnvelope) []*action.SealedEnvelope {
+	if len(acts) == 0 || acts[0].Nonce() != nonce {
+		return nil
+	}
+	return acts
+}
+
+func (p *strictNoncePolicy) Name() string {
+	return NoncePolicyStrict
+}
+
+func (p *strictNoncePolicy) CheckNonce(nonce, confirmedNonce, pendingNonce uint64) error {
+	if nonce > pendingNonce {
+		return errors.Wrapf(action.ErrNonceTooHigh, "nonce %d leaves a gap after the pending nonce %d", nonce, pendingNonce)
+	}
+	return checkNonceWindow(nonce, confirmedNonce, p.window)
+}
+
+// Packable returns the contiguous actions from the nonce, and stops at the first gap. The pool has no gap under the
+// strict policy, unless the actions in the middle are removed, in which case the actions beyond the gap are not
+// packed until they expire
+func (p *strictNoncePolicy) Packable(nonce uint64, acts []*action.SealedEnvelope) []*action.SealedEnvelope {
+	return contiguousActs(nonce, acts)
+}
+
+func checkNonceWindow(nonce, confirmedNonce, window uint64) error {
+	if nonce-confirmedNonce >= window {
+		return errors.Wrapf(action.ErrNonceTooHigh, "nonce %d is beyond the window of %d nonces from the confirmed nonce %d", nonce, window, confirmedNonce)
+	}
+	return nil
+}
+
+// contiguousActs returns the leading actions of contiguous nonces from the nonce, none if the first action is not
+// at the nonce
+func contiguousActs(nonce uint64, acts []*action.SealedEnvelope) []*action.SealedEnvelope {
+	for i, act := range acts {
+		if act.Nonce() != nonce+uint64(i) {
+			if i == 0 {
+				return nil
+			}
+			return acts[:i]
+		}
+	}
+	return acts
+}
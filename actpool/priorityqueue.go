@@ -13,6 +13,7 @@ type (
 		descIdx  int
 		nonce    uint64
 		deadline time.Time
+		added    time.Time
 	}
 
 	ascNoncePriorityQueue  []*nonceWithTTL
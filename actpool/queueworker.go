@@ -9,13 +9,16 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/iotexproject/go-pkgs/cache/ttl"
 	"github.com/iotexproject/iotex-address/address"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
 	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/tracer"
@@ -35,6 +38,8 @@ type (
 		act *action.SealedEnvelope
 		rep bool
 		err chan error
+		// queuedAt is only set for a sampled action to report its queue time
+		queuedAt time.Time
 	}
 
 	pendingActions struct {
@@ -91,13 +96,16 @@ func (worker *queueWorker) Handle(job workerJob) error {
 		replace         = job.rep
 	)
 	defer span.End()
+	if !job.queuedAt.IsZero() {
+		span.SetAttributes(attribute.Int64("queueTimeMs", time.Since(job.queuedAt).Milliseconds()))
+	}
 
 	nonce, balance, err := worker.getConfirmedState(ctx, act.SenderAddress())
 	if err != nil {
 		return err
 	}
 
-	if err := worker.checkSelpWithState(act, nonce, balance); err != nil {
+	if err := worker.checkSelpWithState(act, nonce, worker.pendingNonce(sender, nonce), balance); err != nil {
 		return err
 	}
 	if err := worker.putAction(sender, act, nonce, balance); err != nil {
@@ -108,11 +116,13 @@ func (worker *queueWorker) Handle(job workerJob) error {
 
 	if desAddress, ok := act.Destination(); ok && !strings.EqualFold(sender, desAddress) {
 		if err := worker.ap.accountDesActs.addAction(act); err != nil {
-			log.L().Debug("fail to add destination map", zap.Error(err))
+			log.Logger("actpool").Debug("fail to add destination map", zap.Error(err))
 		}
 	}
 
 	atomic.AddUint64(&worker.ap.gasInPool, intrinsicGas)
+	size := actionSize(act)
+	worker.ap.countBytes(size, false)
 
 	worker.mu.Lock()
 	defer worker.mu.Unlock()
@@ -120,7 +130,7 @@ func (worker *queueWorker) Handle(job workerJob) error {
 		// TODO: early return if sender is the account to pop and nonce is larger than largest in the queue
 		actToReplace := worker.accountActs.PopPeek()
 		if actToReplace == nil {
-			log.L().Warn("UNEXPECTED ERROR: action pool is full, but no action to drop")
+			log.Logger("actpool").Warn("UNEXPECTED ERROR: action pool is full, but no action to drop")
 			return nil
 		}
 		worker.ap.removeInvalidActs([]*action.SealedEnvelope{actToReplace})
@@ -129,12 +139,49 @@ func (worker *queueWorker) Handle(job workerJob) error {
 			_actpoolMtc.WithLabelValues("overMaxNumActsPerPool").Inc()
 		}
 	}
+	// evict the actions of the same tier until its bytes are within the budget, only among the actions handled by
+	// this worker as the replacement above
+	var (
+		large                  = worker.ap.isLarge(size)
+		bytesInPool, budget, _ = worker.ap.tier(large)
+	)
+	for budget > 0 && atomic.LoadUint64(bytesInPool) > budget {
+		actToEvict := worker.accountActs.PopPeekWhere(func(act *action.SealedEnvelope) bool {
+			return worker.ap.isLarge(actionSize(act)) == large
+		})
+		if actToEvict == nil {
+			break
+		}
+		worker.ap.removeInvalidActs([]*action.SealedEnvelope{actToEvict})
+		if h, _ := actToEvict.Hash(); h == actHash {
+			err = action.ErrTxPoolOverflow
+			_actpoolMtc.WithLabelValues("overMaxBytesPerPool").Inc()
+		}
+	}
 
 	worker.removeEmptyAccounts()
 
 	return err
 }
 
+// Check checks the action against the confirmed state and the pending actions of the sender, as Handle does
+// before putting it into the queue
+func (worker *queueWorker) Check(ctx context.Context, act *action.SealedEnvelope) error {
+	nonce, balance, err := worker.getConfirmedState(ctx, act.SenderAddress())
+	if err != nil {
+		return err
+	}
+	if err := worker.checkSelpWithState(act, nonce, worker.pendingNonce(act.SenderAddress().String(), nonce), balance); err != nil {
+		return err
+	}
+	worker.mu.RLock()
+	defer worker.mu.RUnlock()
+	if queue := worker.accountActs.Account(act.SenderAddress().String()); queue != nil {
+		return queue.Check(act)
+	}
+	return nil
+}
+
 func (worker *queueWorker) getConfirmedState(ctx context.Context, sender address.Address) (uint64, *big.Int, error) {
 	worker.mu.RLock()
 	queue := worker.accountActs.Account(sender.String())
@@ -151,34 +198,65 @@ func (worker *queueWorker) getConfirmedState(ctx context.Context, sender address
 		} else {
 			nonce = confirmedState.PendingNonce()
 		}
-		return nonce, confirmedState.Balance, nil
+		balance, err := worker.spendableBalance(ctx, sender, confirmedState.Balance)
+		if err != nil {
+			return 0, nil, err
+		}
+		return nonce, balance, nil
 	}
 	nonce, balance := queue.AccountState()
 	return nonce, balance, nil
 }
 
-func (worker *queueWorker) checkSelpWithState(act *action.SealedEnvelope, pendingNonce uint64, balance *big.Int) error {
-	if act.Nonce() < pendingNonce {
+// spendableBalance returns the balance the account could spend in the next block, including the amounts locked to
+// the account which are unlocked but not released yet, as they are released ahead of the action of the account
+func (worker *queueWorker) spendableBalance(ctx context.Context, addr address.Address, balance *big.Int) (*big.Int, error) {
+	if !protocol.MustGetFeatureCtx(ctx).EnableTimeLockedTransfer {
+		return balance, nil
+	}
+	unlocked, _, err := account.TimeLockBalances(worker.ap.sf, addr, protocol.MustGetBlockCtx(ctx).BlockHeight, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return unlocked.Add(unlocked, balance), nil
+}
+
+// pendingNonce returns the nonce next to the contiguous actions of the sender in the pool, which is the confirmed
+// nonce if the sender has no action in the pool
+func (worker *queueWorker) pendingNonce(sender string, confirmedNonce uint64) uint64 {
+	worker.mu.RLock()
+	queue := worker.accountActs.Account(sender)
+	worker.mu.RUnlock()
+	if queue == nil {
+		return confirmedNonce
+	}
+	return queue.PendingNonce()
+}
+
+func (worker *queueWorker) checkSelpWithState(act *action.SealedEnvelope, confirmedNonce, pendingNonce uint64, balance *big.Int) error {
+	if act.Nonce() < confirmedNonce {
 		_actpoolMtc.WithLabelValues("nonceTooSmall").Inc()
 		return action.ErrNonceTooLow
 	}
 
-	// Nonce exceeds current range
-	if act.Nonce()-pendingNonce >= worker.ap.cfg.MaxNumActsPerAcct {
+	// Nonce exceeds the tolerance of the nonce policy
+	if err := worker.ap.noncePolicy.CheckNonce(act.Nonce(), confirmedNonce, pendingNonce); err != nil {
 		hash, _ := act.Hash()
-		log.L().Debug("Rejecting action because nonce is too large.",
+		log.Logger("actpool").Debug("Rejecting action because nonce is too large.",
 			log.Hex("hash", hash[:]),
-			zap.Uint64("startNonce", pendingNonce),
+			zap.String("noncePolicy", worker.ap.noncePolicy.Name()),
+			zap.Uint64("startNonce", confirmedNonce),
+			zap.Uint64("pendingNonce", pendingNonce),
 			zap.Uint64("actNonce", act.Nonce()))
 		_actpoolMtc.WithLabelValues("nonceTooLarge").Inc()
-		return action.ErrNonceTooHigh
+		return err
 	}
 
 	if cost, _ := act.Cost(); balance.Cmp(cost) < 0 {
 		_actpoolMtc.WithLabelValues("insufficientBalance").Inc()
 		sender := act.SenderAddress().String()
 		actHash, _ := act.Hash()
-		log.L().Debug("insufficient balance for action",
+		log.Logger("actpool").Debug("insufficient balance for action",
 			zap.String("actionHash", hex.EncodeToString(actHash[:])),
 			zap.String("cost", cost.String()),
 			zap.String("balance", balance.String()),
@@ -203,7 +281,7 @@ func (worker *queueWorker) putAction(sender string, act *action.SealedEnvelope,
 	if err != nil {
 		actHash, _ := act.Hash()
 		_actpoolMtc.WithLabelValues("failedPutActQueue").Inc()
-		log.L().Debug("failed put action into ActQueue",
+		log.Logger("actpool").Debug("failed put action into ActQueue",
 			zap.String("actionHash", hex.EncodeToString(actHash[:])),
 			zap.Error(err))
 		return err
@@ -233,7 +311,7 @@ func (worker *queueWorker) Reset(ctx context.Context) {
 		addr, _ := address.FromString(from)
 		confirmedState, err := accountutil.AccountState(ctx, worker.ap.sf, addr)
 		if err != nil {
-			log.L().Error("Error when removing confirmed actions", zap.Error(err))
+			log.Logger("actpool").Error("Error when removing confirmed actions", zap.Error(err))
 			queue.Reset()
 			worker.emptyAccounts.Set(from, struct{}{})
 			return
@@ -244,8 +322,15 @@ func (worker *queueWorker) Reset(ctx context.Context) {
 		} else {
 			pendingNonce = confirmedState.PendingNonce()
 		}
+		balance, err := worker.spendableBalance(ctx, addr, confirmedState.Balance)
+		if err != nil {
+			log.Logger("actpool").Error("Error when removing confirmed actions", zap.Error(err))
+			queue.Reset()
+			worker.emptyAccounts.Set(from, struct{}{})
+			return
+		}
 		// Remove all actions that are committed to new block
-		acts := queue.UpdateAccountState(pendingNonce, confirmedState.Balance)
+		acts := queue.UpdateAccountState(pendingNonce, balance)
 		acts2 := queue.UpdateQueue()
 		worker.ap.removeInvalidActs(append(acts, acts2...))
 		// Delete the queue entry if it becomes empty
@@ -255,6 +340,26 @@ func (worker *queueWorker) Reset(ctx context.Context) {
 	})
 }
 
+// Evict removes the actions matching the filter from the queues of all accounts
+func (worker *queueWorker) Evict(filter func(*action.SealedEnvelope) bool) int {
+	worker.mu.RLock()
+	defer worker.mu.RUnlock()
+
+	var evicted int
+	worker.accountActs.Range(func(from string, queue ActQueue) {
+		acts := queue.RemoveActs(filter)
+		if len(acts) == 0 {
+			return
+		}
+		evicted += len(acts)
+		worker.ap.removeInvalidActs(acts)
+		if queue.Empty() {
+			worker.emptyAccounts.Set(from, struct{}{})
+		}
+	})
+	return evicted
+}
+
 // PendingActions returns all accepted actions
 func (worker *queueWorker) PendingActions(ctx context.Context) []*pendingActions {
 	actionArr := make([]*pendingActions, 0)
@@ -304,6 +409,26 @@ func (worker *queueWorker) PendingNonce(sender address.Address) (uint64, bool) {
 	return 0, false
 }
 
+// PendingActionInfo returns the info of the action of the nonce in the queue of sender
+func (worker *queueWorker) PendingActionInfo(sender address.Address, nonce uint64) (*PendingActionInfo, bool) {
+	worker.mu.RLock()
+	defer worker.mu.RUnlock()
+	if actQueue := worker.accountActs.Account(sender.String()); actQueue != nil {
+		return actQueue.PendingActionInfo(nonce)
+	}
+	return nil, false
+}
+
+// NonceDetail returns the state of the nonces in the queue of sender
+func (worker *queueWorker) NonceDetail(sender address.Address) (*NonceDetail, bool) {
+	worker.mu.RLock()
+	defer worker.mu.RUnlock()
+	if actQueue := worker.accountActs.Account(sender.String()); actQueue != nil {
+		return actQueue.NonceDetail(), true
+	}
+	return nil, false
+}
+
 // ResetAccount resets account in the accountActs of worker
 func (worker *queueWorker) ResetAccount(sender address.Address) []*action.SealedEnvelope {
 	senderStr := sender.String()
@@ -0,0 +1,133 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"context"
+	"time"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/actpool/actioniterator"
+)
+
+type (
+	// ActionSelectionPolicy selects the pending actions the block proposer puts into a block, in the order they
+	// are run. The iterator yields the actions of each sender in nonce order, the selected actions of a sender
+	// must keep that order without gaps. The block proposer skips the rest of the actions of a sender once one of
+	// them fails or exceeds the remaining gas, so any selection leads to a valid block
+	ActionSelectionPolicy interface {
+		// Select returns the actions to run within the gas budget of the block. The selection stops at the
+		// deadline if it is not zero
+		Select(it actioniterator.ActionIterator, gasBudget uint64, deadline time.Time) []*action.SealedEnvelope
+	}
+
+	defaultSelectionPolicy struct{}
+
+	fairSelectionPolicy struct {
+		maxActsPerSender int
+	}
+
+	largeActionCapPolicy struct {
+		policy    ActionSelectionPolicy
+		largeSize uint64
+		share     float64
+	}
+
+	selectionPolicyCtxKey struct{}
+)
+
+// NewDefaultSelectionPolicy returns the policy selecting the actions by gas price, the actions exceeding the gas
+// budget are skipped along with the following actions of the sender
+func NewDefaultSelectionPolicy() ActionSelectionPolicy {
+	return &defaultSelectionPolicy{}
+}
+
+// NewFairSelectionPolicy returns the policy selecting the actions by gas price as the default one, and at most
+// maxActsPerSender actions of each sender, so that a few senders cannot fill up the block
+func NewFairSelectionPolicy(maxActsPerSender int) ActionSelectionPolicy {
+	return &fairSelectionPolicy{maxActsPerSender: maxActsPerSender}
+}
+
+// NewLargeActionCapPolicy returns the policy selecting the actions by p, and then dropping the large actions of at
+// least largeSize bytes beyond the share of the bytes of the block, along with the following actions of the sender.
+// The first large action is always kept, so that the large actions are not starved by the small ones
+func NewLargeActionCapPolicy(p ActionSelectionPolicy, largeSize uint64, share float64) ActionSelectionPolicy {
+	return &largeActionCapPolicy{policy: p, largeSize: largeSize, share: share}
+}
+
+func (p *defaultSelectionPolicy) Select(it actioniterator.ActionIterator, gasBudget uint64, deadline time.Time) []*action.SealedEnvelope {
+	return selectActions(it, gasBudget, deadline, 0)
+}
+
+func (p *fairSelectionPolicy) Select(it actioniterator.ActionIterator, gasBudget uint64, deadline time.Time) []*action.SealedEnvelope {
+	return selectActions(it, gasBudget, deadline, p.maxActsPerSender)
+}
+
+func (p *largeActionCapPolicy) Select(it actioniterator.ActionIterator, gasBudget uint64, deadline time.Time) []*action.SealedEnvelope {
+	var (
+		selected     []*action.SealedEnvelope
+		skipped      = make(map[string]struct{})
+		total, large uint64
+	)
+	for _, act := range p.policy.Select(it, gasBudget, deadline) {
+		sender := act.SenderAddress().String()
+		if _, ok := skipped[sender]; ok {
+			continue
+		}
+		size := actionSize(act)
+		if size >= p.largeSize {
+			if large > 0 && float64(large+size) > p.share*float64(total+size) {
+				skipped[sender] = struct{}{}
+				continue
+			}
+			large += size
+		}
+		total += size
+		selected = append(selected, act)
+	}
+	return selected
+}
+
+// selectActions selects the actions in the order of the iterator, at most limit actions of each sender if limit is
+// positive. The actions are selected by their own gas limit, as the gas consumed is known only after they run
+func selectActions(it actioniterator.ActionIterator, gasBudget uint64, deadline time.Time, limit int) []*action.SealedEnvelope {
+	var (
+		selected []*action.SealedEnvelope
+		counts   = make(map[string]int)
+		skipped  = make(map[string]struct{})
+	)
+	for {
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			break
+		}
+		act, ok := it.Next()
+		if !ok {
+			break
+		}
+		sender := act.SenderAddress().String()
+		if _, ok := skipped[sender]; ok {
+			continue
+		}
+		if act.GasLimit() > gasBudget || (limit > 0 && counts[sender] >= limit) {
+			skipped[sender] = struct{}{}
+			continue
+		}
+		counts[sender]++
+		selected = append(selected, act)
+	}
+	return selected
+}
+
+// WithActionSelectionPolicy attaches the action selection policy of the block proposer to the context
+func WithActionSelectionPolicy(ctx context.Context, p ActionSelectionPolicy) context.Context {
+	return context.WithValue(ctx, selectionPolicyCtxKey{}, p)
+}
+
+// GetActionSelectionPolicy returns the action selection policy attached to the context
+func GetActionSelectionPolicy(ctx context.Context) (ActionSelectionPolicy, bool) {
+	p, ok := ctx.Value(selectionPolicyCtxKey{}).(ActionSelectionPolicy)
+	return p, ok
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/actpool/actioniterator"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestActionSelectionPolicy(t *testing.T) {
+	r := require.New(t)
+	transfer := func(sender int, nonce, gasLimit uint64, gasPrice int64) *action.SealedEnvelope {
+		selp, err := action.SignedTransfer(identityset.Address(0).String(), identityset.PrivateKey(sender), nonce, big.NewInt(1), nil, gasLimit, big.NewInt(gasPrice))
+		r.NoError(err)
+		return selp
+	}
+	pending := func() map[string][]*action.SealedEnvelope {
+		return map[string][]*action.SealedEnvelope{
+			identityset.Address(28).String(): {
+				transfer(28, 1, 10000, 30),
+				transfer(28, 2, 50000, 20),
+				transfer(28, 3, 10000, 10),
+			},
+			identityset.Address(29).String(): {
+				transfer(29, 1, 10000, 25),
+				transfer(29, 2, 10000, 15),
+			},
+		}
+	}
+	nonces := func(acts []*action.SealedEnvelope) map[string][]uint64 {
+		ret := make(map[string][]uint64)
+		for _, act := range acts {
+			sender := act.SenderAddress().String()
+			ret[sender] = append(ret[sender], act.Nonce())
+		}
+		return ret
+	}
+	a, b := identityset.Address(28).String(), identityset.Address(29).String()
+
+	t.Run("default", func(t *testing.T) {
+		p := NewDefaultSelectionPolicy()
+		acts := p.Select(actioniterator.NewActionIterator(pending()), 100000, time.Time{})
+		r.Len(acts, 5)
+		// selected by gas price
+		for i := 1; i < len(acts); i++ {
+			r.True(acts[i-1].GasPrice().Cmp(acts[i].GasPrice()) >= 0)
+		}
+	})
+	t.Run("budget exhausted mid-sender", func(t *testing.T) {
+		p := NewDefaultSelectionPolicy()
+		acts := p.Select(actioniterator.NewActionIterator(pending()), 20000, time.Time{})
+		// the 2nd action of a exceeds the budget, the 3rd one is skipped to keep the nonces continuous
+		r.Equal(map[string][]uint64{a: {1}, b: {1, 2}}, nonces(acts))
+	})
+	t.Run("fair", func(t *testing.T) {
+		p := NewFairSelectionPolicy(1)
+		acts := p.Select(actioniterator.NewActionIterator(pending()), 100000, time.Time{})
+		r.Equal(map[string][]uint64{a: {1}, b: {1}}, nonces(acts))
+		p = NewFairSelectionPolicy(2)
+		acts = p.Select(actioniterator.NewActionIterator(pending()), 20000, time.Time{})
+		r.Equal(map[string][]uint64{a: {1}, b: {1, 2}}, nonces(acts))
+	})
+	t.Run("deadline", func(t *testing.T) {
+		p := NewDefaultSelectionPolicy()
+		r.Empty(p.Select(actioniterator.NewActionIterator(pending()), 100000, time.Now().Add(-time.Second)))
+	})
+	t.Run("large action cap", func(t *testing.T) {
+		large := func(sender int, nonce uint64, gasPrice int64) *action.SealedEnvelope {
+			selp, err := action.SignedTransfer(identityset.Address(0).String(), identityset.PrivateKey(sender), nonce, big.NewInt(1), make([]byte, 1000), 200000, big.NewInt(gasPrice))
+			r.NoError(err)
+			return selp
+		}
+		pending := func() map[string][]*action.SealedEnvelope {
+			return map[string][]*action.SealedEnvelope{
+				a: {large(28, 1, 40), large(28, 2, 35), transfer(28, 3, 10000, 30)},
+				b: {transfer(29, 1, 10000, 25), large(29, 2, 20), transfer(29, 3, 10000, 15)},
+			}
+		}
+		p := NewLargeActionCapPolicy(NewDefaultSelectionPolicy(), 1000, 0.5)
+		acts := p.Select(actioniterator.NewActionIterator(pending()), 1000000, time.Time{})
+		// the 1st large action is always kept, the other ones exceed the share so the rest of their senders are
+		// dropped, including the small ones
+		r.Equal(map[string][]uint64{a: {1}, b: {1}}, nonces(acts))
+		p = NewLargeActionCapPolicy(NewDefaultSelectionPolicy(), 1000, 1)
+		acts = p.Select(actioniterator.NewActionIterator(pending()), 1000000, time.Time{})
+		r.Equal(map[string][]uint64{a: {1, 2, 3}, b: {1, 2, 3}}, nonces(acts))
+	})
+	t.Run("context", func(t *testing.T) {
+		_, ok := GetActionSelectionPolicy(context.Background())
+		r.False(ok)
+		p := NewFairSelectionPolicy(1)
+		got, ok := GetActionSelectionPolicy(WithActionSelectionPolicy(context.Background(), p))
+		r.True(ok)
+		r.Equal(p, got)
+	})
+}
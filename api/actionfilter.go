@@ -0,0 +1,137 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/iotexproject/iotex-core/blockindex"
+)
+
+// the metadata keys of the filter of GetActions by address, until GetActionsByAddressRequest carries the filter
+const (
+	// MetadataActionDirection is the directions of the actions, any of "sender", "recipient" and "contract"
+	MetadataActionDirection = "x-iotex-action-direction"
+	// MetadataActionType is the types of the actions, the names in the oneof of ActionCore, e.g. "transfer"
+	MetadataActionType = "x-iotex-action-type"
+	// MetadataFromHeight is the lowest block height of the actions
+	MetadataFromHeight = "x-iotex-from-height"
+	// MetadataToHeight is the highest block height of the actions
+	MetadataToHeight = "x-iotex-to-height"
+	// MetadataSystemActions is "include" or "exclude" the GrantReward and PutPollResult actions in GetActions by
+	// index, address or block, default to "include"
+	MetadataSystemActions = "x-iotex-system-actions"
+)
+
+var _actionDirections = map[string]blockindex.ActionDirection{
+	"sender":    blockindex.DirectionSender,
+	"recipient": blockindex.DirectionRecipient,
+	"contract":  blockindex.DirectionContract,
+}
+
+// actionFilterFromMetadata returns the filter of the actions in the incoming metadata, or nil if not filtered.
+// Each key could be repeated, or carry a comma-separated list
+func actionFilterFromMetadata(ctx context.Context) (*blockindex.ActionFilter, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	var (
+		filter   = &blockindex.ActionFilter{}
+		filtered = false
+		values   = func(key string) []string {
+			var ret []string
+			for _, v := range md.Get(key) {
+				for _, s := range strings.Split(v, ",") {
+					if s = strings.TrimSpace(s); s != "" {
+						ret = append(ret, s)
+					}
+				}
+			}
+			if len(ret) > 0 {
+				filtered = true
+			}
+			return ret
+		}
+		height = func(key string) (uint64, error) {
+			v := values(key)
+			switch len(v) {
+			case 0:
+				return 0, nil
+			case 1:
+				h, err := strconv.ParseUint(v[0], 10, 64)
+				if err != nil {
+					return 0, errors.Wrapf(err, "invalid %s", key)
+				}
+				return h, nil
+			default:
+				return 0, errors.Errorf("more than one %s", key)
+			}
+		}
+		err error
+	)
+	for _, v := range values(MetadataActionDirection) {
+		direction, ok := _actionDirections[v]
+		if !ok {
+			return nil, errors.Errorf("invalid %s %s", MetadataActionDirection, v)
+		}
+		filter.Direction |= direction
+	}
+	filter.ActionTypes = values(MetadataActionType)
+	if filter.FromHeight, err = height(MetadataFromHeight); err != nil {
+		return nil, err
+	}
+	if filter.ToHeight, err = height(MetadataToHeight); err != nil {
+		return nil, err
+	}
+	if !filtered {
+		return nil, nil
+	}
+	return filter, nil
+}
+
+// excludeSystemActionsFromMetadata returns whether the system actions are excluded in the incoming metadata
+func excludeSystemActionsFromMetadata(ctx context.Context) (bool, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false, nil
+	}
+	v := md.Get(MetadataSystemActions)
+	switch len(v) {
+	case 0:
+		return false, nil
+	case 1:
+	default:
+		return false, errors.Errorf("more than one %s", MetadataSystemActions)
+	}
+	switch strings.TrimSpace(v[0]) {
+	case "", "include":
+		return false, nil
+	case "exclude":
+		return true, nil
+	default:
+		return false, errors.Errorf("invalid %s %s", MetadataSystemActions, v[0])
+	}
+}
+
+// filterSystemActions removes the GrantReward and PutPollResult actions
+func filterSystemActions(acts []*iotexapi.ActionInfo) []*iotexapi.ActionInfo {
+	ret := acts[:0]
+	for _, act := range acts {
+		core := act.GetAction().GetCore()
+		if core.GetGrantReward() != nil || core.GetPutPollResult() != nil {
+			continue
+		}
+		ret = append(ret, act)
+	}
+	return ret
+}
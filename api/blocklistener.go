@@ -32,7 +32,7 @@ func NewGRPCBlockListener(handler streamHandler, errChan chan error) apitypes.Re
 func (bl *gRPCBlockListener) Respond(_ string, blk *block.Block) error {
 	var receiptsPb []*iotextypes.Receipt
 	for _, receipt := range blk.Receipts {
-		receiptsPb = append(receiptsPb, receipt.ConvertToReceiptPb())
+		receiptsPb = append(receiptsPb, receiptPbWithStatusMessage(receipt))
 	}
 	blockInfo := &iotexapi.BlockInfo{
 		Block:    blk.ConvertToBlockPb(),
@@ -48,7 +48,7 @@ func (bl *gRPCBlockListener) Respond(_ string, blk *block.Block) error {
 		Block:           blockInfo,
 		BlockIdentifier: blockID,
 	}); err != nil {
-		log.L().Info(
+		log.Logger("api").Info(
 			"Error when streaming the block",
 			zap.Uint64("height", blockInfo.GetBlock().GetHeader().GetCore().GetHeight()),
 			zap.Error(err),
@@ -85,7 +85,7 @@ func (bl *web3BlockListener) Respond(id string, blk *block.Block) error {
 	}
 	// send blockInfo thru streaming API
 	if _, err := bl.streamHandle(res); err != nil {
-		log.L().Info(
+		log.Logger("api").Info(
 			"Error when streaming the block",
 			zap.Uint64("height", blk.Height()),
 			zap.Error(err),
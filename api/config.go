@@ -6,6 +6,8 @@
 package api
 
 import (
+	"time"
+
 	"github.com/iotexproject/iotex-core/gasstation"
 	"github.com/iotexproject/iotex-core/pkg/tracer"
 )
@@ -25,17 +27,95 @@ type Config struct {
 	BatchRequestLimit int `yaml:"batchRequestLimit"`
 	// WebsocketRateLimit is the maximum number of messages per second per client.
 	WebsocketRateLimit int `yaml:"websocketRateLimit"`
+	// SimulateBatchLimit is the maximum number of calls in a batch simulation.
+	SimulateBatchLimit int `yaml:"simulateBatchLimit"`
+	// SimulateBatchGasBudget is the total gas of the calls in a batch simulation.
+	SimulateBatchGasBudget uint64 `yaml:"simulateBatchGasBudget"`
+	// SimulateBatchTimeout is the time limit of a batch simulation.
+	SimulateBatchTimeout time.Duration `yaml:"simulateBatchTimeout"`
+	// TransactionLogRangeSizeLimit is the maximum size in bytes of the transaction logs read in a range.
+	TransactionLogRangeSizeLimit int `yaml:"transactionLogRangeSizeLimit"`
+	// TraceTimeout is the time limit of tracing a block or a transaction in it.
+	TraceTimeout time.Duration `yaml:"traceTimeout"`
+	// TraceConcurrency is the maximum number of traces running at the same time.
+	TraceConcurrency int `yaml:"traceConcurrency"`
+	// LogQueryRangeLimit is the maximum number of blocks in the range of a paginated logs query.
+	LogQueryRangeLimit uint64 `yaml:"logQueryRangeLimit"`
+	// LogQueryResultLimit is the maximum number of logs in a page of a paginated logs query.
+	LogQueryResultLimit uint64 `yaml:"logQueryResultLimit"`
+	// ReadCacheTTL is how long a read result is kept in the cache if not invalidated before.
+	ReadCacheTTL time.Duration `yaml:"readCacheTTL"`
+	// ReadCacheSize is the maximum size in bytes of the read results in the cache.
+	ReadCacheSize int `yaml:"readCacheSize"`
+	// ReservedConcurrency is the maximum number of the write path and consensus-supporting requests running at the
+	// same time, in slots reserved from the heavy reads.
+	ReservedConcurrency int `yaml:"reservedConcurrency"`
+	// HeavyReadConcurrency is the maximum number of the heavy reads, i.e. logs, traces and simulations, running at
+	// the same time.
+	HeavyReadConcurrency int `yaml:"heavyReadConcurrency"`
+	// HeavyReadShedLatency is the block commit latency beyond which the heavy reads are shed, 0 never sheds.
+	HeavyReadShedLatency time.Duration `yaml:"heavyReadShedLatency"`
+	// GRPCKeepaliveMinTime is the minimum interval of the keepalive pings of a client, the connection of a client
+	// pinging more often is closed.
+	GRPCKeepaliveMinTime time.Duration `yaml:"grpcKeepaliveMinTime"`
+	// GRPCKeepaliveTime is the idle time of a connection after which the server pings the client, so the connection
+	// isn't dropped by the middleboxes.
+	GRPCKeepaliveTime time.Duration `yaml:"grpcKeepaliveTime"`
+	// GRPCKeepaliveTimeout is the time waiting for the ack of a ping before the connection is closed.
+	GRPCKeepaliveTimeout time.Duration `yaml:"grpcKeepaliveTimeout"`
+	// GRPCMaxConnectionIdle is the time without any call after which a connection is closed by a GOAWAY.
+	GRPCMaxConnectionIdle time.Duration `yaml:"grpcMaxConnectionIdle"`
+	// GRPCRequestTimeout is the default deadline of a unary call, an earlier deadline of the client is kept.
+	GRPCRequestTimeout time.Duration `yaml:"grpcRequestTimeout"`
+	// GRPCBackfillTimeout is the default deadline of a unary call reading a range of blocks, actions or logs.
+	GRPCBackfillTimeout time.Duration `yaml:"grpcBackfillTimeout"`
+	// GRPCStreamTimeout is the default deadline of a streaming call, 0 never expires.
+	GRPCStreamTimeout time.Duration `yaml:"grpcStreamTimeout"`
+	// Web3Host is the interface the web3 http and websocket servers listen on, empty listens on all the interfaces.
+	Web3Host string `yaml:"web3Host"`
+	// Web3KeystoreDir is the dir of a local keystore in the ioctl format serving eth_accounts and
+	// eth_signTransaction, empty disables them. It's for the development setups and requires a loopback Web3Host.
+	Web3KeystoreDir string `yaml:"web3KeystoreDir"`
+	// Web3KeystorePasswordFile is the file of the password decrypting the keys of the web3 keystore.
+	Web3KeystorePasswordFile string `yaml:"web3KeystorePasswordFile"`
+	// MaintenanceFile is the file persisting the maintenance mode across restarts, empty keeps it in memory.
+	MaintenanceFile string `yaml:"maintenanceFile"`
+	// ResponseSizeLimit is the maximum size in bytes of the result of a logs or blocks query, estimated while the
+	// result is assembled. The query exceeding it fails with ErrResultTooLarge, 0 means no limit.
+	ResponseSizeLimit int `yaml:"responseSizeLimit"`
 }
 
 // DefaultConfig is the default config
 var DefaultConfig = Config{
-	UseRDS:             false,
-	GRPCPort:           14014,
-	HTTPPort:           15014,
-	WebSocketPort:      16014,
-	TpsWindow:          10,
-	GasStation:         gasstation.DefaultConfig,
-	RangeQueryLimit:    1000,
-	BatchRequestLimit:  _defaultBatchRequestLimit,
-	WebsocketRateLimit: 5,
+	UseRDS:                       false,
+	GRPCPort:                     14014,
+	HTTPPort:                     15014,
+	WebSocketPort:                16014,
+	TpsWindow:                    10,
+	GasStation:                   gasstation.DefaultConfig,
+	RangeQueryLimit:              1000,
+	BatchRequestLimit:            _defaultBatchRequestLimit,
+	WebsocketRateLimit:           5,
+	SimulateBatchLimit:           100,
+	SimulateBatchGasBudget:       50000000,
+	SimulateBatchTimeout:         5 * time.Second,
+	TransactionLogRangeSizeLimit: 4 << 20,
+	TraceTimeout:                 30 * time.Second,
+	TraceConcurrency:             4,
+	LogQueryRangeLimit:           100000,
+	LogQueryResultLimit:          1000,
+	ReadCacheTTL:                 10 * time.Minute,
+	ReadCacheSize:                64 << 20,
+	ReservedConcurrency:          256,
+	HeavyReadConcurrency:         64,
+	HeavyReadShedLatency:         0,
+	GRPCKeepaliveMinTime:         10 * time.Second,
+	GRPCKeepaliveTime:            30 * time.Second,
+	GRPCKeepaliveTimeout:         10 * time.Second,
+	GRPCMaxConnectionIdle:        5 * time.Minute,
+	GRPCRequestTimeout:           30 * time.Second,
+	GRPCBackfillTimeout:          2 * time.Minute,
+	GRPCStreamTimeout:            24 * time.Hour,
+	MaintenanceFile:              "/var/data/maintenance.json",
+	ResponseSizeLimit:            64 << 20,
 }
@@ -12,9 +12,12 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"slices"
 	"strconv"
+	"sync/atomic"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 
@@ -42,6 +45,7 @@ import (
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
 	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
 	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
 	"github.com/iotexproject/iotex-core/action/protocol/poll"
@@ -63,7 +67,7 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/log"
 	batch "github.com/iotexproject/iotex-core/pkg/messagebatcher"
 	"github.com/iotexproject/iotex-core/pkg/tracer"
-	"github.com/iotexproject/iotex-core/pkg/version"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
 	"github.com/iotexproject/iotex-core/server/itx/nodestats"
 	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/state/factory"
@@ -74,6 +78,13 @@ const (
 	// defaultTraceTimeout is the amount of time a single transaction can execute
 	// by default before being forcefully aborted.
 	defaultTraceTimeout = 5 * time.Second
+	// _maxContractStatsDays is the max number of days of a contract stats query
+	_maxContractStatsDays = 366
+	// _maxRawHeaders is the max number of headers returned by a raw header query
+	_maxRawHeaders = 1000
+	// _logSizeOverhead is the estimated size of a log in a response besides its address, topics and data, i.e. the
+	// hashes of the block and the action, the height and the indices
+	_logSizeOverhead = 128
 )
 
 type (
@@ -87,12 +98,23 @@ type (
 		ServerMeta() (packageVersion string, packageCommitID string, gitStatus string, goVersion string, buildTime string)
 		// SendAction is the API to send an action to blockchain.
 		SendAction(ctx context.Context, in *iotextypes.Action) (string, error)
+		// SendActionWithStatus sends the action as SendAction, and returns the current status of the action along with
+		// its hash, which tells whether the same action was sent before
+		SendActionWithStatus(ctx context.Context, in *iotextypes.Action) (*apitypes.SentAction, error)
+		// ValidateAction validates the action as SendAction does, without adding it into the actpool or broadcasting it
+		ValidateAction(ctx context.Context, in *iotextypes.Action) (*apitypes.ActionValidation, error)
 		// ReadContract reads the state in a contract address specified by the slot
 		ReadContract(ctx context.Context, callerAddr address.Address, sc *action.Execution) (string, *iotextypes.Receipt, error)
 		// ReadState reads state on blockchain
 		ReadState(protocolID string, height string, methodName []byte, arguments [][]byte) (*iotexapi.ReadStateResponse, error)
 		// SuggestGasPrice suggests gas price
 		SuggestGasPrice() (uint64, error)
+		// SuggestGasPrices suggests the gas prices of the slow, standard and fast tiers
+		SuggestGasPrices() (*gasstation.GasPrices, error)
+		// SuggestGasTipCap suggests the gas tip cap
+		SuggestGasTipCap() (uint64, error)
+		// FeeHistory returns the fee history of the blocks up to the newest
+		FeeHistory(blocks, newest uint64, rewardPercentiles []float64) (uint64, [][]*big.Int, []*big.Int, []float64, error)
 		// EstimateGasForAction estimates gas for action
 		EstimateGasForAction(ctx context.Context, in *iotextypes.Action) (uint64, error)
 		// EpochMeta gets epoch metadata
@@ -107,6 +129,21 @@ type (
 		TransactionLogByActionHash(actHash string) (*iotextypes.TransactionLog, error)
 		// TransactionLogByBlockHeight returns transaction log by block height
 		TransactionLogByBlockHeight(blockHeight uint64) (*iotextypes.BlockIdentifier, *iotextypes.TransactionLogs, error)
+		// ContractsCreatedByBlock returns the contracts created in the block, including the ones created by contracts
+		ContractsCreatedByBlock(blockHeight uint64) ([]*apitypes.CreatedContract, error)
+		// TransactionLogsByBlockHeightRange returns the transaction logs of the blocks in range in height order, and
+		// the height to continue from
+		TransactionLogsByBlockHeightRange(start, count uint64, recipients []address.Address) ([]*apitypes.BlockTransactionLogs, uint64, error)
+		// RawBlockByHeight returns the serialized protos of the parts of the block at the height
+		RawBlockByHeight(height uint64, parts apitypes.RawBlockParts) (*apitypes.RawBlock, error)
+		// RawBlockByHash returns the serialized protos of the parts of the block by hash
+		RawBlockByHash(h hash.Hash256, parts apitypes.RawBlockParts) (*apitypes.RawBlock, error)
+		// RawHeaders returns the serialized headers of the blocks in range in height order, up to 1000 headers
+		RawHeaders(start, count uint64) ([]*apitypes.RawBlock, error)
+		// ActionInclusionProof returns the merkle proof of the action included in a block
+		ActionInclusionProof(h hash.Hash256) (*block.ActionInclusionProof, error)
+		// CancelPendingAction returns the unsigned action replacing the pending action of the sender at the nonce
+		CancelPendingAction(sender address.Address, nonce uint64, gasPriceBump *big.Int) (*apitypes.CancelAction, error)
 
 		// Start starts the API server
 		Start(ctx context.Context) error
@@ -119,10 +156,43 @@ type (
 		Action(actionHash string, checkPending bool) (*iotexapi.ActionInfo, error)
 		// ActionsByAddress returns all actions associated with an address
 		ActionsByAddress(addr address.Address, start uint64, count uint64) ([]*iotexapi.ActionInfo, error)
+		// ActionsByAddressWithFilter returns the actions associated with an address matching the filter
+		ActionsByAddressWithFilter(addr address.Address, filter *blockindex.ActionFilter, start uint64, count uint64) ([]*iotexapi.ActionInfo, error)
+		// TokenTransfersByAddress returns the XRC20 and XRC721 transfers from or to an address, and the cursor of next query
+		TokenTransfersByAddress(addr address.Address, query *blockindex.TokenTransferQuery) ([]*blockindex.TokenTransfer, uint64, error)
+		// TokenTransfersByContract returns the transfers of an XRC20 or XRC721 contract, and the cursor of next query
+		TokenTransfersByContract(token address.Address, query *blockindex.TokenTransferQuery) ([]*blockindex.TokenTransfer, uint64, error)
+		// CandidateHistory returns the votes, self-stake, bucket count and rank of a candidate at the end of the epochs
+		CandidateHistory(candidate address.Address, startEpoch, endEpoch uint64) ([]*staking.CandidateHistory, error)
+		// EpochRanking returns the candidates at the end of an epoch in the order of rank
+		EpochRanking(epoch uint64) ([]*staking.CandidateHistory, error)
+		// SystemActionsByHeight returns the GrantReward and PutPollResult actions of a block
+		SystemActionsByHeight(height uint64) ([]*blockindex.SystemAction, error)
+		// GrantRewardsByEpoch returns the GrantReward actions of the blocks in an epoch
+		GrantRewardsByEpoch(epoch uint64) ([]*blockindex.SystemAction, error)
+		// ContractStats returns the daily call stats of a contract within the range of days
+		ContractStats(contract address.Address, fromDay, toDay uint64) ([]*blockindex.ContractDayStats, error)
+		// TopContracts returns the contracts with the most call stats in the order within the range of days
+		TopContracts(fromDay, toDay uint64, order blockindex.ContractStatsOrder, limit uint64) ([]*blockindex.ContractStats, error)
+		// TransfersByRecipientAndMemo returns the successful transfers to a recipient with the memo in the payload,
+		// and the cursor of next query
+		TransfersByRecipientAndMemo(recipient address.Address, memo string, query *blockindex.MemoTransferQuery) ([]*blockindex.MemoTransfer, uint64, error)
+		// BlockHeightByTimestamp returns the highest height whose block time is at or before the timestamp if before
+		// is true, otherwise the lowest height whose block time is at or after the timestamp
+		BlockHeightByTimestamp(ts time.Time, before bool) (uint64, error)
+		// BlockMetasByTimestampRange returns the metas of the blocks with the time in [start, end] from the offset,
+		// and the total number of the blocks in the range
+		BlockMetasByTimestampRange(start, end time.Time, offset, count uint64) ([]*iotextypes.BlockMeta, uint64, error)
+		// ProtocolParameters returns the height-gated parameters of the protocols in force at the height, and the
+		// activations after the height
+		ProtocolParameters(height uint64) ([]protocol.Parameter, []protocol.Parameter, error)
 		// ActionByActionHash returns action by action hash
 		ActionByActionHash(h hash.Hash256) (*action.SealedEnvelope, *block.Block, uint32, error)
 		// PendingActionByActionHash returns action by action hash
 		PendingActionByActionHash(h hash.Hash256) (*action.SealedEnvelope, error)
+		// ActionWithStatusByHash returns the action by hash, which is pending in the actpool, confirmed in a block,
+		// or not found
+		ActionWithStatusByHash(h hash.Hash256) (*apitypes.ActionWithStatus, error)
 		// ActPoolActions returns the all Transaction Identifiers in the actpool
 		ActionsInActPool(actHashes []string) ([]*action.SealedEnvelope, error)
 		// BlockByHeightRange returns blocks within the height range
@@ -142,9 +212,16 @@ type (
 		// LogsInBlockByHash filter logs in the block by hash
 		LogsInBlockByHash(filter *logfilter.LogFilter, blockHash hash.Hash256) ([]*action.Log, error)
 		// LogsInRange filter logs among [start, end] blocks
-		LogsInRange(filter *logfilter.LogFilter, start, end, paginationSize uint64) ([]*action.Log, []hash.Hash256, error)
+		LogsInRange(ctx context.Context, filter *logfilter.LogFilter, start, end, paginationSize uint64) ([]*action.Log, []hash.Hash256, error)
+		// LogsPage returns a page of the logs among [start, end] blocks in order, following the cursor if not nil
+		LogsPage(ctx context.Context, filter *logfilter.LogFilter, start, end uint64, cursor *apitypes.LogCursor, descending bool, limit uint64) (*apitypes.LogsPage, error)
+		// StreamLogsInRange sends the logs among [start, end] blocks page by page, the next page is read once the
+		// previous one is sent
+		StreamLogsInRange(ctx context.Context, filter *logfilter.LogFilter, start, end uint64, send func(*apitypes.LogsPage) error) error
 		// Genesis returns the genesis of the chain
 		Genesis() genesis.Genesis
+		// NetworkIdentity returns the identity of the network and the node
+		NetworkIdentity() *apitypes.NetworkIdentity
 		// EVMNetworkID returns the network id of evm
 		EVMNetworkID() uint32
 		// ChainID returns the chain id of evm
@@ -155,18 +232,37 @@ type (
 		ChainListener() apitypes.Listener
 		// SimulateExecution simulates execution
 		SimulateExecution(context.Context, address.Address, *action.Execution) ([]byte, *action.Receipt, error)
-		// SyncingProgress returns the syncing status of node
-		SyncingProgress() (uint64, uint64, uint64)
+		// SimulateBatch simulates the calls in order on the state at height with the overrides, each call sees the
+		// changes of the previous ones, and the batch halts at the first failed call unless continueOnFailure
+		SimulateBatch(ctx context.Context, height uint64, calls []*apitypes.SimulateCall, overrides map[common.Address]*evm.StateOverride, continueOnFailure bool) ([]*apitypes.SimulateResult, error)
+		// ConvertAddress converts the address in either io or hex format into both formats, and tells its kind
+		ConvertAddress(string) (*apitypes.AddressInfo, error)
+		// SyncingProgress returns the syncing progress of node
+		SyncingProgress() blocksync.SyncProgress
 		// TipHeight returns the tip of the chain
 		TipHeight() uint64
+		// FinalizedHeight returns the height of the last finalized block
+		FinalizedHeight() uint64
+		// EarliestStateHeight returns the earliest height whose state can be queried
+		EarliestStateHeight() uint64
 		// PendingNonce returns the pending nonce of an account
 		PendingNonce(address.Address) (uint64, error)
+		// AccountNonceDetail returns the confirmed and pending nonce of an account, the missing nonces and the actions
+		// in the actpool by nonce
+		AccountNonceDetail(address.Address) (*apitypes.AccountNonceDetail, error)
+		// ActPoolStatus returns the usage of the actpool along with its policies
+		ActPoolStatus() *actpool.ActPoolStatus
+		// TotalSupply returns the total and circulating supply of token at the height, along with the base fee burnt
+		// and redistributed, where height 0 is the tip
+		TotalSupply(ctx context.Context, height uint64) (*rewarding.Supply, error)
 		// ReceiveBlock broadcasts the block to api subscribers
 		ReceiveBlock(blk *block.Block) error
 		// BlockHashByBlockHeight returns block hash by block height
 		BlockHashByBlockHeight(blkHeight uint64) (hash.Hash256, error)
 		// TraceTransaction returns the trace result of a transaction
 		TraceTransaction(ctx context.Context, actHash string, config *tracers.TraceConfig) ([]byte, *action.Receipt, any, error)
+		// TraceBlockByNumber returns the traces of the transactions run in the EVM in the block at height
+		TraceBlockByNumber(ctx context.Context, height uint64, config *tracers.TraceConfig) ([]*apitypes.TxTrace, error)
 		// TraceCall returns the trace result of a call
 		TraceCall(ctx context.Context,
 			callerAddr address.Address,
@@ -190,6 +286,13 @@ type (
 		dao               blockdao.BlockDAO
 		indexer           blockindex.Indexer
 		bfIndexer         blockindex.BloomFilterIndexer
+		tsfIndexer        blockindex.TokenTransferIndexer
+		candHistory       *staking.CandidateHistoryIndexer
+		saIndexer         blockindex.SystemActionIndexer
+		csIndexer         blockindex.ContractStatsIndexer
+		memoIndexer       blockindex.MemoIndexer
+		blockTimeIndexer  blockindex.BlockTimeIndexer
+		finality          FinalityReader
 		ap                actpool.ActPool
 		gs                *gasstation.GasStation
 		broadcastHandler  BroadcastOutbound
@@ -201,6 +304,10 @@ type (
 		messageBatcher    *batch.Manager
 		apiStats          *nodestats.APILocalStats
 		getBlockTime      evm.GetBlockTime
+		// traceSlots limits the number of traces running at the same time, nil if unlimited
+		traceSlots  chan struct{}
+		loadShedder *LoadShedder
+		identity    *apitypes.NetworkIdentity
 	}
 
 	// jobDesc provides a struct to get and store logs in core.LogsInRange
@@ -208,11 +315,23 @@ type (
 		idx    int
 		blkNum uint64
 	}
+
+	// txTracer passes the gas of the transaction to the tracer, since the EVM does not call CaptureTxStart and
+	// CaptureTxEnd, and tells whether the transaction has entered the EVM
+	txTracer struct {
+		vm.EVMLogger
+		started bool
+	}
 )
 
 // Option is the option to override the api config
 type Option func(cfg *coreService)
 
+// FinalityReader reads the finalized height of the chain
+type FinalityReader interface {
+	FinalizedHeight() uint64
+}
+
 // BroadcastOutbound sends a broadcast message to the whole network
 type BroadcastOutbound func(ctx context.Context, chainID uint32, msg proto.Message) error
 
@@ -237,13 +356,121 @@ func WithAPIStats(stats *nodestats.APILocalStats) Option {
 	}
 }
 
+// WithTokenTransferIndexer is the option to return the token transfers through API.
+func WithTokenTransferIndexer(indexer blockindex.TokenTransferIndexer) Option {
+	return func(svr *coreService) {
+		svr.tsfIndexer = indexer
+	}
+}
+
+// WithCandidateHistoryIndexer is the option to return the candidate history through API.
+func WithCandidateHistoryIndexer(indexer *staking.CandidateHistoryIndexer) Option {
+	return func(svr *coreService) {
+		svr.candHistory = indexer
+	}
+}
+
+// WithSystemActionIndexer is the option to return the system actions through API.
+func WithSystemActionIndexer(indexer blockindex.SystemActionIndexer) Option {
+	return func(svr *coreService) {
+		svr.saIndexer = indexer
+	}
+}
+
+// WithContractStatsIndexer is the option to return the contract stats through API.
+func WithContractStatsIndexer(indexer blockindex.ContractStatsIndexer) Option {
+	return func(svr *coreService) {
+		svr.csIndexer = indexer
+	}
+}
+
+// WithMemoIndexer is the option to return the transfers by recipient and memo through API.
+func WithMemoIndexer(indexer blockindex.MemoIndexer) Option {
+	return func(svr *coreService) {
+		svr.memoIndexer = indexer
+	}
+}
+
+// WithBlockTimeIndexer is the option to return the blocks by timestamp through API.
+func WithBlockTimeIndexer(indexer blockindex.BlockTimeIndexer) Option {
+	return func(svr *coreService) {
+		svr.blockTimeIndexer = indexer
+	}
+}
+
+// WithFinalityReader is the option to return the finalized height through API.
+func WithFinalityReader(reader FinalityReader) Option {
+	return func(svr *coreService) {
+		svr.finality = reader
+	}
+}
+
+// withMaintenance is the option to reject the heavy reads in the maintenance mode
+func withMaintenance(m *Maintenance) Option {
+	return func(svr *coreService) {
+		svr.loadShedder.maintenance = m
+	}
+}
+
 type intrinsicGasCalculator interface {
 	IntrinsicGas() (uint64, error)
 }
 
+// callerPolicy is the policy on the caller of a simulated execution
+type callerPolicy uint8
+
+const (
+	// allowImpersonation simulates as any caller, which is the zero address if omitted as geth. It is for the pure
+	// reads, whose results don't admit an action
+	allowImpersonation callerPolicy = iota
+	// requireVerifiedSender simulates as the sender recovered from the verified signature of the action. It is for
+	// the admission checks
+	requireVerifiedSender
+)
+
+// simulationPolicy is the policy of a simulated execution. The combinations of the caller, the value and the
+// balance check behave as geth:
+//
+//	from      value / gas price        checkBalance             result
+//	omitted   any                      any                      runs as the zero address
+//	given     value <= balance         any                      runs as the caller
+//	given     value > balance          false (eth_call)         the transfer of the value fails in the evm
+//	given     value > balance          true (eth_estimateGas)   rejected for insufficient funds for transfer
+//	given     gas price > 0            false (eth_call)         the gas is free, the gas price is ignored
+//	given     gas price > 0            true (eth_estimateGas)   the gas is capped by what the balance pays
+//	                                                            after the value, or rejected if exceeded
+type simulationPolicy struct {
+	caller       callerPolicy
+	checkBalance bool
+}
+
+var (
+	// _readPolicy is the policy of eth_call and ReadContract
+	_readPolicy = simulationPolicy{caller: allowImpersonation}
+	// _estimatePolicy is the policy of eth_estimateGas
+	_estimatePolicy = simulationPolicy{caller: allowImpersonation, checkBalance: true}
+	// _admissionPolicy is the policy of ValidateAction
+	_admissionPolicy = simulationPolicy{caller: requireVerifiedSender, checkBalance: true}
+)
+
 var (
 	// ErrNotFound indicates the record isn't found
 	ErrNotFound = errors.New("not found")
+	// ErrHistoryUnavailable indicates the states at a past height are not retained by the node
+	ErrHistoryUnavailable = errors.New("history states unavailable")
+	// ErrResultTooLarge indicates the result of a query exceeds the response size limit
+	ErrResultTooLarge = errors.New("result too large, narrow your query or use streaming")
+
+	// _epochScopedReads are the reads of each protocol whose results only change per epoch at the tip
+	_epochScopedReads = map[string]map[string]struct{}{
+		"poll": {
+			"CandidatesByEpoch":           {},
+			"BlockProducersByEpoch":       {},
+			"ActiveBlockProducersByEpoch": {},
+			"ProbationListByEpoch":        {},
+			"GetGravityChainStartHeight":  {},
+		},
+	}
 )
 
 // newcoreService creates a api server that contains major blockchain components
@@ -261,7 +488,7 @@ func newCoreService(
 	opts ...Option,
 ) (CoreService, error) {
 	if cfg == (Config{}) {
-		log.L().Warn("API server is not configured.")
+		log.Logger("api").Warn("API server is not configured.")
 		cfg = DefaultConfig
 	}
 
@@ -269,6 +496,7 @@ func newCoreService(
 		return nil, errors.New("range query upper limit cannot be less than tps window")
 	}
 
+	g := chain.Genesis()
 	core := coreService{
 		bc:            chain,
 		bs:            bs,
@@ -281,8 +509,14 @@ func newCoreService(
 		registry:      registry,
 		chainListener: NewChainListener(500),
 		gs:            gasstation.NewGasStation(chain, dao, cfg.GasStation),
-		readCache:     NewReadCache(),
+		readCache:     NewReadCache(cfg.ReadCacheTTL, cfg.ReadCacheSize),
 		getBlockTime:  getBlockTime,
+		loadShedder:   NewLoadShedder(cfg.ReservedConcurrency, cfg.HeavyReadConcurrency, cfg.HeavyReadShedLatency),
+		identity:      apitypes.NewNetworkIdentity(chain.ChainID(), chain.EvmNetworkID(), g.Hash()),
+	}
+
+	if cfg.TraceConcurrency > 0 {
+		core.traceSlots = make(chan struct{}, cfg.TraceConcurrency)
 	}
 
 	for _, opt := range opts {
@@ -325,14 +559,30 @@ func (core *coreService) Account(addr address.Address) (*iotextypes.AccountMeta,
 	if err != nil {
 		return nil, nil, status.Error(codes.NotFound, err.Error())
 	}
+	// the amounts locked to the account which are unlocked are released at the next action of the account, so they
+	// are reported as spendable already
+	balance, locked := state.Balance, big.NewInt(0)
+	if protocol.MustGetFeatureCtx(protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+		BlockHeight: tipHeight + 1,
+	}))).EnableTimeLockedTransfer {
+		span.AddEvent("account.TimeLockBalances")
+		var unlocked *big.Int
+		if unlocked, locked, err = account.TimeLockBalances(core.sf, addr, tipHeight+1, time.Now()); err != nil {
+			return nil, nil, status.Error(codes.Internal, err.Error())
+		}
+		balance = new(big.Int).Add(balance, unlocked)
+	}
 	// TODO: deprecate nonce field in account meta
 	accountMeta := &iotextypes.AccountMeta{
 		Address:      addrStr,
-		Balance:      state.Balance.String(),
+		Balance:      balance.String(),
 		PendingNonce: pendingNonce,
 		NumActions:   numActions,
 		IsContract:   state.IsContract(),
 	}
+	if locked.Sign() > 0 {
+		account.SetAccountMetaLockedBalance(accountMeta, locked)
+	}
 	if state.IsContract() {
 		var code protocol.SerializableBytes
 		_, err = core.sf.State(&code, protocol.NamespaceOption(evm.CodeKVNameSpace), protocol.KeyOption(state.CodeHash))
@@ -360,7 +610,7 @@ func (core *coreService) ChainMeta() (*iotextypes.ChainMeta, string, error) {
 	if tipHeight == 0 {
 		return &iotextypes.ChainMeta{
 			Epoch:   &iotextypes.EpochData{},
-			ChainID: core.bc.ChainID(),
+			ChainID: core.identity.ChainID,
 		}, "", nil
 	}
 	syncStatus := ""
@@ -369,7 +619,7 @@ func (core *coreService) ChainMeta() (*iotextypes.ChainMeta, string, error) {
 	}
 	chainMeta := &iotextypes.ChainMeta{
 		Height:  tipHeight,
-		ChainID: core.bc.ChainID(),
+		ChainID: core.identity.ChainID,
 	}
 	if core.indexer == nil {
 		return chainMeta, syncStatus, nil
@@ -430,25 +680,37 @@ func (core *coreService) ChainMeta() (*iotextypes.ChainMeta, string, error) {
 
 // ServerMeta gets the server metadata
 func (core *coreService) ServerMeta() (packageVersion string, packageCommitID string, gitStatus string, goVersion string, buildTime string) {
-	packageVersion = version.PackageVersion
-	packageCommitID = version.PackageCommitID
-	gitStatus = version.GitStatus
-	goVersion = version.GoVersion
-	buildTime = version.BuildTime
-	return
+	id := core.identity
+	return id.PackageVersion, id.PackageCommitID, id.GitStatus, id.GoVersion, id.BuildTime
 }
 
 // SendAction is the API to send an action to blockchain.
 func (core *coreService) SendAction(ctx context.Context, in *iotextypes.Action) (string, error) {
+	sent, err := core.SendActionWithStatus(ctx, in)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(sent.Hash[:]), nil
+}
+
+// SendActionWithStatus sends the action as SendAction. An action sent before, which is pending in the actpool or
+// confirmed in a block, is returned with its current status and no error, as long as it is the same action, so a
+// client retrying on a timeout gets the hash back. An action with the nonce of another one is still rejected
+func (core *coreService) SendActionWithStatus(ctx context.Context, in *iotextypes.Action) (*apitypes.SentAction, error) {
+	release, err := core.loadShedder.Admit(ctx, PriorityReserved)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	log.Logger("api").Debug("receive send action request")
 	selp, err := (&action.Deserializer{}).SetEvmNetworkID(core.EVMNetworkID()).ActionToSealedEnvelope(in)
 	if err != nil {
-		return "", status.Error(codes.InvalidArgument, err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
 	// reject action if chainID is not matched at KamchatkaHeight
 	if err := core.validateChainID(in.GetCore().GetChainID()); err != nil {
-		return "", err
+		return nil, err
 	}
 	// reject action if a replay tx is not whitelisted
 	var (
@@ -456,17 +718,23 @@ func (core *coreService) SendAction(ctx context.Context, in *iotextypes.Action)
 		deployer = selp.SenderAddress()
 	)
 	if selp.Encoding() == uint32(iotextypes.Encoding_ETHEREUM_UNPROTECTED) && !g.IsDeployerWhitelisted(deployer) {
-		return "", status.Errorf(codes.InvalidArgument, "replay deployer %v not whitelisted", deployer.Hex())
+		return nil, status.Errorf(codes.InvalidArgument, "replay deployer %v not whitelisted", deployer.Hex())
 	}
 
 	// Add to local actpool
 	ctx = protocol.WithRegistry(ctx, core.registry)
 	hash, err := selp.Hash()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
+	ctx, span := tracer.StartActionSpan(ctx, hash, "coreService.SendAction")
+	defer span.End()
 	l := log.Logger("api").With(zap.String("actionHash", hex.EncodeToString(hash[:])))
 	if err = core.ap.Add(ctx, selp); err != nil {
+		if known := core.knownAction(hash, err); known != nil {
+			l.Debug("Action sent before.", zap.String("status", known.Status))
+			return &apitypes.SentAction{Hash: hash, Known: true, Status: known}, nil
+		}
 		txBytes, serErr := proto.Marshal(in)
 		if serErr != nil {
 			l.Error("Data corruption", zap.Error(serErr))
@@ -486,7 +754,7 @@ func (core *coreService) SendAction(ctx context.Context, in *iotextypes.Action)
 		if err != nil {
 			log.Logger("api").Panic("Unexpected error attaching metadata", zap.Error(err))
 		}
-		return "", st.Err()
+		return nil, st.Err()
 	}
 	// If there is no error putting into local actpool, broadcast it to the network
 	// TODO: broadcast action hash if it's blobTx
@@ -497,6 +765,7 @@ func (core *coreService) SendAction(ctx context.Context, in *iotextypes.Action)
 			Hash: hash[:],
 		}
 	}
+	_, broadcastSpan := tracer.NewSpan(ctx, "coreService.broadcast")
 	if core.messageBatcher != nil && !isBlobTx {
 		// TODO: batch blobTx
 		err = core.messageBatcher.Put(&batch.Message{
@@ -507,16 +776,255 @@ func (core *coreService) SendAction(ctx context.Context, in *iotextypes.Action)
 	} else {
 		err = core.broadcastHandler(ctx, core.bc.ChainID(), out)
 	}
+	broadcastSpan.End()
 	if err != nil {
 		l.Warn("Failed to broadcast SendAction request.", zap.Error(err))
 	}
-	return hex.EncodeToString(hash[:]), nil
+	return &apitypes.SentAction{
+		Hash: hash,
+		Status: &apitypes.ActionWithStatus{
+			Status: apitypes.ActionStatusPending,
+			Action: selp,
+		},
+	}, nil
+}
+
+// knownAction returns the status of the action rejected by the actpool for being sent before, which is the same
+// action either in the actpool or in a block, or nil if the nonce is taken by a different action
+func (core *coreService) knownAction(h hash.Hash256, err error) *apitypes.ActionWithStatus {
+	switch errors.Cause(err) {
+	case action.ErrExistedInPool, action.ErrNonceTooLow:
+	default:
+		return nil
+	}
+	act, err := core.ActionWithStatusByHash(h)
+	if err != nil {
+		log.Logger("api").Warn("Failed to look up the action sent before.", log.Hex("actionHash", h[:]), zap.Error(err))
+		return nil
+	}
+	if act.Status == apitypes.ActionStatusNotFound {
+		return nil
+	}
+	return act
+}
+
+// ValidateAction runs the validation of the actpool on the action against the confirmed state and the pending actions
+// of the sender, and simulates the execution bounded by the block gas limit as EstimateExecutionGasConsumption. The
+// action is neither added into the actpool nor broadcast. A rejected action is returned with the reason, and the error
+// is only returned for the failure of the validation itself
+func (core *coreService) ValidateAction(ctx context.Context, in *iotextypes.Action) (*apitypes.ActionValidation, error) {
+	selp, err := (&action.Deserializer{}).SetEvmNetworkID(core.EVMNetworkID()).ActionToSealedEnvelope(in)
+	if err != nil {
+		return rejectAction(apitypes.RejectInvalidAction, err), nil
+	}
+	if err := core.validateChainID(in.GetCore().GetChainID()); err != nil {
+		return rejectAction(apitypes.RejectInvalidChainID, err), nil
+	}
+	var (
+		g        = core.Genesis()
+		deployer = selp.SenderAddress()
+	)
+	if selp.Encoding() == uint32(iotextypes.Encoding_ETHEREUM_UNPROTECTED) && !g.IsDeployerWhitelisted(deployer) {
+		return rejectAction(apitypes.RejectInvalidAction, errors.Errorf("replay deployer %v not whitelisted", deployer.Hex())), nil
+	}
+	ctx = protocol.WithRegistry(ctx, core.registry)
+	if err := core.ap.Check(ctx, selp); err != nil {
+		return rejectAction(actionRejectReason(err), err), nil
+	}
+	gas, err := action.IntrinsicGasBySchedule(selp.Action(), core.dataCostSchedule())
+	if err != nil {
+		return nil, err
+	}
+	if exec, ok := selp.Action().(*action.Execution); ok {
+		caller, err := simulationCaller(_admissionPolicy.caller, nil, selp)
+		if err != nil {
+			return rejectAction(apitypes.RejectInvalidSignature, err), nil
+		}
+		// simulate on a copy, the nonce, gas price and gas limit are changed by the estimation
+		sc, err := action.NewExecution(exec.Contract(), selp.Nonce(), exec.Amount(), selp.GasLimit(), selp.GasPrice(), exec.Data())
+		if err != nil {
+			return nil, err
+		}
+		if gas, err = core.estimateExecutionGas(ctx, sc, caller, _admissionPolicy); err != nil {
+			return rejectAction(apitypes.RejectExecutionReverted, err), nil
+		}
+		if gas > selp.GasLimit() {
+			return rejectAction(apitypes.RejectGasLimitTooLow, errors.Errorf("gas limit %d is lower than the estimated gas %d", selp.GasLimit(), gas)), nil
+		}
+	}
+	return &apitypes.ActionValidation{
+		Gas: gas,
+		Fee: new(big.Int).Mul(new(big.Int).SetUint64(gas), selp.GasPrice()),
+	}, nil
+}
+
+func rejectAction(reason string, err error) *apitypes.ActionValidation {
+	return &apitypes.ActionValidation{
+		Reason:  reason,
+		Message: err.Error(),
+	}
+}
+
+func actionRejectReason(err error) string {
+	switch errors.Cause(err) {
+	case action.ErrInvalidSender:
+		return apitypes.RejectInvalidSignature
+	case action.ErrExistedInPool:
+		return apitypes.RejectKnownTransaction
+	case action.ErrUnderpriced:
+		return apitypes.RejectUnderpriced
+	case action.ErrReplaceUnderpriced:
+		return apitypes.RejectReplaceUnderpriced
+	case action.ErrNonceTooLow:
+		return apitypes.RejectNonceTooLow
+	case action.ErrNonceTooHigh:
+		return apitypes.RejectNonceTooHigh
+	case action.ErrInsufficientFunds:
+		return apitypes.RejectInsufficientFunds
+	case action.ErrIntrinsicGas:
+		return apitypes.RejectIntrinsicGas
+	case actpool.ErrGasTooHigh, action.ErrGasLimit:
+		return apitypes.RejectGasTooHigh
+	case actpool.ErrGasLimitExceedsBlock:
+		return apitypes.RejectExceedsBlockGas
+	case actpool.ErrActionTooLarge:
+		return apitypes.RejectOversized
+	case action.ErrAddress, action.ErrInvalidAct, action.ErrInvalidAmount, action.ErrNegativeValue, action.ErrOversizedData, action.ErrNotSupported:
+		return apitypes.RejectInvalidAction
+	default:
+		return apitypes.RejectUnknown
+	}
 }
 
 func (core *coreService) PendingNonce(addr address.Address) (uint64, error) {
 	return core.ap.GetPendingNonce(addr.String())
 }
 
+// ActPoolStatus returns the usage of the actpool along with its policies
+func (core *coreService) ActPoolStatus() *actpool.ActPoolStatus {
+	return core.ap.Status()
+}
+
+// AccountNonceDetail returns the confirmed and pending nonce of an account, the missing nonces and the hashes of the
+// actions in the actpool by nonce
+func (core *coreService) AccountNonceDetail(addr address.Address) (*apitypes.AccountNonceDetail, error) {
+	detail, err := core.ap.GetNonceDetail(addr.String())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	ret := &apitypes.AccountNonceDetail{
+		ConfirmedNonce: detail.ConfirmedNonce,
+		PendingNonce:   detail.PendingNonce,
+		Gaps:           detail.Gaps,
+		Pending:        make([]*apitypes.PendingNonce, 0, len(detail.Actions)),
+	}
+	for _, selp := range detail.Actions {
+		h, err := selp.Hash()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		ret.Pending = append(ret.Pending, &apitypes.PendingNonce{Nonce: selp.Nonce(), Hash: h})
+	}
+	return ret, nil
+}
+
+// CancelPendingAction returns the unsigned zero-value self-transfer of the nonce, which replaces the pending action of
+// the sender once signed and sent. Its gas price is the one of the pending action raised by the bump, which must be
+// no lower than the minimum by the replacement rules of the actpool, and is the minimum if nil
+func (core *coreService) CancelPendingAction(sender address.Address, nonce uint64, gasPriceBump *big.Int) (*apitypes.CancelAction, error) {
+	detail, err := core.ap.GetNonceDetail(sender.String())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if nonce < detail.ConfirmedNonce {
+		return nil, status.Errorf(codes.FailedPrecondition, "the action of nonce %d has been mined", nonce)
+	}
+	var pending *action.SealedEnvelope
+	for _, selp := range detail.Actions {
+		if selp.Nonce() == nonce {
+			pending = selp
+			break
+		}
+	}
+	if pending == nil {
+		return nil, status.Errorf(codes.NotFound, "no pending action of nonce %d", nonce)
+	}
+	h, err := pending.Hash()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	_, info, err := core.ap.GetPendingActionInfo(h)
+	if err != nil {
+		if errors.Cause(err) == action.ErrNotFound {
+			// the action leaves the pool once mined
+			return nil, status.Errorf(codes.FailedPrecondition, "the action of nonce %d is no longer pending", nonce)
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	minBump := big.NewInt(0)
+	if info.MinReplacementGasFeeCap != nil {
+		minBump.Sub(info.MinReplacementGasFeeCap, pending.GasFeeCap())
+	}
+	bump := gasPriceBump
+	if bump == nil {
+		bump = minBump
+	}
+	if bump.Cmp(minBump) < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "gas price bump %s is lower than the minimum %s", bump, minBump)
+	}
+	gasPrice := new(big.Int).Add(pending.GasFeeCap(), bump)
+	tsf, err := action.NewTransfer(nonce, big.NewInt(0), sender.String(), nil, action.TransferBaseIntrinsicGas, gasPrice)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &apitypes.CancelAction{
+		Envelope: (&action.EnvelopeBuilder{}).
+			SetNonce(nonce).
+			SetGasPrice(gasPrice).
+			SetGasLimit(action.TransferBaseIntrinsicGas).
+			SetChainID(core.bc.ChainID()).
+			SetAction(tsf).Build(),
+		Pending:         pending,
+		MinGasPriceBump: minBump,
+	}, nil
+}
+
+// TotalSupply returns the total and circulating supply of token at the height, along with the base fee burnt and
+// redistributed, where height 0 is the tip
+func (core *coreService) TotalSupply(ctx context.Context, height uint64) (*rewarding.Supply, error) {
+	rp := rewarding.FindProtocol(core.registry)
+	if rp == nil {
+		return nil, status.Error(codes.Internal, "rewarding protocol is not registered")
+	}
+	tip := core.bc.TipHeight()
+	var sr protocol.StateReader = core.sf
+	switch {
+	case height == 0:
+		height = tip
+	case height > tip:
+		return nil, status.Errorf(codes.InvalidArgument, "height %d is higher than tip height %d", height, tip)
+	case height < tip:
+		sr = factory.NewHistoryStateReader(core.sf, height)
+	}
+	ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+		BlockHeight: height,
+	})
+	ctx = genesis.WithGenesisContext(
+		protocol.WithRegistry(ctx, core.registry),
+		core.bc.Genesis(),
+	)
+	supply, err := rp.TotalSupply(protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx)), sr)
+	if err != nil {
+		switch errors.Cause(err) {
+		case factory.ErrNoArchiveData, factory.ErrNotSupported:
+			return nil, status.Error(codes.Unimplemented, err.Error())
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	return supply, nil
+}
+
 func (core *coreService) validateChainID(chainID uint32) error {
 	ge := core.bc.Genesis()
 	if ge.IsQuebec(core.bc.TipHeight()) && chainID != core.bc.ChainID() {
@@ -531,7 +1039,16 @@ func (core *coreService) validateChainID(chainID uint32) error {
 // ReadContract reads the state in a contract address specified by the slot
 func (core *coreService) ReadContract(ctx context.Context, callerAddr address.Address, sc *action.Execution) (string, *iotextypes.Receipt, error) {
 	log.Logger("api").Debug("receive read smart contract request")
-	key := hash.Hash160b(append([]byte(sc.Contract()), sc.Data()...))
+	callerAddr, err := simulationCaller(_readPolicy.caller, callerAddr, nil)
+	if err != nil {
+		return "", nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	// the result depends on the impersonated caller and the value as well
+	var value []byte
+	if sc.Amount() != nil {
+		value = sc.Amount().Bytes()
+	}
+	key := hash.Hash160b(bytes.Join([][]byte{[]byte(sc.Contract()), sc.Data(), callerAddr.Bytes(), value}, []byte{0}))
 	// TODO: either moving readcache into the upper layer or change the storage format
 	if d, ok := core.readCache.Get(key); ok {
 		res := iotexapi.ReadContractResponse{}
@@ -564,7 +1081,8 @@ func (core *coreService) ReadContract(ctx context.Context, callerAddr address.Ad
 	if sc.GasLimit() == 0 || blockGasLimit < sc.GasLimit() {
 		sc.SetGasLimit(blockGasLimit)
 	}
-	sc.SetGasPrice(big.NewInt(0)) // ReadContract() is read-only, use 0 to prevent insufficient gas
+	// ReadContract() is read-only and doesn't check the balance, the gas is free to prevent insufficient gas
+	sc.SetGasPrice(big.NewInt(0))
 
 	retval, receipt, err := core.simulateExecution(ctx, callerAddr, sc, core.dao.GetBlockHash, core.getBlockTime)
 	if err != nil {
@@ -584,13 +1102,25 @@ func (core *coreService) ReadContract(ctx context.Context, callerAddr address.Ad
 
 // ReadState reads state on blockchain
 func (core *coreService) ReadState(protocolID string, height string, methodName []byte, arguments [][]byte) (*iotexapi.ReadStateResponse, error) {
+	release, err := core.loadShedder.Admit(context.Background(), PriorityReserved)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	p, ok := core.registry.Find(protocolID)
 	if !ok {
 		return nil, status.Errorf(codes.Internal, "protocol %s isn't registered", protocolID)
 	}
 	data, readStateHeight, err := core.readState(context.Background(), p, height, methodName, arguments...)
 	if err != nil {
-		return nil, status.Error(codes.NotFound, err.Error())
+		switch errors.Cause(err) {
+		case factory.ErrOutOfRetentionRange:
+			return nil, status.Error(codes.OutOfRange, err.Error())
+		case ErrHistoryUnavailable:
+			return nil, status.Error(codes.Unavailable, err.Error())
+		default:
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
 	}
 	blkHash, err := core.dao.GetBlockHash(readStateHeight)
 	if err != nil {
@@ -613,6 +1143,25 @@ func (core *coreService) SuggestGasPrice() (uint64, error) {
 	return core.gs.SuggestGasPrice()
 }
 
+// SuggestGasPrices suggests the gas prices of the slow, standard and fast tiers
+func (core *coreService) SuggestGasPrices() (*gasstation.GasPrices, error) {
+	return core.gs.SuggestGasPrices()
+}
+
+// SuggestGasTipCap suggests the gas tip cap
+func (core *coreService) SuggestGasTipCap() (uint64, error) {
+	return core.gs.SuggestGasTipCap()
+}
+
+// FeeHistory returns the fee history of the blocks up to the newest
+func (core *coreService) FeeHistory(blocks, newest uint64, rewardPercentiles []float64) (uint64, [][]*big.Int, []*big.Int, []float64, error) {
+	oldest, rewards, baseFees, gasUsedRatios, err := core.gs.FeeHistory(blocks, newest, rewardPercentiles)
+	if errors.Cause(err) == gasstation.ErrNotFound {
+		return 0, nil, nil, nil, errors.Wrap(ErrNotFound, err.Error())
+	}
+	return oldest, rewards, baseFees, gasUsedRatios, err
+}
+
 // EstimateGasForAction estimates gas for action
 func (core *coreService) EstimateGasForAction(ctx context.Context, in *iotextypes.Action) (uint64, error) {
 	selp, err := (&action.Deserializer{}).SetEvmNetworkID(core.EVMNetworkID()).ActionToSealedEnvelope(in)
@@ -621,7 +1170,7 @@ func (core *coreService) EstimateGasForAction(ctx context.Context, in *iotextype
 	}
 	sc, ok := selp.Action().(*action.Execution)
 	if !ok {
-		gas, err := selp.IntrinsicGas()
+		gas, err := action.IntrinsicGasBySchedule(selp.Action(), core.dataCostSchedule())
 		if err != nil {
 			return 0, status.Error(codes.Internal, err.Error())
 		}
@@ -710,7 +1259,8 @@ func (core *coreService) EpochMeta(epochNum uint64) (*iotextypes.EpochData, uint
 	return epochData, numBlks, blockProducersInfo, nil
 }
 
-// RawBlocks gets raw block data
+// RawBlocks gets raw block data, the query fails with ErrResultTooLarge once the size of the blocks read exceeds the
+// ResponseSizeLimit
 func (core *coreService) RawBlocks(startHeight uint64, count uint64, withReceipts bool, withTransactionLogs bool) ([]*iotexapi.BlockInfo, error) {
 	if count == 0 || count > core.cfg.RangeQueryLimit {
 		return nil, status.Error(codes.InvalidArgument, "range exceeds the limit")
@@ -724,7 +1274,10 @@ func (core *coreService) RawBlocks(startHeight uint64, count uint64, withReceipt
 	if endHeight > tipHeight {
 		endHeight = tipHeight
 	}
-	var res []*iotexapi.BlockInfo
+	var (
+		res  []*iotexapi.BlockInfo
+		size int
+	)
 	for height := startHeight; height <= endHeight; height++ {
 		blk, err := core.dao.GetBlockByHeight(height)
 		if err != nil {
@@ -737,7 +1290,7 @@ func (core *coreService) RawBlocks(startHeight uint64, count uint64, withReceipt
 				return nil, status.Error(codes.NotFound, err.Error())
 			}
 			for _, receipt := range receipts {
-				receiptsPb = append(receiptsPb, receipt.ConvertToReceiptPb())
+				receiptsPb = append(receiptsPb, receiptPbWithStatusMessage(receipt))
 			}
 		}
 		var transactionLogs *iotextypes.TransactionLogs
@@ -746,11 +1299,17 @@ func (core *coreService) RawBlocks(startHeight uint64, count uint64, withReceipt
 				return nil, status.Error(codes.NotFound, err.Error())
 			}
 		}
-		res = append(res, &iotexapi.BlockInfo{
+		info := &iotexapi.BlockInfo{
 			Block:           blk.ConvertToBlockPb(),
 			Receipts:        receiptsPb,
 			TransactionLogs: transactionLogs,
-		})
+		}
+		if limit := core.cfg.ResponseSizeLimit; limit > 0 {
+			if size += proto.Size(info); size > limit {
+				return nil, errors.Wrapf(ErrResultTooLarge, "blocks in [%d, %d] exceed %d bytes", startHeight, endHeight, limit)
+			}
+		}
+		res = append(res, info)
 	}
 	return res, nil
 }
@@ -880,98 +1439,358 @@ func (core *coreService) TransactionLogByBlockHeight(blockHeight uint64) (*iotex
 	return blockIdentifier, sysLog, nil
 }
 
-func (core *coreService) TipHeight() uint64 {
-	return core.bc.TipHeight()
-}
-
-// Start starts the API server
-func (core *coreService) Start(_ context.Context) error {
-	if err := core.chainListener.Start(); err != nil {
-		return errors.Wrap(err, "failed to start blockchain listener")
+// ContractsCreatedByBlock returns the contracts created in the block in the order of the actions, including the ones
+// created by contracts
+func (core *coreService) ContractsCreatedByBlock(blockHeight uint64) ([]*apitypes.CreatedContract, error) {
+	tip, err := core.dao.Height()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
 	}
-	if core.messageBatcher != nil {
-		if err := core.messageBatcher.Start(); err != nil {
-			return errors.Wrap(err, "failed to start message batcher")
+	if blockHeight < 1 || blockHeight > tip {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid block height = %d", blockHeight)
+	}
+	receipts, err := core.dao.GetReceipts(blockHeight)
+	if err != nil {
+		if errors.Cause(err) == db.ErrNotExist {
+			return nil, status.Error(codes.NotFound, err.Error())
 		}
+		return nil, status.Error(codes.Internal, err.Error())
 	}
-	return nil
-}
-
-// Stop stops the API server
-func (core *coreService) Stop(_ context.Context) error {
-	if core.messageBatcher != nil {
-		if err := core.messageBatcher.Stop(); err != nil {
-			return errors.Wrap(err, "failed to stop message batcher")
+	ret := []*apitypes.CreatedContract{}
+	for _, receipt := range receipts {
+		for _, c := range receipt.CreatedContracts() {
+			ret = append(ret, &apitypes.CreatedContract{
+				ActionHash: receipt.ActionHash,
+				Address:    c.Address,
+				Creator:    c.Counterparty,
+				Depth:      c.Depth,
+			})
 		}
 	}
-	return core.chainListener.Stop()
+	return ret, nil
 }
 
-func (core *coreService) readState(ctx context.Context, p protocol.Protocol, height string, methodName []byte, arguments ...[]byte) ([]byte, uint64, error) {
-	key := ReadKey{
-		Name:   p.Name(),
-		Height: height,
-		Method: methodName,
-		Args:   arguments,
-	}
-	tipHeight := core.bc.TipHeight()
-	if height == "" {
-		key.Height = strconv.FormatUint(tipHeight, 10)
+// RawBlockByHeight returns the serialized protos of the parts of the block at the height, which are read without
+// decoding the block where the block store supports it
+func (core *coreService) RawBlockByHeight(height uint64, parts apitypes.RawBlockParts) (*apitypes.RawBlock, error) {
+	if height < 1 || height > core.bc.TipHeight() {
+		return nil, errors.Wrapf(ErrNotFound, "block at height %d", height)
 	}
-	if d, ok := core.readCache.Get(key.Hash()); ok {
-		h := tipHeight
-		if height != "" {
-			h, _ = strconv.ParseUint(height, 0, 64)
-		}
-		return d, h, nil
+	h, err := core.dao.GetBlockHash(height)
+	if err != nil {
+		return nil, errors.Wrap(ErrNotFound, err.Error())
 	}
+	return core.rawBlock(height, h, parts)
+}
 
-	// TODO: need to complete the context
-	ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{
-		BlockHeight: tipHeight,
-	})
-	ctx = genesis.WithGenesisContext(
-		protocol.WithRegistry(ctx, core.registry),
-		core.bc.Genesis(),
-	)
-	ctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
-
-	if height != "" {
-		inputHeight, err := strconv.ParseUint(height, 0, 64)
-		if err != nil {
-			return nil, uint64(0), err
-		}
-		rp := rolldpos.FindProtocol(core.registry)
-		if rp != nil {
-			tipEpochNum := rp.GetEpochNum(tipHeight)
-			inputEpochNum := rp.GetEpochNum(inputHeight)
-			if inputEpochNum < tipEpochNum {
-				inputHeight = rp.GetEpochHeight(inputEpochNum)
-			}
-		}
-		if inputHeight < tipHeight {
-			// old data, wrap to history state reader
-			d, h, err := p.ReadState(ctx, factory.NewHistoryStateReader(core.sf, inputHeight), methodName, arguments...)
-			if err == nil {
-				key.Height = strconv.FormatUint(h, 10)
-				core.readCache.Put(key.Hash(), d)
-			}
-			return d, h, err
-		}
+// RawBlockByHash returns the serialized protos of the parts of the block by hash, see RawBlockByHeight
+func (core *coreService) RawBlockByHash(h hash.Hash256, parts apitypes.RawBlockParts) (*apitypes.RawBlock, error) {
+	height, err := core.dao.GetBlockHeight(h)
+	if err != nil {
+		return nil, errors.Wrap(ErrNotFound, err.Error())
 	}
-	// TODO: need to distinguish user error and system error
-	d, h, err := p.ReadState(ctx, core.sf, methodName, arguments...)
-	if err == nil {
-		key.Height = strconv.FormatUint(h, 10)
-		core.readCache.Put(key.Hash(), d)
+	if height < 1 {
+		return nil, errors.Wrapf(ErrNotFound, "block %x", h)
 	}
-	return d, h, err
+	return core.rawBlock(height, h, parts)
 }
 
-func (core *coreService) getActionsFromIndex(start, count uint64) ([]*iotexapi.ActionInfo, error) {
-	hashes, err := core.indexer.GetActionHashFromIndex(start, count)
+// RawHeaders returns the serialized headers of count blocks from start in height order, which are cut at the tip and
+// at 1000 headers, so a light client syncs the headers by consecutive queries
+func (core *coreService) RawHeaders(start, count uint64) ([]*apitypes.RawBlock, error) {
+	release, err := core.loadShedder.Admit(context.Background(), PriorityHeavy)
 	if err != nil {
-		return nil, status.Error(codes.Unavailable, err.Error())
+		return nil, err
+	}
+	defer release()
+	if count == 0 {
+		return nil, status.Error(codes.InvalidArgument, "count must be greater than zero")
+	}
+	tip := core.bc.TipHeight()
+	if start < 1 || start > tip {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid block height = %d", start)
+	}
+	count = min(count, _maxRawHeaders, tip-start+1)
+	headers := make([]*apitypes.RawBlock, 0, count)
+	for height := start; height < start+count; height++ {
+		h, err := core.dao.GetBlockHash(height)
+		if err != nil {
+			return nil, errors.Wrap(ErrNotFound, err.Error())
+		}
+		header, err := core.rawBlock(height, h, apitypes.RawBlockHeader)
+		if err != nil {
+			return nil, err
+		}
+		headers = append(headers, header)
+	}
+	return headers, nil
+}
+
+// ActionInclusionProof returns the merkle proof of the action included in a block, which is verified against the block
+// hash by block.VerifyActionInclusionProof without trusting the API node
+func (core *coreService) ActionInclusionProof(h hash.Hash256) (*block.ActionInclusionProof, error) {
+	blk, err := core.blockOfAction(h)
+	if err != nil {
+		return nil, err
+	}
+	_, index, err := blk.ActionByHash(h)
+	if err != nil {
+		return nil, errors.Wrap(ErrNotFound, err.Error())
+	}
+	return block.NewActionInclusionProof(blk, int(index))
+}
+
+func (core *coreService) rawBlock(height uint64, h hash.Hash256, parts apitypes.RawBlockParts) (*apitypes.RawBlock, error) {
+	store, err := blockdao.BlockStore(core.dao, height)
+	if err != nil {
+		return nil, errors.Wrap(ErrNotFound, err.Error())
+	}
+	var (
+		blk = &apitypes.RawBlock{Height: height, Hash: h}
+		pbs = []struct {
+			part apitypes.RawBlockParts
+			msg  proto.Message
+			data *[]byte
+		}{
+			{apitypes.RawBlockHeader, store.GetBlock().GetHeader(), &blk.Header},
+			{apitypes.RawBlockBody, store.GetBlock().GetBody(), &blk.Body},
+			{apitypes.RawBlockFooter, store.GetBlock().GetFooter(), &blk.Footer},
+			{apitypes.RawBlockReceipts, &iotextypes.Receipts{Receipts: store.GetReceipts()}, &blk.Receipts},
+		}
+	)
+	for _, pb := range pbs {
+		if parts&pb.part == 0 {
+			continue
+		}
+		data, err := proto.Marshal(pb.msg)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		// the part asked for is never nil, even if it serializes into no byte
+		*pb.data = append([]byte{}, data...)
+	}
+	return blk, nil
+}
+
+// TransactionLogsByBlockHeightRange returns the transaction logs of count blocks from start in height order, with only
+// the transactions to the recipients if any. The blocks are returned until the size of the logs reaches the limit,
+// and the height after the last block returned is the one to continue from
+func (core *coreService) TransactionLogsByBlockHeightRange(start, count uint64, recipients []address.Address) ([]*apitypes.BlockTransactionLogs, uint64, error) {
+	release, err := core.loadShedder.Admit(context.Background(), PriorityHeavy)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer release()
+	if !core.dao.ContainsTransactionLog() {
+		return nil, 0, status.Error(codes.Unimplemented, filedao.ErrNotSupported.Error())
+	}
+	if count == 0 || count > core.cfg.RangeQueryLimit {
+		return nil, 0, status.Error(codes.InvalidArgument, "range exceeds the limit")
+	}
+	tip, err := core.dao.Height()
+	if err != nil {
+		return nil, 0, status.Error(codes.Internal, err.Error())
+	}
+	if start < 1 || start > tip {
+		return nil, 0, status.Errorf(codes.InvalidArgument, "invalid block height = %d", start)
+	}
+	if count > tip-start+1 {
+		count = tip - start + 1
+	}
+	logs, err := blockdao.TransactionLogsInRange(core.dao, start, count)
+	if err != nil {
+		return nil, 0, status.Error(codes.Internal, err.Error())
+	}
+	filter := make(map[string]struct{}, len(recipients))
+	for _, addr := range recipients {
+		filter[addr.String()] = struct{}{}
+	}
+	var (
+		res  = make([]*apitypes.BlockTransactionLogs, 0, len(logs))
+		size int
+	)
+	for i, l := range logs {
+		height := start + uint64(i)
+		blkLogs := &apitypes.BlockTransactionLogs{
+			Height: height,
+			Status: apitypes.TransactionLogStatusUnavailable,
+		}
+		if l != nil {
+			h, err := core.dao.GetBlockHash(height)
+			switch errors.Cause(err) {
+			case nil:
+				blkLogs.Hash = h
+				if l = filterTransactionLogs(l, filter); len(l.Logs) > 0 {
+					blkLogs.Status = apitypes.TransactionLogStatusFound
+					blkLogs.Logs = l
+				} else {
+					blkLogs.Status = apitypes.TransactionLogStatusEmpty
+				}
+			case db.ErrNotExist:
+			default:
+				return nil, 0, status.Error(codes.Internal, err.Error())
+			}
+		}
+		// at least one block is returned so that the caller can move on
+		if size += proto.Size(blkLogs.Logs); size > core.cfg.TransactionLogRangeSizeLimit && len(res) > 0 {
+			return res, height, nil
+		}
+		res = append(res, blkLogs)
+	}
+	return res, start + count, nil
+}
+
+// filterTransactionLogs returns the transactions to the recipients in the filter, or all if the filter is empty
+func filterTransactionLogs(logs *iotextypes.TransactionLogs, filter map[string]struct{}) *iotextypes.TransactionLogs {
+	if len(filter) == 0 {
+		return logs
+	}
+	filtered := &iotextypes.TransactionLogs{}
+	for _, l := range logs.Logs {
+		var txs []*iotextypes.TransactionLog_Transaction
+		for _, tx := range l.Transactions {
+			if _, ok := filter[tx.Recipient]; ok {
+				txs = append(txs, tx)
+			}
+		}
+		if len(txs) > 0 {
+			filtered.Logs = append(filtered.Logs, &iotextypes.TransactionLog{
+				ActionHash:      l.ActionHash,
+				NumTransactions: uint64(len(txs)),
+				Transactions:    txs,
+			})
+		}
+	}
+	return filtered
+}
+
+func (core *coreService) TipHeight() uint64 {
+	return core.bc.TipHeight()
+}
+
+// FinalizedHeight returns the height of the last finalized block, which is the tip without a finality reader, as
+// rolldpos gives instant finality
+func (core *coreService) FinalizedHeight() uint64 {
+	if core.finality == nil {
+		return core.bc.TipHeight()
+	}
+	return core.finality.FinalizedHeight()
+}
+
+// EarliestStateHeight returns the earliest height whose state can be queried, the states below it are pruned by the
+// state retention of the factory
+func (core *coreService) EarliestStateHeight() uint64 {
+	return core.sf.EarliestStateHeight()
+}
+
+// Start starts the API server
+func (core *coreService) Start(_ context.Context) error {
+	if err := core.chainListener.Start(); err != nil {
+		return errors.Wrap(err, "failed to start blockchain listener")
+	}
+	if core.messageBatcher != nil {
+		if err := core.messageBatcher.Start(); err != nil {
+			return errors.Wrap(err, "failed to start message batcher")
+		}
+	}
+	return nil
+}
+
+// Stop stops the API server
+func (core *coreService) Stop(_ context.Context) error {
+	if core.messageBatcher != nil {
+		if err := core.messageBatcher.Stop(); err != nil {
+			return errors.Wrap(err, "failed to stop message batcher")
+		}
+	}
+	return core.chainListener.Stop()
+}
+
+func (core *coreService) readState(ctx context.Context, p protocol.Protocol, height string, methodName []byte, arguments ...[]byte) ([]byte, uint64, error) {
+	var (
+		tipHeight = core.bc.TipHeight()
+		rp        = rolldpos.FindProtocol(core.registry)
+		scope     = ReadScopeBlock
+		bucket    = tipHeight
+		readAt    = tipHeight
+	)
+	if height != "" {
+		inputHeight, err := strconv.ParseUint(height, 0, 64)
+		if err != nil {
+			return nil, uint64(0), err
+		}
+		if rp != nil && isEpochScopedRead(p.Name(), methodName) {
+			tipEpochNum := rp.GetEpochNum(tipHeight)
+			inputEpochNum := rp.GetEpochNum(inputHeight)
+			if inputEpochNum < tipEpochNum {
+				inputHeight = rp.GetEpochHeight(inputEpochNum)
+			}
+		}
+		if inputHeight < tipHeight {
+			// old data, read on top of the state retained at the height
+			readAt = inputHeight
+			scope = ReadScopeHistory
+		}
+		bucket = inputHeight
+	} else if rp != nil && isEpochScopedRead(p.Name(), methodName) {
+		scope = ReadScopeEpoch
+		bucket = rp.GetEpochHeight(rp.GetEpochNum(tipHeight))
+	}
+	// TODO: need to complete the context
+	ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+		BlockHeight: readAt,
+	})
+	ctx = genesis.WithGenesisContext(
+		protocol.WithRegistry(ctx, core.registry),
+		core.bc.Genesis(),
+	)
+	ctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
+	read := func() ([]byte, uint64, error) {
+		if readAt == tipHeight {
+			// TODO: need to distinguish user error and system error
+			return p.ReadState(ctx, core.sf, methodName, arguments...)
+		}
+		sr, err := core.sf.StateReaderAtHeight(ctx, readAt)
+		switch errors.Cause(err) {
+		case nil:
+		case factory.ErrNoArchiveData, factory.ErrNotSupported:
+			// the indexers of the protocols could still serve the read without the states at the height
+			sr = factory.NewHistoryStateReader(core.sf, readAt)
+		default:
+			return nil, uint64(0), err
+		}
+		data, h, err := p.ReadState(ctx, sr, methodName, arguments...)
+		if cause := errors.Cause(err); cause == factory.ErrNoArchiveData || cause == factory.ErrNotSupported {
+			return nil, uint64(0), errors.Wrap(ErrHistoryUnavailable, err.Error())
+		}
+		return data, h, err
+	}
+	if bypassReadCache(ctx) {
+		return read()
+	}
+	key := ReadKey{
+		Name:   p.Name(),
+		Height: strconv.FormatUint(bucket, 10),
+		Method: methodName,
+		Args:   arguments,
+	}
+	return core.readCache.Load(key.Hash(), scope, bucket, read)
+}
+
+// isEpochScopedRead returns whether the result of the read only changes per epoch at the tip
+func isEpochScopedRead(protocolID string, methodName []byte) bool {
+	methods, ok := _epochScopedReads[protocolID]
+	if !ok {
+		return false
+	}
+	_, ok = methods[string(methodName)]
+	return ok
+}
+
+func (core *coreService) getActionsFromIndex(start, count uint64) ([]*iotexapi.ActionInfo, error) {
+	hashes, err := core.indexer.GetActionHashFromIndex(start, count)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
 	}
 	var actionInfo []*iotexapi.ActionInfo
 	for i := range hashes {
@@ -1080,7 +1899,283 @@ func (core *coreService) ActionsByAddress(addr address.Address, start uint64, co
 		}
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
+	return core.actionsByHashes(actions), nil
+}
+
+// ActionsByAddressWithFilter returns the actions associated with an address matching the filter
+func (core *coreService) ActionsByAddressWithFilter(addr address.Address, filter *blockindex.ActionFilter, start uint64, count uint64) ([]*iotexapi.ActionInfo, error) {
+	if err := core.checkActionIndex(); err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, status.Error(codes.InvalidArgument, "count must be greater than zero")
+	}
+	if count > core.cfg.RangeQueryLimit {
+		return nil, status.Error(codes.InvalidArgument, "range exceeds the limit")
+	}
+
+	actions, err := core.indexer.GetActionsByAddressWithFilter(hash.BytesToHash160(addr.Bytes()), filter, start, count)
+	if err != nil {
+		switch errors.Cause(err) {
+		case db.ErrBucketNotExist, db.ErrNotExist:
+			// no actions associated with address, return nil
+			return nil, nil
+		case db.ErrInvalid:
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case blockindex.ErrActionFilterNA:
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return core.actionsByHashes(actions), nil
+}
 
+// TokenTransfersByAddress returns the XRC20 and XRC721 transfers from or to an address
+func (core *coreService) TokenTransfersByAddress(addr address.Address, query *blockindex.TokenTransferQuery) ([]*blockindex.TokenTransfer, uint64, error) {
+	if err := core.checkTokenTransferQuery(query); err != nil {
+		return nil, 0, err
+	}
+	return tokenTransfersResult(core.tsfIndexer.TransfersByAddress(hash.BytesToHash160(addr.Bytes()), query))
+}
+
+// TokenTransfersByContract returns the transfers of an XRC20 or XRC721 contract
+func (core *coreService) TokenTransfersByContract(token address.Address, query *blockindex.TokenTransferQuery) ([]*blockindex.TokenTransfer, uint64, error) {
+	if err := core.checkTokenTransferQuery(query); err != nil {
+		return nil, 0, err
+	}
+	return tokenTransfersResult(core.tsfIndexer.TransfersByContract(hash.BytesToHash160(token.Bytes()), query))
+}
+
+func (core *coreService) checkTokenTransferQuery(query *blockindex.TokenTransferQuery) error {
+	if core.tsfIndexer == nil {
+		return status.Error(codes.Unavailable, "token transfer indexer is not enabled")
+	}
+	if query == nil || query.Count == 0 {
+		return status.Error(codes.InvalidArgument, "count must be greater than zero")
+	}
+	if query.Count > core.cfg.RangeQueryLimit {
+		return status.Error(codes.InvalidArgument, "range exceeds the limit")
+	}
+	return nil
+}
+
+func tokenTransfersResult(tsfs []*blockindex.TokenTransfer, next uint64, err error) ([]*blockindex.TokenTransfer, uint64, error) {
+	if err != nil {
+		if errors.Cause(err) == db.ErrInvalid {
+			return nil, 0, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, 0, status.Error(codes.Internal, err.Error())
+	}
+	return tsfs, next, nil
+}
+
+// CandidateHistory returns the votes, self-stake, bucket count and rank of a candidate at the end of the epochs
+func (core *coreService) CandidateHistory(candidate address.Address, startEpoch, endEpoch uint64) ([]*staking.CandidateHistory, error) {
+	if core.candHistory == nil {
+		return nil, status.Error(codes.Unavailable, "candidate history indexer is not enabled")
+	}
+	if startEpoch > endEpoch {
+		return nil, status.Error(codes.InvalidArgument, "start epoch is greater than end epoch")
+	}
+	if endEpoch-startEpoch >= core.cfg.RangeQueryLimit {
+		return nil, status.Error(codes.InvalidArgument, "range exceeds the limit")
+	}
+	return candidateHistoryResult(core.candHistory.CandidateHistory(candidate, startEpoch, endEpoch))
+}
+
+// EpochRanking returns the candidates at the end of an epoch in the order of rank
+func (core *coreService) EpochRanking(epoch uint64) ([]*staking.CandidateHistory, error) {
+	if core.candHistory == nil {
+		return nil, status.Error(codes.Unavailable, "candidate history indexer is not enabled")
+	}
+	return candidateHistoryResult(core.candHistory.EpochRanking(epoch))
+}
+
+func candidateHistoryResult(histories []*staking.CandidateHistory, err error) ([]*staking.CandidateHistory, error) {
+	if err != nil {
+		switch errors.Cause(err) {
+		case db.ErrInvalid:
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case db.ErrNotExist:
+			return nil, status.Error(codes.NotFound, err.Error())
+		case staking.ErrCandidateHistoryNA:
+			return nil, status.Error(codes.OutOfRange, err.Error())
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	return histories, nil
+}
+
+// SystemActionsByHeight returns the GrantReward and PutPollResult actions of a block
+func (core *coreService) SystemActionsByHeight(height uint64) ([]*blockindex.SystemAction, error) {
+	if core.saIndexer == nil {
+		return nil, status.Error(codes.Unavailable, "system action indexer is not enabled")
+	}
+	return systemActionsResult(core.saIndexer.SystemActionsByHeight(height))
+}
+
+// GrantRewardsByEpoch returns the GrantReward actions of the blocks in an epoch
+func (core *coreService) GrantRewardsByEpoch(epoch uint64) ([]*blockindex.SystemAction, error) {
+	if core.saIndexer == nil {
+		return nil, status.Error(codes.Unavailable, "system action indexer is not enabled")
+	}
+	return systemActionsResult(core.saIndexer.GrantRewardsByEpoch(epoch))
+}
+
+func systemActionsResult(sas []*blockindex.SystemAction, err error) ([]*blockindex.SystemAction, error) {
+	if err != nil {
+		if errors.Cause(err) == db.ErrNotExist {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return sas, nil
+}
+
+// ContractStats returns the daily call stats of a contract within the range of days
+func (core *coreService) ContractStats(contract address.Address, fromDay, toDay uint64) ([]*blockindex.ContractDayStats, error) {
+	if err := core.checkContractStatsRange(fromDay, toDay); err != nil {
+		return nil, err
+	}
+	stats, err := core.csIndexer.ContractStats(hash.BytesToHash160(contract.Bytes()), fromDay, toDay)
+	if err != nil {
+		return nil, contractStatsError(err)
+	}
+	return stats, nil
+}
+
+// TopContracts returns the contracts with the most call stats in the order within the range of days
+func (core *coreService) TopContracts(fromDay, toDay uint64, order blockindex.ContractStatsOrder, limit uint64) ([]*blockindex.ContractStats, error) {
+	if err := core.checkContractStatsRange(fromDay, toDay); err != nil {
+		return nil, err
+	}
+	if limit == 0 || limit > core.cfg.RangeQueryLimit {
+		return nil, status.Error(codes.InvalidArgument, "limit is zero or exceeds the range query limit")
+	}
+	stats, err := core.csIndexer.TopContracts(fromDay, toDay, order, limit)
+	if err != nil {
+		return nil, contractStatsError(err)
+	}
+	return stats, nil
+}
+
+func (core *coreService) checkContractStatsRange(fromDay, toDay uint64) error {
+	if core.csIndexer == nil {
+		return status.Error(codes.Unavailable, "contract stats indexer is not enabled")
+	}
+	if fromDay > toDay {
+		return status.Error(codes.InvalidArgument, "from day is greater than to day")
+	}
+	if toDay-fromDay >= _maxContractStatsDays {
+		return status.Error(codes.InvalidArgument, "range exceeds the limit")
+	}
+	return nil
+}
+
+// TransfersByRecipientAndMemo returns the successful transfers to a recipient with the memo in the payload
+func (core *coreService) TransfersByRecipientAndMemo(recipient address.Address, memo string, query *blockindex.MemoTransferQuery) ([]*blockindex.MemoTransfer, uint64, error) {
+	if core.memoIndexer == nil {
+		return nil, 0, status.Error(codes.Unavailable, "memo indexer is not enabled")
+	}
+	if query == nil || query.Count == 0 {
+		return nil, 0, status.Error(codes.InvalidArgument, "count must be greater than zero")
+	}
+	if query.Count > core.cfg.RangeQueryLimit {
+		return nil, 0, status.Error(codes.InvalidArgument, "range exceeds the limit")
+	}
+	tsfs, next, err := core.memoIndexer.TransfersByRecipientAndMemo(hash.BytesToHash160(recipient.Bytes()), []byte(memo), query)
+	if err != nil {
+		if errors.Cause(err) == db.ErrInvalid {
+			return nil, 0, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, 0, status.Error(codes.Internal, err.Error())
+	}
+	return tsfs, next, nil
+}
+
+// BlockHeightByTimestamp returns the height of the block by the timestamp
+func (core *coreService) BlockHeightByTimestamp(ts time.Time, before bool) (uint64, error) {
+	if core.blockTimeIndexer == nil {
+		return 0, status.Error(codes.Unavailable, "block time indexer is not enabled")
+	}
+	height, err := core.blockTimeIndexer.HeightByTimestamp(ts, before)
+	if err != nil {
+		if errors.Cause(err) == db.ErrNotExist {
+			return 0, errors.Wrap(ErrNotFound, err.Error())
+		}
+		return 0, status.Error(codes.Internal, err.Error())
+	}
+	return height, nil
+}
+
+// ProtocolParameters returns the height-gated parameters of the protocols in force at the height, and the
+// activations after the height
+func (core *coreService) ProtocolParameters(height uint64) ([]protocol.Parameter, []protocol.Parameter, error) {
+	params := core.registry.Parameters()
+	if params == nil {
+		return nil, nil, status.Error(codes.Unavailable, "protocol parameters are not populated")
+	}
+	return params.InForce(height), params.Upcoming(height), nil
+}
+
+// BlockMetasByTimestampRange returns the metas of the blocks with the time in [start, end] from the offset
+func (core *coreService) BlockMetasByTimestampRange(start, end time.Time, offset, count uint64) ([]*iotextypes.BlockMeta, uint64, error) {
+	if core.blockTimeIndexer == nil {
+		return nil, 0, status.Error(codes.Unavailable, "block time indexer is not enabled")
+	}
+	if start.After(end) {
+		return nil, 0, status.Error(codes.InvalidArgument, "start time is after end time")
+	}
+	if count == 0 {
+		return nil, 0, status.Error(codes.InvalidArgument, "count must be greater than zero")
+	}
+	if count > core.cfg.RangeQueryLimit {
+		return nil, 0, status.Error(codes.InvalidArgument, "range exceeds the limit")
+	}
+	from, err := core.BlockHeightByTimestamp(start, false)
+	if err != nil {
+		if errors.Cause(err) == ErrNotFound {
+			return []*iotextypes.BlockMeta{}, 0, nil
+		}
+		return nil, 0, err
+	}
+	to, err := core.BlockHeightByTimestamp(end, true)
+	if err != nil {
+		if errors.Cause(err) == ErrNotFound {
+			return []*iotextypes.BlockMeta{}, 0, nil
+		}
+		return nil, 0, err
+	}
+	if from > to {
+		return []*iotextypes.BlockMeta{}, 0, nil
+	}
+	total := to - from + 1
+	if offset >= total {
+		return []*iotextypes.BlockMeta{}, total, nil
+	}
+	if count > total-offset {
+		count = total - offset
+	}
+	blks, err := core.BlockByHeightRange(from+offset, count)
+	if err != nil {
+		return nil, 0, status.Error(codes.NotFound, err.Error())
+	}
+	res := make([]*iotextypes.BlockMeta, 0, len(blks))
+	for _, blk := range blks {
+		res = append(res, generateBlockMeta(blk))
+	}
+	return res, total, nil
+}
+
+func contractStatsError(err error) error {
+	if errors.Cause(err) == db.ErrInvalid {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func (core *coreService) actionsByHashes(actions [][]byte) []*iotexapi.ActionInfo {
 	var res []*iotexapi.ActionInfo
 	for i := range actions {
 		act, err := core.getAction(hash.BytesToHash256(actions[i]), false)
@@ -1089,7 +2184,7 @@ func (core *coreService) ActionsByAddress(addr address.Address, start uint64, co
 		}
 		res = append(res, act)
 	}
-	return res, nil
+	return res
 }
 
 // BlockHashByBlockHeight returns block hash by block height
@@ -1127,6 +2222,80 @@ func (core *coreService) PendingActionByActionHash(h hash.Hash256) (*action.Seal
 	return selp, nil
 }
 
+// ActionWithStatusByHash returns the action by hash, which is pending in the actpool, confirmed in a block, or not
+// found. The actpool is looked up ahead of the blocks, because an action leaves the pool only after the block
+// containing it is committed, so an action moving from the pool into a block between the two lookups is found in
+// either of them, and the block wins if found in both
+func (core *coreService) ActionWithStatusByHash(h hash.Hash256) (*apitypes.ActionWithStatus, error) {
+	pending, info, err := core.ap.GetPendingActionInfo(h)
+	if err != nil && errors.Cause(err) != action.ErrNotFound {
+		return nil, err
+	}
+	blk, err := core.blockOfAction(h)
+	switch {
+	case err == nil:
+	case errors.Cause(err) != ErrNotFound:
+		return nil, err
+	case pending != nil:
+		return &apitypes.ActionWithStatus{
+			Status: apitypes.ActionStatusPending,
+			Action: pending,
+			Pool:   info,
+		}, nil
+	default:
+		return &apitypes.ActionWithStatus{Status: apitypes.ActionStatusNotFound}, nil
+	}
+	selp, index, err := blk.ActionByHash(h)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := core.dao.GetReceipts(blk.Height())
+	if err != nil {
+		return nil, err
+	}
+	receipt := filterReceipts(receipts, h)
+	if receipt == nil {
+		return nil, errors.Wrapf(ErrNotFound, "failed to find receipt for action %x", h)
+	}
+	return &apitypes.ActionWithStatus{
+		Status:  apitypes.ActionStatusConfirmed,
+		Action:  selp,
+		Block:   blk,
+		Index:   index,
+		Receipt: receipt,
+	}, nil
+}
+
+// blockOfAction returns the block containing the action, including the blocks committed but not indexed yet
+func (core *coreService) blockOfAction(h hash.Hash256) (*block.Block, error) {
+	if err := core.checkActionIndex(); err != nil {
+		return nil, status.Error(codes.NotFound, blockindex.ErrActionIndexNA.Error())
+	}
+	// the height is read ahead of the lookup, so a block indexed in between is still scanned below
+	indexed, err := core.indexer.Height()
+	if err != nil {
+		return nil, err
+	}
+	actIndex, err := core.indexer.GetActionIndex(h[:])
+	switch {
+	case err == nil:
+		return core.dao.GetBlockByHeight(actIndex.BlockHeight())
+	case errors.Cause(err) != db.ErrNotExist:
+		return nil, err
+	}
+	// the blocks are indexed asynchronously, so the action may be in a block not indexed yet
+	for height := indexed + 1; height <= core.bc.TipHeight(); height++ {
+		blk, err := core.dao.GetBlockByHeight(height)
+		if err != nil {
+			return nil, err
+		}
+		if _, _, err := blk.ActionByHash(h); err == nil {
+			return blk, nil
+		}
+	}
+	return nil, errors.Wrapf(ErrNotFound, "action %x is not found", h)
+}
+
 // UnconfirmedActionsByAddress returns all unconfirmed actions in actpool associated with an address
 func (core *coreService) UnconfirmedActionsByAddress(address string, start uint64, count uint64) ([]*iotexapi.ActionInfo, error) {
 	if count == 0 {
@@ -1299,23 +2468,24 @@ func (core *coreService) pendingAction(selp *action.SealedEnvelope) (*iotexapi.A
 }
 
 func (core *coreService) getAction(actHash hash.Hash256, checkPending bool) (*iotexapi.ActionInfo, error) {
-	selp, blk, actIndex, err := core.ActionByActionHash(actHash)
-	if err == nil {
-		act, err := core.committedAction(selp, blk.HashBlock(), blk.Height())
+	act, err := core.ActionWithStatusByHash(actHash)
+	if err != nil {
+		return nil, err
+	}
+	switch act.Status {
+	case apitypes.ActionStatusConfirmed:
+		info, err := core.committedAction(act.Action, act.Block.HashBlock(), act.Block.Height())
 		if err != nil {
 			return nil, err
 		}
-		act.Index = actIndex
-		return act, nil
-	}
-	// Try to fetch pending action from actpool
-	if checkPending {
-		selp, err = core.ap.GetActionByHash(actHash)
-	}
-	if err != nil {
-		return nil, err
+		info.Index = act.Index
+		return info, nil
+	case apitypes.ActionStatusPending:
+		if checkPending {
+			return core.pendingAction(act.Action)
+		}
 	}
-	return core.pendingAction(selp)
+	return nil, errors.Wrapf(ErrNotFound, "action %x is not found", actHash)
 }
 
 func (core *coreService) reverseActionsInBlock(blk *block.Block, reverseStart, count uint64) []*iotexapi.ActionInfo {
@@ -1381,24 +2551,62 @@ func (core *coreService) LogsInBlockByHash(filter *logfilter.LogFilter, blockHas
 func (core *coreService) logsInBlock(filter *logfilter.LogFilter, blockNumber uint64) ([]*action.Log, error) {
 	logBloomFilter, err := core.bfIndexer.BlockFilterByHeight(blockNumber)
 	if err != nil {
-		return nil, err
+		return nil, err
+	}
+
+	if !filter.ExistInBloomFilterv2(logBloomFilter) {
+		return []*action.Log{}, nil
+	}
+
+	_, receipts, err := core.receiptsMayMatch(filter, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return filter.MatchLogs(receipts), nil
+}
+
+// receiptsMayMatch returns the receipts of the block at the height whose logs may match the filter, along with their
+// indices in the block. If the dao keeps the log digest of the block, only the receipts matched by the digest are
+// read, otherwise all the receipts are returned
+func (core *coreService) receiptsMayMatch(filter *logfilter.LogFilter, height uint64) ([]uint32, []*action.Receipt, error) {
+	r, ok := core.dao.(blockdao.LogDigestReader)
+	if !ok {
+		receipts, err := core.dao.GetReceipts(height)
+		if err != nil {
+			return nil, nil, err
+		}
+		indices := make([]uint32, len(receipts))
+		for i := range indices {
+			indices[i] = uint32(i)
+		}
+		return indices, receipts, nil
+	}
+	digest, err := r.LogDigest(height)
+	if err != nil {
+		return nil, nil, err
 	}
-
-	if !filter.ExistInBloomFilterv2(logBloomFilter) {
-		return []*action.Log{}, nil
+	indices := filter.MatchLogDigest(digest)
+	if len(indices) == 0 {
+		return nil, nil, nil
 	}
-
-	receipts, err := core.dao.GetReceipts(blockNumber)
+	receipts, err := r.ReceiptsAt(height, indices)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-
-	return filter.MatchLogs(receipts), nil
+	return indices, receipts, nil
 }
 
-// LogsInRange filter logs among [start, end] blocks
-func (core *coreService) LogsInRange(filter *logfilter.LogFilter, start, end, paginationSize uint64) ([]*action.Log, []hash.Hash256, error) {
-	start, end, err := core.correctQueryRange(start, end)
+// LogsInRange filter logs among [start, end] blocks, the blocks are no longer read once the ctx is done. The size of
+// the logs is summed up as each block is read, and the query fails with ErrResultTooLarge once it exceeds the
+// ResponseSizeLimit, so the blocks left are not read
+func (core *coreService) LogsInRange(ctx context.Context, filter *logfilter.LogFilter, start, end, paginationSize uint64) ([]*action.Log, []hash.Hash256, error) {
+	release, err := core.loadShedder.Admit(ctx, PriorityHeavy)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer release()
+	start, end, err = core.correctQueryRange(start, end)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1413,7 +2621,8 @@ func (core *coreService) LogsInRange(filter *logfilter.LogFilter, start, end, pa
 		logsInBlk = make([][]*action.Log, len(blockNumbers))
 		HashInBlk = make([]hash.Hash256, len(blockNumbers))
 		jobs      = make(chan jobDesc, len(blockNumbers))
-		eg, ctx   = errgroup.WithContext(context.Background())
+		eg, egCtx = errgroup.WithContext(ctx)
+		size      atomic.Int64
 	)
 	if len(blockNumbers) == 0 {
 		return logs, hashes, nil
@@ -1427,8 +2636,8 @@ func (core *coreService) LogsInRange(filter *logfilter.LogFilter, start, end, pa
 		eg.Go(func() error {
 			for {
 				select {
-				case <-ctx.Done():
-					return ctx.Err()
+				case <-egCtx.Done():
+					return egCtx.Err()
 				default:
 					job, ok := <-jobs
 					if !ok {
@@ -1438,6 +2647,9 @@ func (core *coreService) LogsInRange(filter *logfilter.LogFilter, start, end, pa
 					if err != nil {
 						return err
 					}
+					if limit := core.cfg.ResponseSizeLimit; limit > 0 && size.Add(int64(logsSize(logsInBlock))) > int64(limit) {
+						return errors.Wrapf(ErrResultTooLarge, "logs in [%d, %d] exceed %d bytes", start, end, limit)
+					}
 					blkHash, err := core.dao.GetBlockHash(job.blkNum)
 					if err != nil {
 						return err
@@ -1449,6 +2661,9 @@ func (core *coreService) LogsInRange(filter *logfilter.LogFilter, start, end, pa
 		})
 	}
 	if err := eg.Wait(); err != nil {
+		if ctx.Err() != nil {
+			return nil, nil, status.FromContextError(ctx.Err()).Err()
+		}
 		return nil, nil, err
 	}
 
@@ -1465,6 +2680,156 @@ func (core *coreService) LogsInRange(filter *logfilter.LogFilter, start, end, pa
 	return logs, hashes, nil
 }
 
+// LogsPage returns a page of the logs among [start, end] blocks, ordered by the block height, the index of the action
+// in the block and the index of the log in the action. The page starts after the cursor in the order if not nil, so
+// it is not affected by the blocks added to the chain. The range is limited to LogQueryRangeLimit blocks, and the
+// page to LogQueryResultLimit logs and about ResponseSizeLimit bytes. The blocks are no longer read once the ctx is done
+func (core *coreService) LogsPage(ctx context.Context, filter *logfilter.LogFilter, start, end uint64, cursor *apitypes.LogCursor, descending bool, limit uint64) (*apitypes.LogsPage, error) {
+	release, err := core.loadShedder.Admit(ctx, PriorityHeavy)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	start, end, err = core.correctQueryRange(start, end)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if rangeLimit := core.cfg.LogQueryRangeLimit; rangeLimit > 0 && end-start >= rangeLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "range of %d blocks exceeds the limit %d", end-start+1, rangeLimit)
+	}
+	if resultLimit := core.cfg.LogQueryResultLimit; resultLimit > 0 && (limit == 0 || limit > resultLimit) {
+		limit = resultLimit
+	}
+	var (
+		page = &apitypes.LogsPage{
+			Logs:        []*action.Log{},
+			BlockHashes: []hash.Hash256{},
+		}
+		size      int
+		sizeLimit = core.cfg.ResponseSizeLimit
+	)
+	if cursor != nil {
+		switch {
+		case !descending && cursor.BlockHeight > end, descending && cursor.BlockHeight < start:
+			return page, nil
+		case !descending && cursor.BlockHeight > start:
+			start = cursor.BlockHeight
+		case descending && cursor.BlockHeight < end:
+			end = cursor.BlockHeight
+		}
+	}
+	// the blocks are skipped by the range bloom filter
+	blockNumbers, err := core.bfIndexer.FilterBlocksInRange(filter, start, end, 0)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if descending {
+		slices.Reverse(blockNumbers)
+	}
+	// after tells whether the log at the position comes after the cursor in the order
+	after := func(pos *apitypes.LogCursor) bool {
+		if cursor == nil || pos.BlockHeight != cursor.BlockHeight {
+			return true
+		}
+		if pos.ActionIndex != cursor.ActionIndex {
+			return (pos.ActionIndex > cursor.ActionIndex) != descending
+		}
+		return pos.LogIndex != cursor.LogIndex && (pos.LogIndex > cursor.LogIndex) != descending
+	}
+	for _, height := range blockNumbers {
+		if err := ctx.Err(); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+		indices, receipts, err := core.receiptsMayMatch(filter, height)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		var (
+			positions []*apitypes.LogCursor
+			matched   []*action.Log
+		)
+		for i, r := range receipts {
+			for j, l := range r.Logs() {
+				if filter.MatchLog(l) {
+					positions = append(positions, &apitypes.LogCursor{BlockHeight: height, ActionIndex: indices[i], LogIndex: uint32(j)})
+					matched = append(matched, l)
+				}
+			}
+		}
+		if descending {
+			slices.Reverse(positions)
+			slices.Reverse(matched)
+		}
+		var blkHash hash.Hash256
+		for k, pos := range positions {
+			if !after(pos) {
+				continue
+			}
+			if blkHash == hash.ZeroHash256 {
+				if blkHash, err = core.dao.GetBlockHash(height); err != nil {
+					return nil, status.Error(codes.Internal, err.Error())
+				}
+			}
+			page.Logs = append(page.Logs, matched[k])
+			page.BlockHashes = append(page.BlockHashes, blkHash)
+			// the page has at least one log so that the caller can move on
+			size += logsSize(matched[k : k+1])
+			if uint64(len(page.Logs)) == limit || (sizeLimit > 0 && size >= sizeLimit) {
+				page.Next = pos
+				return page, nil
+			}
+		}
+	}
+	return page, nil
+}
+
+// StreamLogsInRange sends the logs among [start, end] blocks in ascending order page by page, for the query of a range
+// whose logs exceed the ResponseSizeLimit. The range is read in windows of LogQueryRangeLimit blocks, and the next page
+// is read once the previous one is sent, so the memory is bounded by a page however large the range is, and a slow
+// receiver holds the reads back
+func (core *coreService) StreamLogsInRange(ctx context.Context, filter *logfilter.LogFilter, start, end uint64, send func(*apitypes.LogsPage) error) error {
+	start, end, err := core.correctQueryRange(start, end)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	for from := start; from <= end; {
+		to := end
+		if rangeLimit := core.cfg.LogQueryRangeLimit; rangeLimit > 0 && to-from >= rangeLimit {
+			to = from + rangeLimit - 1
+		}
+		var cursor *apitypes.LogCursor
+		for {
+			page, err := core.LogsPage(ctx, filter, from, to, cursor, false, 0)
+			if err != nil {
+				return err
+			}
+			if len(page.Logs) > 0 {
+				if err := send(page); err != nil {
+					return err
+				}
+			}
+			if page.Next == nil {
+				break
+			}
+			cursor = page.Next
+		}
+		if to == end {
+			break
+		}
+		from = to + 1
+	}
+	return nil
+}
+
+// logsSize estimates the size of the logs in a response
+func logsSize(logs []*action.Log) int {
+	var size int
+	for _, l := range logs {
+		size += _logSizeOverhead + len(l.Address) + len(l.Topics)*len(hash.ZeroHash256) + len(l.Data)
+	}
+	return size
+}
+
 func (core *coreService) correctQueryRange(start, end uint64) (uint64, uint64, error) {
 	bfTipHeight, err := core.bfIndexer.Height()
 	if err != nil {
@@ -1490,11 +2855,18 @@ func (core *coreService) correctQueryRange(start, end uint64) (uint64, uint64, e
 
 // EstimateGasForNonExecution estimates action gas except execution
 func (core *coreService) EstimateGasForNonExecution(actType action.Action) (uint64, error) {
-	act, ok := actType.(intrinsicGasCalculator)
-	if !ok {
+	if _, ok := actType.(intrinsicGasCalculator); !ok {
 		return 0, errors.Errorf("invalid action type not supported")
 	}
-	return act.IntrinsicGas()
+	return action.IntrinsicGasBySchedule(actType, core.dataCostSchedule())
+}
+
+// dataCostSchedule returns the schedule of the gas charged for the payload data of the actions in the next block
+func (core *coreService) dataCostSchedule() action.DataCostSchedule {
+	return protocol.DataCostSchedule(protocol.WithFeatureCtx(protocol.WithBlockCtx(
+		genesis.WithGenesisContext(context.Background(), core.bc.Genesis()),
+		protocol.BlockCtx{BlockHeight: core.bc.TipHeight() + 1},
+	)))
 }
 
 // EstimateMigrateStakeGasConsumption estimates gas consumption for migrate stake action
@@ -1531,11 +2903,21 @@ func (core *coreService) EstimateMigrateStakeGasConsumption(ctx context.Context,
 
 // EstimateExecutionGasConsumption estimate gas consumption for execution action
 func (core *coreService) EstimateExecutionGasConsumption(ctx context.Context, sc *action.Execution, callerAddr address.Address) (uint64, error) {
+	callerAddr, err := simulationCaller(_estimatePolicy.caller, callerAddr, nil)
+	if err != nil {
+		return 0, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return core.estimateExecutionGas(ctx, sc, callerAddr, _estimatePolicy)
+}
+
+// estimateExecutionGas estimates the gas of the execution by the caller, which is resolved under the policy
+func (core *coreService) estimateExecutionGas(ctx context.Context, sc *action.Execution, callerAddr address.Address, policy simulationPolicy) (uint64, error) {
 	ctx = genesis.WithGenesisContext(ctx, core.bc.Genesis())
 	state, err := accountutil.AccountState(ctx, core.sf, callerAddr)
 	if err != nil {
 		return 0, status.Error(codes.InvalidArgument, err.Error())
 	}
+	gasPrice := sc.GasPrice()
 	ctx = protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
 		BlockHeight: core.bc.TipHeight(),
 	}))
@@ -1552,15 +2934,28 @@ func (core *coreService) EstimateExecutionGasConsumption(ctx context.Context, sc
 		g             = core.bc.Genesis()
 		blockGasLimit = g.BlockGasLimitByHeight(core.bc.TipHeight())
 	)
-	sc.SetGasLimit(blockGasLimit)
+	gasCap := blockGasLimit
+	if policy.checkBalance {
+		if gasCap, err = balanceGasCap(state.Balance, sc.Amount(), gasPrice, blockGasLimit); err != nil {
+			return 0, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	sc.SetGasLimit(gasCap)
 	enough, receipt, err := core.isGasLimitEnough(ctx, callerAddr, sc)
 	if err != nil {
+		if gasCap < blockGasLimit && errors.Cause(err) == action.ErrInsufficientFunds {
+			// the allowance doesn't even cover the intrinsic gas
+			return 0, status.Errorf(codes.InvalidArgument, "gas required exceeds allowance (%d)", gasCap)
+		}
 		return 0, status.Error(codes.Internal, err.Error())
 	}
 	if !enough {
 		if receipt.ExecutionRevertMsg() != "" {
 			return 0, status.Errorf(codes.Internal, fmt.Sprintf("execution simulation is reverted due to the reason: %s", receipt.ExecutionRevertMsg()))
 		}
+		if gasCap < blockGasLimit {
+			return 0, status.Errorf(codes.InvalidArgument, "gas required exceeds allowance (%d)", gasCap)
+		}
 		return 0, status.Error(codes.Internal, fmt.Sprintf("execution simulation failed: status = %d", receipt.Status))
 	}
 	estimatedGas := receipt.GasConsumed
@@ -1570,7 +2965,7 @@ func (core *coreService) EstimateExecutionGasConsumption(ctx context.Context, sc
 		return 0, status.Error(codes.Internal, err.Error())
 	}
 	if !enough {
-		low, high := estimatedGas, blockGasLimit
+		low, high := estimatedGas, gasCap
 		estimatedGas = high
 		for low <= high {
 			mid := (low + high) / 2
@@ -1717,14 +3112,19 @@ func (core *coreService) Genesis() genesis.Genesis {
 	return core.bc.Genesis()
 }
 
+// NetworkIdentity returns the identity of the network and the node
+func (core *coreService) NetworkIdentity() *apitypes.NetworkIdentity {
+	return core.identity
+}
+
 // EVMNetworkID returns the network id of evm
 func (core *coreService) EVMNetworkID() uint32 {
-	return core.bc.EvmNetworkID()
+	return core.identity.EVMNetworkID
 }
 
 // ChainID returns the chain id of evm
 func (core *coreService) ChainID() uint32 {
-	return core.bc.ChainID()
+	return core.identity.ChainID
 }
 
 // ReadContractStorage reads contract's storage
@@ -1737,11 +3137,20 @@ func (core *coreService) ReadContractStorage(ctx context.Context, addr address.A
 }
 
 func (core *coreService) ReceiveBlock(blk *block.Block) error {
-	core.readCache.Clear()
+	scope := ReadScopeBlock
+	if rp := rolldpos.FindProtocol(core.registry); rp != nil && rp.GetEpochHeight(rp.GetEpochNum(blk.Height())) == blk.Height() {
+		scope = ReadScopeEpoch
+	}
+	core.readCache.Invalidate(scope, blk.Height())
+	core.loadShedder.ObserveCommit(blk.Timestamp(), time.Now())
 	return core.chainListener.ReceiveBlock(blk)
 }
 
 func (core *coreService) SimulateExecution(ctx context.Context, addr address.Address, exec *action.Execution) ([]byte, *action.Receipt, error) {
+	addr, err := simulationCaller(allowImpersonation, addr, nil)
+	if err != nil {
+		return nil, nil, err
+	}
 	ctx = genesis.WithGenesisContext(ctx, core.bc.Genesis())
 	state, err := accountutil.AccountState(ctx, core.sf, addr)
 	if err != nil {
@@ -1770,32 +3179,225 @@ func (core *coreService) SimulateExecution(ctx context.Context, addr address.Add
 	return core.simulateExecution(ctx, addr, exec, core.dao.GetBlockHash, core.getBlockTime)
 }
 
-// SyncingProgress returns the syncing status of node
-func (core *coreService) SyncingProgress() (uint64, uint64, uint64) {
-	startingHeight, currentHeight, targetHeight, _ := core.bs.SyncStatus()
-	return startingHeight, currentHeight, targetHeight
+// SimulateBatch simulates the calls in order on one working set, which is discarded after the batch, so the calls
+// see the changes of each other without committing anything. The height of zero is the tip height
+func (core *coreService) SimulateBatch(
+	ctx context.Context,
+	height uint64,
+	calls []*apitypes.SimulateCall,
+	overrides map[common.Address]*evm.StateOverride,
+	continueOnFailure bool,
+) ([]*apitypes.SimulateResult, error) {
+	release, err := core.loadShedder.Admit(ctx, PriorityHeavy)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	if len(calls) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no call to simulate")
+	}
+	if core.cfg.SimulateBatchLimit > 0 && len(calls) > core.cfg.SimulateBatchLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "%d calls exceed the limit %d of a batch", len(calls), core.cfg.SimulateBatchLimit)
+	}
+	if core.cfg.SimulateBatchTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, core.cfg.SimulateBatchTimeout)
+		defer cancel()
+	}
+	g := core.bc.Genesis()
+	ctx, err = core.bc.Context(genesis.WithGenesisContext(ctx, g))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	tip := core.bc.TipHeight()
+	switch {
+	case height == 0:
+		height = tip
+	case height > tip:
+		return nil, status.Errorf(codes.InvalidArgument, "height %d is higher than tip height %d", height, tip)
+	case height < tip:
+		// the calls are simulated in the block following the one at height
+		header, err := core.dao.HeaderByHeight(height)
+		if err != nil {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		bcCtx := protocol.MustGetBlockchainCtx(ctx)
+		bcCtx.Tip = protocol.TipInfo{
+			Height:    height,
+			GasUsed:   header.GasUsed(),
+			Hash:      header.HashBlock(),
+			Timestamp: header.Timestamp(),
+			BaseFee:   header.BaseFee(),
+		}
+		ctx = protocol.WithBlockchainCtx(ctx, bcCtx)
+	}
+	ws, err := core.sf.SimulationWorkingSet(ctx, height)
+	if err != nil {
+		switch errors.Cause(err) {
+		case factory.ErrOutOfRetentionRange:
+			return nil, status.Error(codes.OutOfRange, err.Error())
+		case factory.ErrNoArchiveData, factory.ErrNotSupported:
+			return nil, status.Error(codes.Unimplemented, err.Error())
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	ctx = evm.WithHelperCtx(ctx, evm.HelperContext{
+		GetBlockHash:   core.dao.GetBlockHash,
+		GetBlockTime:   core.getBlockTime,
+		DepositGasFunc: rewarding.DepositGas,
+	})
+	if len(overrides) > 0 {
+		if err := evm.OverrideStates(ctx, ws, overrides); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	var (
+		budget        = core.cfg.SimulateBatchGasBudget
+		blockGasLimit = g.BlockGasLimitByHeight(height + 1)
+		featureCtx    = protocol.MustGetFeatureCtx(protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight: height + 1,
+		})))
+		results = make([]*apitypes.SimulateResult, 0, len(calls))
+	)
+	for i, call := range calls {
+		if err := ctx.Err(); err != nil {
+			return nil, status.Errorf(codes.DeadlineExceeded, "batch simulation exceeds the time limit %s at call %d", core.cfg.SimulateBatchTimeout, i)
+		}
+		if budget == 0 {
+			return nil, status.Errorf(codes.ResourceExhausted, "gas budget %d of the batch is used up at call %d", core.cfg.SimulateBatchGasBudget, i)
+		}
+		gasLimit := call.GasLimit
+		if gasLimit == 0 || gasLimit > blockGasLimit {
+			gasLimit = blockGasLimit
+		}
+		if gasLimit > budget {
+			gasLimit = budget
+		}
+		state, err := accountutil.AccountState(ctx, ws, call.Caller)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		nonce := state.PendingNonce()
+		if featureCtx.RefactorFreshAccountConversion {
+			nonce = state.PendingNonceConsideringFreshAccount()
+		}
+		amount := call.Amount
+		if amount == nil {
+			amount = big.NewInt(0)
+		}
+		exec, err := action.NewExecution(call.To, nonce, amount, gasLimit, big.NewInt(0), call.Data)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		result := &apitypes.SimulateResult{}
+		retval, receipt, err := evm.SimulateExecution(ctx, ws, call.Caller, exec)
+		if err != nil {
+			result.Status, result.Err = uint64(iotextypes.ReceiptStatus_Failure), err
+		} else {
+			result.GasUsed = receipt.GasConsumed
+			result.Status = receipt.Status
+			result.ReturnData = retval
+			result.Logs = receipt.Logs()
+			result.ContractAddress = receipt.ContractAddress
+			result.RevertReason = receipt.ExecutionRevertMsg()
+		}
+		results = append(results, result)
+		if result.GasUsed > budget {
+			budget = 0
+		} else {
+			budget -= result.GasUsed
+		}
+		if result.Status != uint64(iotextypes.ReceiptStatus_Success) && !continueOnFailure {
+			break
+		}
+	}
+	return results, nil
+}
+
+// ConvertAddress converts the address in either io or hex format into both formats, and tells its kind by the state
+// at the tip
+func (core *coreService) ConvertAddress(str string) (*apitypes.AddressInfo, error) {
+	converted, err := addrutil.ConvertAddress(str)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	info := &apitypes.AddressInfo{
+		ConvertedAddress: converted,
+		Kind:             apitypes.AddressKindNone,
+	}
+	ctx := genesis.WithGenesisContext(context.Background(), core.bc.Genesis())
+	if addr := converted.IoAddress; addr == address.RewardingPoolAddr || addr == address.StakingBucketPoolAddr {
+		meta, _, err := core.getProtocolAccount(ctx, addr)
+		if err != nil {
+			return nil, err
+		}
+		if meta.Balance != "0" {
+			info.Kind = apitypes.AddressKindAccount
+		}
+		return info, nil
+	}
+	state, err := accountutil.AccountState(ctx, core.sf, converted.Address)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	switch {
+	case state.IsContract():
+		info.Kind = apitypes.AddressKindContract
+	case state.Balance.Sign() > 0 || state.PendingNonceConsideringFreshAccount() > 0:
+		info.Kind = apitypes.AddressKindAccount
+	}
+	return info, nil
+}
+
+// SyncingProgress returns the syncing progress of node
+func (core *coreService) SyncingProgress() blocksync.SyncProgress {
+	return core.bs.SyncProgress()
 }
 
-// TraceTransaction returns the trace result of transaction
+// TraceTransaction returns the trace result of transaction, which is replayed in its block after the actions ahead
+// of it, on top of the state of the parent block
 func (core *coreService) TraceTransaction(ctx context.Context, actHash string, config *tracers.TraceConfig) ([]byte, *action.Receipt, any, error) {
-	actInfo, err := core.Action(util.Remove0xPrefix(actHash), false)
+	release, err := core.loadShedder.Admit(ctx, PriorityHeavy)
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	act, err := (&action.Deserializer{}).SetEvmNetworkID(core.EVMNetworkID()).ActionToSealedEnvelope(actInfo.Action)
+	defer release()
+	h, err := hash.HexStringToHash256(util.Remove0xPrefix(actHash))
+	if err != nil {
+		return nil, nil, nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	selp, blk, index, err := core.ActionByActionHash(h)
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	sc, ok := act.Action().(*action.Execution)
-	if !ok {
+	if _, ok := selp.Action().(*action.Execution); !ok {
 		return nil, nil, nil, errors.New("the type of action is not supported")
 	}
-	addr, _ := address.FromString(address.ZeroAddress)
-	retval, receipt, tracer, err := core.traceTx(ctx, new(tracers.Context), config, func(ctx context.Context) ([]byte, *action.Receipt, error) {
+	traces, err := core.traceBlock(ctx, blk, int(index)+1, config)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	trace := traces[len(traces)-1]
+	return trace.ReturnValue, trace.Receipt, trace.Tracer, nil
+}
 
-		return core.simulateExecution(ctx, addr, sc, core.dao.GetBlockHash, core.getBlockTime)
-	})
-	return retval, receipt, tracer, err
+// TraceBlockByNumber returns the traces of the transactions run in the EVM in the block at height, which is replayed
+// on top of the state of the parent block
+func (core *coreService) TraceBlockByNumber(ctx context.Context, height uint64, config *tracers.TraceConfig) ([]*apitypes.TxTrace, error) {
+	release, err := core.loadShedder.Admit(ctx, PriorityHeavy)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	if height == 0 || height > core.bc.TipHeight() {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot trace the block at height %d", height)
+	}
+	blk, err := core.dao.GetBlockByHeight(height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return core.traceBlock(ctx, blk, len(blk.Actions), config)
 }
 
 // TraceCall returns the trace result of call
@@ -1808,6 +3410,11 @@ func (core *coreService) TraceCall(ctx context.Context,
 	gasLimit uint64,
 	data []byte,
 	config *tracers.TraceConfig) ([]byte, *action.Receipt, any, error) {
+	release, err := core.loadShedder.Admit(ctx, PriorityHeavy)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer release()
 	var (
 		g             = core.bc.Genesis()
 		blockGasLimit = g.BlockGasLimitByHeight(core.bc.TipHeight())
@@ -1815,7 +3422,7 @@ func (core *coreService) TraceCall(ctx context.Context,
 	if gasLimit == 0 {
 		gasLimit = blockGasLimit
 	}
-	ctx, err := core.bc.Context(ctx)
+	ctx, err = core.bc.Context(ctx)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -1865,26 +3472,175 @@ func (core *coreService) Track(ctx context.Context, start time.Time, method stri
 	}, size)
 }
 
-func (core *coreService) traceTx(ctx context.Context, txctx *tracers.Context, config *tracers.TraceConfig, simulateFn func(ctx context.Context) ([]byte, *action.Receipt, error)) ([]byte, *action.Receipt, any, error) {
+// traceBlock replays the first n actions of the block on top of the state of the parent block, and traces the ones
+// run in the EVM. The replay is refused if the state of the parent block is not available, or too many traces are
+// in progress, and it is stopped once the time limit is exceeded
+func (core *coreService) traceBlock(ctx context.Context, blk *block.Block, n int, config *tracers.TraceConfig) ([]*apitypes.TxTrace, error) {
+	if core.traceSlots != nil {
+		select {
+		case core.traceSlots <- struct{}{}:
+			defer func() { <-core.traceSlots }()
+		default:
+			return nil, status.Error(codes.ResourceExhausted, "too many traces in progress")
+		}
+	}
+	timeout := core.cfg.TraceTimeout
+	if config != nil && config.Timeout != nil {
+		d, err := time.ParseDuration(*config.Timeout)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if timeout == 0 || d < timeout {
+			timeout = d
+		}
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	g := core.bc.Genesis()
+	ctx, err := core.bc.Context(genesis.WithGenesisContext(ctx, g))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	// the block is replayed as it was validated, following its parent block
+	bcCtx := protocol.MustGetBlockchainCtx(ctx)
+	if parent := blk.Height() - 1; parent == 0 {
+		bcCtx.Tip = protocol.TipInfo{
+			Height:    0,
+			Hash:      g.Hash(),
+			Timestamp: time.Unix(g.Timestamp, 0),
+		}
+	} else {
+		header, err := core.dao.HeaderByHeight(parent)
+		if err != nil {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		bcCtx.Tip = protocol.TipInfo{
+			Height:    parent,
+			GasUsed:   header.GasUsed(),
+			Hash:      header.HashBlock(),
+			Timestamp: header.Timestamp(),
+			BaseFee:   header.BaseFee(),
+		}
+	}
+	ctx = protocol.WithBlockchainCtx(ctx, bcCtx)
+	ctx = protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+		BlockHeight:    blk.Height(),
+		BlockTimeStamp: blk.Timestamp(),
+		GasLimit:       g.BlockGasLimitByHeight(blk.Height()),
+		Producer:       blk.PublicKey().Address(),
+	}))
+	ctx = evm.WithHelperCtx(ctx, evm.HelperContext{
+		GetBlockHash:   core.dao.GetBlockHash,
+		GetBlockTime:   core.getBlockTime,
+		DepositGasFunc: rewarding.DepositGas,
+	})
 	var (
-		tracer vm.EVMLogger
-		err    error
+		blkHash = blk.HashBlock()
+		traces  = make([]*txTracer, n)
 	)
+	receipts, err := core.sf.ReplayBlock(ctx, blk, n, func(ctx context.Context, i int) (context.Context, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, status.Error(codes.DeadlineExceeded, err.Error())
+		}
+		selp := blk.Actions[i]
+		if _, ok := selp.Action().(*action.Execution); !ok {
+			return ctx, nil
+		}
+		actHash := protocol.MustGetActionCtx(ctx).ActionHash
+		tracer, err := newTracer(&tracers.Context{
+			BlockHash:   common.Hash(blkHash),
+			BlockNumber: new(big.Int).SetUint64(blk.Height()),
+			TxIndex:     i,
+			TxHash:      common.Hash(actHash),
+		}, config)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if t, ok := tracer.(interface{ Stop(error) }); ok {
+			context.AfterFunc(ctx, func() {
+				t.Stop(errors.New("execution timeout"))
+			})
+		}
+		traces[i] = &txTracer{EVMLogger: tracer}
+		traces[i].CaptureTxStart(selp.GasLimit())
+		return protocol.WithVMConfigCtx(ctx, vm.Config{Tracer: traces[i]}), nil
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		switch errors.Cause(err) {
+		case factory.ErrOutOfRetentionRange:
+			return nil, status.Error(codes.OutOfRange, err.Error())
+		case factory.ErrNoArchiveData, factory.ErrNotSupported:
+			return nil, status.Error(codes.Unimplemented, err.Error())
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	results := make([]*apitypes.TxTrace, 0, n)
+	for i, t := range traces {
+		if t == nil {
+			continue
+		}
+		receipt := receipts[i]
+		if t.started {
+			t.CaptureTxEnd(blk.Actions[i].GasLimit() - receipt.GasConsumed)
+		}
+		trace := &apitypes.TxTrace{
+			ActionHash: receipt.ActionHash,
+			Receipt:    receipt,
+			Tracer:     t.EVMLogger,
+		}
+		if l, ok := t.EVMLogger.(*logger.StructLogger); ok {
+			trace.ReturnValue = l.Output()
+		}
+		results = append(results, trace)
+	}
+	return results, nil
+}
+
+// CaptureStart implements vm.EVMLogger
+func (t *txTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	t.started = true
+	t.EVMLogger.CaptureStart(env, from, to, create, input, gas, value)
+}
+
+// newTracer creates the tracer in the config, which is the struct logger by default
+func newTracer(txctx *tracers.Context, config *tracers.TraceConfig) (vm.EVMLogger, error) {
 	switch {
 	case config == nil:
-		tracer = logger.NewStructLogger(nil)
+		return logger.NewStructLogger(nil), nil
 	case config.Tracer != nil:
+		return tracers.DefaultDirectory.New(*config.Tracer, txctx, config.TracerConfig)
+	default:
+		return logger.NewStructLogger(config.Config), nil
+	}
+}
+
+func (core *coreService) traceTx(ctx context.Context, txctx *tracers.Context, config *tracers.TraceConfig, simulateFn func(ctx context.Context) ([]byte, *action.Receipt, error)) ([]byte, *action.Receipt, any, error) {
+	var (
+		customTracer = config != nil && config.Tracer != nil
 		// Define a meaningful timeout of a single transaction trace
-		timeout := defaultTraceTimeout
-		if config.Timeout != nil {
-			if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
-				return nil, nil, nil, err
-			}
-		}
-		t, err := tracers.DefaultDirectory.New(*config.Tracer, txctx, config.TracerConfig)
-		if err != nil {
+		timeout = defaultTraceTimeout
+		err     error
+	)
+	if customTracer && config.Timeout != nil {
+		if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
 			return nil, nil, nil, err
 		}
+	}
+	tracer, err := newTracer(txctx, config)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if t, ok := tracer.(tracers.Tracer); ok && customTracer {
 		deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
 		defer cancel()
 		go func() {
@@ -1893,10 +3649,6 @@ func (core *coreService) traceTx(ctx context.Context, txctx *tracers.Context, co
 				t.Stop(errors.New("execution timeout"))
 			}
 		}()
-		tracer = t
-
-	default:
-		tracer = logger.NewStructLogger(config.Config)
 	}
 	ctx = protocol.WithVMConfigCtx(ctx, vm.Config{
 		Tracer:    tracer,
@@ -1918,6 +3670,55 @@ func (core *coreService) simulateExecution(ctx context.Context, addr address.Add
 	return core.sf.SimulateExecution(ctx, addr, exec)
 }
 
+// simulationCaller returns the caller of a simulation under the policy. An impersonated caller is the zero address
+// if omitted, and a verified sender is recovered from the signature of the action, which the caller if given must
+// match
+func simulationCaller(policy callerPolicy, caller address.Address, selp *action.SealedEnvelope) (address.Address, error) {
+	switch policy {
+	case allowImpersonation:
+		if caller == nil {
+			return address.FromString(address.ZeroAddress)
+		}
+		return caller, nil
+	case requireVerifiedSender:
+		if selp == nil {
+			return nil, errors.Wrap(action.ErrInvalidSender, "the sender can't be verified without a signed action")
+		}
+		if err := selp.VerifySignature(); err != nil {
+			return nil, errors.Wrap(action.ErrInvalidSender, err.Error())
+		}
+		sender := selp.SenderAddress()
+		if caller != nil && caller.String() != sender.String() {
+			return nil, errors.Wrapf(action.ErrInvalidSender, "caller %s is not the sender %s", caller.String(), sender.String())
+		}
+		return sender, nil
+	default:
+		return nil, errors.Errorf("unknown caller policy %d", policy)
+	}
+}
+
+// balanceGasCap returns the most gas the balance pays at the gas price after the value, up to the gas limit, as geth
+// caps the gas of an estimation. The value not covered by the balance is rejected
+func balanceGasCap(balance, value, gasPrice *big.Int, gasLimit uint64) (uint64, error) {
+	available := new(big.Int)
+	if balance != nil {
+		available.Set(balance)
+	}
+	if value != nil && value.Sign() > 0 {
+		if available.Cmp(value) < 0 {
+			return 0, errors.Wrapf(action.ErrInsufficientFunds, "insufficient funds for transfer: balance %s, value %s", available, value)
+		}
+		available.Sub(available, value)
+	}
+	if gasPrice == nil || gasPrice.Sign() == 0 {
+		return gasLimit, nil
+	}
+	if allowance := available.Div(available, gasPrice); allowance.IsUint64() && allowance.Uint64() < gasLimit {
+		return allowance.Uint64(), nil
+	}
+	return gasLimit, nil
+}
+
 func filterReceipts(receipts []*action.Receipt, actHash hash.Hash256) *action.Receipt {
 	for _, r := range receipts {
 		if r.ActionHash == actHash {
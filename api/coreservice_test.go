@@ -9,37 +9,57 @@ import (
 	"context"
 	"encoding/hex"
 	"math/big"
+	"runtime"
+	"slices"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	. "github.com/agiledragon/gomonkey/v2"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/execution"
 	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/api/logfilter"
+	apitypes "github.com/iotexproject/iotex-core/api/types"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/blockdao"
 	"github.com/iotexproject/iotex-core/blockchain/filedao"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/blockindex"
+	"github.com/iotexproject/iotex-core/blocksync"
 	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/tracer"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/server/itx/nodestats"
 	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/test/mock/mock_actpool"
 	mock_apitypes "github.com/iotexproject/iotex-core/test/mock/mock_apiresponder"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blockdao"
@@ -47,6 +67,7 @@ import (
 	"github.com/iotexproject/iotex-core/test/mock/mock_blocksync"
 	"github.com/iotexproject/iotex-core/test/mock/mock_envelope"
 	"github.com/iotexproject/iotex-core/test/mock/mock_factory"
+	"github.com/iotexproject/iotex-core/test/mock/mock_poll"
 	"github.com/iotexproject/iotex-core/testutil"
 	"github.com/iotexproject/iotex-election/test/mock/mock_committee"
 	"github.com/iotexproject/iotex-election/types"
@@ -56,6 +77,7 @@ import (
 
 func TestLogsInRange(t *testing.T) {
 	require := require.New(t)
+	ctx := context.Background()
 	svr, _, _, _, cleanCallback := setupTestCoreService()
 	defer cleanCallback()
 
@@ -68,7 +90,7 @@ func TestLogsInRange(t *testing.T) {
 		to, err := strconv.ParseUint(testData.ToBlock, 10, 64)
 		require.NoError(err)
 
-		logs, hashes, err := svr.LogsInRange(logfilter.NewLogFilter(filter), from, to, uint64(0))
+		logs, hashes, err := svr.LogsInRange(ctx, logfilter.NewLogFilter(filter), from, to, uint64(0))
 		require.NoError(err)
 		require.Equal(4, len(logs))
 		require.Equal(4, len(hashes))
@@ -82,7 +104,7 @@ func TestLogsInRange(t *testing.T) {
 		to, err := strconv.ParseUint(testData.ToBlock, 10, 64)
 		require.NoError(err)
 
-		logs, hashes, err := svr.LogsInRange(logfilter.NewLogFilter(filter), from, to, uint64(0))
+		logs, hashes, err := svr.LogsInRange(ctx, logfilter.NewLogFilter(filter), from, to, uint64(0))
 		require.NoError(err)
 		require.Equal(0, len(logs))
 		require.Equal(0, len(hashes))
@@ -96,7 +118,7 @@ func TestLogsInRange(t *testing.T) {
 		to, err := strconv.ParseUint(testData.ToBlock, 10, 64)
 		require.NoError(err)
 
-		logs, hashes, err := svr.LogsInRange(logfilter.NewLogFilter(filter), from, to, uint64(5001))
+		logs, hashes, err := svr.LogsInRange(ctx, logfilter.NewLogFilter(filter), from, to, uint64(5001))
 		require.NoError(err)
 		require.Equal(4, len(logs))
 		require.Equal(4, len(hashes))
@@ -110,7 +132,7 @@ func TestLogsInRange(t *testing.T) {
 		to, err := strconv.ParseUint(testData.ToBlock, 10, 64)
 		require.NoError(err)
 
-		_, _, err = svr.LogsInRange(logfilter.NewLogFilter(filter), from, to, uint64(0))
+		_, _, err = svr.LogsInRange(ctx, logfilter.NewLogFilter(filter), from, to, uint64(0))
 		expectedErr := errors.New("invalid start or end height")
 		require.Error(err)
 		require.Equal(expectedErr.Error(), err.Error())
@@ -124,14 +146,255 @@ func TestLogsInRange(t *testing.T) {
 		to, err := strconv.ParseUint(testData.ToBlock, 10, 64)
 		require.NoError(err)
 
-		_, _, err = svr.LogsInRange(logfilter.NewLogFilter(filter), from, to, uint64(0))
+		_, _, err = svr.LogsInRange(ctx, logfilter.NewLogFilter(filter), from, to, uint64(0))
 		expectedErr := errors.New("start block > tip height")
 		require.Error(err)
 		require.Equal(expectedErr.Error(), err.Error())
 	})
 }
 
+func TestLogsPage(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	cfg := newConfig()
+	cfg.api.LogQueryRangeLimit = 3
+	cfg.api.LogQueryResultLimit = 2
+	svr, _, dao, _, cleanCallback := setupTestCoreServiceWithConfig(cfg)
+	defer cleanCallback()
+
+	filter := logfilter.NewLogFilter(&iotexapi.LogsFilter{})
+	var expected []*action.Log
+	for height := uint64(2); height <= 4; height++ {
+		receipts, err := dao.GetReceipts(height)
+		require.NoError(err)
+		for _, r := range receipts {
+			expected = append(expected, r.Logs()...)
+		}
+	}
+	require.Len(expected, 3)
+	reversed := slices.Clone(expected)
+	slices.Reverse(reversed)
+
+	for _, test := range []struct {
+		name       string
+		descending bool
+		expected   []*action.Log
+	}{
+		{"ascending", false, expected},
+		{"descending", true, reversed},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			// the page is limited by the server
+			page, err := svr.LogsPage(ctx, filter, 2, 4, nil, test.descending, 10)
+			require.NoError(err)
+			require.Equal(test.expected[:2], page.Logs)
+			require.Len(page.BlockHashes, 2)
+			for i, l := range page.Logs {
+				h, err := dao.GetBlockHash(l.BlockHeight)
+				require.NoError(err)
+				require.Equal(h, page.BlockHashes[i])
+			}
+			require.NotNil(page.Next)
+			require.Equal(test.expected[1].BlockHeight, page.Next.BlockHeight)
+
+			page, err = svr.LogsPage(ctx, filter, 2, 4, page.Next, test.descending, 10)
+			require.NoError(err)
+			require.Equal(test.expected[2:], page.Logs)
+			require.Nil(page.Next)
+
+			page, err = svr.LogsPage(ctx, filter, 2, 4, nil, test.descending, 1)
+			require.NoError(err)
+			require.Equal(test.expected[:1], page.Logs)
+			page, err = svr.LogsPage(ctx, filter, 2, 4, page.Next, test.descending, 1)
+			require.NoError(err)
+			require.Equal(test.expected[1:2], page.Logs)
+		})
+	}
+	t.Run("cursor out of range", func(t *testing.T) {
+		page, err := svr.LogsPage(ctx, filter, 2, 3, &apitypes.LogCursor{BlockHeight: 4}, false, 0)
+		require.NoError(err)
+		require.Empty(page.Logs)
+		require.Nil(page.Next)
+	})
+	t.Run("range exceeds limit", func(t *testing.T) {
+		_, err := svr.LogsPage(ctx, filter, 1, 4, nil, false, 0)
+		require.Equal(codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestLogsCanceled(t *testing.T) {
+	require := require.New(t)
+	filter := logfilter.NewLogFilter(&iotexapi.LogsFilter{})
+	blocks := make([]uint64, 10000)
+	for i := range blocks {
+		blocks[i] = uint64(i + 1)
+	}
+	end := uint64(len(blocks))
+
+	for _, test := range []struct {
+		name     string
+		query    func(context.Context, *coreService) error
+		maxReads int
+	}{
+		{"LogsInRange", func(ctx context.Context, cs *coreService) error {
+			_, _, err := cs.LogsInRange(ctx, filter, 1, end, 0)
+			return err
+		}, 10 + _workerNumbers},
+		{"LogsPage", func(ctx context.Context, cs *coreService) error {
+			_, err := cs.LogsPage(ctx, filter, 1, end, nil, false, 0)
+			return err
+		}, 10},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				ctrl        = gomock.NewController(t)
+				blkDAO      = mock_blockdao.NewMockBlockDAO(ctrl)
+				bfIndexer   = mock_blockindex.NewMockBloomFilterIndexer(ctrl)
+				cs          = &coreService{dao: blkDAO, bfIndexer: bfIndexer}
+				ctx, cancel = context.WithCancel(context.Background())
+				reads       atomic.Int32
+			)
+			defer cancel()
+			bfIndexer.EXPECT().Height().Return(end, nil).AnyTimes()
+			bfIndexer.EXPECT().FilterBlocksInRange(gomock.Any(), uint64(1), end, uint64(0)).Return(blocks, nil).Times(1)
+			bfIndexer.EXPECT().BlockFilterByHeight(gomock.Any()).Return(nil, nil).AnyTimes()
+			blkDAO.EXPECT().GetBlockHash(gomock.Any()).Return(hash.ZeroHash256, nil).AnyTimes()
+			// the query is canceled after reading 10 blocks, while reading all of them takes seconds
+			blkDAO.EXPECT().GetReceipts(gomock.Any()).DoAndReturn(func(uint64) ([]*action.Receipt, error) {
+				if reads.Add(1) == 10 {
+					cancel()
+				}
+				time.Sleep(time.Millisecond)
+				return nil, nil
+			}).MinTimes(10)
+
+			start := time.Now()
+			require.Equal(codes.Canceled, status.Code(test.query(ctx, cs)))
+			require.Less(time.Since(start), time.Second)
+			require.LessOrEqual(int(reads.Load()), test.maxReads)
+		})
+	}
+}
+
+func TestLogsResultTooLarge(t *testing.T) {
+	require := require.New(t)
+	const (
+		blocks    = 256
+		dataSize  = 1 << 20
+		sizeLimit = 8 << 20
+		// the logs of all the blocks take 256MB, while the heap grows by a few times the size limit at most
+		maxHeapGrowth = 64 << 20
+	)
+	var (
+		ctrl      = gomock.NewController(t)
+		blkDAO    = mock_blockdao.NewMockBlockDAO(ctrl)
+		bfIndexer = mock_blockindex.NewMockBloomFilterIndexer(ctrl)
+		cs        = &coreService{dao: blkDAO, bfIndexer: bfIndexer, cfg: Config{ResponseSizeLimit: sizeLimit}}
+		filter    = logfilter.NewLogFilter(&iotexapi.LogsFilter{})
+		reads     atomic.Int32
+	)
+	bfIndexer.EXPECT().Height().Return(uint64(blocks), nil).AnyTimes()
+	bfIndexer.EXPECT().FilterBlocksInRange(gomock.Any(), gomock.Any(), gomock.Any(), uint64(0)).DoAndReturn(func(_ *logfilter.LogFilter, start, end, _ uint64) ([]uint64, error) {
+		var heights []uint64
+		for h := start; h <= end; h++ {
+			heights = append(heights, h)
+		}
+		return heights, nil
+	}).AnyTimes()
+	bfIndexer.EXPECT().BlockFilterByHeight(gomock.Any()).Return(nil, nil).AnyTimes()
+	blkDAO.EXPECT().GetBlockHash(gomock.Any()).Return(hash.ZeroHash256, nil).AnyTimes()
+	// each block has a log of 1MB data, which is allocated as the block is read
+	blkDAO.EXPECT().GetReceipts(gomock.Any()).DoAndReturn(func(height uint64) ([]*action.Receipt, error) {
+		reads.Add(1)
+		return []*action.Receipt{(&action.Receipt{BlockHeight: height}).AddLogs(&action.Log{
+			Address:     identityset.Address(0).String(),
+			Data:        make([]byte, dataSize),
+			BlockHeight: height,
+		})}, nil
+	}).AnyTimes()
+
+	// heapGrowth returns the peak growth of the heap sampled while the query runs
+	heapGrowth := func(query func()) uint64 {
+		var (
+			ms   runtime.MemStats
+			done = make(chan struct{})
+			peak = make(chan uint64)
+		)
+		runtime.GC()
+		runtime.ReadMemStats(&ms)
+		base := ms.HeapAlloc
+		go func() {
+			var (
+				ms  runtime.MemStats
+				max uint64
+			)
+			ticker := time.NewTicker(time.Millisecond)
+			defer ticker.Stop()
+			for {
+				runtime.ReadMemStats(&ms)
+				if ms.HeapAlloc > max {
+					max = ms.HeapAlloc
+				}
+				select {
+				case <-done:
+					peak <- max
+					return
+				case <-ticker.C:
+				}
+			}
+		}()
+		query()
+		close(done)
+		if max := <-peak; max > base {
+			return max - base
+		}
+		return 0
+	}
+
+	t.Run("LogsInRange", func(t *testing.T) {
+		reads.Store(0)
+		var err error
+		growth := heapGrowth(func() {
+			_, _, err = cs.LogsInRange(context.Background(), filter, 1, blocks, 0)
+		})
+		require.ErrorIs(err, ErrResultTooLarge)
+		// the blocks left are not read once the size exceeds the limit
+		require.Less(int(reads.Load()), sizeLimit/dataSize+2*_workerNumbers)
+		require.Less(growth, uint64(maxHeapGrowth))
+	})
+	t.Run("LogsPage", func(t *testing.T) {
+		page, err := cs.LogsPage(context.Background(), filter, 1, blocks, nil, false, 0)
+		require.NoError(err)
+		// the page is cut by the size
+		require.Len(page.Logs, sizeLimit/logsSize(page.Logs[:1])+1)
+		require.Equal(page.Logs[len(page.Logs)-1].BlockHeight, page.Next.BlockHeight)
+	})
+	t.Run("StreamLogsInRange", func(t *testing.T) {
+		reads.Store(0)
+		var (
+			sent  int
+			pages int
+			err   error
+		)
+		growth := heapGrowth(func() {
+			err = cs.StreamLogsInRange(context.Background(), filter, 1, blocks, func(page *apitypes.LogsPage) error {
+				for _, l := range page.Logs {
+					require.Equal(uint64(sent+1), l.BlockHeight)
+					sent++
+				}
+				pages++
+				return nil
+			})
+		})
+		require.NoError(err)
+		require.Equal(blocks, sent)
+		require.Greater(pages, blocks/(sizeLimit/dataSize+1)-1)
+		require.Less(growth, uint64(maxHeapGrowth))
+	})
+}
+
 func BenchmarkLogsInRange(b *testing.B) {
+	ctx := context.Background()
 	svr, _, _, _, cleanCallback := setupTestCoreService()
 	defer cleanCallback()
 
@@ -147,7 +410,7 @@ func BenchmarkLogsInRange(b *testing.B) {
 	b.Run("five workers to extract logs", func(b *testing.B) {
 		blk.EXPECT().FilterBlocksInRange(logfilter.NewLogFilter(filter), uint64(from), uint64(to), 0).Return([]uint64{1, 2, 3, 4}, nil).AnyTimes()
 		for i := 0; i < b.N; i++ {
-			svr.LogsInRange(logfilter.NewLogFilter(filter), uint64(from), uint64(to), uint64(0))
+			svr.LogsInRange(ctx, logfilter.NewLogFilter(filter), uint64(from), uint64(to), uint64(0))
 		}
 	})
 }
@@ -155,7 +418,7 @@ func BenchmarkLogsInRange(b *testing.B) {
 func getTopicsAddress(addr []string, topics [][]string) (*iotexapi.LogsFilter, error) {
 	var filter iotexapi.LogsFilter
 	for _, ethAddr := range addr {
-		ioAddr, err := ethAddrToIoAddr(ethAddr)
+		ioAddr, err := parseAddress(ethAddr)
 		if err != nil {
 			return nil, err
 		}
@@ -177,8 +440,10 @@ func getTopicsAddress(addr []string, topics [][]string) (*iotexapi.LogsFilter, e
 }
 
 func setupTestCoreService() (CoreService, blockchain.Blockchain, blockdao.BlockDAO, actpool.ActPool, func()) {
-	cfg := newConfig()
+	return setupTestCoreServiceWithConfig(newConfig())
+}
 
+func setupTestCoreServiceWithConfig(cfg testConfig) (CoreService, blockchain.Blockchain, blockdao.BlockDAO, actpool.ActPool, func()) {
 	// TODO (zhi): revise
 	bc, dao, indexer, bfIndexer, sf, ap, registry, bfIndexFile, err := setupChain(cfg)
 	if err != nil {
@@ -382,6 +647,242 @@ func TestTransactionLogByActionHash(t *testing.T) {
 	})
 }
 
+func TestActionWithStatusByHash(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		bc      = mock_blockchain.NewMockBlockchain(ctrl)
+		blkDAO  = mock_blockdao.NewMockBlockDAO(ctrl)
+		indexer = mock_blockindex.NewMockIndexer(ctrl)
+		ap      = mock_actpool.NewMockActPool(ctrl)
+		cs      = &coreService{
+			bc:      bc,
+			dao:     blkDAO,
+			indexer: indexer,
+			ap:      ap,
+		}
+	)
+	selp, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), 1, big.NewInt(10), nil, 10000, big.NewInt(0))
+	require.NoError(err)
+	h, err := selp.Hash()
+	require.NoError(err)
+	blk, err := block.NewTestingBuilder().
+		SetHeight(3).
+		SetTimeStamp(time.Now()).
+		AddActions(selp).
+		SignAndBuild(identityset.PrivateKey(0))
+	require.NoError(err)
+	receipt := &action.Receipt{Status: 1, BlockHeight: 3, ActionHash: h}
+	info := &actpool.PendingActionInfo{Position: 1, AddedAt: time.Now()}
+
+	t.Run("Pending", func(t *testing.T) {
+		ap.EXPECT().GetPendingActionInfo(h).Return(selp, info, nil).Times(1)
+		indexer.EXPECT().Height().Return(uint64(2), nil).Times(1)
+		indexer.EXPECT().GetActionIndex(h[:]).Return(nil, db.ErrNotExist).Times(1)
+		bc.EXPECT().TipHeight().Return(uint64(2)).Times(1)
+		res, err := cs.ActionWithStatusByHash(h)
+		require.NoError(err)
+		require.Equal(&apitypes.ActionWithStatus{Status: apitypes.ActionStatusPending, Action: selp, Pool: info}, res)
+	})
+
+	t.Run("ConfirmedWhileInPool", func(t *testing.T) {
+		// the action is committed but not removed from the actpool yet
+		ap.EXPECT().GetPendingActionInfo(h).Return(selp, info, nil).Times(1)
+		indexer.EXPECT().Height().Return(uint64(3), nil).Times(1)
+		indexer.EXPECT().GetActionIndex(h[:]).Return(&blockindex.ActionIndex{}, nil).Times(1)
+		blkDAO.EXPECT().GetBlockByHeight(gomock.Any()).Return(&blk, nil).Times(1)
+		blkDAO.EXPECT().GetReceipts(uint64(3)).Return([]*action.Receipt{receipt}, nil).Times(1)
+		res, err := cs.ActionWithStatusByHash(h)
+		require.NoError(err)
+		require.Equal(apitypes.ActionStatusConfirmed, res.Status)
+		require.Equal(&blk, res.Block)
+		require.Equal(receipt, res.Receipt)
+		require.Nil(res.Pool)
+	})
+
+	t.Run("ConfirmedNotIndexed", func(t *testing.T) {
+		// the action is removed from the actpool, and the block is not indexed yet
+		ap.EXPECT().GetPendingActionInfo(h).Return(nil, nil, action.ErrNotFound).Times(1)
+		indexer.EXPECT().Height().Return(uint64(1), nil).Times(1)
+		indexer.EXPECT().GetActionIndex(h[:]).Return(nil, db.ErrNotExist).Times(1)
+		bc.EXPECT().TipHeight().Return(uint64(3)).AnyTimes()
+		blkDAO.EXPECT().GetBlockByHeight(uint64(2)).Return(&block.Block{}, nil).Times(1)
+		blkDAO.EXPECT().GetBlockByHeight(uint64(3)).Return(&blk, nil).Times(1)
+		blkDAO.EXPECT().GetReceipts(uint64(3)).Return([]*action.Receipt{receipt}, nil).Times(1)
+		res, err := cs.ActionWithStatusByHash(h)
+		require.NoError(err)
+		require.Equal(apitypes.ActionStatusConfirmed, res.Status)
+		require.Equal(selp, res.Action)
+		require.Equal(receipt, res.Receipt)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		ap.EXPECT().GetPendingActionInfo(h).Return(nil, nil, action.ErrNotFound).Times(1)
+		indexer.EXPECT().Height().Return(uint64(3), nil).Times(1)
+		indexer.EXPECT().GetActionIndex(h[:]).Return(nil, db.ErrNotExist).Times(1)
+		res, err := cs.ActionWithStatusByHash(h)
+		require.NoError(err)
+		require.Equal(apitypes.ActionStatusNotFound, res.Status)
+	})
+
+	t.Run("FailedToReadActPool", func(t *testing.T) {
+		ap.EXPECT().GetPendingActionInfo(h).Return(nil, nil, errors.New(t.Name())).Times(1)
+		_, err := cs.ActionWithStatusByHash(h)
+		require.ErrorContains(err, t.Name())
+	})
+}
+
+func TestAccountNonceDetail(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		ap     = mock_actpool.NewMockActPool(ctrl)
+		cs     = &coreService{ap: ap}
+		sender = identityset.Address(27)
+		acts   []*action.SealedEnvelope
+		hashes []hash.Hash256
+	)
+	// nonce 3 is missing
+	for _, nonce := range []uint64{1, 2, 4} {
+		selp, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), nonce, big.NewInt(10), nil, 10000, big.NewInt(int64(nonce)))
+		require.NoError(err)
+		h, err := selp.Hash()
+		require.NoError(err)
+		acts = append(acts, selp)
+		hashes = append(hashes, h)
+	}
+	ap.EXPECT().GetNonceDetail(sender.String()).Return(&actpool.NonceDetail{
+		ConfirmedNonce: 1,
+		PendingNonce:   3,
+		Gaps:           []uint64{3},
+		Actions:        acts,
+	}, nil).Times(1)
+	detail, err := cs.AccountNonceDetail(sender)
+	require.NoError(err)
+	require.Equal(&apitypes.AccountNonceDetail{
+		ConfirmedNonce: 1,
+		PendingNonce:   3,
+		Gaps:           []uint64{3},
+		Pending: []*apitypes.PendingNonce{
+			{Nonce: 1, Hash: hashes[0]},
+			{Nonce: 2, Hash: hashes[1]},
+			{Nonce: 4, Hash: hashes[2]},
+		},
+	}, detail)
+
+	ap.EXPECT().GetNonceDetail(sender.String()).Return(nil, errors.New(t.Name())).Times(1)
+	_, err = cs.AccountNonceDetail(sender)
+	require.ErrorContains(err, t.Name())
+}
+
+func TestCancelPendingAction(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		ap     = mock_actpool.NewMockActPool(ctrl)
+		bc     = mock_blockchain.NewMockBlockchain(ctrl)
+		cs     = &coreService{ap: ap, bc: bc}
+		sender = identityset.Address(27)
+	)
+	selp, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), 2, big.NewInt(10), nil, 10000, big.NewInt(5))
+	require.NoError(err)
+	h, err := selp.Hash()
+	require.NoError(err)
+	detail := &actpool.NonceDetail{
+		ConfirmedNonce: 2,
+		PendingNonce:   3,
+		Actions:        []*action.SealedEnvelope{selp},
+	}
+
+	t.Run("mined", func(t *testing.T) {
+		ap.EXPECT().GetNonceDetail(sender.String()).Return(detail, nil).Times(1)
+		_, err := cs.CancelPendingAction(sender, 1, nil)
+		require.Equal(codes.FailedPrecondition, status.Code(err))
+	})
+	t.Run("not in pool", func(t *testing.T) {
+		ap.EXPECT().GetNonceDetail(sender.String()).Return(detail, nil).Times(1)
+		_, err := cs.CancelPendingAction(sender, 3, nil)
+		require.Equal(codes.NotFound, status.Code(err))
+	})
+	t.Run("mined meanwhile", func(t *testing.T) {
+		ap.EXPECT().GetNonceDetail(sender.String()).Return(detail, nil).Times(1)
+		ap.EXPECT().GetPendingActionInfo(h).Return(nil, nil, action.ErrNotFound).Times(1)
+		_, err := cs.CancelPendingAction(sender, 2, nil)
+		require.Equal(codes.FailedPrecondition, status.Code(err))
+	})
+	t.Run("underpriced", func(t *testing.T) {
+		ap.EXPECT().GetNonceDetail(sender.String()).Return(detail, nil).Times(1)
+		ap.EXPECT().GetPendingActionInfo(h).Return(selp, &actpool.PendingActionInfo{MinReplacementGasFeeCap: big.NewInt(6)}, nil).Times(1)
+		_, err := cs.CancelPendingAction(sender, 2, big.NewInt(0))
+		require.Equal(codes.InvalidArgument, status.Code(err))
+	})
+	for _, c := range []struct {
+		bump, gasPrice *big.Int
+	}{
+		{nil, big.NewInt(6)},
+		{big.NewInt(10), big.NewInt(15)},
+	} {
+		ap.EXPECT().GetNonceDetail(sender.String()).Return(detail, nil).Times(1)
+		ap.EXPECT().GetPendingActionInfo(h).Return(selp, &actpool.PendingActionInfo{MinReplacementGasFeeCap: big.NewInt(6)}, nil).Times(1)
+		bc.EXPECT().ChainID().Return(uint32(1)).Times(1)
+		cancel, err := cs.CancelPendingAction(sender, 2, c.bump)
+		require.NoError(err)
+		require.Equal(selp, cancel.Pending)
+		require.Equal(big.NewInt(1), cancel.MinGasPriceBump)
+		elp := cancel.Envelope
+		require.Equal(uint64(2), elp.Nonce())
+		require.Equal(c.gasPrice, elp.GasPrice())
+		require.Equal(action.TransferBaseIntrinsicGas, elp.GasLimit())
+		require.Equal(uint32(1), elp.ChainID())
+		tsf, ok := elp.Action().(*action.Transfer)
+		require.True(ok)
+		require.Equal(sender.String(), tsf.Recipient())
+		require.Zero(tsf.Amount().Sign())
+	}
+}
+
+func TestContractsCreatedByBlock(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		blkDAO   = mock_blockdao.NewMockBlockDAO(ctrl)
+		cs       = &coreService{dao: blkDAO}
+		deployer = identityset.Address(27).String()
+		factory  = identityset.Address(28).String()
+		child    = identityset.Address(29).String()
+		hashes   = []hash.Hash256{hash.Hash256b([]byte("deploy")), hash.Hash256b([]byte("transfer"))}
+	)
+	deploy := (&action.Receipt{ActionHash: hashes[0]}).SetContractChanges([]*action.ContractChange{
+		{Address: factory, Counterparty: deployer, Depth: 0},
+		{Address: child, Counterparty: factory, Depth: 1},
+	}, nil)
+	blkDAO.EXPECT().Height().Return(uint64(10), nil).Times(1)
+	blkDAO.EXPECT().GetReceipts(uint64(5)).Return([]*action.Receipt{deploy, {ActionHash: hashes[1]}}, nil).Times(1)
+	contracts, err := cs.ContractsCreatedByBlock(5)
+	require.NoError(err)
+	require.Equal([]*apitypes.CreatedContract{
+		{ActionHash: hashes[0], Address: factory, Creator: deployer, Depth: 0},
+		{ActionHash: hashes[0], Address: child, Creator: factory, Depth: 1},
+	}, contracts)
+
+	blkDAO.EXPECT().Height().Return(uint64(10), nil).Times(1)
+	_, err = cs.ContractsCreatedByBlock(11)
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	blkDAO.EXPECT().Height().Return(uint64(10), nil).Times(1)
+	blkDAO.EXPECT().GetReceipts(uint64(5)).Return(nil, db.ErrNotExist).Times(1)
+	_, err = cs.ContractsCreatedByBlock(5)
+	require.Equal(codes.NotFound, status.Code(err))
+}
+
 func TestTransactionLogByBlockHeight(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -468,6 +969,95 @@ func TestTransactionLogByBlockHeight(t *testing.T) {
 	})
 }
 
+func TestTransactionLogsByBlockHeightRange(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		blkDAO = mock_blockdao.NewMockBlockDAO(ctrl)
+		cs     = &coreService{dao: blkDAO, cfg: DefaultConfig}
+		to     = identityset.Address(1)
+		other  = identityset.Address(2)
+		txLogs = &iotextypes.TransactionLogs{
+			Logs: []*iotextypes.TransactionLog{
+				{
+					ActionHash:      []byte("transfer"),
+					NumTransactions: 2,
+					Transactions: []*iotextypes.TransactionLog_Transaction{
+						{Amount: "1", Sender: other.String(), Recipient: to.String(), Type: iotextypes.TransactionLogType_NATIVE_TRANSFER},
+						{Amount: "2", Sender: to.String(), Recipient: other.String(), Type: iotextypes.TransactionLogType_GAS_FEE},
+					},
+				},
+			},
+		}
+	)
+	expectLogs := func() {
+		blkDAO.EXPECT().ContainsTransactionLog().Return(true).Times(1)
+		blkDAO.EXPECT().Height().Return(uint64(10), nil).Times(1)
+		// block 8 is not stored, block 9 has no transaction, and block 10 has the transfer
+		blkDAO.EXPECT().TransactionLogs(uint64(8)).Return(nil, filedao.ErrNotSupported).Times(1)
+		blkDAO.EXPECT().TransactionLogs(uint64(9)).Return(nil, db.ErrNotExist).Times(1)
+		blkDAO.EXPECT().TransactionLogs(uint64(10)).Return(txLogs, nil).Times(1)
+		blkDAO.EXPECT().GetBlockHash(gomock.Any()).DoAndReturn(func(height uint64) (hash.Hash256, error) {
+			return hash.Hash256b(byteutil.Uint64ToBytes(height)), nil
+		}).Times(2)
+	}
+
+	t.Run("RangeExceedsLimit", func(t *testing.T) {
+		blkDAO.EXPECT().ContainsTransactionLog().Return(true).Times(1)
+		_, _, err := cs.TransactionLogsByBlockHeightRange(1, DefaultConfig.RangeQueryLimit+1, nil)
+		require.ErrorContains(err, "range exceeds the limit")
+	})
+
+	t.Run("StatusInHeightOrder", func(t *testing.T) {
+		expectLogs()
+		blocks, next, err := cs.TransactionLogsByBlockHeightRange(8, 5, nil)
+		require.NoError(err)
+		require.Equal(uint64(11), next)
+		require.Len(blocks, 3)
+		for i, status := range []string{apitypes.TransactionLogStatusUnavailable, apitypes.TransactionLogStatusEmpty, apitypes.TransactionLogStatusFound} {
+			require.Equal(uint64(8+i), blocks[i].Height)
+			require.Equal(status, blocks[i].Status)
+		}
+		require.Equal(hash.ZeroHash256, blocks[0].Hash)
+		require.Equal(hash.Hash256b(byteutil.Uint64ToBytes(10)), blocks[2].Hash)
+		require.Len(blocks[2].Logs.Logs[0].Transactions, 2)
+	})
+
+	t.Run("FilterByRecipient", func(t *testing.T) {
+		expectLogs()
+		blocks, _, err := cs.TransactionLogsByBlockHeightRange(8, 3, []address.Address{to})
+		require.NoError(err)
+		require.Equal(apitypes.TransactionLogStatusFound, blocks[2].Status)
+		l := blocks[2].Logs.Logs[0]
+		require.EqualValues(1, l.NumTransactions)
+		require.Equal(to.String(), l.Transactions[0].Recipient)
+
+		expectLogs()
+		blocks, _, err = cs.TransactionLogsByBlockHeightRange(8, 3, []address.Address{identityset.Address(3)})
+		require.NoError(err)
+		require.Equal(apitypes.TransactionLogStatusEmpty, blocks[2].Status)
+		require.Nil(blocks[2].Logs)
+	})
+
+	t.Run("SizeLimit", func(t *testing.T) {
+		cs.cfg.TransactionLogRangeSizeLimit = 1
+		defer func() {
+			cs.cfg.TransactionLogRangeSizeLimit = DefaultConfig.TransactionLogRangeSizeLimit
+		}()
+		blkDAO.EXPECT().ContainsTransactionLog().Return(true).Times(1)
+		blkDAO.EXPECT().Height().Return(uint64(10), nil).Times(1)
+		blkDAO.EXPECT().TransactionLogs(gomock.Any()).Return(txLogs, nil).Times(2)
+		blkDAO.EXPECT().GetBlockHash(gomock.Any()).Return(hash.ZeroHash256, nil).Times(2)
+		// the first block is returned even if it exceeds the limit
+		blocks, next, err := cs.TransactionLogsByBlockHeightRange(9, 2, nil)
+		require.NoError(err)
+		require.Len(blocks, 1)
+		require.Equal(uint64(10), next)
+	})
+}
+
 func TestEstimateExecutionGasConsumption(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -608,11 +1198,124 @@ func TestEstimateExecutionGasConsumption(t *testing.T) {
 		require.NoError(err)
 		require.Equal(uint64(10000), estimatedGas)
 
-		//gasprice no zero, should return error before fixed
+		// the gas price is paid by the balance of the caller, which is empty, as geth
 		sc.SetGasPrice(big.NewInt(100))
-		estimatedGas, err = svr.EstimateExecutionGasConsumption(context.Background(), sc, callAddr)
+		_, err = svr.EstimateExecutionGasConsumption(context.Background(), sc, callAddr)
+		require.Equal(codes.InvalidArgument, status.Code(err))
+		require.ErrorContains(err, "gas required exceeds allowance (0)")
+	})
+}
+
+func TestSimulationPolicy(t *testing.T) {
+	require := require.New(t)
+
+	t.Run("Caller", func(t *testing.T) {
+		// an impersonated caller is the zero address if omitted
+		caller, err := simulationCaller(allowImpersonation, nil, nil)
 		require.NoError(err)
-		require.Equal(uint64(10000), estimatedGas)
+		require.Equal(address.ZeroAddress, caller.String())
+		caller, err = simulationCaller(allowImpersonation, identityset.Address(1), nil)
+		require.NoError(err)
+		require.Equal(identityset.Address(1), caller)
+
+		// a verified sender is recovered from the signature
+		selp, err := action.SignedTransfer(identityset.Address(30).String(), identityset.PrivateKey(27), 1,
+			big.NewInt(10), nil, testutil.TestGasLimit, big.NewInt(testutil.TestGasPriceInt64))
+		require.NoError(err)
+		caller, err = simulationCaller(requireVerifiedSender, nil, selp)
+		require.NoError(err)
+		require.Equal(identityset.Address(27).String(), caller.String())
+		caller, err = simulationCaller(requireVerifiedSender, identityset.Address(27), selp)
+		require.NoError(err)
+		require.Equal(identityset.Address(27).String(), caller.String())
+		for _, c := range []struct {
+			caller address.Address
+			selp   func() *action.SealedEnvelope
+		}{
+			// the sender can't be impersonated
+			{identityset.Address(1), func() *action.SealedEnvelope { return selp }},
+			{nil, func() *action.SealedEnvelope { return nil }},
+			// the signature doesn't match the sender
+			{nil, func() *action.SealedEnvelope {
+				pb := selp.Proto()
+				pb.Signature[0]++
+				tampered, err := (&action.Deserializer{}).ActionToSealedEnvelope(pb)
+				require.NoError(err)
+				return tampered
+			}},
+		} {
+			_, err = simulationCaller(requireVerifiedSender, c.caller, c.selp())
+			require.ErrorIs(err, action.ErrInvalidSender)
+		}
+	})
+
+	t.Run("BalanceGasCap", func(t *testing.T) {
+		for _, c := range []struct {
+			balance, value, gasPrice int64
+			gasCap                   uint64
+			err                      error
+		}{
+			// the gas is free at the zero gas price
+			{0, 0, 0, 1000, nil},
+			{100, 100, 0, 1000, nil},
+			// the value not covered by the balance is rejected
+			{100, 101, 0, 0, action.ErrInsufficientFunds},
+			{100, 101, 1, 0, action.ErrInsufficientFunds},
+			// the gas is capped by the balance left after the value
+			{1000, 400, 2, 300, nil},
+			{10000, 0, 2, 1000, nil},
+			{100, 100, 1, 0, nil},
+		} {
+			gasCap, err := balanceGasCap(big.NewInt(c.balance), big.NewInt(c.value), big.NewInt(c.gasPrice), 1000)
+			if c.err != nil {
+				require.ErrorIs(err, c.err)
+				continue
+			}
+			require.NoError(err)
+			require.Equal(c.gasCap, gasCap)
+		}
+		// the nil balance, value and gas price are zero
+		gasCap, err := balanceGasCap(nil, nil, nil, 1000)
+		require.NoError(err)
+		require.EqualValues(1000, gasCap)
+	})
+
+	t.Run("Simulation", func(t *testing.T) {
+		svr, _, _, _, cleanCallback := setupTestCoreService()
+		defer cleanCallback()
+		ctx := context.Background()
+		caller := identityset.Address(27)
+		meta, _, err := svr.Account(caller)
+		require.NoError(err)
+		balance, ok := new(big.Int).SetString(meta.Balance, 10)
+		require.True(ok)
+		overdraft := new(big.Int).Add(balance, big.NewInt(1))
+
+		// eth_call runs as the zero address if from is omitted, and ignores the gas price
+		sc, err := action.NewExecution(identityset.Address(30).String(), 0, big.NewInt(0), 0, overdraft, nil)
+		require.NoError(err)
+		_, receipt, err := svr.ReadContract(ctx, nil, sc)
+		require.NoError(err)
+		require.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+
+		// eth_estimateGas rejects the value not covered by the balance
+		sc, err = action.NewExecution("", 0, overdraft, 0, big.NewInt(0), nil)
+		require.NoError(err)
+		_, err = svr.EstimateExecutionGasConsumption(ctx, sc, caller)
+		require.Equal(codes.InvalidArgument, status.Code(err))
+		require.ErrorContains(err, "insufficient funds for transfer")
+
+		// and caps the gas by what the balance pays at the gas price
+		sc, err = action.NewExecution("", 0, big.NewInt(0), 0, new(big.Int).Div(balance, big.NewInt(9000)), nil)
+		require.NoError(err)
+		_, err = svr.EstimateExecutionGasConsumption(ctx, sc, caller)
+		require.Equal(codes.InvalidArgument, status.Code(err))
+		require.ErrorContains(err, "gas required exceeds allowance")
+		sc, err = action.NewExecution("", 0, big.NewInt(0), 0, new(big.Int).Div(balance, big.NewInt(11000)), nil)
+		require.NoError(err)
+		gas, err := svr.EstimateExecutionGasConsumption(ctx, sc, caller)
+		require.NoError(err)
+		require.EqualValues(10000, gas)
 	})
 }
 
@@ -620,7 +1323,10 @@ func TestTraceTransaction(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	svr, bc, _, ap, cleanCallback := setupTestCoreService()
+	// the transaction is replayed on the state of the parent block
+	chainCfg := newConfig()
+	chainCfg.chain.EnableArchiveMode = true
+	svr, bc, _, ap, cleanCallback := setupTestCoreServiceWithConfig(chainCfg)
 	defer cleanCallback()
 	ctx := context.Background()
 	tsf, err := action.SignedExecution(identityset.Address(29).String(),
@@ -689,6 +1395,50 @@ func TestTraceCall(t *testing.T) {
 	require.Equal(0, len(traces.(*logger.StructLogger).StructLogs()))
 }
 
+func TestTraceBlockByNumber(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig()
+	cfg.chain.EnableArchiveMode = true
+	svr, bc, dao, _, cleanCallback := setupTestCoreServiceWithConfig(cfg)
+	defer cleanCallback()
+	ctx := context.Background()
+
+	// the executions replayed on the state of the parent block have the receipts in the block
+	var traced int
+	for height := uint64(1); height <= bc.TipHeight(); height++ {
+		traces, err := svr.TraceBlockByNumber(ctx, height, nil)
+		require.NoError(err)
+		receipts, err := dao.GetReceipts(height)
+		require.NoError(err)
+		for _, trace := range traces {
+			receipt := filterReceipts(receipts, trace.ActionHash)
+			require.NotNil(receipt)
+			require.Equal(receipt.Status, trace.Receipt.Status)
+			require.Equal(receipt.GasConsumed, trace.Receipt.GasConsumed)
+			require.IsType(&logger.StructLogger{}, trace.Tracer)
+		}
+		traced += len(traces)
+	}
+	require.NotZero(traced)
+
+	_, err := svr.TraceBlockByNumber(ctx, bc.TipHeight()+1, nil)
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	// no trace runs once the limit of concurrent traces is reached
+	cs := svr.(*coreService)
+	for i := 0; i < cfg.api.TraceConcurrency; i++ {
+		cs.traceSlots <- struct{}{}
+	}
+	_, err = svr.TraceBlockByNumber(ctx, 1, nil)
+	require.Equal(codes.ResourceExhausted, status.Code(err))
+
+	// the state of the parent block is not kept without the archive mode
+	svr, _, _, _, cleanCallback = setupTestCoreService()
+	defer cleanCallback()
+	_, err = svr.TraceBlockByNumber(ctx, 1, nil)
+	require.Equal(codes.Unimplemented, status.Code(err))
+}
+
 func TestProofAndCompareReverseActions(t *testing.T) {
 	sliceN := func(n uint64) (value []uint64) {
 		value = make([]uint64, 0, n)
@@ -1014,7 +1764,7 @@ func TestReceiveBlock(t *testing.T) {
 		p := NewPatches()
 		defer p.Reset()
 
-		p = p.ApplyMethodReturn(cs.readCache, "Clear")
+		p = p.ApplyMethodReturn(cs.readCache, "Invalidate")
 		listener.EXPECT().ReceiveBlock(gomock.Any()).Return(errors.New(t.Name())).Times(1)
 		err := cs.ReceiveBlock(&block.Block{})
 		require.ErrorContains(err, t.Name())
@@ -1024,7 +1774,7 @@ func TestReceiveBlock(t *testing.T) {
 		p := NewPatches()
 		defer p.Reset()
 
-		p = p.ApplyMethodReturn(cs.readCache, "Clear")
+		p = p.ApplyMethodReturn(cs.readCache, "Invalidate")
 		listener.EXPECT().ReceiveBlock(gomock.Any()).Return(nil).Times(1)
 		err := cs.ReceiveBlock(&block.Block{})
 		require.NoError(err)
@@ -1128,6 +1878,116 @@ func TestSimulateExecution(t *testing.T) {
 	})
 }
 
+func TestSimulateBatchOnWorkingSet(t *testing.T) {
+	require := require.New(t)
+	svr, bc, _, _, cleanCallback := setupTestCoreService()
+	defer cleanCallback()
+	ctx := context.Background()
+	sk, err := crypto.GenerateKey()
+	require.NoError(err)
+	fresh := sk.PublicKey().Address()
+	freshEth := common.BytesToAddress(fresh.Bytes())
+	stateCtx, err := bc.Context(genesis.WithGenesisContext(ctx, bc.Genesis()))
+	require.NoError(err)
+	state, err := accountutil.AccountState(stateCtx, svr.(*coreService).sf, fresh)
+	require.NoError(err)
+	require.Zero(state.Balance.Sign())
+	amount := big.NewInt(100)
+	calls := []*apitypes.SimulateCall{
+		{Caller: identityset.Address(27), To: fresh.String(), Amount: amount},
+		// spends the amount received by the previous call
+		{Caller: fresh, To: identityset.Address(28).String(), Amount: amount},
+		{Caller: fresh, To: identityset.Address(28).String(), Amount: amount},
+		{Caller: identityset.Address(27), To: fresh.String(), Amount: amount},
+	}
+
+	t.Run("HaltOnFailure", func(t *testing.T) {
+		results, err := svr.SimulateBatch(ctx, 0, calls, nil, false)
+		require.NoError(err)
+		require.Len(results, 3)
+		require.Equal(uint64(iotextypes.ReceiptStatus_Success), results[0].Status)
+		require.Equal(uint64(iotextypes.ReceiptStatus_Success), results[1].Status)
+		require.NotEqual(uint64(iotextypes.ReceiptStatus_Success), results[2].Status)
+	})
+	t.Run("ContinueOnFailure", func(t *testing.T) {
+		results, err := svr.SimulateBatch(ctx, 0, calls, nil, true)
+		require.NoError(err)
+		require.Len(results, 4)
+		require.NotEqual(uint64(iotextypes.ReceiptStatus_Success), results[2].Status)
+		require.Equal(uint64(iotextypes.ReceiptStatus_Success), results[3].Status)
+	})
+	t.Run("StateOverrides", func(t *testing.T) {
+		results, err := svr.SimulateBatch(ctx, 0, calls[1:3], map[common.Address]*evm.StateOverride{
+			freshEth: {Balance: new(big.Int).Mul(amount, big.NewInt(2))},
+		}, false)
+		require.NoError(err)
+		require.Len(results, 2)
+		for _, r := range results {
+			require.Equal(uint64(iotextypes.ReceiptStatus_Success), r.Status)
+		}
+	})
+	t.Run("NothingCommitted", func(t *testing.T) {
+		state, err := accountutil.AccountState(stateCtx, svr.(*coreService).sf, fresh)
+		require.NoError(err)
+		require.Zero(state.Balance.Sign())
+	})
+	t.Run("InvalidBatch", func(t *testing.T) {
+		_, err := svr.SimulateBatch(ctx, 0, nil, nil, false)
+		require.Equal(codes.InvalidArgument, status.Code(err))
+		_, err = svr.SimulateBatch(ctx, bc.TipHeight()+1, calls, nil, false)
+		require.Equal(codes.InvalidArgument, status.Code(err))
+		// the past states require the archive mode
+		_, err = svr.SimulateBatch(ctx, bc.TipHeight()-1, calls, nil, false)
+		require.Equal(codes.Unimplemented, status.Code(err))
+	})
+}
+
+func TestConvertAddress(t *testing.T) {
+	require := require.New(t)
+	svr, _, _, _, cleanCallback := setupTestCoreService()
+	defer cleanCallback()
+
+	funded := identityset.Address(27)
+	info, err := svr.ConvertAddress(common.BytesToAddress(funded.Bytes()).Hex())
+	require.NoError(err)
+	require.Equal(funded.String(), info.IoAddress)
+	require.Equal(addrutil.HexFormat, info.Format)
+	require.Equal(apitypes.AddressKindAccount, info.Kind)
+
+	sk, err := crypto.GenerateKey()
+	require.NoError(err)
+	info, err = svr.ConvertAddress(sk.PublicKey().Address().String())
+	require.NoError(err)
+	require.Equal(addrutil.IoFormat, info.Format)
+	require.Equal(apitypes.AddressKindNone, info.Kind)
+
+	info, err = svr.ConvertAddress(address.StakingProtocolAddr)
+	require.NoError(err)
+	require.Equal("staking", info.SystemContract)
+
+	_, err = svr.ConvertAddress("0x1234")
+	require.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func TestTotalSupply(t *testing.T) {
+	require := require.New(t)
+	svr, bc, _, _, cleanCallback := setupTestCoreService()
+	defer cleanCallback()
+
+	supply, err := svr.TotalSupply(context.Background(), 0)
+	require.NoError(err)
+	require.Equal(bc.TipHeight(), supply.Height)
+	// no base fee is burnt before vanuatu
+	require.Zero(supply.Burned.Sign())
+	require.Zero(supply.Redistributed.Sign())
+	g := bc.Genesis()
+	require.Equal(g.TotalAllocation().String(), supply.Total.String())
+	require.Equal(-1, supply.Circulating.Cmp(supply.Total))
+
+	_, err = svr.TotalSupply(context.Background(), bc.TipHeight()+1)
+	require.Equal(codes.InvalidArgument, status.Code(err))
+}
+
 func TestSyncingProgress(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -1135,11 +1995,12 @@ func TestSyncingProgress(t *testing.T) {
 
 	bs := mock_blocksync.NewMockBlockSync(ctrl)
 	cs := &coreService{bs: bs}
-	bs.EXPECT().SyncStatus().Return(uint64(0), uint64(0), uint64(0), "").Times(1)
-	startingHeight, currentHeight, targetHeight := cs.SyncingProgress()
-	require.Equal(uint64(0), startingHeight)
-	require.Equal(uint64(0), currentHeight)
-	require.Equal(uint64(0), targetHeight)
+	bs.EXPECT().SyncProgress().Return(blocksync.SyncProgress{Stage: blocksync.SyncStageIdle}).Times(1)
+	progress := cs.SyncingProgress()
+	require.Zero(progress.StartingHeight)
+	require.Zero(progress.CurrentHeight)
+	require.Zero(progress.TargetHeight)
+	require.False(progress.Syncing())
 }
 
 func TestTrack(t *testing.T) {
@@ -1240,3 +2101,551 @@ func TestTraceTx(t *testing.T) {
 		require.Empty(tracer)
 	})
 }
+
+func TestSendActionKnown(t *testing.T) {
+	require := require.New(t)
+	svr, bc, _, _, cleanCallback := setupTestCoreService()
+	defer cleanCallback()
+
+	nonce, err := svr.PendingNonce(identityset.Address(27))
+	require.NoError(err)
+	selp, err := action.SignedTransfer(identityset.Address(30).String(), identityset.PrivateKey(27), nonce,
+		big.NewInt(10), nil, testutil.TestGasLimit, big.NewInt(testutil.TestGasPriceInt64))
+	require.NoError(err)
+	actHash, err := selp.Hash()
+	require.NoError(err)
+	// the same nonce with a different payload
+	conflict, err := action.SignedTransfer(identityset.Address(30).String(), identityset.PrivateKey(27), nonce,
+		big.NewInt(11), nil, testutil.TestGasLimit, big.NewInt(testutil.TestGasPriceInt64))
+	require.NoError(err)
+
+	sent, err := svr.SendActionWithStatus(context.Background(), selp.Proto())
+	require.NoError(err)
+	require.Equal(actHash, sent.Hash)
+	require.False(sent.Known)
+	require.Equal(apitypes.ActionStatusPending, sent.Status.Status)
+
+	// resent while pending in the actpool
+	sent, err = svr.SendActionWithStatus(context.Background(), selp.Proto())
+	require.NoError(err)
+	require.Equal(actHash, sent.Hash)
+	require.True(sent.Known)
+	require.Equal(apitypes.ActionStatusPending, sent.Status.Status)
+	_, err = svr.SendAction(context.Background(), conflict.Proto())
+	require.ErrorContains(err, action.ErrReplaceUnderpriced.Error())
+
+	blk, err := bc.MintNewBlock(testutil.TimestampNow())
+	require.NoError(err)
+	require.NoError(bc.ValidateBlock(blk))
+	require.NoError(bc.CommitBlock(blk))
+
+	// resent after confirmed in a block
+	h, err := svr.SendAction(context.Background(), selp.Proto())
+	require.NoError(err)
+	require.Equal(hex.EncodeToString(actHash[:]), h)
+	sent, err = svr.SendActionWithStatus(context.Background(), selp.Proto())
+	require.NoError(err)
+	require.True(sent.Known)
+	require.Equal(apitypes.ActionStatusConfirmed, sent.Status.Status)
+	require.Equal(blk.Height(), sent.Status.Block.Height())
+	require.Equal(uint64(iotextypes.ReceiptStatus_Success), sent.Status.Receipt.Status)
+	_, err = svr.SendAction(context.Background(), conflict.Proto())
+	require.ErrorContains(err, action.ErrNonceTooLow.Error())
+}
+
+func TestSendActionTraceSpans(t *testing.T) {
+	require := require.New(t)
+	exp := tracetest.NewInMemoryExporter()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tracesdk.NewTracerProvider(tracesdk.WithSyncer(exp)))
+	defer otel.SetTracerProvider(prev)
+	svr, bc, _, _, cleanCallback := setupTestCoreService()
+	defer cleanCallback()
+
+	nonce, err := svr.PendingNonce(identityset.Address(27))
+	require.NoError(err)
+	selp, err := action.SignedTransfer(identityset.Address(30).String(), identityset.PrivateKey(27), nonce,
+		big.NewInt(10), nil, testutil.TestGasLimit, big.NewInt(testutil.TestGasPriceInt64))
+	require.NoError(err)
+	actHash, err := selp.Hash()
+	require.NoError(err)
+	_, err = svr.SendAction(context.Background(), selp.Proto())
+	require.NoError(err)
+	require.True(tracer.TracksActions())
+	blk, err := bc.MintNewBlock(testutil.TimestampNow())
+	require.NoError(err)
+	require.NoError(bc.ValidateBlock(blk))
+	require.NoError(bc.CommitBlock(blk))
+	require.False(tracer.TracksActions())
+
+	spans := make(map[string]tracetest.SpanStub)
+	for _, span := range exp.GetSpans() {
+		spans[span.Name] = span
+	}
+	root, ok := spans["coreService.SendAction"]
+	require.True(ok)
+	require.Contains(root.Attributes, tracer.ActionHashKey.String(hex.EncodeToString(actHash[:])))
+	for _, name := range []string{
+		"actPool.Add",
+		"coreService.broadcast",
+		"blockchain.MintNewBlock",
+		"blockchain.ValidateBlock",
+		"blockchain.CommitBlock",
+	} {
+		span, ok := spans[name]
+		require.True(ok, name)
+		require.Equal(root.SpanContext.TraceID(), span.SpanContext.TraceID(), name)
+		require.Equal(root.SpanContext.SpanID(), span.Parent.SpanID(), name)
+	}
+	var queueTime bool
+	for _, kv := range spans["actPool.Add"].Attributes {
+		queueTime = queueTime || kv.Key == "queueTimeMs"
+	}
+	require.True(queueTime)
+}
+
+func TestValidateAction(t *testing.T) {
+	require := require.New(t)
+	svr, _, _, ap, cleanCallback := setupTestCoreService()
+	defer cleanCallback()
+	ctx := context.Background()
+	nonce, err := svr.PendingNonce(identityset.Address(27))
+	require.NoError(err)
+	size := ap.GetSize()
+	gasPrice := big.NewInt(testutil.TestGasPriceInt64)
+
+	selp, err := action.SignedTransfer(identityset.Address(30).String(), identityset.PrivateKey(27), nonce,
+		big.NewInt(10), nil, testutil.TestGasLimit, gasPrice)
+	require.NoError(err)
+	ret, err := svr.ValidateAction(ctx, selp.Proto())
+	require.NoError(err)
+	require.Empty(ret.Reason)
+	require.Equal(uint64(10000), ret.Gas)
+	require.Equal(new(big.Int).Mul(big.NewInt(10000), gasPrice), ret.Fee)
+	// the action is neither added into the actpool
+	require.Equal(size, ap.GetSize())
+
+	for _, c := range []struct {
+		nonce  uint64
+		amount *big.Int
+		reason string
+	}{
+		{nonce - 1, big.NewInt(10), apitypes.RejectNonceTooLow},
+		{nonce, new(big.Int).Lsh(big.NewInt(1), 128), apitypes.RejectInsufficientFunds},
+	} {
+		selp, err := action.SignedTransfer(identityset.Address(30).String(), identityset.PrivateKey(27), c.nonce,
+			c.amount, nil, testutil.TestGasLimit, gasPrice)
+		require.NoError(err)
+		ret, err := svr.ValidateAction(ctx, selp.Proto())
+		require.NoError(err)
+		require.Equal(c.reason, ret.Reason)
+		require.NotEmpty(ret.Message)
+	}
+
+	// the init code stores a word, which costs more gas than the gas limit
+	selp, err = action.SignedExecution(action.EmptyAddress, identityset.PrivateKey(27), nonce, big.NewInt(0),
+		11000, gasPrice, []byte{0x60, 0x01, 0x60, 0x00, 0x55})
+	require.NoError(err)
+	ret, err = svr.ValidateAction(ctx, selp.Proto())
+	require.NoError(err)
+	require.Equal(apitypes.RejectGasLimitTooLow, ret.Reason)
+	selp, err = action.SignedExecution(action.EmptyAddress, identityset.PrivateKey(27), nonce, big.NewInt(0),
+		100000, gasPrice, []byte{0x60, 0x01, 0x60, 0x00, 0x55})
+	require.NoError(err)
+	ret, err = svr.ValidateAction(ctx, selp.Proto())
+	require.NoError(err)
+	require.Empty(ret.Reason)
+	require.Greater(ret.Gas, uint64(11000))
+	require.Equal(size, ap.GetSize())
+}
+
+// newReadStateTestService returns the core service reading the candidates from the poll protocol and the staking
+// protocol, which count the reads from the state. An epoch is 2 blocks
+func newReadStateTestService(tb testing.TB, tip *uint64, reads map[string]*uint64) *coreService {
+	r := require.New(tb)
+	ctrl := gomock.NewController(tb)
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().TipHeight().DoAndReturn(func() uint64 { return atomic.LoadUint64(tip) }).AnyTimes()
+	bc.EXPECT().Genesis().Return(genesis.Default).AnyTimes()
+	listener := mock_apitypes.NewMockListener(ctrl)
+	listener.EXPECT().ReceiveBlock(gomock.Any()).Return(nil).AnyTimes()
+	sf := mock_factory.NewMockFactory(ctrl)
+	sf.EXPECT().StateReaderAtHeight(gomock.Any(), gomock.Any()).Return(sf, nil).AnyTimes()
+	registry := protocol.NewRegistry()
+	r.NoError(rolldpos.NewProtocol(2, 2, 1).Register(registry))
+	for _, name := range []string{"poll", "staking"} {
+		count := new(uint64)
+		reads[name] = count
+		p := mock_poll.NewMockProtocol(ctrl)
+		p.EXPECT().Name().Return(name).AnyTimes()
+		p.EXPECT().ReadState(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(context.Context, protocol.StateReader, []byte, ...[]byte) ([]byte, uint64, error) {
+				atomic.AddUint64(count, 1)
+				return []byte("candidates"), atomic.LoadUint64(tip), nil
+			}).AnyTimes()
+		r.NoError(registry.Register(name, p))
+	}
+	return &coreService{
+		bc:            bc,
+		sf:            sf,
+		registry:      registry,
+		chainListener: listener,
+		readCache:     NewReadCache(time.Minute, 1<<20),
+	}
+}
+
+func TestReadStateCache(t *testing.T) {
+	require := require.New(t)
+	var (
+		tip   = uint64(3)
+		reads = make(map[string]*uint64)
+		cs    = newReadStateTestService(t, &tip, reads)
+		ctx   = context.Background()
+		epoch = [][]byte{[]byte("2")}
+	)
+	read := func(ctx context.Context, name, height, method string, args ...[]byte) {
+		p, ok := cs.registry.Find(name)
+		require.True(ok)
+		d, _, err := cs.readState(ctx, p, height, []byte(method), args...)
+		require.NoError(err)
+		require.Equal([]byte("candidates"), d)
+	}
+	commit := func(height uint64) {
+		atomic.StoreUint64(&tip, height)
+		blk, err := block.NewTestingBuilder().SetHeight(height).SignAndBuild(identityset.PrivateKey(0))
+		require.NoError(err)
+		require.NoError(cs.ReceiveBlock(&blk))
+	}
+
+	for i := 0; i < 3; i++ {
+		read(ctx, "poll", "", "CandidatesByEpoch", epoch...)
+		read(ctx, "staking", "", "CANDIDATES")
+		read(ctx, "staking", "2", "CANDIDATES")
+	}
+	require.Equal(uint64(1), *reads["poll"])
+	require.Equal(uint64(2), *reads["staking"])
+
+	// the results at the tip are invalidated on block commit, but not the ones only changing per epoch
+	commit(4)
+	read(ctx, "poll", "", "CandidatesByEpoch", epoch...)
+	read(ctx, "staking", "", "CANDIDATES")
+	read(ctx, "staking", "2", "CANDIDATES")
+	require.Equal(uint64(1), *reads["poll"])
+	require.Equal(uint64(3), *reads["staking"])
+
+	// the results only changing per epoch are invalidated on epoch transition
+	commit(5)
+	read(ctx, "poll", "", "CandidatesByEpoch", epoch...)
+	require.Equal(uint64(2), *reads["poll"])
+
+	// the bypass always reads from the state
+	read(WithReadCacheBypass(ctx), "poll", "", "CandidatesByEpoch", epoch...)
+	read(WithReadCacheBypass(ctx), "staking", "2", "CANDIDATES")
+	require.Equal(uint64(3), *reads["poll"])
+	require.Equal(uint64(4), *reads["staking"])
+}
+
+// BenchmarkReadStateCandidates reads the candidates concurrently while the blocks are committed, and the candidates
+// are read from the state only once per block
+func BenchmarkReadStateCandidates(b *testing.B) {
+	const (
+		numBlocks  = 10
+		numReaders = 16
+	)
+	var (
+		tip   = uint64(1)
+		reads = make(map[string]*uint64)
+		cs    = newReadStateTestService(b, &tip, reads)
+	)
+	p, _ := cs.registry.Find("staking")
+	b.ResetTimer()
+	for height := uint64(1); height <= numBlocks; height++ {
+		atomic.StoreUint64(&tip, height)
+		blk, err := block.NewTestingBuilder().SetHeight(height).SignAndBuild(identityset.PrivateKey(0))
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := cs.ReceiveBlock(&blk); err != nil {
+			b.Fatal(err)
+		}
+		var wg sync.WaitGroup
+		for i := 0; i < numReaders; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < b.N/numBlocks/numReaders+1; j++ {
+					if _, _, err := cs.readState(context.Background(), p, "", []byte("CANDIDATES")); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			}()
+		}
+		wg.Wait()
+	}
+	b.StopTimer()
+	if n := atomic.LoadUint64(reads["staking"]); n != numBlocks {
+		b.Fatalf("candidates read from the state %d times in %d blocks", n, numBlocks)
+	}
+}
+
+func TestRawBlock(t *testing.T) {
+	require := require.New(t)
+	svr, bc, dao, _, cleanCallback := setupTestCoreService()
+	defer cleanCallback()
+
+	tip := bc.TipHeight()
+	blk, err := dao.GetBlockByHeight(tip)
+	require.NoError(err)
+	receipts, err := dao.GetReceipts(tip)
+	require.NoError(err)
+	raw, err := svr.RawBlockByHeight(tip, apitypes.RawBlockAll)
+	require.NoError(err)
+	require.Equal(blk.HashBlock(), raw.Hash)
+
+	// the block hash is recomputed from the header bytes, and the header commits to the body and the receipts
+	require.Equal(raw.Hash, hash.Hash256b(raw.Header))
+	header := &block.Header{}
+	require.NoError(header.Deserialize(raw.Header))
+	require.Equal(tip, header.Height())
+	body, err := blk.Body.Serialize()
+	require.NoError(err)
+	require.Equal(body, raw.Body)
+	txRoot, err := blk.Body.CalculateTxRoot()
+	require.NoError(err)
+	require.Equal(txRoot, header.TxRoot())
+	receiptsPb := &iotextypes.Receipts{}
+	require.NoError(proto.Unmarshal(raw.Receipts, receiptsPb))
+	require.Len(receiptsPb.Receipts, len(receipts))
+	receiptHashes := make([]hash.Hash256, len(receiptsPb.Receipts))
+	for i, pb := range receiptsPb.Receipts {
+		r := &action.Receipt{}
+		r.ConvertFromReceiptPb(pb)
+		receiptHashes[i] = r.Hash()
+	}
+	require.Equal(header.ReceiptRoot(), crypto.NewMerkleTree(receiptHashes).HashTree())
+	footer := &block.Footer{}
+	require.NoError(footer.Deserialize(raw.Footer))
+	require.Equal(blk.CommitTime().Unix(), footer.CommitTime().Unix())
+
+	// only the parts asked for are returned
+	raw2, err := svr.RawBlockByHash(raw.Hash, apitypes.RawBlockHeader|apitypes.RawBlockReceipts)
+	require.NoError(err)
+	require.Equal(raw.Header, raw2.Header)
+	require.Equal(raw.Receipts, raw2.Receipts)
+	require.Nil(raw2.Body)
+	require.Nil(raw2.Footer)
+	_, err = svr.RawBlockByHeight(tip+1, apitypes.RawBlockAll)
+	require.Equal(ErrNotFound, errors.Cause(err))
+	_, err = svr.RawBlockByHash(hash.Hash256b([]byte("unknown")), apitypes.RawBlockAll)
+	require.Equal(ErrNotFound, errors.Cause(err))
+
+	// the headers are cut at the tip
+	headers, err := svr.RawHeaders(1, 1000)
+	require.NoError(err)
+	require.Len(headers, int(tip))
+	for i, h := range headers {
+		require.Equal(uint64(i+1), h.Height)
+		require.Equal(h.Hash, hash.Hash256b(h.Header))
+		require.Nil(h.Body)
+	}
+	require.Equal(raw.Header, headers[tip-1].Header)
+	_, err = svr.RawHeaders(1, 0)
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = svr.RawHeaders(tip+1, 1)
+	require.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func TestActionInclusionProof(t *testing.T) {
+	require := require.New(t)
+	svr, bc, dao, _, cleanCallback := setupTestCoreService()
+	defer cleanCallback()
+
+	proved := 0
+	for height := uint64(1); height <= bc.TipHeight(); height++ {
+		blk, err := dao.GetBlockByHeight(height)
+		require.NoError(err)
+		for i, selp := range blk.Actions {
+			actHash, err := selp.Hash()
+			require.NoError(err)
+			proof, err := svr.ActionInclusionProof(actHash)
+			require.NoError(err)
+			require.Equal(height, proof.Height)
+			require.Equal(uint32(i), proof.Index)
+			require.Equal(actHash, proof.ActionHash)
+			require.NoError(block.VerifyActionInclusionProof(proof, blk.HashBlock()))
+			proved++
+		}
+	}
+	require.NotZero(proved)
+	_, err := svr.ActionInclusionProof(hash.Hash256b([]byte("unknown")))
+	require.Equal(ErrNotFound, errors.Cause(err))
+}
+
+func TestRawHeadersLimit(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		bc     = mock_blockchain.NewMockBlockchain(ctrl)
+		blkDAO = mock_blockdao.NewMockBlockDAO(ctrl)
+		cs     = &coreService{bc: bc, dao: blkDAO}
+	)
+	blk, err := block.NewTestingBuilder().SetHeight(10).SignAndBuild(identityset.PrivateKey(0))
+	require.NoError(err)
+	bc.EXPECT().TipHeight().Return(uint64(5000)).Times(1)
+	blkDAO.EXPECT().GetBlockHash(gomock.Any()).Return(blk.HashBlock(), nil).Times(1000)
+	blkDAO.EXPECT().GetBlockByHeight(gomock.Any()).Return(&blk, nil).Times(1000)
+	blkDAO.EXPECT().GetReceipts(gomock.Any()).Return(nil, nil).Times(1000)
+	headers, err := cs.RawHeaders(10, 2000)
+	require.NoError(err)
+	require.Len(headers, 1000)
+	require.Equal(uint64(1009), headers[999].Height)
+}
+
+func TestBlockMetasByTimestampRange(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		ctx    = context.Background()
+		bc     = mock_blockchain.NewMockBlockchain(ctrl)
+		blkDAO = mock_blockdao.NewMockBlockDAO(ctrl)
+		cs     = &coreService{bc: bc, dao: blkDAO, cfg: Config{RangeQueryLimit: 2}}
+	)
+	_, err := cs.BlockHeightByTimestamp(time.Unix(100, 0), true)
+	require.Equal(codes.Unavailable, status.Code(err))
+	_, _, err = cs.BlockMetasByTimestampRange(time.Unix(100, 0), time.Unix(110, 0), 0, 1)
+	require.Equal(codes.Unavailable, status.Code(err))
+
+	indexer, err := blockindex.NewBlockTimeIndexer(db.NewMemKVStore())
+	require.NoError(err)
+	require.NoError(indexer.Start(ctx))
+	cs.blockTimeIndexer = indexer
+	blks := make([]*block.Block, 0, 4)
+	for i, sec := range []int64{100, 105, 105, 110} {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(uint64(i + 1)).
+			SetTimeStamp(time.Unix(sec, 0)).
+			SignAndBuild(identityset.PrivateKey(0))
+		require.NoError(err)
+		require.NoError(indexer.PutBlock(ctx, &blk))
+		blks = append(blks, &blk)
+	}
+	height, err := cs.BlockHeightByTimestamp(time.Unix(107, 0), true)
+	require.NoError(err)
+	require.Equal(uint64(3), height)
+	height, err = cs.BlockHeightByTimestamp(time.Unix(105, 0), false)
+	require.NoError(err)
+	require.Equal(uint64(2), height)
+	_, err = cs.BlockHeightByTimestamp(time.Unix(111, 0), false)
+	require.Equal(ErrNotFound, errors.Cause(err))
+
+	bc.EXPECT().TipHeight().Return(uint64(4)).AnyTimes()
+	blkDAO.EXPECT().GetBlockByHeight(gomock.Any()).DoAndReturn(func(height uint64) (*block.Block, error) {
+		return blks[height-1], nil
+	}).AnyTimes()
+	blkDAO.EXPECT().GetReceipts(gomock.Any()).Return(nil, nil).AnyTimes()
+	metas, total, err := cs.BlockMetasByTimestampRange(time.Unix(101, 0), time.Unix(110, 0), 1, 2)
+	require.NoError(err)
+	require.Equal(uint64(3), total)
+	require.Len(metas, 2)
+	require.Equal(uint64(3), metas[0].Height)
+	require.Equal(uint64(4), metas[1].Height)
+	require.Equal(int64(110), metas[1].Timestamp.GetSeconds())
+	metas, total, err = cs.BlockMetasByTimestampRange(time.Unix(101, 0), time.Unix(110, 0), 2, 2)
+	require.NoError(err)
+	require.Equal(uint64(3), total)
+	require.Len(metas, 1)
+	metas, total, err = cs.BlockMetasByTimestampRange(time.Unix(101, 0), time.Unix(104, 0), 0, 2)
+	require.NoError(err)
+	require.Zero(total)
+	require.Empty(metas)
+
+	_, _, err = cs.BlockMetasByTimestampRange(time.Unix(110, 0), time.Unix(100, 0), 0, 1)
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	_, _, err = cs.BlockMetasByTimestampRange(time.Unix(100, 0), time.Unix(110, 0), 0, 3)
+	require.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func TestProtocolParameters(t *testing.T) {
+	require := require.New(t)
+	registry := protocol.NewRegistry()
+	cs := &coreService{registry: registry}
+	_, _, err := cs.ProtocolParameters(1)
+	require.Equal(codes.Unavailable, status.Code(err))
+
+	g := genesis.Default
+	g.DardanellesBlockHeight = 10
+	g.ToBeEnabledBlockHeight = 20
+	require.NoError(rewarding.NewProtocol(g.Rewarding).Register(registry))
+	require.NoError(execution.NewProtocol(nil, nil, nil).Register(registry))
+	_, err = registry.PopulateParameters(g)
+	require.NoError(err)
+
+	value := func(params []protocol.Parameter, protocolID, name string) (protocol.Parameter, bool) {
+		for _, p := range params {
+			if p.Protocol == protocolID && p.Name == name {
+				return p, true
+			}
+		}
+		return protocol.Parameter{}, false
+	}
+	inForce, upcoming, err := cs.ProtocolParameters(9)
+	require.NoError(err)
+	p, ok := value(inForce, "rewarding", "blockReward")
+	require.True(ok)
+	require.Equal(g.BlockRewardStr, p.Value)
+	p, ok = value(upcoming, "rewarding", "blockReward")
+	require.True(ok)
+	require.Equal(protocol.Parameter{Protocol: "rewarding", Name: "blockReward", Value: g.DardanellesBlockRewardStr, Height: 10}, p)
+	p, ok = value(inForce, "smart_contract", "systemContracts")
+	require.True(ok)
+	require.Equal("false", p.Value)
+	for i := 1; i < len(upcoming); i++ {
+		require.LessOrEqual(upcoming[i-1].Height, upcoming[i].Height)
+	}
+
+	inForce, upcoming, err = cs.ProtocolParameters(20)
+	require.NoError(err)
+	p, ok = value(inForce, "rewarding", "blockReward")
+	require.True(ok)
+	require.Equal(g.DardanellesBlockRewardStr, p.Value)
+	p, ok = value(inForce, "smart_contract", "systemContracts")
+	require.True(ok)
+	require.Equal(protocol.ParameterEnabled, p.Value)
+	_, ok = value(upcoming, "smart_contract", "systemContracts")
+	require.False(ok)
+}
+
+type testFinalityReader uint64
+
+func (r testFinalityReader) FinalizedHeight() uint64 { return uint64(r) }
+
+func TestFinalizedHeight(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	cs := &coreService{bc: bc}
+	// rolldpos gives instant finality, the tip is final without a finality reader
+	bc.EXPECT().TipHeight().Return(uint64(5)).Times(1)
+	require.Equal(uint64(5), cs.FinalizedHeight())
+	WithFinalityReader(testFinalityReader(4))(cs)
+	require.Equal(uint64(4), cs.FinalizedHeight())
+}
+
+func TestEarliestStateHeight(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	sf := mock_factory.NewMockFactory(ctrl)
+	cs := &coreService{sf: sf}
+	sf.EXPECT().EarliestStateHeight().Return(uint64(0)).Times(1)
+	require.Zero(cs.EarliestStateHeight())
+	// the states below the height are pruned
+	sf.EXPECT().EarliestStateHeight().Return(uint64(3)).Times(1)
+	require.Equal(uint64(3), cs.EarliestStateHeight())
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"time"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// _backfillMethods are the unary methods reading a range of blocks, actions or logs
+var _backfillMethods = map[string]bool{
+	iotexapi.APIService_GetActions_FullMethodName:         true,
+	iotexapi.APIService_GetBlockMetas_FullMethodName:      true,
+	iotexapi.APIService_GetRawBlocks_FullMethodName:       true,
+	iotexapi.APIService_GetLogs_FullMethodName:            true,
+	iotexapi.APIService_GetElectionBuckets_FullMethodName: true,
+}
+
+// deadlineInterceptor applies the default deadlines to the grpc calls. The ctx of a call is canceled once the
+// client disconnects or the deadline is exceeded, which stops the work of coreservice reading with the ctx
+type deadlineInterceptor struct {
+	request  time.Duration
+	backfill time.Duration
+	stream   time.Duration
+}
+
+func newDeadlineInterceptor(cfg Config) *deadlineInterceptor {
+	return &deadlineInterceptor{
+		request:  cfg.GRPCRequestTimeout,
+		backfill: cfg.GRPCBackfillTimeout,
+		stream:   cfg.GRPCStreamTimeout,
+	}
+}
+
+// Unary applies the default deadline of the method to a unary call
+func (d *deadlineInterceptor) Unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	timeout := d.request
+	if _backfillMethods[info.FullMethod] {
+		timeout = d.backfill
+	}
+	ctx, cancel := withTimeout(ctx, timeout)
+	defer cancel()
+	resp, err := handler(ctx, req)
+	return resp, contextError(ctx, err)
+}
+
+// Stream applies the default deadline to a streaming call
+func (d *deadlineInterceptor) Stream(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, cancel := withTimeout(ss.Context(), d.stream)
+	defer cancel()
+	wrapped := grpc_middleware.WrapServerStream(ss)
+	wrapped.WrappedContext = ctx
+	return contextError(ctx, handler(srv, wrapped))
+}
+
+// withTimeout returns the ctx expiring after the timeout, or the earlier deadline of the client. A zero timeout
+// never expires
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// contextError returns the status of the ctx error if the call fails after the ctx is done, so an abandoned or
+// expired call is reported as canceled or deadline exceeded, rather than by the error of the aborted work
+func contextError(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+	return status.FromContextError(ctx.Err()).Err()
+}
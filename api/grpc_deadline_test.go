@@ -0,0 +1,85 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDeadlineInterceptor(t *testing.T) {
+	require := require.New(t)
+	cfg := DefaultConfig
+	cfg.GRPCRequestTimeout = time.Minute
+	cfg.GRPCBackfillTimeout = time.Hour
+	cfg.GRPCStreamTimeout = 0
+	d := newDeadlineInterceptor(cfg)
+
+	deadline := func(ctx context.Context, method string) time.Duration {
+		var timeout time.Duration
+		_, err := d.Unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+			dl, ok := ctx.Deadline()
+			require.True(ok)
+			timeout = time.Until(dl)
+			return nil, nil
+		})
+		require.NoError(err)
+		return timeout
+	}
+	t.Run("default deadlines", func(t *testing.T) {
+		require.InDelta(time.Minute, deadline(context.Background(), iotexapi.APIService_GetAccount_FullMethodName), float64(time.Second))
+		require.InDelta(time.Hour, deadline(context.Background(), iotexapi.APIService_GetLogs_FullMethodName), float64(time.Second))
+	})
+	t.Run("earlier deadline of client", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		require.LessOrEqual(deadline(ctx, iotexapi.APIService_GetLogs_FullMethodName), time.Second)
+	})
+	t.Run("error of aborted work", func(t *testing.T) {
+		info := &grpc.UnaryServerInfo{FullMethod: iotexapi.APIService_GetLogs_FullMethodName}
+		_, err := d.Unary(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+			return nil, errors.New("failed")
+		})
+		require.Equal(codes.Unknown, status.Code(err))
+		ctx, cancel := context.WithCancel(context.Background())
+		_, err = d.Unary(ctx, nil, info, func(context.Context, interface{}) (interface{}, error) {
+			cancel()
+			return nil, errors.New("failed")
+		})
+		require.Equal(codes.Canceled, status.Code(err))
+		ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+		_, err = d.Unary(ctx, nil, info, func(ctx context.Context, _ interface{}) (interface{}, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+		require.Equal(codes.DeadlineExceeded, status.Code(err))
+	})
+	t.Run("stream", func(t *testing.T) {
+		stream := &testLogsStream{ctx: context.Background()}
+		handler := func(_ interface{}, ss grpc.ServerStream) error {
+			_, ok := ss.Context().Deadline()
+			require.False(ok)
+			return nil
+		}
+		require.NoError(d.Stream(nil, stream, &grpc.StreamServerInfo{}, handler))
+		d.stream = time.Millisecond
+		handler = func(_ interface{}, ss grpc.ServerStream) error {
+			<-ss.Context().Done()
+			return status.Error(codes.Aborted, "aborted")
+		}
+		err := d.Stream(nil, stream, &grpc.StreamServerInfo{}, handler)
+		require.Equal(codes.DeadlineExceeded, status.Code(err))
+	})
+}
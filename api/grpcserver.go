@@ -13,7 +13,6 @@ import (
 	"math/big"
 	"net"
 	"strconv"
-	"time"
 
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
@@ -33,6 +32,7 @@ import (
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -41,9 +41,11 @@ import (
 	"github.com/iotexproject/iotex-core/api/logfilter"
 	apitypes "github.com/iotexproject/iotex-core/api/types"
 	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockindex"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/recovery"
 	"github.com/iotexproject/iotex-core/pkg/tracer"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
 )
 
 type (
@@ -59,19 +61,6 @@ type (
 	}
 )
 
-// TODO: move this into config
-var (
-	kaep = keepalive.EnforcementPolicy{
-		MinTime:             1 * time.Second, // If a client pings more than once every 1 seconds, terminate the connection
-		PermitWithoutStream: true,            // Allow pings even when there are no active streams
-	}
-	kasp = keepalive.ServerParameters{
-		Time:              60 * time.Second, // Ping the client if it is idle for 60 seconds to ensure the connection is still active
-		Timeout:           10 * time.Second, // Wait 10 seconds for the ping ack before assuming the connection is dead
-		MaxConnectionIdle: 5 * time.Minute,  // If a client is idle for 5 minutes, send a GOAWAY
-	}
-)
-
 // RecoveryInterceptor handles panic to a custom error
 func RecoveryInterceptor() grpc_recovery.Option {
 	return grpc_recovery.WithRecoveryHandler(func(p interface{}) (err error) {
@@ -81,33 +70,45 @@ func RecoveryInterceptor() grpc_recovery.Option {
 }
 
 // NewGRPCServer creates a new grpc server
-func NewGRPCServer(core CoreService, grpcPort int) *GRPCServer {
-	if grpcPort == 0 {
+func NewGRPCServer(core CoreService, cfg Config) *GRPCServer {
+	if cfg.GRPCPort == 0 {
 		return nil
 	}
 
+	deadline := newDeadlineInterceptor(cfg)
 	gSvr := grpc.NewServer(
 		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
 			grpc_prometheus.StreamServerInterceptor,
 			otelgrpc.StreamServerInterceptor(),
 			grpc_recovery.StreamServerInterceptor(RecoveryInterceptor()),
+			deadline.Stream,
 		)),
 		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
 			grpc_prometheus.UnaryServerInterceptor,
 			otelgrpc.UnaryServerInterceptor(),
 			grpc_recovery.UnaryServerInterceptor(RecoveryInterceptor()),
+			deadline.Unary,
 		)),
-		grpc.KeepaliveEnforcementPolicy(kaep),
-		grpc.KeepaliveParams(kasp),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             cfg.GRPCKeepaliveMinTime,
+			PermitWithoutStream: true, // Allow pings even when there are no active streams
+		}),
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:              cfg.GRPCKeepaliveTime,
+			Timeout:           cfg.GRPCKeepaliveTimeout,
+			MaxConnectionIdle: cfg.GRPCMaxConnectionIdle,
+		}),
 	)
 
 	//serviceName: grpc.health.v1.Health
 	grpc_health_v1.RegisterHealthServer(gSvr, health.NewServer())
 	iotexapi.RegisterAPIServiceServer(gSvr, newGRPCHandler(core))
+	// the latency of each method is recorded in the histogram grpc_server_handling_seconds
+	grpc_prometheus.EnableHandlingTimeHistogram()
 	grpc_prometheus.Register(gSvr)
 	reflection.Register(gSvr)
 	return &GRPCServer{
-		port: ":" + strconv.Itoa(grpcPort),
+		port: ":" + strconv.Itoa(cfg.GRPCPort),
 		svr:  gSvr,
 	}
 }
@@ -116,14 +117,14 @@ func NewGRPCServer(core CoreService, grpcPort int) *GRPCServer {
 func (grpc *GRPCServer) Start(_ context.Context) error {
 	lis, err := net.Listen("tcp", grpc.port)
 	if err != nil {
-		log.L().Error("grpc server failed to listen.", zap.Error(err))
+		log.Logger("api").Error("grpc server failed to listen.", zap.Error(err))
 		return errors.Wrap(err, "grpc server failed to listen")
 	}
-	log.L().Info("grpc server is listening.", zap.String("addr", lis.Addr().String()))
+	log.Logger("api").Info("grpc server is listening.", zap.String("addr", lis.Addr().String()))
 	go func() {
 		defer recovery.Recover()
 		if err := grpc.svr.Serve(lis); err != nil {
-			log.L().Fatal("grpc failed to serve.", zap.Error(err))
+			log.Logger("api").Fatal("grpc failed to serve.", zap.Error(err))
 		}
 	}()
 	return nil
@@ -154,7 +155,7 @@ func (svr *gRPCHandler) SuggestGasPrice(ctx context.Context, in *iotexapi.Sugges
 func (svr *gRPCHandler) GetAccount(ctx context.Context, in *iotexapi.GetAccountRequest) (*iotexapi.GetAccountResponse, error) {
 	span := tracer.SpanFromContext(ctx)
 	defer span.End()
-	addr, err := address.FromString(in.Address)
+	addr, err := addrutil.ParseAddress(in.Address)
 	if err != nil {
 		return nil, err
 	}
@@ -176,6 +177,10 @@ func (svr *gRPCHandler) GetActions(ctx context.Context, in *iotexapi.GetActionsR
 		ret []*iotexapi.ActionInfo
 		err error
 	)
+	excludeSystemActions, err := excludeSystemActionsFromMetadata(ctx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	switch {
 	case in.GetByIndex() != nil:
 		request := in.GetByIndex()
@@ -188,10 +193,22 @@ func (svr *gRPCHandler) GetActions(ctx context.Context, in *iotexapi.GetActionsR
 	case in.GetByAddr() != nil:
 		request := in.GetByAddr()
 		var addr address.Address
-		addr, err = address.FromString(request.Address)
+		addr, err = addrutil.ParseAddress(request.Address)
 		if err != nil {
 			return nil, err
 		}
+		var filter *blockindex.ActionFilter
+		filter, err = actionFilterFromMetadata(ctx)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if filter != nil {
+			ret, err = svr.coreService.ActionsByAddressWithFilter(addr, filter, request.Start, request.Count)
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
 		ret, err = svr.coreService.ActionsByAddress(addr, request.Start, request.Count)
 	case in.GetUnconfirmedByAddr() != nil:
 		request := in.GetUnconfirmedByAddr()
@@ -212,6 +229,10 @@ func (svr *gRPCHandler) GetActions(ctx context.Context, in *iotexapi.GetActionsR
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
+	if excludeSystemActions && in.GetByHash() == nil && in.GetUnconfirmedByAddr() == nil {
+		// the page is filtered after queried, so it could have less than the count of actions
+		ret = filterSystemActions(ret)
+	}
 	return &iotexapi.GetActionsResponse{
 		Total:      uint64(len(ret)),
 		ActionInfo: ret,
@@ -306,13 +327,13 @@ func (svr *gRPCHandler) GetChainMeta(ctx context.Context, in *iotexapi.GetChainM
 
 // GetServerMeta gets the server metadata
 func (svr *gRPCHandler) GetServerMeta(ctx context.Context, in *iotexapi.GetServerMetaRequest) (*iotexapi.GetServerMetaResponse, error) {
-	packageVersion, packageCommitID, gitStatus, goVersion, buildTime := svr.coreService.ServerMeta()
+	id := svr.coreService.NetworkIdentity()
 	return &iotexapi.GetServerMetaResponse{ServerMeta: &iotextypes.ServerMeta{
-		PackageVersion:  packageVersion,
-		PackageCommitID: packageCommitID,
-		GitStatus:       gitStatus,
-		GoVersion:       goVersion,
-		BuildTime:       buildTime,
+		PackageVersion:  id.PackageVersion,
+		PackageCommitID: id.PackageCommitID,
+		GitStatus:       id.GitStatus,
+		GoVersion:       id.GoVersion,
+		BuildTime:       id.BuildTime,
 	}}, nil
 }
 
@@ -322,11 +343,17 @@ func (svr *gRPCHandler) SendAction(ctx context.Context, in *iotexapi.SendActionR
 	// tags output
 	span.SetAttributes(attribute.String("actType", fmt.Sprintf("%T", in.GetAction().GetCore())))
 	defer span.End()
-	actHash, err := svr.coreService.SendAction(ctx, in.GetAction())
+	sent, err := svr.coreService.SendActionWithStatus(ctx, in.GetAction())
 	if err != nil {
 		return nil, err
 	}
-	return &iotexapi.SendActionResponse{ActionHash: actHash}, nil
+	// the action is accepted already, so failing to set the header does not fail the request
+	if grpc.ServerTransportStreamFromContext(ctx) != nil {
+		if err := grpc.SetHeader(ctx, sentActionMetadata(sent)); err != nil {
+			log.Logger("api").Warn("Failed to set the status of the action sent.", zap.Error(err))
+		}
+	}
+	return &iotexapi.SendActionResponse{ActionHash: hex.EncodeToString(sent.Hash[:])}, nil
 }
 
 // GetReceiptByAction gets receipt with corresponding action hash
@@ -346,7 +373,7 @@ func (svr *gRPCHandler) GetReceiptByAction(ctx context.Context, in *iotexapi.Get
 
 	return &iotexapi.GetReceiptByActionResponse{
 		ReceiptInfo: &iotexapi.ReceiptInfo{
-			Receipt: receipt.ConvertToReceiptPb(),
+			Receipt: receiptPbWithStatusMessage(receipt),
 			BlkHash: hex.EncodeToString(blkHash[:]),
 		},
 	}, nil
@@ -358,7 +385,7 @@ func (svr *gRPCHandler) ReadContract(ctx context.Context, in *iotexapi.ReadContr
 	if from == action.EmptyAddress {
 		from = address.ZeroAddress
 	}
-	callerAddr, err := address.FromString(from)
+	callerAddr, err := addrutil.ParseAddress(from)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
@@ -395,7 +422,7 @@ func (svr *gRPCHandler) EstimateGasForAction(ctx context.Context, in *iotexapi.E
 // EstimateActionGasConsumption estimate gas consume for action without signature
 func (svr *gRPCHandler) EstimateActionGasConsumption(ctx context.Context, in *iotexapi.EstimateActionGasConsumptionRequest) (*iotexapi.EstimateActionGasConsumptionResponse, error) {
 	if in.GetExecution() != nil {
-		callerAddr, err := address.FromString(in.GetCallerAddress())
+		callerAddr, err := addrutil.ParseAddress(in.GetCallerAddress())
 		if err != nil {
 			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
@@ -421,7 +448,7 @@ func (svr *gRPCHandler) EstimateActionGasConsumption(ctx context.Context, in *io
 		return &iotexapi.EstimateActionGasConsumptionResponse{Gas: ret}, nil
 	}
 	if in.GetStakeMigrate() != nil {
-		callerAddr, err := address.FromString(in.GetCallerAddress())
+		callerAddr, err := addrutil.ParseAddress(in.GetCallerAddress())
 		if err != nil {
 			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
@@ -524,6 +551,9 @@ func (svr *gRPCHandler) GetEpochMeta(ctx context.Context, in *iotexapi.GetEpochM
 func (svr *gRPCHandler) GetRawBlocks(ctx context.Context, in *iotexapi.GetRawBlocksRequest) (*iotexapi.GetRawBlocksResponse, error) {
 	ret, err := svr.coreService.RawBlocks(in.StartHeight, in.Count, in.WithReceipts, in.WithTransactionLogs)
 	if err != nil {
+		if errors.Cause(err) == ErrResultTooLarge {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
 		return nil, err
 	}
 	return &iotexapi.GetRawBlocksResponse{Blocks: ret}, nil
@@ -549,8 +579,35 @@ func (svr *gRPCHandler) GetLogs(ctx context.Context, in *iotexapi.GetLogsRequest
 		}
 	case in.GetByRange() != nil:
 		req := in.GetByRange()
-		logs, hashes, err := svr.coreService.LogsInRange(logfilter.NewLogFilter(in.GetFilter()), req.GetFromBlock(), req.GetToBlock(), req.GetPaginationSize())
+		pagination, err := logsPaginationFromMetadata(ctx)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if pagination != nil {
+			page, err := svr.coreService.LogsPage(ctx, logfilter.NewLogFilter(in.GetFilter()), req.GetFromBlock(), req.GetToBlock(), pagination.cursor, pagination.descending, req.GetPaginationSize())
+			if err != nil {
+				return nil, err
+			}
+			for i := range page.Logs {
+				ret = append(ret, toLogPb(page.Logs[i], page.BlockHashes[i]))
+			}
+			if page.Next != nil {
+				if err := grpc.SetHeader(ctx, metadata.Pairs(MetadataLogsNextCursor, formatLogCursor(page.Next))); err != nil {
+					return nil, status.Error(codes.Internal, err.Error())
+				}
+			}
+			break
+		}
+		logs, hashes, err := svr.coreService.LogsInRange(ctx, logfilter.NewLogFilter(in.GetFilter()), req.GetFromBlock(), req.GetToBlock(), req.GetPaginationSize())
 		if err != nil {
+			// the range of too many logs is paginated or streamed instead
+			if errors.Cause(err) == ErrResultTooLarge {
+				return nil, status.Error(codes.ResourceExhausted, err.Error())
+			}
+			// the logs shed under pressure are retryable
+			if _, ok := status.FromError(err); ok {
+				return nil, err
+			}
 			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
 		for i := range logs {
@@ -570,46 +627,75 @@ func toLogPb(lg *action.Log, blkHash hash.Hash256) *iotextypes.Log {
 
 // StreamBlocks streams blocks
 func (svr *gRPCHandler) StreamBlocks(_ *iotexapi.StreamBlocksRequest, stream iotexapi.APIService_StreamBlocksServer) error {
-	errChan := make(chan error)
-	defer close(errChan)
+	// the responder could exit after the stream is done, which is never waited, so the channel is buffered for the
+	// error of the last response and the exit
+	errChan := make(chan error, 2)
 	chainListener := svr.coreService.ChainListener()
-	if _, err := chainListener.AddResponder(NewGRPCBlockListener(
+	id, err := chainListener.AddResponder(NewGRPCBlockListener(
 		func(resp interface{}) (int, error) {
 			return 0, stream.Send(resp.(*iotexapi.StreamBlocksResponse))
 		},
 		errChan,
-	)); err != nil {
-		return status.Error(codes.Internal, err.Error())
-	}
-	err := <-errChan
+	))
 	if err != nil {
-		return status.Error(codes.Aborted, err.Error())
+		return status.Error(codes.Internal, err.Error())
 	}
-	return nil
+	return waitStream(stream.Context(), chainListener, id, errChan)
 }
 
-// StreamLogs streams logs that match the filter condition
+// StreamLogs streams logs that match the filter condition. If the range is in the incoming metadata, the logs in the
+// range are streamed instead, and the stream ends after the logs of the last block
 func (svr *gRPCHandler) StreamLogs(in *iotexapi.StreamLogsRequest, stream iotexapi.APIService_StreamLogsServer) error {
 	if in.GetFilter() == nil {
 		return status.Error(codes.InvalidArgument, "empty filter")
 	}
-	errChan := make(chan error)
-	defer close(errChan)
+	rng, err := logsRangeFromMetadata(stream.Context())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if rng != nil {
+		// each log is sent once the client has room for it by the flow control of the stream
+		return svr.coreService.StreamLogsInRange(stream.Context(), logfilter.NewLogFilter(in.GetFilter()), rng.from, rng.to, func(page *apitypes.LogsPage) error {
+			for i := range page.Logs {
+				if err := stream.Send(&iotexapi.StreamLogsResponse{Log: toLogPb(page.Logs[i], page.BlockHashes[i])}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	// the responder could exit after the stream is done, which is never waited, so the channel is buffered for the
+	// error of the last response and the exit
+	errChan := make(chan error, 2)
 	chainListener := svr.coreService.ChainListener()
-	if _, err := chainListener.AddResponder(NewGRPCLogListener(
+	id, err := chainListener.AddResponder(NewGRPCLogListener(
 		logfilter.NewLogFilter(in.GetFilter()),
 		func(in interface{}) (int, error) {
 			return 0, stream.Send(in.(*iotexapi.StreamLogsResponse))
 		},
 		errChan,
-	)); err != nil {
+	))
+	if err != nil {
 		return status.Error(codes.Internal, err.Error())
 	}
-	err := <-errChan
-	if err != nil {
-		return status.Error(codes.Aborted, err.Error())
+	return waitStream(stream.Context(), chainListener, id, errChan)
+}
+
+// waitStream waits until the responder of a stream exits, or the stream is done because the client disconnects or
+// the deadline is exceeded, in which case the responder is removed from the listener
+func waitStream(ctx context.Context, listener apitypes.Listener, id string, errChan chan error) error {
+	select {
+	case err := <-errChan:
+		if err != nil {
+			return status.Error(codes.Aborted, err.Error())
+		}
+		return nil
+	case <-ctx.Done():
+		if _, err := listener.RemoveResponder(id); err != nil {
+			log.Logger("api").Debug("Failed to remove the responder of the stream.", zap.Error(err))
+		}
+		return status.FromContextError(ctx.Err()).Err()
 	}
-	return nil
 }
 
 // GetElectionBuckets returns the native election buckets.
@@ -671,7 +757,7 @@ func (svr *gRPCHandler) GetActPoolActions(ctx context.Context, in *iotexapi.GetA
 
 // ReadContractStorage reads contract's storage
 func (svr *gRPCHandler) ReadContractStorage(ctx context.Context, in *iotexapi.ReadContractStorageRequest) (*iotexapi.ReadContractStorageResponse, error) {
-	addr, err := address.FromString(in.GetContract())
+	addr, err := addrutil.ParseAddress(in.GetContract())
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
@@ -774,3 +860,10 @@ func gasLimitAndUsed(acts []*action.SealedEnvelope, receipts []*action.Receipt)
 	}
 	return gasLimit, gasUsed
 }
+
+// receiptPbWithStatusMessage converts the receipt to protobuf along with the message of its status
+func receiptPbWithStatusMessage(receipt *action.Receipt) *iotextypes.Receipt {
+	receiptPb := receipt.ConvertToReceiptPb()
+	action.SetReceiptStatusMessage(receiptPb)
+	return receiptPb
+}
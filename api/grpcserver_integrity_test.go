@@ -12,6 +12,7 @@ import (
 	"math"
 	"math/big"
 	"regexp"
+	"runtime"
 	"strconv"
 	"testing"
 	"time"
@@ -1267,7 +1268,6 @@ func TestGrpcServer_GetChainMetaIntegrity(t *testing.T) {
 		if test.emptyChain {
 			mbc := mock_blockchain.NewMockBlockchain(ctrl)
 			mbc.EXPECT().TipHeight().Return(uint64(0)).Times(1)
-			mbc.EXPECT().ChainID().Return(uint32(1)).Times(1)
 			coreService, ok := svr.core.(*coreService)
 			require.True(ok)
 			// TODO: create a core service with empty chain to test
@@ -1448,7 +1448,7 @@ func TestGrpcServer_GetServerMetaIntegrity(t *testing.T) {
 	resProto, err := grpcHandler.GetServerMeta(context.Background(), &iotexapi.GetServerMetaRequest{})
 	res := resProto.GetServerMeta()
 	require.Equal(res.BuildTime, version.BuildTime)
-	require.Equal(res.GoVersion, version.GoVersion)
+	require.Equal(res.GoVersion, runtime.Version())
 	require.Equal(res.GitStatus, version.GitStatus)
 	require.Equal(res.PackageCommitID, version.PackageCommitID)
 	require.Equal(res.PackageVersion, version.PackageVersion)
@@ -2213,7 +2213,7 @@ func TestGrpcServer_GetRawBlocksIntegrity(t *testing.T) {
 			require.EqualValues(version.ProtocolVersion, header.Version)
 			require.Zero(header.Height)
 			ts := timestamppb.New(time.Unix(genesis.Timestamp(), 0))
-			require.Equal(ts, header.Timestamp)
+			require.True(proto.Equal(ts, header.Timestamp))
 			require.Equal(0, bytes.Compare(hash.ZeroHash256[:], header.PrevBlockHash))
 			require.Equal(0, bytes.Compare(hash.ZeroHash256[:], header.TxRoot))
 			require.Equal(0, bytes.Compare(hash.ZeroHash256[:], header.DeltaStateDigest))
@@ -2252,6 +2252,16 @@ func TestGrpcServer_GetRawBlocksIntegrity(t *testing.T) {
 	})
 	require.Error(err)
 
+	// failure: blocks exceed the response size limit
+	coreService, ok := svr.core.(*coreService)
+	require.True(ok)
+	coreService.cfg.ResponseSizeLimit = 1
+	_, err = grpcHandler.GetRawBlocks(context.Background(), &iotexapi.GetRawBlocksRequest{
+		StartHeight:  1,
+		Count:        1,
+		WithReceipts: true,
+	})
+	require.Equal(codes.ResourceExhausted, status.Code(err))
 }
 
 func TestGrpcServer_GetLogsIntegrity(t *testing.T) {
@@ -2558,14 +2568,6 @@ func TestChainlinkErrIntegrity(t *testing.T) {
 			[]*iotextypes.Action{_testTransferInvalid4Pb},
 			gethFatal,
 		},
-		{
-			"TransactionAlreadyInMempool",
-			func() testConfig {
-				return newConfig()
-			},
-			[]*iotextypes.Action{_testTransferPb, _testTransferPb},
-			regexp.MustCompile(`(: |^)(?i)(known transaction|already known)`),
-		},
 		{
 			"ReplacementTransactionUnderpriced",
 			func() testConfig {
@@ -2630,6 +2632,7 @@ func TestGrpcServer_TraceTransactionStructLogsIntegrity(t *testing.T) {
 	require := require.New(t)
 	cfg := newConfig()
 	cfg.api.GRPCPort = testutil.RandomPort()
+	cfg.chain.EnableArchiveMode = true
 	svr, bc, _, _, _, actPool, bfIndexFile, err := createServerV2(cfg, true)
 	require.NoError(err)
 	grpcHandler := newGRPCHandler(svr.core)
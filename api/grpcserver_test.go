@@ -10,6 +10,7 @@ import (
 	"encoding/hex"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/golang/mock/gomock"
@@ -18,14 +19,21 @@ import (
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/api/logfilter"
 	apitypes "github.com/iotexproject/iotex-core/api/types"
 	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockindex"
 	"github.com/iotexproject/iotex-core/pkg/version"
 	"github.com/iotexproject/iotex-core/test/identityset"
 	"github.com/iotexproject/iotex-core/test/mock/mock_apicoreservice"
 	mock_apitypes "github.com/iotexproject/iotex-core/test/mock/mock_apiresponder"
+	"github.com/iotexproject/iotex-core/test/mock/mock_apiserver"
 )
 
 func TestGrpcServer_GetAccount(t *testing.T) {
@@ -150,6 +158,77 @@ func TestGrpcServer_GetActions(t *testing.T) {
 		}
 	})
 
+	t.Run("get actions by address with filter", func(t *testing.T) {
+		request := &iotexapi.GetActionsRequest{
+			Lookup: &iotexapi.GetActionsRequest_ByAddr{
+				ByAddr: &iotexapi.GetActionsByAddressRequest{
+					Address: identityset.Address(27).String(),
+					Start:   1,
+					Count:   10,
+				},
+			},
+		}
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+			MetadataActionDirection, "sender,recipient",
+			MetadataActionType, "transfer",
+			MetadataActionType, "execution",
+			MetadataFromHeight, "3",
+			MetadataToHeight, "8",
+		))
+		core.EXPECT().ActionsByAddressWithFilter(identityset.Address(27), &blockindex.ActionFilter{
+			Direction:   blockindex.DirectionSender | blockindex.DirectionRecipient,
+			ActionTypes: []string{"transfer", "execution"},
+			FromHeight:  3,
+			ToHeight:    8,
+		}, uint64(1), uint64(10)).Return([]*iotexapi.ActionInfo{{ActHash: "test"}}, nil)
+		res, err := grpcSvr.GetActions(ctx, request)
+		require.NoError(err)
+		require.EqualValues(1, res.Total)
+
+		// the status of the core service is kept
+		core.EXPECT().ActionsByAddressWithFilter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.FailedPrecondition, "reindex"))
+		_, err = grpcSvr.GetActions(metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataFromHeight, "3")), request)
+		require.Equal(codes.FailedPrecondition, status.Code(err))
+
+		for _, md := range []metadata.MD{
+			metadata.Pairs(MetadataActionDirection, "unknown"),
+			metadata.Pairs(MetadataFromHeight, "x"),
+			metadata.Pairs(MetadataToHeight, "1", MetadataToHeight, "2"),
+		} {
+			_, err = grpcSvr.GetActions(metadata.NewIncomingContext(context.Background(), md), request)
+			require.Equal(codes.InvalidArgument, status.Code(err))
+		}
+	})
+
+	t.Run("get actions excluding system actions", func(t *testing.T) {
+		request := &iotexapi.GetActionsRequest{
+			Lookup: &iotexapi.GetActionsRequest_ByIndex{
+				ByIndex: &iotexapi.GetActionsByIndexRequest{Start: 0, Count: 3},
+			},
+		}
+		actions := func() []*iotexapi.ActionInfo {
+			return []*iotexapi.ActionInfo{
+				{ActHash: "transfer", Action: &iotextypes.Action{Core: &iotextypes.ActionCore{
+					Action: &iotextypes.ActionCore_Transfer{Transfer: &iotextypes.Transfer{}}}}},
+				{ActHash: "grantReward", Action: &iotextypes.Action{Core: &iotextypes.ActionCore{
+					Action: &iotextypes.ActionCore_GrantReward{GrantReward: &iotextypes.GrantReward{}}}}},
+				{ActHash: "putPollResult", Action: &iotextypes.Action{Core: &iotextypes.ActionCore{
+					Action: &iotextypes.ActionCore_PutPollResult{PutPollResult: &iotextypes.PutPollResult{}}}}},
+			}
+		}
+		core.EXPECT().Actions(uint64(0), uint64(3)).Return(actions(), nil).Times(2)
+		res, err := grpcSvr.GetActions(metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataSystemActions, "include")), request)
+		require.NoError(err)
+		require.EqualValues(3, res.Total)
+		res, err = grpcSvr.GetActions(metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataSystemActions, "exclude")), request)
+		require.NoError(err)
+		require.EqualValues(1, res.Total)
+		require.Equal("transfer", res.ActionInfo[0].ActHash)
+
+		_, err = grpcSvr.GetActions(metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataSystemActions, "unknown")), request)
+		require.Equal(codes.InvalidArgument, status.Code(err))
+	})
+
 	t.Run("get actions by hash", func(t *testing.T) {
 		for _, test := range _getActionTests {
 			response := &iotexapi.ActionInfo{
@@ -327,12 +406,42 @@ func TestGrpcServer_SendAction(t *testing.T) {
 	grpcSvr := newGRPCHandler(core)
 
 	for _, test := range _sendActionTests {
-		core.EXPECT().SendAction(context.Background(), test.actionPb).Return(test.actionHash, nil)
+		h, err := hash.HexStringToHash256(test.actionHash)
+		require.NoError(err)
+		core.EXPECT().SendActionWithStatus(context.Background(), test.actionPb).Return(&apitypes.SentAction{
+			Hash:   h,
+			Status: &apitypes.ActionWithStatus{Status: apitypes.ActionStatusPending},
+		}, nil)
 		request := &iotexapi.SendActionRequest{Action: test.actionPb}
 		res, err := grpcSvr.SendAction(context.Background(), request)
 		require.NoError(err)
 		require.Equal(test.actionHash, res.ActionHash)
 	}
+
+	t.Run("known action", func(t *testing.T) {
+		h, err := hash.HexStringToHash256(_sendActionTests[0].actionHash)
+		require.NoError(err)
+		blk, err := block.NewTestingBuilder().SetHeight(7).SignAndBuild(identityset.PrivateKey(0))
+		require.NoError(err)
+		core.EXPECT().SendActionWithStatus(gomock.Any(), _sendActionTests[0].actionPb).Return(&apitypes.SentAction{
+			Hash:  h,
+			Known: true,
+			Status: &apitypes.ActionWithStatus{
+				Status:  apitypes.ActionStatusConfirmed,
+				Block:   &blk,
+				Receipt: &action.Receipt{Status: uint64(iotextypes.ReceiptStatus_Success)},
+			},
+		}, nil)
+		stream := &testServerTransportStream{}
+		res, err := grpcSvr.SendAction(grpc.NewContextWithServerTransportStream(context.Background(), stream),
+			&iotexapi.SendActionRequest{Action: _sendActionTests[0].actionPb})
+		require.NoError(err)
+		require.Equal(_sendActionTests[0].actionHash, res.ActionHash)
+		require.Equal([]string{apitypes.ActionStatusConfirmed}, stream.header.Get(MetadataActionStatus))
+		require.Equal([]string{"true"}, stream.header.Get(MetadataActionKnown))
+		require.Equal([]string{"7"}, stream.header.Get(MetadataActionBlockHeight))
+		require.Equal([]string{"1"}, stream.header.Get(MetadataActionReceiptStatus))
+	})
 }
 
 func TestGrpcServer_StreamBlocks(t *testing.T) {
@@ -359,9 +468,34 @@ func TestGrpcServer_StreamBlocks(t *testing.T) {
 			return "", nil
 		})
 		core.EXPECT().ChainListener().Return(listener)
-		err := grpcSvr.StreamBlocks(&iotexapi.StreamBlocksRequest{}, nil)
+		stream := mock_apiserver.NewMockStreamBlocksServer(ctrl)
+		stream.EXPECT().Context().Return(context.Background()).AnyTimes()
+		err := grpcSvr.StreamBlocks(&iotexapi.StreamBlocksRequest{}, stream)
 		require.NoError(err)
 	})
+
+	t.Run("client disconnects", func(t *testing.T) {
+		var (
+			ctx, cancel = context.WithCancel(context.Background())
+			responder   *gRPCBlockListener
+		)
+		listener := mock_apitypes.NewMockListener(ctrl)
+		listener.EXPECT().AddResponder(gomock.Any()).DoAndReturn(func(g *gRPCBlockListener) (string, error) {
+			responder = g
+			cancel()
+			return "1", nil
+		})
+		// the responder exits once removed, which mustn't block
+		listener.EXPECT().RemoveResponder("1").DoAndReturn(func(string) (bool, error) {
+			responder.Exit()
+			return true, nil
+		}).Times(1)
+		core.EXPECT().ChainListener().Return(listener)
+		stream := mock_apiserver.NewMockStreamBlocksServer(ctrl)
+		stream.EXPECT().Context().Return(ctx).AnyTimes()
+		err := grpcSvr.StreamBlocks(&iotexapi.StreamBlocksRequest{}, stream)
+		require.Equal(codes.Canceled, status.Code(err))
+	})
 }
 
 func TestGrpcServer_StreamLogs(t *testing.T) {
@@ -379,7 +513,7 @@ func TestGrpcServer_StreamLogs(t *testing.T) {
 		listener := mock_apitypes.NewMockListener(ctrl)
 		listener.EXPECT().AddResponder(gomock.Any()).Return("", errors.New("mock test"))
 		core.EXPECT().ChainListener().Return(listener)
-		err := grpcSvr.StreamLogs(&iotexapi.StreamLogsRequest{Filter: &iotexapi.LogsFilter{}}, nil)
+		err := grpcSvr.StreamLogs(&iotexapi.StreamLogsRequest{Filter: &iotexapi.LogsFilter{}}, &testLogsStream{ctx: context.Background()})
 		require.Contains(err.Error(), "mock test")
 	})
 	t.Run("StreamLogsSuccess", func(t *testing.T) {
@@ -391,9 +525,78 @@ func TestGrpcServer_StreamLogs(t *testing.T) {
 			return "", nil
 		})
 		core.EXPECT().ChainListener().Return(listener)
-		err := grpcSvr.StreamLogs(&iotexapi.StreamLogsRequest{Filter: &iotexapi.LogsFilter{}}, nil)
+		err := grpcSvr.StreamLogs(&iotexapi.StreamLogsRequest{Filter: &iotexapi.LogsFilter{}}, &testLogsStream{ctx: context.Background()})
 		require.NoError(err)
 	})
+	t.Run("StreamLogsDeadlineExceeded", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+		listener := mock_apitypes.NewMockListener(ctrl)
+		listener.EXPECT().AddResponder(gomock.Any()).Return("1", nil)
+		listener.EXPECT().RemoveResponder("1").Return(true, nil).Times(1)
+		core.EXPECT().ChainListener().Return(listener)
+		err := grpcSvr.StreamLogs(&iotexapi.StreamLogsRequest{Filter: &iotexapi.LogsFilter{}}, &testLogsStream{ctx: ctx})
+		require.Equal(codes.DeadlineExceeded, status.Code(err))
+	})
+	t.Run("StreamLogsInRange", func(t *testing.T) {
+		var (
+			logs = []*action.Log{
+				{Address: identityset.Address(0).String(), BlockHeight: 3},
+				{Address: identityset.Address(1).String(), BlockHeight: 5},
+				{Address: identityset.Address(2).String(), BlockHeight: 8},
+			}
+			hashes = []hash.Hash256{hash.Hash256b([]byte("3")), hash.Hash256b([]byte("5")), hash.Hash256b([]byte("8"))}
+			stream = &testLogsStream{ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+				MetadataLogsFromBlock, "2",
+				MetadataLogsToBlock, "9",
+			))}
+		)
+		core.EXPECT().StreamLogsInRange(gomock.Any(), gomock.Any(), uint64(2), uint64(9), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ *logfilter.LogFilter, _, _ uint64, send func(*apitypes.LogsPage) error) error {
+				if err := send(&apitypes.LogsPage{Logs: logs[:2], BlockHashes: hashes[:2]}); err != nil {
+					return err
+				}
+				return send(&apitypes.LogsPage{Logs: logs[2:], BlockHashes: hashes[2:]})
+			})
+		require.NoError(grpcSvr.StreamLogs(&iotexapi.StreamLogsRequest{Filter: &iotexapi.LogsFilter{}}, stream))
+		require.Len(stream.sent, 3)
+		for i, resp := range stream.sent {
+			require.Equal(logs[i].BlockHeight, resp.Log.BlkHeight)
+			require.Equal(logs[i].Address, resp.Log.ContractAddress)
+			require.Equal(hashes[i][:], resp.Log.BlkHash)
+		}
+
+		// the range ends at the tip if the last block is absent
+		core.EXPECT().StreamLogsInRange(gomock.Any(), gomock.Any(), uint64(2), uint64(0), gomock.Any()).Return(nil)
+		require.NoError(grpcSvr.StreamLogs(&iotexapi.StreamLogsRequest{Filter: &iotexapi.LogsFilter{}}, &testLogsStream{
+			ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataLogsFromBlock, "2")),
+		}))
+
+		for _, md := range []metadata.MD{
+			metadata.Pairs(MetadataLogsToBlock, "9"),
+			metadata.Pairs(MetadataLogsFromBlock, "0"),
+			metadata.Pairs(MetadataLogsFromBlock, "x"),
+			metadata.Pairs(MetadataLogsFromBlock, "9", MetadataLogsToBlock, "2"),
+			metadata.Pairs(MetadataLogsFromBlock, "1", MetadataLogsFromBlock, "2"),
+		} {
+			err := grpcSvr.StreamLogs(&iotexapi.StreamLogsRequest{Filter: &iotexapi.LogsFilter{}}, &testLogsStream{ctx: metadata.NewIncomingContext(context.Background(), md)})
+			require.Equal(codes.InvalidArgument, status.Code(err))
+		}
+	})
+}
+
+// testLogsStream is a logs stream of the ctx, which records the responses sent
+type testLogsStream struct {
+	iotexapi.APIService_StreamLogsServer
+	ctx  context.Context
+	sent []*iotexapi.StreamLogsResponse
+}
+
+func (s *testLogsStream) Context() context.Context { return s.ctx }
+
+func (s *testLogsStream) Send(resp *iotexapi.StreamLogsResponse) error {
+	s.sent = append(s.sent, resp)
+	return nil
 }
 
 func TestGrpcServer_GetReceiptByAction(t *testing.T) {
@@ -451,7 +654,13 @@ func TestGrpcServer_GetServerMeta(t *testing.T) {
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
 	grpcSvr := newGRPCHandler(core)
 
-	core.EXPECT().ServerMeta().Return("packageVersion", "packageCommitID", "gitStatus", "goVersion", "buildTime")
+	core.EXPECT().NetworkIdentity().Return(&apitypes.NetworkIdentity{
+		PackageVersion:  "packageVersion",
+		PackageCommitID: "packageCommitID",
+		GitStatus:       "gitStatus",
+		GoVersion:       "goVersion",
+		BuildTime:       "buildTime",
+	})
 	res, err := grpcSvr.GetServerMeta(context.Background(), &iotexapi.GetServerMetaRequest{})
 	require.NoError(err)
 	require.Equal("packageVersion", res.ServerMeta.PackageVersion)
@@ -879,7 +1088,7 @@ func TestGrpcServer_GetLogs(t *testing.T) {
 			hash.BytesToHash256([]byte("02ae2a956d21e8d481c3a69e146633470cf625ec")),
 			hash.BytesToHash256([]byte("956d21e8d481c3a6901fc246633470cf62ae2ae1")),
 		}
-		core.EXPECT().LogsInRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(logs, hashes, nil)
+		core.EXPECT().LogsInRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(logs, hashes, nil)
 		request.Lookup = &iotexapi.GetLogsRequest_ByRange{
 			ByRange: &iotexapi.GetLogsByRange{
 				FromBlock: 1,
@@ -902,8 +1111,92 @@ func TestGrpcServer_GetLogs(t *testing.T) {
 		require.Equal(logs[1].Topics[0][:], res.Logs[1].Topics[0])
 		require.Equal(logs[1].Topics[1][:], res.Logs[1].Topics[1])
 	})
+
+	t.Run("by range with pagination", func(t *testing.T) {
+		hashes := []hash.Hash256{
+			hash.BytesToHash256([]byte("02ae2a956d21e8d481c3a69e146633470cf625ec")),
+			hash.BytesToHash256([]byte("956d21e8d481c3a6901fc246633470cf62ae2ae1")),
+		}
+		request.Lookup = &iotexapi.GetLogsRequest_ByRange{
+			ByRange: &iotexapi.GetLogsByRange{
+				FromBlock:      1,
+				ToBlock:        100,
+				PaginationSize: 2,
+			},
+		}
+		core.EXPECT().LogsPage(gomock.Any(), gomock.Any(), uint64(1), uint64(100), &apitypes.LogCursor{BlockHeight: 3, ActionIndex: 1, LogIndex: 2}, true, uint64(2)).Return(&apitypes.LogsPage{
+			Logs:        []*action.Log{logs[1], logs[0]},
+			BlockHashes: []hash.Hash256{hashes[1], hashes[0]},
+			Next:        &apitypes.LogCursor{BlockHeight: 1, ActionIndex: 0, LogIndex: 4},
+		}, nil)
+		var (
+			stream = &testServerTransportStream{}
+			ctx    = grpc.NewContextWithServerTransportStream(metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+				MetadataLogsOrder, "descending",
+				MetadataLogsCursor, "3.1.2",
+			)), stream)
+		)
+		res, err := grpcSvr.GetLogs(ctx, request)
+		require.NoError(err)
+		require.Len(res.Logs, 2)
+		require.Equal(hashes[1][:], res.Logs[0].BlkHash)
+		require.Equal(logs[1].BlockHeight, res.Logs[0].BlkHeight)
+		require.Equal(hashes[0][:], res.Logs[1].BlkHash)
+		require.Equal(logs[0].BlockHeight, res.Logs[1].BlkHeight)
+		require.Equal([]string{"1.0.4"}, stream.header.Get(MetadataLogsNextCursor))
+
+		// no cursor of the next page if no log is left
+		core.EXPECT().LogsPage(gomock.Any(), gomock.Any(), uint64(1), uint64(100), nil, false, uint64(2)).Return(&apitypes.LogsPage{}, nil)
+		stream = &testServerTransportStream{}
+		ctx = grpc.NewContextWithServerTransportStream(metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+			MetadataLogsOrder, "ascending",
+		)), stream)
+		res, err = grpcSvr.GetLogs(ctx, request)
+		require.NoError(err)
+		require.Empty(res.Logs)
+		require.Empty(stream.header.Get(MetadataLogsNextCursor))
+
+		for _, md := range []metadata.MD{
+			metadata.Pairs(MetadataLogsOrder, "random"),
+			metadata.Pairs(MetadataLogsCursor, "3.1"),
+			metadata.Pairs(MetadataLogsCursor, "3.x.1"),
+			metadata.Pairs(MetadataLogsCursor, "1.0.0", MetadataLogsCursor, "2.0.0"),
+		} {
+			_, err = grpcSvr.GetLogs(metadata.NewIncomingContext(context.Background(), md), request)
+			require.Equal(codes.InvalidArgument, status.Code(err))
+		}
+	})
+
+	t.Run("by range too large", func(t *testing.T) {
+		core.EXPECT().LogsInRange(gomock.Any(), gomock.Any(), uint64(1), uint64(100), gomock.Any()).Return(nil, nil, errors.Wrap(ErrResultTooLarge, "logs in [1, 100] exceed 1 bytes"))
+		request.Lookup = &iotexapi.GetLogsRequest_ByRange{
+			ByRange: &iotexapi.GetLogsByRange{
+				FromBlock: 1,
+				ToBlock:   100,
+			},
+		}
+		_, err := grpcSvr.GetLogs(context.Background(), request)
+		require.Equal(codes.ResourceExhausted, status.Code(err))
+		require.Contains(err.Error(), ErrResultTooLarge.Error())
+	})
 }
 
+// testServerTransportStream records the header set by the handler
+type testServerTransportStream struct {
+	header metadata.MD
+}
+
+func (s *testServerTransportStream) Method() string { return "" }
+
+func (s *testServerTransportStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *testServerTransportStream) SendHeader(md metadata.MD) error { return s.SetHeader(md) }
+
+func (s *testServerTransportStream) SetTrailer(metadata.MD) error { return nil }
+
 func TestGrpcServer_GetElectionBuckets(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
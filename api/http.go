@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"net"
 	"net/http"
 	"strconv"
 	"time"
@@ -28,13 +29,18 @@ type (
 
 // NewHTTPServer creates a new http server
 func NewHTTPServer(route string, port int, handler http.Handler) *HTTPServer {
+	return newHTTPServerOnHost("", route, port, handler)
+}
+
+// newHTTPServerOnHost creates a new http server listening on the interface of host, all the interfaces if empty
+func newHTTPServerOnHost(host, route string, port int, handler http.Handler) *HTTPServer {
 	if port == 0 {
 		return nil
 	}
 	mux := http.NewServeMux()
 	mux.Handle("/"+route, handler)
 
-	svr := httputil.NewServer(":"+strconv.Itoa(port), mux, httputil.ReadHeaderTimeout(10*time.Second))
+	svr := httputil.NewServer(net.JoinHostPort(host, strconv.Itoa(port)), mux, httputil.ReadHeaderTimeout(10*time.Second))
 	return &HTTPServer{
 		svr: &svr,
 	}
@@ -44,7 +50,7 @@ func NewHTTPServer(route string, port int, handler http.Handler) *HTTPServer {
 func (hSvr *HTTPServer) Start(_ context.Context) error {
 	go func() {
 		if err := hSvr.svr.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.L().Fatal("Node failed to serve.", zap.Error(err))
+			log.Logger("api").Fatal("Node failed to serve.", zap.Error(err))
 		}
 	}()
 	return nil
@@ -56,7 +56,7 @@ func (cl *chainListener) Stop() error {
 	cl.streamMap.Range(func(_, value interface{}) error {
 		r, ok := value.(apitypes.Responder)
 		if !ok {
-			log.L().Error("streamMap stores a value which is not a Responder")
+			log.Logger("api").Error("streamMap stores a value which is not a Responder")
 			return errorUnsupportedType
 		}
 		r.Exit()
@@ -72,12 +72,12 @@ func (cl *chainListener) ReceiveBlock(blk *block.Block) error {
 	cl.streamMap.Range(func(key, value interface{}) error {
 		r, ok := value.(apitypes.Responder)
 		if !ok {
-			log.L().Error("streamMap stores a value which is not a Responder")
+			log.Logger("api").Error("streamMap stores a value which is not a Responder")
 			return errorUnsupportedType
 		}
 		err := r.Respond(key.(string), blk)
 		if err != nil {
-			log.L().Error("responder failed to process block", zap.Error(err))
+			log.Logger("api").Error("responder failed to process block", zap.Error(err))
 		}
 		return err
 	})
@@ -118,7 +118,7 @@ func (cl *chainListener) RemoveResponder(listenerID string) (bool, error) {
 	}
 	r, ok := value.(apitypes.Responder)
 	if !ok {
-		log.L().Error("streamMap stores a value which is not a Responder")
+		log.Logger("api").Error("streamMap stores a value which is not a Responder")
 		return false, errListenerNotFound
 	}
 	r.Exit()
@@ -0,0 +1,150 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// the priorities of the requests handled by coreservice
+const (
+	// PriorityReserved is the priority of the write path and the reads supporting consensus, which run within the
+	// slots reserved for them, so they are never queued behind the heavy reads
+	PriorityReserved RequestPriority = iota
+	// PriorityHeavy is the priority of the heavy reads, i.e. logs, traces and simulations, which run in a separate
+	// bounded pool, and are shed while the node is under pressure
+	PriorityHeavy
+)
+
+var _loadShedderMtc = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "iotex_api_load_shedder",
+	Help: "api load shedder metrics.",
+}, []string{"priority", "result"})
+
+func init() {
+	prometheus.MustRegister(_loadShedderMtc)
+}
+
+type (
+	// RequestPriority is the priority of a request handled by coreservice
+	RequestPriority uint8
+
+	// LoadShedder admits the requests by their priorities. The pressure of the node is signaled by the latency of
+	// committing blocks, i.e. the time from the timestamp of a block to its commit on this node, which grows once
+	// the node is too busy to keep up with the chain
+	LoadShedder struct {
+		reserved  chan struct{}
+		heavy     chan struct{}
+		threshold time.Duration
+		// maintenance rejects the heavy reads while enabled, nil if never
+		maintenance *Maintenance
+		// commitLatency is the latency in nanoseconds of committing the last block
+		commitLatency atomic.Int64
+	}
+)
+
+// String returns the name of the priority
+func (p RequestPriority) String() string {
+	switch p {
+	case PriorityReserved:
+		return "reserved"
+	case PriorityHeavy:
+		return "heavy"
+	default:
+		return "unknown"
+	}
+}
+
+// NewLoadShedder returns a load shedder with the slots reserved for the write path and the reads supporting
+// consensus, and the slots of the heavy reads, which are shed once the latency of committing blocks exceeds the
+// threshold. Zero slots are unlimited, and a zero threshold never sheds
+func NewLoadShedder(reservedSlots, heavySlots int, threshold time.Duration) *LoadShedder {
+	ls := &LoadShedder{threshold: threshold}
+	if reservedSlots > 0 {
+		ls.reserved = make(chan struct{}, reservedSlots)
+	}
+	if heavySlots > 0 {
+		ls.heavy = make(chan struct{}, heavySlots)
+	}
+	return ls
+}
+
+// ObserveCommit records the latency of committing the block of the timestamp at the time
+func (ls *LoadShedder) ObserveCommit(blkTime, commitTime time.Time) {
+	if ls == nil {
+		return
+	}
+	latency := commitTime.Sub(blkTime)
+	if latency < 0 {
+		latency = 0
+	}
+	ls.commitLatency.Store(int64(latency))
+}
+
+// CommitLatency returns the latency of committing the last block
+func (ls *LoadShedder) CommitLatency() time.Duration {
+	if ls == nil {
+		return 0
+	}
+	return time.Duration(ls.commitLatency.Load())
+}
+
+// UnderPressure returns true if the latency of committing the last block exceeds the threshold
+func (ls *LoadShedder) UnderPressure() bool {
+	return ls != nil && ls.threshold > 0 && ls.CommitLatency() > ls.threshold
+}
+
+// Admit admits a request of the priority, and returns the function to release its slot once done. A reserved
+// request waits for a slot until the context is done, and a heavy request is rejected with a retryable error if
+// the node is in maintenance or under pressure, or all the slots of the heavy reads are taken. A nil load shedder
+// admits all requests
+func (ls *LoadShedder) Admit(ctx context.Context, priority RequestPriority) (func(), error) {
+	if ls == nil {
+		return func() {}, nil
+	}
+	switch priority {
+	case PriorityReserved:
+		if ls.reserved == nil {
+			return func() {}, nil
+		}
+		select {
+		case ls.reserved <- struct{}{}:
+		case <-ctx.Done():
+			_loadShedderMtc.WithLabelValues(priority.String(), "canceled").Inc()
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+		_loadShedderMtc.WithLabelValues(priority.String(), "admitted").Inc()
+		return func() { <-ls.reserved }, nil
+	case PriorityHeavy:
+		if err := ls.maintenance.Err(); err != nil {
+			_loadShedderMtc.WithLabelValues(priority.String(), "maintenance").Inc()
+			return nil, err
+		}
+		if ls.UnderPressure() {
+			_loadShedderMtc.WithLabelValues(priority.String(), "shed").Inc()
+			return nil, status.Errorf(codes.Unavailable, "node is under pressure with block commit latency %s, retry later", ls.CommitLatency())
+		}
+		if ls.heavy == nil {
+			return func() {}, nil
+		}
+		select {
+		case ls.heavy <- struct{}{}:
+		default:
+			_loadShedderMtc.WithLabelValues(priority.String(), "exhausted").Inc()
+			return nil, status.Error(codes.ResourceExhausted, "too many heavy reads in progress, retry later")
+		}
+		_loadShedderMtc.WithLabelValues(priority.String(), "admitted").Inc()
+		return func() { <-ls.heavy }, nil
+	default:
+		return func() {}, nil
+	}
+}
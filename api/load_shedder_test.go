@@ -0,0 +1,111 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoadShedder(t *testing.T) {
+	r := require.New(t)
+
+	t.Run("Pressure", func(t *testing.T) {
+		ls := NewLoadShedder(0, 0, 10*time.Second)
+		now := time.Now()
+		ls.ObserveCommit(now.Add(-2*time.Second), now)
+		r.Equal(2*time.Second, ls.CommitLatency())
+		r.False(ls.UnderPressure())
+		release, err := ls.Admit(context.Background(), PriorityHeavy)
+		r.NoError(err)
+		release()
+
+		// heavy reads are shed once blocks are committed late, and admitted again once the commits catch up
+		ls.ObserveCommit(now.Add(-time.Minute), now)
+		r.True(ls.UnderPressure())
+		_, err = ls.Admit(context.Background(), PriorityHeavy)
+		r.Equal(codes.Unavailable, status.Code(err))
+		release, err = ls.Admit(context.Background(), PriorityReserved)
+		r.NoError(err)
+		release()
+		ls.ObserveCommit(now.Add(time.Second), now)
+		r.Zero(ls.CommitLatency())
+		r.False(ls.UnderPressure())
+		release, err = ls.Admit(context.Background(), PriorityHeavy)
+		r.NoError(err)
+		release()
+
+		// a zero threshold never sheds
+		ls = NewLoadShedder(0, 0, 0)
+		ls.ObserveCommit(now.Add(-time.Hour), now)
+		r.False(ls.UnderPressure())
+	})
+
+	t.Run("Slots", func(t *testing.T) {
+		ls := NewLoadShedder(1, 1, 0)
+		heavy, err := ls.Admit(context.Background(), PriorityHeavy)
+		r.NoError(err)
+		_, err = ls.Admit(context.Background(), PriorityHeavy)
+		r.Equal(codes.ResourceExhausted, status.Code(err))
+
+		// the reserved slots are not taken by the heavy reads
+		reserved, err := ls.Admit(context.Background(), PriorityReserved)
+		r.NoError(err)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = ls.Admit(ctx, PriorityReserved)
+		r.Equal(codes.DeadlineExceeded, status.Code(err))
+
+		// a reserved request waits for a slot
+		done := make(chan error)
+		go func() {
+			release, err := ls.Admit(context.Background(), PriorityReserved)
+			if err == nil {
+				release()
+			}
+			done <- err
+		}()
+		reserved()
+		r.NoError(<-done)
+		heavy()
+		heavy, err = ls.Admit(context.Background(), PriorityHeavy)
+		r.NoError(err)
+		heavy()
+	})
+
+	t.Run("Maintenance", func(t *testing.T) {
+		m, err := NewMaintenance("")
+		r.NoError(err)
+		ls := NewLoadShedder(0, 0, 0)
+		ls.maintenance = m
+		r.NoError(m.Enable(time.Minute, 0))
+		_, err = ls.Admit(context.Background(), PriorityHeavy)
+		r.Equal(codes.Unavailable, status.Code(err))
+
+		// the write path is admitted in the maintenance mode
+		release, err := ls.Admit(context.Background(), PriorityReserved)
+		r.NoError(err)
+		release()
+		r.NoError(m.Disable())
+		release, err = ls.Admit(context.Background(), PriorityHeavy)
+		r.NoError(err)
+		release()
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		var ls *LoadShedder
+		ls.ObserveCommit(time.Now().Add(-time.Hour), time.Now())
+		r.False(ls.UnderPressure())
+		release, err := ls.Admit(context.Background(), PriorityHeavy)
+		r.NoError(err)
+		release()
+	})
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/metadata"
+
+	apitypes "github.com/iotexproject/iotex-core/api/types"
+)
+
+// the metadata keys of the pagination of GetLogs by range, until GetLogsByRange carries the cursor and the order
+const (
+	// MetadataLogsOrder is "ascending" or "descending" the logs, which turns on the pagination
+	MetadataLogsOrder = "x-iotex-logs-order"
+	// MetadataLogsCursor is the cursor of the page to continue from, which turns on the pagination
+	MetadataLogsCursor = "x-iotex-logs-cursor"
+	// MetadataLogsNextCursor is the cursor of the next page in the header of the response, which is absent if no log
+	// is left in the range
+	MetadataLogsNextCursor = "x-iotex-logs-next-cursor"
+)
+
+// the metadata keys of the range of StreamLogs, until StreamLogsRequest carries the range
+const (
+	// MetadataLogsFromBlock is the first block of the range, which turns StreamLogs into streaming the logs in the
+	// range instead of the logs in the new blocks
+	MetadataLogsFromBlock = "x-iotex-logs-from-block"
+	// MetadataLogsToBlock is the last block of the range, which is the tip if absent
+	MetadataLogsToBlock = "x-iotex-logs-to-block"
+)
+
+// logsPagination is the pagination of GetLogs by range in the incoming metadata
+type logsPagination struct {
+	cursor     *apitypes.LogCursor
+	descending bool
+}
+
+// logsPaginationFromMetadata returns the pagination of the logs in the incoming metadata, or nil if not paginated
+func logsPaginationFromMetadata(ctx context.Context) (*logsPagination, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	var (
+		orders  = md.Get(MetadataLogsOrder)
+		cursors = md.Get(MetadataLogsCursor)
+		p       = &logsPagination{}
+		err     error
+	)
+	if len(orders) == 0 && len(cursors) == 0 {
+		return nil, nil
+	}
+	switch len(orders) {
+	case 0:
+	case 1:
+		switch strings.TrimSpace(orders[0]) {
+		case "", "ascending":
+		case "descending":
+			p.descending = true
+		default:
+			return nil, errors.Errorf("invalid %s %s", MetadataLogsOrder, orders[0])
+		}
+	default:
+		return nil, errors.Errorf("more than one %s", MetadataLogsOrder)
+	}
+	switch len(cursors) {
+	case 0:
+	case 1:
+		if p.cursor, err = parseLogCursor(strings.TrimSpace(cursors[0])); err != nil {
+			return nil, errors.Wrapf(err, "invalid %s", MetadataLogsCursor)
+		}
+	default:
+		return nil, errors.Errorf("more than one %s", MetadataLogsCursor)
+	}
+	return p, nil
+}
+
+// logsRange is the range of StreamLogs in the incoming metadata
+type logsRange struct {
+	from, to uint64
+}
+
+// logsRangeFromMetadata returns the range of the logs in the incoming metadata, or nil if the range is absent
+func logsRangeFromMetadata(ctx context.Context) (*logsRange, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	var (
+		froms = md.Get(MetadataLogsFromBlock)
+		tos   = md.Get(MetadataLogsToBlock)
+		r     = &logsRange{}
+		err   error
+	)
+	switch len(froms) {
+	case 0:
+		if len(tos) > 0 {
+			return nil, errors.Errorf("%s without %s", MetadataLogsToBlock, MetadataLogsFromBlock)
+		}
+		return nil, nil
+	case 1:
+		if r.from, err = strconv.ParseUint(strings.TrimSpace(froms[0]), 10, 64); err != nil || r.from == 0 {
+			return nil, errors.Errorf("invalid %s %s", MetadataLogsFromBlock, froms[0])
+		}
+	default:
+		return nil, errors.Errorf("more than one %s", MetadataLogsFromBlock)
+	}
+	switch len(tos) {
+	case 0:
+	case 1:
+		if r.to, err = strconv.ParseUint(strings.TrimSpace(tos[0]), 10, 64); err != nil || r.to < r.from {
+			return nil, errors.Errorf("invalid %s %s", MetadataLogsToBlock, tos[0])
+		}
+	default:
+		return nil, errors.Errorf("more than one %s", MetadataLogsToBlock)
+	}
+	return r, nil
+}
+
+// parseLogCursor parses the cursor in the format of "blockHeight.actionIndex.logIndex"
+func parseLogCursor(s string) (*apitypes.LogCursor, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return nil, errors.Errorf("cursor %s is not in the format of blockHeight.actionIndex.logIndex", s)
+	}
+	height, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	actIndex, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	logIndex, err := strconv.ParseUint(parts[2], 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	return &apitypes.LogCursor{
+		BlockHeight: height,
+		ActionIndex: uint32(actIndex),
+		LogIndex:    uint32(logIndex),
+	}, nil
+}
+
+// formatLogCursor formats the cursor in the format of "blockHeight.actionIndex.logIndex"
+func formatLogCursor(c *apitypes.LogCursor) string {
+	return fmt.Sprintf("%d.%d.%d", c.BlockHeight, c.ActionIndex, c.LogIndex)
+}
@@ -4,10 +4,12 @@ import (
 	"bytes"
 
 	"github.com/iotexproject/go-pkgs/bloom"
+	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-proto/golang/iotexapi"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 )
 
@@ -52,6 +54,11 @@ func (l *LogFilter) MatchLogs(receipts []*action.Receipt) []*action.Log {
 	return res
 }
 
+// MatchLog checks if a given log matches the filter
+func (l *LogFilter) MatchLog(log *action.Log) bool {
+	return l.match(log.ConvertToLogPb())
+}
+
 // match checks if a given log matches the filter
 // TODO: replace iotextypes.Log with action.log
 func (l *LogFilter) match(log *iotextypes.Log) bool {
@@ -94,6 +101,62 @@ func (l *LogFilter) match(log *iotextypes.Log) bool {
 	return true
 }
 
+// MatchLogDigest returns the indices of the receipts in the log digest of a block whose logs may match the filter,
+// only the receipts at the indices need to be read to match the logs. A log matching the filter always matches its
+// digest, and the other way round unless the fingerprints collide
+func (l *LogFilter) MatchLogDigest(d *block.LogDigest) []uint32 {
+	var (
+		addrs  = make(map[uint64]struct{}, len(l.pbFilter.Address))
+		topics = make([]map[uint64]struct{}, len(l.pbFilter.Topics))
+		res    = []uint32{}
+	)
+	for _, addr := range l.pbFilter.Address {
+		addrs[block.AddressFingerprint(addr)] = struct{}{}
+	}
+	for i, e := range l.pbFilter.Topics {
+		if e == nil || len(e.Topic) == 0 {
+			continue
+		}
+		topics[i] = make(map[uint64]struct{}, len(e.Topic))
+		for _, v := range e.Topic {
+			// a topic of other length never equals the hash in a log
+			if len(v) == len(hash.ZeroHash256) {
+				topics[i][block.TopicFingerprint(hash.BytesToHash256(v))] = struct{}{}
+			}
+		}
+	}
+	for _, r := range d.Receipts {
+		for _, log := range r.Logs {
+			if l.matchDigest(log, addrs, topics) {
+				res = append(res, r.Index)
+				break
+			}
+		}
+	}
+	return res
+}
+
+// matchDigest checks if the digest of a log matches the fingerprints of the filter in the same way as match
+func (l *LogFilter) matchDigest(log block.LogEntryDigest, addrs map[uint64]struct{}, topics []map[uint64]struct{}) bool {
+	if len(l.pbFilter.Address) > 0 {
+		if _, ok := addrs[log.Address]; !ok {
+			return false
+		}
+	}
+	if len(topics) > len(log.Topics) {
+		return false
+	}
+	for i, fps := range topics {
+		if fps == nil {
+			continue
+		}
+		if _, ok := fps[log.Topics[i]]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
 // ExistInBloomFilter returns true if topics of filter exist in the bloom filter
 func (l *LogFilter) ExistInBloomFilter(bf bloom.BloomFilter) bool {
 	if bf == nil {
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 )
 
@@ -203,3 +204,28 @@ func TestLogFilter_ExistInBloomFilterv2(t *testing.T) {
 		}
 	}
 }
+
+func TestLogFilter_MatchLogDigest(t *testing.T) {
+	require := require.New(t)
+
+	receipts := make([]*action.Receipt, 0, 2*len(_testData))
+	for _, v := range _testData {
+		// a receipt without log in between shifts the indices
+		receipts = append(receipts, &action.Receipt{}, (&action.Receipt{}).AddLogs(v.log))
+	}
+	digest := block.NewLogDigest(receipts)
+	for i, q := range _testFilter {
+		f := NewLogFilter(q)
+		expected := []uint32{}
+		for j, v := range _testData {
+			if v.match[i] {
+				expected = append(expected, uint32(2*j+1))
+			}
+		}
+		require.Equal(expected, f.MatchLogDigest(digest))
+	}
+
+	// a topic of other length never matches
+	f := NewLogFilter(&iotexapi.LogsFilter{Topics: []*iotexapi.Topics{{Topic: [][]byte{_topic1[:8]}}}})
+	require.Empty(f.MatchLogDigest(digest))
+}
@@ -38,7 +38,7 @@ func (ll *gRPCLogListener) Respond(_ string, blk *block.Block) error {
 		logPb.BlkHash = blkHash[:]
 		if _, err := ll.streamHandle(&iotexapi.StreamLogsResponse{Log: logPb}); err != nil {
 			ll.errChan <- err
-			log.L().Info("error streaming the log",
+			log.Logger("api").Info("error streaming the log",
 				zap.Uint64("height", e.BlockHeight),
 				zap.Error(err))
 			return err
@@ -82,7 +82,7 @@ func (ll *web3LogListener) Respond(id string, blk *block.Block) error {
 			},
 		}
 		if _, err := ll.streamHandle(res); err != nil {
-			log.L().Info(
+			log.Logger("api").Info(
 				"Error when streaming the block",
 				zap.Uint64("height", blk.Height()),
 				zap.Error(err),
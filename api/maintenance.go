@@ -0,0 +1,171 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	apitypes "github.com/iotexproject/iotex-core/api/types"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// _defaultMaintenanceRetryAfter is the time the clients are told to retry after if not given on enabling
+const _defaultMaintenanceRetryAfter = time.Minute
+
+type (
+	// Maintenance is the maintenance mode of the api, in which the heavy reads are rejected with a retryable error,
+	// so the public traffic is drained before an upgrade, while the write path keeps working, and the consensus,
+	// block sync and p2p are never affected. The mode is persisted in a file, so it survives a restart
+	Maintenance struct {
+		mutex    sync.RWMutex
+		path     string
+		state    MaintenanceState
+		listener apitypes.Listener
+		timer    *time.Timer
+	}
+
+	// MaintenanceState is the state of the maintenance mode
+	MaintenanceState struct {
+		Enabled bool `json:"enabled"`
+		// RetryAfter is the time the rejected clients are told to retry after
+		RetryAfter time.Duration `json:"retryAfter"`
+		Since      time.Time     `json:"since"`
+	}
+)
+
+// NewMaintenance returns the maintenance mode persisted in the file of the path, which isn't persisted if the path
+// is empty
+func NewMaintenance(path string) (*Maintenance, error) {
+	m := &Maintenance{path: path}
+	if path == "" {
+		return m, nil
+	}
+	data, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		return m, nil
+	case err != nil:
+		return nil, errors.Wrapf(err, "failed to read maintenance state from %s", path)
+	}
+	if err := json.Unmarshal(data, &m.state); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode maintenance state from %s", path)
+	}
+	if m.state.Enabled {
+		log.L().Warn("API is in maintenance mode.", zap.Time("since", m.state.Since))
+	}
+	return m, nil
+}
+
+// Enable enables the maintenance mode, the rejected clients are told to retry after the duration. The streaming
+// subscriptions are closed after the grace period if it's positive, or left open otherwise
+func (m *Maintenance) Enable(retryAfter, grace time.Duration) error {
+	if retryAfter <= 0 {
+		retryAfter = _defaultMaintenanceRetryAfter
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	state := MaintenanceState{Enabled: true, RetryAfter: retryAfter, Since: m.state.Since}
+	if !m.state.Enabled {
+		state.Since = time.Now()
+	}
+	if err := m.persist(state); err != nil {
+		return err
+	}
+	m.state = state
+	m.stopTimer()
+	if grace > 0 && m.listener != nil {
+		listener := m.listener
+		m.timer = time.AfterFunc(grace, func() {
+			// stopping the listener makes all the responders exit, which closes the streams, and the listener
+			// still accepts new subscriptions
+			if err := listener.Stop(); err != nil {
+				log.L().Error("Failed to close the streaming subscriptions.", zap.Error(err))
+			}
+		})
+	}
+	return nil
+}
+
+// Disable disables the maintenance mode, and cancels closing the streaming subscriptions if not done yet
+func (m *Maintenance) Disable() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if err := m.persist(MaintenanceState{}); err != nil {
+		return err
+	}
+	m.state = MaintenanceState{}
+	m.stopTimer()
+	return nil
+}
+
+// State returns the state of the maintenance mode
+func (m *Maintenance) State() MaintenanceState {
+	if m == nil {
+		return MaintenanceState{}
+	}
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.state
+}
+
+// Enabled returns true if the maintenance mode is enabled
+func (m *Maintenance) Enabled() bool {
+	return m.State().Enabled
+}
+
+// Err returns the error rejecting a request in the maintenance mode, which is retryable after the time in the
+// details, or nil if not enabled
+func (m *Maintenance) Err() error {
+	state := m.State()
+	if !state.Enabled {
+		return nil
+	}
+	st, err := status.New(codes.Unavailable, "node is in maintenance, retry after "+state.RetryAfter.String()).
+		WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(state.RetryAfter)})
+	if err != nil {
+		log.Logger("api").Panic("Unexpected error attaching metadata", zap.Error(err))
+	}
+	return st.Err()
+}
+
+func (m *Maintenance) stopTimer() {
+	if m.timer != nil {
+		m.timer.Stop()
+		m.timer = nil
+	}
+}
+
+func (m *Maintenance) persist(state MaintenanceState) error {
+	if m.path == "" {
+		return nil
+	}
+	if !state.Enabled {
+		if err := os.Remove(m.path); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "failed to remove maintenance state %s", m.path)
+		}
+		return nil
+	}
+	data, err := json.Marshal(&state)
+	if err != nil {
+		return err
+	}
+	// write to a temp file and rename it, so a crash never leaves a partial state
+	tmp := m.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write maintenance state %s", tmp)
+	}
+	return errors.Wrapf(os.Rename(tmp, m.path), "failed to persist maintenance state %s", m.path)
+}
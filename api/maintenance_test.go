@@ -0,0 +1,98 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMaintenance(t *testing.T) {
+	r := require.New(t)
+
+	t.Run("Persist", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "maintenance.json")
+		m, err := NewMaintenance(path)
+		r.NoError(err)
+		r.False(m.Enabled())
+		r.NoError(m.Err())
+
+		r.NoError(m.Enable(30*time.Second, 0))
+		r.True(m.Enabled())
+		since := m.State().Since
+		r.False(since.IsZero())
+		st := status.Convert(m.Err())
+		r.Equal(codes.Unavailable, st.Code())
+		r.Len(st.Details(), 1)
+		r.Equal(30*time.Second, st.Details()[0].(*errdetails.RetryInfo).GetRetryDelay().AsDuration())
+
+		// enabling again updates the retry time but keeps the time of entering the maintenance
+		r.NoError(m.Enable(0, 0))
+		r.Equal(_defaultMaintenanceRetryAfter, m.State().RetryAfter)
+		r.True(since.Equal(m.State().Since))
+
+		// the state survives a restart
+		m, err = NewMaintenance(path)
+		r.NoError(err)
+		r.True(m.Enabled())
+		r.Equal(_defaultMaintenanceRetryAfter, m.State().RetryAfter)
+		r.NoError(m.Disable())
+		r.False(m.Enabled())
+		r.NoError(m.Err())
+		m, err = NewMaintenance(path)
+		r.NoError(err)
+		r.False(m.Enabled())
+	})
+
+	t.Run("InMemory", func(t *testing.T) {
+		m, err := NewMaintenance("")
+		r.NoError(err)
+		r.NoError(m.Enable(time.Second, 0))
+		r.True(m.Enabled())
+		r.NoError(m.Disable())
+		r.False(m.Enabled())
+
+		// a nil maintenance is never enabled
+		var nilM *Maintenance
+		r.False(nilM.Enabled())
+		r.NoError(nilM.Err())
+	})
+
+	t.Run("Grace", func(t *testing.T) {
+		m, err := NewMaintenance("")
+		r.NoError(err)
+		m.listener = NewChainListener(10)
+		errChan := make(chan error, 2)
+		_, err = m.listener.AddResponder(NewGRPCBlockListener(func(interface{}) (int, error) { return 0, nil }, errChan))
+		r.NoError(err)
+
+		// the streams are closed after the grace period
+		r.NoError(m.Enable(time.Second, 10*time.Millisecond))
+		select {
+		case err := <-errChan:
+			r.NoError(err)
+		case <-time.After(5 * time.Second):
+			r.Fail("the stream is not closed after the grace period")
+		}
+
+		// disabling cancels closing the streams
+		_, err = m.listener.AddResponder(NewGRPCBlockListener(func(interface{}) (int, error) { return 0, nil }, errChan))
+		r.NoError(err)
+		r.NoError(m.Enable(time.Second, 50*time.Millisecond))
+		r.NoError(m.Disable())
+		select {
+		case <-errChan:
+			r.Fail("the stream is closed after the maintenance is disabled")
+		case <-time.After(200 * time.Millisecond):
+		}
+	})
+}
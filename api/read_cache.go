@@ -1,15 +1,37 @@
 package api
 
 import (
+	"container/list"
+	"context"
 	"encoding/json"
+	"sync"
+	"time"
 
-	"github.com/iotexproject/go-pkgs/cache/ttl"
 	"github.com/iotexproject/go-pkgs/hash"
-	"go.uber.org/zap"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/singleflight"
+)
 
-	"github.com/iotexproject/iotex-core/pkg/log"
+// the scopes of the read results, by which they are invalidated
+const (
+	// ReadScopeBlock is the scope of the results read at the tip, which are invalidated on block commit
+	ReadScopeBlock ReadScope = iota
+	// ReadScopeEpoch is the scope of the results only changing per epoch, which are invalidated on epoch transition
+	ReadScopeEpoch
+	// ReadScopeHistory is the scope of the results read at a past height, which never change and are only evicted
+	// by the ttl or the bound of the bytes
+	ReadScopeHistory
 )
 
+var _readCacheMtc = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "iotex_api_read_cache",
+	Help: "api read cache metrics.",
+}, []string{"result"})
+
+func init() {
+	prometheus.MustRegister(_readCacheMtc)
+}
+
 type (
 	// ReadKey represents a read key
 	ReadKey struct {
@@ -19,11 +41,35 @@ type (
 		Args   [][]byte `json:"args,omitempty"`
 	}
 
-	// ReadCache stores read results
+	// ReadScope is the scope of a read result
+	ReadScope uint8
+
+	readEntry struct {
+		key   hash.Hash160
+		value []byte
+		// height is the height the value is read at, and bucket is the height from which the value stays the same
+		// until invalidated, i.e., the tip height for the block scope and the epoch start height for the epoch scope
+		height   uint64
+		bucket   uint64
+		scope    ReadScope
+		expireAt time.Time
+	}
+
+	// ReadCache stores read results, bounded by the bytes of the results. A result is invalidated by its scope, or
+	// expires after the ttl otherwise, and the least recently used ones are evicted beyond the bytes
 	ReadCache struct {
-		total, hit int
-		c          *ttl.Cache
+		mutex    sync.Mutex
+		ttl      time.Duration
+		maxBytes int
+		bytes    int
+		entries  map[hash.Hash160]*list.Element
+		lru      *list.List
+		// invalidated is the height invalidated to of each scope, the results of a lower bucket are stale
+		invalidated [ReadScopeHistory]uint64
+		group       singleflight.Group
 	}
+
+	readCacheBypassCtxKey struct{}
 )
 
 // Hash returns the hash of key's json string
@@ -32,34 +78,135 @@ func (k *ReadKey) Hash() hash.Hash160 {
 	return hash.Hash160b(b)
 }
 
-// NewReadCache returns a new read cache
-func NewReadCache() *ReadCache {
-	c, _ := ttl.NewCache()
+// NewReadCache returns a new read cache, the results expire after ttl and the total bytes of them are bounded by
+// maxBytes, zero means no limit for both
+func NewReadCache(ttl time.Duration, maxBytes int) *ReadCache {
 	return &ReadCache{
-		c: c,
+		ttl:      ttl,
+		maxBytes: maxBytes,
+		entries:  make(map[hash.Hash160]*list.Element),
+		lru:      list.New(),
 	}
 }
 
 // Get reads according to key
 func (rc *ReadCache) Get(key hash.Hash160) ([]byte, bool) {
-	rc.total++
-	d, ok := rc.c.Get(key)
+	e, ok := rc.get(key)
 	if !ok {
 		return nil, false
 	}
-	rc.hit++
-	if rc.hit%100 == 0 {
-		log.Logger("api").Info("API cache hit", zap.Int("total", rc.total), zap.Int("hit", rc.hit))
-	}
-	return d.([]byte), true
+	return e.value, true
 }
 
-// Put writes according to key
+// Put writes according to key, the value is invalidated on the next block commit
 func (rc *ReadCache) Put(key hash.Hash160, value []byte) {
-	rc.c.Set(key, value)
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	rc.put(&readEntry{key: key, value: value, bucket: rc.invalidated[ReadScopeBlock], scope: ReadScopeBlock})
+}
+
+// Load reads according to key, or loads the value along with the height it is read at if missed, and puts it into
+// the cache in the scope and the bucket. The concurrent loads of the same key are merged into one
+func (rc *ReadCache) Load(key hash.Hash160, scope ReadScope, bucket uint64, load func() ([]byte, uint64, error)) ([]byte, uint64, error) {
+	if e, ok := rc.get(key); ok {
+		return e.value, e.height, nil
+	}
+	v, err, _ := rc.group.Do(string(key[:]), func() (interface{}, error) {
+		value, height, err := load()
+		if err != nil {
+			return nil, err
+		}
+		e := &readEntry{key: key, value: value, height: height, bucket: bucket, scope: scope}
+		rc.mutex.Lock()
+		rc.put(e)
+		rc.mutex.Unlock()
+		return e, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	e := v.(*readEntry)
+	return e.value, e.height, nil
+}
+
+// Invalidate removes the results of a bucket lower than the height in the scope, along with the ones in the
+// narrower scopes, and the stale results loaded afterwards are not put into the cache
+func (rc *ReadCache) Invalidate(scope ReadScope, height uint64) {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	for s := ReadScopeBlock; s <= scope && s < ReadScopeHistory; s++ {
+		if rc.invalidated[s] < height {
+			rc.invalidated[s] = height
+		}
+	}
+	for elem := rc.lru.Front(); elem != nil; {
+		next := elem.Next()
+		if e := elem.Value.(*readEntry); e.scope <= scope && e.bucket < height {
+			rc.remove(elem)
+		}
+		elem = next
+	}
 }
 
 // Clear clears the cache
 func (rc *ReadCache) Clear() {
-	rc.c.Reset()
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	rc.entries = make(map[hash.Hash160]*list.Element)
+	rc.lru.Init()
+	rc.bytes = 0
+}
+
+func (rc *ReadCache) get(key hash.Hash160) (*readEntry, bool) {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	elem, ok := rc.entries[key]
+	if ok && rc.ttl > 0 && time.Now().After(elem.Value.(*readEntry).expireAt) {
+		rc.remove(elem)
+		ok = false
+	}
+	if !ok {
+		_readCacheMtc.WithLabelValues("miss").Inc()
+		return nil, false
+	}
+	_readCacheMtc.WithLabelValues("hit").Inc()
+	rc.lru.MoveToFront(elem)
+	return elem.Value.(*readEntry), true
+}
+
+func (rc *ReadCache) put(e *readEntry) {
+	if e.scope < ReadScopeHistory && e.bucket < rc.invalidated[e.scope] {
+		return
+	}
+	if rc.maxBytes > 0 && len(e.value) > rc.maxBytes {
+		return
+	}
+	if elem, ok := rc.entries[e.key]; ok {
+		rc.remove(elem)
+	}
+	if rc.ttl > 0 {
+		e.expireAt = time.Now().Add(rc.ttl)
+	}
+	rc.entries[e.key] = rc.lru.PushFront(e)
+	rc.bytes += len(e.value)
+	for rc.maxBytes > 0 && rc.bytes > rc.maxBytes {
+		rc.remove(rc.lru.Back())
+	}
+}
+
+func (rc *ReadCache) remove(elem *list.Element) {
+	e := rc.lru.Remove(elem).(*readEntry)
+	delete(rc.entries, e.key)
+	rc.bytes -= len(e.value)
+}
+
+// WithReadCacheBypass attaches to the context that the reads skip the read cache, for the callers requiring the
+// results read from the state
+func WithReadCacheBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readCacheBypassCtxKey{}, true)
+}
+
+func bypassReadCache(ctx context.Context) bool {
+	bypass, _ := ctx.Value(readCacheBypassCtxKey{}).(bool)
+	return bypass
 }
@@ -1,7 +1,9 @@
 package api
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/stretchr/testify/require"
@@ -33,7 +35,7 @@ func TestReadKey(t *testing.T) {
 func TestReadCache(t *testing.T) {
 	r := require.New(t)
 
-	c := NewReadCache()
+	c := NewReadCache(0, 0)
 	rcTests := []struct {
 		k hash.Hash160
 		v []byte
@@ -63,3 +65,98 @@ func TestReadCache(t *testing.T) {
 		r.Nil(d)
 	}
 }
+
+func TestReadCacheLoad(t *testing.T) {
+	r := require.New(t)
+
+	var (
+		c     = NewReadCache(0, 0)
+		reads int
+		key   = hash.Hash160b([]byte{1})
+		load  = func() ([]byte, uint64, error) {
+			reads++
+			return []byte{1}, 10, nil
+		}
+	)
+	for i := 0; i < 2; i++ {
+		d, h, err := c.Load(key, ReadScopeBlock, 10, load)
+		r.NoError(err)
+		r.Equal([]byte{1}, d)
+		r.Equal(uint64(10), h)
+	}
+	r.Equal(1, reads)
+
+	// the failure to load is not cached
+	_, _, err := c.Load(hash.Hash160b([]byte{2}), ReadScopeBlock, 10, func() ([]byte, uint64, error) {
+		return nil, 0, errors.New("failed to load")
+	})
+	r.ErrorContains(err, "failed to load")
+	_, ok := c.Get(hash.Hash160b([]byte{2}))
+	r.False(ok)
+}
+
+func TestReadCacheInvalidate(t *testing.T) {
+	r := require.New(t)
+
+	c := NewReadCache(0, 0)
+	put := func(key byte, scope ReadScope, bucket uint64) {
+		_, _, err := c.Load(hash.Hash160b([]byte{key}), scope, bucket, func() ([]byte, uint64, error) {
+			return []byte{key}, bucket, nil
+		})
+		r.NoError(err)
+	}
+	has := func(key byte) bool {
+		_, ok := c.Get(hash.Hash160b([]byte{key}))
+		return ok
+	}
+	put(1, ReadScopeBlock, 10)
+	put(2, ReadScopeBlock, 11)
+	put(3, ReadScopeEpoch, 9)
+	put(4, ReadScopeHistory, 5)
+	c.Put(hash.Hash160b([]byte{5}), []byte{5})
+
+	c.Invalidate(ReadScopeBlock, 11)
+	r.False(has(1))
+	r.True(has(2))
+	r.True(has(3))
+	r.True(has(4))
+	r.False(has(5))
+	// the stale results loaded after the invalidation are not put into the cache
+	put(1, ReadScopeBlock, 10)
+	r.False(has(1))
+	c.Put(hash.Hash160b([]byte{5}), []byte{5})
+	r.True(has(5))
+
+	c.Invalidate(ReadScopeEpoch, 13)
+	r.False(has(2))
+	r.False(has(3))
+	r.True(has(4))
+	r.False(has(5))
+}
+
+func TestReadCacheBound(t *testing.T) {
+	r := require.New(t)
+
+	t.Run("bytes", func(t *testing.T) {
+		c := NewReadCache(0, 4)
+		for i := byte(1); i <= 3; i++ {
+			c.Put(hash.Hash160b([]byte{i}), []byte{i, i})
+		}
+		// the least recently used one is evicted
+		_, ok := c.Get(hash.Hash160b([]byte{1}))
+		r.False(ok)
+		_, ok = c.Get(hash.Hash160b([]byte{3}))
+		r.True(ok)
+		// the result larger than the bound is not cached
+		c.Put(hash.Hash160b([]byte{4}), []byte{4, 4, 4, 4, 4})
+		_, ok = c.Get(hash.Hash160b([]byte{4}))
+		r.False(ok)
+	})
+	t.Run("ttl", func(t *testing.T) {
+		c := NewReadCache(time.Millisecond, 0)
+		c.Put(hash.Hash160b([]byte{1}), []byte{1})
+		time.Sleep(2 * time.Millisecond)
+		_, ok := c.Get(hash.Hash160b([]byte{1}))
+		r.False(ok)
+	})
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"strconv"
+
+	"google.golang.org/grpc/metadata"
+
+	apitypes "github.com/iotexproject/iotex-core/api/types"
+)
+
+// the metadata keys in the header of the response of SendAction, until SendActionResponse carries the status
+const (
+	// MetadataActionStatus is "pending" or "confirmed", the current status of the action sent
+	MetadataActionStatus = "x-iotex-action-status"
+	// MetadataActionKnown is "true" if the same action was sent before, and is not added or broadcast again
+	MetadataActionKnown = "x-iotex-action-known"
+	// MetadataActionBlockHeight is the height of the block containing the action, which is absent if not confirmed
+	MetadataActionBlockHeight = "x-iotex-action-block-height"
+	// MetadataActionReceiptStatus is the status of the receipt of the action, which is absent if not confirmed
+	MetadataActionReceiptStatus = "x-iotex-action-receipt-status"
+)
+
+// sentActionMetadata returns the header of the response of SendAction for the action sent
+func sentActionMetadata(sent *apitypes.SentAction) metadata.MD {
+	md := metadata.Pairs(
+		MetadataActionStatus, sent.Status.Status,
+		MetadataActionKnown, strconv.FormatBool(sent.Known),
+	)
+	if sent.Status.Status == apitypes.ActionStatusConfirmed {
+		md.Set(MetadataActionBlockHeight, strconv.FormatUint(sent.Status.Block.Height(), 10))
+		md.Set(MetadataActionReceiptStatus, strconv.FormatUint(sent.Status.Receipt.Status, 10))
+	}
+	return md
+}
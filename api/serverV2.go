@@ -32,6 +32,7 @@ type ServerV2 struct {
 	httpSvr      *HTTPServer
 	websocketSvr *HTTPServer
 	tracer       *tracesdk.TracerProvider
+	maintenance  *Maintenance
 }
 
 // NewServerV2 creates a new server with coreService and GRPC Server
@@ -48,17 +49,32 @@ func NewServerV2(
 	getBlockTime evm.GetBlockTime,
 	opts ...Option,
 ) (*ServerV2, error) {
+	maintenance, err := NewMaintenance(cfg.MaintenanceFile)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, withMaintenance(maintenance))
 	coreAPI, err := newCoreService(cfg, chain, bs, sf, dao, indexer, bfIndexer, actPool, registry, getBlockTime, opts...)
 	if err != nil {
 		return nil, err
 	}
-	web3Handler := NewWeb3Handler(coreAPI, cfg.RedisCacheURL, cfg.BatchRequestLimit)
+	maintenance.listener = coreAPI.ChainListener()
+	var web3Opts []web3HandlerOption
+	if cfg.Web3KeystoreDir != "" {
+		ks, err := newWeb3Keystore(cfg.Web3Host, cfg.Web3KeystoreDir, cfg.Web3KeystorePasswordFile)
+		if err != nil {
+			return nil, err
+		}
+		web3Opts = append(web3Opts, withWeb3Keystore(ks))
+	}
+	web3Handler := NewWeb3Handler(coreAPI, cfg.RedisCacheURL, cfg.BatchRequestLimit, web3Opts...)
 
 	tp, err := tracer.NewProvider(
 		tracer.WithServiceName(cfg.Tracer.ServiceName),
 		tracer.WithEndpoint(cfg.Tracer.EndPoint),
 		tracer.WithInstanceID(cfg.Tracer.InstanceID),
 		tracer.WithSamplingRatio(cfg.Tracer.SamplingRatio),
+		tracer.WithMaxTracesPerSecond(cfg.Tracer.MaxTracesPerSecond),
 	)
 	if err != nil {
 		return nil, errors.Wrapf(err, "cannot config tracer provider")
@@ -71,10 +87,11 @@ func NewServerV2(
 
 	return &ServerV2{
 		core:         coreAPI,
-		grpcServer:   NewGRPCServer(coreAPI, cfg.GRPCPort),
-		httpSvr:      NewHTTPServer("", cfg.HTTPPort, wrappedWeb3Handler),
-		websocketSvr: NewHTTPServer("", cfg.WebSocketPort, wrappedWebsocketHandler),
+		grpcServer:   NewGRPCServer(coreAPI, cfg),
+		httpSvr:      newHTTPServerOnHost(cfg.Web3Host, "", cfg.HTTPPort, wrappedWeb3Handler),
+		websocketSvr: newHTTPServerOnHost(cfg.Web3Host, "", cfg.WebSocketPort, wrappedWebsocketHandler),
 		tracer:       tp,
+		maintenance:  maintenance,
 	}, nil
 }
 
@@ -138,3 +155,8 @@ func (svr *ServerV2) ReceiveBlock(blk *block.Block) error {
 func (svr *ServerV2) CoreService() CoreService {
 	return svr.core
 }
+
+// Maintenance returns the maintenance mode of the api
+func (svr *ServerV2) Maintenance() *Maintenance {
+	return svr.maintenance
+}
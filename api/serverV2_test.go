@@ -24,9 +24,11 @@ func TestServerV2(t *testing.T) {
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
 	web3Handler := NewWeb3Handler(core, "", _defaultBatchRequestLimit)
+	cfg := DefaultConfig
+	cfg.GRPCPort = testutil.RandomPort()
 	svr := &ServerV2{
 		core:         core,
-		grpcServer:   NewGRPCServer(core, testutil.RandomPort()),
+		grpcServer:   NewGRPCServer(core, cfg),
 		httpSvr:      NewHTTPServer("", testutil.RandomPort(), newHTTPHandler(web3Handler)),
 		websocketSvr: NewHTTPServer("", testutil.RandomPort(), NewWebsocketHandler(web3Handler, nil)),
 	}
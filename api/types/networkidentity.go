@@ -0,0 +1,96 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apitypes
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/iotexproject/go-pkgs/hash"
+
+	"github.com/iotexproject/iotex-core/pkg/version"
+)
+
+// ClientName is the name of the node in the client version
+const ClientName = "iotex-core"
+
+// NetworkIdentity is the identity of the network and the node, which is the single source of the chain ids, the
+// genesis hash and the versions answered by the APIs
+type NetworkIdentity struct {
+	// ChainID is the native chain id in the core of the actions
+	ChainID uint32
+	// EVMNetworkID is the chain id of the EIP-155 signed transactions, i.e., the chain id of the web3 APIs
+	EVMNetworkID    uint32
+	GenesisHash     hash.Hash256
+	ProtocolVersion uint32
+	PackageVersion  string
+	PackageCommitID string
+	GitStatus       string
+	// GoVersion is the version of go building the package, e.g., "go1.22.5"
+	GoVersion string
+	BuildTime string
+	// Platform is the os and the architecture the node runs on, e.g., "linux-amd64"
+	Platform string
+}
+
+// NewNetworkIdentity returns the identity of the network with the version of the package
+func NewNetworkIdentity(chainID, evmNetworkID uint32, genesisHash hash.Hash256) *NetworkIdentity {
+	return &NetworkIdentity{
+		ChainID:         chainID,
+		EVMNetworkID:    evmNetworkID,
+		GenesisHash:     genesisHash,
+		ProtocolVersion: version.ProtocolVersion,
+		PackageVersion:  version.PackageVersion,
+		PackageCommitID: version.PackageCommitID,
+		GitStatus:       version.GitStatus,
+		GoVersion:       goVersion(version.GoVersion),
+		BuildTime:       version.BuildTime,
+		Platform:        runtime.GOOS + "-" + runtime.GOARCH,
+	}
+}
+
+// EthChainID returns the chain id of eth_chainId, which is the hex of the evm network id, e.g., "0x1251"
+func (id *NetworkIdentity) EthChainID() string {
+	return hexutil.EncodeUint64(uint64(id.EVMNetworkID))
+}
+
+// NetVersion returns the network id of net_version, which is the decimal of the evm network id, e.g., "4689"
+func (id *NetworkIdentity) NetVersion() string {
+	return strconv.FormatUint(uint64(id.EVMNetworkID), 10)
+}
+
+// ClientVersion returns the version of web3_clientVersion in the format of geth, i.e.,
+// "name/version-commit/os-arch/goversion", e.g., "iotex-core/v2.0.0-1a2b3c4d/linux-amd64/go1.22.5", where the commit
+// is omitted if unknown
+func (id *NetworkIdentity) ClientVersion() string {
+	v := id.PackageVersion
+	if len(id.PackageCommitID) >= 8 && isHex(id.PackageCommitID) {
+		v += "-" + id.PackageCommitID[:8]
+	}
+	return strings.Join([]string{ClientName, v, id.Platform, id.GoVersion}, "/")
+}
+
+// goVersion returns the go version in the output of "go version", e.g., "go1.22.5" in
+// "go version go1.22.5 linux/amd64", or the version of the running go if not found
+func goVersion(s string) string {
+	for _, field := range strings.Fields(s) {
+		if strings.HasPrefix(field, "go1") {
+			return field
+		}
+	}
+	return runtime.Version()
+}
+
+func isHex(s string) bool {
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apitypes
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkIdentity(t *testing.T) {
+	r := require.New(t)
+	id := &NetworkIdentity{
+		ChainID:         1,
+		EVMNetworkID:    4689,
+		PackageVersion:  "v2.0.0",
+		PackageCommitID: "1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b",
+		GoVersion:       "go1.22.5",
+		Platform:        "linux-amd64",
+	}
+	r.Equal("0x1251", id.EthChainID())
+	r.Equal("4689", id.NetVersion())
+	r.Equal("iotex-core/v2.0.0-1a2b3c4d/linux-amd64/go1.22.5", id.ClientVersion())
+
+	// the commit is omitted if unknown
+	id.PackageCommitID = "NoBuildInfo"
+	r.Equal("iotex-core/v2.0.0/linux-amd64/go1.22.5", id.ClientVersion())
+	id.EVMNetworkID = 0
+	r.Equal("0x0", id.EthChainID())
+	r.Equal("0", id.NetVersion())
+
+	for _, v := range []struct {
+		in, out string
+	}{
+		{"go version go1.22.5 linux/amd64", "go1.22.5"},
+		{"go1.21.4", "go1.21.4"},
+		{"NoBuildInfo", runtime.Version()},
+	} {
+		r.Equal(v.out, goVersion(v.in))
+	}
+}
@@ -3,9 +3,67 @@ package apitypes
 import (
 	"encoding/json"
 	"errors"
+	"math/big"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
+)
+
+// the kinds of an address by its current state
+const (
+	AddressKindContract = "contract"
+	AddressKindAccount  = "account"
+	AddressKindNone     = "none"
+)
+
+// the reasons an action is rejected by the validation without submitting it
+const (
+	RejectInvalidAction      = "invalidAction"
+	RejectInvalidChainID     = "invalidChainID"
+	RejectInvalidSignature   = "invalidSignature"
+	RejectKnownTransaction   = "knownTransaction"
+	RejectUnderpriced        = "underpriced"
+	RejectReplaceUnderpriced = "replaceUnderpriced"
+	RejectNonceTooLow        = "nonceTooLow"
+	RejectNonceTooHigh       = "nonceTooHigh"
+	RejectInsufficientFunds  = "insufficientFunds"
+	RejectIntrinsicGas       = "intrinsicGasTooLow"
+	RejectGasTooHigh         = "gasTooHigh"
+	RejectGasLimitTooLow     = "gasLimitTooLow"
+	RejectExceedsBlockGas    = "exceedsBlockGasLimit"
+	RejectOversized          = "oversized"
+	RejectExecutionReverted  = "executionReverted"
+	RejectUnknown            = "unknown"
+)
+
+// the statuses of an action looked up by hash
+const (
+	ActionStatusPending   = "pending"
+	ActionStatusConfirmed = "confirmed"
+	ActionStatusNotFound  = "notFound"
+)
+
+// the statuses of the transaction logs of a block read in a range
+const (
+	TransactionLogStatusFound       = "found"
+	TransactionLogStatusEmpty       = "empty"
+	TransactionLogStatusUnavailable = "unavailable"
+)
+
+// the parts of a raw block
+const (
+	RawBlockHeader RawBlockParts = 1 << iota
+	RawBlockBody
+	RawBlockFooter
+	RawBlockReceipts
+
+	RawBlockAll = RawBlockHeader | RawBlockBody | RawBlockFooter | RawBlockReceipts
 )
 
 // MaxResponseSize is the max size of response
@@ -37,6 +95,144 @@ type (
 		Block    *block.Block
 		Receipts []*action.Receipt
 	}
+
+	// SimulateCall is a call of a batch simulation, To is empty to deploy a contract, and the gas limit is capped
+	// by the block gas limit and the remaining gas budget of the batch, which is also the gas limit of zero
+	SimulateCall struct {
+		Caller   address.Address
+		To       string
+		Amount   *big.Int
+		GasLimit uint64
+		Data     []byte
+	}
+
+	// SimulateResult is the result of a call of a batch simulation, Err is the error failing the call before it
+	// runs in EVM, e.g., insufficient balance to transfer the amount
+	SimulateResult struct {
+		GasUsed         uint64
+		Status          uint64
+		ReturnData      []byte
+		Logs            []*action.Log
+		ContractAddress string
+		RevertReason    string
+		Err             error
+	}
+
+	// ActionValidation is the result of validating an action without submitting it. Reason is empty if the action
+	// would be accepted, in which case Gas is the estimated gas and Fee is the estimated gas times the gas price
+	ActionValidation struct {
+		Reason  string
+		Message string
+		Gas     uint64
+		Fee     *big.Int
+	}
+
+	// ActionWithStatus is an action looked up by hash. A pending action comes with its state in the actpool, and a
+	// confirmed action with the block containing it, its index in the block and its receipt
+	ActionWithStatus struct {
+		Status  string
+		Action  *action.SealedEnvelope
+		Pool    *actpool.PendingActionInfo
+		Block   *block.Block
+		Index   uint32
+		Receipt *action.Receipt
+	}
+
+	// CancelAction is the unsigned zero-value self-transfer replacing a pending action of the same nonce. The gas
+	// price of Envelope is the one of Pending raised by the bump, no lower than MinGasPriceBump the actpool accepts
+	CancelAction struct {
+		Envelope        action.Envelope
+		Pending         *action.SealedEnvelope
+		MinGasPriceBump *big.Int
+	}
+
+	// SentAction is the result of sending an action. Known tells the same action was sent before, in which case it is
+	// neither added into the actpool nor broadcast again, and Status is its current status, pending or confirmed
+	SentAction struct {
+		Hash   hash.Hash256
+		Known  bool
+		Status *ActionWithStatus
+	}
+
+	// BlockTransactionLogs is the transaction logs of a block read in a range. Status is empty if the block has no
+	// transaction log, or none to the recipients asked for, and unavailable if the block is no longer stored, in
+	// which case Hash is zero
+	BlockTransactionLogs struct {
+		Height uint64
+		Hash   hash.Hash256
+		Status string
+		Logs   *iotextypes.TransactionLogs
+	}
+
+	// AccountNonceDetail is the state of the nonces of an account. PendingNonce is the end of the contiguous actions
+	// in the actpool from ConfirmedNonce, Gaps are the missing nonces up to the largest one in the actpool, and
+	// Pending are the actions occupying the nonces in the actpool, so the sender can fill the gaps or replace them
+	AccountNonceDetail struct {
+		ConfirmedNonce uint64
+		PendingNonce   uint64
+		Gaps           []uint64
+		Pending        []*PendingNonce
+	}
+
+	// PendingNonce is a nonce taken by an action in the actpool
+	PendingNonce struct {
+		Nonce uint64
+		Hash  hash.Hash256
+	}
+
+	// CreatedContract is a contract created by the action in a block, directly or by a contract at depth
+	CreatedContract struct {
+		ActionHash hash.Hash256
+		Address    string
+		Creator    string
+		Depth      uint32
+	}
+
+	// TxTrace is the trace of a transaction replayed in its block, where Tracer is the tracer requested, and
+	// ReturnValue is only captured by the struct logger
+	TxTrace struct {
+		ActionHash  hash.Hash256
+		ReturnValue []byte
+		Receipt     *action.Receipt
+		Tracer      any
+	}
+
+	// AddressInfo is an address converted into both formats, and Kind tells whether it is a contract, an account
+	// with balance or outgoing actions, or nothing on the chain
+	AddressInfo struct {
+		*addrutil.ConvertedAddress
+		Kind string
+	}
+
+	// LogCursor is the position of a log in the chain, which stays the same as the chain grows
+	LogCursor struct {
+		BlockHeight uint64
+		// ActionIndex is the index of the action in the block, and LogIndex is the index of the log in the action
+		ActionIndex uint32
+		LogIndex    uint32
+	}
+
+	// RawBlockParts is a set of the parts of a raw block
+	RawBlockParts uint8
+
+	// RawBlock is the serialized protos of the parts of a block asked for. The hash of Header is the block hash, the
+	// actions in Body and the receipts in Receipts make the tx root and the receipt root in the header
+	RawBlock struct {
+		Height   uint64
+		Hash     hash.Hash256
+		Header   []byte
+		Body     []byte
+		Footer   []byte
+		Receipts []byte
+	}
+
+	// LogsPage is a page of the logs in order, along with the hashes of their blocks. Next is the cursor of the last
+	// log to continue from, or nil if no log is left in the range
+	LogsPage struct {
+		Logs        []*action.Log
+		BlockHashes []hash.Hash256
+		Next        *LogCursor
+	}
 )
 
 // responseWriter for server
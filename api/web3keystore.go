@@ -0,0 +1,93 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"bytes"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+)
+
+var (
+	errKeystoreNotLoopback = errors.New("the web3 keystore requires the web3 servers to listen on a loopback interface")
+	errUnknownAccount      = errors.New("unknown account")
+)
+
+// web3Keystore signs the actions with the keys in a local keystore directory of the ioctl format, for the
+// development setups of the private chains
+type web3Keystore struct {
+	ks       *keystore.KeyStore
+	password string
+}
+
+// newWeb3Keystore loads the keystore of the dir, the keys are decrypted by the password in the password file when
+// signing. It refuses to load unless the web3 servers listen on a loopback interface only
+func newWeb3Keystore(host, dir, passwordFile string) (*web3Keystore, error) {
+	if !isLoopbackHost(host) {
+		return nil, errors.Wrapf(errKeystoreNotLoopback, "host %q", host)
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return nil, errors.Wrapf(err, "failed to open the keystore dir %s", dir)
+	}
+	password := ""
+	if passwordFile != "" {
+		data, err := os.ReadFile(filepath.Clean(passwordFile))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read the keystore password file %s", passwordFile)
+		}
+		password = strings.TrimRight(string(data), "\r\n")
+	}
+	return &web3Keystore{
+		ks:       keystore.NewKeyStore(dir, keystore.StandardScryptN, keystore.StandardScryptP),
+		password: password,
+	}, nil
+}
+
+// Accounts returns the addresses of the keys in the keystore
+func (w *web3Keystore) Accounts() []address.Address {
+	accounts := w.ks.Accounts()
+	addrs := make([]address.Address, 0, len(accounts))
+	for _, account := range accounts {
+		addr, err := address.FromBytes(account.Address.Bytes())
+		if err != nil {
+			continue
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+// Sign signs the envelope with the key of the signer as ioctl does
+func (w *web3Keystore) Sign(signer address.Address, elp action.Envelope) (*action.SealedEnvelope, error) {
+	for _, account := range w.ks.Accounts() {
+		if !bytes.Equal(signer.Bytes(), account.Address.Bytes()) {
+			continue
+		}
+		sk, err := crypto.KeystoreToPrivateKey(account, w.password)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decrypt the key of %s", signer.String())
+		}
+		return action.Sign(elp, sk)
+	}
+	return nil, errors.Wrap(errUnknownAccount, signer.String())
+}
+
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
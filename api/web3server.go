@@ -6,12 +6,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"math/big"
-	"strconv"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
@@ -19,6 +21,7 @@ import (
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/go-pkgs/util"
 	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -26,11 +29,13 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/iotexproject/iotex-core/action"
 	rewardingabi "github.com/iotexproject/iotex-core/action/protocol/rewarding/ethabi"
 	stakingabi "github.com/iotexproject/iotex-core/action/protocol/staking/ethabi"
 	apitypes "github.com/iotexproject/iotex-core/api/types"
+	"github.com/iotexproject/iotex-core/blockindex"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/tracer"
 	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
@@ -41,6 +46,16 @@ const (
 	_metamaskBalanceContractAddr = "io1k8uw2hrlvnfq8s2qpwwc24ws2ru54heenx8chr"
 	// _defaultBatchRequestLimit is the default maximum number of items in a batch.
 	_defaultBatchRequestLimit = 100 // Maximum number of items in a batch.
+	// _defaultTokenTransfersLimit is the default maximum number of token transfers returned
+	_defaultTokenTransfersLimit = 100
+	// _defaultTopContractsLimit is the default maximum number of top contracts returned
+	_defaultTopContractsLimit = 10
+	// _defaultBlockMetasLimit is the default maximum number of block metas returned
+	_defaultBlockMetasLimit = 100
+	// _defaultStakingPageLimit is the default number of buckets or candidates returned in a page
+	_defaultStakingPageLimit = 100
+	// _maxStakingPageLimit is the maximum number of buckets or candidates returned in a page
+	_maxStakingPageLimit = 1000
 )
 
 type (
@@ -53,7 +68,10 @@ type (
 		coreService       CoreService
 		cache             apiCache
 		batchRequestLimit int
+		keystore          *web3Keystore
 	}
+
+	web3HandlerOption func(*web3Handler)
 )
 
 type (
@@ -76,7 +94,7 @@ var (
 	errUnkownType        = errors.New("wrong type of params")
 	errNullPointer       = errors.New("null pointer")
 	errInvalidFormat     = errors.New("invalid format of request")
-	errNotImplemented    = errors.New("method not implemented")
+	errMethodNotFound    = errors.New("method not found")
 	errInvalidFilterID   = errors.New("filter not found")
 	errInvalidEvmChainID = errors.New("invalid EVM chain ID")
 	errInvalidBlock      = errors.New("invalid block")
@@ -86,19 +104,97 @@ var (
 	_pendingBlockNumber  = "pending"
 	_latestBlockNumber   = "latest"
 	_earliestBlockNumber = "earliest"
+	// the finalized and the safe blocks are both the last finalized block, as rolldpos gives instant finality
+	_finalizedBlockNumber = "finalized"
+	_safeBlockNumber      = "safe"
+
+	// _web3MethodCapabilities are the methods which iotex-core knows beyond the ones of ethereum it serves. The methods
+	// of the iotex namespace are supported, and listed by iotex_supportedMethods for the clients to discover them. The
+	// methods of the eth and personal namespaces which iotex-core doesn't support are answered as not found with the
+	// method name like the unknown ones
+	_web3MethodCapabilities = map[string]bool{
+		"iotex_cancelPendingTransaction":       true,
+		"iotex_convertAddress":                 true,
+		"iotex_getAccountNonceDetail":          true,
+		"iotex_getActPoolStatus":               true,
+		"iotex_getActionByHash":                true,
+		"iotex_getActionInclusionProof":        true,
+		"iotex_getBlockMetasByTimestampRange":  true,
+		"iotex_getBlockNumberByTimestamp":      true,
+		"iotex_getBuckets":                     true,
+		"iotex_getCandidateHistory":            true,
+		"iotex_getCandidates":                  true,
+		"iotex_getContractStats":               true,
+		"iotex_getContractsCreatedByBlock":     true,
+		"iotex_getEarliestStateHeight":         true,
+		"iotex_getEpochMeta":                   true,
+		"iotex_getEpochRanking":                true,
+		"iotex_getFinalizedHeight":             true,
+		"iotex_getGrantRewardsByEpoch":         true,
+		"iotex_getProtocolParameters":          true,
+		"iotex_getRawBlock":                    true,
+		"iotex_getRawHeaders":                  true,
+		"iotex_getSystemActionsByHeight":       true,
+		"iotex_getTokenTransfersByAddress":     true,
+		"iotex_getTokenTransfersByContract":    true,
+		"iotex_getTopContracts":                true,
+		"iotex_getTotalSupply":                 true,
+		"iotex_getTransactionLogsByBlockRange": true,
+		"iotex_getTransactionStatus":           true,
+		"iotex_getTransfersByRecipientAndMemo": true,
+		"iotex_getUnclaimedRewards":            true,
+		"iotex_simulateBatch":                  true,
+		"iotex_suggestGasPrices":               true,
+		"iotex_supportedMethods":               true,
+		"iotex_validateRawTransaction":         true,
+		"eth_coinbase":                         false,
+		"eth_getUncleCountByBlockHash":         false,
+		"eth_getUncleCountByBlockNumber":       false,
+		"eth_getUncleByBlockHashAndIndex":      false,
+		"eth_getUncleByBlockNumberAndIndex":    false,
+		"eth_pendingTransactions":              false,
+		"eth_sign":                             false,
+		"eth_signTypedData":                    false,
+		"eth_signTypedData_v3":                 false,
+		"eth_signTypedData_v4":                 false,
+		"eth_sendTransaction":                  false,
+		"personal_listAccounts":                false,
+		"personal_listWallets":                 false,
+		"personal_newAccount":                  false,
+		"personal_importRawKey":                false,
+		"personal_unlockAccount":               false,
+		"personal_lockAccount":                 false,
+		"personal_openWallet":                  false,
+		"personal_deriveAccount":               false,
+		"personal_sign":                        false,
+		"personal_ecRecover":                   false,
+		"personal_signTransaction":             false,
+		"personal_sendTransaction":             false,
+	}
 )
 
 func init() {
 	prometheus.MustRegister(_web3ServerMtc)
 }
 
+// withWeb3Keystore serves eth_accounts and eth_signTransaction with the local keystore
+func withWeb3Keystore(ks *web3Keystore) web3HandlerOption {
+	return func(svr *web3Handler) {
+		svr.keystore = ks
+	}
+}
+
 // NewWeb3Handler creates a handle to process web3 requests
-func NewWeb3Handler(core CoreService, cacheURL string, batchRequestLimit int) Web3Handler {
-	return &web3Handler{
+func NewWeb3Handler(core CoreService, cacheURL string, batchRequestLimit int, opts ...web3HandlerOption) Web3Handler {
+	svr := &web3Handler{
 		coreService:       core,
 		cache:             newAPICache(15*time.Minute, cacheURL),
 		batchRequestLimit: batchRequestLimit,
 	}
+	for _, opt := range opts {
+		opt(svr)
+	}
+	return svr
 }
 
 // HandlePOSTReq handles web3 request
@@ -153,6 +249,10 @@ func (svr *web3Handler) handleWeb3Req(ctx context.Context, web3Req *gjson.Result
 		res, err = svr.ethAccounts()
 	case "eth_gasPrice":
 		res, err = svr.gasPrice()
+	case "eth_maxPriorityFeePerGas":
+		res, err = svr.maxPriorityFeePerGas()
+	case "eth_feeHistory":
+		res, err = svr.feeHistory(web3Req)
 	case "eth_getBlockByHash":
 		res, err = svr.getBlockByHash(web3Req)
 	case "eth_chainId":
@@ -197,6 +297,8 @@ func (svr *web3Handler) handleWeb3Req(ctx context.Context, web3Req *gjson.Result
 		res, err = svr.estimateGas(web3Req)
 	case "eth_sendRawTransaction":
 		res, err = svr.sendRawTransaction(web3Req)
+	case "eth_signTransaction":
+		res, err = svr.signTransaction(web3Req)
 	case "eth_getTransactionByHash":
 		res, err = svr.getTransactionByHash(web3Req)
 	case "eth_getTransactionByBlockNumberAndIndex":
@@ -223,21 +325,87 @@ func (svr *web3Handler) handleWeb3Req(ctx context.Context, web3Req *gjson.Result
 		}
 	case "eth_newBlockFilter":
 		res, err = svr.newBlockFilter()
+	case "iotex_getTokenTransfersByAddress":
+		res, err = svr.getTokenTransfers(web3Req, svr.coreService.TokenTransfersByAddress)
+	case "iotex_getTokenTransfersByContract":
+		res, err = svr.getTokenTransfers(web3Req, svr.coreService.TokenTransfersByContract)
+	case "iotex_getTransfersByRecipientAndMemo":
+		res, err = svr.getTransfersByRecipientAndMemo(web3Req)
+	case "iotex_getBlockNumberByTimestamp":
+		res, err = svr.getBlockNumberByTimestamp(web3Req)
+	case "iotex_getBlockMetasByTimestampRange":
+		res, err = svr.getBlockMetasByTimestampRange(web3Req)
+	case "iotex_getProtocolParameters":
+		res, err = svr.getProtocolParameters(web3Req)
+	case "iotex_getCandidateHistory":
+		res, err = svr.getCandidateHistory(web3Req)
+	case "iotex_getEpochRanking":
+		res, err = svr.getEpochRanking(web3Req)
+	case "iotex_getSystemActionsByHeight":
+		res, err = svr.getSystemActions(web3Req, svr.coreService.SystemActionsByHeight)
+	case "iotex_getGrantRewardsByEpoch":
+		res, err = svr.getSystemActions(web3Req, svr.coreService.GrantRewardsByEpoch)
+	case "iotex_getContractStats":
+		res, err = svr.getContractStats(web3Req)
+	case "iotex_getTopContracts":
+		res, err = svr.getTopContracts(web3Req)
+	case "iotex_suggestGasPrices":
+		res, err = svr.suggestGasPrices()
+	case "iotex_simulateBatch":
+		res, err = svr.simulateBatch(ctx, web3Req)
+	case "iotex_validateRawTransaction":
+		res, err = svr.validateRawTransaction(web3Req)
+	case "iotex_convertAddress":
+		res, err = svr.convertAddress(web3Req)
+	case "iotex_getTransactionStatus":
+		res, err = svr.getTransactionStatus(web3Req)
+	case "iotex_getTransactionLogsByBlockRange":
+		res, err = svr.getTransactionLogsByBlockRange(web3Req)
+	case "iotex_getAccountNonceDetail":
+		res, err = svr.getAccountNonceDetail(web3Req)
+	case "iotex_getActPoolStatus":
+		res, err = svr.getActPoolStatus()
+	case "iotex_getContractsCreatedByBlock":
+		res, err = svr.getContractsCreatedByBlock(web3Req)
+	case "iotex_getTotalSupply":
+		res, err = svr.getTotalSupply(ctx, web3Req)
+	case "iotex_getRawBlock":
+		res, err = svr.getRawBlock(web3Req)
+	case "iotex_getRawHeaders":
+		res, err = svr.getRawHeaders(web3Req)
+	case "iotex_getActionInclusionProof":
+		res, err = svr.getActionInclusionProof(web3Req)
+	case "iotex_cancelPendingTransaction":
+		res, err = svr.cancelPendingTransaction(web3Req)
+	case "iotex_getFinalizedHeight":
+		res, err = svr.getFinalizedHeight()
+	case "iotex_getEarliestStateHeight":
+		res, err = svr.getEarliestStateHeight()
+	case "iotex_getBuckets":
+		res, err = svr.getBuckets(web3Req)
+	case "iotex_getCandidates":
+		res, err = svr.getCandidates(web3Req)
+	case "iotex_getUnclaimedRewards":
+		res, err = svr.getUnclaimedRewards(web3Req)
+	case "iotex_getEpochMeta":
+		res, err = svr.getEpochMeta(web3Req)
+	case "iotex_getActionByHash":
+		res, err = svr.getActionByHash(web3Req)
+	case "iotex_supportedMethods":
+		res, err = web3IotexMethods(), nil
 	case "eth_subscribe":
 		res, err = svr.subscribe(web3Req, writer)
 	case "eth_unsubscribe":
 		res, err = svr.unsubscribe(web3Req)
+	case "debug_traceTransaction":
+		res, err = svr.traceTransaction(ctx, web3Req)
+	case "debug_traceBlockByNumber":
+		res, err = svr.traceBlockByNumber(ctx, web3Req)
 	//TODO: enable debug api after archive mode is supported
-	// case "debug_traceTransaction":
-	// 	res, err = svr.traceTransaction(ctx, web3Req)
 	// case "debug_traceCall":
 	// 	res, err = svr.traceCall(ctx, web3Req)
-	case "eth_coinbase", "eth_getUncleCountByBlockHash", "eth_getUncleCountByBlockNumber",
-		"eth_sign", "eth_signTransaction", "eth_sendTransaction", "eth_getUncleByBlockHashAndIndex",
-		"eth_getUncleByBlockNumberAndIndex", "eth_pendingTransactions":
-		res, err = svr.unimplemented()
 	default:
-		res, err = nil, errors.Wrapf(errors.New("web3 method not found"), "method: %s\n", web3Req.Get("method"))
+		res, err = nil, methodNotFound(web3Req.Get("method").String())
 	}
 	if err != nil {
 		log.Logger("api").Debug("web3server",
@@ -286,7 +454,15 @@ func parseWeb3Reqs(reader io.Reader) (gjson.Result, error) {
 }
 
 func (svr *web3Handler) ethAccounts() (interface{}, error) {
-	return []string{}, nil
+	if svr.keystore == nil {
+		return []string{}, nil
+	}
+	addrs := svr.keystore.Accounts()
+	ret := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		ret = append(ret, addr.Hex())
+	}
+	return ret, nil
 }
 
 func (svr *web3Handler) gasPrice() (interface{}, error) {
@@ -297,14 +473,110 @@ func (svr *web3Handler) gasPrice() (interface{}, error) {
 	return uint64ToHex(ret), nil
 }
 
+func (svr *web3Handler) maxPriorityFeePerGas() (interface{}, error) {
+	ret, err := svr.coreService.SuggestGasTipCap()
+	if err != nil {
+		return nil, err
+	}
+	return uint64ToHex(ret), nil
+}
+
+func (svr *web3Handler) feeHistory(in *gjson.Result) (interface{}, error) {
+	blkCnt, newestBlk, rewardPercentiles := in.Get("params.0"), in.Get("params.1"), in.Get("params.2")
+	if !blkCnt.Exists() || !newestBlk.Exists() {
+		return nil, errInvalidFormat
+	}
+	blocks := blkCnt.Uint()
+	if blkCnt.Type == gjson.String {
+		var err error
+		if blocks, err = hexStringToNumber(blkCnt.String()); err != nil {
+			return nil, errors.Wrapf(errUnkownType, "blockCount: %s", blkCnt.String())
+		}
+	}
+	newest, err := svr.parseBlockNumber(newestBlk.String())
+	if err != nil {
+		return nil, err
+	}
+	var percentiles []float64
+	for i, p := range rewardPercentiles.Array() {
+		v := p.Float()
+		if p.Type != gjson.Number || v < 0 || v > 100 || (i > 0 && v < percentiles[i-1]) {
+			return nil, errors.Wrapf(errInvalidFormat, "rewardPercentiles: %s", rewardPercentiles.Raw)
+		}
+		percentiles = append(percentiles, v)
+	}
+	oldest, rewards, baseFees, gasUsedRatios, err := svr.coreService.FeeHistory(blocks, newest, percentiles)
+	if err != nil {
+		return nil, err
+	}
+	ret := &feeHistoryResult{
+		OldestBlock:   uint64ToHex(oldest),
+		BaseFeePerGas: make([]string, 0, len(baseFees)),
+		GasUsedRatio:  gasUsedRatios,
+	}
+	for _, fee := range baseFees {
+		ret.BaseFeePerGas = append(ret.BaseFeePerGas, hexutil.EncodeBig(fee))
+	}
+	for _, reward := range rewards {
+		tips := make([]string, 0, len(reward))
+		for _, tip := range reward {
+			tips = append(tips, hexutil.EncodeBig(tip))
+		}
+		ret.Reward = append(ret.Reward, tips)
+	}
+	return ret, nil
+}
+
+// suggestGasPrices returns the suggested gas prices of the slow, standard and fast tiers
+func (svr *web3Handler) suggestGasPrices() (interface{}, error) {
+	prices, err := svr.coreService.SuggestGasPrices()
+	if err != nil {
+		return nil, err
+	}
+	return &getGasPricesResult{
+		Slow:     uint64ToHex(prices.Slow),
+		Standard: uint64ToHex(prices.Standard),
+		Fast:     uint64ToHex(prices.Fast),
+	}, nil
+}
+
+func (svr *web3Handler) convertAddress(in *gjson.Result) (interface{}, error) {
+	addr := in.Get("params.0")
+	if !addr.Exists() {
+		return nil, errInvalidFormat
+	}
+	info, err := svr.coreService.ConvertAddress(addr.String())
+	if err != nil {
+		return nil, err
+	}
+	ret := &convertAddressResult{
+		IoAddress:      info.IoAddress,
+		Format:         info.Format,
+		SystemContract: info.SystemContract,
+		Kind:           info.Kind,
+	}
+	if info.HexAddress != "" {
+		ret.HexAddress = &info.HexAddress
+	}
+	return ret, nil
+}
+
 func (svr *web3Handler) getChainID() (interface{}, error) {
-	return uint64ToHex(uint64(svr.coreService.EVMNetworkID())), nil
+	return svr.coreService.NetworkIdentity().EthChainID(), nil
 }
 
 func (svr *web3Handler) getBlockNumber() (interface{}, error) {
 	return uint64ToHex(svr.coreService.TipHeight()), nil
 }
 
+func (svr *web3Handler) getFinalizedHeight() (interface{}, error) {
+	return uint64ToHex(svr.coreService.FinalizedHeight()), nil
+}
+
+func (svr *web3Handler) getEarliestStateHeight() (interface{}, error) {
+	return uint64ToHex(svr.coreService.EarliestStateHeight()), nil
+}
+
 func (svr *web3Handler) getBlockByNumber(in *gjson.Result) (interface{}, error) {
 	blkNum, isDetailed := in.Get("params.0"), in.Get("params.1")
 	if !blkNum.Exists() || !isDetailed.Exists() {
@@ -330,7 +602,7 @@ func (svr *web3Handler) getBalance(in *gjson.Result) (interface{}, error) {
 	if !addr.Exists() {
 		return nil, errInvalidFormat
 	}
-	ioAddr, err := ethAddrToIoAddr(addr.String())
+	ioAddr, err := parseAddress(addr.String())
 	if err != nil {
 		return nil, err
 	}
@@ -347,17 +619,17 @@ func (svr *web3Handler) getTransactionCount(in *gjson.Result) (interface{}, erro
 	if !addr.Exists() {
 		return nil, errInvalidFormat
 	}
-	ioAddr, err := ethAddrToIoAddr(addr.String())
+	ioAddr, err := parseAddress(addr.String())
 	if err != nil {
 		return nil, err
 	}
 	// TODO (liuhaai): returns the nonce in given block height after archive mode is supported
 	// blkNum, err := getStringFromArray(in, 1)
-	pendingNonce, err := svr.coreService.PendingNonce(ioAddr)
+	detail, err := svr.coreService.AccountNonceDetail(ioAddr)
 	if err != nil {
 		return nil, err
 	}
-	return uint64ToHex(pendingNonce), nil
+	return uint64ToHex(detail.PendingNonce), nil
 }
 
 func (svr *web3Handler) call(in *gjson.Result) (interface{}, error) {
@@ -409,6 +681,54 @@ func (svr *web3Handler) call(in *gjson.Result) (interface{}, error) {
 	return "0x" + ret, nil
 }
 
+func (svr *web3Handler) simulateBatch(ctx context.Context, in *gjson.Result) (interface{}, error) {
+	req := in.Get("params.0")
+	callsJSON := req.Get("calls")
+	if !callsJSON.IsArray() {
+		return nil, errInvalidFormat
+	}
+	// the height of zero simulates on the tip
+	var height uint64
+	switch blkNum := req.Get("blockNumber").String(); blkNum {
+	case "", _pendingBlockNumber, _latestBlockNumber:
+	default:
+		num, err := svr.parseBlockNumber(blkNum)
+		if err != nil {
+			return nil, err
+		}
+		height = num
+	}
+	var calls []*apitypes.SimulateCall
+	for _, c := range callsJSON.Array() {
+		// reuse the parser of eth_call on each call object
+		callObj := gjson.Parse(`{"params":[` + c.Raw + `]}`)
+		callerAddr, to, gasLimit, _, value, data, err := parseCallObject(&callObj)
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, &apitypes.SimulateCall{
+			Caller:   callerAddr,
+			To:       to,
+			Amount:   value,
+			GasLimit: gasLimit,
+			Data:     data,
+		})
+	}
+	overrides, err := parseStateOverrides(req.Get("stateOverrides"))
+	if err != nil {
+		return nil, err
+	}
+	results, err := svr.coreService.SimulateBatch(ctx, height, calls, overrides, req.Get("continueOnFailure").Bool())
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*simulateCallResult, 0, len(results))
+	for _, r := range results {
+		ret = append(ret, &simulateCallResult{r})
+	}
+	return ret, nil
+}
+
 func (svr *web3Handler) estimateGas(in *gjson.Result) (interface{}, error) {
 	from, to, gasLimit, gasPrice, value, data, err := parseCallObject(in)
 	if err != nil {
@@ -462,29 +782,218 @@ func (svr *web3Handler) sendRawTransaction(in *gjson.Result) (interface{}, error
 	if !dataStr.Exists() {
 		return nil, errInvalidFormat
 	}
-	// parse raw data string from json request
+	req, err := svr.rawTxToAction(dataStr.String())
+	if err != nil {
+		return nil, err
+	}
+	actionHash, err := svr.coreService.SendAction(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+	return "0x" + actionHash, nil
+}
+
+// signTransaction signs the transaction with the local keystore into an action as ioctl signs it, the raw action can
+// be sent by ioctl action sendraw
+func (svr *web3Handler) signTransaction(in *gjson.Result) (interface{}, error) {
+	if svr.keystore == nil {
+		return nil, methodNotFound("eth_signTransaction")
+	}
+	if in.Get("params.0.from").String() == "" {
+		return nil, errors.Wrap(errInvalidFormat, "from is required")
+	}
+	from, to, gasLimit, gasPrice, value, data, err := parseCallObject(in)
+	if err != nil {
+		return nil, err
+	}
+	if gasLimit == 0 {
+		return nil, errors.Wrap(errInvalidFormat, "gas is required")
+	}
+	if !in.Get("params.0.gasPrice").Exists() {
+		price, err := svr.coreService.SuggestGasPrice()
+		if err != nil {
+			return nil, err
+		}
+		gasPrice = new(big.Int).SetUint64(price)
+	}
+	var nonce uint64
+	if nonceStr := in.Get("params.0.nonce").String(); nonceStr != "" {
+		if nonce, err = hexStringToNumber(nonceStr); err != nil {
+			return nil, err
+		}
+	} else {
+		detail, err := svr.coreService.AccountNonceDetail(from)
+		if err != nil {
+			return nil, err
+		}
+		nonce = detail.PendingNonce
+	}
+	var toAddr *common.Address
+	if len(to) != 0 {
+		addr, err := addrutil.IoAddrToEvmAddr(to)
+		if err != nil {
+			return nil, err
+		}
+		toAddr = &addr
+	}
+	elp, err := svr.ethTxToEnvelope(types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		GasPrice: gasPrice,
+		Gas:      gasLimit,
+		To:       toAddr,
+		Value:    value,
+		Data:     data,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := svr.keystore.Sign(from, elp)
+	if err != nil {
+		return nil, err
+	}
+	actBytes, err := proto.Marshal(sealed.Proto())
+	if err != nil {
+		return nil, err
+	}
+	actHash, err := sealed.Hash()
+	if err != nil {
+		return nil, err
+	}
+	return &signTransactionResult{
+		Raw:  "0x" + hex.EncodeToString(actBytes),
+		Hash: "0x" + hex.EncodeToString(actHash[:]),
+	}, nil
+}
+
+// cancelPendingTransaction returns the zero-value self-transfer of the nonce params.1 replacing the pending
+// transaction of the account params.0, with the gas price raised by params.2, or by the minimum the actpool accepts
+// if omitted. It's signed if the account is in the local keystore, otherwise left for the wallet to sign
+func (svr *web3Handler) cancelPendingTransaction(in *gjson.Result) (interface{}, error) {
+	fromStr, nonceStr, bumpStr := in.Get("params.0"), in.Get("params.1"), in.Get("params.2")
+	if !fromStr.Exists() || !nonceStr.Exists() {
+		return nil, errInvalidFormat
+	}
+	from, err := parseAddress(fromStr.String())
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hexStringToNumber(nonceStr.String())
+	if err != nil {
+		return nil, errors.Wrapf(errUnkownType, "nonce: %s", nonceStr.String())
+	}
+	var bump *big.Int
+	if bumpStr.Exists() {
+		if bump, err = hexutil.DecodeBig(bumpStr.String()); err != nil {
+			return nil, errors.Wrapf(errUnkownType, "gasPriceBump: %s", bumpStr.String())
+		}
+	}
+	cancel, err := svr.coreService.CancelPendingAction(from, nonce, bump)
+	if err != nil {
+		return nil, err
+	}
+	elp := cancel.Envelope
+	fromHex, err := ioAddrToEthAddr(from.String())
+	if err != nil {
+		return nil, err
+	}
+	replaces, err := cancel.Pending.Hash()
+	if err != nil {
+		return nil, err
+	}
+	ret := &cancelTransactionResult{
+		From:            fromHex,
+		To:              fromHex,
+		Nonce:           uint64ToHex(elp.Nonce()),
+		Gas:             uint64ToHex(elp.GasLimit()),
+		GasPrice:        hexutil.EncodeBig(elp.GasPrice()),
+		Value:           "0x0",
+		Input:           "0x",
+		ChainID:         svr.coreService.NetworkIdentity().EthChainID(),
+		Replaces:        "0x" + hex.EncodeToString(replaces[:]),
+		MinGasPriceBump: hexutil.EncodeBig(cancel.MinGasPriceBump),
+	}
+	if svr.keystore == nil {
+		return ret, nil
+	}
+	sealed, err := svr.keystore.Sign(from, elp)
+	switch {
+	case errors.Cause(err) == errUnknownAccount:
+		return ret, nil
+	case err != nil:
+		return nil, err
+	}
+	actBytes, err := proto.Marshal(sealed.Proto())
+	if err != nil {
+		return nil, err
+	}
+	actHash, err := sealed.Hash()
+	if err != nil {
+		return nil, err
+	}
+	ret.Signed = &signTransactionResult{
+		Raw:  "0x" + hex.EncodeToString(actBytes),
+		Hash: "0x" + hex.EncodeToString(actHash[:]),
+	}
+	return ret, nil
+}
+
+func (svr *web3Handler) validateRawTransaction(in *gjson.Result) (interface{}, error) {
+	dataStr := in.Get("params.0")
+	if !dataStr.Exists() {
+		return nil, errInvalidFormat
+	}
+	req, err := svr.rawTxToAction(dataStr.String())
+	if err != nil {
+		return nil, err
+	}
+	ret, err := svr.coreService.ValidateAction(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+	if ret.Reason != "" {
+		return &validateActionResult{
+			Reason:  ret.Reason,
+			Message: ret.Message,
+		}, nil
+	}
+	return &validateActionResult{
+		Accepted: true,
+		Gas:      uint64ToHex(ret.Gas),
+		Fee:      "0x" + ret.Fee.Text(16),
+	}, nil
+}
+
+// rawTxToAction parses the raw data string of a signed eth tx into the action to send
+func (svr *web3Handler) rawTxToAction(rawString string) (*iotextypes.Action, error) {
 	var (
-		cs        = svr.coreService
-		rawString = dataStr.String()
-		tx        *types.Transaction
-		encoding  iotextypes.Encoding
-		sig       []byte
-		pubkey    crypto.PublicKey
-		err       error
-		req       *iotextypes.Action
+		cs       = svr.coreService
+		tx       *types.Transaction
+		encoding iotextypes.Encoding
+		sig      []byte
+		pubkey   crypto.PublicKey
+		err      error
+		req      *iotextypes.Action
 	)
 	tx, err = action.DecodeEtherTx(rawString)
 	if err != nil {
 		return nil, err
 	}
-	if tx.Protected() && tx.ChainId().Uint64() != uint64(cs.EVMNetworkID()) {
-		return nil, errors.Wrapf(errInvalidEvmChainID, "expect chainID = %d, got %d", cs.EVMNetworkID(), tx.ChainId().Uint64())
-	}
 	encoding, sig, pubkey, err = action.ExtractTypeSigPubkey(tx)
 	if err != nil {
 		return nil, err
 	}
-	if g := cs.Genesis(); g.IsToBeEnabled(cs.TipHeight()) {
+	var (
+		g       = cs.Genesis()
+		enabled = g.IsToBeEnabled(cs.TipHeight())
+	)
+	// the pre-EIP155 tx is only accepted from the whitelisted replay deployers once enabled
+	if err = action.ValidateEthChainID(tx, cs.EVMNetworkID(), !enabled || g.IsDeployerWhitelisted(pubkey.Address())); err != nil {
+		if errors.Cause(err) == action.ErrChainID {
+			return nil, errors.Wrap(errInvalidEvmChainID, err.Error())
+		}
+		return nil, err
+	}
+	if enabled {
 		if strings.HasPrefix(rawString, "0x") || strings.HasPrefix(rawString, "0X") {
 			rawString = rawString[2:]
 		}
@@ -518,11 +1027,7 @@ func (svr *web3Handler) sendRawTransaction(in *gjson.Result) (interface{}, error
 			Encoding:     encoding,
 		}
 	}
-	actionHash, err := cs.SendAction(context.Background(), req)
-	if err != nil {
-		return nil, err
-	}
-	return "0x" + actionHash, nil
+	return req, nil
 }
 
 func (svr *web3Handler) getCode(in *gjson.Result) (interface{}, error) {
@@ -530,7 +1035,7 @@ func (svr *web3Handler) getCode(in *gjson.Result) (interface{}, error) {
 	if !addr.Exists() {
 		return nil, errInvalidFormat
 	}
-	ioAddr, err := ethAddrToIoAddr(addr.String())
+	ioAddr, err := parseAddress(addr.String())
 	if err != nil {
 		return nil, err
 	}
@@ -542,12 +1047,11 @@ func (svr *web3Handler) getCode(in *gjson.Result) (interface{}, error) {
 }
 
 func (svr *web3Handler) getNodeInfo() (interface{}, error) {
-	packageVersion, _, _, goVersion, _ := svr.coreService.ServerMeta()
-	return packageVersion + "/" + goVersion, nil
+	return svr.coreService.NetworkIdentity().ClientVersion(), nil
 }
 
 func (svr *web3Handler) getNetworkID() (interface{}, error) {
-	return strconv.Itoa(int(svr.coreService.EVMNetworkID())), nil
+	return svr.coreService.NetworkIdentity().NetVersion(), nil
 }
 
 func (svr *web3Handler) getPeerCount() (interface{}, error) {
@@ -563,14 +1067,17 @@ func (svr *web3Handler) getProtocolVersion() (interface{}, error) {
 }
 
 func (svr *web3Handler) isSyncing() (interface{}, error) {
-	start, curr, highest := svr.coreService.SyncingProgress()
-	if curr >= highest {
+	progress := svr.coreService.SyncingProgress()
+	if !progress.Syncing() {
 		return false, nil
 	}
 	return &getSyncingResult{
-		StartingBlock: uint64ToHex(start),
-		CurrentBlock:  uint64ToHex(curr),
-		HighestBlock:  uint64ToHex(highest),
+		StartingBlock:             uint64ToHex(progress.StartingHeight),
+		CurrentBlock:              uint64ToHex(progress.CurrentHeight),
+		HighestBlock:              uint64ToHex(progress.TargetHeight),
+		BlocksPerSecond:           progress.BlocksPerSecond,
+		EstimatedSecondsRemaining: uint64ToHex(uint64(progress.EstimatedRemaining.Seconds())),
+		Stage:                     string(progress.Stage),
 	}, nil
 }
 
@@ -619,98 +1126,816 @@ func (svr *web3Handler) getTransactionByHash(in *gjson.Result) (interface{}, err
 		return nil, err
 	}
 
-	selp, blk, _, err := svr.coreService.ActionByActionHash(actHash)
-	if err == nil {
-		receipt, err := svr.coreService.ReceiptByActionHash(actHash)
-		if err == nil {
-			return svr.assembleConfirmedTransaction(blk.HashBlock(), selp, receipt)
-		}
+	act, err := svr.coreService.ActionWithStatusByHash(actHash)
+	if err != nil {
 		if errors.Cause(err) == ErrNotFound {
 			return nil, nil
 		}
 		return nil, err
 	}
-	if errors.Cause(err) == ErrNotFound {
-		selp, err = svr.coreService.PendingActionByActionHash(actHash)
-		if err == nil {
-			return svr.assemblePendingTransaction(selp)
-		}
-		if errors.Cause(err) == ErrNotFound {
-			return nil, nil
-		}
-		return nil, err
+	switch act.Status {
+	case apitypes.ActionStatusConfirmed:
+		return svr.assembleConfirmedTransaction(act.Block.HashBlock(), act.Action, act.Receipt)
+	case apitypes.ActionStatusPending:
+		// a pending transaction has null blockHash, blockNumber and transactionIndex, as geth returns
+		return svr.assemblePendingTransaction(act.Action)
 	}
-	return nil, err
+	return nil, nil
 }
 
-func (svr *web3Handler) getLogs(filter *filterObject) (interface{}, error) {
-	from, to, err := svr.parseBlockRange(filter.FromBlock, filter.ToBlock)
+// getTransactionStatus returns whether the transaction of hash params.0 is pending, confirmed or not found, along
+// with the transaction, and its state in the actpool if pending, or its receipt if confirmed
+func (svr *web3Handler) getTransactionStatus(in *gjson.Result) (interface{}, error) {
+	txHash := in.Get("params.0")
+	if !txHash.Exists() {
+		return nil, errInvalidFormat
+	}
+	actHash, err := hash.HexStringToHash256(util.Remove0xPrefix(txHash.String()))
+	if err != nil {
+		return nil, errors.Wrapf(errUnkownType, "actHash: %s", txHash.String())
+	}
+	act, err := svr.coreService.ActionWithStatusByHash(actHash)
 	if err != nil {
 		return nil, err
 	}
-	return svr.getLogsWithFilter(from, to, filter.Address, filter.Topics)
+	ret := &getTransactionStatusResult{Status: act.Status}
+	switch act.Status {
+	case apitypes.ActionStatusConfirmed:
+		if ret.Transaction, err = svr.assembleConfirmedTransaction(act.Block.HashBlock(), act.Action, act.Receipt); err != nil {
+			return nil, err
+		}
+		if ret.Receipt, err = svr.assembleReceipt(act.Block, act.Action, act.Receipt); err != nil {
+			return nil, err
+		}
+	case apitypes.ActionStatusPending:
+		if ret.Transaction, err = svr.assemblePendingTransaction(act.Action); err != nil {
+			return nil, err
+		}
+		ret.Pool = &txPoolStateResult{
+			Position:   uint64ToHex(uint64(act.Pool.Position)),
+			Executable: act.Pool.Executable,
+			AddedAt:    uint64ToHex(uint64(act.Pool.AddedAt.Unix())),
+			TimeInPool: uint64ToHex(uint64(time.Since(act.Pool.AddedAt) / time.Second)),
+		}
+	}
+	return ret, nil
 }
 
-func (svr *web3Handler) getTransactionReceipt(in *gjson.Result) (interface{}, error) {
-	// parse action hash from request
-	actHashStr := in.Get("params.0")
-	if !actHashStr.Exists() {
+// getTransactionLogsByBlockRange returns the transaction logs of params.1 blocks from height params.0 in height order,
+// with only the transfers to the addresses in params.2 if any. The blocks may be cut short by the size limit of the
+// response, in which case the query continues from the next height returned
+func (svr *web3Handler) getTransactionLogsByBlockRange(in *gjson.Result) (interface{}, error) {
+	startStr, countStr := in.Get("params.0"), in.Get("params.1")
+	if !startStr.Exists() || !countStr.Exists() {
 		return nil, errInvalidFormat
 	}
-	actHash, err := hash.HexStringToHash256(util.Remove0xPrefix(actHashStr.String()))
+	start, err := hexStringToNumber(startStr.String())
 	if err != nil {
-		return nil, errors.Wrapf(errUnkownType, "actHash: %s", actHashStr.String())
+		return nil, errors.Wrapf(errUnkownType, "start: %s", startStr.String())
 	}
-
-	// acquire action receipt by action hash
-	selp, blk, _, err := svr.coreService.ActionByActionHash(actHash)
+	count, err := hexStringToNumber(countStr.String())
 	if err != nil {
-		if errors.Cause(err) == ErrNotFound {
-			return nil, nil
+		return nil, errors.Wrapf(errUnkownType, "count: %s", countStr.String())
+	}
+	var recipients []address.Address
+	for _, r := range in.Get("params.2").Array() {
+		addr, err := parseAddress(r.String())
+		if err != nil {
+			return nil, err
 		}
-		return nil, err
+		recipients = append(recipients, addr)
 	}
-	receipt, err := svr.coreService.ReceiptByActionHash(actHash)
+	blocks, next, err := svr.coreService.TransactionLogsByBlockHeightRange(start, count, recipients)
 	if err != nil {
-		if errors.Cause(err) == ErrNotFound {
-			return nil, nil
+		return nil, err
+	}
+	ret := &getTransactionLogsResult{
+		Blocks: make([]*blockTransactionLogsResult, 0, len(blocks)),
+		Next:   uint64ToHex(next),
+	}
+	for _, blk := range blocks {
+		blkRet, err := assembleBlockTransactionLogs(blk)
+		if err != nil {
+			return nil, err
 		}
+		ret.Blocks = append(ret.Blocks, blkRet)
+	}
+	return ret, nil
+}
+
+// getAccountNonceDetail returns the confirmed and pending nonce of the account params.0, along with the missing
+// nonces and the hashes of the transactions in the actpool by nonce
+func (svr *web3Handler) getAccountNonceDetail(in *gjson.Result) (interface{}, error) {
+	addr := in.Get("params.0")
+	if !addr.Exists() {
+		return nil, errInvalidFormat
+	}
+	ioAddr, err := parseAddress(addr.String())
+	if err != nil {
 		return nil, err
 	}
-	to, contractAddr, err := getRecipientAndContractAddrFromAction(selp, receipt)
+	detail, err := svr.coreService.AccountNonceDetail(ioAddr)
 	if err != nil {
 		return nil, err
 	}
-
-	// acquire logsBloom from blockMeta
-	var logsBloomStr string
-	if logsBloom := blk.LogsBloomfilter(); logsBloom != nil {
-		logsBloomStr = hex.EncodeToString(logsBloom.Bytes())
+	ret := &getAccountNonceDetailResult{
+		ConfirmedNonce: uint64ToHex(detail.ConfirmedNonce),
+		PendingNonce:   uint64ToHex(detail.PendingNonce),
+		Gaps:           make([]string, 0, len(detail.Gaps)),
+		Pending:        make([]*pendingNonceResult, 0, len(detail.Pending)),
+	}
+	for _, nonce := range detail.Gaps {
+		ret.Gaps = append(ret.Gaps, uint64ToHex(nonce))
 	}
+	for _, p := range detail.Pending {
+		ret.Pending = append(ret.Pending, &pendingNonceResult{
+			Nonce: uint64ToHex(p.Nonce),
+			Hash:  "0x" + hex.EncodeToString(p.Hash[:]),
+		})
+	}
+	return ret, nil
+}
 
-	return &getReceiptResult{
-		blockHash:       blk.HashBlock(),
-		from:            selp.SenderAddress(),
-		to:              to,
-		contractAddress: contractAddr,
-		logsBloom:       logsBloomStr,
-		receipt:         receipt,
+func (svr *web3Handler) getActPoolStatus() (interface{}, error) {
+	status := svr.coreService.ActPoolStatus()
+	return &getActPoolStatusResult{
+		Size:        uint64ToHex(status.Size),
+		Capacity:    uint64ToHex(status.Capacity),
+		GasSize:     uint64ToHex(status.GasSize),
+		GasCapacity: uint64ToHex(status.GasCapacity),
+		NoncePolicy: status.NoncePolicy,
 	}, nil
-
 }
 
-func (svr *web3Handler) getBlockTransactionCountByNumber(in *gjson.Result) (interface{}, error) {
+// getContractsCreatedByBlock returns the contracts created in the block number params.0, including the ones created by
+// contracts
+func (svr *web3Handler) getContractsCreatedByBlock(in *gjson.Result) (interface{}, error) {
 	blkNum := in.Get("params.0")
 	if !blkNum.Exists() {
 		return nil, errInvalidFormat
 	}
-	num, err := svr.parseBlockNumber(blkNum.String())
+	height, err := svr.parseBlockNumber(blkNum.String())
 	if err != nil {
 		return nil, err
 	}
-	blk, err := svr.coreService.BlockByHeight(num)
+	contracts, err := svr.coreService.ContractsCreatedByBlock(height)
 	if err != nil {
-		if errors.Cause(err) == ErrNotFound {
+		return nil, err
+	}
+	ret := make([]*createdContractResult, 0, len(contracts))
+	for _, c := range contracts {
+		addr, err := ioAddrToEthAddr(c.Address)
+		if err != nil {
+			return nil, err
+		}
+		creator, err := ioAddrToEthAddr(c.Creator)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, &createdContractResult{
+			TransactionHash: "0x" + hex.EncodeToString(c.ActionHash[:]),
+			Address:         addr,
+			Creator:         creator,
+			Depth:           uint64ToHex(uint64(c.Depth)),
+		})
+	}
+	return ret, nil
+}
+
+// getTotalSupply returns the total and circulating supply of token at the block number params.0, which is the latest
+// block if omitted, along with the base fee burnt and redistributed
+func (svr *web3Handler) getTotalSupply(ctx context.Context, in *gjson.Result) (interface{}, error) {
+	height, err := svr.parseBlockNumber(in.Get("params.0").String())
+	if err != nil {
+		return nil, err
+	}
+	supply, err := svr.coreService.TotalSupply(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return &getTotalSupplyResult{
+		BlockNumber:        uint64ToHex(supply.Height),
+		Total:              hexutil.EncodeBig(supply.Total),
+		Circulating:        hexutil.EncodeBig(supply.Circulating),
+		Burned:             hexutil.EncodeBig(supply.Burned),
+		Redistributed:      hexutil.EncodeBig(supply.Redistributed),
+		BlockBurned:        hexutil.EncodeBig(supply.BlockBurned),
+		BlockRedistributed: hexutil.EncodeBig(supply.BlockRedistributed),
+	}, nil
+}
+
+// getBuckets returns the staking buckets, including the ones staked by contracts, in the page of params.0 of the
+// latest block. The buckets are of the voter or the candidate name in params.0 if given, all buckets otherwise
+func (svr *web3Handler) getBuckets(in *gjson.Result) (interface{}, error) {
+	params := in.Get("params.0")
+	pagination, err := parseStakingPagination(params)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		voter, candidate = params.Get("voter"), params.Get("candidate")
+		method           iotexapi.ReadStakingDataMethod_Name
+		request          = &iotexapi.ReadStakingDataRequest{}
+	)
+	switch {
+	case voter.Exists() && candidate.Exists():
+		return nil, errInvalidFormat
+	case voter.Exists():
+		addr, err := parseAddress(voter.String())
+		if err != nil {
+			return nil, err
+		}
+		method = iotexapi.ReadStakingDataMethod_COMPOSITE_BUCKETS_BY_VOTER
+		request.Request = &iotexapi.ReadStakingDataRequest_BucketsByVoter{
+			BucketsByVoter: &iotexapi.ReadStakingDataRequest_VoteBucketsByVoter{VoterAddress: addr.String(), Pagination: pagination},
+		}
+	case candidate.Exists():
+		method = iotexapi.ReadStakingDataMethod_COMPOSITE_BUCKETS_BY_CANDIDATE
+		request.Request = &iotexapi.ReadStakingDataRequest_BucketsByCandidate{
+			BucketsByCandidate: &iotexapi.ReadStakingDataRequest_VoteBucketsByCandidate{CandName: candidate.String(), Pagination: pagination},
+		}
+	default:
+		method = iotexapi.ReadStakingDataMethod_COMPOSITE_BUCKETS
+		request.Request = &iotexapi.ReadStakingDataRequest_Buckets{
+			Buckets: &iotexapi.ReadStakingDataRequest_VoteBuckets{Pagination: pagination},
+		}
+	}
+	var buckets iotextypes.VoteBucketList
+	height, err := svr.readStakingData(method, request, &buckets)
+	if err != nil {
+		return nil, err
+	}
+	return &getBucketsResult{height: height, buckets: buckets.GetBuckets()}, nil
+}
+
+// getCandidates returns the staking candidates in the page of params.0 of the latest block
+func (svr *web3Handler) getCandidates(in *gjson.Result) (interface{}, error) {
+	pagination, err := parseStakingPagination(in.Get("params.0"))
+	if err != nil {
+		return nil, err
+	}
+	var (
+		candidates iotextypes.CandidateListV2
+		request    = &iotexapi.ReadStakingDataRequest{
+			Request: &iotexapi.ReadStakingDataRequest_Candidates_{
+				Candidates: &iotexapi.ReadStakingDataRequest_Candidates{Pagination: pagination},
+			},
+		}
+	)
+	height, err := svr.readStakingData(iotexapi.ReadStakingDataMethod_CANDIDATES, request, &candidates)
+	if err != nil {
+		return nil, err
+	}
+	return &getCandidatesResult{height: height, candidates: candidates.GetCandidates()}, nil
+}
+
+// getUnclaimedRewards returns the rewards of the account params.0 not claimed yet in the latest block
+func (svr *web3Handler) getUnclaimedRewards(in *gjson.Result) (interface{}, error) {
+	addrParam := in.Get("params.0")
+	if !addrParam.Exists() {
+		return nil, errInvalidFormat
+	}
+	addr, err := parseAddress(addrParam.String())
+	if err != nil {
+		return nil, err
+	}
+	res, err := svr.coreService.ReadState("rewarding", "", []byte("UnclaimedBalance"), [][]byte{[]byte(addr.String())})
+	if err != nil {
+		return nil, err
+	}
+	balance, ok := new(big.Int).SetString(string(res.GetData()), 10)
+	if !ok {
+		return nil, errors.Errorf("invalid unclaimed balance %s", res.GetData())
+	}
+	return &getUnclaimedRewardsResult{
+		Address:     newAddressResult(addr),
+		Balance:     hexutil.EncodeBig(balance),
+		BlockNumber: uint64ToHex(res.GetBlockIdentifier().GetHeight()),
+	}, nil
+}
+
+// getEpochMeta returns the meta of the epoch params.0 and the block producers of the epoch
+func (svr *web3Handler) getEpochMeta(in *gjson.Result) (interface{}, error) {
+	epochStr := in.Get("params.0")
+	if !epochStr.Exists() {
+		return nil, errInvalidFormat
+	}
+	epoch, err := hexStringToNumber(epochStr.String())
+	if err != nil {
+		return nil, errors.Wrapf(errUnkownType, "epoch: %s", epochStr.String())
+	}
+	data, totalBlocks, producers, err := svr.coreService.EpochMeta(epoch)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+	return &getEpochMetaResult{data: data, totalBlocks: totalBlocks, producers: producers}, nil
+}
+
+// getActionByHash returns the action of the hash params.0 in the native format of iotex, pending or committed
+func (svr *web3Handler) getActionByHash(in *gjson.Result) (interface{}, error) {
+	h := in.Get("params.0")
+	if !h.Exists() {
+		return nil, errInvalidFormat
+	}
+	info, err := svr.coreService.Action(util.Remove0xPrefix(h.String()), true)
+	if err != nil {
+		return nil, err
+	}
+	selp, err := (&action.Deserializer{}).SetEvmNetworkID(svr.coreService.EVMNetworkID()).ActionToSealedEnvelope(info.GetAction())
+	if err != nil {
+		return nil, err
+	}
+	return &getActionResult{info: info, selp: selp}, nil
+}
+
+// readStakingData reads the staking data of the method and the request in the latest block into out, and returns
+// the height of the block
+func (svr *web3Handler) readStakingData(method iotexapi.ReadStakingDataMethod_Name, request *iotexapi.ReadStakingDataRequest, out proto.Message) (uint64, error) {
+	methodName, err := proto.Marshal(&iotexapi.ReadStakingDataMethod{Method: method})
+	if err != nil {
+		return 0, err
+	}
+	arg, err := proto.Marshal(request)
+	if err != nil {
+		return 0, err
+	}
+	res, err := svr.coreService.ReadState("staking", "", methodName, [][]byte{arg})
+	if err != nil {
+		return 0, err
+	}
+	if err := proto.Unmarshal(res.GetData(), out); err != nil {
+		return 0, errors.Wrap(err, "failed to unmarshal staking data")
+	}
+	return res.GetBlockIdentifier().GetHeight(), nil
+}
+
+// parseStakingPagination parses the offset and the limit of a page of buckets or candidates
+func parseStakingPagination(params gjson.Result) (*iotexapi.PaginationParam, error) {
+	var (
+		offset uint64
+		limit  = uint64(_defaultStakingPageLimit)
+		err    error
+	)
+	for _, field := range []struct {
+		name  string
+		value *uint64
+	}{
+		{"offset", &offset},
+		{"limit", &limit},
+	} {
+		if v := params.Get(field.name); v.Exists() {
+			if *field.value, err = hexStringToNumber(v.String()); err != nil {
+				return nil, errors.Wrapf(errUnkownType, "%s: %s", field.name, v.String())
+			}
+		}
+	}
+	if offset > math.MaxUint32 {
+		return nil, errors.Wrapf(errUnkownType, "offset: %d", offset)
+	}
+	if limit > _maxStakingPageLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit %d exceeds the maximum %d", limit, _maxStakingPageLimit)
+	}
+	return &iotexapi.PaginationParam{Offset: uint32(offset), Limit: uint32(limit)}, nil
+}
+
+// getRawBlock returns the serialized protos of the parts params.1 of the block params.0, which is a block hash or a
+// block number. The parts are any of "header", "body", "footer" and "receipts", all of them if omitted
+func (svr *web3Handler) getRawBlock(in *gjson.Result) (interface{}, error) {
+	blkParam := in.Get("params.0")
+	if !blkParam.Exists() {
+		return nil, errInvalidFormat
+	}
+	parts := apitypes.RawBlockAll
+	if partsParam := in.Get("params.1"); partsParam.Exists() {
+		parts = 0
+		for _, part := range partsParam.Array() {
+			p, ok := _rawBlockParts[part.String()]
+			if !ok {
+				return nil, errors.Wrapf(errUnkownType, "part: %s", part.String())
+			}
+			parts |= p
+		}
+	}
+	var (
+		blk    *apitypes.RawBlock
+		h      hash.Hash256
+		height uint64
+		err    error
+	)
+	if str := util.Remove0xPrefix(blkParam.String()); len(str) == 2*len(h) {
+		if h, err = hash.HexStringToHash256(str); err != nil {
+			return nil, errors.Wrapf(errUnkownType, "blockHash: %s", blkParam.String())
+		}
+		blk, err = svr.coreService.RawBlockByHash(h, parts)
+	} else {
+		if height, err = svr.parseBlockNumber(blkParam.String()); err != nil {
+			return nil, err
+		}
+		blk, err = svr.coreService.RawBlockByHeight(height, parts)
+	}
+	if err != nil {
+		if errors.Cause(err) == ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return newRawBlockResult(blk), nil
+}
+
+// getRawHeaders returns the serialized headers of params.1 blocks from the block number params.0, which are cut at
+// the tip and at 1000 headers
+func (svr *web3Handler) getRawHeaders(in *gjson.Result) (interface{}, error) {
+	startStr, countStr := in.Get("params.0"), in.Get("params.1")
+	if !startStr.Exists() || !countStr.Exists() {
+		return nil, errInvalidFormat
+	}
+	start, err := hexStringToNumber(startStr.String())
+	if err != nil {
+		return nil, errors.Wrapf(errUnkownType, "start: %s", startStr.String())
+	}
+	count, err := hexStringToNumber(countStr.String())
+	if err != nil {
+		return nil, errors.Wrapf(errUnkownType, "count: %s", countStr.String())
+	}
+	headers, err := svr.coreService.RawHeaders(start, count)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*rawBlockResult, 0, len(headers))
+	for _, header := range headers {
+		ret = append(ret, newRawBlockResult(header))
+	}
+	return ret, nil
+}
+
+// getActionInclusionProof returns the merkle proof of the transaction of hash params.0 included in a block, along with
+// the serialized header of the block, whose hash is the block hash
+func (svr *web3Handler) getActionInclusionProof(in *gjson.Result) (interface{}, error) {
+	txHash := in.Get("params.0")
+	if !txHash.Exists() {
+		return nil, errInvalidFormat
+	}
+	actHash, err := hash.HexStringToHash256(util.Remove0xPrefix(txHash.String()))
+	if err != nil {
+		return nil, errors.Wrapf(errUnkownType, "actHash: %s", txHash.String())
+	}
+	proof, err := svr.coreService.ActionInclusionProof(actHash)
+	if err != nil {
+		if errors.Cause(err) == ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return newActionInclusionProofResult(proof), nil
+}
+
+func (svr *web3Handler) getLogs(filter *filterObject) (interface{}, error) {
+	from, to, err := svr.parseBlockRange(filter.FromBlock, filter.ToBlock)
+	if err != nil {
+		return nil, err
+	}
+	return svr.getLogsWithFilter(from, to, filter.Address, filter.Topics)
+}
+
+// getTokenTransfers returns the token transfers of the address in params.0.address, within the block range of
+// params.0.fromBlock and params.0.toBlock, starting from params.0.cursor returned by the previous call
+func (svr *web3Handler) getTokenTransfers(in *gjson.Result, query func(address.Address, *blockindex.TokenTransferQuery) ([]*blockindex.TokenTransfer, uint64, error)) (interface{}, error) {
+	params := in.Get("params.0")
+	addr := params.Get("address")
+	if !addr.Exists() {
+		return nil, errInvalidFormat
+	}
+	ioAddr, err := parseAddress(addr.String())
+	if err != nil {
+		return nil, err
+	}
+	q, err := svr.parseTransferQuery(params)
+	if err != nil {
+		return nil, err
+	}
+	tsfs, next, err := query(ioAddr, q)
+	if err != nil {
+		return nil, err
+	}
+	return &getTokenTransfersResult{transfers: tsfs, cursor: next}, nil
+}
+
+// getTransfersByRecipientAndMemo returns the transfers to params.0.recipient with the memo params.0.memo, within
+// the block range of params.0.fromBlock and params.0.toBlock, starting from params.0.cursor returned by the
+// previous call
+func (svr *web3Handler) getTransfersByRecipientAndMemo(in *gjson.Result) (interface{}, error) {
+	params := in.Get("params.0")
+	recipient, memo := params.Get("recipient"), params.Get("memo")
+	if !recipient.Exists() || memo.Type != gjson.String {
+		return nil, errInvalidFormat
+	}
+	ioAddr, err := parseAddress(recipient.String())
+	if err != nil {
+		return nil, err
+	}
+	q, err := svr.parseTransferQuery(params)
+	if err != nil {
+		return nil, err
+	}
+	tsfs, next, err := svr.coreService.TransfersByRecipientAndMemo(ioAddr, memo.String(), (*blockindex.MemoTransferQuery)(q))
+	if err != nil {
+		return nil, err
+	}
+	return &getMemoTransfersResult{transfers: tsfs, cursor: next}, nil
+}
+
+// getBlockNumberByTimestamp returns the number of the last block at or before params.0.timestamp in unix seconds,
+// or the first block at or after it if params.0.closest is "after"
+func (svr *web3Handler) getBlockNumberByTimestamp(in *gjson.Result) (interface{}, error) {
+	params := in.Get("params.0")
+	timestamp := params.Get("timestamp")
+	if !timestamp.Exists() {
+		return nil, errInvalidFormat
+	}
+	ts, err := parseUnixTime(timestamp.String())
+	if err != nil {
+		return nil, err
+	}
+	before := true
+	if closest := params.Get("closest"); closest.Exists() {
+		switch closest.String() {
+		case "before":
+		case "after":
+			before = false
+		default:
+			return nil, errors.Wrapf(errUnkownType, "closest: %s", closest.String())
+		}
+	}
+	height, err := svr.coreService.BlockHeightByTimestamp(ts, before)
+	if err != nil {
+		if errors.Cause(err) == ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return uint64ToHex(height), nil
+}
+
+// getBlockMetasByTimestampRange returns the metas of at most params.0.limit blocks with the time from
+// params.0.fromTime to params.0.toTime in unix seconds, skipping the first params.0.offset of them
+func (svr *web3Handler) getBlockMetasByTimestampRange(in *gjson.Result) (interface{}, error) {
+	var (
+		params           = in.Get("params.0")
+		fromTime, toTime = params.Get("fromTime"), params.Get("toTime")
+		offset           uint64
+		limit            = uint64(_defaultBlockMetasLimit)
+		err              error
+	)
+	if !fromTime.Exists() || !toTime.Exists() {
+		return nil, errInvalidFormat
+	}
+	from, err := parseUnixTime(fromTime.String())
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseUnixTime(toTime.String())
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range []struct {
+		name  string
+		value *uint64
+	}{
+		{"offset", &offset},
+		{"limit", &limit},
+	} {
+		if v := params.Get(field.name); v.Exists() {
+			if *field.value, err = hexStringToNumber(v.String()); err != nil {
+				return nil, errors.Wrapf(errUnkownType, "%s: %s", field.name, v.String())
+			}
+		}
+	}
+	metas, total, err := svr.coreService.BlockMetasByTimestampRange(from, to, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &getBlockMetasResult{metas: metas, total: total}, nil
+}
+
+// getProtocolParameters returns the height-gated parameters of the protocols in force at the block in params.0, the
+// latest block if omitted, and the activations after the block
+func (svr *web3Handler) getProtocolParameters(in *gjson.Result) (interface{}, error) {
+	height, err := svr.parseBlockNumber(in.Get("params.0").String())
+	if err != nil {
+		return nil, errors.Wrapf(errUnkownType, "blockNumber: %s", in.Get("params.0").String())
+	}
+	inForce, upcoming, err := svr.coreService.ProtocolParameters(height)
+	if err != nil {
+		return nil, err
+	}
+	return &getProtocolParametersResult{height: height, inForce: inForce, upcoming: upcoming}, nil
+}
+
+// parseTransferQuery parses the cursor, limit, fromBlock and toBlock of a transfer query
+func (svr *web3Handler) parseTransferQuery(params gjson.Result) (*blockindex.TokenTransferQuery, error) {
+	var (
+		q   = &blockindex.TokenTransferQuery{Count: _defaultTokenTransfersLimit}
+		err error
+	)
+	for _, field := range []struct {
+		name  string
+		value *uint64
+	}{
+		{"cursor", &q.Cursor},
+		{"limit", &q.Count},
+	} {
+		if v := params.Get(field.name); v.Exists() {
+			if *field.value, err = hexStringToNumber(v.String()); err != nil {
+				return nil, errors.Wrapf(errUnkownType, "%s: %s", field.name, v.String())
+			}
+		}
+	}
+	if v := params.Get("fromBlock"); v.Exists() {
+		if q.FromHeight, err = svr.parseBlockNumber(v.String()); err != nil {
+			return nil, err
+		}
+	}
+	if v := params.Get("toBlock"); v.Exists() {
+		if q.ToHeight, err = svr.parseBlockNumber(v.String()); err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+// getCandidateHistory returns the history of the candidate in params.0.candidate, from params.0.startEpoch to
+// params.0.endEpoch
+func (svr *web3Handler) getCandidateHistory(in *gjson.Result) (interface{}, error) {
+	var (
+		params                          = in.Get("params.0")
+		candidate, startEpoch, endEpoch = params.Get("candidate"), params.Get("startEpoch"), params.Get("endEpoch")
+	)
+	if !candidate.Exists() || !startEpoch.Exists() || !endEpoch.Exists() {
+		return nil, errInvalidFormat
+	}
+	ioAddr, err := parseAddress(candidate.String())
+	if err != nil {
+		return nil, err
+	}
+	start, err := hexStringToNumber(startEpoch.String())
+	if err != nil {
+		return nil, errors.Wrapf(errUnkownType, "startEpoch: %s", startEpoch.String())
+	}
+	end, err := hexStringToNumber(endEpoch.String())
+	if err != nil {
+		return nil, errors.Wrapf(errUnkownType, "endEpoch: %s", endEpoch.String())
+	}
+	histories, err := svr.coreService.CandidateHistory(ioAddr, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return &getCandidateHistoryResult{histories: histories}, nil
+}
+
+// getEpochRanking returns the candidates at the end of the epoch in params.0 in the order of rank
+func (svr *web3Handler) getEpochRanking(in *gjson.Result) (interface{}, error) {
+	epochStr := in.Get("params.0")
+	if !epochStr.Exists() {
+		return nil, errInvalidFormat
+	}
+	epoch, err := hexStringToNumber(epochStr.String())
+	if err != nil {
+		return nil, errors.Wrapf(errUnkownType, "epoch: %s", epochStr.String())
+	}
+	histories, err := svr.coreService.EpochRanking(epoch)
+	if err != nil {
+		return nil, err
+	}
+	return &getCandidateHistoryResult{histories: histories}, nil
+}
+
+// getSystemActions returns the system actions of the block height or epoch in params.0
+func (svr *web3Handler) getSystemActions(in *gjson.Result, query func(uint64) ([]*blockindex.SystemAction, error)) (interface{}, error) {
+	numStr := in.Get("params.0")
+	if !numStr.Exists() {
+		return nil, errInvalidFormat
+	}
+	num, err := hexStringToNumber(numStr.String())
+	if err != nil {
+		return nil, errors.Wrapf(errUnkownType, "number: %s", numStr.String())
+	}
+	sas, err := query(num)
+	if err != nil {
+		return nil, err
+	}
+	return &getSystemActionsResult{actions: sas}, nil
+}
+
+// getContractStats returns the daily call stats of the contract in params.0.address, from params.0.fromDate to
+// params.0.toDate in the format of 2006-01-02
+func (svr *web3Handler) getContractStats(in *gjson.Result) (interface{}, error) {
+	var (
+		params                     = in.Get("params.0")
+		contract, fromDate, toDate = params.Get("address"), params.Get("fromDate"), params.Get("toDate")
+	)
+	if !contract.Exists() || !fromDate.Exists() || !toDate.Exists() {
+		return nil, errInvalidFormat
+	}
+	ioAddr, err := parseAddress(contract.String())
+	if err != nil {
+		return nil, err
+	}
+	from, to, err := parseDayRange(fromDate.String(), toDate.String())
+	if err != nil {
+		return nil, err
+	}
+	stats, err := svr.coreService.ContractStats(ioAddr, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return &getContractStatsResult{stats: stats}, nil
+}
+
+// getTopContracts returns at most params.0.limit contracts with the most stats of params.0.orderBy, one of calls,
+// gas, failures and callers, from params.0.fromDate to params.0.toDate in the format of 2006-01-02
+func (svr *web3Handler) getTopContracts(in *gjson.Result) (interface{}, error) {
+	var (
+		params           = in.Get("params.0")
+		fromDate, toDate = params.Get("fromDate"), params.Get("toDate")
+		order            = blockindex.OrderByCalls
+		limit            = uint64(_defaultTopContractsLimit)
+	)
+	if !fromDate.Exists() || !toDate.Exists() {
+		return nil, errInvalidFormat
+	}
+	from, to, err := parseDayRange(fromDate.String(), toDate.String())
+	if err != nil {
+		return nil, err
+	}
+	if v := params.Get("orderBy"); v.Exists() {
+		switch v.String() {
+		case "calls":
+		case "gas":
+			order = blockindex.OrderByGasConsumed
+		case "failures":
+			order = blockindex.OrderByFailures
+		case "callers":
+			order = blockindex.OrderByUniqueCallers
+		default:
+			return nil, errors.Wrapf(errUnkownType, "orderBy: %s", v.String())
+		}
+	}
+	if v := params.Get("limit"); v.Exists() {
+		if limit, err = hexStringToNumber(v.String()); err != nil {
+			return nil, errors.Wrapf(errUnkownType, "limit: %s", v.String())
+		}
+	}
+	stats, err := svr.coreService.TopContracts(from, to, order, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &getTopContractsResult{stats: stats}, nil
+}
+
+func (svr *web3Handler) getTransactionReceipt(in *gjson.Result) (interface{}, error) {
+	// parse action hash from request
+	actHashStr := in.Get("params.0")
+	if !actHashStr.Exists() {
+		return nil, errInvalidFormat
+	}
+	actHash, err := hash.HexStringToHash256(util.Remove0xPrefix(actHashStr.String()))
+	if err != nil {
+		return nil, errors.Wrapf(errUnkownType, "actHash: %s", actHashStr.String())
+	}
+
+	// acquire action receipt by action hash
+	selp, blk, _, err := svr.coreService.ActionByActionHash(actHash)
+	if err != nil {
+		if errors.Cause(err) == ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	receipt, err := svr.coreService.ReceiptByActionHash(actHash)
+	if err != nil {
+		if errors.Cause(err) == ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return svr.assembleReceipt(blk, selp, receipt)
+}
+
+func (svr *web3Handler) getBlockTransactionCountByNumber(in *gjson.Result) (interface{}, error) {
+	blkNum := in.Get("params.0")
+	if !blkNum.Exists() {
+		return nil, errInvalidFormat
+	}
+	num, err := svr.parseBlockNumber(blkNum.String())
+	if err != nil {
+		return nil, err
+	}
+	blk, err := svr.coreService.BlockByHeight(num)
+	if err != nil {
+		if errors.Cause(err) == ErrNotFound {
 			return nil, nil
 		}
 		return nil, err
@@ -797,7 +2022,7 @@ func (svr *web3Handler) newFilter(filter *filterObject) (interface{}, error) {
 		return nil, errors.Wrapf(errUnkownType, "to: %s", filter.ToBlock)
 	}
 	for _, ethAddr := range filter.Address {
-		if _, err := ethAddrToIoAddr(ethAddr); err != nil {
+		if _, err := parseAddress(ethAddr); err != nil {
 			return nil, err
 		}
 	}
@@ -979,6 +2204,42 @@ func (svr *web3Handler) traceTransaction(ctx context.Context, in *gjson.Result)
 	if !actHash.Exists() {
 		return nil, errInvalidFormat
 	}
+	retval, receipt, tracer, err := svr.coreService.TraceTransaction(ctx, actHash.String(), parseTraceConfig(options))
+	if err != nil {
+		return nil, err
+	}
+	return traceResult(retval, receipt, tracer)
+}
+
+func (svr *web3Handler) traceBlockByNumber(ctx context.Context, in *gjson.Result) (interface{}, error) {
+	blkNum, options := in.Get("params.0"), in.Get("params.1")
+	if !blkNum.Exists() {
+		return nil, errInvalidFormat
+	}
+	height, err := svr.parseBlockNumber(blkNum.String())
+	if err != nil {
+		return nil, err
+	}
+	traces, err := svr.coreService.TraceBlockByNumber(ctx, height, parseTraceConfig(options))
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*debugTraceBlockResult, 0, len(traces))
+	for _, trace := range traces {
+		res, err := traceResult(trace.ReturnValue, trace.Receipt, trace.Tracer)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, &debugTraceBlockResult{
+			TxHash: "0x" + hex.EncodeToString(trace.ActionHash[:]),
+			Result: res,
+		})
+	}
+	return ret, nil
+}
+
+// parseTraceConfig parses the options of debug_traceTransaction and debug_traceBlockByNumber
+func parseTraceConfig(options gjson.Result) *tracers.TraceConfig {
 	var (
 		enableMemory, disableStack, disableStorage, enableReturnData bool
 	)
@@ -1003,10 +2264,15 @@ func (svr *web3Handler) traceTransaction(ctx context.Context, in *gjson.Result)
 			cfg.TracerConfig = json.RawMessage(tracerConfig.Raw)
 		}
 	}
-	retval, receipt, tracer, err := svr.coreService.TraceTransaction(ctx, actHash.String(), cfg)
-	if err != nil {
-		return nil, err
+	if timeout := options.Get("timeout"); timeout.Exists() {
+		cfg.Timeout = new(string)
+		*cfg.Timeout = timeout.String()
 	}
+	return cfg
+}
+
+// traceResult returns the result of the tracer in the format of geth
+func traceResult(retval []byte, receipt *action.Receipt, tracer any) (any, error) {
 	switch tracer := tracer.(type) {
 	case *logger.StructLogger:
 		return &debugTraceTransactionResult{
@@ -1080,22 +2346,25 @@ func (svr *web3Handler) traceCall(ctx context.Context, in *gjson.Result) (interf
 	if err != nil {
 		return nil, err
 	}
-	switch tracer := tracer.(type) {
-	case *logger.StructLogger:
-		return &debugTraceTransactionResult{
-			Failed:      receipt.Status != uint64(iotextypes.ReceiptStatus_Success),
-			Revert:      receipt.ExecutionRevertMsg(),
-			ReturnValue: byteToHex(retval),
-			StructLogs:  fromLoggerStructLogs(tracer.StructLogs()),
-			Gas:         receipt.GasConsumed,
-		}, nil
-	case tracers.Tracer:
-		return tracer.GetResult()
-	default:
-		return nil, fmt.Errorf("unknown tracer type: %T", tracer)
+	return traceResult(retval, receipt, tracer)
+}
+
+// web3IotexMethods returns the supported methods of the iotex namespace in the capability table, sorted by names
+func web3IotexMethods() []string {
+	methods := make([]string, 0, len(_web3MethodCapabilities))
+	for method, supported := range _web3MethodCapabilities {
+		if supported {
+			methods = append(methods, method)
+		}
 	}
+	sort.Strings(methods)
+	return methods
 }
 
-func (svr *web3Handler) unimplemented() (interface{}, error) {
-	return nil, errNotImplemented
+// methodNotFound returns the error of a method unknown or unsupported, which is answered with the code -32601
+func methodNotFound(method string) error {
+	if supported, ok := _web3MethodCapabilities[method]; ok && !supported {
+		return errors.Wrapf(errMethodNotFound, "%s is not supported", method)
+	}
+	return errors.Wrapf(errMethodNotFound, "%s does not exist", method)
 }
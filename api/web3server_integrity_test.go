@@ -14,6 +14,7 @@ import (
 	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -21,6 +22,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/iotexproject/go-pkgs/util"
 	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/stretchr/testify/require"
 
@@ -227,6 +229,22 @@ func getBlockByNumber(t *testing.T, handler *hTTPHandler) {
 			require.Equal(test.expected, len(actual.([]interface{})))
 		}
 	}
+	// the finalized and the safe blocks are the tip, as no finality reader is set
+	for _, test := range []struct {
+		tag      string
+		expected uint64
+	}{
+		{"latest", 4},
+		{"pending", 4},
+		{"finalized", 4},
+		{"safe", 4},
+		{"earliest", 1},
+	} {
+		result := serveTestHTTP(require, handler, "eth_getBlockByNumber", fmt.Sprintf(`["%s", false]`, test.tag))
+		actual, ok := result.(map[string]interface{})["number"]
+		require.True(ok, test.tag)
+		require.Equal(uint64ToHex(test.expected), actual, test.tag)
+	}
 }
 
 func getBalance(t *testing.T, handler *hTTPHandler) {
@@ -304,7 +322,7 @@ func getNodeInfo(t *testing.T, handler *hTTPHandler) {
 	result := serveTestHTTP(require, handler, "web3_clientVersion", "[]")
 	actual, ok := result.(string)
 	require.True(ok)
-	require.Equal("NoBuildInfo/NoBuildInfo", actual)
+	require.Equal(fmt.Sprintf("iotex-core/NoBuildInfo/%s-%s/%s", runtime.GOOS, runtime.GOARCH, runtime.Version()), actual)
 }
 
 func getBlockTransactionCountByHash(t *testing.T, handler *hTTPHandler, bc blockchain.Blockchain) {
@@ -719,6 +737,11 @@ func sendRawTransaction(t *testing.T, handler *hTTPHandler) {
 	actual, ok := result.(string)
 	require.True(ok)
 	require.Equal(tx.Hash().Hex(), actual)
+	// the hash is returned for the known transaction
+	result = serveTestHTTP(require, handler, "eth_sendRawTransaction", fmt.Sprintf(`["%s"]`, rawData))
+	actual, ok = result.(string)
+	require.True(ok)
+	require.Equal(tx.Hash().Hex(), actual)
 }
 
 func estimateGas(t *testing.T, handler *hTTPHandler, bc blockchain.Blockchain, dao blockdao.BlockDAO, actPool actpool.ActPool) {
@@ -816,3 +839,40 @@ func getStorageAt(t *testing.T, handler *hTTPHandler, bc blockchain.Blockchain,
 		require.Equal("0x0000000000000000000000000000000000000000000000000000000000000000", actual)
 	}
 }
+
+// TestNetworkIdentityIntegrity pins the outputs of the APIs answering the identity of the network, which must all
+// read from the same source
+func TestNetworkIdentityIntegrity(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig()
+	cfg.chain.ID = 1
+	cfg.chain.EVMNetworkID = 4689
+	svr, bc, _, _, _, _, bfIndexFile, err := createServerV2(cfg, false)
+	require.NoError(err)
+	defer testutil.CleanupPath(bfIndexFile)
+	handler := newHTTPHandler(NewWeb3Handler(svr.core, "", _defaultBatchRequestLimit))
+
+	clientVersion := fmt.Sprintf("iotex-core/NoBuildInfo/%s-%s/%s", runtime.GOOS, runtime.GOARCH, runtime.Version())
+	for _, v := range []struct {
+		method, expected string
+	}{
+		{"eth_chainId", "0x1251"},
+		{"net_version", "4689"},
+		{"web3_clientVersion", clientVersion},
+	} {
+		require.Equal(v.expected, serveTestHTTP(require, handler, v.method, "[]"), v.method)
+	}
+
+	id := svr.core.NetworkIdentity()
+	require.Equal(clientVersion, id.ClientVersion())
+	g := bc.Genesis()
+	require.Equal(g.Hash(), id.GenesisHash)
+	grpcHandler := newGRPCHandler(svr.core)
+	chainMeta, err := grpcHandler.GetChainMeta(context.Background(), &iotexapi.GetChainMetaRequest{})
+	require.NoError(err)
+	require.EqualValues(1, chainMeta.GetChainMeta().GetChainID())
+	serverMeta, err := grpcHandler.GetServerMeta(context.Background(), &iotexapi.GetServerMetaRequest{})
+	require.NoError(err)
+	require.Equal("NoBuildInfo", serverMeta.GetServerMeta().GetPackageVersion())
+	require.Equal(runtime.Version(), serverMeta.GetServerMeta().GetGoVersion())
+}
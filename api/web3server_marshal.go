@@ -3,19 +3,28 @@ package api
 import (
 	"encoding/hex"
 	"encoding/json"
+	"math/big"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/staking"
 	apitypes "github.com/iotexproject/iotex-core/api/types"
 	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockindex"
 )
 
 const (
@@ -72,10 +81,246 @@ type (
 		log       *action.Log
 	}
 
+	getTokenTransfersResult struct {
+		transfers []*blockindex.TokenTransfer
+		cursor    uint64
+	}
+
+	getMemoTransfersResult struct {
+		transfers []*blockindex.MemoTransfer
+		cursor    uint64
+	}
+
+	getBlockMetasResult struct {
+		metas []*iotextypes.BlockMeta
+		total uint64
+	}
+
+	getProtocolParametersResult struct {
+		height   uint64
+		inForce  []protocol.Parameter
+		upcoming []protocol.Parameter
+	}
+
+	getCandidateHistoryResult struct {
+		histories []*staking.CandidateHistory
+	}
+
+	getSystemActionsResult struct {
+		actions []*blockindex.SystemAction
+	}
+
+	getContractStatsResult struct {
+		stats []*blockindex.ContractDayStats
+	}
+
+	getTopContractsResult struct {
+		stats []*blockindex.ContractStats
+	}
+
+	simulateCallResult struct {
+		result *apitypes.SimulateResult
+	}
+
+	getGasPricesResult struct {
+		Slow     string `json:"slow"`
+		Standard string `json:"standard"`
+		Fast     string `json:"fast"`
+	}
+
+	signTransactionResult struct {
+		Raw  string `json:"raw"`
+		Hash string `json:"hash"`
+	}
+
+	// cancelTransactionResult is the transaction replacing a pending one of the same nonce, in the fields of
+	// eth_signTransaction for the wallet to sign. Signed is the raw action if signed by the local keystore
+	cancelTransactionResult struct {
+		From            string                 `json:"from"`
+		To              string                 `json:"to"`
+		Nonce           string                 `json:"nonce"`
+		Gas             string                 `json:"gas"`
+		GasPrice        string                 `json:"gasPrice"`
+		Value           string                 `json:"value"`
+		Input           string                 `json:"input"`
+		ChainID         string                 `json:"chainId"`
+		Replaces        string                 `json:"replaces"`
+		MinGasPriceBump string                 `json:"minGasPriceBump"`
+		Signed          *signTransactionResult `json:"signed,omitempty"`
+	}
+
+	// addressResult is an address in the io format and its 0x equivalent
+	addressResult struct {
+		IoAddress  string `json:"ioAddress"`
+		HexAddress string `json:"hexAddress"`
+	}
+
+	getBucketsResult struct {
+		height  uint64
+		buckets []*iotextypes.VoteBucket
+	}
+
+	getCandidatesResult struct {
+		height     uint64
+		candidates []*iotextypes.CandidateV2
+	}
+
+	getUnclaimedRewardsResult struct {
+		Address     *addressResult `json:"address"`
+		Balance     string         `json:"unclaimedBalance"`
+		BlockNumber string         `json:"blockNumber"`
+	}
+
+	getEpochMetaResult struct {
+		data        *iotextypes.EpochData
+		totalBlocks uint64
+		producers   []*iotexapi.BlockProducerInfo
+	}
+
+	// getActionResult is the action in the native format, with the block of the action if committed
+	getActionResult struct {
+		info *iotexapi.ActionInfo
+		selp *action.SealedEnvelope
+	}
+
+	convertAddressResult struct {
+		IoAddress      string  `json:"ioAddress"`
+		HexAddress     *string `json:"hexAddress"`
+		Format         string  `json:"format"`
+		SystemContract string  `json:"systemContract,omitempty"`
+		Kind           string  `json:"kind"`
+	}
+
+	getTransactionStatusResult struct {
+		Status      string                `json:"status"`
+		Transaction *getTransactionResult `json:"transaction"`
+		Receipt     *getReceiptResult     `json:"receipt,omitempty"`
+		Pool        *txPoolStateResult    `json:"pool,omitempty"`
+	}
+
+	getTransactionLogsResult struct {
+		Blocks []*blockTransactionLogsResult `json:"blocks"`
+		Next   string                        `json:"next"`
+	}
+
+	// blockTransactionLogsResult is the transaction logs of a block, of which blockHash is null if the block is
+	// unavailable
+	blockTransactionLogsResult struct {
+		BlockNumber string                  `json:"blockNumber"`
+		BlockHash   *string                 `json:"blockHash"`
+		Status      string                  `json:"status"`
+		Logs        []*transactionLogResult `json:"logs"`
+	}
+
+	transactionLogResult struct {
+		TransactionHash string                       `json:"transactionHash"`
+		Transfers       []*transactionTransferResult `json:"transfers"`
+	}
+
+	transactionTransferResult struct {
+		Type   string `json:"type"`
+		Topic  string `json:"topic"`
+		From   string `json:"from"`
+		To     string `json:"to"`
+		Amount string `json:"amount"`
+	}
+
+	// getAccountNonceDetailResult is the nonces of an account, where pending is the transactions in the actpool by
+	// nonce
+	getAccountNonceDetailResult struct {
+		ConfirmedNonce string                `json:"confirmedNonce"`
+		PendingNonce   string                `json:"pendingNonce"`
+		Gaps           []string              `json:"gaps"`
+		Pending        []*pendingNonceResult `json:"pending"`
+	}
+
+	pendingNonceResult struct {
+		Nonce string `json:"nonce"`
+		Hash  string `json:"hash"`
+	}
+
+	// getActPoolStatusResult is the usage of the actpool, where noncePolicy is "tolerant" or "strict"
+	getActPoolStatusResult struct {
+		Size        string `json:"size"`
+		Capacity    string `json:"capacity"`
+		GasSize     string `json:"gasSize"`
+		GasCapacity string `json:"gasCapacity"`
+		NoncePolicy string `json:"noncePolicy"`
+	}
+
+	// createdContractResult is a contract created in a block, where depth is 0 for the deployment by transaction
+	createdContractResult struct {
+		TransactionHash string `json:"transactionHash"`
+		Address         string `json:"address"`
+		Creator         string `json:"creator"`
+		Depth           string `json:"depth"`
+	}
+
+	// getTotalSupplyResult is the supply of token at a block, where the amounts are in rau
+	getTotalSupplyResult struct {
+		BlockNumber        string `json:"blockNumber"`
+		Total              string `json:"total"`
+		Circulating        string `json:"circulating"`
+		Burned             string `json:"burned"`
+		Redistributed      string `json:"redistributed"`
+		BlockBurned        string `json:"blockBurned"`
+		BlockRedistributed string `json:"blockRedistributed"`
+	}
+
+	// rawBlockResult is the serialized protos of the parts of a block, where the parts not asked for are omitted
+	rawBlockResult struct {
+		Number   string `json:"number"`
+		Hash     string `json:"hash"`
+		Header   string `json:"header,omitempty"`
+		Body     string `json:"body,omitempty"`
+		Footer   string `json:"footer,omitempty"`
+		Receipts string `json:"receipts,omitempty"`
+	}
+
+	// actionInclusionProofResult is the merkle proof of a transaction included in a block, where path is the hashes of
+	// the siblings from the transaction hash up to the tx root in the header
+	actionInclusionProofResult struct {
+		BlockNumber      string   `json:"blockNumber"`
+		BlockHash        string   `json:"blockHash"`
+		TransactionIndex string   `json:"transactionIndex"`
+		TransactionHash  string   `json:"transactionHash"`
+		Path             []string `json:"path"`
+		Header           string   `json:"header"`
+	}
+
+	// txPoolStateResult is the state of a pending transaction in the actpool, where addedAt is in unix seconds and
+	// timeInPool is in seconds
+	txPoolStateResult struct {
+		Position   string `json:"position"`
+		Executable bool   `json:"executable"`
+		AddedAt    string `json:"addedAt"`
+		TimeInPool string `json:"timeInPool"`
+	}
+
+	validateActionResult struct {
+		Accepted bool   `json:"accepted"`
+		Reason   string `json:"reason,omitempty"`
+		Message  string `json:"message,omitempty"`
+		Gas      string `json:"gas,omitempty"`
+		Fee      string `json:"fee,omitempty"`
+	}
+
+	feeHistoryResult struct {
+		OldestBlock   string     `json:"oldestBlock"`
+		BaseFeePerGas []string   `json:"baseFeePerGas"`
+		GasUsedRatio  []float64  `json:"gasUsedRatio"`
+		Reward        [][]string `json:"reward,omitempty"`
+	}
+
 	getSyncingResult struct {
 		StartingBlock string `json:"startingBlock"`
 		CurrentBlock  string `json:"currentBlock"`
 		HighestBlock  string `json:"highestBlock"`
+		// extension of the syncing object, the speed is measured over the last minute, and the estimated seconds
+		// remaining is 0 if the speed is unknown
+		BlocksPerSecond           float64 `json:"blocksPerSecond"`
+		EstimatedSecondsRemaining string  `json:"estimatedSecondsRemaining"`
+		Stage                     string  `json:"stage"`
 	}
 
 	debugTraceTransactionResult struct {
@@ -85,6 +330,11 @@ type (
 		Gas         uint64               `json:"gas"`
 		StructLogs  []apitypes.StructLog `json:"structLogs"`
 	}
+
+	debugTraceBlockResult struct {
+		TxHash string `json:"txHash"`
+		Result any    `json:"result"`
+	}
 )
 
 var (
@@ -109,7 +359,12 @@ func (obj *web3Response) MarshalJSON() ([]byte, error) {
 		errMsg  string
 	)
 	// error code: https://eth.wiki/json-rpc/json-rpc-error-codes-improvement-proposal
-	if s, ok := status.FromError(obj.err); ok {
+	if errors.Cause(obj.err) == errMethodNotFound {
+		errCode, errMsg = -32601, obj.err.Error()
+	} else if errors.Cause(obj.err) == ErrResultTooLarge {
+		// limit exceeded
+		errCode, errMsg = -32005, obj.err.Error()
+	} else if s, ok := status.FromError(obj.err); ok {
 		errCode, errMsg = int(s.Code()), s.Message()
 	} else {
 		errCode, errMsg = -32603, obj.err.Error()
@@ -250,36 +505,66 @@ func (obj *getTransactionResult) MarshalJSON() ([]byte, error) {
 		tmp := "0x" + hex.EncodeToString(obj.blockHash[:])
 		blkHash = &tmp
 	}
+	// typed tx has the additional fields of its type, legacy tx keeps the original shape
+	var (
+		txType, chainID, yParity, maxFeePerGas, maxPriorityFeePerGas *string
+		accessList                                                   *types.AccessList
+	)
+	if obj.ethTx.Type() != types.LegacyTxType {
+		tmpType, tmpChainID, tmpYParity := uint64ToHex(uint64(obj.ethTx.Type())), hexutil.EncodeBig(obj.ethTx.ChainId()), hexutil.EncodeBig(v)
+		txType, chainID, yParity = &tmpType, &tmpChainID, &tmpYParity
+		al := obj.ethTx.AccessList()
+		if al == nil {
+			al = types.AccessList{}
+		}
+		accessList = &al
+	}
+	if obj.ethTx.Type() == types.DynamicFeeTxType {
+		tmpFeeCap, tmpTipCap := hexutil.EncodeBig(obj.ethTx.GasFeeCap()), hexutil.EncodeBig(obj.ethTx.GasTipCap())
+		maxFeePerGas, maxPriorityFeePerGas = &tmpFeeCap, &tmpTipCap
+	}
 	return json.Marshal(&struct {
-		Hash             string  `json:"hash"`
-		Nonce            string  `json:"nonce"`
-		BlockHash        *string `json:"blockHash"`
-		BlockNumber      *string `json:"blockNumber"`
-		TransactionIndex *string `json:"transactionIndex"`
-		From             string  `json:"from"`
-		To               *string `json:"to"`
-		Value            string  `json:"value"`
-		GasPrice         string  `json:"gasPrice"`
-		Gas              string  `json:"gas"`
-		Input            string  `json:"input"`
-		R                string  `json:"r"`
-		S                string  `json:"s"`
-		V                string  `json:"v"`
+		Hash                 string            `json:"hash"`
+		Nonce                string            `json:"nonce"`
+		BlockHash            *string           `json:"blockHash"`
+		BlockNumber          *string           `json:"blockNumber"`
+		TransactionIndex     *string           `json:"transactionIndex"`
+		From                 string            `json:"from"`
+		To                   *string           `json:"to"`
+		Value                string            `json:"value"`
+		GasPrice             string            `json:"gasPrice"`
+		Gas                  string            `json:"gas"`
+		Input                string            `json:"input"`
+		R                    string            `json:"r"`
+		S                    string            `json:"s"`
+		V                    string            `json:"v"`
+		Type                 *string           `json:"type,omitempty"`
+		ChainID              *string           `json:"chainId,omitempty"`
+		MaxFeePerGas         *string           `json:"maxFeePerGas,omitempty"`
+		MaxPriorityFeePerGas *string           `json:"maxPriorityFeePerGas,omitempty"`
+		AccessList           *types.AccessList `json:"accessList,omitempty"`
+		YParity              *string           `json:"yParity,omitempty"`
 	}{
-		Hash:             "0x" + hex.EncodeToString(txHash),
-		Nonce:            uint64ToHex(obj.ethTx.Nonce()),
-		BlockHash:        blkHash,
-		BlockNumber:      blkNum,
-		TransactionIndex: txIndex,
-		From:             obj.pubkey.Address().Hex(),
-		To:               obj.to,
-		Value:            value,
-		GasPrice:         gasPrice,
-		Gas:              uint64ToHex(obj.ethTx.Gas()),
-		Input:            byteToHex(obj.ethTx.Data()),
-		R:                hexutil.EncodeBig(r),
-		S:                hexutil.EncodeBig(s),
-		V:                hexutil.EncodeBig(v),
+		Hash:                 "0x" + hex.EncodeToString(txHash),
+		Nonce:                uint64ToHex(obj.ethTx.Nonce()),
+		BlockHash:            blkHash,
+		BlockNumber:          blkNum,
+		TransactionIndex:     txIndex,
+		From:                 obj.pubkey.Address().Hex(),
+		To:                   obj.to,
+		Value:                value,
+		GasPrice:             gasPrice,
+		Gas:                  uint64ToHex(obj.ethTx.Gas()),
+		Input:                byteToHex(obj.ethTx.Data()),
+		R:                    hexutil.EncodeBig(r),
+		S:                    hexutil.EncodeBig(s),
+		V:                    hexutil.EncodeBig(v),
+		Type:                 txType,
+		ChainID:              chainID,
+		MaxFeePerGas:         maxFeePerGas,
+		MaxPriorityFeePerGas: maxPriorityFeePerGas,
+		AccessList:           accessList,
+		YParity:              yParity,
 	})
 }
 
@@ -305,6 +590,8 @@ func (obj *getReceiptResult) MarshalJSON() ([]byte, error) {
 		LogsBloom         string           `json:"logsBloom"`
 		Logs              []*getLogsResult `json:"logs"`
 		Status            string           `json:"status"`
+		StatusCode        string           `json:"statusCode"`
+		StatusMessage     string           `json:"statusMessage"`
 	}{
 		TransactionIndex:  uint64ToHex(uint64(obj.receipt.TxIndex)),
 		TransactionHash:   "0x" + hex.EncodeToString(obj.receipt.ActionHash[:]),
@@ -317,10 +604,256 @@ func (obj *getReceiptResult) MarshalJSON() ([]byte, error) {
 		ContractAddress:   obj.contractAddress,
 		LogsBloom:         getLogsBloomHex(obj.logsBloom),
 		Logs:              logs,
-		Status:            uint64ToHex(obj.receipt.Status),
+		Status:            uint64ToHex(action.ReceiptStatus(obj.receipt.Status).EthStatus()),
+		StatusCode:        uint64ToHex(obj.receipt.Status),
+		StatusMessage:     action.ReceiptStatus(obj.receipt.Status).Message(),
+	})
+}
+
+func (obj *getTokenTransfersResult) MarshalJSON() ([]byte, error) {
+	type transfer struct {
+		Standard        string  `json:"standard"`
+		Token           string  `json:"token"`
+		From            string  `json:"from"`
+		To              string  `json:"to"`
+		Value           *string `json:"value,omitempty"`
+		TokenID         *string `json:"tokenId,omitempty"`
+		BlockNumber     string  `json:"blockNumber"`
+		TransactionHash string  `json:"transactionHash"`
+	}
+	transfers := make([]*transfer, 0, len(obj.transfers))
+	for _, tsf := range obj.transfers {
+		t := &transfer{
+			Token:           common.BytesToAddress(tsf.Token[:]).Hex(),
+			From:            common.BytesToAddress(tsf.From[:]).Hex(),
+			To:              common.BytesToAddress(tsf.To[:]).Hex(),
+			BlockNumber:     uint64ToHex(tsf.Height),
+			TransactionHash: "0x" + hex.EncodeToString(tsf.ActionHash[:]),
+		}
+		amount := hexutil.EncodeBig(tsf.Amount)
+		switch tsf.Standard {
+		case blockindex.XRC20:
+			t.Standard, t.Value = "xrc20", &amount
+		case blockindex.XRC721:
+			t.Standard, t.TokenID = "xrc721", &amount
+		default:
+			return nil, errors.Wrapf(errInvalidObject, "unknown token standard %d", tsf.Standard)
+		}
+		transfers = append(transfers, t)
+	}
+	var cursor *string
+	if obj.cursor != 0 {
+		c := uint64ToHex(obj.cursor)
+		cursor = &c
+	}
+	return json.Marshal(&struct {
+		Transfers []*transfer `json:"transfers"`
+		Cursor    *string     `json:"cursor"`
+	}{
+		Transfers: transfers,
+		Cursor:    cursor,
+	})
+}
+
+func (obj *getMemoTransfersResult) MarshalJSON() ([]byte, error) {
+	type transfer struct {
+		From            string `json:"from"`
+		Value           string `json:"value"`
+		BlockNumber     string `json:"blockNumber"`
+		TransactionHash string `json:"transactionHash"`
+	}
+	transfers := make([]*transfer, 0, len(obj.transfers))
+	for _, tsf := range obj.transfers {
+		transfers = append(transfers, &transfer{
+			From:            common.BytesToAddress(tsf.Sender[:]).Hex(),
+			Value:           hexutil.EncodeBig(tsf.Amount),
+			BlockNumber:     uint64ToHex(tsf.Height),
+			TransactionHash: "0x" + hex.EncodeToString(tsf.ActionHash[:]),
+		})
+	}
+	var cursor *string
+	if obj.cursor != 0 {
+		c := uint64ToHex(obj.cursor)
+		cursor = &c
+	}
+	return json.Marshal(&struct {
+		Transfers []*transfer `json:"transfers"`
+		Cursor    *string     `json:"cursor"`
+	}{
+		Transfers: transfers,
+		Cursor:    cursor,
+	})
+}
+
+func (obj *getBlockMetasResult) MarshalJSON() ([]byte, error) {
+	type blockMeta struct {
+		Number       string `json:"number"`
+		Hash         string `json:"hash"`
+		ParentHash   string `json:"parentHash"`
+		Timestamp    string `json:"timestamp"`
+		Miner        string `json:"miner"`
+		Transactions string `json:"transactions"`
+		GasLimit     string `json:"gasLimit"`
+		GasUsed      string `json:"gasUsed"`
+	}
+	metas := make([]*blockMeta, 0, len(obj.metas))
+	for _, m := range obj.metas {
+		miner, err := ioAddrToEthAddr(m.ProducerAddress)
+		if err != nil {
+			return nil, err
+		}
+		metas = append(metas, &blockMeta{
+			Number:       uint64ToHex(m.Height),
+			Hash:         "0x" + m.Hash,
+			ParentHash:   "0x" + m.PreviousBlockHash,
+			Timestamp:    uint64ToHex(uint64(m.Timestamp.GetSeconds())),
+			Miner:        miner,
+			Transactions: uint64ToHex(uint64(m.NumActions)),
+			GasLimit:     uint64ToHex(m.GasLimit),
+			GasUsed:      uint64ToHex(m.GasUsed),
+		})
+	}
+	return json.Marshal(&struct {
+		Total      string       `json:"total"`
+		BlockMetas []*blockMeta `json:"blockMetas"`
+	}{
+		Total:      uint64ToHex(obj.total),
+		BlockMetas: metas,
+	})
+}
+
+func (obj *getProtocolParametersResult) MarshalJSON() ([]byte, error) {
+	type parameter struct {
+		Protocol string `json:"protocol"`
+		Name     string `json:"name"`
+		Value    string `json:"value"`
+		Height   string `json:"height"`
+	}
+	convert := func(params []protocol.Parameter) []*parameter {
+		res := make([]*parameter, 0, len(params))
+		for _, p := range params {
+			res = append(res, &parameter{
+				Protocol: p.Protocol,
+				Name:     p.Name,
+				Value:    p.Value,
+				Height:   uint64ToHex(p.Height),
+			})
+		}
+		return res
+	}
+	return json.Marshal(&struct {
+		Number     string       `json:"number"`
+		Parameters []*parameter `json:"parameters"`
+		Upcoming   []*parameter `json:"upcoming"`
+	}{
+		Number:     uint64ToHex(obj.height),
+		Parameters: convert(obj.inForce),
+		Upcoming:   convert(obj.upcoming),
 	})
 }
 
+func (obj *getCandidateHistoryResult) MarshalJSON() ([]byte, error) {
+	type candidate struct {
+		Epoch       string `json:"epoch"`
+		Candidate   string `json:"candidate"`
+		Name        string `json:"name"`
+		Votes       string `json:"votes"`
+		SelfStake   string `json:"selfStake"`
+		BucketCount string `json:"bucketCount"`
+		Rank        string `json:"rank"`
+	}
+	candidates := make([]*candidate, 0, len(obj.histories))
+	for _, h := range obj.histories {
+		candidates = append(candidates, &candidate{
+			Epoch:       uint64ToHex(h.Epoch),
+			Candidate:   common.BytesToAddress(h.ID.Bytes()).Hex(),
+			Name:        h.Name,
+			Votes:       hexutil.EncodeBig(h.Votes),
+			SelfStake:   hexutil.EncodeBig(h.SelfStake),
+			BucketCount: uint64ToHex(h.BucketCount),
+			Rank:        uint64ToHex(uint64(h.Rank)),
+		})
+	}
+	return json.Marshal(candidates)
+}
+
+func (obj *getSystemActionsResult) MarshalJSON() ([]byte, error) {
+	type systemAction struct {
+		Type            string `json:"type"`
+		BlockNumber     string `json:"blockNumber"`
+		Index           string `json:"transactionIndex"`
+		TransactionHash string `json:"transactionHash"`
+		RewardType      string `json:"rewardType,omitempty"`
+		Epoch           string `json:"epoch"`
+	}
+	actions := make([]*systemAction, 0, len(obj.actions))
+	for _, sa := range obj.actions {
+		act := &systemAction{
+			BlockNumber:     uint64ToHex(sa.BlockHeight),
+			Index:           uint64ToHex(uint64(sa.Index)),
+			TransactionHash: "0x" + hex.EncodeToString(sa.ActionHash[:]),
+			Epoch:           uint64ToHex(sa.Epoch),
+		}
+		switch sa.Type {
+		case blockindex.SystemActionGrantReward:
+			act.Type = "grantReward"
+			if sa.RewardType == action.EpochReward {
+				act.RewardType = "epoch"
+			} else {
+				act.RewardType = "block"
+			}
+		case blockindex.SystemActionPutPollResult:
+			act.Type = "putPollResult"
+		default:
+			return nil, errInvalidObject
+		}
+		actions = append(actions, act)
+	}
+	return json.Marshal(actions)
+}
+
+func (obj *getContractStatsResult) MarshalJSON() ([]byte, error) {
+	type dayStats struct {
+		Date          string `json:"date"`
+		Calls         string `json:"calls"`
+		GasConsumed   string `json:"gasConsumed"`
+		Failures      string `json:"failures"`
+		UniqueCallers string `json:"uniqueCallers"`
+	}
+	stats := make([]*dayStats, 0, len(obj.stats))
+	for _, s := range obj.stats {
+		stats = append(stats, &dayStats{
+			Date:          time.Unix(int64(s.Day*24*60*60), 0).UTC().Format(time.DateOnly),
+			Calls:         uint64ToHex(s.Calls),
+			GasConsumed:   uint64ToHex(s.GasConsumed),
+			Failures:      uint64ToHex(s.Failures),
+			UniqueCallers: uint64ToHex(s.UniqueCallers),
+		})
+	}
+	return json.Marshal(stats)
+}
+
+func (obj *getTopContractsResult) MarshalJSON() ([]byte, error) {
+	type contractStats struct {
+		Address       string `json:"address"`
+		Calls         string `json:"calls"`
+		GasConsumed   string `json:"gasConsumed"`
+		Failures      string `json:"failures"`
+		UniqueCallers string `json:"uniqueCallers"`
+	}
+	stats := make([]*contractStats, 0, len(obj.stats))
+	for _, s := range obj.stats {
+		stats = append(stats, &contractStats{
+			Address:       common.BytesToAddress(s.Contract[:]).Hex(),
+			Calls:         uint64ToHex(s.Calls),
+			GasConsumed:   uint64ToHex(s.GasConsumed),
+			Failures:      uint64ToHex(s.Failures),
+			UniqueCallers: uint64ToHex(s.UniqueCallers),
+		})
+	}
+	return json.Marshal(stats)
+}
+
 func (obj *getLogsResult) MarshalJSON() ([]byte, error) {
 	if obj.log == nil {
 		return nil, errInvalidObject
@@ -370,3 +903,302 @@ func (obj *streamResponse) MarshalJSON() ([]byte, error) {
 		},
 	})
 }
+
+func (obj *simulateCallResult) MarshalJSON() ([]byte, error) {
+	if obj.result == nil {
+		return nil, errInvalidObject
+	}
+	type simulatedLog struct {
+		Address string   `json:"address"`
+		Topics  []string `json:"topics"`
+		Data    string   `json:"data"`
+	}
+	logs := make([]*simulatedLog, 0, len(obj.result.Logs))
+	for _, l := range obj.result.Logs {
+		addr, err := ioAddrToEthAddr(l.Address)
+		if err != nil {
+			return nil, err
+		}
+		topics := make([]string, 0, len(l.Topics))
+		for _, tpc := range l.Topics {
+			topics = append(topics, "0x"+hex.EncodeToString(tpc[:]))
+		}
+		logs = append(logs, &simulatedLog{
+			Address: addr,
+			Topics:  topics,
+			Data:    "0x" + hex.EncodeToString(l.Data),
+		})
+	}
+	var contractAddr, errMsg *string
+	if obj.result.ContractAddress != "" {
+		addr, err := ioAddrToEthAddr(obj.result.ContractAddress)
+		if err != nil {
+			return nil, err
+		}
+		contractAddr = &addr
+	}
+	if obj.result.Err != nil {
+		msg := obj.result.Err.Error()
+		errMsg = &msg
+	}
+	return json.Marshal(&struct {
+		GasUsed         string          `json:"gasUsed"`
+		Status          string          `json:"status"`
+		ReturnData      string          `json:"returnData"`
+		Logs            []*simulatedLog `json:"logs"`
+		ContractAddress *string         `json:"contractAddress,omitempty"`
+		RevertReason    string          `json:"revertReason,omitempty"`
+		Error           *string         `json:"error,omitempty"`
+	}{
+		GasUsed:         uint64ToHex(obj.result.GasUsed),
+		Status:          uint64ToHex(obj.result.Status),
+		ReturnData:      "0x" + hex.EncodeToString(obj.result.ReturnData),
+		Logs:            logs,
+		ContractAddress: contractAddr,
+		RevertReason:    obj.result.RevertReason,
+		Error:           errMsg,
+	})
+}
+
+func newAddressResult(addr address.Address) *addressResult {
+	return &addressResult{
+		IoAddress:  addr.String(),
+		HexAddress: common.BytesToAddress(addr.Bytes()).Hex(),
+	}
+}
+
+// parseAddressResult parses the address in the io format, the result is nil if the address is empty
+func parseAddressResult(ioAddr string) (*addressResult, error) {
+	if ioAddr == "" {
+		return nil, nil
+	}
+	addr, err := address.FromString(ioAddr)
+	if err != nil {
+		return nil, err
+	}
+	return newAddressResult(addr), nil
+}
+
+// decimalToHex converts the amount in decimal of the native format into hex
+func decimalToHex(amount string) (string, error) {
+	if amount == "" {
+		return "0x0", nil
+	}
+	v, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		return "", errors.Errorf("invalid amount %s", amount)
+	}
+	return hexutil.EncodeBig(v), nil
+}
+
+// timestampToHex converts the timestamp into the unix seconds in hex, the time before the unix epoch is zero
+func timestampToHex(ts *timestamppb.Timestamp) string {
+	if ts.GetSeconds() <= 0 {
+		return "0x0"
+	}
+	return uint64ToHex(uint64(ts.GetSeconds()))
+}
+
+func (obj *getBucketsResult) MarshalJSON() ([]byte, error) {
+	type bucket struct {
+		Index                     string         `json:"index"`
+		Candidate                 *addressResult `json:"candidate"`
+		Owner                     *addressResult `json:"owner"`
+		Contract                  *addressResult `json:"contract"`
+		StakedAmount              string         `json:"stakedAmount"`
+		StakedDuration            string         `json:"stakedDuration"`
+		StakedDurationBlockNumber string         `json:"stakedDurationBlockNumber"`
+		AutoStake                 bool           `json:"autoStake"`
+		CreateTime                string         `json:"createTime"`
+		StakeStartTime            string         `json:"stakeStartTime"`
+		UnstakeStartTime          string         `json:"unstakeStartTime"`
+		CreateBlockNumber         string         `json:"createBlockNumber"`
+		StakeStartBlockNumber     string         `json:"stakeStartBlockNumber"`
+		UnstakeStartBlockNumber   string         `json:"unstakeStartBlockNumber"`
+	}
+	buckets := make([]*bucket, 0, len(obj.buckets))
+	for _, b := range obj.buckets {
+		candidate, err := parseAddressResult(b.GetCandidateAddress())
+		if err != nil {
+			return nil, err
+		}
+		owner, err := parseAddressResult(b.GetOwner())
+		if err != nil {
+			return nil, err
+		}
+		contract, err := parseAddressResult(b.GetContractAddress())
+		if err != nil {
+			return nil, err
+		}
+		amount, err := decimalToHex(b.GetStakedAmount())
+		if err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, &bucket{
+			Index:                     uint64ToHex(b.GetIndex()),
+			Candidate:                 candidate,
+			Owner:                     owner,
+			Contract:                  contract,
+			StakedAmount:              amount,
+			StakedDuration:            uint64ToHex(uint64(b.GetStakedDuration())),
+			StakedDurationBlockNumber: uint64ToHex(b.GetStakedDurationBlockNumber()),
+			AutoStake:                 b.GetAutoStake(),
+			CreateTime:                timestampToHex(b.GetCreateTime()),
+			StakeStartTime:            timestampToHex(b.GetStakeStartTime()),
+			UnstakeStartTime:          timestampToHex(b.GetUnstakeStartTime()),
+			CreateBlockNumber:         uint64ToHex(b.GetCreateBlockHeight()),
+			StakeStartBlockNumber:     uint64ToHex(b.GetStakeStartBlockHeight()),
+			UnstakeStartBlockNumber:   uint64ToHex(b.GetUnstakeStartBlockHeight()),
+		})
+	}
+	return json.Marshal(&struct {
+		BlockNumber string    `json:"blockNumber"`
+		Buckets     []*bucket `json:"buckets"`
+	}{
+		BlockNumber: uint64ToHex(obj.height),
+		Buckets:     buckets,
+	})
+}
+
+func (obj *getCandidatesResult) MarshalJSON() ([]byte, error) {
+	type candidate struct {
+		ID                   *addressResult `json:"id"`
+		Name                 string         `json:"name"`
+		Owner                *addressResult `json:"owner"`
+		Operator             *addressResult `json:"operator"`
+		Reward               *addressResult `json:"reward"`
+		TotalWeightedVotes   string         `json:"totalWeightedVotes"`
+		SelfStakeBucketIndex string         `json:"selfStakeBucketIndex"`
+		SelfStakingTokens    string         `json:"selfStakingTokens"`
+	}
+	candidates := make([]*candidate, 0, len(obj.candidates))
+	for _, c := range obj.candidates {
+		cand := &candidate{
+			Name:                 c.GetName(),
+			SelfStakeBucketIndex: uint64ToHex(c.GetSelfStakeBucketIdx()),
+		}
+		for _, field := range []struct {
+			addr  string
+			value **addressResult
+		}{
+			{c.GetId(), &cand.ID},
+			{c.GetOwnerAddress(), &cand.Owner},
+			{c.GetOperatorAddress(), &cand.Operator},
+			{c.GetRewardAddress(), &cand.Reward},
+		} {
+			addr, err := parseAddressResult(field.addr)
+			if err != nil {
+				return nil, err
+			}
+			*field.value = addr
+		}
+		for _, field := range []struct {
+			amount string
+			value  *string
+		}{
+			{c.GetTotalWeightedVotes(), &cand.TotalWeightedVotes},
+			{c.GetSelfStakingTokens(), &cand.SelfStakingTokens},
+		} {
+			amount, err := decimalToHex(field.amount)
+			if err != nil {
+				return nil, err
+			}
+			*field.value = amount
+		}
+		candidates = append(candidates, cand)
+	}
+	return json.Marshal(&struct {
+		BlockNumber string       `json:"blockNumber"`
+		Candidates  []*candidate `json:"candidates"`
+	}{
+		BlockNumber: uint64ToHex(obj.height),
+		Candidates:  candidates,
+	})
+}
+
+func (obj *getEpochMetaResult) MarshalJSON() ([]byte, error) {
+	type producer struct {
+		Address    *addressResult `json:"address"`
+		Votes      string         `json:"votes"`
+		Active     bool           `json:"active"`
+		Production string         `json:"production"`
+	}
+	producers := make([]*producer, 0, len(obj.producers))
+	for _, p := range obj.producers {
+		addr, err := parseAddressResult(p.GetAddress())
+		if err != nil {
+			return nil, err
+		}
+		votes, err := decimalToHex(p.GetVotes())
+		if err != nil {
+			return nil, err
+		}
+		producers = append(producers, &producer{
+			Address:    addr,
+			Votes:      votes,
+			Active:     p.GetActive(),
+			Production: uint64ToHex(p.GetProduction()),
+		})
+	}
+	return json.Marshal(&struct {
+		Epoch                   string      `json:"epoch"`
+		Height                  string      `json:"height"`
+		GravityChainStartHeight string      `json:"gravityChainStartHeight"`
+		TotalBlocks             string      `json:"totalBlocks"`
+		BlockProducers          []*producer `json:"blockProducers"`
+	}{
+		Epoch:                   uint64ToHex(obj.data.GetNum()),
+		Height:                  uint64ToHex(obj.data.GetHeight()),
+		GravityChainStartHeight: uint64ToHex(obj.data.GetGravityChainStartHeight()),
+		TotalBlocks:             uint64ToHex(obj.totalBlocks),
+		BlockProducers:          producers,
+	})
+}
+
+func (obj *getActionResult) MarshalJSON() ([]byte, error) {
+	act, err := protojson.Marshal(obj.info.GetAction())
+	if err != nil {
+		return nil, err
+	}
+	sender, err := parseAddressResult(obj.info.GetSender())
+	if err != nil {
+		return nil, err
+	}
+	var to *addressResult
+	if dst, ok := obj.selp.Destination(); ok {
+		if to, err = parseAddressResult(dst); err != nil {
+			return nil, err
+		}
+	}
+	ret := struct {
+		ActionHash  string          `json:"actionHash"`
+		BlockHash   *string         `json:"blockHash"`
+		BlockNumber *string         `json:"blockNumber"`
+		Index       *string         `json:"index"`
+		Timestamp   *string         `json:"timestamp"`
+		Sender      *addressResult  `json:"sender"`
+		To          *addressResult  `json:"to"`
+		GasFee      *string         `json:"gasFee"`
+		Action      json.RawMessage `json:"action"`
+	}{
+		ActionHash: "0x" + obj.info.GetActHash(),
+		Sender:     sender,
+		To:         to,
+		Action:     act,
+	}
+	// the block of a pending action is null
+	if obj.info.GetBlkHeight() > 0 {
+		gasFee, err := decimalToHex(obj.info.GetGasFee())
+		if err != nil {
+			return nil, err
+		}
+		var (
+			blkHash   = "0x" + obj.info.GetBlkHash()
+			blkNum    = uint64ToHex(obj.info.GetBlkHeight())
+			index     = uint64ToHex(uint64(obj.info.GetIndex()))
+			timestamp = timestampToHex(obj.info.GetTimestamp())
+		)
+		ret.BlockHash, ret.BlockNumber, ret.Index, ret.Timestamp, ret.GasFee = &blkHash, &blkNum, &index, &timestamp, &gasFee
+	}
+	return json.Marshal(&ret)
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"math/big"
 	"testing"
 	"time"
@@ -11,11 +12,16 @@ import (
 	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/staking"
 	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockindex"
 	"github.com/iotexproject/iotex-core/pkg/unit"
 	"github.com/iotexproject/iotex-core/test/identityset"
 )
@@ -411,7 +417,9 @@ func TestReceiptObjectMarshal(t *testing.T) {
 			"logs":[
 			   
 			],
-			"status":"0x1"
+			"status":"0x1",
+			"statusCode":"0x1",
+			"statusMessage":"succeeded"
 		 }
 		`, string(res))
 	})
@@ -466,10 +474,33 @@ func TestReceiptObjectMarshal(t *testing.T) {
 				  ]
 			   }
 			],
-			"status":"0x1"
+			"status":"0x1",
+			"statusCode":"0x1",
+			"statusMessage":"succeeded"
 		 }
 		`, string(res))
 	})
+
+	t.Run("ExecutionFailed", func(t *testing.T) {
+		failed := &action.Receipt{
+			Status:      uint64(iotextypes.ReceiptStatus_ErrExecutionReverted),
+			BlockHeight: 16,
+			ActionHash:  _testTxHash,
+			GasConsumed: 21000,
+			TxIndex:     1,
+		}
+		contractEthaddr, _ := ioAddrToEthAddr(_testContractIoAddr)
+		res, err := json.Marshal(&getReceiptResult{
+			blockHash: _testBlkHash,
+			from:      _testSenderIoAddr,
+			to:        &contractEthaddr,
+			receipt:   failed,
+		})
+		require.NoError(err)
+		require.Equal("0x0", gjson.GetBytes(res, "status").String())
+		require.Equal("0x6a", gjson.GetBytes(res, "statusCode").String())
+		require.Equal("execution reverted", gjson.GetBytes(res, "statusMessage").String())
+	})
 }
 
 func TestLogsObjectMarshal(t *testing.T) {
@@ -508,6 +539,444 @@ func TestLogsObjectMarshal(t *testing.T) {
 	`, string(res))
 }
 
+func TestTokenTransfersObjectMarshal(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		from = hash.BytesToHash160(_testTopic2[12:])
+		to   = hash.BytesToHash160(_testTopic3[12:])
+	)
+	token, err := address.FromString(_testContractIoAddr)
+	require.NoError(err)
+	res, err := json.Marshal(&getTokenTransfersResult{
+		transfers: []*blockindex.TokenTransfer{
+			{
+				Standard:   blockindex.XRC20,
+				Height:     2,
+				ActionHash: _testTxHash,
+				Token:      hash.BytesToHash160(token.Bytes()),
+				From:       from,
+				To:         to,
+				Amount:     big.NewInt(100),
+			},
+			{
+				Standard:   blockindex.XRC721,
+				Height:     3,
+				ActionHash: _testTxHash,
+				Token:      hash.BytesToHash160(token.Bytes()),
+				From:       to,
+				To:         from,
+				Amount:     big.NewInt(7),
+			},
+		},
+		cursor: 5,
+	})
+	require.NoError(err)
+	require.JSONEq(`
+	{
+		"transfers":[
+			{
+				"standard":"xrc20",
+				"token":"0x19088c581273F5E53f082CB4BB396119b959231D",
+				"from":"0x8A68E01add9aDc8b887025dC54C36CFa91432F58",
+				"to":"0x567Ff65F8b4BEc33B9925CAd6F7EC3C45Ac79b26",
+				"value":"0x64",
+				"blockNumber":"0x2",
+				"transactionHash":"0x25bef7a7e20402a625973613b19bbc1793ed3a38cad270abf623222120a10fd0"
+			},
+			{
+				"standard":"xrc721",
+				"token":"0x19088c581273F5E53f082CB4BB396119b959231D",
+				"from":"0x567Ff65F8b4BEc33B9925CAd6F7EC3C45Ac79b26",
+				"to":"0x8A68E01add9aDc8b887025dC54C36CFa91432F58",
+				"tokenId":"0x7",
+				"blockNumber":"0x3",
+				"transactionHash":"0x25bef7a7e20402a625973613b19bbc1793ed3a38cad270abf623222120a10fd0"
+			}
+		],
+		"cursor":"0x5"
+	}
+	`, string(res))
+
+	res, err = json.Marshal(&getTokenTransfersResult{})
+	require.NoError(err)
+	require.JSONEq(`{"transfers":[],"cursor":null}`, string(res))
+}
+
+func TestMemoTransfersObjectMarshal(t *testing.T) {
+	require := require.New(t)
+
+	res, err := json.Marshal(&getMemoTransfersResult{
+		transfers: []*blockindex.MemoTransfer{
+			{
+				Height:     2,
+				ActionHash: _testTxHash,
+				Sender:     hash.BytesToHash160(_testTopic2[12:]),
+				Amount:     big.NewInt(100),
+			},
+		},
+		cursor: 5,
+	})
+	require.NoError(err)
+	require.JSONEq(`
+	{
+		"transfers":[
+			{
+				"from":"0x8A68E01add9aDc8b887025dC54C36CFa91432F58",
+				"value":"0x64",
+				"blockNumber":"0x2",
+				"transactionHash":"0x25bef7a7e20402a625973613b19bbc1793ed3a38cad270abf623222120a10fd0"
+			}
+		],
+		"cursor":"0x5"
+	}
+	`, string(res))
+
+	res, err = json.Marshal(&getMemoTransfersResult{})
+	require.NoError(err)
+	require.JSONEq(`{"transfers":[],"cursor":null}`, string(res))
+}
+
+func TestCandidateHistoryObjectMarshal(t *testing.T) {
+	require := require.New(t)
+
+	res, err := json.Marshal(&getCandidateHistoryResult{
+		histories: []*staking.CandidateHistory{
+			{
+				Epoch:       2,
+				ID:          identityset.Address(1),
+				Name:        "test1",
+				Votes:       big.NewInt(100),
+				SelfStake:   big.NewInt(10),
+				BucketCount: 3,
+				Rank:        1,
+			},
+		},
+	})
+	require.NoError(err)
+	require.JSONEq(`
+	[
+		{
+			"epoch":"0x2",
+			"candidate":"0xfff810C667050c7E4263A39e796aF8D5E74A1B55",
+			"name":"test1",
+			"votes":"0x64",
+			"selfStake":"0xa",
+			"bucketCount":"0x3",
+			"rank":"0x1"
+		}
+	]
+	`, string(res))
+
+	res, err = json.Marshal(&getCandidateHistoryResult{})
+	require.NoError(err)
+	require.JSONEq(`[]`, string(res))
+}
+
+func TestBucketsObjectMarshal(t *testing.T) {
+	require := require.New(t)
+
+	res, err := json.Marshal(&getBucketsResult{
+		height: 5,
+		buckets: []*iotextypes.VoteBucket{
+			{
+				Index:                     1,
+				CandidateAddress:          _testSenderIoAddr.String(),
+				Owner:                     _testContractIoAddr,
+				StakedAmount:              "100",
+				StakedDuration:            7,
+				StakedDurationBlockNumber: 120960,
+				AutoStake:                 true,
+				CreateTime:                timestamppb.New(time.Unix(1690000000, 0)),
+				StakeStartTime:            timestamppb.New(time.Unix(1690000000, 0)),
+				UnstakeStartTime:          timestamppb.New(time.Unix(0, 0)),
+				CreateBlockHeight:         10,
+				StakeStartBlockHeight:     10,
+			},
+		},
+	})
+	require.NoError(err)
+	require.JSONEq(`
+	{
+		"blockNumber":"0x5",
+		"buckets":[
+			{
+				"index":"0x1",
+				"candidate":{
+					"ioAddress":"io154mvzs09vkgn0hw6gg3ayzw5w39jzp47f8py9v",
+					"hexAddress":"0xA576C141e5659137ddDa4223d209d4744b2106BE"
+				},
+				"owner":{
+					"ioAddress":"io1ryygckqjw06720cg9j6tkwtprxu4jgcag4w6vn",
+					"hexAddress":"0x19088c581273F5E53f082CB4BB396119b959231D"
+				},
+				"contract":null,
+				"stakedAmount":"0x64",
+				"stakedDuration":"0x7",
+				"stakedDurationBlockNumber":"0x1d880",
+				"autoStake":true,
+				"createTime":"0x64bb5a80",
+				"stakeStartTime":"0x64bb5a80",
+				"unstakeStartTime":"0x0",
+				"createBlockNumber":"0xa",
+				"stakeStartBlockNumber":"0xa",
+				"unstakeStartBlockNumber":"0x0"
+			}
+		]
+	}
+	`, string(res))
+
+	_, err = json.Marshal(&getBucketsResult{
+		buckets: []*iotextypes.VoteBucket{{StakedAmount: "x"}},
+	})
+	require.Error(err)
+}
+
+func TestCandidatesObjectMarshal(t *testing.T) {
+	require := require.New(t)
+
+	res, err := json.Marshal(&getCandidatesResult{
+		height: 5,
+		candidates: []*iotextypes.CandidateV2{
+			{
+				Id:                 _testSenderIoAddr.String(),
+				Name:               "test1",
+				OwnerAddress:       _testSenderIoAddr.String(),
+				OperatorAddress:    _testContractIoAddr,
+				RewardAddress:      _testContractIoAddr,
+				TotalWeightedVotes: "1000",
+				SelfStakeBucketIdx: 2,
+				SelfStakingTokens:  "100",
+			},
+		},
+	})
+	require.NoError(err)
+	require.JSONEq(`
+	{
+		"blockNumber":"0x5",
+		"candidates":[
+			{
+				"id":{
+					"ioAddress":"io154mvzs09vkgn0hw6gg3ayzw5w39jzp47f8py9v",
+					"hexAddress":"0xA576C141e5659137ddDa4223d209d4744b2106BE"
+				},
+				"name":"test1",
+				"owner":{
+					"ioAddress":"io154mvzs09vkgn0hw6gg3ayzw5w39jzp47f8py9v",
+					"hexAddress":"0xA576C141e5659137ddDa4223d209d4744b2106BE"
+				},
+				"operator":{
+					"ioAddress":"io1ryygckqjw06720cg9j6tkwtprxu4jgcag4w6vn",
+					"hexAddress":"0x19088c581273F5E53f082CB4BB396119b959231D"
+				},
+				"reward":{
+					"ioAddress":"io1ryygckqjw06720cg9j6tkwtprxu4jgcag4w6vn",
+					"hexAddress":"0x19088c581273F5E53f082CB4BB396119b959231D"
+				},
+				"totalWeightedVotes":"0x3e8",
+				"selfStakeBucketIndex":"0x2",
+				"selfStakingTokens":"0x64"
+			}
+		]
+	}
+	`, string(res))
+
+	res, err = json.Marshal(&getCandidatesResult{})
+	require.NoError(err)
+	require.JSONEq(`{"blockNumber":"0x0","candidates":[]}`, string(res))
+}
+
+func TestUnclaimedRewardsObjectMarshal(t *testing.T) {
+	require := require.New(t)
+
+	res, err := json.Marshal(&getUnclaimedRewardsResult{
+		Address:     newAddressResult(_testSenderIoAddr),
+		Balance:     "0x64",
+		BlockNumber: "0x5",
+	})
+	require.NoError(err)
+	require.JSONEq(`
+	{
+		"address":{
+			"ioAddress":"io154mvzs09vkgn0hw6gg3ayzw5w39jzp47f8py9v",
+			"hexAddress":"0xA576C141e5659137ddDa4223d209d4744b2106BE"
+		},
+		"unclaimedBalance":"0x64",
+		"blockNumber":"0x5"
+	}
+	`, string(res))
+}
+
+func TestEpochMetaObjectMarshal(t *testing.T) {
+	require := require.New(t)
+
+	res, err := json.Marshal(&getEpochMetaResult{
+		data:        &iotextypes.EpochData{Num: 2, Height: 721, GravityChainStartHeight: 100},
+		totalBlocks: 360,
+		producers: []*iotexapi.BlockProducerInfo{
+			{Address: _testSenderIoAddr.String(), Votes: "100", Active: true, Production: 30},
+		},
+	})
+	require.NoError(err)
+	require.JSONEq(`
+	{
+		"epoch":"0x2",
+		"height":"0x2d1",
+		"gravityChainStartHeight":"0x64",
+		"totalBlocks":"0x168",
+		"blockProducers":[
+			{
+				"address":{
+					"ioAddress":"io154mvzs09vkgn0hw6gg3ayzw5w39jzp47f8py9v",
+					"hexAddress":"0xA576C141e5659137ddDa4223d209d4744b2106BE"
+				},
+				"votes":"0x64",
+				"active":true,
+				"production":"0x1e"
+			}
+		]
+	}
+	`, string(res))
+}
+
+func TestActionObjectMarshal(t *testing.T) {
+	require := require.New(t)
+
+	selp, err := action.SignedTransfer(_testContractIoAddr, identityset.PrivateKey(27), 1, big.NewInt(10), nil, 100000, big.NewInt(0))
+	require.NoError(err)
+	info := &iotexapi.ActionInfo{
+		Action:    selp.Proto(),
+		ActHash:   hex.EncodeToString(_testTxHash[:]),
+		BlkHash:   hex.EncodeToString(_testBlkHash[:]),
+		BlkHeight: 5,
+		Sender:    _testSenderIoAddr.String(),
+		GasFee:    "10000",
+		Timestamp: timestamppb.New(time.Unix(1690000000, 0)),
+		Index:     1,
+	}
+	res, err := json.Marshal(&getActionResult{info: info, selp: selp})
+	require.NoError(err)
+	ret := gjson.ParseBytes(res)
+	require.Equal("0x25bef7a7e20402a625973613b19bbc1793ed3a38cad270abf623222120a10fd0", ret.Get("actionHash").String())
+	require.Equal("0xc4aace64c1f4d7c0b6ebe74ba01e00e27c7ff4b2552c36ef617f38f0f2b1ebb3", ret.Get("blockHash").String())
+	require.Equal("0x5", ret.Get("blockNumber").String())
+	require.Equal("0x1", ret.Get("index").String())
+	require.Equal("0x64bb5a80", ret.Get("timestamp").String())
+	require.Equal("0x2710", ret.Get("gasFee").String())
+	require.JSONEq(`
+	{
+		"ioAddress":"io154mvzs09vkgn0hw6gg3ayzw5w39jzp47f8py9v",
+		"hexAddress":"0xA576C141e5659137ddDa4223d209d4744b2106BE"
+	}
+	`, ret.Get("sender").Raw)
+	require.JSONEq(`
+	{
+		"ioAddress":"io1ryygckqjw06720cg9j6tkwtprxu4jgcag4w6vn",
+		"hexAddress":"0x19088c581273F5E53f082CB4BB396119b959231D"
+	}
+	`, ret.Get("to").Raw)
+	require.Equal("10", ret.Get("action.core.transfer.amount").String())
+
+	// the block of a pending action is null
+	info.BlkHash, info.BlkHeight, info.GasFee, info.Timestamp, info.Index = "", 0, "", nil, 0
+	res, err = json.Marshal(&getActionResult{info: info, selp: selp})
+	require.NoError(err)
+	ret = gjson.ParseBytes(res)
+	for _, field := range []string{"blockHash", "blockNumber", "index", "timestamp", "gasFee"} {
+		require.Equal(gjson.Null, ret.Get(field).Type, field)
+	}
+}
+
+func TestSystemActionsObjectMarshal(t *testing.T) {
+	require := require.New(t)
+
+	res, err := json.Marshal(&getSystemActionsResult{
+		actions: []*blockindex.SystemAction{
+			{
+				Type:        blockindex.SystemActionGrantReward,
+				BlockHeight: 2,
+				Index:       1,
+				ActionHash:  _testTxHash,
+				RewardType:  action.EpochReward,
+				Epoch:       1,
+			},
+			{
+				Type:        blockindex.SystemActionPutPollResult,
+				BlockHeight: 2,
+				Index:       2,
+				ActionHash:  _testTxHash,
+				Epoch:       2,
+			},
+		},
+	})
+	require.NoError(err)
+	require.JSONEq(fmt.Sprintf(`
+	[
+		{
+			"type":"grantReward",
+			"blockNumber":"0x2",
+			"transactionIndex":"0x1",
+			"transactionHash":"0x%[1]s",
+			"rewardType":"epoch",
+			"epoch":"0x1"
+		},
+		{
+			"type":"putPollResult",
+			"blockNumber":"0x2",
+			"transactionIndex":"0x2",
+			"transactionHash":"0x%[1]s",
+			"epoch":"0x2"
+		}
+	]
+	`, hex.EncodeToString(_testTxHash[:])), string(res))
+
+	_, err = json.Marshal(&getSystemActionsResult{actions: []*blockindex.SystemAction{{}}})
+	require.Error(err)
+}
+
+func TestContractStatsObjectMarshal(t *testing.T) {
+	require := require.New(t)
+
+	day := blockindex.DayOf(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	res, err := json.Marshal(&getContractStatsResult{
+		stats: []*blockindex.ContractDayStats{
+			{Day: day, ContractStats: blockindex.ContractStats{Calls: 3, GasConsumed: 250, Failures: 1, UniqueCallers: 2}},
+		},
+	})
+	require.NoError(err)
+	require.JSONEq(`
+	[
+		{
+			"date":"2024-03-01",
+			"calls":"0x3",
+			"gasConsumed":"0xfa",
+			"failures":"0x1",
+			"uniqueCallers":"0x2"
+		}
+	]
+	`, string(res))
+
+	contract, err := address.FromString("io1ph0u2psnd7muq5xv9623rmxdsxc4uapxhzpg02")
+	require.NoError(err)
+	res, err = json.Marshal(&getTopContractsResult{
+		stats: []*blockindex.ContractStats{
+			{Contract: hash.BytesToHash160(contract.Bytes()), Calls: 3, GasConsumed: 250},
+		},
+	})
+	require.NoError(err)
+	require.JSONEq(`
+	[
+		{
+			"address":"0x0ddfC506136fb7c050Cc2E9511eccD81b15e7426",
+			"calls":"0x3",
+			"gasConsumed":"0xfa",
+			"failures":"0x0",
+			"uniqueCallers":"0x0"
+		}
+	]
+	`, string(res))
+}
+
 func TestStreamResponseMarshal(t *testing.T) {
 	require := require.New(t)
 
@@ -2,34 +2,55 @@ package api
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"math/big"
 	"math/rand"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"github.com/tidwall/gjson"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/go-pkgs/util"
+	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-proto/golang/iotexapi"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/actpool"
 	apitypes "github.com/iotexproject/iotex-core/api/types"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/blockindex"
+	"github.com/iotexproject/iotex-core/blocksync"
+	"github.com/iotexproject/iotex-core/gasstation"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
 	"github.com/iotexproject/iotex-core/test/identityset"
 	"github.com/iotexproject/iotex-core/test/mock/mock_apicoreservice"
 	mock_apitypes "github.com/iotexproject/iotex-core/test/mock/mock_apiresponder"
@@ -152,7 +173,7 @@ func TestHandlePost(t *testing.T) {
 
 	// web3 req without params
 	request7, _ := http.NewRequest(http.MethodPost, "http://url.com", strings.NewReader(`{"jsonrpc":"2.0","method":"web3_clientVersion","id":67}`))
-	core.EXPECT().ServerMeta().Return("mock str1", "mock str2", "mock str3", "mock str4", "mock str5")
+	core.EXPECT().NetworkIdentity().Return(&apitypes.NetworkIdentity{PackageVersion: "mock str1"})
 	response7 := getServerResp(svr, request7)
 	bodyBytes7, _ := io.ReadAll(response7.Body)
 	require.Contains(string(bodyBytes7), "result")
@@ -171,7 +192,7 @@ func TestGasPrice(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 	core.EXPECT().SuggestGasPrice().Return(uint64(1), nil)
 	ret, err := web3svr.gasPrice()
 	require.NoError(err)
@@ -182,13 +203,226 @@ func TestGasPrice(t *testing.T) {
 	require.Equal("mock gas price error", err.Error())
 }
 
+func TestMaxPriorityFeePerGas(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+	core.EXPECT().SuggestGasTipCap().Return(uint64(16), nil)
+	ret, err := web3svr.maxPriorityFeePerGas()
+	require.NoError(err)
+	require.Equal("0x10", ret.(string))
+
+	core.EXPECT().SuggestGasTipCap().Return(uint64(0), errors.New("mock gas tip error"))
+	_, err = web3svr.maxPriorityFeePerGas()
+	require.Equal("mock gas tip error", err.Error())
+}
+
+func TestFeeHistory(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	t.Run("fee history", func(t *testing.T) {
+		core.EXPECT().TipHeight().Return(uint64(10))
+		core.EXPECT().FeeHistory(uint64(2), uint64(10), []float64{25, 75}).Return(
+			uint64(9),
+			[][]*big.Int{{big.NewInt(1), big.NewInt(2)}, {big.NewInt(3), big.NewInt(4)}},
+			[]*big.Int{big.NewInt(10), big.NewInt(11), big.NewInt(12)},
+			[]float64{0.5, 0.25},
+			nil,
+		)
+		in := gjson.Parse(`{"params":["0x2", "latest", [25, 75]]}`)
+		ret, err := web3svr.feeHistory(&in)
+		require.NoError(err)
+		require.Equal(&feeHistoryResult{
+			OldestBlock:   "0x9",
+			BaseFeePerGas: []string{"0xa", "0xb", "0xc"},
+			GasUsedRatio:  []float64{0.5, 0.25},
+			Reward:        [][]string{{"0x1", "0x2"}, {"0x3", "0x4"}},
+		}, ret)
+	})
+
+	t.Run("without reward percentiles", func(t *testing.T) {
+		core.EXPECT().FeeHistory(uint64(3), uint64(5), nil).Return(uint64(3), nil, []*big.Int{big.NewInt(1), big.NewInt(1), big.NewInt(1), big.NewInt(1)}, []float64{0, 0, 0}, nil)
+		in := gjson.Parse(`{"params":[3, "0x5"]}`)
+		ret, err := web3svr.feeHistory(&in)
+		require.NoError(err)
+		bytes, err := json.Marshal(ret)
+		require.NoError(err)
+		require.JSONEq(`{"oldestBlock":"0x3","baseFeePerGas":["0x1","0x1","0x1","0x1"],"gasUsedRatio":[0,0,0]}`, string(bytes))
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		for _, params := range []string{
+			`{"params":["0x2"]}`,
+			`{"params":["0xz", "latest"]}`,
+			`{"params":["0x2", "0x5", [75, 25]]}`,
+			`{"params":["0x2", "0x5", [101]]}`,
+			`{"params":["0x2", "0x5", ["a"]]}`,
+		} {
+			in := gjson.Parse(params)
+			_, err := web3svr.feeHistory(&in)
+			require.Error(err, params)
+		}
+	})
+}
+
+func TestSuggestGasPrices(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+	core.EXPECT().SuggestGasPrices().Return(&gasstation.GasPrices{Slow: 1, Standard: 2, Fast: 16}, nil)
+	ret, err := web3svr.suggestGasPrices()
+	require.NoError(err)
+	require.Equal(&getGasPricesResult{Slow: "0x1", Standard: "0x2", Fast: "0x10"}, ret)
+
+	core.EXPECT().SuggestGasPrices().Return(nil, errors.New("mock gas price error"))
+	_, err = web3svr.suggestGasPrices()
+	require.Equal("mock gas price error", err.Error())
+}
+
+func TestWeb3SimulateBatch(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	t.Run("InvalidFormat", func(t *testing.T) {
+		for _, req := range []string{
+			`{"params":[{}]}`,
+			`{"params":[{"calls":{}}]}`,
+			`{"params":[{"calls":[{"to":"0x1"}]}]}`,
+			`{"params":[{"calls":[], "stateOverrides":{"0x1":{}}}]}`,
+			`{"params":[{"calls":[], "stateOverrides":{"0x04C22AfaE6a03438b8FED74cb1Cf441168DF3F12":{"balance":"-0x1"}}}]}`,
+		} {
+			in := gjson.Parse(req)
+			_, err := web3svr.simulateBatch(context.Background(), &in)
+			require.Error(err, req)
+		}
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		contract := identityset.Address(31)
+		core.EXPECT().SimulateBatch(gomock.Any(), uint64(10), gomock.Any(), gomock.Any(), true).DoAndReturn(
+			func(_ context.Context, _ uint64, calls []*apitypes.SimulateCall, overrides map[common.Address]*evm.StateOverride, _ bool) ([]*apitypes.SimulateResult, error) {
+				require.Len(calls, 2)
+				require.Equal(identityset.Address(28).String(), calls[0].Caller.String())
+				require.Equal(contract.String(), calls[0].To)
+				require.Equal(big.NewInt(1), calls[0].Amount)
+				require.Equal(uint64(0x5208), calls[0].GasLimit)
+				require.Equal([]byte{0xd2, 0x01, 0x11, 0x4a}, calls[1].Data)
+				require.Equal("", calls[1].To)
+				override := overrides[common.BytesToAddress(identityset.Address(28).Bytes())]
+				require.Equal(big.NewInt(256), override.Balance)
+				require.Equal(common.HexToHash("0x2"), override.StateDiff[common.HexToHash("0x1")])
+				return []*apitypes.SimulateResult{
+					{
+						GasUsed:    21000,
+						Status:     uint64(iotextypes.ReceiptStatus_Success),
+						ReturnData: []byte{1},
+						Logs: []*action.Log{{
+							Address: contract.String(),
+							Topics:  []hash.Hash256{hash.Hash256b([]byte("topic"))},
+						}},
+					},
+					{
+						Status: uint64(iotextypes.ReceiptStatus_Failure),
+						Err:    errors.New("insufficient funds"),
+					},
+				}, nil
+			})
+		in := gjson.Parse(fmt.Sprintf(`{"params":[{
+			"blockNumber": "0xa",
+			"calls": [
+				{"from": "%[1]s", "to": "%[2]s", "value": "0x1", "gas": "0x5208"},
+				{"from": "%[1]s", "input": "0xd201114a"}
+			],
+			"stateOverrides": {"%[1]s": {"balance": "0x100", "stateDiff": {"0x1": "0x2"}}},
+			"continueOnFailure": true
+		}]}`, common.BytesToAddress(identityset.Address(28).Bytes()).Hex(), common.BytesToAddress(contract.Bytes()).Hex()))
+		ret, err := web3svr.simulateBatch(context.Background(), &in)
+		require.NoError(err)
+		raw, err := json.Marshal(ret)
+		require.NoError(err)
+		results := gjson.ParseBytes(raw).Array()
+		require.Len(results, 2)
+		require.Equal("0x5208", results[0].Get("gasUsed").String())
+		require.Equal("0x1", results[0].Get("status").String())
+		require.Equal("0x01", results[0].Get("returnData").String())
+		require.Equal(strings.ToLower(common.BytesToAddress(contract.Bytes()).Hex()), strings.ToLower(results[0].Get("logs.0.address").String()))
+		require.False(results[0].Get("error").Exists())
+		require.Equal("0x0", results[1].Get("status").String())
+		require.Equal("insufficient funds", results[1].Get("error").String())
+	})
+}
+
+func TestWeb3ConvertAddress(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	in := gjson.Parse(`{"params":[]}`)
+	_, err := web3svr.convertAddress(&in)
+	require.Equal(errInvalidFormat, errors.Cause(err))
+
+	addr := identityset.Address(10)
+	hexAddr := common.BytesToAddress(addr.Bytes()).Hex()
+	core.EXPECT().ConvertAddress(hexAddr).Return(&apitypes.AddressInfo{
+		ConvertedAddress: &addrutil.ConvertedAddress{
+			Address:    addr,
+			IoAddress:  addr.String(),
+			HexAddress: hexAddr,
+			Format:     addrutil.HexFormat,
+		},
+		Kind: apitypes.AddressKindAccount,
+	}, nil)
+	in = gjson.Parse(fmt.Sprintf(`{"params":["%s"]}`, hexAddr))
+	ret, err := web3svr.convertAddress(&in)
+	require.NoError(err)
+	require.Equal(&convertAddressResult{
+		IoAddress:  addr.String(),
+		HexAddress: &hexAddr,
+		Format:     addrutil.HexFormat,
+		Kind:       apitypes.AddressKindAccount,
+	}, ret)
+
+	core.EXPECT().ConvertAddress(address.RewardingPoolAddr).Return(&apitypes.AddressInfo{
+		ConvertedAddress: &addrutil.ConvertedAddress{
+			IoAddress:      address.RewardingPoolAddr,
+			Format:         addrutil.IoFormat,
+			SystemContract: "rewardingPool",
+		},
+		Kind: apitypes.AddressKindAccount,
+	}, nil)
+	in = gjson.Parse(fmt.Sprintf(`{"params":["%s"]}`, address.RewardingPoolAddr))
+	ret, err = web3svr.convertAddress(&in)
+	require.NoError(err)
+	b, err := json.Marshal(ret)
+	require.NoError(err)
+	require.JSONEq(fmt.Sprintf(`{"ioAddress":"%s","hexAddress":null,"format":"io","systemContract":"rewardingPool","kind":"account"}`, address.RewardingPoolAddr), string(b))
+
+	core.EXPECT().ConvertAddress("0x12").Return(nil, status.Error(codes.InvalidArgument, "invalid address"))
+	in = gjson.Parse(`{"params":["0x12"]}`)
+	_, err = web3svr.convertAddress(&in)
+	require.Equal(codes.InvalidArgument, status.Code(err))
+}
+
 func TestGetChainID(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
-	core.EXPECT().EVMNetworkID().Return(uint32(1))
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+	core.EXPECT().NetworkIdentity().Return(&apitypes.NetworkIdentity{EVMNetworkID: 1})
 	ret, err := web3svr.getChainID()
 	require.NoError(err)
 	require.Equal("0x1", ret.(string))
@@ -199,19 +433,43 @@ func TestGetBlockNumber(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 	core.EXPECT().TipHeight().Return(uint64(1))
 	ret, err := web3svr.getBlockNumber()
 	require.NoError(err)
 	require.Equal("0x1", ret.(string))
 }
 
+func TestGetFinalizedHeight(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+	core.EXPECT().FinalizedHeight().Return(uint64(10))
+	ret, err := web3svr.getFinalizedHeight()
+	require.NoError(err)
+	require.Equal("0xa", ret.(string))
+}
+
+func TestGetEarliestStateHeight(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+	core.EXPECT().EarliestStateHeight().Return(uint64(3))
+	ret, err := web3svr.getEarliestStateHeight()
+	require.NoError(err)
+	require.Equal("0x3", ret.(string))
+}
+
 func TestGetBlockByNumber(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -262,7 +520,7 @@ func TestGetBalance(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 	balance := "111111111111111111"
 	core.EXPECT().Account(gomock.Any()).Return(&iotextypes.AccountMeta{Balance: balance}, nil, nil)
 
@@ -279,8 +537,8 @@ func TestGetTransactionCount(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
-	core.EXPECT().PendingNonce(gomock.Any()).Return(uint64(2), nil)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+	core.EXPECT().AccountNonceDetail(gomock.Any()).Return(&apitypes.AccountNonceDetail{ConfirmedNonce: 1, PendingNonce: 2, Gaps: []uint64{2}}, nil)
 
 	inNil := gjson.Parse(`{"params":[]}`)
 	ret, err := web3svr.getTransactionCount(&inNil)
@@ -297,7 +555,7 @@ func TestCall(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	t.Run("to is StakingProtocol addr", func(t *testing.T) {
 		meta := &iotextypes.AccountMeta{
@@ -388,7 +646,7 @@ func TestEstimateGas(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 	core.EXPECT().ChainID().Return(uint32(1)).Times(2)
 
 	t.Run("estimate execution", func(t *testing.T) {
@@ -433,7 +691,7 @@ func TestSendRawTransaction(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 	core.EXPECT().Genesis().Return(genesis.Default)
 	core.EXPECT().TipHeight().Return(uint64(0))
 	core.EXPECT().EVMNetworkID().Return(uint32(1))
@@ -455,12 +713,184 @@ func TestSendRawTransaction(t *testing.T) {
 	})
 }
 
+func TestSendRawTransactionChainID(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+	g := genesis.Default
+	g.ToBeEnabledBlockHeight = 0
+	core.EXPECT().Genesis().Return(g).AnyTimes()
+	core.EXPECT().TipHeight().Return(uint64(1)).AnyTimes()
+	core.EXPECT().EVMNetworkID().Return(uint32(4689)).AnyTimes()
+	core.EXPECT().ChainID().Return(uint32(1)).AnyTimes()
+	core.EXPECT().SendAction(gomock.Any(), gomock.Any()).Return("111111111111111", nil).Times(1)
+
+	var (
+		sk     = identityset.PrivateKey(1).EcdsaPrivateKey().(*ecdsa.PrivateKey)
+		to     = common.BytesToAddress(identityset.Address(2).Bytes())
+		legacy = &types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(100), Gas: 21000, To: &to, Value: big.NewInt(1)}
+	)
+	send := func(tx *types.Transaction) error {
+		raw, err := tx.MarshalBinary()
+		require.NoError(err)
+		in := gjson.Parse(fmt.Sprintf(`{"params":["0x%s"]}`, hex.EncodeToString(raw)))
+		_, err = web3svr.sendRawTransaction(&in)
+		return err
+	}
+	for _, v := range []struct {
+		name string
+		tx   *types.Transaction
+		err  error
+	}{
+		{"wrong chain", types.MustSignNewTx(sk, types.NewEIP155Signer(big.NewInt(4690)), legacy), errInvalidEvmChainID},
+		{"unprotected", types.MustSignNewTx(sk, types.HomesteadSigner{}, legacy), action.ErrUnprotectedTx},
+		{"malformed", types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(100), Gas: 21000, To: &to, V: big.NewInt(1), R: big.NewInt(1), S: big.NewInt(1)}), action.ErrInvalidSignature},
+		{"accepted", types.MustSignNewTx(sk, types.NewEIP155Signer(big.NewInt(4689)), legacy), nil},
+	} {
+		t.Run(v.name, func(t *testing.T) {
+			err := send(v.tx)
+			if v.err == nil {
+				require.NoError(err)
+				return
+			}
+			require.ErrorIs(err, v.err)
+		})
+	}
+}
+
+func TestValidateRawTransaction(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+	core.EXPECT().Genesis().Return(genesis.Default).AnyTimes()
+	core.EXPECT().TipHeight().Return(uint64(0)).AnyTimes()
+	core.EXPECT().EVMNetworkID().Return(uint32(1)).AnyTimes()
+	core.EXPECT().ChainID().Return(uint32(1)).AnyTimes()
+	core.EXPECT().Account(gomock.Any()).Return(&iotextypes.AccountMeta{IsContract: true}, nil, nil).AnyTimes()
+	core.EXPECT().SendAction(gomock.Any(), gomock.Any()).Times(0)
+
+	inNil := gjson.Parse(`{"params":[]}`)
+	_, err := web3svr.validateRawTransaction(&inNil)
+	require.EqualError(err, errInvalidFormat.Error())
+
+	in := gjson.Parse(`{"params":["f8600180830186a09412745fec82b585f239c01090882eb40702c32b04808025a0b0e1aab5b64d744ae01fc9f1c3e9919844a799e90c23129d611f7efe6aec8a29a0195e28d22d9b280e00d501ff63525bb76f5c87b8646c89d5d9c5485edcb1b498"]}`)
+	core.EXPECT().ValidateAction(gomock.Any(), gomock.Any()).Return(&apitypes.ActionValidation{
+		Gas: 21000,
+		Fee: big.NewInt(21000000),
+	}, nil)
+	ret, err := web3svr.validateRawTransaction(&in)
+	require.NoError(err)
+	require.Equal(&validateActionResult{Accepted: true, Gas: "0x5208", Fee: "0x1406f40"}, ret)
+
+	core.EXPECT().ValidateAction(gomock.Any(), gomock.Any()).Return(&apitypes.ActionValidation{
+		Reason:  apitypes.RejectNonceTooLow,
+		Message: action.ErrNonceTooLow.Error(),
+	}, nil)
+	ret, err = web3svr.validateRawTransaction(&in)
+	require.NoError(err)
+	require.Equal(&validateActionResult{Reason: apitypes.RejectNonceTooLow, Message: "nonce too low"}, ret)
+}
+
+func TestTypedTransactionRoundTrip(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	var (
+		evmNetworkID = uint32(4689)
+		chainID      = big.NewInt(int64(evmNetworkID))
+		to           = common.BytesToAddress(identityset.Address(2).Bytes())
+		sk           = identityset.PrivateKey(1).EcdsaPrivateKey().(*ecdsa.PrivateKey)
+		signedTx     = types.MustSignNewTx(sk, types.NewLondonSigner(chainID), &types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     3,
+			GasTipCap: big.NewInt(1000000000),
+			GasFeeCap: big.NewInt(2000000000000),
+			Gas:       21000,
+			To:        &to,
+			Value:     big.NewInt(10),
+		})
+		raw, _ = signedTx.MarshalBinary()
+		sent   *iotextypes.Action
+	)
+	core.EXPECT().Genesis().Return(genesis.Default)
+	core.EXPECT().TipHeight().Return(uint64(0))
+	core.EXPECT().EVMNetworkID().Return(evmNetworkID).AnyTimes()
+	core.EXPECT().ChainID().Return(uint32(1))
+	core.EXPECT().Account(gomock.Any()).Return(&iotextypes.AccountMeta{}, nil, nil)
+	core.EXPECT().SendAction(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, in *iotextypes.Action) (string, error) {
+		sent = in
+		return signedTx.Hash().Hex()[2:], nil
+	})
+
+	// submit
+	in := gjson.Parse(fmt.Sprintf(`{"params":["0x%s"]}`, hex.EncodeToString(raw)))
+	ret, err := web3svr.sendRawTransaction(&in)
+	require.NoError(err)
+	require.Equal(signedTx.Hash().Hex(), ret.(string))
+	require.Equal(iotextypes.Encoding_ETHEREUM_DYNAMICFEE, sent.GetEncoding())
+	selp, err := (&action.Deserializer{}).SetEvmNetworkID(evmNetworkID).ActionToSealedEnvelope(sent)
+	require.NoError(err)
+	actHash, err := selp.Hash()
+	require.NoError(err)
+	require.Equal(signedTx.Hash().Bytes(), actHash[:])
+
+	// get by hash
+	receipt := &action.Receipt{
+		Status:      1,
+		BlockHeight: 1,
+		ActionHash:  actHash,
+		GasConsumed: 21000,
+	}
+	blk, err := block.NewTestingBuilder().
+		SetHeight(1).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetTimeStamp(time.Now()).
+		AddActions(selp).
+		SignAndBuild(identityset.PrivateKey(0))
+	require.NoError(err)
+	core.EXPECT().ActionWithStatusByHash(gomock.Any()).Return(&apitypes.ActionWithStatus{
+		Status:  apitypes.ActionStatusConfirmed,
+		Action:  selp,
+		Block:   &blk,
+		Receipt: receipt,
+	}, nil)
+	in = gjson.Parse(fmt.Sprintf(`{"params":["0x%s"]}`, hex.EncodeToString(actHash[:])))
+	ret, err = web3svr.getTransactionByHash(&in)
+	require.NoError(err)
+	res, err := json.Marshal(ret)
+	require.NoError(err)
+	for _, field := range []string{"type", "chainId", "maxFeePerGas", "maxPriorityFeePerGas", "accessList", "yParity"} {
+		require.True(gjson.GetBytes(res, field).Exists(), field)
+	}
+	require.Equal("0x2", gjson.GetBytes(res, "type").String())
+
+	// re-hash the returned tx
+	var tx types.Transaction
+	require.NoError(tx.UnmarshalJSON(res))
+	require.Equal(signedTx.Hash(), tx.Hash())
+
+	// full tx in block uses the same format
+	blkResult, err := web3svr.getBlockWithTransactions(&blk, []*action.Receipt{receipt}, true)
+	require.NoError(err)
+	require.Len(blkResult.transactions, 1)
+	blkTx, err := json.Marshal(blkResult.transactions[0])
+	require.NoError(err)
+	require.JSONEq(string(res), string(blkTx))
+}
+
 func TestGetCode(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 	code := "608060405234801561001057600080fd5b50610150806100206contractbytecode"
 	data, _ := hex.DecodeString(code)
 	core.EXPECT().Account(gomock.Any()).Return(&iotextypes.AccountMeta{ContractByteCode: data}, nil, nil)
@@ -484,11 +914,16 @@ func TestGetNodeInfo(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
-	core.EXPECT().ServerMeta().Return("111", "", "", "222", "")
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+	core.EXPECT().NetworkIdentity().Return(&apitypes.NetworkIdentity{
+		PackageVersion:  "v2.0.0",
+		PackageCommitID: "1a2b3c4d5e6f",
+		GoVersion:       "go1.22.5",
+		Platform:        "linux-amd64",
+	})
 	ret, err := web3svr.getNodeInfo()
 	require.NoError(err)
-	require.Equal("111/222", ret.(string))
+	require.Equal("iotex-core/v2.0.0-1a2b3c4d/linux-amd64/go1.22.5", ret.(string))
 }
 
 func TestGetNetworkID(t *testing.T) {
@@ -496,8 +931,8 @@ func TestGetNetworkID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
-	core.EXPECT().EVMNetworkID().Return(uint32(123))
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+	core.EXPECT().NetworkIdentity().Return(&apitypes.NetworkIdentity{EVMNetworkID: 123})
 	ret, err := web3svr.getNetworkID()
 	require.NoError(err)
 	require.Equal("123", ret.(string))
@@ -508,15 +943,30 @@ func TestIsSyncing(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
-	core.EXPECT().SyncingProgress().Return(uint64(1), uint64(2), uint64(3))
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+	core.EXPECT().SyncingProgress().Return(blocksync.SyncProgress{
+		StartingHeight:     1,
+		CurrentHeight:      2,
+		TargetHeight:       32,
+		BlocksPerSecond:    2.5,
+		EstimatedRemaining: 12 * time.Second,
+		Stage:              blocksync.SyncStageBlockDownload,
+	})
 	ret, err := web3svr.isSyncing()
 	require.NoError(err)
 	rlt, ok := ret.(*getSyncingResult)
 	require.True(ok)
 	require.Equal("0x1", rlt.StartingBlock)
 	require.Equal("0x2", rlt.CurrentBlock)
-	require.Equal("0x3", rlt.HighestBlock)
+	require.Equal("0x20", rlt.HighestBlock)
+	require.Equal(2.5, rlt.BlocksPerSecond)
+	require.Equal("0xc", rlt.EstimatedSecondsRemaining)
+	require.Equal("blockDownload", rlt.Stage)
+
+	core.EXPECT().SyncingProgress().Return(blocksync.SyncProgress{StartingHeight: 1, CurrentHeight: 32, TargetHeight: 32})
+	ret, err = web3svr.isSyncing()
+	require.NoError(err)
+	require.Equal(false, ret)
 }
 
 func TestGetBlockTransactionCountByHash(t *testing.T) {
@@ -524,7 +974,7 @@ func TestGetBlockTransactionCountByHash(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -560,7 +1010,7 @@ func TestGetBlockByHash(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -612,7 +1062,7 @@ func TestGetTransactionByHash(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	selp, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -634,8 +1084,12 @@ func TestGetTransactionByHash(t *testing.T) {
 		AddActions(selp).
 		SignAndBuild(identityset.PrivateKey(0))
 	require.NoError(err)
-	core.EXPECT().ActionByActionHash(gomock.Any()).Return(selp, &blk, uint32(0), nil)
-	core.EXPECT().ReceiptByActionHash(gomock.Any()).Return(receipt, nil)
+	core.EXPECT().ActionWithStatusByHash(gomock.Any()).Return(&apitypes.ActionWithStatus{
+		Status:  apitypes.ActionStatusConfirmed,
+		Action:  selp,
+		Block:   &blk,
+		Receipt: receipt,
+	}, nil)
 	core.EXPECT().EVMNetworkID().Return(uint32(0))
 
 	inNil := gjson.Parse(`{"params":[]}`)
@@ -650,8 +1104,11 @@ func TestGetTransactionByHash(t *testing.T) {
 	require.Equal(receipt, rlt.receipt)
 
 	// get pending transaction
-	core.EXPECT().ActionByActionHash(gomock.Any()).Return(nil, nil, uint32(0), ErrNotFound)
-	core.EXPECT().PendingActionByActionHash(gomock.Any()).Return(selp, nil)
+	core.EXPECT().ActionWithStatusByHash(gomock.Any()).Return(&apitypes.ActionWithStatus{
+		Status: apitypes.ActionStatusPending,
+		Action: selp,
+		Pool:   &actpool.PendingActionInfo{},
+	}, nil)
 	core.EXPECT().EVMNetworkID().Return(uint32(0))
 	ret, err = web3svr.getTransactionByHash(&in)
 	require.NoError(err)
@@ -665,8 +1122,11 @@ func TestGetTransactionByHash(t *testing.T) {
 	require.NoError(err)
 	txHash, err = selp.Hash()
 	require.NoError(err)
-	core.EXPECT().ActionByActionHash(gomock.Any()).Return(nil, nil, uint32(0), ErrNotFound)
-	core.EXPECT().PendingActionByActionHash(gomock.Any()).Return(selp, nil)
+	core.EXPECT().ActionWithStatusByHash(gomock.Any()).Return(&apitypes.ActionWithStatus{
+		Status: apitypes.ActionStatusPending,
+		Action: selp,
+		Pool:   &actpool.PendingActionInfo{},
+	}, nil)
 	core.EXPECT().EVMNetworkID().Return(uint32(0))
 	ret, err = web3svr.getTransactionByHash(&in)
 	require.NoError(err)
@@ -677,12 +1137,456 @@ func TestGetTransactionByHash(t *testing.T) {
 	require.Nil(rlt.to)
 }
 
+func TestGetTransactionStatus(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+	core.EXPECT().EVMNetworkID().Return(uint32(0)).AnyTimes()
+
+	selp, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	txHash, err := selp.Hash()
+	require.NoError(err)
+	in := gjson.Parse(fmt.Sprintf(`{"params":["0x%s"]}`, hex.EncodeToString(txHash[:])))
+	status := func() []byte {
+		ret, err := web3svr.getTransactionStatus(&in)
+		require.NoError(err)
+		res, err := json.Marshal(ret)
+		require.NoError(err)
+		return res
+	}
+
+	// pending in the actpool, behind a nonce gap
+	addedAt := time.Now().Add(-time.Minute)
+	core.EXPECT().ActionWithStatusByHash(txHash).Return(&apitypes.ActionWithStatus{
+		Status: apitypes.ActionStatusPending,
+		Action: selp,
+		Pool:   &actpool.PendingActionInfo{Position: 2, Executable: false, AddedAt: addedAt},
+	}, nil)
+	res := status()
+	require.Equal("pending", gjson.GetBytes(res, "status").String())
+	require.True(strings.EqualFold(identityset.Address(28).Hex(), gjson.GetBytes(res, "transaction.to").String()))
+	require.Equal(gjson.Null, gjson.GetBytes(res, "transaction.blockHash").Type)
+	require.False(gjson.GetBytes(res, "receipt").Exists())
+	require.Equal("0x2", gjson.GetBytes(res, "pool.position").String())
+	require.False(gjson.GetBytes(res, "pool.executable").Bool())
+	require.Equal(uint64ToHex(uint64(addedAt.Unix())), gjson.GetBytes(res, "pool.addedAt").String())
+	timeInPool, err := hexStringToNumber(gjson.GetBytes(res, "pool.timeInPool").String())
+	require.NoError(err)
+	require.GreaterOrEqual(timeInPool, uint64(60))
+
+	// confirmed in a block
+	receipt := &action.Receipt{
+		Status:      1,
+		BlockHeight: 1,
+		ActionHash:  txHash,
+		GasConsumed: 10000,
+	}
+	blk, err := block.NewTestingBuilder().
+		SetHeight(1).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetTimeStamp(time.Now()).
+		AddActions(selp).
+		SignAndBuild(identityset.PrivateKey(0))
+	require.NoError(err)
+	core.EXPECT().ActionWithStatusByHash(txHash).Return(&apitypes.ActionWithStatus{
+		Status:  apitypes.ActionStatusConfirmed,
+		Action:  selp,
+		Block:   &blk,
+		Receipt: receipt,
+	}, nil)
+	res = status()
+	blkHash := blk.HashBlock()
+	require.Equal("confirmed", gjson.GetBytes(res, "status").String())
+	require.Equal("0x"+hex.EncodeToString(blkHash[:]), gjson.GetBytes(res, "transaction.blockHash").String())
+	require.Equal("0x1", gjson.GetBytes(res, "receipt.blockNumber").String())
+	require.Equal("0x1", gjson.GetBytes(res, "receipt.status").String())
+	require.False(gjson.GetBytes(res, "pool").Exists())
+
+	// not found
+	core.EXPECT().ActionWithStatusByHash(txHash).Return(&apitypes.ActionWithStatus{Status: apitypes.ActionStatusNotFound}, nil)
+	res = status()
+	require.Equal("notFound", gjson.GetBytes(res, "status").String())
+	require.Equal(gjson.Null, gjson.GetBytes(res, "transaction").Type)
+
+	in = gjson.Parse(`{"params":[]}`)
+	_, err = web3svr.getTransactionStatus(&in)
+	require.ErrorIs(err, errInvalidFormat)
+}
+
+func TestGetTransactionLogsByBlockRange(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	var (
+		to      = identityset.Address(28)
+		blkHash = hash.Hash256b([]byte("block"))
+	)
+	core.EXPECT().TransactionLogsByBlockHeightRange(uint64(8), uint64(16), []address.Address{to}).Return([]*apitypes.BlockTransactionLogs{
+		{Height: 8, Status: apitypes.TransactionLogStatusUnavailable},
+		{Height: 9, Hash: blkHash, Status: apitypes.TransactionLogStatusFound, Logs: &iotextypes.TransactionLogs{
+			Logs: []*iotextypes.TransactionLog{{
+				ActionHash:      []byte{1, 2},
+				NumTransactions: 1,
+				Transactions: []*iotextypes.TransactionLog_Transaction{
+					{Amount: "16", Sender: identityset.Address(27).String(), Recipient: to.String(), Type: iotextypes.TransactionLogType_IN_CONTRACT_TRANSFER},
+				},
+			}},
+		}},
+	}, uint64(10), nil)
+	in := gjson.Parse(fmt.Sprintf(`{"params":["0x8", "0x10", ["%s"]]}`, to.Hex()))
+	ret, err := web3svr.getTransactionLogsByBlockRange(&in)
+	require.NoError(err)
+	res, err := json.Marshal(ret)
+	require.NoError(err)
+	require.Equal("0xa", gjson.GetBytes(res, "next").String())
+	require.Equal("unavailable", gjson.GetBytes(res, "blocks.0.status").String())
+	require.Equal(gjson.Null, gjson.GetBytes(res, "blocks.0.blockHash").Type)
+	require.Equal("0x9", gjson.GetBytes(res, "blocks.1.blockNumber").String())
+	require.Equal("0x"+hex.EncodeToString(blkHash[:]), gjson.GetBytes(res, "blocks.1.blockHash").String())
+	require.Equal("0x0102", gjson.GetBytes(res, "blocks.1.logs.0.transactionHash").String())
+	transfer := gjson.GetBytes(res, "blocks.1.logs.0.transfers.0")
+	require.Equal("IN_CONTRACT_TRANSFER", transfer.Get("type").String())
+	require.True(strings.EqualFold(to.Hex(), transfer.Get("to").String()))
+	require.Equal("0x10", transfer.Get("amount").String())
+
+	in = gjson.Parse(`{"params":["0x8"]}`)
+	_, err = web3svr.getTransactionLogsByBlockRange(&in)
+	require.ErrorIs(err, errInvalidFormat)
+}
+
+func TestGetAccountNonceDetail(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	var (
+		addr  = identityset.Address(27)
+		hash1 = hash.Hash256b([]byte("nonce1"))
+		hash4 = hash.Hash256b([]byte("nonce4"))
+	)
+	core.EXPECT().AccountNonceDetail(addr).Return(&apitypes.AccountNonceDetail{
+		ConfirmedNonce: 1,
+		PendingNonce:   2,
+		Gaps:           []uint64{2, 3},
+		Pending:        []*apitypes.PendingNonce{{Nonce: 1, Hash: hash1}, {Nonce: 4, Hash: hash4}},
+	}, nil).Times(1)
+	in := gjson.Parse(fmt.Sprintf(`{"params":["%s"]}`, addr.Hex()))
+	ret, err := web3svr.getAccountNonceDetail(&in)
+	require.NoError(err)
+	res, err := json.Marshal(ret)
+	require.NoError(err)
+	require.Equal("0x1", gjson.GetBytes(res, "confirmedNonce").String())
+	require.Equal("0x2", gjson.GetBytes(res, "pendingNonce").String())
+	require.Equal(`["0x2","0x3"]`, gjson.GetBytes(res, "gaps").Raw)
+	require.Equal("0x4", gjson.GetBytes(res, "pending.1.nonce").String())
+	require.Equal("0x"+hex.EncodeToString(hash4[:]), gjson.GetBytes(res, "pending.1.hash").String())
+
+	// no gap
+	core.EXPECT().AccountNonceDetail(addr).Return(&apitypes.AccountNonceDetail{ConfirmedNonce: 1, PendingNonce: 1}, nil).Times(1)
+	ret, err = web3svr.getAccountNonceDetail(&in)
+	require.NoError(err)
+	res, err = json.Marshal(ret)
+	require.NoError(err)
+	require.Equal("[]", gjson.GetBytes(res, "gaps").Raw)
+	require.Equal("[]", gjson.GetBytes(res, "pending").Raw)
+
+	in = gjson.Parse(`{"params":[]}`)
+	_, err = web3svr.getAccountNonceDetail(&in)
+	require.ErrorIs(err, errInvalidFormat)
+}
+
+func TestGetActPoolStatus(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	core.EXPECT().ActPoolStatus().Return(&actpool.ActPoolStatus{
+		Size:        2,
+		Capacity:    16,
+		GasSize:     21000,
+		GasCapacity: 1000000,
+		NoncePolicy: actpool.NoncePolicyStrict,
+	}).Times(1)
+	ret, err := web3svr.getActPoolStatus()
+	require.NoError(err)
+	res, err := json.Marshal(ret)
+	require.NoError(err)
+	require.Equal("0x2", gjson.GetBytes(res, "size").String())
+	require.Equal("0x10", gjson.GetBytes(res, "capacity").String())
+	require.Equal("0x5208", gjson.GetBytes(res, "gasSize").String())
+	require.Equal("0xf4240", gjson.GetBytes(res, "gasCapacity").String())
+	require.Equal("strict", gjson.GetBytes(res, "noncePolicy").String())
+}
+
+func TestGetContractsCreatedByBlock(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	var (
+		h       = hash.Hash256b([]byte("deploy"))
+		factory = identityset.Address(28)
+		child   = identityset.Address(29)
+	)
+	core.EXPECT().ContractsCreatedByBlock(uint64(5)).Return([]*apitypes.CreatedContract{
+		{ActionHash: h, Address: child.String(), Creator: factory.String(), Depth: 1},
+	}, nil).Times(1)
+	in := gjson.Parse(`{"params":["0x5"]}`)
+	ret, err := web3svr.getContractsCreatedByBlock(&in)
+	require.NoError(err)
+	res, err := json.Marshal(ret)
+	require.NoError(err)
+	require.Equal("0x"+hex.EncodeToString(h[:]), gjson.GetBytes(res, "0.transactionHash").String())
+	require.Equal(common.BytesToAddress(child.Bytes()).Hex(), gjson.GetBytes(res, "0.address").String())
+	require.Equal(common.BytesToAddress(factory.Bytes()).Hex(), gjson.GetBytes(res, "0.creator").String())
+	require.Equal("0x1", gjson.GetBytes(res, "0.depth").String())
+
+	core.EXPECT().ContractsCreatedByBlock(uint64(6)).Return([]*apitypes.CreatedContract{}, nil).Times(1)
+	in = gjson.Parse(`{"params":["0x6"]}`)
+	ret, err = web3svr.getContractsCreatedByBlock(&in)
+	require.NoError(err)
+	res, err = json.Marshal(ret)
+	require.NoError(err)
+	require.Equal("[]", string(res))
+
+	in = gjson.Parse(`{"params":[]}`)
+	_, err = web3svr.getContractsCreatedByBlock(&in)
+	require.ErrorIs(err, errInvalidFormat)
+}
+
+func TestGetTotalSupply(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	supply := &rewarding.Supply{
+		Height:             10,
+		Total:              big.NewInt(1000),
+		Circulating:        big.NewInt(600),
+		Burned:             big.NewInt(20),
+		Redistributed:      big.NewInt(0),
+		BlockBurned:        big.NewInt(2),
+		BlockRedistributed: big.NewInt(0),
+	}
+	core.EXPECT().TipHeight().Return(uint64(10)).Times(1)
+	core.EXPECT().TotalSupply(gomock.Any(), uint64(10)).Return(supply, nil).Times(1)
+	in := gjson.Parse(`{"params":["latest"]}`)
+	ret, err := web3svr.getTotalSupply(context.Background(), &in)
+	require.NoError(err)
+	res, err := json.Marshal(ret)
+	require.NoError(err)
+	require.Equal("0xa", gjson.GetBytes(res, "blockNumber").String())
+	require.Equal("0x3e8", gjson.GetBytes(res, "total").String())
+	require.Equal("0x258", gjson.GetBytes(res, "circulating").String())
+	require.Equal("0x14", gjson.GetBytes(res, "burned").String())
+	require.Equal("0x0", gjson.GetBytes(res, "redistributed").String())
+	require.Equal("0x2", gjson.GetBytes(res, "blockBurned").String())
+
+	core.EXPECT().TotalSupply(gomock.Any(), uint64(5)).Return(nil, status.Error(codes.Unimplemented, "no archive")).Times(1)
+	in = gjson.Parse(`{"params":["0x5"]}`)
+	_, err = web3svr.getTotalSupply(context.Background(), &in)
+	require.Equal(codes.Unimplemented, status.Code(err))
+}
+
+func TestGetRawBlock(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	blkHash := hash.Hash256b([]byte("block"))
+	raw := &apitypes.RawBlock{Height: 10, Hash: blkHash, Header: []byte{1, 2}, Receipts: []byte{}}
+	core.EXPECT().RawBlockByHeight(uint64(10), apitypes.RawBlockHeader|apitypes.RawBlockReceipts).Return(raw, nil).Times(1)
+	in := gjson.Parse(`{"params":["0xa", ["header", "receipts"]]}`)
+	ret, err := web3svr.getRawBlock(&in)
+	require.NoError(err)
+	res, err := json.Marshal(ret)
+	require.NoError(err)
+	require.JSONEq(`{"number":"0xa","hash":"0x`+hex.EncodeToString(blkHash[:])+`","header":"0x0102","receipts":"0x"}`, string(res))
+
+	core.EXPECT().RawBlockByHash(blkHash, apitypes.RawBlockAll).Return(nil, errors.Wrap(ErrNotFound, "no block")).Times(1)
+	in = gjson.Parse(`{"params":["0x` + hex.EncodeToString(blkHash[:]) + `"]}`)
+	ret, err = web3svr.getRawBlock(&in)
+	require.NoError(err)
+	require.Nil(ret)
+
+	in = gjson.Parse(`{"params":["0xa", ["logs"]]}`)
+	_, err = web3svr.getRawBlock(&in)
+	require.ErrorIs(err, errUnkownType)
+
+	core.EXPECT().RawHeaders(uint64(1), uint64(2)).Return([]*apitypes.RawBlock{
+		{Height: 1, Header: []byte{1}},
+		{Height: 2, Header: []byte{2}},
+	}, nil).Times(1)
+	in = gjson.Parse(`{"params":["0x1", "0x2"]}`)
+	ret, err = web3svr.getRawHeaders(&in)
+	require.NoError(err)
+	res, err = json.Marshal(ret)
+	require.NoError(err)
+	require.Equal("0x02", gjson.GetBytes(res, "1.header").String())
+	require.False(gjson.GetBytes(res, "1.body").Exists())
+
+	in = gjson.Parse(`{"params":["0x1"]}`)
+	_, err = web3svr.getRawHeaders(&in)
+	require.ErrorIs(err, errInvalidFormat)
+}
+
+func TestGetActionInclusionProof(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	actHash := hash.Hash256b([]byte("action"))
+	sibling := hash.Hash256b([]byte("sibling"))
+	header := []byte{1, 2}
+	blkHash := hash.Hash256b(header)
+	core.EXPECT().ActionInclusionProof(actHash).Return(&block.ActionInclusionProof{
+		Height:     10,
+		Index:      3,
+		ActionHash: actHash,
+		Path:       []hash.Hash256{sibling},
+		Header:     header,
+	}, nil).Times(1)
+	in := gjson.Parse(`{"params":["0x` + hex.EncodeToString(actHash[:]) + `"]}`)
+	ret, err := web3svr.getActionInclusionProof(&in)
+	require.NoError(err)
+	res, err := json.Marshal(ret)
+	require.NoError(err)
+	require.JSONEq(`{"blockNumber":"0xa","blockHash":"0x`+hex.EncodeToString(blkHash[:])+`","transactionIndex":"0x3",`+
+		`"transactionHash":"0x`+hex.EncodeToString(actHash[:])+`","path":["0x`+hex.EncodeToString(sibling[:])+`"],`+
+		`"header":"0x0102"}`, string(res))
+
+	core.EXPECT().ActionInclusionProof(actHash).Return(nil, errors.Wrap(ErrNotFound, "no action")).Times(1)
+	ret, err = web3svr.getActionInclusionProof(&in)
+	require.NoError(err)
+	require.Nil(ret)
+
+	in = gjson.Parse(`{"params":["0xzz"]}`)
+	_, err = web3svr.getActionInclusionProof(&in)
+	require.ErrorIs(err, errUnkownType)
+	in = gjson.Parse(`{"params":[]}`)
+	_, err = web3svr.getActionInclusionProof(&in)
+	require.ErrorIs(err, errInvalidFormat)
+}
+
+func TestCancelPendingTransaction(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	sender := identityset.Address(28)
+	pending, err := action.SignedTransfer(identityset.Address(29).String(), identityset.PrivateKey(28), 3, big.NewInt(10), nil, 10000, big.NewInt(5))
+	require.NoError(err)
+	pendingHash, err := pending.Hash()
+	require.NoError(err)
+	tsf, err := action.NewTransfer(3, big.NewInt(0), sender.String(), nil, action.TransferBaseIntrinsicGas, big.NewInt(7))
+	require.NoError(err)
+	elp := (&action.EnvelopeBuilder{}).SetNonce(3).SetGasLimit(action.TransferBaseIntrinsicGas).
+		SetGasPrice(big.NewInt(7)).SetChainID(1).SetAction(tsf).Build()
+	core.EXPECT().CancelPendingAction(sender, uint64(3), big.NewInt(2)).Return(&apitypes.CancelAction{
+		Envelope:        elp,
+		Pending:         pending,
+		MinGasPriceBump: big.NewInt(1),
+	}, nil).Times(1)
+	core.EXPECT().NetworkIdentity().Return(&apitypes.NetworkIdentity{EVMNetworkID: 4689}).Times(1)
+	in := gjson.Parse(`{"params":["` + sender.Hex() + `","0x3","0x2"]}`)
+	ret, err := web3svr.cancelPendingTransaction(&in)
+	require.NoError(err)
+	res, err := json.Marshal(ret)
+	require.NoError(err)
+	senderHex, err := ioAddrToEthAddr(sender.String())
+	require.NoError(err)
+	require.JSONEq(`{"from":"`+senderHex+`","to":"`+senderHex+`","nonce":"0x3","gas":"0x2710","gasPrice":"0x7",`+
+		`"value":"0x0","input":"0x","chainId":"0x1251",`+
+		`"replaces":"0x`+hex.EncodeToString(pendingHash[:])+`","minGasPriceBump":"0x1"}`, string(res))
+
+	core.EXPECT().CancelPendingAction(sender, uint64(3), nil).Return(nil, status.Error(codes.FailedPrecondition, "mined")).Times(1)
+	in = gjson.Parse(`{"params":["` + sender.String() + `","0x3"]}`)
+	_, err = web3svr.cancelPendingTransaction(&in)
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+
+	in = gjson.Parse(`{"params":["` + sender.Hex() + `","0x3","bump"]}`)
+	_, err = web3svr.cancelPendingTransaction(&in)
+	require.ErrorIs(err, errUnkownType)
+	in = gjson.Parse(`{"params":["` + sender.Hex() + `"]}`)
+	_, err = web3svr.cancelPendingTransaction(&in)
+	require.ErrorIs(err, errInvalidFormat)
+}
+
+func TestDebugTraceBlockByNumber(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	h := hash.Hash256b([]byte("execution"))
+	traces := []*apitypes.TxTrace{
+		{
+			ActionHash:  h,
+			ReturnValue: []byte{1},
+			Receipt:     &action.Receipt{Status: uint64(iotextypes.ReceiptStatus_Success), GasConsumed: 21000, ActionHash: h},
+			Tracer:      logger.NewStructLogger(nil),
+		},
+	}
+	core.EXPECT().TipHeight().Return(uint64(5)).Times(1)
+	core.EXPECT().TraceBlockByNumber(gomock.Any(), uint64(5), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ uint64, cfg *tracers.TraceConfig) ([]*apitypes.TxTrace, error) {
+			require.Nil(cfg.Tracer)
+			require.True(cfg.Config.DisableStack)
+			return traces, nil
+		}).Times(1)
+	in := gjson.Parse(`{"params":["latest", {"disableStack": true}]}`)
+	ret, err := web3svr.traceBlockByNumber(context.Background(), &in)
+	require.NoError(err)
+	res, err := json.Marshal(ret)
+	require.NoError(err)
+	require.Len(gjson.ParseBytes(res).Array(), 1)
+	require.Equal("0x"+hex.EncodeToString(h[:]), gjson.GetBytes(res, "0.txHash").String())
+	require.False(gjson.GetBytes(res, "0.result.failed").Bool())
+	require.Equal("0x01", gjson.GetBytes(res, "0.result.returnValue").String())
+	require.Equal(uint64(21000), gjson.GetBytes(res, "0.result.gas").Uint())
+
+	core.EXPECT().TraceBlockByNumber(gomock.Any(), uint64(3), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ uint64, cfg *tracers.TraceConfig) ([]*apitypes.TxTrace, error) {
+			require.Equal("callTracer", *cfg.Tracer)
+			require.Equal("1s", *cfg.Timeout)
+			return nil, status.Error(codes.OutOfRange, "state pruned")
+		}).Times(1)
+	in = gjson.Parse(`{"params":["0x3", {"tracer": "callTracer", "timeout": "1s"}]}`)
+	_, err = web3svr.traceBlockByNumber(context.Background(), &in)
+	require.Equal(codes.OutOfRange, status.Code(err))
+
+	in = gjson.Parse(`{"params":[]}`)
+	_, err = web3svr.traceBlockByNumber(context.Background(), &in)
+	require.ErrorIs(err, errInvalidFormat)
+}
+
 func TestGetLogs(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	logs := []*action.Log{
 		{
@@ -708,7 +1612,7 @@ func TestGetLogs(t *testing.T) {
 		blkHash1,
 		blkHash2,
 	}
-	core.EXPECT().LogsInRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(logs, hashes, nil)
+	core.EXPECT().LogsInRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(logs, hashes, nil)
 
 	ret, err := web3svr.getLogs(&filterObject{
 		FromBlock: "1",
@@ -726,12 +1630,223 @@ func TestGetLogs(t *testing.T) {
 	require.Equal(blkHash2, rlt[1].blockHash)
 }
 
+func TestGetTokenTransfers(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	tsfs := []*blockindex.TokenTransfer{
+		{
+			Standard: blockindex.XRC20,
+			Height:   2,
+			Token:    hash.BytesToHash160(identityset.Address(10).Bytes()),
+			From:     hash.BytesToHash160(identityset.Address(1).Bytes()),
+			To:       hash.BytesToHash160(identityset.Address(2).Bytes()),
+			Amount:   big.NewInt(100),
+		},
+	}
+	core.EXPECT().TipHeight().Return(uint64(5))
+	core.EXPECT().TokenTransfersByAddress(identityset.Address(1), &blockindex.TokenTransferQuery{
+		Cursor:     3,
+		Count:      10,
+		FromHeight: 2,
+		ToHeight:   5,
+	}).Return(tsfs, uint64(4), nil)
+	in := gjson.Parse(fmt.Sprintf(`{"params":[{"address":"%s", "fromBlock":"0x2", "toBlock":"latest", "cursor":"0x3", "limit":"0xa"}]}`, identityset.Address(1).Hex()))
+	ret, err := web3svr.getTokenTransfers(&in, core.TokenTransfersByAddress)
+	require.NoError(err)
+	require.Equal(&getTokenTransfersResult{transfers: tsfs, cursor: 4}, ret)
+
+	// the default query
+	core.EXPECT().TokenTransfersByContract(identityset.Address(10), &blockindex.TokenTransferQuery{
+		Count: _defaultTokenTransfersLimit,
+	}).Return(nil, uint64(0), nil)
+	in = gjson.Parse(fmt.Sprintf(`{"params":[{"address":"%s"}]}`, identityset.Address(10).Hex()))
+	ret, err = web3svr.getTokenTransfers(&in, core.TokenTransfersByContract)
+	require.NoError(err)
+	require.Equal(&getTokenTransfersResult{}, ret)
+
+	in = gjson.Parse(`{"params":[{}]}`)
+	_, err = web3svr.getTokenTransfers(&in, core.TokenTransfersByContract)
+	require.Equal(errInvalidFormat, errors.Cause(err))
+	in = gjson.Parse(fmt.Sprintf(`{"params":[{"address":"%s", "cursor":"x"}]}`, identityset.Address(10).Hex()))
+	_, err = web3svr.getTokenTransfers(&in, core.TokenTransfersByContract)
+	require.Equal(errUnkownType, errors.Cause(err))
+}
+
+func TestGetTransfersByRecipientAndMemo(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	tsfs := []*blockindex.MemoTransfer{
+		{
+			Height: 2,
+			Sender: hash.BytesToHash160(identityset.Address(1).Bytes()),
+			Amount: big.NewInt(100),
+		},
+	}
+	core.EXPECT().TipHeight().Return(uint64(5))
+	core.EXPECT().TransfersByRecipientAndMemo(identityset.Address(2), "deposit-001", &blockindex.MemoTransferQuery{
+		Cursor:     3,
+		Count:      10,
+		FromHeight: 2,
+		ToHeight:   5,
+	}).Return(tsfs, uint64(4), nil)
+	in := gjson.Parse(fmt.Sprintf(`{"params":[{"recipient":"%s", "memo":"deposit-001", "fromBlock":"0x2", "toBlock":"latest", "cursor":"0x3", "limit":"0xa"}]}`, identityset.Address(2).Hex()))
+	ret, err := web3svr.getTransfersByRecipientAndMemo(&in)
+	require.NoError(err)
+	require.Equal(&getMemoTransfersResult{transfers: tsfs, cursor: 4}, ret)
+
+	// the default query
+	core.EXPECT().TransfersByRecipientAndMemo(identityset.Address(2), "memo", &blockindex.MemoTransferQuery{
+		Count: _defaultTokenTransfersLimit,
+	}).Return(nil, uint64(0), nil)
+	in = gjson.Parse(fmt.Sprintf(`{"params":[{"recipient":"%s", "memo":"memo"}]}`, identityset.Address(2).String()))
+	ret, err = web3svr.getTransfersByRecipientAndMemo(&in)
+	require.NoError(err)
+	require.Equal(&getMemoTransfersResult{}, ret)
+
+	for _, params := range []string{
+		`{}`,
+		fmt.Sprintf(`{"recipient":"%s"}`, identityset.Address(2).Hex()),
+		fmt.Sprintf(`{"recipient":"%s", "memo":1}`, identityset.Address(2).Hex()),
+	} {
+		in = gjson.Parse(`{"params":[` + params + `]}`)
+		_, err = web3svr.getTransfersByRecipientAndMemo(&in)
+		require.Equal(errInvalidFormat, errors.Cause(err))
+	}
+	in = gjson.Parse(fmt.Sprintf(`{"params":[{"recipient":"%s", "memo":"memo", "limit":"x"}]}`, identityset.Address(2).Hex()))
+	_, err = web3svr.getTransfersByRecipientAndMemo(&in)
+	require.Equal(errUnkownType, errors.Cause(err))
+}
+
+func TestGetCandidateHistory(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	histories := []*staking.CandidateHistory{
+		{
+			Epoch:       2,
+			ID:          identityset.Address(1),
+			Name:        "test1",
+			Votes:       big.NewInt(100),
+			SelfStake:   big.NewInt(10),
+			BucketCount: 3,
+			Rank:        1,
+		},
+	}
+	core.EXPECT().CandidateHistory(identityset.Address(1), uint64(2), uint64(16)).Return(histories, nil)
+	in := gjson.Parse(fmt.Sprintf(`{"params":[{"candidate":"%s", "startEpoch":"0x2", "endEpoch":"0x10"}]}`, identityset.Address(1).Hex()))
+	ret, err := web3svr.getCandidateHistory(&in)
+	require.NoError(err)
+	require.Equal(&getCandidateHistoryResult{histories: histories}, ret)
+
+	in = gjson.Parse(fmt.Sprintf(`{"params":[{"candidate":"%s", "startEpoch":"0x2"}]}`, identityset.Address(1).Hex()))
+	_, err = web3svr.getCandidateHistory(&in)
+	require.Equal(errInvalidFormat, errors.Cause(err))
+	in = gjson.Parse(fmt.Sprintf(`{"params":[{"candidate":"%s", "startEpoch":"x", "endEpoch":"0x10"}]}`, identityset.Address(1).Hex()))
+	_, err = web3svr.getCandidateHistory(&in)
+	require.Equal(errUnkownType, errors.Cause(err))
+
+	core.EXPECT().EpochRanking(uint64(2)).Return(histories, nil)
+	in = gjson.Parse(`{"params":["0x2"]}`)
+	ret, err = web3svr.getEpochRanking(&in)
+	require.NoError(err)
+	require.Equal(&getCandidateHistoryResult{histories: histories}, ret)
+	in = gjson.Parse(`{"params":[]}`)
+	_, err = web3svr.getEpochRanking(&in)
+	require.Equal(errInvalidFormat, errors.Cause(err))
+}
+
+func TestGetSystemActions(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	sas := []*blockindex.SystemAction{
+		{
+			Type:        blockindex.SystemActionGrantReward,
+			BlockHeight: 2,
+			Index:       1,
+			RewardType:  action.BlockReward,
+			Epoch:       1,
+		},
+	}
+	core.EXPECT().SystemActionsByHeight(uint64(2)).Return(sas, nil)
+	in := gjson.Parse(`{"params":["0x2"]}`)
+	ret, err := web3svr.getSystemActions(&in, core.SystemActionsByHeight)
+	require.NoError(err)
+	require.Equal(&getSystemActionsResult{actions: sas}, ret)
+
+	core.EXPECT().GrantRewardsByEpoch(uint64(1)).Return(nil, status.Error(codes.Unavailable, "disabled"))
+	in = gjson.Parse(`{"params":["0x1"]}`)
+	_, err = web3svr.getSystemActions(&in, core.GrantRewardsByEpoch)
+	require.Equal(codes.Unavailable, status.Code(err))
+
+	in = gjson.Parse(`{"params":[]}`)
+	_, err = web3svr.getSystemActions(&in, core.GrantRewardsByEpoch)
+	require.Equal(errInvalidFormat, errors.Cause(err))
+	in = gjson.Parse(`{"params":["x"]}`)
+	_, err = web3svr.getSystemActions(&in, core.GrantRewardsByEpoch)
+	require.Equal(errUnkownType, errors.Cause(err))
+}
+
+func TestGetContractStats(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	day := blockindex.DayOf(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	stats := []*blockindex.ContractDayStats{
+		{Day: day, ContractStats: blockindex.ContractStats{Calls: 3, GasConsumed: 250, Failures: 1, UniqueCallers: 2}},
+	}
+	core.EXPECT().ContractStats(gomock.Any(), day, day+1).Return(stats, nil)
+	in := gjson.Parse(`{"params":[{"address":"0x7c13866F9253DEf79e20034eDD011e1d69E67fe5","fromDate":"2024-03-01","toDate":"2024-03-02"}]}`)
+	ret, err := web3svr.getContractStats(&in)
+	require.NoError(err)
+	require.Equal(&getContractStatsResult{stats: stats}, ret)
+
+	in = gjson.Parse(`{"params":[{"address":"0x7c13866F9253DEf79e20034eDD011e1d69E67fe5","fromDate":"2024-03-01"}]}`)
+	_, err = web3svr.getContractStats(&in)
+	require.Equal(errInvalidFormat, errors.Cause(err))
+	in = gjson.Parse(`{"params":[{"address":"0x7c13866F9253DEf79e20034eDD011e1d69E67fe5","fromDate":"2024-03-01","toDate":"03/02/2024"}]}`)
+	_, err = web3svr.getContractStats(&in)
+	require.Equal(errUnkownType, errors.Cause(err))
+
+	top := []*blockindex.ContractStats{{Calls: 3, GasConsumed: 250}}
+	core.EXPECT().TopContracts(day, day, blockindex.OrderByGasConsumed, uint64(5)).Return(top, nil)
+	in = gjson.Parse(`{"params":[{"fromDate":"2024-03-01","toDate":"2024-03-01","orderBy":"gas","limit":"0x5"}]}`)
+	ret, err = web3svr.getTopContracts(&in)
+	require.NoError(err)
+	require.Equal(&getTopContractsResult{stats: top}, ret)
+
+	core.EXPECT().TopContracts(day, day, blockindex.OrderByCalls, uint64(_defaultTopContractsLimit)).Return(nil, status.Error(codes.Unavailable, "disabled"))
+	in = gjson.Parse(`{"params":[{"fromDate":"2024-03-01","toDate":"2024-03-01"}]}`)
+	_, err = web3svr.getTopContracts(&in)
+	require.Equal(codes.Unavailable, status.Code(err))
+	in = gjson.Parse(`{"params":[{"fromDate":"2024-03-01","toDate":"2024-03-01","orderBy":"size"}]}`)
+	_, err = web3svr.getTopContracts(&in)
+	require.Equal(errUnkownType, errors.Cause(err))
+}
+
 func TestGetTransactionReceipt(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	selp, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -779,7 +1894,7 @@ func TestGetBlockTransactionCountByNumber(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -814,7 +1929,7 @@ func TestGetTransactionByBlockHashAndIndex(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -861,7 +1976,7 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -907,7 +2022,7 @@ func TestGetStorageAt(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 	val := []byte("test")
 	core.EXPECT().ReadContractStorage(gomock.Any(), gomock.Any(), gomock.Any()).Return(val, nil)
 
@@ -922,7 +2037,7 @@ func TestNewfilter(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit, nil}
 
 	ret, err := web3svr.newFilter(&filterObject{
 		FromBlock: "1",
@@ -939,7 +2054,7 @@ func TestNewBlockFilter(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit, nil}
 	core.EXPECT().TipHeight().Return(uint64(123))
 
 	ret, err := web3svr.newBlockFilter()
@@ -952,7 +2067,7 @@ func TestUninstallFilter(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit, nil}
 
 	require.NoError(web3svr.cache.Set("123456789abc", []byte("test")))
 
@@ -975,7 +2090,7 @@ func TestGetFilterChanges(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit, nil}
 	core.EXPECT().TipHeight().Return(uint64(0)).Times(3)
 
 	t.Run("log filterType", func(t *testing.T) {
@@ -1003,7 +2118,7 @@ func TestGetFilterChanges(t *testing.T) {
 			blkHash1,
 			blkHash2,
 		}
-		core.EXPECT().LogsInRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(logs, hashes, nil)
+		core.EXPECT().LogsInRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(logs, hashes, nil)
 
 		require.NoError(web3svr.cache.Set("123456789abc", []byte(`{"logHeight":0,"filterType":"log","fromBlock":"0x1"}`)))
 		in := gjson.Parse(`{"params":["0x123456789abc"]}`)
@@ -1060,7 +2175,7 @@ func TestGetFilterLogs(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit, nil}
 
 	logs := []*action.Log{
 		{
@@ -1087,7 +2202,7 @@ func TestGetFilterLogs(t *testing.T) {
 		blkHash2,
 	}
 	core.EXPECT().TipHeight().Return(uint64(0))
-	core.EXPECT().LogsInRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(logs, hashes, nil)
+	core.EXPECT().LogsInRange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(logs, hashes, nil)
 
 	require.NoError(web3svr.cache.Set("123456789abc", []byte(`{"logHeight":0,"filterType":"log","fromBlock":"0x1"}`)))
 
@@ -1116,7 +2231,7 @@ func TestSubscribe(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	listener := mock_apitypes.NewMockListener(ctrl)
 	listener.EXPECT().AddResponder(gomock.Any()).Return("streamid_1", nil).Times(3)
@@ -1162,7 +2277,7 @@ func TestUnsubscribe(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	listener := mock_apitypes.NewMockListener(ctrl)
 	listener.EXPECT().RemoveResponder(gomock.Any()).Return(true, nil)
@@ -1202,7 +2317,7 @@ func TestDebugTraceTransaction(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	ctx := context.Background()
 	tsf, err := action.SignedExecution(identityset.Address(29).String(),
@@ -1241,7 +2356,7 @@ func TestDebugTraceCall(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	ctx := context.Background()
 	tsf, err := action.SignedExecution(identityset.Address(29).String(),
@@ -1300,3 +2415,429 @@ func TestResponseIDMatchTypeWithRequest(t *testing.T) {
 		require.Contains(string(bodyBytes), tt.sub)
 	}
 }
+
+func TestWeb3MethodNotFound(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	core.EXPECT().Track(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return().AnyTimes()
+	svr := newHTTPHandler(NewWeb3Handler(core, "", _defaultBatchRequestLimit))
+
+	for _, method := range []string{"personal_sign", "eth_sendTransaction", "eth_signTransaction", "web3_foo"} {
+		req, _ := http.NewRequest(http.MethodPost, "http://url.com",
+			strings.NewReader(fmt.Sprintf(`{"jsonrpc":"2.0","method":"%s","params":[],"id":1}`, method)))
+		resp := httptest.NewRecorder()
+		svr.ServeHTTP(resp, req)
+		var body struct {
+			Error errMessage `json:"error"`
+		}
+		require.NoError(json.Unmarshal(resp.Body.Bytes(), &body))
+		require.Equal(-32601, body.Error.Code)
+		require.Contains(body.Error.Message, method)
+	}
+}
+
+func TestWeb3ResultTooLarge(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	core.EXPECT().Track(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return().AnyTimes()
+	core.EXPECT().LogsInRange(gomock.Any(), gomock.Any(), uint64(1), uint64(100), uint64(0)).Return(nil, nil, errors.Wrap(ErrResultTooLarge, "logs in [1, 100] exceed 1 bytes"))
+	svr := newHTTPHandler(NewWeb3Handler(core, "", _defaultBatchRequestLimit))
+
+	req, _ := http.NewRequest(http.MethodPost, "http://url.com",
+		strings.NewReader(`{"jsonrpc":"2.0","method":"eth_getLogs","params":[{"fromBlock":"0x1","toBlock":"0x64"}],"id":1}`))
+	resp := httptest.NewRecorder()
+	svr.ServeHTTP(resp, req)
+	var body struct {
+		Error errMessage `json:"error"`
+	}
+	require.NoError(json.Unmarshal(resp.Body.Bytes(), &body))
+	require.Equal(-32005, body.Error.Code)
+	require.Contains(body.Error.Message, ErrResultTooLarge.Error())
+}
+
+func TestSignTransaction(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+
+	dir := t.TempDir()
+	passwordFile := dir + "/password"
+	require.NoError(os.WriteFile(passwordFile, []byte("secret\n"), 0600))
+	ksDir := dir + "/keystore"
+	sk := identityset.PrivateKey(28)
+	_, err := keystore.NewKeyStore(ksDir, keystore.LightScryptN, keystore.LightScryptP).
+		ImportECDSA(sk.EcdsaPrivateKey().(*ecdsa.PrivateKey), "secret")
+	require.NoError(err)
+
+	t.Run("refuse non-loopback", func(t *testing.T) {
+		for _, host := range []string{"", "0.0.0.0", "192.168.1.1", "::"} {
+			_, err := newWeb3Keystore(host, ksDir, passwordFile)
+			require.ErrorIs(err, errKeystoreNotLoopback)
+		}
+	})
+
+	ks, err := newWeb3Keystore("127.0.0.1", ksDir, passwordFile)
+	require.NoError(err)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, ks}
+
+	t.Run("accounts", func(t *testing.T) {
+		ret, err := web3svr.ethAccounts()
+		require.NoError(err)
+		require.Equal([]string{identityset.Address(28).Hex()}, ret)
+	})
+
+	t.Run("sign as ioctl", func(t *testing.T) {
+		core.EXPECT().ChainID().Return(uint32(1))
+		core.EXPECT().Account(gomock.Any()).Return(&iotextypes.AccountMeta{IsContract: false}, nil, nil)
+		core.EXPECT().SuggestGasPrice().Return(uint64(1000000000000), nil)
+		core.EXPECT().AccountNonceDetail(gomock.Any()).Return(&apitypes.AccountNonceDetail{PendingNonce: 3}, nil)
+
+		in := gjson.Parse(fmt.Sprintf(`{"params":[{
+			"from":  "%s",
+			"to":    "%s",
+			"gas":   "0x5208",
+			"value": "0x64"
+		}]}`, identityset.Address(28).Hex(), identityset.Address(29).Hex()))
+		ret, err := web3svr.signTransaction(&in)
+		require.NoError(err)
+
+		tsf, err := action.NewTransfer(3, big.NewInt(100), identityset.Address(29).String(), nil, 21000, big.NewInt(1000000000000))
+		require.NoError(err)
+		elp := (&action.EnvelopeBuilder{}).SetNonce(3).SetGasLimit(21000).SetGasPrice(big.NewInt(1000000000000)).
+			SetChainID(1).SetAction(tsf).Build()
+		sealed, err := action.Sign(elp, sk)
+		require.NoError(err)
+		actBytes, err := proto.Marshal(sealed.Proto())
+		require.NoError(err)
+		actHash, err := sealed.Hash()
+		require.NoError(err)
+		res := ret.(*signTransactionResult)
+		require.Equal("0x"+hex.EncodeToString(actBytes), res.Raw)
+		require.Equal("0x"+hex.EncodeToString(actHash[:]), res.Hash)
+	})
+
+	t.Run("unknown account", func(t *testing.T) {
+		core.EXPECT().Account(gomock.Any()).Return(&iotextypes.AccountMeta{IsContract: false}, nil, nil)
+		core.EXPECT().ChainID().Return(uint32(1))
+		in := gjson.Parse(fmt.Sprintf(`{"params":[{
+			"from":     "%s",
+			"to":       "%s",
+			"gas":      "0x5208",
+			"gasPrice": "0x1",
+			"nonce":    "0x1"
+		}]}`, identityset.Address(29).Hex(), identityset.Address(28).Hex()))
+		_, err := web3svr.signTransaction(&in)
+		require.ErrorIs(err, errUnknownAccount)
+	})
+}
+
+func TestGetBlockByTimestamp(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	core.EXPECT().BlockHeightByTimestamp(time.Unix(100, 0), true).Return(uint64(10), nil)
+	in := gjson.Parse(`{"params":[{"timestamp":"0x64"}]}`)
+	ret, err := web3svr.getBlockNumberByTimestamp(&in)
+	require.NoError(err)
+	require.Equal("0xa", ret)
+	core.EXPECT().BlockHeightByTimestamp(time.Unix(100, 0), false).Return(uint64(0), ErrNotFound)
+	in = gjson.Parse(`{"params":[{"timestamp":"0x64", "closest":"after"}]}`)
+	ret, err = web3svr.getBlockNumberByTimestamp(&in)
+	require.NoError(err)
+	require.Nil(ret)
+	in = gjson.Parse(`{"params":[{}]}`)
+	_, err = web3svr.getBlockNumberByTimestamp(&in)
+	require.Equal(errInvalidFormat, errors.Cause(err))
+	for _, params := range []string{`{"timestamp":"x"}`, `{"timestamp":"0x64", "closest":"nearest"}`} {
+		in = gjson.Parse(`{"params":[` + params + `]}`)
+		_, err = web3svr.getBlockNumberByTimestamp(&in)
+		require.Equal(errUnkownType, errors.Cause(err))
+	}
+
+	metas := []*iotextypes.BlockMeta{
+		{
+			Height:          3,
+			Timestamp:       timestamppb.New(time.Unix(105, 0)),
+			ProducerAddress: identityset.Address(1).String(),
+			NumActions:      2,
+		},
+	}
+	core.EXPECT().BlockMetasByTimestampRange(time.Unix(100, 0), time.Unix(110, 0), uint64(1), uint64(5)).Return(metas, uint64(2), nil)
+	in = gjson.Parse(`{"params":[{"fromTime":"0x64", "toTime":"0x6e", "offset":"0x1", "limit":"0x5"}]}`)
+	ret, err = web3svr.getBlockMetasByTimestampRange(&in)
+	require.NoError(err)
+	require.Equal(&getBlockMetasResult{metas: metas, total: 2}, ret)
+	raw, err := json.Marshal(ret)
+	require.NoError(err)
+	require.Equal("0x2", gjson.GetBytes(raw, "total").String())
+	require.Equal("0x69", gjson.GetBytes(raw, "blockMetas.0.timestamp").String())
+	miner, err := ioAddrToEthAddr(identityset.Address(1).String())
+	require.NoError(err)
+	require.Equal(miner, gjson.GetBytes(raw, "blockMetas.0.miner").String())
+
+	core.EXPECT().BlockMetasByTimestampRange(time.Unix(100, 0), time.Unix(110, 0), uint64(0), uint64(_defaultBlockMetasLimit)).Return(nil, uint64(0), nil)
+	in = gjson.Parse(`{"params":[{"fromTime":"0x64", "toTime":"0x6e"}]}`)
+	_, err = web3svr.getBlockMetasByTimestampRange(&in)
+	require.NoError(err)
+	in = gjson.Parse(`{"params":[{"fromTime":"0x64"}]}`)
+	_, err = web3svr.getBlockMetasByTimestampRange(&in)
+	require.Equal(errInvalidFormat, errors.Cause(err))
+	in = gjson.Parse(`{"params":[{"fromTime":"0x64", "toTime":"0x6e", "limit":"x"}]}`)
+	_, err = web3svr.getBlockMetasByTimestampRange(&in)
+	require.Equal(errUnkownType, errors.Cause(err))
+}
+
+func TestGetProtocolParameters(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	var (
+		inForce = []protocol.Parameter{
+			{Protocol: "rewarding", Name: "blockReward", Value: "16", Height: 0},
+		}
+		upcoming = []protocol.Parameter{
+			{Protocol: "rewarding", Name: "blockReward", Value: "8", Height: 100},
+		}
+	)
+	core.EXPECT().TipHeight().Return(uint64(10))
+	core.EXPECT().ProtocolParameters(uint64(10)).Return(inForce, upcoming, nil)
+	in := gjson.Parse(`{"params":[]}`)
+	ret, err := web3svr.getProtocolParameters(&in)
+	require.NoError(err)
+	require.Equal(&getProtocolParametersResult{height: 10, inForce: inForce, upcoming: upcoming}, ret)
+	raw, err := json.Marshal(ret)
+	require.NoError(err)
+	require.Equal("0xa", gjson.GetBytes(raw, "number").String())
+	require.Equal("rewarding", gjson.GetBytes(raw, "parameters.0.protocol").String())
+	require.Equal("16", gjson.GetBytes(raw, "parameters.0.value").String())
+	require.Equal("0x0", gjson.GetBytes(raw, "parameters.0.height").String())
+	require.Equal("0x64", gjson.GetBytes(raw, "upcoming.0.height").String())
+
+	core.EXPECT().ProtocolParameters(uint64(5)).Return(nil, nil, status.Error(codes.Unavailable, "not populated"))
+	in = gjson.Parse(`{"params":["0x5"]}`)
+	_, err = web3svr.getProtocolParameters(&in)
+	require.Equal(codes.Unavailable, status.Code(err))
+	in = gjson.Parse(`{"params":["x"]}`)
+	_, err = web3svr.getProtocolParameters(&in)
+	require.Equal(errUnkownType, errors.Cause(err))
+}
+
+func TestGetBuckets(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	buckets := &iotextypes.VoteBucketList{
+		Buckets: []*iotextypes.VoteBucket{
+			{Index: 1, CandidateAddress: identityset.Address(1).String(), Owner: identityset.Address(2).String(), StakedAmount: "100"},
+		},
+	}
+	data, err := proto.Marshal(buckets)
+	require.NoError(err)
+	voter := identityset.Address(2)
+	for _, c := range []struct {
+		params  string
+		method  iotexapi.ReadStakingDataMethod_Name
+		request *iotexapi.ReadStakingDataRequest
+	}{
+		{
+			`[]`,
+			iotexapi.ReadStakingDataMethod_COMPOSITE_BUCKETS,
+			&iotexapi.ReadStakingDataRequest{Request: &iotexapi.ReadStakingDataRequest_Buckets{
+				Buckets: &iotexapi.ReadStakingDataRequest_VoteBuckets{Pagination: &iotexapi.PaginationParam{Limit: _defaultStakingPageLimit}},
+			}},
+		},
+		{
+			fmt.Sprintf(`[{"voter":"%s", "offset":"0x1", "limit":"0x2"}]`, voter.Hex()),
+			iotexapi.ReadStakingDataMethod_COMPOSITE_BUCKETS_BY_VOTER,
+			&iotexapi.ReadStakingDataRequest{Request: &iotexapi.ReadStakingDataRequest_BucketsByVoter{
+				BucketsByVoter: &iotexapi.ReadStakingDataRequest_VoteBucketsByVoter{VoterAddress: voter.String(), Pagination: &iotexapi.PaginationParam{Offset: 1, Limit: 2}},
+			}},
+		},
+		{
+			`[{"candidate":"test1"}]`,
+			iotexapi.ReadStakingDataMethod_COMPOSITE_BUCKETS_BY_CANDIDATE,
+			&iotexapi.ReadStakingDataRequest{Request: &iotexapi.ReadStakingDataRequest_BucketsByCandidate{
+				BucketsByCandidate: &iotexapi.ReadStakingDataRequest_VoteBucketsByCandidate{CandName: "test1", Pagination: &iotexapi.PaginationParam{Limit: _defaultStakingPageLimit}},
+			}},
+		},
+	} {
+		methodName, err := proto.Marshal(&iotexapi.ReadStakingDataMethod{Method: c.method})
+		require.NoError(err)
+		arg, err := proto.Marshal(c.request)
+		require.NoError(err)
+		core.EXPECT().ReadState("staking", "", methodName, [][]byte{arg}).Return(&iotexapi.ReadStateResponse{
+			Data:            data,
+			BlockIdentifier: &iotextypes.BlockIdentifier{Height: 5},
+		}, nil)
+		in := gjson.Parse(`{"params":` + c.params + `}`)
+		ret, err := web3svr.getBuckets(&in)
+		require.NoError(err, c.params)
+		require.Equal(uint64(5), ret.(*getBucketsResult).height)
+		require.True(proto.Equal(buckets.Buckets[0], ret.(*getBucketsResult).buckets[0]))
+	}
+
+	in := gjson.Parse(fmt.Sprintf(`{"params":[{"voter":"%s", "candidate":"test1"}]}`, voter.Hex()))
+	_, err = web3svr.getBuckets(&in)
+	require.Equal(errInvalidFormat, errors.Cause(err))
+	in = gjson.Parse(`{"params":[{"limit":"0x3e9"}]}`)
+	_, err = web3svr.getBuckets(&in)
+	require.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetCandidates(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	candidates := &iotextypes.CandidateListV2{
+		Candidates: []*iotextypes.CandidateV2{{Name: "test1", OwnerAddress: identityset.Address(1).String()}},
+	}
+	data, err := proto.Marshal(candidates)
+	require.NoError(err)
+	methodName, err := proto.Marshal(&iotexapi.ReadStakingDataMethod{Method: iotexapi.ReadStakingDataMethod_CANDIDATES})
+	require.NoError(err)
+	arg, err := proto.Marshal(&iotexapi.ReadStakingDataRequest{Request: &iotexapi.ReadStakingDataRequest_Candidates_{
+		Candidates: &iotexapi.ReadStakingDataRequest_Candidates{Pagination: &iotexapi.PaginationParam{Offset: 2, Limit: _defaultStakingPageLimit}},
+	}})
+	require.NoError(err)
+	core.EXPECT().ReadState("staking", "", methodName, [][]byte{arg}).Return(&iotexapi.ReadStateResponse{
+		Data:            data,
+		BlockIdentifier: &iotextypes.BlockIdentifier{Height: 5},
+	}, nil)
+	in := gjson.Parse(`{"params":[{"offset":"0x2"}]}`)
+	ret, err := web3svr.getCandidates(&in)
+	require.NoError(err)
+	require.Equal(uint64(5), ret.(*getCandidatesResult).height)
+	require.True(proto.Equal(candidates.Candidates[0], ret.(*getCandidatesResult).candidates[0]))
+
+	in = gjson.Parse(`{"params":[{"offset":"x"}]}`)
+	_, err = web3svr.getCandidates(&in)
+	require.Equal(errUnkownType, errors.Cause(err))
+}
+
+func TestGetUnclaimedRewards(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	addr := identityset.Address(1)
+	core.EXPECT().ReadState("rewarding", "", []byte("UnclaimedBalance"), [][]byte{[]byte(addr.String())}).Return(&iotexapi.ReadStateResponse{
+		Data:            []byte("100"),
+		BlockIdentifier: &iotextypes.BlockIdentifier{Height: 5},
+	}, nil)
+	in := gjson.Parse(fmt.Sprintf(`{"params":["%s"]}`, addr.Hex()))
+	ret, err := web3svr.getUnclaimedRewards(&in)
+	require.NoError(err)
+	require.Equal(&getUnclaimedRewardsResult{
+		Address:     &addressResult{IoAddress: addr.String(), HexAddress: common.BytesToAddress(addr.Bytes()).Hex()},
+		Balance:     "0x64",
+		BlockNumber: "0x5",
+	}, ret)
+
+	in = gjson.Parse(`{"params":[]}`)
+	_, err = web3svr.getUnclaimedRewards(&in)
+	require.Equal(errInvalidFormat, errors.Cause(err))
+}
+
+func TestGetEpochMeta(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	data := &iotextypes.EpochData{Num: 2, Height: 721, GravityChainStartHeight: 100}
+	producers := []*iotexapi.BlockProducerInfo{{Address: identityset.Address(1).String(), Votes: "100", Active: true, Production: 30}}
+	core.EXPECT().EpochMeta(uint64(2)).Return(data, uint64(360), producers, nil)
+	in := gjson.Parse(`{"params":["0x2"]}`)
+	ret, err := web3svr.getEpochMeta(&in)
+	require.NoError(err)
+	require.Equal(&getEpochMetaResult{data: data, totalBlocks: 360, producers: producers}, ret)
+
+	// the epoch meta is null without the rolldpos protocol
+	core.EXPECT().EpochMeta(uint64(2)).Return(nil, uint64(0), nil, nil)
+	ret, err = web3svr.getEpochMeta(&in)
+	require.NoError(err)
+	require.Nil(ret)
+
+	in = gjson.Parse(`{"params":["x"]}`)
+	_, err = web3svr.getEpochMeta(&in)
+	require.Equal(errUnkownType, errors.Cause(err))
+}
+
+func TestGetActionByHash(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	selp, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), 1, big.NewInt(10), nil, 100000, big.NewInt(0))
+	require.NoError(err)
+	h, err := selp.Hash()
+	require.NoError(err)
+	info := &iotexapi.ActionInfo{Action: selp.Proto(), ActHash: hex.EncodeToString(h[:]), Sender: identityset.Address(27).String()}
+	core.EXPECT().Action(hex.EncodeToString(h[:]), true).Return(info, nil)
+	core.EXPECT().EVMNetworkID().Return(uint32(0))
+	in := gjson.Parse(fmt.Sprintf(`{"params":["0x%x"]}`, h[:]))
+	ret, err := web3svr.getActionByHash(&in)
+	require.NoError(err)
+	require.Equal(info, ret.(*getActionResult).info)
+	require.Equal(selp.Envelope, ret.(*getActionResult).selp.Envelope)
+
+	core.EXPECT().Action(hex.EncodeToString(h[:]), true).Return(nil, status.Error(codes.Unavailable, "not found"))
+	_, err = web3svr.getActionByHash(&in)
+	require.Equal(codes.Unavailable, status.Code(err))
+}
+
+func TestWeb3IotexMethods(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	core.EXPECT().Track(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return().AnyTimes()
+	svr := newHTTPHandler(NewWeb3Handler(core, "", _defaultBatchRequestLimit))
+
+	req, _ := http.NewRequest(http.MethodPost, "http://url.com",
+		strings.NewReader(`{"jsonrpc":"2.0","method":"iotex_supportedMethods","params":[],"id":1}`))
+	resp := httptest.NewRecorder()
+	svr.ServeHTTP(resp, req)
+	var body struct {
+		Result []string `json:"result"`
+	}
+	require.NoError(json.Unmarshal(resp.Body.Bytes(), &body))
+	require.True(sort.StringsAreSorted(body.Result))
+	for _, method := range []string{
+		"iotex_getBuckets",
+		"iotex_getCandidates",
+		"iotex_getUnclaimedRewards",
+		"iotex_getEpochMeta",
+		"iotex_getActionByHash",
+		"iotex_getFinalizedHeight",
+		"iotex_getEarliestStateHeight",
+	} {
+		require.Contains(body.Result, method)
+	}
+	for _, method := range body.Result {
+		require.True(strings.HasPrefix(method, "iotex_"), method)
+	}
+	require.NotContains(body.Result, "eth_sign")
+}
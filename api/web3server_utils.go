@@ -25,9 +25,12 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
 	logfilter "github.com/iotexproject/iotex-core/api/logfilter"
 	apitypes "github.com/iotexproject/iotex-core/api/types"
 	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockindex"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
 )
@@ -36,11 +39,22 @@ func hexStringToNumber(hexStr string) (uint64, error) {
 	return strconv.ParseUint(util.Remove0xPrefix(hexStr), 16, 64)
 }
 
-func ethAddrToIoAddr(ethAddr string) (address.Address, error) {
-	if ok := common.IsHexAddress(ethAddr); !ok {
-		return nil, errors.Wrapf(errUnkownType, "ethAddr: %s", ethAddr)
+// parseUnixTime parses the time of a request in unix seconds of hex
+func parseUnixTime(str string) (time.Time, error) {
+	sec, err := hexStringToNumber(str)
+	if err != nil || sec > math.MaxInt64 {
+		return time.Time{}, errors.Wrapf(errUnkownType, "timestamp: %s", str)
 	}
-	return address.FromHex(ethAddr)
+	return time.Unix(int64(sec), 0), nil
+}
+
+// parseAddress parses the address of a request in either io or hex format
+func parseAddress(str string) (address.Address, error) {
+	addr, err := addrutil.ParseAddress(str)
+	if err != nil {
+		return nil, errors.Wrap(errUnkownType, err.Error())
+	}
+	return addr, nil
 }
 
 func ioAddrToEthAddr(ioAddr string) (string, error) {
@@ -54,6 +68,19 @@ func ioAddrToEthAddr(ioAddr string) (string, error) {
 	return addr.String(), nil
 }
 
+// parseDayRange parses the dates in the format of 2006-01-02 into the days since the unix epoch
+func parseDayRange(fromDate, toDate string) (uint64, uint64, error) {
+	from, err := time.Parse(time.DateOnly, fromDate)
+	if err != nil {
+		return 0, 0, errors.Wrapf(errUnkownType, "fromDate: %s", fromDate)
+	}
+	to, err := time.Parse(time.DateOnly, toDate)
+	if err != nil {
+		return 0, 0, errors.Wrapf(errUnkownType, "toDate: %s", toDate)
+	}
+	return blockindex.DayOf(from), blockindex.DayOf(to), nil
+}
+
 func uint64ToHex(val uint64) string {
 	return "0x" + strconv.FormatUint(val, 16)
 }
@@ -112,6 +139,114 @@ func (svr *web3Handler) assemblePendingTransaction(selp *action.SealedEnvelope)
 	return newGetTransactionResult(nil, selp, nil, svr.coreService.EVMNetworkID())
 }
 
+func (svr *web3Handler) assembleReceipt(blk *block.Block, selp *action.SealedEnvelope, receipt *action.Receipt) (*getReceiptResult, error) {
+	to, contractAddr, err := getRecipientAndContractAddrFromAction(selp, receipt)
+	if err != nil {
+		return nil, err
+	}
+
+	// acquire logsBloom from blockMeta
+	var logsBloomStr string
+	if logsBloom := blk.LogsBloomfilter(); logsBloom != nil {
+		logsBloomStr = hex.EncodeToString(logsBloom.Bytes())
+	}
+
+	return &getReceiptResult{
+		blockHash:       blk.HashBlock(),
+		from:            selp.SenderAddress(),
+		to:              to,
+		contractAddress: contractAddr,
+		logsBloom:       logsBloomStr,
+		receipt:         receipt,
+	}, nil
+}
+
+// _rawBlockParts are the names of the parts of a raw block
+var _rawBlockParts = map[string]apitypes.RawBlockParts{
+	"header":   apitypes.RawBlockHeader,
+	"body":     apitypes.RawBlockBody,
+	"footer":   apitypes.RawBlockFooter,
+	"receipts": apitypes.RawBlockReceipts,
+}
+
+func newRawBlockResult(blk *apitypes.RawBlock) *rawBlockResult {
+	ret := &rawBlockResult{
+		Number: uint64ToHex(blk.Height),
+		Hash:   "0x" + hex.EncodeToString(blk.Hash[:]),
+	}
+	for _, part := range []struct {
+		data []byte
+		str  *string
+	}{
+		{blk.Header, &ret.Header},
+		{blk.Body, &ret.Body},
+		{blk.Footer, &ret.Footer},
+		{blk.Receipts, &ret.Receipts},
+	} {
+		if part.data != nil {
+			*part.str = byteToHex(part.data)
+		}
+	}
+	return ret
+}
+
+func newActionInclusionProofResult(proof *block.ActionInclusionProof) *actionInclusionProofResult {
+	path := make([]string, 0, len(proof.Path))
+	for _, h := range proof.Path {
+		path = append(path, "0x"+hex.EncodeToString(h[:]))
+	}
+	blkHash := hash.Hash256b(proof.Header)
+	return &actionInclusionProofResult{
+		BlockNumber:      uint64ToHex(proof.Height),
+		BlockHash:        "0x" + hex.EncodeToString(blkHash[:]),
+		TransactionIndex: uint64ToHex(uint64(proof.Index)),
+		TransactionHash:  "0x" + hex.EncodeToString(proof.ActionHash[:]),
+		Path:             path,
+		Header:           byteToHex(proof.Header),
+	}
+}
+
+func assembleBlockTransactionLogs(blk *apitypes.BlockTransactionLogs) (*blockTransactionLogsResult, error) {
+	ret := &blockTransactionLogsResult{
+		BlockNumber: uint64ToHex(blk.Height),
+		Status:      blk.Status,
+		Logs:        make([]*transactionLogResult, 0),
+	}
+	if blk.Status != apitypes.TransactionLogStatusUnavailable {
+		h := byteToHex(blk.Hash[:])
+		ret.BlockHash = &h
+	}
+	for _, l := range blk.Logs.GetLogs() {
+		logRet := &transactionLogResult{
+			TransactionHash: byteToHex(l.ActionHash),
+			Transfers:       make([]*transactionTransferResult, 0, len(l.Transactions)),
+		}
+		for _, tx := range l.Transactions {
+			from, err := ioAddrToEthAddr(tx.Sender)
+			if err != nil {
+				return nil, err
+			}
+			to, err := ioAddrToEthAddr(tx.Recipient)
+			if err != nil {
+				return nil, err
+			}
+			amount, err := intStrToHex(tx.Amount)
+			if err != nil {
+				return nil, err
+			}
+			logRet.Transfers = append(logRet.Transfers, &transactionTransferResult{
+				Type:   tx.Type.String(),
+				Topic:  byteToHex(tx.Topic),
+				From:   from,
+				To:     to,
+				Amount: amount,
+			})
+		}
+		ret.Logs = append(ret.Logs, logRet)
+	}
+	return ret, nil
+}
+
 func getRecipientAndContractAddrFromAction(selp *action.SealedEnvelope, receipt *action.Receipt) (*string, *string, error) {
 	// recipient is empty when contract is created
 	if exec, ok := selp.Action().(*action.Execution); ok && len(exec.Contract()) == 0 {
@@ -135,6 +270,8 @@ func (svr *web3Handler) parseBlockNumber(str string) (uint64, error) {
 		return 1, nil
 	case "", _pendingBlockNumber, _latestBlockNumber:
 		return svr.coreService.TipHeight(), nil
+	case _finalizedBlockNumber, _safeBlockNumber:
+		return svr.coreService.FinalizedHeight(), nil
 	default:
 		return hexStringToNumber(str)
 	}
@@ -162,6 +299,9 @@ func (svr *web3Handler) ethTxToEnvelope(tx *types.Transaction) (action.Envelope,
 	if to == address.RewardingProtocol {
 		return elpBuilder.BuildRewardingAction(tx)
 	}
+	if to == account.ProtocolAddr().String() {
+		return elpBuilder.BuildAccountAction(tx)
+	}
 	isContract, err := svr.checkContractAddr(to)
 	if err != nil {
 		return nil, err
@@ -189,7 +329,7 @@ func (svr *web3Handler) getLogsWithFilter(from uint64, to uint64, addrs []string
 	if err != nil {
 		return nil, err
 	}
-	logs, hashes, err := svr.coreService.LogsInRange(filter, from, to, 0)
+	logs, hashes, err := svr.coreService.LogsInRange(context.Background(), filter, from, to, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -204,7 +344,7 @@ func (svr *web3Handler) getLogsWithFilter(from uint64, to uint64, addrs []string
 func newLogFilterFrom(addrs []string, topics [][]string) (*logfilter.LogFilter, error) {
 	filter := iotexapi.LogsFilter{}
 	for _, ethAddr := range addrs {
-		ioAddr, err := ethAddrToIoAddr(ethAddr)
+		ioAddr, err := parseAddress(ethAddr)
 		if err != nil {
 			return nil, err
 		}
@@ -263,6 +403,8 @@ func parseLogRequest(in gjson.Result) (*filterObject, error) {
 	return &logReq, nil
 }
 
+// parseCallObject parses the call object of the simulations, e.g. eth_call and eth_estimateGas. The call object is
+// unsigned, so its chainId isn't validated against the evm network id as a signed raw tx is
 func parseCallObject(in *gjson.Result) (address.Address, string, uint64, *big.Int, *big.Int, []byte, error) {
 	var (
 		from     address.Address
@@ -277,13 +419,13 @@ func parseCallObject(in *gjson.Result) (address.Address, string, uint64, *big.In
 	if fromStr == "" {
 		fromStr = "0x0000000000000000000000000000000000000000"
 	}
-	if from, err = ethAddrToIoAddr(fromStr); err != nil {
+	if from, err = parseAddress(fromStr); err != nil {
 		return nil, "", 0, nil, nil, nil, err
 	}
 
 	toStr := in.Get("params.0.to").String()
 	if toStr != "" {
-		ioAddr, err := ethAddrToIoAddr(toStr)
+		ioAddr, err := parseAddress(toStr)
 		if err != nil {
 			return nil, "", 0, nil, nil, nil, err
 		}
@@ -322,6 +464,48 @@ func parseCallObject(in *gjson.Result) (address.Address, string, uint64, *big.In
 	return from, to, gasLimit, gasPrice, value, data, nil
 }
 
+func parseStateOverrides(in gjson.Result) (map[common.Address]*evm.StateOverride, error) {
+	if !in.Exists() {
+		return nil, nil
+	}
+	if !in.IsObject() {
+		return nil, errInvalidFormat
+	}
+	overrides := make(map[common.Address]*evm.StateOverride)
+	var err error
+	in.ForEach(func(key, value gjson.Result) bool {
+		if !common.IsHexAddress(key.String()) {
+			err = errors.Wrapf(errUnkownType, "address: %s", key.String())
+			return false
+		}
+		override := &evm.StateOverride{}
+		if balance := value.Get("balance"); balance.Exists() {
+			b, ok := new(big.Int).SetString(util.Remove0xPrefix(balance.String()), 16)
+			if !ok || b.Sign() < 0 || b.BitLen() > 256 {
+				err = errors.Wrapf(errUnkownType, "balance: %s", balance.String())
+				return false
+			}
+			override.Balance = b
+		}
+		if code := value.Get("code"); code.Exists() {
+			override.Code = common.FromHex(code.String())
+		}
+		if stateDiff := value.Get("stateDiff"); stateDiff.Exists() {
+			override.StateDiff = make(map[common.Hash]common.Hash)
+			stateDiff.ForEach(func(slot, val gjson.Result) bool {
+				override.StateDiff[common.HexToHash(slot.String())] = common.HexToHash(val.String())
+				return true
+			})
+		}
+		overrides[common.HexToAddress(key.String())] = override
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
 func (svr *web3Handler) getLogQueryRange(fromStr, toStr string, logHeight uint64) (from uint64, to uint64, hasNewLogs bool, err error) {
 	if from, to, err = svr.parseBlockRange(fromStr, toStr); err != nil {
 		return
@@ -359,13 +543,13 @@ func newAPICache(expireTime time.Duration, remoteURL string) apiCache {
 		DB:       0,  // use default DB
 	})
 	if redisClient.Ping(context.Background()).Err() != nil {
-		log.L().Info("local cache is used as API cache")
+		log.Logger("api").Info("local cache is used as API cache")
 		filterCache, _ := ttl.NewCache(ttl.AutoExpireOption(expireTime))
 		return &localCache{
 			ttlCache: filterCache,
 		}
 	}
-	log.L().Info("remote cache is used as API cache")
+	log.Logger("api").Info("remote cache is used as API cache")
 	return &remoteCache{
 		redisCache: redisClient,
 		expireTime: expireTime,
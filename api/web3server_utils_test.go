@@ -2,13 +2,18 @@ package api
 
 import (
 	"math/big"
+	"strings"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/golang/mock/gomock"
 	"github.com/iotexproject/iotex-address/address"
-	"github.com/iotexproject/iotex-core/test/mock/mock_apicoreservice"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"github.com/tidwall/gjson"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/test/mock/mock_apicoreservice"
 )
 
 func TestParseCallObject(t *testing.T) {
@@ -115,7 +120,7 @@ func TestParseBlockNumber(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := mock_apicoreservice.NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	t.Run("earliest block number", func(t *testing.T) {
 		num, _ := web3svr.parseBlockNumber("earliest")
@@ -139,4 +144,32 @@ func TestParseBlockNumber(t *testing.T) {
 		num, _ := web3svr.parseBlockNumber("")
 		require.Equal(num, uint64(0x1))
 	})
+
+	t.Run("finalized block number", func(t *testing.T) {
+		core.EXPECT().FinalizedHeight().Return(uint64(0x2))
+		num, _ := web3svr.parseBlockNumber("finalized")
+		require.Equal(num, uint64(0x2))
+	})
+
+	t.Run("safe block number", func(t *testing.T) {
+		core.EXPECT().FinalizedHeight().Return(uint64(0x2))
+		num, _ := web3svr.parseBlockNumber("safe")
+		require.Equal(num, uint64(0x2))
+	})
+}
+
+func TestParseAddress(t *testing.T) {
+	require := require.New(t)
+	addr := identityset.Address(10)
+	hexAddr := common.BytesToAddress(addr.Bytes()).Hex()
+
+	for _, v := range []string{addr.String(), hexAddr, strings.ToLower(hexAddr), hexAddr[2:]} {
+		ret, err := parseAddress(v)
+		require.NoError(err, v)
+		require.Equal(addr.String(), ret.String(), v)
+	}
+	for _, v := range []string{"", "0x", "0x12", "0x7c13866F9253DEf79e20034eDD011e1d69E67fE5", "io1abc"} {
+		_, err := parseAddress(v)
+		require.Equal(errUnkownType, errors.Cause(err), v)
+	}
 }
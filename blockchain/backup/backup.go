@@ -0,0 +1,407 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/filedao"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state/factory"
+)
+
+const (
+	// ManifestFile is the name of the manifest file in a backup directory
+	ManifestFile = "manifest.json"
+
+	// ChainStore is the name of the chain db in a backup
+	ChainStore = "chain"
+	// StateStore is the name of the state db in a backup
+	StateStore = "trie"
+	// IndexStore is the name of the block index db in a backup
+	IndexStore = "index"
+	// BloomfilterIndexStore is the name of the bloomfilter index db in a backup
+	BloomfilterIndexStore = "bloomfilter.index"
+	// CandidateIndexStore is the name of the candidate index db in a backup
+	CandidateIndexStore = "candidate.index"
+	// StakingIndexStore is the name of the staking index db in a backup
+	StakingIndexStore = "staking.index"
+	// ContractStakingIndexStore is the name of the contract staking index db in a backup
+	ContractStakingIndexStore = "contractstaking.index"
+	// TokenTransferIndexStore is the name of the token transfer index db in a backup
+	TokenTransferIndexStore = "tokentransfer.index"
+	// CandidateHistoryIndexStore is the name of the candidate history index db in a backup
+	CandidateHistoryIndexStore = "candidatehistory.index"
+	// SystemActionIndexStore is the name of the system action index db in a backup
+	SystemActionIndexStore = "systemaction.index"
+	// ContractStatsIndexStore is the name of the contract stats index db in a backup
+	ContractStatsIndexStore = "contractstats.index"
+	// MemoIndexStore is the name of the transfer memo index db in a backup
+	MemoIndexStore = "memo.index"
+	// BlockTimeIndexStore is the name of the block time index db in a backup
+	BlockTimeIndexStore = "blocktime.index"
+)
+
+var (
+	// ErrBackupMismatch indicates the error that a backup doesn't match its manifest
+	ErrBackupMismatch = errors.New("backup mismatch")
+	// ErrBackupIncomplete indicates the error that a backup hasn't been completely taken
+	ErrBackupIncomplete = errors.New("backup is incomplete")
+)
+
+type (
+	// Manifest describes a backup, which consists of the files of the chain db and the kv stores at the fence
+	// height. Root is the state root at the fence height, which is empty for the trieless state db
+	Manifest struct {
+		Height    uint64  `json:"height"`
+		BlockHash string  `json:"blockHash"`
+		Root      string  `json:"root,omitempty"`
+		Files     []*File `json:"files"`
+		Complete  bool    `json:"complete"`
+	}
+
+	// File describes a file in a backup, Index is the index of a chain db file, and a sealed file is no longer
+	// written by new blocks, so it's kept when an interrupted backup is resumed
+	File struct {
+		Name     string `json:"name"`
+		Store    string `json:"store"`
+		Index    uint64 `json:"index,omitempty"`
+		Sealed   bool   `json:"sealed,omitempty"`
+		Checksum string `json:"checksum"`
+	}
+
+	// Chain is the chain db to back up, Path is the path of its master file
+	Chain struct {
+		Path string
+		DAO  filedao.FileDAOWithBackup
+	}
+
+	// Store is a kv store to back up
+	Store struct {
+		Name    string
+		KVStore db.KVStoreWithBackup
+	}
+
+	liveBackup struct {
+		file *File
+		done chan error
+	}
+)
+
+// ReadManifest reads the manifest of the backup in dir
+func ReadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	manifest := &Manifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, errors.Wrap(err, "failed to parse backup manifest")
+	}
+	return manifest, nil
+}
+
+// Backup takes a backup of the chain db and the kv stores at the tip height into dir, and returns the manifest.
+// The block commits are only blocked until the copies of the files being written begin, while the sealed chain
+// db files are hard-linked or copied afterwards. An interrupted backup is resumed by calling it again with the
+// same dir, which keeps the sealed files already backed up, and takes the others at the new tip height
+func Backup(ctx context.Context, dir string, fencer blockchain.Fencer, chain *Chain, stores []*Store) (*Manifest, error) {
+	var state *Store
+	for _, s := range stores {
+		if s.Name == StateStore {
+			state = s
+		}
+	}
+	if state == nil {
+		return nil, errors.New("state db is not in the stores to back up")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	prev, err := ReadManifest(dir)
+	switch {
+	case err == nil:
+		if prev.Complete {
+			return prev, nil
+		}
+	case os.IsNotExist(err):
+		prev = &Manifest{}
+	default:
+		return nil, err
+	}
+	// the kv stores are always copied again at the new tip height
+	for _, s := range stores {
+		if err := removeFile(dir, storeFileName(s.Name)); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		m      = &Manifest{}
+		sealed []*File
+		lives  []*liveBackup
+	)
+	startBackup := func(kvStore db.KVStoreWithBackup, file *File) error {
+		done, err := begin(kvStore, filepath.Join(dir, file.Name))
+		if err != nil {
+			return errors.Wrapf(err, "failed to back up %s", file.Name)
+		}
+		lives = append(lives, &liveBackup{file: file, done: done})
+		return nil
+	}
+	err = fencer.Fence(func(height uint64) error {
+		if height == 0 {
+			return errors.New("no block to back up")
+		}
+		h, err := chain.DAO.GetBlockHash(height)
+		if err != nil {
+			return err
+		}
+		m.Height = height
+		m.BlockHash = hex.EncodeToString(h[:])
+		if m.Root, err = stateRoot(state.KVStore, height); err != nil {
+			return err
+		}
+		indices, top, err := chain.DAO.Files()
+		if err != nil {
+			return err
+		}
+		for _, k := range indices[:len(indices)-1] {
+			sealed = append(sealed, &File{Name: chainFileName(k), Store: ChainStore, Index: k, Sealed: true})
+		}
+		k := indices[len(indices)-1]
+		file := &File{Name: chainFileName(k), Store: ChainStore, Index: k}
+		if err := removeFile(dir, file.Name); err != nil {
+			return err
+		}
+		if err := startBackup(top, file); err != nil {
+			return err
+		}
+		for _, s := range stores {
+			if err := startBackup(s.KVStore, &File{Name: storeFileName(s.Name), Store: s.Name}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	// the sealed files are backed up while the others are being copied
+	if err == nil {
+		err = m.backupSealedFiles(ctx, dir, chain.Path, prev, sealed)
+	}
+	for _, l := range lives {
+		if e := <-l.done; e != nil && err == nil {
+			err = errors.Wrapf(e, "failed to back up %s", l.file.Name)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range lives {
+		if l.file.Checksum, err = checksum(filepath.Join(dir, l.file.Name)); err != nil {
+			return nil, err
+		}
+		m.Files = append(m.Files, l.file)
+	}
+	m.Complete = true
+	if err := m.write(dir); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *Manifest) backupSealedFiles(ctx context.Context, dir, chainPath string, prev *Manifest, files []*File) error {
+	for _, f := range files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if done := prev.file(f.Name); done != nil && done.Sealed && done.Index == f.Index {
+			f.Checksum = done.Checksum
+		} else {
+			path := filepath.Join(dir, f.Name)
+			if err := removeFile(dir, f.Name); err != nil {
+				return err
+			}
+			if err := linkOrCopy(filedao.FileName(chainPath, f.Index), path); err != nil {
+				return err
+			}
+			var err error
+			if f.Checksum, err = checksum(path); err != nil {
+				return err
+			}
+		}
+		m.Files = append(m.Files, f)
+		// record the progress, so the file is kept if the backup is interrupted
+		if err := m.write(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Manifest) write(dir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	// write into a temporary file first, so an interrupted backup always leaves a valid manifest
+	tmp := filepath.Join(dir, ManifestFile+".tmp")
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(dir, ManifestFile))
+}
+
+func (m *Manifest) file(name string) *File {
+	for _, f := range m.Files {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// begin starts backing up kvStore into path in background, and returns once the backup begins
+func begin(kvStore db.KVStoreWithBackup, path string) (chan error, error) {
+	began, done := make(chan struct{}), make(chan error, 1)
+	go func() {
+		done <- kvStore.Backup(path, func() {
+			close(began)
+		})
+	}()
+	select {
+	case <-began:
+		return done, nil
+	case err := <-done:
+		return nil, err
+	}
+}
+
+// stateRoot returns the state root at height in the state db, which is empty for the trieless state db
+func stateRoot(kvStore db.KVStore, height uint64) (string, error) {
+	h, err := kvStore.Get(factory.AccountKVNamespace, []byte(factory.CurrentHeightKey))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get the height of state db")
+	}
+	if stateHeight := byteutil.BytesToUint64(h); stateHeight != height {
+		return "", errors.Errorf("state db at height %d doesn't match height %d", stateHeight, height)
+	}
+	root, err := kvStore.Get(factory.ArchiveTrieNamespace, []byte(factory.ArchiveTrieRootKey))
+	switch errors.Cause(err) {
+	case nil:
+		return hex.EncodeToString(root), nil
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return "", nil
+	default:
+		return "", err
+	}
+}
+
+func chainFileName(k uint64) string {
+	return filedao.FileName(storeFileName(ChainStore), k)
+}
+
+func storeFileName(name string) string {
+	return name + ".db"
+}
+
+func removeFile(dir, name string) error {
+	path := filepath.Join(dir, name)
+	if err := os.RemoveAll(path + ".tmp"); err != nil {
+		return err
+	}
+	return os.RemoveAll(path)
+}
+
+// linkOrCopy hard-links the file, or copies it if the link fails, e.g., across file systems
+func linkOrCopy(src, dst string) error {
+	if err := os.Link(src, dst); err == nil {
+		return nil
+	}
+	return copyPath(src, dst)
+}
+
+// copyPath copies the file, or the directory with all the files in it, into a temporary path first, so an
+// interrupted copy is never taken as done
+func copyPath(src, dst string) error {
+	tmp := dst + ".tmp"
+	if err := os.RemoveAll(tmp); err != nil {
+		return err
+	}
+	if err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(tmp, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0700)
+		}
+		return copyFile(path, target)
+	}); err != nil {
+		return err
+	}
+	return os.Rename(tmp, dst)
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// checksum returns the sha256 of the file, or of all the files in the directory in the order of names
+func checksum(path string) (string, error) {
+	h := sha256.New()
+	if err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(path, p)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, _ = h.Write([]byte(rel))
+		_, err = io.Copy(h, f)
+		return err
+	}); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
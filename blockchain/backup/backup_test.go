@@ -0,0 +1,208 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/filedao"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state/factory"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+type testFencer struct {
+	fd     filedao.FileDAO
+	fenced int
+}
+
+func (f *testFencer) Fence(fn func(uint64) error) error {
+	f.fenced++
+	height, err := f.fd.Height()
+	if err != nil {
+		return err
+	}
+	return fn(height)
+}
+
+func TestBackupRestore(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	deser := block.NewDeserializer(4689)
+
+	// the chain db splits a new file every 5 blocks
+	cfg := db.DefaultConfig
+	cfg.V2BlocksToSplitDB = 5
+	cfg.DbPath = filepath.Join(dataDir, "chain.db")
+	fd, err := filedao.NewFileDAO(cfg, deser)
+	r.NoError(err)
+	r.NoError(fd.Start(ctx))
+	defer fd.Stop(ctx)
+	stateCfg := db.DefaultConfig
+	stateCfg.DbPath = filepath.Join(dataDir, "trie.db")
+	stateDB := db.NewBoltDB(stateCfg)
+	r.NoError(stateDB.Start(ctx))
+	defer stateDB.Stop(ctx)
+	indexCfg := db.DefaultConfig
+	indexCfg.DbPath = filepath.Join(dataDir, "index.db")
+	indexDB := db.NewPebbleDB(indexCfg)
+	r.NoError(indexDB.Start(ctx))
+	defer indexDB.Stop(ctx)
+	r.NoError(indexDB.Put("ns", []byte("key"), []byte("value")))
+
+	prevHash := hash.ZeroHash256
+	commit := func(start, end uint64) {
+		for i := start; i <= end; i++ {
+			blk, err := block.NewTestingBuilder().
+				SetHeight(i).
+				SetPrevBlockHash(prevHash).
+				SetTimeStamp(testutil.TimestampNow()).
+				SignAndBuild(identityset.PrivateKey(27))
+			r.NoError(err)
+			r.NoError(fd.PutBlock(ctx, &blk))
+			prevHash = blk.HashBlock()
+		}
+		r.NoError(stateDB.Put(factory.AccountKVNamespace, []byte(factory.CurrentHeightKey), byteutil.Uint64ToBytes(end)))
+		r.NoError(stateDB.Put(factory.ArchiveTrieNamespace, []byte(factory.ArchiveTrieRootKey), prevHash[:]))
+	}
+	commit(1, 12)
+
+	fencer := &testFencer{fd: fd}
+	chain := &Chain{Path: cfg.DbPath, DAO: fd.(filedao.FileDAOWithBackup)}
+	stores := []*Store{{Name: StateStore, KVStore: stateDB}, {Name: IndexStore, KVStore: indexDB}}
+	dir := filepath.Join(t.TempDir(), "backup")
+	_, err = Backup(ctx, dir, fencer, chain, stores[1:])
+	r.Error(err)
+	m, err := Backup(ctx, dir, fencer, chain, stores)
+	r.NoError(err)
+	r.True(m.Complete)
+	r.EqualValues(12, m.Height)
+	r.Equal(hex.EncodeToString(prevHash[:]), m.BlockHash)
+	r.Equal(m.BlockHash, m.Root)
+	names := []string{}
+	for _, f := range m.Files {
+		names = append(names, f.Name)
+	}
+	r.Equal([]string{"chain.db", "chain-00000001.db", "chain-00000002.db", "trie.db", "index.db"}, names)
+	r.True(m.Files[0].Sealed)
+	r.True(m.Files[1].Sealed)
+	r.False(m.Files[2].Sealed)
+	read, err := ReadManifest(dir)
+	r.NoError(err)
+	r.Equal(m, read)
+	// the complete backup is returned without fencing again
+	read, err = Backup(ctx, dir, fencer, chain, stores)
+	r.NoError(err)
+	r.Equal(m, read)
+	r.Equal(1, fencer.fenced)
+
+	// resume an interrupted backup after more blocks are committed, the sealed file backed up is kept
+	sealed, err := os.Stat(filepath.Join(dir, "chain.db"))
+	r.NoError(err)
+	interrupted := &Manifest{Height: m.Height, BlockHash: m.BlockHash, Root: m.Root, Files: m.Files[:1]}
+	r.NoError(interrupted.write(dir))
+	r.NoError(os.Remove(filepath.Join(dir, "chain-00000001.db")))
+	commit(13, 17)
+	m, err = Backup(ctx, dir, fencer, chain, stores)
+	r.NoError(err)
+	r.True(m.Complete)
+	r.EqualValues(17, m.Height)
+	r.Len(m.Files, 6)
+	r.True(m.Files[2].Sealed)
+	kept, err := os.Stat(filepath.Join(dir, "chain.db"))
+	r.NoError(err)
+	r.True(os.SameFile(sealed, kept))
+	commit(18, 20)
+
+	// restore the backup
+	restoreDir := t.TempDir()
+	paths := map[string]string{
+		ChainStore: filepath.Join(restoreDir, "chain.db"),
+		StateStore: filepath.Join(restoreDir, "trie.db"),
+		IndexStore: filepath.Join(restoreDir, "index.db"),
+	}
+	restored, err := Restore(dir, paths, cfg, deser)
+	r.NoError(err)
+	r.Equal(m, restored)
+	_, err = Restore(dir, paths, cfg, deser)
+	r.Error(err)
+	cfg.DbPath = paths[ChainStore]
+	fd2, err := filedao.NewFileDAO(cfg, deser)
+	r.NoError(err)
+	r.NoError(fd2.Start(ctx))
+	defer fd2.Stop(ctx)
+	height, err := fd2.Height()
+	r.NoError(err)
+	r.EqualValues(17, height)
+	for i := uint64(1); i <= height; i++ {
+		h, err := fd.GetBlockHash(i)
+		r.NoError(err)
+		h2, err := fd2.GetBlockHash(i)
+		r.NoError(err)
+		r.Equal(h, h2)
+	}
+	indexCfg.DbPath = paths[IndexStore]
+	indexDB2 := db.NewPebbleDB(indexCfg)
+	r.NoError(indexDB2.Start(ctx))
+	defer indexDB2.Stop(ctx)
+	v, err := indexDB2.Get("ns", []byte("key"))
+	r.NoError(err)
+	r.Equal([]byte("value"), v)
+}
+
+func TestRestoreCorruptedBackup(t *testing.T) {
+	r := require.New(t)
+	dir := t.TempDir()
+	r.NoError(os.WriteFile(filepath.Join(dir, "chain.db"), []byte{1, 2}, 0600))
+	r.NoError(os.WriteFile(filepath.Join(dir, "trie.db"), []byte{3, 4}, 0600))
+	chainSum, err := checksum(filepath.Join(dir, "chain.db"))
+	r.NoError(err)
+	m := &Manifest{
+		Height:   1,
+		Complete: true,
+		Files: []*File{
+			{Name: "chain.db", Store: ChainStore, Checksum: chainSum},
+			{Name: "trie.db", Store: StateStore, Checksum: "00"},
+		},
+	}
+	r.NoError(m.write(dir))
+	restoreDir := t.TempDir()
+	paths := map[string]string{
+		ChainStore: filepath.Join(restoreDir, "chain.db"),
+		StateStore: filepath.Join(restoreDir, "trie.db"),
+	}
+	deser := block.NewDeserializer(4689)
+	_, err = Restore(dir, paths, db.DefaultConfig, deser)
+	r.Equal(ErrBackupMismatch, errors.Cause(err))
+	_, err = os.Stat(paths[ChainStore])
+	r.True(os.IsNotExist(err))
+
+	// the files are removed if the restored chain db can't be verified
+	m.Files[1].Checksum, err = checksum(filepath.Join(dir, "trie.db"))
+	r.NoError(err)
+	r.NoError(m.write(dir))
+	_, err = Restore(dir, paths, db.DefaultConfig, deser)
+	r.Error(err)
+	_, err = os.Stat(paths[ChainStore])
+	r.True(os.IsNotExist(err))
+
+	m.Complete = false
+	r.NoError(m.write(dir))
+	_, err = Restore(dir, paths, db.DefaultConfig, deser)
+	r.Equal(ErrBackupIncomplete, errors.Cause(err))
+}
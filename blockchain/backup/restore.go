@@ -0,0 +1,149 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package backup
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/filedao"
+	"github.com/iotexproject/iotex-core/db"
+)
+
+// StorePaths returns the paths of the stores in a backup configured for the chain
+func StorePaths(cfg blockchain.Config) map[string]string {
+	return map[string]string{
+		ChainStore:                 cfg.ChainDBPath,
+		StateStore:                 cfg.TrieDBPath,
+		IndexStore:                 cfg.IndexDBPath,
+		BloomfilterIndexStore:      cfg.BloomfilterIndexDBPath,
+		CandidateIndexStore:        cfg.CandidateIndexDBPath,
+		StakingIndexStore:          cfg.StakingIndexDBPath,
+		ContractStakingIndexStore:  cfg.ContractStakingIndexDBPath,
+		TokenTransferIndexStore:    cfg.TokenTransferIndexDBPath,
+		CandidateHistoryIndexStore: cfg.CandidateHistoryIndexDBPath,
+		SystemActionIndexStore:     cfg.SystemActionIndexDBPath,
+		ContractStatsIndexStore:    cfg.ContractStatsIndexDBPath,
+		MemoIndexStore:             cfg.MemoIndexDBPath,
+		BlockTimeIndexStore:        cfg.BlockTimeIndexDBPath,
+	}
+}
+
+// Restore verifies the files of the backup in dir against the manifest, and copies them into the paths of the
+// stores, which should not exist, e.g., in a fresh data directory. The restored chain db and state db are then
+// checked to be at the height of the manifest, otherwise the restored files are removed
+func Restore(dir string, paths map[string]string, cfg db.Config, deser *block.Deserializer) (*Manifest, error) {
+	m, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !m.Complete {
+		return nil, ErrBackupIncomplete
+	}
+	if m.file(chainFileName(0)) == nil || m.file(storeFileName(StateStore)) == nil {
+		return nil, errors.Wrap(ErrBackupMismatch, "chain db or state db is missing")
+	}
+	targets := make([]string, len(m.Files))
+	for i, f := range m.Files {
+		path := paths[f.Store]
+		if path == "" {
+			return nil, errors.Errorf("no path to restore %s into", f.Store)
+		}
+		if f.Store == ChainStore {
+			path = filedao.FileName(path, f.Index)
+		}
+		if _, err := os.Stat(path); err == nil {
+			return nil, errors.Errorf("%s already exists", path)
+		} else if !os.IsNotExist(err) {
+			return nil, err
+		}
+		sum, err := checksum(filepath.Join(dir, f.Name))
+		if err != nil {
+			return nil, err
+		}
+		if sum != f.Checksum {
+			return nil, errors.Wrapf(ErrBackupMismatch, "checksum of %s is %s, expecting %s", f.Name, sum, f.Checksum)
+		}
+		targets[i] = path
+	}
+
+	if err := func() error {
+		for i, f := range m.Files {
+			if err := os.MkdirAll(filepath.Dir(targets[i]), 0700); err != nil {
+				return err
+			}
+			if err := copyPath(filepath.Join(dir, f.Name), targets[i]); err != nil {
+				return err
+			}
+		}
+		return m.verify(paths, cfg, deser)
+	}(); err != nil {
+		for _, path := range targets {
+			_ = os.RemoveAll(path + ".tmp")
+			_ = os.RemoveAll(path)
+		}
+		return nil, err
+	}
+	return m, nil
+}
+
+// verify checks the restored chain db and state db are at the height of the manifest
+func (m *Manifest) verify(paths map[string]string, cfg db.Config, deser *block.Deserializer) error {
+	ctx := context.Background()
+	cfg.DbPath = paths[ChainStore]
+	fd, err := filedao.NewFileDAO(cfg, deser)
+	if err != nil {
+		return err
+	}
+	if err := fd.Start(ctx); err != nil {
+		return err
+	}
+	defer fd.Stop(ctx)
+	height, err := fd.Height()
+	if err != nil {
+		return err
+	}
+	if height != m.Height {
+		return errors.Wrapf(ErrBackupMismatch, "chain db at height %d, expecting %d", height, m.Height)
+	}
+	h, err := fd.GetBlockHash(height)
+	if err != nil {
+		return err
+	}
+	if hex.EncodeToString(h[:]) != m.BlockHash {
+		return errors.Wrapf(ErrBackupMismatch, "block hash %x, expecting %s", h, m.BlockHash)
+	}
+
+	cfg.DbPath = paths[StateStore]
+	info, err := os.Stat(cfg.DbPath)
+	if err != nil {
+		return err
+	}
+	var kvStore db.KVStore
+	if info.IsDir() {
+		kvStore = db.NewPebbleDB(cfg)
+	} else {
+		kvStore = db.NewBoltDB(cfg)
+	}
+	if err := kvStore.Start(ctx); err != nil {
+		return err
+	}
+	defer kvStore.Stop(ctx)
+	root, err := stateRoot(kvStore, m.Height)
+	if err != nil {
+		return errors.Wrap(ErrBackupMismatch, err.Error())
+	}
+	if root != m.Root {
+		return errors.Wrapf(ErrBackupMismatch, "state root %s, expecting %s", root, m.Root)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package backup
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/pkg/util/fileutil"
+)
+
+// StampSuffix is the suffix of the file next to a store, which is stamped with the genesis hash of the chain the
+// store belongs to
+const StampSuffix = ".genesis"
+
+var (
+	// ErrStoreMissing indicates the error that a store is missing, e.g., the volume of the store isn't mounted
+	ErrStoreMissing = errors.New("store is missing")
+	// ErrStoreChainMismatch indicates the error that a store belongs to another chain
+	ErrStoreChainMismatch = errors.New("store belongs to another chain")
+)
+
+// StampStores checks the stores at the paths belong to the chain of the genesis hash, and stamps the ones not
+// stamped yet. The stores may live on different volumes, so the directory of each store should exist, and the chain
+// db and the state db should exist together, unless the state db is deleted after stamped, e.g., by a rollback, or
+// lives on the volume of the chain db. The other stores are rebuilt from the chain db if missing
+func StampStores(paths map[string]string, genesisHash hash.Hash256) error {
+	var (
+		names    = make([]string, 0, len(paths))
+		stamp    = hex.EncodeToString(genesisHash[:])
+		exists   = make(map[string]bool, len(paths))
+		unstamps []string
+	)
+	for name := range paths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path := paths[name]
+		if dir := filepath.Dir(path); !dirExists(dir) {
+			return errors.Wrapf(ErrStoreMissing, "directory %s of %s db doesn't exist, is its volume mounted?", dir, name)
+		}
+		b, err := os.ReadFile(path + StampSuffix)
+		switch {
+		case err == nil:
+			if s := strings.TrimSpace(string(b)); s != stamp {
+				return errors.Wrapf(ErrStoreChainMismatch, "%s db at %s is of genesis %s, not %s", name, path, s, stamp)
+			}
+		case os.IsNotExist(err):
+			unstamps = append(unstamps, name)
+		default:
+			return errors.Wrapf(err, "failed to read the stamp of %s db", name)
+		}
+		_, err = os.Stat(path)
+		exists[name] = err == nil
+	}
+	chainPath, hasChain := paths[ChainStore]
+	statePath, hasState := paths[StateStore]
+	if hasChain && hasState {
+		switch {
+		case exists[StateStore] && !exists[ChainStore]:
+			return errors.Wrapf(ErrStoreMissing, "chain db at %s is missing while state db exists at %s", chainPath, statePath)
+		case exists[ChainStore] && !exists[StateStore] && !fileutil.FileExists(statePath+StampSuffix) &&
+			filepath.Dir(statePath) != filepath.Dir(chainPath):
+			return errors.Wrapf(ErrStoreMissing, "state db at %s is missing while chain db exists at %s", statePath, chainPath)
+		}
+	}
+	for _, name := range unstamps {
+		if err := os.WriteFile(paths[name]+StampSuffix, []byte(stamp), 0600); err != nil {
+			return errors.Wrapf(err, "failed to stamp %s db", name)
+		}
+	}
+	return nil
+}
+
+func dirExists(dir string) bool {
+	info, err := os.Stat(dir)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStampStores(t *testing.T) {
+	r := require.New(t)
+	var (
+		hdd, nvme = t.TempDir(), t.TempDir()
+		paths     = map[string]string{
+			ChainStore: filepath.Join(hdd, "chain.db"),
+			IndexStore: filepath.Join(hdd, "index.db"),
+			StateStore: filepath.Join(nvme, "trie.db"),
+		}
+		genesis = hash.Hash256b([]byte("genesis"))
+		other   = hash.Hash256b([]byte("other"))
+		create  = func(path string) { r.NoError(os.WriteFile(path, []byte("db"), 0600)) }
+	)
+
+	// the stores of a fresh node are stamped before created
+	r.NoError(StampStores(paths, genesis))
+	for _, path := range paths {
+		r.FileExists(path + StampSuffix)
+	}
+	for _, path := range paths {
+		create(path)
+	}
+	r.NoError(StampStores(paths, genesis))
+
+	// the stores of another chain are rejected
+	r.True(errors.Is(StampStores(paths, other), ErrStoreChainMismatch))
+
+	// an index is rebuilt if missing, and so is the state db deleted after stamped
+	r.NoError(os.Remove(paths[IndexStore]))
+	r.NoError(os.Remove(paths[StateStore]))
+	r.NoError(StampStores(paths, genesis))
+
+	// a volume not mounted is missing
+	r.NoError(os.RemoveAll(nvme))
+	err := StampStores(paths, genesis)
+	r.True(errors.Is(err, ErrStoreMissing))
+	r.Contains(err.Error(), "is its volume mounted")
+
+	// an empty volume mounted in place of the state db is missing
+	r.NoError(os.Mkdir(nvme, 0700))
+	err = StampStores(paths, genesis)
+	r.True(errors.Is(err, ErrStoreMissing))
+	r.Contains(err.Error(), "state db")
+
+	// while a state db on the volume of the chain db is rebuilt from it
+	sameVolume := map[string]string{
+		ChainStore: paths[ChainStore],
+		StateStore: filepath.Join(hdd, "trie.db"),
+	}
+	r.NoError(StampStores(sameVolume, genesis))
+
+	// a state db without the chain db is missing the chain db
+	create(paths[StateStore])
+	r.NoError(os.Remove(paths[ChainStore]))
+	err = StampStores(paths, genesis)
+	r.True(errors.Is(err, ErrStoreMissing))
+	r.Contains(err.Error(), "chain db")
+
+	// the stores not stamped before are adopted
+	create(paths[ChainStore])
+	for _, path := range paths {
+		r.NoError(os.RemoveAll(path + StampSuffix))
+	}
+	r.NoError(StampStores(paths, genesis))
+	r.NoError(StampStores(paths, genesis))
+	r.True(errors.Is(StampStores(paths, other), ErrStoreChainMismatch))
+}
@@ -10,11 +10,11 @@ import (
 	"time"
 
 	"github.com/iotexproject/go-pkgs/bloom"
-	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/action"
+	cp "github.com/iotexproject/iotex-core/crypto"
 	"github.com/iotexproject/iotex-core/pkg/version"
 )
 
@@ -96,8 +96,14 @@ func (b *Builder) SetBaseFee(fee *big.Int) *Builder {
 	return b
 }
 
+// SetRandomnessProof sets the VRF proof of the producer's randomness
+func (b *Builder) SetRandomnessProof(proof []byte) *Builder {
+	b.blk.Header.randomnessProof = proof
+	return b
+}
+
 // SignAndBuild signs and then builds a block.
-func (b *Builder) SignAndBuild(signerPrvKey crypto.PrivateKey) (Block, error) {
+func (b *Builder) SignAndBuild(signerPrvKey cp.Signer) (Block, error) {
 	b.blk.Header.pubkey = signerPrvKey.PublicKey()
 	h := b.blk.Header.HashHeaderCore()
 	sig, err := signerPrvKey.Sign(h[:])
@@ -18,6 +18,9 @@ import (
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/actionpb"
+	cp "github.com/iotexproject/iotex-core/crypto"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 )
@@ -37,6 +40,7 @@ type Header struct {
 	blockSig         []byte            // block signature
 	pubkey           crypto.PublicKey  // block producer's public key
 	baseFee          *big.Int          // added by EIP-1559 and is ignored in legacy headers
+	randomnessProof  []byte            // VRF proof of the producer's randomness, added by the randomness beacon
 }
 
 // Errors
@@ -44,6 +48,9 @@ var (
 	ErrTxRootMismatch      = errors.New("transaction merkle root does not match")
 	ErrDeltaStateMismatch  = errors.New("delta state digest doesn't match")
 	ErrReceiptRootMismatch = errors.New("receipt root hash does not match")
+	// ErrFinalizedHeight indicates the error that a block is put at a height at or below the finalized height,
+	// which would overwrite a block that can never change
+	ErrFinalizedHeight = errors.New("height is finalized")
 )
 
 // Version returns the version of this block.
@@ -87,6 +94,9 @@ func (h *Header) BaseFee() *big.Int {
 	return new(big.Int).Set(h.baseFee)
 }
 
+// RandomnessProof returns the VRF proof of the producer's randomness, nil before the randomness beacon is enabled
+func (h *Header) RandomnessProof() []byte { return h.randomnessProof }
+
 // Proto returns BlockHeader proto.
 func (h *Header) Proto() *iotextypes.BlockHeader {
 	header := iotextypes.BlockHeader{
@@ -119,6 +129,9 @@ func (h *Header) BlockHeaderCoreProto() *iotextypes.BlockHeaderCore {
 	if h.baseFee != nil {
 		header.BaseFee = h.baseFee.Bytes()
 	}
+	if len(h.randomnessProof) > 0 {
+		action.MustAppendUnknownFields(&header, &actionpb.BlockHeaderCoreExt{RandomnessProof: h.randomnessProof})
+	}
 	return &header
 }
 
@@ -164,7 +177,12 @@ func (h *Header) loadFromBlockHeaderCoreProto(pb *iotextypes.BlockHeaderCore) er
 	if fee := pb.GetBaseFee(); fee != nil {
 		h.baseFee = new(big.Int).SetBytes(fee)
 	}
-	return err
+	ext := actionpb.BlockHeaderCoreExt{}
+	if err = action.LoadUnknownFields(pb, &ext); err != nil {
+		return err
+	}
+	h.randomnessProof = ext.GetRandomnessProof()
+	return nil
 }
 
 // SerializeCore returns byte stream for header core.
@@ -207,6 +225,21 @@ func (h *Header) VerifySignature() bool {
 	return h.pubkey.Verify(hash[:], h.blockSig)
 }
 
+// VerifyRandomness verifies the randomness proof against the producer's public key, and returns the randomness
+func (h *Header) VerifyRandomness() (hash.Hash256, error) {
+	if h.pubkey == nil {
+		return hash.ZeroHash256, errors.New("missing producer's public key")
+	}
+	return cp.VRFVerify(h.pubkey, RandomnessAlpha(h.prevBlockHash, h.height), h.randomnessProof)
+}
+
+// RandomnessAlpha returns the input of the producer's randomness of the block at the height, which is the hash of the
+// previous block and the height, so it's fixed once the previous block is committed, and the randomness is unknown
+// until the producer reveals the proof
+func RandomnessAlpha(prevHash hash.Hash256, height uint64) []byte {
+	return append(prevHash[:], byteutil.Uint64ToBytesBigEndian(height)...)
+}
+
 // VerifyDeltaStateDigest verifies the delta state digest in header
 func (h *Header) VerifyDeltaStateDigest(digest hash.Hash256) bool {
 	return h.deltaStateDigest == digest
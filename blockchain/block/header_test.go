@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/iotexproject/go-pkgs/hash"
+	cp "github.com/iotexproject/iotex-core/crypto"
 	"github.com/iotexproject/iotex-core/test/identityset"
 	"github.com/stretchr/testify/require"
 )
@@ -62,6 +63,43 @@ func TestSerDesHeadrer(t *testing.T) {
 		}
 	}
 }
+
+func TestHeaderRandomness(t *testing.T) {
+	r := require.New(t)
+	h := getHeader(false)
+	coreHash, blkHash := h.HashHeaderCore(), h.HashBlock()
+	r.Nil(h.RandomnessProof())
+	_, err := h.VerifyRandomness()
+	r.ErrorIs(err, cp.ErrInvalidVRFProof)
+
+	proof, err := cp.VRFProve(identityset.PrivateKey(27), RandomnessAlpha(h.PrevHash(), h.Height()))
+	r.NoError(err)
+	h.randomnessProof = proof
+	output, err := h.VerifyRandomness()
+	r.NoError(err)
+	// the proof is covered by the signature and the hash of the block
+	r.NotEqual(coreHash, h.HashHeaderCore())
+	r.NotEqual(blkHash, h.HashBlock())
+	ser, err := h.Serialize()
+	r.NoError(err)
+	header := &Header{}
+	r.NoError(header.Deserialize(ser))
+	r.Equal(proof, header.RandomnessProof())
+	r.Equal(h.HashBlock(), header.HashBlock())
+	o, err := header.VerifyRandomness()
+	r.NoError(err)
+	r.Equal(output, o)
+
+	// the proof of another producer or another height fails
+	header.pubkey = identityset.PrivateKey(28).PublicKey()
+	_, err = header.VerifyRandomness()
+	r.ErrorIs(err, cp.ErrInvalidVRFProof)
+	header.pubkey = h.pubkey
+	header.height++
+	_, err = header.VerifyRandomness()
+	r.ErrorIs(err, cp.ErrInvalidVRFProof)
+}
+
 func getHeader(hasBlob bool) *Header {
 	ti, err := time.Parse("2006-Jan-02", "2019-Feb-03")
 	if err != nil {
@@ -0,0 +1,80 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package block
+
+import (
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/crypto"
+)
+
+// ErrInvalidInclusionProof is the error of an action inclusion proof failing the verification
+var ErrInvalidInclusionProof = errors.New("invalid action inclusion proof")
+
+// ActionInclusionProof proves an action is included in a block. The path of the action hash makes the tx root in the
+// header, and the hash of the header is the block hash, so the proof is checked against a trusted block hash only
+type ActionInclusionProof struct {
+	Height     uint64
+	Index      uint32
+	ActionHash hash.Hash256
+	// Path is the hashes of the siblings on the merkle path from the action hash up to the tx root
+	Path []hash.Hash256
+	// Header is the serialized header of the block
+	Header []byte
+}
+
+// NewActionInclusionProof returns the inclusion proof of the action at index in the block
+func NewActionInclusionProof(blk *Block, index int) (*ActionInclusionProof, error) {
+	if index < 0 || index >= len(blk.Actions) {
+		return nil, errors.Wrapf(crypto.ErrInvalidLeafIndex, "index %d of %d actions", index, len(blk.Actions))
+	}
+	mk, err := NewTxMerkleTree(blk.Actions)
+	if err != nil {
+		return nil, err
+	}
+	path, err := mk.Proof(index)
+	if err != nil {
+		return nil, err
+	}
+	actHash, err := blk.Actions[index].Hash()
+	if err != nil {
+		return nil, err
+	}
+	header, err := blk.Header.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	return &ActionInclusionProof{
+		Height:     blk.Height(),
+		Index:      uint32(index),
+		ActionHash: actHash,
+		Path:       path,
+		Header:     header,
+	}, nil
+}
+
+// VerifyActionInclusionProof verifies the proof against the hash of the block, which is trusted by the caller
+func VerifyActionInclusionProof(proof *ActionInclusionProof, blkHash hash.Hash256) error {
+	if hash.Hash256b(proof.Header) != blkHash {
+		return errors.Wrap(ErrInvalidInclusionProof, "the header is not of the block hash")
+	}
+	var header Header
+	if err := header.Deserialize(proof.Header); err != nil {
+		return errors.Wrap(ErrInvalidInclusionProof, err.Error())
+	}
+	if header.Height() != proof.Height {
+		return errors.Wrapf(ErrInvalidInclusionProof, "the header is at height %d, not %d", header.Height(), proof.Height)
+	}
+	// the index takes a bit at each level of the path, a longer index would prove a leaf out of the tree
+	if len(proof.Path) < 32 && uint64(proof.Index)>>len(proof.Path) != 0 {
+		return errors.Wrapf(ErrInvalidInclusionProof, "index %d beyond the path of %d levels", proof.Index, len(proof.Path))
+	}
+	if crypto.MerkleProofRoot(proof.ActionHash, uint64(proof.Index), proof.Path) != header.TxRoot() {
+		return errors.Wrap(ErrInvalidInclusionProof, "the path doesn't make the tx root")
+	}
+	return nil
+}
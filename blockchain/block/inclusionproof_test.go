@@ -0,0 +1,96 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package block
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func newInclusionProofTestBlock(t *testing.T, n int) Block {
+	r := require.New(t)
+	acts := make([]*action.SealedEnvelope, 0, n)
+	for i := 0; i < n; i++ {
+		tsf, err := action.NewTransfer(uint64(i+1), big.NewInt(int64(i+1)), identityset.Address(29).String(), nil, 10000, big.NewInt(1))
+		r.NoError(err)
+		elp := (&action.EnvelopeBuilder{}).SetNonce(uint64(i + 1)).SetGasLimit(10000).
+			SetGasPrice(big.NewInt(1)).SetAction(tsf).Build()
+		selp, err := action.Sign(elp, identityset.PrivateKey(28))
+		r.NoError(err)
+		acts = append(acts, selp)
+	}
+	blk, err := NewTestingBuilder().
+		SetHeight(7).
+		SetTimeStamp(time.Unix(1700000000, 0)).
+		AddActions(acts...).
+		SignAndBuild(identityset.PrivateKey(27))
+	r.NoError(err)
+	return blk
+}
+
+func TestActionInclusionProof(t *testing.T) {
+	r := require.New(t)
+	// a single action is the root, and the last leaf of an odd count is paired with itself at each level
+	for _, n := range []int{1, 2, 3, 4, 5, 6, 7, 9} {
+		blk := newInclusionProofTestBlock(t, n)
+		blkHash := blk.HashBlock()
+		for i := 0; i < n; i++ {
+			proof, err := NewActionInclusionProof(&blk, i)
+			r.NoError(err)
+			actHash, err := blk.Actions[i].Hash()
+			r.NoError(err)
+			r.Equal(actHash, proof.ActionHash)
+			r.Equal(uint32(i), proof.Index)
+			r.Equal(uint64(7), proof.Height)
+			r.NoError(VerifyActionInclusionProof(proof, blkHash), "action %d of %d", i, n)
+			if n == 1 {
+				r.Empty(proof.Path)
+				r.Equal(actHash, blk.TxRoot())
+			}
+		}
+		_, err := NewActionInclusionProof(&blk, n)
+		r.Error(err)
+		_, err = NewActionInclusionProof(&blk, -1)
+		r.Error(err)
+	}
+
+	blk := newInclusionProofTestBlock(t, 5)
+	blkHash := blk.HashBlock()
+	proof, err := NewActionInclusionProof(&blk, 4)
+	r.NoError(err)
+	r.Len(proof.Path, 3)
+
+	// the proof of another block
+	r.ErrorIs(VerifyActionInclusionProof(proof, hash.Hash256b([]byte("block"))), ErrInvalidInclusionProof)
+	// the proof of another action
+	other := *proof
+	other.ActionHash = hash.Hash256b([]byte("action"))
+	r.ErrorIs(VerifyActionInclusionProof(&other, blkHash), ErrInvalidInclusionProof)
+	// the proof at another index
+	other = *proof
+	other.Index = 3
+	r.ErrorIs(VerifyActionInclusionProof(&other, blkHash), ErrInvalidInclusionProof)
+	other.Index = 4 + 1<<3
+	r.ErrorIs(VerifyActionInclusionProof(&other, blkHash), ErrInvalidInclusionProof)
+	// the proof at another height
+	other = *proof
+	other.Height = 8
+	r.ErrorIs(VerifyActionInclusionProof(&other, blkHash), ErrInvalidInclusionProof)
+	// a tampered path
+	other = *proof
+	other.Path = append([]hash.Hash256{}, proof.Path...)
+	other.Path[1] = hash.Hash256b([]byte("sibling"))
+	r.ErrorIs(VerifyActionInclusionProof(&other, blkHash), ErrInvalidInclusionProof)
+	other.Path = proof.Path[:2]
+	r.ErrorIs(VerifyActionInclusionProof(&other, blkHash), ErrInvalidInclusionProof)
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package block
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+)
+
+// ErrInvalidLogDigest is the error of a log digest failing to decode
+var ErrInvalidLogDigest = errors.New("invalid log digest")
+
+type (
+	// LogDigest is the compact digest of the logs in the receipts of a block. The address and topics of a log are
+	// kept as 8-byte fingerprints, so a log matching a filter always matches the digest, while a log matching the
+	// digest is matched against the filter once its receipt is read. The receipts without log are left out
+	LogDigest struct {
+		Receipts []ReceiptLogDigest
+	}
+
+	// ReceiptLogDigest is the digest of the logs of the receipt at Index of the block, which is the index of the
+	// action in the block
+	ReceiptLogDigest struct {
+		Index uint32
+		Logs  []LogEntryDigest
+	}
+
+	// LogEntryDigest is the fingerprints of the address and the topics of a log
+	LogEntryDigest struct {
+		Address uint64
+		Topics  []uint64
+	}
+)
+
+// NewLogDigest returns the digest of the logs in the receipts of a block
+func NewLogDigest(receipts []*action.Receipt) *LogDigest {
+	d := &LogDigest{}
+	for i, r := range receipts {
+		logs := r.Logs()
+		if len(logs) == 0 {
+			continue
+		}
+		rd := ReceiptLogDigest{Index: uint32(i), Logs: make([]LogEntryDigest, len(logs))}
+		for j, l := range logs {
+			rd.Logs[j].Address = AddressFingerprint(l.Address)
+			rd.Logs[j].Topics = make([]uint64, len(l.Topics))
+			for k, topic := range l.Topics {
+				rd.Logs[j].Topics[k] = TopicFingerprint(topic)
+			}
+		}
+		d.Receipts = append(d.Receipts, rd)
+	}
+	return d
+}
+
+// AddressFingerprint returns the fingerprint of the address of a log in the digest
+func AddressFingerprint(addr string) uint64 {
+	h := hash.Hash160b([]byte(addr))
+	return binary.BigEndian.Uint64(h[:8])
+}
+
+// TopicFingerprint returns the fingerprint of a topic of a log in the digest, which is the prefix of the topic as
+// the topic is a hash already
+func TopicFingerprint(topic hash.Hash256) uint64 {
+	return binary.BigEndian.Uint64(topic[:8])
+}
+
+// Serialize returns the serialized bytes of the digest. Each receipt is encoded as its index and the number of its
+// logs, followed by the address, the number of topics and the topics of each log
+func (d *LogDigest) Serialize() []byte {
+	buf := binary.AppendUvarint(nil, uint64(len(d.Receipts)))
+	for _, r := range d.Receipts {
+		buf = binary.AppendUvarint(buf, uint64(r.Index))
+		buf = binary.AppendUvarint(buf, uint64(len(r.Logs)))
+		for _, l := range r.Logs {
+			buf = binary.BigEndian.AppendUint64(buf, l.Address)
+			buf = binary.AppendUvarint(buf, uint64(len(l.Topics)))
+			for _, topic := range l.Topics {
+				buf = binary.BigEndian.AppendUint64(buf, topic)
+			}
+		}
+	}
+	return buf
+}
+
+// DeserializeLogDigest decodes the log digest from the bytes
+func DeserializeLogDigest(buf []byte) (*LogDigest, error) {
+	r := &digestReader{buf: buf}
+	d := &LogDigest{}
+	n := r.count(1)
+	if n > 0 {
+		d.Receipts = make([]ReceiptLogDigest, n)
+	}
+	for i := range d.Receipts {
+		index := r.uvarint()
+		if index > math.MaxUint32 {
+			return nil, errors.Wrapf(ErrInvalidLogDigest, "receipt index %d overflows", index)
+		}
+		d.Receipts[i].Index = uint32(index)
+		d.Receipts[i].Logs = make([]LogEntryDigest, r.count(9))
+		for j := range d.Receipts[i].Logs {
+			l := &d.Receipts[i].Logs[j]
+			l.Address = r.uint64()
+			l.Topics = make([]uint64, r.count(8))
+			for k := range l.Topics {
+				l.Topics[k] = r.uint64()
+			}
+		}
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	if len(r.buf) > 0 {
+		return nil, errors.Wrapf(ErrInvalidLogDigest, "%d trailing bytes", len(r.buf))
+	}
+	return d, nil
+}
+
+// digestReader reads the fields of a serialized log digest, the first error is kept and zero values are read after
+type digestReader struct {
+	buf []byte
+	err error
+}
+
+func (r *digestReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		r.err = errors.Wrap(ErrInvalidLogDigest, "malformed varint")
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+// count reads the number of the items, each of which takes at least minSize bytes, so a corrupted count never
+// allocates beyond the size of the bytes
+func (r *digestReader) count(minSize int) int {
+	v := r.uvarint()
+	if v > uint64(len(r.buf)/minSize) {
+		if r.err == nil {
+			r.err = errors.Wrapf(ErrInvalidLogDigest, "count %d exceeds the remaining %d bytes", v, len(r.buf))
+		}
+		return 0
+	}
+	return int(v)
+}
+
+func (r *digestReader) uint64() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	if len(r.buf) < 8 {
+		r.err = errors.Wrap(ErrInvalidLogDigest, "truncated fingerprint")
+		return 0
+	}
+	v := binary.BigEndian.Uint64(r.buf)
+	r.buf = r.buf[8:]
+	return v
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package block
+
+import (
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestLogDigest(t *testing.T) {
+	r := require.New(t)
+
+	var (
+		addr   = identityset.Address(1).String()
+		topic0 = hash.Hash256b([]byte("topic0"))
+		topic1 = hash.Hash256b([]byte("topic1"))
+	)
+	receipts := []*action.Receipt{
+		(&action.Receipt{}).AddLogs(&action.Log{Address: addr, Topics: action.Topics{topic0, topic1}}),
+		{},
+		(&action.Receipt{}).AddLogs(
+			&action.Log{Address: addr},
+			&action.Log{Address: identityset.Address(2).String(), Topics: action.Topics{topic1}},
+		),
+	}
+	d := NewLogDigest(receipts)
+	// the receipt without log is left out
+	r.Len(d.Receipts, 2)
+	r.Equal(uint32(0), d.Receipts[0].Index)
+	r.Equal(uint32(2), d.Receipts[1].Index)
+	r.Len(d.Receipts[1].Logs, 2)
+	r.Equal(AddressFingerprint(addr), d.Receipts[0].Logs[0].Address)
+	r.Equal([]uint64{TopicFingerprint(topic0), TopicFingerprint(topic1)}, d.Receipts[0].Logs[0].Topics)
+	r.Empty(d.Receipts[1].Logs[0].Topics)
+	r.NotEqual(d.Receipts[1].Logs[0].Address, d.Receipts[1].Logs[1].Address)
+
+	t.Run("Serialize", func(t *testing.T) {
+		for _, d := range []*LogDigest{d, NewLogDigest(nil)} {
+			ser := d.Serialize()
+			d2, err := DeserializeLogDigest(ser)
+			r.NoError(err)
+			r.Equal(d, d2)
+			r.Equal(ser, d2.Serialize())
+		}
+	})
+
+	t.Run("Corrupted", func(t *testing.T) {
+		ser := d.Serialize()
+		for _, b := range [][]byte{
+			nil,
+			ser[:len(ser)-1],
+			append(ser, 0),
+			// a count far beyond the bytes
+			{0xff, 0xff, 0xff, 0xff, 0x0f},
+		} {
+			_, err := DeserializeLogDigest(b)
+			r.Equal(ErrInvalidLogDigest, errors.Cause(err))
+		}
+	})
+}
@@ -16,20 +16,30 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/log"
 )
 
-func calculateTxRoot(acts []*action.SealedEnvelope) (hash.Hash256, error) {
+// NewTxMerkleTree returns the merkle tree of the action hashes, whose root is the tx root of the block of the
+// actions, or nil if there is no action. Both the tx root and the inclusion proofs of the actions are built by it
+func NewTxMerkleTree(acts []*action.SealedEnvelope) (*crypto.Merkle, error) {
 	h := make([]hash.Hash256, 0, len(acts))
 	for _, act := range acts {
 		actHash, err := act.Hash()
 		if err != nil {
 			log.L().Debug("Error in getting hash", zap.Error(err))
-			return hash.ZeroHash256, err
+			return nil, err
 		}
 		h = append(h, actHash)
 	}
-	if len(h) == 0 {
+	return crypto.NewMerkleTree(h), nil
+}
+
+func calculateTxRoot(acts []*action.SealedEnvelope) (hash.Hash256, error) {
+	mk, err := NewTxMerkleTree(acts)
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	if mk == nil {
 		return hash.ZeroHash256, nil
 	}
-	return crypto.NewMerkleTree(h).HashTree(), nil
+	return mk.HashTree(), nil
 }
 
 // calculateTransferAmount returns the calculated transfer amount
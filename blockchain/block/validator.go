@@ -31,6 +31,10 @@ func NewValidator(subsequenceValidator Validator, validators ...action.SealedEnv
 
 func (v *validator) Validate(ctx context.Context, blk *Block) error {
 	actions := blk.Actions
+	// Verify the signatures in batch, so the validators skip verifying them one by one
+	if err := action.VerifySignatures(actions); err != nil {
+		return errors.Wrap(err, "failed to validate action")
+	}
 	// Verify transfers, votes, executions, witness, and secrets
 	errChan := make(chan error, len(actions))
 
@@ -63,4 +63,17 @@ func TestValidator(t *testing.T) {
 	v = NewValidator(nil, valid)
 	require.Contains(v.Validate(ctx, &nblk).Error(), "MockChainManager nonce error")
 
+	// the action with an invalid signature fails the block before the validators
+	sig := append([]byte{}, tsf2.Signature()...)
+	sig[10]++
+	nblk, err = NewTestingBuilder().
+		SetHeight(1).
+		SetPrevBlockHash(blkhash).
+		SetTimeStamp(testutil.TimestampNow()).
+		AddActions(tsf1, action.AssembleSealedEnvelope(tsf2.Envelope, tsf2.SrcPubkey(), sig)).
+		SignAndBuild(identityset.PrivateKey(27))
+	require.NoError(err)
+	err = v.Validate(ctx, &nblk)
+	require.Equal(action.ErrInvalidSender, errors.Cause(err))
+	require.Contains(err.Error(), "at index 1")
 }
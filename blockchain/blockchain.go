@@ -7,6 +7,7 @@ package blockchain
 
 import (
 	"context"
+	"sort"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -17,6 +18,8 @@ import (
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/action"
@@ -25,15 +28,25 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain/blockdao"
 	"github.com/iotexproject/iotex-core/blockchain/filedao"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	cp "github.com/iotexproject/iotex-core/crypto"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/prometheustimer"
+	"github.com/iotexproject/iotex-core/pkg/tracer"
 )
 
 // const
 const (
 	SigP256k1  = "secp256k1"
 	SigP256sm2 = "p256sm2"
+
+	// ProtocolViewRepair rebuilds a protocol view mismatching the states
+	ProtocolViewRepair = "repair"
+	// ProtocolViewStrict refuses to start on a protocol view mismatching the states
+	ProtocolViewStrict = "strict"
+
+	// _medianTimePastBlocks is the number of the last blocks whose median timestamp a new block must be after
+	_medianTimePastBlocks = 11
 )
 
 var (
@@ -55,6 +68,13 @@ var (
 	ErrInsufficientGas = errors.New("insufficient intrinsic gas value")
 	// ErrBalance indicates the error of balance
 	ErrBalance = errors.New("invalid balance")
+	// ErrStopped is the error returned when committing a block to a stopped blockchain
+	ErrStopped = errors.New("blockchain is stopped")
+	// ErrInvalidBlockTimestamp is the error returned when the block timestamp is not after the median time past, or
+	// too far ahead of the local clock
+	ErrInvalidBlockTimestamp = errors.New("invalid block timestamp")
+	// ErrInvalidRandomness is the error returned when the block misses the valid proof of the producer's randomness
+	ErrInvalidRandomness = errors.New("invalid block randomness")
 )
 
 func init() {
@@ -102,6 +122,11 @@ type (
 		RemoveSubscriber(BlockCreationSubscriber) error
 	}
 
+	// Fencer runs a function at the tip height, no block is committed until the function returns
+	Fencer interface {
+		Fence(func(uint64) error) error
+	}
+
 	// BlockBuilderFactory is the factory interface of block builder
 	BlockBuilderFactory interface {
 		// NewBlockBuilder creates block builder
@@ -119,6 +144,8 @@ type (
 		clk            clock.Clock
 		pubSubManager  PubSubManager
 		timerFactory   *prometheustimer.TimerFactory
+		stopped        bool
+		producer       cp.Signer
 
 		// used by account-based model
 		bbf BlockBuilderFactory
@@ -159,6 +186,14 @@ func ClockOption(clk clock.Clock) Option {
 	}
 }
 
+// ProducerSignerOption sets the signer of the blocks minted, which is the configured private key by default
+func ProducerSignerOption(s cp.Signer) Option {
+	return func(bc *blockchain) error {
+		bc.producer = s
+		return nil
+	}
+}
+
 // NewBlockchain creates a new blockchain and DB instance
 func NewBlockchain(cfg Config, g genesis.Genesis, dao blockdao.BlockDAO, bbf BlockBuilderFactory, opts ...Option) Blockchain {
 	// create the Blockchain
@@ -216,14 +251,46 @@ func (bc *blockchain) Start(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	return bc.lifecycle.OnStart(ctx)
+	if err := bc.lifecycle.OnStart(ctx); err != nil {
+		return err
+	}
+	bc.stopped = false
+	return nil
 }
 
-// Stop stops the blockchain.
+// Stop stops the blockchain. The block being committed is waited for, until ShutdownCommitTimeout or ctx is
+// done, then the dao and its indexers are stopped. If the commit is still in progress by then, the partially
+// committed block is repaired by the dao on restart
 func (bc *blockchain) Stop(ctx context.Context) error {
-	bc.mu.Lock()
-	defer bc.mu.Unlock()
-	return bc.lifecycle.OnStop(ctx)
+	locked := make(chan struct{})
+	go func() {
+		bc.mu.Lock()
+		close(locked)
+	}()
+	var timeout <-chan time.Time
+	if bc.config.ShutdownCommitTimeout > 0 {
+		timer := time.NewTimer(bc.config.ShutdownCommitTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case <-locked:
+	case <-timeout:
+	case <-ctx.Done():
+	}
+	select {
+	case <-locked:
+		defer bc.mu.Unlock()
+		bc.stopped = true
+	default:
+		log.L().Error("Timed out waiting for the block being committed, stopping the blockchain anyway.")
+		go func() {
+			<-locked
+			bc.stopped = true
+			bc.mu.Unlock()
+		}()
+	}
+	return bc.lifecycle.OnStopSequentially(ctx)
 }
 
 func (bc *blockchain) BlockHeaderByHeight(height uint64) (*block.Header, error) {
@@ -312,18 +379,29 @@ func (bc *blockchain) ValidateBlock(blk *block.Block) error {
 	}
 	ctx = protocol.WithBlockCtx(ctx,
 		protocol.BlockCtx{
-			BlockHeight:    blk.Height(),
-			BlockTimeStamp: blk.Timestamp(),
-			GasLimit:       bc.genesis.BlockGasLimitByHeight(blk.Height()),
-			Producer:       producerAddr,
+			BlockHeight:     blk.Height(),
+			BlockTimeStamp:  blk.Timestamp(),
+			GasLimit:        bc.genesis.BlockGasLimitByHeight(blk.Height()),
+			Producer:        producerAddr,
+			RandomnessProof: blk.RandomnessProof(),
 		},
 	)
 	ctx = protocol.WithFeatureCtx(ctx)
+	if protocol.MustGetFeatureCtx(ctx).ValidateBlockTimestamp {
+		if err := bc.validateTimestamp(blk, tip.Height); err != nil {
+			return err
+		}
+	}
+	if err := validateRandomness(protocol.MustGetFeatureCtx(ctx), blk); err != nil {
+		return err
+	}
 	if bc.blockValidator == nil {
 		return nil
 	}
-
-	return bc.blockValidator.Validate(ctx, blk)
+	start := time.Now()
+	err = bc.blockValidator.Validate(ctx, blk)
+	traceActions(blk, "blockchain.ValidateBlock", start, err, false)
+	return err
 }
 
 func (bc *blockchain) Context(ctx context.Context) (context.Context, error) {
@@ -373,6 +451,7 @@ func (bc *blockchain) MintNewBlock(timestamp time.Time) (*block.Block, error) {
 	defer bc.mu.RUnlock()
 	mintNewBlockTimer := bc.timerFactory.NewTimer("MintNewBlock")
 	defer mintNewBlockTimer.End()
+	start := time.Now()
 	tipHeight, err := bc.dao.Height()
 	if err != nil {
 		return nil, err
@@ -382,24 +461,35 @@ func (bc *blockchain) MintNewBlock(timestamp time.Time) (*block.Block, error) {
 	if err != nil {
 		return nil, err
 	}
-	ctx = bc.contextWithBlock(ctx, bc.config.ProducerAddress(), newblockHeight, timestamp)
+	minter := bc.producer
+	if minter == nil {
+		minter = bc.config.ProducerPrivateKey()
+	}
+	ctx = bc.contextWithBlock(ctx, minter.PublicKey().Address(), newblockHeight, timestamp)
 	ctx = protocol.WithFeatureCtx(ctx)
 	// run execution and update state trie root hash
-	minterPrivateKey := bc.config.ProducerPrivateKey()
+	if protocol.MustGetFeatureCtx(ctx).EnableRandomnessBeacon {
+		blkCtx := protocol.MustGetBlockCtx(ctx)
+		tip := protocol.MustGetBlockchainCtx(ctx).Tip
+		if blkCtx.RandomnessProof, err = cp.ProveVRF(minter, block.RandomnessAlpha(tip.Hash, newblockHeight)); err != nil {
+			return nil, errors.Wrap(err, "failed to prove the randomness")
+		}
+		ctx = protocol.WithBlockCtx(ctx, blkCtx)
+	}
 	blockBuilder, err := bc.bbf.NewBlockBuilder(
 		ctx,
 		func(elp action.Envelope) (*action.SealedEnvelope, error) {
-			return action.Sign(elp, minterPrivateKey)
+			return action.Sign(elp, minter)
 		},
 	)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create block builder at new block height %d", newblockHeight)
 	}
-	blk, err := blockBuilder.SignAndBuild(minterPrivateKey)
+	blk, err := blockBuilder.SignAndBuild(minter)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create block")
 	}
-
+	traceActions(&blk, "blockchain.MintNewBlock", start, nil, false)
 	return &blk, nil
 }
 
@@ -407,9 +497,26 @@ func (bc *blockchain) MintNewBlock(timestamp time.Time) (*block.Block, error) {
 func (bc *blockchain) CommitBlock(blk *block.Block) error {
 	bc.mu.Lock()
 	defer bc.mu.Unlock()
+	if bc.stopped {
+		return ErrStopped
+	}
 	timer := bc.timerFactory.NewTimer("CommitBlock")
 	defer timer.End()
-	return bc.commitBlock(blk)
+	start := time.Now()
+	err := bc.commitBlock(blk)
+	traceActions(blk, "blockchain.CommitBlock", start, err, err == nil)
+	return err
+}
+
+// Fence runs fn at the tip height with the commits blocked, so fn should return as soon as possible
+func (bc *blockchain) Fence(fn func(uint64) error) error {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	tipHeight, err := bc.dao.Height()
+	if err != nil {
+		return err
+	}
+	return fn(tipHeight)
 }
 
 func (bc *blockchain) AddSubscriber(s BlockCreationSubscriber) error {
@@ -463,6 +570,69 @@ func (bc *blockchain) tipInfo() (*protocol.TipInfo, error) {
 	}, nil
 }
 
+// validateTimestamp checks the block timestamp is strictly after the median timestamp of the last blocks, so the
+// block time never goes backward, and not ahead of the local clock by more than MaxBlockTimeDrift
+func (bc *blockchain) validateTimestamp(blk *block.Block, tipHeight uint64) error {
+	mtp, err := bc.medianTimePast(tipHeight)
+	if err != nil {
+		return err
+	}
+	if !blk.Timestamp().After(mtp) {
+		return errors.Wrapf(
+			ErrInvalidBlockTimestamp,
+			"timestamp %s is not after the median time past %s",
+			blk.Timestamp(),
+			mtp,
+		)
+	}
+	if latest := bc.clk.Now().Add(bc.config.MaxBlockTimeDrift); blk.Timestamp().After(latest) {
+		return errors.Wrapf(
+			ErrInvalidBlockTimestamp,
+			"timestamp %s is ahead of the local time by more than %s",
+			blk.Timestamp(),
+			bc.config.MaxBlockTimeDrift,
+		)
+	}
+	return nil
+}
+
+// validateRandomness checks the block carries the valid proof of the producer's randomness once the randomness beacon
+// is enabled, and no proof before that
+func validateRandomness(fCtx protocol.FeatureCtx, blk *block.Block) error {
+	if !fCtx.EnableRandomnessBeacon {
+		if len(blk.RandomnessProof()) > 0 {
+			return errors.Wrap(ErrInvalidRandomness, "unexpected randomness proof")
+		}
+		return nil
+	}
+	if _, err := blk.VerifyRandomness(); err != nil {
+		return errors.Wrapf(ErrInvalidRandomness, "failed to verify the randomness proof: %v", err)
+	}
+	return nil
+}
+
+// medianTimePast returns the median timestamp of the last _medianTimePastBlocks blocks up to the height, where the
+// genesis timestamp counts as the block 0's
+func (bc *blockchain) medianTimePast(height uint64) (time.Time, error) {
+	timestamps := make([]time.Time, 0, _medianTimePastBlocks)
+	for i := 0; i < _medianTimePastBlocks; i++ {
+		if height == 0 {
+			timestamps = append(timestamps, time.Unix(bc.genesis.Timestamp, 0))
+			break
+		}
+		header, err := bc.dao.HeaderByHeight(height)
+		if err != nil {
+			return time.Time{}, err
+		}
+		timestamps = append(timestamps, header.Timestamp())
+		height--
+	}
+	sort.Slice(timestamps, func(i, j int) bool {
+		return timestamps[i].Before(timestamps[j])
+	})
+	return timestamps[len(timestamps)/2], nil
+}
+
 // commitBlock commits a block to the chain
 func (bc *blockchain) commitBlock(blk *block.Block) error {
 	ctx, err := bc.context(context.Background(), true)
@@ -490,6 +660,31 @@ func (bc *blockchain) commitBlock(blk *block.Block) error {
 	return nil
 }
 
+// traceActions records a span of the stage since start for each tracked action in the block, and stops tracking
+// the actions once they are committed
+func traceActions(blk *block.Block, name string, start time.Time, err error, committed bool) {
+	if !tracer.TracksActions() {
+		return
+	}
+	for _, selp := range blk.Actions {
+		h, hashErr := selp.Hash()
+		if hashErr != nil {
+			continue
+		}
+		span := tracer.ActionSpan(h, name,
+			trace.WithTimestamp(start),
+			trace.WithAttributes(attribute.Int64("blockHeight", int64(blk.Height()))),
+		)
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.End()
+		if committed {
+			tracer.EndActionTrace(h)
+		}
+	}
+}
+
 func (bc *blockchain) emitToSubscribers(blk *block.Block) {
 	if bc.pubSubManager == nil {
 		return
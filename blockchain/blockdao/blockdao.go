@@ -18,6 +18,8 @@ import (
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/filedao"
+	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/prometheustimer"
@@ -53,6 +55,23 @@ type (
 		FooterByHeight(uint64) (*block.Footer, error)
 	}
 
+	// TransactionLogRangeReader is the BlockDAO reading the transaction logs of consecutive blocks at once
+	TransactionLogRangeReader interface {
+		TransactionLogsInRange(start, count uint64) ([]*iotextypes.TransactionLogs, error)
+	}
+
+	// BlockStoreReader is the BlockDAO reading the stored protos of a block without decoding them into the block
+	BlockStoreReader interface {
+		BlockStore(height uint64) (*iotextypes.BlockStore, error)
+	}
+
+	// LogDigestReader is the BlockDAO keeping the digest of the logs in each block, so the log queries read only
+	// the receipts having the logs matched by the digest
+	LogDigestReader interface {
+		LogDigest(height uint64) (*block.LogDigest, error)
+		ReceiptsAt(height uint64, indices []uint32) ([]*action.Receipt, error)
+	}
+
 	blockDAO struct {
 		blockStore   BlockDAO
 		indexers     []BlockIndexer
@@ -63,12 +82,35 @@ type (
 		receiptCache cache.LRUCache
 		blockCache   cache.LRUCache
 		tipHeight    uint64
+		commitGroup  *db.CommitGroup
+		journal      *commitJournal
 	}
+
+	// Option sets an option of the block DAO
+	Option func(*blockDAO)
 )
 
+// CommitGroupOption sets the commit group of the stores written by the indexers. The writes of all the indexers
+// putting a block are flushed together once every indexer succeeds, or discarded altogether if any fails. The
+// block is stored ahead of the indexers, so if the node crashes in between, the indexers lagging behind are
+// caught up from the stored block on restart
+func CommitGroupOption(g *db.CommitGroup) Option {
+	return func(dao *blockDAO) {
+		dao.commitGroup = g
+	}
+}
+
+// CommitJournalOption sets the file recording the block being committed, so that the block partially committed
+// when the node is killed is repaired on restart
+func CommitJournalOption(path string, deserializer *block.Deserializer) Option {
+	return func(dao *blockDAO) {
+		dao.journal = newCommitJournal(path, deserializer)
+	}
+}
+
 // NewBlockDAOWithIndexersAndCache returns a BlockDAO with indexers which will consume blocks appended, and
 // caches which will speed up reading
-func NewBlockDAOWithIndexersAndCache(blkStore BlockDAO, indexers []BlockIndexer, cacheSize int) BlockDAO {
+func NewBlockDAOWithIndexersAndCache(blkStore BlockDAO, indexers []BlockIndexer, cacheSize int, opts ...Option) BlockDAO {
 	if blkStore == nil {
 		return nil
 	}
@@ -77,6 +119,9 @@ func NewBlockDAOWithIndexersAndCache(blkStore BlockDAO, indexers []BlockIndexer,
 		blockStore: blkStore,
 		indexers:   indexers,
 	}
+	for _, opt := range opts {
+		opt(blockDAO)
+	}
 
 	blockDAO.lifecycle.Add(blkStore)
 	for _, indexer := range indexers {
@@ -108,12 +153,64 @@ func (dao *blockDAO) Start(ctx context.Context) error {
 		return errors.Wrap(err, "failed to start child services")
 	}
 
+	if err := dao.repairTip(ctx); err != nil {
+		return err
+	}
 	tipHeight, err := dao.blockStore.Height()
 	if err != nil {
 		return err
 	}
 	atomic.StoreUint64(&dao.tipHeight, tipHeight)
-	return dao.checkIndexers(ctx)
+	if err := dao.checkIndexers(ctx); err != nil {
+		return err
+	}
+	if dao.journal != nil {
+		return dao.journal.clear()
+	}
+	return nil
+}
+
+// repairTip repairs the block partially committed when the node was killed. The block is re-applied to the block
+// store if it's missing there, and the indexers lagging behind are caught up by checkIndexers afterwards. If the
+// block store refuses the block, the indexers which have indexed it are truncated instead
+func (dao *blockDAO) repairTip(ctx context.Context) error {
+	if dao.journal == nil {
+		return nil
+	}
+	blk, err := dao.journal.read()
+	if err != nil || blk == nil {
+		return err
+	}
+	tipHeight, err := dao.blockStore.Height()
+	if err != nil {
+		return err
+	}
+	height := blk.Height()
+	switch {
+	case tipHeight >= height:
+		return nil
+	case tipHeight+1 < height:
+		return errors.Errorf("block %d in commit journal is ahead of the block store at height %d", height, tipHeight)
+	}
+	log.L().Warn("Found a partially committed block, re-applying it to the block store.", zap.Uint64("height", height))
+	err = dao.blockStore.PutBlock(ctx, blk)
+	if err == nil {
+		return nil
+	}
+	log.L().Error("Failed to re-apply the partially committed block, truncating the indexers.", zap.Uint64("height", height), zap.Error(err))
+	for i, indexer := range dao.indexers {
+		indexerHeight, err := indexer.Height()
+		if err != nil {
+			return err
+		}
+		if indexerHeight != height {
+			continue
+		}
+		if err := indexer.DeleteTipBlock(ctx, blk); err != nil {
+			return errors.Wrapf(err, "failed to truncate indexer %d at height %d", i, height)
+		}
+	}
+	return nil
 }
 
 func (dao *blockDAO) checkIndexers(ctx context.Context) error {
@@ -138,8 +235,9 @@ func (dao *blockDAO) checkIndexers(ctx context.Context) error {
 	return nil
 }
 
+// Stop stops the indexers in the reverse order of their dependencies, then the block store
 func (dao *blockDAO) Stop(ctx context.Context) error {
-	return dao.lifecycle.OnStop(ctx)
+	return dao.lifecycle.OnStopSequentially(ctx)
 }
 
 func (dao *blockDAO) GetBlockHash(height uint64) (hash.Hash256, error) {
@@ -255,7 +353,163 @@ func (dao *blockDAO) TransactionLogs(height uint64) (*iotextypes.TransactionLogs
 	return dao.blockStore.TransactionLogs(height)
 }
 
+// TransactionLogsInRange returns the transaction logs of count blocks from start, see TransactionLogsInRange
+func (dao *blockDAO) TransactionLogsInRange(start, count uint64) ([]*iotextypes.TransactionLogs, error) {
+	timer := dao.timerFactory.NewTimer("get_transactionlog_range")
+	defer timer.End()
+	return TransactionLogsInRange(dao.blockStore, start, count)
+}
+
+// TransactionLogsInRange returns the transaction logs of count blocks from start, which are read at once if the dao
+// is a TransactionLogRangeReader, otherwise one block after another. The logs of a block not in the dao are nil, and
+// the logs of a block without transaction are empty
+func TransactionLogsInRange(dao BlockDAO, start, count uint64) ([]*iotextypes.TransactionLogs, error) {
+	if r, ok := dao.(TransactionLogRangeReader); ok {
+		return r.TransactionLogsInRange(start, count)
+	}
+	logs := make([]*iotextypes.TransactionLogs, count)
+	for i := range logs {
+		l, err := dao.TransactionLogs(start + uint64(i))
+		switch errors.Cause(err) {
+		case nil:
+			logs[i] = l
+		case db.ErrNotExist:
+			logs[i] = &iotextypes.TransactionLogs{}
+		case filedao.ErrNotSupported:
+		default:
+			return nil, err
+		}
+	}
+	return logs, nil
+}
+
+// BlockStore returns the protos of the block and its receipts at the height, see BlockStore
+func (dao *blockDAO) BlockStore(height uint64) (*iotextypes.BlockStore, error) {
+	timer := dao.timerFactory.NewTimer("get_block_store")
+	defer timer.End()
+	return BlockStore(dao.blockStore, height)
+}
+
+// BlockStore returns the protos of the block and its receipts at the height, which are read without decoding the
+// block if the dao is a BlockStoreReader supporting the height, otherwise converted from the block read
+func BlockStore(dao BlockDAO, height uint64) (*iotextypes.BlockStore, error) {
+	if r, ok := dao.(BlockStoreReader); ok {
+		store, err := r.BlockStore(height)
+		if errors.Cause(err) != filedao.ErrNotSupported {
+			return store, err
+		}
+	}
+	blk, err := dao.GetBlockByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := dao.GetReceipts(height)
+	if err != nil {
+		return nil, err
+	}
+	return (&block.Store{Block: blk, Receipts: receipts}).ToProto(), nil
+}
+
+// LogDigest returns the digest of the logs in the block at the height, see LogDigest
+func (dao *blockDAO) LogDigest(height uint64) (*block.LogDigest, error) {
+	timer := dao.timerFactory.NewTimer("get_log_digest")
+	defer timer.End()
+	if receipts, ok := lruCacheGet(dao.receiptCache, height); ok {
+		_cacheMtc.WithLabelValues("hit_receipts").Inc()
+		return block.NewLogDigest(receipts.([]*action.Receipt)), nil
+	}
+	return LogDigest(dao.blockStore, height)
+}
+
+// LogDigest returns the digest of the logs in the block at the height, which is read from the dao if it's a
+// LogDigestReader supporting the height, otherwise built from the receipts read
+func LogDigest(dao BlockDAO, height uint64) (*block.LogDigest, error) {
+	if r, ok := dao.(LogDigestReader); ok {
+		digest, err := r.LogDigest(height)
+		if errors.Cause(err) != filedao.ErrNotSupported {
+			return digest, err
+		}
+	}
+	receipts, err := dao.GetReceipts(height)
+	if err != nil {
+		return nil, err
+	}
+	return block.NewLogDigest(receipts), nil
+}
+
+// ReceiptsAt returns the receipts at the indices of the block at the height, see ReceiptsAt
+func (dao *blockDAO) ReceiptsAt(height uint64, indices []uint32) ([]*action.Receipt, error) {
+	timer := dao.timerFactory.NewTimer("get_receipts_at")
+	defer timer.End()
+	if receipts, ok := lruCacheGet(dao.receiptCache, height); ok {
+		_cacheMtc.WithLabelValues("hit_receipts").Inc()
+		return pickReceipts(receipts.([]*action.Receipt), indices)
+	}
+	return ReceiptsAt(dao.blockStore, height, indices)
+}
+
+// ReceiptsAt returns the receipts at the indices of the block at the height, which are read alone if the dao is a
+// LogDigestReader supporting the height, otherwise picked from all the receipts read
+func ReceiptsAt(dao BlockDAO, height uint64, indices []uint32) ([]*action.Receipt, error) {
+	if r, ok := dao.(LogDigestReader); ok {
+		receipts, err := r.ReceiptsAt(height, indices)
+		if errors.Cause(err) != filedao.ErrNotSupported {
+			return receipts, err
+		}
+	}
+	receipts, err := dao.GetReceipts(height)
+	if err != nil {
+		return nil, err
+	}
+	return pickReceipts(receipts, indices)
+}
+
+func pickReceipts(receipts []*action.Receipt, indices []uint32) ([]*action.Receipt, error) {
+	picked := make([]*action.Receipt, len(indices))
+	for i, idx := range indices {
+		if int(idx) >= len(receipts) {
+			return nil, errors.Wrapf(db.ErrNotExist, "receipt %d of %d", idx, len(receipts))
+		}
+		picked[i] = receipts[idx]
+	}
+	return picked, nil
+}
+
 func (dao *blockDAO) PutBlock(ctx context.Context, blk *block.Block) error {
+	if err := dao.checkFinalized(blk); err != nil {
+		return err
+	}
+	if dao.journal != nil {
+		if err := dao.journal.write(blk); err != nil {
+			return err
+		}
+	}
+	if err := dao.putBlock(ctx, blk); err != nil {
+		return err
+	}
+	if dao.journal != nil {
+		if err := dao.journal.clear(); err != nil {
+			log.L().Warn("Failed to clear commit journal.", zap.Uint64("height", blk.Height()), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// checkFinalized refuses the block at or below the tip height. Rolldpos gives instant finality, so every block up to
+// the tip is final, and the block is either committed already, or would overwrite a final block
+func (dao *blockDAO) checkFinalized(blk *block.Block) error {
+	height, tip := blk.Height(), atomic.LoadUint64(&dao.tipHeight)
+	if height > tip {
+		return nil
+	}
+	if h, err := dao.blockStore.GetBlockHash(height); err == nil && h == blk.HashBlock() {
+		return errors.Wrapf(filedao.ErrAlreadyExist, "block %d is committed", height)
+	}
+	log.L().Error("Refused to overwrite a finalized block.", zap.Uint64("height", height), zap.Uint64("tipHeight", tip))
+	return errors.Wrapf(block.ErrFinalizedHeight, "block %d is at or below the tip height %d", height, tip)
+}
+
+func (dao *blockDAO) putBlock(ctx context.Context, blk *block.Block) error {
 	timer := dao.timerFactory.NewTimer("put_block")
 	if err := dao.blockStore.PutBlock(ctx, blk); err != nil {
 		timer.End()
@@ -270,11 +524,30 @@ func (dao *blockDAO) PutBlock(ctx context.Context, blk *block.Block) error {
 	// index the block if there's indexer
 	timer = dao.timerFactory.NewTimer("index_block")
 	defer timer.End()
+	if dao.commitGroup == nil {
+		for _, indexer := range dao.indexers {
+			if err := indexer.PutBlock(ctx, blk); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := dao.commitGroup.Begin(); err != nil {
+		return err
+	}
 	for _, indexer := range dao.indexers {
 		if err := indexer.PutBlock(ctx, blk); err != nil {
+			dao.commitGroup.Rollback()
+			log.L().Error("Failed to index block, the indexers are caught up on restart.", zap.Uint64("height", blk.Height()), zap.Error(err))
 			return err
 		}
 	}
+	commitTimer := dao.timerFactory.NewTimer("group_commit")
+	defer commitTimer.End()
+	if err := dao.commitGroup.Commit(); err != nil {
+		log.L().Error("Failed to commit indexes of block, the indexers are caught up on restart.", zap.Uint64("height", blk.Height()), zap.Error(err))
+		return err
+	}
 	return nil
 }
 
@@ -4,6 +4,8 @@ import (
 	"context"
 	"hash/fnv"
 	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -21,10 +23,12 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain/filedao"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/batch"
 	"github.com/iotexproject/iotex-core/pkg/compress"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/prometheustimer"
 	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/test/identityset"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blockdao"
 	"github.com/iotexproject/iotex-core/testutil"
@@ -172,7 +176,7 @@ func Test_blockDAO_Stop(t *testing.T) {
 		p := gomonkey.NewPatches()
 		defer p.Reset()
 
-		p = p.ApplyMethodReturn(&lifecycle.Lifecycle{}, "OnStop", errors.New(t.Name()))
+		p = p.ApplyMethodReturn(&lifecycle.Lifecycle{}, "OnStopSequentially", errors.New(t.Name()))
 
 		err := dao.Stop(context.Background())
 
@@ -183,7 +187,7 @@ func Test_blockDAO_Stop(t *testing.T) {
 		p := gomonkey.NewPatches()
 		defer p.Reset()
 
-		p = p.ApplyMethodReturn(&lifecycle.Lifecycle{}, "OnStop", nil)
+		p = p.ApplyMethodReturn(&lifecycle.Lifecycle{}, "OnStopSequentially", nil)
 
 		err := dao.Stop(context.Background())
 
@@ -488,6 +492,56 @@ func Test_blockDAO_GetReceipts(t *testing.T) {
 	})
 }
 
+func Test_blockDAO_LogDigest(t *testing.T) {
+	r := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mock_blockdao.NewMockBlockDAO(ctrl)
+
+	dao := &blockDAO{blockStore: store}
+	receipts := []*action.Receipt{
+		{},
+		(&action.Receipt{}).AddLogs(&action.Log{Address: identityset.Address(1).String(), Topics: action.Topics{hash.ZeroHash256}}),
+	}
+
+	t.Run("FailedToGetReceipts", func(t *testing.T) {
+		store.EXPECT().GetReceipts(gomock.Any()).Return(nil, errors.New(t.Name())).Times(2)
+
+		_, err := dao.LogDigest(100)
+		r.ErrorContains(err, t.Name())
+		_, err = dao.ReceiptsAt(100, []uint32{1})
+		r.ErrorContains(err, t.Name())
+	})
+
+	t.Run("BuiltFromReceipts", func(t *testing.T) {
+		// the store not keeping the digest falls back to the receipts
+		store.EXPECT().GetReceipts(gomock.Any()).Return(receipts, nil).Times(3)
+
+		digest, err := dao.LogDigest(100)
+		r.NoError(err)
+		r.Equal(block.NewLogDigest(receipts), digest)
+		picked, err := dao.ReceiptsAt(100, []uint32{1})
+		r.NoError(err)
+		r.Equal(receipts[1:], picked)
+		_, err = dao.ReceiptsAt(100, []uint32{2})
+		r.Equal(db.ErrNotExist, errors.Cause(err))
+	})
+
+	t.Run("ReceiptCache", func(t *testing.T) {
+		dao := &blockDAO{blockStore: store, receiptCache: cache.NewThreadSafeLruCache(1)}
+		dao.receiptCache.Add(uint64(100), receipts)
+
+		digest, err := dao.LogDigest(100)
+		r.NoError(err)
+		r.Equal(block.NewLogDigest(receipts), digest)
+		picked, err := dao.ReceiptsAt(100, []uint32{0, 1})
+		r.NoError(err)
+		r.Equal(receipts, picked)
+	})
+}
+
 func Test_blockDAO_ContainsTransactionLog(t *testing.T) {
 	r := require.New(t)
 
@@ -546,11 +600,12 @@ func Test_blockDAO_PutBlock(t *testing.T) {
 		indexers:   []BlockIndexer{indexer},
 		blockStore: store,
 	}
+	blks := getTestBlocks(t)
 
 	t.Run("FailedToPutBlockToBlockStore", func(t *testing.T) {
 		store.EXPECT().PutBlock(gomock.Any(), gomock.Any()).Return(errors.New(t.Name())).Times(1)
 
-		err := dao.PutBlock(context.Background(), &block.Block{})
+		err := dao.PutBlock(context.Background(), blks[0])
 
 		r.ErrorContains(err, t.Name())
 	})
@@ -559,7 +614,7 @@ func Test_blockDAO_PutBlock(t *testing.T) {
 		store.EXPECT().PutBlock(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 		indexer.EXPECT().PutBlock(gomock.Any(), gomock.Any()).Return(errors.New(t.Name())).Times(1)
 
-		err := dao.PutBlock(context.Background(), &block.Block{})
+		err := dao.PutBlock(context.Background(), blks[0])
 
 		r.ErrorContains(err, t.Name())
 	})
@@ -568,10 +623,252 @@ func Test_blockDAO_PutBlock(t *testing.T) {
 		store.EXPECT().PutBlock(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 		indexer.EXPECT().PutBlock(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 
-		err := dao.PutBlock(context.Background(), &block.Block{})
+		err := dao.PutBlock(context.Background(), blks[1])
+
+		r.NoError(err)
+	})
+
+	t.Run("AlreadyExist", func(t *testing.T) {
+		store.EXPECT().GetBlockHash(uint64(2)).Return(blks[1].HashBlock(), nil).Times(1)
+
+		err := dao.PutBlock(context.Background(), blks[1])
+
+		r.ErrorIs(err, filedao.ErrAlreadyExist)
+	})
+
+	t.Run("RefuseFinalizedHeight", func(t *testing.T) {
+		// another block is committed at the height, the block store and the indexers are not touched by the overwrite
+		store.EXPECT().GetBlockHash(gomock.Any()).Return(hash.ZeroHash256, nil).Times(3)
+		for _, blk := range []*block.Block{blks[0], blks[1], {}} {
+			err := dao.PutBlock(context.Background(), blk)
+
+			r.ErrorIs(err, block.ErrFinalizedHeight)
+		}
+		r.EqualValues(2, dao.tipHeight)
+	})
+}
+
+type testGroupIndexer struct {
+	kvStore db.KVStore
+	ns      string
+	err     error
+	kill    bool
+}
+
+func (ti *testGroupIndexer) Start(ctx context.Context) error { return nil }
+
+func (ti *testGroupIndexer) Stop(ctx context.Context) error { return nil }
+
+func (ti *testGroupIndexer) Height() (uint64, error) {
+	h, err := ti.kvStore.Get(ti.ns, []byte("height"))
+	if errors.Cause(err) == db.ErrNotExist {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return byteutil.BytesToUint64(h), nil
+}
+
+func (ti *testGroupIndexer) PutBlock(ctx context.Context, blk *block.Block) error {
+	if ti.kill {
+		panic("killed")
+	}
+	b := batch.NewBatch()
+	for _, act := range blk.Actions {
+		h, err := act.Hash()
+		if err != nil {
+			return err
+		}
+		b.Put(ti.ns, h[:], byteutil.Uint64ToBytes(blk.Height()), "failed to put action")
+	}
+	b.Put(ti.ns, []byte("height"), byteutil.Uint64ToBytes(blk.Height()), "failed to put height")
+	if err := ti.kvStore.WriteBatch(b); err != nil {
+		return err
+	}
+	return ti.err
+}
+
+func (ti *testGroupIndexer) DeleteTipBlock(_ context.Context, blk *block.Block) error {
+	height, err := ti.Height()
+	if err != nil {
+		return err
+	}
+	if height != blk.Height() {
+		return errors.New("not the tip block")
+	}
+	b := batch.NewBatch()
+	for _, act := range blk.Actions {
+		h, err := act.Hash()
+		if err != nil {
+			return err
+		}
+		b.Delete(ti.ns, h[:], "failed to delete action")
+	}
+	b.Put(ti.ns, []byte("height"), byteutil.Uint64ToBytes(height-1), "failed to put height")
+	return ti.kvStore.WriteBatch(b)
+}
+
+func Test_blockDAO_RepairPartialCommit(t *testing.T) {
+	r := require.New(t)
+	ctx := protocol.WithBlockchainCtx(genesis.WithGenesisContext(context.Background(), genesis.Default), protocol.BlockchainCtx{ChainID: 1})
+	blks := getTestBlocks(t)
+	journalPath := filepath.Join(t.TempDir(), "chain.db.journal")
+	deser := block.NewDeserializer(4689)
+
+	newDAO := func(store filedao.FileDAO, indexers ...*testGroupIndexer) BlockDAO {
+		blockIndexers := make([]BlockIndexer, len(indexers))
+		for i := range indexers {
+			blockIndexers[i] = indexers[i]
+		}
+		return NewBlockDAOWithIndexersAndCache(store, blockIndexers, 0, CommitJournalOption(journalPath, deser))
+	}
+	// kill the node in the middle of committing the block, after the block store and the first indexer are written
+	putAndKill := func(dao BlockDAO, blk *block.Block, indexer *testGroupIndexer) {
+		indexer.kill = true
+		defer func() {
+			indexer.kill = false
+			r.Equal("killed", recover())
+		}()
+		_ = dao.PutBlock(ctx, blk)
+	}
+	height := func(indexer *testGroupIndexer) uint64 {
+		h, err := indexer.Height()
+		r.NoError(err)
+		return h
+	}
+
+	t.Run("CatchUpIndexers", func(t *testing.T) {
+		store, err := filedao.NewFileDAOInMemForTest()
+		r.NoError(err)
+		indexers := []*testGroupIndexer{{kvStore: db.NewMemKVStore(), ns: "a"}, {kvStore: db.NewMemKVStore(), ns: "b"}}
+		dao := newDAO(store, indexers...)
+		r.NoError(dao.Start(ctx))
+		r.NoError(dao.PutBlock(ctx, blks[0]))
+		_, err = os.Stat(journalPath)
+		r.True(os.IsNotExist(err))
+
+		putAndKill(dao, blks[1], indexers[1])
+		r.EqualValues(2, height(indexers[0]))
+		r.EqualValues(1, height(indexers[1]))
+		_, err = os.Stat(journalPath)
+		r.NoError(err)
+
+		dao = newDAO(store, indexers...)
+		r.NoError(dao.Start(ctx))
+		defer dao.Stop(ctx)
+		r.EqualValues(2, height(indexers[1]))
+		_, err = os.Stat(journalPath)
+		r.True(os.IsNotExist(err))
+	})
+
+	t.Run("ReapplyBlockStore", func(t *testing.T) {
+		store, err := filedao.NewFileDAOInMemForTest()
+		r.NoError(err)
+		indexers := []*testGroupIndexer{{kvStore: db.NewMemKVStore(), ns: "a"}, {kvStore: db.NewMemKVStore(), ns: "b"}}
+		dao := newDAO(store, indexers...)
+		r.NoError(dao.Start(ctx))
+		r.NoError(dao.PutBlock(ctx, blks[0]))
+
+		putAndKill(dao, blks[1], indexers[1])
+		// the write of the block store is lost, leaving the first indexer a block ahead
+		r.NoError(store.DeleteTipBlock())
+		daoHeight, err := store.Height()
+		r.NoError(err)
+		r.EqualValues(1, daoHeight)
+		r.EqualValues(2, height(indexers[0]))
 
+		dao = newDAO(store, indexers...)
+		r.NoError(dao.Start(ctx))
+		defer dao.Stop(ctx)
+		daoHeight, err = dao.Height()
+		r.NoError(err)
+		r.EqualValues(2, daoHeight)
+		r.EqualValues(2, height(indexers[0]))
+		r.EqualValues(2, height(indexers[1]))
+		blk, err := dao.GetBlockByHeight(2)
 		r.NoError(err)
+		r.Equal(blks[1].HashBlock(), blk.HashBlock())
 	})
+
+	t.Run("TruncateIndexers", func(t *testing.T) {
+		store, err := filedao.NewFileDAOInMemForTest()
+		r.NoError(err)
+		indexers := []*testGroupIndexer{{kvStore: db.NewMemKVStore(), ns: "a"}, {kvStore: db.NewMemKVStore(), ns: "b"}}
+		dao := newDAO(store, indexers...)
+		r.NoError(dao.Start(ctx))
+		r.NoError(dao.PutBlock(ctx, blks[0]))
+
+		putAndKill(dao, blks[1], indexers[1])
+		r.NoError(store.DeleteTipBlock())
+		// the block store refuses the block on restart
+		p := gomonkey.NewPatches()
+		defer p.Reset()
+		p.ApplyMethodReturn(store, "PutBlock", errors.New("refused"))
+
+		dao = newDAO(store, indexers...)
+		r.NoError(dao.Start(ctx))
+		defer dao.Stop(ctx)
+		r.EqualValues(1, height(indexers[0]))
+		r.EqualValues(1, height(indexers[1]))
+		for _, act := range blks[1].Actions {
+			h, err := act.Hash()
+			r.NoError(err)
+			_, err = indexers[0].kvStore.Get("a", h[:])
+			r.Equal(db.ErrNotExist, errors.Cause(err))
+		}
+	})
+}
+
+func Test_blockDAO_PutBlockWithCommitGroup(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	blks := getTestBlocks(t)
+	store, err := filedao.NewFileDAOInMemForTest()
+	r.NoError(err)
+	mem1, mem2 := db.NewMemKVStore(), db.NewMemKVStore()
+	g := db.NewCommitGroup()
+	kv1, kv2 := g.Join(mem1), g.Join(mem2)
+	// the last indexer shares the store with the first one
+	indexers := []*testGroupIndexer{{kvStore: kv1, ns: "a"}, {kvStore: kv2, ns: "b"}, {kvStore: kv1, ns: "c"}}
+	dao := NewBlockDAOWithIndexersAndCache(store, []BlockIndexer{indexers[0], indexers[1], indexers[2]}, 0, CommitGroupOption(g))
+	ctx = protocol.WithBlockchainCtx(genesis.WithGenesisContext(ctx, genesis.Default), protocol.BlockchainCtx{ChainID: 1})
+	r.NoError(dao.Start(ctx))
+	defer dao.Stop(ctx)
+
+	r.NoError(dao.PutBlock(ctx, blks[0]))
+	for i, kv := range []db.KVStore{mem1, mem2, mem1} {
+		h, err := kv.Get(indexers[i].ns, []byte("height"))
+		r.NoError(err)
+		r.EqualValues(1, byteutil.BytesToUint64(h))
+	}
+
+	// the writes of all the indexers are discarded if any fails
+	indexers[2].err = errors.New("failed to index")
+	r.ErrorContains(dao.PutBlock(ctx, blks[1]), "failed to index")
+	for i, kv := range []db.KVStore{mem1, mem2, mem1} {
+		h, err := kv.Get(indexers[i].ns, []byte("height"))
+		r.NoError(err)
+		r.EqualValues(1, byteutil.BytesToUint64(h))
+	}
+	for _, act := range blks[1].Actions {
+		h, err := act.Hash()
+		r.NoError(err)
+		_, err = mem1.Get("a", h[:])
+		r.Equal(db.ErrNotExist, errors.Cause(err))
+	}
+	// the block stored ahead is indexed again on restart
+	height, err := dao.Height()
+	r.NoError(err)
+	r.EqualValues(2, height)
+	indexers[2].err = nil
+	r.NoError(dao.Stop(ctx))
+	r.NoError(dao.Start(ctx))
+	for i, kv := range []db.KVStore{mem1, mem2, mem1} {
+		h, err := kv.Get(indexers[i].ns, []byte("height"))
+		r.NoError(err)
+		r.EqualValues(2, byteutil.BytesToUint64(h))
+	}
 }
 
 func Test_lruCache(t *testing.T) {
@@ -1104,6 +1401,75 @@ func BenchmarkBlockCache(b *testing.B) {
 	})
 }
 
+func BenchmarkPutBlockWithCommitGroup(b *testing.B) {
+	const numTsfs = 200
+	tsfs := make([]*action.SealedEnvelope, numTsfs)
+	for i := range tsfs {
+		tsf, err := action.SignedTransfer(
+			identityset.Address(i%30).String(),
+			identityset.PrivateKey(i%30+1),
+			uint64(i/30+1),
+			unit.ConvertIotxToRau(1),
+			nil,
+			testutil.TestGasLimit,
+			testutil.TestGasPrice,
+		)
+		require.NoError(b, err)
+		tsfs[i] = tsf
+	}
+	test := func(group bool, b *testing.B) {
+		ctx := protocol.WithBlockchainCtx(genesis.WithGenesisContext(context.Background(), genesis.Default), protocol.BlockchainCtx{ChainID: 1})
+		// the blocks are kept in memory, to measure the writes of the indexers only
+		fileDAO, err := filedao.NewFileDAOInMemForTest()
+		require.NoError(b, err)
+		dir := b.TempDir()
+		cfg := db.DefaultConfig
+		cfg.DbPath = dir + "/index1.db"
+		store1 := db.KVStore(db.NewBoltDB(cfg))
+		cfg.DbPath = dir + "/index2.db"
+		store2 := db.KVStore(db.NewBoltDB(cfg))
+		var opts []Option
+		if group {
+			g := db.NewCommitGroup()
+			store1, store2 = g.Join(store1), g.Join(store2)
+			opts = append(opts, CommitGroupOption(g))
+		}
+		lc := lifecycle.Lifecycle{}
+		lc.AddModels(store1, store2)
+		require.NoError(b, lc.OnStart(ctx))
+		defer lc.OnStop(ctx)
+		indexers := []BlockIndexer{
+			&testGroupIndexer{kvStore: store1, ns: "a"},
+			&testGroupIndexer{kvStore: store2, ns: "b"},
+			&testGroupIndexer{kvStore: store1, ns: "c"},
+		}
+		dao := NewBlockDAOWithIndexersAndCache(fileDAO, indexers, 0, opts...)
+		require.NoError(b, dao.Start(ctx))
+		defer dao.Stop(ctx)
+		prevHash := hash.ZeroHash256
+		b.ResetTimer()
+		for i := 1; i <= b.N; i++ {
+			b.StopTimer()
+			blk, err := block.NewTestingBuilder().
+				SetPrevBlockHash(prevHash).
+				SetTimeStamp(time.Now()).
+				SetHeight(uint64(i)).
+				AddActions(tsfs...).
+				SignAndBuild(identityset.PrivateKey(0))
+			require.NoError(b, err)
+			prevHash = blk.HashBlock()
+			b.StartTimer()
+			require.NoError(b, dao.PutBlock(ctx, &blk))
+		}
+	}
+	b.Run("direct", func(b *testing.B) {
+		test(false, b)
+	})
+	b.Run("group", func(b *testing.B) {
+		test(true, b)
+	})
+}
+
 func receiptByActionHash(receipts []*action.Receipt, h hash.Hash256) (*action.Receipt, error) {
 	for _, r := range receipts {
 		if r.ActionHash == h {
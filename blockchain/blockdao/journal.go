@@ -0,0 +1,75 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockdao
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+)
+
+// commitJournal records the block being committed in a file, which is synced to disk before the block is written
+// into the block store and the indexers, and removed once all of them are written. A journal left on start means
+// the node was killed in the middle of committing the block
+type commitJournal struct {
+	path         string
+	deserializer *block.Deserializer
+}
+
+func newCommitJournal(path string, deserializer *block.Deserializer) *commitJournal {
+	return &commitJournal{
+		path:         path,
+		deserializer: deserializer,
+	}
+}
+
+func (j *commitJournal) write(blk *block.Block) error {
+	data, err := (&block.Store{Block: blk, Receipts: blk.Receipts}).Serialize()
+	if err != nil {
+		return err
+	}
+	tmp := j.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(data); err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to write commit journal")
+	}
+	return os.Rename(tmp, j.path)
+}
+
+// read returns the block in the journal, or nil if there's no journal
+func (j *commitJournal) read() (*block.Block, error) {
+	data, err := os.ReadFile(j.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	store, err := j.deserializer.DeserializeBlockStore(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read commit journal")
+	}
+	store.Block.Receipts = store.Receipts
+	return store.Block, nil
+}
+
+func (j *commitJournal) clear() error {
+	if err := os.Remove(j.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
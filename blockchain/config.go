@@ -17,45 +17,95 @@ import (
 	"go.uber.org/config"
 	"go.uber.org/zap"
 
+	cp "github.com/iotexproject/iotex-core/crypto"
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/signer"
 )
 
 type (
 	// Config is the config struct for blockchain package
 	Config struct {
-		ChainDBPath                string           `yaml:"chainDBPath"`
-		TrieDBPatchFile            string           `yaml:"trieDBPatchFile"`
-		TrieDBPath                 string           `yaml:"trieDBPath"`
-		StakingPatchDir            string           `yaml:"stakingPatchDir"`
-		IndexDBPath                string           `yaml:"indexDBPath"`
-		BloomfilterIndexDBPath     string           `yaml:"bloomfilterIndexDBPath"`
-		CandidateIndexDBPath       string           `yaml:"candidateIndexDBPath"`
-		StakingIndexDBPath         string           `yaml:"stakingIndexDBPath"`
-		ContractStakingIndexDBPath string           `yaml:"contractStakingIndexDBPath"`
-		ID                         uint32           `yaml:"id"`
-		EVMNetworkID               uint32           `yaml:"evmNetworkID"`
-		Address                    string           `yaml:"address"`
-		ProducerPrivKey            string           `yaml:"producerPrivKey"`
-		ProducerPrivKeySchema      string           `yaml:"producerPrivKeySchema"`
-		SignatureScheme            []string         `yaml:"signatureScheme"`
-		EmptyGenesis               bool             `yaml:"emptyGenesis"`
-		GravityChainDB             db.Config        `yaml:"gravityChainDB"`
-		Committee                  committee.Config `yaml:"committee"`
+		ChainDBPath                 string `yaml:"chainDBPath"`
+		TrieDBPatchFile             string `yaml:"trieDBPatchFile"`
+		TrieDBPath                  string `yaml:"trieDBPath"`
+		StakingPatchDir             string `yaml:"stakingPatchDir"`
+		IndexDBPath                 string `yaml:"indexDBPath"`
+		BloomfilterIndexDBPath      string `yaml:"bloomfilterIndexDBPath"`
+		CandidateIndexDBPath        string `yaml:"candidateIndexDBPath"`
+		StakingIndexDBPath          string `yaml:"stakingIndexDBPath"`
+		ContractStakingIndexDBPath  string `yaml:"contractStakingIndexDBPath"`
+		TokenTransferIndexDBPath    string `yaml:"tokenTransferIndexDBPath"`
+		CandidateHistoryIndexDBPath string `yaml:"candidateHistoryIndexDBPath"`
+		SystemActionIndexDBPath     string `yaml:"systemActionIndexDBPath"`
+		ContractStatsIndexDBPath    string `yaml:"contractStatsIndexDBPath"`
+		MemoIndexDBPath             string `yaml:"memoIndexDBPath"`
+		BlockTimeIndexDBPath        string `yaml:"blockTimeIndexDBPath"`
+		ID                          uint32 `yaml:"id"`
+		EVMNetworkID                uint32 `yaml:"evmNetworkID"`
+		Address                     string `yaml:"address"`
+		ProducerPrivKey             string `yaml:"producerPrivKey"`
+		ProducerPrivKeySchema       string `yaml:"producerPrivKeySchema"`
+		// ProducerSigner is the signer of the producer key kept out of the config, i.e., in an encrypted keystore
+		// or by a remote signer, which takes the place of ProducerPrivKey if its type is set
+		ProducerSigner  signer.Config    `yaml:"producerSigner"`
+		SignatureScheme []string         `yaml:"signatureScheme"`
+		EmptyGenesis    bool             `yaml:"emptyGenesis"`
+		GravityChainDB  db.Config        `yaml:"gravityChainDB"`
+		Committee       committee.Config `yaml:"committee"`
 
 		EnableTrielessStateDB bool `yaml:"enableTrielessStateDB"`
 		// EnableStateDBCaching enables cachedStateDBOption
 		EnableStateDBCaching bool `yaml:"enableStateDBCaching"`
 		// EnableArchiveMode is only meaningful when EnableTrielessStateDB is false
 		EnableArchiveMode bool `yaml:"enableArchiveMode"`
+		// EnableHistoryStateRetention keeps the history states of the most recent HistoryStateRetention heights,
+		// it is only meaningful when EnableTrielessStateDB and EnableArchiveMode are false
+		EnableHistoryStateRetention bool `yaml:"enableHistoryStateRetention"`
+		// HistoryStateRetention is the number of most recent heights whose history states are retained
+		HistoryStateRetention uint64 `yaml:"historyStateRetention"`
 		// EnableAsyncIndexWrite enables writing the block actions' and receipts' index asynchronously
 		EnableAsyncIndexWrite bool `yaml:"enableAsyncIndexWrite"`
+		// EnableGroupCommit enables flushing the writes of the indexers putting a block together, one batch per
+		// db, which are discarded altogether if any indexer fails
+		EnableGroupCommit bool `yaml:"enableGroupCommit"`
+		// EnableParallelExecution enables executing the transfers of a block which touch disjoint accounts in parallel,
+		// when the block is validated or committed. The states are the same as those of executing the block serially
+		EnableParallelExecution bool `yaml:"enableParallelExecution"`
+		// VerifyProtocolViewOnStart verifies the views of the protocols loaded on start, e.g., the candidate center and
+		// the bucket pool of staking, against the states they are built from. A mismatched view is rebuilt from the
+		// states if it is "repair", the node refuses to start with the diff if it is "strict", and no check if empty
+		VerifyProtocolViewOnStart string `yaml:"verifyProtocolViewOnStart"`
+		// VerifyProtocolViewAtEpoch verifies the views of the protocols after the first block of each epoch is
+		// committed, the diff is logged on mismatch, and the view is rebuilt if VerifyProtocolViewOnStart is "repair"
+		VerifyProtocolViewAtEpoch bool `yaml:"verifyProtocolViewAtEpoch"`
 		// deprecated
 		EnableSystemLogIndexer bool `yaml:"enableSystemLog"`
 		// EnableStakingProtocol enables staking protocol
 		EnableStakingProtocol bool `yaml:"enableStakingProtocol"`
 		// EnableStakingIndexer enables staking indexer
 		EnableStakingIndexer bool `yaml:"enableStakingIndexer"`
+		// EnableTokenTransferIndexer enables indexing the transfers of XRC20 and XRC721 tokens, the history is
+		// indexed when the node starts if enabled the first time
+		EnableTokenTransferIndexer bool `yaml:"enableTokenTransferIndexer"`
+		// EnableCandidateHistoryIndexer enables recording the votes and rank of every candidate at the end of each
+		// epoch, the epochs before it is enabled are recorded only if their state is available in archive mode
+		EnableCandidateHistoryIndexer bool `yaml:"enableCandidateHistoryIndexer"`
+		// EnableSystemActionIndexer enables indexing the GrantReward and PutPollResult actions of each block, the
+		// history is indexed when the node starts if enabled the first time
+		EnableSystemActionIndexer bool `yaml:"enableSystemActionIndexer"`
+		// EnableContractStatsIndexer enables indexing the daily calls, gas consumed, failures and unique callers of
+		// each contract, the history is indexed when the node starts if enabled the first time
+		EnableContractStatsIndexer bool `yaml:"enableContractStatsIndexer"`
+		// EnableMemoIndexer enables indexing the successful transfers whose payload is a memo of valid UTF-8 up to
+		// 256 bytes by recipient and memo, the history is indexed when the node starts if enabled the first time
+		EnableMemoIndexer bool `yaml:"enableMemoIndexer"`
+		// EnableBlockTimeIndexer enables indexing the heights by block time, the history is indexed when the node
+		// starts if enabled the first time
+		EnableBlockTimeIndexer bool `yaml:"enableBlockTimeIndexer"`
+		// ContractStatsMinDailyCalls is the number of calls in a day below which a contract is folded into the
+		// bucket of other contracts, once the day is older than yesterday
+		ContractStatsMinDailyCalls uint64 `yaml:"contractStatsMinDailyCalls"`
 		// AllowedBlockGasResidue is the amount of gas remained when block producer could stop processing more actions
 		AllowedBlockGasResidue uint64 `yaml:"allowedBlockGasResidue"`
 		// MaxCacheSize is the max number of blocks that will be put into an LRU cache. 0 means disabled
@@ -64,54 +114,97 @@ type (
 		PollInitialCandidatesInterval time.Duration `yaml:"pollInitialCandidatesInterval"`
 		// StateDBCacheSize is the max size of statedb LRU cache
 		StateDBCacheSize int `yaml:"stateDBCacheSize"`
-		// WorkingSetCacheSize is the max size of workingset cache in state factory
+		// WorkingSetCacheSize is the max size of workingset cache in state factory, which keeps the workingsets of the
+		// proposals validated at the next height to be reused at commit
 		WorkingSetCacheSize uint64 `yaml:"workingSetCacheSize"`
+		// SnapshotDir is the directory where the state snapshots are exported into
+		SnapshotDir string `yaml:"snapshotDir"`
+		// SnapshotRateLimit is the max bytes of states read per second when exporting a snapshot. 0 means unlimited
+		SnapshotRateLimit int `yaml:"snapshotRateLimit"`
+		// BackupDir is the directory where the online backups of the dbs are taken into
+		BackupDir string `yaml:"backupDir"`
+		// TrieNodeCacheSize is the max bytes of decoded trie nodes cached in state factory. 0 means disabled
+		TrieNodeCacheSize uint64 `yaml:"trieNodeCacheSize"`
 		// StreamingBlockBufferSize
 		StreamingBlockBufferSize uint64 `yaml:"streamingBlockBufferSize"`
 		// PersistStakingPatchBlock is the block to persist staking patch
 		PersistStakingPatchBlock uint64 `yaml:"persistStakingPatchBlock"`
 		// FactoryDBType is the type of factory db
 		FactoryDBType string `yaml:"factoryDBType"`
+		// IndexDBType is the type of the indexer dbs, including the contract staking indexer db
+		IndexDBType string `yaml:"indexDBType"`
+		// ShutdownCommitTimeout is the longest time the shutdown waits for the block being committed
+		ShutdownCommitTimeout time.Duration `yaml:"shutdownCommitTimeout"`
+		// MaxBlockTimeDrift is the most a block timestamp may be ahead of the local clock to pass the validation,
+		// once the block timestamp validation is activated
+		MaxBlockTimeDrift time.Duration `yaml:"maxBlockTimeDrift"`
 	}
 )
 
 var (
 	// DefaultConfig is the default config of chain
 	DefaultConfig = Config{
-		ChainDBPath:                "/var/data/chain.db",
-		TrieDBPatchFile:            "/var/data/trie.db.patch",
-		TrieDBPath:                 "/var/data/trie.db",
-		StakingPatchDir:            "/var/data",
-		IndexDBPath:                "/var/data/index.db",
-		BloomfilterIndexDBPath:     "/var/data/bloomfilter.index.db",
-		CandidateIndexDBPath:       "/var/data/candidate.index.db",
-		StakingIndexDBPath:         "/var/data/staking.index.db",
-		ContractStakingIndexDBPath: "/var/data/contractstaking.index.db",
-		ID:                         1,
-		EVMNetworkID:               4689,
-		Address:                    "",
-		ProducerPrivKey:            generateRandomKey(SigP256k1),
-		SignatureScheme:            []string{SigP256k1},
-		EmptyGenesis:               false,
-		GravityChainDB:             db.Config{DbPath: "/var/data/poll.db", NumRetries: 10},
+		ChainDBPath:                 "/var/data/chain.db",
+		TrieDBPatchFile:             "/var/data/trie.db.patch",
+		TrieDBPath:                  "/var/data/trie.db",
+		StakingPatchDir:             "/var/data",
+		IndexDBPath:                 "/var/data/index.db",
+		BloomfilterIndexDBPath:      "/var/data/bloomfilter.index.db",
+		CandidateIndexDBPath:        "/var/data/candidate.index.db",
+		StakingIndexDBPath:          "/var/data/staking.index.db",
+		ContractStakingIndexDBPath:  "/var/data/contractstaking.index.db",
+		TokenTransferIndexDBPath:    "/var/data/tokentransfer.index.db",
+		CandidateHistoryIndexDBPath: "/var/data/candidatehistory.index.db",
+		SystemActionIndexDBPath:     "/var/data/systemaction.index.db",
+		ContractStatsIndexDBPath:    "/var/data/contractstats.index.db",
+		MemoIndexDBPath:             "/var/data/memo.index.db",
+		BlockTimeIndexDBPath:        "/var/data/blocktime.index.db",
+		ID:                          1,
+		EVMNetworkID:                4689,
+		Address:                     "",
+		ProducerPrivKey:             generateRandomKey(SigP256k1),
+		ProducerSigner:              signer.DefaultConfig,
+		SignatureScheme:             []string{SigP256k1},
+		EmptyGenesis:                false,
+		GravityChainDB:              db.Config{DbPath: "/var/data/poll.db", NumRetries: 10},
 		Committee: committee.Config{
 			GravityChainAPIs: []string{},
 		},
 		EnableTrielessStateDB:         true,
 		EnableStateDBCaching:          false,
 		EnableArchiveMode:             false,
+		EnableHistoryStateRetention:   false,
+		HistoryStateRetention:         120960, // a week of 5s blocks
 		EnableAsyncIndexWrite:         true,
+		EnableGroupCommit:             false,
+		EnableParallelExecution:       false,
+		VerifyProtocolViewOnStart:     "",
+		VerifyProtocolViewAtEpoch:     false,
 		EnableSystemLogIndexer:        false,
 		EnableStakingProtocol:         true,
 		EnableStakingIndexer:          false,
+		EnableTokenTransferIndexer:    false,
+		EnableCandidateHistoryIndexer: false,
+		EnableSystemActionIndexer:     false,
+		EnableContractStatsIndexer:    false,
+		EnableMemoIndexer:             false,
+		EnableBlockTimeIndexer:        false,
+		ContractStatsMinDailyCalls:    10,
 		AllowedBlockGasResidue:        10000,
 		MaxCacheSize:                  0,
 		PollInitialCandidatesInterval: 10 * time.Second,
 		StateDBCacheSize:              1000,
-		WorkingSetCacheSize:           20,
+		WorkingSetCacheSize:           2,
+		TrieNodeCacheSize:             64 << 20,
+		SnapshotDir:                   "/var/data/snapshot",
+		SnapshotRateLimit:             16 << 20,
+		BackupDir:                     "/var/data/backup",
 		StreamingBlockBufferSize:      200,
 		PersistStakingPatchBlock:      19778037,
 		FactoryDBType:                 db.DBBolt,
+		IndexDBType:                   db.DBBolt,
+		ShutdownCommitTimeout:         30 * time.Second,
+		MaxBlockTimeDrift:             10 * time.Second,
 	}
 
 	// ErrConfig config error
@@ -120,14 +213,34 @@ var (
 
 // ProducerAddress returns the configured producer address derived from key
 func (cfg *Config) ProducerAddress() address.Address {
-	sk := cfg.ProducerPrivateKey()
-	addr := sk.PublicKey().Address()
+	addr := cfg.ProducerPublicKey().Address()
 	if addr == nil {
 		log.L().Panic("Error when constructing producer address")
 	}
 	return addr
 }
 
+// ProducerPublicKey returns the public key of the producer signer, or of the configured private key
+func (cfg *Config) ProducerPublicKey() crypto.PublicKey {
+	if cfg.ProducerSigner.Type == "" {
+		return cfg.ProducerPrivateKey().PublicKey()
+	}
+	pk, err := crypto.HexStringToPublicKey(cfg.ProducerSigner.PublicKey)
+	if err != nil {
+		log.L().Panic("Error when decoding producer public key", zap.Error(err))
+	}
+	return pk
+}
+
+// NewProducerSigner creates the signer of the producer, which is the configured private key unless the producer
+// signer is set
+func (cfg *Config) NewProducerSigner() (cp.Signer, error) {
+	if cfg.ProducerSigner.Type == "" {
+		return cfg.ProducerPrivateKey(), nil
+	}
+	return signer.New(cfg.ProducerSigner)
+}
+
 // ProducerPrivateKey returns the configured private key
 func (cfg *Config) ProducerPrivateKey() crypto.PrivateKey {
 	sk, err := crypto.HexStringToPrivateKey(cfg.ProducerPrivKey)
@@ -8,6 +8,7 @@ package filedao
 import (
 	"context"
 	"fmt"
+	"os"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -69,6 +70,37 @@ type (
 		FooterByHeight(uint64) (*block.Footer, error)
 	}
 
+	// FileDAOWithBackup is FileDAO whose files could be backed up online
+	FileDAOWithBackup interface {
+		FileDAO
+		// Files returns the indices of all the files in order, see FileName for the path of each file, and the
+		// kvstore of the last file, which is the only file written by new blocks
+		Files() ([]uint64, db.KVStoreWithBackup, error)
+	}
+
+	// transactionLogRanger is the db file reading the transaction logs of consecutive blocks at once
+	transactionLogRanger interface {
+		BaseFileDAO
+		ContainsHeight(uint64) bool
+		TransactionLogsInRange(uint64, uint64) ([]*iotextypes.TransactionLogs, error)
+	}
+
+	// blockStoreReader is the db file reading the stored protos of a block without decoding them
+	blockStoreReader interface {
+		BaseFileDAO
+		ContainsHeight(uint64) bool
+		BlockStore(uint64) (*iotextypes.BlockStore, error)
+	}
+
+	// logDigestReader is the db file keeping the digest of the logs in each block, and reading a part of the
+	// receipts of a block
+	logDigestReader interface {
+		BaseFileDAO
+		ContainsHeight(uint64) bool
+		LogDigest(uint64) (*block.LogDigest, error)
+		ReceiptsAt(uint64, []uint32) ([]*action.Receipt, error)
+	}
+
 	// fileDAO implements FileDAO
 	fileDAO struct {
 		lock              sync.Mutex
@@ -111,6 +143,40 @@ func NewFileDAO(cfg db.Config, deser *block.Deserializer) (FileDAO, error) {
 	}
 }
 
+// InitFileDAOWithBlock creates a new chain db starting from blk instead of genesis, which is used to
+// bootstrap a node from the state snapshot at the height of blk
+func InitFileDAOWithBlock(cfg db.Config, deser *block.Deserializer, blk *block.Block) error {
+	if _, err := readFileHeader(cfg.DbPath, FileAll); err != ErrFileNotExist {
+		if err == nil {
+			return errors.Wrapf(ErrAlreadyExist, "chain db %s", cfg.DbPath)
+		}
+		return err
+	}
+	if err := createNewV2File(blk.Height(), cfg, deser); err != nil {
+		return err
+	}
+	fd, err := NewFileDAO(cfg, deser)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	if err := fd.Start(ctx); err != nil {
+		return err
+	}
+	if err := fd.PutBlock(ctx, blk); err != nil {
+		return err
+	}
+	return fd.Stop(ctx)
+}
+
+// FileName returns the path of the k-th file of the chain db in path, the 0-th file is the master file
+func FileName(path string, k uint64) string {
+	if k == 0 {
+		return path
+	}
+	return kthAuxFileName(path, k)
+}
+
 // NewFileDAOInMemForTest creates an in-memory FileDAO for testing
 func NewFileDAOInMemForTest() (FileDAO, error) {
 	return newTestInMemFd()
@@ -294,6 +360,76 @@ func (fd *fileDAO) TransactionLogs(height uint64) (*iotextypes.TransactionLogs,
 	return nil, ErrNotSupported
 }
 
+// TransactionLogsInRange returns the transaction logs of count blocks from start, which are read at once from each
+// v2 db file. The logs of a block not in the db are nil, and the logs of a block without transaction are empty
+func (fd *fileDAO) TransactionLogsInRange(start, count uint64) ([]*iotextypes.TransactionLogs, error) {
+	logs := make([]*iotextypes.TransactionLogs, 0, count)
+	for height, end := start, start+count; height < end; {
+		if fd.v2Fd != nil {
+			if v2, ok := fd.v2Fd.FileDAOByHeight(height).(transactionLogRanger); ok && v2.ContainsHeight(height) {
+				tip, err := v2.Height()
+				if err != nil {
+					return nil, err
+				}
+				n := min(end, tip+1) - height
+				v2Logs, err := v2.TransactionLogsInRange(height, n)
+				if err != nil {
+					return nil, err
+				}
+				logs = append(logs, v2Logs...)
+				height += n
+				continue
+			}
+		}
+		l, err := fd.TransactionLogs(height)
+		switch errors.Cause(err) {
+		case nil:
+		case db.ErrNotExist:
+			l = &iotextypes.TransactionLogs{}
+		case ErrNotSupported:
+			l = nil
+		default:
+			return nil, err
+		}
+		logs = append(logs, l)
+		height++
+	}
+	return logs, nil
+}
+
+// BlockStore returns the stored protos of the block at the height, which are read without decoding from the v2 db
+// files. The blocks in the legacy db are not supported
+func (fd *fileDAO) BlockStore(height uint64) (*iotextypes.BlockStore, error) {
+	if fd.v2Fd != nil {
+		if v2, ok := fd.v2Fd.FileDAOByHeight(height).(blockStoreReader); ok && v2.ContainsHeight(height) {
+			return v2.BlockStore(height)
+		}
+	}
+	return nil, ErrNotSupported
+}
+
+// LogDigest returns the digest of the logs in the block at the height, which is kept in the v2 db files. The blocks
+// in the legacy db are not supported
+func (fd *fileDAO) LogDigest(height uint64) (*block.LogDigest, error) {
+	if fd.v2Fd != nil {
+		if v2, ok := fd.v2Fd.FileDAOByHeight(height).(logDigestReader); ok && v2.ContainsHeight(height) {
+			return v2.LogDigest(height)
+		}
+	}
+	return nil, ErrNotSupported
+}
+
+// ReceiptsAt returns the receipts at the indices of the block at the height, only which are decoded from the v2 db
+// files. The blocks in the legacy db are not supported
+func (fd *fileDAO) ReceiptsAt(height uint64, indices []uint32) ([]*action.Receipt, error) {
+	if fd.v2Fd != nil {
+		if v2, ok := fd.v2Fd.FileDAOByHeight(height).(logDigestReader); ok && v2.ContainsHeight(height) {
+			return v2.ReceiptsAt(height, indices)
+		}
+	}
+	return nil, ErrNotSupported
+}
+
 func (fd *fileDAO) PutBlock(ctx context.Context, blk *block.Block) error {
 	// bail out if block already exists
 	h := blk.HashBlock()
@@ -366,6 +502,32 @@ func (fd *fileDAO) DeleteTipBlock() error {
 	return fd.currFd.DeleteTipBlock()
 }
 
+func (fd *fileDAO) Files() ([]uint64, db.KVStoreWithBackup, error) {
+	fd.lock.Lock()
+	defer fd.lock.Unlock()
+
+	top, ok := fd.currFd.(*fileDAOv2)
+	if !ok {
+		// a legacy file dao writes both the master file and the top auxiliary file
+		return nil, nil, errors.Wrap(ErrNotSupported, "cannot back up legacy chain db being written")
+	}
+	kvStore, ok := top.kvStore.(db.KVStoreWithBackup)
+	if !ok {
+		return nil, nil, errors.Wrap(ErrNotSupported, "chain db doesn't support backup")
+	}
+	indices := []uint64{0}
+	for k := uint64(1); k <= fd.topIndex; k++ {
+		if _, err := os.Stat(kthAuxFileName(fd.cfg.DbPath, k)); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, nil, err
+		}
+		indices = append(indices, k)
+	}
+	return indices, kvStore, nil
+}
+
 // CreateFileDAO creates FileDAO according to master file
 func CreateFileDAO(legacy bool, cfg db.Config, deser *block.Deserializer) (FileDAO, error) {
 	fd := fileDAO{splitHeight: 1, cfg: cfg, blockDeserializer: deser}
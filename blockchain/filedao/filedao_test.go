@@ -13,6 +13,7 @@ import (
 
 	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/blockchain/block"
@@ -174,6 +175,13 @@ func TestNewFileDAOSplitV2(t *testing.T) {
 	r.EqualValues(2, fm.topIndex)
 	r.EqualValues(21, fm.splitHeight)
 	testVerifyChainDB(t, fd, 1, 25)
+	// only the top file is written by new blocks
+	indices, kvStore, err := fm.Files()
+	r.NoError(err)
+	r.Equal([]uint64{0, 1, 2}, indices)
+	r.Equal(fm.currFd.(*fileDAOv2).kvStore, kvStore)
+	r.Equal(cfg.DbPath, FileName(cfg.DbPath, 0))
+	r.Equal(kthAuxFileName(cfg.DbPath, 2), FileName(cfg.DbPath, 2))
 	r.NoError(fd.Stop(ctx))
 	top, files := checkAuxFiles(cfg.DbPath, FileV2)
 	r.EqualValues(2, top)
@@ -186,6 +194,32 @@ func TestNewFileDAOSplitV2(t *testing.T) {
 	os.RemoveAll(file2)
 }
 
+func TestInitFileDAOWithBlock(t *testing.T) {
+	r := require.New(t)
+
+	cfg := db.DefaultConfig
+	cfg.DbPath = "./filedao_snapshot.db"
+	defer os.RemoveAll(cfg.DbPath)
+
+	deser := block.NewDeserializer(_defaultEVMNetworkID)
+	blk := createTestingBlock(block.NewTestingBuilder(), 100, hash.ZeroHash256)
+	r.NoError(InitFileDAOWithBlock(cfg, deser, blk))
+	r.Equal(ErrAlreadyExist, errors.Cause(InitFileDAOWithBlock(cfg, deser, blk)))
+
+	fd, err := NewFileDAO(cfg, deser)
+	r.NoError(err)
+	ctx := context.Background()
+	r.NoError(fd.Start(ctx))
+	defer fd.Stop(ctx)
+	testVerifyChainDB(t, fd, 100, 100)
+	_, err = fd.GetBlockByHeight(99)
+	r.Error(err)
+	// blocks are appended after the snapshot height
+	r.Equal(ErrInvalidTipHeight, testCommitBlocks(t, fd, 102, 102, blk.HashBlock()))
+	r.NoError(testCommitBlocks(t, fd, 101, 110, blk.HashBlock()))
+	testVerifyChainDB(t, fd, 100, 110)
+}
+
 func TestNewFileDAOSplitLegacy(t *testing.T) {
 	r := require.New(t)
 
@@ -217,6 +251,8 @@ func TestNewFileDAOSplitLegacy(t *testing.T) {
 	fm := fd.(*fileDAO)
 	r.EqualValues(1, fm.topIndex)
 	r.EqualValues(1, fm.splitHeight)
+	_, _, err = fm.Files()
+	r.Equal(ErrNotSupported, errors.Cause(err))
 	r.NoError(testCommitBlocks(t, fd, 11, 28, hash.ZeroHash256))
 	r.EqualValues(2, fm.topIndex)
 	r.EqualValues(16, fm.splitHeight)
@@ -226,6 +262,9 @@ func TestNewFileDAOSplitLegacy(t *testing.T) {
 	r.EqualValues(4, fm.topIndex)
 	r.EqualValues(46, fm.splitHeight)
 	testVerifyChainDB(t, fd, 1, 55)
+	indices, _, err := fm.Files()
+	r.NoError(err)
+	r.Equal([]uint64{0, 1, 2, 3, 4}, indices)
 	r.NoError(fd.Stop(ctx))
 
 	// now we should have:
@@ -11,6 +11,7 @@ import (
 	"unsafe"
 
 	"github.com/pkg/errors"
+	"go.uber.org/zap"
 
 	"github.com/iotexproject/go-pkgs/cache"
 	"github.com/iotexproject/go-pkgs/hash"
@@ -20,6 +21,7 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 )
 
@@ -28,6 +30,7 @@ const (
 	_hashDataNS   = "hsh"
 	_blockDataNS  = "bdn"
 	_headerDataNs = "hdr"
+	_logDigestNS  = "ldg"
 )
 
 var (
@@ -220,6 +223,84 @@ func (fd *fileDAOv2) TransactionLogs(height uint64) (*iotextypes.TransactionLogs
 	return block.DeserializeSystemLogPb(value)
 }
 
+// TransactionLogsInRange returns the transaction logs of count blocks from start, which are stored one entry per
+// block and read at once
+func (fd *fileDAOv2) TransactionLogsInRange(start, count uint64) ([]*iotextypes.TransactionLogs, error) {
+	if !fd.ContainsHeight(start) || !fd.ContainsHeight(start+count-1) {
+		return nil, ErrNotSupported
+	}
+
+	values, err := fd.sysStore.Range(start-fd.header.Start, count)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get transaction logs from height %d", start)
+	}
+	logs := make([]*iotextypes.TransactionLogs, len(values))
+	for i, value := range values {
+		if value, err = decompBytes(value, fd.header.Compressor); err != nil {
+			return nil, errors.Wrapf(err, "failed to get transaction log at height %d", start+uint64(i))
+		}
+		if logs[i], err = block.DeserializeSystemLogPb(value); err != nil {
+			return nil, errors.Wrapf(err, "failed to get transaction log at height %d", start+uint64(i))
+		}
+	}
+	return logs, nil
+}
+
+// BlockStore returns the stored protos of the block and its receipts at the height, without decoding them into the
+// block, so the serialized parts are the same as the ones hashed into the header
+func (fd *fileDAOv2) BlockStore(height uint64) (*iotextypes.BlockStore, error) {
+	if !fd.ContainsHeight(height) {
+		return nil, db.ErrNotExist
+	}
+	if blockStoreKey(height, fd.header) >= fd.blkStore.Size() {
+		blkStore, err := fd.blkBuffer.Get(stagingKey(height, fd.header))
+		if err != nil {
+			return nil, err
+		}
+		return blkStore.ToProto(), nil
+	}
+	blkStore, err := fd.getBlockStore(height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get block store at height %d", height)
+	}
+	return blkStore, nil
+}
+
+// LogDigest returns the digest of the logs in the block at the height. The digest is written along with the block,
+// and the digest of a block stored before is built from its receipts on the first read and stored for later reads
+func (fd *fileDAOv2) LogDigest(height uint64) (*block.LogDigest, error) {
+	if !fd.ContainsHeight(height) {
+		return nil, db.ErrNotExist
+	}
+	key := byteutil.Uint64ToBytesBigEndian(height)
+	value, err := fd.kvStore.Get(_logDigestNS, key)
+	switch errors.Cause(err) {
+	case nil:
+		return block.DeserializeLogDigest(value)
+	case db.ErrNotExist, db.ErrBucketNotExist:
+	default:
+		return nil, errors.Wrapf(err, "failed to get log digest at height %d", height)
+	}
+	receipts, err := fd.getReceipt(height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get receipts at height %d", height)
+	}
+	digest := block.NewLogDigest(receipts)
+	if err := fd.kvStore.Put(_logDigestNS, key, digest.Serialize()); err != nil {
+		log.L().Warn("Failed to backfill log digest.", zap.Uint64("height", height), zap.Error(err))
+	}
+	return digest, nil
+}
+
+// ReceiptsAt returns the receipts at the indices of the block at the height, only which are decoded
+func (fd *fileDAOv2) ReceiptsAt(height uint64, indices []uint32) ([]*action.Receipt, error) {
+	receipts, err := fd.getReceiptsAt(height, indices)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get receipts at height %d", height)
+	}
+	return receipts, nil
+}
+
 func (fd *fileDAOv2) PutBlock(_ context.Context, blk *block.Block) error {
 	tip := fd.loadTip()
 	if blk.Height() != tip.Height+1 {
@@ -241,6 +322,9 @@ func (fd *fileDAOv2) PutBlock(_ context.Context, blk *block.Block) error {
 		return errors.Wrap(err, "failed to write receipt")
 	}
 
+	// write log digest in the same batch as the receipts
+	fd.putLogDigest(blk)
+
 	if err := fd.kvStore.WriteBatch(fd.batch); err != nil {
 		return errors.Wrapf(err, "failed to put block at height %d", blk.Height())
 	}
@@ -277,6 +361,8 @@ func (fd *fileDAOv2) DeleteTipBlock() error {
 
 	// delete hash -> height mapping
 	fd.batch.Delete(_blockHashHeightMappingNS, hashKey(tip.Hash), "failed to delete hash -> height mapping")
+	// delete log digest
+	fd.batch.Delete(_logDigestNS, byteutil.Uint64ToBytesBigEndian(height), "failed to delete log digest")
 
 	// update file tip
 	var (
@@ -18,10 +18,13 @@ import (
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
+	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/pkg/compress"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/test/identityset"
 	"github.com/iotexproject/iotex-core/testutil"
 )
 
@@ -349,3 +352,145 @@ func TestNewFdStart(t *testing.T) {
 		}
 	}
 }
+
+func TestFileDAOv2LogDigest(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	fd, err := newFileDAOv2InMem(1)
+	r.NoError(err)
+	r.NoError(fd.Start(ctx))
+	defer fd.Stop(ctx)
+
+	// the blocks beyond the staging buffer are packed into the block storage
+	var (
+		builder = block.NewTestingBuilder()
+		h       = hash.ZeroHash256
+		blks    []*block.Block
+	)
+	for i := uint64(1); i <= 20; i++ {
+		blk := createTestingBlockWithLogs(builder, i, h, 8)
+		r.NoError(fd.PutBlock(ctx, blk))
+		h = blk.HashBlock()
+		blks = append(blks, blk)
+	}
+	r.Equal(uint64(1), fd.blkStore.Size())
+	for _, blk := range blks {
+		height := blk.Height()
+		digest, err := fd.LogDigest(height)
+		r.NoError(err)
+		r.Equal(block.NewLogDigest(blk.Receipts), digest)
+		receipts, err := fd.ReceiptsAt(height, []uint32{1, 5})
+		r.NoError(err)
+		r.Len(receipts, 2)
+		r.Equal(blk.Receipts[1].Hash(), receipts[0].Hash())
+		r.Equal(blk.Receipts[5].Hash(), receipts[1].Hash())
+		_, err = fd.ReceiptsAt(height, []uint32{8})
+		r.Equal(ErrDataCorruption, errors.Cause(err))
+	}
+	_, err = fd.LogDigest(21)
+	r.Equal(db.ErrNotExist, errors.Cause(err))
+
+	t.Run("Backfill", func(t *testing.T) {
+		// the digest of a block stored before is built from its receipts on the first read
+		key := byteutil.Uint64ToBytesBigEndian(3)
+		r.NoError(fd.kvStore.Delete(_logDigestNS, key))
+		digest, err := fd.LogDigest(3)
+		r.NoError(err)
+		r.Equal(block.NewLogDigest(blks[2].Receipts), digest)
+		v, err := fd.kvStore.Get(_logDigestNS, key)
+		r.NoError(err)
+		r.Equal(digest.Serialize(), v)
+	})
+
+	t.Run("DeleteTipBlock", func(t *testing.T) {
+		r.NoError(fd.DeleteTipBlock())
+		_, err := fd.kvStore.Get(_logDigestNS, byteutil.Uint64ToBytesBigEndian(20))
+		r.Equal(db.ErrNotExist, errors.Cause(err))
+	})
+}
+
+func BenchmarkLogQuery(b *testing.B) {
+	r := require.New(b)
+	ctx := context.Background()
+	fd, err := newFileDAOv2InMem(1)
+	r.NoError(err)
+	r.NoError(fd.Start(ctx))
+	defer fd.Stop(ctx)
+
+	// dense blocks of 500 receipts each, and the topic queried is emitted by a single receipt in a block
+	var (
+		builder = block.NewTestingBuilder()
+		h       = hash.ZeroHash256
+		height  = uint64(17)
+		target  = hash.Hash256b([]byte("target"))
+		match   = func(receipts []*action.Receipt) int {
+			n := 0
+			for _, receipt := range receipts {
+				for _, l := range receipt.Logs() {
+					if l.Topics[0] == target {
+						n++
+					}
+				}
+			}
+			return n
+		}
+	)
+	for i := uint64(1); i <= height; i++ {
+		blk := createTestingBlockWithLogs(builder, i, h, 500)
+		blk.Receipts[250].Logs()[0].Topics[0] = target
+		r.NoError(fd.PutBlock(ctx, blk))
+		h = blk.HashBlock()
+	}
+	// the block read is packed in the block storage, and its protos are cached
+	r.Equal(uint64(1), fd.blkStore.Size())
+
+	b.Run("Receipts", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			receipts, err := fd.GetReceipts(1)
+			r.NoError(err)
+			r.Equal(1, match(receipts))
+		}
+	})
+	b.Run("LogDigest", func(b *testing.B) {
+		fp := block.TopicFingerprint(target)
+		for i := 0; i < b.N; i++ {
+			digest, err := fd.LogDigest(1)
+			r.NoError(err)
+			var indices []uint32
+			for _, rd := range digest.Receipts {
+				for _, l := range rd.Logs {
+					if l.Topics[0] == fp {
+						indices = append(indices, rd.Index)
+						break
+					}
+				}
+			}
+			receipts, err := fd.ReceiptsAt(1, indices)
+			r.NoError(err)
+			r.Equal(1, match(receipts))
+		}
+	})
+}
+
+func createTestingBlockWithLogs(builder *block.TestingBuilder, height uint64, h hash.Hash256, n int) *block.Block {
+	block.LoadGenesisHash(&genesis.Default)
+	receipts := make([]*action.Receipt, n)
+	for i := range receipts {
+		receipts[i] = (&action.Receipt{
+			Status:      1,
+			BlockHeight: height,
+			ActionHash:  hash.Hash256b(byteutil.Uint64ToBytes(height*uint64(n) + uint64(i))),
+		}).AddLogs(&action.Log{
+			Address: identityset.Address(i % 10).String(),
+			Topics:  action.Topics{hash.Hash256b(byteutil.Uint64ToBytes(uint64(i))), h},
+			Data:    h[:],
+		})
+	}
+	blk, _ := builder.
+		SetHeight(height).
+		SetPrevBlockHash(h).
+		SetReceipts(receipts).
+		SetTimeStamp(testutil.TimestampNow().UTC()).
+		SignAndBuild(identityset.PrivateKey(27))
+	return &blk
+}
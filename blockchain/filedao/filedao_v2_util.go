@@ -107,6 +107,11 @@ func (fd *fileDAOv2) putBlock(blk *block.Block) error {
 	return addOneEntryToBatch(fd.blkStore, blkBytes, fd.batch)
 }
 
+func (fd *fileDAOv2) putLogDigest(blk *block.Block) {
+	digest := block.NewLogDigest(blk.Receipts)
+	fd.batch.Put(_logDigestNS, byteutil.Uint64ToBytesBigEndian(blk.Height()), digest.Serialize(), "failed to put log digest")
+}
+
 func (fd *fileDAOv2) putTransactionLog(blk *block.Block) error {
 	sysLog := blk.TransactionLog()
 	if sysLog == nil {
@@ -215,6 +220,41 @@ func (fd *fileDAOv2) getReceipt(height uint64) ([]*action.Receipt, error) {
 	return fd.deser.ReceiptsFromBlockStoreProto(blockStore)
 }
 
+func (fd *fileDAOv2) getReceiptsAt(height uint64, indices []uint32) ([]*action.Receipt, error) {
+	if !fd.ContainsHeight(height) {
+		return nil, db.ErrNotExist
+	}
+	receipts := make([]*action.Receipt, len(indices))
+	// check whether block in staging buffer or not
+	storeKey := blockStoreKey(height, fd.header)
+	if storeKey >= fd.blkStore.Size() {
+		blkStore, err := fd.blkBuffer.Get(stagingKey(height, fd.header))
+		if err != nil {
+			return nil, err
+		}
+		for i, idx := range indices {
+			if int(idx) >= len(blkStore.Receipts) {
+				return nil, errors.Wrapf(ErrDataCorruption, "receipt %d of %d", idx, len(blkStore.Receipts))
+			}
+			receipts[i] = blkStore.Receipts[idx]
+		}
+		return receipts, nil
+	}
+	// read from storage DB, only the receipts at the indices are decoded
+	blockStore, err := fd.getBlockStore(height)
+	if err != nil {
+		return nil, err
+	}
+	for i, idx := range indices {
+		if int(idx) >= len(blockStore.Receipts) {
+			return nil, errors.Wrapf(ErrDataCorruption, "receipt %d of %d", idx, len(blockStore.Receipts))
+		}
+		receipts[i] = &action.Receipt{}
+		receipts[i].ConvertFromReceiptPb(blockStore.Receipts[idx])
+	}
+	return receipts, nil
+}
+
 func (fd *fileDAOv2) getBlockStore(height uint64) (*iotextypes.BlockStore, error) {
 	// check whether blockStore in read cache or not
 	storeKey := blockStoreKey(height, fd.header)
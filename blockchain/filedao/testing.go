@@ -13,6 +13,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/iotexproject/go-pkgs/cache"
 	"github.com/iotexproject/go-pkgs/hash"
@@ -221,6 +222,39 @@ func testVerifyChainDB(t *testing.T, fd FileDAO, start, end uint64) {
 			r.Equal(ErrNotSupported, err)
 		}
 	}
+
+	// the logs read in range are the same as the ones read block by block
+	if ranger, ok := fd.(interface {
+		TransactionLogsInRange(uint64, uint64) ([]*iotextypes.TransactionLogs, error)
+	}); ok {
+		logs, err := ranger.TransactionLogsInRange(start, end-start+1)
+		r.NoError(err)
+		r.Len(logs, int(end-start+1))
+		for i, l := range logs {
+			expected, err := fd.TransactionLogs(start + uint64(i))
+			r.NoError(err)
+			r.True(proto.Equal(expected, l))
+		}
+	}
+
+	// the stored protos are the same as the ones of the blocks, except the ones in the legacy file
+	if reader, ok := fd.(interface {
+		BlockStore(uint64) (*iotextypes.BlockStore, error)
+	}); ok {
+		for i := start; i <= end; i++ {
+			store, err := reader.BlockStore(i)
+			if errors.Cause(err) == ErrNotSupported {
+				continue
+			}
+			r.NoError(err)
+			blk, err := fd.GetBlockByHeight(i)
+			r.NoError(err)
+			r.True(proto.Equal(blk.ConvertToBlockPb(), store.Block))
+			receipts, err := fd.GetReceipts(i)
+			r.NoError(err)
+			r.Len(store.Receipts, len(receipts))
+		}
+	}
 }
 
 func createTestingBlock(builder *block.TestingBuilder, height uint64, h hash.Hash256) *block.Block {
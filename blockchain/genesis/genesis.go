@@ -47,6 +47,8 @@ func defaultConfig() Genesis {
 			BlockGasLimit:           20000000,
 			TsunamiBlockGasLimit:    50000000,
 			ActionGasLimit:          5000000,
+			SystemCallGasBudget:     10000000,
+			SystemCallProtocols:     []string{"staking"},
 			BlockInterval:           10 * time.Second,
 			NumSubEpochs:            2,
 			DardanellesNumSubEpochs: 30,
@@ -78,11 +80,14 @@ func defaultConfig() Genesis {
 			TsunamiBlockHeight:      29275561,
 			UpernavikBlockHeight:    31174201,
 			VanuatuBlockHeight:      41174201,
+			WakeBlockHeight:         math.MaxUint64,
 			ToBeEnabledBlockHeight:  math.MaxUint64,
 		},
 		Account: Account{
 			InitBalanceMap:          make(map[string]string),
 			ReplayDeployerWhitelist: []string{"0x3fab184622dc19b6109349b94811493bf2a45362"},
+			TimeLockMinAmountStr:    unit.ConvertIotxToRau(1).String(),
+			MaxTimeLocks:            64,
 		},
 		Poll: Poll{
 			PollMode:                         "nativeMix",
@@ -124,6 +129,12 @@ func defaultConfig() Genesis {
 			MinStakeAmount:                   unit.ConvertIotxToRau(100).String(),
 			BootstrapCandidates:              []BootstrapCandidate{},
 			EndorsementWithdrawWaitingBlocks: 24 * 60 * 60 / 5,
+			TransferLockDelayEpochs:          24,
+			RewardAddressDelayEpochs:         24,
+			MisbehaviorSlashRate:             10,
+			MisbehaviorBountyRate:            10,
+			MisbehaviorProbationEpochs:       24,
+			MisbehaviorProbationIntensity:    90,
 		},
 	}
 }
@@ -171,6 +182,11 @@ type (
 		TsunamiBlockGasLimit uint64 `yaml:"tsunamiBlockGasLimit"`
 		// ActionGasLimit is the per action gas limit cap
 		ActionGasLimit uint64 `yaml:"actionGasLimit"`
+		// SystemCallGasBudget is the gas budget of a block for the contract calls initiated by the protocols, which
+		// is accounted apart from the gas of the actions
+		SystemCallGasBudget uint64 `yaml:"systemCallGasBudget"`
+		// SystemCallProtocols are the protocols allowed to initiate contract calls on the system call gas budget
+		SystemCallProtocols []string `yaml:"systemCallProtocols"`
 		// BlockInterval is the interval between two blocks
 		BlockInterval time.Duration `yaml:"blockInterval"`
 		// NumSubEpochs is the number of sub epochs in one epoch of block production
@@ -270,9 +286,13 @@ type (
 		// 6. add address in claim reward action
 		UpernavikBlockHeight uint64 `yaml:"upernavikHeight"`
 		// VanuatuBlockHeight is the start height to
-		// 1. enable Cancun EVM
-		// 2. enable dynamic fee tx
+		// 1. enable dynamic fee tx
 		VanuatuBlockHeight uint64 `yaml:"vanuatuHeight"`
+		// WakeBlockHeight is the start height to
+		// 1. enable Cancun EVM, SELFDESTRUCT only deletes the contract created in the same transaction (EIP-6780),
+		//    add transient storage TSTORE/TLOAD (EIP-1153) and MCOPY (EIP-5656)
+		// it is not scheduled on mainnet until a release sets the height
+		WakeBlockHeight uint64 `yaml:"wakeHeight"`
 		// ToBeEnabledBlockHeight is a fake height that acts as a gating factor for WIP features
 		// upon next release, change IsToBeEnabled() to IsNextHeight() for features to be released
 		ToBeEnabledBlockHeight uint64 `yaml:"toBeEnabledHeight"`
@@ -283,6 +303,10 @@ type (
 		InitBalanceMap map[string]string `yaml:"initBalances"`
 		// ReplayDeployerWhitelist is the whitelist address for unprotected (pre-EIP155) transaction
 		ReplayDeployerWhitelist []string `yaml:"replayDeployerWhitelist"`
+		// TimeLockMinAmountStr is the min amount of a time-locked transfer
+		TimeLockMinAmountStr string `yaml:"timeLockMinAmount"`
+		// MaxTimeLocks is the max number of the amounts locked to an account at the same time
+		MaxTimeLocks uint64 `yaml:"maxTimeLocks"`
 	}
 	// Poll contains the configs for poll protocol
 	Poll struct {
@@ -366,6 +390,9 @@ type (
 		FoundationBonusP2EndEpoch uint64 `yaml:"foundationBonusP2EndEpoch"`
 		// ProductivityThreshold is the percentage number that a delegate's productivity needs to reach not to get probation
 		ProductivityThreshold uint64 `yaml:"productivityThreshold"`
+		// RedistributeBaseFee is true if the base fee is deposited into the rewarding fund instead of being burnt, which
+		// takes effect along with the supply tracking
+		RedistributeBaseFee bool `yaml:"redistributeBaseFee"`
 	}
 	// Staking contains the configs for staking protocol
 	Staking struct {
@@ -375,6 +402,22 @@ type (
 		MinStakeAmount                   string               `yaml:"minStakeAmount"`
 		BootstrapCandidates              []BootstrapCandidate `yaml:"bootstrapCandidates"`
 		EndorsementWithdrawWaitingBlocks uint64               `yaml:"endorsementWithdrawWaitingBlocks"`
+		// BootstrapBuckets are the buckets created after the bootstrap candidates, e.g. the ones exported from another
+		// chain to seed a fork with its staking distribution
+		BootstrapBuckets []BootstrapBucket `yaml:"bootstrapBuckets,omitempty"`
+		// TransferLockDelayEpochs is the number of epochs the changes loosening a stake transfer lock wait to take effect
+		TransferLockDelayEpochs uint64 `yaml:"transferLockDelayEpochs"`
+		// RewardAddressDelayEpochs is the number of epochs a change of the reward address of a candidate waits to take effect
+		RewardAddressDelayEpochs uint64 `yaml:"rewardAddressDelayEpochs"`
+		// MisbehaviorSlashRate is the percentage of the self-stake slashed from a delegate signing conflicting consensus messages
+		MisbehaviorSlashRate uint32 `yaml:"misbehaviorSlashRate"`
+		// MisbehaviorBountyRate is the percentage of the slashed amount paid to the reporter of the misbehavior, the rest
+		// goes to the rewarding fund
+		MisbehaviorBountyRate uint32 `yaml:"misbehaviorBountyRate"`
+		// MisbehaviorProbationEpochs is the number of epochs a misbehaving delegate is on probation
+		MisbehaviorProbationEpochs uint64 `yaml:"misbehaviorProbationEpochs"`
+		// MisbehaviorProbationIntensity is the intensity rate of the probation range from [0, 100], where 100 is hard-probation
+		MisbehaviorProbationIntensity uint32 `yaml:"misbehaviorProbationIntensity"`
 	}
 
 	// VoteWeightCalConsts contains the configs for calculating vote weight
@@ -392,11 +435,35 @@ type (
 
 	// BootstrapCandidate is the candidate data need to be provided to bootstrap candidate.
 	BootstrapCandidate struct {
-		OwnerAddress      string `yaml:"ownerAddress"`
-		OperatorAddress   string `yaml:"operatorAddress"`
-		RewardAddress     string `yaml:"rewardAddress"`
-		Name              string `yaml:"name"`
+		OwnerAddress    string `yaml:"ownerAddress"`
+		OperatorAddress string `yaml:"operatorAddress"`
+		RewardAddress   string `yaml:"rewardAddress"`
+		Name            string `yaml:"name"`
+		// SelfStakingTokens is the amount of the 7-day auto-staked self-stake bucket created for the candidate, no
+		// bucket is created if it's 0, the self-stake may then be one of the bootstrap buckets
 		SelfStakingTokens string `yaml:"selfStakingTokens"`
+		// Identifier is the identifier of the candidate if it's different from the owner
+		Identifier string `yaml:"identifier,omitempty"`
+		// Votes are the expected votes of the candidate after the bootstrap buckets are created, the chain refuses
+		// to create the genesis states otherwise. Not checked if empty
+		Votes string `yaml:"votes,omitempty"`
+	}
+
+	// BootstrapBucket is the bucket data need to be provided to bootstrap a bucket
+	BootstrapBucket struct {
+		OwnerAddress string `yaml:"ownerAddress"`
+		// CandidateAddress is the identifier of the candidate the bucket votes for
+		CandidateAddress string `yaml:"candidateAddress"`
+		StakedAmount     string `yaml:"stakedAmount"`
+		// StakedDuration is the staked duration in days
+		StakedDuration uint32 `yaml:"stakedDuration"`
+		AutoStake      bool   `yaml:"autoStake"`
+		// Unstaked is true if the bucket is unstaked at genesis, it doesn't vote then
+		Unstaked bool `yaml:"unstaked,omitempty"`
+		// SelfStake is true if the bucket is the self-stake bucket of its candidate
+		SelfStake bool `yaml:"selfStake,omitempty"`
+		// EndorseExpireHeight is the expire height of the endorsement of the bucket, 0 if it isn't endorsed
+		EndorseExpireHeight uint64 `yaml:"endorseExpireHeight,omitempty"`
 	}
 )
 
@@ -505,6 +572,24 @@ func (g *Genesis) Hash() hash.Hash256 {
 	return hash.Hash256b(b)
 }
 
+// TotalAllocation returns the amount of token allocated in genesis, which is the initial balances of the accounts and
+// the rewarding fund, and the self-stake of the bootstrap candidates
+func (g *Genesis) TotalAllocation() *big.Int {
+	_, balances := g.InitBalances()
+	total := g.Rewarding.InitBalance()
+	for _, balance := range balances {
+		total.Add(total, balance)
+	}
+	for _, bc := range g.BootstrapCandidates {
+		selfStake, ok := new(big.Int).SetString(bc.SelfStakingTokens, 10)
+		if !ok {
+			log.S().Panicf("Error when casting self-stake string %s into big int", bc.SelfStakingTokens)
+		}
+		total.Add(total, selfStake)
+	}
+	return total
+}
+
 func (g *Blockchain) isPost(targetHeight, height uint64) bool {
 	return height >= targetHeight
 }
@@ -634,6 +719,11 @@ func (g *Blockchain) IsVanuatu(height uint64) bool {
 	return g.isPost(g.VanuatuBlockHeight, height)
 }
 
+// IsWake checks whether height is equal to or larger than wake height
+func (g *Blockchain) IsWake(height uint64) bool {
+	return g.isPost(g.WakeBlockHeight, height)
+}
+
 // IsToBeEnabled checks whether height is equal to or larger than toBeEnabled height
 func (g *Blockchain) IsToBeEnabled(height uint64) bool {
 	return g.isPost(g.ToBeEnabledBlockHeight, height)
@@ -693,6 +783,15 @@ func (a *Account) InitBalances() ([]address.Address, []*big.Int) {
 	return addrs, amounts
 }
 
+// TimeLockMinAmount returns the min amount of a time-locked transfer
+func (a *Account) TimeLockMinAmount() *big.Int {
+	val, ok := new(big.Int).SetString(a.TimeLockMinAmountStr, 10)
+	if !ok {
+		log.S().Panicf("Error when casting time lock min amount string %s into big int", a.TimeLockMinAmountStr)
+	}
+	return val
+}
+
 // OperatorAddr is the address of operator
 func (d *Delegate) OperatorAddr() address.Address {
 	addr, err := address.FromString(d.OperatorAddrStr)
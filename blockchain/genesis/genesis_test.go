@@ -89,6 +89,20 @@ func TestAccount_InitBalances(t *testing.T) {
 	require.Equal(InitBalanceMap["io1mflp9m6hcgm2qcghchsdqj3z3eccrnekx9p0ms"], balances[1].Text(10))
 }
 
+func TestTotalAllocation(t *testing.T) {
+	require := require.New(t)
+	g := TestDefault()
+	g.InitBalanceMap = map[string]string{
+		"io1emxf8zzqckhgjde6dqd97ts0y3q496gm3fdrl6": "1",
+		"io1mflp9m6hcgm2qcghchsdqj3z3eccrnekx9p0ms": "2",
+	}
+	g.Rewarding.InitBalanceStr = "30"
+	g.BootstrapCandidates = []BootstrapCandidate{{SelfStakingTokens: "400"}, {SelfStakingTokens: "5000"}}
+	require.Equal("5433", g.TotalAllocation().String())
+	// the allocation is not changed by the call
+	require.Equal("5433", g.TotalAllocation().String())
+}
+
 func TestTsunamiBlockGasLimit(t *testing.T) {
 	r := require.New(t)
 
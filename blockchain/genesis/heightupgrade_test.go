@@ -6,6 +6,7 @@
 package genesis
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -67,6 +68,8 @@ func TestNewHeightChange(t *testing.T) {
 	require.True(cfg.IsUpernavik(uint64(31174201)))
 	require.False(cfg.IsVanuatu(uint64(41174200)))
 	require.True(cfg.IsVanuatu(uint64(41174201)))
+	require.False(cfg.IsWake(uint64(51174201)))
+	require.False(cfg.IsWake(math.MaxUint64 - 1))
 
 	require.Equal(cfg.PacificBlockHeight, uint64(432001))
 	require.Equal(cfg.AleutianBlockHeight, uint64(864001))
@@ -93,4 +96,5 @@ func TestNewHeightChange(t *testing.T) {
 	require.Equal(cfg.TsunamiBlockHeight, uint64(29275561))
 	require.Equal(cfg.UpernavikBlockHeight, uint64(31174201))
 	require.Equal(cfg.VanuatuBlockHeight, uint64(41174201))
+	require.Equal(cfg.WakeBlockHeight, uint64(math.MaxUint64))
 }
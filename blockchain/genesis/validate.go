@@ -0,0 +1,416 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package genesis
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrInvalidGenesis indicates the error that the genesis config is not internally consistent
+	ErrInvalidGenesis = errors.New("invalid genesis")
+)
+
+type (
+	// Difference is a field of two genesis configs with different values, Field is the path of the field
+	// in yaml, e.g., blockchain.numDelegates or account.initBalances[io1...]
+	Difference struct {
+		Field string
+		A     string
+		B     string
+	}
+
+	namedHeight struct {
+		name   string
+		height uint64
+	}
+)
+
+// ValidateGenesis checks the internal consistency of the genesis config, and returns the error of the first
+// inconsistent field found, named by its path in yaml
+func ValidateGenesis(g *Genesis) error {
+	for _, check := range []func(*Genesis) error{
+		validateEpoch,
+		validateHeights,
+		validateAccount,
+		validatePoll,
+		validateRewarding,
+		validateStaking,
+	} {
+		if err := check(g); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateEpoch(g *Genesis) error {
+	switch {
+	case g.Timestamp <= 0:
+		return errors.Wrap(ErrInvalidGenesis, "blockchain.timestamp should be positive")
+	case g.BlockInterval <= 0:
+		return errors.Wrap(ErrInvalidGenesis, "blockchain.blockInterval should be positive")
+	case g.BlockGasLimit == 0:
+		return errors.Wrap(ErrInvalidGenesis, "blockchain.blockGasLimit should be positive")
+	case g.TsunamiBlockGasLimit == 0:
+		return errors.Wrap(ErrInvalidGenesis, "blockchain.tsunamiBlockGasLimit should be positive")
+	case g.ActionGasLimit == 0 || g.ActionGasLimit > g.BlockGasLimit:
+		return errors.Wrapf(ErrInvalidGenesis, "blockchain.actionGasLimit %d should be in [1, blockGasLimit %d]", g.ActionGasLimit, g.BlockGasLimit)
+	case g.NumDelegates == 0:
+		return errors.Wrap(ErrInvalidGenesis, "blockchain.numDelegates should be positive")
+	case g.NumCandidateDelegates < g.NumDelegates:
+		return errors.Wrapf(ErrInvalidGenesis, "blockchain.numCandidateDelegates %d is less than numDelegates %d", g.NumCandidateDelegates, g.NumDelegates)
+	case g.NumSubEpochs == 0:
+		return errors.Wrap(ErrInvalidGenesis, "blockchain.numSubEpochs should be positive")
+	case g.DardanellesNumSubEpochs == 0:
+		return errors.Wrap(ErrInvalidGenesis, "blockchain.dardanellesNumSubEpochs should be positive")
+	}
+	return nil
+}
+
+func validateHeights(g *Genesis) error {
+	heights := []namedHeight{
+		{"pacificHeight", g.PacificBlockHeight},
+		{"aleutianHeight", g.AleutianBlockHeight},
+		{"beringHeight", g.BeringBlockHeight},
+		{"cookHeight", g.CookBlockHeight},
+		{"dardanellesHeight", g.DardanellesBlockHeight},
+		{"daytonaBlockHeight", g.DaytonaBlockHeight},
+		{"easterHeight", g.EasterBlockHeight},
+		{"fbkMigrationHeight", g.FbkMigrationBlockHeight},
+		{"fairbankHeight", g.FairbankBlockHeight},
+		{"greenlandHeight", g.GreenlandBlockHeight},
+		{"hawaiiHeight", g.HawaiiBlockHeight},
+		{"icelandHeight", g.IcelandBlockHeight},
+		{"jutlandHeight", g.JutlandBlockHeight},
+		{"kamchatkaHeight", g.KamchatkaBlockHeight},
+		{"lordHoweHeight", g.LordHoweBlockHeight},
+		{"midwayHeight", g.MidwayBlockHeight},
+		{"newfoundlandHeight", g.NewfoundlandBlockHeight},
+		{"okhotskHeight", g.OkhotskBlockHeight},
+		{"palauHeight", g.PalauBlockHeight},
+		{"quebecHeight", g.QuebecBlockHeight},
+		{"redseaHeight", g.RedseaBlockHeight},
+		{"sumatraHeight", g.SumatraBlockHeight},
+		{"tsunamiHeight", g.TsunamiBlockHeight},
+		{"upernavikHeight", g.UpernavikBlockHeight},
+		{"vanuatuHeight", g.VanuatuBlockHeight},
+		{"wakeHeight", g.WakeBlockHeight},
+		{"toBeEnabledHeight", g.ToBeEnabledBlockHeight},
+	}
+	// the logic of each upgrade builds on the previous ones, so they are activated in order
+	for i := 1; i < len(heights); i++ {
+		if prev, curr := heights[i-1], heights[i]; curr.height < prev.height {
+			return errors.Wrapf(ErrInvalidGenesis, "blockchain.%s %d is lower than %s %d", curr.name, curr.height, prev.name, prev.height)
+		}
+	}
+	return nil
+}
+
+func validateAccount(g *Genesis) error {
+	for addr, balance := range g.InitBalanceMap {
+		field := fmt.Sprintf("account.initBalances[%s]", addr)
+		if err := validateAddress(field, addr); err != nil {
+			return err
+		}
+		if err := validateAmount(field, balance); err != nil {
+			return err
+		}
+	}
+	for i, addr := range g.ReplayDeployerWhitelist {
+		if strings.HasPrefix(addr, address.MainnetPrefix) || strings.HasPrefix(addr, address.TestnetPrefix) {
+			if err := validateAddress(fmt.Sprintf("account.replayDeployerWhitelist[%d]", i), addr); err != nil {
+				return err
+			}
+		} else if !common.IsHexAddress(addr) {
+			return errors.Wrapf(ErrInvalidGenesis, "account.replayDeployerWhitelist[%d] %s is not a valid address", i, addr)
+		}
+	}
+	return nil
+}
+
+func validatePoll(g *Genesis) error {
+	switch g.PollMode {
+	case "lifeLong":
+		if uint64(len(g.Delegates)) < g.NumDelegates {
+			return errors.Wrapf(ErrInvalidGenesis, "poll.delegates has %d delegates, less than numDelegates %d", len(g.Delegates), g.NumDelegates)
+		}
+	case "governanceMix", "native", "nativeMix", "consortium":
+	default:
+		return errors.Wrapf(ErrInvalidGenesis, "poll.pollMode %s is not supported", g.PollMode)
+	}
+	operators := make(map[string]int, len(g.Delegates))
+	for i, d := range g.Delegates {
+		field := fmt.Sprintf("poll.delegates[%d]", i)
+		if err := validateAddress(field+".operatorAddr", d.OperatorAddrStr); err != nil {
+			return err
+		}
+		if j, ok := operators[d.OperatorAddrStr]; ok {
+			return errors.Wrapf(ErrInvalidGenesis, "%s.operatorAddr %s is the same as poll.delegates[%d]", field, d.OperatorAddrStr, j)
+		}
+		operators[d.OperatorAddrStr] = i
+		if d.RewardAddrStr != "" {
+			if err := validateAddress(field+".rewardAddr", d.RewardAddrStr); err != nil {
+				return err
+			}
+		}
+		if err := validateAmount(field+".votes", d.VotesStr); err != nil {
+			return err
+		}
+	}
+	for _, s := range []struct{ field, amount string }{
+		{"poll.voteThreshold", g.VoteThreshold},
+		{"poll.scoreThreshold", g.ScoreThreshold},
+		{"poll.selfStakingThreshold", g.SelfStakingThreshold},
+	} {
+		// the thresholds are only read from gravity chain voting
+		if s.amount == "" {
+			continue
+		}
+		if err := validateAmount(s.field, s.amount); err != nil {
+			return err
+		}
+	}
+	for _, s := range []struct{ field, addr string }{
+		{"poll.registerContractAddress", g.RegisterContractAddress},
+		{"poll.stakingContractAddress", g.StakingContractAddress},
+	} {
+		// the contracts are deployed on gravity chain
+		if s.addr != "" && !common.IsHexAddress(s.addr) {
+			return errors.Wrapf(ErrInvalidGenesis, "%s %s is not a valid address", s.field, s.addr)
+		}
+	}
+	for _, s := range []struct{ field, addr string }{
+		{"poll.nativeStakingContractAddress", g.NativeStakingContractAddress},
+		{"poll.systemStakingContractAddress", g.SystemStakingContractAddress},
+		{"poll.systemStakingContractV2Address", g.SystemStakingContractV2Address},
+	} {
+		if s.addr == "" {
+			continue
+		}
+		if err := validateAddress(s.field, s.addr); err != nil {
+			return err
+		}
+	}
+	switch {
+	case g.ProbationIntensityRate > 100:
+		return errors.Wrapf(ErrInvalidGenesis, "poll.probationIntensityRate %d should be in [0, 100]", g.ProbationIntensityRate)
+	case g.ProbationEpochPeriod > g.UnproductiveDelegateMaxCacheSize:
+		return errors.Wrapf(ErrInvalidGenesis, "poll.probationEpochPeriod %d is larger than unproductiveDelegateMaxCacheSize %d", g.ProbationEpochPeriod, g.UnproductiveDelegateMaxCacheSize)
+	}
+	return nil
+}
+
+func validateRewarding(g *Genesis) error {
+	for _, s := range []struct{ field, amount string }{
+		{"rewarding.initBalance", g.InitBalanceStr},
+		{"rewarding.blockReward", g.BlockRewardStr},
+		{"rewarding.dardanellesBlockReward", g.DardanellesBlockRewardStr},
+		{"rewarding.epochReward", g.EpochRewardStr},
+		{"rewarding.aleutianEpochReward", g.AleutianEpochRewardStr},
+		{"rewarding.foundationBonus", g.FoundationBonusStr},
+	} {
+		if err := validateAmount(s.field, s.amount); err != nil {
+			return err
+		}
+	}
+	for i, addr := range g.ExemptAddrStrsFromEpochReward {
+		if err := validateAddress(fmt.Sprintf("rewarding.exemptAddrsFromEpochReward[%d]", i), addr); err != nil {
+			return err
+		}
+	}
+	switch {
+	case g.ProductivityThreshold > 100:
+		return errors.Wrapf(ErrInvalidGenesis, "rewarding.productivityThreshold %d should be in [0, 100]", g.ProductivityThreshold)
+	case g.FoundationBonusP2StartEpoch > g.FoundationBonusP2EndEpoch:
+		return errors.Wrapf(ErrInvalidGenesis, "rewarding.foundationBonusP2StartEpoch %d is larger than foundationBonusP2EndEpoch %d", g.FoundationBonusP2StartEpoch, g.FoundationBonusP2EndEpoch)
+	}
+	return nil
+}
+
+func validateStaking(g *Genesis) error {
+	for _, s := range []struct{ field, amount string }{
+		{"staking.minStakeAmount", g.MinStakeAmount},
+		{"staking.registrationConsts.fee", g.RegistrationConsts.Fee},
+		{"staking.registrationConsts.minSelfStake", g.RegistrationConsts.MinSelfStake},
+	} {
+		if err := validateAmount(s.field, s.amount); err != nil {
+			return err
+		}
+	}
+	names := make(map[string]int, len(g.BootstrapCandidates))
+	ids := make(map[string]int, len(g.BootstrapCandidates))
+	for i, c := range g.BootstrapCandidates {
+		field := fmt.Sprintf("staking.bootstrapCandidates[%d]", i)
+		for _, s := range []struct{ field, addr string }{
+			{field + ".ownerAddress", c.OwnerAddress},
+			{field + ".operatorAddress", c.OperatorAddress},
+			{field + ".rewardAddress", c.RewardAddress},
+		} {
+			if err := validateAddress(s.field, s.addr); err != nil {
+				return err
+			}
+		}
+		if c.Name == "" {
+			return errors.Wrapf(ErrInvalidGenesis, "%s.name is empty", field)
+		}
+		if j, ok := names[c.Name]; ok {
+			return errors.Wrapf(ErrInvalidGenesis, "%s.name %s is the same as staking.bootstrapCandidates[%d]", field, c.Name, j)
+		}
+		names[c.Name] = i
+		if err := validateAmount(field+".selfStakingTokens", c.SelfStakingTokens); err != nil {
+			return err
+		}
+		if c.Identifier != "" {
+			if err := validateAddress(field+".identifier", c.Identifier); err != nil {
+				return err
+			}
+		}
+		if c.Votes != "" {
+			if err := validateAmount(field+".votes", c.Votes); err != nil {
+				return err
+			}
+		}
+		ids[bootstrapCandidateID(c)] = i
+	}
+	selfStakes := make(map[string]int)
+	for i, b := range g.BootstrapBuckets {
+		field := fmt.Sprintf("staking.bootstrapBuckets[%d]", i)
+		for _, s := range []struct{ field, addr string }{
+			{field + ".ownerAddress", b.OwnerAddress},
+			{field + ".candidateAddress", b.CandidateAddress},
+		} {
+			if err := validateAddress(s.field, s.addr); err != nil {
+				return err
+			}
+		}
+		j, ok := ids[b.CandidateAddress]
+		if !ok {
+			return errors.Wrapf(ErrInvalidGenesis, "%s.candidateAddress %s is not a bootstrap candidate", field, b.CandidateAddress)
+		}
+		if err := validateAmount(field+".stakedAmount", b.StakedAmount); err != nil {
+			return err
+		}
+		if !b.SelfStake {
+			continue
+		}
+		if b.Unstaked {
+			return errors.Wrapf(ErrInvalidGenesis, "%s is an unstaked self-stake bucket", field)
+		}
+		if k, ok := selfStakes[b.CandidateAddress]; ok {
+			return errors.Wrapf(ErrInvalidGenesis, "%s is the self-stake of the same candidate as staking.bootstrapBuckets[%d]", field, k)
+		}
+		if tokens, _ := new(big.Int).SetString(g.BootstrapCandidates[j].SelfStakingTokens, 10); tokens.Sign() != 0 {
+			return errors.Wrapf(ErrInvalidGenesis, "%s is the self-stake of staking.bootstrapCandidates[%d] with selfStakingTokens", field, j)
+		}
+		selfStakes[b.CandidateAddress] = i
+	}
+	return nil
+}
+
+func bootstrapCandidateID(c BootstrapCandidate) string {
+	if c.Identifier != "" {
+		return c.Identifier
+	}
+	return c.OwnerAddress
+}
+
+func validateAddress(field, addr string) error {
+	if _, err := address.FromString(addr); err != nil {
+		return errors.Wrapf(ErrInvalidGenesis, "%s %s is not a valid address", field, addr)
+	}
+	return nil
+}
+
+func validateAmount(field, amount string) error {
+	val, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		return errors.Wrapf(ErrInvalidGenesis, "%s %q is not a decimal integer", field, amount)
+	}
+	if val.Sign() < 0 {
+		return errors.Wrapf(ErrInvalidGenesis, "%s %s is negative", field, amount)
+	}
+	return nil
+}
+
+// DiffGenesis returns the fields of the genesis configs a and b with different values, in the order of the
+// fields in yaml, and the entries of a map in the order of keys
+func DiffGenesis(a, b *Genesis) []Difference {
+	var diffs []Difference
+	diffValue("", reflect.ValueOf(*a), reflect.ValueOf(*b), &diffs)
+	return diffs
+}
+
+func diffValue(field string, a, b reflect.Value, diffs *[]Difference) {
+	switch a.Kind() {
+	case reflect.Struct:
+		t := a.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+			if name == "" {
+				// the yaml decoder matches the field of no tag by its name in lower case
+				name = strings.ToLower(f.Name)
+			}
+			if field != "" {
+				name = field + "." + name
+			}
+			diffValue(name, a.Field(i), b.Field(i), diffs)
+		}
+	case reflect.Map:
+		keys := make(map[string]reflect.Value)
+		for _, k := range append(a.MapKeys(), b.MapKeys()...) {
+			keys[fmt.Sprint(k.Interface())] = k
+		}
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+		for _, k := range sorted {
+			diffEntry(fmt.Sprintf("%s[%s]", field, k), a.MapIndex(keys[k]), b.MapIndex(keys[k]), diffs)
+		}
+	case reflect.Slice:
+		n := a.Len()
+		if b.Len() > n {
+			n = b.Len()
+		}
+		for i := 0; i < n; i++ {
+			var ai, bi reflect.Value
+			if i < a.Len() {
+				ai = a.Index(i)
+			}
+			if i < b.Len() {
+				bi = b.Index(i)
+			}
+			diffEntry(fmt.Sprintf("%s[%d]", field, i), ai, bi, diffs)
+		}
+	default:
+		if av, bv := fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()); av != bv {
+			*diffs = append(*diffs, Difference{Field: field, A: av, B: bv})
+		}
+	}
+}
+
+// diffEntry compares the entries of a map or a slice, an invalid value is the entry missing in a config
+func diffEntry(field string, a, b reflect.Value, diffs *[]Difference) {
+	switch {
+	case a.IsValid() && b.IsValid():
+		diffValue(field, a, b, diffs)
+	case a.IsValid():
+		*diffs = append(*diffs, Difference{Field: field, A: fmt.Sprintf("%+v", a.Interface())})
+	case b.IsValid():
+		*diffs = append(*diffs, Difference{Field: field, B: fmt.Sprintf("%+v", b.Interface())})
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package genesis
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateGenesis(t *testing.T) {
+	r := require.New(t)
+	for _, g := range []Genesis{defaultConfig(), TestDefault()} {
+		r.NoError(ValidateGenesis(&g))
+	}
+
+	// the first line of each broken genesis file is the comment of the expected error
+	files, err := filepath.Glob("testdata/invalid/*.yaml")
+	r.NoError(err)
+	r.NotEmpty(files)
+	for _, file := range files {
+		t.Run(strings.TrimSuffix(filepath.Base(file), ".yaml"), func(t *testing.T) {
+			r := require.New(t)
+			f, err := os.Open(file)
+			r.NoError(err)
+			line, err := bufio.NewReader(f).ReadString('\n')
+			r.NoError(err)
+			r.NoError(f.Close())
+			expected := strings.TrimSpace(strings.TrimPrefix(line, "#"))
+
+			g, err := New(file)
+			r.NoError(err)
+			err = ValidateGenesis(&g)
+			r.Equal(ErrInvalidGenesis, errors.Cause(err))
+			r.Equal(expected+": "+ErrInvalidGenesis.Error(), err.Error())
+		})
+	}
+}
+
+func TestDiffGenesis(t *testing.T) {
+	r := require.New(t)
+	a, b := TestDefault(), TestDefault()
+	r.Empty(DiffGenesis(&a, &b))
+
+	b.NumDelegates = 36
+	b.BlockInterval = a.BlockInterval / 2
+	b.InitBalanceMap = map[string]string{}
+	for k, v := range a.InitBalanceMap {
+		b.InitBalanceMap[k] = v
+	}
+	addr := a.Delegates[0].OperatorAddrStr
+	b.InitBalanceMap[addr] = "1"
+	b.InitBalanceMap["io1uqhmnttmv0pg8prugxxn7d8ex9angrvfjfthxa"] = "2"
+	b.Delegates = append([]Delegate{}, a.Delegates[:len(a.Delegates)-1]...)
+	b.Delegates[1].VotesStr = "3"
+	b.VoteWeightCalConsts.AutoStake = 2
+	n := len(a.Delegates) - 1
+	r.Equal([]Difference{
+		{Field: "blockchain.blockInterval", A: "10s", B: "5s"},
+		{Field: "blockchain.numDelegates", A: "24", B: "36"},
+		{Field: "account.initBalances[" + addr + "]", A: a.InitBalanceMap[addr], B: "1"},
+		{Field: "account.initBalances[io1uqhmnttmv0pg8prugxxn7d8ex9angrvfjfthxa]", B: "2"},
+		{Field: "poll.delegates[1].votes", A: a.Delegates[1].VotesStr, B: "3"},
+		{Field: fmt.Sprintf("poll.delegates[%d]", n), A: fmt.Sprintf("%+v", a.Delegates[n])},
+		{Field: "staking.voteWeightCalConsts.autoStake", A: "1", B: "2"},
+	}, DiffGenesis(&a, &b))
+}
@@ -11,6 +11,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/big"
+	"strconv"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -22,6 +23,7 @@ import (
 	iotexcrypto "github.com/iotexproject/go-pkgs/crypto"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
@@ -34,6 +36,7 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol/execution"
 	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
 	"github.com/iotexproject/iotex-core/action/protocol/poll"
+	"github.com/iotexproject/iotex-core/action/protocol/randomness"
 	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
 	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/action/protocol/vote/candidatesutil"
@@ -55,6 +58,7 @@ import (
 	"github.com/iotexproject/iotex-core/test/mock/mock_blockcreationsubscriber"
 	"github.com/iotexproject/iotex-core/test/mock/mock_poll"
 	"github.com/iotexproject/iotex-core/testutil"
+	"github.com/iotexproject/iotex-core/testutil/testchain"
 )
 
 var (
@@ -757,7 +761,7 @@ func TestBlockchain_MintNewBlock(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, ap.Add(ctx, selp1))
 	// This execution should not be included in block because block is out of gas
-	elp2 := bd.SetAction(execution).
+	elp2 := (&action.EnvelopeBuilder{}).SetAction(execution).
 		SetNonce(2).
 		SetGasLimit(100000).
 		SetGasPrice(big.NewInt(10)).Build()
@@ -2632,3 +2636,117 @@ func receiptByActionHash(dao blockdao.BlockDAO, height uint64, h hash.Hash256) (
 }
 
 // TODO: add func TestValidateBlock()
+
+func TestBlockchain_ValidateBlockTimestamp(t *testing.T) {
+	require := require.New(t)
+	for _, enabled := range []bool{false, true} {
+		chain := testchain.NewBuilder(t).Genesis(func(g *genesis.Genesis) {
+			if enabled {
+				g.ToBeEnabledBlockHeight = 0
+			}
+		}).Build()
+		g := chain.Genesis()
+		chain.MintBlocks(11)
+		bc := chain.Blockchain()
+		tip, err := bc.BlockHeaderByHeight(bc.TipHeight())
+		require.NoError(err)
+		// the median of the timestamps of the blocks 2 to 12
+		mtp := tip.Timestamp().Add(-5 * g.BlockInterval)
+		validate := func(ts time.Time) error {
+			blk, err := bc.MintNewBlock(ts)
+			require.NoError(err)
+			return bc.ValidateBlock(blk)
+		}
+		for _, ts := range []time.Time{
+			mtp,
+			mtp.Add(-g.BlockInterval),
+			time.Now().Add(time.Hour),
+		} {
+			err := validate(ts)
+			if enabled {
+				require.ErrorIs(err, blockchain.ErrInvalidBlockTimestamp)
+			} else {
+				require.NoError(err)
+			}
+		}
+		// the block time may go backward as long as it is after the median time past
+		require.NoError(validate(mtp.Add(time.Second)))
+		require.NoError(validate(time.Now().Add(blockchain.DefaultConfig.MaxBlockTimeDrift / 2)))
+
+		// the block.timestamp seen by a contract is the block timestamp, which is after the median time past
+		selp := chain.Sign(identityset.PrivateKey(1), func(nonce, gasLimit uint64, gasPrice *big.Int) (*action.SealedEnvelope, error) {
+			// TIMESTAMP PUSH1 0 MSTORE PUSH1 1 PUSH1 32 PUSH1 0 LOG1 STOP
+			code, _ := hex.DecodeString("42600052600160206000a100")
+			return action.SignedExecution(action.EmptyAddress, identityset.PrivateKey(1), nonce, big.NewInt(0), gasLimit, gasPrice, code, action.WithChainID(chain.ChainID()))
+		})
+		blk := chain.MintBlock(selp)
+		chain.RequireReceiptStatus(selp, iotextypes.ReceiptStatus_Success)
+		logs := chain.Receipt(selp).Logs()
+		require.Len(logs, 1)
+		ts := new(big.Int).SetBytes(logs[0].Data).Int64()
+		require.Equal(blk.Timestamp().Unix(), ts)
+		require.Greater(ts, mtp.Unix())
+	}
+}
+
+func TestBlockchain_RandomnessBeacon(t *testing.T) {
+	require := require.New(t)
+	for _, enabled := range []bool{false, true} {
+		chain := testchain.NewBuilder(t).Genesis(func(g *genesis.Genesis) {
+			if enabled {
+				g.ToBeEnabledBlockHeight = 0
+			}
+		}).Build()
+		bc := chain.Blockchain()
+		chain.MintBlocks(2)
+		// PREVRANDAO PUSH1 0 MSTORE PUSH1 1 PUSH1 32 PUSH1 0 LOG1 STOP
+		selp := chain.Sign(identityset.PrivateKey(1), func(nonce, gasLimit uint64, gasPrice *big.Int) (*action.SealedEnvelope, error) {
+			code, _ := hex.DecodeString("44600052600160206000a100")
+			return action.SignedExecution(action.EmptyAddress, identityset.PrivateKey(1), nonce, big.NewInt(0), gasLimit, gasPrice, code, action.WithChainID(chain.ChainID()))
+		})
+		blk := chain.MintBlock(selp)
+		chain.RequireReceiptStatus(selp, iotextypes.ReceiptStatus_Success)
+		logs := chain.Receipt(selp).Logs()
+		require.Len(logs, 1)
+		readBeacon := func(height uint64) ([]byte, error) {
+			return chain.ReadState("randomness", []byte("BlockRandomness"), []byte(strconv.FormatUint(height, 10)))
+		}
+		if !enabled {
+			require.Nil(blk.RandomnessProof())
+			_, err := readBeacon(blk.Height())
+			require.Equal(state.ErrStateNotExist, errors.Cause(err))
+			continue
+		}
+
+		// the beacon mixes the verified randomness of each block since the activation
+		beacon := hash.ZeroHash256
+		for height := uint64(1); height <= bc.TipHeight(); height++ {
+			b, err := chain.BlockDAO().GetBlockByHeight(height)
+			require.NoError(err)
+			output, err := b.VerifyRandomness()
+			require.NoError(err)
+			beacon = randomness.Mix(beacon, output)
+			data, err := readBeacon(height)
+			require.NoError(err)
+			require.Equal(beacon[:], data)
+		}
+		// the contract reads the beacon of the block
+		require.Equal(beacon[:], logs[0].Data)
+
+		// the block without the proof is rejected
+		blk, err := bc.MintNewBlock(testutil.TimestampNow())
+		require.NoError(err)
+		require.NoError(bc.ValidateBlock(blk))
+		pb := blk.ConvertToBlockPb()
+		pb.Header.Core.ProtoReflect().SetUnknown(nil)
+		core, err := proto.Marshal(pb.Header.Core)
+		require.NoError(err)
+		h := hash.Hash256b(core)
+		pb.Header.Signature, err = chain.Producer().Sign(h[:])
+		require.NoError(err)
+		blk, err = block.NewDeserializer(bc.EvmNetworkID()).FromBlockProto(pb)
+		require.NoError(err)
+		require.True(blk.VerifySignature())
+		require.ErrorIs(bc.ValidateBlock(blk), blockchain.ErrInvalidRandomness)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+const (
+	// _addrFilterPrefix is the prefix of the bucket storing the filter columns of an address's actions, the
+	// entries are aligned with the tail of the address's action index
+	_addrFilterPrefix = "af"
+	// _addrFilterEntryLen is 8-byte block height, 1-byte direction and 4-byte action type
+	_addrFilterEntryLen = 13
+	// _addrFilterScanBatch is the number of entries read at a time when scanning the index with filter
+	_addrFilterScanBatch = 256
+)
+
+// the directions of an action relative to an address, an action could have more than one direction
+const (
+	// DirectionSender is the action sent by the address
+	DirectionSender ActionDirection = 1 << iota
+	// DirectionRecipient is the action received by the address
+	DirectionRecipient
+	// DirectionContract is the action calling the contract of the address
+	DirectionContract
+)
+
+var (
+	// ErrActionFilterNA indicates the actions of the address were indexed without the filter columns, which
+	// need a reindex to be filtered
+	ErrActionFilterNA = errors.New("action filter not supported by the index, reindex is required")
+
+	_actionOneof = (&iotextypes.ActionCore{}).ProtoReflect().Descriptor().Oneofs().ByName("action")
+)
+
+type (
+	// ActionDirection is the direction of an action relative to an address
+	ActionDirection uint8
+
+	// ActionFilter filters the actions of an address, the zero value of each field matches any action
+	ActionFilter struct {
+		// Direction matches the actions of any of the directions
+		Direction ActionDirection
+		// ActionTypes matches the actions of any of the types, which are the names of the action in the
+		// oneof of ActionCore, e.g. "transfer", "execution", "stakeCreate"
+		ActionTypes []string
+		// FromHeight matches the actions in blocks at or above the height
+		FromHeight uint64
+		// ToHeight matches the actions in blocks at or below the height, 0 means no upper bound
+		ToHeight uint64
+	}
+
+	// addrFilterEntry is the filter columns of an action in the index of an address
+	addrFilterEntry struct {
+		height     uint64
+		direction  ActionDirection
+		actionType uint32
+	}
+
+	// actionMatcher matches the filter entries against an action filter
+	actionMatcher struct {
+		direction ActionDirection
+		types     map[uint32]struct{}
+		to        uint64
+	}
+)
+
+// actionType returns the type of the action, which is the field number of the action in the oneof of ActionCore
+func actionType(elp *action.SealedEnvelope) uint32 {
+	field := elp.Envelope.Proto().ProtoReflect().WhichOneof(_actionOneof)
+	if field == nil {
+		return 0
+	}
+	return uint32(field.Number())
+}
+
+func (e *addrFilterEntry) serialize() []byte {
+	b := make([]byte, 0, _addrFilterEntryLen)
+	b = append(b, byteutil.Uint64ToBytesBigEndian(e.height)...)
+	b = append(b, byte(e.direction))
+	return append(b, byteutil.Uint32ToBytesBigEndian(e.actionType)...)
+}
+
+func (e *addrFilterEntry) deserialize(buf []byte) error {
+	if len(buf) != _addrFilterEntryLen {
+		return errors.Wrapf(db.ErrInvalid, "wrong length of address filter entry %d", len(buf))
+	}
+	e.height = byteutil.BytesToUint64BigEndian(buf[:8])
+	e.direction = ActionDirection(buf[8])
+	e.actionType = byteutil.BytesToUint32BigEndian(buf[9:])
+	return nil
+}
+
+func newActionMatcher(filter *ActionFilter) (*actionMatcher, error) {
+	if filter.ToHeight != 0 && filter.ToHeight < filter.FromHeight {
+		return nil, errors.Wrapf(db.ErrInvalid, "from height %d > to height %d", filter.FromHeight, filter.ToHeight)
+	}
+	m := &actionMatcher{
+		direction: filter.Direction,
+		to:        filter.ToHeight,
+	}
+	if len(filter.ActionTypes) > 0 {
+		m.types = make(map[uint32]struct{}, len(filter.ActionTypes))
+		for _, name := range filter.ActionTypes {
+			field := _actionOneof.Fields().ByName(protoreflect.Name(name))
+			if field == nil {
+				return nil, errors.Wrapf(db.ErrInvalid, "unknown action type %s", name)
+			}
+			m.types[uint32(field.Number())] = struct{}{}
+		}
+	}
+	return m, nil
+}
+
+// match returns whether the entry matches, and whether the entries after it are beyond the height range
+func (m *actionMatcher) match(e *addrFilterEntry) (bool, bool) {
+	if m.to != 0 && e.height > m.to {
+		return false, true
+	}
+	if m.direction != 0 && m.direction&e.direction == 0 {
+		return false, false
+	}
+	if m.types != nil {
+		if _, ok := m.types[e.actionType]; !ok {
+			return false, false
+		}
+	}
+	return true, false
+}
+
+func addrFilterBucket(addr []byte) []byte {
+	return append([]byte(_addrFilterPrefix), addr...)
+}
@@ -9,13 +9,19 @@ import (
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/blockindex/indexpb"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 )
 
 // ActionIndex change private to public for mock Indexer
+//
+// The index is keyed by the action hash, no matter which version computes the hash, and records the hash version,
+// so the actions of different hash versions are indexed alike. The index of a v1 action is the same as the one
+// stored before the hash versions are introduced
 type ActionIndex struct {
-	blkHeight uint64
+	blkHeight   uint64
+	hashVersion action.HashVersion
 }
 
 // Height returns the block height of action
@@ -23,6 +29,14 @@ func (a *ActionIndex) BlockHeight() uint64 {
 	return a.blkHeight
 }
 
+// HashVersion returns the version of the algorithm computing the hash of action
+func (a *ActionIndex) HashVersion() action.HashVersion {
+	if a.hashVersion == 0 {
+		return action.HashV1
+	}
+	return a.hashVersion
+}
+
 // Serialize into byte stream
 func (a *ActionIndex) Serialize() []byte {
 	return byteutil.Must(proto.Marshal(a.toProto()))
@@ -39,9 +53,13 @@ func (a *ActionIndex) Deserialize(buf []byte) error {
 
 // toProto converts to protobuf
 func (a *ActionIndex) toProto() *indexpb.ActionIndex {
-	return &indexpb.ActionIndex{
+	pb := &indexpb.ActionIndex{
 		BlkHeight: a.blkHeight,
 	}
+	if a.HashVersion() != action.HashV1 {
+		pb.HashVersion = uint32(a.hashVersion)
+	}
+	return pb
 }
 
 // fromProto converts from protobuf
@@ -50,5 +68,6 @@ func (a *ActionIndex) fromProto(pbIndex *indexpb.ActionIndex) error {
 		return errors.New("empty protobuf")
 	}
 	a.blkHeight = pbIndex.BlkHeight
+	a.hashVersion = action.HashVersion(pbIndex.HashVersion)
 	return nil
 }
@@ -0,0 +1,172 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+// _blockTimeBucket is the name of the counting index of the block times, the entry at position i is of height i+1
+var _blockTimeBucket = []byte("bt")
+
+type (
+	// BlockTimeIndexer is the interface of the indexer of the heights by block time
+	BlockTimeIndexer interface {
+		blockdao.BlockIndexer
+		// HeightByTimestamp returns the highest height whose block time is at or before the timestamp if before is
+		// true, otherwise the lowest height whose block time is at or after the timestamp. db.ErrNotExist is
+		// returned if there is no such block
+		HeightByTimestamp(ts time.Time, before bool) (uint64, error)
+	}
+
+	// blockTimeIndexer stores the time of each block in the order of height. A block is validated to be after the
+	// median time of the blocks before it rather than the block right before it, so the time of a block is raised
+	// to that of the block before if earlier, which keeps the index monotone. Blocks of the same time are resolved
+	// to the lowest height of them after the timestamp, and the highest before it
+	blockTimeIndexer struct {
+		mutex   sync.RWMutex
+		kvStore db.KVStoreWithRange
+		index   db.CountingIndex
+	}
+)
+
+// NewBlockTimeIndexer creates a new block time indexer
+func NewBlockTimeIndexer(kv db.KVStore) (BlockTimeIndexer, error) {
+	if kv == nil {
+		return nil, errors.New("empty kvStore")
+	}
+	kvRange, ok := kv.(db.KVStoreWithRange)
+	if !ok {
+		return nil, errors.New("block time indexer can only be created from KVStoreWithRange")
+	}
+	return &blockTimeIndexer{
+		kvStore: kvRange,
+	}, nil
+}
+
+// Start starts the block time indexer
+func (x *blockTimeIndexer) Start(ctx context.Context) error {
+	if err := x.kvStore.Start(ctx); err != nil {
+		return err
+	}
+	index, err := db.NewCountingIndexNX(x.kvStore, _blockTimeBucket)
+	if err != nil {
+		return err
+	}
+	x.index = index
+	return nil
+}
+
+// Stop stops the block time indexer
+func (x *blockTimeIndexer) Stop(ctx context.Context) error {
+	return x.kvStore.Stop(ctx)
+}
+
+// Height returns the height of the block time indexer
+func (x *blockTimeIndexer) Height() (uint64, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	return x.index.Size(), nil
+}
+
+// PutBlock indexes the time of the block, only the header of the block is read
+func (x *blockTimeIndexer) PutBlock(_ context.Context, blk *block.Block) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	height, size := blk.Height(), x.index.Size()
+	if height <= size {
+		// the block has been indexed
+		return nil
+	}
+	if height != size+1 {
+		return errors.Wrapf(db.ErrInvalid, "wrong block height %d, expecting %d", height, size+1)
+	}
+	ts := blk.Timestamp().UnixNano()
+	if ts < 0 {
+		return errors.Wrapf(db.ErrInvalid, "block %d is before 1970", height)
+	}
+	if size > 0 {
+		last, err := x.timeAt(size - 1)
+		if err != nil {
+			return err
+		}
+		if ts < last {
+			ts = last
+		}
+	}
+	return x.index.Add(byteutil.Uint64ToBytesBigEndian(uint64(ts)), false)
+}
+
+// DeleteTipBlock deletes the time of the tip block
+func (x *blockTimeIndexer) DeleteTipBlock(_ context.Context, blk *block.Block) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	if height := blk.Height(); height != x.index.Size() {
+		return errors.Wrapf(db.ErrInvalid, "wrong block height %d, expecting %d", height, x.index.Size())
+	}
+	return x.index.Revert(1)
+}
+
+// HeightByTimestamp returns the height of the block by the timestamp
+func (x *blockTimeIndexer) HeightByTimestamp(ts time.Time, before bool) (uint64, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+
+	target := ts.UnixNano()
+	var searchErr error
+	size := x.index.Size()
+	// the position of the first block after the timestamp if before, otherwise the first block at or after it
+	pos := uint64(sort.Search(int(size), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		t, err := x.timeAt(uint64(i))
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		if before {
+			return t > target
+		}
+		return t >= target
+	}))
+	switch {
+	case searchErr != nil:
+		return 0, searchErr
+	case before && pos == 0:
+		return 0, errors.Wrapf(db.ErrNotExist, "no block at or before %s", ts)
+	case before:
+		return pos, nil
+	case pos == size:
+		return 0, errors.Wrapf(db.ErrNotExist, "no block at or after %s", ts)
+	default:
+		return pos + 1, nil
+	}
+}
+
+// timeAt returns the block time in unix nanoseconds at the position of the index
+func (x *blockTimeIndexer) timeAt(pos uint64) (int64, error) {
+	v, err := x.index.Get(pos)
+	if err != nil {
+		return 0, err
+	}
+	if len(v) != 8 {
+		return 0, errors.Wrapf(db.ErrInvalid, "wrong length of block time %d", len(v))
+	}
+	return int64(byteutil.BytesToUint64BigEndian(v)), nil
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestBlockTimeIndexer(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	newBlock := func(height uint64, sec int64) *block.Block {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(height).
+			SetTimeStamp(time.Unix(sec, 0)).
+			SignAndBuild(identityset.PrivateKey(27))
+		r.NoError(err)
+		return &blk
+	}
+
+	kv := db.NewMemKVStore()
+	indexer, err := NewBlockTimeIndexer(kv)
+	r.NoError(err)
+	r.NoError(indexer.Start(ctx))
+	_, err = indexer.HeightByTimestamp(time.Unix(100, 0), false)
+	r.ErrorIs(err, db.ErrNotExist)
+
+	// block 2 is of the same time as block 1, and block 4 is earlier than block 3
+	blks := []*block.Block{newBlock(1, 100), newBlock(2, 100), newBlock(3, 105), newBlock(4, 103), newBlock(5, 110)}
+	for _, blk := range blks {
+		r.NoError(indexer.PutBlock(ctx, blk))
+	}
+	// indexed blocks are skipped
+	r.NoError(indexer.PutBlock(ctx, blks[3]))
+	r.ErrorIs(indexer.PutBlock(ctx, newBlock(7, 120)), db.ErrInvalid)
+	height, err := indexer.Height()
+	r.NoError(err)
+	r.Equal(uint64(5), height)
+
+	for _, c := range []struct {
+		sec    int64
+		before bool
+		height uint64
+	}{
+		{99, false, 1},
+		{100, false, 1},
+		{101, false, 3},
+		{104, false, 3},
+		{105, false, 3},
+		{106, false, 5},
+		{111, false, 0},
+		{99, true, 0},
+		{100, true, 2},
+		{104, true, 2},
+		{105, true, 4},
+		{109, true, 4},
+		{200, true, 5},
+	} {
+		height, err := indexer.HeightByTimestamp(time.Unix(c.sec, 0), c.before)
+		if c.height == 0 {
+			r.ErrorIs(err, db.ErrNotExist, "%d %t", c.sec, c.before)
+			continue
+		}
+		r.NoError(err)
+		r.Equal(c.height, height, "%d %t", c.sec, c.before)
+	}
+	// in nanoseconds
+	height, err = indexer.HeightByTimestamp(time.Unix(100, 1), false)
+	r.NoError(err)
+	r.Equal(uint64(3), height)
+
+	r.ErrorIs(indexer.DeleteTipBlock(ctx, blks[3]), db.ErrInvalid)
+	r.NoError(indexer.DeleteTipBlock(ctx, blks[4]))
+	height, err = indexer.HeightByTimestamp(time.Unix(200, 0), true)
+	r.NoError(err)
+	r.Equal(uint64(4), height)
+	_, err = indexer.HeightByTimestamp(time.Unix(106, 0), false)
+	r.ErrorIs(err, db.ErrNotExist)
+
+	// the index is kept over restart
+	r.NoError(indexer.Stop(ctx))
+	indexer, err = NewBlockTimeIndexer(kv)
+	r.NoError(err)
+	r.NoError(indexer.Start(ctx))
+	height, err = indexer.Height()
+	r.NoError(err)
+	r.Equal(uint64(4), height)
+	r.NoError(indexer.PutBlock(ctx, newBlock(5, 108)))
+	height, err = indexer.HeightByTimestamp(time.Unix(106, 0), false)
+	r.NoError(err)
+	r.Equal(uint64(5), height)
+}
@@ -5,6 +5,8 @@
 
 package blockindex
 
+import "time"
+
 // Config is the config for indexer
 type Config struct {
 	// RangeBloomFilterNumElements is the number of elements each rangeBloomfilter will store in bloomfilterIndexer
@@ -13,6 +15,14 @@ type Config struct {
 	RangeBloomFilterSize uint64 `yaml:"rangeBloomFilterSize"`
 	// RangeBloomFilterNumHash is the number of hash functions of rangeBloomfilter
 	RangeBloomFilterNumHash uint64 `yaml:"rangeBloomFilterNumHash"`
+	// Reindex rebuilds the block index in background while the current one keeps serving, it is resumed on
+	// restart until the rebuild completes. Only supported with async index write
+	Reindex bool `yaml:"reindex"`
+	// ReindexBatchSize is the number of blocks indexed at a time by the reindex, the progress is checkpointed
+	// after each batch
+	ReindexBatchSize uint64 `yaml:"reindexBatchSize"`
+	// ReindexInterval is the pause between the batches of the reindex, which limits its load on the node
+	ReindexInterval time.Duration `yaml:"reindexInterval"`
 }
 
 // DefaultConfig is the default config of indexer
@@ -20,4 +30,5 @@ var DefaultConfig = Config{
 	RangeBloomFilterNumElements: 100000,
 	RangeBloomFilterSize:        1200000,
 	RangeBloomFilterNumHash:     8,
+	ReindexBatchSize:            5000,
 }
@@ -0,0 +1,656 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+const (
+	// _contractStatsNS is the namespace storing the height and the first day not compacted
+	_contractStatsNS = "cs"
+	// _contractDayStatsNS is the namespace storing the stats keyed by 8-byte day and 20-byte contract address
+	_contractDayStatsNS = "csd"
+	// _contractCallerNS is the namespace storing the callers seen, keyed by day, contract and caller address
+	_contractCallerNS = "csu"
+	// _contractJournalNS is the namespace storing the journal of a day keyed by 8-byte day and 8-byte sequence,
+	// which records the contracts and callers in the order they are first seen
+	_contractJournalNS = "csj"
+	// _contractJournalSizeNS is the namespace storing the size of the journal of each day
+	_contractJournalSizeNS = "csn"
+	// _contractListNS is the namespace storing the contracts kept in a compacted day
+	_contractListNS = "csl"
+	// _contractStatsLen is 8-byte calls, gas consumed, failures and unique callers each
+	_contractStatsLen = 32
+	// _contractJournalLen is 8-byte height, 1-byte kind, 20-byte contract and caller address each
+	_contractJournalLen = 8 + 1 + 20 + 20
+
+	_journalNewContract byte = 1
+	_journalNewCaller   byte = 2
+
+	_secondsPerDay = 24 * 60 * 60
+)
+
+// the orders of the top contracts
+const (
+	// OrderByCalls orders the contracts by the number of calls
+	OrderByCalls ContractStatsOrder = iota
+	// OrderByGasConsumed orders the contracts by the gas consumed
+	OrderByGasConsumed
+	// OrderByFailures orders the contracts by the number of failed calls
+	OrderByFailures
+	// OrderByUniqueCallers orders the contracts by the number of unique callers
+	OrderByUniqueCallers
+)
+
+var (
+	_contractStatsHeightKey    = []byte("height")
+	_contractStatsCompactedKey = []byte("compacted")
+
+	// OtherContracts is the address of the bucket the contracts below the call threshold of a day are folded into
+	OtherContracts = hash.ZeroHash160
+)
+
+type (
+	// ContractStatsOrder is the order of the top contracts
+	ContractStatsOrder uint8
+
+	// ContractStats is the call stats of a contract. The unique callers of a range of days is the sum of the
+	// unique callers of each day, and the unique callers of OtherContracts is the sum of the folded contracts
+	ContractStats struct {
+		Contract      hash.Hash160
+		Calls         uint64
+		GasConsumed   uint64
+		Failures      uint64
+		UniqueCallers uint64
+	}
+
+	// ContractDayStats is the call stats of a contract in a day
+	ContractDayStats struct {
+		// Day is the number of days since the unix epoch in UTC of the block timestamps
+		Day uint64
+		ContractStats
+	}
+
+	// ContractStatsIndexer is the interface of the indexer of the daily call stats of the contracts, which are
+	// the targets of the executions in the blocks
+	ContractStatsIndexer interface {
+		blockdao.BlockIndexer
+		// ContractStats returns the stats of the contract of each day in the range, the days without calls are
+		// skipped
+		ContractStats(hash.Hash160, uint64, uint64) ([]*ContractDayStats, error)
+		// TopContracts returns at most limit contracts with the most stats in the order within the range of days
+		TopContracts(uint64, uint64, ContractStatsOrder, uint64) ([]*ContractStats, error)
+	}
+
+	// contractStatsIndexer rolls the receipts of the executions up into the stats of each day. The day before
+	// yesterday of the latest block is compacted, in which the contracts with fewer calls than the threshold
+	// are folded into OtherContracts and the callers are deleted, so the size of the index is bounded
+	contractStatsIndexer struct {
+		mutex          sync.RWMutex
+		kvStore        db.KVStore
+		batch          batch.KVStoreBatch
+		minDailyCalls  uint64
+		height         uint64
+		compacted      uint64
+		dirty          map[string]*ContractStats
+		journalSizes   map[uint64]uint64
+		callers        map[string]struct{}
+		compactedDirty bool
+	}
+)
+
+// NewContractStatsIndexer creates a new contract stats indexer, the contracts with fewer calls than minDailyCalls
+// in a day are folded into OtherContracts when the day is compacted
+func NewContractStatsIndexer(kv db.KVStore, minDailyCalls uint64) (ContractStatsIndexer, error) {
+	if kv == nil {
+		return nil, errors.New("empty kvStore")
+	}
+	return &contractStatsIndexer{
+		kvStore:       kv,
+		batch:         batch.NewBatch(),
+		minDailyCalls: minDailyCalls,
+		dirty:         make(map[string]*ContractStats),
+		journalSizes:  make(map[uint64]uint64),
+		callers:       make(map[string]struct{}),
+	}, nil
+}
+
+// Start starts the contract stats indexer
+func (x *contractStatsIndexer) Start(ctx context.Context) error {
+	if err := x.kvStore.Start(ctx); err != nil {
+		return err
+	}
+	var err error
+	if x.height, err = x.getUint64(_contractStatsNS, _contractStatsHeightKey); err != nil {
+		return err
+	}
+	x.compacted, err = x.getUint64(_contractStatsNS, _contractStatsCompactedKey)
+	return err
+}
+
+// Stop stops the contract stats indexer
+func (x *contractStatsIndexer) Stop(ctx context.Context) error {
+	return x.kvStore.Stop(ctx)
+}
+
+// Height returns the height of the contract stats indexer
+func (x *contractStatsIndexer) Height() (uint64, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	return x.height, nil
+}
+
+// PutBlock adds the executions of the block to the stats of the day of the block
+func (x *contractStatsIndexer) PutBlock(_ context.Context, blk *block.Block) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	height := blk.Height()
+	if height <= x.height {
+		// the block has been indexed
+		return nil
+	}
+	if height != x.height+1 {
+		return errors.Wrapf(db.ErrInvalid, "wrong block height %d, expecting %d", height, x.height+1)
+	}
+	defer x.clear()
+	day := DayOf(blk.Timestamp())
+	if x.compacted == 0 {
+		x.compacted, x.compactedDirty = day, true
+	}
+	for ; x.compacted+1 < day; x.compacted++ {
+		if err := x.compact(x.compacted); err != nil {
+			return err
+		}
+		x.compactedDirty = true
+	}
+	calls, err := executionCalls(blk)
+	if err != nil {
+		return err
+	}
+	for _, call := range calls {
+		stats, err := x.getStats(day, call.contract)
+		if err != nil {
+			return err
+		}
+		if stats.Calls == 0 {
+			if err := x.appendJournal(day, height, _journalNewContract, call.contract, hash.ZeroHash160); err != nil {
+				return err
+			}
+		}
+		seen, err := x.seenCaller(day, call.contract, call.caller)
+		if err != nil {
+			return err
+		}
+		if !seen {
+			x.callers[string(callerKey(day, call.contract, call.caller))] = struct{}{}
+			x.batch.Put(_contractCallerNS, callerKey(day, call.contract, call.caller), []byte{1}, "failed to put caller")
+			if err := x.appendJournal(day, height, _journalNewCaller, call.contract, call.caller); err != nil {
+				return err
+			}
+			stats.UniqueCallers++
+		}
+		stats.Calls++
+		stats.GasConsumed += call.gas
+		if call.failed {
+			stats.Failures++
+		}
+	}
+	return x.commit(height)
+}
+
+// DeleteTipBlock subtracts the executions of the tip block from the stats, the block cannot be deleted if its
+// day has been compacted
+func (x *contractStatsIndexer) DeleteTipBlock(_ context.Context, blk *block.Block) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	height := blk.Height()
+	if height != x.height {
+		return errors.Wrapf(db.ErrInvalid, "wrong block height %d, expecting %d", height, x.height)
+	}
+	defer x.clear()
+	day := DayOf(blk.Timestamp())
+	if day < x.compacted {
+		return errors.Wrapf(db.ErrInvalid, "day %d of block %d has been compacted", day, height)
+	}
+	calls, err := executionCalls(blk)
+	if err != nil {
+		return err
+	}
+	for _, call := range calls {
+		stats, err := x.getStats(day, call.contract)
+		if err != nil {
+			return err
+		}
+		if stats.Calls == 0 || stats.GasConsumed < call.gas {
+			return errors.Wrapf(db.ErrInvalid, "stats of contract %x in day %d are not consistent with block %d", call.contract, day, height)
+		}
+		stats.Calls--
+		stats.GasConsumed -= call.gas
+		if call.failed {
+			stats.Failures--
+		}
+	}
+	// revert the contracts and the callers first seen in the block
+	size, err := x.journalSize(day)
+	if err != nil {
+		return err
+	}
+	for ; size > 0; size-- {
+		entry, err := x.kvStore.Get(_contractJournalNS, journalKey(day, size-1))
+		if err != nil {
+			return err
+		}
+		if len(entry) != _contractJournalLen {
+			return errors.Wrapf(db.ErrInvalid, "wrong length of contract journal %d", len(entry))
+		}
+		if byteutil.BytesToUint64BigEndian(entry[:8]) != height {
+			break
+		}
+		contract, caller := hash.BytesToHash160(entry[9:29]), hash.BytesToHash160(entry[29:])
+		switch entry[8] {
+		case _journalNewContract:
+			delete(x.dirty, string(statsKey(day, contract)))
+			x.batch.Delete(_contractDayStatsNS, statsKey(day, contract), "failed to delete stats")
+		case _journalNewCaller:
+			if stats, ok := x.dirty[string(statsKey(day, contract))]; ok {
+				stats.UniqueCallers--
+			}
+			x.batch.Delete(_contractCallerNS, callerKey(day, contract, caller), "failed to delete caller")
+		}
+		x.batch.Delete(_contractJournalNS, journalKey(day, size-1), "failed to delete contract journal")
+	}
+	x.journalSizes[day] = size
+	return x.commit(height - 1)
+}
+
+// ContractStats returns the stats of the contract of each day in the range
+func (x *contractStatsIndexer) ContractStats(contract hash.Hash160, fromDay, toDay uint64) ([]*ContractDayStats, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+
+	if fromDay > toDay {
+		return nil, errors.Wrapf(db.ErrInvalid, "from day %d > to day %d", fromDay, toDay)
+	}
+	var ret []*ContractDayStats
+	for day := fromDay; day <= toDay; day++ {
+		stats, err := x.readStats(day, contract)
+		if err != nil {
+			return nil, err
+		}
+		if stats.Calls > 0 {
+			ret = append(ret, &ContractDayStats{Day: day, ContractStats: *stats})
+		}
+	}
+	return ret, nil
+}
+
+// TopContracts returns the contracts with the most stats in the order within the range of days, OtherContracts
+// is not ranked
+func (x *contractStatsIndexer) TopContracts(fromDay, toDay uint64, order ContractStatsOrder, limit uint64) ([]*ContractStats, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+
+	if fromDay > toDay {
+		return nil, errors.Wrapf(db.ErrInvalid, "from day %d > to day %d", fromDay, toDay)
+	}
+	if order > OrderByUniqueCallers {
+		return nil, errors.Wrapf(db.ErrInvalid, "unknown order %d", order)
+	}
+	total := make(map[hash.Hash160]*ContractStats)
+	for day := fromDay; day <= toDay; day++ {
+		contracts, err := x.contractsOfDay(day)
+		if err != nil {
+			return nil, err
+		}
+		for _, contract := range contracts {
+			if contract == OtherContracts {
+				continue
+			}
+			stats, err := x.readStats(day, contract)
+			if err != nil {
+				return nil, err
+			}
+			if s, ok := total[contract]; ok {
+				s.add(stats)
+			} else {
+				total[contract] = stats
+			}
+		}
+	}
+	ret := make([]*ContractStats, 0, len(total))
+	for _, s := range total {
+		ret = append(ret, s)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		vi, vj := ret[i].value(order), ret[j].value(order)
+		if vi != vj {
+			return vi > vj
+		}
+		return string(ret[i].Contract[:]) < string(ret[j].Contract[:])
+	})
+	if uint64(len(ret)) > limit {
+		ret = ret[:limit]
+	}
+	return ret, nil
+}
+
+// compact folds the contracts of the day with fewer calls than the threshold into OtherContracts, and deletes
+// the callers and the journal of the day
+func (x *contractStatsIndexer) compact(day uint64) error {
+	size, err := x.journalSize(day)
+	if err != nil || size == 0 {
+		return err
+	}
+	var (
+		other = &ContractStats{Contract: OtherContracts}
+		kept  []byte
+	)
+	for i := uint64(0); i < size; i++ {
+		entry, err := x.kvStore.Get(_contractJournalNS, journalKey(day, i))
+		if err != nil {
+			return err
+		}
+		if len(entry) != _contractJournalLen {
+			return errors.Wrapf(db.ErrInvalid, "wrong length of contract journal %d", len(entry))
+		}
+		contract, caller := hash.BytesToHash160(entry[9:29]), hash.BytesToHash160(entry[29:])
+		switch entry[8] {
+		case _journalNewContract:
+			stats, err := x.readStats(day, contract)
+			if err != nil {
+				return err
+			}
+			if stats.Calls >= x.minDailyCalls {
+				kept = append(kept, contract[:]...)
+				break
+			}
+			other.add(stats)
+			x.batch.Delete(_contractDayStatsNS, statsKey(day, contract), "failed to delete stats")
+		case _journalNewCaller:
+			x.batch.Delete(_contractCallerNS, callerKey(day, contract, caller), "failed to delete caller")
+		}
+		x.batch.Delete(_contractJournalNS, journalKey(day, i), "failed to delete contract journal")
+	}
+	if other.Calls > 0 {
+		kept = append(kept, OtherContracts[:]...)
+		x.batch.Put(_contractDayStatsNS, statsKey(day, OtherContracts), other.serialize(), "failed to put stats")
+	}
+	x.batch.Put(_contractListNS, byteutil.Uint64ToBytesBigEndian(day), kept, "failed to put contracts")
+	x.batch.Delete(_contractJournalSizeNS, byteutil.Uint64ToBytesBigEndian(day), "failed to delete journal size")
+	return nil
+}
+
+// contractsOfDay returns the contracts with stats in the day, from the journal if the day is not compacted
+func (x *contractStatsIndexer) contractsOfDay(day uint64) ([]hash.Hash160, error) {
+	var contracts []hash.Hash160
+	if day < x.compacted {
+		v, err := x.kvStore.Get(_contractListNS, byteutil.Uint64ToBytesBigEndian(day))
+		switch errors.Cause(err) {
+		case nil:
+		case db.ErrNotExist, db.ErrBucketNotExist:
+			return nil, nil
+		default:
+			return nil, err
+		}
+		for ; len(v) >= 20; v = v[20:] {
+			contracts = append(contracts, hash.BytesToHash160(v[:20]))
+		}
+		return contracts, nil
+	}
+	size, err := x.journalSize(day)
+	if err != nil {
+		return nil, err
+	}
+	for i := uint64(0); i < size; i++ {
+		entry, err := x.kvStore.Get(_contractJournalNS, journalKey(day, i))
+		if err != nil {
+			return nil, err
+		}
+		if len(entry) == _contractJournalLen && entry[8] == _journalNewContract {
+			contracts = append(contracts, hash.BytesToHash160(entry[9:29]))
+		}
+	}
+	return contracts, nil
+}
+
+// getStats returns the stats of the contract in the day, which is placed into the dirty map to be committed later
+func (x *contractStatsIndexer) getStats(day uint64, contract hash.Hash160) (*ContractStats, error) {
+	key := string(statsKey(day, contract))
+	if stats, ok := x.dirty[key]; ok {
+		return stats, nil
+	}
+	stats, err := x.readStats(day, contract)
+	if err != nil {
+		return nil, err
+	}
+	x.dirty[key] = stats
+	return stats, nil
+}
+
+func (x *contractStatsIndexer) readStats(day uint64, contract hash.Hash160) (*ContractStats, error) {
+	stats := &ContractStats{Contract: contract}
+	v, err := x.kvStore.Get(_contractDayStatsNS, statsKey(day, contract))
+	switch errors.Cause(err) {
+	case nil:
+		if err := stats.deserialize(v); err != nil {
+			return nil, err
+		}
+	case db.ErrNotExist, db.ErrBucketNotExist:
+	default:
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (x *contractStatsIndexer) seenCaller(day uint64, contract, caller hash.Hash160) (bool, error) {
+	key := callerKey(day, contract, caller)
+	if _, ok := x.callers[string(key)]; ok {
+		return true, nil
+	}
+	_, err := x.kvStore.Get(_contractCallerNS, key)
+	switch errors.Cause(err) {
+	case nil:
+		return true, nil
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (x *contractStatsIndexer) journalSize(day uint64) (uint64, error) {
+	if size, ok := x.journalSizes[day]; ok {
+		return size, nil
+	}
+	size, err := x.getUint64(_contractJournalSizeNS, byteutil.Uint64ToBytesBigEndian(day))
+	if err != nil {
+		return 0, err
+	}
+	x.journalSizes[day] = size
+	return size, nil
+}
+
+func (x *contractStatsIndexer) appendJournal(day, height uint64, kind byte, contract, caller hash.Hash160) error {
+	size, err := x.journalSize(day)
+	if err != nil {
+		return err
+	}
+	entry := make([]byte, 0, _contractJournalLen)
+	entry = append(entry, byteutil.Uint64ToBytesBigEndian(height)...)
+	entry = append(entry, kind)
+	entry = append(entry, contract[:]...)
+	entry = append(entry, caller[:]...)
+	x.batch.Put(_contractJournalNS, journalKey(day, size), entry, "failed to put contract journal")
+	x.journalSizes[day] = size + 1
+	return nil
+}
+
+func (x *contractStatsIndexer) getUint64(ns string, key []byte) (uint64, error) {
+	v, err := x.kvStore.Get(ns, key)
+	switch errors.Cause(err) {
+	case nil:
+		return byteutil.BytesToUint64BigEndian(v), nil
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return 0, nil
+	default:
+		return 0, err
+	}
+}
+
+// commit writes the changes and the height
+func (x *contractStatsIndexer) commit(height uint64) error {
+	for key, stats := range x.dirty {
+		if stats.Calls == 0 {
+			continue
+		}
+		x.batch.Put(_contractDayStatsNS, []byte(key), stats.serialize(), "failed to put stats")
+	}
+	for day, size := range x.journalSizes {
+		x.batch.Put(_contractJournalSizeNS, byteutil.Uint64ToBytesBigEndian(day), byteutil.Uint64ToBytesBigEndian(size), "failed to put journal size")
+	}
+	if x.compactedDirty {
+		x.batch.Put(_contractStatsNS, _contractStatsCompactedKey, byteutil.Uint64ToBytesBigEndian(x.compacted), "failed to put compacted day")
+	}
+	x.batch.Put(_contractStatsNS, _contractStatsHeightKey, byteutil.Uint64ToBytesBigEndian(height), "failed to put height")
+	if err := x.kvStore.WriteBatch(x.batch); err != nil {
+		return err
+	}
+	x.height = height
+	return nil
+}
+
+// clear drops the changes not committed, and reloads the compacted day in case the commit failed
+func (x *contractStatsIndexer) clear() {
+	x.batch.Clear()
+	x.dirty = make(map[string]*ContractStats)
+	x.journalSizes = make(map[uint64]uint64)
+	x.callers = make(map[string]struct{})
+	if x.compactedDirty {
+		if compacted, err := x.getUint64(_contractStatsNS, _contractStatsCompactedKey); err == nil {
+			x.compacted = compacted
+		}
+		x.compactedDirty = false
+	}
+}
+
+type executionCall struct {
+	contract hash.Hash160
+	caller   hash.Hash160
+	gas      uint64
+	failed   bool
+}
+
+// executionCalls returns the calls of the executions to the contracts in the block, the contract creations are
+// not calls
+func executionCalls(blk *block.Block) ([]*executionCall, error) {
+	receipts := make(map[hash.Hash256]*action.Receipt, len(blk.Receipts))
+	for _, r := range blk.Receipts {
+		receipts[r.ActionHash] = r
+	}
+	var calls []*executionCall
+	for _, selp := range blk.Actions {
+		exec, ok := selp.Action().(*action.Execution)
+		if !ok || exec.Contract() == action.EmptyAddress {
+			continue
+		}
+		h, err := selp.Hash()
+		if err != nil {
+			return nil, err
+		}
+		r, ok := receipts[h]
+		if !ok {
+			return nil, errors.Wrapf(db.ErrInvalid, "receipt of action %x not found in block %d", h, blk.Height())
+		}
+		contract, err := address.FromString(exec.Contract())
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid contract address %s", exec.Contract())
+		}
+		calls = append(calls, &executionCall{
+			contract: hash.BytesToHash160(contract.Bytes()),
+			caller:   hash.BytesToHash160(selp.SenderAddress().Bytes()),
+			gas:      r.GasConsumed,
+			failed:   r.Status != uint64(iotextypes.ReceiptStatus_Success),
+		})
+	}
+	return calls, nil
+}
+
+func (s *ContractStats) add(o *ContractStats) {
+	s.Calls += o.Calls
+	s.GasConsumed += o.GasConsumed
+	s.Failures += o.Failures
+	s.UniqueCallers += o.UniqueCallers
+}
+
+func (s *ContractStats) value(order ContractStatsOrder) uint64 {
+	switch order {
+	case OrderByGasConsumed:
+		return s.GasConsumed
+	case OrderByFailures:
+		return s.Failures
+	case OrderByUniqueCallers:
+		return s.UniqueCallers
+	default:
+		return s.Calls
+	}
+}
+
+func (s *ContractStats) serialize() []byte {
+	b := make([]byte, 0, _contractStatsLen)
+	b = append(b, byteutil.Uint64ToBytesBigEndian(s.Calls)...)
+	b = append(b, byteutil.Uint64ToBytesBigEndian(s.GasConsumed)...)
+	b = append(b, byteutil.Uint64ToBytesBigEndian(s.Failures)...)
+	return append(b, byteutil.Uint64ToBytesBigEndian(s.UniqueCallers)...)
+}
+
+func (s *ContractStats) deserialize(buf []byte) error {
+	if len(buf) != _contractStatsLen {
+		return errors.Wrapf(db.ErrInvalid, "wrong length of contract stats %d", len(buf))
+	}
+	s.Calls = byteutil.BytesToUint64BigEndian(buf[:8])
+	s.GasConsumed = byteutil.BytesToUint64BigEndian(buf[8:16])
+	s.Failures = byteutil.BytesToUint64BigEndian(buf[16:24])
+	s.UniqueCallers = byteutil.BytesToUint64BigEndian(buf[24:])
+	return nil
+}
+
+// DayOf returns the number of days since the unix epoch in UTC of the time
+func DayOf(t time.Time) uint64 {
+	if t.Unix() < 0 {
+		return 0
+	}
+	return uint64(t.Unix()) / _secondsPerDay
+}
+
+func statsKey(day uint64, contract hash.Hash160) []byte {
+	return append(byteutil.Uint64ToBytesBigEndian(day), contract[:]...)
+}
+
+func callerKey(day uint64, contract, caller hash.Hash160) []byte {
+	return append(statsKey(day, contract), caller[:]...)
+}
+
+func journalKey(day, seq uint64) []byte {
+	return append(byteutil.Uint64ToBytesBigEndian(day), byteutil.Uint64ToBytesBigEndian(seq)...)
+}
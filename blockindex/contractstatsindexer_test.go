@@ -0,0 +1,172 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestContractStatsIndexer(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	type call struct {
+		contract, caller int
+		gas              uint64
+		failed           bool
+	}
+	var (
+		addr = func(i int) hash.Hash160 {
+			return hash.BytesToHash160(identityset.Address(i).Bytes())
+		}
+		c1, c2 = 10, 11
+		day0   = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+		d0     = DayOf(day0)
+		nonce  uint64
+		newBlk = func(height uint64, ts time.Time, calls ...call) *block.Block {
+			var (
+				acts     []*action.SealedEnvelope
+				receipts []*action.Receipt
+			)
+			for _, c := range calls {
+				nonce++
+				contract := ""
+				if c.contract > 0 {
+					contract = identityset.Address(c.contract).String()
+				}
+				selp, err := action.SignedExecution(contract, identityset.PrivateKey(c.caller), nonce, big.NewInt(0), 100000, big.NewInt(1), nil)
+				r.NoError(err)
+				h, err := selp.Hash()
+				r.NoError(err)
+				status := uint64(iotextypes.ReceiptStatus_Success)
+				if c.failed {
+					status = uint64(iotextypes.ReceiptStatus_ErrExecutionReverted)
+				}
+				acts = append(acts, selp)
+				receipts = append(receipts, &action.Receipt{ActionHash: h, GasConsumed: c.gas, Status: status})
+			}
+			blk, err := block.NewTestingBuilder().
+				SetHeight(height).
+				SetTimeStamp(ts).
+				AddActions(acts...).
+				SetReceipts(receipts).
+				SignAndBuild(identityset.PrivateKey(27))
+			r.NoError(err)
+			return &blk
+		}
+		blks = []*block.Block{
+			nil,
+			// the contract creation is not a call
+			newBlk(1, day0.Add(time.Hour), call{c1, 1, 100, false}, call{c1, 2, 50, true}, call{c2, 1, 30, false}, call{0, 1, 1000, false}),
+			newBlk(2, day0.Add(2*time.Hour), call{c1, 1, 100, false}),
+			newBlk(3, day0.Add(25*time.Hour), call{c2, 2, 40, false}),
+			newBlk(4, day0.Add(49*time.Hour), call{c1, 1, 10, false}),
+		}
+	)
+	r.Equal(d0, DayOf(blks[2].Timestamp()))
+
+	cfg := db.DefaultConfig
+	cfg.DbPath = t.TempDir() + "/contractstats.db"
+	kv := db.NewBoltDB(cfg)
+	indexer, err := NewContractStatsIndexer(kv, 2)
+	r.NoError(err)
+	r.NoError(indexer.Start(ctx))
+
+	checkStats := func(contract int, from, to uint64, expected ...ContractDayStats) {
+		stats, err := indexer.ContractStats(addr(contract), from, to)
+		r.NoError(err)
+		r.Len(stats, len(expected))
+		for i := range expected {
+			expected[i].Contract = addr(contract)
+			r.Equal(expected[i], *stats[i])
+		}
+	}
+	checkTop := func(from, to uint64, order ContractStatsOrder, limit uint64, expected ...int) {
+		stats, err := indexer.TopContracts(from, to, order, limit)
+		r.NoError(err)
+		r.Len(stats, len(expected))
+		for i := range expected {
+			r.Equal(addr(expected[i]), stats[i].Contract)
+		}
+	}
+
+	for _, blk := range blks[1:3] {
+		r.NoError(indexer.PutBlock(ctx, blk))
+	}
+	checkStats(c1, d0, d0, ContractDayStats{Day: d0, ContractStats: ContractStats{Calls: 3, GasConsumed: 250, Failures: 1, UniqueCallers: 2}})
+	checkStats(c2, d0, d0, ContractDayStats{Day: d0, ContractStats: ContractStats{Calls: 1, GasConsumed: 30, UniqueCallers: 1}})
+
+	// replaying the indexed blocks doesn't change the stats
+	for _, blk := range blks[1:3] {
+		r.NoError(indexer.PutBlock(ctx, blk))
+	}
+	checkStats(c1, d0, d0, ContractDayStats{Day: d0, ContractStats: ContractStats{Calls: 3, GasConsumed: 250, Failures: 1, UniqueCallers: 2}})
+	r.ErrorIs(indexer.PutBlock(ctx, blks[4]), db.ErrInvalid)
+
+	// the deleted tip block is reverted
+	r.NoError(indexer.PutBlock(ctx, blks[3]))
+	checkStats(c2, d0, d0+1,
+		ContractDayStats{Day: d0, ContractStats: ContractStats{Calls: 1, GasConsumed: 30, UniqueCallers: 1}},
+		ContractDayStats{Day: d0 + 1, ContractStats: ContractStats{Calls: 1, GasConsumed: 40, UniqueCallers: 1}},
+	)
+	r.NoError(indexer.DeleteTipBlock(ctx, blks[3]))
+	checkStats(c2, d0+1, d0+1)
+	height, err := indexer.Height()
+	r.NoError(err)
+	r.Equal(uint64(2), height)
+	r.NoError(indexer.PutBlock(ctx, blks[3]))
+
+	checkTop(d0, d0+1, OrderByCalls, 10, c1, c2)
+	checkTop(d0, d0+1, OrderByGasConsumed, 1, c1)
+	checkTop(d0, d0+1, OrderByFailures, 10, c1, c2)
+	checkTop(d0+1, d0+1, OrderByCalls, 10, c2)
+	_, err = indexer.TopContracts(d0, d0, OrderByUniqueCallers+1, 10)
+	r.ErrorIs(err, db.ErrInvalid)
+	_, err = indexer.ContractStats(addr(c1), d0+1, d0)
+	r.ErrorIs(err, db.ErrInvalid)
+
+	// the day before yesterday is compacted, c2 with 1 call is folded into the other contracts
+	r.NoError(indexer.PutBlock(ctx, blks[4]))
+	checkStats(c2, d0, d0)
+	checkStats(c1, d0, d0, ContractDayStats{Day: d0, ContractStats: ContractStats{Calls: 3, GasConsumed: 250, Failures: 1, UniqueCallers: 2}})
+	stats, err := indexer.ContractStats(OtherContracts, d0, d0)
+	r.NoError(err)
+	r.Equal([]*ContractDayStats{{Day: d0, ContractStats: ContractStats{Contract: OtherContracts, Calls: 1, GasConsumed: 30, UniqueCallers: 1}}}, stats)
+	checkTop(d0, d0+2, OrderByCalls, 10, c1, c2)
+	checkTop(d0, d0, OrderByCalls, 10, c1)
+
+	// the stats are kept after restart, and the blocks of the compacted day cannot be deleted
+	r.NoError(indexer.Stop(ctx))
+	indexer, err = NewContractStatsIndexer(db.NewBoltDB(cfg), 2)
+	r.NoError(err)
+	r.NoError(indexer.Start(ctx))
+	defer func() {
+		r.NoError(indexer.Stop(ctx))
+	}()
+	height, err = indexer.Height()
+	r.NoError(err)
+	r.Equal(uint64(4), height)
+	checkStats(c1, d0, d0+2,
+		ContractDayStats{Day: d0, ContractStats: ContractStats{Calls: 3, GasConsumed: 250, Failures: 1, UniqueCallers: 2}},
+		ContractDayStats{Day: d0 + 2, ContractStats: ContractStats{Calls: 1, GasConsumed: 10, UniqueCallers: 1}},
+	)
+	r.NoError(indexer.DeleteTipBlock(ctx, blks[4]))
+	r.NoError(indexer.DeleteTipBlock(ctx, blks[3]))
+	r.ErrorIs(indexer.DeleteTipBlock(ctx, blks[2]), db.ErrInvalid)
+	checkStats(c1, d0+2, d0+2)
+}
@@ -6,14 +6,20 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockindex/indexpb"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 )
 
 func TestActionIndex(t *testing.T) {
 	require := require.New(t)
 
 	ad := []*ActionIndex{
-		{1048000},
-		{1048001},
+		{blkHeight: 1048000},
+		{blkHeight: 1048001},
+		{blkHeight: 1048002, hashVersion: action.HashV2},
 	}
 
 	for i := range ad {
@@ -22,6 +28,10 @@ func TestActionIndex(t *testing.T) {
 		require.NoError(bd2.Deserialize(s))
 		require.Equal(ad[i], bd2)
 	}
+	// the index of a v1 action is the same as before the hash versions are introduced
+	require.Equal(action.HashV1, ad[0].HashVersion())
+	require.Equal(byteutil.Must(proto.Marshal(&indexpb.ActionIndex{BlkHeight: 1048000})), ad[0].Serialize())
+	require.Equal(action.HashV2, ad[2].HashVersion())
 }
 
 func TestBlockIndex(t *testing.T) {
@@ -44,16 +44,22 @@ const (
 	_transferAmountNS                 = "tfa"
 )
 
-// IndexBuilder defines the index builder
-type IndexBuilder struct {
-	timerFactory *prometheustimer.TimerFactory
-	dao          blockdao.BlockDAO
-	indexer      Indexer
-	genesis      genesis.Genesis
-}
+type (
+	// IndexBuilder defines the index builder
+	IndexBuilder struct {
+		timerFactory *prometheustimer.TimerFactory
+		dao          blockdao.BlockDAO
+		indexer      Indexer
+		genesis      genesis.Genesis
+		reindexer    *reindexer
+	}
+
+	// IndexBuilderOption sets an option of the index builder
+	IndexBuilderOption func(*IndexBuilder) error
+)
 
 // NewIndexBuilder instantiates an index builder
-func NewIndexBuilder(chainID uint32, g genesis.Genesis, dao blockdao.BlockDAO, indexer Indexer) (*IndexBuilder, error) {
+func NewIndexBuilder(chainID uint32, g genesis.Genesis, dao blockdao.BlockDAO, indexer Indexer, opts ...IndexBuilderOption) (*IndexBuilder, error) {
 	timerFactory, err := prometheustimer.New(
 		"iotex_indexer_batch_time",
 		"Indexer batch time",
@@ -63,12 +69,18 @@ func NewIndexBuilder(chainID uint32, g genesis.Genesis, dao blockdao.BlockDAO, i
 	if err != nil {
 		return nil, err
 	}
-	return &IndexBuilder{
+	ib := &IndexBuilder{
 		timerFactory: timerFactory,
 		dao:          dao,
 		indexer:      indexer,
 		genesis:      g,
-	}, nil
+	}
+	for _, opt := range opts {
+		if err := opt(ib); err != nil {
+			return nil, err
+		}
+	}
+	return ib, nil
 }
 
 // Start starts the index builder
@@ -76,6 +88,10 @@ func (ib *IndexBuilder) Start(ctx context.Context) error {
 	if err := ib.indexer.Start(ctx); err != nil {
 		return err
 	}
+	if ib.reindexer != nil {
+		// the indexer keeps serving as is, while the index is rebuilt
+		return ib.startReindex(ctx)
+	}
 	if err := ib.init(ctx); err != nil {
 		return err
 	}
@@ -85,6 +101,11 @@ func (ib *IndexBuilder) Start(ctx context.Context) error {
 
 // Stop stops the index builder
 func (ib *IndexBuilder) Stop(ctx context.Context) error {
+	if ib.reindexer != nil {
+		if err := ib.stopReindex(ctx); err != nil {
+			return err
+		}
+	}
 	return ib.indexer.Stop(ctx)
 }
 
@@ -95,6 +116,17 @@ func (ib *IndexBuilder) Indexer() Indexer {
 
 // ReceiveBlock handles the block and create the indices for the actions and receipts in it
 func (ib *IndexBuilder) ReceiveBlock(blk *block.Block) error {
+	if ib.reindexer != nil {
+		height, err := ib.indexer.Height()
+		if err != nil {
+			return err
+		}
+		// the block is queued for the index being rebuilt, and is indexed into the indexer serving only if it
+		// is caught up, or the block has been indexed by the reindex switched over
+		if ib.reindexer.receiveBlock(blk) && blk.Height() != height+1 || blk.Height() <= height {
+			return nil
+		}
+	}
 	timer := ib.timerFactory.NewTimer("indexBlock")
 	if err := ib.indexer.PutBlock(genesis.WithGenesisContext(context.Background(), ib.genesis), blk); err != nil {
 		log.L().Error(
@@ -209,3 +209,96 @@ func classifyActions(actions []*action.SealedEnvelope) ([]*action.Transfer, []*a
 	}
 	return tsfs, exes
 }
+
+type blockingDAO struct {
+	blockdao.BlockDAO
+	release chan struct{}
+}
+
+func (d *blockingDAO) GetBlockByHeight(height uint64) (*block.Block, error) {
+	<-d.release
+	return d.BlockDAO.GetBlockByHeight(height)
+}
+
+func TestIndexBuilderReindex(t *testing.T) {
+	require := require.New(t)
+	ctx := protocol.WithBlockchainCtx(
+		genesis.WithGenesisContext(context.Background(), genesis.Default),
+		protocol.BlockchainCtx{
+			ChainID: blockchain.DefaultConfig.ID,
+		})
+	blks := getTestBlocks(t)
+	t1Hash, _ := blks[0].Actions[0].Hash()
+	t3Hash, _ := blks[2].Actions[0].Hash()
+
+	memstore, err := filedao.NewFileDAOInMemForTest()
+	require.NoError(err)
+	dao := &blockingDAO{BlockDAO: memstore, release: make(chan struct{})}
+	require.NoError(dao.Start(ctx))
+	defer func() {
+		require.NoError(dao.Stop(ctx))
+	}()
+	require.NoError(dao.PutBlock(ctx, blks[0]))
+	require.NoError(dao.PutBlock(ctx, blks[1]))
+
+	// the indexer serving has indexed the first 2 blocks, and the index is rebuilt into the shadow indexer
+	indexer, err := NewIndexer(db.NewMemKVStore(), hash.ZeroHash256)
+	require.NoError(err)
+	require.NoError(indexer.Start(ctx))
+	require.NoError(indexer.PutBlocks(ctx, blks[:2]))
+	require.NoError(indexer.Stop(ctx))
+	shadow, err := NewIndexer(db.NewMemKVStore(), hash.ZeroHash256)
+	require.NoError(err)
+	_, err = NewIndexBuilder(blockchain.DefaultConfig.ID, genesis.Default, dao, indexer, ReindexOption(DefaultConfig, nil, nil))
+	require.Error(err)
+	ib, err := NewIndexBuilder(blockchain.DefaultConfig.ID, genesis.Default, dao, indexer, ReindexOption(DefaultConfig, shadow, func(ctx context.Context) (Indexer, error) {
+		if err := indexer.Stop(ctx); err != nil {
+			return nil, err
+		}
+		return shadow, nil
+	}))
+	require.NoError(err)
+	require.NoError(ib.Start(ctx))
+	defer func() {
+		require.NoError(ib.Stop(ctx))
+	}()
+	status, err := ib.Status()
+	require.NoError(err)
+	require.True(status.Reindexing)
+	require.EqualValues(2, status.TargetHeight)
+	require.Zero(status.CurrentHeight)
+
+	// the indexer keeps serving during the reindex, and the block received is queued for the shadow indexer
+	actIndex, err := ib.Indexer().GetActionIndex(t1Hash[:])
+	require.NoError(err)
+	require.Equal(blks[0].Height(), actIndex.BlockHeight())
+	require.NoError(dao.PutBlock(ctx, blks[2]))
+	require.NoError(ib.ReceiveBlock(blks[2]))
+	height, err := ib.Indexer().Height()
+	require.NoError(err)
+	require.EqualValues(3, height)
+	status, err = ib.Status()
+	require.NoError(err)
+	require.True(status.Reindexing)
+	require.EqualValues(3, status.TargetHeight)
+
+	// switch over to the shadow indexer once it catches up
+	close(dao.release)
+	require.Eventually(func() bool {
+		status, err := ib.Status()
+		require.NoError(err)
+		return !status.Reindexing
+	}, 5*time.Second, 10*time.Millisecond)
+	status, err = ib.Status()
+	require.NoError(err)
+	require.NoError(status.Err)
+	require.EqualValues(3, status.TargetHeight)
+	require.EqualValues(3, status.CurrentHeight)
+	require.Equal(shadow, ib.Indexer().(*switchableIndexer).indexer)
+	actIndex, err = ib.Indexer().GetActionIndex(t3Hash[:])
+	require.NoError(err)
+	require.Equal(blks[2].Height(), actIndex.BlockHeight())
+	total, err := ib.Indexer().GetTotalActions()
+	require.NoError(err)
+	require.EqualValues(9, total)
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"sort"
 	"sync"
 
 	"github.com/iotexproject/go-pkgs/hash"
@@ -59,6 +60,7 @@ type (
 		GetActionHashFromIndex(uint64, uint64) ([][]byte, error)
 		GetActionCountByAddress(hash.Hash160) (uint64, error)
 		GetActionsByAddress(hash.Hash160, uint64, uint64) ([][]byte, error)
+		GetActionsByAddressWithFilter(hash.Hash160, *ActionFilter, uint64, uint64) ([][]byte, error)
 	}
 
 	// blockIndexer implements the Indexer interface
@@ -68,6 +70,7 @@ type (
 		kvStore     db.KVStoreWithRange
 		batch       batch.KVStoreBatch
 		dirtyAddr   addrIndex
+		dirtyFilter addrIndex
 		tbk         db.CountingIndex
 		tac         db.CountingIndex
 	}
@@ -86,6 +89,7 @@ func NewIndexer(kv db.KVStore, genesisHash hash.Hash256) (Indexer, error) {
 		kvStore:     kvRange,
 		batch:       batch.NewBatch(),
 		dirtyAddr:   make(addrIndex),
+		dirtyFilter: make(addrIndex),
 		genesisHash: genesisHash,
 	}
 	return &x, nil
@@ -171,7 +175,7 @@ func (x *blockIndexer) DeleteTipBlock(ctx context.Context, blk *block.Block) err
 			return err
 		}
 		x.batch.Delete(_actionToBlockHashNS, actHash[_hashOffset:], fmt.Sprintf("failed to delete action hash %x", actHash))
-		if err := x.indexAction(actHash, selp, false, fCtx.TolerateLegacyAddress); err != nil {
+		if err := x.indexAction(height, actHash, selp, false, fCtx.TolerateLegacyAddress); err != nil {
 			return err
 		}
 	}
@@ -298,6 +302,115 @@ func (x *blockIndexer) GetActionsByAddress(addrBytes hash.Hash160, start, count
 	return addr.Range(start, count)
 }
 
+// GetActionsByAddressWithFilter returns hash of an address's actions[start, start+count) among those matching
+// the filter, in the same order as GetActionsByAddress
+func (x *blockIndexer) GetActionsByAddressWithFilter(addrBytes hash.Hash160, filter *ActionFilter, start, count uint64) ([][]byte, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+
+	if filter == nil {
+		return nil, errors.Wrap(db.ErrInvalid, "filter is nil")
+	}
+	matcher, err := newActionMatcher(filter)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := db.GetCountingIndex(x.kvStore, addrBytes[:])
+	if err != nil {
+		return nil, err
+	}
+	var (
+		filterIndex db.CountingIndex
+		filterSize  uint64
+	)
+	filterIndex, err = db.GetCountingIndex(x.kvStore, addrFilterBucket(addrBytes[:]))
+	switch errors.Cause(err) {
+	case nil:
+		filterSize = filterIndex.Size()
+	case db.ErrNotExist, db.ErrBucketNotExist:
+	default:
+		return nil, err
+	}
+	// the filter entries are aligned with the tail of the address index
+	offset := addr.Size() - filterSize
+	// find the first entry at or above the from height
+	var searchErr error
+	first := uint64(sort.Search(int(filterSize), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		v, err := filterIndex.Get(uint64(i))
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		e := &addrFilterEntry{}
+		if err := e.deserialize(v); err != nil {
+			searchErr = err
+			return true
+		}
+		return e.height >= filter.FromHeight
+	}))
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	if first == 0 && offset > 0 {
+		// check whether the actions indexed without the filter columns are in the range
+		lastLegacy, err := addr.Get(offset - 1)
+		if err != nil {
+			return nil, err
+		}
+		v, err := x.kvStore.Get(_actionToBlockHashNS, lastLegacy[_hashOffset:])
+		if err != nil {
+			return nil, err
+		}
+		a := &ActionIndex{}
+		if err := a.Deserialize(v); err != nil {
+			return nil, err
+		}
+		if a.BlockHeight() >= filter.FromHeight {
+			return nil, errors.Wrapf(ErrActionFilterNA, "address %x", addrBytes[:])
+		}
+	}
+	var hashes [][]byte
+	for i := first; i < filterSize && uint64(len(hashes)) < count; i += _addrFilterScanBatch {
+		n := filterSize - i
+		if n > _addrFilterScanBatch {
+			n = _addrFilterScanBatch
+		}
+		entries, err := filterIndex.Range(i, n)
+		if err != nil {
+			return nil, err
+		}
+		actHashes, err := addr.Range(offset+i, n)
+		if err != nil {
+			return nil, err
+		}
+		for j := range entries {
+			e := &addrFilterEntry{}
+			if err := e.deserialize(entries[j]); err != nil {
+				return nil, err
+			}
+			matched, beyond := matcher.match(e)
+			if beyond {
+				return hashes, nil
+			}
+			if !matched {
+				continue
+			}
+			if start > 0 {
+				start--
+				continue
+			}
+			hashes = append(hashes, actHashes[j])
+			if uint64(len(hashes)) == count {
+				break
+			}
+		}
+	}
+	return hashes, nil
+}
+
 func (x *blockIndexer) putBlock(ctx context.Context, blk *block.Block) error {
 	// the block to be indexed must be exactly current top + 1, otherwise counting index would not work correctly
 	height := blk.Height()
@@ -321,9 +434,6 @@ func (x *blockIndexer) putBlock(ctx context.Context, blk *block.Block) error {
 		return errors.Wrapf(err, "failed to put block %d index", height)
 	}
 
-	// store height of the block, so getReceiptByActionHash() can use height to directly pull receipts
-	ad := (&ActionIndex{
-		blkHeight: blk.Height()}).Serialize()
 	if err := x.tac.UseBatch(x.batch); err != nil {
 		return err
 	}
@@ -336,12 +446,16 @@ func (x *blockIndexer) putBlock(ctx context.Context, blk *block.Block) error {
 		if err != nil {
 			return err
 		}
+		// store height of the block, so getReceiptByActionHash() can use height to directly pull receipts
+		ad := (&ActionIndex{
+			blkHeight:   blk.Height(),
+			hashVersion: selp.HashVersion()}).Serialize()
 		x.batch.Put(_actionToBlockHashNS, actHash[_hashOffset:], ad, fmt.Sprintf("failed to put action hash %x", actHash))
 		// add to total account index
 		if err := x.tac.Add(actHash[:], true); err != nil {
 			return err
 		}
-		if err := x.indexAction(actHash, selp, true, fCtx.TolerateLegacyAddress); err != nil {
+		if err := x.indexAction(height, actHash, selp, true, fCtx.TolerateLegacyAddress); err != nil {
 			return err
 		}
 	}
@@ -351,13 +465,15 @@ func (x *blockIndexer) putBlock(ctx context.Context, blk *block.Block) error {
 // commit writes the changes
 func (x *blockIndexer) commit() error {
 	var commitErr error
-	for k, v := range x.dirtyAddr {
-		if commitErr == nil {
-			if err := v.Finalize(); err != nil {
-				commitErr = err
+	for _, dirty := range []addrIndex{x.dirtyAddr, x.dirtyFilter} {
+		for k, v := range dirty {
+			if commitErr == nil {
+				if err := v.Finalize(); err != nil {
+					commitErr = err
+				}
 			}
+			delete(dirty, k)
 		}
-		delete(x.dirtyAddr, k)
 	}
 	if commitErr != nil {
 		return commitErr
@@ -379,49 +495,93 @@ func (x *blockIndexer) commit() error {
 // getIndexerForAddr returns the counting indexer for an address
 // if batch is true, the indexer will be placed into a dirty map, to be committed later
 func (x *blockIndexer) getIndexerForAddr(addr []byte, batch bool) (db.CountingIndex, error) {
+	return x.getCountingIndex(x.dirtyAddr, addr, addr, batch)
+}
+
+// getFilterIndexerForAddr returns the counting indexer of the filter columns for an address
+func (x *blockIndexer) getFilterIndexerForAddr(addr []byte, batch bool) (db.CountingIndex, error) {
+	return x.getCountingIndex(x.dirtyFilter, addr, addrFilterBucket(addr), batch)
+}
+
+func (x *blockIndexer) getCountingIndex(dirty addrIndex, addr, name []byte, batch bool) (db.CountingIndex, error) {
 	if !batch {
-		return db.NewCountingIndexNX(x.kvStore, addr)
+		return db.NewCountingIndexNX(x.kvStore, name)
 	}
 	address := hash.BytesToHash160(addr)
-	indexer, ok := x.dirtyAddr[address]
+	indexer, ok := dirty[address]
 	if !ok {
 		// create indexer for addr if not exist
 		var err error
-		indexer, err = db.NewCountingIndexNX(x.kvStore, addr)
+		indexer, err = db.NewCountingIndexNX(x.kvStore, name)
 		if err != nil {
 			return nil, err
 		}
 		if err := indexer.UseBatch(x.batch); err != nil {
 			return nil, err
 		}
-		x.dirtyAddr[address] = indexer
+		dirty[address] = indexer
 	}
 	return indexer, nil
 }
 
-// indexAction builds index for an action
-func (x *blockIndexer) indexAction(actHash hash.Hash256, elp *action.SealedEnvelope, insert, tolerateLegacyAddress bool) error {
-	// add to sender's index
-	callerAddrBytes := elp.SrcPubkey().Hash()
-	sender, err := x.getIndexerForAddr(callerAddrBytes, insert)
+// indexActionForAddr adds the action to the index of the address and its filter columns, or reverts them
+func (x *blockIndexer) indexActionForAddr(addr []byte, actHash hash.Hash256, entry *addrFilterEntry, insert bool) error {
+	indexer, err := x.getIndexerForAddr(addr, insert)
 	if err != nil {
 		return err
 	}
-	if insert {
-		err = sender.Add(actHash[:], insert)
-	} else {
-		err = sender.Revert(1)
+	if !insert {
+		if err := indexer.Revert(1); err != nil {
+			return err
+		}
+		filter, err := db.GetCountingIndex(x.kvStore, addrFilterBucket(addr))
+		if err != nil {
+			if errors.Cause(err) == db.ErrBucketNotExist || errors.Cause(err) == db.ErrNotExist {
+				// the action was indexed without the filter columns
+				return nil
+			}
+			return err
+		}
+		if filter.Size() == 0 {
+			return nil
+		}
+		return filter.Revert(1)
+	}
+	if err := indexer.Add(actHash[:], insert); err != nil {
+		return err
 	}
+	filter, err := x.getFilterIndexerForAddr(addr, insert)
 	if err != nil {
 		return err
 	}
+	return filter.Add(entry.serialize(), insert)
+}
 
+// indexAction builds index for an action
+func (x *blockIndexer) indexAction(height uint64, actHash hash.Hash256, elp *action.SealedEnvelope, insert, tolerateLegacyAddress bool) error {
+	var (
+		callerAddrBytes = elp.SrcPubkey().Hash()
+		actType         = actionType(elp)
+		dstDirection    = DirectionRecipient
+		senderEntry     = &addrFilterEntry{
+			height:     height,
+			direction:  DirectionSender,
+			actionType: actType,
+		}
+	)
+	if _, ok := elp.Action().(*action.Execution); ok {
+		dstDirection = DirectionContract
+	}
 	dst, ok := elp.Destination()
 	if !ok || dst == "" {
-		return nil
+		// add to sender's index
+		return x.indexActionForAddr(callerAddrBytes, actHash, senderEntry, insert)
 	}
 
-	var dstAddr address.Address
+	var (
+		dstAddr address.Address
+		err     error
+	)
 	if tolerateLegacyAddress {
 		dstAddr, err = address.FromStringLegacy(dst)
 	} else {
@@ -434,18 +594,17 @@ func (x *blockIndexer) indexAction(actHash hash.Hash256, elp *action.SealedEnvel
 
 	if bytes.Equal(dstAddrBytes, callerAddrBytes) {
 		// recipient is same as sender
-		return nil
+		senderEntry.direction |= dstDirection
+		return x.indexActionForAddr(callerAddrBytes, actHash, senderEntry, insert)
 	}
 
-	// add to recipient's index
-	recipient, err := x.getIndexerForAddr(dstAddrBytes, insert)
-	if err != nil {
+	// add to sender's and recipient's index
+	if err := x.indexActionForAddr(callerAddrBytes, actHash, senderEntry, insert); err != nil {
 		return err
 	}
-	if insert {
-		err = recipient.Add(actHash[:], insert)
-	} else {
-		err = recipient.Revert(1)
-	}
-	return err
+	return x.indexActionForAddr(dstAddrBytes, actHash, &addrFilterEntry{
+		height:     height,
+		direction:  dstDirection,
+		actionType: actType,
+	}, insert)
 }
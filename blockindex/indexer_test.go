@@ -49,7 +49,7 @@ func getTestBlocks(t *testing.T) []*block.Block {
 	require.NoError(t, err)
 	execution2, err := action.SignedExecution(identityset.Address(31).String(), identityset.PrivateKey(29), 2, big.NewInt(0), 0, big.NewInt(0), nil)
 	require.NoError(t, err)
-	execution3, err := action.SignedExecution(identityset.Address(31).String(), identityset.PrivateKey(30), 3, big.NewInt(2), 0, big.NewInt(0), nil)
+	execution3, err := action.SignedExecution(identityset.Address(31).String(), identityset.PrivateKey(30), 3, big.NewInt(2), 0, big.NewInt(0), nil, action.WithHashVersion(action.HashV2))
 	require.NoError(t, err)
 
 	hash1 := hash.Hash256{}
@@ -211,6 +211,7 @@ func TestIndexer(t *testing.T) {
 				actIndex, err := indexer.GetActionIndex(indexTests[0].hashTotal[i*3+j])
 				require.NoError(err)
 				require.Equal(blks[i].Height(), actIndex.blkHeight)
+				require.Equal(blks[i].Actions[j].HashVersion(), actIndex.HashVersion())
 			}
 		}
 
@@ -333,3 +334,105 @@ func TestIndexer(t *testing.T) {
 		testDelete(db.NewBoltDB(cfg), t)
 	})
 }
+
+func TestIndexerActionFilter(t *testing.T) {
+	require := require.New(t)
+	ctx := genesis.WithGenesisContext(context.Background(), genesis.Default)
+
+	blks := getTestBlocks(t)
+	// 28 and 29 both send to and receive from each other in block 4
+	amount := big.NewInt(1)
+	tsf7, err := action.SignedTransfer(identityset.Address(29).String(), identityset.PrivateKey(28), 5, amount, nil, testutil.TestGasLimit, big.NewInt(0))
+	require.NoError(err)
+	tsf8, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(29), 5, amount, nil, testutil.TestGasLimit, big.NewInt(0))
+	require.NoError(err)
+	blk4, err := block.NewTestingBuilder().
+		SetHeight(4).
+		SetPrevBlockHash(blks[2].HashBlock()).
+		SetTimeStamp(testutil.TimestampNow()).
+		AddActions(tsf7, tsf8).
+		SignAndBuild(identityset.PrivateKey(27))
+	require.NoError(err)
+	blks = append(blks, &blk4)
+	hashes := make([][][]byte, len(blks))
+	for i, blk := range blks {
+		for _, act := range blk.Actions {
+			h, err := act.Hash()
+			require.NoError(err)
+			hashes[i] = append(hashes[i], h[:])
+		}
+	}
+	var (
+		t1, t4, e1 = hashes[0][0], hashes[0][1], hashes[0][2]
+		e2         = hashes[1][2]
+		t6, e3     = hashes[2][1], hashes[2][2]
+		t7, t8     = hashes[3][0], hashes[3][1]
+		addr28     = hash.BytesToHash160(identityset.Address(28).Bytes())
+		addr29     = hash.BytesToHash160(identityset.Address(29).Bytes())
+		addr31     = hash.BytesToHash160(identityset.Address(31).Bytes())
+	)
+
+	kvStore := db.NewMemKVStore()
+	indexer, err := NewIndexer(kvStore, hash.ZeroHash256)
+	require.NoError(err)
+	require.NoError(indexer.Start(ctx))
+	defer func() {
+		require.NoError(indexer.Stop(ctx))
+	}()
+	require.NoError(indexer.PutBlocks(ctx, blks))
+
+	for _, v := range []struct {
+		addr         hash.Hash160
+		filter       ActionFilter
+		start, count uint64
+		expected     [][]byte
+	}{
+		// t1 is sent by 28 to itself
+		{addr28, ActionFilter{}, 0, 10, [][]byte{t1, t4, e1, t6, t7, t8}},
+		{addr28, ActionFilter{Direction: DirectionSender}, 0, 10, [][]byte{t1, t4, e1, t7}},
+		{addr28, ActionFilter{Direction: DirectionRecipient}, 0, 10, [][]byte{t1, t6, t8}},
+		{addr28, ActionFilter{Direction: DirectionSender, FromHeight: 4}, 0, 10, [][]byte{t7}},
+		{addr28, ActionFilter{Direction: DirectionRecipient, FromHeight: 4}, 0, 10, [][]byte{t8}},
+		{addr29, ActionFilter{FromHeight: 4, ToHeight: 4}, 0, 10, [][]byte{t7, t8}},
+		{addr29, ActionFilter{Direction: DirectionSender | DirectionRecipient, FromHeight: 4}, 1, 10, [][]byte{t8}},
+		{addr28, ActionFilter{ActionTypes: []string{"execution"}}, 0, 10, [][]byte{e1}},
+		{addr28, ActionFilter{ActionTypes: []string{"transfer"}, ToHeight: 3}, 0, 10, [][]byte{t1, t4, t6}},
+		{addr28, ActionFilter{ActionTypes: []string{"transfer", "execution"}, ToHeight: 3}, 1, 2, [][]byte{t4, e1}},
+		{addr28, ActionFilter{Direction: DirectionContract}, 0, 10, nil},
+		{addr31, ActionFilter{Direction: DirectionContract, FromHeight: 2}, 0, 10, [][]byte{e2, e3}},
+		{addr31, ActionFilter{Direction: DirectionContract}, 3, 10, nil},
+		{addr31, ActionFilter{FromHeight: 5}, 0, 10, nil},
+	} {
+		actions, err := indexer.GetActionsByAddressWithFilter(v.addr, &v.filter, v.start, v.count)
+		require.NoError(err)
+		require.Equal(v.expected, actions)
+	}
+	_, err = indexer.GetActionsByAddressWithFilter(addr28, &ActionFilter{ActionTypes: []string{"unknown"}}, 0, 10)
+	require.Equal(db.ErrInvalid, errors.Cause(err))
+	_, err = indexer.GetActionsByAddressWithFilter(addr28, &ActionFilter{FromHeight: 3, ToHeight: 2}, 0, 10)
+	require.Equal(db.ErrInvalid, errors.Cause(err))
+	_, err = indexer.GetActionsByAddressWithFilter(hash.BytesToHash160(identityset.Address(13).Bytes()), &ActionFilter{}, 0, 10)
+	require.Equal(db.ErrNotExist, errors.Cause(err))
+
+	// the filter columns are reverted along with the block
+	require.NoError(indexer.DeleteTipBlock(ctx, blks[3]))
+	actions, err := indexer.GetActionsByAddressWithFilter(addr28, &ActionFilter{Direction: DirectionSender}, 0, 10)
+	require.NoError(err)
+	require.Equal([][]byte{t1, t4, e1}, actions)
+
+	// the actions indexed without the filter columns can only be filtered out by height
+	filterIndex, err := db.GetCountingIndex(kvStore, addrFilterBucket(addr28[:]))
+	require.NoError(err)
+	require.NoError(filterIndex.Revert(filterIndex.Size()))
+	require.NoError(indexer.PutBlock(ctx, blks[3]))
+	_, err = indexer.GetActionsByAddressWithFilter(addr28, &ActionFilter{Direction: DirectionSender}, 0, 10)
+	require.Equal(ErrActionFilterNA, errors.Cause(err))
+	_, err = indexer.GetActionsByAddressWithFilter(addr28, &ActionFilter{FromHeight: 3}, 0, 10)
+	require.Equal(ErrActionFilterNA, errors.Cause(err))
+	actions, err = indexer.GetActionsByAddressWithFilter(addr28, &ActionFilter{Direction: DirectionSender, FromHeight: 4}, 0, 10)
+	require.NoError(err)
+	require.Equal([][]byte{t7}, actions)
+	count, err := indexer.GetActionCountByAddress(addr28)
+	require.NoError(err)
+	require.EqualValues(6, count)
+}
@@ -96,7 +96,8 @@ type ActionIndex struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	BlkHeight uint64 `protobuf:"varint,1,opt,name=blkHeight,proto3" json:"blkHeight,omitempty"`
+	BlkHeight   uint64 `protobuf:"varint,1,opt,name=blkHeight,proto3" json:"blkHeight,omitempty"`
+	HashVersion uint32 `protobuf:"varint,2,opt,name=hashVersion,proto3" json:"hashVersion,omitempty"`
 }
 
 func (x *ActionIndex) Reset() {
@@ -138,6 +139,13 @@ func (x *ActionIndex) GetBlkHeight() uint64 {
 	return 0
 }
 
+func (x *ActionIndex) GetHashVersion() uint32 {
+	if x != nil {
+		return x.HashVersion
+	}
+	return 0
+}
+
 var File_index_proto protoreflect.FileDescriptor
 
 var file_index_proto_rawDesc = []byte{
@@ -148,10 +156,12 @@ var file_index_proto_rawDesc = []byte{
 	0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
 	0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x73, 0x66, 0x41, 0x6d, 0x6f,
 	0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x74, 0x73, 0x66, 0x41, 0x6d,
-	0x6f, 0x75, 0x6e, 0x74, 0x22, 0x2b, 0x0a, 0x0b, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e,
+	0x6f, 0x75, 0x6e, 0x74, 0x22, 0x4d, 0x0a, 0x0b, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e,
 	0x64, 0x65, 0x78, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x6c, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x62, 0x6c, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68,
-	0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x74, 0x12, 0x20, 0x0a, 0x0b, 0x68, 0x61, 0x73, 0x68, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x68, 0x61, 0x73, 0x68, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
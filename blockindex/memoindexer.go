@@ -0,0 +1,402 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+const (
+	// MaxMemoLen is the max length of the payload of a transfer indexed as a memo
+	MaxMemoLen = 256
+
+	// _memoNS is the namespace storing the height of the memo indexer
+	_memoNS = "mm"
+	// _memoPrefix is the prefix of the bucket storing the transfers to a recipient with a memo
+	_memoPrefix = "mr"
+	// _memoTransferLen is 8-byte height, 32-byte action hash, 20-byte sender and 32-byte amount
+	_memoTransferLen = 8 + 32 + 20 + 32
+)
+
+var (
+	_memoHeightKey = []byte("height")
+
+	_memoMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_memo_indexer",
+			Help: "IoTeX transfer memo indexer counter.",
+		},
+		[]string{"type"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(_memoMtc)
+}
+
+type (
+	// MemoTransfer is a successful transfer with a memo in the payload
+	MemoTransfer struct {
+		Height     uint64
+		ActionHash hash.Hash256
+		Sender     hash.Hash160
+		Amount     *big.Int
+	}
+
+	// MemoTransferQuery queries the transfers to a recipient with a memo
+	MemoTransferQuery struct {
+		// Cursor is the position to continue from, which is returned by the previous query, 0 to start over
+		Cursor uint64
+		// Count is the max number of transfers returned
+		Count uint64
+		// FromHeight matches the transfers in blocks at or above the height
+		FromHeight uint64
+		// ToHeight matches the transfers in blocks at or below the height, 0 means no upper bound
+		ToHeight uint64
+	}
+
+	// MemoIndexer is the interface of the indexer of the transfers by recipient and memo
+	MemoIndexer interface {
+		blockdao.BlockIndexer
+		// TransfersByRecipientAndMemo returns the transfers to the recipient with the memo in ascending order, and
+		// the cursor of the next query, which is 0 if there is no more transfer
+		TransfersByRecipientAndMemo(hash.Hash160, []byte, *MemoTransferQuery) ([]*MemoTransfer, uint64, error)
+	}
+
+	// memoIndexer stores the successful transfers with a memo in the index of the recipient and the hash of the
+	// memo. A memo is a payload of valid UTF-8 up to MaxMemoLen bytes, binary payloads are skipped. The length is
+	// checked before the payload is decoded and hashed, so the work of a block is bounded by the number of its
+	// transfers, which is in turn bounded by the gas limit of the block
+	memoIndexer struct {
+		mutex   sync.RWMutex
+		kvStore db.KVStoreWithRange
+		batch   batch.KVStoreBatch
+		dirty   map[string]db.CountingIndex
+		height  uint64
+	}
+
+	memoTransferOfBlock struct {
+		bucket []byte
+		tsf    *MemoTransfer
+	}
+)
+
+// NewMemoIndexer creates a new memo indexer
+func NewMemoIndexer(kv db.KVStore) (MemoIndexer, error) {
+	if kv == nil {
+		return nil, errors.New("empty kvStore")
+	}
+	kvRange, ok := kv.(db.KVStoreWithRange)
+	if !ok {
+		return nil, errors.New("memo indexer can only be created from KVStoreWithRange")
+	}
+	return &memoIndexer{
+		kvStore: kvRange,
+		batch:   batch.NewBatch(),
+		dirty:   make(map[string]db.CountingIndex),
+	}, nil
+}
+
+// Start starts the memo indexer
+func (x *memoIndexer) Start(ctx context.Context) error {
+	if err := x.kvStore.Start(ctx); err != nil {
+		return err
+	}
+	h, err := x.kvStore.Get(_memoNS, _memoHeightKey)
+	switch errors.Cause(err) {
+	case nil:
+		x.height = byteutil.BytesToUint64BigEndian(h)
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		x.height = 0
+	default:
+		return err
+	}
+	return nil
+}
+
+// Stop stops the memo indexer
+func (x *memoIndexer) Stop(ctx context.Context) error {
+	return x.kvStore.Stop(ctx)
+}
+
+// Height returns the height of the memo indexer
+func (x *memoIndexer) Height() (uint64, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	return x.height, nil
+}
+
+// PutBlock indexes the successful transfers with a memo in the block
+func (x *memoIndexer) PutBlock(_ context.Context, blk *block.Block) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	height := blk.Height()
+	if height <= x.height {
+		// the block has been indexed
+		return nil
+	}
+	if height != x.height+1 {
+		return errors.Wrapf(db.ErrInvalid, "wrong block height %d, expecting %d", height, x.height+1)
+	}
+	tsfs, err := memoTransfers(blk)
+	if err != nil {
+		return err
+	}
+	receipts := make(map[hash.Hash256]*action.Receipt, len(blk.Receipts))
+	for _, r := range blk.Receipts {
+		receipts[r.ActionHash] = r
+	}
+	for _, t := range tsfs {
+		r, ok := receipts[t.tsf.ActionHash]
+		if !ok {
+			return errors.Wrapf(db.ErrInvalid, "receipt of action %x not found in block %d", t.tsf.ActionHash, height)
+		}
+		if r.Status != uint64(iotextypes.ReceiptStatus_Success) {
+			continue
+		}
+		index, err := x.getIndex(t.bucket)
+		if err != nil {
+			return err
+		}
+		if err := index.Add(t.tsf.serialize(), true); err != nil {
+			return err
+		}
+		_memoMtc.WithLabelValues("indexed").Inc()
+	}
+	return x.commit(height)
+}
+
+// DeleteTipBlock deletes the transfers in the tip block
+func (x *memoIndexer) DeleteTipBlock(_ context.Context, blk *block.Block) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	height := blk.Height()
+	if height != x.height {
+		return errors.Wrapf(db.ErrInvalid, "wrong block height %d, expecting %d", height, x.height)
+	}
+	tsfs, err := memoTransfers(blk)
+	if err != nil {
+		return err
+	}
+	// the failed transfers are not indexed, so the transfers at the tip height are counted from the end of each index
+	reverted := make(map[string]bool)
+	for _, t := range tsfs {
+		if reverted[string(t.bucket)] {
+			continue
+		}
+		reverted[string(t.bucket)] = true
+		index, err := db.GetCountingIndex(x.kvStore, t.bucket)
+		switch errors.Cause(err) {
+		case nil:
+		case db.ErrNotExist, db.ErrBucketNotExist:
+			continue
+		default:
+			return err
+		}
+		var count uint64
+		for size := index.Size(); count < size; count++ {
+			v, err := index.Get(size - count - 1)
+			if err != nil {
+				return err
+			}
+			if byteutil.BytesToUint64BigEndian(v[:8]) != height {
+				break
+			}
+		}
+		if count > 0 {
+			if err := index.Revert(count); err != nil {
+				return err
+			}
+		}
+	}
+	x.batch.Put(_memoNS, _memoHeightKey, byteutil.Uint64ToBytesBigEndian(height-1), "failed to put height")
+	if err := x.kvStore.WriteBatch(x.batch); err != nil {
+		return err
+	}
+	x.batch.Clear()
+	x.height = height - 1
+	return nil
+}
+
+// TransfersByRecipientAndMemo returns the transfers to the recipient with the memo
+func (x *memoIndexer) TransfersByRecipientAndMemo(recipient hash.Hash160, memo []byte, query *MemoTransferQuery) ([]*MemoTransfer, uint64, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+
+	if query == nil || query.Count == 0 {
+		return nil, 0, errors.Wrap(db.ErrInvalid, "count must be greater than 0")
+	}
+	if query.ToHeight != 0 && query.ToHeight < query.FromHeight {
+		return nil, 0, errors.Wrapf(db.ErrInvalid, "from height %d > to height %d", query.FromHeight, query.ToHeight)
+	}
+	if !isMemo(memo) {
+		return nil, 0, errors.Wrapf(db.ErrInvalid, "memo must be valid UTF-8 of 1 to %d bytes", MaxMemoLen)
+	}
+	index, err := db.GetCountingIndex(x.kvStore, memoBucket(recipient, memo))
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return nil, 0, nil
+	default:
+		return nil, 0, err
+	}
+	size := index.Size()
+	start, err := searchHeight(index, query.FromHeight)
+	if err != nil {
+		return nil, 0, err
+	}
+	if query.Cursor > start {
+		start = query.Cursor
+	}
+	if start >= size {
+		return nil, 0, nil
+	}
+	count := query.Count
+	if count > size-start {
+		count = size - start
+	}
+	values, err := index.Range(start, count)
+	if err != nil {
+		return nil, 0, err
+	}
+	tsfs := make([]*MemoTransfer, 0, len(values))
+	for _, v := range values {
+		tsf := &MemoTransfer{}
+		if err := tsf.deserialize(v); err != nil {
+			return nil, 0, err
+		}
+		if query.ToHeight != 0 && tsf.Height > query.ToHeight {
+			return tsfs, 0, nil
+		}
+		tsfs = append(tsfs, tsf)
+	}
+	next := start + count
+	if next >= size {
+		next = 0
+	}
+	return tsfs, next, nil
+}
+
+// getIndex returns the counting index of the bucket, which is placed into the dirty map to be committed later
+func (x *memoIndexer) getIndex(name []byte) (db.CountingIndex, error) {
+	index, ok := x.dirty[string(name)]
+	if ok {
+		return index, nil
+	}
+	index, err := db.NewCountingIndexNX(x.kvStore, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := index.UseBatch(x.batch); err != nil {
+		return nil, err
+	}
+	x.dirty[string(name)] = index
+	return index, nil
+}
+
+// commit writes the changes and the height
+func (x *memoIndexer) commit(height uint64) error {
+	var commitErr error
+	for k, v := range x.dirty {
+		if commitErr == nil {
+			if err := v.Finalize(); err != nil {
+				commitErr = err
+			}
+		}
+		delete(x.dirty, k)
+	}
+	if commitErr != nil {
+		return commitErr
+	}
+	x.batch.Put(_memoNS, _memoHeightKey, byteutil.Uint64ToBytesBigEndian(height), "failed to put height")
+	if err := x.kvStore.WriteBatch(x.batch); err != nil {
+		return err
+	}
+	x.batch.Clear()
+	x.height = height
+	return nil
+}
+
+// memoTransfers returns the transfers with a memo in the block regardless of their receipts, the transfers with
+// a binary payload are skipped and counted
+func memoTransfers(blk *block.Block) ([]*memoTransferOfBlock, error) {
+	var tsfs []*memoTransferOfBlock
+	for _, selp := range blk.Actions {
+		tsf, ok := selp.Action().(*action.Transfer)
+		if !ok || len(tsf.Payload()) == 0 {
+			continue
+		}
+		if !isMemo(tsf.Payload()) {
+			_memoMtc.WithLabelValues("skipped").Inc()
+			continue
+		}
+		recipient, err := address.FromString(tsf.Recipient())
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid recipient %s", tsf.Recipient())
+		}
+		h, err := selp.Hash()
+		if err != nil {
+			return nil, err
+		}
+		tsfs = append(tsfs, &memoTransferOfBlock{
+			bucket: memoBucket(hash.BytesToHash160(recipient.Bytes()), tsf.Payload()),
+			tsf: &MemoTransfer{
+				Height:     blk.Height(),
+				ActionHash: h,
+				Sender:     hash.BytesToHash160(selp.SenderAddress().Bytes()),
+				Amount:     tsf.Amount(),
+			},
+		})
+	}
+	return tsfs, nil
+}
+
+// isMemo returns whether the payload is a memo, the length is checked first so that a large payload is not decoded
+func isMemo(payload []byte) bool {
+	return len(payload) > 0 && len(payload) <= MaxMemoLen && utf8.Valid(payload)
+}
+
+func (tsf *MemoTransfer) serialize() []byte {
+	b := make([]byte, 0, _memoTransferLen)
+	b = append(b, byteutil.Uint64ToBytesBigEndian(tsf.Height)...)
+	b = append(b, tsf.ActionHash[:]...)
+	b = append(b, tsf.Sender[:]...)
+	return append(b, tsf.Amount.FillBytes(make([]byte, 32))...)
+}
+
+func (tsf *MemoTransfer) deserialize(buf []byte) error {
+	if len(buf) != _memoTransferLen {
+		return errors.Wrapf(db.ErrInvalid, "wrong length of memo transfer %d", len(buf))
+	}
+	tsf.Height = byteutil.BytesToUint64BigEndian(buf[:8])
+	tsf.ActionHash = hash.BytesToHash256(buf[8:40])
+	tsf.Sender = hash.BytesToHash160(buf[40:60])
+	tsf.Amount = new(big.Int).SetBytes(buf[60:])
+	return nil
+}
+
+func memoBucket(recipient hash.Hash160, memo []byte) []byte {
+	h := hash.Hash256b(memo)
+	return append(append([]byte(_memoPrefix), recipient[:]...), h[:]...)
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestMemoIndexer(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	type transfer struct {
+		sender, recipient int
+		amount            int64
+		memo              []byte
+		failed            bool
+	}
+	var (
+		addr = func(i int) hash.Hash160 {
+			return hash.BytesToHash160(identityset.Address(i).Bytes())
+		}
+		exchange, other = 5, 6
+		memo1, memo2    = []byte("deposit-001"), []byte("deposit-002")
+		blks            = make([]*block.Block, 4)
+		hashes          = make([][]hash.Hash256, 4)
+		nonce           uint64
+	)
+	for i, tsfs := range [][]transfer{
+		1: {
+			{1, exchange, 10, memo1, false},
+			// the same memo from another sender to the same recipient in the same block
+			{2, exchange, 20, memo1, false},
+			// a memo extending the memo
+			{1, exchange, 30, []byte("deposit-0010"), false},
+			// the same memo to another recipient
+			{1, other, 40, memo1, false},
+			// binary and oversized payloads are not memos
+			{1, exchange, 50, []byte{0xff, 0xfe}, false},
+			{1, exchange, 60, bytes.Repeat([]byte("a"), MaxMemoLen+1), false},
+			// the failed transfer is not indexed
+			{1, exchange, 70, memo1, true},
+			{1, exchange, 80, nil, false},
+		},
+		2: {
+			{3, exchange, 90, memo1, false},
+			{3, exchange, 100, memo2, false},
+		},
+		3: {
+			{1, exchange, 110, memo1, false},
+		},
+	} {
+		if i == 0 {
+			continue
+		}
+		var (
+			acts     []*action.SealedEnvelope
+			receipts []*action.Receipt
+		)
+		for _, tsf := range tsfs {
+			nonce++
+			selp, err := action.SignedTransfer(identityset.Address(tsf.recipient).String(), identityset.PrivateKey(tsf.sender), nonce, big.NewInt(tsf.amount), tsf.memo, 100000, big.NewInt(1))
+			r.NoError(err)
+			h, err := selp.Hash()
+			r.NoError(err)
+			status := uint64(iotextypes.ReceiptStatus_Success)
+			if tsf.failed {
+				status = uint64(iotextypes.ReceiptStatus_Failure)
+			}
+			acts = append(acts, selp)
+			receipts = append(receipts, &action.Receipt{Status: status, ActionHash: h})
+			hashes[i] = append(hashes[i], h)
+		}
+		blk, err := block.NewTestingBuilder().
+			SetHeight(uint64(i)).
+			AddActions(acts...).
+			SetReceipts(receipts).
+			SignAndBuild(identityset.PrivateKey(27))
+		r.NoError(err)
+		blks[i] = &blk
+	}
+
+	cfg := db.DefaultConfig
+	cfg.DbPath = t.TempDir() + "/memo.db"
+	indexer, err := NewMemoIndexer(db.NewBoltDB(cfg))
+	r.NoError(err)
+	r.NoError(indexer.Start(ctx))
+	defer func() {
+		r.NoError(indexer.Stop(ctx))
+	}()
+
+	skipped := testutil.ToFloat64(_memoMtc.WithLabelValues("skipped"))
+	r.Equal(db.ErrInvalid, errors.Cause(indexer.PutBlock(ctx, blks[2])))
+	for i := 1; i <= 3; i++ {
+		r.NoError(indexer.PutBlock(ctx, blks[i]))
+	}
+	// the indexed block is skipped
+	r.NoError(indexer.PutBlock(ctx, blks[3]))
+	height, err := indexer.Height()
+	r.NoError(err)
+	r.EqualValues(3, height)
+	r.Equal(skipped+2, testutil.ToFloat64(_memoMtc.WithLabelValues("skipped")))
+
+	query := func(tsfs []*MemoTransfer, next uint64, err error) ([]*MemoTransfer, uint64) {
+		r.NoError(err)
+		return tsfs, next
+	}
+	tsfs, next := query(indexer.TransfersByRecipientAndMemo(addr(exchange), memo1, &MemoTransferQuery{Count: 10}))
+	r.Zero(next)
+	r.Len(tsfs, 4)
+	r.Equal(&MemoTransfer{
+		Height:     1,
+		ActionHash: hashes[1][0],
+		Sender:     addr(1),
+		Amount:     big.NewInt(10),
+	}, tsfs[0])
+	r.Equal(addr(2), tsfs[1].Sender)
+	r.Equal(hashes[1][1], tsfs[1].ActionHash)
+	r.Equal(hashes[2][0], tsfs[2].ActionHash)
+	r.Equal(hashes[3][0], tsfs[3].ActionHash)
+
+	// the memos colliding in the prefix or the recipient are apart
+	tsfs, _ = query(indexer.TransfersByRecipientAndMemo(addr(exchange), []byte("deposit-0010"), &MemoTransferQuery{Count: 10}))
+	r.Len(tsfs, 1)
+	r.Equal(big.NewInt(30), tsfs[0].Amount)
+	tsfs, _ = query(indexer.TransfersByRecipientAndMemo(addr(exchange), []byte("deposit-00"), &MemoTransferQuery{Count: 10}))
+	r.Empty(tsfs)
+	tsfs, _ = query(indexer.TransfersByRecipientAndMemo(addr(other), memo1, &MemoTransferQuery{Count: 10}))
+	r.Len(tsfs, 1)
+	r.Equal(big.NewInt(40), tsfs[0].Amount)
+	tsfs, _ = query(indexer.TransfersByRecipientAndMemo(addr(exchange), memo2, &MemoTransferQuery{Count: 10}))
+	r.Len(tsfs, 1)
+	r.Equal(addr(3), tsfs[0].Sender)
+	tsfs, _ = query(indexer.TransfersByRecipientAndMemo(addr(1), memo1, &MemoTransferQuery{Count: 10}))
+	r.Empty(tsfs)
+
+	// cursor pagination
+	tsfs, next = query(indexer.TransfersByRecipientAndMemo(addr(exchange), memo1, &MemoTransferQuery{Count: 3}))
+	r.Len(tsfs, 3)
+	r.EqualValues(3, next)
+	tsfs, next = query(indexer.TransfersByRecipientAndMemo(addr(exchange), memo1, &MemoTransferQuery{Cursor: next, Count: 3}))
+	r.Len(tsfs, 1)
+	r.EqualValues(3, tsfs[0].Height)
+	r.Zero(next)
+
+	// height range
+	tsfs, next = query(indexer.TransfersByRecipientAndMemo(addr(exchange), memo1, &MemoTransferQuery{Count: 10, FromHeight: 2, ToHeight: 2}))
+	r.Len(tsfs, 1)
+	r.EqualValues(2, tsfs[0].Height)
+	r.Zero(next)
+	tsfs, next = query(indexer.TransfersByRecipientAndMemo(addr(exchange), memo1, &MemoTransferQuery{Count: 10, ToHeight: 1}))
+	r.Len(tsfs, 2)
+	r.Zero(next)
+	tsfs, _ = query(indexer.TransfersByRecipientAndMemo(addr(exchange), memo1, &MemoTransferQuery{Count: 10, FromHeight: 4}))
+	r.Empty(tsfs)
+	for _, v := range []struct {
+		memo  []byte
+		query *MemoTransferQuery
+	}{
+		{memo1, &MemoTransferQuery{Count: 10, FromHeight: 3, ToHeight: 2}},
+		{memo1, &MemoTransferQuery{}},
+		{nil, &MemoTransferQuery{Count: 10}},
+		{[]byte{0xff}, &MemoTransferQuery{Count: 10}},
+	} {
+		_, _, err = indexer.TransfersByRecipientAndMemo(addr(exchange), v.memo, v.query)
+		r.Equal(db.ErrInvalid, errors.Cause(err))
+	}
+
+	// delete the tip blocks
+	r.Equal(db.ErrInvalid, errors.Cause(indexer.DeleteTipBlock(ctx, blks[2])))
+	r.NoError(indexer.DeleteTipBlock(ctx, blks[3]))
+	r.NoError(indexer.DeleteTipBlock(ctx, blks[2]))
+	height, err = indexer.Height()
+	r.NoError(err)
+	r.EqualValues(1, height)
+	tsfs, _ = query(indexer.TransfersByRecipientAndMemo(addr(exchange), memo1, &MemoTransferQuery{Count: 10}))
+	r.Len(tsfs, 2)
+	tsfs, _ = query(indexer.TransfersByRecipientAndMemo(addr(exchange), memo2, &MemoTransferQuery{Count: 10}))
+	r.Empty(tsfs)
+
+	// the blocks are indexed again
+	r.NoError(indexer.PutBlock(ctx, blks[2]))
+	tsfs, _ = query(indexer.TransfersByRecipientAndMemo(addr(exchange), memo1, &MemoTransferQuery{Count: 10}))
+	r.Len(tsfs, 3)
+}
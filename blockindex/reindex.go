@@ -0,0 +1,362 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+type (
+	// IndexerStatus is the status of the index builder
+	IndexerStatus struct {
+		// Reindexing is true if the index is being rebuilt in background
+		Reindexing bool
+		// TargetHeight is the height of the chain
+		TargetHeight uint64
+		// CurrentHeight is the height of the index being built
+		CurrentHeight uint64
+		// ETA is the estimated time for the index being built to catch up with the chain
+		ETA time.Duration
+		// Err is the error which stopped the reindex
+		Err error
+	}
+
+	// reindexer rebuilds the index into a shadow indexer, the blocks received meanwhile are queued and applied
+	// to the shadow indexer once it catches up with the chain
+	reindexer struct {
+		batchSize   uint64
+		interval    time.Duration
+		shadow      Indexer
+		switchOver  func(context.Context) (Indexer, error)
+		mutex       sync.Mutex
+		pending     []*block.Block
+		switched    bool
+		err         error
+		startTime   time.Time
+		startHeight uint64
+		current     uint64
+		target      uint64
+		cancel      context.CancelFunc
+		done        chan struct{}
+	}
+
+	// switchableIndexer serves the reads from an indexer, which could be switched to another one
+	switchableIndexer struct {
+		mutex   sync.RWMutex
+		indexer Indexer
+	}
+)
+
+// ReindexOption sets the index builder to rebuild the index into the shadow indexer in background, while the
+// indexer keeps serving. The blocks are indexed in batches of cfg.ReindexBatchSize, paused by cfg.ReindexInterval
+// in between, and an interrupted reindex is resumed from the height of the shadow indexer. Once the shadow indexer
+// catches up with the chain, switchOver is called with the reads paused, which replaces the indexer and the shadow
+// indexer with the one returned
+func ReindexOption(cfg Config, shadow Indexer, switchOver func(context.Context) (Indexer, error)) IndexBuilderOption {
+	return func(ib *IndexBuilder) error {
+		if shadow == nil || switchOver == nil {
+			return errors.New("shadow indexer or switch over cannot be nil")
+		}
+		if cfg.ReindexBatchSize == 0 {
+			return errors.New("reindex batch size cannot be 0")
+		}
+		ib.reindexer = &reindexer{
+			batchSize:  cfg.ReindexBatchSize,
+			interval:   cfg.ReindexInterval,
+			shadow:     shadow,
+			switchOver: switchOver,
+		}
+		ib.indexer = &switchableIndexer{indexer: ib.indexer}
+		return nil
+	}
+}
+
+// Status returns the status of the index builder
+func (ib *IndexBuilder) Status() (*IndexerStatus, error) {
+	if r := ib.reindexer; r != nil {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		if !r.switched {
+			status := &IndexerStatus{
+				Reindexing:    r.err == nil,
+				TargetHeight:  r.target,
+				CurrentHeight: r.current,
+				Err:           r.err,
+			}
+			if done := r.current - r.startHeight; done > 0 && r.target > r.current {
+				status.ETA = time.Since(r.startTime) / time.Duration(done) * time.Duration(r.target-r.current)
+			}
+			return status, nil
+		}
+	}
+	target, err := ib.dao.Height()
+	if err != nil {
+		return nil, err
+	}
+	current, err := ib.indexer.Height()
+	if err != nil {
+		return nil, err
+	}
+	return &IndexerStatus{TargetHeight: target, CurrentHeight: current}, nil
+}
+
+func (ib *IndexBuilder) startReindex(ctx context.Context) error {
+	r := ib.reindexer
+	if err := r.shadow.Start(ctx); err != nil {
+		return err
+	}
+	height, err := r.shadow.Height()
+	if err != nil {
+		return err
+	}
+	target, err := ib.dao.Height()
+	if err != nil {
+		return err
+	}
+	if height > target {
+		return errors.Errorf("Inconsistent DB: shadow indexer height %d > blockDAO height %d", height, target)
+	}
+	r.startTime, r.startHeight, r.current, r.target = time.Now(), height, height, target
+	ctx, r.cancel = context.WithCancel(ctx)
+	r.done = make(chan struct{})
+	go func() {
+		defer close(r.done)
+		log.L().Info("Start reindexing in background.", zap.Uint64("height", height), zap.Uint64("target", target))
+		if err := ib.reindex(ctx); err != nil {
+			log.L().Error("Failed to reindex.", zap.Error(err))
+			r.mutex.Lock()
+			r.err = err
+			r.mutex.Unlock()
+		}
+	}()
+	return nil
+}
+
+func (ib *IndexBuilder) stopReindex(ctx context.Context) error {
+	r := ib.reindexer
+	r.cancel()
+	<-r.done
+	if r.switched {
+		return nil
+	}
+	return r.shadow.Stop(ctx)
+}
+
+// reindex catches up the shadow indexer with the chain, applies the blocks queued, and switches over to it
+func (ib *IndexBuilder) reindex(ctx context.Context) error {
+	var (
+		r    = ib.reindexer
+		gCtx = genesis.WithGenesisContext(ctx, ib.genesis)
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil
+		}
+		height, err := r.shadow.Height()
+		if err != nil {
+			return err
+		}
+		target, err := ib.dao.Height()
+		if err != nil {
+			return err
+		}
+		if height >= target {
+			switched, err := ib.applyPendingAndSwitch(gCtx, height)
+			if err != nil || switched {
+				return err
+			}
+			continue
+		}
+		blks := make([]*block.Block, 0, r.batchSize)
+		for h := height + 1; h <= target && uint64(len(blks)) < r.batchSize; h++ {
+			blk, err := ib.dao.GetBlockByHeight(h)
+			if err != nil {
+				return err
+			}
+			blks = append(blks, blk)
+		}
+		if err := r.shadow.PutBlocks(gCtx, blks); err != nil {
+			return err
+		}
+		height += uint64(len(blks))
+		r.mutex.Lock()
+		r.current, r.target = height, target
+		// drop the blocks queued which have been indexed
+		for len(r.pending) > 0 && r.pending[0].Height() <= height {
+			r.pending = r.pending[1:]
+		}
+		r.mutex.Unlock()
+		zap.L().Info("Finished reindexing blocks up to", zap.Uint64("height", height))
+		if r.interval > 0 {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(r.interval):
+			}
+		}
+	}
+}
+
+// applyPendingAndSwitch applies the blocks queued to the shadow indexer at height, and switches over to it if
+// no block is missing. The blocks received are queued until the switch is done
+func (ib *IndexBuilder) applyPendingAndSwitch(ctx context.Context, height uint64) (bool, error) {
+	r := ib.reindexer
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, blk := range r.pending {
+		if blk.Height() <= height {
+			continue
+		}
+		if blk.Height() != height+1 {
+			// catch up the missing blocks from the chain first
+			return false, nil
+		}
+		if err := r.shadow.PutBlock(ctx, blk); err != nil {
+			return false, err
+		}
+		height++
+	}
+	r.pending = nil
+	r.current = height
+	if height > r.target {
+		r.target = height
+	}
+	proxy := ib.indexer.(*switchableIndexer)
+	if err := proxy.switchTo(func() (Indexer, error) {
+		return r.switchOver(ctx)
+	}); err != nil {
+		return false, errors.Wrap(err, "failed to switch over to the shadow indexer")
+	}
+	r.switched = true
+	log.L().Info("Finish reindexing, switched over to the shadow indexer.", zap.Uint64("height", height))
+	return true, nil
+}
+
+// receiveBlock queues the block for the shadow indexer if the reindex is in progress, and returns whether queued
+func (r *reindexer) receiveBlock(blk *block.Block) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.switched || r.err != nil {
+		return false
+	}
+	r.pending = append(r.pending, blk)
+	if blk.Height() > r.target {
+		r.target = blk.Height()
+	}
+	return true
+}
+
+// switchTo replaces the indexer with the one returned by fn, the reads wait until the switch is done
+func (si *switchableIndexer) switchTo(fn func() (Indexer, error)) error {
+	si.mutex.Lock()
+	defer si.mutex.Unlock()
+	indexer, err := fn()
+	if err != nil {
+		return err
+	}
+	si.indexer = indexer
+	return nil
+}
+
+func (si *switchableIndexer) Start(ctx context.Context) error {
+	si.mutex.RLock()
+	defer si.mutex.RUnlock()
+	return si.indexer.Start(ctx)
+}
+
+func (si *switchableIndexer) Stop(ctx context.Context) error {
+	si.mutex.RLock()
+	defer si.mutex.RUnlock()
+	return si.indexer.Stop(ctx)
+}
+
+func (si *switchableIndexer) PutBlock(ctx context.Context, blk *block.Block) error {
+	si.mutex.RLock()
+	defer si.mutex.RUnlock()
+	return si.indexer.PutBlock(ctx, blk)
+}
+
+func (si *switchableIndexer) PutBlocks(ctx context.Context, blks []*block.Block) error {
+	si.mutex.RLock()
+	defer si.mutex.RUnlock()
+	return si.indexer.PutBlocks(ctx, blks)
+}
+
+func (si *switchableIndexer) DeleteTipBlock(ctx context.Context, blk *block.Block) error {
+	si.mutex.RLock()
+	defer si.mutex.RUnlock()
+	return si.indexer.DeleteTipBlock(ctx, blk)
+}
+
+func (si *switchableIndexer) Height() (uint64, error) {
+	si.mutex.RLock()
+	defer si.mutex.RUnlock()
+	return si.indexer.Height()
+}
+
+func (si *switchableIndexer) GetBlockHash(height uint64) (hash.Hash256, error) {
+	si.mutex.RLock()
+	defer si.mutex.RUnlock()
+	return si.indexer.GetBlockHash(height)
+}
+
+func (si *switchableIndexer) GetBlockHeight(h hash.Hash256) (uint64, error) {
+	si.mutex.RLock()
+	defer si.mutex.RUnlock()
+	return si.indexer.GetBlockHeight(h)
+}
+
+func (si *switchableIndexer) GetBlockIndex(height uint64) (*BlockIndex, error) {
+	si.mutex.RLock()
+	defer si.mutex.RUnlock()
+	return si.indexer.GetBlockIndex(height)
+}
+
+func (si *switchableIndexer) GetActionIndex(h []byte) (*ActionIndex, error) {
+	si.mutex.RLock()
+	defer si.mutex.RUnlock()
+	return si.indexer.GetActionIndex(h)
+}
+
+func (si *switchableIndexer) GetTotalActions() (uint64, error) {
+	si.mutex.RLock()
+	defer si.mutex.RUnlock()
+	return si.indexer.GetTotalActions()
+}
+
+func (si *switchableIndexer) GetActionHashFromIndex(start, count uint64) ([][]byte, error) {
+	si.mutex.RLock()
+	defer si.mutex.RUnlock()
+	return si.indexer.GetActionHashFromIndex(start, count)
+}
+
+func (si *switchableIndexer) GetActionCountByAddress(addr hash.Hash160) (uint64, error) {
+	si.mutex.RLock()
+	defer si.mutex.RUnlock()
+	return si.indexer.GetActionCountByAddress(addr)
+}
+
+func (si *switchableIndexer) GetActionsByAddress(addr hash.Hash160, start, count uint64) ([][]byte, error) {
+	si.mutex.RLock()
+	defer si.mutex.RUnlock()
+	return si.indexer.GetActionsByAddress(addr, start, count)
+}
+
+func (si *switchableIndexer) GetActionsByAddressWithFilter(addr hash.Hash160, filter *ActionFilter, start, count uint64) ([][]byte, error) {
+	si.mutex.RLock()
+	defer si.mutex.RUnlock()
+	return si.indexer.GetActionsByAddressWithFilter(addr, filter, start, count)
+}
@@ -0,0 +1,260 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+const (
+	// _systemActionNS is the namespace storing the system actions of each block keyed by the block height
+	_systemActionNS = "sa"
+	// _systemActionLen is 32-byte action hash, 4-byte index in the block, 1-byte type, 1-byte reward type,
+	// and 8-byte epoch
+	_systemActionLen = 32 + 4 + 1 + 1 + 8
+)
+
+// the types of the system actions
+const (
+	// SystemActionGrantReward is the action granting the block or epoch reward
+	SystemActionGrantReward SystemActionType = iota + 1
+	// SystemActionPutPollResult is the action putting the delegates of the next epoch
+	SystemActionPutPollResult
+)
+
+var _systemActionHeightKey = []byte("height")
+
+type (
+	// SystemActionType is the type of a system action
+	SystemActionType uint8
+
+	// SystemAction is a system action appended to a block by the block producer
+	SystemAction struct {
+		Type        SystemActionType
+		BlockHeight uint64
+		// Index is the index of the action in the block
+		Index      uint32
+		ActionHash hash.Hash256
+		// RewardType is action.BlockReward or action.EpochReward of a GrantReward
+		RewardType int
+		// Epoch is the epoch of the block of a GrantReward, or the epoch of the poll result of a PutPollResult
+		Epoch uint64
+	}
+
+	// SystemActionIndexer is the interface of the indexer of system actions
+	SystemActionIndexer interface {
+		blockdao.BlockIndexer
+		// SystemActionsByHeight returns the system actions of the block at the height
+		SystemActionsByHeight(uint64) ([]*SystemAction, error)
+		// GrantRewardsByEpoch returns the GrantReward actions of the blocks in the epoch
+		GrantRewardsByEpoch(uint64) ([]*SystemAction, error)
+	}
+
+	// systemActionIndexer stores the system actions of each block, which are tagged when the block is committed
+	systemActionIndexer struct {
+		mutex   sync.RWMutex
+		kvStore db.KVStore
+		rp      *rolldpos.Protocol
+		height  uint64
+	}
+)
+
+// NewSystemActionIndexer creates a new system action indexer
+func NewSystemActionIndexer(kv db.KVStore, rp *rolldpos.Protocol) (SystemActionIndexer, error) {
+	if kv == nil {
+		return nil, errors.New("empty kvStore")
+	}
+	if rp == nil {
+		return nil, errors.New("empty rolldpos protocol")
+	}
+	return &systemActionIndexer{
+		kvStore: kv,
+		rp:      rp,
+	}, nil
+}
+
+// Start starts the system action indexer
+func (x *systemActionIndexer) Start(ctx context.Context) error {
+	if err := x.kvStore.Start(ctx); err != nil {
+		return err
+	}
+	h, err := x.kvStore.Get(_systemActionNS, _systemActionHeightKey)
+	switch errors.Cause(err) {
+	case nil:
+		x.height = byteutil.BytesToUint64BigEndian(h)
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		x.height = 0
+	default:
+		return err
+	}
+	return nil
+}
+
+// Stop stops the system action indexer
+func (x *systemActionIndexer) Stop(ctx context.Context) error {
+	return x.kvStore.Stop(ctx)
+}
+
+// Height returns the height of the system action indexer
+func (x *systemActionIndexer) Height() (uint64, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	return x.height, nil
+}
+
+// PutBlock indexes the system actions of the block, which have been validated to be the tail of the block
+func (x *systemActionIndexer) PutBlock(_ context.Context, blk *block.Block) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	height := blk.Height()
+	if height <= x.height {
+		// the block has been indexed
+		return nil
+	}
+	if height != x.height+1 {
+		return errors.Wrapf(db.ErrInvalid, "wrong block height %d, expecting %d", height, x.height+1)
+	}
+	var (
+		b   = batch.NewBatch()
+		buf []byte
+	)
+	for i, selp := range blk.Actions {
+		if !action.IsSystemAction(selp) {
+			continue
+		}
+		actHash, err := selp.Hash()
+		if err != nil {
+			return err
+		}
+		sa := &SystemAction{
+			BlockHeight: height,
+			Index:       uint32(i),
+			ActionHash:  actHash,
+		}
+		switch act := selp.Action().(type) {
+		case *action.GrantReward:
+			sa.Type, sa.RewardType, sa.Epoch = SystemActionGrantReward, act.RewardType(), x.rp.GetEpochNum(height)
+		case *action.PutPollResult:
+			sa.Type, sa.Epoch = SystemActionPutPollResult, x.rp.GetEpochNum(act.Height())
+		}
+		buf = append(buf, sa.serialize()...)
+	}
+	if len(buf) > 0 {
+		b.Put(_systemActionNS, byteutil.Uint64ToBytesBigEndian(height), buf, "failed to put system actions")
+	}
+	b.Put(_systemActionNS, _systemActionHeightKey, byteutil.Uint64ToBytesBigEndian(height), "failed to put height")
+	if err := x.kvStore.WriteBatch(b); err != nil {
+		return err
+	}
+	x.height = height
+	return nil
+}
+
+// DeleteTipBlock deletes the system actions of the tip block
+func (x *systemActionIndexer) DeleteTipBlock(_ context.Context, blk *block.Block) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	height := blk.Height()
+	if height != x.height {
+		return errors.Wrapf(db.ErrInvalid, "wrong block height %d, expecting %d", height, x.height)
+	}
+	b := batch.NewBatch()
+	b.Delete(_systemActionNS, byteutil.Uint64ToBytesBigEndian(height), "failed to delete system actions")
+	b.Put(_systemActionNS, _systemActionHeightKey, byteutil.Uint64ToBytesBigEndian(height-1), "failed to put height")
+	if err := x.kvStore.WriteBatch(b); err != nil {
+		return err
+	}
+	x.height = height - 1
+	return nil
+}
+
+// SystemActionsByHeight returns the system actions of the block at the height
+func (x *systemActionIndexer) SystemActionsByHeight(height uint64) ([]*SystemAction, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	if height > x.height {
+		return nil, errors.Wrapf(db.ErrNotExist, "block %d has not been indexed", height)
+	}
+	return x.systemActions(height)
+}
+
+// GrantRewardsByEpoch returns the GrantReward actions of the blocks in the epoch, up to the height indexed
+func (x *systemActionIndexer) GrantRewardsByEpoch(epoch uint64) ([]*SystemAction, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	start, end := x.rp.GetEpochHeight(epoch), x.rp.GetEpochLastBlockHeight(epoch)
+	if epoch == 0 || start > x.height {
+		return nil, errors.Wrapf(db.ErrNotExist, "epoch %d has not been indexed", epoch)
+	}
+	if end > x.height {
+		end = x.height
+	}
+	var ret []*SystemAction
+	for h := start; h <= end; h++ {
+		sas, err := x.systemActions(h)
+		if err != nil {
+			return nil, err
+		}
+		for _, sa := range sas {
+			if sa.Type == SystemActionGrantReward {
+				ret = append(ret, sa)
+			}
+		}
+	}
+	return ret, nil
+}
+
+func (x *systemActionIndexer) systemActions(height uint64) ([]*SystemAction, error) {
+	buf, err := x.kvStore.Get(_systemActionNS, byteutil.Uint64ToBytesBigEndian(height))
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		// no system action in the block
+		return nil, nil
+	default:
+		return nil, err
+	}
+	if len(buf)%_systemActionLen != 0 {
+		return nil, errors.Wrapf(db.ErrInvalid, "wrong length of system actions %d", len(buf))
+	}
+	ret := make([]*SystemAction, 0, len(buf)/_systemActionLen)
+	for ; len(buf) > 0; buf = buf[_systemActionLen:] {
+		sa := &SystemAction{BlockHeight: height}
+		sa.deserialize(buf[:_systemActionLen])
+		ret = append(ret, sa)
+	}
+	return ret, nil
+}
+
+func (sa *SystemAction) serialize() []byte {
+	b := make([]byte, 0, _systemActionLen)
+	b = append(b, sa.ActionHash[:]...)
+	b = append(b, byteutil.Uint32ToBytesBigEndian(sa.Index)...)
+	b = append(b, byte(sa.Type), byte(sa.RewardType))
+	return append(b, byteutil.Uint64ToBytesBigEndian(sa.Epoch)...)
+}
+
+func (sa *SystemAction) deserialize(buf []byte) {
+	sa.ActionHash = hash.BytesToHash256(buf[:32])
+	sa.Index = byteutil.BytesToUint32BigEndian(buf[32:36])
+	sa.Type = SystemActionType(buf[36])
+	sa.RewardType = int(buf[37])
+	sa.Epoch = byteutil.BytesToUint64BigEndian(buf[38:])
+}
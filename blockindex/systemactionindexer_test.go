@@ -0,0 +1,120 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestSystemActionIndexer(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	var (
+		producer = identityset.PrivateKey(27)
+		sign     = func(elp action.Envelope) *action.SealedEnvelope {
+			selp, err := action.Sign(elp, producer)
+			r.NoError(err)
+			return selp
+		}
+		grant = func(rewardType int, height uint64) *action.SealedEnvelope {
+			gb := action.GrantRewardBuilder{}
+			act := gb.SetRewardType(rewardType).SetHeight(height).Build()
+			return sign((&action.EnvelopeBuilder{}).SetAction(&act).Build())
+		}
+	)
+	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(28), 1, big.NewInt(1), nil, testutil.TestGasLimit, big.NewInt(0))
+	r.NoError(err)
+	// 2 blocks per epoch, the poll result of epoch 2 is put in block 1, and the epoch reward is granted in block 2
+	actions := [][]*action.SealedEnvelope{
+		nil,
+		{tsf, grant(action.BlockReward, 1), sign((&action.EnvelopeBuilder{}).SetAction(action.NewPutPollResult(0, 3, nil)).Build())},
+		{grant(action.BlockReward, 2), grant(action.EpochReward, 2)},
+		{tsf},
+	}
+	blks := make([]*block.Block, len(actions))
+	for i := 1; i < len(actions); i++ {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(uint64(i)).
+			AddActions(actions[i]...).
+			SignAndBuild(producer)
+		r.NoError(err)
+		blks[i] = &blk
+	}
+
+	indexer, err := NewSystemActionIndexer(db.NewMemKVStore(), rolldpos.NewProtocol(2, 2, 1))
+	r.NoError(err)
+	r.NoError(indexer.Start(ctx))
+	defer func() {
+		r.NoError(indexer.Stop(ctx))
+	}()
+	r.Equal(db.ErrInvalid, errors.Cause(indexer.PutBlock(ctx, blks[2])))
+	for i := 1; i < len(blks); i++ {
+		r.NoError(indexer.PutBlock(ctx, blks[i]))
+	}
+	height, err := indexer.Height()
+	r.NoError(err)
+	r.EqualValues(3, height)
+
+	sas, err := indexer.SystemActionsByHeight(1)
+	r.NoError(err)
+	r.Len(sas, 2)
+	h, err := actions[1][1].Hash()
+	r.NoError(err)
+	r.Equal(&SystemAction{
+		Type:        SystemActionGrantReward,
+		BlockHeight: 1,
+		Index:       1,
+		ActionHash:  h,
+		RewardType:  action.BlockReward,
+		Epoch:       1,
+	}, sas[0])
+	r.Equal(SystemActionPutPollResult, sas[1].Type)
+	r.EqualValues(2, sas[1].Index)
+	r.EqualValues(2, sas[1].Epoch)
+	sas, err = indexer.SystemActionsByHeight(3)
+	r.NoError(err)
+	r.Empty(sas)
+	_, err = indexer.SystemActionsByHeight(4)
+	r.Equal(db.ErrNotExist, errors.Cause(err))
+
+	sas, err = indexer.GrantRewardsByEpoch(1)
+	r.NoError(err)
+	r.Len(sas, 3)
+	r.EqualValues(2, sas[2].BlockHeight)
+	r.Equal(action.EpochReward, sas[2].RewardType)
+	sas, err = indexer.GrantRewardsByEpoch(2)
+	r.NoError(err)
+	r.Empty(sas)
+	_, err = indexer.GrantRewardsByEpoch(3)
+	r.Equal(db.ErrNotExist, errors.Cause(err))
+
+	// delete the tip blocks
+	r.Equal(db.ErrInvalid, errors.Cause(indexer.DeleteTipBlock(ctx, blks[2])))
+	r.NoError(indexer.DeleteTipBlock(ctx, blks[3]))
+	r.NoError(indexer.DeleteTipBlock(ctx, blks[2]))
+	sas, err = indexer.GrantRewardsByEpoch(1)
+	r.NoError(err)
+	r.Len(sas, 1)
+	_, err = indexer.SystemActionsByHeight(2)
+	r.Equal(db.ErrNotExist, errors.Cause(err))
+	r.NoError(indexer.PutBlock(ctx, blks[2]))
+	sas, err = indexer.SystemActionsByHeight(2)
+	r.NoError(err)
+	r.Len(sas, 2)
+}
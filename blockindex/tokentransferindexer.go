@@ -0,0 +1,478 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+const (
+	// _tokenTransferNS is the namespace storing the height of the token transfer indexer
+	_tokenTransferNS = "tt"
+	// _tokenHolderPrefix is the prefix of the bucket storing the transfers from or to a holder
+	_tokenHolderPrefix = "th"
+	// _tokenContractPrefix is the prefix of the bucket storing the transfers of a token contract
+	_tokenContractPrefix = "tc"
+	// _tokenTransferLen is 1-byte standard, 8-byte height, 32-byte action hash, 20-byte token, from, to
+	// address each, and 32-byte amount or token id
+	_tokenTransferLen = 1 + 8 + 32 + 20*3 + 32
+	// _tokenTransferRefLen is 8-byte height and 8-byte position of the transfer in the total index
+	_tokenTransferRefLen = 16
+)
+
+// the standards of the token transfers
+const (
+	// XRC20 is the transfer of fungible tokens, Transfer(address indexed, address indexed, uint256)
+	XRC20 TokenStandard = iota + 1
+	// XRC721 is the transfer of a non-fungible token, Transfer(address indexed, address indexed, uint256 indexed)
+	XRC721
+)
+
+var (
+	_tokenTransferHeightKey    = []byte("height")
+	_totalTokenTransfersBucket = []byte("tx")
+	// _transferTopic is the topic of Transfer event of both XRC20 and XRC721
+	_transferTopic = hash.BytesToHash256(crypto.Keccak256([]byte("Transfer(address,address,uint256)")))
+
+	_tokenTransferMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_token_transfer_indexer",
+			Help: "IoTeX token transfer indexer counter.",
+		},
+		[]string{"type"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(_tokenTransferMtc)
+}
+
+type (
+	// TokenStandard is the standard of a token transfer
+	TokenStandard uint8
+
+	// TokenTransfer is a transfer of XRC20 or XRC721 token
+	TokenTransfer struct {
+		Standard   TokenStandard
+		Height     uint64
+		ActionHash hash.Hash256
+		Token      hash.Hash160
+		From       hash.Hash160
+		To         hash.Hash160
+		// Amount is the amount of XRC20 token, or the token id of XRC721 token
+		Amount *big.Int
+	}
+
+	// TokenTransferQuery queries the transfers of a holder or a token contract
+	TokenTransferQuery struct {
+		// Cursor is the position to continue from, which is returned by the previous query, 0 to start over
+		Cursor uint64
+		// Count is the max number of transfers returned
+		Count uint64
+		// FromHeight matches the transfers in blocks at or above the height
+		FromHeight uint64
+		// ToHeight matches the transfers in blocks at or below the height, 0 means no upper bound
+		ToHeight uint64
+	}
+
+	// TokenTransferIndexer is the interface of the indexer of XRC20 and XRC721 transfer events
+	TokenTransferIndexer interface {
+		blockdao.BlockIndexer
+		// TransfersByAddress returns the transfers from or to the address in ascending order, and the cursor of
+		// the next query, which is 0 if there is no more transfer
+		TransfersByAddress(hash.Hash160, *TokenTransferQuery) ([]*TokenTransfer, uint64, error)
+		// TransfersByContract returns the transfers of the token contract in ascending order, and the cursor of
+		// the next query, which is 0 if there is no more transfer
+		TransfersByContract(hash.Hash160, *TokenTransferQuery) ([]*TokenTransfer, uint64, error)
+	}
+
+	// tokenTransferIndexer stores the transfers in a total index, and the positions of them in the index of
+	// each holder and token contract
+	tokenTransferIndexer struct {
+		mutex   sync.RWMutex
+		kvStore db.KVStoreWithRange
+		batch   batch.KVStoreBatch
+		dirty   map[string]db.CountingIndex
+		total   db.CountingIndex
+		height  uint64
+	}
+)
+
+// NewTokenTransferIndexer creates a new token transfer indexer
+func NewTokenTransferIndexer(kv db.KVStore) (TokenTransferIndexer, error) {
+	if kv == nil {
+		return nil, errors.New("empty kvStore")
+	}
+	kvRange, ok := kv.(db.KVStoreWithRange)
+	if !ok {
+		return nil, errors.New("token transfer indexer can only be created from KVStoreWithRange")
+	}
+	return &tokenTransferIndexer{
+		kvStore: kvRange,
+		batch:   batch.NewBatch(),
+		dirty:   make(map[string]db.CountingIndex),
+	}, nil
+}
+
+// Start starts the token transfer indexer
+func (x *tokenTransferIndexer) Start(ctx context.Context) error {
+	if err := x.kvStore.Start(ctx); err != nil {
+		return err
+	}
+	h, err := x.kvStore.Get(_tokenTransferNS, _tokenTransferHeightKey)
+	switch errors.Cause(err) {
+	case nil:
+		x.height = byteutil.BytesToUint64BigEndian(h)
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		x.height = 0
+	default:
+		return err
+	}
+	x.total, err = db.NewCountingIndexNX(x.kvStore, _totalTokenTransfersBucket)
+	return err
+}
+
+// Stop stops the token transfer indexer
+func (x *tokenTransferIndexer) Stop(ctx context.Context) error {
+	return x.kvStore.Stop(ctx)
+}
+
+// Height returns the height of the token transfer indexer
+func (x *tokenTransferIndexer) Height() (uint64, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	return x.height, nil
+}
+
+// PutBlock indexes the transfer events in the receipts of the block
+func (x *tokenTransferIndexer) PutBlock(_ context.Context, blk *block.Block) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	height := blk.Height()
+	if height <= x.height {
+		// the block has been indexed
+		return nil
+	}
+	if height != x.height+1 {
+		return errors.Wrapf(db.ErrInvalid, "wrong block height %d, expecting %d", height, x.height+1)
+	}
+	if err := x.total.UseBatch(x.batch); err != nil {
+		return err
+	}
+	for _, receipt := range blk.Receipts {
+		for _, l := range receipt.Logs() {
+			tsf, err := decodeTokenTransfer(height, l)
+			if err != nil {
+				return err
+			}
+			if tsf == nil {
+				continue
+			}
+			if err := x.putTransfer(tsf); err != nil {
+				return err
+			}
+		}
+	}
+	return x.commit(height)
+}
+
+// DeleteTipBlock deletes the transfers in the tip block
+func (x *tokenTransferIndexer) DeleteTipBlock(_ context.Context, blk *block.Block) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	height := blk.Height()
+	if height != x.height {
+		return errors.Wrapf(db.ErrInvalid, "wrong block height %d, expecting %d", height, x.height)
+	}
+	var count uint64
+	for size := x.total.Size(); count < size; count++ {
+		tsf, err := x.getTransfer(size - count - 1)
+		if err != nil {
+			return err
+		}
+		if tsf.Height != height {
+			break
+		}
+		for _, name := range x.bucketsOfTransfer(tsf) {
+			index, err := db.GetCountingIndex(x.kvStore, name)
+			if err != nil {
+				return err
+			}
+			if err := index.Revert(1); err != nil {
+				return err
+			}
+		}
+	}
+	if count > 0 {
+		if err := x.total.Revert(count); err != nil {
+			return err
+		}
+	}
+	x.batch.Put(_tokenTransferNS, _tokenTransferHeightKey, byteutil.Uint64ToBytesBigEndian(height-1), "failed to put height")
+	if err := x.kvStore.WriteBatch(x.batch); err != nil {
+		return err
+	}
+	x.batch.Clear()
+	x.height = height - 1
+	return nil
+}
+
+// TransfersByAddress returns the transfers from or to the address
+func (x *tokenTransferIndexer) TransfersByAddress(addr hash.Hash160, query *TokenTransferQuery) ([]*TokenTransfer, uint64, error) {
+	return x.transfers(tokenHolderBucket(addr[:]), query)
+}
+
+// TransfersByContract returns the transfers of the token contract
+func (x *tokenTransferIndexer) TransfersByContract(token hash.Hash160, query *TokenTransferQuery) ([]*TokenTransfer, uint64, error) {
+	return x.transfers(tokenContractBucket(token[:]), query)
+}
+
+func (x *tokenTransferIndexer) transfers(name []byte, query *TokenTransferQuery) ([]*TokenTransfer, uint64, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+
+	if query == nil || query.Count == 0 {
+		return nil, 0, errors.Wrap(db.ErrInvalid, "count must be greater than 0")
+	}
+	if query.ToHeight != 0 && query.ToHeight < query.FromHeight {
+		return nil, 0, errors.Wrapf(db.ErrInvalid, "from height %d > to height %d", query.FromHeight, query.ToHeight)
+	}
+	index, err := db.GetCountingIndex(x.kvStore, name)
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return nil, 0, nil
+	default:
+		return nil, 0, err
+	}
+	size := index.Size()
+	start, err := searchHeight(index, query.FromHeight)
+	if err != nil {
+		return nil, 0, err
+	}
+	if query.Cursor > start {
+		start = query.Cursor
+	}
+	if start >= size {
+		return nil, 0, nil
+	}
+	count := query.Count
+	if count > size-start {
+		count = size - start
+	}
+	refs, err := index.Range(start, count)
+	if err != nil {
+		return nil, 0, err
+	}
+	tsfs := make([]*TokenTransfer, 0, len(refs))
+	for _, ref := range refs {
+		if len(ref) != _tokenTransferRefLen {
+			return nil, 0, errors.Wrapf(db.ErrInvalid, "wrong length of token transfer reference %d", len(ref))
+		}
+		if query.ToHeight != 0 && byteutil.BytesToUint64BigEndian(ref[:8]) > query.ToHeight {
+			return tsfs, 0, nil
+		}
+		tsf, err := x.getTransfer(byteutil.BytesToUint64BigEndian(ref[8:]))
+		if err != nil {
+			return nil, 0, err
+		}
+		tsfs = append(tsfs, tsf)
+	}
+	next := start + count
+	if next >= size {
+		next = 0
+	}
+	return tsfs, next, nil
+}
+
+// searchHeight returns the position of the first entry at or above the height in the index, whose entries are in
+// ascending order of the 8-byte height they start with
+func searchHeight(index db.CountingIndex, height uint64) (uint64, error) {
+	var searchErr error
+	pos := sort.Search(int(index.Size()), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		v, err := index.Get(uint64(i))
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		return byteutil.BytesToUint64BigEndian(v[:8]) >= height
+	})
+	if searchErr != nil {
+		return 0, searchErr
+	}
+	return uint64(pos), nil
+}
+
+func (x *tokenTransferIndexer) putTransfer(tsf *TokenTransfer) error {
+	ref := append(byteutil.Uint64ToBytesBigEndian(tsf.Height), byteutil.Uint64ToBytesBigEndian(x.total.Size())...)
+	if err := x.total.Add(tsf.serialize(), true); err != nil {
+		return err
+	}
+	for _, name := range x.bucketsOfTransfer(tsf) {
+		index, err := x.getIndex(name)
+		if err != nil {
+			return err
+		}
+		if err := index.Add(ref, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *tokenTransferIndexer) getTransfer(i uint64) (*TokenTransfer, error) {
+	v, err := x.total.Get(i)
+	if err != nil {
+		return nil, err
+	}
+	tsf := &TokenTransfer{}
+	if err := tsf.deserialize(v); err != nil {
+		return nil, err
+	}
+	return tsf, nil
+}
+
+// getIndex returns the counting index of the bucket, which is placed into the dirty map to be committed later
+func (x *tokenTransferIndexer) getIndex(name []byte) (db.CountingIndex, error) {
+	index, ok := x.dirty[string(name)]
+	if ok {
+		return index, nil
+	}
+	index, err := db.NewCountingIndexNX(x.kvStore, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := index.UseBatch(x.batch); err != nil {
+		return nil, err
+	}
+	x.dirty[string(name)] = index
+	return index, nil
+}
+
+// bucketsOfTransfer returns the buckets indexing the transfer, a transfer to self is indexed once, and the zero
+// address of mint and burn is not indexed as a holder
+func (x *tokenTransferIndexer) bucketsOfTransfer(tsf *TokenTransfer) [][]byte {
+	names := [][]byte{tokenContractBucket(tsf.Token[:])}
+	if tsf.From != hash.ZeroHash160 {
+		names = append(names, tokenHolderBucket(tsf.From[:]))
+	}
+	if tsf.To != hash.ZeroHash160 && tsf.To != tsf.From {
+		names = append(names, tokenHolderBucket(tsf.To[:]))
+	}
+	return names
+}
+
+// commit writes the changes and the height
+func (x *tokenTransferIndexer) commit(height uint64) error {
+	var commitErr error
+	for k, v := range x.dirty {
+		if commitErr == nil {
+			if err := v.Finalize(); err != nil {
+				commitErr = err
+			}
+		}
+		delete(x.dirty, k)
+	}
+	if commitErr != nil {
+		return commitErr
+	}
+	if err := x.total.Finalize(); err != nil {
+		return err
+	}
+	x.batch.Put(_tokenTransferNS, _tokenTransferHeightKey, byteutil.Uint64ToBytesBigEndian(height), "failed to put height")
+	if err := x.kvStore.WriteBatch(x.batch); err != nil {
+		return err
+	}
+	x.batch.Clear()
+	x.height = height
+	return nil
+}
+
+// decodeTokenTransfer decodes the log into a token transfer, which is nil if the log is not a transfer event. The
+// event with the transfer topic but of neither standard is skipped and counted
+func decodeTokenTransfer(height uint64, l *action.Log) (*TokenTransfer, error) {
+	if len(l.Topics) == 0 || l.Topics[0] != _transferTopic {
+		return nil, nil
+	}
+	tsf := &TokenTransfer{
+		Height:     height,
+		ActionHash: l.ActionHash,
+	}
+	switch {
+	case len(l.Topics) == 3 && len(l.Data) == 32:
+		tsf.Standard = XRC20
+		tsf.Amount = new(big.Int).SetBytes(l.Data)
+	case len(l.Topics) == 4 && len(l.Data) == 0:
+		tsf.Standard = XRC721
+		tsf.Amount = new(big.Int).SetBytes(l.Topics[3][:])
+	default:
+		_tokenTransferMtc.WithLabelValues("skipped").Inc()
+		return nil, nil
+	}
+	token, err := address.FromString(l.Address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid address of log %s", l.Address)
+	}
+	tsf.Token = hash.BytesToHash160(token.Bytes())
+	tsf.From = hash.BytesToHash160(l.Topics[1][12:])
+	tsf.To = hash.BytesToHash160(l.Topics[2][12:])
+	_tokenTransferMtc.WithLabelValues("indexed").Inc()
+	return tsf, nil
+}
+
+func (tsf *TokenTransfer) serialize() []byte {
+	b := make([]byte, 0, _tokenTransferLen)
+	b = append(b, byte(tsf.Standard))
+	b = append(b, byteutil.Uint64ToBytesBigEndian(tsf.Height)...)
+	b = append(b, tsf.ActionHash[:]...)
+	b = append(b, tsf.Token[:]...)
+	b = append(b, tsf.From[:]...)
+	b = append(b, tsf.To[:]...)
+	return append(b, tsf.Amount.FillBytes(make([]byte, 32))...)
+}
+
+func (tsf *TokenTransfer) deserialize(buf []byte) error {
+	if len(buf) != _tokenTransferLen {
+		return errors.Wrapf(db.ErrInvalid, "wrong length of token transfer %d", len(buf))
+	}
+	tsf.Standard = TokenStandard(buf[0])
+	tsf.Height = byteutil.BytesToUint64BigEndian(buf[1:9])
+	tsf.ActionHash = hash.BytesToHash256(buf[9:41])
+	tsf.Token = hash.BytesToHash160(buf[41:61])
+	tsf.From = hash.BytesToHash160(buf[61:81])
+	tsf.To = hash.BytesToHash160(buf[81:101])
+	tsf.Amount = new(big.Int).SetBytes(buf[101:])
+	return nil
+}
+
+func tokenHolderBucket(addr []byte) []byte {
+	return append([]byte(_tokenHolderPrefix), addr...)
+}
+
+func tokenContractBucket(addr []byte) []byte {
+	return append([]byte(_tokenContractPrefix), addr...)
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func newTransferLog(token int, actHash hash.Hash256, from, to hash.Hash160, topics []hash.Hash256, data []byte) *action.Log {
+	var fromTopic, toTopic hash.Hash256
+	copy(fromTopic[12:], from[:])
+	copy(toTopic[12:], to[:])
+	return &action.Log{
+		Address:    identityset.Address(token).String(),
+		Topics:     append([]hash.Hash256{_transferTopic, fromTopic, toTopic}, topics...),
+		Data:       data,
+		ActionHash: actHash,
+	}
+}
+
+func TestTokenTransferIndexer(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	var (
+		addr = func(i int) hash.Hash160 {
+			return hash.BytesToHash160(identityset.Address(i).Bytes())
+		}
+		xrc20, xrc721 = 10, 11
+		h1, h2, h3    = hash.Hash256b([]byte("1")), hash.Hash256b([]byte("2")), hash.Hash256b([]byte("3"))
+		amount        = big.NewInt(100).FillBytes(make([]byte, 32))
+		tokenID       = hash.BytesToHash256(big.NewInt(7).FillBytes(make([]byte, 32)))
+		receipts      = make([][]*action.Receipt, 4)
+		newReceipt    = func(logs ...*action.Log) *action.Receipt {
+			return (&action.Receipt{}).AddLogs(logs...)
+		}
+	)
+	// block 1: mint of xrc20 to 1, and transfer from 1 to 2
+	receipts[1] = []*action.Receipt{
+		newReceipt(
+			newTransferLog(xrc20, h1, hash.ZeroHash160, addr(1), nil, amount),
+			newTransferLog(xrc20, h1, addr(1), addr(2), nil, amount),
+		),
+	}
+	// block 2: transfer of xrc721 from 2 to 3, a non-standard event, and a transfer to self
+	receipts[2] = []*action.Receipt{
+		newReceipt(
+			newTransferLog(xrc721, h2, addr(2), addr(3), []hash.Hash256{tokenID}, nil),
+			newTransferLog(xrc20, h2, addr(2), addr(3), nil, []byte{1, 2, 3}),
+			newTransferLog(xrc20, h2, addr(3), addr(3), nil, amount),
+		),
+		newReceipt(newTestLog(identityset.Address(xrc20).String(), []hash.Hash256{_data1})),
+	}
+	// block 3: transfer of xrc20 from 2 to 1
+	receipts[3] = []*action.Receipt{
+		newReceipt(newTransferLog(xrc20, h3, addr(2), addr(1), nil, amount)),
+	}
+	blks := make([]*block.Block, 4)
+	for i := 1; i <= 3; i++ {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(uint64(i)).
+			SetReceipts(receipts[i]).
+			SignAndBuild(identityset.PrivateKey(27))
+		r.NoError(err)
+		blks[i] = &blk
+	}
+
+	cfg := db.DefaultConfig
+	cfg.DbPath = t.TempDir() + "/tokentransfer.db"
+	indexer, err := NewTokenTransferIndexer(db.NewBoltDB(cfg))
+	r.NoError(err)
+	r.NoError(indexer.Start(ctx))
+	defer func() {
+		r.NoError(indexer.Stop(ctx))
+	}()
+
+	skipped := testutil.ToFloat64(_tokenTransferMtc.WithLabelValues("skipped"))
+	r.Equal(db.ErrInvalid, errors.Cause(indexer.PutBlock(ctx, blks[2])))
+	for i := 1; i <= 3; i++ {
+		r.NoError(indexer.PutBlock(ctx, blks[i]))
+	}
+	// the indexed block is skipped
+	r.NoError(indexer.PutBlock(ctx, blks[3]))
+	height, err := indexer.Height()
+	r.NoError(err)
+	r.EqualValues(3, height)
+	r.Equal(skipped+1, testutil.ToFloat64(_tokenTransferMtc.WithLabelValues("skipped")))
+
+	query := func(tsfs []*TokenTransfer, next uint64, err error) ([]*TokenTransfer, uint64) {
+		r.NoError(err)
+		return tsfs, next
+	}
+	tsfs, next := query(indexer.TransfersByContract(addr(xrc20), &TokenTransferQuery{Count: 10}))
+	r.Zero(next)
+	r.Len(tsfs, 4)
+	r.Equal(&TokenTransfer{
+		Standard:   XRC20,
+		Height:     1,
+		ActionHash: h1,
+		Token:      addr(xrc20),
+		From:       hash.ZeroHash160,
+		To:         addr(1),
+		Amount:     big.NewInt(100),
+	}, tsfs[0])
+	r.Equal(addr(3), tsfs[2].From)
+	r.Equal(addr(3), tsfs[2].To)
+	r.EqualValues(3, tsfs[3].Height)
+
+	tsfs, _ = query(indexer.TransfersByContract(addr(xrc721), &TokenTransferQuery{Count: 10}))
+	r.Len(tsfs, 1)
+	r.Equal(XRC721, tsfs[0].Standard)
+	r.Equal(big.NewInt(7), tsfs[0].Amount)
+	r.Equal(addr(2), tsfs[0].From)
+	r.Equal(addr(3), tsfs[0].To)
+
+	// the transfer to self is returned once, and the zero address is not indexed
+	tsfs, _ = query(indexer.TransfersByAddress(addr(3), &TokenTransferQuery{Count: 10}))
+	r.Len(tsfs, 2)
+	tsfs, _ = query(indexer.TransfersByAddress(hash.ZeroHash160, &TokenTransferQuery{Count: 10}))
+	r.Empty(tsfs)
+
+	// cursor pagination
+	tsfs, next = query(indexer.TransfersByAddress(addr(2), &TokenTransferQuery{Count: 2}))
+	r.Len(tsfs, 2)
+	r.EqualValues(1, tsfs[0].Height)
+	r.Equal(XRC721, tsfs[1].Standard)
+	r.EqualValues(2, next)
+	tsfs, next = query(indexer.TransfersByAddress(addr(2), &TokenTransferQuery{Cursor: next, Count: 2}))
+	r.Len(tsfs, 1)
+	r.EqualValues(3, tsfs[0].Height)
+	r.Zero(next)
+
+	// height range
+	tsfs, next = query(indexer.TransfersByAddress(addr(2), &TokenTransferQuery{Count: 10, FromHeight: 2, ToHeight: 2}))
+	r.Len(tsfs, 1)
+	r.Equal(XRC721, tsfs[0].Standard)
+	r.Zero(next)
+	tsfs, next = query(indexer.TransfersByAddress(addr(2), &TokenTransferQuery{Count: 1, FromHeight: 2}))
+	r.Len(tsfs, 1)
+	r.EqualValues(2, tsfs[0].Height)
+	r.EqualValues(2, next)
+	tsfs, _ = query(indexer.TransfersByAddress(addr(2), &TokenTransferQuery{Count: 10, FromHeight: 4}))
+	r.Empty(tsfs)
+	_, _, err = indexer.TransfersByAddress(addr(2), &TokenTransferQuery{Count: 10, FromHeight: 3, ToHeight: 2})
+	r.Equal(db.ErrInvalid, errors.Cause(err))
+	_, _, err = indexer.TransfersByAddress(addr(2), &TokenTransferQuery{})
+	r.Equal(db.ErrInvalid, errors.Cause(err))
+
+	// delete the tip blocks
+	r.Equal(db.ErrInvalid, errors.Cause(indexer.DeleteTipBlock(ctx, blks[2])))
+	r.NoError(indexer.DeleteTipBlock(ctx, blks[3]))
+	r.NoError(indexer.DeleteTipBlock(ctx, blks[2]))
+	height, err = indexer.Height()
+	r.NoError(err)
+	r.EqualValues(1, height)
+	tsfs, _ = query(indexer.TransfersByAddress(addr(2), &TokenTransferQuery{Count: 10}))
+	r.Len(tsfs, 1)
+	tsfs, _ = query(indexer.TransfersByAddress(addr(3), &TokenTransferQuery{Count: 10}))
+	r.Empty(tsfs)
+	tsfs, _ = query(indexer.TransfersByContract(addr(xrc721), &TokenTransferQuery{Count: 10}))
+	r.Empty(tsfs)
+	tsfs, _ = query(indexer.TransfersByContract(addr(xrc20), &TokenTransferQuery{Count: 10}))
+	r.Len(tsfs, 2)
+
+	// the blocks are indexed again
+	r.NoError(indexer.PutBlock(ctx, blks[2]))
+	tsfs, _ = query(indexer.TransfersByAddress(addr(3), &TokenTransferQuery{Count: 10}))
+	r.Len(tsfs, 2)
+}
@@ -18,6 +18,7 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/pkg/fastrand"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
@@ -52,6 +53,8 @@ type (
 		ProcessBlock(context.Context, string, *block.Block) error
 		// SyncStatus report block sync status
 		SyncStatus() (startingHeight uint64, currentHeight uint64, targetHeight uint64, syncSpeedDesc string)
+		// SyncProgress reports the block sync progress with the speed and the estimated time remaining
+		SyncProgress() SyncProgress
 	}
 
 	dummyBlockSync struct{}
@@ -73,6 +76,7 @@ type (
 
 		syncStageHeight   uint64
 		syncBlockIncrease uint64
+		tracker           *progressTracker
 
 		startingHeight    uint64 // block number this node started to synchronise from
 		lastTip           uint64
@@ -123,6 +127,10 @@ func (*dummyBlockSync) SyncStatus() (uint64, uint64, uint64, string) {
 	return 0, 0, 0, ""
 }
 
+func (*dummyBlockSync) SyncProgress() SyncProgress {
+	return SyncProgress{Stage: SyncStageIdle}
+}
+
 func (*dummyBlockSync) BuildReport() string {
 	return ""
 }
@@ -148,6 +156,7 @@ func NewBlockSyncer(
 		unicastOutbound:      uniCastHandler,
 		blockP2pPeer:         blockP2pPeer,
 		targetHeight:         0,
+		tracker:              newProgressTracker(_progressWindow),
 	}
 	if bs.cfg.Interval != 0 {
 		bs.syncTask = routine.NewRecurringTask(bs.sync, bs.cfg.Interval)
@@ -172,6 +181,21 @@ func (bs *blockSyncer) commitBlocks(blks []*peerBlock) bool {
 	return false
 }
 
+// verifySignatures verifies the signatures of the actions in the blocks ready to commit in one batch, so the block
+// validation skips verifying them. If the batch fails, the validation of each block finds the invalid one
+func (bs *blockSyncer) verifySignatures(blks []*peerBlock) {
+	if len(blks) < 2 {
+		return
+	}
+	var acts []*action.SealedEnvelope
+	for _, blk := range blks {
+		acts = append(acts, blk.block.Actions...)
+	}
+	if err := action.VerifySignatures(acts); err != nil {
+		log.L().Debug("failed to verify signatures of blocks in batch", zap.Error(err), zap.Int("blocks", len(blks)))
+	}
+}
+
 func (bs *blockSyncer) flushInfo() (time.Time, uint64) {
 	bs.mu.Lock()
 	defer bs.mu.Unlock()
@@ -278,6 +302,7 @@ func (bs *blockSyncer) ProcessBlock(ctx context.Context, peer string, blk *block
 	if !added {
 		return nil
 	}
+	bs.verifySignatures(bs.buf.Contiguous(tip + 1))
 	syncedHeight := tip
 	for {
 		if !bs.commitBlocks(bs.buf.Pop(syncedHeight + 1)) {
@@ -290,6 +315,7 @@ func (bs *blockSyncer) ProcessBlock(ctx context.Context, peer string, blk *block
 	if syncedHeight > bs.lastTip {
 		bs.lastTip = syncedHeight
 		bs.lastTipUpdateTime = time.Now()
+		bs.tracker.record(bs.lastTipUpdateTime, syncedHeight)
 	}
 	return nil
 }
@@ -325,6 +351,7 @@ func (bs *blockSyncer) syncStageChecker() {
 	tipHeight := bs.tipHeightHandler()
 	atomic.StoreUint64(&bs.syncBlockIncrease, tipHeight-bs.syncStageHeight)
 	bs.syncStageHeight = tipHeight
+	bs.tracker.record(time.Now(), tipHeight)
 }
 
 func (bs *blockSyncer) SyncStatus() (uint64, uint64, uint64, string) {
@@ -341,6 +368,11 @@ func (bs *blockSyncer) SyncStatus() (uint64, uint64, uint64, string) {
 	return bs.startingHeight, bs.tipHeightHandler(), bs.targetHeight, syncSpeedDesc
 }
 
+// SyncProgress reports the block sync progress with the speed and the estimated time remaining
+func (bs *blockSyncer) SyncProgress() SyncProgress {
+	return bs.tracker.progress(time.Now(), bs.startingHeight, bs.tipHeightHandler(), bs.TargetHeight())
+}
+
 // BuildReport builds a report of block syncer
 func (bs *blockSyncer) BuildReport() string {
 	startingHeight, tipHeight, targetHeight, syncSpeedDesc := bs.SyncStatus()
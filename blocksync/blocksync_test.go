@@ -35,7 +35,6 @@ import (
 	"github.com/iotexproject/iotex-core/test/identityset"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blockdao"
-	"github.com/iotexproject/iotex-core/test/mock/mock_blocksync"
 	"github.com/iotexproject/iotex-core/test/mock/mock_consensus"
 	"github.com/iotexproject/iotex-core/testutil"
 )
@@ -112,28 +111,6 @@ func TestNewBlockSyncer(t *testing.T) {
 	assert.NotNil(bs)
 }
 
-func TestBlockSyncerStart(t *testing.T) {
-	assert := assert.New(t)
-
-	ctrl := gomock.NewController(t)
-
-	ctx := context.Background()
-	mBs := mock_blocksync.NewMockBlockSync(ctrl)
-	mBs.EXPECT().Start(gomock.Any()).Times(1)
-	assert.Nil(mBs.Start(ctx))
-}
-
-func TestBlockSyncerStop(t *testing.T) {
-	assert := assert.New(t)
-
-	ctrl := gomock.NewController(t)
-
-	ctx := context.Background()
-	mBs := mock_blocksync.NewMockBlockSync(ctrl)
-	mBs.EXPECT().Stop(gomock.Any()).Times(1)
-	assert.Nil(mBs.Stop(ctx))
-}
-
 func TestBlockSyncerProcessSyncRequest(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -547,4 +524,5 @@ func TestDummyBlockSync(t *testing.T) {
 	require.Zero(currentHeight)
 	require.Zero(targetHeight)
 	require.Empty(desc)
+	require.Equal(SyncProgress{Stage: SyncStageIdle}, bs.SyncProgress())
 }
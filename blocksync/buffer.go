@@ -47,6 +47,20 @@ func (b *blockBuffer) Pop(height uint64) []*peerBlock {
 	return blks
 }
 
+// Contiguous returns the blocks buffered at the contiguous heights from the height
+func (b *blockBuffer) Contiguous(height uint64) []*peerBlock {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	var blks []*peerBlock
+	for h := height; ; h++ {
+		queue, ok := b.blockQueues[h]
+		if !ok {
+			return blks
+		}
+		blks = append(blks, queue.blocks...)
+	}
+}
+
 func (b *blockBuffer) Cleanup(height uint64) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
@@ -267,3 +267,27 @@ func TestBlockBufferGetBlocksIntervalsToSync(t *testing.T) {
 	// There should always have at least 1 interval range to sync
 	assert.Len(b.GetBlocksIntervalsToSync(chain.TipHeight(), 0), 1)
 }
+
+func TestBlockBufferContiguous(t *testing.T) {
+	require := require.New(t)
+	b := newBlockBuffer(16, 8)
+	for _, h := range []uint64{1, 2, 2, 3, 5} {
+		blk := block.NewBlockDeprecated(
+			uint32(123),
+			h,
+			hash.Hash256{},
+			testutil.TimestampNow(),
+			identityset.PrivateKey(27+int(h)).PublicKey(),
+			nil,
+		)
+		added, _ := b.AddBlock(0, newPeerBlock("peer1", blk))
+		require.True(added)
+	}
+	blks := b.Contiguous(1)
+	require.Len(blks, 4)
+	for i, h := range []uint64{1, 2, 2, 3} {
+		require.Equal(h, blks[i].block.Height())
+	}
+	require.Len(b.Contiguous(5), 1)
+	require.Empty(b.Contiguous(4))
+}
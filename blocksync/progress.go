@@ -0,0 +1,124 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"sync"
+	"time"
+)
+
+// SyncStage is the stage of block sync
+type SyncStage string
+
+const (
+	// SyncStageIdle means the node has caught up with the best height announced by the peers
+	SyncStageIdle SyncStage = "idle"
+	// SyncStageBlockDownload means the node is downloading and committing the blocks from the peers
+	SyncStageBlockDownload SyncStage = "blockDownload"
+
+	// _progressWindow is the window of the tip heights the sync speed is measured over
+	_progressWindow = time.Minute
+)
+
+type (
+	// SyncProgress is the progress of block sync
+	SyncProgress struct {
+		// StartingHeight is the tip height the node started to synchronise from
+		StartingHeight uint64
+		// CurrentHeight is the tip height of the node
+		CurrentHeight uint64
+		// TargetHeight is the best height announced by the peers
+		TargetHeight uint64
+		// BlocksPerSecond is the sync speed over the last minute
+		BlocksPerSecond float64
+		// EstimatedRemaining is the estimated time to reach the target height, 0 if the speed is unknown
+		EstimatedRemaining time.Duration
+		Stage              SyncStage
+	}
+
+	progressSample struct {
+		time   time.Time
+		height uint64
+	}
+
+	// progressTracker measures the sync speed from the tip heights recorded in a sliding window
+	progressTracker struct {
+		mu      sync.Mutex
+		window  time.Duration
+		samples []progressSample
+	}
+)
+
+// Syncing returns true if the node is behind the target height
+func (p SyncProgress) Syncing() bool {
+	return p.CurrentHeight < p.TargetHeight
+}
+
+func newProgressTracker(window time.Duration) *progressTracker {
+	return &progressTracker{
+		window: window,
+	}
+}
+
+// record records the tip height at the time
+func (pt *progressTracker) record(now time.Time, height uint64) {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+	if n := len(pt.samples); n > 0 && pt.samples[n-1].height == height {
+		return
+	}
+	pt.samples = append(pt.samples, progressSample{now, height})
+	pt.prune(now)
+}
+
+// speed returns the blocks per second in the window ending at the time
+func (pt *progressTracker) speed(now time.Time) float64 {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+	pt.prune(now)
+	if len(pt.samples) == 0 {
+		return 0
+	}
+	first, last := pt.samples[0], pt.samples[len(pt.samples)-1]
+	if last.height <= first.height {
+		return 0
+	}
+	// the window is measured up to now, so the speed drops once the blocks stop coming
+	elapsed := now.Sub(first.time)
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(last.height-first.height) / elapsed.Seconds()
+}
+
+// progress returns the sync progress at the time
+func (pt *progressTracker) progress(now time.Time, start, current, target uint64) SyncProgress {
+	p := SyncProgress{
+		StartingHeight: start,
+		CurrentHeight:  current,
+		TargetHeight:   target,
+		Stage:          SyncStageIdle,
+	}
+	if !p.Syncing() {
+		return p
+	}
+	p.Stage = SyncStageBlockDownload
+	p.BlocksPerSecond = pt.speed(now)
+	if p.BlocksPerSecond > 0 {
+		p.EstimatedRemaining = time.Duration(float64(target-current) / p.BlocksPerSecond * float64(time.Second))
+	}
+	return p
+}
+
+// prune drops the samples older than the window, except the latest one which is the base of the next samples
+func (pt *progressTracker) prune(now time.Time) {
+	cutoff := now.Add(-pt.window)
+	i := 0
+	for i < len(pt.samples)-1 && pt.samples[i].time.Before(cutoff) {
+		i++
+	}
+	pt.samples = pt.samples[i:]
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressTracker(t *testing.T) {
+	r := require.New(t)
+	pt := newProgressTracker(time.Minute)
+	now := time.Unix(1700000000, 0)
+
+	// no speed before two heights are recorded
+	p := pt.progress(now, 0, 0, 100)
+	r.True(p.Syncing())
+	r.Equal(SyncStageBlockDownload, p.Stage)
+	r.Zero(p.BlocksPerSecond)
+	r.Zero(p.EstimatedRemaining)
+
+	pt.record(now, 0)
+	pt.record(now.Add(10*time.Second), 20)
+	// the same height is recorded once
+	pt.record(now.Add(15*time.Second), 20)
+	r.Len(pt.samples, 2)
+	p = pt.progress(now.Add(10*time.Second), 0, 20, 100)
+	r.Equal(2.0, p.BlocksPerSecond)
+	r.Equal(40*time.Second, p.EstimatedRemaining)
+
+	// the speed is measured over the last minute
+	pt.record(now.Add(70*time.Second), 80)
+	r.Len(pt.samples, 2)
+	p = pt.progress(now.Add(70*time.Second), 0, 80, 100)
+	r.Equal(1.0, p.BlocksPerSecond)
+	r.Equal(20*time.Second, p.EstimatedRemaining)
+
+	// the speed drops once the blocks stop coming
+	r.Zero(pt.speed(now.Add(200 * time.Second)))
+
+	p = pt.progress(now.Add(70*time.Second), 0, 100, 100)
+	r.False(p.Syncing())
+	r.Equal(SyncStageIdle, p.Stage)
+	r.Zero(p.BlocksPerSecond)
+}
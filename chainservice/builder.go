@@ -8,10 +8,12 @@ package chainservice
 import (
 	"context"
 	"math/big"
+	"os"
 	"time"
 
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-election/committee"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
@@ -23,6 +25,7 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol/execution"
 	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
 	"github.com/iotexproject/iotex-core/action/protocol/poll"
+	"github.com/iotexproject/iotex-core/action/protocol/randomness"
 	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
 	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/action/protocol/staking"
@@ -30,6 +33,7 @@ import (
 	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/actsync"
 	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/backup"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/blockdao"
 	"github.com/iotexproject/iotex-core/blockchain/filedao"
@@ -46,6 +50,7 @@ import (
 	"github.com/iotexproject/iotex-core/p2p"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/util/blockutil"
+	"github.com/iotexproject/iotex-core/pkg/util/fileutil"
 	"github.com/iotexproject/iotex-core/server/itx/nodestats"
 	"github.com/iotexproject/iotex-core/state/factory"
 	"github.com/iotexproject/iotex-core/systemcontractindex/stakingindex"
@@ -53,8 +58,10 @@ import (
 
 // Builder is a builder to build chainservice
 type Builder struct {
-	cfg config.Config
-	cs  *ChainService
+	cfg             config.Config
+	cs              *ChainService
+	commitGroup     *db.CommitGroup
+	selectionPolicy actpool.ActionSelectionPolicy
 }
 
 // NewBuilder creates a new chainservice builder
@@ -72,6 +79,13 @@ func (builder *Builder) SetActionPool(ap actpool.ActPool) *Builder {
 	return builder
 }
 
+// SetActionSelectionPolicy sets the policy the block proposer selects the actions from the action pool by, the
+// actions are selected by gas price if it is not set
+func (builder *Builder) SetActionSelectionPolicy(p actpool.ActionSelectionPolicy) *Builder {
+	builder.selectionPolicy = p
+	return builder
+}
+
 // SetBlockchain sets the blockchain instance
 func (builder *Builder) SetBlockchain(bc blockchain.Blockchain) *Builder {
 	builder.createInstance()
@@ -141,7 +155,7 @@ func (builder *Builder) Build() (*ChainService, error) {
 
 func (builder *Builder) createInstance() {
 	if builder.cs == nil {
-		builder.cs = &ChainService{}
+		builder.cs = &ChainService{kvStores: make(map[string]db.KVStore)}
 	}
 }
 
@@ -179,7 +193,8 @@ func (builder *Builder) createFactory(forTest bool) (factory.Factory, error) {
 		if err != nil {
 			return nil, err
 		}
-		return factory.NewStateDB(factoryCfg, dao, opts...)
+		builder.cs.kvStores[backup.StateStore] = dao
+		return factory.NewStateDB(factoryCfg, builder.joinCommitGroup(dao), opts...)
 	}
 	if forTest {
 		return factory.NewFactory(factoryCfg, db.NewMemKVStore(), factory.RegistryOption(builder.cs.registry))
@@ -188,9 +203,10 @@ func (builder *Builder) createFactory(forTest bool) (factory.Factory, error) {
 	if err != nil {
 		return nil, err
 	}
+	builder.cs.kvStores[backup.StateStore] = dao
 	return factory.NewFactory(
 		factoryCfg,
-		dao,
+		builder.joinCommitGroup(dao),
 		factory.RegistryOption(builder.cs.registry),
 		factory.DefaultTriePatchOption(),
 	)
@@ -285,6 +301,24 @@ func (builder *Builder) buildBlockDAO(forTest bool) error {
 	if builder.cs.bfIndexer != nil {
 		indexers = append(indexers, builder.cs.bfIndexer)
 	}
+	if builder.cs.tokenTransferIndexer != nil {
+		indexers = append(indexers, builder.cs.tokenTransferIndexer)
+	}
+	if builder.cs.candHistoryIndexer != nil {
+		indexers = append(indexers, builder.cs.candHistoryIndexer)
+	}
+	if builder.cs.systemActionIndexer != nil {
+		indexers = append(indexers, builder.cs.systemActionIndexer)
+	}
+	if builder.cs.contractStatsIndexer != nil {
+		indexers = append(indexers, builder.cs.contractStatsIndexer)
+	}
+	if builder.cs.memoIndexer != nil {
+		indexers = append(indexers, builder.cs.memoIndexer)
+	}
+	if builder.cs.blockTimeIndexer != nil {
+		indexers = append(indexers, builder.cs.blockTimeIndexer)
+	}
 	var (
 		err   error
 		store blockdao.BlockDAO
@@ -294,16 +328,39 @@ func (builder *Builder) buildBlockDAO(forTest bool) error {
 	} else {
 		dbConfig := builder.cfg.DB
 		dbConfig.DbPath = builder.cfg.Chain.ChainDBPath
-		store, err = filedao.NewFileDAO(dbConfig, block.NewDeserializer(builder.cfg.Chain.EVMNetworkID))
+		builder.cs.fileDAO, err = filedao.NewFileDAO(dbConfig, block.NewDeserializer(builder.cfg.Chain.EVMNetworkID))
+		store = builder.cs.fileDAO
+		builder.cs.chainDBPath = dbConfig.DbPath
 	}
 	if err != nil {
 		return err
 	}
-	builder.cs.blockdao = blockdao.NewBlockDAOWithIndexersAndCache(store, indexers, builder.cfg.DB.MaxCacheSize)
+	var opts []blockdao.Option
+	if builder.commitGroup != nil {
+		opts = append(opts, blockdao.CommitGroupOption(builder.commitGroup))
+	}
+	if !forTest {
+		opts = append(opts, blockdao.CommitJournalOption(builder.cfg.Chain.ChainDBPath+".journal", block.NewDeserializer(builder.cfg.Chain.EVMNetworkID)))
+	}
+	builder.cs.blockdao = blockdao.NewBlockDAOWithIndexersAndCache(store, indexers, builder.cfg.DB.MaxCacheSize, opts...)
 
 	return nil
 }
 
+// stampStores checks the stores on disk, which may live on different volumes, belong to the chain of the genesis
+// before any of them is opened
+func (builder *Builder) stampStores(forTest bool) error {
+	if forTest {
+		return nil
+	}
+	paths := make(map[string]string, len(builder.cs.kvStores)+1)
+	for name := range builder.cs.kvStores {
+		paths[name] = builder.cs.storePaths[name]
+	}
+	paths[backup.ChainStore] = builder.cfg.Chain.ChainDBPath
+	return backup.StampStores(paths, builder.cfg.Genesis.Hash())
+}
+
 func (builder *Builder) buildContractStakingIndexer(forTest bool) error {
 	if !builder.cfg.Chain.EnableStakingProtocol {
 		return nil
@@ -313,9 +370,11 @@ func (builder *Builder) buildContractStakingIndexer(forTest bool) error {
 		builder.cs.contractStakingIndexerV2 = nil
 		return nil
 	}
-	dbConfig := builder.cfg.DB
-	dbConfig.DbPath = builder.cfg.Chain.ContractStakingIndexDBPath
-	kvstore := db.NewBoltDB(dbConfig)
+	store, err := builder.createIndexKVStore(builder.cfg.Chain.ContractStakingIndexDBPath)
+	if err != nil {
+		return err
+	}
+	kvstore := builder.joinCommitGroup(store)
 	// build contract staking indexer
 	if builder.cs.contractStakingIndexer == nil && len(builder.cfg.Genesis.SystemStakingContractAddress) > 0 {
 		voteCalcConsts := builder.cfg.Genesis.VoteWeightCalConsts
@@ -343,10 +402,186 @@ func (builder *Builder) buildContractStakingIndexer(forTest bool) error {
 		)
 		builder.cs.contractStakingIndexerV2 = indexer
 	}
+	if builder.cs.contractStakingIndexer != nil || builder.cs.contractStakingIndexerV2 != nil {
+		builder.cs.kvStores[backup.ContractStakingIndexStore] = store
+	}
+
+	return nil
+}
+
+func (builder *Builder) buildTokenTransferIndexer(forTest bool) error {
+	if !builder.cfg.Chain.EnableTokenTransferIndexer || builder.cs.tokenTransferIndexer != nil {
+		return nil
+	}
+	if forTest {
+		indexer, err := blockindex.NewTokenTransferIndexer(db.NewMemKVStore())
+		if err != nil {
+			return err
+		}
+		builder.cs.tokenTransferIndexer = indexer
+		return nil
+	}
+	// the history is indexed by the block DAO on start, if the indexer is enabled the first time
+	store, err := builder.createIndexKVStore(builder.cfg.Chain.TokenTransferIndexDBPath)
+	if err != nil {
+		return err
+	}
+	builder.cs.kvStores[backup.TokenTransferIndexStore] = store
+	indexer, err := blockindex.NewTokenTransferIndexer(builder.joinCommitGroup(store))
+	if err != nil {
+		return err
+	}
+	builder.cs.tokenTransferIndexer = indexer
+	return nil
+}
+
+func (builder *Builder) buildCandidateHistoryIndexer(forTest bool) error {
+	if !builder.cfg.Chain.EnableStakingProtocol || !builder.cfg.Chain.EnableCandidateHistoryIndexer || builder.cs.candHistoryIndexer != nil {
+		return nil
+	}
+	var (
+		sf = builder.cs.factory
+		// the indexer is put after the factory into the block DAO, so the state at the height of the block being
+		// indexed is the tip state, and the history is read from the archive if the indexer is enabled the first time
+		stateReaderAt = func(height uint64) (protocol.StateReader, error) {
+			tip, err := sf.Height()
+			if err != nil {
+				return nil, err
+			}
+			if height == tip {
+				return sf, nil
+			}
+			if height < sf.EarliestStateHeight() {
+				return nil, errors.Wrapf(staking.ErrCandidateHistoryNA, "the state at height %d is not available", height)
+			}
+			return factory.NewHistoryStateReader(sf, height), nil
+		}
+		store db.KVStore
+	)
+	if forTest {
+		store = db.NewMemKVStore()
+	} else {
+		kvStore, err := builder.createIndexKVStore(builder.cfg.Chain.CandidateHistoryIndexDBPath)
+		if err != nil {
+			return err
+		}
+		builder.cs.kvStores[backup.CandidateHistoryIndexStore] = kvStore
+		store = builder.joinCommitGroup(kvStore)
+	}
+	indexer, err := staking.NewCandidateHistoryIndexer(store, builder.newRollDPoSProtocol(), stateReaderAt)
+	if err != nil {
+		return err
+	}
+	builder.cs.candHistoryIndexer = indexer
+	return nil
+}
 
+func (builder *Builder) buildSystemActionIndexer(forTest bool) error {
+	if !builder.cfg.Chain.EnableSystemActionIndexer || builder.cs.systemActionIndexer != nil {
+		return nil
+	}
+	var store db.KVStore
+	if forTest {
+		store = db.NewMemKVStore()
+	} else {
+		kvStore, err := builder.createIndexKVStore(builder.cfg.Chain.SystemActionIndexDBPath)
+		if err != nil {
+			return err
+		}
+		builder.cs.kvStores[backup.SystemActionIndexStore] = kvStore
+		store = builder.joinCommitGroup(kvStore)
+	}
+	indexer, err := blockindex.NewSystemActionIndexer(store, builder.newRollDPoSProtocol())
+	if err != nil {
+		return err
+	}
+	builder.cs.systemActionIndexer = indexer
+	return nil
+}
+
+func (builder *Builder) buildContractStatsIndexer(forTest bool) error {
+	if !builder.cfg.Chain.EnableContractStatsIndexer || builder.cs.contractStatsIndexer != nil {
+		return nil
+	}
+	var store db.KVStore
+	if forTest {
+		store = db.NewMemKVStore()
+	} else {
+		// the history is indexed by the block DAO on start, if the indexer is enabled the first time
+		kvStore, err := builder.createIndexKVStore(builder.cfg.Chain.ContractStatsIndexDBPath)
+		if err != nil {
+			return err
+		}
+		builder.cs.kvStores[backup.ContractStatsIndexStore] = kvStore
+		store = builder.joinCommitGroup(kvStore)
+	}
+	indexer, err := blockindex.NewContractStatsIndexer(store, builder.cfg.Chain.ContractStatsMinDailyCalls)
+	if err != nil {
+		return err
+	}
+	builder.cs.contractStatsIndexer = indexer
+	return nil
+}
+
+func (builder *Builder) buildMemoIndexer(forTest bool) error {
+	if !builder.cfg.Chain.EnableMemoIndexer || builder.cs.memoIndexer != nil {
+		return nil
+	}
+	var store db.KVStore
+	if forTest {
+		store = db.NewMemKVStore()
+	} else {
+		// the history is indexed by the block DAO on start, if the indexer is enabled the first time
+		kvStore, err := builder.createIndexKVStore(builder.cfg.Chain.MemoIndexDBPath)
+		if err != nil {
+			return err
+		}
+		builder.cs.kvStores[backup.MemoIndexStore] = kvStore
+		store = builder.joinCommitGroup(kvStore)
+	}
+	indexer, err := blockindex.NewMemoIndexer(store)
+	if err != nil {
+		return err
+	}
+	builder.cs.memoIndexer = indexer
+	return nil
+}
+
+func (builder *Builder) buildBlockTimeIndexer(forTest bool) error {
+	if !builder.cfg.Chain.EnableBlockTimeIndexer || builder.cs.blockTimeIndexer != nil {
+		return nil
+	}
+	var store db.KVStore
+	if forTest {
+		store = db.NewMemKVStore()
+	} else {
+		// the history is indexed by the block DAO on start, if the indexer is enabled the first time
+		kvStore, err := builder.createIndexKVStore(builder.cfg.Chain.BlockTimeIndexDBPath)
+		if err != nil {
+			return err
+		}
+		builder.cs.kvStores[backup.BlockTimeIndexStore] = kvStore
+		store = builder.joinCommitGroup(kvStore)
+	}
+	indexer, err := blockindex.NewBlockTimeIndexer(store)
+	if err != nil {
+		return err
+	}
+	builder.cs.blockTimeIndexer = indexer
 	return nil
 }
 
+// newRollDPoSProtocol creates the roll dpos protocol of the genesis, which converts between heights and epochs
+func (builder *Builder) newRollDPoSProtocol() *rolldpos.Protocol {
+	g := builder.cfg.Genesis
+	return rolldpos.NewProtocol(
+		g.NumCandidateDelegates,
+		g.NumDelegates,
+		g.NumSubEpochs,
+		rolldpos.EnableDardanellesSubEpoch(g.DardanellesBlockHeight, g.DardanellesNumSubEpochs),
+	)
+}
+
 func (builder *Builder) buildGatewayComponents(forTest bool) error {
 	indexer, bfIndexer, candidateIndexer, candBucketsIndexer, err := builder.createGateWayComponents(forTest)
 	if err != nil {
@@ -396,35 +631,75 @@ func (builder *Builder) createGateWayComponents(forTest bool) (
 		}
 		return
 	}
-	dbConfig := builder.cfg.DB
-	dbConfig.DbPath = builder.cfg.Chain.IndexDBPath
-	indexer, err = blockindex.NewIndexer(db.NewBoltDB(dbConfig), builder.cfg.Genesis.Hash())
+	var kvStore db.KVStore
+	if kvStore, err = builder.createIndexKVStore(builder.cfg.Chain.IndexDBPath); err != nil {
+		return
+	}
+	builder.cs.kvStores[backup.IndexStore] = kvStore
+	if !builder.cfg.Chain.EnableAsyncIndexWrite {
+		// the index is written by the block DAO only if not written asynchronously
+		kvStore = builder.joinCommitGroup(kvStore)
+	}
+	indexer, err = blockindex.NewIndexer(kvStore, builder.cfg.Genesis.Hash())
 	if err != nil {
 		return
 	}
 
 	// create bloomfilter indexer
-	dbConfig.DbPath = builder.cfg.Chain.BloomfilterIndexDBPath
-	bfIndexer, err = blockindex.NewBloomfilterIndexer(db.NewBoltDB(dbConfig), builder.cfg.Indexer)
+	if kvStore, err = builder.createIndexKVStore(builder.cfg.Chain.BloomfilterIndexDBPath); err != nil {
+		return
+	}
+	builder.cs.kvStores[backup.BloomfilterIndexStore] = kvStore
+	bfIndexer, err = blockindex.NewBloomfilterIndexer(kvStore, builder.cfg.Indexer)
 	if err != nil {
 		return
 	}
 
 	// create candidate indexer
-	dbConfig.DbPath = builder.cfg.Chain.CandidateIndexDBPath
-	candidateIndexer, err = poll.NewCandidateIndexer(db.NewBoltDB(dbConfig))
+	if kvStore, err = builder.createIndexKVStore(builder.cfg.Chain.CandidateIndexDBPath); err != nil {
+		return
+	}
+	builder.cs.kvStores[backup.CandidateIndexStore] = kvStore
+	candidateIndexer, err = poll.NewCandidateIndexer(kvStore)
 	if err != nil {
 		return
 	}
 
 	// create staking indexer
 	if builder.cfg.Chain.EnableStakingIndexer {
-		dbConfig.DbPath = builder.cfg.Chain.StakingIndexDBPath
-		candBucketsIndexer, err = staking.NewStakingCandidatesBucketsIndexer(db.NewBoltDB(dbConfig))
+		if kvStore, err = builder.createIndexKVStore(builder.cfg.Chain.StakingIndexDBPath); err != nil {
+			return
+		}
+		builder.cs.kvStores[backup.StakingIndexStore] = kvStore
+		kvRange, ok := kvStore.(db.KVStoreForRangeIndex)
+		if !ok {
+			err = errors.Errorf("db type %s doesn't support range index", builder.cfg.Chain.IndexDBType)
+			return
+		}
+		candBucketsIndexer, err = staking.NewStakingCandidatesBucketsIndexer(kvRange)
 	}
 	return
 }
 
+// joinCommitGroup joins the store written by the indexers of the block DAO into the commit group if enabled, so
+// the writes of a block into the stores are flushed together
+func (builder *Builder) joinCommitGroup(store db.KVStore) db.KVStore {
+	if !builder.cfg.Chain.EnableGroupCommit {
+		return store
+	}
+	if builder.commitGroup == nil {
+		builder.commitGroup = db.NewCommitGroup()
+	}
+	return builder.commitGroup.Join(store)
+}
+
+// createIndexKVStore creates the db of an indexer in the path, of the type configured for indexers
+func (builder *Builder) createIndexKVStore(path string) (db.KVStore, error) {
+	dbConfig := builder.cfg.DB
+	dbConfig.DBType = builder.cfg.Chain.IndexDBType
+	return db.CreateKVStore(dbConfig, path)
+}
+
 func (builder *Builder) buildBlockchain(forSubChain, forTest bool) error {
 	builder.cs.chain = builder.createBlockchain(forSubChain, forTest)
 	builder.cs.lifecycle.Add(builder.cs.chain)
@@ -432,12 +707,29 @@ func (builder *Builder) buildBlockchain(forSubChain, forTest bool) error {
 	if err := builder.cs.chain.AddSubscriber(builder.cs.actpool); err != nil {
 		return errors.Wrap(err, "failed to add actpool as subscriber")
 	}
+	builder.cs.finality = NewFinalityTracker(builder.cs.chain.TipHeight)
+	builder.cs.lifecycle.Add(builder.cs.finality)
+	if err := builder.cs.chain.AddSubscriber(builder.cs.finality); err != nil {
+		return errors.Wrap(err, "failed to add finality tracker as subscriber")
+	}
 	if builder.cs.indexer != nil && builder.cfg.Chain.EnableAsyncIndexWrite {
 		// config asks for a standalone indexer
-		indexBuilder, err := blockindex.NewIndexBuilder(builder.cs.chain.ChainID(), builder.cfg.Genesis, builder.cs.blockdao, builder.cs.indexer)
+		var opts []blockindex.IndexBuilderOption
+		if !forTest {
+			opt, err := builder.createReindexOption()
+			if err != nil {
+				return errors.Wrap(err, "failed to create reindex option")
+			}
+			if opt != nil {
+				opts = append(opts, opt)
+			}
+		}
+		indexBuilder, err := blockindex.NewIndexBuilder(builder.cs.chain.ChainID(), builder.cfg.Genesis, builder.cs.blockdao, builder.cs.indexer, opts...)
 		if err != nil {
 			return errors.Wrap(err, "failed to create index builder")
 		}
+		builder.cs.indexer = indexBuilder.Indexer()
+		builder.cs.indexBuilder = indexBuilder
 		builder.cs.lifecycle.Add(indexBuilder)
 		if err := builder.cs.chain.AddSubscriber(indexBuilder); err != nil {
 			return errors.Wrap(err, "failed to add index builder as subscriber")
@@ -446,18 +738,83 @@ func (builder *Builder) buildBlockchain(forSubChain, forTest bool) error {
 	return nil
 }
 
+// createReindexOption returns the option to rebuild the block index into a shadow db, if asked by the config or
+// a rebuild was interrupted. Once the rebuild completes, the shadow db replaces the index db
+func (builder *Builder) createReindexOption() (blockindex.IndexBuilderOption, error) {
+	var (
+		indexPath  = builder.cfg.Chain.IndexDBPath
+		shadowPath = indexPath + ".reindex"
+	)
+	if !builder.cfg.Indexer.Reindex && !fileutil.FileExists(shadowPath) {
+		return nil, nil
+	}
+	kvStore, err := builder.createIndexKVStore(shadowPath)
+	if err != nil {
+		return nil, err
+	}
+	shadow, err := blockindex.NewIndexer(kvStore, builder.cfg.Genesis.Hash())
+	if err != nil {
+		return nil, err
+	}
+	indexer := builder.cs.indexer
+	cs := builder.cs
+	return blockindex.ReindexOption(builder.cfg.Indexer, shadow, func(ctx context.Context) (blockindex.Indexer, error) {
+		if err := shadow.Stop(ctx); err != nil {
+			return nil, errors.Wrap(err, "failed to stop the shadow indexer")
+		}
+		if err := indexer.Stop(ctx); err != nil {
+			return nil, errors.Wrap(err, "failed to stop the indexer")
+		}
+		if err := os.RemoveAll(indexPath); err != nil {
+			return nil, errors.Wrapf(err, "failed to remove %s", indexPath)
+		}
+		if err := os.Rename(shadowPath, indexPath); err != nil {
+			return nil, errors.Wrapf(err, "failed to rename %s to %s", shadowPath, indexPath)
+		}
+		kvStore, err := builder.createIndexKVStore(indexPath)
+		if err != nil {
+			return nil, err
+		}
+		newIndexer, err := blockindex.NewIndexer(kvStore, builder.cfg.Genesis.Hash())
+		if err != nil {
+			return nil, err
+		}
+		if err := newIndexer.Start(ctx); err != nil {
+			return nil, err
+		}
+		cs.kvStoresMutex.Lock()
+		cs.kvStores[backup.IndexStore] = kvStore
+		cs.kvStoresMutex.Unlock()
+		return newIndexer, nil
+	}), nil
+}
+
 func (builder *Builder) createBlockchain(forSubChain, forTest bool) blockchain.Blockchain {
 	if builder.cs.chain != nil {
 		return builder.cs.chain
 	}
-	var chainOpts []blockchain.Option
+	chainOpts := []blockchain.Option{blockchain.ProducerSignerOption(builder.cs.producerSigner)}
 	if !forSubChain {
 		chainOpts = append(chainOpts, blockchain.BlockValidatorOption(block.NewValidator(builder.cs.factory, builder.cs.actpool)))
 	} else {
 		chainOpts = append(chainOpts, blockchain.BlockValidatorOption(builder.cs.factory))
 	}
 
-	return blockchain.NewBlockchain(builder.cfg.Chain, builder.cfg.Genesis, builder.cs.blockdao, factory.NewMinter(builder.cs.factory, builder.cs.actpool), chainOpts...)
+	var (
+		minterOpts []factory.MinterOption
+		policy     = builder.selectionPolicy
+		apCfg      = builder.cfg.ActPool
+	)
+	if apCfg.LargeActionSize > 0 && apCfg.LargeActionsBlockShare > 0 {
+		if policy == nil {
+			policy = actpool.NewDefaultSelectionPolicy()
+		}
+		policy = actpool.NewLargeActionCapPolicy(policy, apCfg.LargeActionSize, apCfg.LargeActionsBlockShare)
+	}
+	if policy != nil {
+		minterOpts = append(minterOpts, factory.WithActionSelectionPolicy(policy))
+	}
+	return blockchain.NewBlockchain(builder.cfg.Chain, builder.cfg.Genesis, builder.cs.blockdao, factory.NewMinter(builder.cs.factory, builder.cs.actpool, minterOpts...), chainOpts...)
 }
 
 func (builder *Builder) buildNodeInfoManager() error {
@@ -467,7 +824,7 @@ func (builder *Builder) buildNodeInfoManager() error {
 		return errors.New("cannot find staking protocol")
 	}
 	chain := builder.cs.chain
-	dm := nodeinfo.NewInfoManager(&builder.cfg.NodeInfo, cs.p2pAgent, cs.chain, builder.cfg.Chain.ProducerPrivateKey(), func() []string {
+	dm := nodeinfo.NewInfoManager(&builder.cfg.NodeInfo, cs.p2pAgent, cs.chain, cs.producerSigner, func() []string {
 		ctx := protocol.WithFeatureCtx(
 			protocol.WithBlockCtx(
 				genesis.WithGenesisContext(context.Background(), chain.Genesis()),
@@ -490,6 +847,21 @@ func (builder *Builder) buildNodeInfoManager() error {
 	return nil
 }
 
+// buildCompactionScheduler builds the scheduler of the db compactions, which is added last, so the compaction in
+// progress is aborted before the stores are stopped
+func (builder *Builder) buildCompactionScheduler(forTest bool) error {
+	if forTest {
+		return nil
+	}
+	cs := builder.cs
+	cfg := builder.cfg.DB.Compaction
+	cs.compactionScheduler = db.NewCompactionScheduler(cfg, cs.compactionStores, func() (bool, error) {
+		return cs.lowActivityWindow(cfg)
+	})
+	cs.lifecycle.Add(cs.compactionScheduler)
+	return nil
+}
+
 func (builder *Builder) buildBlockSyncer() error {
 	if builder.cs.blocksync != nil {
 		return nil
@@ -614,16 +986,15 @@ func (builder *Builder) registerExecutionProtocol() error {
 	return execution.NewProtocol(builder.cs.blockdao.GetBlockHash, rewarding.DepositGas, builder.cs.blockTimeCalculator.CalculateBlockTime).Register(builder.cs.registry)
 }
 
+func (builder *Builder) registerRandomnessProtocol() error {
+	return randomness.NewProtocol().Register(builder.cs.registry)
+}
+
 func (builder *Builder) registerRollDPoSProtocol() error {
 	if builder.cfg.Consensus.Scheme != config.RollDPoSScheme {
 		return nil
 	}
-	if err := rolldpos.NewProtocol(
-		builder.cfg.Genesis.NumCandidateDelegates,
-		builder.cfg.Genesis.NumDelegates,
-		builder.cfg.Genesis.NumSubEpochs,
-		rolldpos.EnableDardanellesSubEpoch(builder.cfg.Genesis.DardanellesBlockHeight, builder.cfg.Genesis.DardanellesNumSubEpochs),
-	).Register(builder.cs.registry); err != nil {
+	if err := builder.newRollDPoSProtocol().Register(builder.cs.registry); err != nil {
 		return err
 	}
 	factory := builder.cs.factory
@@ -685,6 +1056,16 @@ func (builder *Builder) registerRollDPoSProtocol() error {
 	return pollProtocol.Register(builder.cs.registry)
 }
 
+// buildProducerSigner creates the signer of the producer key, which is the private key in the config, or the key in
+// an encrypted keystore or kept by a remote signer
+func (builder *Builder) buildProducerSigner() (err error) {
+	if builder.cs.producerSigner != nil {
+		return nil
+	}
+	builder.cs.producerSigner, err = builder.cfg.Chain.NewProducerSigner()
+	return errors.Wrap(err, "failed to create the producer signer")
+}
+
 func (builder *Builder) buildBlockTimeCalculator() (err error) {
 	consensusCfg := consensusfsm.NewConsensusConfig(builder.cfg.Consensus.RollDPoS.FSM, builder.cfg.DardanellesUpgrade, builder.cfg.Genesis, builder.cfg.Consensus.RollDPoS.Delay)
 	dao := builder.cs.BlockDAO()
@@ -698,6 +1079,47 @@ func (builder *Builder) buildBlockTimeCalculator() (err error) {
 	return err
 }
 
+// reportMisbehavior submits a misbehavior report of the conflicting consensus messages, signed by the producer key
+func (builder *Builder) reportMisbehavior(first, second *iotextypes.ConsensusMessage) {
+	l := log.Logger("consensus").With(zap.Uint64("height", first.GetHeight()))
+	firstBytes, err := proto.Marshal(first)
+	if err != nil {
+		l.Error("Failed to serialize the conflicting consensus message", zap.Error(err))
+		return
+	}
+	secondBytes, err := proto.Marshal(second)
+	if err != nil {
+		l.Error("Failed to serialize the conflicting consensus message", zap.Error(err))
+		return
+	}
+	ap := builder.cs.actpool
+	signer := builder.cs.producerSigner
+	nonce, err := ap.GetPendingNonce(signer.PublicKey().Address().String())
+	if err != nil {
+		l.Error("Failed to get the pending nonce of the producer", zap.Error(err))
+		return
+	}
+	gasLimit, err := action.NewReportMisbehavior(nonce, 0, nil, firstBytes, secondBytes).IntrinsicGas()
+	if err != nil {
+		l.Error("Failed to calculate the gas of the misbehavior report", zap.Error(err))
+		return
+	}
+	selp, err := action.SignedReportMisbehavior(nonce, firstBytes, secondBytes, gasLimit, builder.cfg.ActPool.MinGasPrice(),
+		signer, action.WithChainID(builder.cfg.Chain.ID))
+	if err != nil {
+		l.Error("Failed to sign the misbehavior report", zap.Error(err))
+		return
+	}
+	ctx := protocol.WithRegistry(context.Background(), builder.cs.registry)
+	if err := ap.Add(ctx, selp); err != nil {
+		l.Error("Failed to add the misbehavior report to actpool", zap.Error(err))
+		return
+	}
+	if err := builder.cs.p2pAgent.BroadcastOutbound(ctx, selp.Proto()); err != nil {
+		l.Error("Failed to broadcast the misbehavior report", zap.Error(err))
+	}
+}
+
 func (builder *Builder) buildConsensusComponent() error {
 	p2pAgent := builder.cs.p2pAgent
 	copts := []consensus.Option{
@@ -711,6 +1133,7 @@ func (builder *Builder) buildConsensusComponent() error {
 	if pollProtocol := poll.FindProtocol(builder.cs.registry); pollProtocol != nil {
 		copts = append(copts, consensus.WithPollProtocol(pollProtocol))
 	}
+	copts = append(copts, consensus.WithMisbehaviorReporter(builder.reportMisbehavior), consensus.WithSigner(builder.cs.producerSigner))
 
 	// TODO: explorer dependency deleted at #1085, need to revive by migrating to api
 	builderCfg := rp.BuilderConfig{
@@ -734,6 +1157,7 @@ func (builder *Builder) buildConsensusComponent() error {
 
 func (builder *Builder) build(forSubChain, forTest bool) (*ChainService, error) {
 	builder.cs.registry = protocol.NewRegistry()
+	builder.cs.storePaths = backup.StorePaths(builder.cfg.Chain)
 	if builder.cs.p2pAgent == nil {
 		builder.cs.p2pAgent = p2p.NewDummyAgent()
 	}
@@ -752,9 +1176,33 @@ func (builder *Builder) build(forSubChain, forTest bool) (*ChainService, error)
 	if err := builder.buildContractStakingIndexer(forTest); err != nil {
 		return nil, err
 	}
+	if err := builder.buildTokenTransferIndexer(forTest); err != nil {
+		return nil, err
+	}
+	if err := builder.buildCandidateHistoryIndexer(forTest); err != nil {
+		return nil, err
+	}
+	if err := builder.buildSystemActionIndexer(forTest); err != nil {
+		return nil, err
+	}
+	if err := builder.buildContractStatsIndexer(forTest); err != nil {
+		return nil, err
+	}
+	if err := builder.buildMemoIndexer(forTest); err != nil {
+		return nil, err
+	}
+	if err := builder.buildBlockTimeIndexer(forTest); err != nil {
+		return nil, err
+	}
+	if err := builder.stampStores(forTest); err != nil {
+		return nil, err
+	}
 	if err := builder.buildBlockDAO(forTest); err != nil {
 		return nil, err
 	}
+	if err := builder.buildProducerSigner(); err != nil {
+		return nil, err
+	}
 	if err := builder.buildBlockchain(forSubChain, forTest); err != nil {
 		return nil, err
 	}
@@ -777,6 +1225,12 @@ func (builder *Builder) build(forSubChain, forTest bool) (*ChainService, error)
 	if err := builder.registerRewardingProtocol(); err != nil {
 		return nil, errors.Wrap(err, "failed to register rewarding protocol")
 	}
+	if err := builder.registerRandomnessProtocol(); err != nil {
+		return nil, errors.Wrap(err, "failed to register randomness protocol")
+	}
+	if _, err := builder.cs.registry.PopulateParameters(builder.cfg.Genesis); err != nil {
+		return nil, errors.Wrap(err, "failed to populate protocol parameters")
+	}
 	if err := builder.buildConsensusComponent(); err != nil {
 		return nil, err
 	}
@@ -789,6 +1243,9 @@ func (builder *Builder) build(forSubChain, forTest bool) (*ChainService, error)
 	if err := builder.buildNodeInfoManager(); err != nil {
 		return nil, err
 	}
+	if err := builder.buildCompactionScheduler(forTest); err != nil {
+		return nil, err
+	}
 	cs := builder.cs
 	builder.cs = nil
 
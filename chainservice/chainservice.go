@@ -7,6 +7,9 @@ package chainservice
 
 import (
 	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
 
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/pkg/errors"
@@ -26,12 +29,17 @@ import (
 	"github.com/iotexproject/iotex-core/actsync"
 	"github.com/iotexproject/iotex-core/api"
 	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/backup"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/blockchain/filedao"
 	"github.com/iotexproject/iotex-core/blockindex"
 	"github.com/iotexproject/iotex-core/blockindex/contractstaking"
 	"github.com/iotexproject/iotex-core/blocksync"
 	"github.com/iotexproject/iotex-core/consensus"
+	"github.com/iotexproject/iotex-core/consensus/scheme/rolldpos"
+	cp "github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/nodeinfo"
 	"github.com/iotexproject/iotex-core/p2p"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
@@ -50,6 +58,10 @@ var (
 		},
 		[]string{"message_type"},
 	)
+
+	// ErrStopping indicates the error that the chain service is being stopped, and no longer accepts the
+	// incoming actions and blocks
+	ErrStopping = errors.New("chain service is stopping")
 )
 
 func init() {
@@ -63,6 +75,7 @@ type ChainService struct {
 	blocksync         blocksync.BlockSync
 	consensus         consensus.Consensus
 	chain             blockchain.Blockchain
+	finality          *FinalityTracker
 	factory           factory.Factory
 	blockdao          blockdao.BlockDAO
 	p2pAgent          p2p.Agent
@@ -74,20 +87,40 @@ type ChainService struct {
 	candBucketsIndexer       *staking.CandidatesBucketsIndexer
 	contractStakingIndexer   *contractstaking.Indexer
 	contractStakingIndexerV2 stakingindex.StakingIndexer
+	tokenTransferIndexer     blockindex.TokenTransferIndexer
+	candHistoryIndexer       *staking.CandidateHistoryIndexer
+	systemActionIndexer      blockindex.SystemActionIndexer
+	contractStatsIndexer     blockindex.ContractStatsIndexer
+	memoIndexer              blockindex.MemoIndexer
+	blockTimeIndexer         blockindex.BlockTimeIndexer
 	registry                 *protocol.Registry
 	nodeInfoManager          *nodeinfo.InfoManager
 	apiStats                 *nodestats.APILocalStats
 	blockTimeCalculator      *blockutil.BlockTimeCalculator
 	actionsync               *actsync.ActionSync
+	fileDAO                  filedao.FileDAO
+	chainDBPath              string
+	storePaths               map[string]string
+	kvStoresMutex            sync.Mutex
+	kvStores                 map[string]db.KVStore
+	indexBuilder             *blockindex.IndexBuilder
+	compactionScheduler      *db.CompactionScheduler
+	producerSigner           cp.Signer
+	stopping                 atomic.Bool
 }
 
 // Start starts the server
 func (cs *ChainService) Start(ctx context.Context) error {
+	cs.stopping.Store(false)
 	return cs.lifecycle.OnStartSequentially(ctx)
 }
 
-// Stop stops the server
+// Stop stops the server. The incoming actions and blocks are refused first, then the components are stopped in
+// the reverse order of their dependencies: the consensus abandons the current round and the block syncer stops
+// before the blockchain, which waits for the block being committed before closing the block dao, the indexers
+// and the state factory
 func (cs *ChainService) Stop(ctx context.Context) error {
+	cs.stopping.Store(true)
 	return cs.lifecycle.OnStopSequentially(ctx)
 }
 
@@ -98,6 +131,9 @@ func (cs *ChainService) ReportFullness(_ context.Context, messageType iotexrpc.M
 
 // HandleAction handles incoming action request.
 func (cs *ChainService) HandleAction(ctx context.Context, actPb *iotextypes.Action) error {
+	if cs.stopping.Load() {
+		return ErrStopping
+	}
 	act, err := (&action.Deserializer{}).SetEvmNetworkID(cs.chain.EvmNetworkID()).ActionToSealedEnvelope(actPb)
 	if err != nil {
 		return err
@@ -118,6 +154,9 @@ func (cs *ChainService) HandleAction(ctx context.Context, actPb *iotextypes.Acti
 
 // HandleActionHash handles incoming action hash request.
 func (cs *ChainService) HandleActionHash(ctx context.Context, actHash hash.Hash256, from string) error {
+	if cs.stopping.Load() {
+		return ErrStopping
+	}
 	_, err := cs.actpool.GetActionByHash(actHash)
 	if err == nil { // action already in pool
 		return nil
@@ -142,6 +181,9 @@ func (cs *ChainService) HandleActionRequest(ctx context.Context, peer peer.AddrI
 
 // HandleBlock handles incoming block request.
 func (cs *ChainService) HandleBlock(ctx context.Context, peer string, pbBlock *iotextypes.Block) error {
+	if cs.stopping.Load() {
+		return ErrStopping
+	}
 	blk, err := block.NewDeserializer(cs.chain.EvmNetworkID()).FromBlockProto(pbBlock)
 	if err != nil {
 		return err
@@ -160,6 +202,9 @@ func (cs *ChainService) HandleSyncRequest(ctx context.Context, peer peer.AddrInf
 
 // HandleConsensusMsg handles incoming consensus message.
 func (cs *ChainService) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
+	if cs.stopping.Load() {
+		return ErrStopping
+	}
 	return cs.consensus.HandleConsensusMsg(msg)
 }
 
@@ -192,6 +237,41 @@ func (cs *ChainService) BlockDAO() blockdao.BlockDAO {
 	return cs.blockdao
 }
 
+// Indexer returns the block indexer
+func (cs *ChainService) Indexer() blockindex.Indexer {
+	return cs.indexer
+}
+
+// TokenTransferIndexer returns the token transfer indexer, which is nil if not enabled
+func (cs *ChainService) TokenTransferIndexer() blockindex.TokenTransferIndexer {
+	return cs.tokenTransferIndexer
+}
+
+// CandidateHistoryIndexer returns the candidate history indexer, which is nil if not enabled
+func (cs *ChainService) CandidateHistoryIndexer() *staking.CandidateHistoryIndexer {
+	return cs.candHistoryIndexer
+}
+
+// SystemActionIndexer returns the system action indexer, which is nil if not enabled
+func (cs *ChainService) SystemActionIndexer() blockindex.SystemActionIndexer {
+	return cs.systemActionIndexer
+}
+
+// ContractStatsIndexer returns the contract stats indexer, which is nil if not enabled
+func (cs *ChainService) ContractStatsIndexer() blockindex.ContractStatsIndexer {
+	return cs.contractStatsIndexer
+}
+
+// MemoIndexer returns the transfer memo indexer, which is nil if not enabled
+func (cs *ChainService) MemoIndexer() blockindex.MemoIndexer {
+	return cs.memoIndexer
+}
+
+// BlockTimeIndexer returns the block time indexer, which is nil if not enabled
+func (cs *ChainService) BlockTimeIndexer() blockindex.BlockTimeIndexer {
+	return cs.blockTimeIndexer
+}
+
 // ActionPool returns the Action pool
 func (cs *ChainService) ActionPool() actpool.ActPool {
 	return cs.actpool
@@ -202,6 +282,19 @@ func (cs *ChainService) Consensus() consensus.Consensus {
 	return cs.consensus
 }
 
+// DelegateMonitor returns the delegate monitor of the roll-DPoS consensus, or nil if it is not enabled
+func (cs *ChainService) DelegateMonitor() *rolldpos.DelegateMonitor {
+	c, ok := cs.consensus.(*consensus.IotxConsensus)
+	if !ok {
+		return nil
+	}
+	r, ok := c.Scheme().(*rolldpos.RollDPoS)
+	if !ok {
+		return nil
+	}
+	return r.DelegateMonitor()
+}
+
 // BlockSync returns the block syncer
 func (cs *ChainService) BlockSync() blocksync.BlockSync {
 	return cs.blocksync
@@ -212,9 +305,49 @@ func (cs *ChainService) NodeInfoManager() *nodeinfo.InfoManager {
 	return cs.nodeInfoManager
 }
 
+// ProducerSigner returns the signer of the producer key
+func (cs *ChainService) ProducerSigner() cp.Signer {
+	return cs.producerSigner
+}
+
 // Registry returns a pointer to the registry
 func (cs *ChainService) Registry() *protocol.Registry { return cs.registry }
 
+// Backup takes an online backup of the chain db, the state db and the indexer dbs into dir, see backup.Backup
+func (cs *ChainService) Backup(ctx context.Context, dir string) (*backup.Manifest, error) {
+	fencer, ok := cs.chain.(blockchain.Fencer)
+	if !ok {
+		return nil, errors.New("blockchain doesn't support fencing the commits")
+	}
+	fd, ok := cs.fileDAO.(filedao.FileDAOWithBackup)
+	if !ok {
+		return nil, errors.New("chain db doesn't support backup")
+	}
+	cs.kvStoresMutex.Lock()
+	stores := make([]*backup.Store, 0, len(cs.kvStores))
+	for name, kvStore := range cs.kvStores {
+		store, ok := kvStore.(db.KVStoreWithBackup)
+		if !ok {
+			cs.kvStoresMutex.Unlock()
+			return nil, errors.Errorf("%s db doesn't support backup", name)
+		}
+		stores = append(stores, &backup.Store{Name: name, KVStore: store})
+	}
+	cs.kvStoresMutex.Unlock()
+	sort.Slice(stores, func(i, j int) bool {
+		return stores[i].Name < stores[j].Name
+	})
+	return backup.Backup(ctx, dir, fencer, &backup.Chain{Path: cs.chainDBPath, DAO: fd}, stores)
+}
+
+// IndexerStatus returns the status of the block indexer written asynchronously
+func (cs *ChainService) IndexerStatus() (*blockindex.IndexerStatus, error) {
+	if cs.indexBuilder == nil {
+		return nil, errors.New("block indexer is not written asynchronously")
+	}
+	return cs.indexBuilder.Status()
+}
+
 // NewAPIServer creates a new api server
 func (cs *ChainService) NewAPIServer(cfg api.Config, plugins map[int]interface{}) (*api.ServerV2, error) {
 	if cfg.GRPCPort == 0 && cfg.HTTPPort == 0 {
@@ -228,6 +361,27 @@ func (cs *ChainService) NewAPIServer(cfg api.Config, plugins map[int]interface{}
 		api.WithNativeElection(cs.electionCommittee),
 		api.WithAPIStats(cs.apiStats),
 	}
+	if cs.finality != nil {
+		apiServerOptions = append(apiServerOptions, api.WithFinalityReader(cs.finality))
+	}
+	if cs.tokenTransferIndexer != nil {
+		apiServerOptions = append(apiServerOptions, api.WithTokenTransferIndexer(cs.tokenTransferIndexer))
+	}
+	if cs.candHistoryIndexer != nil {
+		apiServerOptions = append(apiServerOptions, api.WithCandidateHistoryIndexer(cs.candHistoryIndexer))
+	}
+	if cs.systemActionIndexer != nil {
+		apiServerOptions = append(apiServerOptions, api.WithSystemActionIndexer(cs.systemActionIndexer))
+	}
+	if cs.contractStatsIndexer != nil {
+		apiServerOptions = append(apiServerOptions, api.WithContractStatsIndexer(cs.contractStatsIndexer))
+	}
+	if cs.memoIndexer != nil {
+		apiServerOptions = append(apiServerOptions, api.WithMemoIndexer(cs.memoIndexer))
+	}
+	if cs.blockTimeIndexer != nil {
+		apiServerOptions = append(apiServerOptions, api.WithBlockTimeIndexer(cs.blockTimeIndexer))
+	}
 
 	svr, err := api.NewServerV2(
 		cfg,
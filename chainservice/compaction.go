@@ -0,0 +1,61 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package chainservice
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/db"
+)
+
+// CompactionScheduler returns the scheduler of the db compactions
+func (cs *ChainService) CompactionScheduler() *db.CompactionScheduler {
+	return cs.compactionScheduler
+}
+
+// compactionStores returns the kv stores which could be compacted, sorted by names
+func (cs *ChainService) compactionStores() []*db.CompactionStore {
+	cs.kvStoresMutex.Lock()
+	stores := make([]*db.CompactionStore, 0, len(cs.kvStores))
+	for name, kvStore := range cs.kvStores {
+		if store, ok := kvStore.(db.KVStoreWithCompaction); ok {
+			stores = append(stores, &db.CompactionStore{Name: name, KVStore: store})
+		}
+	}
+	cs.kvStoresMutex.Unlock()
+	sort.Slice(stores, func(i, j int) bool {
+		return stores[i].Name < stores[j].Name
+	})
+	return stores
+}
+
+// lowActivityWindow returns true if the tip is away from the epoch boundaries, and the average ratio of the gas used
+// by the recent blocks is no more than the max block fullness
+func (cs *ChainService) lowActivityWindow(cfg db.CompactionConfig) (bool, error) {
+	tip := cs.chain.TipHeight()
+	if rp := rolldpos.FindProtocol(cs.registry); rp != nil {
+		epoch := rp.GetEpochNum(tip)
+		if tip < rp.GetEpochHeight(epoch)+cfg.EpochMargin || tip+cfg.EpochMargin > rp.GetEpochLastBlockHeight(epoch) {
+			return false, nil
+		}
+	}
+	var (
+		g           = cs.chain.Genesis()
+		used, limit uint64
+	)
+	for h := tip; h > 0 && h+cfg.RecentBlocks > tip; h-- {
+		header, err := cs.blockdao.HeaderByHeight(h)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to get header at height %d", h)
+		}
+		used += header.GasUsed()
+		limit += g.BlockGasLimitByHeight(h)
+	}
+	return float64(used) <= cfg.MaxBlockFullness*float64(limit), nil
+}
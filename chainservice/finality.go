@@ -0,0 +1,57 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package chainservice
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+)
+
+// FinalityTracker tracks the finalized height of the chain. Rolldpos commits a block only after the delegates have
+// reached the consensus on it, so a block is final once it's committed, and the finalized height follows the tip
+type FinalityTracker struct {
+	tipHeight func() uint64
+	height    atomic.Uint64
+}
+
+// NewFinalityTracker creates a finality tracker starting from the tip height of the chain
+func NewFinalityTracker(tipHeight func() uint64) *FinalityTracker {
+	return &FinalityTracker{tipHeight: tipHeight}
+}
+
+// Start marks the tip of the chain as final
+func (ft *FinalityTracker) Start(_ context.Context) error {
+	ft.height.Store(ft.tipHeight())
+	return nil
+}
+
+// Stop stops the tracker
+func (ft *FinalityTracker) Stop(_ context.Context) error {
+	return nil
+}
+
+// ReceiveBlock marks the committed block as final, the block must be above the finalized height
+func (ft *FinalityTracker) ReceiveBlock(blk *block.Block) error {
+	height := blk.Height()
+	for {
+		finalized := ft.height.Load()
+		if height <= finalized {
+			return errors.Wrapf(block.ErrFinalizedHeight, "block %d is at or below the finalized height %d", height, finalized)
+		}
+		if ft.height.CompareAndSwap(finalized, height) {
+			return nil
+		}
+	}
+}
+
+// FinalizedHeight returns the height of the last finalized block
+func (ft *FinalityTracker) FinalizedHeight() uint64 {
+	return ft.height.Load()
+}
@@ -0,0 +1,266 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package chainservice
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/blockchain/backup"
+	"github.com/iotexproject/iotex-core/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/state/factory"
+)
+
+var (
+	// ErrRollbackRefused indicates the error that rolling back to the target height is unsafe or impossible
+	ErrRollbackRefused = errors.New("rollback refused")
+)
+
+type (
+	// RollbackPlan describes what rolling back the chain from the tip height to the target height deletes
+	RollbackPlan struct {
+		TipHeight    uint64
+		TargetHeight uint64
+		// MinHeight is the lowest height allowed to roll back to by the lookback window of the protocols
+		MinHeight uint64
+		// Indexers are the indexers which delete their indices of the blocks above the target height
+		Indexers []string
+		// Rebuilt are the stores which can't be rolled back, and are deleted to be rebuilt from the blocks
+		// on the next start of the node
+		Rebuilt []string
+		// SnapshotDir is the state snapshot imported into the rebuilt state db, and SnapshotHeight is its
+		// height, the states are replayed from genesis if SnapshotDir is empty
+		SnapshotDir    string
+		SnapshotHeight uint64
+	}
+
+	namedIndexer struct {
+		name    string
+		indexer blockdao.BlockIndexer
+	}
+)
+
+// String returns the printable summary of the plan
+func (p *RollbackPlan) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "roll back from height %d to %d, deleting %d blocks\n", p.TipHeight, p.TargetHeight, p.TipHeight-p.TargetHeight)
+	fmt.Fprintf(&sb, "indexers rolled back: %s\n", strings.Join(p.Indexers, ", "))
+	fmt.Fprintf(&sb, "stores deleted and rebuilt on next start: %s\n", strings.Join(p.Rebuilt, ", "))
+	if p.SnapshotDir != "" {
+		fmt.Fprintf(&sb, "states replayed from snapshot %s at height %d", p.SnapshotDir, p.SnapshotHeight)
+	} else {
+		sb.WriteString("states replayed from genesis")
+	}
+	return sb.String()
+}
+
+// PlanRollback checks that the chain could be rolled back to the target height, and returns the plan of the
+// rollback. The node must be stopped, the stores are opened and closed by the plan and the rollback
+func (cs *ChainService) PlanRollback(ctx context.Context, target uint64, snapshotDir string) (*RollbackPlan, error) {
+	if cs.fileDAO == nil || cs.kvStores[backup.StateStore] == nil {
+		return nil, errors.Wrap(ErrRollbackRefused, "rollback requires the chain db and the state db on disk")
+	}
+	if err := cs.fileDAO.Start(ctx); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := cs.fileDAO.Stop(ctx); err != nil {
+			log.L().Error("failed to stop chain db", zap.Error(err))
+		}
+	}()
+	tip, err := cs.fileDAO.Height()
+	if err != nil {
+		return nil, err
+	}
+	if target == 0 || target >= tip {
+		return nil, errors.Wrapf(ErrRollbackRefused, "target height %d should be in [1, %d)", target, tip)
+	}
+	// the states are replayed from the blocks, and the rebuilt indexers are indexed from genesis
+	if _, err := cs.fileDAO.GetBlockHash(1); err != nil {
+		return nil, errors.Wrapf(ErrRollbackRefused, "chain db doesn't contain the blocks from genesis: %v", err)
+	}
+	plan := &RollbackPlan{
+		TipHeight:    tip,
+		TargetHeight: target,
+		MinHeight:    1,
+	}
+	// the delegates of the current epoch are elected by the states of the previous epoch, rolling back further
+	// changes the committees which the other nodes have already produced blocks with
+	if rp := rolldpos.FindProtocol(cs.registry); rp != nil {
+		if epoch := rp.GetEpochNum(tip); epoch > 1 {
+			plan.MinHeight = rp.GetEpochHeight(epoch - 1)
+		}
+	}
+	if target < plan.MinHeight {
+		return nil, errors.Wrapf(ErrRollbackRefused, "target height %d is below the lookback window starting at %d", target, plan.MinHeight)
+	}
+	if snapshotDir != "" {
+		if _, ok := cs.factory.(factory.SnapshotExporter); !ok {
+			return nil, errors.Wrap(ErrRollbackRefused, "importing snapshot requires the trie-based state db")
+		}
+		manifest, err := factory.ReadSnapshotManifest(snapshotDir)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read snapshot manifest")
+		}
+		if !manifest.Complete {
+			return nil, factory.ErrSnapshotIncomplete
+		}
+		if manifest.Height > target {
+			return nil, errors.Wrapf(ErrRollbackRefused, "snapshot height %d is above the target height %d", manifest.Height, target)
+		}
+		plan.SnapshotDir, plan.SnapshotHeight = snapshotDir, manifest.Height
+	}
+	for _, x := range cs.rollbackIndexers() {
+		plan.Indexers = append(plan.Indexers, x.name)
+	}
+	plan.Rebuilt = cs.rebuiltStores()
+	return plan, nil
+}
+
+// Rollback rolls back the chain to the target height of the plan. The indexers delete the blocks above the
+// target height one by one from the tip, then the chain db does, and the stores which can't be rolled back are
+// deleted, the state db is then initialized from the snapshot if any. The states and the deleted indices are
+// rebuilt by the block DAO on the next start of the node
+func (cs *ChainService) Rollback(ctx context.Context, plan *RollbackPlan) error {
+	g := cs.chain.Genesis()
+	ctx = genesis.WithGenesisContext(ctx, g)
+	if err := cs.fileDAO.Start(ctx); err != nil {
+		return err
+	}
+	defer func() {
+		if err := cs.fileDAO.Stop(ctx); err != nil {
+			log.L().Error("failed to stop chain db", zap.Error(err))
+		}
+	}()
+	tip, err := cs.fileDAO.Height()
+	if err != nil {
+		return err
+	}
+	if tip != plan.TipHeight {
+		return errors.Errorf("chain db height %d doesn't match the tip height %d of the plan", tip, plan.TipHeight)
+	}
+	indexers := cs.rollbackIndexers()
+	for _, x := range indexers {
+		if err := x.indexer.Start(ctx); err != nil {
+			return errors.Wrapf(err, "failed to start %s", x.name)
+		}
+	}
+	defer func() {
+		for _, x := range indexers {
+			if err := x.indexer.Stop(ctx); err != nil {
+				log.L().Error("failed to stop indexer", zap.String("indexer", x.name), zap.Error(err))
+			}
+		}
+	}()
+	for height := tip; height > plan.TargetHeight; height-- {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		blk, err := cs.fileDAO.GetBlockByHeight(height)
+		if err != nil {
+			return err
+		}
+		if blk.Receipts, err = cs.fileDAO.GetReceipts(height); err != nil {
+			return err
+		}
+		blkCtx := protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight:    height,
+			BlockTimeStamp: blk.Timestamp(),
+		}))
+		for _, x := range indexers {
+			// the indexer written asynchronously may be behind the chain db
+			h, err := x.indexer.Height()
+			if err != nil {
+				return err
+			}
+			if h < height {
+				continue
+			}
+			if err := x.indexer.DeleteTipBlock(blkCtx, blk); err != nil {
+				return errors.Wrapf(err, "failed to delete block %d from %s", height, x.name)
+			}
+		}
+		if err := cs.fileDAO.DeleteTipBlock(); err != nil {
+			return errors.Wrapf(err, "failed to delete block %d from chain db", height)
+		}
+	}
+	for _, name := range plan.Rebuilt {
+		if err := os.RemoveAll(cs.storePaths[name]); err != nil {
+			return errors.Wrapf(err, "failed to delete %s", name)
+		}
+	}
+	log.L().Info("rolled back chain db and indexers", zap.Uint64("height", plan.TargetHeight))
+	if plan.SnapshotDir == "" {
+		return nil
+	}
+	header, err := cs.fileDAO.HeaderByHeight(plan.SnapshotHeight)
+	if err != nil {
+		return err
+	}
+	// the state db is not started before, and is created empty at its path
+	dao := cs.kvStores[backup.StateStore]
+	if err := dao.Start(ctx); err != nil {
+		return err
+	}
+	if err := factory.ImportSnapshot(ctx, dao, plan.SnapshotDir, header); err != nil {
+		return errors.Wrap(err, "failed to import snapshot")
+	}
+	return dao.Stop(ctx)
+}
+
+// rollbackIndexers returns the indexers on disk which delete their indices of the tip block
+func (cs *ChainService) rollbackIndexers() []namedIndexer {
+	var indexers []namedIndexer
+	add := func(name string, indexer blockdao.BlockIndexer) {
+		if _, ok := cs.kvStores[name]; ok {
+			indexers = append(indexers, namedIndexer{name: name, indexer: indexer})
+		}
+	}
+	if cs.indexer != nil {
+		add(backup.IndexStore, cs.indexer)
+	}
+	if cs.tokenTransferIndexer != nil {
+		add(backup.TokenTransferIndexStore, cs.tokenTransferIndexer)
+	}
+	if cs.candHistoryIndexer != nil {
+		add(backup.CandidateHistoryIndexStore, cs.candHistoryIndexer)
+	}
+	if cs.systemActionIndexer != nil {
+		add(backup.SystemActionIndexStore, cs.systemActionIndexer)
+	}
+	if cs.contractStatsIndexer != nil {
+		add(backup.ContractStatsIndexStore, cs.contractStatsIndexer)
+	}
+	if cs.memoIndexer != nil {
+		add(backup.MemoIndexStore, cs.memoIndexer)
+	}
+	if cs.blockTimeIndexer != nil {
+		add(backup.BlockTimeIndexStore, cs.blockTimeIndexer)
+	}
+	return indexers
+}
+
+// rebuiltStores returns the stores on disk which can't be rolled back. The indices of the state db and the
+// contract staking indexers are written with the states at tip height, and the range bloomfilters merge the
+// logs of many blocks
+func (cs *ChainService) rebuiltStores() []string {
+	var stores []string
+	for _, name := range []string{backup.StateStore, backup.ContractStakingIndexStore, backup.BloomfilterIndexStore} {
+		if _, ok := cs.kvStores[name]; ok {
+			stores = append(stores, name)
+		}
+	}
+	return stores
+}
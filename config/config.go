@@ -115,6 +115,9 @@ type (
 		StartSubChainInterval time.Duration `yaml:"startSubChainInterval"`
 		SystemLogDBPath       string        `yaml:"systemLogDBPath"`
 		MptrieLogPath         string        `yaml:"mptrieLogPath"`
+		// HTTPAdminToken is the bearer token required on the requests to the admin port, no token is required if
+		// it is empty
+		HTTPAdminToken string `yaml:"httpAdminToken"`
 	}
 
 	// Config is the root config struct, each package's config should be put as its sub struct
@@ -254,6 +257,22 @@ func ValidateRollDPoS(cfg Config) error {
 
 // ValidateArchiveMode validates the state factory setting
 func ValidateArchiveMode(cfg Config) error {
+	if cfg.Chain.EnableHistoryStateRetention {
+		if cfg.Chain.EnableTrielessStateDB {
+			return errors.Wrap(ErrInvalidCfg, "History state retention is incompatible with trieless state DB")
+		}
+		if cfg.Chain.EnableArchiveMode {
+			return errors.Wrap(ErrInvalidCfg, "History state retention is incompatible with archive mode")
+		}
+		if cfg.Chain.HistoryStateRetention == 0 {
+			return errors.Wrap(ErrInvalidCfg, "History state retention should be greater than 0")
+		}
+	}
+	switch cfg.Chain.VerifyProtocolViewOnStart {
+	case "", blockchain.ProtocolViewRepair, blockchain.ProtocolViewStrict:
+	default:
+		return errors.Wrapf(ErrInvalidCfg, "unknown protocol view verification %s", cfg.Chain.VerifyProtocolViewOnStart)
+	}
 	if !cfg.Chain.EnableArchiveMode || !cfg.Chain.EnableTrielessStateDB {
 		return nil
 	}
@@ -338,6 +357,8 @@ func ValidateForkHeights(cfg Config) error {
 		return errors.Wrap(ErrInvalidCfg, "Tsunami is heigher than Upernavik")
 	case hu.UpernavikBlockHeight > hu.VanuatuBlockHeight:
 		return errors.Wrap(ErrInvalidCfg, "Upernavik is heigher than Vanuatu")
+	case hu.VanuatuBlockHeight > hu.WakeBlockHeight:
+		return errors.Wrap(ErrInvalidCfg, "Vanuatu is heigher than Wake")
 	}
 	return nil
 }
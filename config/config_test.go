@@ -16,6 +16,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
+	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 )
 
@@ -267,6 +268,24 @@ func TestValidateArchiveMode(t *testing.T) {
 	cfg.Chain.EnableArchiveMode = false
 	cfg.Chain.EnableTrielessStateDB = false
 	require.NoError(t, errors.Cause(ValidateArchiveMode(cfg)))
+	cfg.Chain.EnableHistoryStateRetention = true
+	require.NoError(t, errors.Cause(ValidateArchiveMode(cfg)))
+	cfg.Chain.EnableTrielessStateDB = true
+	require.EqualError(t, ValidateArchiveMode(cfg), "History state retention is incompatible with trieless state DB: invalid config value")
+	cfg.Chain.EnableTrielessStateDB = false
+	cfg.Chain.EnableArchiveMode = true
+	require.EqualError(t, ValidateArchiveMode(cfg), "History state retention is incompatible with archive mode: invalid config value")
+	cfg.Chain.EnableArchiveMode = false
+	cfg.Chain.HistoryStateRetention = 0
+	require.EqualError(t, ValidateArchiveMode(cfg), "History state retention should be greater than 0: invalid config value")
+
+	cfg = Default
+	for _, mode := range []string{"", blockchain.ProtocolViewRepair, blockchain.ProtocolViewStrict} {
+		cfg.Chain.VerifyProtocolViewOnStart = mode
+		require.NoError(t, ValidateArchiveMode(cfg))
+	}
+	cfg.Chain.VerifyProtocolViewOnStart = "rebuild"
+	require.EqualError(t, ValidateArchiveMode(cfg), "unknown protocol view verification rebuild: invalid config value")
 }
 
 func TestValidateActPool(t *testing.T) {
@@ -386,6 +405,9 @@ func TestValidateForkHeights(t *testing.T) {
 		{
 			"Upernavik", ErrInvalidCfg, "Upernavik is heigher than Vanuatu",
 		},
+		{
+			"Vanuatu", ErrInvalidCfg, "Vanuatu is heigher than Wake",
+		},
 		{
 			"", nil, "",
 		},
@@ -450,6 +472,8 @@ func newTestCfg(fork string) Config {
 		cfg.Genesis.TsunamiBlockHeight = cfg.Genesis.UpernavikBlockHeight + 1
 	case "Upernavik":
 		cfg.Genesis.UpernavikBlockHeight = cfg.Genesis.VanuatuBlockHeight + 1
+	case "Vanuatu":
+		cfg.Genesis.WakeBlockHeight = cfg.Genesis.VanuatuBlockHeight - 1
 	}
 	return cfg
 }
@@ -21,6 +21,7 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/consensus/scheme"
 	"github.com/iotexproject/iotex-core/consensus/scheme/rolldpos"
+	cp "github.com/iotexproject/iotex-core/crypto"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/state"
@@ -49,6 +50,8 @@ type optionParams struct {
 	broadcastHandler scheme.Broadcast
 	pp               poll.Protocol
 	rp               *rp.Protocol
+	reporter         rolldpos.MisbehaviorReporter
+	signer           cp.Signer
 }
 
 // Option sets Consensus construction parameter.
@@ -78,6 +81,23 @@ func WithPollProtocol(pp poll.Protocol) Option {
 	}
 }
 
+// WithMisbehaviorReporter is an option to report the conflicting consensus messages of a delegate
+func WithMisbehaviorReporter(reporter rolldpos.MisbehaviorReporter) Option {
+	return func(ops *optionParams) error {
+		ops.reporter = reporter
+		return nil
+	}
+}
+
+// WithSigner is an option to sign the proposals and the endorsements by the signer, instead of the configured
+// private key
+func WithSigner(signer cp.Signer) Option {
+	return func(ops *optionParams) error {
+		ops.signer = signer
+		return nil
+	}
+}
+
 // NewConsensus creates a IotxConsensus struct.
 func NewConsensus(
 	cfg rolldpos.BuilderConfig,
@@ -97,7 +117,6 @@ func NewConsensus(
 		Scheme:   cfg.Scheme,
 		RollDPoS: cfg.Consensus,
 	}}
-	var err error
 	switch cfg.Scheme {
 	case RollDPoSScheme:
 		delegatesByEpochFunc := func(epochNum uint64) ([]string, error) {
@@ -132,9 +151,13 @@ func NewConsensus(
 			return addrs, nil
 		}
 		proposersByEpochFunc := delegatesByEpochFunc
+		signer := ops.signer
+		if signer == nil {
+			signer = cfg.Chain.ProducerPrivateKey()
+		}
 		bd := rolldpos.NewRollDPoSBuilder().
-			SetAddr(cfg.Chain.ProducerAddress().String()).
-			SetPriKey(cfg.Chain.ProducerPrivateKey()).
+			SetAddr(signer.PublicKey().Address().String()).
+			SetSigner(signer).
 			SetConfig(cfg).
 			SetChainManager(rolldpos.NewChainManager(bc)).
 			SetBlockDeserializer(block.NewDeserializer(bc.EvmNetworkID())).
@@ -142,12 +165,19 @@ func NewConsensus(
 			SetBroadcast(ops.broadcastHandler).
 			SetDelegatesByEpochFunc(delegatesByEpochFunc).
 			SetProposersByEpochFunc(proposersByEpochFunc).
+			SetMisbehaviorReporter(ops.reporter).
 			RegisterProtocol(ops.rp)
 		// TODO: explorer dependency deleted here at #1085, need to revive by migrating to api
-		cs.scheme, err = bd.Build()
+		rdpos, err := bd.Build()
 		if err != nil {
 			log.Logger("consensus").Panic("Error when constructing RollDPoS.", zap.Error(err))
 		}
+		if monitor := rdpos.DelegateMonitor(); monitor != nil {
+			if err := bc.AddSubscriber(monitor); err != nil {
+				return nil, errors.Wrap(err, "failed to subscribe the delegate monitor to blocks")
+			}
+		}
+		cs.scheme = rdpos
 	case NOOPScheme:
 		cs.scheme = scheme.NewNoop()
 	case StandaloneScheme:
@@ -0,0 +1,317 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+const (
+	// AlertMissedBlock is the type of the alert that the delegate missed its time slot to produce a block
+	AlertMissedBlock = "missedBlock"
+	// AlertMissedEndorsement is the type of the alert that the endorsement of the delegate is missing in a block
+	AlertMissedEndorsement = "missedEndorsement"
+
+	// CauseNoProposal is the cause of a missed block that no proposal of the delegate was seen
+	CauseNoProposal = "noProposal"
+	// CauseProposalNotEndorsed is the cause of a missed block that the proposal of the delegate was seen, but
+	// not endorsed by the majority
+	CauseProposalNotEndorsed = "proposalNotEndorsed"
+	// CauseEndorsementNotIncluded is the cause of a missed endorsement that the endorsement of the delegate is not
+	// in the block footer, either it was not sent or it arrived after the majority
+	CauseEndorsementNotIncluded = "endorsementNotIncluded"
+
+	_alertQueueSize = 64
+)
+
+var _delegateMonitorMtc = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "iotex_delegate_monitor",
+		Help: "Blocks produced and endorsed, and time slots and endorsements missed by the monitored delegate",
+	},
+	[]string{"type"},
+)
+
+func init() {
+	prometheus.MustRegister(_delegateMonitorMtc)
+}
+
+type (
+	// MonitorConfig is the config of the delegate monitor, which is enabled if Delegate is set
+	MonitorConfig struct {
+		// Delegate is the operator address of the monitored delegate
+		Delegate string `yaml:"delegate"`
+		// Webhook is the url the alerts are posted to in json, no alert is posted if it is empty
+		Webhook        string        `yaml:"webhook"`
+		WebhookTimeout time.Duration `yaml:"webhookTimeout"`
+		// Epochs is the number of the recent epochs whose stats are kept
+		Epochs int `yaml:"epochs"`
+	}
+
+	// EpochStats is the stats of the monitored delegate in an epoch
+	EpochStats struct {
+		Epoch uint64 `json:"epoch"`
+		// Selected is false if the delegate is not selected as a delegate in the epoch
+		Selected           bool   `json:"selected"`
+		Produced           uint64 `json:"produced"`
+		MissedBlocks       uint64 `json:"missedBlocks"`
+		Endorsed           uint64 `json:"endorsed"`
+		MissedEndorsements uint64 `json:"missedEndorsements"`
+	}
+
+	// Alert is posted to the webhook when the monitored delegate misses a block or an endorsement
+	Alert struct {
+		Delegate string `json:"delegate"`
+		Type     string `json:"type"`
+		Epoch    uint64 `json:"epoch"`
+		Height   uint64 `json:"height"`
+		Round    uint32 `json:"round"`
+		Cause    string `json:"cause"`
+	}
+
+	// DelegateMonitor watches the committed blocks and the block proposals to detect the time slots and the
+	// endorsements missed by a delegate. The proposer schedule is calculated by the round calculator, so the
+	// epochs the delegate is not selected in are told apart from the ones it is selected but misses
+	DelegateMonitor struct {
+		cfg       MonitorConfig
+		ctx       RDPoSCtx
+		client    *http.Client
+		alerts    chan Alert
+		done      chan struct{}
+		wg        sync.WaitGroup
+		mutex     sync.Mutex
+		proposals map[uint64]struct{}
+		stats     map[uint64]*EpochStats
+	}
+)
+
+// DefaultMonitorConfig is the default config of the delegate monitor
+var DefaultMonitorConfig = MonitorConfig{
+	WebhookTimeout: 5 * time.Second,
+	Epochs:         24,
+}
+
+// NewDelegateMonitor creates a delegate monitor, whose proposer schedule is calculated by the context
+func NewDelegateMonitor(cfg MonitorConfig, ctx RDPoSCtx) *DelegateMonitor {
+	if cfg.Epochs <= 0 {
+		cfg.Epochs = DefaultMonitorConfig.Epochs
+	}
+	if cfg.WebhookTimeout <= 0 {
+		cfg.WebhookTimeout = DefaultMonitorConfig.WebhookTimeout
+	}
+	return &DelegateMonitor{
+		cfg:       cfg,
+		ctx:       ctx,
+		client:    &http.Client{Timeout: cfg.WebhookTimeout},
+		alerts:    make(chan Alert, _alertQueueSize),
+		proposals: make(map[uint64]struct{}),
+		stats:     make(map[uint64]*EpochStats),
+	}
+}
+
+// Start starts posting the alerts to the webhook
+func (m *DelegateMonitor) Start(context.Context) error {
+	if m.cfg.Webhook == "" {
+		return nil
+	}
+	m.done = make(chan struct{})
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		for {
+			select {
+			case <-m.done:
+				return
+			case alert := <-m.alerts:
+				if err := m.post(alert); err != nil {
+					log.Logger("consensus").Warn("Failed to post delegate alert.", zap.Any("alert", alert), zap.Error(err))
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop stops posting the alerts
+func (m *DelegateMonitor) Stop(context.Context) error {
+	if m.done != nil {
+		close(m.done)
+		m.wg.Wait()
+		m.done = nil
+	}
+	return nil
+}
+
+// Delegate returns the address of the monitored delegate
+func (m *DelegateMonitor) Delegate() string {
+	return m.cfg.Delegate
+}
+
+// Stats returns the stats of the recent epochs in ascending order
+func (m *DelegateMonitor) Stats() []EpochStats {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	stats := make([]EpochStats, 0, len(m.stats))
+	for _, s := range m.stats {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Epoch < stats[j].Epoch
+	})
+	return stats
+}
+
+// wrapBroadcast records the proposals of the node itself, which are broadcast without being received
+func (m *DelegateMonitor) wrapBroadcast(broadcast scheme.Broadcast) scheme.Broadcast {
+	return func(msg proto.Message) error {
+		if cm, ok := msg.(*iotextypes.ConsensusMessage); ok && cm.GetBlockProposal() != nil {
+			if pk, err := crypto.BytesToPublicKey(cm.GetEndorsement().GetEndorser()); err == nil {
+				m.observeProposal(cm.Height, pk.Address().String())
+			}
+		}
+		return broadcast(msg)
+	}
+}
+
+func (m *DelegateMonitor) observeProposal(height uint64, proposer string) {
+	if proposer != m.cfg.Delegate {
+		return
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.proposals[height] = struct{}{}
+}
+
+// ReceiveBlock checks the committed block for the time slots of the delegate before the round the block is
+// produced in, and for the endorsement of the delegate in the block footer
+func (m *DelegateMonitor) ReceiveBlock(blk *block.Block) error {
+	height := blk.Height()
+	round, err := m.ctx.RoundCalculator().NewRound(height, m.ctx.BlockInterval(height), blk.Timestamp(), nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to calculate the round of block %d", height)
+	}
+	var alerts []Alert
+	m.mutex.Lock()
+	stats := m.epochStats(round.EpochNum(), round.IsDelegate(m.cfg.Delegate))
+	_, proposed := m.proposals[height]
+	for h := range m.proposals {
+		if h <= height {
+			delete(m.proposals, h)
+		}
+	}
+	if stats.Selected {
+		if blk.ProducerAddress() == m.cfg.Delegate {
+			stats.Produced++
+			_delegateMonitorMtc.WithLabelValues("produced").Inc()
+		}
+		for r := uint32(0); r < round.Number(); r++ {
+			proposer, err := m.ctx.RoundCalculator().calculateProposer(height, r, round.Proposers())
+			if err != nil {
+				m.mutex.Unlock()
+				return err
+			}
+			if proposer != m.cfg.Delegate {
+				continue
+			}
+			cause := CauseNoProposal
+			if proposed {
+				cause = CauseProposalNotEndorsed
+			}
+			stats.MissedBlocks++
+			_delegateMonitorMtc.WithLabelValues(AlertMissedBlock).Inc()
+			alerts = append(alerts, m.alert(AlertMissedBlock, stats.Epoch, height, r, cause))
+		}
+		if m.endorsed(blk) {
+			stats.Endorsed++
+			_delegateMonitorMtc.WithLabelValues("endorsed").Inc()
+		} else {
+			stats.MissedEndorsements++
+			_delegateMonitorMtc.WithLabelValues(AlertMissedEndorsement).Inc()
+			alerts = append(alerts, m.alert(AlertMissedEndorsement, stats.Epoch, height, round.Number(), CauseEndorsementNotIncluded))
+		}
+	}
+	m.mutex.Unlock()
+
+	for _, alert := range alerts {
+		log.Logger("consensus").Warn("Delegate missed its duty.", zap.Any("alert", alert))
+		if m.cfg.Webhook == "" {
+			continue
+		}
+		select {
+		case m.alerts <- alert:
+		default:
+			log.Logger("consensus").Warn("Delegate alert queue is full, dropping the alert.", zap.Any("alert", alert))
+		}
+	}
+	return nil
+}
+
+func (m *DelegateMonitor) endorsed(blk *block.Block) bool {
+	for _, en := range blk.Endorsements() {
+		if endorser := en.Endorser(); endorser != nil && endorser.Address().String() == m.cfg.Delegate {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *DelegateMonitor) epochStats(epoch uint64, selected bool) *EpochStats {
+	if s, ok := m.stats[epoch]; ok {
+		return s
+	}
+	s := &EpochStats{Epoch: epoch, Selected: selected}
+	m.stats[epoch] = s
+	for e := range m.stats {
+		if e+uint64(m.cfg.Epochs) <= epoch {
+			delete(m.stats, e)
+		}
+	}
+	return s
+}
+
+func (m *DelegateMonitor) alert(typ string, epoch, height uint64, round uint32, cause string) Alert {
+	return Alert{
+		Delegate: m.cfg.Delegate,
+		Type:     typ,
+		Epoch:    epoch,
+		Height:   height,
+		Round:    round,
+		Cause:    cause,
+	}
+}
+
+func (m *DelegateMonitor) post(alert Alert) error {
+	data, err := json.Marshal(&alert)
+	if err != nil {
+		return err
+	}
+	resp, err := m.client.Post(m.cfg.Webhook, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return errors.Errorf("webhook responded %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+type monitorTestCtx struct {
+	RDPoSCtx
+	rc *roundCalculator
+}
+
+func (c *monitorTestCtx) RoundCalculator() *roundCalculator { return c.rc }
+
+func (c *monitorTestCtx) BlockInterval(uint64) time.Duration { return time.Second }
+
+func TestDelegateMonitor(t *testing.T) {
+	r := require.New(t)
+	rc := makeRoundCalculator(t)
+
+	// block 51 is produced in round 3, after the proposers of round 0 to 2 missed their time slots
+	ts := time.Unix(1562382425, 0)
+	round, err := rc.NewRound(51, time.Second, ts, nil)
+	r.NoError(err)
+	r.Equal(uint32(3), round.Number())
+	delegate, err := rc.calculateProposer(51, 0, round.Proposers())
+	r.NoError(err)
+	var (
+		delegateKey = identityset.PrivateKey(0)
+		missed      uint64
+	)
+	for i := 0; i < identityset.Size(); i++ {
+		if identityset.Address(i).String() == delegate {
+			delegateKey = identityset.PrivateKey(i)
+		}
+	}
+	for n := uint32(0); n < 3; n++ {
+		proposer, err := rc.calculateProposer(51, n, round.Proposers())
+		r.NoError(err)
+		if proposer == delegate {
+			missed++
+		}
+	}
+	producer := identityset.PrivateKey(0)
+	if producer.PublicKey().Address().String() == delegate {
+		producer = identityset.PrivateKey(1)
+	}
+	blk, err := block.NewTestingBuilder().SetHeight(51).SetTimeStamp(ts).SignAndBuild(producer)
+	r.NoError(err)
+
+	alerts := make(chan Alert, 16)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var alert Alert
+		r.NoError(json.NewDecoder(req.Body).Decode(&alert))
+		alerts <- alert
+	}))
+	defer svr.Close()
+	cfg := DefaultMonitorConfig
+	cfg.Delegate = delegate
+	cfg.Webhook = svr.URL
+	m := NewDelegateMonitor(cfg, &monitorTestCtx{rc: rc})
+	r.NoError(m.Start(context.Background()))
+	defer func() {
+		r.NoError(m.Stop(context.Background()))
+	}()
+
+	// no proposal is seen, and the block is not endorsed by the delegate
+	r.NoError(m.ReceiveBlock(&blk))
+	stats := m.Stats()
+	r.Len(stats, 1)
+	r.True(stats[0].Selected)
+	r.Equal(round.EpochNum(), stats[0].Epoch)
+	r.Equal(missed, stats[0].MissedBlocks)
+	r.Zero(stats[0].Endorsed)
+	r.Equal(uint64(1), stats[0].MissedEndorsements)
+	for i := uint64(0); i < missed; i++ {
+		alert := <-alerts
+		r.Equal(AlertMissedBlock, alert.Type)
+		r.Equal(CauseNoProposal, alert.Cause)
+		r.Equal(delegate, alert.Delegate)
+		r.Equal(uint64(51), alert.Height)
+	}
+	alert := <-alerts
+	r.Equal(AlertMissedEndorsement, alert.Type)
+	r.Equal(CauseEndorsementNotIncluded, alert.Cause)
+	r.Equal(uint32(3), alert.Round)
+
+	// the proposal of the delegate is seen but not endorsed, and the block is endorsed by the delegate
+	m.observeProposal(51, identityset.Address(25).String())
+	m.observeProposal(51, delegate)
+	r.NoError(blk.Finalize([]*endorsement.Endorsement{
+		endorsement.NewEndorsement(ts, delegateKey.PublicKey(), nil),
+	}, ts))
+	r.NoError(m.ReceiveBlock(&blk))
+	stats = m.Stats()
+	r.Equal(2*missed, stats[0].MissedBlocks)
+	r.Equal(uint64(1), stats[0].Endorsed)
+	r.Equal(uint64(1), stats[0].MissedEndorsements)
+	for i := uint64(0); i < missed; i++ {
+		alert := <-alerts
+		r.Equal(AlertMissedBlock, alert.Type)
+		r.Equal(CauseProposalNotEndorsed, alert.Cause)
+	}
+	r.Empty(m.proposals)
+
+	// a delegate not selected in the epoch has no stats but the epoch
+	cfg.Delegate = identityset.Address(25).String()
+	cfg.Webhook = ""
+	m = NewDelegateMonitor(cfg, &monitorTestCtx{rc: rc})
+	r.NoError(m.ReceiveBlock(&blk))
+	stats = m.Stats()
+	r.Len(stats, 1)
+	r.Equal(EpochStats{Epoch: round.EpochNum()}, stats[0])
+}
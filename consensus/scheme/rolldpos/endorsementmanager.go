@@ -247,7 +247,7 @@ func newEndorsementManager(eManagerDB db.KVStore, deserializer *block.Deserializ
 		return manager, nil
 	case db.ErrNotExist:
 		// If DB doesn't have any information
-		log.L().Info("First initializing DB")
+		log.Logger("consensus").Info("First initializing DB")
 		return &endorsementManager{
 			eManagerDB:      eManagerDB,
 			collections:     map[string]*blockEndorsementCollection{},
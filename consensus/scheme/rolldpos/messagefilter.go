@@ -0,0 +1,200 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/cache"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	_droppedReplay = "replay"
+	_droppedFuture = "future"
+
+	// the topic of a block proposal in the dedup key, next to the topics of the votes
+	_proposalTopic = ConsensusVoteTopic(255)
+)
+
+var _consensusDroppedMsgMtc = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "iotex_consensus_dropped_messages",
+		Help: "Consensus messages dropped as replays, and future-round messages evicted from the buffer",
+	},
+	[]string{"reason"},
+)
+
+func init() {
+	prometheus.MustRegister(_consensusDroppedMsgMtc)
+}
+
+type (
+	// MessageFilterConfig is the config of the replay protection of the consensus messages
+	MessageFilterConfig struct {
+		// RoundWindow is the number of the past rounds whose messages are still accepted
+		RoundWindow uint32 `yaml:"roundWindow"`
+		// DedupCacheSize is the number of the recent messages remembered to drop the duplicates
+		DedupCacheSize int `yaml:"dedupCacheSize"`
+		// FutureBufferSize is the max number of the future-round messages buffered per endorser, the oldest one is
+		// evicted when it is full
+		FutureBufferSize int `yaml:"futureBufferSize"`
+	}
+
+	// messageKey identifies a consensus message of an endorser. The round of a message of the next height is not
+	// known before the tip block is committed, so the endorsement time is used instead
+	messageKey struct {
+		endorser    string
+		height      uint64
+		roundOrTime int64
+		topic       ConsensusVoteTopic
+	}
+
+	// seenMessage is a message remembered by the filter, to detect a conflicting message of the same key
+	seenMessage struct {
+		msg      *EndorsedConsensusMessage
+		reported bool
+	}
+
+	// messageFilter drops the replayed consensus messages and buffers the future-round ones. As the handler does not
+	// see the peer a message comes from, the future-round messages are buffered per endorser
+	messageFilter struct {
+		cfg    MessageFilterConfig
+		mutex  sync.Mutex
+		seen   cache.LRUCache
+		future map[string][]*EndorsedConsensusMessage
+	}
+)
+
+// DefaultMessageFilterConfig is the default config of the replay protection of the consensus messages
+var DefaultMessageFilterConfig = MessageFilterConfig{
+	RoundWindow:      2,
+	DedupCacheSize:   8192,
+	FutureBufferSize: 16,
+}
+
+func newMessageFilter(cfg MessageFilterConfig) *messageFilter {
+	if cfg.DedupCacheSize <= 0 {
+		cfg.DedupCacheSize = DefaultMessageFilterConfig.DedupCacheSize
+	}
+	if cfg.FutureBufferSize <= 0 {
+		cfg.FutureBufferSize = DefaultMessageFilterConfig.FutureBufferSize
+	}
+	return &messageFilter{
+		cfg:    cfg,
+		seen:   cache.NewThreadSafeLruCache(cfg.DedupCacheSize),
+		future: make(map[string][]*EndorsedConsensusMessage),
+	}
+}
+
+// isStaleRound returns true if the round is older than the current round minus the window
+func (f *messageFilter) isStaleRound(round, current uint32) bool {
+	return uint64(round)+uint64(f.cfg.RoundWindow) < uint64(current)
+}
+
+// firstSeen returns true if the message is seen for the first time, and remembers it. Otherwise, if the message
+// conflicts with the one seen first, the latter is returned once as the evidence of the misbehavior
+func (f *messageFilter) firstSeen(key messageKey, msg *EndorsedConsensusMessage) (bool, *EndorsedConsensusMessage) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	v, ok := f.seen.Get(key)
+	if !ok {
+		f.seen.Add(key, &seenMessage{msg: msg})
+		return true, nil
+	}
+	seen := v.(*seenMessage)
+	if seen.reported || !conflicting(seen.msg, msg) {
+		return false, nil
+	}
+	seen.reported = true
+	return false, seen.msg
+}
+
+// buffer buffers a future-round message of the endorser, and evicts the oldest one if the buffer is full
+func (f *messageFilter) buffer(endorser string, msg *EndorsedConsensusMessage) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	msgs := f.future[endorser]
+	if len(msgs) >= f.cfg.FutureBufferSize {
+		n := len(msgs) - f.cfg.FutureBufferSize + 1
+		msgs = append(msgs[:0:0], msgs[n:]...)
+		_consensusDroppedMsgMtc.WithLabelValues(_droppedFuture).Add(float64(n))
+	}
+	f.future[endorser] = append(msgs, msg)
+}
+
+// release removes and returns the buffered messages which are not in the future any more. isFuture returns whether
+// a message is still in the future, and whether it is to be dropped
+func (f *messageFilter) release(isFuture func(*EndorsedConsensusMessage) (future, drop bool)) []*EndorsedConsensusMessage {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	var due []*EndorsedConsensusMessage
+	for endorser, msgs := range f.future {
+		pending := msgs[:0]
+		for _, msg := range msgs {
+			switch future, drop := isFuture(msg); {
+			case drop:
+			case future:
+				pending = append(pending, msg)
+			default:
+				due = append(due, msg)
+			}
+		}
+		if len(pending) == 0 {
+			delete(f.future, endorser)
+			continue
+		}
+		for i := len(pending); i < len(msgs); i++ {
+			msgs[i] = nil
+		}
+		f.future[endorser] = pending
+	}
+	return due
+}
+
+// numBuffered returns the number of the buffered future-round messages
+func (f *messageFilter) numBuffered() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	n := 0
+	for _, msgs := range f.future {
+		n += len(msgs)
+	}
+	return n
+}
+
+func dropReplay() {
+	_consensusDroppedMsgMtc.WithLabelValues(_droppedReplay).Inc()
+}
+
+// conflicting returns true if the two messages of the same key are endorsed at the same time over different blocks.
+// The empty votes are not counted, as a delegate may vote for a block after timing out on it
+func conflicting(a, b *EndorsedConsensusMessage) bool {
+	if a == nil || b == nil || !a.Endorsement().Timestamp().Equal(b.Endorsement().Timestamp()) {
+		return false
+	}
+	hashA, hashB := messageBlockHash(a), messageBlockHash(b)
+	return len(hashA) > 0 && len(hashB) > 0 && !bytes.Equal(hashA, hashB)
+}
+
+func messageBlockHash(msg *EndorsedConsensusMessage) []byte {
+	switch doc := msg.Document().(type) {
+	case *ConsensusVote:
+		return doc.BlockHash()
+	case *blockProposal:
+		h := doc.block.HashBlock()
+		return h[:]
+	}
+	return nil
+}
+
+func messageTopic(msg *EndorsedConsensusMessage) ConsensusVoteTopic {
+	if vote, ok := msg.Document().(*ConsensusVote); ok {
+		return vote.Topic()
+	}
+	return _proposalTopic
+}
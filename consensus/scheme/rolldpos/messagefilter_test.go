@@ -0,0 +1,215 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/consensus/consensusfsm"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+)
+
+func TestMessageFilter(t *testing.T) {
+	r := require.New(t)
+	f := newMessageFilter(MessageFilterConfig{RoundWindow: 2, DedupCacheSize: 4, FutureBufferSize: 2})
+
+	r.False(f.isStaleRound(3, 5))
+	r.True(f.isStaleRound(2, 5))
+	r.False(f.isStaleRound(7, 5))
+
+	ts := time.Now()
+	vote := func(blkHash []byte) *EndorsedConsensusMessage {
+		v := NewConsensusVote(blkHash, LOCK)
+		en, err := endorsement.Endorse(identityset.PrivateKey(1), v, ts)
+		r.NoError(err)
+		return NewEndorsedConsensusMessage(1, v, en)
+	}
+	key := messageKey{endorser: "a", height: 1, roundOrTime: 1, topic: LOCK}
+	first, conflict := f.firstSeen(key, vote([]byte{1}))
+	r.True(first)
+	r.Nil(conflict)
+	for _, blkHash := range [][]byte{{1}, nil} {
+		first, conflict = f.firstSeen(key, vote(blkHash))
+		r.False(first)
+		r.Nil(conflict)
+	}
+	// a conflicting message is reported once
+	first, conflict = f.firstSeen(key, vote([]byte{2}))
+	r.False(first)
+	r.Equal([]byte{1}, conflict.Document().(*ConsensusVote).BlockHash())
+	first, conflict = f.firstSeen(key, vote([]byte{3}))
+	r.False(first)
+	r.Nil(conflict)
+	for i := int64(0); i < 100; i++ {
+		f.firstSeen(messageKey{endorser: "b", height: 1, roundOrTime: i, topic: COMMIT}, vote([]byte{1}))
+	}
+	r.Equal(4, f.seen.Len())
+
+	msgs := make([]*EndorsedConsensusMessage, 3)
+	for i := range msgs {
+		msgs[i] = NewEndorsedConsensusMessage(uint64(i+1), NewConsensusVote([]byte{1}, LOCK), nil)
+		f.buffer("a", msgs[i])
+	}
+	f.buffer("b", msgs[0])
+	r.Equal(3, f.numBuffered())
+	// the oldest message is evicted
+	r.Equal(msgs[1:], f.future["a"])
+
+	due := f.release(func(msg *EndorsedConsensusMessage) (bool, bool) {
+		return msg.Height() > 2, msg.Height() < 2
+	})
+	r.Equal([]*EndorsedConsensusMessage{msgs[1]}, due)
+	r.Equal(1, f.numBuffered())
+	r.Equal(msgs[2:], f.future["a"])
+}
+
+func TestRollDPoS_ReplayStorm(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	candidates := make([]string, 4)
+	for i := range candidates {
+		candidates[i] = identityset.Address(i).String()
+	}
+	blockHeight := uint64(8)
+	g := genesis.Default
+	g.NumDelegates = 4
+	g.NumSubEpochs = 1
+	g.BlockInterval = 10 * time.Second
+	g.Timestamp = int64(1500000000)
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().TipHeight().Return(blockHeight).AnyTimes()
+	bc.EXPECT().Genesis().Return(g).AnyTimes()
+	bc.EXPECT().BlockFooterByHeight(gomock.Any()).Return(&block.Footer{}, nil).AnyTimes()
+	cfg := DefaultConfig
+	cfg.ConsensusDBPath = ""
+	cfg.MessageFilter = MessageFilterConfig{RoundWindow: 2, DedupCacheSize: 16, FutureBufferSize: 4}
+	delegatesByEpoch := func(uint64) ([]string, error) {
+		return candidates, nil
+	}
+	clk := clock.NewMock()
+	var reported [][2]*iotextypes.ConsensusMessage
+	rdpos, err := NewRollDPoSBuilder().
+		SetConfig(BuilderConfig{
+			Chain:              blockchain.DefaultConfig,
+			Consensus:          cfg,
+			DardanellesUpgrade: consensusfsm.DefaultDardanellesUpgradeConfig,
+			DB:                 db.DefaultConfig,
+			Genesis:            g,
+			SystemActive:       true,
+		}).
+		SetAddr(identityset.Address(1).String()).
+		SetSigner(identityset.PrivateKey(1)).
+		SetChainManager(NewChainManager(bc)).
+		SetBroadcast(func(_ proto.Message) error {
+			return nil
+		}).
+		SetClock(clk).
+		SetDelegatesByEpochFunc(delegatesByEpoch).
+		SetProposersByEpochFunc(delegatesByEpoch).
+		SetMisbehaviorReporter(func(first, second *iotextypes.ConsensusMessage) {
+			reported = append(reported, [2]*iotextypes.ConsensusMessage{first, second})
+		}).
+		RegisterProtocol(rolldpos.NewProtocol(g.NumCandidateDelegates, g.NumDelegates, g.NumSubEpochs)).
+		Build()
+	r.NoError(err)
+	ctx := rdpos.ctx.(*rollDPoSCtx)
+	clk.Add(ctx.BlockInterval(blockHeight))
+	r.NoError(ctx.Start(context.Background()))
+	ctx.round, err = ctx.roundCalc.UpdateRound(ctx.round, blockHeight+1, ctx.BlockInterval(blockHeight+1), clk.Now(), 0)
+	r.NoError(err)
+	// the consensus FSM is not started, so the events produced stay in the queue
+	close(rdpos.ready)
+
+	interval := ctx.BlockInterval(blockHeight + 1)
+	_, roundStart, err := ctx.roundCalc.RoundInfo(blockHeight+1, interval, clk.Now())
+	r.NoError(err)
+	blkHash := hash.Hash256b([]byte("block"))
+	message := func(endorser int, height uint64, topic ConsensusVoteTopic, ts time.Time) *iotextypes.ConsensusMessage {
+		vote := NewConsensusVote(blkHash[:], topic)
+		en, err := endorsement.Endorse(identityset.PrivateKey(endorser), vote, ts)
+		r.NoError(err)
+		msg, err := NewEndorsedConsensusMessage(height, vote, en).Proto()
+		r.NoError(err)
+		return msg
+	}
+	dropped := func(reason string) float64 {
+		return testutil.ToFloat64(_consensusDroppedMsgMtc.WithLabelValues(reason))
+	}
+	replays, futures := dropped(_droppedReplay), dropped(_droppedFuture)
+
+	// a replay storm of the same message only produces one event
+	msg := message(2, blockHeight+1, LOCK, roundStart)
+	for i := 0; i < 1000; i++ {
+		r.NoError(rdpos.HandleConsensusMsg(msg))
+	}
+	r.Equal(1, rdpos.NumPendingEvts())
+	r.Equal(replays+999, dropped(_droppedReplay))
+
+	// the messages of the committed heights and of the rounds out of the window are dropped
+	for i := 0; i < 100; i++ {
+		r.NoError(rdpos.HandleConsensusMsg(message(2, blockHeight, LOCK, roundStart)))
+		r.NoError(rdpos.HandleConsensusMsg(message(2, blockHeight+1, COMMIT, roundStart.Add(-3*interval))))
+	}
+	r.Equal(1, rdpos.NumPendingEvts())
+	r.Equal(replays+1199, dropped(_droppedReplay))
+
+	// the future-round messages are buffered up to the limit per endorser, and the dedup cache is bounded
+	for i := 1; i <= 100; i++ {
+		for endorser := 0; endorser < 2; endorser++ {
+			r.NoError(rdpos.HandleConsensusMsg(message(endorser, blockHeight+1, PROPOSAL, roundStart.Add(time.Duration(i)*interval))))
+		}
+	}
+	r.Equal(1, rdpos.NumPendingEvts())
+	r.Equal(8, rdpos.filter.numBuffered())
+	r.Equal(16, rdpos.filter.seen.Len())
+	r.Equal(futures+192, dropped(_droppedFuture))
+
+	// the buffered messages are released once their rounds come
+	clk.Add(98 * interval)
+	rdpos.releaseFutureMessages()
+	r.Equal(5, rdpos.NumPendingEvts())
+	r.Equal(4, rdpos.filter.numBuffered())
+	clk.Add(2 * interval)
+	rdpos.releaseFutureMessages()
+	r.Equal(9, rdpos.NumPendingEvts())
+	r.Zero(rdpos.filter.numBuffered())
+
+	// the conflicting votes of a delegate are reported once, and not passed to the consensus FSM
+	r.Empty(reported)
+	ts := roundStart.Add(100 * interval)
+	msg = message(3, blockHeight+1, COMMIT, ts)
+	otherHash := hash.Hash256b([]byte("other block"))
+	other := NewConsensusVote(otherHash[:], COMMIT)
+	en, err := endorsement.Endorse(identityset.PrivateKey(3), other, ts)
+	r.NoError(err)
+	conflict, err := NewEndorsedConsensusMessage(blockHeight+1, other, en).Proto()
+	r.NoError(err)
+	for _, m := range []*iotextypes.ConsensusMessage{msg, conflict, conflict, msg} {
+		r.NoError(rdpos.HandleConsensusMsg(m))
+	}
+	r.Equal(10, rdpos.NumPendingEvts())
+	r.Len(reported, 1)
+	r.True(proto.Equal(msg, reported[0][0]))
+	r.True(proto.Equal(conflict, reported[0][1]))
+}
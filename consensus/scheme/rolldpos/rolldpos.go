@@ -7,11 +7,11 @@ package rolldpos
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/facebookgo/clock"
 	"github.com/iotexproject/go-fsm"
-	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -22,6 +22,7 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/consensus/consensusfsm"
 	"github.com/iotexproject/iotex-core/consensus/scheme"
+	cp "github.com/iotexproject/iotex-core/crypto"
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-core/pkg/log"
@@ -43,6 +44,8 @@ type (
 		ToleratedOvertime time.Duration                `yaml:"toleratedOvertime"`
 		Delay             time.Duration                `yaml:"delay"`
 		ConsensusDBPath   string                       `yaml:"consensusDBPath"`
+		Monitor           MonitorConfig                `yaml:"monitor"`
+		MessageFilter     MessageFilterConfig          `yaml:"messageFilter"`
 	}
 
 	// ChainManager defines the blockchain interface
@@ -83,6 +86,8 @@ var DefaultConfig = Config{
 	ToleratedOvertime: 2 * time.Second,
 	Delay:             5 * time.Second,
 	ConsensusDBPath:   "/var/data/consensus.db",
+	Monitor:           DefaultMonitorConfig,
+	MessageFilter:     DefaultMessageFilterConfig,
 }
 
 // NewChainManager creates a chain manager
@@ -144,12 +149,20 @@ func (cm *chainManager) ChainAddress() string {
 	return cm.bc.ChainAddress()
 }
 
+// MisbehaviorReporter reports two conflicting consensus messages signed by a delegate
+type MisbehaviorReporter func(first, second *iotextypes.ConsensusMessage)
+
 // RollDPoS is Roll-DPoS consensus main entrance
 type RollDPoS struct {
 	cfsm       *consensusfsm.ConsensusFSM
 	ctx        RDPoSCtx
 	startDelay time.Duration
 	ready      chan interface{}
+	monitor    *DelegateMonitor
+	filter     *messageFilter
+	reporter   MisbehaviorReporter
+	done       chan struct{}
+	wg         sync.WaitGroup
 }
 
 // Start starts RollDPoS consensus
@@ -157,6 +170,11 @@ func (r *RollDPoS) Start(ctx context.Context) error {
 	if err := r.ctx.Start(ctx); err != nil {
 		return errors.Wrap(err, "error when starting the roll dpos context")
 	}
+	if r.monitor != nil {
+		if err := r.monitor.Start(ctx); err != nil {
+			return errors.Wrap(err, "error when starting the delegate monitor")
+		}
+	}
 	if err := r.cfsm.Start(ctx); err != nil {
 		return errors.Wrap(err, "error when starting the consensus FSM")
 	}
@@ -164,17 +182,31 @@ func (r *RollDPoS) Start(ctx context.Context) error {
 		return err
 	}
 	close(r.ready)
+	r.wg.Add(1)
+	go r.releaseLoop()
 	return nil
 }
 
 // Stop stops RollDPoS consensus
 func (r *RollDPoS) Stop(ctx context.Context) error {
+	close(r.done)
+	r.wg.Wait()
 	if err := r.cfsm.Stop(ctx); err != nil {
 		return errors.Wrap(err, "error when stopping the consensus FSM")
 	}
+	if r.monitor != nil {
+		if err := r.monitor.Stop(ctx); err != nil {
+			return errors.Wrap(err, "error when stopping the delegate monitor")
+		}
+	}
 	return errors.Wrap(r.ctx.Stop(ctx), "error when stopping the roll dpos context")
 }
 
+// DelegateMonitor returns the delegate monitor, or nil if it is not enabled
+func (r *RollDPoS) DelegateMonitor() *DelegateMonitor {
+	return r.monitor
+}
+
 // HandleConsensusMsg handles incoming consensus message
 func (r *RollDPoS) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
 	// Do not handle consensus message if the node is not active in consensus
@@ -193,6 +225,7 @@ func (r *RollDPoS) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
 			zap.Uint64("consensusHeight", consensusHeight),
 			zap.Uint64("msgHeight", msg.Height),
 		)
+		dropReplay()
 		return nil
 	case msg.Height > consensusHeight+1:
 		log.Logger("consensus").Debug(
@@ -202,6 +235,7 @@ func (r *RollDPoS) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
 		)
 		return nil
 	}
+	r.releaseFutureMessages()
 	endorsedMessage := &EndorsedConsensusMessage{}
 	if err := endorsedMessage.LoadProto(msg, r.ctx.BlockDeserializer()); err != nil {
 		return errors.Wrapf(err, "failed to decode endorsed consensus message")
@@ -215,24 +249,149 @@ func (r *RollDPoS) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
 		if err := r.ctx.CheckBlockProposer(endorsedMessage.Height(), consensusMessage, en); err != nil {
 			return errors.Wrap(err, "failed to verify block proposal")
 		}
-		r.cfsm.ProduceReceiveBlockEvent(endorsedMessage)
-		return nil
+		if r.monitor != nil {
+			r.monitor.observeProposal(endorsedMessage.Height(), consensusMessage.ProposerAddress())
+		}
 	case *ConsensusVote:
 		if err := r.ctx.CheckVoteEndorser(endorsedMessage.Height(), consensusMessage, en); err != nil {
 			return errors.Wrapf(err, "failed to verify vote")
 		}
+	// TODO: response block by hash, requestBlock.BlockHash
+	default:
+		return errors.Errorf("Invalid consensus message type %+v", msg)
+	}
+	return r.filterConsensusMsg(endorsedMessage, consensusHeight)
+}
+
+// filterConsensusMsg drops the message if it is a replay, buffers it if it is in a future round, or passes it to
+// the consensus FSM
+func (r *RollDPoS) filterConsensusMsg(msg *EndorsedConsensusMessage, consensusHeight uint64) error {
+	var (
+		en     = msg.Endorsement()
+		height = msg.Height()
+		future = height > consensusHeight
+		key    = messageKey{
+			endorser:    en.Endorser().HexString(),
+			height:      height,
+			roundOrTime: en.Timestamp().UnixNano(),
+			topic:       messageTopic(msg),
+		}
+	)
+	if !future {
+		interval := r.ctx.BlockInterval(height)
+		round, _, err := r.ctx.RoundCalculator().RoundInfo(height, interval, en.Timestamp())
+		if err != nil {
+			return errors.Wrap(err, "failed to calculate the round of consensus message")
+		}
+		current, _, err := r.ctx.RoundCalculator().RoundInfo(height, interval, r.ctx.Clock().Now())
+		if err != nil {
+			return errors.Wrap(err, "failed to calculate the current round")
+		}
+		if r.filter.isStaleRound(round, current) {
+			dropReplay()
+			return nil
+		}
+		future = round > current
+		key.roundOrTime = int64(round)
+	}
+	if first, conflict := r.filter.firstSeen(key, msg); !first {
+		if conflict != nil {
+			r.reportMisbehavior(conflict, msg)
+		}
+		dropReplay()
+		return nil
+	}
+	if future {
+		r.filter.buffer(key.endorser, msg)
+		return nil
+	}
+	r.produce(msg)
+	return nil
+}
+
+// reportMisbehavior reports the two conflicting messages of a delegate, if a reporter is set
+func (r *RollDPoS) reportMisbehavior(first, second *EndorsedConsensusMessage) {
+	if r.reporter == nil {
+		return
+	}
+	l := log.Logger("consensus").With(
+		zap.String("endorser", first.Endorsement().Endorser().Address().String()),
+		zap.Uint64("height", first.Height()),
+	)
+	firstPb, err := first.Proto()
+	if err != nil {
+		l.Error("Failed to convert the conflicting consensus message", zap.Error(err))
+		return
+	}
+	secondPb, err := second.Proto()
+	if err != nil {
+		l.Error("Failed to convert the conflicting consensus message", zap.Error(err))
+		return
+	}
+	l.Warn("Delegate signed conflicting consensus messages")
+	r.reporter(firstPb, secondPb)
+}
+
+// releaseFutureMessages passes the buffered messages whose rounds have come to the consensus FSM
+func (r *RollDPoS) releaseFutureMessages() {
+	consensusHeight := r.ctx.Height()
+	now := r.ctx.Clock().Now()
+	for _, msg := range r.filter.release(func(msg *EndorsedConsensusMessage) (bool, bool) {
+		height := msg.Height()
+		switch {
+		case height < consensusHeight:
+			return false, true
+		case height > consensusHeight:
+			return true, false
+		}
+		interval := r.ctx.BlockInterval(height)
+		round, _, err := r.ctx.RoundCalculator().RoundInfo(height, interval, msg.Endorsement().Timestamp())
+		if err != nil {
+			return false, true
+		}
+		current, _, err := r.ctx.RoundCalculator().RoundInfo(height, interval, now)
+		if err != nil {
+			return true, false
+		}
+		return round > current, r.filter.isStaleRound(round, current)
+	}) {
+		r.produce(msg)
+	}
+}
+
+func (r *RollDPoS) releaseLoop() {
+	defer r.wg.Done()
+	interval := r.ctx.UnmatchedEventInterval(r.ctx.Height())
+	if interval <= 0 {
+		interval = DefaultConfig.FSM.UnmatchedEventInterval
+	}
+	ticker := r.ctx.Clock().Ticker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-ticker.C:
+			if r.ctx.Height() > 0 {
+				r.releaseFutureMessages()
+			}
+		}
+	}
+}
+
+func (r *RollDPoS) produce(msg *EndorsedConsensusMessage) {
+	switch consensusMessage := msg.Document().(type) {
+	case *blockProposal:
+		r.cfsm.ProduceReceiveBlockEvent(msg)
+	case *ConsensusVote:
 		switch consensusMessage.Topic() {
 		case PROPOSAL:
-			r.cfsm.ProduceReceiveProposalEndorsementEvent(endorsedMessage)
+			r.cfsm.ProduceReceiveProposalEndorsementEvent(msg)
 		case LOCK:
-			r.cfsm.ProduceReceiveLockEndorsementEvent(endorsedMessage)
+			r.cfsm.ProduceReceiveLockEndorsementEvent(msg)
 		case COMMIT:
-			r.cfsm.ProduceReceivePreCommitEndorsementEvent(endorsedMessage)
+			r.cfsm.ProduceReceivePreCommitEndorsementEvent(msg)
 		}
-		return nil
-	// TODO: response block by hash, requestBlock.BlockHash
-	default:
-		return errors.Errorf("Invalid consensus message type %+v", msg)
 	}
 }
 
@@ -306,7 +465,7 @@ func (r *RollDPoS) Activate(active bool) {
 	r.ctx.Activate(active)
 	// reactivate cfsm if the node is reactivated
 	if _, err := r.cfsm.BackToPrepare(0); err != nil {
-		log.L().Panic("Failed to reactivate cfsm", zap.Error(err))
+		log.Logger("consensus").Panic("Failed to reactivate cfsm", zap.Error(err))
 	}
 }
 
@@ -329,10 +488,9 @@ type (
 
 	// Builder is the builder for rollDPoS
 	Builder struct {
-		cfg BuilderConfig
-		// TODO: we should use keystore in the future
+		cfg               BuilderConfig
 		encodedAddr       string
-		priKey            crypto.PrivateKey
+		signer            cp.Signer
 		chain             ChainManager
 		blockDeserializer *block.Deserializer
 		broadcastHandler  scheme.Broadcast
@@ -341,6 +499,7 @@ type (
 		rp                   *rolldpos.Protocol
 		delegatesByEpochFunc NodesSelectionByEpochFunc
 		proposersByEpochFunc NodesSelectionByEpochFunc
+		reporter             MisbehaviorReporter
 	}
 )
 
@@ -361,9 +520,10 @@ func (b *Builder) SetAddr(encodedAddr string) *Builder {
 	return b
 }
 
-// SetPriKey sets the private key
-func (b *Builder) SetPriKey(priKey crypto.PrivateKey) *Builder {
-	b.priKey = priKey
+// SetSigner sets the signer of the proposals and the endorsements, which is the private key in memory, or the
+// signer of a key kept out of the config
+func (b *Builder) SetSigner(signer cp.Signer) *Builder {
+	b.signer = signer
 	return b
 }
 
@@ -407,6 +567,12 @@ func (b *Builder) SetProposersByEpochFunc(
 	return b
 }
 
+// SetMisbehaviorReporter sets the reporter of the conflicting consensus messages
+func (b *Builder) SetMisbehaviorReporter(reporter MisbehaviorReporter) *Builder {
+	b.reporter = reporter
+	return b
+}
+
 // RegisterProtocol sets the rolldpos protocol
 func (b *Builder) RegisterProtocol(rp *rolldpos.Protocol) *Builder {
 	b.rp = rp
@@ -425,6 +591,12 @@ func (b *Builder) Build() (*RollDPoS, error) {
 		b.clock = clock.New()
 	}
 	b.cfg.DB.DbPath = b.cfg.Consensus.ConsensusDBPath
+	var monitor *DelegateMonitor
+	broadcastHandler := b.broadcastHandler
+	if b.cfg.Consensus.Monitor.Delegate != "" {
+		monitor = NewDelegateMonitor(b.cfg.Consensus.Monitor, nil)
+		broadcastHandler = monitor.wrapBroadcast(broadcastHandler)
+	}
 	ctx, err := NewRollDPoSCtx(
 		consensusfsm.NewConsensusConfig(b.cfg.Consensus.FSM, b.cfg.DardanellesUpgrade, b.cfg.Genesis, b.cfg.Consensus.Delay),
 		b.cfg.DB,
@@ -434,17 +606,20 @@ func (b *Builder) Build() (*RollDPoS, error) {
 		b.chain,
 		b.blockDeserializer,
 		b.rp,
-		b.broadcastHandler,
+		broadcastHandler,
 		b.delegatesByEpochFunc,
 		b.proposersByEpochFunc,
 		b.encodedAddr,
-		b.priKey,
+		b.signer,
 		b.clock,
 		b.cfg.Genesis.BeringBlockHeight,
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "error when constructing consensus context")
 	}
+	if monitor != nil {
+		monitor.ctx = ctx
+	}
 	cfsm, err := consensusfsm.NewConsensusFSM(ctx, b.clock)
 	if err != nil {
 		return nil, errors.Wrap(err, "error when constructing the consensus FSM")
@@ -454,5 +629,9 @@ func (b *Builder) Build() (*RollDPoS, error) {
 		ctx:        ctx,
 		startDelay: b.cfg.Consensus.Delay,
 		ready:      make(chan interface{}),
+		monitor:    monitor,
+		filter:     newMessageFilter(b.cfg.Consensus.MessageFilter),
+		reporter:   b.reporter,
+		done:       make(chan struct{}),
 	}, nil
 }
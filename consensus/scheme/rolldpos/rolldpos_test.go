@@ -9,6 +9,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"net"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -77,7 +78,7 @@ func TestNewRollDPoS(t *testing.T) {
 		r, err := NewRollDPoSBuilder().
 			SetConfig(builderCfg).
 			SetAddr(identityset.Address(0).String()).
-			SetPriKey(sk).
+			SetSigner(sk).
 			SetChainManager(NewChainManager(mock_blockchain.NewMockBlockchain(ctrl))).
 			SetBroadcast(func(_ proto.Message) error {
 				return nil
@@ -94,7 +95,7 @@ func TestNewRollDPoS(t *testing.T) {
 		r, err := NewRollDPoSBuilder().
 			SetConfig(builderCfg).
 			SetAddr(identityset.Address(0).String()).
-			SetPriKey(sk).
+			SetSigner(sk).
 			SetChainManager(NewChainManager(mock_blockchain.NewMockBlockchain(ctrl))).
 			SetBroadcast(func(_ proto.Message) error {
 				return nil
@@ -115,7 +116,7 @@ func TestNewRollDPoS(t *testing.T) {
 		r, err := NewRollDPoSBuilder().
 			SetConfig(builderCfg).
 			SetAddr(identityset.Address(0).String()).
-			SetPriKey(sk).
+			SetSigner(sk).
 			SetChainManager(NewChainManager(mock_blockchain.NewMockBlockchain(ctrl))).
 			SetBroadcast(func(_ proto.Message) error {
 				return nil
@@ -133,7 +134,7 @@ func TestNewRollDPoS(t *testing.T) {
 		r, err := NewRollDPoSBuilder().
 			SetConfig(builderCfg).
 			SetAddr(identityset.Address(0).String()).
-			SetPriKey(sk).
+			SetSigner(sk).
 			SetBroadcast(func(_ proto.Message) error {
 				return nil
 			}).
@@ -235,7 +236,7 @@ func TestValidateBlockFooter(t *testing.T) {
 	r, err := NewRollDPoSBuilder().
 		SetConfig(builderCfg).
 		SetAddr(identityset.Address(1).String()).
-		SetPriKey(sk1).
+		SetSigner(sk1).
 		SetChainManager(NewChainManager(bc)).
 		SetBroadcast(func(_ proto.Message) error {
 			return nil
@@ -293,7 +294,7 @@ func TestRollDPoS_Metrics(t *testing.T) {
 
 	sk1 := identityset.PrivateKey(1)
 	cfg := DefaultConfig
-	cfg.ConsensusDBPath = "consensus.db"
+	cfg.ConsensusDBPath = filepath.Join(t.TempDir(), "consensus.db")
 	g := genesis.Default
 	g.NumDelegates = 4
 	g.NumSubEpochs = 1
@@ -324,7 +325,7 @@ func TestRollDPoS_Metrics(t *testing.T) {
 	r, err := NewRollDPoSBuilder().
 		SetConfig(builderCfg).
 		SetAddr(identityset.Address(1).String()).
-		SetPriKey(sk1).
+		SetSigner(sk1).
 		SetChainManager(NewChainManager(bc)).
 		SetBroadcast(func(_ proto.Message) error {
 			return nil
@@ -384,8 +385,19 @@ func (o *directOverlay) GetPeers() []net.Addr {
 	return addrs
 }
 
+// skewedChainManager mints the blocks as a proposer whose clock is skewed
+type skewedChainManager struct {
+	ChainManager
+	skew time.Duration
+}
+
+func (cm *skewedChainManager) MintNewBlock(timestamp time.Time) (*block.Block, error) {
+	return cm.ChainManager.MintNewBlock(timestamp.Add(cm.skew))
+}
+
 func TestRollDPoSConsensus(t *testing.T) {
-	newConsensusComponents := func(numNodes int) ([]*RollDPoS, []*directOverlay, []blockchain.Blockchain) {
+	// the nodes in skews mint the blocks as proposers whose clocks are skewed
+	newConsensusComponents := func(numNodes int, skews map[int]time.Duration) ([]*RollDPoS, []*directOverlay, []blockchain.Blockchain) {
 		cfg := DefaultConfig
 		cfg.ConsensusDBPath = ""
 		cfg.Delay = 300 * time.Millisecond
@@ -401,6 +413,7 @@ func TestRollDPoSConsensus(t *testing.T) {
 		g.Blockchain.NumDelegates = uint64(numNodes)
 		g.Blockchain.NumSubEpochs = 1
 		g.EnableGravityChainVoting = false
+		g.ToBeEnabledBlockHeight = 0
 		builderCfg := BuilderConfig{
 			Chain:              blockchain.DefaultConfig,
 			Consensus:          cfg,
@@ -483,11 +496,15 @@ func TestRollDPoSConsensus(t *testing.T) {
 			}
 			p2ps = append(p2ps, p2p)
 
+			cm := NewChainManager(chain)
+			if skew, ok := skews[i]; ok {
+				cm = &skewedChainManager{cm, skew}
+			}
 			consensus, err := NewRollDPoSBuilder().
 				SetAddr(chainAddrs[i].encodedAddr).
-				SetPriKey(chainAddrs[i].priKey).
+				SetSigner(chainAddrs[i].priKey).
 				SetConfig(builderCfg).
-				SetChainManager(NewChainManager(chain)).
+				SetChainManager(cm).
 				SetBroadcast(p2p.Broadcast).
 				SetDelegatesByEpochFunc(delegatesByEpochFunc).
 				SetProposersByEpochFunc(delegatesByEpochFunc).
@@ -512,7 +529,7 @@ func TestRollDPoSConsensus(t *testing.T) {
 		t.Skip()
 
 		ctx := context.Background()
-		cs, p2ps, chains := newConsensusComponents(24)
+		cs, p2ps, chains := newConsensusComponents(24, nil)
 
 		for i := 0; i < 24; i++ {
 			require.NoError(t, chains[i].Start(ctx))
@@ -551,7 +568,7 @@ func TestRollDPoSConsensus(t *testing.T) {
 			t.Skip("Skip the 1-epoch test in short mode.")
 		}
 		ctx := context.Background()
-		cs, p2ps, chains := newConsensusComponents(24)
+		cs, p2ps, chains := newConsensusComponents(24, nil)
 
 		for i := 0; i < 24; i++ {
 			require.NoError(t, chains[i].Start(ctx))
@@ -590,7 +607,7 @@ func TestRollDPoSConsensus(t *testing.T) {
 		t.Skip()
 
 		ctx := context.Background()
-		cs, p2ps, chains := newConsensusComponents(24)
+		cs, p2ps, chains := newConsensusComponents(24, nil)
 		// 1 should be the block 1's proposer
 		for i, p2p := range p2ps {
 			if i == 1 {
@@ -639,7 +656,7 @@ func TestRollDPoSConsensus(t *testing.T) {
 
 	t.Run("proposer-network-partition-blocking", func(t *testing.T) {
 		ctx := context.Background()
-		cs, p2ps, chains := newConsensusComponents(24)
+		cs, p2ps, chains := newConsensusComponents(24, nil)
 		// 1 should be the block 1's proposer
 		for i, p2p := range p2ps {
 			if i == 1 {
@@ -679,9 +696,44 @@ func TestRollDPoSConsensus(t *testing.T) {
 		}
 	})
 
+	t.Run("proposer-clock-skew", func(t *testing.T) {
+		ctx := context.Background()
+		// 1 should be the block 1's proposer, whose block is stamped an hour ahead
+		cs, p2ps, chains := newConsensusComponents(24, map[int]time.Duration{1: time.Hour})
+
+		for i := 0; i < 24; i++ {
+			require.NoError(t, chains[i].Start(ctx))
+			require.NoError(t, p2ps[i].Start(ctx))
+		}
+		wg := sync.WaitGroup{}
+		wg.Add(24)
+		for i := 0; i < 24; i++ {
+			go func(idx int) {
+				defer wg.Done()
+				err := cs[idx].Start(ctx)
+				require.NoError(t, err)
+			}(i)
+		}
+		wg.Wait()
+
+		defer func() {
+			for i := 0; i < 24; i++ {
+				require.NoError(t, cs[i].Stop(ctx))
+				require.NoError(t, p2ps[i].Stop(ctx))
+				require.NoError(t, chains[i].Stop(ctx))
+			}
+		}()
+		time.Sleep(5 * time.Second)
+		for _, chain := range chains {
+			header, err := chain.BlockHeaderByHeight(1)
+			assert.Nil(t, header)
+			assert.Error(t, err)
+		}
+	})
+
 	t.Run("non-proposer-network-partition-blocking", func(t *testing.T) {
 		ctx := context.Background()
-		cs, p2ps, chains := newConsensusComponents(24)
+		cs, p2ps, chains := newConsensusComponents(24, nil)
 		// 1 should be the block 1's proposer
 		for i, p2p := range p2ps {
 			if i == 0 {
@@ -12,7 +12,6 @@ import (
 
 	"github.com/facebookgo/clock"
 	fsm "github.com/iotexproject/go-fsm"
-	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
@@ -22,6 +21,7 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/consensus/consensusfsm"
 	"github.com/iotexproject/iotex-core/consensus/scheme"
+	cp "github.com/iotexproject/iotex-core/crypto"
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-core/pkg/log"
@@ -95,7 +95,7 @@ type (
 		toleratedOvertime time.Duration
 
 		encodedAddr string
-		priKey      crypto.PrivateKey
+		signer      cp.Signer
 		round       *roundCtx
 		clock       clock.Clock
 		active      bool
@@ -117,7 +117,7 @@ func NewRollDPoSCtx(
 	delegatesByEpochFunc NodesSelectionByEpochFunc,
 	proposersByEpochFunc NodesSelectionByEpochFunc,
 	encodedAddr string,
-	priKey crypto.PrivateKey,
+	signer cp.Signer,
 	clock clock.Clock,
 	beringHeight uint64,
 ) (RDPoSCtx, error) {
@@ -162,7 +162,7 @@ func NewRollDPoSCtx(
 		ConsensusConfig:   cfg,
 		active:            active,
 		encodedAddr:       encodedAddr,
-		priKey:            priKey,
+		signer:            signer,
 		chain:             chain,
 		blockDeserializer: blockDeserializer,
 		broadcastHandler:  broadcastHandler,
@@ -521,7 +521,7 @@ func (ctx *rollDPoSCtx) Commit(msg interface{}) (bool, error) {
 	case nil:
 		break
 	default:
-		log.L().Error("error when committing the block", zap.Error(err))
+		log.Logger("consensus").Error("error when committing the block", zap.Error(err))
 		return false, errors.Wrap(err, "error when committing a block")
 	}
 	// Broadcast the committed block to the network
@@ -652,7 +652,7 @@ func (ctx *rollDPoSCtx) isDelegate() bool {
 }
 
 func (ctx *rollDPoSCtx) endorseBlockProposal(proposal *blockProposal) (*EndorsedConsensusMessage, error) {
-	en, err := endorsement.Endorse(ctx.priKey, proposal, ctx.round.StartTime())
+	en, err := endorsement.Endorse(ctx.signer, proposal, ctx.round.StartTime())
 	if err != nil {
 		return nil, err
 	}
@@ -742,7 +742,7 @@ func (ctx *rollDPoSCtx) newEndorsement(
 		blkHash,
 		topic,
 	)
-	en, err := endorsement.Endorse(ctx.priKey, vote, timestamp)
+	en, err := endorsement.Endorse(ctx.signer, vote, timestamp)
 	if err != nil {
 		return nil, err
 	}
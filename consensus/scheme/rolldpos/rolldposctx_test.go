@@ -7,6 +7,9 @@ package rolldpos
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -21,8 +24,10 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/consensus/consensusfsm"
+	cp "github.com/iotexproject/iotex-core/crypto"
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/pkg/signer"
 	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/test/identityset"
 )
@@ -349,6 +354,116 @@ func TestNotProducingMultipleBlocks(t *testing.T) {
 	require.Equal(height1, height2)
 }
 
+func TestRemoteSignerProposal(t *testing.T) {
+	require := require.New(t)
+	b, sf, _, rp, pp := makeChain(t)
+	c := clock.New()
+	g := genesis.Default
+	g.Blockchain.BlockInterval = time.Second * 20
+	delegatesByEpoch := func(epochnum uint64) ([]string, error) {
+		re := protocol.NewRegistry()
+		if err := rp.Register(re); err != nil {
+			return nil, err
+		}
+		ctx := genesis.WithGenesisContext(
+			protocol.WithBlockchainCtx(
+				protocol.WithRegistry(context.Background(), re),
+				protocol.BlockchainCtx{
+					Tip: protocol.TipInfo{
+						Height: b.TipHeight(),
+					},
+				},
+			), g)
+		candidatesList, err := pp.Delegates(ctx, sf)
+		if err != nil {
+			return nil, err
+		}
+		var addrs []string
+		for _, cand := range candidatesList {
+			addrs = append(addrs, cand.Address)
+		}
+		return addrs, nil
+	}
+	secret := []byte("secret")
+	sk := identityset.PrivateKey(10)
+	var slow atomic.Bool
+	handler := signer.NewRemoteSignerHandler(sk, secret)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if slow.Load() {
+			time.Sleep(500 * time.Millisecond)
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+	newCtx := func(s cp.Signer) RDPoSCtx {
+		rctx, err := NewRollDPoSCtx(
+			consensusfsm.NewConsensusConfig(DefaultConfig.FSM, consensusfsm.DefaultDardanellesUpgradeConfig, g, DefaultConfig.Delay),
+			db.DefaultConfig,
+			true,
+			time.Second,
+			true,
+			NewChainManager(b),
+			block.NewDeserializer(0),
+			rp,
+			nil,
+			delegatesByEpoch,
+			delegatesByEpoch,
+			"",
+			s,
+			c,
+			genesis.Default.BeringBlockHeight,
+		)
+		require.NoError(err)
+		require.NoError(rctx.Start(context.Background()))
+		return rctx
+	}
+
+	t.Run("sign by the remote signer", func(t *testing.T) {
+		s, err := signer.NewRemoteSigner(srv.URL, secret, sk.PublicKey(), 100*time.Millisecond)
+		require.NoError(err)
+		rctx := newCtx(s)
+		defer rctx.Stop(context.Background())
+		res, err := rctx.Proposal()
+		require.NoError(err)
+		ecm, ok := res.(*EndorsedConsensusMessage)
+		require.True(ok)
+		require.Equal(sk.PublicKey().HexString(), ecm.Endorsement().Endorser().HexString())
+		require.True(endorsement.VerifyEndorsedDocument(ecm))
+
+		en, err := rctx.NewProposalEndorsement(nil)
+		require.NoError(err)
+		require.Equal(sk.PublicKey().HexString(), en.(*EndorsedConsensusMessage).Endorsement().Endorser().HexString())
+	})
+	t.Run("timeout is a missed endorsement", func(t *testing.T) {
+		s, err := signer.NewRemoteSigner(srv.URL, secret, sk.PublicKey(), 100*time.Millisecond)
+		require.NoError(err)
+		rctx := newCtx(s)
+		defer rctx.Stop(context.Background())
+		slow.Store(true)
+		defer slow.Store(false)
+		start := time.Now()
+		_, err = rctx.NewProposalEndorsement(nil)
+		require.ErrorIs(err, signer.ErrRemoteSigner)
+		require.Less(time.Since(start), 400*time.Millisecond)
+	})
+	t.Run("wrong secret", func(t *testing.T) {
+		s, err := signer.NewRemoteSigner(srv.URL, []byte("wrong"), sk.PublicKey(), 100*time.Millisecond)
+		require.NoError(err)
+		rctx := newCtx(s)
+		defer rctx.Stop(context.Background())
+		_, err = rctx.NewProposalEndorsement(nil)
+		require.ErrorIs(err, signer.ErrRemoteSigner)
+	})
+	t.Run("signature of another key", func(t *testing.T) {
+		s, err := signer.NewRemoteSigner(srv.URL, secret, identityset.PrivateKey(11).PublicKey(), 100*time.Millisecond)
+		require.NoError(err)
+		rctx := newCtx(s)
+		defer rctx.Stop(context.Background())
+		_, err = rctx.NewProposalEndorsement(nil)
+		require.ErrorIs(err, signer.ErrRemoteSigner)
+	})
+}
+
 func getBlockforctx(t *testing.T, i int, sign bool) block.Block {
 	require := require.New(t)
 	ts := &timestamp.Timestamp{Seconds: 1596329600, Nanos: 10}
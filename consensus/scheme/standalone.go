@@ -34,16 +34,16 @@ type standaloneHandler struct {
 func (s *standaloneHandler) Run() {
 	blk, err := s.createCb()
 	if err != nil {
-		log.L().Error("Failed to create.", zap.Error(err))
+		log.Logger("consensus").Error("Failed to create.", zap.Error(err))
 		return
 	}
 
 	if err := s.commitCb(blk); err != nil {
-		log.L().Error("Failed to commit.", zap.Error(err))
+		log.Logger("consensus").Error("Failed to commit.", zap.Error(err))
 		return
 	}
 	if err := s.pubCb(blk); err != nil {
-		log.L().Error("Failed to publish event.", zap.Error(err))
+		log.Logger("consensus").Error("Failed to publish event.", zap.Error(err))
 		return
 	}
 }
@@ -73,7 +73,7 @@ func (s *Standalone) Stop(ctx context.Context) error {
 
 // HandleConsensusMsg handles incoming consensus message
 func (s *Standalone) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
-	log.L().Warn("Standalone scheme does not handle incoming block propose requests.")
+	log.Logger("consensus").Warn("Standalone scheme does not handle incoming block propose requests.")
 	return nil
 }
 
@@ -82,7 +82,7 @@ func (s *Standalone) Calibrate(uint64) {}
 
 // ValidateBlockFooter validates signatures in block footer
 func (s *Standalone) ValidateBlockFooter(*block.Block) error {
-	log.L().Warn("Standalone scheme always return true for block footer validation")
+	log.Logger("consensus").Warn("Standalone scheme always return true for block footer validation")
 	return nil
 }
 
@@ -7,15 +7,20 @@ package crypto
 
 import (
 	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
 )
 
 // Merkle tree struct
 type Merkle struct {
-	root hash.Hash256
-	leaf []hash.Hash256
-	size int
+	root  hash.Hash256
+	leaf  []hash.Hash256
+	size  int
+	count int
 }
 
+// ErrInvalidLeafIndex is the error of proving a leaf not in the merkle tree
+var ErrInvalidLeafIndex = errors.New("invalid merkle leaf index")
+
 // NewMerkleTree creates a merkle tree given hashed leaves
 func NewMerkleTree(leaves []hash.Hash256) *Merkle {
 	size := len(leaves)
@@ -24,8 +29,9 @@ func NewMerkleTree(leaves []hash.Hash256) *Merkle {
 	}
 
 	mk := &Merkle{
-		leaf: make([]hash.Hash256, (size+1)>>1<<1),
-		size: size,
+		leaf:  make([]hash.Hash256, (size+1)>>1<<1),
+		size:  size,
+		count: size,
 	}
 
 	copy(mk.leaf, leaves)
@@ -78,3 +84,46 @@ func (mk *Merkle) HashTree() hash.Hash256 {
 	mk.root = merkle[0]
 	return mk.root
 }
+
+// Proof returns the hashes of the siblings on the path from the leaf at index up to the root, from the bottom level.
+// The last hash of a level with an odd number of hashes is its own sibling, same as in HashTree
+func (mk *Merkle) Proof(index int) ([]hash.Hash256, error) {
+	if index < 0 || index >= mk.count {
+		return nil, errors.Wrapf(ErrInvalidLeafIndex, "index %d of %d leaves", index, mk.count)
+	}
+	var (
+		level = mk.leaf[:mk.size]
+		path  []hash.Hash256
+	)
+	for len(level) > 1 {
+		if len(level)&1 != 0 {
+			level = append(level[:len(level):len(level)], level[len(level)-1])
+		}
+		path = append(path, level[index^1])
+		next := make([]hash.Hash256, len(level)>>1)
+		for i := range next {
+			next[i] = hashPair(level[i<<1], level[i<<1+1])
+		}
+		level = next
+		index >>= 1
+	}
+	return path, nil
+}
+
+// MerkleProofRoot returns the root of the merkle tree proved by the path of the leaf at index, see Merkle.Proof
+func MerkleProofRoot(leaf hash.Hash256, index uint64, path []hash.Hash256) hash.Hash256 {
+	root := leaf
+	for _, sibling := range path {
+		if index&1 == 0 {
+			root = hashPair(root, sibling)
+		} else {
+			root = hashPair(sibling, root)
+		}
+		index >>= 1
+	}
+	return root
+}
+
+func hashPair(left, right hash.Hash256) hash.Hash256 {
+	return hash.Hash256b(append(left[:], right[:]...))
+}
@@ -40,3 +40,21 @@ func TestMerkleTree(t *testing.T) {
 	rootHashHex := hex.EncodeToString(rootHash[:])
 	assert.Equal(t, "4de26a6d1d6618f7bfeb3d168e37ef645db94c2d558bf8c3546d1311877ddffa", rootHashHex)
 }
+
+func TestMerkleProof(t *testing.T) {
+	for n := 1; n <= 9; n++ {
+		leaves := make([]hash.Hash256, n)
+		for i := range leaves {
+			leaves[i] = hash.Hash256b([]byte{byte(i)})
+		}
+		m := NewMerkleTree(leaves)
+		root := m.HashTree()
+		for i := range leaves {
+			path, err := m.Proof(i)
+			assert.NoError(t, err)
+			assert.Equal(t, root, MerkleProofRoot(leaves[i], uint64(i), path))
+		}
+		_, err := m.Proof(n)
+		assert.ErrorIs(t, err, ErrInvalidLeafIndex)
+	}
+}
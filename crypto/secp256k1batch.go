@@ -0,0 +1,307 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package crypto
+
+import (
+	"crypto/rand"
+	"math/bits"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// _secp256k1SigSize is the size of a signature in the [R || S || V] format
+	_secp256k1SigSize = 65
+	// _minBatchSize is the size of a batch below which the signatures are verified one by one, as the batch
+	// verification doesn't pay off
+	_minBatchSize = 8
+)
+
+type (
+	// Secp256k1Signature is a signature in the [R || S || V] format, of the hash signed by the private key of the
+	// public key, which is in the uncompressed or the compressed format
+	Secp256k1Signature struct {
+		PubKey    []byte
+		Hash      []byte
+		Signature []byte
+	}
+
+	// batchItem is a signature parsed for the batch verification, with the point R recovered from r and V, and
+	// negated. The signature is valid if u1*G + u2*Q - R is the point at infinity, where u1 = e/s and u2 = r/s
+	batchItem struct {
+		key      int
+		negR     secp256k1.JacobianPoint
+		e, rs, s secp256k1.ModNScalar
+		u1, u2   secp256k1.ModNScalar
+	}
+)
+
+// VerifySecp256k1 verifies a signature one by one, as the verification of a public key, where V must be 0 or 1, or
+// 27 or 28, but not necessarily match R
+func VerifySecp256k1(sig *Secp256k1Signature) bool {
+	if len(sig.Signature) != _secp256k1SigSize {
+		return false
+	}
+	if _, ok := recoveryID(sig.Signature); !ok {
+		return false
+	}
+	return ethcrypto.VerifySignature(sig.PubKey, sig.Hash, sig.Signature[:_secp256k1SigSize-1])
+}
+
+// BatchVerifySecp256k1 verifies the signatures at once, and returns true if all of them are valid. The verification
+// equations u1*G + u2*Q = R of the signatures are combined by random coefficients into one multi-scalar
+// multiplication, which costs much less than the multiplications of the signatures one by one. A signature that
+// fails the batch verification may still be valid one by one, e.g. V doesn't match R, so use FindInvalidSecp256k1 to
+// tell the invalid ones
+func BatchVerifySecp256k1(sigs []*Secp256k1Signature) bool {
+	if len(sigs) < _minBatchSize {
+		for _, sig := range sigs {
+			if !VerifySecp256k1(sig) {
+				return false
+			}
+		}
+		return true
+	}
+	var (
+		items = make([]batchItem, len(sigs))
+		keys  []secp256k1.JacobianPoint
+		index = make(map[string]int)
+	)
+	for i, sig := range sigs {
+		// the signatures of the same key share one point in the multiplication
+		key, ok := index[string(sig.PubKey)]
+		if !ok {
+			pk, err := secp256k1.ParsePubKey(sig.PubKey)
+			if err != nil {
+				return false
+			}
+			key = len(keys)
+			keys = append(keys, secp256k1.JacobianPoint{})
+			pk.AsJacobian(&keys[key])
+			index[string(sig.PubKey)] = key
+		}
+		items[i].key = key
+		if !items[i].parse(sig) {
+			return false
+		}
+	}
+	if !invertS(items) {
+		return false
+	}
+	coefficients, ok := randomCoefficients(len(items))
+	if !ok {
+		return false
+	}
+	// sum(a_i*u1_i)*G + sum(a_i*u2_i*Q_i) + sum(a_i*(-R_i)) must be the point at infinity, the coefficients of 128
+	// bits make the multiplications of R cheaper without weakening the check
+	var (
+		g       secp256k1.ModNScalar
+		scalars = make([]secp256k1.ModNScalar, len(keys), len(keys)+len(items))
+		points  = make([]*secp256k1.JacobianPoint, len(keys), len(keys)+len(items))
+	)
+	for i := range keys {
+		points[i] = &keys[i]
+	}
+	for i := range items {
+		var (
+			a      = &coefficients[i]
+			u1, u2 secp256k1.ModNScalar
+		)
+		g.Add(u1.Mul2(a, &items[i].u1))
+		scalars[items[i].key].Add(u2.Mul2(a, &items[i].u2))
+		scalars = append(scalars, *a)
+		points = append(points, &items[i].negR)
+	}
+	var sum, gPoint secp256k1.JacobianPoint
+	multiScalarMult(scalars, points, &sum)
+	secp256k1.ScalarBaseMultNonConst(&g, &gPoint)
+	secp256k1.AddNonConst(&sum, &gPoint, &sum)
+	return isInfinity(&sum)
+}
+
+// FindInvalidSecp256k1 returns the indices of the invalid signatures in ascending order. The signatures are verified
+// in batches, and a failed batch is bisected until the invalid signatures are verified one by one
+func FindInvalidSecp256k1(sigs []*Secp256k1Signature) []int {
+	if BatchVerifySecp256k1(sigs) {
+		return nil
+	}
+	if len(sigs) < _minBatchSize {
+		var invalid []int
+		for i, sig := range sigs {
+			if !VerifySecp256k1(sig) {
+				invalid = append(invalid, i)
+			}
+		}
+		return invalid
+	}
+	mid := len(sigs) / 2
+	invalid := FindInvalidSecp256k1(sigs[:mid])
+	for _, i := range FindInvalidSecp256k1(sigs[mid:]) {
+		invalid = append(invalid, mid+i)
+	}
+	return invalid
+}
+
+func (item *batchItem) parse(sig *Secp256k1Signature) bool {
+	if len(sig.Signature) != _secp256k1SigSize || len(sig.Hash) != 32 {
+		return false
+	}
+	// r and s must be in [1, n-1], and s in the lower half as required by the verification one by one
+	if overflow := item.rs.SetByteSlice(sig.Signature[:32]); overflow || item.rs.IsZero() {
+		return false
+	}
+	if overflow := item.s.SetByteSlice(sig.Signature[32:64]); overflow || item.s.IsZero() || item.s.IsOverHalfOrder() {
+		return false
+	}
+	// -R = (r, -y) where the parity of y is V, and r < n < p is a valid x coordinate
+	v, ok := recoveryID(sig.Signature)
+	if !ok {
+		return false
+	}
+	item.negR.X.SetByteSlice(sig.Signature[:32])
+	if !secp256k1.DecompressY(&item.negR.X, v == 0, &item.negR.Y) {
+		return false
+	}
+	item.negR.Y.Normalize()
+	item.negR.Z.SetInt(1)
+	// e is the hash reduced mod n
+	item.e.SetByteSlice(sig.Hash)
+	return true
+}
+
+func recoveryID(sig []byte) (byte, bool) {
+	v := sig[_secp256k1SigSize-1]
+	if v >= 27 {
+		v -= 27
+	}
+	return v, v <= 1
+}
+
+// invertS computes u1 = e/s and u2 = r/s of the items, with a single inversion of the product of s
+func invertS(items []batchItem) bool {
+	prefix := make([]secp256k1.ModNScalar, len(items))
+	var acc secp256k1.ModNScalar
+	acc.SetInt(1)
+	for i := range items {
+		prefix[i] = acc
+		acc.Mul(&items[i].s)
+	}
+	if acc.IsZero() {
+		return false
+	}
+	acc.InverseNonConst()
+	for i := len(items) - 1; i >= 0; i-- {
+		// 1/s_i = s_0*...*s_(i-1) / (s_0*...*s_i)
+		var inv secp256k1.ModNScalar
+		inv.Mul2(&prefix[i], &acc)
+		acc.Mul(&items[i].s)
+		items[i].u1.Mul2(&items[i].e, &inv)
+		items[i].u2.Mul2(&items[i].rs, &inv)
+	}
+	return true
+}
+
+// multiScalarMult computes the sum of k_i*P_i by the bucket method of Pippenger, the points must be normalized
+func multiScalarMult(scalars []secp256k1.ModNScalar, points []*secp256k1.JacobianPoint, result *secp256k1.JacobianPoint) {
+	var (
+		digits  = make([][32]byte, len(scalars))
+		bitLens = make([]int, len(scalars))
+		maxLen  int
+	)
+	for i := range scalars {
+		digits[i] = scalars[i].Bytes()
+		bitLens[i] = bitLen(&digits[i])
+		if bitLens[i] > maxLen {
+			maxLen = bitLens[i]
+		}
+	}
+	c := windowSize(bitLens, maxLen)
+	buckets := make([]secp256k1.JacobianPoint, 1<<c)
+	result.X.Zero()
+	result.Y.Zero()
+	result.Z.Zero()
+	for w := (maxLen + c - 1) / c * c; w > 0; w -= c {
+		for i := 0; i < c; i++ {
+			secp256k1.DoubleNonConst(result, result)
+		}
+		for i := range buckets {
+			buckets[i] = secp256k1.JacobianPoint{}
+		}
+		for i := range points {
+			if bitLens[i] <= w-c {
+				continue
+			}
+			if d := window(&digits[i], w-c, c); d != 0 {
+				secp256k1.AddNonConst(&buckets[d], points[i], &buckets[d])
+			}
+		}
+		// sum(d*bucket_d) by the running sums from the highest bucket
+		var running, sum secp256k1.JacobianPoint
+		for d := len(buckets) - 1; d > 0; d-- {
+			secp256k1.AddNonConst(&running, &buckets[d], &running)
+			secp256k1.AddNonConst(&sum, &running, &sum)
+		}
+		secp256k1.AddNonConst(result, &sum, result)
+	}
+}
+
+// window returns the c bits of the big-endian scalar from the bit offset
+func window(b *[32]byte, offset, c int) int {
+	d := 0
+	for i := offset + c - 1; i >= offset; i-- {
+		d <<= 1
+		if i < 256 {
+			d |= int(b[31-i/8]>>(i%8)) & 1
+		}
+	}
+	return d
+}
+
+func bitLen(b *[32]byte) int {
+	for i, v := range b {
+		if v != 0 {
+			return (32-i)*8 - bits.LeadingZeros8(v)
+		}
+	}
+	return 0
+}
+
+// windowSize returns the window of the fewest additions, i.e. the additions of the points into the buckets, and the
+// running sums of the buckets in each window
+func windowSize(bitLens []int, maxLen int) int {
+	best, bestCost := 1, -1
+	for c := 1; c <= 16; c++ {
+		cost := (maxLen + c - 1) / c << (c + 1)
+		for _, l := range bitLens {
+			cost += (l + c - 1) / c
+		}
+		if bestCost < 0 || cost < bestCost {
+			best, bestCost = c, cost
+		}
+	}
+	return best
+}
+
+// randomCoefficients returns n non-zero random scalars of 128 bits as the coefficients of the batch verification
+func randomCoefficients(n int) ([]secp256k1.ModNScalar, bool) {
+	b := make([]byte, 16*n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, false
+	}
+	scalars := make([]secp256k1.ModNScalar, n)
+	for i := range scalars {
+		scalars[i].SetByteSlice(b[16*i : 16*(i+1)])
+		if scalars[i].IsZero() {
+			return nil, false
+		}
+	}
+	return scalars, true
+}
+
+func isInfinity(p *secp256k1.JacobianPoint) bool {
+	return (p.X.IsZero() && p.Y.IsZero()) || p.Z.IsZero()
+}
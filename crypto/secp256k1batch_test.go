@@ -0,0 +1,113 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package crypto
+
+import (
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+)
+
+func testSecp256k1Signatures(tb testing.TB, n, keys int) []*Secp256k1Signature {
+	sks := make([]crypto.PrivateKey, keys)
+	for i := range sks {
+		sk, err := crypto.GenerateKey()
+		require.NoError(tb, err)
+		sks[i] = sk
+	}
+	sigs := make([]*Secp256k1Signature, n)
+	for i := range sigs {
+		var b [8]byte
+		binary.BigEndian.PutUint64(b[:], uint64(i))
+		h := hash.Hash256b(b[:])
+		sk := sks[i%keys]
+		sig, err := sk.Sign(h[:])
+		require.NoError(tb, err)
+		sigs[i] = &Secp256k1Signature{PubKey: sk.PublicKey().Bytes(), Hash: h[:], Signature: sig}
+	}
+	return sigs
+}
+
+func TestBatchVerifySecp256k1(t *testing.T) {
+	r := require.New(t)
+	// the same key signs several hashes in a batch
+	sigs := testSecp256k1Signatures(t, 100, 7)
+	r.True(BatchVerifySecp256k1(sigs))
+	r.Empty(FindInvalidSecp256k1(sigs))
+	r.True(BatchVerifySecp256k1(sigs[:3]))
+	r.True(BatchVerifySecp256k1(nil))
+
+	copySig := func(sig *Secp256k1Signature) *Secp256k1Signature {
+		return &Secp256k1Signature{
+			PubKey:    sig.PubKey,
+			Hash:      sig.Hash,
+			Signature: append([]byte{}, sig.Signature...),
+		}
+	}
+	for _, c := range []struct {
+		name    string
+		corrupt func(*Secp256k1Signature)
+	}{
+		{"signature", func(sig *Secp256k1Signature) { sig.Signature[10]++ }},
+		{"hash", func(sig *Secp256k1Signature) { sig.Hash = sigs[0].Hash }},
+		{"key", func(sig *Secp256k1Signature) { sig.PubKey = sigs[1].PubKey }},
+		{"size", func(sig *Secp256k1Signature) { sig.Signature = sig.Signature[:64] }},
+		{"recoveryIDRange", func(sig *Secp256k1Signature) { sig.Signature[64] = 2 }},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			// exactly one signature in the batch is corrupt
+			corrupted := append([]*Secp256k1Signature{}, sigs...)
+			corrupted[42] = copySig(sigs[42])
+			c.corrupt(corrupted[42])
+			r.False(VerifySecp256k1(corrupted[42]))
+			r.False(BatchVerifySecp256k1(corrupted))
+			r.Equal([]int{42}, FindInvalidSecp256k1(corrupted))
+		})
+	}
+	t.Run("recoveryID", func(t *testing.T) {
+		// the signature with the flipped V fails the batch, but it's valid one by one
+		flipped := append([]*Secp256k1Signature{}, sigs...)
+		flipped[42] = copySig(sigs[42])
+		flipped[42].Signature[64] ^= 1
+		r.True(VerifySecp256k1(flipped[42]))
+		r.False(BatchVerifySecp256k1(flipped))
+		r.Empty(FindInvalidSecp256k1(flipped))
+	})
+	t.Run("multiple", func(t *testing.T) {
+		corrupted := append([]*Secp256k1Signature{}, sigs...)
+		for _, i := range []int{0, 57, 99} {
+			corrupted[i] = copySig(sigs[i])
+			corrupted[i].Signature[40]++
+		}
+		r.Equal([]int{0, 57, 99}, FindInvalidSecp256k1(corrupted))
+	})
+}
+
+func BenchmarkBatchVerifySecp256k1(b *testing.B) {
+	for _, n := range []int{1000, 10000} {
+		sigs := testSecp256k1Signatures(b, n, n/10)
+		b.Run(fmt.Sprintf("batch-%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if !BatchVerifySecp256k1(sigs) {
+					b.Fatal("invalid batch")
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("individual-%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, sig := range sigs {
+					if !VerifySecp256k1(sig) {
+						b.Fatal("invalid signature")
+					}
+				}
+			}
+		})
+	}
+}
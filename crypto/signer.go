@@ -0,0 +1,36 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package crypto
+
+import (
+	"github.com/iotexproject/go-pkgs/crypto"
+)
+
+type (
+	// Signer signs the hashes with the key of its public key, which is either a private key in memory, or a key kept
+	// out of the config, e.g., in an encrypted keystore or by a remote signer. A crypto.PrivateKey is a Signer
+	Signer interface {
+		PublicKey() crypto.PublicKey
+		Sign([]byte) ([]byte, error)
+	}
+
+	// VRFProver is a Signer proving the VRF output with its key, without exposing the key
+	VRFProver interface {
+		VRFProve(alpha []byte) ([]byte, error)
+	}
+)
+
+// ProveVRF returns the proof of the VRF output of alpha with the key of the signer
+func ProveVRF(s Signer, alpha []byte) ([]byte, error) {
+	switch s := s.(type) {
+	case crypto.PrivateKey:
+		return VRFProve(s, alpha)
+	case VRFProver:
+		return s.VRFProve(alpha)
+	default:
+		return nil, ErrUnsupportedVRFKey
+	}
+}
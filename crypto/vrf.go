@@ -0,0 +1,207 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/subtle"
+	"math/big"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+)
+
+const (
+	// VRFProofSize is the size of the VRF proof, which is the compressed gamma, the challenge and the scalar s
+	VRFProofSize = _compressedPointSize + _vrfChallengeSize + _scalarSize
+
+	// _vrfSuite is the suite string of the ECVRF on secp256k1 with sha256 and try-and-increment, which is not
+	// assigned in RFC 9381, so it's taken from the private use range
+	_vrfSuite             = 0xFE
+	_vrfChallengeSize     = 16
+	_compressedPointSize  = 33
+	_scalarSize           = 32
+	_maxHashToCurveTrials = 256
+)
+
+var (
+	// ErrInvalidVRFProof is the error of a VRF proof failing the verification
+	ErrInvalidVRFProof = errors.New("invalid VRF proof")
+	// ErrUnsupportedVRFKey is the error of a key not on secp256k1
+	ErrUnsupportedVRFKey = errors.New("unsupported VRF key")
+
+	_curve = ethcrypto.S256()
+)
+
+// VRFProve returns the proof of the VRF output of alpha with the private key. It follows ECVRF of RFC 9381 on
+// secp256k1 with the try-and-increment encoding to the curve, so the output is unique to the key and alpha: the
+// prover cannot choose it, and nobody else can compute it before the proof is revealed
+func VRFProve(sk crypto.PrivateKey, alpha []byte) ([]byte, error) {
+	priv, ok := sk.EcdsaPrivateKey().(*ecdsa.PrivateKey)
+	if !ok || !onCurve(priv.Curve) {
+		return nil, ErrUnsupportedVRFKey
+	}
+	n := _curve.Params().N
+	pk := compress(priv.X, priv.Y)
+	hx, hy, err := hashToCurve(pk, alpha)
+	if err != nil {
+		return nil, err
+	}
+	h := compress(hx, hy)
+	var (
+		x              = priv.D.FillBytes(make([]byte, _scalarSize))
+		gammaX, gammaY = _curve.ScalarMult(hx, hy, x)
+		gamma          = compress(gammaX, gammaY)
+		nonce          = sha256.Sum256(append(append([]byte{}, x...), h...))
+		k              = new(big.Int).Mod(new(big.Int).SetBytes(nonce[:]), n)
+	)
+	if k.Sign() == 0 {
+		return nil, errors.New("invalid VRF nonce")
+	}
+	ux, uy := _curve.ScalarBaseMult(k.Bytes())
+	vx, vy := _curve.ScalarMult(hx, hy, k.Bytes())
+	c := challenge(pk, h, gamma, compress(ux, uy), compress(vx, vy))
+	s := new(big.Int).Mul(new(big.Int).SetBytes(c), priv.D)
+	s.Add(s, k).Mod(s, n)
+
+	proof := make([]byte, 0, VRFProofSize)
+	proof = append(proof, gamma...)
+	proof = append(proof, c...)
+	return append(proof, s.FillBytes(make([]byte, _scalarSize))...), nil
+}
+
+// VRFVerify verifies the VRF proof of alpha against the public key, and returns the VRF output
+func VRFVerify(pk crypto.PublicKey, alpha, proof []byte) (hash.Hash256, error) {
+	pub, ok := pk.EcdsaPublicKey().(*ecdsa.PublicKey)
+	if !ok || !onCurve(pub.Curve) {
+		return hash.ZeroHash256, ErrUnsupportedVRFKey
+	}
+	gammaX, gammaY, c, s, err := decodeProof(proof)
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	y := compress(pub.X, pub.Y)
+	hx, hy, err := hashToCurve(y, alpha)
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	// U = s*G - c*Y, V = s*H - c*Gamma
+	negC := new(big.Int).Sub(_curve.Params().N, new(big.Int).SetBytes(c)).Bytes()
+	sgx, sgy := _curve.ScalarBaseMult(s)
+	cyx, cyy := _curve.ScalarMult(pub.X, pub.Y, negC)
+	ux, uy := _curve.Add(sgx, sgy, cyx, cyy)
+	shx, shy := _curve.ScalarMult(hx, hy, s)
+	cgx, cgy := _curve.ScalarMult(gammaX, gammaY, negC)
+	vx, vy := _curve.Add(shx, shy, cgx, cgy)
+
+	expected := challenge(y, compress(hx, hy), compress(gammaX, gammaY), compress(ux, uy), compress(vx, vy))
+	if subtle.ConstantTimeCompare(c, expected) != 1 {
+		return hash.ZeroHash256, ErrInvalidVRFProof
+	}
+	return proofToHash(compress(gammaX, gammaY)), nil
+}
+
+// VRFProofToHash returns the VRF output of the proof, without verifying it
+func VRFProofToHash(proof []byte) (hash.Hash256, error) {
+	gammaX, gammaY, _, _, err := decodeProof(proof)
+	if err != nil {
+		return hash.ZeroHash256, err
+	}
+	return proofToHash(compress(gammaX, gammaY)), nil
+}
+
+// onCurve checks the curve is secp256k1
+func onCurve(c elliptic.Curve) bool {
+	params, expected := c.Params(), _curve.Params()
+	return params.P.Cmp(expected.P) == 0 && params.N.Cmp(expected.N) == 0 && params.B.Cmp(expected.B) == 0
+}
+
+func decodeProof(proof []byte) (*big.Int, *big.Int, []byte, []byte, error) {
+	if len(proof) != VRFProofSize {
+		return nil, nil, nil, nil, errors.Wrapf(ErrInvalidVRFProof, "invalid size %d", len(proof))
+	}
+	gammaX, gammaY, ok := decompress(proof[:_compressedPointSize])
+	if !ok {
+		return nil, nil, nil, nil, errors.Wrap(ErrInvalidVRFProof, "gamma is not on the curve")
+	}
+	c := proof[_compressedPointSize : _compressedPointSize+_vrfChallengeSize]
+	s := proof[_compressedPointSize+_vrfChallengeSize:]
+	if sInt := new(big.Int).SetBytes(s); sInt.Sign() == 0 || sInt.Cmp(_curve.Params().N) >= 0 {
+		return nil, nil, nil, nil, errors.Wrap(ErrInvalidVRFProof, "invalid scalar")
+	}
+	if new(big.Int).SetBytes(c).Sign() == 0 {
+		return nil, nil, nil, nil, errors.Wrap(ErrInvalidVRFProof, "invalid challenge")
+	}
+	return gammaX, gammaY, c, s, nil
+}
+
+// hashToCurve encodes the public key and alpha to a point by try-and-increment
+func hashToCurve(pk, alpha []byte) (*big.Int, *big.Int, error) {
+	for ctr := 0; ctr < _maxHashToCurveTrials; ctr++ {
+		h := sha256.New()
+		h.Write([]byte{_vrfSuite, 0x01})
+		h.Write(pk)
+		h.Write(alpha)
+		h.Write([]byte{byte(ctr), 0x00})
+		if x, y, ok := decompress(append([]byte{0x02}, h.Sum(nil)...)); ok {
+			return x, y, nil
+		}
+	}
+	return nil, nil, errors.New("failed to hash to the curve")
+}
+
+func challenge(points ...[]byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{_vrfSuite, 0x02})
+	for _, p := range points {
+		h.Write(p)
+	}
+	h.Write([]byte{0x00})
+	return h.Sum(nil)[:_vrfChallengeSize]
+}
+
+func proofToHash(gamma []byte) hash.Hash256 {
+	h := sha256.New()
+	h.Write([]byte{_vrfSuite, 0x03})
+	h.Write(gamma)
+	h.Write([]byte{0x00})
+	return hash.BytesToHash256(h.Sum(nil))
+}
+
+func compress(x, y *big.Int) []byte {
+	b := make([]byte, _compressedPointSize)
+	b[0] = 0x02 | byte(y.Bit(0))
+	x.FillBytes(b[1:])
+	return b
+}
+
+// decompress decodes the compressed point, the square root exists as p = 3 mod 4 on secp256k1
+func decompress(b []byte) (*big.Int, *big.Int, bool) {
+	if len(b) != _compressedPointSize || (b[0] != 0x02 && b[0] != 0x03) {
+		return nil, nil, false
+	}
+	p := _curve.Params().P
+	x := new(big.Int).SetBytes(b[1:])
+	if x.Cmp(p) >= 0 {
+		return nil, nil, false
+	}
+	// y^2 = x^3 + 7
+	y2 := new(big.Int).Exp(x, big.NewInt(3), p)
+	y2.Add(y2, _curve.Params().B).Mod(y2, p)
+	exp := new(big.Int).Add(p, big.NewInt(1))
+	y := new(big.Int).Exp(y2, exp.Rsh(exp, 2), p)
+	if new(big.Int).Exp(y, big.NewInt(2), p).Cmp(y2) != 0 {
+		return nil, nil, false
+	}
+	if y.Bit(0) != uint(b[0]&1) {
+		y.Sub(p, y)
+	}
+	return x, y, true
+}
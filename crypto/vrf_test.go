@@ -0,0 +1,67 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package crypto
+
+import (
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVRF(t *testing.T) {
+	r := require.New(t)
+	sk1, err := crypto.GenerateKey()
+	r.NoError(err)
+	sk2, err := crypto.GenerateKey()
+	r.NoError(err)
+	alpha := []byte("block 100")
+
+	proof, err := VRFProve(sk1, alpha)
+	r.NoError(err)
+	r.Len(proof, VRFProofSize)
+	beta, err := VRFVerify(sk1.PublicKey(), alpha, proof)
+	r.NoError(err)
+	h, err := VRFProofToHash(proof)
+	r.NoError(err)
+	r.Equal(beta, h)
+
+	// the proof is deterministic
+	again, err := VRFProve(sk1, alpha)
+	r.NoError(err)
+	r.Equal(proof, again)
+
+	// the outputs of different keys or alphas are different
+	proof2, err := VRFProve(sk2, alpha)
+	r.NoError(err)
+	beta2, err := VRFVerify(sk2.PublicKey(), alpha, proof2)
+	r.NoError(err)
+	r.NotEqual(beta, beta2)
+	proof3, err := VRFProve(sk1, []byte("block 101"))
+	r.NoError(err)
+	beta3, err := VRFVerify(sk1.PublicKey(), []byte("block 101"), proof3)
+	r.NoError(err)
+	r.NotEqual(beta, beta3)
+
+	// the proof fails with another key or alpha
+	_, err = VRFVerify(sk2.PublicKey(), alpha, proof)
+	r.True(errors.Is(err, ErrInvalidVRFProof))
+	_, err = VRFVerify(sk1.PublicKey(), []byte("block 101"), proof)
+	r.True(errors.Is(err, ErrInvalidVRFProof))
+
+	// the tampered proof fails
+	for _, i := range []int{1, _compressedPointSize, VRFProofSize - 1} {
+		tampered := append([]byte{}, proof...)
+		tampered[i] ^= 0x01
+		_, err = VRFVerify(sk1.PublicKey(), alpha, tampered)
+		r.True(errors.Is(err, ErrInvalidVRFProof))
+	}
+	_, err = VRFVerify(sk1.PublicKey(), alpha, proof[1:])
+	r.True(errors.Is(err, ErrInvalidVRFProof))
+	_, err = VRFProofToHash(nil)
+	r.True(errors.Is(err, ErrInvalidVRFProof))
+}
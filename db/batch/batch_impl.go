@@ -29,11 +29,17 @@ type (
 	cachedBatch struct {
 		lock         sync.RWMutex
 		kvStoreBatch *baseKVStoreBatch
-		tag          int            // latest snapshot + 1
-		batchShots   []int          // snapshots of batch are merely size of write queue at time of snapshot
-		caches       []KVStoreCache // snapshots of cache
-		keyTags      map[kvCacheKey]*kvCacheValue
-		tagKeys      [][]kvCacheKey
+		tag          int   // latest snapshot + 1
+		batchShots   []int // snapshots of batch are merely size of write queue at time of snapshot
+		cache        *kvCache
+		journal      []journalEntry // changes of cache since the first snapshot
+		journalShots []int          // snapshots of cache are merely size of journal at time of snapshot
+	}
+
+	// journalEntry records the node of a key before a change, nil if the key was not in cache
+	journalEntry struct {
+		key  kvCacheKey
+		prev *node
 	}
 )
 
@@ -250,30 +256,25 @@ func (cb *cachedBatch) ClearAndUnlock() {
 	cb.clear()
 }
 
-func (cb *cachedBatch) currentCache() KVStoreCache {
-	return cb.caches[len(cb.caches)-1]
-}
-
 func (cb *cachedBatch) clear() {
 	cb.kvStoreBatch.Clear()
 	cb.tag = 0
 	cb.batchShots = make([]int, 0)
-	cb.caches = []KVStoreCache{NewKVCache()}
-	cb.keyTags = map[kvCacheKey]*kvCacheValue{}
-	cb.tagKeys = [][]kvCacheKey{{}}
+	cb.cache = newKVCache()
+	cb.journal = nil
+	cb.journalShots = make([]int, 0)
 }
 
-func (cb *cachedBatch) touchKey(h kvCacheKey) {
-	tags, ok := cb.keyTags[h]
-	if !ok {
-		cb.keyTags[h] = newkvCacheValue([]int{cb.tag})
-		cb.tagKeys[cb.tag] = append(cb.tagKeys[cb.tag], h)
+// record saves the node of key into journal before it is changed, so that the change can be reverted.
+// There is nothing to revert to before the first snapshot
+func (cb *cachedBatch) record(h kvCacheKey) {
+	if cb.tag == 0 {
 		return
 	}
-	if tags.last() != cb.tag {
-		tags.append(cb.tag)
-		cb.tagKeys[cb.tag] = append(cb.tagKeys[cb.tag], h)
-	}
+	cb.journal = append(cb.journal, journalEntry{
+		key:  h,
+		prev: cb.cache.node(&h),
+	})
 }
 
 // Put inserts a <key, value> record
@@ -281,8 +282,8 @@ func (cb *cachedBatch) Put(namespace string, key, value []byte, errorMessage str
 	cb.lock.Lock()
 	defer cb.lock.Unlock()
 	h := cb.hash(namespace, key)
-	cb.touchKey(h)
-	cb.currentCache().Write(&h, value)
+	cb.record(h)
+	cb.cache.Write(&h, value)
 	cb.kvStoreBatch.batch(Put, namespace, key, value, errorMessage)
 }
 
@@ -291,8 +292,8 @@ func (cb *cachedBatch) Delete(namespace string, key []byte, errorMessage string)
 	cb.lock.Lock()
 	defer cb.lock.Unlock()
 	h := cb.hash(namespace, key)
-	cb.touchKey(h)
-	cb.currentCache().Evict(&h)
+	cb.record(h)
+	cb.cache.Evict(&h)
 	cb.kvStoreBatch.batch(Delete, namespace, key, nil, errorMessage)
 }
 
@@ -308,18 +309,7 @@ func (cb *cachedBatch) Get(namespace string, key []byte) ([]byte, error) {
 	cb.lock.RLock()
 	defer cb.lock.RUnlock()
 	h := cb.hash(namespace, key)
-	var v []byte
-	err := ErrNotExist
-	if tags, ok := cb.keyTags[h]; ok {
-		for i := tags.len() - 1; i >= 0; i-- {
-			v, err = cb.caches[tags.getAt(i)].Read(&h)
-			if errors.Cause(err) == ErrNotExist {
-				continue
-			}
-			break
-		}
-	}
-	return v, err
+	return cb.cache.Read(&h)
 }
 
 // Snapshot takes a snapshot of current cached batch
@@ -327,10 +317,9 @@ func (cb *cachedBatch) Snapshot() int {
 	cb.lock.Lock()
 	defer cb.lock.Unlock()
 	defer func() { cb.tag++ }()
-	// save a copy of current batch/cache
+	// save the size of current batch/journal
 	cb.batchShots = append(cb.batchShots, cb.kvStoreBatch.Size())
-	cb.caches = append(cb.caches, NewKVCache())
-	cb.tagKeys = append(cb.tagKeys, []kvCacheKey{})
+	cb.journalShots = append(cb.journalShots, len(cb.journal))
 	return cb.tag
 }
 
@@ -344,20 +333,13 @@ func (cb *cachedBatch) RevertSnapshot(snapshot int) error {
 	cb.tag = snapshot + 1
 	cb.batchShots = cb.batchShots[:cb.tag]
 	cb.kvStoreBatch.truncate(cb.batchShots[snapshot])
-	cb.caches = cb.caches[:cb.tag+1]
-	cb.caches[cb.tag].Clear()
-	for tag := cb.tag; tag < len(cb.tagKeys); tag++ {
-		keys := cb.tagKeys[tag]
-		for _, key := range keys {
-			kv := cb.keyTags[key]
-			kv.pop()
-			if kv.len() == 0 {
-				delete(cb.keyTags, key)
-			}
-		}
+	cb.journalShots = cb.journalShots[:cb.tag]
+	// undo the changes in reverse order
+	size := cb.journalShots[snapshot]
+	for i := len(cb.journal) - 1; i >= size; i-- {
+		cb.cache.restore(&cb.journal[i].key, cb.journal[i].prev)
 	}
-	cb.tagKeys = cb.tagKeys[:cb.tag+1]
-	cb.tagKeys[cb.tag] = []kvCacheKey{}
+	cb.journal = cb.journal[:size]
 	return nil
 }
 
@@ -370,20 +352,9 @@ func (cb *cachedBatch) ResetSnapshots() {
 		return
 	}
 	cb.tag = 0
-	cb.batchShots = nil
 	cb.batchShots = make([]int, 0)
-	if len(cb.caches) > 1 {
-		if err := cb.caches[0].Append(cb.caches[1:]...); err != nil {
-			panic(errors.Wrap(err, "failed to reset snapshots"))
-		}
-		cb.caches = cb.caches[:1]
-	}
-	keys := make([]kvCacheKey, 0, len(cb.keyTags))
-	for key, v := range cb.keyTags {
-		v.reset()
-		keys = append(keys, key)
-	}
-	cb.tagKeys = [][]kvCacheKey{keys}
+	cb.journal = nil
+	cb.journalShots = make([]int, 0)
 }
 
 func (cb *cachedBatch) CheckFillPercent(ns string) (float64, bool) {
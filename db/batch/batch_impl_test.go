@@ -269,3 +269,35 @@ func BenchmarkCachedBatch_Snapshot(b *testing.B) {
 		cb.Delete(_bucket1, k[:], "")
 	}
 }
+
+func BenchmarkCachedBatch_NestedSnapshots(b *testing.B) {
+	var v [32]byte
+	for _, dirty := range []int{1000, 10000, 100000} {
+		cb := NewCachedBatch()
+		for i := 0; i < dirty; i++ {
+			k := hash.Hash256b([]byte(strconv.Itoa(i)))
+			cb.Put(_bucket1, k[:], v[:], "")
+		}
+		keys := make([][]byte, 1000)
+		for i := range keys {
+			k := hash.Hash256b([]byte("nested" + strconv.Itoa(i)))
+			keys[i] = k[:]
+		}
+		b.Run(strconv.Itoa(dirty), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				sn := make([]int, len(keys))
+				for i, k := range keys {
+					sn[i] = cb.Snapshot()
+					cb.Put(_bucket1, k, v[:], "")
+				}
+				for i := len(keys) - 1; i >= 0; i-- {
+					if err := cb.RevertSnapshot(sn[i]); err != nil {
+						b.Fatal(err)
+					}
+				}
+				cb.ResetSnapshots()
+			}
+		})
+	}
+}
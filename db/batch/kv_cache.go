@@ -27,7 +27,6 @@ type (
 		key1 string
 		key2 string
 	}
-	kvCacheValue []int
 
 	node struct {
 		value   []byte
@@ -40,40 +39,12 @@ type (
 	}
 )
 
-func newkvCacheValue(v []int) *kvCacheValue {
-	return (*kvCacheValue)(&v)
-}
-
-func (kv *kvCacheValue) reset() {
-	([]int)(*kv)[0] = 0
-	*kv = (*kv)[:1]
-}
-
-func (kv *kvCacheValue) pop() {
-	*kv = (*kv)[:kv.len()-1]
-}
-func (kv *kvCacheValue) get() []int {
-	return ([]int)(*kv)
-}
-
-func (kv *kvCacheValue) getAt(i int) int {
-	return ([]int)(*kv)[i]
-}
-
-func (kv *kvCacheValue) append(v int) {
-	*kv = append(*kv, v)
-}
-
-func (kv *kvCacheValue) len() int {
-	return len(*kv)
-}
-
-func (kv *kvCacheValue) last() int {
-	return (*kv)[len(*kv)-1]
-}
-
 // NewKVCache returns a KVCache
 func NewKVCache() KVStoreCache {
+	return newKVCache()
+}
+
+func newKVCache() *kvCache {
 	return &kvCache{
 		cache: make(map[string]map[string]*node),
 	}
@@ -129,6 +100,28 @@ func (c *kvCache) Evict(key *kvCacheKey) {
 	}
 }
 
+// node returns the node of a record, nil if the record is not in cache
+func (c *kvCache) node(key *kvCacheKey) *node {
+	if ns, ok := c.cache[key.key1]; ok {
+		return ns[key.key2]
+	}
+	return nil
+}
+
+// restore sets the node of a record, or removes the record from cache if the node is nil
+func (c *kvCache) restore(key *kvCacheKey, n *node) {
+	if n != nil {
+		if _, ok := c.cache[key.key1]; !ok {
+			c.cache[key.key1] = make(map[string]*node)
+		}
+		c.cache[key.key1][key.key2] = n
+		return
+	}
+	if ns, ok := c.cache[key.key1]; ok {
+		delete(ns, key.key2)
+	}
+}
+
 // Clear clear the cache
 func (c *kvCache) Clear() {
 	c.cache = make(map[string]map[string]*node)
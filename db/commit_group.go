@@ -0,0 +1,224 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/db/batch"
+)
+
+var (
+	// ErrGroupOpen indicates the commit group has been opened already
+	ErrGroupOpen = errors.New("commit group is open")
+	// ErrGroupClosed indicates the commit group is not open
+	ErrGroupClosed = errors.New("commit group is not open")
+)
+
+type (
+	// CommitGroup groups the writes into the stores joined. Once the group is open, the writes into each store
+	// are accumulated in a buffer, which are read back by the later reads, and are either flushed into the store
+	// in one batch on commit, or discarded on rollback
+	CommitGroup struct {
+		mutex  sync.Mutex
+		open   bool
+		stores []*kvStoreInGroup
+	}
+
+	// kvStoreInGroup is a KVStore joined into a commit group
+	kvStoreInGroup struct {
+		lock   sync.RWMutex
+		store  KVStore
+		buffer *kvStoreWithBuffer
+	}
+)
+
+// NewCommitGroup returns a commit group
+func NewCommitGroup() *CommitGroup {
+	return &CommitGroup{}
+}
+
+// Join joins the store into the group, and returns the store to write through. The buffers are flushed in the
+// order of the stores joined. Note that the writes of any writer in between Begin and Commit are grouped, so a
+// store written in background is flushed or discarded along with the group
+func (g *CommitGroup) Join(store KVStore) KVStore {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	s := &kvStoreInGroup{store: store}
+	if g.open {
+		s.begin()
+	}
+	g.stores = append(g.stores, s)
+	return s
+}
+
+// Begin opens the group, the writes into the stores are buffered until Commit or Rollback
+func (g *CommitGroup) Begin() error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if g.open {
+		return ErrGroupOpen
+	}
+	for _, s := range g.stores {
+		s.begin()
+	}
+	g.open = true
+	return nil
+}
+
+// Commit flushes the writes buffered into each store in one batch, and closes the group. If a store fails to
+// flush, the stores flushed before are kept, and the writes into the rest stores are discarded
+func (g *CommitGroup) Commit() error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if !g.open {
+		return ErrGroupClosed
+	}
+	g.open = false
+	for i, s := range g.stores {
+		if err := s.flush(); err != nil {
+			for _, rest := range g.stores[i:] {
+				rest.discard()
+			}
+			return errors.Wrapf(err, "failed to flush the writes into store %d of the group", i)
+		}
+	}
+	return nil
+}
+
+// Rollback discards the writes buffered into the stores, and closes the group
+func (g *CommitGroup) Rollback() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if !g.open {
+		return
+	}
+	g.open = false
+	for _, s := range g.stores {
+		s.discard()
+	}
+}
+
+func (s *kvStoreInGroup) begin() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.buffer = &kvStoreWithBuffer{
+		store:  s.store,
+		buffer: batch.NewCachedBatch(),
+	}
+}
+
+func (s *kvStoreInGroup) flush() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.buffer.Size() > 0 {
+		if err := s.store.WriteBatch(s.buffer.buffer); err != nil {
+			return err
+		}
+	}
+	s.buffer = nil
+	return nil
+}
+
+func (s *kvStoreInGroup) discard() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.buffer = nil
+}
+
+// current returns the buffer if the group is open, the caller must hold the lock
+func (s *kvStoreInGroup) current() KVStore {
+	if s.buffer != nil {
+		return s.buffer
+	}
+	return s.store
+}
+
+func (s *kvStoreInGroup) Start(ctx context.Context) error {
+	return s.store.Start(ctx)
+}
+
+func (s *kvStoreInGroup) Stop(ctx context.Context) error {
+	return s.store.Stop(ctx)
+}
+
+func (s *kvStoreInGroup) Put(ns string, key, value []byte) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.current().Put(ns, key, value)
+}
+
+func (s *kvStoreInGroup) Get(ns string, key []byte) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.current().Get(ns, key)
+}
+
+func (s *kvStoreInGroup) Delete(ns string, key []byte) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.current().Delete(ns, key)
+}
+
+func (s *kvStoreInGroup) WriteBatch(b batch.KVStoreBatch) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.current().WriteBatch(b)
+}
+
+func (s *kvStoreInGroup) Filter(ns string, cond Condition, minKey, maxKey []byte) ([][]byte, [][]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.current().Filter(ns, cond, minKey, maxKey)
+}
+
+// Range reads the records committed into the store only
+func (s *kvStoreInGroup) Range(ns string, key []byte, count uint64) ([][]byte, error) {
+	store, ok := s.store.(KVStoreWithRange)
+	if !ok {
+		return nil, errors.Wrap(ErrNotSupported, "range is not supported by the store in group")
+	}
+	return store.Range(ns, key, count)
+}
+
+// Seek reads the records committed into the store only
+func (s *kvStoreInGroup) Seek(ns string, key []byte, fn func([]byte, []byte) bool) error {
+	store, ok := s.store.(KVStoreWithSeek)
+	if !ok {
+		return errors.Wrap(ErrNotSupported, "seek is not supported by the store in group")
+	}
+	return store.Seek(ns, key, fn)
+}
+
+// Checkpoint saves a copy of the records committed into the store only
+func (s *kvStoreInGroup) Checkpoint(path string) (KVStoreWithBuckets, error) {
+	store, ok := s.store.(KVStoreWithCheckpoint)
+	if !ok {
+		return nil, errors.Wrap(ErrNotSupported, "checkpoint is not supported by the store in group")
+	}
+	return store.Checkpoint(path)
+}
+
+// Backup copies the records committed into the store only
+func (s *kvStoreInGroup) Backup(path string, began func()) error {
+	store, ok := s.store.(KVStoreWithBackup)
+	if !ok {
+		return errors.Wrap(ErrNotSupported, "backup is not supported by the store in group")
+	}
+	return store.Backup(path, began)
+}
+
+// Compact compacts the records committed into the store only
+func (s *kvStoreInGroup) Compact(ctx context.Context, progress func(int64)) (int64, error) {
+	store, ok := s.store.(KVStoreWithCompaction)
+	if !ok {
+		return 0, errors.Wrap(ErrNotSupported, "compaction is not supported by the store in group")
+	}
+	return store.Compact(ctx, progress)
+}
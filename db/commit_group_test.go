@@ -0,0 +1,112 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/db/batch"
+)
+
+func TestCommitGroup(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	cfg := DefaultConfig
+	cfg.DbPath = t.TempDir() + "/bolt.db"
+	bolt := NewBoltDB(cfg)
+	mem := NewMemKVStore()
+	g := NewCommitGroup()
+	s1, s2 := g.Join(bolt), g.Join(mem)
+	r.NoError(s1.Start(ctx))
+	defer s1.Stop(ctx)
+	r.NoError(s2.Start(ctx))
+	r.Equal(ErrGroupClosed, g.Commit())
+
+	// writes are written through if the group is not open
+	r.NoError(s1.Put("ns", []byte("k0"), []byte("v0")))
+	v, err := bolt.Get("ns", []byte("k0"))
+	r.NoError(err)
+	r.Equal([]byte("v0"), v)
+
+	// the writes are read back from the buffer, and flushed on commit
+	r.NoError(g.Begin())
+	r.Equal(ErrGroupOpen, g.Begin())
+	r.NoError(s1.Put("ns", []byte("k1"), []byte("v1")))
+	r.NoError(s1.Delete("ns", []byte("k0")))
+	b := batch.NewBatch()
+	b.Put("ns", []byte("k2"), []byte("v2"), "failed to put")
+	r.NoError(s2.WriteBatch(b))
+	v, err = s1.Get("ns", []byte("k1"))
+	r.NoError(err)
+	r.Equal([]byte("v1"), v)
+	_, err = s1.Get("ns", []byte("k0"))
+	r.Equal(ErrNotExist, errors.Cause(err))
+	_, err = bolt.Get("ns", []byte("k1"))
+	r.Equal(ErrNotExist, errors.Cause(err))
+	_, err = mem.Get("ns", []byte("k2"))
+	r.Equal(ErrNotExist, errors.Cause(err))
+	r.NoError(g.Commit())
+	v, err = bolt.Get("ns", []byte("k1"))
+	r.NoError(err)
+	r.Equal([]byte("v1"), v)
+	_, err = bolt.Get("ns", []byte("k0"))
+	r.Equal(ErrNotExist, errors.Cause(err))
+	v, err = mem.Get("ns", []byte("k2"))
+	r.NoError(err)
+	r.Equal([]byte("v2"), v)
+
+	// the writes are discarded on rollback
+	r.NoError(g.Begin())
+	r.NoError(s1.Put("ns", []byte("k3"), []byte("v3")))
+	r.NoError(s2.Put("ns", []byte("k3"), []byte("v3")))
+	g.Rollback()
+	_, err = s1.Get("ns", []byte("k3"))
+	r.Equal(ErrNotExist, errors.Cause(err))
+	_, err = s2.Get("ns", []byte("k3"))
+	r.Equal(ErrNotExist, errors.Cause(err))
+
+	// range and seek pass through to the store
+	_, ok := s1.(KVStoreWithRange)
+	r.True(ok)
+	s3 := g.Join(NewMockKVStore(gomock.NewController(t)))
+	_, err = s3.(KVStoreWithRange).Range("ns", []byte("k1"), 1)
+	r.Equal(ErrNotSupported, errors.Cause(err))
+	keys := [][]byte{}
+	r.NoError(s1.(KVStoreWithSeek).Seek("ns", nil, func(k, _ []byte) bool {
+		keys = append(keys, k)
+		return true
+	}))
+	r.Equal([][]byte{[]byte("k1")}, keys)
+}
+
+func TestCommitGroupFlushError(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	mem1, mem2 := NewMemKVStore(), NewMemKVStore()
+	failing := NewMockKVStore(ctrl)
+	failing.EXPECT().WriteBatch(gomock.Any()).Return(ErrIO).Times(1)
+	g := NewCommitGroup()
+	s1, s2, s3 := g.Join(mem1), g.Join(failing), g.Join(mem2)
+	r.NoError(g.Begin())
+	r.NoError(s1.Put("ns", []byte("k"), []byte("v")))
+	r.NoError(s2.Put("ns", []byte("k"), []byte("v")))
+	r.NoError(s3.Put("ns", []byte("k"), []byte("v")))
+	r.Equal(ErrIO, errors.Cause(g.Commit()))
+	// the store flushed before is kept, the rest are discarded
+	v, err := mem1.Get("ns", []byte("k"))
+	r.NoError(err)
+	r.Equal([]byte("v"), v)
+	_, err = s3.Get("ns", []byte("k"))
+	r.Equal(ErrNotExist, errors.Cause(err))
+	// the group could be opened again
+	r.NoError(g.Begin())
+	g.Rollback()
+}
@@ -0,0 +1,361 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/pkg/lifecycle"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/routine"
+)
+
+var (
+	// ErrInsufficientSpace indicates the error that the free disk space isn't enough for the copy of a db
+	ErrInsufficientSpace = errors.New("insufficient disk space")
+	// ErrPauseExceeded indicates the error that the compaction of a db takes longer than the max pause
+	ErrPauseExceeded = errors.New("compaction exceeds the max pause")
+	// ErrCompactionRunning indicates the error that a compaction run is in progress
+	ErrCompactionRunning = errors.New("compaction is running")
+
+	_compactionMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_db_compaction",
+			Help: "db compaction statistics.",
+		},
+		[]string{"store", "result"},
+	)
+	_compactionReclaimedMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_db_compaction_reclaimed_bytes",
+			Help: "bytes reclaimed by the db compactions.",
+		},
+		[]string{"store"},
+	)
+	_compactionProgressMtc = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "iotex_db_compaction_progress_bytes",
+			Help: "bytes copied by the db compaction in progress.",
+		},
+		[]string{"store"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(_compactionMtc)
+	prometheus.MustRegister(_compactionReclaimedMtc)
+	prometheus.MustRegister(_compactionProgressMtc)
+}
+
+var _ lifecycle.StartStopper = (*CompactionScheduler)(nil)
+
+type (
+	// CompactionStore is a kv store to compact
+	CompactionStore struct {
+		Name    string
+		KVStore KVStoreWithCompaction
+	}
+
+	// CompactionStores returns the kv stores to compact, which could be replaced at runtime
+	CompactionStores func() []*CompactionStore
+
+	// CompactionWindow returns true if the chain is in a low-activity window to compact the dbs
+	CompactionWindow func() (bool, error)
+
+	// CompactionScheduler compacts the kv stores in the low-activity windows. A store is compacted in each window,
+	// which is the one compacted least recently, so the writes of a block commit are paused by one compaction
+	// at most, and no longer than the max pause. A store whose compaction has exceeded the max pause is skipped
+	// afterwards, unless it's compacted with a longer max pause by the admin
+	CompactionScheduler struct {
+		cfg      CompactionConfig
+		stores   CompactionStores
+		window   CompactionWindow
+		task     *routine.RecurringTask
+		ctx      context.Context
+		stop     context.CancelFunc
+		wg       sync.WaitGroup
+		mutex    sync.Mutex
+		cancel   context.CancelFunc
+		status   CompactionStatus
+		lastRuns map[string]time.Time
+		exceeded map[string]time.Duration
+	}
+
+	// CompactionStatus is the status of the last compaction run, Store is the store being compacted and Copied is
+	// the bytes of its records copied so far
+	CompactionStatus struct {
+		Running   bool
+		Scheduled bool
+		Started   time.Time
+		Store     string
+		Copied    int64
+		Results   []*CompactionResult
+	}
+
+	// CompactionResult is the result of the compaction of a store, Err is the reason if it's skipped or failed
+	CompactionResult struct {
+		Store     string
+		Reclaimed int64
+		Duration  time.Duration
+		Err       error
+	}
+)
+
+// NewCompactionScheduler instantiates a CompactionScheduler instance
+func NewCompactionScheduler(cfg CompactionConfig, stores CompactionStores, window CompactionWindow) *CompactionScheduler {
+	s := &CompactionScheduler{
+		cfg:      cfg,
+		stores:   stores,
+		window:   window,
+		lastRuns: make(map[string]time.Time),
+		exceeded: make(map[string]time.Duration),
+	}
+	s.ctx, s.stop = context.WithCancel(context.Background())
+	if cfg.Enabled {
+		s.task = routine.NewRecurringTask(s.schedule, cfg.CheckInterval)
+	}
+	return s
+}
+
+// Start starts scheduling the compactions if enabled
+func (s *CompactionScheduler) Start(ctx context.Context) error {
+	if s.task == nil {
+		return nil
+	}
+	return s.task.Start(ctx)
+}
+
+// Stop stops scheduling the compactions, and aborts the run in progress
+func (s *CompactionScheduler) Stop(ctx context.Context) error {
+	if s.task != nil {
+		if err := s.task.Stop(ctx); err != nil {
+			return err
+		}
+	}
+	s.stop()
+	s.wg.Wait()
+	return nil
+}
+
+// Run starts compacting the stores of the names one by one in background regardless of the activity of the
+// chain, or all the stores if no name is given. The max pause of the config is used if maxPause is 0
+func (s *CompactionScheduler) Run(names []string, maxPause time.Duration) error {
+	stores := s.stores()
+	if len(names) > 0 {
+		all := stores
+		stores = make([]*CompactionStore, 0, len(names))
+		for _, name := range names {
+			store := findCompactionStore(all, name)
+			if store == nil {
+				return errors.Wrapf(ErrInvalid, "unknown store %s", name)
+			}
+			stores = append(stores, store)
+		}
+	}
+	if maxPause == 0 {
+		maxPause = s.cfg.MaxPause
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.status.Running {
+		return ErrCompactionRunning
+	}
+	s.start(stores, maxPause, false)
+	return nil
+}
+
+// Cancel aborts the run in progress, and returns false if there's none
+func (s *CompactionScheduler) Cancel() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if !s.status.Running {
+		return false
+	}
+	s.cancel()
+	return true
+}
+
+// Status returns the status of the last compaction run
+func (s *CompactionScheduler) Status() CompactionStatus {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	status := s.status
+	status.Results = append([]*CompactionResult(nil), s.status.Results...)
+	return status
+}
+
+// schedule starts compacting the store compacted least recently if the chain is in a low-activity window
+func (s *CompactionScheduler) schedule() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.status.Running {
+		return
+	}
+	var next *CompactionStore
+	for _, store := range s.stores() {
+		if _, ok := s.exceeded[store.Name]; ok {
+			continue
+		}
+		last := s.lastRuns[store.Name]
+		if time.Since(last) < s.cfg.StoreInterval {
+			continue
+		}
+		if next == nil || last.Before(s.lastRuns[next.Name]) {
+			next = store
+		}
+	}
+	if next == nil {
+		return
+	}
+	ok, err := s.window()
+	if err != nil {
+		log.L().Error("Failed to check the window of db compaction.", zap.Error(err))
+		return
+	}
+	if ok {
+		s.start([]*CompactionStore{next}, s.cfg.MaxPause, true)
+	}
+}
+
+// start starts the run in background, it must be called with the mutex held
+func (s *CompactionScheduler) start(stores []*CompactionStore, maxPause time.Duration, scheduled bool) {
+	ctx, cancel := context.WithCancel(s.ctx)
+	s.cancel = cancel
+	s.status = CompactionStatus{
+		Running:   true,
+		Scheduled: scheduled,
+		Started:   time.Now(),
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer cancel()
+		s.run(ctx, stores, maxPause)
+	}()
+}
+
+func (s *CompactionScheduler) run(ctx context.Context, stores []*CompactionStore, maxPause time.Duration) {
+	for _, store := range stores {
+		if ctx.Err() != nil {
+			break
+		}
+		result := s.compact(ctx, store, maxPause)
+		s.mutex.Lock()
+		s.status.Results = append(s.status.Results, result)
+		s.mutex.Unlock()
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.status.Running = false
+	s.status.Store = ""
+	s.status.Copied = 0
+}
+
+func (s *CompactionScheduler) compact(ctx context.Context, store *CompactionStore, maxPause time.Duration) *CompactionResult {
+	result := &CompactionResult{Store: store.Name}
+	s.mutex.Lock()
+	s.lastRuns[store.Name] = time.Now()
+	exceeded, ok := s.exceeded[store.Name]
+	s.status.Store = store.Name
+	s.status.Copied = 0
+	s.mutex.Unlock()
+	if ok && maxPause <= exceeded {
+		result.Err = errors.Wrapf(ErrPauseExceeded, "exceeded %s before", exceeded)
+		_compactionMtc.WithLabelValues(store.Name, "skipped").Inc()
+		return result
+	}
+
+	log.L().Info("Start compacting db.", zap.String("store", store.Name), zap.Duration("maxPause", maxPause))
+	progress := _compactionProgressMtc.WithLabelValues(store.Name)
+	defer progress.Set(0)
+	pauseCtx, cancel := context.WithTimeout(ctx, maxPause)
+	defer cancel()
+	start := time.Now()
+	reclaimed, err := store.KVStore.Compact(pauseCtx, func(copied int64) {
+		progress.Set(float64(copied))
+		s.mutex.Lock()
+		s.status.Copied = copied
+		s.mutex.Unlock()
+	})
+	result.Duration = time.Since(start)
+	switch {
+	case err == nil:
+		result.Reclaimed = reclaimed
+		s.mutex.Lock()
+		delete(s.exceeded, store.Name)
+		s.mutex.Unlock()
+		_compactionMtc.WithLabelValues(store.Name, "compacted").Inc()
+		_compactionReclaimedMtc.WithLabelValues(store.Name).Add(float64(reclaimed))
+		log.L().Info("Finish compacting db.", zap.String("store", store.Name), zap.Int64("reclaimed", reclaimed), zap.Duration("duration", result.Duration))
+		return result
+	case errors.Cause(err) == ErrInsufficientSpace:
+		_compactionMtc.WithLabelValues(store.Name, "skipped").Inc()
+	case ctx.Err() == nil && pauseCtx.Err() != nil:
+		err = errors.Wrap(ErrPauseExceeded, maxPause.String())
+		s.mutex.Lock()
+		s.exceeded[store.Name] = maxPause
+		s.mutex.Unlock()
+		_compactionMtc.WithLabelValues(store.Name, "aborted").Inc()
+	case ctx.Err() != nil:
+		_compactionMtc.WithLabelValues(store.Name, "aborted").Inc()
+	default:
+		_compactionMtc.WithLabelValues(store.Name, "failed").Inc()
+	}
+	result.Err = err
+	log.L().Warn("Failed to compact db.", zap.String("store", store.Name), zap.Error(err))
+	return result
+}
+
+func findCompactionStore(stores []*CompactionStore, name string) *CompactionStore {
+	for _, store := range stores {
+		if store.Name == name {
+			return store
+		}
+	}
+	return nil
+}
+
+// checkFreeSpace returns ErrInsufficientSpace if the free space of the disk of the path is less than size
+func checkFreeSpace(path string, size int64) error {
+	free, err := freeSpace(filepath.Dir(path))
+	if err != nil {
+		return errors.Wrap(ErrIO, err.Error())
+	}
+	if free < uint64(size) {
+		return errors.Wrapf(ErrInsufficientSpace, "%d bytes free, %d bytes required", free, size)
+	}
+	return nil
+}
+
+func freeSpace(dir string) (uint64, error) {
+	fs := syscall.Statfs_t{}
+	if err := syscall.Statfs(dir, &fs); err != nil {
+		return 0, err
+	}
+	return fs.Bavail * uint64(fs.Bsize), nil
+}
+
+// syncDir syncs the directory, so a file renamed in it survives a crash
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	err = d.Sync()
+	if cerr := d.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactionScheduler(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	var (
+		small = NewMockKVStoreWithCompaction(ctrl)
+		large = NewMockKVStoreWithCompaction(ctrl)
+		cfg   = DefaultConfig.Compaction
+		open  bool
+	)
+	cfg.Enabled = false
+	cfg.MaxPause = 10 * time.Millisecond
+	s := NewCompactionScheduler(cfg, func() []*CompactionStore {
+		return []*CompactionStore{
+			{Name: "small", KVStore: small},
+			{Name: "large", KVStore: large},
+		}
+	}, func() (bool, error) {
+		return open, nil
+	})
+	r.NoError(s.Start(ctx))
+	defer func() {
+		r.NoError(s.Stop(ctx))
+	}()
+	wait := func() CompactionStatus {
+		r.Eventually(func() bool {
+			return !s.Status().Running
+		}, time.Second, time.Millisecond)
+		return s.Status()
+	}
+	compacted := func(reclaimed int64) func(context.Context, func(int64)) (int64, error) {
+		return func(_ context.Context, progress func(int64)) (int64, error) {
+			progress(reclaimed * 2)
+			return reclaimed, nil
+		}
+	}
+	blocked := func(ctx context.Context, _ func(int64)) (int64, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}
+
+	// the compaction of the large store exceeds the max pause
+	reclaimed := testutil.ToFloat64(_compactionReclaimedMtc.WithLabelValues("small"))
+	small.EXPECT().Compact(gomock.Any(), gomock.Any()).DoAndReturn(compacted(100)).Times(1)
+	large.EXPECT().Compact(gomock.Any(), gomock.Any()).DoAndReturn(blocked).Times(1)
+	r.NoError(s.Run(nil, 0))
+	status := wait()
+	r.False(status.Scheduled)
+	r.Len(status.Results, 2)
+	r.Equal("small", status.Results[0].Store)
+	r.EqualValues(100, status.Results[0].Reclaimed)
+	r.NoError(status.Results[0].Err)
+	r.Equal("large", status.Results[1].Store)
+	r.Equal(ErrPauseExceeded, errors.Cause(status.Results[1].Err))
+	r.Equal(reclaimed+100, testutil.ToFloat64(_compactionReclaimedMtc.WithLabelValues("small")))
+	r.Zero(testutil.ToFloat64(_compactionProgressMtc.WithLabelValues("small")))
+
+	// the large store is skipped unless the max pause is longer
+	r.NoError(s.Run([]string{"large"}, 0))
+	status = wait()
+	r.Len(status.Results, 1)
+	r.Equal(ErrPauseExceeded, errors.Cause(status.Results[0].Err))
+	large.EXPECT().Compact(gomock.Any(), gomock.Any()).DoAndReturn(compacted(1000)).Times(1)
+	r.NoError(s.Run([]string{"large"}, time.Second))
+	status = wait()
+	r.EqualValues(1000, status.Results[0].Reclaimed)
+	r.Equal(ErrInvalid, errors.Cause(s.Run([]string{"unknown"}, 0)))
+
+	// a run is canceled
+	r.False(s.Cancel())
+	small.EXPECT().Compact(gomock.Any(), gomock.Any()).DoAndReturn(blocked).Times(1)
+	r.NoError(s.Run([]string{"small", "large"}, time.Minute))
+	r.Eventually(func() bool {
+		return s.Status().Store == "small"
+	}, time.Second, time.Millisecond)
+	r.Equal(ErrCompactionRunning, s.Run(nil, 0))
+	r.True(s.Cancel())
+	status = wait()
+	r.Len(status.Results, 1)
+	r.Equal(context.Canceled, errors.Cause(status.Results[0].Err))
+
+	// the store compacted least recently is scheduled in a low-activity window
+	s.cfg.StoreInterval = time.Hour
+	s.schedule()
+	r.False(s.Status().Scheduled)
+	s.cfg.StoreInterval = 0
+	s.schedule()
+	r.False(s.Status().Scheduled)
+	open = true
+	large.EXPECT().Compact(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, func(int64)) (int64, error) {
+		return 0, errors.Wrap(ErrInsufficientSpace, "no space")
+	}).Times(1)
+	s.schedule()
+	status = wait()
+	r.True(status.Scheduled)
+	r.Len(status.Results, 1)
+	r.Equal("large", status.Results[0].Store)
+	r.Equal(ErrInsufficientSpace, errors.Cause(status.Results[0].Err))
+	small.EXPECT().Compact(gomock.Any(), gomock.Any()).DoAndReturn(compacted(10)).Times(1)
+	s.schedule()
+	status = wait()
+	r.Len(status.Results, 1)
+	r.Equal("small", status.Results[0].Store)
+}
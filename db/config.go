@@ -5,6 +5,8 @@
 
 package db
 
+import "time"
+
 // Config is the config for database
 type Config struct {
 	DbPath string `yaml:"dbPath"`
@@ -30,6 +32,28 @@ type Config struct {
 	ReadOnly bool `yaml:"readOnly"`
 	// DBType is the type of database
 	DBType string `yaml:"dbType"`
+	// Compaction is the config of the compactions of the dbs
+	Compaction CompactionConfig `yaml:"compaction"`
+}
+
+// CompactionConfig is the config of the compactions of the dbs scheduled in the low-activity windows
+type CompactionConfig struct {
+	// Enabled enables scheduling the compactions, otherwise the dbs are only compacted by the admin
+	Enabled bool `yaml:"enabled"`
+	// CheckInterval is the interval to check whether the chain is in a low-activity window
+	CheckInterval time.Duration `yaml:"checkInterval"`
+	// StoreInterval is the min interval between the scheduled compactions of a db
+	StoreInterval time.Duration `yaml:"storeInterval"`
+	// MaxPause is the max duration the writes into a db are paused by its compaction, which bounds the latency
+	// added to a block commit
+	MaxPause time.Duration `yaml:"maxPause"`
+	// MaxBlockFullness is the max average ratio of the gas used by the recent blocks in a low-activity window
+	MaxBlockFullness float64 `yaml:"maxBlockFullness"`
+	// RecentBlocks is the number of the recent blocks to measure the fullness
+	RecentBlocks uint64 `yaml:"recentBlocks"`
+	// EpochMargin is the number of blocks around an epoch boundary out of the low-activity windows, since the
+	// blocks settling the epoch are heavy
+	EpochMargin uint64 `yaml:"epochMargin"`
 }
 
 // Database types
@@ -55,4 +79,13 @@ var DefaultConfig = Config{
 	SplitDBHeight:         900000,
 	HistoryStateRetention: 2000,
 	DBType:                DBBolt,
+	Compaction: CompactionConfig{
+		Enabled:          true,
+		CheckInterval:    10 * time.Minute,
+		StoreInterval:    7 * 24 * time.Hour,
+		MaxPause:         2 * time.Second,
+		MaxBlockFullness: 0.2,
+		RecentBlocks:     60,
+		EpochMargin:      30,
+	},
 }
@@ -154,9 +154,13 @@ func TestCountingIndex(t *testing.T) {
 	cfg := DefaultConfig
 	cfg.DbPath = testPath
 
+	pebbleCfg := cfg
+	pebbleCfg.DbPath = t.TempDir()
+
 	for _, v := range []KVStore{
 		NewMemKVStore(),
 		NewBoltDB(cfg),
+		NewPebbleDB(pebbleCfg),
 	} {
 		t.Run("test counting index", func(t *testing.T) {
 			testFunc(v, t)
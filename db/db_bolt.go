@@ -8,8 +8,11 @@ package db
 import (
 	"bytes"
 	"context"
+	"os"
+	"path/filepath"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -22,7 +25,11 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 )
 
-const _fileMode = 0600
+const (
+	_fileMode = 0600
+	// _compactTxSize is the max bytes of the records written in a transaction of the compaction
+	_compactTxSize = 64 << 20
+)
 
 var (
 	// ErrDBNotStarted represents the error when a db has not started
@@ -45,6 +52,10 @@ type BoltDB struct {
 	path   string
 	config Config
 	mutex  sync.Mutex
+	// fileMutex is held by the transactions to read, and locked by the compaction to swap the db file
+	fileMutex sync.RWMutex
+	// writeMutex is held by the transactions to write, and locked by the compaction to pause the writes
+	writeMutex sync.Mutex
 }
 
 // NewBoltDB instantiates an BoltDB with implements KVStore
@@ -64,11 +75,7 @@ func (b *BoltDB) Start(_ context.Context) error {
 	if b.IsReady() {
 		return nil
 	}
-	opts := *bolt.DefaultOptions
-	if b.config.ReadOnly {
-		opts.ReadOnly = true
-	}
-	db, err := bolt.Open(b.path, _fileMode, &opts)
+	db, err := bolt.Open(b.path, _fileMode, b.options())
 	if err != nil {
 		return errors.Wrap(ErrIO, err.Error())
 	}
@@ -100,7 +107,7 @@ func (b *BoltDB) Put(namespace string, key, value []byte) (err error) {
 	}
 
 	for c := uint8(0); c < b.config.NumRetries; c++ {
-		if err = b.db.Update(func(tx *bolt.Tx) error {
+		if err = b.update(func(tx *bolt.Tx) error {
 			bucket, err := tx.CreateBucketIfNotExists([]byte(namespace))
 			if err != nil {
 				return err
@@ -126,7 +133,7 @@ func (b *BoltDB) Get(namespace string, key []byte) ([]byte, error) {
 	}
 
 	var value []byte
-	err := b.db.View(func(tx *bolt.Tx) error {
+	err := b.view(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket([]byte(namespace))
 		if bucket == nil {
 			return errors.Wrapf(ErrNotExist, "bucket = %x doesn't exist", []byte(namespace))
@@ -156,7 +163,7 @@ func (b *BoltDB) Filter(namespace string, cond Condition, minKey, maxKey []byte)
 	}
 
 	var fk, fv [][]byte
-	if err := b.db.View(func(tx *bolt.Tx) error {
+	if err := b.view(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket([]byte(namespace))
 		if bucket == nil {
 			return errors.Wrapf(ErrBucketNotExist, "bucket = %x doesn't exist", []byte(namespace))
@@ -206,7 +213,7 @@ func (b *BoltDB) Range(namespace string, key []byte, count uint64) ([][]byte, er
 	}
 
 	value := make([][]byte, count)
-	err := b.db.View(func(tx *bolt.Tx) error {
+	err := b.view(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket([]byte(namespace))
 		if bucket == nil {
 			return errors.Wrapf(ErrNotExist, "bucket = %s doesn't exist", namespace)
@@ -244,7 +251,7 @@ func (b *BoltDB) GetBucketByPrefix(namespace []byte) ([][]byte, error) {
 	}
 
 	allKey := make([][]byte, 0)
-	err := b.db.View(func(tx *bolt.Tx) error {
+	err := b.view(func(tx *bolt.Tx) error {
 		if err := tx.ForEach(func(name []byte, b *bolt.Bucket) error {
 			if bytes.HasPrefix(name, namespace) && !bytes.Equal(name, namespace) {
 				temp := make([]byte, len(name))
@@ -267,7 +274,7 @@ func (b *BoltDB) GetKeyByPrefix(namespace, prefix []byte) ([][]byte, error) {
 	}
 
 	allKey := make([][]byte, 0)
-	err := b.db.View(func(tx *bolt.Tx) error {
+	err := b.view(func(tx *bolt.Tx) error {
 		buck := tx.Bucket(namespace)
 		if buck == nil {
 			return ErrNotExist
@@ -292,14 +299,14 @@ func (b *BoltDB) Delete(namespace string, key []byte) (err error) {
 	numRetries := b.config.NumRetries
 	for c := uint8(0); c < numRetries; c++ {
 		if key == nil {
-			err = b.db.Update(func(tx *bolt.Tx) error {
+			err = b.update(func(tx *bolt.Tx) error {
 				if err := tx.DeleteBucket([]byte(namespace)); err != bolt.ErrBucketNotFound {
 					return err
 				}
 				return nil
 			})
 		} else {
-			err = b.db.Update(func(tx *bolt.Tx) error {
+			err = b.update(func(tx *bolt.Tx) error {
 				bucket := tx.Bucket([]byte(namespace))
 				if bucket == nil {
 					return nil
@@ -354,7 +361,7 @@ func (b *BoltDB) WriteBatch(kvsb batch.KVStoreBatch) (err error) {
 	boltdbMtc.WithLabelValues(b.path, "entrySize").Set(float64(kvsb.Size()))
 	boltdbMtc.WithLabelValues(b.path, "uniqueEntrySize").Set(float64(len(entryKeySet)))
 	for c := uint8(0); c < b.config.NumRetries; c++ {
-		if err = b.db.Update(func(tx *bolt.Tx) error {
+		if err = b.update(func(tx *bolt.Tx) error {
 			// keep order of the writes same as the original batch
 			for i := len(uniqEntries) - 1; i >= 0; i-- {
 				write := uniqEntries[i]
@@ -404,7 +411,7 @@ func (b *BoltDB) BucketExists(namespace string) bool {
 	}
 
 	var exist bool
-	_ = b.db.View(func(tx *bolt.Tx) error {
+	_ = b.view(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket([]byte(namespace))
 		if bucket != nil {
 			exist = true
@@ -414,6 +421,169 @@ func (b *BoltDB) BucketExists(namespace string) bool {
 	return exist
 }
 
+// Buckets returns the names of all the buckets
+func (b *BoltDB) Buckets() ([]string, error) {
+	if !b.IsReady() {
+		return nil, ErrDBNotStarted
+	}
+
+	names := make([]string, 0)
+	err := b.view(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, _ *bolt.Bucket) error {
+			names = append(names, string(name))
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, errors.Wrap(ErrIO, err.Error())
+	}
+	return names, nil
+}
+
+// ForEach iterates over all <k, v> pairs in a bucket
+func (b *BoltDB) ForEach(namespace string, fn func(k, v []byte) error) error {
+	if !b.IsReady() {
+		return ErrDBNotStarted
+	}
+
+	return b.view(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(namespace))
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(k, v []byte) error {
+			key := make([]byte, len(k))
+			copy(key, k)
+			value := make([]byte, len(v))
+			copy(value, v)
+			return fn(key, value)
+		})
+	})
+}
+
+// Seek iterates over the <k, v> pairs in a bucket from the first key not less than key, until fn returns false
+func (b *BoltDB) Seek(namespace string, key []byte, fn func(k, v []byte) bool) error {
+	if !b.IsReady() {
+		return ErrDBNotStarted
+	}
+
+	return b.view(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(namespace))
+		if bucket == nil {
+			return nil
+		}
+		c := bucket.Cursor()
+		for k, v := c.Seek(key); k != nil; k, v = c.Next() {
+			ck := make([]byte, len(k))
+			copy(ck, k)
+			cv := make([]byte, len(v))
+			copy(cv, v)
+			if !fn(ck, cv) {
+				return nil
+			}
+		}
+		return nil
+	})
+}
+
+// Checkpoint copies the db at a point in time into the path without blocking the writes, and returns the
+// copy opened as read-only. An existing copy in the path is returned instead, so a job using the copy could
+// be resumed after restart
+func (b *BoltDB) Checkpoint(path string) (KVStoreWithBuckets, error) {
+	if !b.IsReady() {
+		return nil, ErrDBNotStarted
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		// copy into a temporary file first, so an interrupted copy is never taken as a checkpoint
+		tmp := path + ".tmp"
+		if err := b.view(func(tx *bolt.Tx) error {
+			return tx.CopyFile(tmp, _fileMode)
+		}); err != nil {
+			return nil, errors.Wrap(ErrIO, err.Error())
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			return nil, errors.Wrap(ErrIO, err.Error())
+		}
+	} else if err != nil {
+		return nil, errors.Wrap(ErrIO, err.Error())
+	}
+	cfg := b.config
+	cfg.DbPath = path
+	cfg.ReadOnly = true
+	return NewBoltDB(cfg), nil
+}
+
+// Backup copies the db at the time the read transaction begins into the path, and calls began right after
+// the transaction begins. The copy is written into a temporary file first, so an interrupted backup never
+// leaves a partial copy in the path. Note a write which grows the mmap of the db waits until the copy is done
+func (b *BoltDB) Backup(path string, began func()) error {
+	if !b.IsReady() {
+		return ErrDBNotStarted
+	}
+
+	b.fileMutex.RLock()
+	defer b.fileMutex.RUnlock()
+	tx, err := b.db.Begin(false)
+	if err != nil {
+		return errors.Wrap(ErrIO, err.Error())
+	}
+	began()
+	tmp := path + ".tmp"
+	err = tx.CopyFile(tmp, _fileMode)
+	if rerr := tx.Rollback(); err == nil {
+		err = rerr
+	}
+	if err != nil {
+		return errors.Wrap(ErrIO, err.Error())
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return errors.Wrap(ErrIO, err.Error())
+	}
+	return nil
+}
+
+// Compact copies the records into a compacted file, which replaces the db file atomically, and returns the number
+// of bytes reclaimed. The writes are paused until the copy is done, while the reads are only paused while the files
+// are swapped. The compaction is aborted once ctx is done, and progress is called with the bytes of the records
+// copied so far. ErrInsufficientSpace is returned if the free disk space is less than the size of the db
+func (b *BoltDB) Compact(ctx context.Context, progress func(int64)) (int64, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if !b.IsReady() {
+		return 0, ErrDBNotStarted
+	}
+	if b.config.ReadOnly {
+		return 0, errors.Wrap(ErrNotSupported, "read-only db can't be compacted")
+	}
+
+	b.writeMutex.Lock()
+	defer b.writeMutex.Unlock()
+	before, err := os.Stat(b.path)
+	if err != nil {
+		return 0, errors.Wrap(ErrIO, err.Error())
+	}
+	if err := checkFreeSpace(b.path, before.Size()); err != nil {
+		return 0, err
+	}
+	// an interrupted compaction never leaves a partial copy in the path, the db file is either the original or the
+	// compacted one, and the copy left in the temporary file is overwritten by the next compaction
+	tmp := b.path + ".compact"
+	if err := b.compactInto(ctx, tmp, progress); err != nil {
+		_ = os.Remove(tmp)
+		return 0, err
+	}
+	if err := b.swap(ctx, tmp); err != nil {
+		_ = os.Remove(tmp)
+		return 0, err
+	}
+	after, err := os.Stat(b.path)
+	if err != nil {
+		return 0, errors.Wrap(ErrIO, err.Error())
+	}
+	return before.Size() - after.Size(), nil
+}
+
 // ======================================
 // below functions used by RangeIndex
 // ======================================
@@ -426,7 +596,7 @@ func (b *BoltDB) Insert(name []byte, key uint64, value []byte) error {
 
 	var err error
 	for i := uint8(0); i < b.config.NumRetries; i++ {
-		if err = b.db.Update(func(tx *bolt.Tx) error {
+		if err = b.update(func(tx *bolt.Tx) error {
 			bucket := tx.Bucket(name)
 			if bucket == nil {
 				return errors.Wrapf(ErrBucketNotExist, "bucket = %x doesn't exist", name)
@@ -467,7 +637,7 @@ func (b *BoltDB) SeekNext(name []byte, key uint64) ([]byte, error) {
 	}
 
 	var value []byte
-	err := b.db.View(func(tx *bolt.Tx) error {
+	err := b.view(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket(name)
 		if bucket == nil {
 			return errors.Wrapf(ErrBucketNotExist, "bucket = %x doesn't exist", name)
@@ -492,7 +662,7 @@ func (b *BoltDB) SeekPrev(name []byte, key uint64) ([]byte, error) {
 	}
 
 	var value []byte
-	if err := b.db.View(func(tx *bolt.Tx) error {
+	if err := b.view(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket(name)
 		if bucket == nil {
 			return errors.Wrapf(ErrBucketNotExist, "bucket = %x doesn't exist", name)
@@ -518,7 +688,7 @@ func (b *BoltDB) Remove(name []byte, key uint64) error {
 
 	var err error
 	for i := uint8(0); i < b.config.NumRetries; i++ {
-		if err = b.db.Update(func(tx *bolt.Tx) error {
+		if err = b.update(func(tx *bolt.Tx) error {
 			bucket := tx.Bucket(name)
 			if bucket == nil {
 				return errors.Wrapf(ErrBucketNotExist, "bucket = %x doesn't exist", name)
@@ -561,7 +731,7 @@ func (b *BoltDB) Purge(name []byte, key uint64) error {
 
 	var err error
 	for i := uint8(0); i < b.config.NumRetries; i++ {
-		if err = b.db.Update(func(tx *bolt.Tx) error {
+		if err = b.update(func(tx *bolt.Tx) error {
 			bucket := tx.Bucket(name)
 			if bucket == nil {
 				return errors.Wrapf(ErrBucketNotExist, "bucket = %x doesn't exist", name)
@@ -597,13 +767,150 @@ func (b *BoltDB) Purge(name []byte, key uint64) error {
 // private functions
 // ======================================
 
+func (b *BoltDB) options() *bolt.Options {
+	opts := *bolt.DefaultOptions
+	if b.config.ReadOnly {
+		opts.ReadOnly = true
+	}
+	return &opts
+}
+
+// view runs fn in a read-only transaction, the db file isn't swapped until fn returns
+func (b *BoltDB) view(fn func(*bolt.Tx) error) error {
+	b.fileMutex.RLock()
+	defer b.fileMutex.RUnlock()
+	return b.db.View(fn)
+}
+
+// update runs fn in a read-write transaction, which waits until the compaction in progress is done
+func (b *BoltDB) update(fn func(*bolt.Tx) error) error {
+	b.writeMutex.Lock()
+	defer b.writeMutex.Unlock()
+	b.fileMutex.RLock()
+	defer b.fileMutex.RUnlock()
+	return b.db.Update(fn)
+}
+
+// compactInto copies the records into a new db in the path, which is synced once the copy is done
+func (b *BoltDB) compactInto(ctx context.Context, path string, progress func(int64)) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(ErrIO, err.Error())
+	}
+	opts := *bolt.DefaultOptions
+	opts.NoSync = true
+	dst, err := bolt.Open(path, _fileMode, &opts)
+	if err != nil {
+		return errors.Wrap(ErrIO, err.Error())
+	}
+	err = b.view(func(tx *bolt.Tx) error {
+		return copyBolt(ctx, dst, tx, progress)
+	})
+	if err == nil {
+		err = dst.Sync()
+	}
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil && ctx.Err() == nil {
+		return errors.Wrap(ErrIO, err.Error())
+	}
+	return err
+}
+
+// swap replaces the db file with the compacted file in the path, which waits for the reads in progress until ctx is
+// done. The db is turned off if it can't be reopened
+func (b *BoltDB) swap(ctx context.Context, path string) error {
+	// the reads nested in a read are never blocked by the swap waiting for the lock
+	for !b.fileMutex.TryLock() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Millisecond):
+		}
+	}
+	defer b.fileMutex.Unlock()
+	if err := b.db.Close(); err != nil {
+		_ = b.TurnOff()
+		return errors.Wrap(ErrIO, err.Error())
+	}
+	err := os.Rename(path, b.path)
+	if err == nil {
+		err = syncDir(filepath.Dir(b.path))
+	}
+	// the db is reopened even if the compacted file isn't renamed
+	db, oerr := bolt.Open(b.path, _fileMode, b.options())
+	if oerr != nil {
+		_ = b.TurnOff()
+		return errors.Wrap(ErrIO, oerr.Error())
+	}
+	b.db = db
+	if err != nil {
+		return errors.Wrap(ErrIO, err.Error())
+	}
+	return nil
+}
+
+// copyBolt copies the buckets in the transaction into dst, the records written in a transaction of dst are limited
+// to _compactTxSize bytes, and progress is called once a transaction is committed
+func copyBolt(ctx context.Context, dst *bolt.DB, src *bolt.Tx, progress func(int64)) error {
+	tx, err := dst.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	var size, copied int64
+	if err := src.ForEach(func(name []byte, srcBucket *bolt.Bucket) error {
+		bucket, err := tx.CreateBucket(name)
+		if err != nil {
+			return err
+		}
+		if err := bucket.SetSequence(srcBucket.Sequence()); err != nil {
+			return err
+		}
+		// the keys are put in order, so the pages are filled up
+		bucket.FillPercent = 1.0
+		return srcBucket.ForEach(func(k, v []byte) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if v == nil {
+				return errors.Errorf("nested bucket %x in bucket %x isn't supported", k, name)
+			}
+			if size >= _compactTxSize {
+				if err := tx.Commit(); err != nil {
+					return err
+				}
+				copied += size
+				size = 0
+				progress(copied)
+				if tx, err = dst.Begin(true); err != nil {
+					return err
+				}
+				bucket = tx.Bucket(name)
+				bucket.FillPercent = 1.0
+			}
+			size += int64(len(k) + len(v))
+			return bucket.Put(k, v)
+		})
+	}); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	progress(copied + size)
+	return nil
+}
+
 // intentionally fail to test DB can successfully rollback
 func (b *BoltDB) batchPutForceFail(namespace string, key [][]byte, value [][]byte) error {
 	if !b.IsReady() {
 		return ErrDBNotStarted
 	}
 
-	return b.db.Update(func(tx *bolt.Tx) error {
+	return b.update(func(tx *bolt.Tx) error {
 		bucket, err := tx.CreateBucketIfNotExists([]byte(namespace))
 		if err != nil {
 			return err
@@ -80,6 +80,61 @@ func TestBucketExists(t *testing.T) {
 	r.Equal([]byte{}, v)
 }
 
+func TestBoltDBCheckpoint(t *testing.T) {
+	r := require.New(t)
+	testPath, err := testutil.PathOfTempFile("test-checkpoint")
+	r.NoError(err)
+	defer func() {
+		testutil.CleanupPath(testPath)
+	}()
+
+	cfg := DefaultConfig
+	cfg.DbPath = testPath
+	kv := NewBoltDB(cfg)
+	ctx := context.Background()
+	r.NoError(kv.Start(ctx))
+	defer kv.Stop(ctx)
+	r.NoError(kv.Put("ns1", []byte("key2"), []byte("value2")))
+	r.NoError(kv.Put("ns1", []byte("key1"), []byte("value1")))
+	r.NoError(kv.Put("ns2", []byte("key3"), []byte("value3")))
+
+	cpPath := testPath + ".checkpoint"
+	defer func() {
+		testutil.CleanupPath(cpPath)
+	}()
+	cp, err := kv.Checkpoint(cpPath)
+	r.NoError(err)
+	// writes after the checkpoint are not in the copy
+	r.NoError(kv.Put("ns2", []byte("key4"), []byte("value4")))
+	r.NoError(cp.Start(ctx))
+	names, err := cp.Buckets()
+	r.NoError(err)
+	r.Equal([]string{"ns1", "ns2"}, names)
+	var keys, values []string
+	r.NoError(cp.ForEach("ns1", func(k, v []byte) error {
+		keys = append(keys, string(k))
+		values = append(values, string(v))
+		return nil
+	}))
+	r.Equal([]string{"key1", "key2"}, keys)
+	r.Equal([]string{"value1", "value2"}, values)
+	_, err = cp.Get("ns2", []byte("key4"))
+	r.Equal(ErrNotExist, errors.Cause(err))
+	r.NoError(cp.ForEach("ns3", func(k, v []byte) error {
+		return errors.New("bucket doesn't exist")
+	}))
+	r.Error(cp.Put("ns2", []byte("key5"), []byte("value5")))
+	r.NoError(cp.Stop(ctx))
+
+	// the existing copy is reused
+	cp, err = kv.Checkpoint(cpPath)
+	r.NoError(err)
+	r.NoError(cp.Start(ctx))
+	defer cp.Stop(ctx)
+	_, err = cp.Get("ns2", []byte("key4"))
+	r.Equal(ErrNotExist, errors.Cause(err))
+}
+
 func TestDiskfullErr(t *testing.T) {
 	err := fmt.Errorf("write /run/data/chain.db: %w", syscall.ENOSPC)
 	require.True(t, errors.Is(err, syscall.ENOSPC))
@@ -8,6 +8,7 @@ package db
 import (
 	"bytes"
 	"context"
+	"os"
 	"syscall"
 
 	"github.com/cockroachdb/pebble"
@@ -19,6 +20,7 @@ import (
 	"github.com/iotexproject/iotex-core/db/batch"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 )
 
 const (
@@ -120,9 +122,11 @@ func (b *PebbleDB) Delete(ns string, key []byte) (err error) {
 		return ErrDBNotStarted
 	}
 	if key == nil {
-		panic("delete whole ns not supported by PebbleDB")
+		prefix := nsToPrefix(ns)
+		err = b.db.DeleteRange(prefix, prefixUpperBound(prefix), nil)
+	} else {
+		err = b.db.Delete(nsKey(ns, key), nil)
 	}
-	err = b.db.Delete(nsKey(ns, key), nil)
 	if err != nil {
 		if errors.Is(err, syscall.ENOSPC) {
 			log.L().Fatal("Failed to delete db.", zap.Error(err))
@@ -140,7 +144,7 @@ func (b *PebbleDB) WriteBatch(kvsb batch.KVStoreBatch) error {
 
 	batch, err := b.dedup(kvsb)
 	if err != nil {
-		return nil
+		return err
 	}
 	err = batch.Commit(nil)
 	if err != nil {
@@ -224,11 +228,38 @@ func (b *PebbleDB) Filter(ns string, cond Condition, minKey []byte, maxKey []byt
 		vals = append(vals, value)
 	}
 	if len(keys) == 0 {
+		if !b.nsExists(ns) {
+			return nil, nil, errors.Wrapf(ErrBucketNotExist, "bucket = %x doesn't exist", []byte(ns))
+		}
 		return nil, nil, errors.Wrap(ErrNotExist, "filter returns no match")
 	}
 	return
 }
 
+// Range retrieves values for a range of keys
+func (b *PebbleDB) Range(ns string, key []byte, count uint64) ([][]byte, error) {
+	if !b.IsReady() {
+		return nil, ErrDBNotStarted
+	}
+	iter, err := b.newNsIter(ns)
+	if err != nil {
+		return nil, err
+	}
+	defer closeIter(iter)
+	value := make([][]byte, count)
+	if !iter.SeekGE(nsKey(ns, key)) {
+		return nil, errors.Wrapf(ErrNotExist, "entry for key 0x%x doesn't exist", key)
+	}
+	for i := uint64(0); i < count; i++ {
+		if !iter.Valid() {
+			return nil, errors.Wrapf(ErrNotExist, "entry for key 0x%x doesn't exist", key)
+		}
+		value[i] = copyBytes(iter.Value())
+		iter.Next()
+	}
+	return value, nil
+}
+
 // ForEach iterates over all <k, v> pairs in a bucket
 func (b *PebbleDB) ForEach(ns string, fn func(k, v []byte) error) error {
 	if !b.IsReady() {
@@ -260,6 +291,310 @@ func (b *PebbleDB) ForEach(ns string, fn func(k, v []byte) error) error {
 	return nil
 }
 
+// Seek iterates over the <k, v> pairs in a bucket from the first key not less than key, until fn returns false
+func (b *PebbleDB) Seek(ns string, key []byte, fn func(k, v []byte) bool) error {
+	if !b.IsReady() {
+		return ErrDBNotStarted
+	}
+	iter, err := b.db.NewIter(&pebble.IterOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to create iterator")
+	}
+	defer func() {
+		if e := iter.Close(); e != nil {
+			log.L().Error("Failed to close iterator", zap.Error(e))
+		}
+	}()
+	for iter.SeekPrefixGE(nsKey(ns, key)); iter.Valid(); iter.Next() {
+		ck, v := iter.Key(), iter.Value()
+		k, err := decodeKey(ck)
+		if err != nil {
+			return err
+		}
+		key := make([]byte, len(k))
+		copy(key, k)
+		value := make([]byte, len(v))
+		copy(value, v)
+		if !fn(key, value) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// ======================================
+// below functions used by RangeIndex
+// ======================================
+
+// Insert inserts a value into the index
+func (b *PebbleDB) Insert(name []byte, key uint64, value []byte) error {
+	if !b.IsReady() {
+		return ErrDBNotStarted
+	}
+	ns := string(name)
+	iter, err := b.newNsIter(ns)
+	if err != nil {
+		return err
+	}
+	defer closeIter(iter)
+	ch := b.db.NewBatch()
+	ak := nsKey(ns, byteutil.Uint64ToBytesBigEndian(key-1))
+	if iter.SeekGE(ak) && bytes.Equal(iter.Key(), ak) {
+		// update an existing key
+		iter.Next()
+	} else {
+		// insert new key
+		ch.Set(ak, iterValue(iter), nil)
+	}
+	if iter.Valid() {
+		ch.Set(copyBytes(iter.Key()), value, nil)
+	}
+	return b.commit(ch, "Failed to insert db.")
+}
+
+// SeekNext returns value by the key (if key not exist, use next key)
+func (b *PebbleDB) SeekNext(name []byte, key uint64) ([]byte, error) {
+	if !b.IsReady() {
+		return nil, ErrDBNotStarted
+	}
+	ns := string(name)
+	iter, err := b.newNsIter(ns)
+	if err != nil {
+		return nil, err
+	}
+	defer closeIter(iter)
+	iter.SeekGE(nsKey(ns, byteutil.Uint64ToBytesBigEndian(key)))
+	return iterValue(iter), nil
+}
+
+// SeekPrev returns value by the key (if key not exist, use previous key)
+func (b *PebbleDB) SeekPrev(name []byte, key uint64) ([]byte, error) {
+	if !b.IsReady() {
+		return nil, ErrDBNotStarted
+	}
+	ns := string(name)
+	iter, err := b.newNsIter(ns)
+	if err != nil {
+		return nil, err
+	}
+	defer closeIter(iter)
+	iter.SeekLT(nsKey(ns, byteutil.Uint64ToBytesBigEndian(key)))
+	return iterValue(iter), nil
+}
+
+// Remove removes an existing key
+func (b *PebbleDB) Remove(name []byte, key uint64) error {
+	if !b.IsReady() {
+		return ErrDBNotStarted
+	}
+	ns := string(name)
+	iter, err := b.newNsIter(ns)
+	if err != nil {
+		return err
+	}
+	defer closeIter(iter)
+	ak := nsKey(ns, byteutil.Uint64ToBytesBigEndian(key-1))
+	if !iter.SeekGE(ak) || !bytes.Equal(iter.Key(), ak) {
+		// return nil if the key does not exist
+		return nil
+	}
+	ch := b.db.NewBatch()
+	ch.Delete(ak, nil)
+	// write the corresponding value to next key
+	v := copyBytes(iter.Value())
+	if iter.Next() {
+		ch.Set(copyBytes(iter.Key()), v, nil)
+	}
+	return b.commit(ch, "Failed to remove db.")
+}
+
+// Purge deletes an existing key and all keys before it
+func (b *PebbleDB) Purge(name []byte, key uint64) error {
+	if !b.IsReady() {
+		return ErrDBNotStarted
+	}
+	ns := string(name)
+	iter, err := b.newNsIter(ns)
+	if err != nil {
+		return err
+	}
+	defer closeIter(iter)
+	ch := b.db.NewBatch()
+	nk := nsKey(ns, byteutil.Uint64ToBytesBigEndian(key))
+	if iter.SeekGE(nk) {
+		nk = copyBytes(iter.Key())
+		// write not exist value to next key
+		ch.Set(nk, NotExist, nil)
+	}
+	// delete all keys before this key
+	if err := ch.DeleteRange(nsToPrefix(ns), nk, nil); err != nil {
+		return errors.Wrap(ErrIO, err.Error())
+	}
+	return b.commit(ch, "Failed to purge db.")
+}
+
+// GetBucketByPrefix retrieves all bucket those with const namespace prefix
+func (b *PebbleDB) GetBucketByPrefix(namespace []byte) ([][]byte, error) {
+	// the namespaces are hashed into the key prefix, so they cannot be enumerated
+	return nil, errors.New("get bucket by prefix is not supported by PebbleDB")
+}
+
+// GetKeyByPrefix retrieves all keys those with const prefix
+func (b *PebbleDB) GetKeyByPrefix(namespace, prefix []byte) ([][]byte, error) {
+	if !b.IsReady() {
+		return nil, ErrDBNotStarted
+	}
+	ns := string(namespace)
+	iter, err := b.newNsIter(ns)
+	if err != nil {
+		return nil, err
+	}
+	defer closeIter(iter)
+	allKey := make([][]byte, 0)
+	for iter.SeekGE(nsKey(ns, prefix)); iter.Valid(); iter.Next() {
+		k, err := decodeKey(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.HasPrefix(k, prefix) {
+			break
+		}
+		allKey = append(allKey, copyBytes(k))
+	}
+	return allKey, nil
+}
+
+// ======================================
+// private functions
+// ======================================
+
+// newNsIter creates an iterator bounded in the namespace, which could move in both directions
+// Backup saves a checkpoint of the db into the path, which hard-links the sstables, so the checkpoint is
+// taken quickly and began is called once it's done. The path is a directory rather than a file
+func (b *PebbleDB) Backup(path string, began func()) error {
+	if !b.IsReady() {
+		return ErrDBNotStarted
+	}
+
+	tmp := path + ".tmp"
+	if err := os.RemoveAll(tmp); err != nil {
+		return errors.Wrap(ErrIO, err.Error())
+	}
+	err := b.db.Checkpoint(tmp, pebble.WithFlushedWAL())
+	began()
+	if err != nil {
+		return errors.Wrap(ErrIO, err.Error())
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return errors.Wrap(ErrIO, err.Error())
+	}
+	return nil
+}
+
+// Compact compacts all the records in the db, and returns the number of bytes reclaimed. The compaction of pebble
+// rewrites the sstables online and installs them in the manifest atomically, so neither the reads nor the writes
+// are paused, while it can't be aborted once started. ErrInsufficientSpace is returned if the free disk space is
+// less than the size of the db
+func (b *PebbleDB) Compact(ctx context.Context, progress func(int64)) (int64, error) {
+	if !b.IsReady() {
+		return 0, ErrDBNotStarted
+	}
+	if b.config.ReadOnly {
+		return 0, errors.Wrap(ErrNotSupported, "read-only db can't be compacted")
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	// the memtables are flushed first, so the bytes reclaimed are measured in the live sstables
+	if err := b.db.Flush(); err != nil {
+		return 0, errors.Wrap(ErrIO, err.Error())
+	}
+	before := b.tableSize()
+	if err := checkFreeSpace(b.path, before); err != nil {
+		return 0, err
+	}
+	iter, err := b.db.NewIter(nil)
+	if err != nil {
+		return 0, errors.Wrap(ErrIO, err.Error())
+	}
+	var start, end []byte
+	if iter.First() {
+		start = bytes.Clone(iter.Key())
+	}
+	if iter.Last() {
+		end = append(bytes.Clone(iter.Key()), 0)
+	}
+	closeIter(iter)
+	if start != nil {
+		if err := b.db.Compact(start, end, true); err != nil {
+			return 0, errors.Wrap(ErrIO, err.Error())
+		}
+	}
+	progress(before)
+	return before - b.tableSize(), nil
+}
+
+// tableSize returns the size of the live sstables
+func (b *PebbleDB) tableSize() int64 {
+	var size int64
+	for _, level := range b.db.Metrics().Levels {
+		size += level.Size
+	}
+	return size
+}
+
+func (b *PebbleDB) newNsIter(ns string) (*pebble.Iterator, error) {
+	prefix := nsToPrefix(ns)
+	iter, err := b.db.NewIter(&pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: prefixUpperBound(prefix),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create iterator")
+	}
+	return iter, nil
+}
+
+func (b *PebbleDB) nsExists(ns string) bool {
+	iter, err := b.newNsIter(ns)
+	if err != nil {
+		return false
+	}
+	defer closeIter(iter)
+	return iter.First()
+}
+
+func (b *PebbleDB) commit(ch *pebble.Batch, msg string) error {
+	if err := ch.Commit(nil); err != nil {
+		if errors.Is(err, syscall.ENOSPC) {
+			log.L().Fatal(msg, zap.Error(err))
+		}
+		return errors.Wrap(ErrIO, err.Error())
+	}
+	return nil
+}
+
+func closeIter(iter *pebble.Iterator) {
+	if e := iter.Close(); e != nil {
+		log.L().Error("Failed to close iterator", zap.Error(e))
+	}
+}
+
+// iterValue returns a copy of the current value, empty if the iterator is exhausted
+func iterValue(iter *pebble.Iterator) []byte {
+	if !iter.Valid() {
+		return []byte{}
+	}
+	return copyBytes(iter.Value())
+}
+
+func copyBytes(b []byte) []byte {
+	c := make([]byte, len(b))
+	copy(c, b)
+	return c
+}
+
 func nsKey(ns string, key []byte) []byte {
 	nk := nsToPrefix(ns)
 	return append(nk, key...)
@@ -270,6 +605,19 @@ func nsToPrefix(ns string) []byte {
 	return h[:prefixLength]
 }
 
+// prefixUpperBound returns the smallest key greater than all the keys with the prefix, nil if there is none
+func prefixUpperBound(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
 func decodeKey(k []byte) (key []byte, err error) {
 	if len(k) < prefixLength {
 		return nil, errors.New("key is too short")
@@ -166,11 +166,11 @@ func TestPebbleDB_Filter(t *testing.T) {
 	r.EqualValues([][]byte{_k2, _k3}, ks)
 	r.EqualValues([][]byte{_v2, _v3}, vs)
 	ks, vs, err = db.Filter(ns0, func(k, v []byte) bool { return true }, nil, nil)
-	r.ErrorIs(err, ErrNotExist)
+	r.ErrorIs(err, ErrBucketNotExist)
 	r.Len(ks, 0)
 	r.Len(vs, 0)
 	ks, vs, err = db.Filter(ns3, func(k, v []byte) bool { return true }, nil, nil)
-	r.ErrorIs(err, ErrNotExist)
+	r.ErrorIs(err, ErrBucketNotExist)
 	r.Len(ks, 0)
 	r.Len(vs, 0)
 	ks, vs, err = db.Filter(ns1, func(k, v []byte) bool { return true }, _k2, nil)
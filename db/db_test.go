@@ -8,8 +8,12 @@ package db
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/agiledragon/gomonkey/v2"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -60,9 +64,13 @@ func TestKVStorePutGet(t *testing.T) {
 	cfg := DefaultConfig
 	cfg.DbPath = testPath
 
+	pebbleCfg := cfg
+	pebbleCfg.DbPath = t.TempDir()
+
 	for _, v := range []KVStore{
 		NewMemKVStore(),
 		NewBoltDB(cfg),
+		NewPebbleDB(pebbleCfg),
 	} {
 		t.Run("test put get", func(t *testing.T) {
 			testKVStorePutGet(v, t)
@@ -240,9 +248,13 @@ func TestDBBatch(t *testing.T) {
 	cfg := DefaultConfig
 	cfg.DbPath = testPath
 
+	pebbleCfg := cfg
+	pebbleCfg.DbPath = t.TempDir()
+
 	for _, v := range []KVStore{
 		NewMemKVStore(),
 		NewBoltDB(cfg),
+		NewPebbleDB(pebbleCfg),
 	} {
 		t.Run("test batch", func(t *testing.T) {
 			testBatchRollback(v, t)
@@ -292,9 +304,13 @@ func TestCacheKV(t *testing.T) {
 	cfg := DefaultConfig
 	cfg.DbPath = testPath
 
+	pebbleCfg := cfg
+	pebbleCfg.DbPath = t.TempDir()
+
 	for _, v := range []KVStore{
 		NewMemKVStore(),
 		NewBoltDB(cfg),
+		NewPebbleDB(pebbleCfg),
 	} {
 		t.Run("test cache kv", func(t *testing.T) {
 			testFunc(v, t)
@@ -340,6 +356,10 @@ func TestDeleteBucket(t *testing.T) {
 	t.Run("test delete bucket", func(t *testing.T) {
 		testFunc(NewBoltDB(cfg), t)
 	})
+	cfg.DbPath = t.TempDir()
+	t.Run("test delete bucket of pebble db", func(t *testing.T) {
+		testFunc(NewPebbleDB(cfg), t)
+	})
 }
 
 func TestFilter(t *testing.T) {
@@ -441,6 +461,271 @@ func TestFilter(t *testing.T) {
 	t.Run("test filter", func(t *testing.T) {
 		testFunc(NewBoltDB(cfg), t)
 	})
+	cfg.DbPath = t.TempDir()
+	t.Run("test filter of pebble db", func(t *testing.T) {
+		testFunc(NewPebbleDB(cfg), t)
+	})
+}
+
+func TestSeek(t *testing.T) {
+	require := require.New(t)
+
+	testFunc := func(kv KVStoreWithSeek, t *testing.T) {
+		require.NoError(kv.Start(context.Background()))
+		defer func() {
+			require.NoError(kv.Stop(context.Background()))
+		}()
+
+		b := batch.NewBatch()
+		for i := 0; i < 3; i++ {
+			b.Put(_bucket1, _testK1[i], _testV1[i], "")
+			b.Put(_bucket2, _testK2[i], _testV2[i], "")
+		}
+		require.NoError(kv.WriteBatch(b))
+
+		seek := func(ns string, key []byte, limit int) ([][]byte, [][]byte) {
+			var keys, values [][]byte
+			require.NoError(kv.Seek(ns, key, func(k, v []byte) bool {
+				keys = append(keys, k)
+				values = append(values, v)
+				return len(keys) < limit
+			}))
+			return keys, values
+		}
+		keys, values := seek(_bucket1, nil, 10)
+		require.Equal(_testK1[:], keys)
+		require.Equal(_testV1[:], values)
+		keys, values = seek(_bucket1, []byte("key_15"), 10)
+		require.Equal(_testK1[1:], keys)
+		require.Equal(_testV1[1:], values)
+		keys, values = seek(_bucket2, _testK2[1], 1)
+		require.Equal(_testK2[1:2], keys)
+		require.Equal(_testV2[1:2], values)
+		keys, _ = seek(_bucket1, []byte("key_4"), 10)
+		require.Empty(keys)
+		keys, _ = seek("nonamespace", nil, 10)
+		require.Empty(keys)
+	}
+
+	testPath, err := testutil.PathOfTempFile("test-seek.bolt")
+	require.NoError(err)
+	defer testutil.CleanupPath(testPath)
+	cfg := DefaultConfig
+	cfg.DbPath = testPath
+	t.Run("bolt db", func(t *testing.T) {
+		testFunc(NewBoltDB(cfg), t)
+	})
+
+	testPath, err = os.MkdirTemp("", "test-seek.pebble")
+	require.NoError(err)
+	defer testutil.CleanupPath(testPath)
+	cfg.DbPath = testPath
+	t.Run("pebble db", func(t *testing.T) {
+		testFunc(NewPebbleDB(cfg), t)
+	})
+}
+
+func TestSeekPrevAndKeyByPrefix(t *testing.T) {
+	require := require.New(t)
+
+	testFunc := func(kv KVStoreForRangeIndex, t *testing.T) {
+		require.NoError(kv.Start(context.Background()))
+		defer func() {
+			require.NoError(kv.Stop(context.Background()))
+		}()
+
+		b := batch.NewBatch()
+		for i := uint64(1); i <= 3; i++ {
+			b.Put(_bucket1, byteutil.Uint64ToBytesBigEndian(i*10), _testV1[i-1], "")
+		}
+		for i := 0; i < 3; i++ {
+			b.Put(_bucket2, _testK2[i], _testV2[i], "")
+		}
+		require.NoError(kv.WriteBatch(b))
+
+		v, err := kv.SeekPrev([]byte(_bucket1), 20)
+		require.NoError(err)
+		require.Equal(_testV1[0], v)
+		v, err = kv.SeekPrev([]byte(_bucket1), 25)
+		require.NoError(err)
+		require.Equal(_testV1[1], v)
+		v, err = kv.SeekPrev([]byte(_bucket1), 100)
+		require.NoError(err)
+		require.Equal(_testV1[2], v)
+		v, err = kv.SeekPrev([]byte(_bucket1), 10)
+		require.NoError(err)
+		require.Empty(v)
+
+		keys, err := kv.GetKeyByPrefix([]byte(_bucket2), []byte("key_"))
+		require.NoError(err)
+		require.Equal(_testK2[:], keys)
+		keys, err = kv.GetKeyByPrefix([]byte(_bucket2), []byte("key_5"))
+		require.NoError(err)
+		require.Equal(_testK2[1:2], keys)
+		keys, err = kv.GetKeyByPrefix([]byte(_bucket2), []byte("value"))
+		require.NoError(err)
+		require.Empty(keys)
+	}
+
+	testPath, err := testutil.PathOfTempFile("test-seek-prev.bolt")
+	require.NoError(err)
+	defer testutil.CleanupPath(testPath)
+	cfg := DefaultConfig
+	cfg.DbPath = testPath
+	t.Run("bolt db", func(t *testing.T) {
+		testFunc(NewBoltDB(cfg), t)
+	})
+	cfg.DbPath = t.TempDir()
+	t.Run("pebble db", func(t *testing.T) {
+		testFunc(NewPebbleDB(cfg), t)
+	})
+}
+
+func TestBackup(t *testing.T) {
+	require := require.New(t)
+
+	testFunc := func(kv KVStoreWithBackup, open func(string) KVStore, t *testing.T) {
+		require.NoError(kv.Start(context.Background()))
+		defer func() {
+			require.NoError(kv.Stop(context.Background()))
+		}()
+
+		require.NoError(kv.Put(_bucket1, _testK1[0], _testV1[0]))
+		path := filepath.Join(t.TempDir(), "backup")
+		// writes after the backup begins are not in the copy
+		written := make(chan error, 1)
+		require.NoError(kv.Backup(path, func() {
+			go func() {
+				written <- kv.Put(_bucket1, _testK1[1], _testV1[1])
+			}()
+		}))
+		require.NoError(<-written)
+		_, err := os.Stat(path + ".tmp")
+		require.True(os.IsNotExist(err))
+
+		cp := open(path)
+		require.NoError(cp.Start(context.Background()))
+		defer func() {
+			require.NoError(cp.Stop(context.Background()))
+		}()
+		v, err := cp.Get(_bucket1, _testK1[0])
+		require.NoError(err)
+		require.Equal(_testV1[0], v)
+		_, err = cp.Get(_bucket1, _testK1[1])
+		require.Equal(ErrNotExist, errors.Cause(err))
+	}
+
+	cfg := DefaultConfig
+	cfg.DbPath = filepath.Join(t.TempDir(), "test-backup.bolt")
+	t.Run("bolt db", func(t *testing.T) {
+		testFunc(NewBoltDB(cfg), func(path string) KVStore {
+			cfg := cfg
+			cfg.DbPath = path
+			return NewBoltDB(cfg)
+		}, t)
+	})
+	cfg.DbPath = t.TempDir()
+	t.Run("pebble db", func(t *testing.T) {
+		testFunc(NewPebbleDB(cfg), func(path string) KVStore {
+			cfg := cfg
+			cfg.DbPath = path
+			return NewPebbleDB(cfg)
+		}, t)
+	})
+	t.Run("not supported", func(t *testing.T) {
+		kv := NewKvStoreWithCache(NewMemKVStore(), 8).(KVStoreWithBackup)
+		require.Equal(ErrNotSupported, errors.Cause(kv.Backup(cfg.DbPath, func() {})))
+	})
+}
+
+func TestCompact(t *testing.T) {
+	require := require.New(t)
+
+	// the records deleted may have been compacted by pebble in background
+	testFunc := func(kv KVStoreWithCompaction, reclaimable bool, t *testing.T) {
+		ctx := context.Background()
+		require.NoError(kv.Start(ctx))
+		defer func() {
+			require.NoError(kv.Stop(ctx))
+		}()
+
+		// the values are random, so they're not compressed
+		value := make([]byte, 1024)
+		_, err := rand.Read(value)
+		require.NoError(err)
+		b := batch.NewBatch()
+		for i := uint64(0); i < 2000; i++ {
+			b.Put(_bucket1, byteutil.Uint64ToBytesBigEndian(i), value, "")
+		}
+		require.NoError(kv.WriteBatch(b))
+		b = batch.NewBatch()
+		for i := uint64(10); i < 2000; i++ {
+			b.Delete(_bucket1, byteutil.Uint64ToBytesBigEndian(i), "")
+		}
+		require.NoError(kv.WriteBatch(b))
+
+		// a write during the compaction isn't lost
+		var (
+			copied    int64
+			reclaimed int64
+			written   = make(chan error, 1)
+		)
+		reclaimed, err = kv.Compact(ctx, func(n int64) {
+			copied = n
+			go func() {
+				written <- kv.Put(_bucket2, _testK2[0], _testV2[0])
+			}()
+		})
+		require.NoError(err)
+		require.NoError(<-written)
+		if reclaimable {
+			require.Positive(reclaimed)
+		}
+		require.Positive(copied)
+		for i := uint64(0); i < 10; i++ {
+			v, err := kv.Get(_bucket1, byteutil.Uint64ToBytesBigEndian(i))
+			require.NoError(err)
+			require.Equal(value, v)
+		}
+		_, err = kv.Get(_bucket1, byteutil.Uint64ToBytesBigEndian(10))
+		require.Equal(ErrNotExist, errors.Cause(err))
+		v, err := kv.Get(_bucket2, _testK2[0])
+		require.NoError(err)
+		require.Equal(_testV2[0], v)
+		require.NoError(kv.Put(_bucket1, _testK1[0], _testV1[0]))
+
+		// the compaction is skipped without enough free space for the copy
+		p := gomonkey.NewPatches()
+		p.ApplyFuncReturn(freeSpace, uint64(0), nil)
+		_, err = kv.Compact(ctx, func(int64) {})
+		p.Reset()
+		require.Equal(ErrInsufficientSpace, errors.Cause(err))
+
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err = kv.Compact(canceled, func(int64) {})
+		require.Equal(context.Canceled, errors.Cause(err))
+		v, err = kv.Get(_bucket1, _testK1[0])
+		require.NoError(err)
+		require.Equal(_testV1[0], v)
+	}
+
+	cfg := DefaultConfig
+	cfg.DbPath = filepath.Join(t.TempDir(), "test-compact.bolt")
+	t.Run("bolt db", func(t *testing.T) {
+		testFunc(NewBoltDB(cfg), true, t)
+		_, err := os.Stat(cfg.DbPath + ".compact")
+		require.True(os.IsNotExist(err))
+	})
+	cfg.DbPath = t.TempDir()
+	t.Run("pebble db", func(t *testing.T) {
+		testFunc(NewPebbleDB(cfg), false, t)
+	})
+	t.Run("not supported", func(t *testing.T) {
+		kv := NewKvStoreWithCache(NewMemKVStore(), 8).(KVStoreWithCompaction)
+		_, err := kv.Compact(context.Background(), func(int64) {})
+		require.Equal(ErrNotSupported, errors.Cause(err))
+	})
 }
 
 func TestCreateKVStore(t *testing.T) {
@@ -6,6 +6,8 @@
 package db
 
 import (
+	"context"
+
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 
 	"github.com/iotexproject/iotex-core/db/batch"
@@ -43,6 +45,46 @@ type (
 		Range(string, []byte, uint64) ([][]byte, error)
 	}
 
+	// KVStoreWithCheckpoint is KVStore which could save a copy of all the records at a point in time
+	KVStoreWithCheckpoint interface {
+		KVStore
+		// Checkpoint saves a copy of all the records into the path, and returns the copy
+		Checkpoint(string) (KVStoreWithBuckets, error)
+	}
+
+	// KVStoreWithBackup is KVStore which could be backed up online
+	KVStoreWithBackup interface {
+		KVStore
+		// Backup copies all the records at the time the backup begins into the path, and calls the function
+		// once the records to copy are fixed, so the writes only need to be paused until then
+		Backup(string, func()) error
+	}
+
+	// KVStoreWithCompaction is KVStore which could be compacted online
+	KVStoreWithCompaction interface {
+		KVStore
+		// Compact compacts the records until the context is done, calls the function with the bytes compacted
+		// so far, and returns the bytes reclaimed
+		Compact(context.Context, func(int64)) (int64, error)
+	}
+
+	// KVStoreWithBuckets is KVStore which could walk through all the buckets
+	KVStoreWithBuckets interface {
+		KVStore
+		// Buckets returns the names of all the buckets
+		Buckets() ([]string, error)
+		// ForEach calls the function for each record in a bucket in the order of keys
+		ForEach(string, func([]byte, []byte) error) error
+	}
+
+	// KVStoreWithSeek is KVStore which could walk through the records in a bucket from a key
+	KVStoreWithSeek interface {
+		KVStore
+		// Seek calls the function for each record in a bucket from the first key not less than the given key in
+		// the order of keys, until the function returns false
+		Seek(string, []byte, func([]byte, []byte) bool) error
+	}
+
 	// KVStoreForRangeIndex is KVStore for range index
 	KVStoreForRangeIndex interface {
 		KVStore
@@ -268,8 +268,7 @@ func (kvb *kvStoreWithBuffer) WriteBatch(b batch.KVStoreBatch) (err error) {
 		}
 		writes[i] = write
 	}
-	kvb.buffer.Lock()
-	defer kvb.buffer.Unlock()
+	// Put and Delete of the buffer lock it on their own
 	for _, write := range writes {
 		switch write.WriteType() {
 		case batch.Put:
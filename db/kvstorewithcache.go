@@ -102,6 +102,24 @@ func (kvc *kvStoreWithCache) WriteBatch(kvsb batch.KVStoreBatch) (err error) {
 	return nil
 }
 
+// Backup backs up the wrapped kvstore, the caches hold no record which isn't written into it
+func (kvc *kvStoreWithCache) Backup(path string, began func()) error {
+	store, ok := kvc.store.(KVStoreWithBackup)
+	if !ok {
+		return ErrNotSupported
+	}
+	return store.Backup(path, began)
+}
+
+// Compact compacts the wrapped kvstore, the records cached are kept intact
+func (kvc *kvStoreWithCache) Compact(ctx context.Context, progress func(int64)) (int64, error) {
+	store, ok := kvc.store.(KVStoreWithCompaction)
+	if !ok {
+		return 0, ErrNotSupported
+	}
+	return store.Compact(ctx, progress)
+}
+
 // ======================================
 // private functions
 // ======================================
@@ -0,0 +1,53 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/db/batch"
+)
+
+// CopyKVStore copies all the records in src into dst, by batches of at most batchSize records. It is used
+// to migrate a db into another type, e.g., from bolt to pebble. The progress function, if not nil, is
+// called with the name of the bucket and the number of records copied so far after each batch
+func CopyKVStore(src KVStoreWithBuckets, dst KVStore, batchSize int, progress func(string, uint64)) error {
+	if batchSize <= 0 {
+		return errors.Wrap(ErrInvalid, "batch size should be positive")
+	}
+	buckets, err := src.Buckets()
+	if err != nil {
+		return err
+	}
+	var (
+		b     = batch.NewBatch()
+		count uint64
+	)
+	for _, ns := range buckets {
+		errMsg := "failed to copy record in bucket " + ns
+		if err := src.ForEach(ns, func(k, v []byte) error {
+			b.Put(ns, k, v, errMsg)
+			count++
+			if b.Size() < batchSize {
+				return nil
+			}
+			if err := dst.WriteBatch(b); err != nil {
+				return err
+			}
+			b.Clear()
+			if progress != nil {
+				progress(ns, count)
+			}
+			return nil
+		}); err != nil {
+			return errors.Wrapf(err, "failed to copy bucket %s", ns)
+		}
+	}
+	if b.Size() == 0 {
+		return nil
+	}
+	return dst.WriteBatch(b)
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestCopyKVStore(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	testPath, err := testutil.PathOfTempFile("test-copy.bolt")
+	require.NoError(err)
+	defer testutil.CleanupPath(testPath)
+	cfg := DefaultConfig
+	cfg.DbPath = testPath
+	src := NewBoltDB(cfg)
+	require.NoError(src.Start(ctx))
+	defer func() {
+		require.NoError(src.Stop(ctx))
+	}()
+	b := batch.NewBatch()
+	for i := 0; i < 3; i++ {
+		b.Put(_bucket1, _testK1[i], _testV1[i], "")
+		b.Put(_bucket2, _testK2[i], _testV2[i], "")
+	}
+	require.NoError(src.WriteBatch(b))
+	index, err := NewRangeIndex(src, []byte("range"), NotExist)
+	require.NoError(err)
+	require.NoError(index.Insert(7, []byte("seven")))
+
+	cfg.DbPath = t.TempDir()
+	dst := NewPebbleDB(cfg)
+	require.NoError(dst.Start(ctx))
+	defer func() {
+		require.NoError(dst.Stop(ctx))
+	}()
+	require.Equal(ErrInvalid, errors.Cause(CopyKVStore(src, dst, 0, nil)))
+	var copied uint64
+	require.NoError(CopyKVStore(src, dst, 2, func(_ string, count uint64) {
+		require.Greater(count, copied)
+		copied = count
+	}))
+	require.EqualValues(8, copied)
+	for i := 0; i < 3; i++ {
+		v, err := dst.Get(_bucket1, _testK1[i])
+		require.NoError(err)
+		require.Equal(_testV1[i], v)
+		v, err = dst.Get(_bucket2, _testK2[i])
+		require.NoError(err)
+		require.Equal(_testV2[i], v)
+	}
+	index, err = NewRangeIndex(dst, []byte("range"), NotExist)
+	require.NoError(err)
+	v, err := index.Get(5)
+	require.NoError(err)
+	require.Equal(NotExist, v)
+	v, err = index.Get(7)
+	require.NoError(err)
+	require.Equal([]byte("seven"), v)
+}
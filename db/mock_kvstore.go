@@ -369,6 +369,713 @@ func (mr *MockKVStoreWithRangeMockRecorder) WriteBatch(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteBatch", reflect.TypeOf((*MockKVStoreWithRange)(nil).WriteBatch), arg0)
 }
 
+// MockKVStoreWithCheckpoint is a mock of KVStoreWithCheckpoint interface.
+type MockKVStoreWithCheckpoint struct {
+	ctrl     *gomock.Controller
+	recorder *MockKVStoreWithCheckpointMockRecorder
+}
+
+// MockKVStoreWithCheckpointMockRecorder is the mock recorder for MockKVStoreWithCheckpoint.
+type MockKVStoreWithCheckpointMockRecorder struct {
+	mock *MockKVStoreWithCheckpoint
+}
+
+// NewMockKVStoreWithCheckpoint creates a new mock instance.
+func NewMockKVStoreWithCheckpoint(ctrl *gomock.Controller) *MockKVStoreWithCheckpoint {
+	mock := &MockKVStoreWithCheckpoint{ctrl: ctrl}
+	mock.recorder = &MockKVStoreWithCheckpointMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKVStoreWithCheckpoint) EXPECT() *MockKVStoreWithCheckpointMockRecorder {
+	return m.recorder
+}
+
+// Checkpoint mocks base method.
+func (m *MockKVStoreWithCheckpoint) Checkpoint(arg0 string) (KVStoreWithBuckets, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkpoint", arg0)
+	ret0, _ := ret[0].(KVStoreWithBuckets)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Checkpoint indicates an expected call of Checkpoint.
+func (mr *MockKVStoreWithCheckpointMockRecorder) Checkpoint(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkpoint", reflect.TypeOf((*MockKVStoreWithCheckpoint)(nil).Checkpoint), arg0)
+}
+
+// Delete mocks base method.
+func (m *MockKVStoreWithCheckpoint) Delete(arg0 string, arg1 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockKVStoreWithCheckpointMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockKVStoreWithCheckpoint)(nil).Delete), arg0, arg1)
+}
+
+// Filter mocks base method.
+func (m *MockKVStoreWithCheckpoint) Filter(arg0 string, arg1 Condition, arg2, arg3 []byte) ([][]byte, [][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Filter", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].([][]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Filter indicates an expected call of Filter.
+func (mr *MockKVStoreWithCheckpointMockRecorder) Filter(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Filter", reflect.TypeOf((*MockKVStoreWithCheckpoint)(nil).Filter), arg0, arg1, arg2, arg3)
+}
+
+// Get mocks base method.
+func (m *MockKVStoreWithCheckpoint) Get(arg0 string, arg1 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockKVStoreWithCheckpointMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockKVStoreWithCheckpoint)(nil).Get), arg0, arg1)
+}
+
+// Put mocks base method.
+func (m *MockKVStoreWithCheckpoint) Put(arg0 string, arg1, arg2 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockKVStoreWithCheckpointMockRecorder) Put(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockKVStoreWithCheckpoint)(nil).Put), arg0, arg1, arg2)
+}
+
+// Start mocks base method.
+func (m *MockKVStoreWithCheckpoint) Start(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockKVStoreWithCheckpointMockRecorder) Start(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockKVStoreWithCheckpoint)(nil).Start), arg0)
+}
+
+// Stop mocks base method.
+func (m *MockKVStoreWithCheckpoint) Stop(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockKVStoreWithCheckpointMockRecorder) Stop(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockKVStoreWithCheckpoint)(nil).Stop), arg0)
+}
+
+// WriteBatch mocks base method.
+func (m *MockKVStoreWithCheckpoint) WriteBatch(arg0 batch.KVStoreBatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteBatch", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteBatch indicates an expected call of WriteBatch.
+func (mr *MockKVStoreWithCheckpointMockRecorder) WriteBatch(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteBatch", reflect.TypeOf((*MockKVStoreWithCheckpoint)(nil).WriteBatch), arg0)
+}
+
+// MockKVStoreWithBackup is a mock of KVStoreWithBackup interface.
+type MockKVStoreWithBackup struct {
+	ctrl     *gomock.Controller
+	recorder *MockKVStoreWithBackupMockRecorder
+}
+
+// MockKVStoreWithBackupMockRecorder is the mock recorder for MockKVStoreWithBackup.
+type MockKVStoreWithBackupMockRecorder struct {
+	mock *MockKVStoreWithBackup
+}
+
+// NewMockKVStoreWithBackup creates a new mock instance.
+func NewMockKVStoreWithBackup(ctrl *gomock.Controller) *MockKVStoreWithBackup {
+	mock := &MockKVStoreWithBackup{ctrl: ctrl}
+	mock.recorder = &MockKVStoreWithBackupMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKVStoreWithBackup) EXPECT() *MockKVStoreWithBackupMockRecorder {
+	return m.recorder
+}
+
+// Backup mocks base method.
+func (m *MockKVStoreWithBackup) Backup(arg0 string, arg1 func()) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Backup indicates an expected call of Backup.
+func (mr *MockKVStoreWithBackupMockRecorder) Backup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backup", reflect.TypeOf((*MockKVStoreWithBackup)(nil).Backup), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockKVStoreWithBackup) Delete(arg0 string, arg1 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockKVStoreWithBackupMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockKVStoreWithBackup)(nil).Delete), arg0, arg1)
+}
+
+// Filter mocks base method.
+func (m *MockKVStoreWithBackup) Filter(arg0 string, arg1 Condition, arg2, arg3 []byte) ([][]byte, [][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Filter", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].([][]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Filter indicates an expected call of Filter.
+func (mr *MockKVStoreWithBackupMockRecorder) Filter(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Filter", reflect.TypeOf((*MockKVStoreWithBackup)(nil).Filter), arg0, arg1, arg2, arg3)
+}
+
+// Get mocks base method.
+func (m *MockKVStoreWithBackup) Get(arg0 string, arg1 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockKVStoreWithBackupMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockKVStoreWithBackup)(nil).Get), arg0, arg1)
+}
+
+// Put mocks base method.
+func (m *MockKVStoreWithBackup) Put(arg0 string, arg1, arg2 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockKVStoreWithBackupMockRecorder) Put(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockKVStoreWithBackup)(nil).Put), arg0, arg1, arg2)
+}
+
+// Start mocks base method.
+func (m *MockKVStoreWithBackup) Start(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockKVStoreWithBackupMockRecorder) Start(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockKVStoreWithBackup)(nil).Start), arg0)
+}
+
+// Stop mocks base method.
+func (m *MockKVStoreWithBackup) Stop(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockKVStoreWithBackupMockRecorder) Stop(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockKVStoreWithBackup)(nil).Stop), arg0)
+}
+
+// WriteBatch mocks base method.
+func (m *MockKVStoreWithBackup) WriteBatch(arg0 batch.KVStoreBatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteBatch", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteBatch indicates an expected call of WriteBatch.
+func (mr *MockKVStoreWithBackupMockRecorder) WriteBatch(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteBatch", reflect.TypeOf((*MockKVStoreWithBackup)(nil).WriteBatch), arg0)
+}
+
+// MockKVStoreWithCompaction is a mock of KVStoreWithCompaction interface.
+type MockKVStoreWithCompaction struct {
+	ctrl     *gomock.Controller
+	recorder *MockKVStoreWithCompactionMockRecorder
+}
+
+// MockKVStoreWithCompactionMockRecorder is the mock recorder for MockKVStoreWithCompaction.
+type MockKVStoreWithCompactionMockRecorder struct {
+	mock *MockKVStoreWithCompaction
+}
+
+// NewMockKVStoreWithCompaction creates a new mock instance.
+func NewMockKVStoreWithCompaction(ctrl *gomock.Controller) *MockKVStoreWithCompaction {
+	mock := &MockKVStoreWithCompaction{ctrl: ctrl}
+	mock.recorder = &MockKVStoreWithCompactionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKVStoreWithCompaction) EXPECT() *MockKVStoreWithCompactionMockRecorder {
+	return m.recorder
+}
+
+// Compact mocks base method.
+func (m *MockKVStoreWithCompaction) Compact(arg0 context.Context, arg1 func(int64)) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compact", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Compact indicates an expected call of Compact.
+func (mr *MockKVStoreWithCompactionMockRecorder) Compact(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compact", reflect.TypeOf((*MockKVStoreWithCompaction)(nil).Compact), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockKVStoreWithCompaction) Delete(arg0 string, arg1 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockKVStoreWithCompactionMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockKVStoreWithCompaction)(nil).Delete), arg0, arg1)
+}
+
+// Filter mocks base method.
+func (m *MockKVStoreWithCompaction) Filter(arg0 string, arg1 Condition, arg2, arg3 []byte) ([][]byte, [][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Filter", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].([][]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Filter indicates an expected call of Filter.
+func (mr *MockKVStoreWithCompactionMockRecorder) Filter(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Filter", reflect.TypeOf((*MockKVStoreWithCompaction)(nil).Filter), arg0, arg1, arg2, arg3)
+}
+
+// Get mocks base method.
+func (m *MockKVStoreWithCompaction) Get(arg0 string, arg1 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockKVStoreWithCompactionMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockKVStoreWithCompaction)(nil).Get), arg0, arg1)
+}
+
+// Put mocks base method.
+func (m *MockKVStoreWithCompaction) Put(arg0 string, arg1, arg2 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockKVStoreWithCompactionMockRecorder) Put(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockKVStoreWithCompaction)(nil).Put), arg0, arg1, arg2)
+}
+
+// Start mocks base method.
+func (m *MockKVStoreWithCompaction) Start(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockKVStoreWithCompactionMockRecorder) Start(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockKVStoreWithCompaction)(nil).Start), arg0)
+}
+
+// Stop mocks base method.
+func (m *MockKVStoreWithCompaction) Stop(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockKVStoreWithCompactionMockRecorder) Stop(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockKVStoreWithCompaction)(nil).Stop), arg0)
+}
+
+// WriteBatch mocks base method.
+func (m *MockKVStoreWithCompaction) WriteBatch(arg0 batch.KVStoreBatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteBatch", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteBatch indicates an expected call of WriteBatch.
+func (mr *MockKVStoreWithCompactionMockRecorder) WriteBatch(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteBatch", reflect.TypeOf((*MockKVStoreWithCompaction)(nil).WriteBatch), arg0)
+}
+
+// MockKVStoreWithBuckets is a mock of KVStoreWithBuckets interface.
+type MockKVStoreWithBuckets struct {
+	ctrl     *gomock.Controller
+	recorder *MockKVStoreWithBucketsMockRecorder
+}
+
+// MockKVStoreWithBucketsMockRecorder is the mock recorder for MockKVStoreWithBuckets.
+type MockKVStoreWithBucketsMockRecorder struct {
+	mock *MockKVStoreWithBuckets
+}
+
+// NewMockKVStoreWithBuckets creates a new mock instance.
+func NewMockKVStoreWithBuckets(ctrl *gomock.Controller) *MockKVStoreWithBuckets {
+	mock := &MockKVStoreWithBuckets{ctrl: ctrl}
+	mock.recorder = &MockKVStoreWithBucketsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKVStoreWithBuckets) EXPECT() *MockKVStoreWithBucketsMockRecorder {
+	return m.recorder
+}
+
+// Buckets mocks base method.
+func (m *MockKVStoreWithBuckets) Buckets() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Buckets")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Buckets indicates an expected call of Buckets.
+func (mr *MockKVStoreWithBucketsMockRecorder) Buckets() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Buckets", reflect.TypeOf((*MockKVStoreWithBuckets)(nil).Buckets))
+}
+
+// Delete mocks base method.
+func (m *MockKVStoreWithBuckets) Delete(arg0 string, arg1 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockKVStoreWithBucketsMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockKVStoreWithBuckets)(nil).Delete), arg0, arg1)
+}
+
+// Filter mocks base method.
+func (m *MockKVStoreWithBuckets) Filter(arg0 string, arg1 Condition, arg2, arg3 []byte) ([][]byte, [][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Filter", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].([][]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Filter indicates an expected call of Filter.
+func (mr *MockKVStoreWithBucketsMockRecorder) Filter(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Filter", reflect.TypeOf((*MockKVStoreWithBuckets)(nil).Filter), arg0, arg1, arg2, arg3)
+}
+
+// ForEach mocks base method.
+func (m *MockKVStoreWithBuckets) ForEach(arg0 string, arg1 func([]byte, []byte) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForEach", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForEach indicates an expected call of ForEach.
+func (mr *MockKVStoreWithBucketsMockRecorder) ForEach(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForEach", reflect.TypeOf((*MockKVStoreWithBuckets)(nil).ForEach), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockKVStoreWithBuckets) Get(arg0 string, arg1 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockKVStoreWithBucketsMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockKVStoreWithBuckets)(nil).Get), arg0, arg1)
+}
+
+// Put mocks base method.
+func (m *MockKVStoreWithBuckets) Put(arg0 string, arg1, arg2 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockKVStoreWithBucketsMockRecorder) Put(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockKVStoreWithBuckets)(nil).Put), arg0, arg1, arg2)
+}
+
+// Start mocks base method.
+func (m *MockKVStoreWithBuckets) Start(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockKVStoreWithBucketsMockRecorder) Start(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockKVStoreWithBuckets)(nil).Start), arg0)
+}
+
+// Stop mocks base method.
+func (m *MockKVStoreWithBuckets) Stop(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockKVStoreWithBucketsMockRecorder) Stop(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockKVStoreWithBuckets)(nil).Stop), arg0)
+}
+
+// WriteBatch mocks base method.
+func (m *MockKVStoreWithBuckets) WriteBatch(arg0 batch.KVStoreBatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteBatch", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteBatch indicates an expected call of WriteBatch.
+func (mr *MockKVStoreWithBucketsMockRecorder) WriteBatch(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteBatch", reflect.TypeOf((*MockKVStoreWithBuckets)(nil).WriteBatch), arg0)
+}
+
+// MockKVStoreWithSeek is a mock of KVStoreWithSeek interface.
+type MockKVStoreWithSeek struct {
+	ctrl     *gomock.Controller
+	recorder *MockKVStoreWithSeekMockRecorder
+}
+
+// MockKVStoreWithSeekMockRecorder is the mock recorder for MockKVStoreWithSeek.
+type MockKVStoreWithSeekMockRecorder struct {
+	mock *MockKVStoreWithSeek
+}
+
+// NewMockKVStoreWithSeek creates a new mock instance.
+func NewMockKVStoreWithSeek(ctrl *gomock.Controller) *MockKVStoreWithSeek {
+	mock := &MockKVStoreWithSeek{ctrl: ctrl}
+	mock.recorder = &MockKVStoreWithSeekMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKVStoreWithSeek) EXPECT() *MockKVStoreWithSeekMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockKVStoreWithSeek) Delete(arg0 string, arg1 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockKVStoreWithSeekMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockKVStoreWithSeek)(nil).Delete), arg0, arg1)
+}
+
+// Filter mocks base method.
+func (m *MockKVStoreWithSeek) Filter(arg0 string, arg1 Condition, arg2, arg3 []byte) ([][]byte, [][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Filter", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].([][]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Filter indicates an expected call of Filter.
+func (mr *MockKVStoreWithSeekMockRecorder) Filter(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Filter", reflect.TypeOf((*MockKVStoreWithSeek)(nil).Filter), arg0, arg1, arg2, arg3)
+}
+
+// Get mocks base method.
+func (m *MockKVStoreWithSeek) Get(arg0 string, arg1 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockKVStoreWithSeekMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockKVStoreWithSeek)(nil).Get), arg0, arg1)
+}
+
+// Put mocks base method.
+func (m *MockKVStoreWithSeek) Put(arg0 string, arg1, arg2 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockKVStoreWithSeekMockRecorder) Put(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockKVStoreWithSeek)(nil).Put), arg0, arg1, arg2)
+}
+
+// Seek mocks base method.
+func (m *MockKVStoreWithSeek) Seek(arg0 string, arg1 []byte, arg2 func([]byte, []byte) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Seek", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Seek indicates an expected call of Seek.
+func (mr *MockKVStoreWithSeekMockRecorder) Seek(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Seek", reflect.TypeOf((*MockKVStoreWithSeek)(nil).Seek), arg0, arg1, arg2)
+}
+
+// Start mocks base method.
+func (m *MockKVStoreWithSeek) Start(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockKVStoreWithSeekMockRecorder) Start(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockKVStoreWithSeek)(nil).Start), arg0)
+}
+
+// Stop mocks base method.
+func (m *MockKVStoreWithSeek) Stop(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockKVStoreWithSeekMockRecorder) Stop(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockKVStoreWithSeek)(nil).Stop), arg0)
+}
+
+// WriteBatch mocks base method.
+func (m *MockKVStoreWithSeek) WriteBatch(arg0 batch.KVStoreBatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteBatch", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteBatch indicates an expected call of WriteBatch.
+func (mr *MockKVStoreWithSeekMockRecorder) WriteBatch(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteBatch", reflect.TypeOf((*MockKVStoreWithSeek)(nil).WriteBatch), arg0)
+}
+
 // MockKVStoreForRangeIndex is a mock of KVStoreForRangeIndex interface.
 type MockKVStoreForRangeIndex struct {
 	ctrl     *gomock.Controller
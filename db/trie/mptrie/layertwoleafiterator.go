@@ -23,3 +23,13 @@ func NewLayerTwoLeafIterator(tr trie.TwoLayerTrie, layerOneKey []byte, l int) (t
 	}
 	return NewLeafIterator(lt.tr)
 }
+
+// NewLayerOneLeafIterator returns a new leaf iterator of the layer one trie, the values are the root
+// hashes of the layer two tries
+func NewLayerOneLeafIterator(tr trie.TwoLayerTrie) (trie.Iterator, error) {
+	tlt, ok := tr.(*twoLayerTrie)
+	if !ok {
+		return nil, errors.New("trie is not supported type")
+	}
+	return NewLeafIterator(tlt.layerOne)
+}
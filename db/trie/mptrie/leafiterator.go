@@ -6,6 +6,8 @@
 package mptrie
 
 import (
+	"bytes"
+
 	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/db/trie"
@@ -28,6 +30,76 @@ func NewLeafIterator(tr trie.Trie) (trie.Iterator, error) {
 	return &LeafIterator{cli: mpt, stack: stack}, nil
 }
 
+// NewLeafIteratorAfter returns a new leaf iterator going through the leaves after key, in the same order
+// as the iterator returned by NewLeafIterator, so that an iteration could be resumed from the last key
+func NewLeafIteratorAfter(tr trie.Trie, key []byte) (trie.Iterator, error) {
+	mpt, ok := tr.(*merklePatriciaTrie)
+	if !ok {
+		return nil, errors.New("trie is not supported type")
+	}
+	kt, err := mpt.checkKeyType(key)
+	if err != nil {
+		return nil, err
+	}
+	li := &LeafIterator{cli: mpt}
+	if err := li.seek(mpt.root, kt); err != nil {
+		return nil, err
+	}
+
+	return li, nil
+}
+
+// seek pushes the subtrees of the leaves after key into the stack. The leaves are popped in descending
+// order of keys, so the leaves after key are those with smaller keys
+func (li *LeafIterator) seek(n node, key keyType) error {
+	for offset := 0; ; {
+		switch nd := n.(type) {
+		case *hashNode:
+			loaded, err := nd.LoadNode(li.cli)
+			if err != nil {
+				return err
+			}
+			n = loaded
+		case *branchNode:
+			if offset >= len(key) {
+				return errors.New("unexpected branch depth")
+			}
+			for _, idx := range nd.indices.List() {
+				if idx < key[offset] {
+					li.stack = append(li.stack, nd.children[idx])
+				}
+			}
+			child, ok := nd.children[key[offset]]
+			if !ok {
+				return nil
+			}
+			n = child
+			offset++
+		case *extensionNode:
+			end := offset + len(nd.path)
+			if end > len(key) {
+				return errors.New("unexpected extension path")
+			}
+			switch bytes.Compare(nd.path, key[offset:end]) {
+			case -1:
+				li.stack = append(li.stack, nd.child)
+				return nil
+			case 1:
+				return nil
+			}
+			n = nd.child
+			offset = end
+		case *leafNode:
+			if bytes.Compare(nd.key, key) < 0 {
+				li.stack = append(li.stack, nd)
+			}
+			return nil
+		default:
+			return errors.New("unexpected node type")
+		}
+	}
+}
+
 // Next moves iterator to next node
 func (li *LeafIterator) Next() ([]byte, []byte, error) {
 	for len(li.stack) > 0 {
@@ -6,7 +6,9 @@
 package mptrie
 
 import (
+	"bytes"
 	"context"
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -52,3 +54,68 @@ func TestIterator(t *testing.T) {
 		require.Equal(item.v, found[item.k], "key: %s", item.k)
 	}
 }
+
+func TestIteratorAfter(t *testing.T) {
+	require := require.New(t)
+	mpt, err := New(KVStoreOption(trie.NewMemKVStore()), KeyLengthOption(4))
+	require.NoError(err)
+	require.NoError(mpt.Start(context.Background()))
+
+	readAll := func(iter trie.Iterator) [][]byte {
+		keys := [][]byte{}
+		for {
+			k, _, err := iter.Next()
+			if err != nil {
+				require.Equal(trie.ErrEndOfIterator, err)
+				return keys
+			}
+			keys = append(keys, k)
+		}
+	}
+	end := []byte{0xff, 0xff, 0xff, 0xff}
+	iter, err := NewLeafIteratorAfter(mpt, end)
+	require.NoError(err)
+	require.Empty(readAll(iter))
+
+	keys := [][]byte{
+		{1, 2, 3, 4}, {1, 2, 3, 5}, {1, 2, 4, 4}, {1, 3, 0, 0}, {2, 0, 0, 0}, {0xff, 0xff, 0xff, 0xff},
+	}
+	for i := 0; i < 200; i++ {
+		k := make([]byte, 4)
+		rand.Read(k)
+		k[0] |= 0x10
+		keys = append(keys, k)
+	}
+	uniq := make(map[string]struct{})
+	for _, k := range keys {
+		require.NoError(mpt.Upsert(k, k))
+		uniq[string(k)] = struct{}{}
+	}
+	_, err = mpt.RootHash()
+	require.NoError(err)
+	// the leaves are iterated in descending order
+	iter, err = NewLeafIterator(mpt)
+	require.NoError(err)
+	all := readAll(iter)
+	require.Len(all, len(uniq))
+	for i := 1; i < len(all); i++ {
+		require.Equal(1, bytes.Compare(all[i-1], all[i]))
+	}
+	for i, k := range all {
+		iter, err := NewLeafIteratorAfter(mpt, k)
+		require.NoError(err)
+		require.Equal(all[i+1:], readAll(iter))
+	}
+	// resume from a key not in the trie
+	for _, k := range [][]byte{{1, 2, 3, 4, 5}, {1, 2, 3}} {
+		_, err = NewLeafIteratorAfter(mpt, k)
+		require.Error(err)
+	}
+	iter, err = NewLeafIteratorAfter(mpt, []byte{1, 2, 4, 0})
+	require.NoError(err)
+	after := readAll(iter)
+	require.Equal([]byte{1, 2, 3, 5}, after[0])
+	for _, k := range after {
+		require.Equal(-1, bytes.Compare(k, []byte{1, 2, 4, 0}))
+	}
+}
@@ -32,6 +32,7 @@ type (
 		hashFunc      HashFunc
 		async         bool
 		emptyRootHash []byte
+		nodeCache     *nodeCacheSession
 	}
 )
 
@@ -88,6 +89,16 @@ func AsyncOption() Option {
 	}
 }
 
+// NodeCacheOption sets the cache of decoded nodes for the trie. The tries created with the same
+// option share the nodes written by each other, and thus should share the same write buffer
+func NodeCacheOption(cache *NodeCache) Option {
+	session := newNodeCacheSession(cache)
+	return func(mpt *merklePatriciaTrie) error {
+		mpt.nodeCache = session
+		return nil
+	}
+}
+
 // New creates a trie with DB filename
 func New(options ...Option) (trie.Trie, error) {
 	t := &merklePatriciaTrie{
@@ -294,22 +305,40 @@ func (mpt *merklePatriciaTrie) hash(key []byte) []byte {
 }
 
 func (mpt *merklePatriciaTrie) deleteNode(key []byte) error {
+	if mpt.nodeCache != nil {
+		mpt.nodeCache.write(key)
+	}
 	return mpt.kvStore.Delete(key)
 }
 
 func (mpt *merklePatriciaTrie) putNode(key []byte, value []byte) error {
+	if mpt.nodeCache != nil {
+		mpt.nodeCache.write(key)
+	}
 	return mpt.kvStore.Put(key, value)
 }
 
 func (mpt *merklePatriciaTrie) loadNode(key []byte) (node, error) {
+	if mpt.nodeCache != nil {
+		if pb, ok := mpt.nodeCache.get(key); ok {
+			return newNodeFromProtoPb(pb, key)
+		}
+	}
 	s, err := mpt.kvStore.Get(key)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get key %x", key)
 	}
-	pb := triepb.NodePb{}
-	if err := proto.Unmarshal(s, &pb); err != nil {
+	pb := &triepb.NodePb{}
+	if err := proto.Unmarshal(s, pb); err != nil {
 		return nil, err
 	}
+	if mpt.nodeCache != nil {
+		mpt.nodeCache.add(key, pb, len(s))
+	}
+	return newNodeFromProtoPb(pb, key)
+}
+
+func newNodeFromProtoPb(pb *triepb.NodePb, key []byte) (node, error) {
 	if pbBranch := pb.GetBranch(); pbBranch != nil {
 		return newBranchNodeFromProtoPb(pbBranch, key), nil
 	}
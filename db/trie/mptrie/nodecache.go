@@ -0,0 +1,177 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package mptrie
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotexproject/iotex-core/db/trie/triepb"
+)
+
+// _nodeCacheEntryOverhead is the estimated memory taken by an entry besides the node itself,
+// including the list element, the map slot and the key string header
+const _nodeCacheEntryOverhead = 128
+
+var _nodeCacheMtc = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "iotex_trie_node_cache",
+	Help: "trie node cache statistics.",
+}, []string{"type"})
+
+func init() {
+	prometheus.MustRegister(_nodeCacheMtc)
+}
+
+type (
+	// NodeCache is a memory-bounded LRU cache of decoded branch and extension nodes, keyed by
+	// node hash. It is shared by the tries reading from the same underlying store, and only
+	// holds nodes which have been read from the store without being written in the session
+	NodeCache struct {
+		mutex    sync.Mutex
+		maxBytes uint64
+		size     uint64
+		lru      *list.List
+		items    map[string]*list.Element
+	}
+
+	nodeCacheEntry struct {
+		key  string
+		pb   *triepb.NodePb
+		size uint64
+	}
+
+	// nodeCacheSession is the view of a node cache from a group of tries sharing the same
+	// write buffer. Nodes written in the session are not committed yet, so they are neither
+	// read from nor added into the shared cache, in case the buffer is discarded later
+	nodeCacheSession struct {
+		cache   *NodeCache
+		mutex   sync.RWMutex
+		written map[string]struct{}
+	}
+)
+
+// NewNodeCache creates a node cache taking at most maxBytes memory
+func NewNodeCache(maxBytes uint64) *NodeCache {
+	return &NodeCache{
+		maxBytes: maxBytes,
+		lru:      list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Len returns the number of nodes in the cache
+func (c *NodeCache) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.lru.Len()
+}
+
+// Size returns the estimated memory taken by the cache in bytes
+func (c *NodeCache) Size() uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.size
+}
+
+func (c *NodeCache) get(key []byte) (*triepb.NodePb, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	elem, ok := c.items[string(key)]
+	if !ok {
+		_nodeCacheMtc.WithLabelValues("miss").Inc()
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	_nodeCacheMtc.WithLabelValues("hit").Inc()
+	return elem.Value.(*nodeCacheEntry).pb, true
+}
+
+func (c *NodeCache) add(key []byte, pb *triepb.NodePb, size int) {
+	entry := &nodeCacheEntry{
+		key:  string(key),
+		pb:   pb,
+		size: uint64(len(key)+size) + _nodeCacheEntryOverhead,
+	}
+	if entry.size > c.maxBytes {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if elem, ok := c.items[entry.key]; ok {
+		c.lru.MoveToFront(elem)
+		return
+	}
+	c.items[entry.key] = c.lru.PushFront(entry)
+	c.size += entry.size
+	for c.size > c.maxBytes {
+		c.removeElement(c.lru.Back())
+		_nodeCacheMtc.WithLabelValues("eviction").Inc()
+	}
+}
+
+func (c *NodeCache) remove(key []byte) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if elem, ok := c.items[string(key)]; ok {
+		c.removeElement(elem)
+	}
+}
+
+func (c *NodeCache) removeElement(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*nodeCacheEntry)
+	delete(c.items, entry.key)
+	c.size -= entry.size
+}
+
+func newNodeCacheSession(cache *NodeCache) *nodeCacheSession {
+	return &nodeCacheSession{
+		cache:   cache,
+		written: make(map[string]struct{}),
+	}
+}
+
+func (s *nodeCacheSession) isWritten(key []byte) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	_, ok := s.written[string(key)]
+	return ok
+}
+
+// get returns the cached node of key, unless the node has been written in the session
+func (s *nodeCacheSession) get(key []byte) (*triepb.NodePb, bool) {
+	if s.isWritten(key) {
+		return nil, false
+	}
+	return s.cache.get(key)
+}
+
+// add caches a node read from the store, only branch and extension nodes are cached
+func (s *nodeCacheSession) add(key []byte, pb *triepb.NodePb, size int) {
+	switch {
+	case pb.GetBranch() != nil:
+	case pb.GetExtend() != nil:
+		// the path is shared by all the nodes created from the cache, clip it to avoid being
+		// overwritten by an append
+		ext := pb.GetExtend()
+		ext.Path = ext.Path[:len(ext.Path):len(ext.Path)]
+	default:
+		return
+	}
+	if s.isWritten(key) {
+		return
+	}
+	s.cache.add(key, pb, size)
+}
+
+// write marks a node as written in the session, and invalidates it in the shared cache
+func (s *nodeCacheSession) write(key []byte) {
+	s.mutex.Lock()
+	s.written[string(key)] = struct{}{}
+	s.mutex.Unlock()
+	s.cache.remove(key)
+}
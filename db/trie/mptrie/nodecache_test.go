@@ -0,0 +1,125 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package mptrie
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/db/trie"
+	"github.com/iotexproject/iotex-core/db/trie/triepb"
+)
+
+func TestNodeCache(t *testing.T) {
+	require := require.New(t)
+	entrySize := uint64(1+10) + _nodeCacheEntryOverhead
+	cache := NewNodeCache(3 * entrySize)
+	pb := &triepb.NodePb{Node: &triepb.NodePb_Branch{Branch: &triepb.BranchPb{}}}
+	for _, k := range []string{"a", "b", "c"} {
+		cache.add([]byte(k), pb, 10)
+	}
+	require.Equal(3, cache.Len())
+	require.Equal(3*entrySize, cache.Size())
+	// a becomes the most recently used one
+	_, ok := cache.get([]byte("a"))
+	require.True(ok)
+	cache.add([]byte("d"), pb, 10)
+	require.Equal(3, cache.Len())
+	_, ok = cache.get([]byte("b"))
+	require.False(ok)
+	for _, k := range []string{"a", "c", "d"} {
+		_, ok = cache.get([]byte(k))
+		require.True(ok)
+	}
+	// a node larger than the cache is never added
+	cache.add([]byte("e"), pb, int(3*entrySize))
+	require.Equal(3, cache.Len())
+	cache.remove([]byte("c"))
+	require.Equal(2, cache.Len())
+	require.Equal(2*entrySize, cache.Size())
+}
+
+func TestNodeCacheSession(t *testing.T) {
+	require := require.New(t)
+	var (
+		cache = NewNodeCache(1 << 20)
+		dao   = db.NewMemKVStore()
+		keys  = [][]byte{[]byte("key1"), []byte("key2"), []byte("key3"), []byte("key4")}
+	)
+	// the tries sharing a buffer share a session as well
+	newTrie := func(flusher db.KVStoreFlusher, session Option, root []byte) trie.Trie {
+		kvStore, err := trie.NewKVStore("test", flusher.KVStoreWithBuffer())
+		require.NoError(err)
+		tr, err := New(KVStoreOption(kvStore), KeyLengthOption(4), RootHashOption(root), session)
+		require.NoError(err)
+		require.NoError(tr.Start(context.Background()))
+		return tr
+	}
+	require.NoError(dao.Start(context.Background()))
+
+	// nodes written into a buffer which is discarded later are not cached
+	flusher, err := db.NewKVStoreFlusher(dao, batch.NewCachedBatch())
+	require.NoError(err)
+	session := NodeCacheOption(cache)
+	tr := newTrie(flusher, session, nil)
+	for _, k := range keys {
+		require.NoError(tr.Upsert(k, k))
+	}
+	root, err := tr.RootHash()
+	require.NoError(err)
+	tr = newTrie(flusher, session, root)
+	for _, k := range keys {
+		_, err = tr.Get(k)
+		require.NoError(err)
+	}
+	require.Zero(cache.Len())
+
+	// nodes are cached once committed
+	flusher, err = db.NewKVStoreFlusher(dao, batch.NewCachedBatch())
+	require.NoError(err)
+	tr = newTrie(flusher, NodeCacheOption(cache), nil)
+	for _, k := range keys {
+		require.NoError(tr.Upsert(k, k))
+	}
+	_, err = tr.RootHash()
+	require.NoError(err)
+	require.NoError(flusher.Flush())
+	flusher, err = db.NewKVStoreFlusher(dao, batch.NewCachedBatch())
+	require.NoError(err)
+	session = NodeCacheOption(cache)
+	tr = newTrie(flusher, session, root)
+	for _, k := range keys {
+		v, err := tr.Get(k)
+		require.NoError(err)
+		require.Equal(k, v)
+	}
+	cached := cache.Len()
+	require.NotZero(cached)
+	tr = newTrie(flusher, session, root)
+	for _, k := range keys {
+		v, err := tr.Get(k)
+		require.NoError(err)
+		require.Equal(k, v)
+	}
+	require.Equal(cached, cache.Len())
+
+	// the nodes written by a session are invalidated
+	require.NoError(tr.Delete(keys[0]))
+	_, ok := cache.get(root)
+	require.False(ok)
+	_, err = tr.Get(keys[0])
+	require.Equal(trie.ErrNotExist, errors.Cause(err))
+	for _, k := range keys[1:] {
+		v, err := tr.Get(k)
+		require.NoError(err)
+		require.Equal(k, v)
+	}
+}
@@ -27,14 +27,16 @@ type (
 		layerTwoMap map[string]*layerTwo
 		kvStore     trie.KVStore
 		rootKey     string
+		opts        []Option
 	}
 )
 
-// NewTwoLayerTrie creates a two layer trie
-func NewTwoLayerTrie(dbForTrie trie.KVStore, rootKey string) trie.TwoLayerTrie {
+// NewTwoLayerTrie creates a two layer trie, the options are applied to the tries of both layers
+func NewTwoLayerTrie(dbForTrie trie.KVStore, rootKey string, opts ...Option) trie.TwoLayerTrie {
 	return &twoLayerTrie{
 		kvStore: dbForTrie,
 		rootKey: rootKey,
+		opts:    opts,
 	}
 }
 
@@ -43,7 +45,7 @@ func (tlt *twoLayerTrie) layerTwoTrie(key []byte, layerTwoTrieKeyLen int) (*laye
 	if lt, ok := tlt.layerTwoMap[hk]; ok {
 		return lt, nil
 	}
-	opts := []Option{KVStoreOption(tlt.kvStore), KeyLengthOption(layerTwoTrieKeyLen), AsyncOption()}
+	opts := append([]Option{KVStoreOption(tlt.kvStore), KeyLengthOption(layerTwoTrieKeyLen), AsyncOption()}, tlt.opts...)
 	value, err := tlt.layerOne.Get(key)
 	switch errors.Cause(err) {
 	case trie.ErrNotExist:
@@ -80,11 +82,11 @@ func (tlt *twoLayerTrie) Start(ctx context.Context) error {
 	if errors.Cause(err) == trie.ErrNotExist {
 		rootHash = nil
 	}
-	layerOne, err := New(
+	layerOne, err := New(append([]Option{
 		KVStoreOption(tlt.kvStore),
 		RootHashOption(rootHash),
 		AsyncOption(),
-	)
+	}, tlt.opts...)...)
 	if err != nil {
 		return errors.Wrapf(err, "failed to generate trie for %s", tlt.rootKey)
 	}
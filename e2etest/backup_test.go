@@ -0,0 +1,142 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package e2etest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/backup"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/server/itx"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestOnlineBackupRestore(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	newConfig := func(dataDir string) config.Config {
+		cfg, err := newTestConfig()
+		require.NoError(err)
+		cfg.Chain.TrieDBPatchFile = ""
+		cfg.Chain.EnableTrielessStateDB = false
+		cfg.Chain.ChainDBPath = filepath.Join(dataDir, "chain.db")
+		cfg.Chain.TrieDBPath = filepath.Join(dataDir, "trie.db")
+		cfg.Chain.IndexDBPath = filepath.Join(dataDir, "index.db")
+		cfg.Chain.BloomfilterIndexDBPath = filepath.Join(dataDir, "bloomfilter.index.db")
+		cfg.Chain.CandidateIndexDBPath = filepath.Join(dataDir, "candidate.index.db")
+		cfg.Chain.StakingIndexDBPath = filepath.Join(dataDir, "staking.index.db")
+		cfg.Chain.ContractStakingIndexDBPath = filepath.Join(dataDir, "contractstaking.index.db")
+		cfg.Chain.BackupDir = filepath.Join(dataDir, "backup")
+		cfg.Plugins[config.GatewayPlugin] = true
+		// split the chain db every 2 blocks, so the backup has sealed files
+		cfg.DB.V2BlocksToSplitDB = 2
+		return cfg
+	}
+	commit := func(bc blockchain.Blockchain, n int) {
+		for i := 0; i < n; i++ {
+			blk, err := bc.MintNewBlock(testutil.TimestampNow())
+			require.NoError(err)
+			require.NoError(bc.CommitBlock(blk))
+		}
+	}
+
+	cfg := newConfig(t.TempDir())
+	defer delete(cfg.Plugins, config.GatewayPlugin)
+	svr, err := itx.NewServer(cfg)
+	require.NoError(err)
+	require.NoError(svr.Start(ctx))
+	defer func() {
+		require.NoError(svr.Stop(ctx))
+	}()
+	cs := svr.ChainService(cfg.Chain.ID)
+	bc := cs.Blockchain()
+	require.NoError(addTestingTsfBlocks(bc, cs.ActionPool()))
+	require.EqualValues(5, bc.TipHeight())
+
+	// take the backup through the admin handler while blocks are being committed
+	handler := itx.NewBackupHandler(ctx, cs, cfg.Chain)
+	status := func(query string) map[string]interface{} {
+		w := httptest.NewRecorder()
+		handler.Handle(w, httptest.NewRequest(http.MethodGet, "/backup"+query, nil))
+		require.Equal(http.StatusOK, w.Code)
+		s := map[string]interface{}{}
+		require.NoError(json.Unmarshal(w.Body.Bytes(), &s))
+		return s
+	}
+	committed := make(chan struct{})
+	go func() {
+		defer close(committed)
+		commit(bc, 5)
+	}()
+	require.True(status("?name=test")["running"].(bool))
+	require.Eventually(func() bool {
+		return !status("")["running"].(bool)
+	}, 30*time.Second, 10*time.Millisecond)
+	<-committed
+	s := status("")
+	require.Nil(s["error"])
+	commit(bc, 3)
+	tip := bc.TipHeight()
+	require.EqualValues(13, tip)
+
+	dir := filepath.Join(cfg.Chain.BackupDir, "test")
+	manifest, err := backup.ReadManifest(dir)
+	require.NoError(err)
+	require.True(manifest.Complete)
+	require.NotEmpty(manifest.Root)
+	require.EqualValues(manifest.Height, s["height"])
+	require.GreaterOrEqual(manifest.Height, uint64(5))
+	require.Less(manifest.Height, tip)
+
+	// restore the backup into a fresh data dir
+	cfg2 := newConfig(t.TempDir())
+	deser := block.NewDeserializer(cfg2.Chain.EVMNetworkID)
+	_, err = backup.Restore(dir, backup.StorePaths(cfg2.Chain), cfg2.DB, deser)
+	require.NoError(err)
+	svr2, err := itx.NewServer(cfg2)
+	require.NoError(err)
+	require.NoError(svr2.Start(ctx))
+	defer func() {
+		require.NoError(svr2.Stop(ctx))
+	}()
+	cs2 := svr2.ChainService(cfg2.Chain.ID)
+	bc2 := cs2.Blockchain()
+	require.Equal(manifest.Height, bc2.TipHeight())
+	height, err := cs2.StateFactory().Height()
+	require.NoError(err)
+	require.Equal(manifest.Height, height)
+
+	// the restored node syncs the blocks after the backup height
+	dao := cs.BlockDAO()
+	for h := manifest.Height + 1; h <= tip; h++ {
+		blk, err := dao.GetBlockByHeight(h)
+		require.NoError(err)
+		require.NoError(bc2.ValidateBlock(blk))
+		require.NoError(bc2.CommitBlock(blk))
+	}
+	require.Equal(bc.TipHash(), bc2.TipHash())
+	height, err = cs2.StateFactory().Height()
+	require.NoError(err)
+	require.Equal(tip, height)
+	for h := uint64(1); h <= tip; h++ {
+		blk, err := dao.GetBlockByHeight(h)
+		require.NoError(err)
+		blk2, err := cs2.BlockDAO().GetBlockByHeight(h)
+		require.NoError(err)
+		require.Equal(blk.HashBlock(), blk2.HashBlock())
+	}
+}
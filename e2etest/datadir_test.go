@@ -0,0 +1,87 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package e2etest
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/backup"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/server/itx"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestSplitDataDirs(t *testing.T) {
+	require := require.New(t)
+	// the block files and the indexes are on one volume, and the state db on another
+	splitCfg := func(hdd, nvme string) config.Config {
+		cfg := initCfg(require)
+		clearDBPaths(&cfg)
+		cfg.Chain.ChainDBPath = filepath.Join(hdd, "chain.db")
+		cfg.Chain.IndexDBPath = filepath.Join(hdd, "index.db")
+		cfg.Chain.BloomfilterIndexDBPath = filepath.Join(hdd, "bloomfilter.index.db")
+		cfg.Chain.CandidateIndexDBPath = filepath.Join(hdd, "candidate.index.db")
+		cfg.Chain.ContractStakingIndexDBPath = filepath.Join(hdd, "contractstaking.index.db")
+		cfg.Chain.TrieDBPath = filepath.Join(nvme, "trie.db")
+		return cfg
+	}
+	cfg := splitCfg(t.TempDir(), t.TempDir())
+	test := newE2ETest(t, cfg)
+	chainID := test.cfg.Chain.ID
+	test.run([]*testcase{
+		{
+			name:   "transfer",
+			act:    &actionWithTime{mustNoErr(action.SignedTransfer(identityset.Address(3).String(), identityset.PrivateKey(1), test.nonceMgr.pop(identityset.Address(1).String()), unit.ConvertIotxToRau(1), nil, gasLimit, gasPrice, action.WithChainID(chainID))), time.Now()},
+			expect: []actionExpect{successExpect},
+		},
+	})
+	tip := test.cs.Blockchain().TipHeight()
+	require.NoError(test.svr.Stop(context.Background()))
+
+	// the node restarts on the split volumes
+	svr, err := itx.NewServer(cfg)
+	require.NoError(err)
+	require.NoError(svr.Start(context.Background()))
+	require.Equal(tip, svr.ChainService(chainID).Blockchain().TipHeight())
+	require.NoError(svr.Stop(context.Background()))
+
+	// the state db of another chain is swapped in
+	other := splitCfg(t.TempDir(), t.TempDir())
+	other.Genesis.InitBalanceMap[identityset.Address(3).String()] = "1"
+	svr, err = itx.NewServer(other)
+	require.NoError(err)
+	require.NoError(svr.Start(context.Background()))
+	require.NoError(svr.Stop(context.Background()))
+	swapped := cfg
+	swapped.Chain.TrieDBPath = other.Chain.TrieDBPath
+	_, err = itx.NewServer(swapped)
+	require.True(errors.Is(err, backup.ErrStoreChainMismatch), err)
+
+	// the volume of the state db isn't mounted, or an empty one is mounted
+	missing := cfg
+	missing.Chain.TrieDBPath = filepath.Join(t.TempDir(), "unmounted", "trie.db")
+	_, err = itx.NewServer(missing)
+	require.True(errors.Is(err, backup.ErrStoreMissing), err)
+	missing.Chain.TrieDBPath = filepath.Join(t.TempDir(), "trie.db")
+	_, err = itx.NewServer(missing)
+	require.True(errors.Is(err, backup.ErrStoreMissing), err)
+
+	// the node still restarts on its own volumes
+	svr, err = itx.NewServer(cfg)
+	require.NoError(err)
+	require.NoError(svr.Start(context.Background()))
+	require.Equal(tip, svr.ChainService(chainID).Blockchain().TipHeight())
+	require.NoError(svr.Stop(context.Background()))
+	clearDBPaths(&cfg)
+}
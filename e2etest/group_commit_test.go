@@ -0,0 +1,88 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package e2etest
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockindex"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/server/itx"
+)
+
+func TestGroupCommit(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	cfg, err := newTestConfig()
+	require.NoError(err)
+	cfg.Chain.TrieDBPatchFile = ""
+	cfg.Chain.ChainDBPath = filepath.Join(dataDir, "chain.db")
+	cfg.Chain.TrieDBPath = filepath.Join(dataDir, "trie.db")
+	cfg.Chain.IndexDBPath = filepath.Join(dataDir, "index.db")
+	cfg.Chain.BloomfilterIndexDBPath = filepath.Join(dataDir, "bloomfilter.index.db")
+	cfg.Chain.CandidateIndexDBPath = filepath.Join(dataDir, "candidate.index.db")
+	cfg.Chain.ContractStakingIndexDBPath = filepath.Join(dataDir, "contractstaking.index.db")
+	cfg.Chain.EnableGroupCommit = true
+	cfg.Chain.EnableAsyncIndexWrite = false
+	cfg.Plugins[config.GatewayPlugin] = true
+	defer delete(cfg.Plugins, config.GatewayPlugin)
+
+	svr, err := itx.NewServer(cfg)
+	require.NoError(err)
+	require.NoError(svr.Start(ctx))
+	cs := svr.ChainService(cfg.Chain.ID)
+	bc := cs.Blockchain()
+	require.NoError(addTestingTsfBlocks(bc, cs.ActionPool()))
+	require.EqualValues(5, bc.TipHeight())
+	tipHash := bc.TipHash()
+	dao := cs.BlockDAO()
+	blks := make([]*block.Block, 0, 5)
+	for h := uint64(1); h <= 5; h++ {
+		blk, err := dao.GetBlockByHeight(h)
+		require.NoError(err)
+		blks = append(blks, blk)
+	}
+	require.NoError(svr.Stop(ctx))
+
+	// the writes of the blocks have been flushed into the dbs
+	dbCfg := cfg.DB
+	dbCfg.DbPath = cfg.Chain.IndexDBPath
+	indexer, err := blockindex.NewIndexer(db.NewBoltDB(dbCfg), cfg.Genesis.Hash())
+	require.NoError(err)
+	require.NoError(indexer.Start(ctx))
+	height, err := indexer.Height()
+	require.NoError(err)
+	require.EqualValues(5, height)
+	for _, blk := range blks {
+		for _, act := range blk.Actions {
+			actHash, err := act.Hash()
+			require.NoError(err)
+			index, err := indexer.GetActionIndex(actHash[:])
+			require.NoError(err)
+			require.Equal(blk.Height(), index.BlockHeight())
+		}
+	}
+	require.NoError(indexer.Stop(ctx))
+
+	svr, err = itx.NewServer(cfg)
+	require.NoError(err)
+	require.NoError(svr.Start(ctx))
+	defer func() {
+		require.NoError(svr.Stop(ctx))
+	}()
+	cs = svr.ChainService(cfg.Chain.ID)
+	require.Equal(tipHash, cs.Blockchain().TipHash())
+	height, err = cs.StateFactory().Height()
+	require.NoError(err)
+	require.EqualValues(5, height)
+}
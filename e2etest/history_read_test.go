@@ -0,0 +1,145 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package e2etest
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestReadStateAtHeight(t *testing.T) {
+	require := require.New(t)
+	cfg := initCfg(require)
+	// the states are read on top of the tries of the past heights
+	cfg.Chain.EnableTrielessStateDB = false
+	cfg.Chain.EnableArchiveMode = true
+	cfg.Plugins[config.GatewayPlugin] = nil
+	test := newE2ETest(t, cfg)
+	defer test.teardown()
+
+	type read struct {
+		protocol string
+		method   []byte
+		args     [][]byte
+	}
+	var (
+		chainID        = test.cfg.Chain.ID
+		ownerID        = 1
+		stakerID       = 2
+		registerAmount = unit.ConvertIotxToRau(1200000)
+		stakeAmount    = unit.ConvertIotxToRau(100)
+		producer       = cfg.Chain.ProducerAddress().String()
+		reads          = []read{
+			{"rewarding", []byte("UnclaimedBalance"), [][]byte{[]byte(producer)}},
+			{"rewarding", []byte("AvailableBalance"), nil},
+			{"staking", mustNoErr(proto.Marshal(&iotexapi.ReadStakingDataMethod{Method: iotexapi.ReadStakingDataMethod_CANDIDATE_BY_NAME})),
+				[][]byte{mustNoErr(proto.Marshal(&iotexapi.ReadStakingDataRequest{
+					Request: &iotexapi.ReadStakingDataRequest_CandidateByName_{
+						CandidateByName: &iotexapi.ReadStakingDataRequest_CandidateByName{CandName: "cand1"},
+					},
+				}))}},
+			{"staking", mustNoErr(proto.Marshal(&iotexapi.ReadStakingDataMethod{Method: iotexapi.ReadStakingDataMethod_BUCKETS_BY_VOTER})),
+				[][]byte{mustNoErr(proto.Marshal(&iotexapi.ReadStakingDataRequest{
+					Request: &iotexapi.ReadStakingDataRequest_BucketsByVoter{
+						BucketsByVoter: &iotexapi.ReadStakingDataRequest_VoteBucketsByVoter{
+							VoterAddress: identityset.Address(stakerID).String(),
+							Pagination:   &iotexapi.PaginationParam{Offset: 0, Limit: 10},
+						},
+					},
+				}))}},
+		}
+		// live are the results read at the tip right after each block is committed
+		live = make(map[uint64][][]byte)
+	)
+	readState := func(r read, height string) (*iotexapi.ReadStateResponse, error) {
+		return test.api.ReadState(context.Background(), &iotexapi.ReadStateRequest{
+			ProtocolID: []byte(r.protocol),
+			MethodName: r.method,
+			Arguments:  r.args,
+			Height:     height,
+		})
+	}
+	capture := &functionExpect{func(test *e2etest, act *action.SealedEnvelope, receipt *action.Receipt, err error) {
+		require.NoError(err)
+		for _, r := range reads {
+			resp, err := readState(r, "")
+			require.NoError(err)
+			require.Equal(receipt.BlockHeight, resp.GetBlockIdentifier().GetHeight())
+			live[receipt.BlockHeight] = append(live[receipt.BlockHeight], resp.GetData())
+		}
+	}}
+	test.run([]*testcase{
+		{
+			name:   "register candidate",
+			act:    &actionWithTime{mustNoErr(action.SignedCandidateRegister(test.nonceMgr.pop(identityset.Address(ownerID).String()), "cand1", identityset.Address(ownerID).String(), identityset.Address(ownerID).String(), identityset.Address(ownerID).String(), registerAmount.String(), 1, false, nil, gasLimit, gasPrice, identityset.PrivateKey(ownerID), action.WithChainID(chainID))), time.Now()},
+			expect: []actionExpect{successExpect, capture},
+		},
+		{
+			name:   "stake to candidate",
+			act:    &actionWithTime{mustNoErr(action.SignedCreateStake(test.nonceMgr.pop(identityset.Address(stakerID).String()), "cand1", stakeAmount.String(), 1, true, nil, gasLimit, gasPrice, identityset.PrivateKey(stakerID), action.WithChainID(chainID))), time.Now()},
+			expect: []actionExpect{successExpect, capture},
+		},
+		{
+			name:   "add deposit to the bucket",
+			act:    &actionWithTime{mustNoErr(action.SignedDepositToStake(test.nonceMgr.pop(identityset.Address(stakerID).String()), 1, stakeAmount.String(), nil, gasLimit, gasPrice, identityset.PrivateKey(stakerID), action.WithChainID(chainID))), time.Now()},
+			expect: []actionExpect{successExpect, capture},
+		},
+		{
+			name:   "transfer",
+			act:    &actionWithTime{mustNoErr(action.SignedTransfer(identityset.Address(3).String(), identityset.PrivateKey(stakerID), test.nonceMgr.pop(identityset.Address(stakerID).String()), big.NewInt(1), nil, gasLimit, gasPrice, action.WithChainID(chainID))), time.Now()},
+			expect: []actionExpect{successExpect, capture},
+		},
+	})
+	require.Len(live, 4)
+
+	// the reads at the past heights return the results captured at the time
+	for height, results := range live {
+		for i, r := range reads {
+			resp, err := readState(r, strconv.FormatUint(height, 10))
+			require.NoError(err)
+			require.Equal(height, resp.GetBlockIdentifier().GetHeight())
+			require.Equal(results[i], resp.GetData(), "read %d at height %d", i, height)
+		}
+	}
+}
+
+func TestReadStateAtHeightUnavailable(t *testing.T) {
+	require := require.New(t)
+	cfg := initCfg(require)
+	cfg.Chain.EnableTrielessStateDB = false
+	test := newE2ETest(t, cfg)
+	defer test.teardown()
+
+	chainID := test.cfg.Chain.ID
+	test.run([]*testcase{
+		{
+			name:   "transfer",
+			act:    &actionWithTime{mustNoErr(action.SignedTransfer(identityset.Address(3).String(), identityset.PrivateKey(1), test.nonceMgr.pop(identityset.Address(1).String()), big.NewInt(1), nil, gasLimit, gasPrice, action.WithChainID(chainID))), time.Now()},
+			expect: []actionExpect{successExpect},
+		},
+	})
+	// the states at the past heights are not kept without the archive mode
+	_, err := test.api.ReadState(context.Background(), &iotexapi.ReadStateRequest{
+		ProtocolID: []byte("rewarding"),
+		MethodName: []byte("AvailableBalance"),
+		Height:     "0",
+	})
+	require.Equal(codes.Unavailable, status.Code(err))
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package e2etest
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestInitCodeGas(t *testing.T) {
+	require := require.New(t)
+	var (
+		// PUSH0 PUSH0 RETURN deploys an empty contract, padded to 2 words
+		initCode            = append([]byte{0x5f, 0x5f, 0xf3}, make([]byte, 61)...)
+		deployer            = identityset.Address(1).String()
+		deployerKey         = identityset.PrivateKey(1)
+		preActivation       uint64
+		preActivationBlocks = 2
+	)
+	deploy := func(test *e2etest, gasLimit uint64) *actionWithTime {
+		return &actionWithTime{mustNoErr(action.SignedExecution("", deployerKey, test.nonceMgr.pop(deployer), big.NewInt(0), gasLimit, gasPrice, initCode, action.WithChainID(test.cfg.Chain.ID))), time.Now()}
+	}
+	// roots returns the state digest and the receipt root of the blocks before the activation
+	roots := func(test *e2etest) [][2][]byte {
+		ret := make([][2][]byte, 0, preActivationBlocks)
+		for h := uint64(1); h <= uint64(preActivationBlocks); h++ {
+			blk, err := test.cs.BlockDAO().GetBlockByHeight(h)
+			require.NoError(err)
+			digest, receiptRoot := blk.DeltaStateDigest(), blk.ReceiptRoot()
+			ret = append(ret, [2][]byte{digest[:], receiptRoot[:]})
+		}
+		return ret
+	}
+
+	cfg := initCfg(require)
+	cfg.Genesis.ToBeEnabledBlockHeight = uint64(preActivationBlocks) + 1
+	test := newE2ETest(t, cfg)
+	test.run([]*testcase{
+		{
+			name: "deploy before activation",
+			act:  deploy(test, gasLimit),
+			expect: []actionExpect{successExpect, &functionExpect{func(test *e2etest, act *action.SealedEnvelope, receipt *action.Receipt, err error) {
+				preActivation = receipt.GasConsumed
+			}}},
+		},
+		{
+			name: "init code gas not charged before activation",
+			act:  deploy(test, gasLimit),
+			expect: []actionExpect{successExpect, &functionExpect{func(test *e2etest, act *action.SealedEnvelope, receipt *action.Receipt, err error) {
+				require.Equal(preActivation, receipt.GasConsumed)
+			}}},
+		},
+	})
+	// the gas limit covering the intrinsic gas without the init code gas is rejected after activation, the zero
+	// bytes of the init code are discounted at the same height
+	exec := deploy(test, 0)
+	intrinsicGas, err := action.IntrinsicGasBySchedule(exec.act.Action(), action.DataCostZeroByteDiscount)
+	require.NoError(err)
+	test.nonceMgr[deployer]--
+	test.run([]*testcase{
+		{
+			name:   "intrinsic gas too low after activation",
+			act:    deploy(test, intrinsicGas),
+			expect: []actionExpect{&basicActionExpect{action.ErrIntrinsicGas, 0, ""}},
+		},
+	})
+	test.nonceMgr[deployer]--
+	test.run([]*testcase{
+		{
+			name: "init code gas charged after activation",
+			act:  deploy(test, gasLimit),
+			expect: []actionExpect{successExpect, &functionExpect{func(test *e2etest, act *action.SealedEnvelope, receipt *action.Receipt, err error) {
+				discount := action.DataCostZeroByteDiscount.DataGasDiscount(initCode, action.ExecutionDataGas)
+				require.Equal(preActivation+2*action.InitCodeWordGas-discount, receipt.GasConsumed)
+			}}},
+		},
+	})
+	activated := roots(test)
+	test.teardown()
+
+	// replaying the blocks before activation on a chain never activating it produces the same states and receipts
+	cfg = initCfg(require)
+	test = newE2ETest(t, cfg)
+	defer test.teardown()
+	test.run([]*testcase{
+		{name: "deploy", act: deploy(test, gasLimit), expect: []actionExpect{successExpect}},
+		{name: "deploy again", act: deploy(test, gasLimit), expect: []actionExpect{successExpect}},
+	})
+	require.Equal(activated, roots(test))
+}
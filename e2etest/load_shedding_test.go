@@ -0,0 +1,109 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package e2etest
+
+import (
+	"context"
+	"math/big"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestSendActionUnderLogScanStorm(t *testing.T) {
+	require := require.New(t)
+	cfg := initCfg(require)
+	cfg.Plugins[config.GatewayPlugin] = nil
+	cfg.API.HeavyReadShedLatency = 10 * time.Second
+	test := newE2ETest(t, cfg)
+	defer test.teardown()
+
+	var (
+		chainID  = test.cfg.Chain.ID
+		senderID = 1
+		bc       = test.cs.Blockchain()
+		ap       = test.cs.ActionPool()
+		getLogs  = &iotexapi.GetLogsRequest{
+			Filter: &iotexapi.LogsFilter{},
+			Lookup: &iotexapi.GetLogsRequest_ByRange{ByRange: &iotexapi.GetLogsByRange{FromBlock: 1}},
+		}
+		shedCode = func() codes.Code {
+			_, err := test.api.GetLogs(context.Background(), getLogs)
+			return status.Code(err)
+		}
+	)
+	// the blocks committed an hour after their timestamps put the node under pressure
+	base := time.Now().Add(-time.Hour)
+	for i := 0; i < 10; i++ {
+		_, err := createAndCommitBlock(bc, ap, base.Add(time.Duration(i)*time.Second))
+		require.NoError(err)
+	}
+	getLogs.GetByRange().ToBlock = bc.TipHeight()
+	require.Eventually(func() bool { return shedCode() == codes.Unavailable }, 5*time.Second, 10*time.Millisecond)
+
+	// the latencies of sending actions stay flat while the heavy reads are shed
+	sendLatencies := func(n int) []time.Duration {
+		latencies := make([]time.Duration, 0, n)
+		for i := 0; i < n; i++ {
+			selp, err := action.SignedTransfer(identityset.Address(3).String(), identityset.PrivateKey(senderID), test.nonceMgr.pop(identityset.Address(senderID).String()), big.NewInt(1), nil, gasLimit, gasPrice, action.WithChainID(chainID))
+			require.NoError(err)
+			start := time.Now()
+			_, err = test.api.SendAction(context.Background(), &iotexapi.SendActionRequest{Action: selp.Proto()})
+			latencies = append(latencies, time.Since(start))
+			require.NoError(err)
+		}
+		slices.Sort(latencies)
+		return latencies
+	}
+	p99 := func(latencies []time.Duration) time.Duration {
+		return latencies[len(latencies)*99/100]
+	}
+	baseline := p99(sendLatencies(200))
+
+	var (
+		stop = make(chan struct{})
+		wg   sync.WaitGroup
+		shed atomic.Int64
+	)
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				if shedCode() == codes.Unavailable {
+					shed.Add(1)
+				}
+			}
+		}()
+	}
+	storm := p99(sendLatencies(200))
+	close(stop)
+	wg.Wait()
+	require.Positive(shed.Load())
+	t.Logf("p99 of sending actions: %s before the log-scan storm, %s during it", baseline, storm)
+	require.LessOrEqual(storm, max(5*baseline, 200*time.Millisecond))
+
+	// the heavy reads are served again once the commits catch up
+	_, err := createAndCommitBlock(bc, ap, time.Now())
+	require.NoError(err)
+	require.Eventually(func() bool { return shedCode() == codes.OK }, 5*time.Second, 10*time.Millisecond)
+}
@@ -7,13 +7,16 @@ package e2etest
 
 import (
 	"context"
+	"fmt"
 	"math/big"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 
@@ -402,7 +405,22 @@ func TestLocalSync(t *testing.T) {
 	})
 	require.NoError(err)
 	nDao := nChain.BlockDAO()
+	// poll eth_syncing on the client, the progress is monotone until it reports not syncing
+	web3, err := ethclient.DialContext(ctx, fmt.Sprintf("http://localhost:%d", cfg.API.HTTPPort))
+	require.NoError(err)
+	defer web3.Close()
+	var lastSynced uint64
 	check := testutil.CheckCondition(func() (bool, error) {
+		progress, err := web3.SyncProgress(ctx)
+		if err != nil {
+			return false, err
+		}
+		if progress != nil {
+			if progress.CurrentBlock < lastSynced || progress.CurrentBlock > progress.HighestBlock {
+				return false, errors.Errorf("invalid sync progress %+v after height %d", progress, lastSynced)
+			}
+			lastSynced = progress.CurrentBlock
+		}
 		for i := uint64(1); i <= 5; i++ {
 			blk, err := nDao.GetBlockByHeight(i)
 			if err != nil {
@@ -416,6 +434,9 @@ func TestLocalSync(t *testing.T) {
 	})
 	require.NoError(testutil.WaitUntil(time.Millisecond*100, time.Second*60, check))
 	require.EqualValues(5, nChain.Blockchain().TipHeight())
+	progress, err := web3.SyncProgress(ctx)
+	require.NoError(err)
+	require.Nil(progress)
 }
 
 func TestStartExistingBlockchain(t *testing.T) {
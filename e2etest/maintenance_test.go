@@ -0,0 +1,122 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package e2etest
+
+import (
+	"context"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/lifecycle"
+	"github.com/iotexproject/iotex-core/server/itx"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestMaintenanceMode(t *testing.T) {
+	require := require.New(t)
+	cfg := initCfg(require)
+	cfg.Plugins[config.GatewayPlugin] = nil
+	cfg.API.MaintenanceFile = filepath.Join(t.TempDir(), "maintenance.json")
+	test := newE2ETest(t, cfg)
+	defer test.teardown()
+
+	var (
+		chainID   = test.cfg.Chain.ID
+		senderID  = 1
+		bc        = test.cs.Blockchain()
+		ap        = test.cs.ActionPool()
+		readiness lifecycle.Readiness
+		admin     = itx.NewMaintenanceHandler(test.svr.APIServer(chainID).Maintenance(), &readiness)
+		toggle    = func(query string) {
+			w := httptest.NewRecorder()
+			admin.Handle(w, httptest.NewRequest(http.MethodPost, "/maintenance?"+query, nil))
+			require.Equal(http.StatusOK, w.Code)
+		}
+		getLogs = func() error {
+			_, err := test.api.GetLogs(context.Background(), &iotexapi.GetLogsRequest{
+				Filter: &iotexapi.LogsFilter{},
+				Lookup: &iotexapi.GetLogsRequest_ByRange{ByRange: &iotexapi.GetLogsByRange{FromBlock: 1, ToBlock: bc.TipHeight()}},
+			})
+			return err
+		}
+	)
+	require.NoError(readiness.TurnOn())
+	_, err := createAndCommitBlock(bc, ap, time.Now())
+	require.NoError(err)
+	require.NoError(getLogs())
+
+	// subscribe to the blocks, and wait until the stream receives one
+	stream, err := test.api.StreamBlocks(context.Background(), &iotexapi.StreamBlocksRequest{})
+	require.NoError(err)
+	streamErr := make(chan error, 1)
+	received := make(chan struct{}, 100)
+	go func() {
+		for {
+			if _, err := stream.Recv(); err != nil {
+				streamErr <- err
+				return
+			}
+			received <- struct{}{}
+		}
+	}()
+	require.Eventually(func() bool {
+		_, err := createAndCommitBlock(bc, ap, time.Now())
+		require.NoError(err)
+		select {
+		case <-received:
+			return true
+		case <-time.After(50 * time.Millisecond):
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the heavy reads are rejected with a retry time in the maintenance mode, and the node isn't ready
+	toggle("action=enable&retryAfter=30s&grace=100ms")
+	require.False(readiness.IsReady())
+	st := status.Convert(getLogs())
+	require.Equal(codes.Unavailable, st.Code())
+	require.Len(st.Details(), 1)
+	require.Equal(30*time.Second, st.Details()[0].(*errdetails.RetryInfo).GetRetryDelay().AsDuration())
+
+	// the actions are still accepted, and the blocks are still committed
+	for i := 0; i < 3; i++ {
+		selp, err := action.SignedTransfer(identityset.Address(3).String(), identityset.PrivateKey(senderID), test.nonceMgr.pop(identityset.Address(senderID).String()), big.NewInt(1), nil, gasLimit, gasPrice, action.WithChainID(chainID))
+		require.NoError(err)
+		_, err = test.api.SendAction(context.Background(), &iotexapi.SendActionRequest{Action: selp.Proto()})
+		require.NoError(err)
+		height := bc.TipHeight()
+		blk, err := createAndCommitBlock(bc, ap, time.Now())
+		require.NoError(err)
+		require.Equal(height+1, bc.TipHeight())
+		require.Len(blk.Actions, 2)
+	}
+
+	// the stream is closed after the grace period
+	select {
+	case err := <-streamErr:
+		require.Equal(io.EOF, err)
+	case <-time.After(5 * time.Second):
+		require.Fail("the stream is not closed after the grace period")
+	}
+
+	// the heavy reads are served again once the maintenance mode is disabled
+	toggle("action=disable")
+	require.True(readiness.IsReady())
+	require.NoError(getLogs())
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package e2etest
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/scheme/rolldpos"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestReportMisbehavior(t *testing.T) {
+	require := require.New(t)
+	cfg := initCfg(require)
+	cfg.Genesis.ToBeEnabledBlockHeight = 1
+	cfg.Plugins[config.GatewayPlugin] = nil
+	test := newE2ETest(t, cfg)
+	defer test.teardown()
+
+	var (
+		chainID        = test.cfg.Chain.ID
+		ownerID        = 1
+		operatorID     = 5
+		reporterID     = 2
+		registerAmount = unit.ConvertIotxToRau(1200000)
+		slashed        = new(big.Int).Div(registerAmount, big.NewInt(10))
+		bounty         = new(big.Int).Div(slashed, big.NewInt(10))
+		ts             = time.Now()
+		initBalance, _ = new(big.Int).SetString(test.cfg.Genesis.InitBalanceMap[identityset.Address(reporterID).String()], 10)
+	)
+	// the operator of the candidate signs two commit votes for different blocks at the same height and round
+	doubleSign := func(blk string) []byte {
+		blkHash := hash.Hash256b([]byte(blk))
+		vote := rolldpos.NewConsensusVote(blkHash[:], rolldpos.COMMIT)
+		en, err := endorsement.Endorse(identityset.PrivateKey(operatorID), vote, ts)
+		require.NoError(err)
+		msg, err := rolldpos.NewEndorsedConsensusMessage(10, vote, en).Proto()
+		require.NoError(err)
+		b, err := proto.Marshal(msg)
+		require.NoError(err)
+		return b
+	}
+	first, second := doubleSign("block"), doubleSign("conflicting block")
+	report := func(first, second []byte) *actionWithTime {
+		return &actionWithTime{mustNoErr(action.SignedReportMisbehavior(test.nonceMgr.pop(identityset.Address(reporterID).String()), first, second, gasLimit, gasPrice, identityset.PrivateKey(reporterID), action.WithChainID(chainID))), time.Now()}
+	}
+	// the penalty lands once, whatever is reported afterwards
+	penalized := func(nonce uint64) []actionExpect {
+		return []actionExpect{
+			&functionExpect{func(test *e2etest, act *action.SealedEnvelope, receipt *action.Receipt, err error) {
+				cand, err := test.getCandidateByName("cand1")
+				require.NoError(err)
+				require.Equal(new(big.Int).Sub(registerAmount, slashed).String(), cand.SelfStakingTokens)
+			}},
+			&accountExpect{identityset.Address(reporterID), new(big.Int).Add(initBalance, bounty).String(), nonce},
+		}
+	}
+	alreadyReported := &basicActionExpect{nil, uint64(action.ReceiptStatusErrMisbehaviorReported), ""}
+	test.run([]*testcase{
+		{
+			name: "report double sign",
+			preActs: []*actionWithTime{
+				{mustNoErr(action.SignedCandidateRegister(test.nonceMgr.pop(identityset.Address(ownerID).String()), "cand1", identityset.Address(operatorID).String(), identityset.Address(ownerID).String(), identityset.Address(ownerID).String(), registerAmount.String(), 1, true, nil, gasLimit, gasPrice, identityset.PrivateKey(ownerID), action.WithChainID(chainID))), time.Now()},
+			},
+			act:    report(first, second),
+			expect: append([]actionExpect{successExpect}, penalized(1)...),
+		},
+		{
+			name:   "duplicate report rejected",
+			act:    report(second, first),
+			expect: append([]actionExpect{alreadyReported}, penalized(2)...),
+		},
+		{
+			name:   "report of another conflicting message rejected",
+			act:    report(first, doubleSign("another block")),
+			expect: append([]actionExpect{alreadyReported}, penalized(3)...),
+		},
+	})
+}
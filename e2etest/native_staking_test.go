@@ -29,6 +29,7 @@ import (
 	"github.com/iotexproject/iotex-core/server/itx"
 	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil/testchain"
 )
 
 const (
@@ -1374,83 +1375,86 @@ func initCfg(r *require.Assertions) config.Config {
 }
 
 func TestCandidateOwnerCollision(t *testing.T) {
-	require := require.New(t)
-	initCfg := func() config.Config {
-		cfg := deepcopy.Copy(config.Default).(config.Config)
-		initDBPaths(require, &cfg)
-
-		cfg.ActPool.MinGasPriceStr = "0"
-		cfg.Chain.TrieDBPatchFile = ""
-		cfg.Consensus.Scheme = config.NOOPScheme
-		cfg.Chain.EnableAsyncIndexWrite = false
-		cfg.Genesis.InitBalanceMap[identityset.Address(1).String()] = "100000000000000000000000000"
-		cfg.Genesis.InitBalanceMap[identityset.Address(2).String()] = "100000000000000000000000000"
-		cfg.Genesis.EndorsementWithdrawWaitingBlocks = 10
-		cfg.Genesis.TsunamiBlockHeight = 1
-		cfg.Genesis.UpernavikBlockHeight = 2 // enable CandidateIdentifiedByOwner feature
-		normalizeGenesisHeights(&cfg)
-		return cfg
-	}
 	registerAmount, _ := big.NewInt(0).SetString("1200000000000000000000000", 10)
-	gasLimit = uint64(10000000)
-	gasPrice = big.NewInt(1)
+	initBalance, _ := big.NewInt(0).SetString("100000000000000000000000000", 10)
+	chain := testchain.NewBuilder(t).
+		Genesis(func(g *genesis.Genesis) {
+			g.EndorsementWithdrawWaitingBlocks = 10
+			g.TsunamiBlockHeight = 1
+			g.UpernavikBlockHeight = 2 // enable CandidateIdentifiedByOwner feature
+			normalizeGenesisHeights(g)
+		}).
+		Fund(identityset.Address(1), initBalance).
+		Fund(identityset.Address(2), initBalance).
+		Build()
+	chainID := action.WithChainID(chain.ChainID())
+	register := func(ownerID int, name string, addrID int) *action.SealedEnvelope {
+		return chain.Sign(identityset.PrivateKey(ownerID), func(nonce, gasLimit uint64, gasPrice *big.Int) (*action.SealedEnvelope, error) {
+			return action.SignedCandidateRegister(nonce, name, identityset.Address(addrID).String(), identityset.Address(addrID).String(), identityset.Address(ownerID).String(), registerAmount.String(), 1, true, nil, gasLimit, gasPrice, identityset.PrivateKey(ownerID), chainID)
+		})
+	}
+	transferOwnership := func(ownerID, newOwnerID int) *action.SealedEnvelope {
+		return chain.Sign(identityset.PrivateKey(ownerID), func(nonce, gasLimit uint64, gasPrice *big.Int) (*action.SealedEnvelope, error) {
+			return action.SignedCandidateTransferOwnership(nonce, identityset.Address(newOwnerID).String(), nil, gasLimit, gasPrice, identityset.PrivateKey(ownerID), chainID)
+		})
+	}
 	oldOwnerID := 1
 	newOwnerID := 2
 	newOwnerID2 := 3
-	test := newE2ETest(t, initCfg())
-	defer test.teardown()
-	chainID := test.cfg.Chain.ID
-	test.run([]*testcase{
-		{
-			name: "owner cannot register again",
-			preActs: []*actionWithTime{
-				{mustNoErr(action.SignedCandidateRegister(test.nonceMgr.pop(identityset.Address(oldOwnerID).String()), "cand1", identityset.Address(1).String(), identityset.Address(1).String(), identityset.Address(oldOwnerID).String(), registerAmount.String(), 1, true, nil, gasLimit, gasPrice, identityset.PrivateKey(oldOwnerID), action.WithChainID(chainID))), time.Now()},
-			},
-			act:    &actionWithTime{mustNoErr(action.SignedCandidateRegister(test.nonceMgr.pop(identityset.Address(oldOwnerID).String()), "cand2", identityset.Address(2).String(), identityset.Address(2).String(), identityset.Address(oldOwnerID).String(), registerAmount.String(), 1, true, nil, gasLimit, gasPrice, identityset.PrivateKey(oldOwnerID), action.WithChainID(chainID))), time.Now()},
-			expect: []actionExpect{&basicActionExpect{nil, uint64(iotextypes.ReceiptStatus_ErrCandidateAlreadyExist), ""}},
-		},
-		{
-			name:    "original owner cannot register again",
-			preActs: []*actionWithTime{{mustNoErr(action.SignedCandidateTransferOwnership(test.nonceMgr.pop(identityset.Address(oldOwnerID).String()), identityset.Address(newOwnerID).String(), nil, gasLimit, gasPrice, identityset.PrivateKey(oldOwnerID), action.WithChainID(chainID))), time.Now()}},
-			act:     &actionWithTime{mustNoErr(action.SignedCandidateRegister(test.nonceMgr.pop(identityset.Address(oldOwnerID).String()), "cand2", identityset.Address(2).String(), identityset.Address(2).String(), identityset.Address(oldOwnerID).String(), registerAmount.String(), 1, true, nil, gasLimit, gasPrice, identityset.PrivateKey(oldOwnerID), action.WithChainID(chainID))), time.Now()},
-			expect:  []actionExpect{&basicActionExpect{nil, uint64(iotextypes.ReceiptStatus_ErrCandidateAlreadyExist), ""}},
-		},
-		{
-			name:    "cannot transfer to a new owner that same as an existed candidate identifier",
-			preActs: []*actionWithTime{{mustNoErr(action.SignedCandidateRegister(test.nonceMgr.pop(identityset.Address(newOwnerID2).String()), "cand2", identityset.Address(3).String(), identityset.Address(3).String(), identityset.Address(newOwnerID2).String(), registerAmount.String(), 1, true, nil, gasLimit, gasPrice, identityset.PrivateKey(newOwnerID2), action.WithChainID(chainID))), time.Now()}},
-			act:     &actionWithTime{mustNoErr(action.SignedCandidateTransferOwnership(test.nonceMgr.pop(identityset.Address(newOwnerID2).String()), identityset.Address(oldOwnerID).String(), nil, gasLimit, gasPrice, identityset.PrivateKey(newOwnerID2), action.WithChainID(chainID))), time.Now()},
-			expect:  []actionExpect{&basicActionExpect{nil, uint64(iotextypes.ReceiptStatus_ErrCandidateAlreadyExist), ""}},
-		},
+
+	t.Run("owner cannot register again", func(t *testing.T) {
+		pre := register(oldOwnerID, "cand1", 1)
+		chain.MintBlock(pre)
+		chain.RequireReceiptStatus(pre, iotextypes.ReceiptStatus_Success)
+		act := register(oldOwnerID, "cand2", 2)
+		chain.MintBlock(act)
+		chain.RequireReceiptStatus(act, iotextypes.ReceiptStatus_ErrCandidateAlreadyExist)
+	})
+	t.Run("original owner cannot register again", func(t *testing.T) {
+		pre := transferOwnership(oldOwnerID, newOwnerID)
+		chain.MintBlock(pre)
+		chain.RequireReceiptStatus(pre, iotextypes.ReceiptStatus_Success)
+		act := register(oldOwnerID, "cand2", 2)
+		chain.MintBlock(act)
+		chain.RequireReceiptStatus(act, iotextypes.ReceiptStatus_ErrCandidateAlreadyExist)
+	})
+	t.Run("cannot transfer to a new owner that same as an existed candidate identifier", func(t *testing.T) {
+		pre := register(newOwnerID2, "cand2", 3)
+		chain.MintBlock(pre)
+		chain.RequireReceiptStatus(pre, iotextypes.ReceiptStatus_Success)
+		act := transferOwnership(newOwnerID2, oldOwnerID)
+		chain.MintBlock(act)
+		chain.RequireReceiptStatus(act, iotextypes.ReceiptStatus_ErrCandidateAlreadyExist)
 	})
 }
 
-func normalizeGenesisHeights(cfg *config.Config) {
+func normalizeGenesisHeights(g *genesis.Genesis) {
 	heights := []*uint64{
-		&cfg.Genesis.PacificBlockHeight,
-		&cfg.Genesis.AleutianBlockHeight,
-		&cfg.Genesis.BeringBlockHeight,
-		&cfg.Genesis.CookBlockHeight,
-		&cfg.Genesis.DardanellesBlockHeight,
-		&cfg.Genesis.DaytonaBlockHeight,
-		&cfg.Genesis.EasterBlockHeight,
-		&cfg.Genesis.FbkMigrationBlockHeight,
-		&cfg.Genesis.FairbankBlockHeight,
-		&cfg.Genesis.GreenlandBlockHeight,
-		&cfg.Genesis.HawaiiBlockHeight,
-		&cfg.Genesis.IcelandBlockHeight,
-		&cfg.Genesis.JutlandBlockHeight,
-		&cfg.Genesis.KamchatkaBlockHeight,
-		&cfg.Genesis.LordHoweBlockHeight,
-		&cfg.Genesis.MidwayBlockHeight,
-		&cfg.Genesis.NewfoundlandBlockHeight,
-		&cfg.Genesis.OkhotskBlockHeight,
-		&cfg.Genesis.PalauBlockHeight,
-		&cfg.Genesis.QuebecBlockHeight,
-		&cfg.Genesis.RedseaBlockHeight,
-		&cfg.Genesis.SumatraBlockHeight,
-		&cfg.Genesis.TsunamiBlockHeight,
-		&cfg.Genesis.UpernavikBlockHeight,
-		&cfg.Genesis.ToBeEnabledBlockHeight,
+		&g.PacificBlockHeight,
+		&g.AleutianBlockHeight,
+		&g.BeringBlockHeight,
+		&g.CookBlockHeight,
+		&g.DardanellesBlockHeight,
+		&g.DaytonaBlockHeight,
+		&g.EasterBlockHeight,
+		&g.FbkMigrationBlockHeight,
+		&g.FairbankBlockHeight,
+		&g.GreenlandBlockHeight,
+		&g.HawaiiBlockHeight,
+		&g.IcelandBlockHeight,
+		&g.JutlandBlockHeight,
+		&g.KamchatkaBlockHeight,
+		&g.LordHoweBlockHeight,
+		&g.MidwayBlockHeight,
+		&g.NewfoundlandBlockHeight,
+		&g.OkhotskBlockHeight,
+		&g.PalauBlockHeight,
+		&g.QuebecBlockHeight,
+		&g.RedseaBlockHeight,
+		&g.SumatraBlockHeight,
+		&g.TsunamiBlockHeight,
+		&g.UpernavikBlockHeight,
+		&g.ToBeEnabledBlockHeight,
 	}
 	for i := len(heights) - 2; i >= 0; i-- {
 		if *(heights[i]) > *(heights[i+1]) {
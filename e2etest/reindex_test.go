@@ -0,0 +1,172 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package e2etest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/chainservice"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/util/fileutil"
+	"github.com/iotexproject/iotex-core/server/itx"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestReindexWhileCommitting(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	cfg, err := newTestConfig()
+	require.NoError(err)
+	cfg.Chain.TrieDBPatchFile = ""
+	cfg.Chain.ChainDBPath = filepath.Join(dataDir, "chain.db")
+	cfg.Chain.TrieDBPath = filepath.Join(dataDir, "trie.db")
+	cfg.Chain.IndexDBPath = filepath.Join(dataDir, "index.db")
+	cfg.Chain.BloomfilterIndexDBPath = filepath.Join(dataDir, "bloomfilter.index.db")
+	cfg.Chain.CandidateIndexDBPath = filepath.Join(dataDir, "candidate.index.db")
+	cfg.Chain.ContractStakingIndexDBPath = filepath.Join(dataDir, "contractstaking.index.db")
+	cfg.Chain.EnableAsyncIndexWrite = true
+	cfg.Plugins[config.GatewayPlugin] = true
+	defer delete(cfg.Plugins, config.GatewayPlugin)
+	commit := func(bc blockchain.Blockchain, n int) {
+		for i := 0; i < n; i++ {
+			blk, err := bc.MintNewBlock(testutil.TimestampNow())
+			require.NoError(err)
+			require.NoError(bc.CommitBlock(blk))
+		}
+	}
+	// checkIndex checks the actions of the blocks are indexed at their heights
+	checkIndex := func(cs *chainservice.ChainService, tip uint64) {
+		require.Eventually(func() bool {
+			height, err := cs.Indexer().Height()
+			require.NoError(err)
+			return height == tip
+		}, 10*time.Second, 10*time.Millisecond)
+		total := uint64(0)
+		for h := uint64(1); h <= tip; h++ {
+			blk, err := cs.BlockDAO().GetBlockByHeight(h)
+			require.NoError(err)
+			for _, act := range blk.Actions {
+				actHash, err := act.Hash()
+				require.NoError(err)
+				index, err := cs.Indexer().GetActionIndex(actHash[:])
+				require.NoError(err)
+				require.Equal(h, index.BlockHeight())
+			}
+			total += uint64(len(blk.Actions))
+		}
+		actions, err := cs.Indexer().GetTotalActions()
+		require.NoError(err)
+		require.Equal(total, actions)
+	}
+
+	svr, err := itx.NewServer(cfg)
+	require.NoError(err)
+	require.NoError(svr.Start(ctx))
+	cs := svr.ChainService(cfg.Chain.ID)
+	bc := cs.Blockchain()
+	require.NoError(addTestingTsfBlocks(bc, cs.ActionPool()))
+	commit(bc, 100)
+	tip := bc.TipHeight()
+	checkIndex(cs, tip)
+	blk, err := cs.BlockDAO().GetBlockByHeight(2)
+	require.NoError(err)
+	actHash, err := blk.Actions[0].Hash()
+	require.NoError(err)
+	require.NoError(svr.Stop(ctx))
+
+	// rebuild the index while blocks are being committed
+	cfg.Indexer.Reindex = true
+	// slow down the reindex, so it lasts while the blocks are committed
+	cfg.Indexer.ReindexBatchSize = 10
+	cfg.Indexer.ReindexInterval = 100 * time.Millisecond
+	svr, err = itx.NewServer(cfg)
+	require.NoError(err)
+	require.NoError(svr.Start(ctx))
+	cs = svr.ChainService(cfg.Chain.ID)
+	bc = cs.Blockchain()
+	status := func() map[string]interface{} {
+		w := httptest.NewRecorder()
+		itx.NewIndexerHandler(cs).Handle(w, httptest.NewRequest(http.MethodGet, "/indexer", nil))
+		require.Equal(http.StatusOK, w.Code)
+		s := map[string]interface{}{}
+		require.NoError(json.Unmarshal(w.Body.Bytes(), &s))
+		return s
+	}
+	// interrupt the reindex, which is resumed on restart
+	require.Eventually(func() bool {
+		return status()["currentHeight"].(float64) >= 20
+	}, 30*time.Second, 10*time.Millisecond)
+	require.NoError(svr.Stop(ctx))
+	require.True(fileutil.FileExists(cfg.Chain.IndexDBPath + ".reindex"))
+	cfg.Indexer.Reindex = false
+	svr, err = itx.NewServer(cfg)
+	require.NoError(err)
+	require.NoError(svr.Start(ctx))
+	cs = svr.ChainService(cfg.Chain.ID)
+	bc = cs.Blockchain()
+	s := status()
+	require.True(s["reindexing"].(bool))
+	require.GreaterOrEqual(s["currentHeight"].(float64), float64(20))
+	var (
+		committed     = make(chan struct{})
+		duringReindex = 0
+	)
+	go func() {
+		defer close(committed)
+		for i := 0; i < 20; i++ {
+			reindexing := status()["reindexing"].(bool)
+			commit(bc, 1)
+			if reindexing {
+				duringReindex++
+			}
+		}
+	}()
+	// the index keeps serving the reads
+	for done := false; !done; {
+		select {
+		case <-committed:
+			done = true
+		default:
+			index, err := cs.Indexer().GetActionIndex(actHash[:])
+			require.NoError(err)
+			require.EqualValues(2, index.BlockHeight())
+		}
+	}
+	require.Eventually(func() bool {
+		return !status()["reindexing"].(bool)
+	}, 30*time.Second, 10*time.Millisecond)
+	require.Positive(duringReindex)
+	s = status()
+	require.Nil(s["error"])
+	tip = bc.TipHeight()
+	require.EqualValues(tip, s["targetHeight"])
+	require.EqualValues(tip, s["currentHeight"])
+	checkIndex(cs, tip)
+	require.False(fileutil.FileExists(cfg.Chain.IndexDBPath + ".reindex"))
+	require.NoError(svr.Stop(ctx))
+
+	// the index rebuilt is used after restart
+	svr, err = itx.NewServer(cfg)
+	require.NoError(err)
+	require.NoError(svr.Start(ctx))
+	defer func() {
+		require.NoError(svr.Stop(ctx))
+	}()
+	cs = svr.ChainService(cfg.Chain.ID)
+	checkIndex(cs, tip)
+	s = status()
+	require.False(s["reindexing"].(bool))
+}
@@ -12,20 +12,23 @@ import (
 	"time"
 
 	"github.com/cenkalti/backoff"
-	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-proto/golang/iotexapi"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/actionpb"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
 	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/api"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
@@ -38,6 +41,7 @@ import (
 	"github.com/iotexproject/iotex-core/state/factory"
 	"github.com/iotexproject/iotex-core/test/identityset"
 	"github.com/iotexproject/iotex-core/testutil"
+	"github.com/iotexproject/iotex-core/testutil/testchain"
 	"github.com/iotexproject/iotex-core/tools/util"
 )
 
@@ -62,77 +66,214 @@ const (
 
 func TestBlockReward(t *testing.T) {
 	r := require.New(t)
-	cfg := config.Default
-	initDBPaths(r, &cfg)
-	defer func() { clearDBPaths(&cfg) }()
-	cfg.Consensus.Scheme = config.RollDPoSScheme
-	cfg.Genesis.NumDelegates = 1
-	cfg.Genesis.NumSubEpochs = 10
-	cfg.Genesis.Delegates = []genesis.Delegate{
-		{
-			OperatorAddrStr: identityset.Address(0).String(),
-			RewardAddrStr:   identityset.Address(0).String(),
-			VotesStr:        "10",
-		},
-	}
-	cfg.Genesis.BlockInterval = time.Second
-	cfg.Consensus.RollDPoS.FSM.AcceptBlockTTL = 300 * time.Millisecond
-	cfg.Consensus.RollDPoS.FSM.AcceptProposalEndorsementTTL = 300 * time.Millisecond
-	cfg.Consensus.RollDPoS.FSM.AcceptLockEndorsementTTL = 300 * time.Millisecond
-	cfg.Consensus.RollDPoS.FSM.CommitTTL = 100 * time.Millisecond
-	cfg.Genesis.EnableGravityChainVoting = false
-	cfg.Chain.ProducerPrivKey = identityset.PrivateKey(0).HexString()
-	cfg.Network.Port = testutil.RandomPort()
-	cfg.Genesis.PollMode = "lifeLong"
-
-	svr, err := itx.NewServer(cfg)
-	require.NoError(t, err)
-	require.NoError(t, svr.Start(context.Background()))
-	defer func() {
-		require.NoError(t, svr.Stop(context.Background()))
-	}()
-
-	require.NoError(t, testutil.WaitUntil(100*time.Millisecond, 20*time.Second, func() (b bool, e error) {
-		return svr.ChainService(1).Blockchain().TipHeight() >= 5, nil
-	}))
+	chain := testchain.NewBuilder(t).
+		Delegates(big.NewInt(10), identityset.PrivateKey(0)).
+		Genesis(func(g *genesis.Genesis) {
+			g.NumSubEpochs = 10
+			g.EnableGravityChainVoting = false
+			g.PollMode = "lifeLong"
+		}).
+		Build()
+	chain.MintBlocks(5)
 
 	ctx := protocol.WithBlockCtx(
-		context.Background(),
+		genesis.WithGenesisContext(context.Background(), chain.Genesis()),
 		protocol.BlockCtx{
 			BlockHeight: 0,
 		},
 	)
-	ctx = genesis.WithGenesisContext(ctx, cfg.Genesis)
 	ctx = protocol.WithFeatureCtx(ctx)
-
-	rp := rewarding.FindProtocol(svr.ChainService(1).Registry())
-	require.NotNil(t, rp)
-	sf := svr.ChainService(1).StateFactory()
-
-	sk, err := crypto.HexStringToPrivateKey(cfg.Chain.ProducerPrivKey)
-	require.NoError(t, err)
-	addr := sk.PublicKey().Address()
-	require.NotNil(t, addr)
-
+	rp := rewarding.FindProtocol(chain.Registry())
+	r.NotNil(rp)
+	sf := chain.StateFactory()
 	blockReward, err := rp.BlockReward(ctx, sf)
-	require.NoError(t, err)
-	balance, _, err := rp.UnclaimedBalance(ctx, sf, addr)
-	require.NoError(t, err)
-	assert.True(t, balance.Cmp(big.NewInt(0).Mul(blockReward, big.NewInt(5))) <= 0)
+	r.NoError(err)
+	balance, _, err := rp.UnclaimedBalance(ctx, sf, chain.Producer().PublicKey().Address())
+	r.NoError(err)
+	r.Equal(new(big.Int).Mul(blockReward, big.NewInt(5)), balance)
 
 	for i := 1; i <= 5; i++ {
-		blk, err := svr.ChainService(1).BlockDAO().GetBlockByHeight(uint64(i))
-		require.NoError(t, err)
+		blk, err := chain.BlockDAO().GetBlockByHeight(uint64(i))
+		r.NoError(err)
 		ok := false
 		var gr *action.GrantReward
 		for _, act := range blk.Body.Actions {
 			gr, ok = act.Action().(*action.GrantReward)
 			if ok {
-				assert.Equal(t, uint64(i), gr.Height())
+				r.Equal(uint64(i), gr.Height())
 				break
 			}
 		}
-		assert.True(t, ok)
+		r.True(ok)
+	}
+}
+
+func TestBlockRewardPayoutSplit(t *testing.T) {
+	r := require.New(t)
+	selfStake, _ := new(big.Int).SetString("1200000000000000000000000", 10)
+	producer := identityset.Address(0)
+	chain := testchain.NewBuilder(t).
+		Delegates(big.NewInt(10), identityset.PrivateKey(0)).
+		Candidate("producer", producer, selfStake).
+		Genesis(func(g *genesis.Genesis) {
+			g.NumSubEpochs = 4
+			g.EnableGravityChainVoting = false
+			g.PollMode = "lifeLong"
+			g.ToBeEnabledBlockHeight = 1
+		}).
+		Build()
+	rp := rewarding.FindProtocol(chain.Registry())
+	r.NotNil(rp)
+	rollDPoS := rolldpos.FindProtocol(chain.Registry())
+	r.NotNil(rollDPoS)
+	ctx := protocol.WithFeatureCtx(protocol.WithBlockCtx(
+		genesis.WithGenesisContext(context.Background(), chain.Genesis()),
+		protocol.BlockCtx{BlockHeight: 0},
+	))
+	unclaimed := func(addr address.Address) *big.Int {
+		balance, _, err := rp.UnclaimedBalance(ctx, chain.StateFactory(), addr)
+		r.NoError(err)
+		return balance
+	}
+	blockReward, err := rp.BlockReward(ctx, chain.StateFactory())
+	r.NoError(err)
+
+	split := []action.PayoutShare{
+		{Address: identityset.Address(5), BasisPoints: 3333},
+		{Address: identityset.Address(6), BasisPoints: 6667},
+	}
+	update := chain.Sign(identityset.PrivateKey(0), func(nonce, gasLimit uint64, gasPrice *big.Int) (*action.SealedEnvelope, error) {
+		return action.SignedCandidateUpdateWithPayoutSplit(nonce, "producer", "", "", split, gasLimit, gasPrice,
+			identityset.PrivateKey(0), action.WithChainID(chain.ChainID()))
+	})
+	blk := chain.MintBlock(update)
+	chain.RequireReceiptStatus(update, iotextypes.ReceiptStatus_Success)
+	epoch := rollDPoS.GetEpochNum(blk.Height())
+
+	// the split is returned by ReadState, and takes effect in the next epoch
+	method, err := proto.Marshal(&iotexapi.ReadStakingDataMethod{Method: iotexapi.ReadStakingDataMethod_CANDIDATE_BY_NAME})
+	r.NoError(err)
+	arg, err := proto.Marshal(&iotexapi.ReadStakingDataRequest{
+		Request: &iotexapi.ReadStakingDataRequest_CandidateByName_{
+			CandidateByName: &iotexapi.ReadStakingDataRequest_CandidateByName{CandName: "producer"},
+		},
+	})
+	r.NoError(err)
+	data, err := chain.ReadState("staking", method, arg)
+	r.NoError(err)
+	var cand iotextypes.CandidateV2
+	r.NoError(proto.Unmarshal(data, &cand))
+	ext := actionpb.CandidateV2Ext{}
+	r.NoError(action.LoadUnknownFields(&cand, &ext))
+	next, err := action.PayoutSplitFromProto(ext.GetNextPayoutSplit())
+	r.NoError(err)
+	r.Equal(split, next)
+
+	for rollDPoS.GetEpochNum(chain.TipHeight()+1) == epoch {
+		before := unclaimed(producer)
+		chain.MintBlock()
+		r.True(unclaimed(producer).Cmp(new(big.Int).Add(before, blockReward)) >= 0)
+		r.Zero(unclaimed(split[0].Address).Sign())
+		r.Zero(unclaimed(split[1].Address).Sign())
+	}
+	// the residue of the rounding goes to the first address
+	before := []*big.Int{unclaimed(producer), unclaimed(split[0].Address), unclaimed(split[1].Address)}
+	chain.MintBlock()
+	share := new(big.Int).Div(new(big.Int).Mul(blockReward, big.NewInt(6667)), big.NewInt(10000))
+	r.Equal(before[0], unclaimed(producer))
+	r.Equal(new(big.Int).Add(before[1], new(big.Int).Sub(blockReward, share)), unclaimed(split[0].Address))
+	r.Equal(new(big.Int).Add(before[2], share), unclaimed(split[1].Address))
+}
+
+func TestBaseFeeSupply(t *testing.T) {
+	for _, redistribute := range []bool{false, true} {
+		t.Run(fmt.Sprintf("redistribute=%t", redistribute), func(t *testing.T) {
+			r := require.New(t)
+			producer := identityset.PrivateKey(0)
+			chain := testchain.NewBuilder(t).
+				Delegates(big.NewInt(10), producer).
+				GasPrice(big.NewInt(2 * action.InitialBaseFee)).
+				Genesis(func(g *genesis.Genesis) {
+					g.NumSubEpochs = 2
+					g.EnableGravityChainVoting = false
+					g.PollMode = "lifeLong"
+					g.ToBeEnabledBlockHeight = 1
+					normalizeGenesisHeights(g)
+					// the base fee is charged since vanuatu
+					g.VanuatuBlockHeight = 1
+					g.Rewarding.RedistributeBaseFee = redistribute
+				}).
+				Build()
+			rp := rewarding.FindProtocol(chain.Registry())
+			r.NotNil(rp)
+			rollDPoS := rolldpos.FindProtocol(chain.Registry())
+			r.NotNil(rollDPoS)
+			g := chain.Genesis()
+			allocation := g.TotalAllocation()
+			burnAddr, err := address.FromString(address.ZeroAddress)
+			r.NoError(err)
+			// the tokens are held by the funded accounts, the burn address and the rewarding fund
+			holders := []address.Address{burnAddr}
+			for addr := range g.InitBalanceMap {
+				holder, err := address.FromString(addr)
+				r.NoError(err)
+				holders = append(holders, holder)
+			}
+
+			prev, err := rp.TotalSupply(chain.Context(), chain.StateFactory())
+			r.NoError(err)
+			for rollDPoS.GetEpochNum(chain.TipHeight()+1) <= 4 {
+				acts := []*action.SealedEnvelope{
+					chain.Transfer(identityset.PrivateKey(1), identityset.Address(2), big.NewInt(1)),
+					chain.Transfer(identityset.PrivateKey(2), identityset.Address(3), big.NewInt(1)),
+				}
+				unclaimed, _, err := rp.UnclaimedBalance(chain.Context(), chain.StateFactory(), producer.PublicKey().Address())
+				r.NoError(err)
+				if unclaimed.Sign() > 0 {
+					acts = append(acts, chain.Sign(producer, func(nonce, gasLimit uint64, gasPrice *big.Int) (*action.SealedEnvelope, error) {
+						return action.SignedClaimReward(nonce, gasLimit, gasPrice, producer, unclaimed, nil, nil, action.WithChainID(chain.ChainID()))
+					}))
+				}
+				blk := chain.MintBlock(acts...)
+				for _, selp := range acts {
+					chain.RequireReceiptStatus(selp, iotextypes.ReceiptStatus_Success)
+				}
+
+				supply, err := rp.TotalSupply(chain.Context(), chain.StateFactory())
+				r.NoError(err)
+				r.Equal(blk.Height(), supply.Height)
+				// the base fee of the block goes to either the burn address or the fund, which is charged since the
+				// second block, as the base fee is set by the block ahead
+				switch {
+				case blk.Height() == 1:
+					r.Zero(supply.BlockBurned.Sign())
+					r.Zero(supply.BlockRedistributed.Sign())
+				case redistribute:
+					r.Zero(supply.BlockBurned.Sign())
+					r.Equal(1, supply.BlockRedistributed.Sign())
+				default:
+					r.Equal(1, supply.BlockBurned.Sign())
+					r.Zero(supply.BlockRedistributed.Sign())
+				}
+				r.Equal(new(big.Int).Add(prev.Burned, supply.BlockBurned).String(), supply.Burned.String())
+				r.Equal(new(big.Int).Add(prev.Redistributed, supply.BlockRedistributed).String(), supply.Redistributed.String())
+				r.Equal(chain.Balance(burnAddr).String(), supply.Burned.String())
+
+				// the fund and the supply tracker reconcile with the genesis allocation
+				total, _, err := rp.TotalBalance(chain.Context(), chain.StateFactory())
+				r.NoError(err)
+				held := new(big.Int).Set(total)
+				for _, holder := range holders {
+					held.Add(held, chain.Balance(holder))
+				}
+				r.Equal(allocation.String(), held.String())
+				r.Equal(new(big.Int).Sub(allocation, supply.Burned).String(), supply.Total.String())
+				available, _, err := rp.AvailableBalance(chain.Context(), chain.StateFactory())
+				r.NoError(err)
+				r.Equal(new(big.Int).Sub(supply.Total, available).String(), supply.Circulating.String())
+				prev = supply
+			}
+		})
 	}
 }
 
@@ -482,7 +623,7 @@ func TestClaimReward(t *testing.T) {
 	cfg.Genesis.UpernavikBlockHeight = 10
 	cfg.Genesis.InitBalanceMap[producerSK.PublicKey().Address().String()] = "100000000000000000000000000"
 	cfg.Plugins[config.GatewayPlugin] = struct{}{}
-	normalizeGenesisHeights(&cfg)
+	normalizeGenesisHeights(&cfg.Genesis)
 	// new e2e test
 	test := newE2ETest(t, cfg)
 	defer test.teardown()
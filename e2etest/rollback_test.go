@@ -0,0 +1,104 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package e2etest
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/backup"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/chainservice"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/server/itx"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestRollback(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	cfg, err := newTestConfig()
+	require.NoError(err)
+	cfg.Chain.TrieDBPatchFile = ""
+	cfg.Chain.ChainDBPath = filepath.Join(dataDir, "chain.db")
+	cfg.Chain.TrieDBPath = filepath.Join(dataDir, "trie.db")
+	cfg.Chain.IndexDBPath = filepath.Join(dataDir, "index.db")
+	cfg.Chain.BloomfilterIndexDBPath = filepath.Join(dataDir, "bloomfilter.index.db")
+	cfg.Chain.CandidateIndexDBPath = filepath.Join(dataDir, "candidate.index.db")
+	cfg.Chain.ContractStakingIndexDBPath = filepath.Join(dataDir, "contractstaking.index.db")
+	cfg.Plugins[config.GatewayPlugin] = true
+	defer delete(cfg.Plugins, config.GatewayPlugin)
+
+	svr, err := itx.NewServer(cfg)
+	require.NoError(err)
+	require.NoError(svr.Start(ctx))
+	cs := svr.ChainService(cfg.Chain.ID)
+	bc := cs.Blockchain()
+	require.NoError(addTestingTsfBlocks(bc, cs.ActionPool()))
+	for i := 0; i < 100; i++ {
+		blk, err := bc.MintNewBlock(testutil.TimestampNow())
+		require.NoError(err)
+		require.NoError(bc.CommitBlock(blk))
+	}
+	tip := bc.TipHeight()
+	tipHash := bc.TipHash()
+	target := tip - 50
+	// keep the blocks above the target to sync forward after the rollback
+	blks := make([]*block.Block, 0, tip-target)
+	for h := target + 1; h <= tip; h++ {
+		blk, err := cs.BlockDAO().GetBlockByHeight(h)
+		require.NoError(err)
+		blks = append(blks, blk)
+	}
+	require.NoError(svr.Stop(ctx))
+
+	svr, err = itx.NewServer(cfg)
+	require.NoError(err)
+	cs = svr.ChainService(cfg.Chain.ID)
+	for _, height := range []uint64{0, tip, tip + 1} {
+		_, err = cs.PlanRollback(ctx, height, "")
+		require.Equal(chainservice.ErrRollbackRefused, errors.Cause(err))
+	}
+	plan, err := cs.PlanRollback(ctx, target, "")
+	require.NoError(err)
+	require.Equal(tip, plan.TipHeight)
+	require.Equal(target, plan.TargetHeight)
+	require.Contains(plan.Indexers, backup.IndexStore)
+	require.Contains(plan.Rebuilt, backup.StateStore)
+	require.Contains(plan.Rebuilt, backup.BloomfilterIndexStore)
+	require.NoError(cs.Rollback(ctx, plan))
+
+	// the states are replayed on start, and the chain resyncs forward to the same tip
+	svr, err = itx.NewServer(cfg)
+	require.NoError(err)
+	require.NoError(svr.Start(ctx))
+	defer func() {
+		require.NoError(svr.Stop(ctx))
+	}()
+	cs = svr.ChainService(cfg.Chain.ID)
+	bc = cs.Blockchain()
+	require.Equal(target, bc.TipHeight())
+	height, err := cs.StateFactory().Height()
+	require.NoError(err)
+	require.Equal(target, height)
+	height, err = cs.Indexer().Height()
+	require.NoError(err)
+	require.Equal(target, height)
+	for _, blk := range blks {
+		require.NoError(bc.ValidateBlock(blk))
+		require.NoError(bc.CommitBlock(blk))
+	}
+	require.Equal(tip, bc.TipHeight())
+	require.Equal(tipHash, bc.TipHash())
+	height, err = cs.StateFactory().Height()
+	require.NoError(err)
+	require.Equal(tip, height)
+}
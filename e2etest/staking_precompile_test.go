@@ -0,0 +1,157 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package e2etest
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+// _stakingWrapperCode deploys a contract forwarding its call data and value to the staking system contract,
+// assembled from
+//
+//	sstore(0, add(sload(0), 1))
+//	calldatacopy(0, 0, calldatasize())
+//	if iszero(call(gas(), STAKING, callvalue(), 0, calldatasize(), 0, 32)) {
+//		returndatacopy(0, 0, returndatasize())
+//		revert(0, returndatasize())
+//	}
+//	mstore(32, selfbalance())
+//	return(0, 64)
+const _stakingWrapperCode = "604480600b6000396000f3" + "600160005401600055" + "366000600037" + "602060003660003473%x5af1" +
+	"603a57" + "3d6000803e" + "3d6000fd" + "5b" + "47602052" + "60406000f3"
+
+func TestStakingPrecompile(t *testing.T) {
+	require := require.New(t)
+	cfg := initCfg(require)
+	cfg.Genesis.ToBeEnabledBlockHeight = 1
+	cfg.Plugins[config.GatewayPlugin] = nil
+	test := newE2ETest(t, cfg)
+	defer test.teardown()
+
+	var (
+		chainID        = test.cfg.Chain.ID
+		deployerID     = 2
+		registerAmount = unit.ConvertIotxToRau(1200000)
+		fund           = unit.ConvertIotxToRau(100)
+		stakeAmount    = unit.ConvertIotxToRau(1000)
+		depositAmount  = unit.ConvertIotxToRau(500)
+		wrapperAddr    string
+	)
+	bytecode, err := hex.DecodeString(fmt.Sprintf(_stakingWrapperCode, evm.StakingPrecompileAddress.Bytes()))
+	require.NoError(err)
+	register := func(ownerID, operatorID int, name string) *actionWithTime {
+		return &actionWithTime{mustNoErr(action.SignedCandidateRegister(test.nonceMgr.pop(identityset.Address(ownerID).String()), name, identityset.Address(operatorID).String(), identityset.Address(ownerID).String(), identityset.Address(ownerID).String(), registerAmount.String(), 1, true, nil, gasLimit, gasPrice, identityset.PrivateKey(ownerID), action.WithChainID(chainID))), time.Now()}
+	}
+	test.run([]*testcase{
+		{
+			name:    "deploy contract calling staking system contract",
+			preActs: []*actionWithTime{register(1, 3, "cand1"), register(2, 4, "cand2")},
+			act:     &actionWithTime{mustNoErr(action.SignedExecution("", identityset.PrivateKey(deployerID), test.nonceMgr.pop(identityset.Address(deployerID).String()), fund, gasLimit, gasPrice, bytecode, action.WithChainID(chainID))), time.Now()},
+			expect: []actionExpect{successExpect, &functionExpect{func(test *e2etest, act *action.SealedEnvelope, receipt *action.Receipt, err error) {
+				wrapperAddr = receipt.ContractAddress
+			}}},
+		},
+	})
+	require.NotEmpty(wrapperAddr)
+	cand2, err := test.getCandidateByName("cand2")
+	require.NoError(err)
+
+	var (
+		autoStakeIdx = cand2.SelfStakeBucketIdx + 1
+		unstakeIdx   = autoStakeIdx + 1
+		abi          = evm.StakingPrecompileInterface()
+	)
+	call := func(amount *big.Int, method string, args ...any) *actionWithTime {
+		data, err := abi.Pack(method, args...)
+		require.NoError(err)
+		return &actionWithTime{mustNoErr(action.SignedExecution(wrapperAddr, identityset.PrivateKey(deployerID), test.nonceMgr.pop(identityset.Address(deployerID).String()), amount, gasLimit, gasPrice, data, action.WithChainID(chainID))), time.Now()}
+	}
+	bucketExpect := func(index uint64, amount *big.Int, cand int) actionExpect {
+		return &functionExpect{func(test *e2etest, act *action.SealedEnvelope, receipt *action.Receipt, err error) {
+			bkt, err := test.getBucket(index, "")
+			require.NoError(err)
+			require.NotNil(bkt)
+			require.Equal(wrapperAddr, bkt.Owner)
+			require.Equal(amount.String(), bkt.StakedAmount)
+			require.Equal(identityset.Address(cand).String(), bkt.CandidateAddress)
+		}}
+	}
+	// the value sent along funds the bucket, so the balance of the contract stays the same
+	balanceExpect := &functionExpect{func(test *e2etest, act *action.SealedEnvelope, receipt *action.Receipt, err error) {
+		resp, err := test.api.GetAccount(context.Background(), &iotexapi.GetAccountRequest{Address: wrapperAddr})
+		require.NoError(err)
+		require.Equal(fund.String(), resp.AccountMeta.Balance)
+	}}
+	reverted := &basicActionExpect{nil, uint64(iotextypes.ReceiptStatus_ErrExecutionReverted), ""}
+	test.run([]*testcase{
+		{
+			name: "create stake",
+			act:  call(stakeAmount, "createStake", "cand1", stakeAmount, uint32(1), true, []uint8{}),
+			expect: []actionExpect{successExpect, balanceExpect, bucketExpect(autoStakeIdx, stakeAmount, 1), &functionExpect{func(test *e2etest, act *action.SealedEnvelope, receipt *action.Receipt, err error) {
+				var found bool
+				for _, l := range receipt.Logs() {
+					found = found || l.Address == address.StakingProtocolAddr
+				}
+				require.True(found)
+			}}},
+		},
+		{
+			name:   "deposit to stake",
+			act:    call(depositAmount, "depositToStake", autoStakeIdx, depositAmount, []uint8{}),
+			expect: []actionExpect{successExpect, balanceExpect, bucketExpect(autoStakeIdx, new(big.Int).Add(stakeAmount, depositAmount), 1)},
+		},
+		{
+			name:   "change candidate",
+			act:    call(big.NewInt(0), "changeCandidate", "cand2", autoStakeIdx, []uint8{}),
+			expect: []actionExpect{successExpect, bucketExpect(autoStakeIdx, new(big.Int).Add(stakeAmount, depositAmount), 2)},
+		},
+		{
+			name:   "change to nonexistent candidate reverted",
+			act:    call(big.NewInt(0), "changeCandidate", "cand9", autoStakeIdx, []uint8{}),
+			expect: []actionExpect{reverted, bucketExpect(autoStakeIdx, new(big.Int).Add(stakeAmount, depositAmount), 2)},
+		},
+		{
+			name:   "amount not matching value reverted",
+			act:    call(stakeAmount, "createStake", "cand1", depositAmount, uint32(0), false, []uint8{}),
+			expect: []actionExpect{reverted, balanceExpect, &noBucketExpect{unstakeIdx, ""}},
+		},
+		{
+			name:   "create stake without auto-stake",
+			act:    call(stakeAmount, "createStake", "cand1", stakeAmount, uint32(0), false, []uint8{}),
+			expect: []actionExpect{successExpect, balanceExpect, bucketExpect(unstakeIdx, stakeAmount, 1)},
+		},
+		{
+			name: "unstake",
+			act:  call(big.NewInt(0), "unstake", unstakeIdx, []uint8{}),
+			expect: []actionExpect{successExpect, &functionExpect{func(test *e2etest, act *action.SealedEnvelope, receipt *action.Receipt, err error) {
+				bkt, err := test.getBucket(unstakeIdx, "")
+				require.NoError(err)
+				require.True(bkt.UnstakeStartTime.AsTime().After(time.Unix(0, 0)))
+			}}},
+		},
+		{
+			name:   "auto-stake bucket cannot be unstaked",
+			act:    call(big.NewInt(0), "unstake", autoStakeIdx, []uint8{}),
+			expect: []actionExpect{reverted},
+		},
+	})
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package e2etest
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockindex"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/server/itx"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+// transferEvent is a Transfer event emitted by the init code of transferEventsCode, the value is the 4th topic
+// of xrc721 event, or the data of xrc20 event in dataLen bytes
+type transferEvent struct {
+	from, to int
+	value    int64
+	xrc721   bool
+	dataLen  byte
+}
+
+// transferEventsCode returns the init code of a contract, which emits the events on deployment
+func transferEventsCode(events ...transferEvent) []byte {
+	var (
+		code  []byte
+		topic = crypto.Keccak256([]byte("Transfer(address,address,uint256)"))
+		push  = func(op byte, b []byte) {
+			code = append(code, op)
+			code = append(code, b...)
+		}
+	)
+	for _, e := range events {
+		value := big.NewInt(e.value).FillBytes(make([]byte, 32))
+		if e.xrc721 {
+			// PUSH32 token id as the 4th topic
+			push(0x7f, value)
+		} else {
+			// MSTORE value at 0
+			push(0x7f, value)
+			push(0x60, []byte{0})
+			code = append(code, 0x52)
+		}
+		// PUSH20 to, PUSH20 from, PUSH32 topic, PUSH1 size, PUSH1 offset
+		push(0x73, identityset.Address(e.to).Bytes())
+		push(0x73, identityset.Address(e.from).Bytes())
+		push(0x7f, topic)
+		push(0x60, []byte{e.dataLen})
+		push(0x60, []byte{0})
+		if e.xrc721 {
+			// LOG4
+			code = append(code, 0xa4)
+		} else {
+			// LOG3
+			code = append(code, 0xa3)
+		}
+	}
+	// STOP
+	return append(code, 0x00)
+}
+
+func TestTokenTransferIndexerBackfill(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	cfg, err := newTestConfig()
+	require.NoError(err)
+	cfg.Chain.TrieDBPatchFile = ""
+	cfg.Chain.ChainDBPath = filepath.Join(dataDir, "chain.db")
+	cfg.Chain.TrieDBPath = filepath.Join(dataDir, "trie.db")
+	cfg.Chain.IndexDBPath = filepath.Join(dataDir, "index.db")
+	cfg.Chain.BloomfilterIndexDBPath = filepath.Join(dataDir, "bloomfilter.index.db")
+	cfg.Chain.CandidateIndexDBPath = filepath.Join(dataDir, "candidate.index.db")
+	cfg.Chain.ContractStakingIndexDBPath = filepath.Join(dataDir, "contractstaking.index.db")
+	cfg.Chain.TokenTransferIndexDBPath = filepath.Join(dataDir, "tokentransfer.index.db")
+	cfg.Chain.EnableAsyncIndexWrite = false
+	// the topics of the logs are copied correctly since Aleutian
+	cfg.Genesis.PacificBlockHeight = 1
+	cfg.Genesis.AleutianBlockHeight = 1
+	cfg.Plugins[config.GatewayPlugin] = true
+	defer delete(cfg.Plugins, config.GatewayPlugin)
+
+	var (
+		deployer = 5
+		nonce    = uint64(1)
+		addr     = func(i int) hash.Hash160 {
+			return hash.BytesToHash160(identityset.Address(i).Bytes())
+		}
+	)
+	// deploy deploys the contract emitting the events, and returns the address of the contract
+	deploy := func(bc blockchain.Blockchain, svr *itx.Server, events ...transferEvent) hash.Hash160 {
+		cs := svr.ChainService(cfg.Chain.ID)
+		_, receipt, err := addOneTx(ctx, cs.ActionPool(), bc, &actionWithTime{mustNoErr(action.SignedExecution(
+			action.EmptyAddress, identityset.PrivateKey(deployer), nonce, big.NewInt(0), 1000000,
+			big.NewInt(testutil.TestGasPriceInt64), transferEventsCode(events...))), time.Now()})
+		require.NoError(err)
+		require.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+		nonce++
+		contract, err := address.FromString(receipt.ContractAddress)
+		require.NoError(err)
+		return hash.BytesToHash160(contract.Bytes())
+	}
+	transfers := func(tsfs []*blockindex.TokenTransfer, _ uint64, err error) []*blockindex.TokenTransfer {
+		require.NoError(err)
+		return tsfs
+	}
+
+	// the transfers are committed with the indexer disabled
+	svr, err := itx.NewServer(cfg)
+	require.NoError(err)
+	require.NoError(svr.Start(ctx))
+	bc := svr.ChainService(cfg.Chain.ID).Blockchain()
+	require.Nil(svr.ChainService(cfg.Chain.ID).TokenTransferIndexer())
+	xrc20 := deploy(bc, svr,
+		transferEvent{from: 0, to: 1, value: 100, dataLen: 32},
+		// non-standard event with the transfer topic is skipped
+		transferEvent{from: 1, to: 2, value: 10, dataLen: 3},
+	)
+	xrc721 := deploy(bc, svr, transferEvent{from: 1, to: 2, value: 7, xrc721: true})
+	require.NoError(svr.Stop(ctx))
+
+	// the history is indexed on start once enabled
+	cfg.Chain.EnableTokenTransferIndexer = true
+	svr, err = itx.NewServer(cfg)
+	require.NoError(err)
+	require.NoError(svr.Start(ctx))
+	defer func() {
+		require.NoError(svr.Stop(ctx))
+	}()
+	cs := svr.ChainService(cfg.Chain.ID)
+	bc = cs.Blockchain()
+	indexer := cs.TokenTransferIndexer()
+	require.NotNil(indexer)
+	height, err := indexer.Height()
+	require.NoError(err)
+	require.Equal(bc.TipHeight(), height)
+	tsfs := transfers(indexer.TransfersByContract(xrc20, &blockindex.TokenTransferQuery{Count: 10}))
+	require.Len(tsfs, 1)
+	require.Equal(blockindex.XRC20, tsfs[0].Standard)
+	require.Equal(addr(0), tsfs[0].From)
+	require.Equal(addr(1), tsfs[0].To)
+	require.Equal(big.NewInt(100), tsfs[0].Amount)
+	tsfs = transfers(indexer.TransfersByContract(xrc721, &blockindex.TokenTransferQuery{Count: 10}))
+	require.Len(tsfs, 1)
+	require.Equal(blockindex.XRC721, tsfs[0].Standard)
+	require.Equal(big.NewInt(7), tsfs[0].Amount)
+
+	// the new transfers are indexed on commit
+	deploy(bc, svr, transferEvent{from: 2, to: 1, value: 50, dataLen: 32})
+	tsfs = transfers(indexer.TransfersByAddress(addr(1), &blockindex.TokenTransferQuery{Count: 10}))
+	require.Len(tsfs, 3)
+	require.Equal(bc.TipHeight(), tsfs[2].Height)
+	require.Equal(addr(2), tsfs[2].From)
+	tsfs = transfers(indexer.TransfersByAddress(addr(2), &blockindex.TokenTransferQuery{Count: 10, FromHeight: bc.TipHeight()}))
+	require.Len(tsfs, 1)
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package e2etest
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/eth/tracers/logger"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+const (
+	// _revertCode deploys a contract which always reverts, assembled from revert(0, 0)
+	_revertCode = "6005600c60003960056000f3" + "60006000fd"
+	// _callRevertCode deploys a contract calling the contract at an address, and storing whether the call succeeded,
+	// assembled from sstore(0, call(gas(), CALLEE, 0, 0, 0, 0, 0))
+	_callRevertCode = "6021600c60003960216000f3" + "600080808080" + "73%x5af1" + "60005500"
+)
+
+func TestTraceBlockWithRevertedInternalCall(t *testing.T) {
+	require := require.New(t)
+	cfg := initCfg(require)
+	// the block is replayed on the state of its parent
+	cfg.Chain.EnableTrielessStateDB = false
+	cfg.Chain.EnableArchiveMode = true
+	cfg.Plugins[config.GatewayPlugin] = nil
+	test := newE2ETest(t, cfg)
+	defer test.teardown()
+
+	var (
+		chainID    = test.cfg.Chain.ID
+		senderID   = 2
+		calleeAddr string
+		callerAddr string
+		callHash   hash.Hash256
+		callHeight uint64
+	)
+	execute := func(contract string, data []byte) *actionWithTime {
+		return &actionWithTime{mustNoErr(action.SignedExecution(contract, identityset.PrivateKey(senderID), test.nonceMgr.pop(identityset.Address(senderID).String()), big.NewInt(0), gasLimit, gasPrice, data, action.WithChainID(chainID))), time.Now()}
+	}
+	bytecode, err := hex.DecodeString(_revertCode)
+	require.NoError(err)
+	test.run([]*testcase{
+		{
+			name: "deploy contract which reverts",
+			act:  execute("", bytecode),
+			expect: []actionExpect{successExpect, &functionExpect{func(test *e2etest, act *action.SealedEnvelope, receipt *action.Receipt, err error) {
+				calleeAddr = receipt.ContractAddress
+			}}},
+		},
+	})
+	callee, err := address.FromString(calleeAddr)
+	require.NoError(err)
+	bytecode, err = hex.DecodeString(fmt.Sprintf(_callRevertCode, callee.Bytes()))
+	require.NoError(err)
+	test.run([]*testcase{
+		{
+			name: "deploy contract calling it",
+			act:  execute("", bytecode),
+			expect: []actionExpect{successExpect, &functionExpect{func(test *e2etest, act *action.SealedEnvelope, receipt *action.Receipt, err error) {
+				callerAddr = receipt.ContractAddress
+			}}},
+		},
+	})
+	test.run([]*testcase{
+		{
+			name: "internal call reverted",
+			act:  execute(callerAddr, nil),
+			expect: []actionExpect{successExpect, &functionExpect{func(test *e2etest, act *action.SealedEnvelope, receipt *action.Receipt, err error) {
+				callHash = receipt.ActionHash
+				callHeight = receipt.BlockHeight
+			}}},
+		},
+	})
+
+	var (
+		ctx        = context.Background()
+		core       = test.svr.APIServer(chainID).CoreService()
+		callTracer = "callTracer"
+	)
+	traces, err := core.TraceBlockByNumber(ctx, callHeight, &tracers.TraceConfig{Tracer: &callTracer})
+	require.NoError(err)
+	require.Len(traces, 1)
+	require.Equal(callHash, traces[0].ActionHash)
+	result, err := traces[0].Tracer.(tracers.Tracer).GetResult()
+	require.NoError(err)
+	var frame struct {
+		Type  string `json:"type"`
+		To    string `json:"to"`
+		Error string `json:"error"`
+		Calls []struct {
+			Type  string `json:"type"`
+			From  string `json:"from"`
+			To    string `json:"to"`
+			Error string `json:"error"`
+		} `json:"calls"`
+	}
+	require.NoError(json.Unmarshal(result, &frame))
+	caller, err := address.FromString(callerAddr)
+	require.NoError(err)
+	ethAddr := func(addr address.Address) string {
+		return strings.ToLower(common.BytesToAddress(addr.Bytes()).Hex())
+	}
+	require.Equal("CALL", frame.Type)
+	require.Equal(ethAddr(caller), frame.To)
+	require.Empty(frame.Error)
+	require.Len(frame.Calls, 1)
+	require.Equal("CALL", frame.Calls[0].Type)
+	require.Equal(ethAddr(caller), frame.Calls[0].From)
+	require.Equal(ethAddr(callee), frame.Calls[0].To)
+	require.Equal(vm.ErrExecutionReverted.Error(), frame.Calls[0].Error)
+
+	// the transaction is replayed with the struct logger by default
+	_, receipt, tracer, err := core.TraceTransaction(ctx, hex.EncodeToString(callHash[:]), nil)
+	require.NoError(err)
+	require.Equal(callHeight, receipt.BlockHeight)
+	var reverted bool
+	for _, l := range tracer.(*logger.StructLogger).StructLogs() {
+		reverted = reverted || (l.Op == vm.REVERT && l.Depth == 2)
+	}
+	require.True(reverted)
+}
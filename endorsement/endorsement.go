@@ -13,6 +13,7 @@ import (
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	cp "github.com/iotexproject/iotex-core/crypto"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 )
 
@@ -64,7 +65,7 @@ func NewEndorsement(
 
 // Endorse endorses a document
 func Endorse(
-	signer crypto.PrivateKey,
+	signer cp.Signer,
 	doc Document,
 	ts time.Time,
 ) (*Endorsement, error) {
@@ -11,12 +11,28 @@ import "github.com/iotexproject/iotex-core/pkg/unit"
 type Config struct {
 	SuggestBlockWindow int    `yaml:"suggestBlockWindow"`
 	DefaultGas         uint64 `yaml:"defaultGas"`
-	Percentile         int    `yaml:"Percentile"`
+	// Percentile is the percentile of the standard tier
+	Percentile     int `yaml:"Percentile"`
+	SlowPercentile int `yaml:"slowPercentile"`
+	FastPercentile int `yaml:"fastPercentile"`
+	// NearEmptyBlockGasPercent is the percent of block gas limit, under which the block is taken as near-empty
+	NearEmptyBlockGasPercent int `yaml:"nearEmptyBlockGasPercent"`
+	// FeeHistoryMaxBlockCount is the max number of blocks in a fee history
+	FeeHistoryMaxBlockCount int `yaml:"feeHistoryMaxBlockCount"`
+	// GasTipCapFloor and GasTipCapCeiling bound the suggested gas tip cap
+	GasTipCapFloor   uint64 `yaml:"gasTipCapFloor"`
+	GasTipCapCeiling uint64 `yaml:"gasTipCapCeiling"`
 }
 
 // DefaultConfig is the default config
 var DefaultConfig = Config{
-	SuggestBlockWindow: 20,
-	DefaultGas:         uint64(unit.Qev),
-	Percentile:         60,
+	SuggestBlockWindow:       20,
+	DefaultGas:               uint64(unit.Qev),
+	Percentile:               60,
+	SlowPercentile:           40,
+	FastPercentile:           90,
+	NearEmptyBlockGasPercent: 1,
+	FeeHistoryMaxBlockCount:  1024,
+	GasTipCapFloor:           0,
+	GasTipCapCeiling:         uint64(unit.Qev) * 100,
 }
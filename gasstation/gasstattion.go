@@ -7,98 +7,365 @@ package gasstation
 
 import (
 	"context"
+	"math"
 	"math/big"
 	"sort"
+	"sync"
 
+	"github.com/iotexproject/go-pkgs/cache"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
 )
 
 // BlockDAO represents the block data access object
 type BlockDAO interface {
 	GetBlockHash(uint64) (hash.Hash256, error)
 	GetBlockByHeight(uint64) (*block.Block, error)
+	GetReceipts(uint64) ([]*action.Receipt, error)
 }
 
 // SimulateFunc is function that simulate execution
 type SimulateFunc func(context.Context, address.Address, *action.Execution, evm.GetBlockHash) ([]byte, *action.Receipt, error)
 
+// ErrNotFound indicates the block is not found
+var ErrNotFound = errors.New("block not found")
+
+// GasPrices are the suggested gas prices of the slow, standard and fast tiers
+type GasPrices struct {
+	Slow     uint64
+	Standard uint64
+	Fast     uint64
+}
+
+type (
+	// blockSample is the fees of a block, sampled once and shared by the gas price and tip suggestions and the fee
+	// history
+	blockSample struct {
+		baseFee  *big.Int
+		gasLimit uint64
+		// blockGas is the gas used by all actions, and gasUsed by the user actions
+		blockGas uint64
+		gasUsed  uint64
+		// prices are the sorted effective gas prices of the user actions
+		prices []*big.Int
+		// tips are the user actions sorted by effective tip
+		tips []actionTip
+	}
+
+	actionTip struct {
+		tip     *big.Int
+		gasUsed uint64
+	}
+
+	// suggestion is the suggested gas prices and tip at a tip height
+	suggestion struct {
+		height    uint64
+		prices    GasPrices
+		gasTipCap uint64
+	}
+)
+
 // GasStation provide gas related api
 type GasStation struct {
 	bc  blockchain.Blockchain
 	dao BlockDAO
 	cfg Config
+
+	samples    cache.LRUCache
+	mutex      sync.Mutex
+	suggestion *suggestion
 }
 
 // NewGasStation creates a new gas station
 func NewGasStation(bc blockchain.Blockchain, dao BlockDAO, cfg Config) *GasStation {
 	return &GasStation{
-		bc:  bc,
-		dao: dao,
-		cfg: cfg,
+		bc:      bc,
+		dao:     dao,
+		cfg:     cfg,
+		samples: cache.NewThreadSafeLruCache(cfg.SuggestBlockWindow + cfg.FeeHistoryMaxBlockCount),
 	}
 }
 
-// SuggestGasPrice suggest gas price
+// SuggestGasPrice suggest gas price of the standard tier
 func (gs *GasStation) SuggestGasPrice() (uint64, error) {
+	s, err := gs.suggest()
+	if err != nil {
+		return gs.cfg.DefaultGas, err
+	}
+	return s.prices.Standard, nil
+}
+
+// SuggestGasPrices suggests the gas prices of the tiers, which are the percentiles of the effective gas prices of
+// the user actions in the recent blocks. The suggestions decay toward the default gas price by the share of empty or
+// near-empty blocks
+func (gs *GasStation) SuggestGasPrices() (*GasPrices, error) {
+	s, err := gs.suggest()
+	if err != nil {
+		return nil, err
+	}
+	prices := s.prices
+	return &prices, nil
+}
+
+// SuggestGasTipCap suggests the gas tip cap, which is the standard percentile of the rewards at the standard
+// percentile in the fee history of the recent blocks, bounded by the floor and ceiling
+func (gs *GasStation) SuggestGasTipCap() (uint64, error) {
+	s, err := gs.suggest()
+	if err != nil {
+		return 0, err
+	}
+	return s.gasTipCap, nil
+}
+
+// FeeHistory returns the oldest block height, the rewards at the percentiles of the effective tips weighted by gas
+// used, the base fees including the one of the block after the newest, and the gas used ratios of the blocks, which
+// are at most the max block count up to the newest
+func (gs *GasStation) FeeHistory(blocks, newest uint64, percentiles []float64) (uint64, [][]*big.Int, []*big.Int, []float64, error) {
+	if newest > gs.bc.TipHeight() {
+		return 0, nil, nil, nil, errors.Wrapf(ErrNotFound, "height %d", newest)
+	}
+	if maxBlocks := uint64(gs.cfg.FeeHistoryMaxBlockCount); blocks > maxBlocks {
+		blocks = maxBlocks
+	}
+	if blocks > newest {
+		blocks = newest
+	}
+	if blocks == 0 {
+		return 0, nil, nil, nil, nil
+	}
+	var (
+		g             = gs.bc.Genesis()
+		oldest        = newest - blocks + 1
+		rewards       [][]*big.Int
+		baseFees      = make([]*big.Int, 0, blocks+1)
+		gasUsedRatios = make([]float64, 0, blocks)
+		last          *blockSample
+	)
+	for height := oldest; height <= newest; height++ {
+		sample, err := gs.sample(height, g)
+		if err != nil {
+			return 0, nil, nil, nil, err
+		}
+		baseFee := new(big.Int)
+		if sample.baseFee != nil {
+			baseFee.Set(sample.baseFee)
+		}
+		baseFees = append(baseFees, baseFee)
+		gasUsedRatios = append(gasUsedRatios, float64(sample.blockGas)/float64(sample.gasLimit))
+		if len(percentiles) > 0 {
+			rewards = append(rewards, sample.rewards(percentiles))
+		}
+		last = sample
+	}
+	next := new(big.Int)
+	switch {
+	case newest+1 < g.VanuatuBlockHeight:
+	case last.baseFee == nil:
+		next.SetUint64(action.InitialBaseFee)
+	default:
+		next = block.CalcBaseFee(g.Blockchain, &protocol.TipInfo{
+			Height:  newest,
+			GasUsed: last.blockGas,
+			BaseFee: last.baseFee,
+		})
+	}
+	return oldest, rewards, append(baseFees, next), gasUsedRatios, nil
+}
+
+// suggest returns the suggestion at the tip height, which is cached until the tip height changes
+func (gs *GasStation) suggest() (*suggestion, error) {
+	tip := gs.bc.TipHeight()
+	gs.mutex.Lock()
+	defer gs.mutex.Unlock()
+	if gs.suggestion != nil && gs.suggestion.height == tip {
+		return gs.suggestion, nil
+	}
 	var (
-		smallestPrices []*big.Int
 		endBlockHeight uint64
-		tip            = gs.bc.TipHeight()
 		g              = gs.bc.Genesis()
+		prices         []*big.Int
+		rewards        []*big.Int
+		blocks, busy   uint64
+		standard       = []float64{float64(gs.cfg.Percentile)}
 	)
 	if tip > uint64(gs.cfg.SuggestBlockWindow) {
 		endBlockHeight = tip - uint64(gs.cfg.SuggestBlockWindow)
 	}
-	maxGas := g.BlockGasLimitByHeight(tip) * (tip - endBlockHeight)
-	defaultGasPrice := gs.cfg.DefaultGas
-	gasConsumed := uint64(0)
 	for height := tip; height > endBlockHeight; height-- {
-		blk, err := gs.dao.GetBlockByHeight(height)
+		sample, err := gs.sample(height, g)
 		if err != nil {
-			return defaultGasPrice, err
+			return nil, err
 		}
-		if len(blk.Actions) == 0 {
+		blocks++
+		rewards = append(rewards, sample.rewards(standard)[0])
+		if len(sample.prices) == 0 || sample.gasUsed*100 < sample.gasLimit*uint64(gs.cfg.NearEmptyBlockGasPercent) {
 			continue
 		}
-		if len(blk.Actions) == 1 && action.IsSystemAction(blk.Actions[0]) {
-			continue
+		busy++
+		prices = append(prices, sample.prices...)
+	}
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].Cmp(prices[j]) < 0
+	})
+	percentile := func(p int) uint64 {
+		floor := gs.cfg.DefaultGas
+		if len(prices) == 0 {
+			return floor
 		}
-		smallestPrice := blk.Actions[0].GasPrice()
-		for _, receipt := range blk.Receipts {
-			gasConsumed += receipt.GasConsumed
+		price := prices[(len(prices)-1)*p/100]
+		if !price.IsUint64() {
+			return math.MaxUint64
 		}
-		for _, act := range blk.Actions {
-			if action.IsSystemAction(act) {
-				continue
-			}
-			if smallestPrice.Cmp(act.GasPrice()) == 1 {
-				smallestPrice = act.GasPrice()
-			}
+		if price.Uint64() <= floor {
+			return floor
 		}
-		smallestPrices = append(smallestPrices, smallestPrice)
+		// decay toward the floor by the share of empty or near-empty blocks
+		decayed := new(big.Int).Sub(price, new(big.Int).SetUint64(floor))
+		decayed.Mul(decayed, new(big.Int).SetUint64(busy))
+		decayed.Div(decayed, new(big.Int).SetUint64(blocks))
+		return floor + decayed.Uint64()
 	}
-	if len(smallestPrices) == 0 {
-		// return default price
-		return defaultGasPrice, nil
+	gs.suggestion = &suggestion{
+		height: tip,
+		prices: GasPrices{
+			Slow:     percentile(gs.cfg.SlowPercentile),
+			Standard: percentile(gs.cfg.Percentile),
+			Fast:     percentile(gs.cfg.FastPercentile),
+		},
+		gasTipCap: gs.gasTipCap(rewards),
 	}
-	sort.Slice(smallestPrices, func(i, j int) bool {
-		return smallestPrices[i].Cmp(smallestPrices[j]) < 0
+	return gs.suggestion, nil
+}
+
+// gasTipCap returns the standard percentile of the rewards, bounded by the floor and ceiling
+func (gs *GasStation) gasTipCap(rewards []*big.Int) uint64 {
+	if len(rewards) == 0 {
+		return gs.cfg.GasTipCapFloor
+	}
+	sort.Slice(rewards, func(i, j int) bool {
+		return rewards[i].Cmp(rewards[j]) < 0
 	})
-	gasPrice := smallestPrices[(len(smallestPrices)-1)*gs.cfg.Percentile/100].Uint64()
+	tip := rewards[(len(rewards)-1)*gs.cfg.Percentile/100]
 	switch {
-	case gasConsumed > maxGas/2:
-		gasPrice += gasPrice / 10
-	case gasConsumed < maxGas/5:
-		gasPrice -= gasPrice / 10
+	case !tip.IsUint64() || tip.Uint64() > gs.cfg.GasTipCapCeiling:
+		return gs.cfg.GasTipCapCeiling
+	case tip.Uint64() < gs.cfg.GasTipCapFloor:
+		return gs.cfg.GasTipCapFloor
+	default:
+		return tip.Uint64()
+	}
+}
+
+// sample returns the sample of the block at the height, from the cache if sampled before
+func (gs *GasStation) sample(height uint64, g genesis.Genesis) (*blockSample, error) {
+	if s, ok := gs.samples.Get(height); ok {
+		return s.(*blockSample), nil
+	}
+	blk, err := gs.dao.GetBlockByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := gs.dao.GetReceipts(height)
+	if err != nil {
+		return nil, err
+	}
+	s := sampleBlock(blk, receipts, g.BlockGasLimitByHeight(height))
+	gs.samples.Add(height, s)
+	return s, nil
+}
+
+// sampleBlock samples the effective gas prices and tips of the actions in the block, except the system actions and
+// the actions of the block producer
+func sampleBlock(blk *block.Block, receipts []*action.Receipt, gasLimit uint64) *blockSample {
+	var (
+		sample   = &blockSample{baseFee: blk.BaseFee(), gasLimit: gasLimit}
+		producer = blk.PublicKey()
+	)
+	gasConsumed := make(map[hash.Hash256]uint64, len(receipts))
+	for _, receipt := range receipts {
+		gasConsumed[receipt.ActionHash] = receipt.GasConsumed
+		sample.blockGas += receipt.GasConsumed
+	}
+	for _, act := range blk.Actions {
+		if action.IsSystemAction(act) {
+			continue
+		}
+		if producer != nil && act.SenderAddress().String() == producer.Address().String() {
+			continue
+		}
+		h, err := act.Hash()
+		if err != nil {
+			continue
+		}
+		sample.prices = append(sample.prices, effectiveGasPrice(act, sample.baseFee))
+		sample.tips = append(sample.tips, actionTip{
+			tip:     effectiveGasTip(act, sample.baseFee),
+			gasUsed: gasConsumed[h],
+		})
+		sample.gasUsed += gasConsumed[h]
+	}
+	sort.Slice(sample.prices, func(i, j int) bool {
+		return sample.prices[i].Cmp(sample.prices[j]) < 0
+	})
+	sort.SliceStable(sample.tips, func(i, j int) bool {
+		return sample.tips[i].tip.Cmp(sample.tips[j].tip) < 0
+	})
+	return sample
+}
+
+// rewards returns the effective tips at the ascending percentiles weighted by gas used, which are zero for a block
+// without user actions
+func (s *blockSample) rewards(percentiles []float64) []*big.Int {
+	rewards := make([]*big.Int, len(percentiles))
+	if len(s.tips) == 0 {
+		for i := range rewards {
+			rewards[i] = new(big.Int)
+		}
+		return rewards
+	}
+	var (
+		idx     int
+		sumUsed = s.tips[0].gasUsed
+	)
+	for i, p := range percentiles {
+		threshold := uint64(float64(s.gasUsed) * p / 100)
+		for sumUsed < threshold && idx < len(s.tips)-1 {
+			idx++
+			sumUsed += s.tips[idx].gasUsed
+		}
+		rewards[i] = new(big.Int).Set(s.tips[idx].tip)
+	}
+	return rewards
+}
+
+// effectiveGasPrice returns the gas price paid by the action, which is capped by the fee cap after the base fee
+func effectiveGasPrice(act *action.SealedEnvelope, baseFee *big.Int) *big.Int {
+	if baseFee == nil {
+		return act.GasPrice()
+	}
+	price := new(big.Int).Add(baseFee, act.GasTipCap())
+	if feeCap := act.GasFeeCap(); price.Cmp(feeCap) > 0 {
+		return feeCap
+	}
+	return price
+}
+
+// effectiveGasTip returns the gas price paid by the action above the base fee
+func effectiveGasTip(act *action.SealedEnvelope, baseFee *big.Int) *big.Int {
+	if baseFee == nil {
+		return act.GasPrice()
 	}
-	if gasPrice < defaultGasPrice {
-		gasPrice = defaultGasPrice
+	tip := new(big.Int).Sub(effectiveGasPrice(act, baseFee), baseFee)
+	if tip.Sign() < 0 {
+		return new(big.Int)
 	}
-	return gasPrice, nil
+	return tip
 }
@@ -49,9 +49,9 @@ type (
 		gasConsumed uint64
 	}
 	testCase struct {
-		name           string
-		blocks         []testActionGas
-		expectGasPrice uint64
+		name   string
+		blocks []testActionGas
+		expect GasPrices
 	}
 )
 
@@ -68,6 +68,7 @@ func newTestConfig() testConfig {
 		GasStation: DefaultConfig,
 	}
 	cfg.Genesis.BlockGasLimit = uint64(100000)
+	cfg.Genesis.TsunamiBlockGasLimit = uint64(100000)
 	cfg.Genesis.EnableGravityChainVoting = false
 
 	return cfg
@@ -150,7 +151,7 @@ func TestSuggestGasPriceForUserAction(t *testing.T) {
 	// i from 10 to 29,gasprice for 20 to 39,60%*20+20=31
 	require.Equal(
 		t,
-		big.NewInt(1).Mul(big.NewInt(int64(31)), big.NewInt(unit.Qev)).Uint64()*9/10,
+		big.NewInt(1).Mul(big.NewInt(int64(31)), big.NewInt(unit.Qev)).Uint64(),
 		gp,
 	)
 }
@@ -215,43 +216,36 @@ func TestSuggestGasPriceForSystemAction(t *testing.T) {
 	require.Equal(t, gs.cfg.DefaultGas, gp)
 }
 
-func TestSuggestGasPrice_GasConsumed(t *testing.T) {
+func TestSuggestGasPrices(t *testing.T) {
+	qev := func(n uint64) uint64 { return n * uint64(unit.Qev) }
 	cases := []testCase{
 		{
-			name: "gas consumed > maxGas/2",
+			name: "percentiles of busy blocks",
 			blocks: []testActionGas{
-				{{uint64(unit.Qev) * 2, 100000000}},
-				{{uint64(unit.Qev) * 2, 100000000}},
-				{{uint64(unit.Qev) * 2, 100000000}},
-				{{uint64(unit.Qev) * 2, 100000000}},
-				{{uint64(unit.Qev) * 2, 100000000}},
-				{{uint64(unit.Qev) * 2, 200000000}},
+				{{qev(1), 1000000}}, {{qev(2), 1000000}}, {{qev(3), 1000000}}, {{qev(4), 1000000}}, {{qev(5), 1000000}},
+				{{qev(6), 1000000}}, {{qev(7), 1000000}}, {{qev(8), 1000000}}, {{qev(9), 1000000}}, {{qev(10), 1000000}},
 			},
-			expectGasPrice: 2200000000000,
+			expect: GasPrices{Slow: qev(4), Standard: qev(6), Fast: qev(9)},
 		},
 		{
-			name: "gas consumed < maxGas/5",
+			name: "decay by near-empty blocks",
 			blocks: []testActionGas{
-				{{uint64(unit.Qev) * 2, 1000000}},
-				{{uint64(unit.Qev) * 2, 1000000}},
-				{{uint64(unit.Qev) * 2, 1000000}},
-				{{uint64(unit.Qev) * 2, 1000000}},
-				{{uint64(unit.Qev) * 2, 1000000}},
-				{{uint64(unit.Qev) * 2, 2000000}},
+				{{qev(11), 1000000}}, {{qev(100), 1000}}, {{qev(11), 1000000}}, {{qev(100), 1000}}, {{qev(11), 1000000}},
+				{{qev(100), 1000}}, {{qev(11), 1000000}}, {{qev(100), 1000}}, {{qev(11), 1000000}}, {},
 			},
-			expectGasPrice: 1800000000000,
+			expect: GasPrices{Slow: qev(6), Standard: qev(6), Fast: qev(6)},
 		},
 		{
-			name: "gas consumed between maxGas/5 - maxGas/2",
+			name:   "empty blocks",
+			blocks: []testActionGas{{}, {}, {}, {}, {}},
+			expect: GasPrices{Slow: qev(1), Standard: qev(1), Fast: qev(1)},
+		},
+		{
+			name: "under default gas",
 			blocks: []testActionGas{
-				{{uint64(unit.Qev) * 2, 10000000}},
-				{{uint64(unit.Qev) * 2, 10000000}},
-				{{uint64(unit.Qev) * 2, 10000000}},
-				{{uint64(unit.Qev) * 2, 10000000}},
-				{{uint64(unit.Qev) * 2, 10000000}},
-				{{uint64(unit.Qev) * 2, 5000000}},
+				{{qev(1) / 2, 1000000}}, {{qev(1) / 2, 1000000}}, {{qev(3), 1000000}}, {{qev(3), 1000000}},
 			},
-			expectGasPrice: 2000000000000,
+			expect: GasPrices{Slow: qev(1), Standard: qev(1), Fast: qev(3)},
 		},
 	}
 	for _, c := range cases {
@@ -262,20 +256,99 @@ func TestSuggestGasPrice_GasConsumed(t *testing.T) {
 			bc := mock_blockchain.NewMockBlockchain(ctrl)
 			dao := mock_blockdao.NewMockBlockDAO(ctrl)
 			gs := NewGasStation(bc, dao, DefaultConfig)
-			bc.EXPECT().TipHeight().Return(uint64(len(blocks) - 1)).Times(1)
+			bc.EXPECT().TipHeight().Return(uint64(len(blocks))).Times(2)
 			bc.EXPECT().Genesis().Return(genesis.Default).Times(1)
 			dao.EXPECT().GetBlockByHeight(gomock.Any()).DoAndReturn(
 				func(height uint64) (*block.Block, error) {
 					return blocks[height], nil
 				},
-			).AnyTimes()
+			).Times(len(blocks))
+			dao.EXPECT().GetReceipts(gomock.Any()).DoAndReturn(
+				func(height uint64) ([]*action.Receipt, error) {
+					return blocks[height].Receipts, nil
+				},
+			).Times(len(blocks))
+			prices, err := gs.SuggestGasPrices()
+			r.NoError(err)
+			r.Equal(c.expect, *prices)
+			// cached at the tip height
 			gp, err := gs.SuggestGasPrice()
 			r.NoError(err)
-			r.Equal(c.expectGasPrice, gp)
+			r.Equal(c.expect.Standard, gp)
 		})
 	}
+
+	t.Run("system and producer actions", func(t *testing.T) {
+		r := require.New(t)
+		producer := identityset.PrivateKey(27)
+		grant := action.GrantRewardBuilder{}
+		act := grant.SetHeight(1).Build()
+		grantReward, err := action.Sign((&action.EnvelopeBuilder{}).SetGasPrice(big.NewInt(0)).SetAction(&act).Build(), producer)
+		r.NoError(err)
+		own, err := action.SignedTransfer(identityset.Address(1).String(), producer, 1, big.NewInt(0), nil, 10000, big.NewInt(int64(qev(100))))
+		r.NoError(err)
+		user, err := action.SignedTransfer(identityset.Address(1).String(), identityset.PrivateKey(1), 1, big.NewInt(0), nil, 10000, big.NewInt(int64(qev(3))))
+		r.NoError(err)
+		blk, err := block.NewTestingBuilder().SetHeight(1).AddActions(own, user, grantReward).SignAndBuild(producer)
+		r.NoError(err)
+		blk.Receipts = make([]*action.Receipt, len(blk.Actions))
+		for i, act := range blk.Actions {
+			h, err := act.Hash()
+			r.NoError(err)
+			blk.Receipts[i] = &action.Receipt{ActionHash: h, GasConsumed: 1000000}
+		}
+
+		ctrl := gomock.NewController(t)
+		bc := mock_blockchain.NewMockBlockchain(ctrl)
+		dao := mock_blockdao.NewMockBlockDAO(ctrl)
+		gs := NewGasStation(bc, dao, DefaultConfig)
+		bc.EXPECT().TipHeight().Return(uint64(1)).Times(1)
+		bc.EXPECT().Genesis().Return(genesis.Default).Times(1)
+		dao.EXPECT().GetBlockByHeight(uint64(1)).Return(&blk, nil).Times(1)
+		dao.EXPECT().GetReceipts(uint64(1)).Return(blk.Receipts, nil).Times(1)
+		prices, err := gs.SuggestGasPrices()
+		r.NoError(err)
+		r.Equal(GasPrices{Slow: qev(3), Standard: qev(3), Fast: qev(3)}, *prices)
+	})
+
+	t.Run("sliding window", func(t *testing.T) {
+		r := require.New(t)
+		history := make([]testActionGas, 30)
+		for i := range history {
+			history[i] = testActionGas{{qev(uint64(i + 1)), 1000000}}
+		}
+		blocks := prepareBlocks(r, history)
+		ctrl := gomock.NewController(t)
+		bc := mock_blockchain.NewMockBlockchain(ctrl)
+		dao := mock_blockdao.NewMockBlockDAO(ctrl)
+		cfg := DefaultConfig
+		cfg.SuggestBlockWindow = 10
+		gs := NewGasStation(bc, dao, cfg)
+		bc.EXPECT().Genesis().Return(genesis.Default).Times(2)
+		dao.EXPECT().GetBlockByHeight(gomock.Any()).DoAndReturn(
+			func(height uint64) (*block.Block, error) {
+				return blocks[height], nil
+			},
+		).Times(11)
+		dao.EXPECT().GetReceipts(gomock.Any()).DoAndReturn(
+			func(height uint64) ([]*action.Receipt, error) {
+				return blocks[height].Receipts, nil
+			},
+		).Times(11)
+		bc.EXPECT().TipHeight().Return(uint64(20)).Times(1)
+		prices, err := gs.SuggestGasPrices()
+		r.NoError(err)
+		// the prices of blocks 11 to 20
+		r.Equal(GasPrices{Slow: qev(14), Standard: qev(16), Fast: qev(19)}, *prices)
+		// only the new block is loaded
+		bc.EXPECT().TipHeight().Return(uint64(21)).Times(1)
+		prices, err = gs.SuggestGasPrices()
+		r.NoError(err)
+		r.Equal(GasPrices{Slow: qev(15), Standard: qev(17), Fast: qev(20)}, *prices)
+	})
 }
 
+// prepareBlocks prepares the blocks at height 1 to len(cases)
 func prepareBlocks(r *require.Assertions, cases []testActionGas) map[uint64]*block.Block {
 	blocks := map[uint64]*block.Block{}
 	for i := range cases {
@@ -285,12 +358,112 @@ func prepareBlocks(r *require.Assertions, cases []testActionGas) map[uint64]*blo
 			seale, err := action.SignedTransfer(identityset.Address(1).String(), identityset.PrivateKey(1), 1, big.NewInt(0), []byte{}, 1000, big.NewInt(int64(gas.gasPrice)))
 			r.NoError(err)
 			actions = append(actions, seale)
-			receipts = append(receipts, &action.Receipt{GasConsumed: gas.gasConsumed})
+			h, err := seale.Hash()
+			r.NoError(err)
+			receipts = append(receipts, &action.Receipt{ActionHash: h, GasConsumed: gas.gasConsumed})
 		}
-		blocks[uint64(i)] = &block.Block{
+		blocks[uint64(i+1)] = &block.Block{
 			Body:     block.Body{Actions: actions},
 			Receipts: receipts,
 		}
 	}
 	return blocks
 }
+
+func TestFeeConsistency(t *testing.T) {
+	r := require.New(t)
+	var (
+		qev      = func(n int64) *big.Int { return new(big.Int).Mul(big.NewInt(n), big.NewInt(unit.Qev)) }
+		producer = identityset.PrivateKey(27)
+		baseFee  = new(big.Int).SetUint64(action.InitialBaseFee)
+		blocks   = map[uint64]*block.Block{}
+		receipts = map[uint64][]*action.Receipt{}
+		g        = genesis.Default
+	)
+	g.VanuatuBlockHeight = 1
+	// block h has the tips of h Qev with 30000 gas and 2h Qev with 10000 gas, block 6 is empty
+	for h := uint64(1); h <= 6; h++ {
+		var acts []*action.SealedEnvelope
+		if h < 6 {
+			for i, v := range []struct {
+				price *big.Int
+				gas   uint64
+			}{
+				{qev(1 + int64(h)), 30000},
+				{qev(1 + 2*int64(h)), 10000},
+			} {
+				selp, err := action.SignedTransfer(identityset.Address(1).String(), identityset.PrivateKey(i+1), h, big.NewInt(0), nil, v.gas, v.price)
+				r.NoError(err)
+				acts = append(acts, selp)
+				hash, err := selp.Hash()
+				r.NoError(err)
+				receipts[h] = append(receipts[h], &action.Receipt{ActionHash: hash, GasConsumed: v.gas})
+			}
+		}
+		blk, err := block.NewBuilder(block.NewRunnableActionsBuilder().AddActions(acts...).Build()).
+			SetHeight(h).SetBaseFee(baseFee).SignAndBuild(producer)
+		r.NoError(err)
+		blocks[h] = &blk
+	}
+
+	ctrl := gomock.NewController(t)
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	dao := mock_blockdao.NewMockBlockDAO(ctrl)
+	cfg := DefaultConfig
+	cfg.SuggestBlockWindow = 5
+	cfg.NearEmptyBlockGasPercent = 0
+	gs := NewGasStation(bc, dao, cfg)
+	tip := uint64(5)
+	bc.EXPECT().TipHeight().DoAndReturn(func() uint64 { return tip }).AnyTimes()
+	bc.EXPECT().Genesis().Return(g).AnyTimes()
+	// each block is loaded once and shared by the suggestions and fee history
+	dao.EXPECT().GetBlockByHeight(gomock.Any()).DoAndReturn(func(h uint64) (*block.Block, error) { return blocks[h], nil }).Times(6)
+	dao.EXPECT().GetReceipts(gomock.Any()).DoAndReturn(func(h uint64) ([]*action.Receipt, error) { return receipts[h], nil }).Times(6)
+
+	oldest, rewards, baseFees, ratios, err := gs.FeeHistory(5, 5, []float64{float64(cfg.Percentile), 90})
+	r.NoError(err)
+	r.EqualValues(1, oldest)
+	r.Len(baseFees, 6)
+	r.Len(ratios, 5)
+	r.Len(rewards, 5)
+	for i, reward := range rewards {
+		h := int64(i + 1)
+		r.Equal(baseFee, baseFees[i])
+		r.Equal([]*big.Int{qev(h), qev(2 * h)}, reward)
+		r.Equal(float64(40000)/float64(g.BlockGasLimitByHeight(uint64(h))), ratios[i])
+	}
+	// the base fee after a block under the gas target does not go below the initial base fee
+	r.Equal(baseFee, baseFees[5])
+
+	gasTipCap, err := gs.SuggestGasTipCap()
+	r.NoError(err)
+	standard := make([]*big.Int, len(rewards))
+	for i := range rewards {
+		standard[i] = rewards[i][0]
+	}
+	r.Equal(standard[(len(standard)-1)*cfg.Percentile/100].Uint64(), gasTipCap)
+	r.Equal(qev(3).Uint64(), gasTipCap)
+	prices, err := gs.SuggestGasPrices()
+	r.NoError(err)
+	r.Equal(qev(5).Uint64(), prices.Standard)
+	r.GreaterOrEqual(prices.Standard, baseFees[5].Uint64()+gasTipCap)
+
+	// the empty block has zero rewards and the tip cap is bounded by the ceiling
+	tip = 6
+	_, rewards, _, _, err = gs.FeeHistory(1, 6, []float64{50})
+	r.NoError(err)
+	r.Equal([][]*big.Int{{big.NewInt(0)}}, rewards)
+	gs.cfg.GasTipCapCeiling = qev(2).Uint64()
+	gasTipCap, err = gs.SuggestGasTipCap()
+	r.NoError(err)
+	r.Equal(qev(2).Uint64(), gasTipCap)
+
+	// the block count is capped by the newest height, and the newest is up to the tip
+	oldest, rewards, baseFees, _, err = gs.FeeHistory(100, 2, nil)
+	r.NoError(err)
+	r.EqualValues(1, oldest)
+	r.Nil(rewards)
+	r.Len(baseFees, 3)
+	_, _, _, _, err = gs.FeeHistory(1, 7, nil)
+	r.ErrorIs(err, ErrNotFound)
+}
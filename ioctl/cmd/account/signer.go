@@ -0,0 +1,130 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package account
+
+import (
+	"fmt"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/ioctl/config"
+	"github.com/iotexproject/iotex-core/ioctl/ledger"
+	"github.com/iotexproject/iotex-core/ioctl/output"
+	"github.com/iotexproject/iotex-core/ioctl/util"
+)
+
+type (
+	// Signer signs the actions with a key of keystore, HDWallet or ledger device
+	Signer interface {
+		// Address returns the address of the key
+		Address() address.Address
+		// SignEnvelope signs the envelope, the envelope is confirmed on device by a ledger signer
+		SignEnvelope(action.Envelope) (*action.SealedEnvelope, error)
+		// Close zeroes the private key or closes the device
+		Close() error
+	}
+
+	keySigner struct {
+		prvKey crypto.PrivateKey
+	}
+
+	ledgerSigner struct {
+		device *ledger.Device
+		path   ledger.Path
+		pk     crypto.PublicKey
+	}
+)
+
+// NewSigner returns the signer of the address or alias, the key of "hdw::" signer is derived from HDWallet and the
+// key of "ledger::" signer is on ledger device
+func NewSigner(signer, password string) (Signer, error) {
+	if !util.AliasIsLedgerKey(signer) {
+		prvKey, err := PrivateKeyFromSigner(signer, password)
+		if err != nil {
+			return nil, err
+		}
+		return &keySigner{prvKey: prvKey}, nil
+	}
+	account, change, index, err := util.ParseLedgerPath(signer)
+	if err != nil {
+		return nil, output.NewError(output.InputError, "invalid ledger key format", err)
+	}
+	transport, err := ledger.NewTCPTransport(config.ReadConfig.LedgerEndpoint)
+	if err != nil {
+		return nil, output.NewError(output.NetworkError, "failed to connect to ledger device", err)
+	}
+	s := &ledgerSigner{
+		device: ledger.NewDevice(transport),
+		path:   ledger.NewPath(account, change, index),
+	}
+	if s.pk, err = s.device.PublicKey(s.path); err != nil {
+		s.device.Close()
+		return nil, ledgerError(err)
+	}
+	return s, nil
+}
+
+func (s *keySigner) Address() address.Address {
+	return s.prvKey.PublicKey().Address()
+}
+
+func (s *keySigner) SignEnvelope(elp action.Envelope) (*action.SealedEnvelope, error) {
+	sealed, err := action.Sign(elp, s.prvKey)
+	if err != nil {
+		return nil, output.NewError(output.CryptoError, "failed to sign action", err)
+	}
+	return sealed, nil
+}
+
+func (s *keySigner) Close() error {
+	s.prvKey.Zero()
+	return nil
+}
+
+func (s *ledgerSigner) Address() address.Address {
+	return s.pk.Address()
+}
+
+func (s *ledgerSigner) SignEnvelope(elp action.Envelope) (*action.SealedEnvelope, error) {
+	core, err := proto.Marshal(elp.Proto())
+	if err != nil {
+		return nil, output.NewError(output.SerializationError, "failed to serialize envelope", err)
+	}
+	output.PrintQuery(fmt.Sprintf("Please confirm the action on ledger device with key %s", s.path))
+	sig, err := s.device.Sign(s.path, core)
+	if err != nil {
+		return nil, ledgerError(err)
+	}
+	sealed := action.AssembleSealedEnvelope(elp, s.pk, sig)
+	// the device signs the hash of the serialized envelope as verified on chain
+	if err := sealed.VerifySignature(); err != nil {
+		return nil, output.NewError(output.CryptoError, "the signature of ledger device does not match the action", err)
+	}
+	return sealed, nil
+}
+
+func (s *ledgerSigner) Close() error {
+	return s.device.Close()
+}
+
+func ledgerError(err error) error {
+	switch errors.Cause(err) {
+	case ledger.ErrRejected:
+		return output.NewError(output.InputError, "the action is rejected on ledger device", nil)
+	case ledger.ErrTimeout:
+		return output.NewError(output.NetworkError, "ledger device is not confirmed in time", err)
+	case ledger.ErrAppNotOpen:
+		return output.NewError(output.InputError, "please open the IoTeX app on ledger device", nil)
+	case ledger.ErrUnsupported:
+		return output.NewError(output.ValidationError, "the action is not supported by the IoTeX app, please update the app", err)
+	default:
+		return output.NewError(output.RuntimeError, "failed to sign with ledger device", err)
+	}
+}
@@ -81,12 +81,16 @@ func (m *sendMessage) String() string {
 func init() {
 	ActionCmd.AddCommand(_actionHashCmd)
 	ActionCmd.AddCommand(_actionTransferCmd)
+	ActionCmd.AddCommand(_actionCancelCmd)
+	ActionCmd.AddCommand(_actionBatchTransferCmd)
 	ActionCmd.AddCommand(_actionDeployCmd)
 	ActionCmd.AddCommand(_actionInvokeCmd)
 	ActionCmd.AddCommand(_actionReadCmd)
 	ActionCmd.AddCommand(_actionClaimCmd)
 	ActionCmd.AddCommand(_actionDepositCmd)
 	ActionCmd.AddCommand(_actionSendRawCmd)
+	ActionCmd.AddCommand(_actionSignCmd)
+	ActionCmd.AddCommand(_actionBroadcastCmd)
 	ActionCmd.PersistentFlags().StringVar(&config.ReadConfig.Endpoint, "endpoint",
 		config.ReadConfig.Endpoint, config.TranslateInLang(_flagActionEndPointUsages,
 			config.UILanguage))
@@ -101,7 +105,7 @@ func decodeBytecode() ([]byte, error) {
 // Signer returns signer's address
 func Signer() (address string, err error) {
 	addressOrAlias := _signerFlag.Value().(string)
-	if util.AliasIsHdwalletKey(addressOrAlias) {
+	if util.AliasIsHdwalletKey(addressOrAlias) || util.AliasIsLedgerKey(addressOrAlias) {
 		return addressOrAlias, nil
 	}
 
@@ -115,8 +119,8 @@ func Signer() (address string, err error) {
 }
 
 func nonce(executor string) (uint64, error) {
-	if util.AliasIsHdwalletKey(executor) {
-		// for hdwallet key, get the nonce in SendAction()
+	if util.AliasIsHdwalletKey(executor) || util.AliasIsLedgerKey(executor) {
+		// for hdwallet or ledger key, get the nonce in SendAction()
 		return 0, nil
 	}
 	nonce := _nonceFlag.Value().(uint64)
@@ -137,6 +141,8 @@ func RegisterWriteCommand(cmd *cobra.Command) {
 	_signerFlag.RegisterCommand(cmd)
 	_nonceFlag.RegisterCommand(cmd)
 	_yesFlag.RegisterCommand(cmd)
+	_signLaterFlag.RegisterCommand(cmd)
+	_expireInFlag.RegisterCommand(cmd)
 	account.RegisterPasswordFlag(cmd)
 }
 
@@ -248,7 +254,7 @@ func SendRawAndRespond(selp *iotextypes.Action) (*iotexapi.SendActionResponse, e
 // SendAction sends signed action to blockchain
 func SendAction(elp action.Envelope, signer string) error {
 	resp, err := SendActionAndResponse(elp, signer)
-	if err != nil {
+	if err != nil || resp == nil {
 		return err
 	}
 	outputActionInfo(resp.ActionHash)
@@ -257,64 +263,82 @@ func SendAction(elp action.Envelope, signer string) error {
 
 // SendActionAndResponse sends signed action to blockchain with response and error return
 func SendActionAndResponse(elp action.Envelope, signer string) (*iotexapi.SendActionResponse, error) {
-	prvKey, err := account.PrivateKeyFromSigner(signer, account.PasswordByFlag())
-	if err != nil {
-		return nil, err
-	}
-
 	chainMeta, err := bc.GetChainMeta()
 	if err != nil {
 		return nil, output.NewError(0, "failed to get chain meta", err)
 	}
 	elp.SetChainID(chainMeta.GetChainID())
+	if _signLaterFlag.Value() == true {
+		if err := isBalanceEnough(signer, elp); err != nil {
+			return nil, output.NewError(0, "failed to pass balance check", err)
+		}
+		return nil, outputUnsignedAction(elp, signer)
+	}
 
-	if util.AliasIsHdwalletKey(signer) {
-		addr := prvKey.PublicKey().Address()
-		signer = addr.String()
+	keySigner, err := account.NewSigner(signer, account.PasswordByFlag())
+	if err != nil {
+		return nil, err
+	}
+	defer keySigner.Close()
+
+	if util.AliasIsHdwalletKey(signer) || util.AliasIsLedgerKey(signer) {
+		signer = keySigner.Address().String()
 		nonce, err := nonce(signer)
 		if err != nil {
 			return nil, output.NewError(0, "failed to get nonce ", err)
 		}
 		elp.SetNonce(nonce)
 	}
+	// the balance is checked before confirming on ledger device
+	if err := isBalanceEnough(signer, elp); err != nil {
+		return nil, output.NewError(0, "failed to pass balance check", err) // TODO: undefined error
+	}
 
-	sealed, err := action.Sign(elp, prvKey)
-	prvKey.Zero()
+	sealed, err := keySigner.SignEnvelope(elp)
 	if err != nil {
-		return nil, output.NewError(output.CryptoError, "failed to sign action", err)
-	}
-	if err := isBalanceEnough(signer, sealed); err != nil {
-		return nil, output.NewError(0, "failed to pass balance check", err) // TODO: undefined error
+		return nil, err
 	}
 
 	selp := sealed.Proto()
+	if ok, err := confirmAction(selp); err != nil || !ok {
+		return nil, err
+	}
+	return SendRawAndRespond(selp)
+}
+
+// confirmAction prints the action and asks for confirmation, unless answering yes for all confirmations
+func confirmAction(selp *iotextypes.Action) (bool, error) {
+	if _yesFlag.Value() == true {
+		return true, nil
+	}
 	actionInfo, err := printActionProto(selp)
 	if err != nil {
-		return nil, output.NewError(0, "failed to print action proto message", err)
+		return false, output.NewError(0, "failed to print action proto message", err)
 	}
+	return confirm(actionInfo)
+}
 
-	if _yesFlag.Value() == false {
-		var confirm string
-		info := fmt.Sprintln(actionInfo + "\nPlease confirm your action.\n")
-		message := output.ConfirmationMessage{Info: info, Options: []string{"yes"}}
-		fmt.Println(message.String())
+// confirm asks for confirmation of the info
+func confirm(info string) (bool, error) {
+	var answer string
+	info = fmt.Sprintln(info + "\nPlease confirm your action.\n")
+	message := output.ConfirmationMessage{Info: info, Options: []string{"yes"}}
+	fmt.Println(message.String())
 
-		if _, err := fmt.Scanf("%s", &confirm); err != nil {
-			return nil, output.NewError(output.InputError, "failed to input yes", err)
-		}
-		if !strings.EqualFold(confirm, "yes") {
-			output.PrintResult("quit")
-			return nil, nil
-		}
+	if _, err := fmt.Scanf("%s", &answer); err != nil {
+		return false, output.NewError(output.InputError, "failed to input yes", err)
 	}
-
-	return SendRawAndRespond(selp)
+	if !strings.EqualFold(answer, "yes") {
+		output.PrintResult("quit")
+		return false, nil
+	}
+	return true, nil
 }
 
 // Execute sends signed execution transaction to blockchain
 func Execute(contract string, amount *big.Int, bytecode []byte) error {
 	resp, err := ExecuteAndResponse(contract, amount, bytecode)
-	if err != nil {
+	if err != nil || resp == nil {
 		return err
 	}
 	outputActionInfo(resp.ActionHash)
@@ -399,7 +423,7 @@ func Read(contract address.Address, amount string, bytecode []byte) (string, err
 	return "", output.NewError(output.NetworkError, "failed to invoke ReadContract api", err)
 }
 
-func isBalanceEnough(address string, act *action.SealedEnvelope) error {
+func isBalanceEnough(address string, act action.Envelope) error {
 	accountMeta, err := account.GetAccountMeta(address)
 	if err != nil {
 		return output.NewError(0, "failed to get account meta", err)
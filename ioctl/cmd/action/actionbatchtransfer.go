@@ -0,0 +1,482 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/ioctl/cmd/account"
+	"github.com/iotexproject/iotex-core/ioctl/cmd/bc"
+	"github.com/iotexproject/iotex-core/ioctl/config"
+	"github.com/iotexproject/iotex-core/ioctl/flag"
+	"github.com/iotexproject/iotex-core/ioctl/output"
+	"github.com/iotexproject/iotex-core/ioctl/util"
+)
+
+// Multi-language support
+var (
+	_batchTransferCmdShorts = map[config.Language]string{
+		config.English: "Transfer tokens to the recipients in a CSV file on IoTeX blockchain",
+		config.Chinese: "在IoTeX区块链上向CSV文件中的接收人转移令牌",
+	}
+	_batchTransferCmdUses = map[config.Language]string{
+		config.English: "batchtransfer --csv FILE [--results FILE] [--concurrency NUM] [--dry-run] [-s SIGNER] [-n NONCE] [-l GAS_LIMIT] [-p GAS_PRICE] [-P PASSWORD] [-y]",
+		config.Chinese: "batchtransfer --csv 文件 [--results 文件] [--concurrency 数量] [--dry-run] [-s 签署人] [-n NONCE] [-l GAS限制] [-p GAS价格] [-P 密码] [-y]",
+	}
+)
+
+// Flags
+var (
+	_csvFlag         = flag.NewStringVarP("csv", "", "", "set the CSV file of the transfers in rows of recipient,amount[,payload], the amount is in IOTX and the payload in hex")
+	_resultsFlag     = flag.NewStringVarP("results", "", "", "set the CSV file of the results to write and resume from (default the CSV file with .results.csv)")
+	_concurrencyFlag = flag.NewUint64VarP("concurrency", "", 4, "set the number of actions submitted concurrently")
+	_dryRunFlag      = flag.BoolVarP("dry-run", "", false, "print the planned actions without sending them")
+)
+
+const (
+	_batchStatusPending = "pending"
+	_batchStatusSuccess = "success"
+	_batchStatusFailure = "failure"
+	_batchStatusError   = "error"
+
+	_receiptPollInterval = 5 * time.Second
+	_receiptPollTimeout  = 5 * time.Minute
+)
+
+var _batchResultsHeader = []string{"row", "recipient", "amount", "payload", "nonce", "hash", "status"}
+
+// _actionBatchTransferCmd represents the action batch transfer command
+var _actionBatchTransferCmd = &cobra.Command{
+	Use:   config.TranslateInLang(_batchTransferCmdUses, config.UILanguage),
+	Short: config.TranslateInLang(_batchTransferCmdShorts, config.UILanguage),
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		err := batchTransfer(cmd.Flags().Changed(_gasLimitFlag.Label()))
+		return output.PrintError(err)
+	},
+}
+
+// batchTransferRow is a transfer in the CSV file, and its result
+type batchTransferRow struct {
+	row       int
+	recipient string
+	amount    *big.Int
+	payload   []byte
+	gasLimit  uint64
+	// the nonce, hash and status are recorded in the results file
+	nonce  uint64
+	hash   string
+	status string
+}
+
+func init() {
+	_csvFlag.RegisterCommand(_actionBatchTransferCmd)
+	_resultsFlag.RegisterCommand(_actionBatchTransferCmd)
+	_concurrencyFlag.RegisterCommand(_actionBatchTransferCmd)
+	_dryRunFlag.RegisterCommand(_actionBatchTransferCmd)
+	_gasLimitFlag.RegisterCommand(_actionBatchTransferCmd)
+	_gasPriceFlag.RegisterCommand(_actionBatchTransferCmd)
+	_signerFlag.RegisterCommand(_actionBatchTransferCmd)
+	_nonceFlag.RegisterCommand(_actionBatchTransferCmd)
+	_yesFlag.RegisterCommand(_actionBatchTransferCmd)
+	account.RegisterPasswordFlag(_actionBatchTransferCmd)
+}
+
+// parseBatchTransfers parses the rows of recipient,amount[,payload], the first row is skipped as header if its
+// recipient is "recipient"
+func parseBatchTransfers(r io.Reader) ([]*batchTransferRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, output.NewError(output.SerializationError, "failed to read CSV", err)
+	}
+	var rows []*batchTransferRow
+	for i, record := range records {
+		if i == 0 && len(record) > 0 && strings.EqualFold(record[0], "recipient") {
+			continue
+		}
+		if len(record) < 2 || len(record) > 3 {
+			return nil, output.NewError(output.InputError, fmt.Sprintf("row %d: expecting recipient,amount[,payload]", i+1), nil)
+		}
+		recipient, err := util.Address(record[0])
+		if err != nil {
+			return nil, output.NewError(output.AddressError, fmt.Sprintf("row %d: invalid recipient", i+1), err)
+		}
+		amount, err := util.StringToRau(record[1], util.IotxDecimalNum)
+		if err != nil {
+			return nil, output.NewError(output.ConvertError, fmt.Sprintf("row %d: invalid amount", i+1), err)
+		}
+		var payload []byte
+		if len(record) == 3 {
+			if payload, err = hex.DecodeString(util.TrimHexPrefix(record[2])); err != nil {
+				return nil, output.NewError(output.ConvertError, fmt.Sprintf("row %d: invalid payload", i+1), err)
+			}
+		}
+		rows = append(rows, &batchTransferRow{
+			row:       i + 1,
+			recipient: recipient,
+			amount:    amount,
+			payload:   payload,
+		})
+	}
+	if len(rows) == 0 {
+		return nil, output.NewError(output.InputError, "no transfer in CSV", nil)
+	}
+	return rows, nil
+}
+
+// loadBatchResults loads the nonces, hashes and statuses of the rows from the results file if it exists
+func loadBatchResults(file string, rows []*batchTransferRow) error {
+	f, err := os.Open(file)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return output.NewError(output.ReadFileError, "failed to open results", err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return output.NewError(output.SerializationError, "failed to read results", err)
+	}
+	byRow := make(map[int]*batchTransferRow, len(rows))
+	for _, r := range rows {
+		byRow[r.row] = r
+	}
+	for i, record := range records {
+		if i == 0 {
+			continue
+		}
+		if len(record) != len(_batchResultsHeader) {
+			return output.NewError(output.SerializationError, fmt.Sprintf("invalid results row %d", i+1), nil)
+		}
+		n, err := strconv.Atoi(record[0])
+		if err != nil {
+			return output.NewError(output.SerializationError, fmt.Sprintf("invalid results row %d", i+1), err)
+		}
+		r, ok := byRow[n]
+		if !ok || r.recipient != record[1] || util.RauToString(r.amount, util.IotxDecimalNum) != record[2] {
+			return output.NewError(output.ValidationError, fmt.Sprintf("results row %d does not match the CSV", i+1), nil)
+		}
+		if record[4] != "" {
+			if r.nonce, err = strconv.ParseUint(record[4], 10, 64); err != nil {
+				return output.NewError(output.SerializationError, fmt.Sprintf("invalid nonce of results row %d", i+1), err)
+			}
+		}
+		r.hash, r.status = record[5], record[6]
+	}
+	return nil
+}
+
+// writeBatchResults writes the results of the rows, to a temporary file renamed as the results file
+func writeBatchResults(file string, rows []*batchTransferRow) error {
+	tmp := file + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return output.NewError(output.WriteFileError, "failed to create results", err)
+	}
+	w := csv.NewWriter(f)
+	records := [][]string{_batchResultsHeader}
+	for _, r := range rows {
+		nonce := ""
+		if r.status != "" {
+			nonce = strconv.FormatUint(r.nonce, 10)
+		}
+		records = append(records, []string{
+			strconv.Itoa(r.row),
+			r.recipient,
+			util.RauToString(r.amount, util.IotxDecimalNum),
+			hex.EncodeToString(r.payload),
+			nonce,
+			r.hash,
+			r.status,
+		})
+	}
+	if err := w.WriteAll(records); err != nil {
+		f.Close()
+		return output.NewError(output.WriteFileError, "failed to write results", err)
+	}
+	if err := f.Close(); err != nil {
+		return output.NewError(output.WriteFileError, "failed to write results", err)
+	}
+	if err := os.Rename(tmp, file); err != nil {
+		return output.NewError(output.WriteFileError, "failed to write results", err)
+	}
+	return nil
+}
+
+// planBatchTransfers assigns the nonces of the rows to send, the rows failed to be sent keep their nonces if not
+// used yet, and the others are assigned sequentially after the pending nonce and the nonces in the results
+func planBatchTransfers(rows []*batchTransferRow, confirmedNonce, pendingNonce uint64) []*batchTransferRow {
+	next := pendingNonce
+	for _, r := range rows {
+		if r.status == _batchStatusPending || r.status == _batchStatusError {
+			if r.nonce >= next {
+				next = r.nonce + 1
+			}
+		}
+	}
+	var todo []*batchTransferRow
+	for _, r := range rows {
+		switch r.status {
+		case _batchStatusSuccess, _batchStatusPending:
+			continue
+		case _batchStatusError:
+			if r.nonce >= confirmedNonce {
+				todo = append(todo, r)
+				continue
+			}
+		}
+		r.nonce, r.hash, r.status = next, "", ""
+		next++
+		todo = append(todo, r)
+	}
+	return todo
+}
+
+func batchTransfer(gasLimitSet bool) error {
+	csvFile := _csvFlag.Value().(string)
+	if csvFile == "" {
+		return output.NewError(output.FlagError, "--csv is required", nil)
+	}
+	resultsFile := _resultsFlag.Value().(string)
+	if resultsFile == "" {
+		resultsFile = strings.TrimSuffix(csvFile, ".csv") + ".results.csv"
+	}
+	f, err := os.Open(csvFile)
+	if err != nil {
+		return output.NewError(output.ReadFileError, "failed to open CSV", err)
+	}
+	rows, err := parseBatchTransfers(f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+	if err := loadBatchResults(resultsFile, rows); err != nil {
+		return err
+	}
+	gasPrice, err := gasPriceInRau()
+	if err != nil {
+		return output.NewError(0, "failed to get gas price", err)
+	}
+	for _, r := range rows {
+		r.gasLimit = _gasLimitFlag.Value().(uint64)
+		if !gasLimitSet {
+			r.gasLimit = action.TransferBaseIntrinsicGas + action.TransferPayloadGas*uint64(len(r.payload))
+		}
+	}
+
+	sender, err := Signer()
+	if err != nil {
+		return output.NewError(output.AddressError, "failed to get signed address", err)
+	}
+	var keySigner account.Signer
+	if !_dryRunFlag.Value().(bool) || util.AliasIsHdwalletKey(sender) || util.AliasIsLedgerKey(sender) {
+		if keySigner, err = account.NewSigner(sender, account.PasswordByFlag()); err != nil {
+			return err
+		}
+		defer keySigner.Close()
+		sender = keySigner.Address().String()
+	}
+
+	conn, err := util.ConnectToEndpoint(config.ReadConfig.SecureConnect && !config.Insecure)
+	if err != nil {
+		return output.NewError(output.NetworkError, "failed to connect to endpoint", err)
+	}
+	defer conn.Close()
+	cli := iotexapi.NewAPIServiceClient(conn)
+	ctx := context.Background()
+	if jwtMD, err := util.JwtAuth(); err == nil {
+		ctx = metautils.NiceMD(jwtMD).ToOutgoing(ctx)
+	}
+
+	// the actions sent before are confirmed first, and the failed ones are sent again
+	if err := pollBatchReceipts(ctx, cli, rows, resultsFile, 0); err != nil {
+		return err
+	}
+	accountMeta, err := account.GetAccountMeta(sender)
+	if err != nil {
+		return output.NewError(0, "failed to get account meta", err)
+	}
+	pendingNonce := accountMeta.PendingNonce
+	if nonce := _nonceFlag.Value().(uint64); nonce != 0 {
+		pendingNonce = nonce
+	}
+	todo := planBatchTransfers(rows, accountMeta.Nonce, pendingNonce)
+	if len(todo) == 0 {
+		output.PrintResult(fmt.Sprintf("All transfers have been sent, results in %s", resultsFile))
+		return nil
+	}
+	total := new(big.Int)
+	for _, r := range todo {
+		total.Add(total, r.amount)
+		total.Add(total, new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(r.gasLimit)))
+	}
+	balance, ok := new(big.Int).SetString(accountMeta.Balance, 10)
+	if !ok {
+		return output.NewError(output.ConvertError, "failed to convert balance into big int", nil)
+	}
+	if balance.Cmp(total) < 0 {
+		return output.NewError(output.ValidationError, fmt.Sprintf("balance %s IOTX is not enough for %s IOTX of the transfers and gas",
+			util.RauToString(balance, util.IotxDecimalNum), util.RauToString(total, util.IotxDecimalNum)), nil)
+	}
+
+	var plan strings.Builder
+	fmt.Fprintf(&plan, "Transfer from %s with gas price %s Rau:\n", sender, gasPrice)
+	for _, r := range todo {
+		fmt.Fprintf(&plan, "row %d: %s IOTX to %s, nonce %d, gas limit %d\n",
+			r.row, util.RauToString(r.amount, util.IotxDecimalNum), r.recipient, r.nonce, r.gasLimit)
+	}
+	fmt.Fprintf(&plan, "%d transfers of total %s IOTX including gas", len(todo), util.RauToString(total, util.IotxDecimalNum))
+	if _dryRunFlag.Value().(bool) {
+		output.PrintResult(plan.String())
+		return nil
+	}
+	if _yesFlag.Value() == false {
+		if ok, err := confirm(plan.String()); err != nil || !ok {
+			return err
+		}
+	}
+
+	chainMeta, err := bc.GetChainMeta()
+	if err != nil {
+		return output.NewError(0, "failed to get chain meta", err)
+	}
+	// the actions are signed in the order of nonce, and submitted concurrently
+	sealed := make([]*action.SealedEnvelope, len(todo))
+	for i, r := range todo {
+		tsf, err := action.NewTransfer(r.nonce, r.amount, r.recipient, r.payload, r.gasLimit, gasPrice)
+		if err != nil {
+			return output.NewError(output.InstantiationError, "failed to make a Transfer instance", err)
+		}
+		elp := (&action.EnvelopeBuilder{}).
+			SetNonce(r.nonce).
+			SetGasPrice(gasPrice).
+			SetGasLimit(r.gasLimit).
+			SetChainID(chainMeta.GetChainID()).
+			SetAction(tsf).Build()
+		if sealed[i], err = keySigner.SignEnvelope(elp); err != nil {
+			return err
+		}
+	}
+	var (
+		mutex sync.Mutex
+		wg    sync.WaitGroup
+		jobs  = make(chan int)
+		errs  []error
+	)
+	concurrency := int(_concurrencyFlag.Value().(uint64))
+	if concurrency == 0 {
+		concurrency = 1
+	}
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				r := todo[i]
+				resp, err := cli.SendAction(ctx, &iotexapi.SendActionRequest{Action: sealed[i].Proto()})
+				mutex.Lock()
+				if err != nil {
+					r.status = _batchStatusError
+					errs = append(errs, fmt.Errorf("row %d: %v", r.row, err))
+				} else {
+					r.hash, r.status = resp.ActionHash, _batchStatusPending
+				}
+				if err := writeBatchResults(resultsFile, rows); err != nil {
+					errs = append(errs, err)
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+	for i := range todo {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	if err := pollBatchReceipts(ctx, cli, rows, resultsFile, _receiptPollTimeout); err != nil {
+		return err
+	}
+
+	var success, failure, pending, failed int
+	for _, r := range rows {
+		switch r.status {
+		case _batchStatusSuccess:
+			success++
+		case _batchStatusFailure:
+			failure++
+		case _batchStatusPending:
+			pending++
+		case _batchStatusError:
+			failed++
+		}
+	}
+	output.PrintResult(fmt.Sprintf("%d succeeded, %d failed on chain, %d pending, %d failed to send, results in %s",
+		success, failure, pending, failed, resultsFile))
+	if len(errs) > 0 {
+		return output.NewError(output.APIError, "failed to send some transfers, run again to resume", errs[0])
+	}
+	if failure > 0 || pending > 0 {
+		return output.NewError(output.RuntimeError, "some transfers are not successful yet, run again to resume", nil)
+	}
+	return nil
+}
+
+// pollBatchReceipts polls the receipts of the pending rows until the timeout, the status of a row is updated once
+// its receipt is found
+func pollBatchReceipts(ctx context.Context, cli iotexapi.APIServiceClient, rows []*batchTransferRow, resultsFile string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		pending := 0
+		for _, r := range rows {
+			if r.status != _batchStatusPending {
+				continue
+			}
+			resp, err := cli.GetReceiptByAction(ctx, &iotexapi.GetReceiptByActionRequest{ActionHash: r.hash})
+			if err != nil {
+				if sta, ok := status.FromError(err); ok && sta.Code() == codes.NotFound {
+					pending++
+					continue
+				}
+				return output.NewError(output.NetworkError, "failed to invoke GetReceiptByAction api", err)
+			}
+			if resp.GetReceiptInfo().GetReceipt().GetStatus() == uint64(iotextypes.ReceiptStatus_Success) {
+				r.status = _batchStatusSuccess
+			} else {
+				r.status = _batchStatusFailure
+			}
+			if err := writeBatchResults(resultsFile, rows); err != nil {
+				return err
+			}
+		}
+		if pending == 0 || time.Now().After(deadline) {
+			return nil
+		}
+		time.Sleep(_receiptPollInterval)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestBatchTransfers(t *testing.T) {
+	r := require.New(t)
+	addr1, addr2, addr3 := identityset.Address(1).String(), identityset.Address(2).String(), identityset.Address(3).String()
+	csv := fmt.Sprintf("recipient,amount,payload\n%s,1.5\n%s,2,0xabcd\n%s,0.000000000000000001\n", addr1, addr2, addr3)
+
+	t.Run("parse", func(t *testing.T) {
+		rows, err := parseBatchTransfers(strings.NewReader(csv))
+		r.NoError(err)
+		r.Len(rows, 3)
+		r.Equal(2, rows[0].row)
+		r.Equal(addr1, rows[0].recipient)
+		r.Equal("1500000000000000000", rows[0].amount.String())
+		r.Equal([]byte{0xab, 0xcd}, rows[1].payload)
+		r.Equal("1", rows[2].amount.String())
+
+		for _, v := range []struct {
+			csv, err string
+		}{
+			{"", "no transfer"},
+			{"io1invalid,1\n", "row 1: invalid recipient"},
+			{addr1 + ",1\n" + addr2 + ",abc\n", "row 2: invalid amount"},
+			{addr1 + ",1,xyz\n", "row 1: invalid payload"},
+			{addr1 + "\n", "row 1: expecting"},
+		} {
+			_, err := parseBatchTransfers(strings.NewReader(v.csv))
+			r.ErrorContains(err, v.err)
+		}
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "results.csv")
+		rows, err := parseBatchTransfers(strings.NewReader(csv))
+		r.NoError(err)
+		// no results file yet
+		r.NoError(loadBatchResults(file, rows))
+		todo := planBatchTransfers(rows, 5, 7)
+		r.Len(todo, 3)
+		for i, row := range todo {
+			r.EqualValues(7+i, row.nonce)
+		}
+
+		rows[0].hash, rows[0].status = "aa", _batchStatusSuccess
+		rows[1].hash, rows[1].status = "bb", _batchStatusPending
+		rows[2].status = _batchStatusError
+		r.NoError(writeBatchResults(file, rows))
+
+		resumed, err := parseBatchTransfers(strings.NewReader(csv))
+		r.NoError(err)
+		r.NoError(loadBatchResults(file, resumed))
+		for i := range rows {
+			r.Equal(rows[i].nonce, resumed[i].nonce)
+			r.Equal(rows[i].hash, resumed[i].hash)
+			r.Equal(rows[i].status, resumed[i].status)
+		}
+		// the row failed to be sent keeps its nonce, which is not used yet
+		todo = planBatchTransfers(resumed, 8, 8)
+		r.Len(todo, 1)
+		r.Equal(4, todo[0].row)
+		r.EqualValues(9, todo[0].nonce)
+		// the nonce is used, a new nonce is assigned after the pending one
+		resumed[2].status = _batchStatusError
+		resumed[2].nonce = 9
+		todo = planBatchTransfers(resumed, 10, 10)
+		r.Len(todo, 1)
+		r.EqualValues(10, todo[0].nonce)
+		// a failed row is sent again with a new nonce
+		resumed[1].status = _batchStatusFailure
+		resumed[2].status = _batchStatusSuccess
+		todo = planBatchTransfers(resumed, 10, 10)
+		r.Len(todo, 1)
+		r.Equal(3, todo[0].row)
+		r.EqualValues(10, todo[0].nonce)
+		r.Empty(todo[0].hash)
+
+		// the results do not match the CSV
+		other, err := parseBatchTransfers(strings.NewReader(fmt.Sprintf("%s,1\n%s,3\n%s,1\n", addr1, addr2, addr3)))
+		r.NoError(err)
+		r.ErrorContains(loadBatchResults(file, other), "does not match")
+	})
+}
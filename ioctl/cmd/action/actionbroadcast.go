@@ -0,0 +1,83 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/ioctl/cmd/bc"
+	"github.com/iotexproject/iotex-core/ioctl/config"
+	"github.com/iotexproject/iotex-core/ioctl/output"
+)
+
+// Multi-language support
+var (
+	_broadcastCmdShorts = map[config.Language]string{
+		config.English: "Broadcast an action signed by \"ioctl action sign\" to IoTeX blockchain",
+		config.Chinese: "向IoTeX区块链广播由\"ioctl action sign\"签署的行为",
+	}
+	_broadcastCmdUses = map[config.Language]string{
+		config.English: "broadcast FILE",
+		config.Chinese: "broadcast 文件",
+	}
+)
+
+// _actionBroadcastCmd represents the action broadcast command
+var _actionBroadcastCmd = &cobra.Command{
+	Use:   config.TranslateInLang(_broadcastCmdUses, config.UILanguage),
+	Short: config.TranslateInLang(_broadcastCmdShorts, config.UILanguage),
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		err := broadcast(args[0])
+		return output.PrintError(err)
+	},
+}
+
+func broadcast(file string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return output.NewError(output.ReadFileError, "failed to read signed action", err)
+	}
+	var signed signedAction
+	if err := json.Unmarshal(data, &signed); err != nil {
+		return output.NewError(output.SerializationError, "failed to unmarshal signed action", err)
+	}
+	if expired(signed.Expiry) {
+		return output.NewError(output.ValidationError, "the signed action has expired", nil)
+	}
+	actBytes, err := hex.DecodeString(signed.Action)
+	if err != nil {
+		return output.NewError(output.ConvertError, "failed to decode action", err)
+	}
+	act := &iotextypes.Action{}
+	if err := proto.Unmarshal(actBytes, act); err != nil {
+		return output.NewError(output.SerializationError, "failed to unmarshal action", err)
+	}
+	selp, err := (&action.Deserializer{}).ActionToSealedEnvelope(act)
+	if err != nil {
+		return output.NewError(output.SerializationError, "failed to load action", err)
+	}
+	if err := selp.VerifySignature(); err != nil {
+		return output.NewError(output.CryptoError, "failed to verify signature", err)
+	}
+	chainMeta, err := bc.GetChainMeta()
+	if err != nil {
+		return output.NewError(0, "failed to get chain meta", err)
+	}
+	if chainID := chainMeta.GetChainID(); selp.ChainID() != chainID || signed.ChainID != chainID {
+		return output.NewError(output.ValidationError, fmt.Sprintf("the chain ID of the action %d does not match the chain %d", selp.ChainID(), chainID), nil)
+	}
+	return SendRaw(act)
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/ioctl/cmd/account"
+	"github.com/iotexproject/iotex-core/ioctl/cmd/bc"
+	"github.com/iotexproject/iotex-core/ioctl/config"
+	"github.com/iotexproject/iotex-core/ioctl/flag"
+	"github.com/iotexproject/iotex-core/ioctl/output"
+	"github.com/iotexproject/iotex-core/ioctl/util"
+)
+
+// Multi-language support
+var (
+	_actionCancelCmdShorts = map[config.Language]string{
+		config.English: "Cancel a pending action by replacing it with a zero-value transfer to self of the same nonce",
+		config.Chinese: "用相同nonce的零值自转账替换待处理的行为以取消它",
+	}
+	_actionCancelCmdUses = map[config.Language]string{
+		config.English: "cancel NONCE [--bump GAS_PRICE_BUMP] [-s SIGNER] [-P PASSWORD] [-y]",
+		config.Chinese: "cancel NONCE [--bump GAS价格增量] [-s 签署人] [-P 密码] [-y]",
+	}
+)
+
+// _bumpFlag is the gas price added to the pending action for its replacement
+var _bumpFlag = flag.NewStringVarP("bump", "", "", "set the gas price bump in Rau over the pending action (default the minimum the actpool accepts)")
+
+// _unconfirmedPageSize is the default range query limit of the API
+const _unconfirmedPageSize = 1000
+
+// _actionCancelCmd represents the action cancel command
+var _actionCancelCmd = &cobra.Command{
+	Use:   config.TranslateInLang(_actionCancelCmdUses, config.UILanguage),
+	Short: config.TranslateInLang(_actionCancelCmdShorts, config.UILanguage),
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		err := cancel(args[0])
+		return output.PrintError(err)
+	},
+}
+
+func init() {
+	_bumpFlag.RegisterCommand(_actionCancelCmd)
+	_signerFlag.RegisterCommand(_actionCancelCmd)
+	_yesFlag.RegisterCommand(_actionCancelCmd)
+	account.RegisterPasswordFlag(_actionCancelCmd)
+}
+
+// cancelGasPrice returns the gas price of the replacement of the pending gas price. The actpool takes the
+// replacement of an executable action only if its gas price is higher, so the minimum bump is 1 Rau, and 0 for an
+// action after a nonce gap
+func cancelGasPrice(pending *big.Int, executable bool, bump string) (*big.Int, error) {
+	minBump := big.NewInt(0)
+	if executable {
+		minBump.SetInt64(1)
+	}
+	gasPriceBump := minBump
+	if bump != "" {
+		var ok bool
+		if gasPriceBump, ok = new(big.Int).SetString(bump, 10); !ok || gasPriceBump.Sign() < 0 {
+			return nil, output.NewError(output.ConvertError, "invalid gas price bump "+bump, nil)
+		}
+	}
+	if gasPriceBump.Cmp(minBump) < 0 {
+		return nil, output.NewError(output.ValidationError,
+			fmt.Sprintf("gas price bump %s Rau is lower than the minimum %s Rau", gasPriceBump, minBump), nil)
+	}
+	return new(big.Int).Add(pending, gasPriceBump), nil
+}
+
+// pendingAction returns the action of the nonce in the actpool sent by the address, or nil if not found
+func pendingAction(ctx context.Context, cli iotexapi.APIServiceClient, addr string, nonce uint64) (*iotextypes.Action, error) {
+	for start := uint64(0); ; start += _unconfirmedPageSize {
+		resp, err := cli.GetActions(ctx, &iotexapi.GetActionsRequest{
+			Lookup: &iotexapi.GetActionsRequest_UnconfirmedByAddr{
+				UnconfirmedByAddr: &iotexapi.GetUnconfirmedActionsByAddressRequest{
+					Address: addr,
+					Start:   start,
+					Count:   _unconfirmedPageSize,
+				},
+			},
+		})
+		if err != nil {
+			// the start beyond the actions in pool
+			if start > 0 && status.Code(err) == codes.InvalidArgument {
+				return nil, nil
+			}
+			return nil, err
+		}
+		for _, info := range resp.ActionInfo {
+			if info.GetAction().GetCore().GetNonce() == nonce {
+				return info.GetAction(), nil
+			}
+		}
+		if len(resp.ActionInfo) < _unconfirmedPageSize {
+			return nil, nil
+		}
+	}
+}
+
+func cancel(arg string) error {
+	nonce, err := strconv.ParseUint(arg, 10, 64)
+	if err != nil {
+		return output.NewError(output.ConvertError, "invalid nonce", err)
+	}
+	sender, err := Signer()
+	if err != nil {
+		return output.NewError(output.AddressError, "failed to get signed address", err)
+	}
+	keySigner, err := account.NewSigner(sender, account.PasswordByFlag())
+	if err != nil {
+		return err
+	}
+	defer keySigner.Close()
+	sender = keySigner.Address().String()
+
+	accountMeta, err := account.GetAccountMeta(sender)
+	if err != nil {
+		return output.NewError(0, "failed to get account meta", err)
+	}
+	if nonce < accountMeta.Nonce {
+		return output.NewError(output.ValidationError, fmt.Sprintf("the action of nonce %d has been mined", nonce), nil)
+	}
+
+	conn, err := util.ConnectToEndpoint(config.ReadConfig.SecureConnect && !config.Insecure)
+	if err != nil {
+		return output.NewError(output.NetworkError, "failed to connect to endpoint", err)
+	}
+	defer conn.Close()
+	cli := iotexapi.NewAPIServiceClient(conn)
+	ctx := context.Background()
+	if jwtMD, err := util.JwtAuth(); err == nil {
+		ctx = metautils.NiceMD(jwtMD).ToOutgoing(ctx)
+	}
+	pending, err := pendingAction(ctx, cli, sender, nonce)
+	if err != nil {
+		return output.NewError(output.APIError, "failed to get pending actions", err)
+	}
+	if pending == nil {
+		return output.NewError(output.ValidationError, fmt.Sprintf("no pending action of nonce %d", nonce), nil)
+	}
+	// the gas fee cap of a dynamic fee action is the gas price it pays at most
+	pendingPrice := pending.GetCore().GetGasFeeCap()
+	if pendingPrice == "" {
+		pendingPrice = pending.GetCore().GetGasPrice()
+	}
+	pendingGasPrice, ok := new(big.Int).SetString(pendingPrice, 10)
+	if !ok {
+		return output.NewError(output.ConvertError, "failed to convert gas price of the pending action", nil)
+	}
+	gasPrice, err := cancelGasPrice(pendingGasPrice, nonce < accountMeta.PendingNonce, _bumpFlag.Value().(string))
+	if err != nil {
+		return err
+	}
+
+	chainMeta, err := bc.GetChainMeta()
+	if err != nil {
+		return output.NewError(0, "failed to get chain meta", err)
+	}
+	tsf, err := action.NewTransfer(nonce, big.NewInt(0), sender, nil, action.TransferBaseIntrinsicGas, gasPrice)
+	if err != nil {
+		return output.NewError(output.InstantiationError, "failed to make a Transfer instance", err)
+	}
+	elp := (&action.EnvelopeBuilder{}).
+		SetNonce(nonce).
+		SetGasPrice(gasPrice).
+		SetGasLimit(action.TransferBaseIntrinsicGas).
+		SetChainID(chainMeta.GetChainID()).
+		SetAction(tsf).Build()
+	if err := isBalanceEnough(sender, elp); err != nil {
+		return output.NewError(0, "failed to pass balance check", err)
+	}
+	sealed, err := keySigner.SignEnvelope(elp)
+	if err != nil {
+		return err
+	}
+	selp := sealed.Proto()
+	if ok, err := confirmAction(selp); err != nil || !ok {
+		return err
+	}
+	return SendRaw(selp)
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancelGasPrice(t *testing.T) {
+	r := require.New(t)
+	for _, c := range []struct {
+		executable bool
+		bump       string
+		gasPrice   int64
+		err        bool
+	}{
+		{true, "", 11, false},
+		{false, "", 10, false},
+		{true, "5", 15, false},
+		{true, "0", 0, true},
+		{false, "0", 10, false},
+		{true, "-1", 0, true},
+		{true, "1.5", 0, true},
+	} {
+		gasPrice, err := cancelGasPrice(big.NewInt(10), c.executable, c.bump)
+		if c.err {
+			r.Error(err, c.bump)
+			continue
+		}
+		r.NoError(err)
+		r.Equal(big.NewInt(c.gasPrice), gasPrice)
+	}
+}
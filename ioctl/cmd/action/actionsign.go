@@ -0,0 +1,193 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/ioctl/cmd/account"
+	"github.com/iotexproject/iotex-core/ioctl/config"
+	"github.com/iotexproject/iotex-core/ioctl/flag"
+	"github.com/iotexproject/iotex-core/ioctl/output"
+	"github.com/iotexproject/iotex-core/ioctl/util"
+)
+
+// Multi-language support
+var (
+	_signCmdShorts = map[config.Language]string{
+		config.English: "Sign an unsigned action created with --sign-later, which could be done offline",
+		config.Chinese: "签署使用--sign-later创建的未签名行为，可在离线状态下进行",
+	}
+	_signCmdUses = map[config.Language]string{
+		config.English: "sign FILE [-s SIGNER] [-P PASSWORD] [-y]",
+		config.Chinese: "sign 文件 [-s 签署人] [-P 密码] [-y]",
+	}
+)
+
+// Flags
+var (
+	_signLaterFlag = flag.BoolVarP("sign-later", "", false, "output the unsigned action in JSON to be signed by \"ioctl action sign\" instead of sending it")
+	_expireInFlag  = flag.NewStringVarP("expire-in", "", "24h", "set the duration after which the unsigned action could not be broadcast, \"0\" for no expiry")
+)
+
+// _actionSignCmd represents the action sign command
+var _actionSignCmd = &cobra.Command{
+	Use:   config.TranslateInLang(_signCmdUses, config.UILanguage),
+	Short: config.TranslateInLang(_signCmdShorts, config.UILanguage),
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		err := sign(args[0])
+		return output.PrintError(err)
+	},
+}
+
+type (
+	// unsignedAction is the action created with --sign-later, the envelope is the serialized action core and the
+	// hash is the hash of the envelope to be signed
+	unsignedAction struct {
+		ChainID  uint32 `json:"chainID"`
+		Signer   string `json:"signer"`
+		Expiry   int64  `json:"expiry"`
+		Envelope string `json:"envelope"`
+		Hash     string `json:"hash"`
+	}
+
+	// signedAction is the action signed by "ioctl action sign", the action is the serialized sealed envelope and
+	// the hash is the hash of the action on chain
+	signedAction struct {
+		ChainID uint32 `json:"chainID"`
+		Expiry  int64  `json:"expiry"`
+		Action  string `json:"action"`
+		Hash    string `json:"hash"`
+	}
+)
+
+func init() {
+	_signerFlag.RegisterCommand(_actionSignCmd)
+	_yesFlag.RegisterCommand(_actionSignCmd)
+	account.RegisterPasswordFlag(_actionSignCmd)
+}
+
+// expired returns whether the expiry in unix seconds has passed, 0 for no expiry
+func expired(expiry int64) bool {
+	return expiry != 0 && time.Now().Unix() > expiry
+}
+
+// outputUnsignedAction outputs the envelope to be signed later by the signer
+func outputUnsignedAction(elp action.Envelope, signer string) error {
+	if util.AliasIsHdwalletKey(signer) || util.AliasIsLedgerKey(signer) {
+		return output.NewError(output.InputError, "failed to sign later with HDWallet or ledger key, use the address of the key as signer", nil)
+	}
+	expireIn, err := time.ParseDuration(_expireInFlag.Value().(string))
+	if err != nil {
+		return output.NewError(output.FlagError, "invalid expire-in", err)
+	}
+	var expiry int64
+	if expireIn > 0 {
+		expiry = time.Now().Add(expireIn).Unix()
+	}
+	core, err := proto.Marshal(elp.Proto())
+	if err != nil {
+		return output.NewError(output.SerializationError, "failed to serialize envelope", err)
+	}
+	h := hash.Hash256b(core)
+	fmt.Println(output.JSONString(&unsignedAction{
+		ChainID:  elp.ChainID(),
+		Signer:   signer,
+		Expiry:   expiry,
+		Envelope: hex.EncodeToString(core),
+		Hash:     hex.EncodeToString(h[:]),
+	}))
+	return nil
+}
+
+func sign(file string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return output.NewError(output.ReadFileError, "failed to read unsigned action", err)
+	}
+	var unsigned unsignedAction
+	if err := json.Unmarshal(data, &unsigned); err != nil {
+		return output.NewError(output.SerializationError, "failed to unmarshal unsigned action", err)
+	}
+	if expired(unsigned.Expiry) {
+		return output.NewError(output.ValidationError, "the unsigned action has expired", nil)
+	}
+	core, err := hex.DecodeString(unsigned.Envelope)
+	if err != nil {
+		return output.NewError(output.ConvertError, "failed to decode envelope", err)
+	}
+	pbAct := &iotextypes.ActionCore{}
+	if err := proto.Unmarshal(core, pbAct); err != nil {
+		return output.NewError(output.SerializationError, "failed to unmarshal envelope", err)
+	}
+	elp, err := (&action.EnvelopeBuilder{}).BuildFromProto(pbAct)
+	if err != nil {
+		return output.NewError(output.SerializationError, "failed to load envelope", err)
+	}
+	// the hash being signed is computed from the loaded envelope as on chain
+	elpBytes, err := proto.Marshal(elp.Proto())
+	if err != nil {
+		return output.NewError(output.SerializationError, "failed to serialize envelope", err)
+	}
+	if h := hash.Hash256b(elpBytes); hex.EncodeToString(h[:]) != unsigned.Hash {
+		return output.NewError(output.ValidationError, "the hash does not match the envelope", nil)
+	}
+	if elp.ChainID() != unsigned.ChainID {
+		return output.NewError(output.ValidationError, "the chain ID does not match the envelope", nil)
+	}
+	signer := _signerFlag.Value().(string)
+	if signer == "" {
+		signer = unsigned.Signer
+	}
+	if !util.AliasIsHdwalletKey(signer) && !util.AliasIsLedgerKey(signer) {
+		if signer, err = util.GetAddress(signer); err != nil {
+			return output.NewError(output.AddressError, "failed to get signer address", err)
+		}
+	}
+	keySigner, err := account.NewSigner(signer, account.PasswordByFlag())
+	if err != nil {
+		return err
+	}
+	defer keySigner.Close()
+	if addr := keySigner.Address().String(); addr != unsigned.Signer {
+		return output.NewError(output.ValidationError, fmt.Sprintf("the key of %s does not match the signer %s", addr, unsigned.Signer), nil)
+	}
+	sealed, err := keySigner.SignEnvelope(elp)
+	if err != nil {
+		return err
+	}
+	selp := sealed.Proto()
+	if ok, err := confirmAction(selp); err != nil || !ok {
+		return err
+	}
+	actBytes, err := proto.Marshal(selp)
+	if err != nil {
+		return output.NewError(output.SerializationError, "failed to serialize action", err)
+	}
+	actHash, err := sealed.Hash()
+	if err != nil {
+		return output.NewError(output.CryptoError, "failed to get action hash", err)
+	}
+	fmt.Println(output.JSONString(&signedAction{
+		ChainID: unsigned.ChainID,
+		Expiry:  unsigned.Expiry,
+		Action:  hex.EncodeToString(actBytes),
+		Hash:    hex.EncodeToString(actHash[:]),
+	}))
+	return nil
+}
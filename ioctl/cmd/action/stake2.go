@@ -39,8 +39,9 @@ var _stake2AutoStake bool
 
 // Stake2Cmd represent stake2 command
 var Stake2Cmd = &cobra.Command{
-	Use:   "stake2",
-	Short: config.TranslateInLang(_stake2CmdShorts, config.UILanguage),
+	Use:     "stake2",
+	Aliases: []string{"stake"},
+	Short:   config.TranslateInLang(_stake2CmdShorts, config.UILanguage),
 }
 
 func init() {
@@ -60,6 +61,7 @@ func init() {
 	Stake2Cmd.AddCommand(_stake2ActivateCmd)
 	Stake2Cmd.AddCommand(_stake2TransferOwnershipCmd)
 	Stake2Cmd.AddCommand(_stake2MigrateCmd)
+	Stake2Cmd.AddCommand(_stake2OverviewCmd)
 	Stake2Cmd.PersistentFlags().StringVar(&config.ReadConfig.Endpoint, "endpoint", config.ReadConfig.Endpoint, config.TranslateInLang(_stake2FlagEndpointUsages, config.UILanguage))
 	Stake2Cmd.PersistentFlags().BoolVar(&config.Insecure, "insecure", config.Insecure, config.TranslateInLang(_stake2FlagInsecureUsages, config.UILanguage))
 }
@@ -0,0 +1,477 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/ioctl/config"
+	"github.com/iotexproject/iotex-core/ioctl/output"
+	"github.com/iotexproject/iotex-core/ioctl/util"
+)
+
+// Multi-language support
+var (
+	_stake2OverviewCmdUses = map[config.Language]string{
+		config.English: "overview [ALIAS|STAKER_ADDRESS] [--json]",
+		config.Chinese: "overview [别名|质押者地址] [--json]",
+	}
+	_stake2OverviewCmdShorts = map[config.Language]string{
+		config.English: "Show the buckets of a staker with the vote weights, unlock dates and projected rewards",
+		config.Chinese: "显示质押者的投票及其权重、解锁日期和预估奖励",
+	}
+	_stake2OverviewCmdLongs = map[config.Language]string{
+		config.English: "Show the native and contract buckets of a staker, the default account if not given, with the " +
+			"vote weights, the unlock and withdraw dates, the unclaimed reward and the projected reward per epoch.\n" +
+			"The dates of contract buckets are projected from the block heights, and the rewards are projected by " +
+			"the reward estimate of the rewarding protocol as if each bucket were a new stake",
+		config.Chinese: "显示质押者（默认为当前账户）的本地和合约投票，及其权重、解锁和提取日期、未领取奖励和每个纪元的预估奖励。\n" +
+			"合约投票的日期由区块高度推算，奖励由奖励协议的预估得出，每个投票按新质押计算",
+	}
+	_stake2OverviewFlagJSONUsages = map[config.Language]string{
+		config.English: "print the overview in json",
+		config.Chinese: "以 json 格式输出",
+	}
+)
+
+const (
+	// _stake2OverviewPageSize is the number of buckets read in a request
+	_stake2OverviewPageSize = 100
+	// _stake2OverviewBlockInterval is the block interval to project the dates of contract buckets
+	_stake2OverviewBlockInterval = 5 * time.Second
+
+	_bucketStatusAutoStake    = "auto-stake"
+	_bucketStatusLocked       = "locked"
+	_bucketStatusUnlocked     = "unlocked"
+	_bucketStatusUnstaking    = "unstaking"
+	_bucketStatusWithdrawable = "withdrawable"
+)
+
+var _stake2OverviewJSON bool
+
+// _stake2OverviewCmd represents the stake2 overview command
+var _stake2OverviewCmd = &cobra.Command{
+	Use:   config.TranslateInLang(_stake2OverviewCmdUses, config.UILanguage),
+	Short: config.TranslateInLang(_stake2OverviewCmdShorts, config.UILanguage),
+	Long:  config.TranslateInLang(_stake2OverviewCmdLongs, config.UILanguage),
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		arg := ""
+		if len(args) == 1 {
+			arg = args[0]
+		}
+		if _stake2OverviewJSON {
+			output.Format = "json"
+		}
+		err := stake2Overview(arg)
+		return output.PrintError(err)
+	},
+}
+
+type (
+	stakeOverviewMessage struct {
+		Address              string                 `json:"address"`
+		Height               uint64                 `json:"height"`
+		Epoch                uint64                 `json:"epoch"`
+		TotalStaked          string                 `json:"totalStaked"`
+		TotalVoteWeight      string                 `json:"totalVoteWeight"`
+		UnclaimedReward      string                 `json:"unclaimedReward"`
+		EstimatedEpochReward string                 `json:"estimatedEpochReward"`
+		Buckets              []*stakeOverviewBucket `json:"buckets"`
+		Assumptions          []string               `json:"assumptions,omitempty"`
+	}
+
+	stakeOverviewBucket struct {
+		Index                uint64 `json:"index"`
+		ContractAddress      string `json:"contractAddress,omitempty"`
+		Candidate            string `json:"candidate"`
+		StakedAmount         string `json:"stakedAmount"`
+		StakedDuration       uint32 `json:"stakedDuration"`
+		AutoStake            bool   `json:"autoStake"`
+		VoteWeight           string `json:"voteWeight"`
+		Status               string `json:"status"`
+		UnlockTime           string `json:"unlockTime,omitempty"`
+		WithdrawTime         string `json:"withdrawTime,omitempty"`
+		EstimatedEpochReward string `json:"estimatedEpochReward"`
+		EstimateNote         string `json:"estimateNote,omitempty"`
+	}
+
+	// stakeRewardEstimate is the part of the reward estimate of the rewarding protocol read by the overview
+	stakeRewardEstimate struct {
+		Epoch       uint64   `json:"epoch"`
+		VoteWeight  string   `json:"voteWeight"`
+		Reason      string   `json:"reason"`
+		Total       string   `json:"total"`
+		Assumptions []string `json:"assumptions"`
+	}
+
+	// stakeOverviewReader reads the states of the overview in a connection, caching the candidates and estimates
+	// shared by the buckets
+	stakeOverviewReader struct {
+		ctx        context.Context
+		cli        iotexapi.APIServiceClient
+		candidates map[string]string
+		estimates  map[string]*stakeRewardEstimate
+	}
+)
+
+func init() {
+	_stake2OverviewCmd.Flags().BoolVar(&_stake2OverviewJSON, "json", false,
+		config.TranslateInLang(_stake2OverviewFlagJSONUsages, config.UILanguage))
+}
+
+func (m *stakeOverviewMessage) String() string {
+	if output.Format != "" {
+		return output.FormatString(output.Result, m)
+	}
+	lines := []string{
+		fmt.Sprintf("Address: %s,  Height: %d,  Epoch: %d", m.Address, m.Height, m.Epoch),
+		fmt.Sprintf("Total staked: %s IOTX,  Total vote weight: %s,  Unclaimed reward: %s IOTX,  Estimated reward per epoch: %s IOTX",
+			m.TotalStaked, m.TotalVoteWeight, m.UnclaimedReward, m.EstimatedEpochReward),
+		"",
+	}
+	if len(m.Buckets) == 0 {
+		return strings.Join(append(lines, "No bucket staked by the address"), "\n")
+	}
+	rows := [][]string{{"Index", "Contract", "Candidate", "Amount(IOTX)", "Days", "AutoStake", "VoteWeight", "Status", "Unlock", "Withdraw", "Reward/Epoch(IOTX)"}}
+	unstaking := 0
+	for _, b := range m.Buckets {
+		contract, unlock, withdraw := "-", b.UnlockTime, b.WithdrawTime
+		if b.ContractAddress != "" {
+			// the dates of contract buckets are projected from the block heights
+			contract, unlock, withdraw = b.ContractAddress, approximate(unlock), approximate(withdraw)
+		}
+		status := b.Status
+		if status == _bucketStatusUnstaking {
+			status += "*"
+			unstaking++
+		}
+		reward := b.EstimatedEpochReward
+		if b.EstimateNote != "" {
+			reward += " (" + b.EstimateNote + ")"
+		}
+		rows = append(rows, []string{strconv.FormatUint(b.Index, 10), contract, b.Candidate, b.StakedAmount,
+			strconv.FormatUint(uint64(b.StakedDuration), 10), strconv.FormatBool(b.AutoStake), b.VoteWeight,
+			status, orNone(unlock), orNone(withdraw), reward})
+	}
+	lines = append(lines, alignColumns(rows)...)
+	if unstaking > 0 {
+		lines = append(lines, "", fmt.Sprintf("* %d bucket(s) in the unstake waiting period, which can be withdrawn after the withdraw date", unstaking))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func stake2Overview(arg string) error {
+	addr, err := util.GetAddress(arg)
+	if err != nil {
+		return output.NewError(output.AddressError, "failed to get address", err)
+	}
+	conn, err := util.ConnectToEndpoint(config.ReadConfig.SecureConnect && !config.Insecure)
+	if err != nil {
+		return output.NewError(output.NetworkError, "failed to connect to endpoint", err)
+	}
+	defer conn.Close()
+	ctx := context.Background()
+	jwtMD, err := util.JwtAuth()
+	if err == nil {
+		ctx = metautils.NiceMD(jwtMD).ToOutgoing(ctx)
+	}
+	r := &stakeOverviewReader{
+		ctx:        ctx,
+		cli:        iotexapi.NewAPIServiceClient(conn),
+		candidates: make(map[string]string),
+		estimates:  make(map[string]*stakeRewardEstimate),
+	}
+
+	chainMeta, err := r.cli.GetChainMeta(ctx, &iotexapi.GetChainMetaRequest{})
+	if err != nil {
+		return apiError(err, "GetChainMeta")
+	}
+	buckets, err := r.buckets(addr)
+	if err != nil {
+		return err
+	}
+	unclaimed, err := r.unclaimedReward(addr)
+	if err != nil {
+		return err
+	}
+	message := stakeOverviewMessage{
+		Address:         addr,
+		Height:          chainMeta.GetChainMeta().GetHeight(),
+		Epoch:           chainMeta.GetChainMeta().GetEpoch().GetNum(),
+		UnclaimedReward: util.RauToString(unclaimed, util.IotxDecimalNum),
+		Buckets:         make([]*stakeOverviewBucket, 0, len(buckets)),
+	}
+	var (
+		now                           = time.Now()
+		totalStaked, totalWeight, sum = big.NewInt(0), big.NewInt(0), big.NewInt(0)
+	)
+	for _, b := range buckets {
+		amount, ok := new(big.Int).SetString(b.StakedAmount, 10)
+		if !ok {
+			return output.NewError(output.ConvertError, "failed to convert staked amount into big int", nil)
+		}
+		status, unlock, withdraw := bucketSchedule(b, now, message.Height)
+		bucket := &stakeOverviewBucket{
+			Index:                b.Index,
+			ContractAddress:      b.ContractAddress,
+			Candidate:            r.candidateName(b.CandidateAddress),
+			StakedAmount:         util.RauToString(amount, util.IotxDecimalNum),
+			StakedDuration:       b.StakedDuration,
+			AutoStake:            b.AutoStake,
+			VoteWeight:           "0",
+			Status:               status,
+			UnlockTime:           formatTime(unlock),
+			WithdrawTime:         formatTime(withdraw),
+			EstimatedEpochReward: "0",
+		}
+		totalStaked.Add(totalStaked, amount)
+		// an unstaked bucket has no votes any more
+		if status != _bucketStatusUnstaking && status != _bucketStatusWithdrawable {
+			est, err := r.estimate(bucket.Candidate, amount, b.StakedDuration, b.AutoStake)
+			if err != nil {
+				return err
+			}
+			weight, ok := new(big.Int).SetString(est.VoteWeight, 10)
+			if !ok {
+				return output.NewError(output.ConvertError, "failed to convert vote weight into big int", nil)
+			}
+			reward, ok := new(big.Int).SetString(est.Total, 10)
+			if !ok {
+				return output.NewError(output.ConvertError, "failed to convert estimated reward into big int", nil)
+			}
+			totalWeight.Add(totalWeight, weight)
+			sum.Add(sum, reward)
+			bucket.VoteWeight = util.RauToString(weight, util.IotxDecimalNum)
+			bucket.EstimatedEpochReward = util.RauToString(reward, util.IotxDecimalNum)
+			bucket.EstimateNote = est.Reason
+			message.Assumptions = est.Assumptions
+		}
+		message.Buckets = append(message.Buckets, bucket)
+	}
+	message.TotalStaked = util.RauToString(totalStaked, util.IotxDecimalNum)
+	message.TotalVoteWeight = util.RauToString(totalWeight, util.IotxDecimalNum)
+	message.EstimatedEpochReward = util.RauToString(sum, util.IotxDecimalNum)
+	fmt.Println(message.String())
+	return nil
+}
+
+// bucketSchedule returns the status of the bucket at the time now of the tip height, and the time it unlocks and the
+// time it can be withdrawn, which are zero if unknown. The time of a contract bucket is projected from the block
+// heights
+func bucketSchedule(b *iotextypes.VoteBucket, now time.Time, tip uint64) (string, time.Time, time.Time) {
+	var (
+		waitingPeriod    = genesis.Default.WithdrawWaitingPeriod
+		unlock, withdraw time.Time
+	)
+	if b.ContractAddress == "" {
+		stakeStart, unstakeStart := b.StakeStartTime.AsTime(), b.UnstakeStartTime.AsTime()
+		if unstakeStart.After(stakeStart) {
+			withdraw = unstakeStart.Add(waitingPeriod)
+		} else if !b.AutoStake {
+			unlock = stakeStart.Add(time.Duration(b.StakedDuration) * 24 * time.Hour)
+		}
+	} else {
+		at := func(height uint64) time.Time {
+			return now.Add(time.Duration(int64(height)-int64(tip)) * _stake2OverviewBlockInterval)
+		}
+		if b.UnstakeStartBlockHeight != math.MaxUint64 {
+			withdraw = at(b.UnstakeStartBlockHeight).Add(waitingPeriod)
+		} else if !b.AutoStake {
+			unlock = at(b.StakeStartBlockHeight + b.StakedDurationBlockNumber)
+		}
+	}
+	switch {
+	case !withdraw.IsZero() && now.Before(withdraw):
+		return _bucketStatusUnstaking, unlock, withdraw
+	case !withdraw.IsZero():
+		return _bucketStatusWithdrawable, unlock, withdraw
+	case b.AutoStake:
+		// the staked duration does not elapse until auto-stake is turned off
+		return _bucketStatusAutoStake, unlock, withdraw
+	case now.Before(unlock):
+		return _bucketStatusLocked, unlock, withdraw
+	default:
+		return _bucketStatusUnlocked, unlock, withdraw
+	}
+}
+
+// buckets reads all the native and contract buckets of the voter page by page
+func (r *stakeOverviewReader) buckets(voter string) ([]*iotextypes.VoteBucket, error) {
+	var buckets []*iotextypes.VoteBucket
+	for offset := uint32(0); ; offset += _stake2OverviewPageSize {
+		page := iotextypes.VoteBucketList{}
+		if err := r.readStakingData(iotexapi.ReadStakingDataMethod_COMPOSITE_BUCKETS_BY_VOTER, &iotexapi.ReadStakingDataRequest{
+			Request: &iotexapi.ReadStakingDataRequest_BucketsByVoter{
+				BucketsByVoter: &iotexapi.ReadStakingDataRequest_VoteBucketsByVoter{
+					VoterAddress: voter,
+					Pagination: &iotexapi.PaginationParam{
+						Offset: offset,
+						Limit:  _stake2OverviewPageSize,
+					},
+				},
+			},
+		}, &page); err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, page.Buckets...)
+		if len(page.Buckets) < _stake2OverviewPageSize {
+			return buckets, nil
+		}
+	}
+}
+
+// candidateName returns the name of the candidate, or the address if the candidate is not found
+func (r *stakeOverviewReader) candidateName(addr string) string {
+	if name, ok := r.candidates[addr]; ok {
+		return name
+	}
+	cand := iotextypes.CandidateV2{}
+	name := addr
+	if err := r.readStakingData(iotexapi.ReadStakingDataMethod_CANDIDATE_BY_ADDRESS, &iotexapi.ReadStakingDataRequest{
+		Request: &iotexapi.ReadStakingDataRequest_CandidateByAddress_{
+			CandidateByAddress: &iotexapi.ReadStakingDataRequest_CandidateByAddress{
+				OwnerAddr: addr,
+				Id:        addr,
+			},
+		},
+	}, &cand); err == nil && cand.Name != "" {
+		name = cand.Name
+	}
+	r.candidates[addr] = name
+	return name
+}
+
+func (r *stakeOverviewReader) unclaimedReward(addr string) (*big.Int, error) {
+	data, err := r.readState("rewarding", []byte("UnclaimedBalance"), []byte(addr))
+	if err != nil {
+		return nil, err
+	}
+	reward, ok := new(big.Int).SetString(string(data), 10)
+	if !ok {
+		return nil, output.NewError(output.ConvertError, "failed to convert string into big int", nil)
+	}
+	return reward, nil
+}
+
+// estimate reads the projected per-epoch reward of staking the amount to the candidate
+func (r *stakeOverviewReader) estimate(candidate string, amount *big.Int, duration uint32, autoStake bool) (*stakeRewardEstimate, error) {
+	args := []string{candidate, amount.String(), strconv.FormatUint(uint64(duration), 10), strconv.FormatBool(autoStake)}
+	key := strings.Join(args, ",")
+	if est, ok := r.estimates[key]; ok {
+		return est, nil
+	}
+	data, err := r.readState("rewarding", []byte("RewardEstimate"),
+		[]byte(args[0]), []byte(args[1]), []byte(args[2]), []byte(args[3]))
+	if err != nil {
+		return nil, err
+	}
+	est := stakeRewardEstimate{}
+	if err := json.Unmarshal(data, &est); err != nil {
+		return nil, output.NewError(output.SerializationError, "failed to unmarshal reward estimate", err)
+	}
+	r.estimates[key] = &est
+	return &est, nil
+}
+
+func (r *stakeOverviewReader) readStakingData(method iotexapi.ReadStakingDataMethod_Name, req *iotexapi.ReadStakingDataRequest, resp proto.Message) error {
+	methodData, err := proto.Marshal(&iotexapi.ReadStakingDataMethod{Method: method})
+	if err != nil {
+		return output.NewError(output.SerializationError, "failed to marshal read staking data method", err)
+	}
+	requestData, err := proto.Marshal(req)
+	if err != nil {
+		return output.NewError(output.SerializationError, "failed to marshal read staking data request", err)
+	}
+	data, err := r.readState("staking", methodData, requestData)
+	if err != nil {
+		return err
+	}
+	if err := proto.Unmarshal(data, resp); err != nil {
+		return output.NewError(output.SerializationError, "failed to unmarshal response", err)
+	}
+	return nil
+}
+
+func (r *stakeOverviewReader) readState(protocolID string, method []byte, args ...[]byte) ([]byte, error) {
+	response, err := r.cli.ReadState(r.ctx, &iotexapi.ReadStateRequest{
+		ProtocolID: []byte(protocolID),
+		MethodName: method,
+		Arguments:  args,
+	})
+	if err != nil {
+		return nil, apiError(err, "ReadState")
+	}
+	return response.Data, nil
+}
+
+func apiError(err error, api string) error {
+	if sta, ok := status.FromError(err); ok {
+		return output.NewError(output.APIError, sta.Message(), nil)
+	}
+	return output.NewError(output.NetworkError, "failed to invoke "+api+" api", err)
+}
+
+// alignColumns pads the cells of the rows to the widths of the columns
+func alignColumns(rows [][]string) []string {
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			if len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+	lines := make([]string, 0, len(rows))
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = fmt.Sprintf("%-*s", widths[i], cell)
+		}
+		lines = append(lines, strings.TrimRight(strings.Join(cells, "   "), " "))
+	}
+	return lines
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func approximate(s string) string {
+	if s == "" {
+		return s
+	}
+	return "~" + s
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/iotexproject/iotex-core/ioctl/output"
+)
+
+func TestBucketSchedule(t *testing.T) {
+	r := require.New(t)
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	native := func(stakeStart, unstakeStart time.Time, days uint32, autoStake bool) *iotextypes.VoteBucket {
+		return &iotextypes.VoteBucket{
+			StakedDuration:   days,
+			StakeStartTime:   timestamppb.New(stakeStart),
+			UnstakeStartTime: timestamppb.New(unstakeStart),
+			AutoStake:        autoStake,
+		}
+	}
+	never := time.Unix(0, 0)
+
+	for _, v := range []struct {
+		b                *iotextypes.VoteBucket
+		status           string
+		unlock, withdraw time.Time
+	}{
+		{native(now.Add(-10*day), never, 91, true), _bucketStatusAutoStake, time.Time{}, time.Time{}},
+		{native(now.Add(-10*day), never, 91, false), _bucketStatusLocked, now.Add(81 * day), time.Time{}},
+		{native(now.Add(-100*day), never, 91, false), _bucketStatusUnlocked, now.Add(-9 * day), time.Time{}},
+		{native(now.Add(-100*day), now.Add(-day), 91, false), _bucketStatusUnstaking, time.Time{}, now.Add(2 * day)},
+		{native(now.Add(-100*day), now.Add(-5*day), 91, false), _bucketStatusWithdrawable, time.Time{}, now.Add(-2 * day)},
+	} {
+		status, unlock, withdraw := bucketSchedule(v.b, now, 0)
+		r.Equal(v.status, status)
+		r.True(v.unlock.Equal(unlock))
+		r.True(v.withdraw.Equal(withdraw))
+	}
+
+	// the dates of contract buckets are projected from the block heights
+	contract := &iotextypes.VoteBucket{
+		ContractAddress:           "io1contract",
+		StakedDuration:            1,
+		StakedDurationBlockNumber: 17280,
+		StakeStartBlockHeight:     1000,
+		UnstakeStartBlockHeight:   math.MaxUint64,
+	}
+	status, unlock, _ := bucketSchedule(contract, now, 1000+17280-720)
+	r.Equal(_bucketStatusLocked, status)
+	r.Equal(now.Add(time.Hour), unlock)
+	contract.UnstakeStartBlockHeight = 20000
+	status, _, withdraw := bucketSchedule(contract, now, 20000+17280)
+	r.Equal(_bucketStatusUnstaking, status)
+	r.Equal(now.Add(2*day), withdraw)
+}
+
+func TestStakeOverviewMessage(t *testing.T) {
+	r := require.New(t)
+	m := &stakeOverviewMessage{
+		Address:              "io1staker",
+		Height:               100,
+		Epoch:                2,
+		TotalStaked:          "300",
+		TotalVoteWeight:      "120",
+		UnclaimedReward:      "1.5",
+		EstimatedEpochReward: "0.2",
+		Buckets: []*stakeOverviewBucket{
+			{Index: 1, Candidate: "delegate", StakedAmount: "100", StakedDuration: 91, AutoStake: true, VoteWeight: "120", Status: _bucketStatusAutoStake, EstimatedEpochReward: "0.2"},
+			{Index: 2, Candidate: "delegate", StakedAmount: "200", StakedDuration: 7, VoteWeight: "0", Status: _bucketStatusUnstaking, WithdrawTime: "2024-06-03T00:00:00Z", EstimatedEpochReward: "0"},
+		},
+	}
+	s := m.String()
+	r.Contains(s, "unstaking*")
+	r.Contains(s, "* 1 bucket(s) in the unstake waiting period")
+
+	output.Format = "json"
+	defer func() { output.Format = "" }()
+	s = m.String()
+	r.Contains(s, `"status": "unstaking"`)
+	r.Contains(s, `"withdrawTime": "2024-06-03T00:00:00Z"`)
+}
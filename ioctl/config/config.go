@@ -87,6 +87,8 @@ type Config struct {
 	WsRouterContract string `json:"wsRouterContract" yaml:"wsRouterContract"`
 	// WsVmTypeContract w3bstream VMType contract address
 	WsVmTypeContract string `json:"wsVmTypeContract" yaml:"wsVmTypeContract"`
+	// LedgerEndpoint APDU endpoint of the ledger device signing for "ledger::" signers
+	LedgerEndpoint string `json:"ledgerEndpoint" yaml:"ledgerEndpoint"`
 }
 
 var (
@@ -152,6 +154,10 @@ func init() {
 		ReadConfig.IPFSGateway = _defaultIPFSGateway
 		completeness = false
 	}
+	if ReadConfig.LedgerEndpoint == "" {
+		ReadConfig.LedgerEndpoint = _defaultLedgerEndpoint
+		completeness = false
+	}
 	if ReadConfig.WsProjectRegisterContract == "" {
 		ReadConfig.WsProjectRegisterContract = _defaultWsProjectRegisterContract
 		completeness = false
@@ -34,6 +34,8 @@ const (
 	_defaultIPFSEndpoint = "ipfs.mainnet.iotex.io"
 	// _defaultIPFSGateway default IPFS gateway for resource fetching
 	_defaultIPFSGateway = "https://ipfs.io"
+	// _defaultLedgerEndpoint default APDU endpoint of ledger device
+	_defaultLedgerEndpoint = "127.0.0.1:9999"
 	// _defaultWsProjectRegisterContract  default project register contract address
 	_defaultWsProjectRegisterContract = "0x6325D51b6F8bC78b00c55e6233e8824231C31DE2"
 	// _defaultWsProjectStoreContract  default project store contract address
@@ -52,8 +54,8 @@ const (
 
 var (
 	_supportedLanguage = []string{"English", "中文"}
-	_validArgs         = []string{"endpoint", "wallet", "explorer", "defaultacc", "language", "nsv2height", "wsEndpoint", "ipfsEndpoint", "ipfsGateway", "wsProjectRegisterContract", "wsProjectStoreContract", "wsFleetManagementContract", "wsProverStoreContract", "wsProjectDevicesContract", "wsRouterContract", "wsVmTypeContract"}
-	_validGetArgs      = []string{"endpoint", "wallet", "explorer", "defaultacc", "language", "nsv2height", "analyserEndpoint", "wsEndpoint", "ipfsEndpoint", "ipfsGateway", "wsProjectRegisterContract", "wsProjectStoreContract", "wsFleetManagementContract", "wsProverStoreContract", "wsProjectDevicesContract", "wsRouterContract", "wsVmTypeContract", "all"}
+	_validArgs         = []string{"endpoint", "wallet", "explorer", "defaultacc", "language", "nsv2height", "wsEndpoint", "ipfsEndpoint", "ipfsGateway", "wsProjectRegisterContract", "wsProjectStoreContract", "wsFleetManagementContract", "wsProverStoreContract", "wsProjectDevicesContract", "wsRouterContract", "wsVmTypeContract", "ledgerEndpoint"}
+	_validGetArgs      = []string{"endpoint", "wallet", "explorer", "defaultacc", "language", "nsv2height", "analyserEndpoint", "wsEndpoint", "ipfsEndpoint", "ipfsGateway", "wsProjectRegisterContract", "wsProjectStoreContract", "wsFleetManagementContract", "wsProverStoreContract", "wsProjectDevicesContract", "wsRouterContract", "wsVmTypeContract", "ledgerEndpoint", "all"}
 	_validExpl         = []string{"iotexscan", "iotxplorer"}
 	_endpointCompile   = regexp.MustCompile("^" + _endpointPattern + "$")
 )
@@ -175,6 +177,8 @@ func Get(arg string) error {
 		fmt.Println(ReadConfig.IPFSEndpoint)
 	case "ipfsGateway":
 		fmt.Println(ReadConfig.IPFSGateway)
+	case "ledgerEndpoint":
+		fmt.Println(ReadConfig.LedgerEndpoint)
 	case "wsProjectRegisterContract":
 		fmt.Println(ReadConfig.WsProjectRegisterContract)
 	case "wsProjectStoreContract":
@@ -323,6 +327,8 @@ func set(args []string) error {
 		ReadConfig.IPFSEndpoint = args[1]
 	case "ipfsGateway":
 		ReadConfig.IPFSGateway = args[1]
+	case "ledgerEndpoint":
+		ReadConfig.LedgerEndpoint = args[1]
 	case "wsProjectRegisterContract":
 		ReadConfig.WsProjectRegisterContract = args[1]
 	case "wsProjectStoreContract":
@@ -358,6 +364,7 @@ func reset() error {
 	ReadConfig.WsEndpoint = _defaultWsEndpoint
 	ReadConfig.IPFSEndpoint = _defaultIPFSEndpoint
 	ReadConfig.IPFSGateway = _defaultIPFSGateway
+	ReadConfig.LedgerEndpoint = _defaultLedgerEndpoint
 	ReadConfig.WsProjectRegisterContract = _defaultWsProjectRegisterContract
 	ReadConfig.WsProjectStoreContract = _defaultWsProjectStoreContract
 	ReadConfig.WsFleetManagementContract = _defaultWsFleetManagementContract
@@ -0,0 +1,196 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package ledger
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/pkg/errors"
+)
+
+// the APDU of the IoTeX ledger app
+const (
+	_cla           = 0x55
+	_insPublicKey  = 0x04
+	_insSign       = 0x02
+	_chunkInit     = 0x00
+	_chunkAdd      = 0x01
+	_chunkLast     = 0x02
+	_chunkSize     = 250
+	_publicKeyLen  = 65
+	_signatureLen  = 65
+	_hardened      = 0x80000000
+	_purpose       = 44
+	_iotexCoinType = 304
+)
+
+// the status words returned by the ledger app
+const (
+	_swOK                = 0x9000
+	_swRejected          = 0x6986
+	_swConditionNotMet   = 0x6985
+	_swDataInvalid       = 0x6984
+	_swInsNotSupported   = 0x6d00
+	_swClaNotSupported   = 0x6e00
+	_swClaNotSupported01 = 0x6e01
+)
+
+var (
+	// ErrRejected is the error that the user rejected the request on device
+	ErrRejected = errors.New("rejected on ledger device")
+	// ErrTimeout is the error that the device did not respond in time
+	ErrTimeout = errors.New("ledger device timeout")
+	// ErrAppNotOpen is the error that the IoTeX app is not open on device
+	ErrAppNotOpen = errors.New("IoTeX app is not open on ledger device")
+	// ErrUnsupported is the error that the app could not decode the request, e.g. an action type unknown to the
+	// version of the app
+	ErrUnsupported = errors.New("request not supported by ledger app")
+)
+
+type (
+	// Transport exchanges APDUs with a ledger device
+	Transport interface {
+		// Exchange sends the APDU and returns the response, with the 2-byte status word at the end
+		Exchange([]byte) ([]byte, error)
+		Close() error
+	}
+
+	// Path is the BIP44 path of a key m/44'/304'/account'/change/index
+	Path [5]uint32
+
+	// Device is a ledger device running the IoTeX app
+	Device struct {
+		transport Transport
+		timeout   time.Duration
+	}
+
+	// Option is the option of the device
+	Option func(*Device)
+)
+
+// NewPath returns the BIP44 path of the IoTeX key
+func NewPath(account, change, index uint32) Path {
+	return Path{_purpose | _hardened, _iotexCoinType | _hardened, account | _hardened, change, index}
+}
+
+// String returns the path in m/44'/304'/account'/change/index
+func (p Path) String() string {
+	return fmt.Sprintf("m/44'/304'/%d'/%d/%d", p[2]&^_hardened, p[3], p[4])
+}
+
+func (p Path) bytes() []byte {
+	b := make([]byte, 4*len(p))
+	for i, v := range p {
+		binary.LittleEndian.PutUint32(b[4*i:], v)
+	}
+	return b
+}
+
+// WithTimeout sets the timeout of a request, which includes the time of the user confirming on device
+func WithTimeout(timeout time.Duration) Option {
+	return func(d *Device) {
+		d.timeout = timeout
+	}
+}
+
+// NewDevice creates a device on the transport
+func NewDevice(transport Transport, opts ...Option) *Device {
+	d := &Device{
+		transport: transport,
+		timeout:   2 * time.Minute,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Close closes the transport of the device
+func (d *Device) Close() error {
+	return d.transport.Close()
+}
+
+// PublicKey returns the public key of the path
+func (d *Device) PublicKey(path Path) (crypto.PublicKey, error) {
+	resp, err := d.exchange(_insPublicKey, 0, path.bytes())
+	if err != nil {
+		return nil, err
+	}
+	if len(resp) < _publicKeyLen {
+		return nil, errors.Errorf("invalid public key length %d", len(resp))
+	}
+	return crypto.BytesToPublicKey(resp[:_publicKeyLen])
+}
+
+// Sign signs the serialized action core with the key of the path, the app decodes and displays the action for the
+// user to confirm. The data is sent in chunks after the path, and the 65-byte signature is returned
+func (d *Device) Sign(path Path, data []byte) ([]byte, error) {
+	if _, err := d.exchange(_insSign, _chunkInit, path.bytes()); err != nil {
+		return nil, err
+	}
+	var resp []byte
+	for start := 0; start < len(data) || start == 0; start += _chunkSize {
+		end, p1 := start+_chunkSize, byte(_chunkAdd)
+		if end >= len(data) {
+			end, p1 = len(data), _chunkLast
+		}
+		var err error
+		if resp, err = d.exchange(_insSign, p1, data[start:end]); err != nil {
+			return nil, err
+		}
+		if p1 == _chunkLast {
+			break
+		}
+	}
+	if len(resp) < _signatureLen {
+		return nil, errors.Errorf("invalid signature length %d", len(resp))
+	}
+	return resp[:_signatureLen], nil
+}
+
+func (d *Device) exchange(ins, p1 byte, data []byte) ([]byte, error) {
+	if len(data) > 255 {
+		return nil, errors.Errorf("APDU data length %d exceeds 255", len(data))
+	}
+	apdu := append([]byte{_cla, ins, p1, 0, byte(len(data))}, data...)
+	type result struct {
+		resp []byte
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		resp, err := d.transport.Exchange(apdu)
+		done <- result{resp, err}
+	}()
+	var r result
+	select {
+	case r = <-done:
+	case <-time.After(d.timeout):
+		return nil, errors.Wrapf(ErrTimeout, "no response in %s", d.timeout)
+	}
+	if r.err != nil {
+		return nil, errors.Wrap(r.err, "failed to exchange with ledger device")
+	}
+	if len(r.resp) < 2 {
+		return nil, errors.Errorf("invalid response length %d", len(r.resp))
+	}
+	resp, sw := r.resp[:len(r.resp)-2], binary.BigEndian.Uint16(r.resp[len(r.resp)-2:])
+	switch sw {
+	case _swOK:
+		return resp, nil
+	case _swRejected, _swConditionNotMet:
+		return nil, ErrRejected
+	case _swClaNotSupported, _swClaNotSupported01:
+		return nil, ErrAppNotOpen
+	case _swDataInvalid, _swInsNotSupported:
+		return nil, errors.Wrapf(ErrUnsupported, "status word %#04x", sw)
+	default:
+		return nil, errors.Errorf("ledger device returned status word %#04x", sw)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+//go:build ledger
+
+package ledger
+
+import (
+	"bytes"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+// TestEmulator signs the actions with the IoTeX app running on the Speculos emulator, with the APDU port at
+// $LEDGER_ENDPOINT (default 127.0.0.1:9999) and the actions approved by the automation of the emulator, run by
+// go test -tags ledger ./ioctl/ledger/
+func TestEmulator(t *testing.T) {
+	r := require.New(t)
+	endpoint := os.Getenv("LEDGER_ENDPOINT")
+	if endpoint == "" {
+		endpoint = "127.0.0.1:9999"
+	}
+	transport, err := NewTCPTransport(endpoint)
+	r.NoError(err)
+	d := NewDevice(transport)
+	defer d.Close()
+
+	path := NewPath(0, 0, 0)
+	pk, err := d.PublicKey(path)
+	r.NoError(err)
+
+	var (
+		gasPrice = big.NewInt(1000000000000)
+		must     = func(act any, err error) any {
+			r.NoError(err)
+			return act
+		}
+	)
+	for name, act := range map[string]any{
+		"CreateStake":     must(action.NewCreateStake(1, "robotbp00000", "100000000000000000000", 91, true, nil, 10000, gasPrice)),
+		"Unstake":         must(action.NewUnstake(2, 7, nil, 10000, gasPrice)),
+		"ChangeCandidate": must(action.NewChangeCandidate(3, "robotbp00001", 7, nil, 10000, gasPrice)),
+		"Execution":       must(action.NewExecution(identityset.Address(28).String(), 4, big.NewInt(0), 1000000, gasPrice, bytes.Repeat([]byte{0xab}, 3*_chunkSize))),
+	} {
+		t.Run(name, func(t *testing.T) {
+			eb := (&action.EnvelopeBuilder{}).SetGasLimit(1000000).SetGasPrice(gasPrice).SetChainID(1)
+			switch act := act.(type) {
+			case *action.CreateStake:
+				eb.SetNonce(1).SetAction(act)
+			case *action.Unstake:
+				eb.SetNonce(2).SetAction(act)
+			case *action.ChangeCandidate:
+				eb.SetNonce(3).SetAction(act)
+			case *action.Execution:
+				eb.SetNonce(4).SetAction(act)
+			}
+			elp := eb.Build()
+			core, err := proto.Marshal(elp.Proto())
+			r.NoError(err)
+			sig, err := d.Sign(path, core)
+			if errors.Cause(err) == ErrRejected {
+				t.Skip("rejected on emulator")
+			}
+			r.NoError(err)
+			r.NoError(action.AssembleSealedEnvelope(elp, pk, sig).VerifySignature())
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package ledger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+// fakeApp is a transport answering as the IoTeX app with a key, or with the status word if set
+type fakeApp struct {
+	key   crypto.PrivateKey
+	sw    uint16
+	delay time.Duration
+	apdus [][]byte
+	data  []byte
+}
+
+func (f *fakeApp) Exchange(apdu []byte) ([]byte, error) {
+	time.Sleep(f.delay)
+	f.apdus = append(f.apdus, apdu)
+	if f.sw != 0 {
+		return binary.BigEndian.AppendUint16(nil, f.sw), nil
+	}
+	var resp []byte
+	switch ins, p1, data := apdu[1], apdu[2], apdu[5:]; {
+	case ins == _insPublicKey:
+		resp = f.key.PublicKey().Bytes()
+	case ins == _insSign && p1 == _chunkInit:
+		f.data = nil
+	case ins == _insSign:
+		f.data = append(f.data, data...)
+		if p1 == _chunkLast {
+			h := hash.Hash256b(f.data)
+			sig, err := f.key.Sign(h[:])
+			if err != nil {
+				return nil, err
+			}
+			resp = sig
+		}
+	}
+	return binary.BigEndian.AppendUint16(resp, _swOK), nil
+}
+
+func (f *fakeApp) Close() error { return nil }
+
+func TestDevice(t *testing.T) {
+	r := require.New(t)
+	key := identityset.PrivateKey(27)
+	path := NewPath(0, 0, 1)
+	r.Equal("m/44'/304'/0'/0/1", path.String())
+
+	t.Run("sign in chunks", func(t *testing.T) {
+		app := &fakeApp{key: key}
+		d := NewDevice(app)
+		pk, err := d.PublicKey(path)
+		r.NoError(err)
+		r.Equal(key.PublicKey().Bytes(), pk.Bytes())
+		r.Equal([]byte{_cla, _insPublicKey, 0, 0, 20}, app.apdus[0][:5])
+		r.Equal(path.bytes(), app.apdus[0][5:])
+
+		for _, size := range []int{0, 100, _chunkSize, 2*_chunkSize + 1} {
+			app.apdus = nil
+			data := bytes.Repeat([]byte{1}, size)
+			sig, err := d.Sign(path, data)
+			r.NoError(err)
+			h := hash.Hash256b(data)
+			r.True(pk.Verify(h[:], sig))
+			// the path and the chunks of data
+			chunks := (size + _chunkSize - 1) / _chunkSize
+			if chunks == 0 {
+				chunks = 1
+			}
+			r.Len(app.apdus, 1+chunks)
+			r.EqualValues(_chunkInit, app.apdus[0][2])
+			for i := 1; i < chunks; i++ {
+				r.EqualValues(_chunkAdd, app.apdus[i][2])
+			}
+			r.EqualValues(_chunkLast, app.apdus[chunks][2])
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, v := range []struct {
+			sw  uint16
+			err error
+		}{
+			{_swRejected, ErrRejected},
+			{_swConditionNotMet, ErrRejected},
+			{_swClaNotSupported, ErrAppNotOpen},
+			{_swDataInvalid, ErrUnsupported},
+		} {
+			_, err := NewDevice(&fakeApp{key: key, sw: v.sw}).Sign(path, []byte{1})
+			r.Equal(v.err, errors.Cause(err))
+		}
+		_, err := NewDevice(&fakeApp{key: key, sw: 0x6a80}).PublicKey(path)
+		r.ErrorContains(err, "0x6a80")
+
+		_, err = NewDevice(&fakeApp{key: key, delay: time.Second}, WithTimeout(10*time.Millisecond)).Sign(path, []byte{1})
+		r.Equal(ErrTimeout, errors.Cause(err))
+	})
+}
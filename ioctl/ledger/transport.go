@@ -0,0 +1,52 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package ledger
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// tcpTransport exchanges APDUs over TCP as the APDU port of the Speculos emulator. Each APDU is prefixed by its
+// 4-byte length, and each response is its 4-byte length excluding the status word, the data and the 2-byte status
+// word
+type tcpTransport struct {
+	conn net.Conn
+}
+
+// NewTCPTransport connects to the APDU endpoint of a ledger emulator
+func NewTCPTransport(endpoint string) (Transport, error) {
+	conn, err := net.DialTimeout("tcp", endpoint, 5*time.Second)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to ledger at %s", endpoint)
+	}
+	return &tcpTransport{conn: conn}, nil
+}
+
+func (t *tcpTransport) Exchange(apdu []byte) ([]byte, error) {
+	req := make([]byte, 4, 4+len(apdu))
+	binary.BigEndian.PutUint32(req, uint32(len(apdu)))
+	if _, err := t.conn.Write(append(req, apdu...)); err != nil {
+		return nil, err
+	}
+	var size [4]byte
+	if _, err := io.ReadFull(t.conn, size[:]); err != nil {
+		return nil, err
+	}
+	resp := make([]byte, binary.BigEndian.Uint32(size[:])+2)
+	if _, err := io.ReadFull(t.conn, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (t *tcpTransport) Close() error {
+	return t.conn.Close()
+}
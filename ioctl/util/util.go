@@ -209,7 +209,16 @@ func ParseHdwPath(addressOrAlias string) (uint32, uint32, uint32, error) {
 	// parse derive path
 	// for hdw::1/1/2, return 1, 1, 2
 	// for hdw::1/2, treat as default account = 0, return 0, 1, 2
-	args := strings.Split(addressOrAlias[5:], "/")
+	return parseDerivePath(addressOrAlias[5:])
+}
+
+// ParseLedgerPath parses the derive path of ledger key, in the same format as HDWallet key, e.g. ledger::1/2
+func ParseLedgerPath(addressOrAlias string) (uint32, uint32, uint32, error) {
+	return parseDerivePath(addressOrAlias[8:])
+}
+
+func parseDerivePath(path string) (uint32, uint32, uint32, error) {
+	args := strings.Split(path, "/")
 	if len(args) < 2 || len(args) > 3 {
 		return 0, 0, 0, output.NewError(output.ValidationError, "derivation path error", nil)
 	}
@@ -231,3 +240,8 @@ func ParseHdwPath(addressOrAlias string) (uint32, uint32, uint32, error) {
 func AliasIsHdwalletKey(addressOrAlias string) bool {
 	return strings.HasPrefix(strings.ToLower(addressOrAlias), "hdw::")
 }
+
+// AliasIsLedgerKey check whether to use ledger key
+func AliasIsLedgerKey(addressOrAlias string) bool {
+	return strings.HasPrefix(strings.ToLower(addressOrAlias), "ledger::")
+}
@@ -96,6 +96,21 @@ func TestParseHdwPath(t *testing.T) {
 	}
 }
 
+func TestParseLedgerPath(t *testing.T) {
+	r := require.New(t)
+
+	r.True(AliasIsLedgerKey("Ledger::0/1"))
+	r.False(AliasIsLedgerKey("hdw::0/1"))
+	a, b, c, err := ParseLedgerPath("ledger::1/2/3")
+	r.NoError(err)
+	r.Equal([]uint32{1, 2, 3}, []uint32{a, b, c})
+	a, b, c, err = ParseLedgerPath("ledger::2/3")
+	r.NoError(err)
+	r.Equal([]uint32{0, 2, 3}, []uint32{a, b, c})
+	_, _, _, err = ParseLedgerPath("ledger::1")
+	r.ErrorContains(err, "derivation path error")
+}
+
 func TestAddress(t *testing.T) {
 	require := require.New(t)
 
@@ -22,6 +22,8 @@ import (
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	cp "github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/p2p"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/routine"
@@ -34,6 +36,7 @@ type (
 		BroadcastOutbound(context.Context, proto.Message) error
 		UnicastOutbound(context.Context, peer.AddrInfo, proto.Message) error
 		Info() (peer.AddrInfo, error)
+		KnownPeers() []*p2p.PeerRecord
 	}
 
 	chain interface {
@@ -58,7 +61,7 @@ type (
 		nodeMap              *lru.Cache
 		transmitter          transmitter
 		chain                chain
-		privKey              crypto.PrivateKey
+		privKey              cp.Signer
 		getBroadcastListFunc getBroadcastListFunc
 	}
 
@@ -78,7 +81,7 @@ func init() {
 }
 
 // NewInfoManager new info manager
-func NewInfoManager(cfg *Config, t transmitter, ch chain, privKey crypto.PrivateKey, broadcastListFunc getBroadcastListFunc) *InfoManager {
+func NewInfoManager(cfg *Config, t transmitter, ch chain, privKey cp.Signer, broadcastListFunc getBroadcastListFunc) *InfoManager {
 	dm := &InfoManager{
 		nodeMap:              lru.New(cfg.NodeMapSize),
 		transmitter:          t,
@@ -161,6 +164,11 @@ func (dm *InfoManager) GetNodeInfo(addr string) (Info, bool) {
 	return info.(Info), true
 }
 
+// KnownPeers returns the peers in the peer store of the p2p agent in the order of score
+func (dm *InfoManager) KnownPeers() []*p2p.PeerRecord {
+	return dm.transmitter.KnownPeers()
+}
+
 // BroadcastNodeInfo broadcast request node info message
 func (dm *InfoManager) BroadcastNodeInfo(ctx context.Context) error {
 	log.L().Debug("nodeinfo manager broadcast node info")
@@ -19,6 +19,7 @@ import (
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/iotexproject/iotex-core/p2p"
 	"github.com/iotexproject/iotex-core/test/mock/mock_nodeinfo"
 )
 
@@ -262,3 +263,18 @@ func TestDelegateManager_GetNodeByAddr(t *testing.T) {
 	})
 
 }
+
+func TestDelegateManager_KnownPeers(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	hMock := mock_nodeinfo.NewMockchain(ctrl)
+	tMock := mock_nodeinfo.NewMocktransmitter(ctrl)
+	privKey, err := crypto.GenerateKey()
+	require.NoError(err)
+
+	dm := NewInfoManager(&DefaultConfig, tMock, hMock, privKey, getEmptyWhiteList)
+	peers := []*p2p.PeerRecord{{ID: "peer1", Addrs: []string{"/ip4/127.0.0.1/tcp/4689"}, Score: 2}}
+	tMock.EXPECT().KnownPeers().Return(peers).Times(1)
+	require.Equal(peers, dm.KnownPeers())
+}
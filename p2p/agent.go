@@ -9,8 +9,11 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -69,6 +72,9 @@ const (
 	_unicastTopic      = "unicast"
 	_numDialRetries    = 8
 	_dialRetryInterval = 2 * time.Second
+	// _maxStoredPeerDials is the number of peers in the peer store dialed at most, in the order of score
+	_maxStoredPeerDials    = 10
+	_storedPeerDialTimeout = 10 * time.Second
 )
 
 type (
@@ -95,6 +101,14 @@ type (
 		PrivateNetworkPSK string              `yaml:"privateNetworkPSK"`
 		MaxPeers          int                 `yaml:"maxPeers"`
 		MaxMessageSize    int                 `yaml:"maxMessageSize"`
+		// DNSSeeds are the domain names whose TXT records list the multiaddrs of the seed nodes, which are dialed
+		// along with the bootstrap nodes. The records are signed by the key of DNSSeedPublicKey
+		DNSSeeds         []string `yaml:"dnsSeeds"`
+		DNSSeedPublicKey string   `yaml:"dnsSeedPublicKey"`
+		// PeerStorePath is the file the peers connected successfully are saved into, which is disabled if empty
+		PeerStorePath string `yaml:"peerStorePath"`
+		// PeerStoreSize is the maximum number of peers in the peer store
+		PeerStoreSize int `yaml:"peerStoreSize"`
 	}
 
 	// Agent is the agent to help the blockchain node connect into the P2P networks and send/receive messages
@@ -113,6 +127,8 @@ type (
 		ConnectedPeers() ([]peer.AddrInfo, error)
 		// BlockPeer blocks the peer in p2p layer
 		BlockPeer(string)
+		// KnownPeers returns the peers in the peer store in the order of score
+		KnownPeers() []*PeerRecord
 	}
 
 	dummyAgent struct{}
@@ -128,6 +144,7 @@ type (
 		reconnectTimeout           time.Duration
 		reconnectTask              *routine.RecurringTask
 		qosMetrics                 *Qos
+		peerStore                  *peerStore
 	}
 )
 
@@ -145,6 +162,10 @@ var DefaultConfig = Config{
 	PrivateNetworkPSK: "",
 	MaxPeers:          30,
 	MaxMessageSize:    p2p.DefaultConfig.MaxMessageSize,
+	DNSSeeds:          []string{},
+	DNSSeedPublicKey:  "",
+	PeerStorePath:     "",
+	PeerStoreSize:     100,
 }
 
 // NewDummyAgent creates a dummy p2p agent
@@ -184,13 +205,17 @@ func (*dummyAgent) BlockPeer(string) {
 	return
 }
 
+func (*dummyAgent) KnownPeers() []*PeerRecord {
+	return nil
+}
+
 func (*dummyAgent) BuildReport() string {
 	return ""
 }
 
 // NewAgent instantiates a local P2P agent instance
 func NewAgent(cfg Config, chainID uint32, genesisHash hash.Hash256, broadcastHandler HandleBroadcastInbound, unicastHandler HandleUnicastInboundAsync) Agent {
-	log.L().Info("p2p agent", log.Hex("topicSuffix", genesisHash[22:]))
+	log.Logger("p2p").Info("p2p agent", log.Hex("topicSuffix", genesisHash[22:]))
 	return &agent{
 		cfg:     cfg,
 		chainID: chainID,
@@ -200,12 +225,20 @@ func NewAgent(cfg Config, chainID uint32, genesisHash hash.Hash256, broadcastHan
 		unicastInboundAsyncHandler: unicastHandler,
 		reconnectTimeout:           cfg.ReconnectInterval,
 		qosMetrics:                 NewQoS(time.Now(), 2*cfg.ReconnectInterval),
+		peerStore:                  newPeerStore(cfg.PeerStorePath, cfg.PeerStoreSize),
 	}
 }
 
 func (p *agent) Start(ctx context.Context) error {
 	ready := make(chan interface{})
-	p2p.SetLogger(log.L())
+	p2p.SetLogger(log.Logger("p2p"))
+	if err := p.peerStore.load(); err != nil {
+		log.Logger("p2p").Warn("Failed to load peer store.", zap.Error(err))
+	}
+	seeds, err := resolveDNSSeeds(ctx, p.cfg.DNSSeeds, p.cfg.DNSSeedPublicKey, net.DefaultResolver.LookupTXT)
+	if err != nil {
+		return err
+	}
 	opts := []p2p.Option{
 		p2p.HostName(p.cfg.Host),
 		p2p.Port(p.cfg.Port),
@@ -337,6 +370,11 @@ func (p *agent) Start(ctx context.Context) error {
 			p.bootNodeAddr = append(p.bootNodeAddr, bootAddr)
 		}
 	}
+	for _, seed := range seeds {
+		if !strings.Contains(seed.String(), hostName) {
+			p.bootNodeAddr = append(p.bootNodeAddr, seed)
+		}
+	}
 	if err := host.AddBootstrap(p.bootNodeAddr); err != nil {
 		return err
 	}
@@ -345,7 +383,7 @@ func (p *agent) Start(ctx context.Context) error {
 
 	// connect to bootstrap nodes
 	if err := p.connectBootNode(ctx); err != nil {
-		log.L().Error("fail to connect bootnode", zap.Error(err))
+		log.Logger("p2p").Error("fail to connect bootnode", zap.Error(err))
 		return err
 	}
 	if err := p.host.AdvertiseAsync(); err != nil {
@@ -366,10 +404,11 @@ func (p *agent) Stop(ctx context.Context) error {
 	if p.host == nil {
 		return ErrAgentNotStarted
 	}
-	log.L().Info("p2p is shutting down.", zap.Error(ctx.Err()))
+	log.Logger("p2p").Info("p2p is shutting down.", zap.Error(ctx.Err()))
 	if err := p.reconnectTask.Stop(ctx); err != nil {
 		return err
 	}
+	p.savePeers()
 	if err := p.host.Close(); err != nil {
 		return errors.Wrap(err, "error when closing Agent host")
 	}
@@ -499,6 +538,10 @@ func (p *agent) BlockPeer(pidStr string) {
 	p.host.BlockPeer(pid)
 }
 
+func (p *agent) KnownPeers() []*PeerRecord {
+	return p.peerStore.records()
+}
+
 // BuildReport builds a report of p2p agent
 func (p *agent) BuildReport() string {
 	neighbors, err := p.ConnectedPeers()
@@ -508,13 +551,60 @@ func (p *agent) BuildReport() string {
 	return ""
 }
 
+// connectBootNode dials the peers in the peer store ahead of the bootstrap nodes. Once any of the peers is connected,
+// the bootstrap nodes are dialed in the background, so the node joins the network even if they are down
 func (p *agent) connectBootNode(ctx context.Context) error {
-	if len(p.cfg.BootstrapNodes) == 0 {
+	if p.connectStoredPeers(ctx) > 0 {
+		go func() {
+			if err := p.dialBootNodes(ctx); err != nil {
+				log.Logger("p2p").Warn("fail to connect bootnode", zap.Error(err))
+			}
+		}()
+		return nil
+	}
+	return p.dialBootNodes(ctx)
+}
+
+// connectStoredPeers dials the peers in the peer store at the same time, and returns the number of peers connected
+func (p *agent) connectStoredPeers(ctx context.Context) int {
+	var (
+		infos     = p.peerStore.addrInfos()
+		self      = p.host.HostIdentity()
+		connected int32
+		wg        sync.WaitGroup
+	)
+	if len(infos) > _maxStoredPeerDials {
+		infos = infos[:_maxStoredPeerDials]
+	}
+	for _, info := range infos {
+		if info.ID.String() == self {
+			continue
+		}
+		wg.Add(1)
+		go func(info peer.AddrInfo) {
+			defer wg.Done()
+			dialCtx, cancel := context.WithTimeout(ctx, _storedPeerDialTimeout)
+			defer cancel()
+			if err := p.host.Connect(dialCtx, info); err != nil {
+				log.Logger("p2p").Info("Failed to connect stored peer.", zap.String("peer", info.ID.String()), zap.Error(err))
+				p.peerStore.fail(info.ID)
+				return
+			}
+			p.peerStore.succeed(info, time.Now())
+			atomic.AddInt32(&connected, 1)
+		}(info)
+	}
+	wg.Wait()
+	return int(connected)
+}
+
+func (p *agent) dialBootNodes(ctx context.Context) error {
+	if len(p.bootNodeAddr) == 0 {
 		return nil
 	}
 	var errNum, connNum, desiredConnNum int
-	conn := make(chan struct{}, len(p.cfg.BootstrapNodes))
-	connErrChan := make(chan error, len(p.cfg.BootstrapNodes))
+	conn := make(chan struct{}, len(p.bootNodeAddr))
+	connErrChan := make(chan error, len(p.bootNodeAddr))
 
 	// try to connect to all bootstrap node beside itself.
 	for i := range p.bootNodeAddr {
@@ -529,8 +619,11 @@ func (p *agent) connectBootNode(ctx context.Context) error {
 				connErrChan <- err
 				return
 			}
+			if info, err := peer.AddrInfoFromP2pAddr(bootAddr); err == nil {
+				p.peerStore.succeed(*info, time.Now())
+			}
 			conn <- struct{}{}
-			log.L().Info("Connected bootstrap node.", zap.String("address", bootAddr.String()))
+			log.Logger("p2p").Info("Connected bootstrap node.", zap.String("address", bootAddr.String()))
 		}()
 	}
 
@@ -539,7 +632,7 @@ func (p *agent) connectBootNode(ctx context.Context) error {
 	for {
 		select {
 		case err := <-connErrChan:
-			log.L().Info("Connection failed.", zap.Error(err))
+			log.Logger("p2p").Info("Connection failed.", zap.Error(err))
 			errNum++
 			if errNum == len(p.bootNodeAddr) {
 				return errors.New("failed to connect to any bootstrap node")
@@ -560,19 +653,31 @@ func (p *agent) reconnect() {
 		return
 	}
 	if len(p.host.ConnectedPeers()) == 0 || p.qosMetrics.lostConnection() {
-		log.L().Info("network lost, try re-connecting.")
+		log.Logger("p2p").Info("network lost, try re-connecting.")
 		p.host.ClearBlocklist()
 		if err := p.connectBootNode(context.Background()); err != nil {
-			log.L().Error("fail to connect bootnode", zap.Error(err))
+			log.Logger("p2p").Error("fail to connect bootnode", zap.Error(err))
 			return
 		}
 		if err := p.host.AdvertiseAsync(); err != nil {
-			log.L().Error("fail to advertise", zap.Error(err))
+			log.Logger("p2p").Error("fail to advertise", zap.Error(err))
 			return
 		}
 	}
 	if err := p.host.FindPeersAsync(); err != nil {
-		log.L().Error("fail to find peer", zap.Error(err))
+		log.Logger("p2p").Error("fail to find peer", zap.Error(err))
+	}
+	p.savePeers()
+}
+
+// savePeers records the peers connected into the peer store, and saves the peer store
+func (p *agent) savePeers() {
+	now := time.Now()
+	for _, info := range p.host.ConnectedPeers() {
+		p.peerStore.succeed(info, now)
+	}
+	if err := p.peerStore.save(); err != nil {
+		log.Logger("p2p").Error("fail to save peer store", zap.Error(err))
 	}
 }
 
@@ -593,7 +698,7 @@ func exponentialRetry(f func() error, retryInterval time.Duration, numRetries in
 		if err = f(); err == nil {
 			return
 		}
-		log.L().Error("Error happens, will retry.", zap.Error(err))
+		log.Logger("p2p").Error("Error happens, will retry.", zap.Error(err))
 		time.Sleep(retryInterval)
 		retryInterval *= 2
 	}
@@ -7,6 +7,7 @@ package p2p
 
 import (
 	"context"
+	"path/filepath"
 	"strconv"
 	"sync"
 	"testing"
@@ -165,3 +166,56 @@ func TestUnicast(t *testing.T) {
 		}))
 	}
 }
+
+func TestConnectWithPeerStore(t *testing.T) {
+	r := require.New(t)
+
+	ctx := context.Background()
+	b := func(_ context.Context, _ uint32, _ string, _ proto.Message) {}
+	u := func(_ context.Context, _ uint32, _ peer.AddrInfo, _ proto.Message) {}
+	seed, err := p2p.NewHost(ctx, p2p.DHTProtocolID(3), p2p.Port(testutil.RandomPort()), p2p.SecureIO(), p2p.MasterKey("seed"))
+	r.NoError(err)
+	defer seed.Close()
+	down, err := p2p.NewHost(ctx, p2p.DHTProtocolID(3), p2p.Port(testutil.RandomPort()), p2p.SecureIO(), p2p.MasterKey("down"))
+	r.NoError(err)
+	downAddr := down.Addresses()[0].String()
+	r.NoError(down.Close())
+
+	cfg := Config{
+		Host:              "127.0.0.1",
+		Port:              testutil.RandomPort(),
+		BootstrapNodes:    []string{seed.Addresses()[0].String()},
+		ReconnectInterval: 150 * time.Second,
+		MasterKey:         "node",
+		PeerStorePath:     filepath.Join(t.TempDir(), "peers.json"),
+		PeerStoreSize:     10,
+	}
+	agent := NewAgent(cfg, 3, hash.ZeroHash256, b, u)
+	r.NoError(agent.Start(ctx))
+	peers := agent.KnownPeers()
+	r.Len(peers, 1)
+	r.Equal(seed.HostIdentity(), peers[0].ID)
+	r.NoError(agent.Stop(ctx))
+
+	// the node restarted with the bootstrap node down connects to the peer in the peer store
+	cfg.Port = testutil.RandomPort()
+	cfg.BootstrapNodes = []string{downAddr}
+	agent = NewAgent(cfg, 3, hash.ZeroHash256, b, u)
+	r.NoError(agent.Start(ctx))
+	defer func() {
+		r.NoError(agent.Stop(ctx))
+	}()
+	r.NoError(testutil.WaitUntil(100*time.Millisecond, 10*time.Second, func() (bool, error) {
+		neighbors, err := agent.ConnectedPeers()
+		if err != nil {
+			return false, err
+		}
+		for _, n := range neighbors {
+			if n.ID.String() == seed.HostIdentity() {
+				return true, nil
+			}
+		}
+		return false, nil
+	}))
+	r.Equal(seed.HostIdentity(), agent.KnownPeers()[0].ID)
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+const _dnsSeedTimeout = 10 * time.Second
+
+// lookupTXT returns the TXT records of the domain name
+type lookupTXT func(ctx context.Context, name string) ([]string, error)
+
+// resolveDNSSeeds returns the multiaddrs in the TXT records of the domain names. Each record is a multiaddr followed
+// by a space and the hex signature of the hash of the multiaddr, signed by the key of the public key. The records
+// failing the verification are skipped, and so are the domain names failing the lookup
+func resolveDNSSeeds(ctx context.Context, names []string, publicKey string, lookup lookupTXT) ([]multiaddr.Multiaddr, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	pk, err := crypto.HexStringToPublicKey(publicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid public key of dns seeds")
+	}
+	ctx, cancel := context.WithTimeout(ctx, _dnsSeedTimeout)
+	defer cancel()
+	var ret []multiaddr.Multiaddr
+	for _, name := range names {
+		records, err := lookup(ctx, name)
+		if err != nil {
+			log.Logger("p2p").Warn("Failed to look up dns seed.", zap.String("name", name), zap.Error(err))
+			continue
+		}
+		for _, record := range records {
+			addr, err := verifySeedRecord(record, pk)
+			if err != nil {
+				log.Logger("p2p").Warn("Invalid dns seed record.", zap.String("name", name), zap.String("record", record), zap.Error(err))
+				continue
+			}
+			ret = append(ret, addr)
+		}
+	}
+	return ret, nil
+}
+
+func verifySeedRecord(record string, pk crypto.PublicKey) (multiaddr.Multiaddr, error) {
+	fields := strings.Fields(record)
+	if len(fields) != 2 {
+		return nil, errors.New("record is not a multiaddr followed by the signature")
+	}
+	sig, err := hex.DecodeString(fields[1])
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid signature")
+	}
+	h := hash.Hash256b([]byte(fields[0]))
+	if !pk.Verify(h[:], sig) {
+		return nil, errors.New("signature does not match the public key")
+	}
+	return multiaddr.NewMultiaddr(fields[0])
+}
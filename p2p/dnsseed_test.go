@@ -0,0 +1,55 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveDNSSeeds(t *testing.T) {
+	r := require.New(t)
+	var (
+		sk, _    = crypto.GenerateKey()
+		other, _ = crypto.GenerateKey()
+		addr1    = "/ip4/10.0.0.1/tcp/4689/p2p/12D3KooWJwW6pUpTkxPTMv84RPdHhqBmNGbkYCgZ8zSxqJyYj4S7"
+		addr2    = "/dns4/seed.example.com/tcp/4689/p2p/12D3KooWJwW6pUpTkxPTMv84RPdHhqBmNGbkYCgZ8zSxqJyYj4S7"
+		sign     = func(sk crypto.PrivateKey, addr string) string {
+			h := hash.Hash256b([]byte(addr))
+			sig, err := sk.Sign(h[:])
+			r.NoError(err)
+			return addr + " " + hex.EncodeToString(sig)
+		}
+		records = map[string][]string{
+			"a.seed.example.com": {sign(sk, addr1), sign(other, addr2), addr2, "/ip4/10.0.0.2 zz"},
+			"b.seed.example.com": {sign(sk, addr2)},
+		}
+		lookup = func(_ context.Context, name string) ([]string, error) {
+			txt, ok := records[name]
+			if !ok {
+				return nil, errors.New("no such host")
+			}
+			return txt, nil
+		}
+		pk = sk.PublicKey().HexString()
+	)
+	seeds, err := resolveDNSSeeds(context.Background(), []string{"a.seed.example.com", "c.seed.example.com", "b.seed.example.com"}, pk, lookup)
+	r.NoError(err)
+	r.Equal([]multiaddr.Multiaddr{multiaddr.StringCast(addr1), multiaddr.StringCast(addr2)}, seeds)
+
+	seeds, err = resolveDNSSeeds(context.Background(), nil, "", lookup)
+	r.NoError(err)
+	r.Empty(seeds)
+	_, err = resolveDNSSeeds(context.Background(), []string{"a.seed.example.com"}, "", lookup)
+	r.ErrorContains(err, "invalid public key of dns seeds")
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+)
+
+const (
+	// _maxPeerScore caps the score of a peer, so a peer failing after a long time of success is evicted soon
+	_maxPeerScore = 100
+	// _peerFailurePenalty is the score deducted on each failure to dial the peer
+	_peerFailurePenalty = 10
+)
+
+type (
+	// PeerRecord is a peer connected successfully before, along with the score of the connections to it
+	PeerRecord struct {
+		ID       string    `json:"id"`
+		Addrs    []string  `json:"addrs"`
+		Score    int       `json:"score"`
+		LastSeen time.Time `json:"lastSeen"`
+	}
+
+	// peerStore keeps the peers connected successfully, which are dialed ahead of the bootstrap nodes. It is saved
+	// into a file to survive restarts, and the peers with the lowest score are evicted beyond the size
+	peerStore struct {
+		mutex sync.RWMutex
+		path  string
+		size  int
+		peers map[string]*PeerRecord
+	}
+)
+
+func newPeerStore(path string, size int) *peerStore {
+	return &peerStore{
+		path:  path,
+		size:  size,
+		peers: make(map[string]*PeerRecord),
+	}
+}
+
+// load reads the peers saved in the file, if any
+func (ps *peerStore) load() error {
+	if ps.path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(ps.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var records []*PeerRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return errors.Wrap(err, "failed to read peer store")
+	}
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	for _, r := range records {
+		if r.Score > 0 {
+			ps.peers[r.ID] = r
+		}
+	}
+	ps.evict()
+	return nil
+}
+
+// save writes the peers into the file
+func (ps *peerStore) save() error {
+	if ps.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(ps.records())
+	if err != nil {
+		return err
+	}
+	tmp := ps.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return errors.Wrap(err, "failed to write peer store")
+	}
+	return os.Rename(tmp, ps.path)
+}
+
+// succeed raises the score of the peer connected
+func (ps *peerStore) succeed(info peer.AddrInfo, now time.Time) {
+	if len(info.Addrs) == 0 {
+		return
+	}
+	addrs := make([]string, len(info.Addrs))
+	for i, addr := range info.Addrs {
+		addrs[i] = addr.String()
+	}
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	r, ok := ps.peers[info.ID.String()]
+	if !ok {
+		r = &PeerRecord{ID: info.ID.String()}
+		ps.peers[r.ID] = r
+	}
+	r.Addrs = addrs
+	r.LastSeen = now
+	if r.Score < _maxPeerScore {
+		r.Score++
+	}
+	ps.evict()
+}
+
+// fail lowers the score of the peer failed to dial, and removes it once the score drops to zero
+func (ps *peerStore) fail(id peer.ID) {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	r, ok := ps.peers[id.String()]
+	if !ok {
+		return
+	}
+	if r.Score -= _peerFailurePenalty; r.Score <= 0 {
+		delete(ps.peers, r.ID)
+	}
+}
+
+// records returns the peers in the order of score, and the peers seen more recently first for the same score
+func (ps *peerStore) records() []*PeerRecord {
+	ps.mutex.RLock()
+	defer ps.mutex.RUnlock()
+	ret := make([]*PeerRecord, 0, len(ps.peers))
+	for _, r := range ps.peers {
+		c := *r
+		ret = append(ret, &c)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Score != ret[j].Score {
+			return ret[i].Score > ret[j].Score
+		}
+		return ret[i].LastSeen.After(ret[j].LastSeen)
+	})
+	return ret
+}
+
+// addrInfos returns the peers to dial in the order of records
+func (ps *peerStore) addrInfos() []peer.AddrInfo {
+	var ret []peer.AddrInfo
+	for _, r := range ps.records() {
+		id, err := peer.Decode(r.ID)
+		if err != nil {
+			continue
+		}
+		info := peer.AddrInfo{ID: id}
+		for _, s := range r.Addrs {
+			if addr, err := multiaddr.NewMultiaddr(s); err == nil {
+				info.Addrs = append(info.Addrs, addr)
+			}
+		}
+		if len(info.Addrs) > 0 {
+			ret = append(ret, info)
+		}
+	}
+	return ret
+}
+
+// evict removes the peers with the lowest score, and the ones seen least recently for the same score, beyond the size
+func (ps *peerStore) evict() {
+	for ps.size > 0 && len(ps.peers) > ps.size {
+		var worst *PeerRecord
+		for _, r := range ps.peers {
+			if worst == nil || r.Score < worst.Score || (r.Score == worst.Score && r.LastSeen.Before(worst.LastSeen)) {
+				worst = r
+			}
+		}
+		delete(ps.peers, worst.ID)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerStore(t *testing.T) {
+	r := require.New(t)
+	newPeer := func(port string) peer.AddrInfo {
+		_, pk, err := crypto.GenerateEd25519Key(nil)
+		r.NoError(err)
+		id, err := peer.IDFromPublicKey(pk)
+		r.NoError(err)
+		return peer.AddrInfo{ID: id, Addrs: []multiaddr.Multiaddr{multiaddr.StringCast("/ip4/127.0.0.1/tcp/" + port)}}
+	}
+	var (
+		p1, p2, p3 = newPeer("4689"), newPeer("4690"), newPeer("4691")
+		now        = time.Now()
+		path       = filepath.Join(t.TempDir(), "peers.json")
+		ps         = newPeerStore(path, 2)
+	)
+	ps.succeed(p1, now)
+	ps.succeed(p1, now)
+	ps.succeed(p2, now)
+	ps.succeed(peer.AddrInfo{ID: p3.ID}, now)
+	r.Equal([]peer.AddrInfo{p1, p2}, ps.addrInfos())
+
+	// the peer with the lowest score is evicted beyond the size, and the older one for the same score
+	ps.succeed(p3, now.Add(time.Second))
+	records := ps.records()
+	r.Len(records, 2)
+	r.Equal(p1.ID.String(), records[0].ID)
+	r.Equal(2, records[0].Score)
+	r.Equal(p3.ID.String(), records[1].ID)
+	r.Equal([]string{"/ip4/127.0.0.1/tcp/4691"}, records[1].Addrs)
+
+	// the peers are reloaded from the file
+	r.NoError(ps.save())
+	reloaded := newPeerStore(path, 2)
+	r.NoError(reloaded.load())
+	r.Equal(ps.addrInfos(), reloaded.addrInfos())
+
+	// the peer is removed once the score drops to zero
+	ps.fail(p3.ID)
+	r.Equal([]peer.AddrInfo{p1}, ps.addrInfos())
+	for i := 0; i < _maxPeerScore+1; i++ {
+		ps.succeed(p1, now)
+	}
+	r.Equal(_maxPeerScore, ps.records()[0].Score)
+
+	// a corrupted file fails to load, and a missing file or path is empty
+	r.NoError(os.WriteFile(path, []byte("x"), 0600))
+	r.Error(newPeerStore(path, 2).load())
+	r.NoError(newPeerStore(filepath.Join(t.TempDir(), "missing.json"), 2).load())
+	ps = newPeerStore("", 2)
+	r.NoError(ps.load())
+	ps.succeed(p1, now)
+	r.NoError(ps.save())
+}
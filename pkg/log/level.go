@@ -0,0 +1,215 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package log
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type (
+	leveler interface {
+		Level() zapcore.Level
+	}
+
+	levelerBox struct {
+		leveler
+	}
+
+	// dynamicLevel is the level of a logger, which follows its base level unless it is overridden at runtime
+	dynamicLevel struct {
+		base     atomic.Value // levelerBox
+		override atomic.Pointer[zapcore.Level]
+
+		mu       sync.Mutex
+		timer    *time.Timer
+		revertAt time.Time
+	}
+
+	// levelCore filters the entries of the wrapped core by a dynamic level, so that the loggers sharing the same
+	// permissive core are tuned independently
+	levelCore struct {
+		zapcore.Core
+		level *dynamicLevel
+	}
+
+	// LevelStatus is the current level of a logger
+	LevelStatus struct {
+		Name       string     `json:"name"`
+		Level      string     `json:"level"`
+		Overridden bool       `json:"overridden"`
+		RevertAt   *time.Time `json:"revertAt,omitempty"`
+	}
+)
+
+// _subsystems are the loggers whose levels are tuned at runtime even if they are not configured as sub loggers
+var _subsystems = []string{"actpool", "api", "consensus", "p2p", "statefactory"}
+
+func newDynamicLevel(base leveler) *dynamicLevel {
+	l := &dynamicLevel{}
+	l.setBase(base)
+	return l
+}
+
+func (l *dynamicLevel) setBase(base leveler) {
+	l.base.Store(levelerBox{base})
+}
+
+// Level returns the effective level
+func (l *dynamicLevel) Level() zapcore.Level {
+	if o := l.override.Load(); o != nil {
+		return *o
+	}
+	return l.base.Load().(levelerBox).Level()
+}
+
+// Enabled implements zapcore.LevelEnabler
+func (l *dynamicLevel) Enabled(lvl zapcore.Level) bool {
+	return l.Level().Enabled(lvl)
+}
+
+func (l *dynamicLevel) set(lvl zapcore.Level, d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	prev := l.override.Load()
+	l.stopTimer()
+	l.override.Store(&lvl)
+	if d <= 0 {
+		return
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(d, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		// the level is set again after this elevation
+		if l.timer != timer {
+			return
+		}
+		l.override.Store(prev)
+		l.timer = nil
+		l.revertAt = time.Time{}
+	})
+	l.timer = timer
+	l.revertAt = time.Now().Add(d)
+}
+
+func (l *dynamicLevel) reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stopTimer()
+	l.override.Store(nil)
+}
+
+func (l *dynamicLevel) stopTimer() {
+	if l.timer != nil {
+		l.timer.Stop()
+		l.timer = nil
+		l.revertAt = time.Time{}
+	}
+}
+
+func (l *dynamicLevel) status(name string) LevelStatus {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	s := LevelStatus{
+		Name:       name,
+		Level:      l.Level().String(),
+		Overridden: l.override.Load() != nil,
+	}
+	if l.timer != nil {
+		revertAt := l.revertAt
+		s.RevertAt = &revertAt
+	}
+	return s
+}
+
+func newLevelCore(core zapcore.Core, level *dynamicLevel) zapcore.Core {
+	return &levelCore{Core: core, level: level}
+}
+
+func (c *levelCore) Enabled(lvl zapcore.Level) bool {
+	return c.level.Enabled(lvl)
+}
+
+// Level returns the effective level, used by zapcore.LevelOf
+func (c *levelCore) Level() zapcore.Level {
+	return c.level.Level()
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return newLevelCore(c.Core.With(fields), c.level)
+}
+
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.level.Enabled(ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
+
+// withLevel returns a zap option which filters the logger's permissive core by the given level
+func withLevel(level *dynamicLevel) zap.Option {
+	return zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		if lc, ok := core.(*levelCore); ok {
+			core = lc.Core
+		}
+		return newLevelCore(core, level)
+	})
+}
+
+// SetLevel sets the level of the global logger, or of a sub logger or subsystem by name. If d is positive, the
+// level reverts to the previous one after d
+func SetLevel(name string, lvl zapcore.Level, d time.Duration) error {
+	level, err := levelOf(name)
+	if err != nil {
+		return err
+	}
+	level.set(lvl, d)
+	return nil
+}
+
+// ResetLevel drops the level set at runtime, the logger follows its configured level again
+func ResetLevel(name string) error {
+	level, err := levelOf(name)
+	if err != nil {
+		return err
+	}
+	level.reset()
+	return nil
+}
+
+// Levels returns the current levels of the global logger, sub loggers and subsystems
+func Levels() []LevelStatus {
+	_logMu.RLock()
+	defer _logMu.RUnlock()
+	ret := make([]LevelStatus, 0, len(_levels))
+	for name, level := range _levels {
+		ret = append(ret, level.status(name))
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Name < ret[j].Name
+	})
+	return ret
+}
+
+func levelOf(name string) (*dynamicLevel, error) {
+	if name == "" {
+		name = _globalLoggerName
+	}
+	_logMu.RLock()
+	defer _logMu.RUnlock()
+	level, ok := _levels[name]
+	if !ok {
+		return nil, errors.Errorf("unknown logger: %s", name)
+	}
+	return level, nil
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package log
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func observeLogs(t *testing.T) *observer.ObservedLogs {
+	core, logs := observer.New(zap.DebugLevel)
+	_logMu.Lock()
+	restore := zap.ReplaceGlobals(zap.New(newLevelCore(core, _levels[_globalLoggerName])))
+	_namedLoggers = make(map[string]*zap.Logger)
+	_logMu.Unlock()
+	t.Cleanup(func() {
+		_logMu.Lock()
+		restore()
+		_namedLoggers = make(map[string]*zap.Logger)
+		_logMu.Unlock()
+		for name := range _levels {
+			require.NoError(t, ResetLevel(name))
+		}
+	})
+	return logs
+}
+
+func TestSetLevel(t *testing.T) {
+	r := require.New(t)
+	logs := observeLogs(t)
+
+	Logger("consensus").Debug("hidden")
+	r.Zero(logs.Len())
+
+	r.NoError(SetLevel("consensus", zap.DebugLevel, 0))
+	Logger("consensus").Debug("shown")
+	Logger("actpool").Debug("hidden")
+	L().Debug("hidden")
+	entries := logs.TakeAll()
+	r.Len(entries, 1)
+	r.Equal("shown", entries[0].Message)
+	r.Equal("consensus", entries[0].LoggerName)
+
+	// the subsystems follow the global level unless they are set
+	r.NoError(SetLevel("", zap.ErrorLevel, 0))
+	Logger("actpool").Warn("hidden")
+	Logger("consensus").Debug("shown")
+	r.Equal(1, logs.Len())
+	r.NoError(ResetLevel("consensus"))
+	Logger("consensus").Warn("hidden")
+	r.Equal(1, logs.Len())
+	r.NoError(ResetLevel(_globalLoggerName))
+	Logger("actpool").Info("shown")
+	r.Equal(2, logs.Len())
+
+	r.ErrorContains(SetLevel("unknown", zap.DebugLevel, 0), "unknown logger")
+}
+
+func TestSetLevelRevert(t *testing.T) {
+	r := require.New(t)
+	logs := observeLogs(t)
+
+	r.NoError(SetLevel("p2p", zap.WarnLevel, 0))
+	r.NoError(SetLevel("p2p", zap.DebugLevel, 50*time.Millisecond))
+	var status LevelStatus
+	for _, s := range Levels() {
+		if s.Name == "p2p" {
+			status = s
+		}
+	}
+	r.Equal("debug", status.Level)
+	r.True(status.Overridden)
+	r.NotNil(status.RevertAt)
+	Logger("p2p").Debug("shown")
+	r.Equal(1, logs.Len())
+
+	// reverts to the level before the elevation
+	r.Eventually(func() bool {
+		return Logger("p2p").Level() == zapcore.WarnLevel
+	}, time.Second, 10*time.Millisecond)
+	Logger("p2p").Info("hidden")
+	r.Equal(1, logs.Len())
+	for _, s := range Levels() {
+		if s.Name == "p2p" {
+			r.Equal("warn", s.Level)
+			r.Nil(s.RevertAt)
+		}
+	}
+
+	// a later elevation is not reverted by an earlier timer
+	r.NoError(SetLevel("api", zap.DebugLevel, 20*time.Millisecond))
+	r.NoError(SetLevel("api", zap.DebugLevel, time.Hour))
+	time.Sleep(50 * time.Millisecond)
+	r.Equal(zapcore.DebugLevel, Logger("api").Level())
+}
@@ -32,6 +32,8 @@ var (
 	_logMu            sync.RWMutex
 	_logServeMux      = http.NewServeMux()
 	_subLoggers       map[string]*zap.Logger
+	_namedLoggers     map[string]*zap.Logger
+	_levels           map[string]*dynamicLevel
 	_globalLoggerName = "global"
 )
 
@@ -39,7 +41,11 @@ func init() {
 	zapCfg := zap.NewDevelopmentConfig()
 	zapCfg.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
 	zapCfg.Level.SetLevel(zap.InfoLevel)
-	l, err := zapCfg.Build()
+	// the core is built permissive, the entries are filtered by the dynamic level of each logger
+	buildCfg := zapCfg
+	buildCfg.Level = zap.NewAtomicLevelAt(zap.DebugLevel)
+	globalLevel := newDynamicLevel(zapCfg.Level)
+	l, err := buildCfg.Build(withLevel(globalLevel))
 	if err != nil {
 		log.Println("Failed to init zap global logger, no zap log will be shown till zap is properly initialized: ", err)
 		return
@@ -47,6 +53,11 @@ func init() {
 	_logMu.Lock()
 	_globalCfg.Zap = &zapCfg
 	_subLoggers = make(map[string]*zap.Logger)
+	_namedLoggers = make(map[string]*zap.Logger)
+	_levels = map[string]*dynamicLevel{_globalLoggerName: globalLevel}
+	for _, name := range _subsystems {
+		_levels[name] = newDynamicLevel(globalLevel)
+	}
 	_logMu.Unlock()
 	zap.ReplaceGlobals(l)
 }
@@ -57,12 +68,34 @@ func L() *zap.Logger { return zap.L() }
 // S wraps zap.S().
 func S() *zap.SugaredLogger { return zap.S() }
 
-// Logger returns logger of the given name
+// Logger returns logger of the given name. If no sub logger of the name is configured, the logger is derived from
+// the global logger, with its own level which follows the global level unless it is set by SetLevel
 func Logger(name string) *zap.Logger {
+	_logMu.RLock()
 	logger, ok := _subLoggers[name]
 	if !ok {
-		return L()
+		logger, ok = _namedLoggers[name]
+	}
+	_logMu.RUnlock()
+	if ok {
+		return logger
+	}
+
+	_logMu.Lock()
+	defer _logMu.Unlock()
+	if logger, ok := _subLoggers[name]; ok {
+		return logger
+	}
+	if logger, ok := _namedLoggers[name]; ok {
+		return logger
 	}
+	level, ok := _levels[name]
+	if !ok {
+		level = newDynamicLevel(_levels[_globalLoggerName])
+		_levels[name] = level
+	}
+	logger = L().WithOptions(withLevel(level)).Named(name)
+	_namedLoggers[name] = logger
 	return logger
 }
 
@@ -96,7 +129,7 @@ func InitLoggers(globalCfg GlobalConfig, subCfgs map[string]GlobalConfig, opts .
 			cores = append(cores, zapcore.NewCore(
 				zapcore.NewJSONEncoder(cfg.Zap.EncoderConfig),
 				zapcore.AddSync(stderrF),
-				cfg.Zap.Level))
+				zap.DebugLevel))
 		}
 		switch cfg.Zap.Encoding {
 		case "console":
@@ -105,28 +138,35 @@ func InitLoggers(globalCfg GlobalConfig, subCfgs map[string]GlobalConfig, opts .
 			cores = append(cores, zapcore.NewCore(
 				zapcore.NewConsoleEncoder(consoleCfg.EncoderConfig),
 				zapcore.AddSync(os.Stdout),
-				cfg.Zap.Level))
+				zap.DebugLevel))
 		case "json":
 			cfg.Zap.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 			cores = append(cores, zapcore.NewCore(
 				zapcore.NewJSONEncoder(cfg.Zap.EncoderConfig),
 				zapcore.AddSync(os.Stdout),
-				cfg.Zap.Level))
+				zap.DebugLevel))
 		default:
 			return errors.Errorf("unknown encoding: %s", cfg.Zap.Encoding)
 		}
 
-		core := zapcore.NewTee(cores...)
-		logger := zap.New(core, opts...)
-
 		_logMu.Lock()
+		level, ok := _levels[name]
+		if !ok {
+			level = newDynamicLevel(cfg.Zap.Level)
+			_levels[name] = level
+		} else {
+			level.setBase(cfg.Zap.Level)
+		}
+		logger := zap.New(newLevelCore(zapcore.NewTee(cores...), level), opts...)
 		if name == _globalLoggerName {
 			_globalCfg = cfg
 			if cfg.RedirectStdLog {
 				zap.RedirectStdLog(logger)
 			}
 			zap.ReplaceGlobals(logger)
+			_namedLoggers = make(map[string]*zap.Logger)
 			if err := initTraceLogger(logger, cfg.Trace); err != nil {
+				_logMu.Unlock()
 				return err
 			}
 		} else {
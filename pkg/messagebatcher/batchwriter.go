@@ -83,7 +83,10 @@ func (bm *Manager) Start() error {
 
 // Stop stops the Manager
 func (bm *Manager) Stop() error {
-	bm.cancelHanlders()
+	// the manager may be stopped without being started, e.g. the api server of a chain stopped before the server
+	if bm.cancelHanlders != nil {
+		bm.cancelHanlders()
+	}
 	return nil
 }
 
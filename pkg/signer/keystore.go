@@ -0,0 +1,113 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/pkg/errors"
+
+	cp "github.com/iotexproject/iotex-core/crypto"
+)
+
+// KeystoreSigner signs with the key in an encrypted keystore file, which is locked until unlocked by the passphrase.
+// The decrypted key is only kept in memory
+type KeystoreSigner struct {
+	path string
+	pk   crypto.PublicKey
+	mu   sync.RWMutex
+	sk   crypto.PrivateKey
+}
+
+// NewKeystoreSigner creates a locked signer of the keystore file, whose key is of the public key
+func NewKeystoreSigner(path string, pk crypto.PublicKey) (*KeystoreSigner, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, errors.Wrapf(err, "failed to open the keystore file %s", path)
+	}
+	return &KeystoreSigner{
+		path: filepath.Clean(path),
+		pk:   pk,
+	}, nil
+}
+
+// Unlock decrypts the key in the keystore by the passphrase
+func (s *KeystoreSigner) Unlock(passphrase string) error {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read the keystore file %s", s.path)
+	}
+	key, err := keystore.DecryptKey(data, passphrase)
+	if err != nil {
+		return errors.Wrap(err, "failed to decrypt the keystore")
+	}
+	d := ethcrypto.FromECDSA(key.PrivateKey)
+	sk, err := crypto.BytesToPrivateKey(d)
+	for i := range d {
+		d[i] = 0
+	}
+	if err != nil {
+		return errors.Wrap(err, "invalid key in the keystore")
+	}
+	if !bytes.Equal(sk.PublicKey().Bytes(), s.pk.Bytes()) {
+		sk.Zero()
+		return errors.Errorf("the key in the keystore is not of the public key %s", s.pk.HexString())
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sk != nil {
+		s.sk.Zero()
+	}
+	s.sk = sk
+	return nil
+}
+
+// Lock drops the decrypted key
+func (s *KeystoreSigner) Lock() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sk != nil {
+		s.sk.Zero()
+		s.sk = nil
+	}
+}
+
+// Locked returns whether the keystore is locked
+func (s *KeystoreSigner) Locked() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.sk == nil
+}
+
+// PublicKey returns the public key
+func (s *KeystoreSigner) PublicKey() crypto.PublicKey {
+	return s.pk
+}
+
+// Sign signs the hash if the keystore is unlocked
+func (s *KeystoreSigner) Sign(h []byte) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.sk == nil {
+		return nil, ErrLocked
+	}
+	return s.sk.Sign(h)
+}
+
+// VRFProve proves the VRF output of alpha if the keystore is unlocked
+func (s *KeystoreSigner) VRFProve(alpha []byte) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.sk == nil {
+		return nil, ErrLocked
+	}
+	return cp.VRFProve(s.sk, alpha)
+}
@@ -0,0 +1,257 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/pkg/errors"
+
+	cp "github.com/iotexproject/iotex-core/crypto"
+)
+
+const (
+	// AuthHeader is the header of the hex HMAC-SHA256 of the body by the shared secret, on both the requests and the
+	// responses of the remote signer
+	AuthHeader = "X-Signer-Auth"
+
+	_methodSign = "sign"
+	_methodVRF  = "vrf"
+	// _maxRequestAge is how old a request the remote signer accepts, to reject the replayed requests
+	_maxRequestAge = 30 * time.Second
+	_maxBodySize   = 4 << 10
+)
+
+var (
+	// ErrRemoteSigner is the error of the remote signer failing to sign in time
+	ErrRemoteSigner = errors.New("remote signer failed")
+)
+
+type (
+	// RemoteSigner signs by a remote signer over HTTP. The requests and the responses are authenticated by the HMAC
+	// of the shared secret, and the signatures are verified against the public key. A request not answered within
+	// the timeout fails, so a slow remote signer costs a missed endorsement rather than a stalled round
+	RemoteSigner struct {
+		endpoint string
+		secret   []byte
+		pk       crypto.PublicKey
+		client   *http.Client
+	}
+
+	remoteRequest struct {
+		Method string `json:"method"`
+		// Payload is the hex hash to sign, or the hex alpha of the VRF
+		Payload string `json:"payload"`
+		// Nonce is echoed in the response, to bind the response to the request
+		Nonce string `json:"nonce"`
+		// Timestamp is the unix milliseconds when the request is sent
+		Timestamp int64 `json:"timestamp"`
+	}
+
+	remoteResponse struct {
+		Nonce  string `json:"nonce"`
+		Result string `json:"result,omitempty"`
+		Error  string `json:"error,omitempty"`
+	}
+
+	remoteSignerHandler struct {
+		s      cp.Signer
+		secret []byte
+	}
+)
+
+// NewRemoteSigner creates the signer of the remote signer at the endpoint, whose key is of the public key
+func NewRemoteSigner(endpoint string, secret []byte, pk crypto.PublicKey, timeout time.Duration) (*RemoteSigner, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.Wrapf(ErrInvalidConfig, "invalid remote signer endpoint %q", endpoint)
+	}
+	if len(secret) == 0 {
+		return nil, errors.Wrap(ErrInvalidConfig, "the secret of the remote signer is empty")
+	}
+	if timeout <= 0 {
+		return nil, errors.Wrap(ErrInvalidConfig, "the timeout of the remote signer must be positive")
+	}
+	return &RemoteSigner{
+		endpoint: endpoint,
+		secret:   secret,
+		pk:       pk,
+		client:   &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// PublicKey returns the public key
+func (s *RemoteSigner) PublicKey() crypto.PublicKey {
+	return s.pk
+}
+
+// Sign signs the hash by the remote signer
+func (s *RemoteSigner) Sign(h []byte) ([]byte, error) {
+	sig, err := s.call(_methodSign, h)
+	if err != nil {
+		return nil, err
+	}
+	if !s.pk.Verify(h, sig) {
+		return nil, errors.Wrap(ErrRemoteSigner, "the signature is not of the public key")
+	}
+	return sig, nil
+}
+
+// VRFProve proves the VRF output of alpha by the remote signer
+func (s *RemoteSigner) VRFProve(alpha []byte) ([]byte, error) {
+	proof, err := s.call(_methodVRF, alpha)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := cp.VRFVerify(s.pk, alpha, proof); err != nil {
+		return nil, errors.Wrap(ErrRemoteSigner, "the VRF proof is not of the public key")
+	}
+	return proof, nil
+}
+
+func (s *RemoteSigner) call(method string, payload []byte) ([]byte, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(&remoteRequest{
+		Method:    method,
+		Payload:   hex.EncodeToString(payload),
+		Nonce:     hex.EncodeToString(nonce),
+		Timestamp: time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.client.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(AuthHeader, authCode(s.secret, body))
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(ErrRemoteSigner, err.Error())
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, _maxBodySize))
+	if err != nil {
+		return nil, errors.Wrap(ErrRemoteSigner, err.Error())
+	}
+	if !validAuthCode(s.secret, data, resp.Header.Get(AuthHeader)) {
+		return nil, errors.Wrapf(ErrRemoteSigner, "unauthenticated response of status %d", resp.StatusCode)
+	}
+	var res remoteResponse
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, errors.Wrap(ErrRemoteSigner, "invalid response")
+	}
+	if res.Nonce != hex.EncodeToString(nonce) {
+		return nil, errors.Wrap(ErrRemoteSigner, "the response is not of the request")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Wrapf(ErrRemoteSigner, "status %d: %s", resp.StatusCode, res.Error)
+	}
+	result, err := hex.DecodeString(res.Result)
+	if err != nil {
+		return nil, errors.Wrap(ErrRemoteSigner, "invalid result")
+	}
+	return result, nil
+}
+
+// NewRemoteSignerHandler returns the handler serving the remote signer protocol with the signer, which only answers
+// the requests authenticated by the secret and sent within the last 30 seconds
+func NewRemoteSignerHandler(s cp.Signer, secret []byte) http.Handler {
+	return &remoteSignerHandler{
+		s:      s,
+		secret: secret,
+	}
+}
+
+func (h *remoteSignerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, _maxBodySize))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if !validAuthCode(h.secret, body, r.Header.Get(AuthHeader)) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	var req remoteRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if age := time.Since(time.UnixMilli(req.Timestamp)); age > _maxRequestAge || age < -_maxRequestAge {
+		h.reply(w, http.StatusUnauthorized, &remoteResponse{Nonce: req.Nonce, Error: "stale request"})
+		return
+	}
+	payload, err := hex.DecodeString(req.Payload)
+	if err != nil {
+		h.reply(w, http.StatusBadRequest, &remoteResponse{Nonce: req.Nonce, Error: "invalid payload"})
+		return
+	}
+	var result []byte
+	switch req.Method {
+	case _methodSign:
+		result, err = h.s.Sign(payload)
+	case _methodVRF:
+		result, err = cp.ProveVRF(h.s, payload)
+	default:
+		h.reply(w, http.StatusBadRequest, &remoteResponse{Nonce: req.Nonce, Error: "unknown method"})
+		return
+	}
+	if err != nil {
+		h.reply(w, http.StatusInternalServerError, &remoteResponse{Nonce: req.Nonce, Error: err.Error()})
+		return
+	}
+	h.reply(w, http.StatusOK, &remoteResponse{Nonce: req.Nonce, Result: hex.EncodeToString(result)})
+}
+
+func (h *remoteSignerHandler) reply(w http.ResponseWriter, status int, res *remoteResponse) {
+	data, err := json.Marshal(res)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(AuthHeader, authCode(h.secret, data))
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+}
+
+func authCode(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func validAuthCode(secret, body []byte, code string) bool {
+	expected, err := hex.DecodeString(code)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/pkg/errors"
+
+	cp "github.com/iotexproject/iotex-core/crypto"
+)
+
+const (
+	// KeystoreType is the type of the signer with the key in an encrypted keystore file
+	KeystoreType = "keystore"
+	// RemoteType is the type of the signer with the key kept by a remote signer
+	RemoteType = "remote"
+)
+
+type (
+	// Config is the config of the signer of a key kept out of the config. None of the secrets is in the config
+	// itself, they are read from the files instead
+	Config struct {
+		// Type is the type of the signer, empty for the private key in the config
+		Type string `yaml:"type"`
+		// PublicKey is the hex public key of the signer, which is known before the keystore is unlocked
+		PublicKey string `yaml:"publicKey"`
+		// KeystorePath is the path of the encrypted keystore file
+		KeystorePath string `yaml:"keystorePath"`
+		// PassphraseFile is the file of the passphrase unlocking the keystore at startup. If empty, the keystore is
+		// locked until unlocked by the admin endpoint
+		PassphraseFile string `yaml:"passphraseFile"`
+		// Endpoint is the url of the remote signer
+		Endpoint string `yaml:"endpoint"`
+		// SecretFile is the file of the secret shared with the remote signer, which authenticates the requests and
+		// the responses
+		SecretFile string `yaml:"secretFile"`
+		// Timeout is the longest time waiting for the remote signer, the signature not returned in time is missed
+		Timeout time.Duration `yaml:"timeout"`
+	}
+)
+
+var (
+	// DefaultConfig is the default config of the signer
+	DefaultConfig = Config{
+		Timeout: time.Second,
+	}
+
+	// ErrLocked is the error of signing with a locked keystore
+	ErrLocked = errors.New("the keystore is locked")
+	// ErrInvalidConfig is the error of an invalid signer config
+	ErrInvalidConfig = errors.New("invalid signer config")
+)
+
+// New creates the signer of the config
+func New(cfg Config) (cp.Signer, error) {
+	pk, err := crypto.HexStringToPublicKey(cfg.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidConfig, "invalid public key")
+	}
+	switch cfg.Type {
+	case KeystoreType:
+		s, err := NewKeystoreSigner(cfg.KeystorePath, pk)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.PassphraseFile != "" {
+			passphrase, err := readSecretFile(cfg.PassphraseFile)
+			if err != nil {
+				return nil, err
+			}
+			if err := s.Unlock(passphrase); err != nil {
+				return nil, err
+			}
+		}
+		return s, nil
+	case RemoteType:
+		secret, err := readSecretFile(cfg.SecretFile)
+		if err != nil {
+			return nil, err
+		}
+		return NewRemoteSigner(cfg.Endpoint, []byte(secret), pk, cfg.Timeout)
+	default:
+		return nil, errors.Wrapf(ErrInvalidConfig, "unknown signer type %q", cfg.Type)
+	}
+}
+
+func readSecretFile(path string) (string, error) {
+	if path == "" {
+		return "", errors.Wrap(ErrInvalidConfig, "the secret file is not set")
+	}
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read the secret file %s", path)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+
+	cp "github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func writeKeystore(t *testing.T, dir, passphrase string) string {
+	r := require.New(t)
+	sk, err := ethcrypto.ToECDSA(identityset.PrivateKey(1).Bytes())
+	r.NoError(err)
+	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+	account, err := ks.ImportECDSA(sk, passphrase)
+	r.NoError(err)
+	return account.URL.Path
+}
+
+func TestKeystoreSigner(t *testing.T) {
+	r := require.New(t)
+	path := writeKeystore(t, t.TempDir(), "pass")
+	h := hash.Hash256b([]byte("block"))
+
+	_, err := NewKeystoreSigner(path+".missing", identityset.PrivateKey(1).PublicKey())
+	r.Error(err)
+
+	s, err := NewKeystoreSigner(path, identityset.PrivateKey(1).PublicKey())
+	r.NoError(err)
+	r.True(s.Locked())
+	_, err = s.Sign(h[:])
+	r.ErrorIs(err, ErrLocked)
+	_, err = cp.ProveVRF(s, h[:])
+	r.ErrorIs(err, ErrLocked)
+
+	r.Error(s.Unlock("wrong"))
+	r.True(s.Locked())
+	r.NoError(s.Unlock("pass"))
+	r.False(s.Locked())
+	sig, err := s.Sign(h[:])
+	r.NoError(err)
+	r.True(s.PublicKey().Verify(h[:], sig))
+	proof, err := cp.ProveVRF(s, h[:])
+	r.NoError(err)
+	expected, err := cp.VRFProve(identityset.PrivateKey(1), h[:])
+	r.NoError(err)
+	r.Equal(expected, proof)
+
+	s.Lock()
+	r.True(s.Locked())
+	_, err = s.Sign(h[:])
+	r.ErrorIs(err, ErrLocked)
+
+	// the key in the keystore is not of the public key
+	s, err = NewKeystoreSigner(path, identityset.PrivateKey(2).PublicKey())
+	r.NoError(err)
+	r.Error(s.Unlock("pass"))
+	r.True(s.Locked())
+}
+
+func TestNew(t *testing.T) {
+	r := require.New(t)
+	dir := t.TempDir()
+	path := writeKeystore(t, dir, "pass")
+	passphraseFile := filepath.Join(dir, "passphrase")
+	r.NoError(os.WriteFile(passphraseFile, []byte("pass\n"), 0600))
+	pk := identityset.PrivateKey(1).PublicKey().HexString()
+
+	_, err := New(Config{Type: KeystoreType, PublicKey: "invalid", KeystorePath: path})
+	r.ErrorIs(err, ErrInvalidConfig)
+	_, err = New(Config{Type: "hsm", PublicKey: pk})
+	r.ErrorIs(err, ErrInvalidConfig)
+
+	s, err := New(Config{Type: KeystoreType, PublicKey: pk, KeystorePath: path})
+	r.NoError(err)
+	r.True(s.(*KeystoreSigner).Locked())
+	s, err = New(Config{Type: KeystoreType, PublicKey: pk, KeystorePath: path, PassphraseFile: passphraseFile})
+	r.NoError(err)
+	r.False(s.(*KeystoreSigner).Locked())
+
+	secretFile := filepath.Join(dir, "secret")
+	r.NoError(os.WriteFile(secretFile, []byte("secret"), 0600))
+	_, err = New(Config{Type: RemoteType, PublicKey: pk, Endpoint: "http://127.0.0.1:1", Timeout: time.Second})
+	r.ErrorIs(err, ErrInvalidConfig)
+	_, err = New(Config{Type: RemoteType, PublicKey: pk, Endpoint: "127.0.0.1:1", SecretFile: secretFile, Timeout: time.Second})
+	r.ErrorIs(err, ErrInvalidConfig)
+	_, err = New(Config{Type: RemoteType, PublicKey: pk, Endpoint: "http://127.0.0.1:1", SecretFile: secretFile})
+	r.ErrorIs(err, ErrInvalidConfig)
+	s, err = New(Config{Type: RemoteType, PublicKey: pk, Endpoint: "http://127.0.0.1:1", SecretFile: secretFile, Timeout: time.Second})
+	r.NoError(err)
+	r.Equal(pk, s.PublicKey().HexString())
+}
+
+func TestRemoteSigner(t *testing.T) {
+	r := require.New(t)
+	secret := []byte("secret")
+	sk := identityset.PrivateKey(1)
+	h := hash.Hash256b([]byte("block"))
+	srv := httptest.NewServer(NewRemoteSignerHandler(sk, secret))
+	defer srv.Close()
+
+	t.Run("sign", func(t *testing.T) {
+		s, err := NewRemoteSigner(srv.URL, secret, sk.PublicKey(), time.Second)
+		r.NoError(err)
+		sig, err := s.Sign(h[:])
+		r.NoError(err)
+		r.True(sk.PublicKey().Verify(h[:], sig))
+		proof, err := cp.ProveVRF(s, h[:])
+		r.NoError(err)
+		expected, err := cp.VRFProve(sk, h[:])
+		r.NoError(err)
+		r.Equal(expected, proof)
+	})
+	t.Run("wrong secret", func(t *testing.T) {
+		s, err := NewRemoteSigner(srv.URL, []byte("wrong"), sk.PublicKey(), time.Second)
+		r.NoError(err)
+		_, err = s.Sign(h[:])
+		r.ErrorIs(err, ErrRemoteSigner)
+	})
+	t.Run("wrong key", func(t *testing.T) {
+		s, err := NewRemoteSigner(srv.URL, secret, identityset.PrivateKey(2).PublicKey(), time.Second)
+		r.NoError(err)
+		_, err = s.Sign(h[:])
+		r.ErrorIs(err, ErrRemoteSigner)
+		_, err = s.VRFProve(h[:])
+		r.ErrorIs(err, ErrRemoteSigner)
+	})
+	t.Run("timeout", func(t *testing.T) {
+		done := make(chan struct{})
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			select {
+			case <-done:
+			case <-req.Context().Done():
+			}
+		}))
+		defer slow.Close()
+		defer close(done)
+		s, err := NewRemoteSigner(slow.URL, secret, sk.PublicKey(), 100*time.Millisecond)
+		r.NoError(err)
+		start := time.Now()
+		_, err = s.Sign(h[:])
+		r.ErrorIs(err, ErrRemoteSigner)
+		r.Less(time.Since(start), time.Second)
+	})
+	t.Run("unauthenticated response", func(t *testing.T) {
+		forged := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			var body remoteRequest
+			r.NoError(json.NewDecoder(req.Body).Decode(&body))
+			sig, err := sk.Sign(h[:])
+			r.NoError(err)
+			r.NoError(json.NewEncoder(w).Encode(&remoteResponse{Nonce: body.Nonce, Result: hex.EncodeToString(sig)}))
+		}))
+		defer forged.Close()
+		s, err := NewRemoteSigner(forged.URL, secret, sk.PublicKey(), time.Second)
+		r.NoError(err)
+		_, err = s.Sign(h[:])
+		r.ErrorIs(err, ErrRemoteSigner)
+	})
+	t.Run("stale request", func(t *testing.T) {
+		body, err := json.Marshal(&remoteRequest{
+			Method:    _methodSign,
+			Payload:   hex.EncodeToString(h[:]),
+			Nonce:     "00",
+			Timestamp: time.Now().Add(-time.Minute).UnixMilli(),
+		})
+		r.NoError(err)
+		req, err := http.NewRequest(http.MethodPost, srv.URL, bytes.NewReader(body))
+		r.NoError(err)
+		req.Header.Set(AuthHeader, authCode(secret, body))
+		resp, err := http.DefaultClient.Do(req)
+		r.NoError(err)
+		defer resp.Body.Close()
+		r.Equal(http.StatusUnauthorized, resp.StatusCode)
+	})
+	t.Run("signer error", func(t *testing.T) {
+		locked, err := NewKeystoreSigner(writeKeystore(t, t.TempDir(), "pass"), sk.PublicKey())
+		r.NoError(err)
+		srv := httptest.NewServer(NewRemoteSignerHandler(locked, secret))
+		defer srv.Close()
+		s, err := NewRemoteSigner(srv.URL, secret, sk.PublicKey(), time.Second)
+		r.NoError(err)
+		_, err = s.Sign(h[:])
+		r.ErrorIs(err, ErrRemoteSigner)
+		r.Contains(err.Error(), ErrLocked.Error())
+	})
+}
@@ -0,0 +1,143 @@
+package tracer
+
+import (
+	"container/list"
+	"context"
+	"encoding/hex"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const (
+	// ActionHashKey is the attribute key of the action hash
+	ActionHashKey = attribute.Key("actionHash")
+	// PoolTimeKey is the attribute key of the time in milliseconds an action stays in the actpool
+	PoolTimeKey = attribute.Key("poolTimeMs")
+
+	_maxTrackedActions = 10000
+)
+
+type (
+	actionTrace struct {
+		hash  hash.Hash256
+		sc    trace.SpanContext
+		start time.Time
+	}
+
+	// actionTraces tracks the span contexts of the sampled actions, so that the spans of the later stages of an
+	// action's lifecycle are the children of its root span
+	actionTraces struct {
+		mu      sync.Mutex
+		size    int32
+		limit   int
+		traces  map[hash.Hash256]*list.Element
+		ordered *list.List
+	}
+)
+
+var (
+	_noopSpan = noop.Span{}
+	_actions  = newActionTraces(_maxTrackedActions)
+)
+
+func newActionTraces(limit int) *actionTraces {
+	return &actionTraces{
+		limit:   limit,
+		traces:  make(map[hash.Hash256]*list.Element),
+		ordered: list.New(),
+	}
+}
+
+func (at *actionTraces) tracking() bool {
+	return atomic.LoadInt32(&at.size) > 0
+}
+
+func (at *actionTraces) add(h hash.Hash256, sc trace.SpanContext) {
+	at.mu.Lock()
+	defer at.mu.Unlock()
+	if _, ok := at.traces[h]; ok {
+		return
+	}
+	// the oldest action is evicted, in case its block is never committed
+	if at.ordered.Len() >= at.limit {
+		at.removeElement(at.ordered.Front())
+	}
+	at.traces[h] = at.ordered.PushBack(&actionTrace{
+		hash:  h,
+		sc:    sc,
+		start: time.Now(),
+	})
+	atomic.StoreInt32(&at.size, int32(at.ordered.Len()))
+}
+
+func (at *actionTraces) get(h hash.Hash256) (*actionTrace, bool) {
+	at.mu.Lock()
+	defer at.mu.Unlock()
+	e, ok := at.traces[h]
+	if !ok {
+		return nil, false
+	}
+	return e.Value.(*actionTrace), true
+}
+
+func (at *actionTraces) remove(h hash.Hash256) {
+	at.mu.Lock()
+	defer at.mu.Unlock()
+	if e, ok := at.traces[h]; ok {
+		at.removeElement(e)
+	}
+}
+
+func (at *actionTraces) removeElement(e *list.Element) {
+	delete(at.traces, e.Value.(*actionTrace).hash)
+	at.ordered.Remove(e)
+	atomic.StoreInt32(&at.size, int32(at.ordered.Len()))
+}
+
+// StartActionSpan starts the root span of an action's lifecycle. If the span is sampled, the action is tracked
+// until EndActionTrace, and the spans started by ActionSpan for it are the children of the root span
+func StartActionSpan(ctx context.Context, h hash.Hash256, name string) (context.Context, trace.Span) {
+	ctx, span := NewSpan(ctx, name, trace.WithAttributes(ActionHashKey.String(hex.EncodeToString(h[:]))))
+	if sc := span.SpanContext(); sc.IsSampled() {
+		_actions.add(h, sc)
+	}
+	return ctx, span
+}
+
+// TracksActions returns true if any action is tracked. The hot paths check it before hashing the actions, so they
+// pay nothing unless an action is sampled
+func TracksActions() bool {
+	return _actions.tracking()
+}
+
+// ActionSpan starts a span of a later stage of the tracked action as a child of its root span, or returns a no-op
+// span if the action is not tracked
+func ActionSpan(h hash.Hash256, name string, opts ...trace.SpanStartOption) trace.Span {
+	if !_actions.tracking() {
+		return _noopSpan
+	}
+	at, ok := _actions.get(h)
+	if !ok {
+		return _noopSpan
+	}
+	opts = append(opts, trace.WithAttributes(
+		ActionHashKey.String(hex.EncodeToString(h[:])),
+		PoolTimeKey.Int64(time.Since(at.start).Milliseconds()),
+	))
+	_, span := NewSpan(trace.ContextWithSpanContext(context.Background(), at.sc), name, opts...)
+	return span
+}
+
+// EndActionTrace stops tracking the action once it is committed
+func EndActionTrace(h hash.Hash256) {
+	if !_actions.tracking() {
+		return
+	}
+	_actions.remove(h)
+}
@@ -0,0 +1,37 @@
+package tracer
+
+import (
+	"fmt"
+
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"golang.org/x/time/rate"
+)
+
+// rateLimitedSampler drops the traces sampled by the underlying sampler beyond the rate limit, so that a busy node
+// doesn't emit millions of spans. It is meant to sample the root spans, the children follow their parents
+type rateLimitedSampler struct {
+	sampler tracesdk.Sampler
+	limiter *rate.Limiter
+	max     int
+}
+
+func newRateLimitedSampler(sampler tracesdk.Sampler, max int) tracesdk.Sampler {
+	return &rateLimitedSampler{
+		sampler: sampler,
+		limiter: rate.NewLimiter(rate.Limit(max), max),
+		max:     max,
+	}
+}
+
+func (s *rateLimitedSampler) ShouldSample(p tracesdk.SamplingParameters) tracesdk.SamplingResult {
+	ret := s.sampler.ShouldSample(p)
+	if ret.Decision == tracesdk.RecordAndSample && !s.limiter.Allow() {
+		ret.Decision = tracesdk.Drop
+		ret.Attributes = nil
+	}
+	return ret
+}
+
+func (s *rateLimitedSampler) Description() string {
+	return fmt.Sprintf("RateLimited{%s,%d/s}", s.sampler.Description(), s.max)
+}
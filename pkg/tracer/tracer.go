@@ -3,6 +3,8 @@ package tracer
 import (
 	"strconv"
 
+	"github.com/pkg/errors"
+
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/jaeger"
@@ -29,6 +31,8 @@ type Config struct {
 	//ratio >= 1 will always sample (default),< 0 are treated as zero will no sample
 	// if you set this to .5, half of traces will be sampled
 	SamplingRatio string `yaml:"samplingRatio"`
+	// MaxTracesPerSecond limits the number of sampled traces per second, 0 means no limit
+	MaxTracesPerSecond int `yaml:"maxTracesPerSecond"`
 }
 
 // Option the tracer provider option
@@ -39,6 +43,7 @@ type optionParams struct {
 	endpoint      string //the jaeger endpoint
 	instanceID    string //Note: MUST be unique for each instance of the same
 	samplingRatio string
+	maxTraces     int
 }
 
 // WithServiceName defines service name
@@ -73,6 +78,17 @@ func WithSamplingRatio(samplingRatio string) Option {
 	}
 }
 
+// WithMaxTracesPerSecond limits the number of sampled traces per second
+func WithMaxTracesPerSecond(max int) Option {
+	return func(ops *optionParams) error {
+		if max < 0 {
+			return errors.Errorf("invalid max traces per second %d", max)
+		}
+		ops.maxTraces = max
+		return nil
+	}
+}
+
 // NewProvider create an instance of tracer provider
 func NewProvider(opts ...Option) (*tracesdk.TracerProvider, error) {
 	var (
@@ -95,13 +111,20 @@ func NewProvider(opts ...Option) (*tracesdk.TracerProvider, error) {
 	if ops.serviceName == "" {
 		ops.serviceName = _service
 	}
-	if ops.samplingRatio != "" {
-		ratio, err := strconv.ParseFloat(ops.samplingRatio, 64)
-		if err != nil {
-			return nil, err
+	if ops.samplingRatio != "" || ops.maxTraces > 0 {
+		sampler := tracesdk.AlwaysSample()
+		if ops.samplingRatio != "" {
+			ratio, err := strconv.ParseFloat(ops.samplingRatio, 64)
+			if err != nil {
+				return nil, err
+			}
+			sampler = tracesdk.TraceIDRatioBased(ratio)
+		}
+		if ops.maxTraces > 0 {
+			sampler = newRateLimitedSampler(sampler, ops.maxTraces)
 		}
 		trackerTracerProviderOption = append(trackerTracerProviderOption,
-			tracesdk.WithSampler(tracesdk.ParentBased(tracesdk.TraceIDRatioBased(ratio))),
+			tracesdk.WithSampler(tracesdk.ParentBased(sampler)),
 		)
 	}
 	kv := []attribute.KeyValue{
@@ -4,7 +4,10 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/stretchr/testify/require"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestTracer(t *testing.T) {
@@ -25,3 +28,44 @@ func TestTracer(t *testing.T) {
 	)
 	require.NoError(err)
 }
+
+func TestRateLimitedSampler(t *testing.T) {
+	require := require.New(t)
+	_, err := NewProvider(WithEndpoint("http://aa"), WithMaxTracesPerSecond(-1))
+	require.Error(err)
+
+	sampler := newRateLimitedSampler(tracesdk.AlwaysSample(), 2)
+	var sampled int
+	for i := 0; i < 10; i++ {
+		if sampler.ShouldSample(tracesdk.SamplingParameters{}).Decision == tracesdk.RecordAndSample {
+			sampled++
+		}
+	}
+	require.Equal(2, sampled)
+	require.Equal(tracesdk.Drop, newRateLimitedSampler(tracesdk.NeverSample(), 2).ShouldSample(tracesdk.SamplingParameters{}).Decision)
+}
+
+func TestActionTraces(t *testing.T) {
+	require := require.New(t)
+	at := newActionTraces(2)
+	require.False(at.tracking())
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+	})
+	h1, h2, h3 := hash.Hash256b([]byte{1}), hash.Hash256b([]byte{2}), hash.Hash256b([]byte{3})
+	at.add(h1, sc)
+	at.add(h2, sc)
+	require.True(at.tracking())
+	// the oldest is evicted
+	at.add(h3, sc)
+	_, ok := at.get(h1)
+	require.False(ok)
+	ret, ok := at.get(h2)
+	require.True(ok)
+	require.Equal(sc, ret.sc)
+	at.remove(h2)
+	at.remove(h3)
+	require.False(at.tracking())
+}
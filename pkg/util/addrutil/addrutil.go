@@ -6,10 +6,46 @@
 package addrutil
 
 import (
+	"strings"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+)
+
+// the formats of an address
+const (
+	IoFormat  = "io"
+	HexFormat = "hex"
+)
+
+var (
+	// ErrInvalidAddress indicates the error of an address in neither io nor hex format
+	ErrInvalidAddress = errors.New("invalid address")
+	// ErrInvalidChecksum indicates the error of a mixed-case hex address not matching its EIP-55 checksum
+	ErrInvalidChecksum = errors.New("invalid address checksum")
+
+	_systemContracts = map[string]string{
+		address.StakingProtocolAddr:   "staking",
+		address.RewardingProtocol:     "rewarding",
+		address.StakingBucketPoolAddr: "stakingBucketPool",
+		address.RewardingPoolAddr:     "rewardingPool",
+	}
 )
 
+// ConvertedAddress is an address in both io and hex format
+type ConvertedAddress struct {
+	Address address.Address
+	// IoAddress is the io-bech32 format
+	IoAddress string
+	// HexAddress is the EIP-55 checksummed hex format, which is empty for the special pool addresses
+	HexAddress string
+	// Format is the format of the address being converted
+	Format string
+	// SystemContract is the name of the system contract at the address, or empty
+	SystemContract string
+}
+
 // IoAddrToEvmAddr converts IoTeX address into evm address
 func IoAddrToEvmAddr(ioAddr string) (common.Address, error) {
 	address, err := address.FromString(ioAddr)
@@ -18,3 +54,71 @@ func IoAddrToEvmAddr(ioAddr string) (common.Address, error) {
 	}
 	return common.BytesToAddress(address.Bytes()), nil
 }
+
+// ParseAddress parses the address in either io-bech32 or hex format. A hex address with or without the 0x prefix
+// in all lower or upper case is accepted as is, while a mixed-case one must match its EIP-55 checksum
+func ParseAddress(str string) (address.Address, error) {
+	addr, _, err := parseAddress(str)
+	return addr, err
+}
+
+// ConvertAddress parses the address in either format, and converts it into the other format
+func ConvertAddress(str string) (*ConvertedAddress, error) {
+	addr, format, err := parseAddress(str)
+	if err != nil {
+		return nil, err
+	}
+	ret := &ConvertedAddress{
+		Address:        addr,
+		IoAddress:      addr.String(),
+		Format:         format,
+		SystemContract: SystemContractName(addr.String()),
+	}
+	// the special pool addresses don't have the hex format
+	if ret.IoAddress != address.StakingBucketPoolAddr && ret.IoAddress != address.RewardingPoolAddr {
+		ret.HexAddress = common.BytesToAddress(addr.Bytes()).Hex()
+	}
+	return ret, nil
+}
+
+// ValidateChecksum checks the EIP-55 checksum of a mixed-case hex address
+func ValidateChecksum(hexAddr string) error {
+	str := strings.TrimPrefix(strings.TrimPrefix(hexAddr, "0x"), "0X")
+	if !common.IsHexAddress(str) {
+		return errors.Wrapf(ErrInvalidAddress, "%q is not a hex address", hexAddr)
+	}
+	if strings.ToLower(str) == str || strings.ToUpper(str) == str {
+		return nil
+	}
+	if common.HexToAddress(str).Hex()[2:] != str {
+		return errors.Wrapf(ErrInvalidChecksum, "%q", hexAddr)
+	}
+	return nil
+}
+
+// SystemContractName returns the name of the system contract at the io address, or empty
+func SystemContractName(ioAddr string) string {
+	return _systemContracts[ioAddr]
+}
+
+func parseAddress(str string) (address.Address, string, error) {
+	switch {
+	case str == "":
+		return nil, "", errors.Wrap(ErrInvalidAddress, "empty address")
+	case len(str) == 2*common.AddressLength || strings.HasPrefix(str, "0x") || strings.HasPrefix(str, "0X"):
+		if err := ValidateChecksum(str); err != nil {
+			return nil, "", err
+		}
+		addr, err := address.FromBytes(common.HexToAddress(str).Bytes())
+		if err != nil {
+			return nil, "", errors.Wrapf(ErrInvalidAddress, "%q: %v", str, err)
+		}
+		return addr, HexFormat, nil
+	default:
+		addr, err := address.FromString(str)
+		if err != nil {
+			return nil, "", errors.Wrapf(ErrInvalidAddress, "%q: %v", str, err)
+		}
+		return addr, IoFormat, nil
+	}
+}
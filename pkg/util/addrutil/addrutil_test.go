@@ -6,9 +6,12 @@
 package addrutil
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/test/identityset"
@@ -30,3 +33,104 @@ func TestIoAddrToEvmAddr(t *testing.T) {
 		require.Contains(t, err.Error(), "address length = 0, expecting 41")
 	})
 }
+
+func TestParseAddress(t *testing.T) {
+	r := require.New(t)
+	addr := identityset.Address(28)
+	hexAddr := common.BytesToAddress(addr.Bytes()).Hex()
+	lower, upper := strings.ToLower(hexAddr[2:]), strings.ToUpper(hexAddr[2:])
+
+	for _, v := range []string{
+		addr.String(),
+		hexAddr,
+		hexAddr[2:],
+		"0x" + lower,
+		"0X" + upper,
+		lower,
+		upper,
+	} {
+		parsed, err := ParseAddress(v)
+		r.NoError(err, v)
+		r.Equal(addr.String(), parsed.String(), v)
+	}
+
+	// flip the case of a letter to break the checksum
+	badChecksum := []byte(hexAddr)
+	for i := 2; i < len(badChecksum); i++ {
+		if c := badChecksum[i]; c >= 'a' && c <= 'f' {
+			badChecksum[i] = c - 'a' + 'A'
+			break
+		} else if c >= 'A' && c <= 'F' {
+			badChecksum[i] = c - 'A' + 'a'
+			break
+		}
+	}
+	_, err := ParseAddress(string(badChecksum))
+	r.Equal(ErrInvalidChecksum, errors.Cause(err))
+
+	for _, v := range []string{
+		"",
+		" ",
+		"0x",
+		"0x0",
+		hexAddr[:len(hexAddr)-1],
+		hexAddr + "0",
+		"0x" + strings.Repeat("g", 40),
+		"0x" + lower[:38] + "zz",
+		" " + hexAddr,
+		hexAddr + " ",
+		addr.String()[:len(addr.String())-1],
+		addr.String() + "q",
+		strings.Replace(addr.String(), "io1", "it1", 1),
+		strings.ToUpper(addr.String()[:3]) + addr.String()[3:],
+		"io1",
+		"io1" + strings.Repeat("b", 38),
+		"9254d943485d0fb859ff63c5581acc44f00fc2110343ac0445b99dfe39a6f1a5",
+	} {
+		_, err := ParseAddress(v)
+		r.Error(err, v)
+		r.Equal(ErrInvalidAddress, errors.Cause(err), v)
+	}
+}
+
+func TestConvertAddress(t *testing.T) {
+	r := require.New(t)
+	addr := identityset.Address(28)
+	hexAddr := common.BytesToAddress(addr.Bytes()).Hex()
+
+	ret, err := ConvertAddress(addr.String())
+	r.NoError(err)
+	r.Equal(addr.String(), ret.IoAddress)
+	r.Equal(hexAddr, ret.HexAddress)
+	r.Equal(IoFormat, ret.Format)
+	r.Empty(ret.SystemContract)
+
+	ret, err = ConvertAddress(strings.ToLower(hexAddr))
+	r.NoError(err)
+	r.Equal(addr.String(), ret.IoAddress)
+	r.Equal(hexAddr, ret.HexAddress)
+	r.Equal(HexFormat, ret.Format)
+
+	ret, err = ConvertAddress(address.StakingProtocolAddr)
+	r.NoError(err)
+	r.Equal("staking", ret.SystemContract)
+	r.NotEmpty(ret.HexAddress)
+
+	ret, err = ConvertAddress(address.RewardingPoolAddr)
+	r.NoError(err)
+	r.Equal("rewardingPool", ret.SystemContract)
+	r.Empty(ret.HexAddress)
+
+	_, err = ConvertAddress("0x123")
+	r.Equal(ErrInvalidAddress, errors.Cause(err))
+}
+
+func TestValidateChecksum(t *testing.T) {
+	r := require.New(t)
+	hexAddr := common.BytesToAddress(identityset.Address(28).Bytes()).Hex()
+	r.NoError(ValidateChecksum(hexAddr))
+	r.NoError(ValidateChecksum(strings.ToLower(hexAddr)))
+	r.NoError(ValidateChecksum("0x7c13866F9253DEf79e20034eDD011e1d69E67fe5"))
+	r.Equal(ErrInvalidChecksum, errors.Cause(ValidateChecksum("0x7c13866F9253DEf79e20034eDD011e1d69E67fE5")))
+	r.Equal(ErrInvalidAddress, errors.Cause(ValidateChecksum("0x12")))
+}
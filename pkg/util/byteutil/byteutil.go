@@ -60,6 +60,11 @@ func BytesToUint64BigEndian(value []byte) uint64 {
 	return binary.BigEndian.Uint64(value)
 }
 
+// BytesToUint32BigEndian converts 4 bytes to uint32 in big-endian
+func BytesToUint32BigEndian(value []byte) uint32 {
+	return binary.BigEndian.Uint32(value)
+}
+
 // BoolToByte converts bool to byte
 func BoolToByte(value bool) byte {
 	if value {
@@ -26,6 +26,11 @@ func TestUint32(t *testing.T) {
 		result := Uint32ToBytesBigEndian(input)
 		require.Equal(t, expectedValue, result)
 	})
+
+	t.Run("converts 4 bytes to uint32 in big-endian", func(t *testing.T) {
+		result := BytesToUint32BigEndian([]byte{0x1, 0xdf, 0x5e, 0x76})
+		require.Equal(t, input, result)
+	})
 }
 
 func TestUint64(t *testing.T) {
@@ -0,0 +1,161 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/server/itx"
+)
+
+const _genesisUsage = `usage:
+  server -genesis-path=[string] genesis validate
+  server genesis diff [genesis path a] [genesis path b]
+  server -genesis-path=[string] -config-path=[string] genesis export-staking [height]
+`
+
+type stakingBootstrap struct {
+	Staking struct {
+		BootstrapCandidates []genesis.BootstrapCandidate `yaml:"bootstrapCandidates"`
+		BootstrapBuckets    []genesis.BootstrapBucket    `yaml:"bootstrapBuckets"`
+	} `yaml:"staking"`
+}
+
+// genesisCommand runs the genesis subcommand, which validates the genesis file of the node, reports the
+// differences of two genesis files, or exports the staking state of the stopped node as the bootstrap candidates
+// and buckets of a genesis, and returns the exit code
+func genesisCommand(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, _genesisUsage)
+		return 2
+	}
+	switch args[0] {
+	case "validate":
+		g, err := genesis.New(_genesisPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to load genesis %s: %v\n", _genesisPath, err)
+			return 1
+		}
+		if err := genesis.ValidateGenesis(&g); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		fmt.Println("genesis is valid")
+		return 0
+	case "diff":
+		if len(args) != 3 {
+			fmt.Fprint(os.Stderr, _genesisUsage)
+			return 2
+		}
+		var gs [2]genesis.Genesis
+		for i, path := range args[1:] {
+			g, err := genesis.New(path)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "failed to load genesis %s: %v\n", path, err)
+				return 1
+			}
+			gs[i] = g
+		}
+		diffs := genesis.DiffGenesis(&gs[0], &gs[1])
+		for _, d := range diffs {
+			fmt.Printf("%s: %q -> %q\n", d.Field, d.A, d.B)
+		}
+		if len(diffs) > 0 {
+			return 1
+		}
+		return 0
+	case "export-staking":
+		var height uint64
+		switch len(args) {
+		case 1:
+		case 2:
+			h, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "invalid height %s\n", args[1])
+				return 2
+			}
+			height = h
+		default:
+			fmt.Fprint(os.Stderr, _genesisUsage)
+			return 2
+		}
+		out, err := exportStaking(height)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		fmt.Print(out)
+		return 0
+	default:
+		fmt.Fprint(os.Stderr, _genesisUsage)
+		return 2
+	}
+}
+
+// exportStaking exports the candidates and buckets at the committed height, the tip height if 0, in the yaml of
+// the staking section of a genesis. A height below the tip requires the archive of the states
+func exportStaking(height uint64) (string, error) {
+	g, err := genesis.New(_genesisPath)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to load genesis %s", _genesisPath)
+	}
+	cfg, err := config.New([]string{_overwritePath, _secretPath}, _plugins)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to load config")
+	}
+	cfg.Genesis = g
+	genesis.SetGenesisTimestamp(g.Timestamp)
+	svr, err := itx.NewServer(cfg)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create server")
+	}
+	sf := svr.ChainService(cfg.Chain.ID).StateFactory()
+	ctx := protocol.WithFeatureWithHeightCtx(genesis.WithGenesisContext(
+		protocol.WithBlockchainCtx(context.Background(), protocol.BlockchainCtx{
+			ChainID:      cfg.Chain.ID,
+			EvmNetworkID: cfg.Chain.EVMNetworkID,
+		}),
+		g,
+	))
+	if err := sf.Start(ctx); err != nil {
+		return "", errors.Wrap(err, "failed to start state factory")
+	}
+	defer sf.Stop(ctx)
+	tip, err := sf.Height()
+	if err != nil {
+		return "", err
+	}
+	var sr protocol.StateReader = sf
+	switch {
+	case height == 0 || height == tip:
+	case height > tip:
+		return "", errors.Errorf("height %d is above the tip height %d", height, tip)
+	default:
+		if sr, err = sf.WorkingSetAtHeight(ctx, height); err != nil {
+			return "", errors.Wrapf(err, "failed to read the states at height %d", height)
+		}
+	}
+	var out stakingBootstrap
+	out.Staking.BootstrapCandidates, out.Staking.BootstrapBuckets, height, err = staking.ExportBootstrap(sr)
+	if err != nil {
+		return "", err
+	}
+	data, err := yaml.Marshal(&out)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("# the staking state exported at height %d\n%s", height, data), nil
+}
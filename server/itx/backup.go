@@ -0,0 +1,94 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/chainservice"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+type (
+	// BackupHandler handles the admin requests to take online backups of the dbs in background
+	BackupHandler struct {
+		ctx    context.Context
+		cs     *chainservice.ChainService
+		cfg    blockchain.Config
+		mutex  sync.Mutex
+		status backupStatus
+	}
+
+	backupStatus struct {
+		Dir     string `json:"dir"`
+		Height  uint64 `json:"height,omitempty"`
+		Root    string `json:"root,omitempty"`
+		Running bool   `json:"running"`
+		Error   string `json:"error,omitempty"`
+	}
+)
+
+// NewBackupHandler instantiates a BackupHandler instance, the backups are canceled once ctx is done
+func NewBackupHandler(ctx context.Context, cs *chainservice.ChainService, cfg blockchain.Config) *BackupHandler {
+	return &BackupHandler{
+		ctx: ctx,
+		cs:  cs,
+		cfg: cfg,
+	}
+}
+
+// Handle handles admin request, "name" starts taking the backup at the tip height into the directory of the
+// name under the backup dir, or resumes the backup interrupted before. Otherwise the status of the last backup
+// is returned
+func (h *BackupHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if name := r.URL.Query().Get("name"); name != "" {
+		if name != filepath.Base(name) || name == "." || name == ".." {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if h.status.Running {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		h.status = backupStatus{
+			Dir:     filepath.Join(h.cfg.BackupDir, name),
+			Running: true,
+		}
+		go h.backup(h.status.Dir)
+	}
+	data, err := json.Marshal(&h.status)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+func (h *BackupHandler) backup(dir string) {
+	log.L().Info("Start taking backup.", zap.String("dir", dir))
+	manifest, err := h.cs.Backup(h.ctx, dir)
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.status.Running = false
+	if err != nil {
+		log.L().Error("Failed to take backup.", zap.String("dir", dir), zap.Error(err))
+		h.status.Error = err.Error()
+		return
+	}
+	log.L().Info("Finish taking backup.", zap.Uint64("height", manifest.Height), zap.String("dir", dir))
+	h.status.Height = manifest.Height
+	h.status.Root = manifest.Root
+}
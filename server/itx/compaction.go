@@ -0,0 +1,115 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/db"
+)
+
+type (
+	// CompactionHandler handles the admin requests to run or cancel the db compactions
+	CompactionHandler struct {
+		scheduler *db.CompactionScheduler
+	}
+
+	compactionStatus struct {
+		Running   bool               `json:"running"`
+		Scheduled bool               `json:"scheduled,omitempty"`
+		Started   string             `json:"started,omitempty"`
+		Store     string             `json:"store,omitempty"`
+		Copied    int64              `json:"copied,omitempty"`
+		Results   []compactionResult `json:"results,omitempty"`
+	}
+
+	compactionResult struct {
+		Store     string `json:"store"`
+		Reclaimed int64  `json:"reclaimed"`
+		Duration  string `json:"duration"`
+		Error     string `json:"error,omitempty"`
+	}
+)
+
+// NewCompactionHandler instantiates a CompactionHandler instance
+func NewCompactionHandler(scheduler *db.CompactionScheduler) *CompactionHandler {
+	return &CompactionHandler{scheduler: scheduler}
+}
+
+// Handle handles admin request, "action=run" starts compacting the dbs in "stores" separated by commas, or all the
+// dbs if not given, with the writes into each db paused no longer than "maxPause", and "action=cancel" aborts the
+// run in progress. The status of the last run is returned
+func (h *CompactionHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if h.scheduler == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	query := r.URL.Query()
+	switch query.Get("action") {
+	case "":
+	case "run":
+		var (
+			stores   []string
+			maxPause time.Duration
+			err      error
+		)
+		if s := query.Get("stores"); s != "" {
+			stores = strings.Split(s, ",")
+		}
+		if s := query.Get("maxPause"); s != "" {
+			if maxPause, err = time.ParseDuration(s); err != nil || maxPause <= 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+		if err := h.scheduler.Run(stores, maxPause); err != nil {
+			if errors.Cause(err) == db.ErrCompactionRunning {
+				w.WriteHeader(http.StatusConflict)
+			} else {
+				w.WriteHeader(http.StatusBadRequest)
+			}
+			return
+		}
+	case "cancel":
+		h.scheduler.Cancel()
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	status := h.scheduler.Status()
+	s := compactionStatus{
+		Running:   status.Running,
+		Scheduled: status.Scheduled,
+		Store:     status.Store,
+		Copied:    status.Copied,
+	}
+	if !status.Started.IsZero() {
+		s.Started = status.Started.UTC().Format(time.RFC3339)
+	}
+	for _, result := range status.Results {
+		res := compactionResult{
+			Store:     result.Store,
+			Reclaimed: result.Reclaimed,
+			Duration:  result.Duration.String(),
+		}
+		if result.Err != nil {
+			res.Error = result.Err.Error()
+		}
+		s.Results = append(s.Results, res)
+	}
+	data, err := json.Marshal(&s)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/iotexproject/iotex-core/consensus/scheme/rolldpos"
+)
+
+type (
+	// DelegateMonitorHandler handles the admin requests of the stats of the monitored delegate
+	DelegateMonitorHandler struct {
+		monitor *rolldpos.DelegateMonitor
+	}
+
+	delegateMonitorStatus struct {
+		Delegate string                `json:"delegate"`
+		Epochs   []rolldpos.EpochStats `json:"epochs"`
+	}
+)
+
+// NewDelegateMonitorHandler instantiates a DelegateMonitorHandler instance
+func NewDelegateMonitorHandler(monitor *rolldpos.DelegateMonitor) *DelegateMonitorHandler {
+	return &DelegateMonitorHandler{monitor: monitor}
+}
+
+// Handle handles admin request, the blocks produced and endorsed, and the time slots and endorsements missed
+// by the monitored delegate in the recent epochs are returned
+func (h *DelegateMonitorHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if h.monitor == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	data, err := json.Marshal(&delegateMonitorStatus{
+		Delegate: h.monitor.Delegate(),
+		Epochs:   h.monitor.Stats(),
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/iotexproject/iotex-core/chainservice"
+)
+
+type (
+	// IndexerHandler handles the admin requests of the status of the block indexer
+	IndexerHandler struct {
+		cs *chainservice.ChainService
+	}
+
+	indexerStatus struct {
+		Reindexing    bool   `json:"reindexing"`
+		TargetHeight  uint64 `json:"targetHeight"`
+		CurrentHeight uint64 `json:"currentHeight"`
+		ETA           string `json:"eta,omitempty"`
+		Error         string `json:"error,omitempty"`
+	}
+)
+
+// NewIndexerHandler instantiates an IndexerHandler instance
+func NewIndexerHandler(cs *chainservice.ChainService) *IndexerHandler {
+	return &IndexerHandler{cs: cs}
+}
+
+// Handle handles admin request, the status of the block indexer is returned, including the progress of the
+// reindex if in progress
+func (h *IndexerHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	status, err := h.cs.IndexerStatus()
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	s := indexerStatus{
+		Reindexing:    status.Reindexing,
+		TargetHeight:  status.TargetHeight,
+		CurrentHeight: status.CurrentHeight,
+	}
+	if status.ETA > 0 {
+		s.ETA = status.ETA.String()
+	}
+	if status.Err != nil {
+		s.Error = status.Err.Error()
+	}
+	data, err := json.Marshal(&s)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
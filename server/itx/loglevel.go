@@ -0,0 +1,87 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// LogLevelHandler handles the admin requests of the log levels of the global logger and the subsystems
+type LogLevelHandler struct{}
+
+// NewLogLevelHandler instantiates a LogLevelHandler instance
+func NewLogLevelHandler() *LogLevelHandler {
+	return &LogLevelHandler{}
+}
+
+// Handle handles admin request, POST sets the "level" of the logger of the "name" (the global logger if empty),
+// which reverts after "minutes" if given, and DELETE drops the level set before. The current levels are returned
+func (h *LogLevelHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost, http.MethodPut:
+		var lvl zapcore.Level
+		if err := lvl.UnmarshalText([]byte(r.URL.Query().Get("level"))); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var d time.Duration
+		if m := r.URL.Query().Get("minutes"); m != "" {
+			minutes, err := strconv.ParseUint(m, 10, 32)
+			if err != nil {
+				http.Error(w, "invalid minutes", http.StatusBadRequest)
+				return
+			}
+			d = time.Duration(minutes) * time.Minute
+		}
+		if err := log.SetLevel(name, lvl, d); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		log.L().Info("Log level is set.", zap.String("name", name), zap.Stringer("level", lvl), zap.Duration("duration", d))
+	case http.MethodDelete:
+		if err := log.ResetLevel(name); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		log.L().Info("Log level is reset.", zap.String("name", name))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	data, err := json.Marshal(log.Levels())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// withAdminToken requires the bearer token on the requests to the admin handler if the token is set
+func withAdminToken(token string, h http.Handler) http.Handler {
+	if token == "" {
+		return h
+	}
+	expected := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+func TestLogLevelHandler(t *testing.T) {
+	r := require.New(t)
+	handler := withAdminToken("secret", http.HandlerFunc(NewLogLevelHandler().Handle))
+	do := func(method, query, token string) (int, []log.LevelStatus) {
+		req := httptest.NewRequest(method, "/loglevel?"+query, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		var levels []log.LevelStatus
+		if w.Code == http.StatusOK {
+			r.NoError(json.Unmarshal(w.Body.Bytes(), &levels))
+		}
+		return w.Code, levels
+	}
+	levelOf := func(levels []log.LevelStatus, name string) log.LevelStatus {
+		for _, s := range levels {
+			if s.Name == name {
+				return s
+			}
+		}
+		r.FailNow("missing logger", name)
+		return log.LevelStatus{}
+	}
+	defer func() {
+		r.NoError(log.ResetLevel("actpool"))
+	}()
+
+	code, _ := do(http.MethodGet, "", "")
+	r.Equal(http.StatusUnauthorized, code)
+	code, _ = do(http.MethodGet, "", "wrong")
+	r.Equal(http.StatusUnauthorized, code)
+	code, levels := do(http.MethodGet, "", "secret")
+	r.Equal(http.StatusOK, code)
+	r.False(levelOf(levels, "actpool").Overridden)
+
+	code, levels = do(http.MethodPost, "name=actpool&level=debug&minutes=10", "secret")
+	r.Equal(http.StatusOK, code)
+	s := levelOf(levels, "actpool")
+	r.Equal("debug", s.Level)
+	r.True(s.Overridden)
+	r.NotNil(s.RevertAt)
+	r.True(log.Logger("actpool").Core().Enabled(zapcore.DebugLevel))
+
+	code, _ = do(http.MethodPost, "name=actpool&level=verbose", "secret")
+	r.Equal(http.StatusBadRequest, code)
+	code, _ = do(http.MethodPost, "name=actpool&level=info&minutes=-1", "secret")
+	r.Equal(http.StatusBadRequest, code)
+	code, _ = do(http.MethodPost, "name=unknown&level=info", "secret")
+	r.Equal(http.StatusNotFound, code)
+
+	code, levels = do(http.MethodDelete, "name=actpool", "secret")
+	r.Equal(http.StatusOK, code)
+	r.False(levelOf(levels, "actpool").Overridden)
+	r.False(log.Logger("actpool").Core().Enabled(zapcore.DebugLevel))
+}
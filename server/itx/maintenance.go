@@ -0,0 +1,116 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/api"
+	"github.com/iotexproject/iotex-core/pkg/lifecycle"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+type (
+	// readinessSwitch turns the readiness of the node reported to the load balancers on and off
+	readinessSwitch interface {
+		TurnOn() error
+		TurnOff() error
+	}
+
+	// MaintenanceHandler handles the admin requests of the maintenance mode of the api
+	MaintenanceHandler struct {
+		m         *api.Maintenance
+		readiness readinessSwitch
+	}
+)
+
+// NewMaintenanceHandler instantiates a MaintenanceHandler instance
+func NewMaintenanceHandler(m *api.Maintenance, readiness readinessSwitch) *MaintenanceHandler {
+	return &MaintenanceHandler{m: m, readiness: readiness}
+}
+
+// Handle handles admin request, "action=enable" enables the maintenance mode, in which the heavy reads are rejected
+// with the "retryAfter" duration, and the streaming subscriptions are closed after the "grace" duration if given,
+// and "action=disable" disables it. The node is reported not ready in the maintenance mode, so the load balancers
+// drain it. The state of the maintenance mode is returned
+func (h *MaintenanceHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if h.m == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	switch r.URL.Query().Get("action") {
+	case "":
+	case "enable":
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		retryAfter, err := parseDurationParam(r, "retryAfter")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		grace, err := parseDurationParam(r, "grace")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := h.m.Enable(retryAfter, grace); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if err := h.readiness.TurnOff(); err != nil && !errors.Is(err, lifecycle.ErrWrongState) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		log.L().Info("Enabled the maintenance mode.", zap.Duration("retryAfter", retryAfter), zap.Duration("grace", grace))
+	case "disable":
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if err := h.m.Disable(); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if err := h.readiness.TurnOn(); err != nil && !errors.Is(err, lifecycle.ErrWrongState) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		log.L().Info("Disabled the maintenance mode.")
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	state := h.m.State()
+	data, err := json.Marshal(&state)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+func parseDurationParam(r *http.Request, name string) (time.Duration, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid %s", name)
+	}
+	if d < 0 {
+		return 0, errors.Errorf("negative %s", name)
+	}
+	return d, nil
+}
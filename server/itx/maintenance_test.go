@@ -0,0 +1,78 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/api"
+	"github.com/iotexproject/iotex-core/pkg/lifecycle"
+)
+
+func TestMaintenanceHandler(t *testing.T) {
+	r := require.New(t)
+	path := filepath.Join(t.TempDir(), "maintenance.json")
+	m, err := api.NewMaintenance(path)
+	r.NoError(err)
+	var readiness lifecycle.Readiness
+	r.NoError(readiness.TurnOn())
+
+	do := func(m *api.Maintenance, method, query string) (int, api.MaintenanceState) {
+		req := httptest.NewRequest(method, "/maintenance?"+query, nil)
+		w := httptest.NewRecorder()
+		NewMaintenanceHandler(m, &readiness).Handle(w, req)
+		var state api.MaintenanceState
+		if w.Code == http.StatusOK {
+			r.NoError(json.Unmarshal(w.Body.Bytes(), &state))
+		}
+		return w.Code, state
+	}
+
+	code, state := do(m, http.MethodGet, "")
+	r.Equal(http.StatusOK, code)
+	r.False(state.Enabled)
+
+	code, _ = do(m, http.MethodGet, "action=enable")
+	r.Equal(http.StatusMethodNotAllowed, code)
+	code, _ = do(m, http.MethodPost, "action=enable&retryAfter=abc")
+	r.Equal(http.StatusBadRequest, code)
+	code, _ = do(m, http.MethodPost, "action=enable&grace=-1s")
+	r.Equal(http.StatusBadRequest, code)
+	r.False(m.Enabled())
+	r.True(readiness.IsReady())
+
+	// the node is reported not ready in the maintenance mode
+	code, state = do(m, http.MethodPost, "action=enable&retryAfter=30s&grace=1m")
+	r.Equal(http.StatusOK, code)
+	r.True(state.Enabled)
+	r.Equal(30*time.Second, state.RetryAfter)
+	r.False(readiness.IsReady())
+	code, state = do(m, http.MethodPost, "action=enable&retryAfter=10s")
+	r.Equal(http.StatusOK, code)
+	r.Equal(10*time.Second, state.RetryAfter)
+	r.False(readiness.IsReady())
+
+	// the mode persists across a restart
+	m, err = api.NewMaintenance(path)
+	r.NoError(err)
+	r.True(m.Enabled())
+	code, state = do(m, http.MethodPost, "action=disable")
+	r.Equal(http.StatusOK, code)
+	r.False(state.Enabled)
+	r.True(readiness.IsReady())
+
+	code, _ = do(m, http.MethodPost, "action=restart")
+	r.Equal(http.StatusBadRequest, code)
+	code, _ = do(nil, http.MethodGet, "")
+	r.Equal(http.StatusNotFound, code)
+}
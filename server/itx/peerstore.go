@@ -0,0 +1,38 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/iotexproject/iotex-core/nodeinfo"
+)
+
+// PeerStoreHandler handles the admin requests of the peer store of the p2p agent
+type PeerStoreHandler struct {
+	nodeInfo *nodeinfo.InfoManager
+}
+
+// NewPeerStoreHandler instantiates a PeerStoreHandler instance
+func NewPeerStoreHandler(nodeInfo *nodeinfo.InfoManager) *PeerStoreHandler {
+	return &PeerStoreHandler{nodeInfo: nodeInfo}
+}
+
+// Handle handles admin request, the peers in the peer store are returned in the order of score
+func (h *PeerStoreHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if h.nodeInfo == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	data, err := json.Marshal(h.nodeInfo.KnownPeers())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/iotexproject/iotex-core/dispatcher"
 	"github.com/iotexproject/iotex-core/p2p"
 	"github.com/iotexproject/iotex-core/pkg/ha"
+	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/probe"
 	"github.com/iotexproject/iotex-core/pkg/routine"
@@ -186,6 +187,12 @@ func (s *Server) StopChainService(ctx context.Context, id uint32) error {
 	if !ok {
 		return errors.New("Chain ID does not match any existing chains")
 	}
+	// the api server is stopped first to stop accepting the actions sent to the chain
+	if as, ok := s.apiServers[id]; ok {
+		if err := as.Stop(ctx); err != nil {
+			return errors.Wrap(err, "error when stopping api server")
+		}
+	}
 	return c.Stop(ctx)
 }
 
@@ -231,8 +238,15 @@ func StartServer(ctx context.Context, svr *Server, probeSvr *probe.Server, cfg c
 			log.L().Panic("Failed to stop server.", zap.Error(err))
 		}
 	}()
-	if err := probeSvr.TurnOn(); err != nil {
-		log.L().Panic("Failed to turn on probe server.", zap.Error(err))
+	var maintenance *api.Maintenance
+	if as := svr.APIServer(cfg.Chain.ID); as != nil {
+		maintenance = as.Maintenance()
+	}
+	// the node stays not ready in the maintenance mode persisted before a restart
+	if !maintenance.Enabled() {
+		if err := probeSvr.TurnOn(); err != nil {
+			log.L().Panic("Failed to turn on probe server.", zap.Error(err))
+		}
 	}
 
 	if cfg.System.HeartbeatInterval > 0 {
@@ -253,6 +267,15 @@ func StartServer(ctx context.Context, svr *Server, probeSvr *probe.Server, cfg c
 		log.RegisterLevelConfigMux(mux)
 		haCtl := ha.New(svr.rootChainService.Consensus())
 		mux.Handle("/ha", http.HandlerFunc(haCtl.Handle))
+		mux.Handle("/snapshot", http.HandlerFunc(NewSnapshotHandler(ctx, svr.rootChainService, cfg.Chain).Handle))
+		mux.Handle("/backup", http.HandlerFunc(NewBackupHandler(ctx, svr.rootChainService, cfg.Chain).Handle))
+		mux.Handle("/indexer", http.HandlerFunc(NewIndexerHandler(svr.rootChainService).Handle))
+		mux.Handle("/compaction", http.HandlerFunc(NewCompactionHandler(svr.rootChainService.CompactionScheduler()).Handle))
+		mux.Handle("/loglevel", http.HandlerFunc(NewLogLevelHandler().Handle))
+		mux.Handle("/delegatemonitor", http.HandlerFunc(NewDelegateMonitorHandler(svr.rootChainService.DelegateMonitor()).Handle))
+		mux.Handle("/peerstore", http.HandlerFunc(NewPeerStoreHandler(svr.rootChainService.NodeInfoManager()).Handle))
+		mux.Handle("/signer", http.HandlerFunc(NewSignerHandler(svr.rootChainService.ProducerSigner()).Handle))
+		mux.Handle("/maintenance", http.HandlerFunc(NewMaintenanceHandler(maintenance, probeSvr).Handle))
 		mux.Handle("/debug/pprof/", http.HandlerFunc(pprof.Index))
 		mux.Handle("/debug/pprof/cmdline", http.HandlerFunc(pprof.Cmdline))
 		mux.Handle("/debug/pprof/profile", http.HandlerFunc(pprof.Profile))
@@ -260,7 +283,7 @@ func StartServer(ctx context.Context, svr *Server, probeSvr *probe.Server, cfg c
 		mux.Handle("/debug/pprof/trace", http.HandlerFunc(pprof.Trace))
 
 		port := fmt.Sprintf(":%d", cfg.System.HTTPAdminPort)
-		adminserv = httputil.NewServer(port, mux)
+		adminserv = httputil.NewServer(port, withAdminToken(cfg.System.HTTPAdminToken, mux))
 		defer func() {
 			if err := adminserv.Shutdown(ctx); err != nil {
 				log.L().Error("Error when serving metrics data.", zap.Error(err))
@@ -281,7 +304,8 @@ func StartServer(ctx context.Context, svr *Server, probeSvr *probe.Server, cfg c
 	}
 
 	<-ctx.Done()
-	if err := probeSvr.TurnOff(); err != nil {
+	// the probe is already turned off in the maintenance mode
+	if err := probeSvr.TurnOff(); err != nil && !errors.Is(err, lifecycle.ErrWrongState) {
 		log.L().Panic("Failed to turn off probe server.", zap.Error(err))
 	}
 }
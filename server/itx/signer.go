@@ -0,0 +1,95 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+
+	cp "github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/signer"
+)
+
+const _maxPassphraseSize = 1 << 10
+
+type (
+	// SignerHandler handles the admin requests to unlock or lock the keystore of the producer key
+	SignerHandler struct {
+		s cp.Signer
+	}
+
+	signerStatus struct {
+		PublicKey string `json:"publicKey"`
+		Address   string `json:"address"`
+		Locked    bool   `json:"locked"`
+	}
+)
+
+// NewSignerHandler instantiates a SignerHandler instance
+func NewSignerHandler(s cp.Signer) *SignerHandler {
+	return &SignerHandler{s: s}
+}
+
+// Handle handles admin request, "action=unlock" unlocks the keystore by the passphrase in the body of a POST
+// request, and "action=lock" drops the decrypted key. The status of the signer is returned
+func (h *SignerHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if h.s == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	ks, isKeystore := h.s.(*signer.KeystoreSigner)
+	switch r.URL.Query().Get("action") {
+	case "":
+	case "unlock":
+		if !isKeystore {
+			w.WriteHeader(http.StatusNotImplemented)
+			return
+		}
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		data, err := io.ReadAll(io.LimitReader(r.Body, _maxPassphraseSize))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if err := ks.Unlock(strings.TrimRight(string(data), "\r\n")); err != nil {
+			log.L().Warn("Failed to unlock the producer keystore.", zap.String("address", ks.PublicKey().Address().String()))
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		log.L().Info("Unlocked the producer keystore.", zap.String("address", ks.PublicKey().Address().String()))
+	case "lock":
+		if !isKeystore {
+			w.WriteHeader(http.StatusNotImplemented)
+			return
+		}
+		ks.Lock()
+		log.L().Info("Locked the producer keystore.", zap.String("address", ks.PublicKey().Address().String()))
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	pk := h.s.PublicKey()
+	s := signerStatus{
+		PublicKey: pk.HexString(),
+		Address:   pk.Address().String(),
+		Locked:    isKeystore && ks.Locked(),
+	}
+	data, err := json.Marshal(&s)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
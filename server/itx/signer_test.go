@@ -0,0 +1,73 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	cp "github.com/iotexproject/iotex-core/crypto"
+	"github.com/iotexproject/iotex-core/pkg/signer"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestSignerHandler(t *testing.T) {
+	r := require.New(t)
+	sk := identityset.PrivateKey(1)
+	ecdsa, err := ethcrypto.ToECDSA(sk.Bytes())
+	r.NoError(err)
+	account, err := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP).ImportECDSA(ecdsa, "pass")
+	r.NoError(err)
+	ks, err := signer.NewKeystoreSigner(account.URL.Path, sk.PublicKey())
+	r.NoError(err)
+
+	do := func(s cp.Signer, method, query, body string) (int, signerStatus) {
+		req := httptest.NewRequest(method, "/signer?"+query, strings.NewReader(body))
+		w := httptest.NewRecorder()
+		NewSignerHandler(s).Handle(w, req)
+		var status signerStatus
+		if w.Code == http.StatusOK {
+			r.NoError(json.Unmarshal(w.Body.Bytes(), &status))
+		}
+		return w.Code, status
+	}
+
+	code, status := do(ks, http.MethodGet, "", "")
+	r.Equal(http.StatusOK, code)
+	r.Equal(sk.PublicKey().HexString(), status.PublicKey)
+	r.Equal(sk.PublicKey().Address().String(), status.Address)
+	r.True(status.Locked)
+
+	code, _ = do(ks, http.MethodGet, "action=unlock", "pass")
+	r.Equal(http.StatusMethodNotAllowed, code)
+	code, _ = do(ks, http.MethodPost, "action=unlock", "wrong")
+	r.Equal(http.StatusForbidden, code)
+	r.True(ks.Locked())
+	code, status = do(ks, http.MethodPost, "action=unlock", "pass\n")
+	r.Equal(http.StatusOK, code)
+	r.False(status.Locked)
+	code, status = do(ks, http.MethodPost, "action=lock", "")
+	r.Equal(http.StatusOK, code)
+	r.True(status.Locked)
+	code, _ = do(ks, http.MethodPost, "action=reset", "")
+	r.Equal(http.StatusBadRequest, code)
+
+	// the private key in the config can be neither unlocked nor locked
+	code, status = do(sk, http.MethodGet, "", "")
+	r.Equal(http.StatusOK, code)
+	r.False(status.Locked)
+	code, _ = do(sk, http.MethodPost, "action=unlock", "pass")
+	r.Equal(http.StatusNotImplemented, code)
+	code, _ = do(nil, http.MethodGet, "", "")
+	r.Equal(http.StatusNotFound, code)
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/chainservice"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/state/factory"
+)
+
+type (
+	// SnapshotHandler handles the admin requests to export state snapshots in background
+	SnapshotHandler struct {
+		ctx    context.Context
+		cs     *chainservice.ChainService
+		cfg    blockchain.Config
+		mutex  sync.Mutex
+		status snapshotStatus
+	}
+
+	snapshotStatus struct {
+		Dir     string `json:"dir"`
+		Height  uint64 `json:"height,omitempty"`
+		Running bool   `json:"running"`
+		Error   string `json:"error,omitempty"`
+	}
+)
+
+// NewSnapshotHandler instantiates a SnapshotHandler instance, the exports are canceled once ctx is done
+func NewSnapshotHandler(ctx context.Context, cs *chainservice.ChainService, cfg blockchain.Config) *SnapshotHandler {
+	return &SnapshotHandler{
+		ctx: ctx,
+		cs:  cs,
+		cfg: cfg,
+	}
+}
+
+// Handle handles admin request, "name" starts exporting the snapshot at the tip height into the directory
+// of the name under the snapshot dir, or resumes the export interrupted before. Otherwise the status of the
+// last export is returned
+func (h *SnapshotHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if name := r.URL.Query().Get("name"); name != "" {
+		if name != filepath.Base(name) || name == "." || name == ".." {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if h.status.Running {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		h.status = snapshotStatus{
+			Dir:     filepath.Join(h.cfg.SnapshotDir, name),
+			Running: true,
+		}
+		go h.export(h.status.Dir)
+	}
+	data, err := json.Marshal(&h.status)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+func (h *SnapshotHandler) export(dir string) {
+	log.L().Info("Start exporting snapshot.", zap.String("dir", dir))
+	height, err := h.exportSnapshot(dir)
+	if err != nil {
+		log.L().Error("Failed to export snapshot.", zap.String("dir", dir), zap.Error(err))
+	} else {
+		log.L().Info("Finish exporting snapshot.", zap.Uint64("height", height), zap.String("dir", dir))
+	}
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.status.Running = false
+	h.status.Height = height
+	if err != nil {
+		h.status.Error = err.Error()
+	}
+}
+
+func (h *SnapshotHandler) exportSnapshot(dir string) (uint64, error) {
+	exporter, ok := h.cs.StateFactory().(factory.SnapshotExporter)
+	if !ok {
+		return 0, errors.New("state factory doesn't support exporting snapshot")
+	}
+	dao := h.cs.BlockDAO()
+	manifest, err := exporter.ExportSnapshot(h.ctx, dir, dao.GetBlockHash, factory.SnapshotRateLimitOption(h.cfg.SnapshotRateLimit))
+	if err != nil {
+		return 0, err
+	}
+	blk, err := dao.GetBlockByHeight(manifest.Height)
+	if err != nil {
+		return 0, err
+	}
+	receipts, err := dao.GetReceipts(manifest.Height)
+	if err != nil {
+		return 0, err
+	}
+	return manifest.Height, factory.WriteSnapshotBlock(dir, &block.Store{Block: blk, Receipts: receipts})
+}
@@ -6,6 +6,8 @@
 // Usage:
 //   make build
 //   ./bin/server -config-file=./config.yaml
+//   ./bin/server -genesis-path=./genesis.yaml genesis validate
+//   ./bin/server genesis diff ./genesis.yaml ./genesis-new.yaml
 //
 
 package main
@@ -75,6 +77,9 @@ func init() {
 }
 
 func main() {
+	if flag.NArg() > 0 && flag.Arg(0) == "genesis" {
+		os.Exit(genesisCommand(flag.Args()[1:]))
+	}
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt)
 	signal.Notify(stop, syscall.SIGTERM)
@@ -86,6 +91,9 @@ func main() {
 	if err != nil {
 		glog.Fatalln("Failed to new genesis config.", zap.Error(err))
 	}
+	if err := genesis.ValidateGenesis(&genesisCfg); err != nil {
+		glog.Fatalln("Invalid genesis config.", zap.Error(err))
+	}
 	// set genesis timestamp
 	genesis.SetGenesisTimestamp(genesisCfg.Timestamp)
 	if genesis.Timestamp() == 0 {
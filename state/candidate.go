@@ -128,7 +128,7 @@ func (l *CandidateList) Deserialize(buf []byte) error {
 // LoadProto loads candidate list from proto
 func (l *CandidateList) LoadProto(candList *iotextypes.CandidateList) error {
 	candidates := make(CandidateList, 0)
-	candidatesPb := candList.Candidates
+	candidatesPb := candList.GetCandidates()
 	for _, candPb := range candidatesPb {
 		cand, err := pbToCandidate(candPb)
 		if err != nil {
@@ -31,6 +31,7 @@ import (
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/db/batch"
 	"github.com/iotexproject/iotex-core/db/trie"
+	"github.com/iotexproject/iotex-core/db/trie/mptrie"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/prometheustimer"
@@ -50,6 +51,12 @@ const (
 	ArchiveTrieNamespace = "AccountTrie"
 	// ArchiveTrieRootKey indicates the key of accountTrie root hash in underlying DB
 	ArchiveTrieRootKey = "archiveTrieRoot"
+	// ArchiveStaleHeightNamespace is the bucket journaling the trie nodes become stale at each height
+	ArchiveStaleHeightNamespace = "ArchiveStaleHeight"
+	// ArchiveStaleNodeNamespace is the bucket storing the height at which a trie node becomes stale
+	ArchiveStaleNodeNamespace = "ArchiveStaleNode"
+	// EarliestHistoryHeightKey indicates the key of the earliest height with history state in underlying DB
+	EarliestHistoryHeightKey = "earliestHistoryHeight"
 )
 
 var (
@@ -57,6 +64,8 @@ var (
 	ErrNotSupported = errors.New("not supported")
 	// ErrNoArchiveData is the error that the node have no archive data
 	ErrNoArchiveData = errors.New("no archive data")
+	// ErrOutOfRetentionRange is the error that the queried height has been pruned from history
+	ErrOutOfRetentionRange = errors.New("height is out of history state retention range")
 
 	_dbBatchSizelMtc = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -92,6 +101,23 @@ type (
 		DeleteTipBlock(context.Context, *block.Block) error
 		StateAtHeight(uint64, interface{}, ...protocol.StateOption) error
 		StatesAtHeight(uint64, ...protocol.StateOption) (state.Iterator, error)
+		// EarliestStateHeight returns the earliest height whose state can be queried
+		EarliestStateHeight() uint64
+		// WorkingSetAtHeight returns a read-only working set on top of the state at a queryable height
+		WorkingSetAtHeight(context.Context, uint64) (protocol.StateManager, error)
+		// StateReaderAtHeight returns a read-only state reader on top of the state at a queryable height, with the
+		// protocol views loaded from the states at the height
+		StateReaderAtHeight(context.Context, uint64) (protocol.StateReader, error)
+		// SimulationWorkingSet returns a working set on top of the state at tip height, or at a queryable height,
+		// to run simulations whose changes are discarded with the working set
+		SimulationWorkingSet(context.Context, uint64) (protocol.StateManager, error)
+		// ReplayBlock runs the first n actions of a block again on top of the state of its parent, in a working set
+		// discarded afterwards, where actionCtx returns the context to run each action with, e.g. to attach a tracer
+		ReplayBlock(ctx context.Context, blk *block.Block, n int, actionCtx func(context.Context, int) (context.Context, error)) ([]*action.Receipt, error)
+		// IterateAccounts iterates a page of the accounts at a queryable height, and returns the next page token
+		IterateAccounts(context.Context, uint64, []byte, []byte, uint64, func(address.Address, *state.Account) error) ([]byte, error)
+		// IterateContractStorage iterates a page of the contract storage at a queryable height, and returns the next page token
+		IterateContractStorage(context.Context, uint64, address.Address, []byte, uint64, func([]byte, []byte) error) ([]byte, error)
 	}
 
 	// factory implements StateFactory interface, tracks changes to account/contract and batch-commits to DB
@@ -102,7 +128,12 @@ type (
 		registry                 *protocol.Registry
 		currentChainHeight       uint64
 		saveHistory              bool
+		historyRetention         uint64 // number of heights with history state retained, 0 means keeping all
+		earliestHistoryHeight    uint64
+		pruneCh                  chan struct{}
+		pruneDone                chan struct{}
 		twoLayerTrie             trie.TwoLayerTrie // global state trie, this is a read only trie
+		nodeCache                *mptrie.NodeCache // cache of decoded trie nodes shared by all tries, nil if disabled
 		dao                      db.KVStore        // the underlying DB for account/contract storage
 		timerFactory             *prometheustimer.TimerFactory
 		workingsets              cache.LRUCache // lru cache for workingsets
@@ -159,12 +190,18 @@ func NewFactory(cfg Config, dao db.KVStore, opts ...Option) (Factory, error) {
 		cfg:                cfg,
 		currentChainHeight: 0,
 		registry:           protocol.NewRegistry(),
-		saveHistory:        cfg.Chain.EnableArchiveMode,
+		saveHistory:        cfg.Chain.EnableArchiveMode || cfg.Chain.EnableHistoryStateRetention,
 		protocolView:       protocol.View{},
 		workingsets:        cache.NewThreadSafeLruCache(int(cfg.Chain.WorkingSetCacheSize)),
 		dao:                dao,
 	}
 
+	if cfg.Chain.TrieNodeCacheSize > 0 {
+		sf.nodeCache = mptrie.NewNodeCache(cfg.Chain.TrieNodeCacheSize)
+	}
+	if cfg.Chain.EnableHistoryStateRetention && !cfg.Chain.EnableArchiveMode {
+		sf.historyRetention = cfg.Chain.HistoryStateRetention
+	}
 	for _, opt := range opts {
 		if err := opt(sf, &cfg); err != nil {
 			log.S().Errorf("Failed to execute state factory creation option %p: %v", opt, err)
@@ -178,7 +215,7 @@ func NewFactory(cfg Config, dao db.KVStore, opts ...Option) (Factory, error) {
 		[]string{"default", strconv.FormatUint(uint64(cfg.Chain.ID), 10)},
 	)
 	if err != nil {
-		log.L().Error("Failed to generate prometheus timer factory.", zap.Error(err))
+		log.Logger("statefactory").Error("Failed to generate prometheus timer factory.", zap.Error(err))
 	}
 	sf.timerFactory = timerFactory
 
@@ -191,7 +228,7 @@ func (sf *factory) Start(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	if sf.twoLayerTrie, err = newTwoLayerTrie(ArchiveTrieNamespace, sf.dao, ArchiveTrieRootKey, true); err != nil {
+	if sf.twoLayerTrie, err = newTwoLayerTrie(ArchiveTrieNamespace, sf.dao, ArchiveTrieRootKey, true, sf.trieOptions()...); err != nil {
 		return errors.Wrap(err, "failed to generate accountTrie from config")
 	}
 	if err := sf.twoLayerTrie.Start(ctx); err != nil {
@@ -207,6 +244,9 @@ func (sf *factory) Start(ctx context.Context) error {
 		if sf.protocolView, err = sf.registry.StartAll(ctx, sf); err != nil {
 			return err
 		}
+		if err = verifyProtocolViewOnStart(ctx, sf.cfg.Chain, sf.registry, sf, sf.protocolView); err != nil {
+			return err
+		}
 	case db.ErrNotExist:
 		if err = sf.dao.Put(AccountKVNamespace, []byte(CurrentHeightKey), byteutil.Uint64ToBytes(0)); err != nil {
 			return errors.Wrap(err, "failed to init factory's height")
@@ -231,10 +271,19 @@ func (sf *factory) Start(ctx context.Context) error {
 	default:
 		return err
 	}
+	if err := sf.loadEarliestHistoryHeight(); err != nil {
+		return err
+	}
+	if sf.historyRetention > 0 {
+		sf.startPruner()
+	}
 	return sf.lifecycle.OnStart(ctx)
 }
 
 func (sf *factory) Stop(ctx context.Context) error {
+	if sf.historyRetention > 0 {
+		sf.stopPruner()
+	}
 	sf.mutex.Lock()
 	defer sf.mutex.Unlock()
 	if err := sf.dao.Stop(ctx); err != nil {
@@ -261,15 +310,21 @@ func (sf *factory) newWorkingSet(ctx context.Context, height uint64) (*workingSe
 	defer span.End()
 
 	g := genesis.MustExtractGenesisContext(ctx)
+	buffer := batch.NewCachedBatch()
 	flusher, err := db.NewKVStoreFlusher(
 		sf.dao,
-		batch.NewCachedBatch(),
+		buffer,
 		sf.flusherOptions(!g.IsEaster(height))...,
 	)
 	if err != nil {
 		return nil, err
 	}
-	store, err := newFactoryWorkingSetStore(sf.protocolView, flusher)
+	var store workingSetStore
+	if sf.historyRetention > 0 {
+		store, err = newRetainingFactoryWorkingSetStore(sf.protocolView, flusher, buffer, sf.trieOptions()...)
+	} else {
+		store, err = newFactoryWorkingSetStore(sf.protocolView, flusher, sf.trieOptions()...)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -288,13 +343,24 @@ func (sf *factory) newWorkingSet(ctx context.Context, height uint64) (*workingSe
 		}
 	}
 
-	return newWorkingSet(height, store), nil
+	ws := newWorkingSet(height, store)
+	ws.parallel = sf.cfg.Chain.EnableParallelExecution
+	return ws, nil
+}
+
+// trieOptions returns the options of the tries sharing a write buffer, each call starts a new node cache session
+func (sf *factory) trieOptions() []mptrie.Option {
+	if sf.nodeCache == nil {
+		return nil
+	}
+	return []mptrie.Option{mptrie.NodeCacheOption(sf.nodeCache)}
 }
 
 func (sf *factory) flusherOptions(preEaster bool) []db.KVStoreFlusherOption {
 	opts := []db.KVStoreFlusherOption{
 		db.SerializeFilterOption(func(wi *batch.WriteInfo) bool {
-			if wi.Namespace() == ArchiveTrieNamespace {
+			switch wi.Namespace() {
+			case ArchiveTrieNamespace, ArchiveStaleHeightNamespace, ArchiveStaleNodeNamespace:
 				return true
 			}
 			if wi.Namespace() != evm.CodeKVNameSpace && wi.Namespace() != staking.CandsMapNS {
@@ -391,6 +457,7 @@ func (sf *factory) SimulateExecution(
 ) ([]byte, *action.Receipt, error) {
 	ctx, span := tracer.NewSpan(ctx, "factory.SimulateExecution")
 	defer span.End()
+	ctx = protocol.WithRegistry(ctx, sf.registry)
 
 	sf.mutex.Lock()
 	ws, err := sf.newWorkingSet(ctx, sf.currentChainHeight+1)
@@ -402,6 +469,18 @@ func (sf *factory) SimulateExecution(
 	return evm.SimulateExecution(ctx, ws, caller, ex)
 }
 
+// SimulationWorkingSet returns a working set on top of the state at height to run simulations
+func (sf *factory) SimulationWorkingSet(ctx context.Context, height uint64) (protocol.StateManager, error) {
+	sf.mutex.Lock()
+	if height == sf.currentChainHeight {
+		ws, err := sf.newWorkingSet(ctx, height+1)
+		sf.mutex.Unlock()
+		return ws, err
+	}
+	sf.mutex.Unlock()
+	return sf.WorkingSetAtHeight(ctx, height)
+}
+
 // ReadContractStorage reads contract's storage
 func (sf *factory) ReadContractStorage(ctx context.Context, contract address.Address, key []byte) ([]byte, error) {
 	sf.mutex.Lock()
@@ -419,6 +498,9 @@ func (sf *factory) PutBlock(ctx context.Context, blk *block.Block) error {
 	timer := sf.timerFactory.NewTimer("Commit")
 	sf.mutex.Unlock()
 	defer timer.End()
+	if err := sf.checkFinalized(blk); err != nil {
+		return err
+	}
 	producer := blk.PublicKey().Address()
 	if producer == nil {
 		return errors.New("failed to get address")
@@ -427,13 +509,18 @@ func (sf *factory) PutBlock(ctx context.Context, blk *block.Block) error {
 	ctx = protocol.WithBlockCtx(
 		protocol.WithRegistry(ctx, sf.registry),
 		protocol.BlockCtx{
-			BlockHeight:    blk.Height(),
-			BlockTimeStamp: blk.Timestamp(),
-			GasLimit:       g.BlockGasLimitByHeight(blk.Height()),
-			Producer:       producer,
+			BlockHeight:     blk.Height(),
+			BlockTimeStamp:  blk.Timestamp(),
+			GasLimit:        g.BlockGasLimitByHeight(blk.Height()),
+			Producer:        producer,
+			RandomnessProof: blk.RandomnessProof(),
 		},
 	)
 	ctx = protocol.WithFeatureCtx(ctx)
+	if sf.cfg.Chain.VerifyProtocolViewAtEpoch {
+		// verified once the block is committed and the lock is released
+		defer verifyProtocolViewAtEpoch(ctx, sf.cfg.Chain, sf.registry, sf, sf.protocolView)
+	}
 	key := generateWorkingSetCacheKey(blk.Header, blk.Header.ProducerAddress())
 	ws, isExist, err := sf.getFromWorkingSets(ctx, key)
 	if err != nil {
@@ -447,7 +534,7 @@ func (sf *factory) PutBlock(ctx context.Context, blk *block.Block) error {
 			err = ws.Process(ctx, blk.RunnableActions().Actions())
 		}
 		if err != nil {
-			log.L().Error("Failed to update state.", zap.Error(err))
+			log.Logger("statefactory").Error("Failed to update state.", zap.Error(err))
 			return err
 		}
 	}
@@ -470,6 +557,8 @@ func (sf *factory) PutBlock(ctx context.Context, blk *block.Block) error {
 	if err := ws.Commit(ctx); err != nil {
 		return err
 	}
+	// the cached workingsets are all on top of the previous height, which cannot be committed anymore
+	sf.workingsets.Clear()
 	rh, err := sf.dao.Get(ArchiveTrieNamespace, []byte(ArchiveTrieRootKey))
 	if err != nil {
 		return err
@@ -478,10 +567,25 @@ func (sf *factory) PutBlock(ctx context.Context, blk *block.Block) error {
 		return err
 	}
 	sf.currentChainHeight = h
+	if sf.historyRetention > 0 {
+		sf.notifyPruner()
+	}
 
 	return nil
 }
 
+// checkFinalized refuses the block at or below the current height, whose states are final
+func (sf *factory) checkFinalized(blk *block.Block) error {
+	sf.mutex.RLock()
+	height := sf.currentChainHeight
+	sf.mutex.RUnlock()
+	if blk.Height() > height {
+		return nil
+	}
+	log.Logger("statefactory").Error("Refused to overwrite the states of a finalized block.", zap.Uint64("height", blk.Height()), zap.Uint64("currentHeight", height))
+	return errors.Wrapf(block.ErrFinalizedHeight, "block %d is at or below the current height %d", blk.Height(), height)
+}
+
 func (sf *factory) DeleteTipBlock(_ context.Context, _ *block.Block) error {
 	return errors.Wrap(ErrNotSupported, "cannot delete tip block from factory")
 }
@@ -500,6 +604,9 @@ func (sf *factory) StateAtHeight(height uint64, s interface{}, opts ...protocol.
 	if height > sf.currentChainHeight {
 		return errors.Errorf("query height %d is higher than tip height %d", height, sf.currentChainHeight)
 	}
+	if err := sf.checkHistoryHeight(height); err != nil {
+		return err
+	}
 	return sf.stateAtHeight(height, cfg.Namespace, cfg.Key, s)
 }
 
@@ -517,7 +624,10 @@ func (sf *factory) StatesAtHeight(height uint64, opts ...protocol.StateOption) (
 	if cfg.Keys != nil {
 		return nil, errors.Wrap(ErrNotSupported, "Read states with keys option has not been implemented yet")
 	}
-	tlt, err := newTwoLayerTrie(ArchiveTrieNamespace, sf.dao, fmt.Sprintf("%s-%d", ArchiveTrieRootKey, height), false)
+	if err := sf.checkHistoryHeight(height); err != nil {
+		return nil, err
+	}
+	tlt, err := newTwoLayerTrie(ArchiveTrieNamespace, sf.dao, fmt.Sprintf("%s-%d", ArchiveTrieRootKey, height), false, sf.trieOptions()...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to generate trie for %d", height)
 	}
@@ -607,7 +717,7 @@ func (sf *factory) stateAtHeight(height uint64, ns string, key []byte, s interfa
 	if !sf.saveHistory {
 		return ErrNoArchiveData
 	}
-	tlt, err := newTwoLayerTrie(ArchiveTrieNamespace, sf.dao, fmt.Sprintf("%s-%d", ArchiveTrieRootKey, height), false)
+	tlt, err := newTwoLayerTrie(ArchiveTrieNamespace, sf.dao, fmt.Sprintf("%s-%d", ArchiveTrieRootKey, height), false, sf.trieOptions()...)
 	if err != nil {
 		return errors.Wrapf(err, "failed to generate trie for %d", height)
 	}
@@ -641,11 +751,15 @@ func (sf *factory) getFromWorkingSets(ctx context.Context, key hash.Hash256) (*w
 	sf.mutex.RLock()
 	defer sf.mutex.RUnlock()
 	if data, ok := sf.workingsets.Get(key); ok {
-		if ws, ok := data.(*workingSet); ok {
-			// if it is already validated, return workingset
+		ws, ok := data.(*workingSet)
+		if !ok {
+			return nil, false, errors.New("type assertion failed to be WorkingSet")
+		}
+		// if it is already validated on top of the current height, return workingset
+		if ws.height == sf.currentChainHeight+1 {
 			return ws, true, nil
 		}
-		return nil, false, errors.New("type assertion failed to be WorkingSet")
+		sf.workingsets.Remove(key)
 	}
 	ws, err := sf.newWorkingSet(ctx, sf.currentChainHeight+1)
 	if err != nil {
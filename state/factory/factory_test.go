@@ -20,11 +20,13 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
+	"github.com/iotexproject/go-pkgs/cache"
 	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-election/test/mock/mock_committee"
 	"github.com/iotexproject/iotex-election/types"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
@@ -35,11 +37,13 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
 	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/action/protocol/vote/candidatesutil"
+	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/pkg/enc"
+	"github.com/iotexproject/iotex-core/pkg/unit"
 	"github.com/iotexproject/iotex-core/pkg/util/fileutil"
 	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/test/identityset"
@@ -405,6 +409,95 @@ func TestHistoryState(t *testing.T) {
 	}()
 }
 
+func TestHistoryStateRetention(t *testing.T) {
+	r := require.New(t)
+	var err error
+	cfg := DefaultConfig
+	cfg.Chain.TrieDBPath, err = testutil.PathOfTempFile(_triePath)
+	r.NoError(err)
+	defer testutil.CleanupPath(cfg.Chain.TrieDBPath)
+	cfg.Chain.EnableHistoryStateRetention = true
+	cfg.Chain.HistoryStateRetention = 2
+	db1, err := db.CreateKVStore(db.DefaultConfig, cfg.Chain.TrieDBPath)
+	r.NoError(err)
+	sf, err := NewFactory(cfg, db1, SkipBlockValidationOption())
+	r.NoError(err)
+
+	a := identityset.Address(28)
+	b := identityset.Address(31)
+	priKeyA := identityset.PrivateKey(28)
+	r.NoError(sf.Register(account.NewProtocol(rewarding.DepositGas)))
+	ge := genesis.Default
+	ge.InitBalanceMap[a.String()] = "100"
+	gasLimit := uint64(1000000)
+	ctx := protocol.WithBlockCtx(context.Background(), protocol.BlockCtx{
+		BlockHeight: 0,
+		Producer:    identityset.Address(27),
+		GasLimit:    gasLimit,
+	})
+	ctx = genesis.WithGenesisContext(ctx, ge)
+	ctx = protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{ChainID: 1})
+	r.NoError(sf.Start(ctx))
+	r.Zero(sf.EarliestStateHeight())
+
+	prevHash := hash.ZeroHash256
+	for i := uint64(1); i <= 4; i++ {
+		tsf, err := action.NewTransfer(i, big.NewInt(10), b.String(), nil, uint64(20000), big.NewInt(0))
+		r.NoError(err)
+		elp := (&action.EnvelopeBuilder{}).SetAction(tsf).SetGasLimit(20000).SetNonce(i).Build()
+		selp, err := action.Sign(elp, priKeyA)
+		r.NoError(err)
+		blk, err := block.NewTestingBuilder().
+			SetHeight(i).
+			SetPrevBlockHash(prevHash).
+			SetTimeStamp(testutil.TimestampNow()).
+			AddActions(selp).
+			SignAndBuild(identityset.PrivateKey(27))
+		r.NoError(err)
+		r.NoError(sf.PutBlock(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight: i,
+			Producer:    identityset.Address(27),
+			GasLimit:    gasLimit,
+		}), &blk))
+		prevHash = blk.HashBlock()
+	}
+	// only the states of the most recent 2 heights are retained
+	r.Eventually(func() bool { return sf.EarliestStateHeight() == 3 }, 5*time.Second, 10*time.Millisecond)
+	_, err = accountutil.AccountState(ctx, NewHistoryStateReader(sf, 2), a)
+	r.Equal(ErrOutOfRetentionRange, errors.Cause(err))
+	_, err = sf.WorkingSetAtHeight(ctx, 2)
+	r.Equal(ErrOutOfRetentionRange, errors.Cause(err))
+	for _, h := range []uint64{3, 4} {
+		accountA, err := accountutil.AccountState(ctx, NewHistoryStateReader(sf, h), a)
+		r.NoError(err)
+		r.Equal(big.NewInt(100-10*int64(h)), accountA.Balance)
+		ws, err := sf.WorkingSetAtHeight(ctx, h)
+		r.NoError(err)
+		accountB, err := accountutil.AccountState(ctx, ws, b)
+		r.NoError(err)
+		r.Equal(big.NewInt(10*int64(h)), accountB.Balance)
+	}
+	accountA, err := accountutil.AccountState(ctx, sf, a)
+	r.NoError(err)
+	r.Equal(big.NewInt(60), accountA.Balance)
+	r.NoError(sf.Stop(ctx))
+
+	// the earliest height is persisted across restart
+	db1, err = db.CreateKVStore(db.DefaultConfig, cfg.Chain.TrieDBPath)
+	r.NoError(err)
+	sf, err = NewFactory(cfg, db1, SkipBlockValidationOption())
+	r.NoError(err)
+	r.NoError(sf.Register(account.NewProtocol(rewarding.DepositGas)))
+	r.NoError(sf.Start(ctx))
+	defer func() {
+		r.NoError(sf.Stop(ctx))
+	}()
+	r.Equal(uint64(3), sf.EarliestStateHeight())
+	accountB, err := accountutil.AccountState(ctx, NewHistoryStateReader(sf, 3), b)
+	r.NoError(err)
+	r.Equal(big.NewInt(30), accountB.Balance)
+}
+
 func TestFactoryStates(t *testing.T) {
 	r := require.New(t)
 	var err error
@@ -927,6 +1020,19 @@ func testLoadStoreHeight(sf Factory, t *testing.T) {
 		require.NoError(err)
 		require.Equal(uint64(i), height)
 	}
+	// the states of the committed blocks are final, and can't be overwritten
+	for _, i := range []uint64{10, 5} {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(i).
+			SetPrevBlockHash(lastBlockHash).
+			SetTimeStamp(testutil.TimestampNow()).
+			SignAndBuild(identityset.PrivateKey(28))
+		require.NoError(err)
+		require.ErrorIs(sf.PutBlock(ctx, &blk), block.ErrFinalizedHeight)
+	}
+	height, err = sf.Height()
+	require.NoError(err)
+	require.Equal(uint64(10), height)
 }
 
 func TestRunActions(t *testing.T) {
@@ -1099,6 +1205,239 @@ func TestSTXPickAndRunActions(t *testing.T) {
 	testNewBlockBuilder(sdb, t)
 }
 
+func tolerantNoncePolicy(tb testing.TB) actpool.NoncePolicy {
+	p, err := actpool.NewNoncePolicy(actpool.NoncePolicyTolerant, actpool.DefaultConfig.MaxNumActsPerAcct)
+	require.NoError(tb, err)
+	return p
+}
+
+func TestPickAndRunActionsBySelectionPolicy(t *testing.T) {
+	require := require.New(t)
+	a := identityset.Address(28).String()
+	b := identityset.Address(29).String()
+	transfer := func(sk crypto.PrivateKey, nonce, gasLimit uint64, recipient string) *action.SealedEnvelope {
+		tx, err := action.NewTransfer(nonce, big.NewInt(10), recipient, nil, gasLimit, big.NewInt(0))
+		require.NoError(err)
+		elp := (&action.EnvelopeBuilder{}).SetNonce(nonce).SetGasLimit(gasLimit).SetAction(tx).Build()
+		selp, err := action.Sign(elp, sk)
+		require.NoError(err)
+		return selp
+	}
+	for _, tc := range []struct {
+		name     string
+		policy   actpool.ActionSelectionPolicy
+		gasLimit uint64
+		expected map[string][]uint64
+	}{
+		// the 2nd action of a exceeds the remaining gas, so its 3rd action is skipped even though it fits
+		{"budget exhausted mid-sender", nil, 45000, map[string][]uint64{a: {1}, b: {1}}},
+		{"fair", actpool.NewFairSelectionPolicy(1), 100000, map[string][]uint64{a: {1}, b: {1}}},
+		{"default", actpool.NewDefaultSelectionPolicy(), 100000, map[string][]uint64{a: {1, 2, 3}, b: {1}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			testTriePath, err := testutil.PathOfTempFile(_triePath)
+			require.NoError(err)
+			defer testutil.CleanupPath(testTriePath)
+			cfg := DefaultConfig
+			cfg.Genesis.InitBalanceMap[a] = "100"
+			cfg.Genesis.InitBalanceMap[b] = "200"
+			db1, err := db.CreateKVStore(db.DefaultConfig, testTriePath)
+			require.NoError(err)
+			registry := protocol.NewRegistry()
+			sf, err := NewFactory(cfg, db1, RegistryOption(registry))
+			require.NoError(err)
+			require.NoError(account.NewProtocol(rewarding.DepositGas).Register(registry))
+			ctx := protocol.WithBlockCtx(
+				genesis.WithGenesisContext(context.Background(), cfg.Genesis),
+				protocol.BlockCtx{},
+			)
+			require.NoError(sf.Start(ctx))
+			defer func() {
+				require.NoError(sf.Stop(ctx))
+			}()
+
+			accMap := map[string][]*action.SealedEnvelope{
+				a: {
+					transfer(identityset.PrivateKey(28), 1, 10000, b),
+					transfer(identityset.PrivateKey(28), 2, 40000, b),
+					transfer(identityset.PrivateKey(28), 3, 10000, b),
+				},
+				b: {transfer(identityset.PrivateKey(29), 1, 10000, a)},
+			}
+			ap := mock_actpool.NewMockActPool(gomock.NewController(t))
+			ap.EXPECT().PendingActionMap().Return(accMap).Times(1)
+			ap.EXPECT().NoncePolicy().Return(tolerantNoncePolicy(t)).Times(1)
+			ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+				BlockHeight: 1,
+				Producer:    identityset.Address(27),
+				GasLimit:    tc.gasLimit,
+			})
+			ctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{})))
+			if tc.policy != nil {
+				ctx = actpool.WithActionSelectionPolicy(ctx, tc.policy)
+			}
+			blkBuilder, err := sf.NewBlockBuilder(ctx, ap, nil)
+			require.NoError(err)
+			blk, err := blkBuilder.SignAndBuild(identityset.PrivateKey(27))
+			require.NoError(err)
+			nonces := make(map[string][]uint64)
+			for _, selp := range blk.Actions {
+				sender := selp.SenderAddress().String()
+				nonces[sender] = append(nonces[sender], selp.Nonce())
+			}
+			require.Equal(tc.expected, nonces)
+			require.NoError(sf.Validate(ctx, &blk))
+		})
+	}
+}
+
+func TestPickAndRunActionsWithLargeActions(t *testing.T) {
+	require := require.New(t)
+	a := identityset.Address(28).String()
+	b := identityset.Address(29).String()
+	testTriePath, err := testutil.PathOfTempFile(_triePath)
+	require.NoError(err)
+	defer testutil.CleanupPath(testTriePath)
+	cfg := DefaultConfig
+	cfg.Genesis.InitBalanceMap[a] = "100"
+	cfg.Genesis.InitBalanceMap[b] = "200"
+	db1, err := db.CreateKVStore(db.DefaultConfig, testTriePath)
+	require.NoError(err)
+	registry := protocol.NewRegistry()
+	sf, err := NewFactory(cfg, db1, RegistryOption(registry))
+	require.NoError(err)
+	require.NoError(account.NewProtocol(rewarding.DepositGas).Register(registry))
+	ctx := protocol.WithBlockCtx(
+		genesis.WithGenesisContext(context.Background(), cfg.Genesis),
+		protocol.BlockCtx{},
+	)
+	require.NoError(sf.Start(ctx))
+	defer func() {
+		require.NoError(sf.Stop(ctx))
+	}()
+
+	// fill the pool with large payloads
+	apCfg := actpool.DefaultConfig
+	apCfg.MinGasPriceStr = "0"
+	apCfg.LargeActionSize = 1024
+	apCfg.MaxLargeBytesPerPool = 4 * 2200
+	ap, err := actpool.NewActPool(cfg.Genesis, sf, apCfg)
+	require.NoError(err)
+	for i := uint64(1); i <= 5; i++ {
+		tsf, err := action.SignedTransfer(b, identityset.PrivateKey(28), i, big.NewInt(1), make([]byte, 2000), 300000, big.NewInt(0))
+		require.NoError(err)
+		if err := ap.Add(ctx, tsf); i <= 4 {
+			require.NoError(err)
+		} else {
+			require.ErrorIs(err, action.ErrTxPoolOverflow)
+		}
+	}
+	// the small transfers are still admitted
+	for i := uint64(1); i <= 2; i++ {
+		tsf, err := action.SignedTransfer(a, identityset.PrivateKey(29), i, big.NewInt(1), nil, 10000, big.NewInt(0))
+		require.NoError(err)
+		require.NoError(ap.Add(ctx, tsf))
+	}
+
+	// and mined along with the share of large actions
+	ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+		BlockHeight: 1,
+		Producer:    identityset.Address(27),
+		GasLimit:    cfg.Genesis.BlockGasLimit,
+	})
+	ctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{})))
+	ctx = actpool.WithActionSelectionPolicy(ctx, actpool.NewLargeActionCapPolicy(actpool.NewDefaultSelectionPolicy(), apCfg.LargeActionSize, 0.5))
+	blkBuilder, err := sf.NewBlockBuilder(ctx, ap, nil)
+	require.NoError(err)
+	blk, err := blkBuilder.SignAndBuild(identityset.PrivateKey(27))
+	require.NoError(err)
+	nonces := make(map[string][]uint64)
+	for _, selp := range blk.Actions {
+		sender := selp.SenderAddress().String()
+		nonces[sender] = append(nonces[sender], selp.Nonce())
+	}
+	require.Equal(map[string][]uint64{a: {1}, b: {1, 2}}, nonces)
+	require.NoError(sf.Validate(ctx, &blk))
+}
+
+func TestPickAndRunActionsWithFailedActions(t *testing.T) {
+	require := require.New(t)
+	a := identityset.Address(28).String()
+	b := identityset.Address(29).String()
+	transfer := func(sk crypto.PrivateKey, nonce uint64, amount *big.Int, recipient string) *action.SealedEnvelope {
+		selp, err := action.SignedTransfer(recipient, sk, nonce, amount, nil, 10000, big.NewInt(1))
+		require.NoError(err)
+		return selp
+	}
+	testTriePath, err := testutil.PathOfTempFile(_triePath)
+	require.NoError(err)
+	defer testutil.CleanupPath(testTriePath)
+	cfg := DefaultConfig
+	cfg.Genesis.ToBeEnabledBlockHeight = 1
+	cfg.Genesis.InitBalanceMap[a] = "100000"
+	cfg.Genesis.InitBalanceMap[b] = "5000"
+	db1, err := db.CreateKVStore(db.DefaultConfig, testTriePath)
+	require.NoError(err)
+	registry := protocol.NewRegistry()
+	sf, err := NewFactory(cfg, db1, RegistryOption(registry))
+	require.NoError(err)
+	require.NoError(account.NewProtocol(rewarding.DepositGas).Register(registry))
+	require.NoError(rewarding.NewProtocol(cfg.Genesis.Rewarding).Register(registry))
+	ctx := protocol.WithBlockCtx(
+		genesis.WithGenesisContext(context.Background(), cfg.Genesis),
+		protocol.BlockCtx{},
+	)
+	require.NoError(sf.Start(ctx))
+	defer func() {
+		require.NoError(sf.Stop(ctx))
+	}()
+
+	// the 2nd transfer of a can't afford the amount but the gas, while b can't afford the gas
+	accMap := map[string][]*action.SealedEnvelope{
+		a: {
+			transfer(identityset.PrivateKey(28), 1, big.NewInt(10), b),
+			transfer(identityset.PrivateKey(28), 2, big.NewInt(1000000), b),
+			transfer(identityset.PrivateKey(28), 3, big.NewInt(10), b),
+		},
+		b: {transfer(identityset.PrivateKey(29), 1, big.NewInt(10), a)},
+	}
+	ap := mock_actpool.NewMockActPool(gomock.NewController(t))
+	ap.EXPECT().PendingActionMap().Return(accMap).Times(1)
+	ap.EXPECT().NoncePolicy().Return(tolerantNoncePolicy(t)).Times(1)
+	ap.EXPECT().DeleteAction(identityset.Address(29)).Times(1)
+	ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+		BlockHeight: 1,
+		Producer:    identityset.Address(27),
+		GasLimit:    cfg.Genesis.BlockGasLimit,
+	})
+	ctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{})))
+	blkBuilder, err := sf.NewBlockBuilder(ctx, ap, func(elp action.Envelope) (*action.SealedEnvelope, error) {
+		return action.Sign(elp, identityset.PrivateKey(27))
+	})
+	require.NoError(err)
+	blk, err := blkBuilder.SignAndBuild(identityset.PrivateKey(27))
+	require.NoError(err)
+	// followed by the grant reward action
+	require.Len(blk.Actions, 4)
+	for i, selp := range blk.Actions[:3] {
+		require.Equal(a, selp.SenderAddress().String())
+		require.Equal(uint64(i+1), selp.Nonce())
+	}
+	require.Len(blk.Receipts, 4)
+	require.EqualValues(iotextypes.ReceiptStatus_Success, blk.Receipts[0].Status)
+	require.EqualValues(iotextypes.ReceiptStatus_ErrNotEnoughBalance, blk.Receipts[1].Status)
+	require.EqualValues(10000, blk.Receipts[1].GasConsumed)
+	require.EqualValues(iotextypes.ReceiptStatus_Success, blk.Receipts[2].Status)
+	require.NoError(sf.Validate(ctx, &blk))
+
+	// the gas of the failed transfer is charged
+	require.NoError(sf.PutBlock(ctx, &blk))
+	acc, err := accountutil.AccountState(ctx, sf, identityset.Address(28))
+	require.NoError(err)
+	require.Equal("69980", acc.Balance.String())
+	require.EqualValues(4, acc.PendingNonce())
+}
+
 func testNewBlockBuilder(factory Factory, t *testing.T) {
 	require := require.New(t)
 	a := identityset.Address(28).String()
@@ -1130,6 +1469,7 @@ func testNewBlockBuilder(factory Factory, t *testing.T) {
 	ctrl := gomock.NewController(t)
 	ap := mock_actpool.NewMockActPool(ctrl)
 	ap.EXPECT().PendingActionMap().Return(accMap).Times(1)
+	ap.EXPECT().NoncePolicy().Return(tolerantNoncePolicy(t)).Times(1)
 	gasLimit := uint64(1000000)
 	ctx := protocol.WithBlockCtx(context.Background(),
 		protocol.BlockCtx{
@@ -1147,7 +1487,31 @@ func testNewBlockBuilder(factory Factory, t *testing.T) {
 	require.NotNil(blkBuilder)
 	blk, err := blkBuilder.SignAndBuild(identityset.PrivateKey(27))
 	require.NoError(err)
+	// the workingset of the proposal is reused at commit, and dropped afterwards
+	wsCache, getFromWorkingSets := workingSetCacheOf(factory)
+	key := generateWorkingSetCacheKey(blk.Header, blk.Header.ProducerAddress())
+	ws, isExist, err := getFromWorkingSets(ctx, key)
+	require.NoError(err)
+	require.True(isExist)
 	require.NoError(factory.PutBlock(ctx, &blk))
+	require.Zero(wsCache.Len())
+	// the workingset on top of a stale height is not reused
+	wsCache.Add(key, ws)
+	_, isExist, err = getFromWorkingSets(ctx, key)
+	require.NoError(err)
+	require.False(isExist)
+	require.Zero(wsCache.Len())
+}
+
+func workingSetCacheOf(sf Factory) (cache.LRUCache, func(context.Context, hash.Hash256) (*workingSet, bool, error)) {
+	switch sf := sf.(type) {
+	case *factory:
+		return sf.workingsets, sf.getFromWorkingSets
+	case *stateDB:
+		return sf.workingsets, sf.getFromWorkingSets
+	default:
+		panic("unexpected factory")
+	}
 }
 
 func TestSimulateExecution(t *testing.T) {
@@ -1619,6 +1983,83 @@ func benchRunAction(sf Factory, b *testing.B) {
 	}
 }
 
+// BenchmarkValidateAndPutBlock measures the validator, which executes a proposed block on endorsement and commits
+// it afterwards, with the workingset of the endorsement reused at commit or not
+func BenchmarkValidateAndPutBlock(b *testing.B) {
+	for _, tc := range []struct {
+		name string
+		opts []StateDBOption
+	}{
+		{"cached", nil},
+		{"uncached", []StateDBOption{DisableWorkingSetCacheOption()}},
+	} {
+		b.Run(tc.name, func(b *testing.B) {
+			benchValidateAndPutBlock(b, tc.opts...)
+		})
+	}
+}
+
+func benchValidateAndPutBlock(b *testing.B, opts ...StateDBOption) {
+	require := require.New(b)
+	const (
+		senders      = 20
+		actsOfSender = 25
+	)
+	cfg := DefaultConfig
+	for i := 0; i < senders; i++ {
+		cfg.Genesis.InitBalanceMap[identityset.Address(i).String()] = "100000000000000000000000000"
+	}
+	ctx := protocol.WithBlockchainCtx(genesis.WithGenesisContext(context.Background(), cfg.Genesis), protocol.BlockchainCtx{})
+	newSDB := func(opts ...StateDBOption) Factory {
+		registry := protocol.NewRegistry()
+		sdb, err := NewStateDB(cfg, db.NewMemKVStore(), append(opts, RegistryStateDBOption(registry))...)
+		require.NoError(err)
+		require.NoError(account.NewProtocol(rewarding.DepositGas).Register(registry))
+		require.NoError(sdb.Start(protocol.WithBlockCtx(ctx, protocol.BlockCtx{})))
+		return sdb
+	}
+	proposer, validator := newSDB(), newSDB(opts...)
+	defer func() {
+		require.NoError(proposer.Stop(ctx))
+		require.NoError(validator.Stop(ctx))
+	}()
+
+	ctrl := gomock.NewController(b)
+	ap := mock_actpool.NewMockActPool(ctrl)
+	nonces := make([]uint64, senders)
+	for n := 0; n < b.N; n++ {
+		b.StopTimer()
+		accMap := make(map[string][]*action.SealedEnvelope)
+		for i := 0; i < senders; i++ {
+			sender := identityset.Address(i).String()
+			for j := 0; j < actsOfSender; j++ {
+				nonces[i]++
+				selp, err := action.SignedTransfer(identityset.Address(27).String(), identityset.PrivateKey(i), nonces[i], big.NewInt(1), nil, testutil.TestGasLimit, big.NewInt(0))
+				require.NoError(err)
+				accMap[sender] = append(accMap[sender], selp)
+			}
+		}
+		ap.EXPECT().PendingActionMap().Return(accMap).Times(1)
+		ap.EXPECT().NoncePolicy().Return(tolerantNoncePolicy(b)).Times(1)
+		bctx := protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight: uint64(n + 1),
+			Producer:    identityset.Address(27),
+			GasLimit:    testutil.TestGasLimit * 100000,
+		})
+		bctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(bctx))
+		blkBuilder, err := proposer.NewBlockBuilder(bctx, ap, nil)
+		require.NoError(err)
+		blk, err := blkBuilder.SignAndBuild(identityset.PrivateKey(27))
+		require.NoError(err)
+		require.Len(blk.Actions, senders*actsOfSender)
+		require.NoError(proposer.PutBlock(bctx, &blk))
+		b.StartTimer()
+
+		require.NoError(validator.Validate(bctx, &blk))
+		require.NoError(validator.PutBlock(bctx, &blk))
+	}
+}
+
 func BenchmarkSDBState(b *testing.B) {
 	tp := filepath.Join(os.TempDir(), _stateDBPath)
 	if fileutil.FileExists(tp) && os.RemoveAll(tp) != nil {
@@ -1762,3 +2203,72 @@ func benchState(sf Factory, b *testing.B) {
 func init() {
 	rand.Seed(time.Now().UnixNano())
 }
+
+func BenchmarkDBReplayTransfer(b *testing.B)          { benchReplayTransfer(b, 0) }
+func BenchmarkDBReplayTransferNodeCache(b *testing.B) { benchReplayTransfer(b, 64<<20) }
+
+// benchReplayTransfer replays 1000 blocks full of transfers among a fixed group of accounts
+func benchReplayTransfer(b *testing.B, nodeCacheSize uint64) {
+	const (
+		_blocks       = 1000
+		_actsPerBlock = 50
+		_accounts     = 24
+	)
+	require := require.New(b)
+	ge := genesis.Default
+	for i := 0; i < _accounts; i++ {
+		ge.InitBalanceMap[identityset.Address(i).String()] = unit.ConvertIotxToRau(1000000).String()
+	}
+	// build the blocks once, all the runs replay the same blocks
+	nonces := make([]uint64, _accounts)
+	blks := make([]*block.Block, 0, _blocks)
+	prevHash := ge.Hash()
+	for h := uint64(1); h <= _blocks; h++ {
+		acts := make([]*action.SealedEnvelope, 0, _actsPerBlock)
+		for i := 0; i < _actsPerBlock; i++ {
+			sender, recipient := rand.Intn(_accounts), rand.Intn(_accounts)
+			nonces[sender]++
+			tsf, err := action.SignedTransfer(identityset.Address(recipient).String(), identityset.PrivateKey(sender), nonces[sender], big.NewInt(1), nil, testutil.TestGasLimit, big.NewInt(0))
+			require.NoError(err)
+			acts = append(acts, tsf)
+		}
+		blk, err := block.NewTestingBuilder().
+			SetHeight(h).
+			SetPrevBlockHash(prevHash).
+			SetTimeStamp(testutil.TimestampNow()).
+			AddActions(acts...).
+			SignAndBuild(identityset.PrivateKey(27))
+		require.NoError(err)
+		blks = append(blks, &blk)
+		prevHash = blk.HashBlock()
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		b.StopTimer()
+		testPath, err := testutil.PathOfTempFile(_triePath)
+		require.NoError(err)
+		cfg := DefaultConfig
+		cfg.Chain.TrieNodeCacheSize = nodeCacheSize
+		dbcfg := db.DefaultConfig
+		dbcfg.DbPath = testPath
+		sf, err := NewFactory(cfg, db.NewBoltDB(dbcfg), SkipBlockValidationOption())
+		require.NoError(err)
+		require.NoError(sf.Register(account.NewProtocol(rewarding.DepositGas)))
+		ctx := protocol.WithBlockchainCtx(genesis.WithGenesisContext(context.Background(), ge), protocol.BlockchainCtx{ChainID: 1})
+		require.NoError(sf.Start(ctx))
+		b.StartTimer()
+		for _, blk := range blks {
+			zctx := protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+				BlockHeight: blk.Height(),
+				Producer:    identityset.Address(27),
+				GasLimit:    testutil.TestGasLimit * 100000,
+			})
+			require.NoError(sf.PutBlock(protocol.WithFeatureCtx(zctx), blk))
+		}
+		b.StopTimer()
+		require.NoError(sf.Stop(ctx))
+		testutil.CleanupPath(testPath)
+		b.StartTimer()
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+// IterateAccounts calls fn for at most limit accounts at height whose addresses have the prefix, in the order
+// of addresses, starting after the page token. It returns the token of the next page, which is nil if there
+// are no more accounts. The addresses are enumerated from the tip states, so an account deleted after height
+// is not visited
+func (sf *factory) IterateAccounts(
+	ctx context.Context,
+	height uint64,
+	prefix []byte,
+	token []byte,
+	limit uint64,
+	fn func(address.Address, *state.Account) error,
+) ([]byte, error) {
+	if limit == 0 {
+		return nil, errors.New("limit should be positive")
+	}
+	if token != nil && !bytes.HasPrefix(token, prefix) {
+		return nil, errors.Errorf("page token %x doesn't have the prefix %x", token, prefix)
+	}
+	dao, ok := sf.dao.(db.KVStoreWithSeek)
+	if !ok {
+		return nil, errors.Wrap(ErrNotSupported, "state db doesn't support seek")
+	}
+	ws, err := sf.WorkingSetAtHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	start := prefix
+	if token != nil {
+		start = token
+	}
+	var (
+		last, next []byte
+		count      uint64
+		ferr       error
+	)
+	if err := dao.Seek(AccountKVNamespace, start, func(k, _ []byte) bool {
+		if !bytes.HasPrefix(k, prefix) {
+			return false
+		}
+		if bytes.Equal(k, token) {
+			return true
+		}
+		if count == limit {
+			next = last
+			return false
+		}
+		// the namespace also holds records other than accounts, e.g., the heights, which are not in the trie
+		account, err := state.NewAccount()
+		if err != nil {
+			ferr = err
+			return false
+		}
+		if _, err := ws.State(account, protocol.KeyOption(k)); err != nil {
+			if errors.Cause(err) == state.ErrStateNotExist {
+				return true
+			}
+			ferr = err
+			return false
+		}
+		addr, err := address.FromBytes(k)
+		if err != nil {
+			ferr = err
+			return false
+		}
+		if ferr = fn(addr, account); ferr != nil {
+			return false
+		}
+		last = k
+		count++
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	if ferr != nil {
+		return nil, ferr
+	}
+	return next, nil
+}
+
+// IterateContractStorage calls fn for at most limit storage slots of the contract at height, in the order of
+// the storage trie, starting after the page token. It returns the token of the next page, which is nil if
+// there are no more slots
+func (sf *factory) IterateContractStorage(
+	ctx context.Context,
+	height uint64,
+	contract address.Address,
+	token []byte,
+	limit uint64,
+	fn func([]byte, []byte) error,
+) ([]byte, error) {
+	if limit == 0 {
+		return nil, errors.New("limit should be positive")
+	}
+	ws, err := sf.WorkingSetAtHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		last, next []byte
+		count      uint64
+	)
+	if err := evm.IterateContractStorage(ws, contract, token, func(k, v []byte) (bool, error) {
+		if count == limit {
+			next = last
+			return false, nil
+		}
+		if err := fn(k, v); err != nil {
+			return false, err
+		}
+		last = k
+		count++
+		return true, nil
+	}); err != nil {
+		return nil, err
+	}
+	return next, nil
+}
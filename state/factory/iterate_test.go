@@ -0,0 +1,150 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestIterateAccounts(t *testing.T) {
+	r := require.New(t)
+	var err error
+	cfg := DefaultConfig
+	cfg.Chain.TrieDBPath, err = testutil.PathOfTempFile(_triePath)
+	r.NoError(err)
+	defer testutil.CleanupPath(cfg.Chain.TrieDBPath)
+	cfg.Chain.EnableArchiveMode = true
+	db1, err := db.CreateKVStore(db.DefaultConfig, cfg.Chain.TrieDBPath)
+	r.NoError(err)
+	sf, err := NewFactory(cfg, db1, SkipBlockValidationOption())
+	r.NoError(err)
+
+	a := identityset.Address(28)
+	priKeyA := identityset.PrivateKey(28)
+	h := hash.Hash160b([]byte("fresh account"))
+	fresh, err := address.FromBytes(h[:])
+	r.NoError(err)
+	r.NoError(sf.Register(account.NewProtocol(rewarding.DepositGas)))
+	ge := genesis.Default
+	ge.InitBalanceMap[a.String()] = "100"
+	gasLimit := uint64(1000000)
+	ctx := protocol.WithBlockCtx(context.Background(), protocol.BlockCtx{
+		BlockHeight: 0,
+		Producer:    identityset.Address(27),
+		GasLimit:    gasLimit,
+	})
+	ctx = genesis.WithGenesisContext(ctx, ge)
+	ctx = protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{ChainID: 1})
+	r.NoError(sf.Start(ctx))
+	defer func() {
+		r.NoError(sf.Stop(ctx))
+	}()
+
+	prevHash := hash.ZeroHash256
+	for i, recipient := range []address.Address{identityset.Address(31), fresh} {
+		height := uint64(i + 1)
+		tsf, err := action.NewTransfer(height, big.NewInt(10), recipient.String(), nil, uint64(20000), big.NewInt(0))
+		r.NoError(err)
+		elp := (&action.EnvelopeBuilder{}).SetAction(tsf).SetGasLimit(20000).SetNonce(height).Build()
+		selp, err := action.Sign(elp, priKeyA)
+		r.NoError(err)
+		blk, err := block.NewTestingBuilder().
+			SetHeight(height).
+			SetPrevBlockHash(prevHash).
+			SetTimeStamp(testutil.TimestampNow()).
+			AddActions(selp).
+			SignAndBuild(identityset.PrivateKey(27))
+		r.NoError(err)
+		r.NoError(sf.PutBlock(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight: height,
+			Producer:    identityset.Address(27),
+			GasLimit:    gasLimit,
+		}), &blk))
+		prevHash = blk.HashBlock()
+	}
+
+	iterate := func(height uint64, prefix []byte, limit uint64) map[string]*state.Account {
+		var (
+			accounts = make(map[string]*state.Account)
+			last     []byte
+			token    []byte
+			pages    int
+		)
+		for {
+			token, err = sf.IterateAccounts(ctx, height, prefix, token, limit, func(addr address.Address, acct *state.Account) error {
+				r.True(bytes.HasPrefix(addr.Bytes(), prefix))
+				r.Equal(-1, bytes.Compare(last, addr.Bytes()))
+				last = addr.Bytes()
+				accounts[addr.String()] = acct
+				return nil
+			})
+			r.NoError(err)
+			pages++
+			if token == nil {
+				break
+			}
+			r.Equal(last, token)
+		}
+		r.LessOrEqual(uint64(len(accounts)), uint64(pages)*limit)
+		return accounts
+	}
+	accounts := iterate(1, nil, 5)
+	r.Contains(accounts, a.String())
+	r.Equal(big.NewInt(90), accounts[a.String()].Balance)
+	r.NotContains(accounts, fresh.String())
+	accounts2 := iterate(2, nil, 7)
+	r.Len(accounts2, len(accounts)+1)
+	r.Equal(big.NewInt(80), accounts2[a.String()].Balance)
+	r.Equal(big.NewInt(10), accounts2[fresh.String()].Balance)
+
+	// iterate the accounts with a prefix
+	prefix := fresh.Bytes()[:1]
+	accounts = iterate(2, prefix, 1)
+	r.Contains(accounts, fresh.String())
+	for addr := range accounts2 {
+		acct, err := address.FromString(addr)
+		r.NoError(err)
+		if bytes.HasPrefix(acct.Bytes(), prefix) {
+			r.Contains(accounts, addr)
+		}
+	}
+
+	// the callback error is returned
+	errStop := errors.New("stop")
+	_, err = sf.IterateAccounts(ctx, 2, nil, nil, 10, func(address.Address, *state.Account) error {
+		return errStop
+	})
+	r.Equal(errStop, errors.Cause(err))
+	_, err = sf.IterateAccounts(ctx, 2, nil, nil, 0, nil)
+	r.Error(err)
+	_, err = sf.IterateAccounts(ctx, 2, []byte{0}, []byte{1}, 10, nil)
+	r.Error(err)
+	_, err = sf.IterateAccounts(ctx, 3, nil, nil, 10, nil)
+	r.Error(err)
+	_, err = sf.IterateContractStorage(ctx, 2, a, nil, 10, func([]byte, []byte) error {
+		return nil
+	})
+	r.Error(err)
+}
@@ -14,17 +14,37 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain/block"
 )
 
-type minter struct {
-	f  Factory
-	ap actpool.ActPool
+type (
+	minter struct {
+		f      Factory
+		ap     actpool.ActPool
+		policy actpool.ActionSelectionPolicy
+	}
+
+	// MinterOption sets the minter construction parameter
+	MinterOption func(*minter)
+)
+
+// WithActionSelectionPolicy sets the policy the minter selects the actions from the actpool by
+func WithActionSelectionPolicy(p actpool.ActionSelectionPolicy) MinterOption {
+	return func(m *minter) {
+		m.policy = p
+	}
 }
 
 // NewMinter creates a wrapper instance
-func NewMinter(f Factory, ap actpool.ActPool) blockchain.BlockBuilderFactory {
-	return &minter{f: f, ap: ap}
+func NewMinter(f Factory, ap actpool.ActPool, opts ...MinterOption) blockchain.BlockBuilderFactory {
+	m := &minter{f: f, ap: ap}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
 }
 
 // NewBlockBuilder implements the BlockMinter interface
 func (m *minter) NewBlockBuilder(ctx context.Context, sign func(action.Envelope) (*action.SealedEnvelope, error)) (*block.Builder, error) {
+	if m.policy != nil {
+		ctx = actpool.WithActionSelectionPolicy(ctx, m.policy)
+	}
 	return m.f.NewBlockBuilder(ctx, m.ap, sign)
 }
@@ -0,0 +1,398 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/db/batch"
+)
+
+var errParallelNotSupported = errors.New("not supported by parallel execution")
+
+type (
+	// stateKey is the key of a state in the working set store
+	stateKey struct {
+		ns  string
+		key string
+	}
+
+	// actionTrace is what an action did on the overlay of its partition, which is replayed on the working set if
+	// none of the states the action read has been changed by the actions out of the partition
+	actionTrace struct {
+		reads   map[stateKey]struct{}
+		ops     []traceOp
+		receipt *action.Receipt
+		err     error
+	}
+
+	// traceOp is either a write of a state, or a change deferred to the replay
+	traceOp struct {
+		key      stateKey
+		value    []byte
+		deferred func(protocol.StateManager) error
+	}
+
+	// parallelStore is the overlay of the working set store, on which a partition of the actions runs in isolation.
+	// The reads fall through to the base store, and the writes are kept in the overlay and traced per action
+	parallelStore struct {
+		base    workingSetStore
+		baseMtx *sync.Mutex
+		writes  map[stateKey][]byte
+		trace   *actionTrace
+		// journal records the value of a key in the overlay before a write, which is undone on revert
+		journal []parallelJournalEntry
+		marks   []parallelMark
+	}
+
+	parallelJournalEntry struct {
+		key    stateKey
+		value  []byte
+		exists bool
+	}
+
+	// parallelMark is the size of the journal and the traced ops at the time of a snapshot
+	parallelMark struct {
+		journal int
+		ops     int
+	}
+
+	// writeRecorder records the partition which last wrote each state into the working set store, -1 for the actions
+	// run serially and the deferred changes
+	writeRecorder struct {
+		workingSetStore
+		writer  int
+		writers map[stateKey]int
+	}
+)
+
+func newParallelStore(base workingSetStore, baseMtx *sync.Mutex) *parallelStore {
+	return &parallelStore{
+		base:    base,
+		baseMtx: baseMtx,
+		writes:  make(map[stateKey][]byte),
+	}
+}
+
+// runActionsInParallel runs the actions the same as runActions does serially. The transfers are partitioned by the
+// accounts they touch, and the partitions run concurrently on the overlays of the working set. Then the actions are
+// applied in order: the trace of an action is replayed if none of the states it read has been changed by the other
+// partitions, otherwise the action, along with the rest of its partition, runs again on the working set
+func (ws *workingSet) runActionsInParallel(
+	ctx context.Context,
+	elps []*action.SealedEnvelope,
+) ([]*action.Receipt, error) {
+	partOf, parts := partitionActions(elps)
+	if len(parts) < 2 {
+		return ws.runActionsSerially(ctx, elps)
+	}
+	ctxs := make([]context.Context, len(elps))
+	for i, elp := range elps {
+		ctxWithActionContext, err := withActionCtx(ctx, elp)
+		if err != nil {
+			return nil, err
+		}
+		ctxs[i] = ctxWithActionContext
+	}
+	traces := ws.traceActions(ctxs, elps, parts)
+
+	// apply the actions in order
+	recorder := &writeRecorder{
+		workingSetStore: ws.store,
+		writer:          -1,
+		writers:         make(map[stateKey]int),
+	}
+	ws.store = recorder
+	defer func() { ws.store = recorder.workingSetStore }()
+	valid := make([]bool, len(parts))
+	for i := range valid {
+		valid[i] = true
+	}
+	receipts := make([]*action.Receipt, 0, len(elps))
+	for i, elp := range elps {
+		if p := partOf[i]; p >= 0 && valid[p] {
+			if t := traces[i]; t != nil && t.err == nil && !recorder.changed(t.reads, p) {
+				if err := ws.applyTrace(t, recorder, p); err != nil {
+					return nil, errors.Wrap(err, "error when run action")
+				}
+				_stateDBMtc.WithLabelValues("parallel").Inc()
+				receipts = append(receipts, t.receipt)
+				continue
+			}
+			valid[p] = false
+		}
+		_stateDBMtc.WithLabelValues("serial").Inc()
+		receipt, err := ws.runAction(ctxs[i], elp)
+		if err != nil {
+			return nil, errors.Wrap(err, "error when run action")
+		}
+		receipts = append(receipts, receipt)
+	}
+	if protocol.MustGetFeatureCtx(ctx).CorrectTxLogIndex {
+		updateReceiptIndex(receipts)
+	}
+	return receipts, nil
+}
+
+// traceActions runs each partition on an overlay of the working set, and returns the traces of the actions. A
+// partition stops at its first failed action, the actions after which are not traced
+func (ws *workingSet) traceActions(ctxs []context.Context, elps []*action.SealedEnvelope, parts [][]int) []*actionTrace {
+	var (
+		traces  = make([]*actionTrace, len(elps))
+		baseMtx sync.Mutex
+		wg      sync.WaitGroup
+		queue   = make(chan []int, len(parts))
+		workers = runtime.NumCPU()
+	)
+	for _, part := range parts {
+		queue <- part
+	}
+	close(queue)
+	if workers > len(parts) {
+		workers = len(parts)
+	}
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for part := range queue {
+				store := newParallelStore(ws.store, &baseMtx)
+				overlay := newWorkingSet(ws.height, store)
+				for _, i := range part {
+					t := &actionTrace{reads: make(map[stateKey]struct{})}
+					store.trace = t
+					t.receipt, t.err = overlay.runAction(ctxs[i], elps[i])
+					traces[i] = t
+					if t.err != nil {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return traces
+}
+
+// applyTrace replays the trace of an action of partition p on the working set
+func (ws *workingSet) applyTrace(t *actionTrace, recorder *writeRecorder, p int) error {
+	defer ws.ResetSnapshots()
+	defer func() { recorder.writer = -1 }()
+	for _, op := range t.ops {
+		if op.deferred != nil {
+			recorder.writer = -1
+			if err := op.deferred(ws); err != nil {
+				return err
+			}
+			continue
+		}
+		recorder.writer = p
+		if err := ws.store.Put(op.key.ns, []byte(op.key.key), op.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// partitionActions groups the transfers by the accounts they touch, such that the transfers touching a common
+// account are in the same partition. It returns the partition of each action, -1 for the actions which have to run
+// serially, and the actions of each partition in order
+func partitionActions(elps []*action.SealedEnvelope) ([]int, [][]int) {
+	var (
+		partOf = make([]int, len(elps))
+		parent = make([]int, len(elps))
+		owner  = make(map[string]int)
+		find   func(int) int
+	)
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for i, elp := range elps {
+		parent[i] = i
+		partOf[i] = -1
+		accounts := touchedAccounts(elp)
+		if accounts == nil {
+			continue
+		}
+		partOf[i] = i
+		for _, addr := range accounts {
+			if j, ok := owner[addr]; ok {
+				parent[find(i)] = find(j)
+			} else {
+				owner[addr] = i
+			}
+		}
+	}
+	var (
+		parts  [][]int
+		partID = make(map[int]int)
+	)
+	for i := range elps {
+		if partOf[i] < 0 {
+			continue
+		}
+		root := find(i)
+		p, ok := partID[root]
+		if !ok {
+			p = len(parts)
+			partID[root] = p
+			parts = append(parts, nil)
+		}
+		partOf[i] = p
+		parts[p] = append(parts[p], i)
+	}
+	return partOf, parts
+}
+
+// touchedAccounts returns the accounts a transfer touches, or nil if the action is not a transfer which could run in
+// parallel. The burn address is credited along with the rewarding fund, which is deferred to the replay, so a
+// transfer touching it has to run serially
+func touchedAccounts(elp *action.SealedEnvelope) []string {
+	tsf, ok := elp.Action().(*action.Transfer)
+	if !ok || action.IsSystemAction(elp) {
+		return nil
+	}
+	sender := elp.SenderAddress()
+	recipient, err := address.FromString(tsf.Recipient())
+	if sender == nil || err != nil {
+		return nil
+	}
+	accounts := []string{sender.String(), recipient.String()}
+	if payer := elp.GasPayer(); payer != nil {
+		accounts = append(accounts, payer.String())
+	}
+	for _, addr := range accounts {
+		if addr == address.ZeroAddress {
+			return nil
+		}
+	}
+	return accounts
+}
+
+// changed returns true if any of the states has been written by the actions out of partition p
+func (r *writeRecorder) changed(reads map[stateKey]struct{}, p int) bool {
+	for k := range reads {
+		if w, ok := r.writers[k]; ok && w != p {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *writeRecorder) Put(ns string, key []byte, value []byte) error {
+	r.writers[stateKey{ns, string(key)}] = r.writer
+	return r.workingSetStore.Put(ns, key, value)
+}
+
+func (r *writeRecorder) Delete(ns string, key []byte) error {
+	r.writers[stateKey{ns, string(key)}] = r.writer
+	return r.workingSetStore.Delete(ns, key)
+}
+
+func (store *parallelStore) Start(context.Context) error {
+	return nil
+}
+
+func (store *parallelStore) Stop(context.Context) error {
+	return nil
+}
+
+func (store *parallelStore) Get(ns string, key []byte) ([]byte, error) {
+	k := stateKey{ns, string(key)}
+	store.trace.reads[k] = struct{}{}
+	if value, ok := store.writes[k]; ok {
+		return value, nil
+	}
+	store.baseMtx.Lock()
+	defer store.baseMtx.Unlock()
+	return store.base.Get(ns, key)
+}
+
+func (store *parallelStore) Put(ns string, key []byte, value []byte) error {
+	k := stateKey{ns, string(key)}
+	if len(store.marks) > 0 {
+		prev, exists := store.writes[k]
+		store.journal = append(store.journal, parallelJournalEntry{key: k, value: prev, exists: exists})
+	}
+	store.writes[k] = value
+	store.trace.ops = append(store.trace.ops, traceOp{key: k, value: value})
+	return nil
+}
+
+func (store *parallelStore) Delete(ns string, key []byte) error {
+	return errors.Wrapf(errParallelNotSupported, "failed to delete state of ns = %x and key = %x", ns, key)
+}
+
+// deferChange defers the change to the replay of the action
+func (store *parallelStore) deferChange(f func(protocol.StateManager) error) {
+	store.trace.ops = append(store.trace.ops, traceOp{deferred: f})
+}
+
+func (store *parallelStore) States(string, [][]byte) ([][]byte, [][]byte, error) {
+	return nil, nil, errors.Wrap(errParallelNotSupported, "failed to read states")
+}
+
+func (store *parallelStore) Digest() hash.Hash256 {
+	return hash.ZeroHash256
+}
+
+func (store *parallelStore) Finalize(uint64) error {
+	return errors.Wrap(errParallelNotSupported, "failed to finalize")
+}
+
+func (store *parallelStore) Commit() error {
+	return errors.Wrap(errParallelNotSupported, "failed to commit")
+}
+
+func (store *parallelStore) ReadView(name string) (interface{}, error) {
+	store.baseMtx.Lock()
+	defer store.baseMtx.Unlock()
+	return store.base.ReadView(name)
+}
+
+func (store *parallelStore) WriteView(name string, _ interface{}) error {
+	return errors.Wrapf(errParallelNotSupported, "failed to write view %s", name)
+}
+
+func (store *parallelStore) Snapshot() int {
+	store.marks = append(store.marks, parallelMark{journal: len(store.journal), ops: len(store.trace.ops)})
+	return len(store.marks) - 1
+}
+
+func (store *parallelStore) RevertSnapshot(snapshot int) error {
+	if snapshot < 0 || snapshot >= len(store.marks) {
+		return errors.Wrapf(batch.ErrOutOfBound, "invalid snapshot number = %d", snapshot)
+	}
+	mark := store.marks[snapshot]
+	for i := len(store.journal) - 1; i >= mark.journal; i-- {
+		entry := &store.journal[i]
+		if entry.exists {
+			store.writes[entry.key] = entry.value
+		} else {
+			delete(store.writes, entry.key)
+		}
+	}
+	store.journal = store.journal[:mark.journal]
+	store.trace.ops = store.trace.ops[:mark.ops]
+	store.marks = store.marks[:snapshot+1]
+	return nil
+}
+
+func (store *parallelStore) ResetSnapshots() {
+	store.journal = nil
+	store.marks = nil
+}
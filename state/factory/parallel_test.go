@@ -0,0 +1,196 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"context"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/iotex-address/address"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/db"
+	. "github.com/iotexproject/iotex-core/pkg/util/assertions"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestPartitionActions(t *testing.T) {
+	r := require.New(t)
+	transfer := func(sender, recipient int, payer int) *action.SealedEnvelope {
+		to := address.ZeroAddress
+		if recipient >= 0 {
+			to = identityset.Address(recipient).String()
+		}
+		tsf := MustNoErrorV(action.NewTransfer(1, big.NewInt(1), to, nil, testutil.TestGasLimit, big.NewInt(1)))
+		return signParallelTestAction(t, newParallelTestEnvelope(1, big.NewInt(1)).SetAction(tsf), sender, payer)
+	}
+	deposit := (&action.DepositToRewardingFundBuilder{}).SetAmount(big.NewInt(1)).Build()
+	partOf, parts := partitionActions([]*action.SealedEnvelope{
+		transfer(1, 2, -1),
+		transfer(3, 4, -1),
+		transfer(2, 5, -1),
+		signParallelTestAction(t, newParallelTestEnvelope(1, big.NewInt(1)).SetAction(&deposit), 6, -1),
+		transfer(7, -1, -1), // to the burn address
+		transfer(8, 9, 3),
+		transfer(10, 10, -1),
+		transfer(11, 5, -1),
+	})
+	r.Equal([]int{0, 1, 0, -1, -1, 1, 2, 0}, partOf)
+	r.Equal([][]int{{0, 2, 7}, {1, 5}, {6}}, parts)
+}
+
+// TestParallelExecution runs randomized blocks both serially and in parallel, the states and receipts of which must
+// be the same
+func TestParallelExecution(t *testing.T) {
+	r := require.New(t)
+	const (
+		_accounts = 12
+		_blocks   = 30
+		_maxActs  = 60
+	)
+	seed := time.Now().UnixNano()
+	t.Logf("seed = %d", seed)
+	rnd := rand.New(rand.NewSource(seed))
+
+	g := genesis.TestDefault()
+	ctx := protocol.WithBlockchainCtx(genesis.WithGenesisContext(context.Background(), g), protocol.BlockchainCtx{ChainID: 1})
+	registries := []*protocol.Registry{protocol.NewRegistry(), protocol.NewRegistry()}
+	for _, registry := range registries {
+		r.NoError(account.NewProtocol(rewarding.DepositGas).Register(registry))
+		r.NoError(rewarding.NewProtocol(g.Rewarding).Register(registry))
+	}
+	newFactories := func(parallel bool) []Factory {
+		cfg := Config{Chain: blockchain.DefaultConfig, Genesis: g}
+		cfg.Chain.EnableParallelExecution = parallel
+		sf, err := NewFactory(cfg, db.NewMemKVStore(), RegistryOption(registries[0]))
+		r.NoError(err)
+		sdb, err := NewStateDB(cfg, db.NewMemKVStore(), RegistryStateDBOption(registries[1]))
+		r.NoError(err)
+		factories := []Factory{sf, sdb}
+		for _, f := range factories {
+			r.NoError(f.Start(protocol.WithBlockCtx(ctx, protocol.BlockCtx{})))
+		}
+		return factories
+	}
+	serial, parallel := newFactories(false), newFactories(true)
+	defer func() {
+		for _, f := range append(serial, parallel...) {
+			r.NoError(f.Stop(ctx))
+		}
+	}()
+
+	nonces := make([]uint64, _accounts)
+	prevHash := g.Hash()
+	replayed := promtestutil.ToFloat64(_stateDBMtc.WithLabelValues("parallel"))
+	for h := uint64(1); h <= _blocks; h++ {
+		// every few blocks are transfers between disjoint pairs of accounts, so that some run in parallel for sure
+		disjoint := h%5 == 1
+		acts := make([]*action.SealedEnvelope, rnd.Intn(_maxActs)+1)
+		if disjoint {
+			acts = make([]*action.SealedEnvelope, _accounts/2)
+		}
+		for i := range acts {
+			var (
+				sender   = rnd.Intn(_accounts)
+				payer    = -1
+				gasPrice = big.NewInt(rnd.Int63n(3))
+				to       = identityset.Address(rnd.Intn(_accounts)).String()
+				kind     = rnd.Intn(10)
+			)
+			if disjoint {
+				sender, to, kind = 2*i, identityset.Address(2*i+1).String(), -1
+			}
+			nonces[sender]++
+			eb := newParallelTestEnvelope(nonces[sender], gasPrice)
+			switch kind {
+			case 0:
+				// deposits to the rewarding fund run serially
+				deposit := (&action.DepositToRewardingFundBuilder{}).SetAmount(big.NewInt(rnd.Int63n(100))).Build()
+				acts[i] = signParallelTestAction(t, eb.SetAction(&deposit), sender, payer)
+				continue
+			case 1:
+				// so do the transfers to the burn address
+				to = address.ZeroAddress
+			case 2:
+				payer = rnd.Intn(_accounts)
+			}
+			eb.SetAction(MustNoErrorV(action.NewTransfer(nonces[sender], big.NewInt(rnd.Int63n(1000)), to, nil, testutil.TestGasLimit, gasPrice)))
+			acts[i] = signParallelTestAction(t, eb, sender, payer)
+		}
+
+		ts := time.Unix(g.Timestamp, 0).Add(time.Duration(h) * 5 * time.Second)
+		for i := range serial {
+			bctx := protocol.WithFeatureCtx(protocol.WithBlockCtx(protocol.WithRegistry(ctx, registries[i]), protocol.BlockCtx{
+				BlockHeight:    h,
+				BlockTimeStamp: ts,
+				GasLimit:       g.BlockGasLimitByHeight(h),
+				Producer:       identityset.Address(27),
+			}))
+			ws, err := serial[i].(workingSetCreator).newWorkingSet(bctx, h)
+			r.NoError(err)
+			r.NoError(ws.Process(bctx, acts))
+			digest := MustNoErrorV(ws.digest())
+			pws, err := parallel[i].(workingSetCreator).newWorkingSet(bctx, h)
+			r.NoError(err)
+			r.NoError(pws.Process(bctx, acts))
+			r.Equal(digest, MustNoErrorV(pws.digest()), "height %d", h)
+			r.Equal(calculateReceiptRoot(ws.receipts), calculateReceiptRoot(pws.receipts), "height %d", h)
+
+			blk, err := block.NewBuilder((&block.RunnableActionsBuilder{}).AddActions(acts...).Build()).
+				SetHeight(h).
+				SetTimestamp(ts).
+				SetVersion(1).
+				SetReceiptRoot(calculateReceiptRoot(ws.receipts)).
+				SetDeltaStateDigest(digest).
+				SetPrevBlockHash(prevHash).
+				SignAndBuild(identityset.PrivateKey(27))
+			r.NoError(err)
+			r.NoError(serial[i].PutBlock(ctx, &blk))
+			r.NoError(parallel[i].PutBlock(ctx, &blk))
+			if i == len(serial)-1 {
+				prevHash = blk.HashBlock()
+			}
+		}
+	}
+	// some of the transfers are replayed from the traces of their partitions
+	r.Greater(promtestutil.ToFloat64(_stateDBMtc.WithLabelValues("parallel")), replayed)
+	for i := range serial {
+		for j := 0; j < _accounts; j++ {
+			addr := identityset.Address(j)
+			acc := MustNoErrorV(accountutil.AccountState(ctx, serial[i], addr))
+			r.Equal(acc, MustNoErrorV(accountutil.AccountState(ctx, parallel[i], addr)))
+		}
+	}
+}
+
+func newParallelTestEnvelope(nonce uint64, gasPrice *big.Int) *action.EnvelopeBuilder {
+	return (&action.EnvelopeBuilder{}).SetNonce(nonce).SetGasLimit(testutil.TestGasLimit).SetGasPrice(gasPrice).SetChainID(1)
+}
+
+// signParallelTestAction signs the action by the sender, and by the gas payer if payer is not -1
+func signParallelTestAction(t *testing.T, eb *action.EnvelopeBuilder, sender, payer int) *action.SealedEnvelope {
+	if payer >= 0 {
+		eb.SetGasPayer(identityset.Address(payer))
+	}
+	selp := MustNoErrorV(action.Sign(eb.Build(), identityset.PrivateKey(sender)))
+	if payer >= 0 {
+		require.NoError(t, action.SignAsGasPayer(selp, identityset.PrivateKey(payer)))
+	}
+	return selp
+}
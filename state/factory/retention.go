@@ -0,0 +1,229 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+// EarliestStateHeight returns the earliest height whose state can be queried
+func (sf *factory) EarliestStateHeight() uint64 {
+	sf.mutex.RLock()
+	defer sf.mutex.RUnlock()
+	if !sf.saveHistory {
+		return sf.currentChainHeight
+	}
+	return sf.earliestHistoryHeight
+}
+
+// WorkingSetAtHeight returns a read-only working set on top of the state at a queryable height,
+// note that the protocol views in the working set are still the ones at tip height
+func (sf *factory) WorkingSetAtHeight(ctx context.Context, height uint64) (protocol.StateManager, error) {
+	ws, err := sf.workingSetAtHeight(ctx, height, sf.protocolView)
+	if err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
+
+// StateReaderAtHeight returns a read-only state reader on top of the state at a queryable height, unlike
+// WorkingSetAtHeight the protocol views are rebuilt from the states at the height, so ctx should carry the
+// read context at the height
+func (sf *factory) StateReaderAtHeight(ctx context.Context, height uint64) (protocol.StateReader, error) {
+	view := protocol.View{}
+	ws, err := sf.workingSetAtHeight(ctx, height, view)
+	if err != nil {
+		return nil, err
+	}
+	loaded, err := sf.registry.StartAll(protocol.WithRegistry(ctx, sf.registry), ws)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load protocol views at height %d", height)
+	}
+	for name, v := range loaded {
+		view[name] = v
+	}
+	return ws, nil
+}
+
+// ReplayBlock runs the first n actions of a block again on top of the state of its parent, which must be queryable,
+// note that the protocol views are still the ones at tip height like in WorkingSetAtHeight
+func (sf *factory) ReplayBlock(ctx context.Context, blk *block.Block, n int, actionCtx func(context.Context, int) (context.Context, error)) ([]*action.Receipt, error) {
+	if blk.Height() == 0 {
+		return nil, errors.New("cannot replay the genesis block")
+	}
+	ws, err := sf.workingSetAtHeight(ctx, blk.Height()-1, sf.protocolView)
+	if err != nil {
+		return nil, err
+	}
+	// the actions run in the block, on top of the state of its parent
+	ws.height = blk.Height()
+	return ws.replay(protocol.WithRegistry(ctx, sf.registry), blk.Actions[:n], actionCtx)
+}
+
+func (sf *factory) workingSetAtHeight(ctx context.Context, height uint64, view protocol.View) (*workingSet, error) {
+	if !sf.saveHistory {
+		return nil, ErrNoArchiveData
+	}
+	sf.mutex.RLock()
+	defer sf.mutex.RUnlock()
+	if height > sf.currentChainHeight {
+		return nil, errors.Errorf("query height %d is higher than tip height %d", height, sf.currentChainHeight)
+	}
+	if err := sf.checkHistoryHeight(height); err != nil {
+		return nil, err
+	}
+	g := genesis.MustExtractGenesisContext(ctx)
+	flusher, err := db.NewKVStoreFlusher(
+		sf.dao,
+		batch.NewCachedBatch(),
+		sf.flusherOptions(!g.IsEaster(height))...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	store, err := newFactoryWorkingSetStoreAtHeight(view, flusher, height, sf.trieOptions()...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to generate trie for %d", height)
+	}
+	if err := store.Start(ctx); err != nil {
+		return nil, err
+	}
+	return newWorkingSet(height, store), nil
+}
+
+// checkHistoryHeight checks whether the history state at height has been pruned, must hold the mutex
+func (sf *factory) checkHistoryHeight(height uint64) error {
+	if !sf.saveHistory || height >= sf.earliestHistoryHeight {
+		return nil
+	}
+	return errors.Wrapf(ErrOutOfRetentionRange, "query height %d is lower than earliest height %d", height, sf.earliestHistoryHeight)
+}
+
+func (sf *factory) loadEarliestHistoryHeight() error {
+	if !sf.saveHistory {
+		return nil
+	}
+	h, err := sf.dao.Get(AccountKVNamespace, []byte(EarliestHistoryHeightKey))
+	switch errors.Cause(err) {
+	case nil:
+		sf.earliestHistoryHeight = byteutil.BytesToUint64(h)
+		return nil
+	case db.ErrNotExist:
+		if sf.historyRetention == 0 {
+			// archive mode keeps all history since genesis
+			sf.earliestHistoryHeight = 0
+			return nil
+		}
+		// history states are retained since the retention mode is turned on
+		sf.earliestHistoryHeight = sf.currentChainHeight
+		return sf.dao.Put(AccountKVNamespace, []byte(EarliestHistoryHeightKey), byteutil.Uint64ToBytes(sf.currentChainHeight))
+	default:
+		return err
+	}
+}
+
+func (sf *factory) startPruner() {
+	pruneCh, pruneDone := make(chan struct{}, 1), make(chan struct{})
+	sf.pruneCh, sf.pruneDone = pruneCh, pruneDone
+	go func() {
+		defer close(pruneDone)
+		for range pruneCh {
+			if err := sf.pruneHistory(); err != nil {
+				log.Logger("statefactory").Error("Failed to prune history states.", zap.Error(err))
+			}
+		}
+	}()
+	sf.notifyPruner()
+}
+
+func (sf *factory) stopPruner() {
+	sf.mutex.Lock()
+	close(sf.pruneCh)
+	sf.pruneCh = nil
+	sf.mutex.Unlock()
+	<-sf.pruneDone
+}
+
+// notifyPruner wakes up the pruner without blocking, the caller must hold the mutex
+func (sf *factory) notifyPruner() {
+	select {
+	case sf.pruneCh <- struct{}{}:
+	default:
+	}
+}
+
+// pruneHistory moves the earliest history height forward until only the most recent
+// historyRetention heights are kept. The mutex is released between heights, so that
+// block commits are not stalled by a long pruning
+func (sf *factory) pruneHistory() error {
+	for {
+		sf.mutex.RLock()
+		earliest, tip := sf.earliestHistoryHeight, sf.currentChainHeight
+		sf.mutex.RUnlock()
+		if earliest+sf.historyRetention > tip {
+			return nil
+		}
+		if err := sf.advanceEarliestHistoryHeight(earliest + 1); err != nil {
+			return err
+		}
+	}
+}
+
+// advanceEarliestHistoryHeight deletes the trie nodes becoming stale at height, which are only
+// referenced by the tries of previous heights, and moves the earliest history height to height
+func (sf *factory) advanceEarliestHistoryHeight(height uint64) error {
+	sf.mutex.Lock()
+	defer sf.mutex.Unlock()
+	prefix := byteutil.Uint64ToBytesBigEndian(height)
+	keys, _, err := sf.dao.Filter(
+		ArchiveStaleHeightNamespace,
+		func(k, v []byte) bool { return bytes.HasPrefix(k, prefix) },
+		prefix,
+		byteutil.Uint64ToBytesBigEndian(height+1),
+	)
+	if err != nil && errors.Cause(err) != db.ErrBucketNotExist && errors.Cause(err) != db.ErrNotExist {
+		return errors.Wrapf(err, "failed to read stale trie nodes at height %d", height)
+	}
+	b := batch.NewBatch()
+	for _, k := range keys {
+		node := k[len(prefix):]
+		h, err := sf.dao.Get(ArchiveStaleNodeNamespace, node)
+		switch errors.Cause(err) {
+		case nil:
+			// the node could be revived and become stale again at a later height
+			if byteutil.BytesToUint64BigEndian(h) == height {
+				b.Delete(ArchiveTrieNamespace, node, "failed to delete stale trie node")
+				b.Delete(ArchiveStaleNodeNamespace, node, "failed to delete stale trie node mark")
+			}
+		case db.ErrNotExist:
+			// the node has been revived since
+		default:
+			return err
+		}
+		b.Delete(ArchiveStaleHeightNamespace, k, "failed to delete stale trie node journal")
+	}
+	b.Delete(ArchiveTrieNamespace, []byte(fmt.Sprintf("%s-%d", ArchiveTrieRootKey, height-1)), "failed to delete history trie root")
+	b.Put(AccountKVNamespace, []byte(EarliestHistoryHeightKey), byteutil.Uint64ToBytes(height), "failed to update earliest history height")
+	if err := sf.dao.WriteBatch(b); err != nil {
+		return errors.Wrapf(err, "failed to prune history states at height %d", height-1)
+	}
+	sf.earliestHistoryHeight = height
+	return nil
+}
@@ -0,0 +1,509 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/db/trie"
+	"github.com/iotexproject/iotex-core/db/trie/mptrie"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+const (
+	// SnapshotHeightKey indicates the key of the height of the snapshot which the state db is initialized from
+	SnapshotHeightKey = "snapshotHeight"
+	// SnapshotManifestFile is the name of the manifest file in a snapshot directory
+	SnapshotManifestFile = "manifest.json"
+	// SnapshotBlockFile is the name of the file in a snapshot directory storing the block at snapshot height
+	SnapshotBlockFile = "block.dat"
+	// SnapshotCheckpointFile is the name of the state db copy in a snapshot directory, which is read by
+	// the export, and removed once the export is done
+	SnapshotCheckpointFile = "checkpoint.db"
+
+	_defaultSnapshotChunkSize = 16 << 20
+)
+
+var (
+	// ErrSnapshotMismatch indicates the error that a snapshot doesn't match the expected one
+	ErrSnapshotMismatch = errors.New("snapshot mismatch")
+	// ErrSnapshotIncomplete indicates the error that a snapshot hasn't been completely exported
+	ErrSnapshotIncomplete = errors.New("snapshot is incomplete")
+)
+
+type (
+	// SnapshotExporter exports the states at the tip height into a snapshot
+	SnapshotExporter interface {
+		ExportSnapshot(context.Context, string, func(uint64) (hash.Hash256, error), ...SnapshotOption) (*SnapshotManifest, error)
+	}
+
+	// SnapshotManifest describes a state snapshot, which consists of the states of all namespaces in the
+	// state trie. The states of each namespace are split into chunks, one file for each chunk
+	SnapshotManifest struct {
+		Height     uint64               `json:"height"`
+		BlockHash  string               `json:"blockHash"`
+		Root       string               `json:"root"`
+		Namespaces []*SnapshotNamespace `json:"namespaces"`
+		Complete   bool                 `json:"complete"`
+	}
+
+	// SnapshotNamespace describes the chunks of a namespace
+	SnapshotNamespace struct {
+		Name     string           `json:"name"`
+		Chunks   []*SnapshotChunk `json:"chunks"`
+		Complete bool             `json:"complete"`
+	}
+
+	// SnapshotChunk describes a chunk file
+	SnapshotChunk struct {
+		File     string `json:"file"`
+		Entries  uint64 `json:"entries"`
+		Checksum string `json:"checksum"`
+	}
+
+	// SnapshotOption sets the parameters of snapshot export and import
+	SnapshotOption func(*snapshotConfig)
+
+	snapshotConfig struct {
+		chunkSize int
+		limiter   *rate.Limiter
+	}
+)
+
+// SnapshotChunkSizeOption sets the max bytes of a chunk
+func SnapshotChunkSizeOption(size int) SnapshotOption {
+	return func(cfg *snapshotConfig) {
+		cfg.chunkSize = size
+	}
+}
+
+// SnapshotRateLimitOption limits the bytes of states exported per second
+func SnapshotRateLimitOption(bytesPerSecond int) SnapshotOption {
+	return func(cfg *snapshotConfig) {
+		if bytesPerSecond > 0 {
+			cfg.limiter = rate.NewLimiter(rate.Limit(bytesPerSecond), bytesPerSecond)
+		}
+	}
+}
+
+func newSnapshotConfig(opts ...SnapshotOption) *snapshotConfig {
+	cfg := &snapshotConfig{
+		chunkSize: _defaultSnapshotChunkSize,
+		limiter:   rate.NewLimiter(rate.Inf, math.MaxInt),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+func (cfg *snapshotConfig) wait(ctx context.Context, n int) error {
+	for burst := cfg.limiter.Burst(); n > burst; n -= burst {
+		if err := cfg.limiter.WaitN(ctx, burst); err != nil {
+			return err
+		}
+	}
+	return cfg.limiter.WaitN(ctx, n)
+}
+
+// ReadSnapshotManifest reads the manifest of the snapshot in dir
+func ReadSnapshotManifest(dir string) (*SnapshotManifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, SnapshotManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	manifest := &SnapshotManifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, errors.Wrap(err, "failed to parse snapshot manifest")
+	}
+	return manifest, nil
+}
+
+// WriteSnapshotBlock writes the block at snapshot height into the snapshot in dir, which is used to
+// initialize the chain db of the importing node
+func WriteSnapshotBlock(dir string, blk *block.Store) error {
+	data, err := blk.Serialize()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, SnapshotBlockFile), data, 0600)
+}
+
+// ReadSnapshotBlock reads the block at snapshot height from the snapshot in dir
+func ReadSnapshotBlock(dir string, deser *block.Deserializer) (*block.Store, error) {
+	data, err := os.ReadFile(filepath.Join(dir, SnapshotBlockFile))
+	if err != nil {
+		return nil, err
+	}
+	return deser.DeserializeBlockStore(data)
+}
+
+func (m *SnapshotManifest) write(dir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	// write into a temporary file first, so an interrupted export always leaves a valid manifest
+	tmp := filepath.Join(dir, SnapshotManifestFile+".tmp")
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(dir, SnapshotManifestFile))
+}
+
+func (m *SnapshotManifest) namespace(name string) *SnapshotNamespace {
+	for _, ns := range m.Namespaces {
+		if ns.Name == name {
+			return ns
+		}
+	}
+	ns := &SnapshotNamespace{Name: name}
+	m.Namespaces = append(m.Namespaces, ns)
+	return ns
+}
+
+// ExportSnapshot exports the states at the tip height into the snapshot in dir, and returns the manifest.
+// The states are read from a copy of the state db, so that the block commits are not blocked during the
+// export. An interrupted export is resumed from the same copy by calling it again with the same dir
+func (sf *factory) ExportSnapshot(ctx context.Context, dir string, blockHash func(uint64) (hash.Hash256, error), opts ...SnapshotOption) (*SnapshotManifest, error) {
+	dao, ok := sf.dao.(db.KVStoreWithCheckpoint)
+	if !ok {
+		return nil, errors.Wrap(ErrNotSupported, "state db doesn't support checkpoint")
+	}
+	manifest, err := ReadSnapshotManifest(dir)
+	switch {
+	case err == nil:
+		if manifest.Complete {
+			return manifest, nil
+		}
+	case os.IsNotExist(errors.Cause(err)):
+		manifest = nil
+	default:
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	cpPath := filepath.Join(dir, SnapshotCheckpointFile)
+	cp, err := dao.Checkpoint(cpPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to checkpoint state db")
+	}
+	if err := cp.Start(ctx); err != nil {
+		return nil, err
+	}
+	manifest, err = sf.exportSnapshot(ctx, cp, dir, manifest, blockHash, newSnapshotConfig(opts...))
+	if err := cp.Stop(ctx); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return manifest, os.Remove(cpPath)
+}
+
+func (sf *factory) exportSnapshot(
+	ctx context.Context,
+	cp db.KVStoreWithBuckets,
+	dir string,
+	manifest *SnapshotManifest,
+	blockHash func(uint64) (hash.Hash256, error),
+	cfg *snapshotConfig,
+) (*SnapshotManifest, error) {
+	h, err := cp.Get(AccountKVNamespace, []byte(CurrentHeightKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get snapshot height")
+	}
+	height := byteutil.BytesToUint64(h)
+	tlt, err := newTwoLayerTrie(ArchiveTrieNamespace, cp, ArchiveTrieRootKey, false, sf.trieOptions()...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to generate trie for %d", height)
+	}
+	if err := tlt.Start(ctx); err != nil {
+		return nil, err
+	}
+	defer tlt.Stop(ctx)
+	root, err := tlt.RootHash()
+	if err != nil {
+		return nil, err
+	}
+	if manifest == nil {
+		blkHash, err := blockHash(height)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get hash of block %d", height)
+		}
+		manifest = &SnapshotManifest{
+			Height:    height,
+			BlockHash: hex.EncodeToString(blkHash[:]),
+			Root:      hex.EncodeToString(root),
+		}
+	} else {
+		if manifest.Height != height || manifest.Root != hex.EncodeToString(root) {
+			return nil, errors.Wrapf(ErrSnapshotMismatch, "%s contains the snapshot at height %d", dir, manifest.Height)
+		}
+		log.Logger("statefactory").Info("Resume exporting snapshot.", zap.Uint64("height", height), zap.String("dir", dir))
+	}
+
+	// the namespaces are those with a layer two trie
+	nsKeys := make(map[hash.Hash160]struct{})
+	nsIter, err := mptrie.NewLayerOneLeafIterator(tlt)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		nsKey, _, err := nsIter.Next()
+		if err == trie.ErrEndOfIterator {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		nsKeys[hash.BytesToHash160(nsKey)] = struct{}{}
+	}
+	names, err := cp.Buckets()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		nsKey := hash.Hash160b([]byte(name))
+		if _, ok := nsKeys[nsKey]; !ok {
+			continue
+		}
+		delete(nsKeys, nsKey)
+		if err := exportSnapshotNamespace(ctx, cfg, cp, tlt, dir, manifest, name); err != nil {
+			return nil, errors.Wrapf(err, "failed to export namespace %s", name)
+		}
+	}
+	if len(nsKeys) > 0 {
+		return nil, errors.Errorf("failed to find %d namespaces of the state trie in state db", len(nsKeys))
+	}
+	manifest.Complete = true
+	return manifest, manifest.write(dir)
+}
+
+func exportSnapshotNamespace(
+	ctx context.Context,
+	cfg *snapshotConfig,
+	cp db.KVStoreWithBuckets,
+	tlt trie.TwoLayerTrie,
+	dir string,
+	manifest *SnapshotManifest,
+	name string,
+) error {
+	ns := manifest.namespace(name)
+	if ns.Complete {
+		return nil
+	}
+	// skip the states exported before the interruption
+	var exported, i uint64
+	for _, chunk := range ns.Chunks {
+		exported += chunk.Entries
+	}
+	var (
+		nsKey   = namespaceKey(name)
+		buf     bytes.Buffer
+		entries uint64
+		flush   = func() error {
+			if entries == 0 {
+				return nil
+			}
+			chunk := &SnapshotChunk{
+				File:    fmt.Sprintf("%x-%d.chunk", nsKey, len(ns.Chunks)),
+				Entries: entries,
+			}
+			h := hash.Hash256b(buf.Bytes())
+			chunk.Checksum = hex.EncodeToString(h[:])
+			if err := os.WriteFile(filepath.Join(dir, chunk.File), buf.Bytes(), 0600); err != nil {
+				return err
+			}
+			ns.Chunks = append(ns.Chunks, chunk)
+			buf.Reset()
+			entries = 0
+			return manifest.write(dir)
+		}
+	)
+	if err := cp.ForEach(name, func(key, value []byte) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// the namespace may hold records other than states, e.g., the heights in account namespace,
+		// only those in the state trie are exported
+		state, err := tlt.Get(nsKey, toLegacyKey(key))
+		switch errors.Cause(err) {
+		case nil:
+		case trie.ErrNotExist:
+			return nil
+		default:
+			return err
+		}
+		if !bytes.Equal(state, value) {
+			return errors.Wrapf(ErrSnapshotMismatch, "state of key %x doesn't match the state trie", key)
+		}
+		if i++; i <= exported {
+			return nil
+		}
+		if err := cfg.wait(ctx, len(key)+len(value)); err != nil {
+			return err
+		}
+		writeSnapshotRecord(&buf, key, value)
+		entries++
+		if buf.Len() >= cfg.chunkSize {
+			return flush()
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	ns.Complete = true
+	return manifest.write(dir)
+}
+
+// ImportSnapshot rebuilds the states from the snapshot in dir into an empty state db, and marks the
+// state db as initialized from the snapshot. The block header doesn't commit to the state root, the
+// snapshot is bound to the header at the snapshot height by the block hash in the manifest instead
+func ImportSnapshot(ctx context.Context, dao db.KVStore, dir string, header *block.Header) error {
+	manifest, err := ReadSnapshotManifest(dir)
+	if err != nil {
+		return err
+	}
+	if !manifest.Complete {
+		return ErrSnapshotIncomplete
+	}
+	blkHash := header.HashBlock()
+	if manifest.Height != header.Height() || manifest.BlockHash != hex.EncodeToString(blkHash[:]) {
+		return errors.Wrapf(ErrSnapshotMismatch, "snapshot of block %s at height %d doesn't match block %x at height %d", manifest.BlockHash, manifest.Height, blkHash, header.Height())
+	}
+	_, err = dao.Get(AccountKVNamespace, []byte(CurrentHeightKey))
+	switch errors.Cause(err) {
+	case nil:
+		return errors.New("failed to import snapshot into a non-empty state db")
+	case db.ErrNotExist, db.ErrBucketNotExist:
+	default:
+		return err
+	}
+
+	flusher, err := db.NewKVStoreFlusher(dao, batch.NewCachedBatch())
+	if err != nil {
+		return err
+	}
+	tlt, err := newTwoLayerTrie(ArchiveTrieNamespace, flusher.KVStoreWithBuffer(), ArchiveTrieRootKey, true)
+	if err != nil {
+		return err
+	}
+	if err := tlt.Start(ctx); err != nil {
+		return err
+	}
+	for _, ns := range manifest.Namespaces {
+		for _, chunk := range ns.Chunks {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := importSnapshotChunk(flusher.KVStoreWithBuffer(), tlt, dir, ns.Name, chunk); err != nil {
+				return errors.Wrapf(err, "failed to import chunk %s", chunk.File)
+			}
+			// write the trie nodes of each chunk into db, to keep the buffer from growing too large
+			if _, err := tlt.RootHash(); err != nil {
+				return err
+			}
+			if err := flusher.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+	root, err := tlt.RootHash()
+	if err != nil {
+		return err
+	}
+	if err := tlt.Stop(ctx); err != nil {
+		return err
+	}
+	if hex.EncodeToString(root) != manifest.Root {
+		return errors.Wrapf(ErrSnapshotMismatch, "state root %x doesn't match the root %s in manifest", root, manifest.Root)
+	}
+	if err := flusher.Flush(); err != nil {
+		return err
+	}
+	b := batch.NewBatch()
+	b.Put(ArchiveTrieNamespace, []byte(ArchiveTrieRootKey), root, "failed to put trie root")
+	b.Put(ArchiveTrieNamespace, []byte(fmt.Sprintf("%s-%d", ArchiveTrieRootKey, manifest.Height)), root, "failed to put history trie root")
+	b.Put(AccountKVNamespace, []byte(SnapshotHeightKey), byteutil.Uint64ToBytes(manifest.Height), "failed to put snapshot height")
+	b.Put(AccountKVNamespace, []byte(CurrentHeightKey), byteutil.Uint64ToBytes(manifest.Height), "failed to put current height")
+	return dao.WriteBatch(b)
+}
+
+func importSnapshotChunk(kvStore db.KVStoreWithBuffer, tlt trie.TwoLayerTrie, dir string, ns string, chunk *SnapshotChunk) error {
+	data, err := os.ReadFile(filepath.Join(dir, chunk.File))
+	if err != nil {
+		return err
+	}
+	h := hash.Hash256b(data)
+	if hex.EncodeToString(h[:]) != chunk.Checksum {
+		return errors.Wrapf(ErrSnapshotMismatch, "checksum %x doesn't match %s", h, chunk.Checksum)
+	}
+	var (
+		nsKey   = namespaceKey(ns)
+		entries uint64
+	)
+	for len(data) > 0 {
+		var key, value []byte
+		if key, value, data, err = readSnapshotRecord(data); err != nil {
+			return err
+		}
+		kvStore.MustPut(ns, key, value)
+		if err := tlt.Upsert(nsKey, toLegacyKey(key), value); err != nil {
+			return err
+		}
+		entries++
+	}
+	if entries != chunk.Entries {
+		return errors.Wrapf(ErrSnapshotMismatch, "chunk has %d entries, expecting %d", entries, chunk.Entries)
+	}
+	return nil
+}
+
+// writeSnapshotRecord writes a record of key and value, each of which is prefixed with its length
+func writeSnapshotRecord(buf *bytes.Buffer, key, value []byte) {
+	var l [binary.MaxVarintLen64]byte
+	buf.Write(l[:binary.PutUvarint(l[:], uint64(len(key)))])
+	buf.Write(key)
+	buf.Write(l[:binary.PutUvarint(l[:], uint64(len(value)))])
+	buf.Write(value)
+}
+
+func readSnapshotRecord(data []byte) ([]byte, []byte, []byte, error) {
+	fields := make([][]byte, 2)
+	for i := range fields {
+		l, n := binary.Uvarint(data)
+		if n <= 0 || uint64(len(data)-n) < l {
+			return nil, nil, nil, errors.Wrap(ErrSnapshotMismatch, "invalid record")
+		}
+		fields[i], data = data[n:n+int(l)], data[n+int(l):]
+	}
+	return fields[0], fields[1], data, nil
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestSnapshotExportImport(t *testing.T) {
+	r := require.New(t)
+	var err error
+	cfg := DefaultConfig
+	cfg.Chain.TrieDBPath, err = testutil.PathOfTempFile(_triePath)
+	r.NoError(err)
+	defer testutil.CleanupPath(cfg.Chain.TrieDBPath)
+	cfg.Chain.EnableArchiveMode = true
+	db1, err := db.CreateKVStore(db.DefaultConfig, cfg.Chain.TrieDBPath)
+	r.NoError(err)
+	sf, err := NewFactory(cfg, db1, SkipBlockValidationOption())
+	r.NoError(err)
+
+	a := identityset.Address(28)
+	b := identityset.Address(31)
+	priKeyA := identityset.PrivateKey(28)
+	r.NoError(sf.Register(account.NewProtocol(rewarding.DepositGas)))
+	ge := genesis.Default
+	ge.InitBalanceMap[a.String()] = "100"
+	gasLimit := uint64(1000000)
+	ctx := protocol.WithBlockCtx(context.Background(), protocol.BlockCtx{
+		BlockHeight: 0,
+		Producer:    identityset.Address(27),
+		GasLimit:    gasLimit,
+	})
+	ctx = genesis.WithGenesisContext(ctx, ge)
+	ctx = protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{ChainID: 1})
+	r.NoError(sf.Start(ctx))
+	defer func() {
+		r.NoError(sf.Stop(ctx))
+	}()
+
+	newBlock := func(height uint64, prevHash hash.Hash256) *block.Block {
+		tsf, err := action.NewTransfer(height, big.NewInt(10), b.String(), nil, uint64(20000), big.NewInt(0))
+		r.NoError(err)
+		elp := (&action.EnvelopeBuilder{}).SetAction(tsf).SetGasLimit(20000).SetNonce(height).Build()
+		selp, err := action.Sign(elp, priKeyA)
+		r.NoError(err)
+		blk, err := block.NewTestingBuilder().
+			SetHeight(height).
+			SetPrevBlockHash(prevHash).
+			SetTimeStamp(testutil.TimestampNow()).
+			AddActions(selp).
+			SignAndBuild(identityset.PrivateKey(27))
+		r.NoError(err)
+		return &blk
+	}
+	blkCtx := func(height uint64) context.Context {
+		return protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight: height,
+			Producer:    identityset.Address(27),
+			GasLimit:    gasLimit,
+		})
+	}
+	blks := []*block.Block{}
+	prevHash := hash.ZeroHash256
+	for i := uint64(1); i <= 3; i++ {
+		blk := newBlock(i, prevHash)
+		blks = append(blks, blk)
+		prevHash = blk.HashBlock()
+	}
+	blockHash := func(height uint64) (hash.Hash256, error) {
+		return blks[height-1].HashBlock(), nil
+	}
+	for _, blk := range blks[:2] {
+		r.NoError(sf.PutBlock(blkCtx(blk.Height()), blk))
+	}
+
+	// export the snapshot at height 2
+	dir := filepath.Join(t.TempDir(), "snapshot")
+	exporter := sf.(SnapshotExporter)
+	manifest, err := exporter.ExportSnapshot(ctx, dir, blockHash, SnapshotChunkSizeOption(64))
+	r.NoError(err)
+	r.True(manifest.Complete)
+	r.EqualValues(2, manifest.Height)
+	_, err = os.Stat(filepath.Join(dir, SnapshotCheckpointFile))
+	r.True(os.IsNotExist(err))
+	read, err := ReadSnapshotManifest(dir)
+	r.NoError(err)
+	r.Equal(manifest, read)
+	read, err = exporter.ExportSnapshot(ctx, dir, blockHash)
+	r.NoError(err)
+	r.Equal(manifest, read)
+
+	// resume an interrupted export
+	interrupt := func() {
+		ns := manifest.Namespaces[len(manifest.Namespaces)-1]
+		r.NotEmpty(ns.Chunks)
+		interrupted := *manifest
+		interrupted.Complete = false
+		interrupted.Namespaces = append([]*SnapshotNamespace{}, manifest.Namespaces...)
+		interrupted.Namespaces[len(interrupted.Namespaces)-1] = &SnapshotNamespace{Name: ns.Name, Chunks: ns.Chunks[:len(ns.Chunks)-1]}
+		r.NoError(interrupted.write(dir))
+	}
+	interrupt()
+	resumed, err := exporter.ExportSnapshot(ctx, dir, blockHash, SnapshotChunkSizeOption(64), SnapshotRateLimitOption(1<<20))
+	r.NoError(err)
+	r.Equal(manifest, resumed)
+	// the states have changed since the interruption
+	r.NoError(sf.PutBlock(blkCtx(3), blks[2]))
+	interrupt()
+	_, err = exporter.ExportSnapshot(ctx, dir, blockHash, SnapshotChunkSizeOption(64))
+	r.Equal(ErrSnapshotMismatch, errors.Cause(err))
+	r.NoError(os.Remove(filepath.Join(dir, SnapshotCheckpointFile)))
+	r.NoError(manifest.write(dir))
+
+	// import the snapshot
+	testPath, err := testutil.PathOfTempFile(_triePath)
+	r.NoError(err)
+	defer testutil.CleanupPath(testPath)
+	db2, err := db.CreateKVStore(db.DefaultConfig, testPath)
+	r.NoError(err)
+	r.NoError(db2.Start(ctx))
+	r.Equal(ErrSnapshotMismatch, errors.Cause(ImportSnapshot(ctx, db2, dir, &blks[2].Header)))
+	r.NoError(ImportSnapshot(ctx, db2, dir, &blks[1].Header))
+	r.Error(ImportSnapshot(ctx, db2, dir, &blks[1].Header))
+	h, err := db2.Get(AccountKVNamespace, []byte(SnapshotHeightKey))
+	r.NoError(err)
+	r.EqualValues(2, byteutil.BytesToUint64(h))
+	r.NoError(db2.Stop(ctx))
+
+	cfg.Chain.EnableArchiveMode = false
+	cfg.Chain.TrieDBPath = testPath
+	db2, err = db.CreateKVStore(db.DefaultConfig, testPath)
+	r.NoError(err)
+	sf2, err := NewFactory(cfg, db2, SkipBlockValidationOption())
+	r.NoError(err)
+	r.NoError(sf2.Register(account.NewProtocol(rewarding.DepositGas)))
+	r.NoError(sf2.Start(ctx))
+	defer func() {
+		r.NoError(sf2.Stop(ctx))
+	}()
+	height, err := sf2.Height()
+	r.NoError(err)
+	r.EqualValues(2, height)
+	accountA, err := accountutil.AccountState(ctx, sf2, a)
+	r.NoError(err)
+	r.Equal(big.NewInt(80), accountA.Balance)
+	// blocks after the snapshot height are appended
+	r.NoError(sf2.PutBlock(blkCtx(3), blks[2]))
+	accountB, err := accountutil.AccountState(ctx, sf2, b)
+	r.NoError(err)
+	r.Equal(big.NewInt(30), accountB.Balance)
+}
+
+func TestImportCorruptedSnapshot(t *testing.T) {
+	r := require.New(t)
+	dir := t.TempDir()
+	blk, err := block.NewTestingBuilder().
+		SetHeight(1).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetTimeStamp(testutil.TimestampNow()).
+		SignAndBuild(identityset.PrivateKey(27))
+	r.NoError(err)
+	h := blk.HashBlock()
+	manifest := &SnapshotManifest{
+		Height:    1,
+		BlockHash: hex.EncodeToString(h[:]),
+		Complete:  true,
+		Namespaces: []*SnapshotNamespace{{
+			Name:     "ns",
+			Chunks:   []*SnapshotChunk{{File: "00-0.chunk", Entries: 1, Checksum: "00"}},
+			Complete: true,
+		}},
+	}
+	r.NoError(manifest.write(dir))
+	r.NoError(os.WriteFile(filepath.Join(dir, "00-0.chunk"), []byte{1, 2}, 0600))
+	dao := db.NewMemKVStore()
+	r.NoError(dao.Start(context.Background()))
+	r.Equal(ErrSnapshotMismatch, errors.Cause(ImportSnapshot(context.Background(), dao, dir, &blk.Header)))
+
+	manifest.Complete = false
+	r.NoError(manifest.write(dir))
+	r.Equal(ErrSnapshotIncomplete, errors.Cause(ImportSnapshot(context.Background(), dao, dir, &blk.Header)))
+}
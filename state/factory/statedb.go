@@ -107,7 +107,7 @@ func NewStateDB(cfg Config, dao db.KVStore, opts ...StateDBOption) (Factory, err
 		[]string{"default", strconv.FormatUint(uint64(cfg.Chain.ID), 10)},
 	)
 	if err != nil {
-		log.L().Error("Failed to generate prometheus timer factory.", zap.Error(err))
+		log.Logger("statefactory").Error("Failed to generate prometheus timer factory.", zap.Error(err))
 	}
 	sdb.timerFactory = timerFactory
 	return &sdb, nil
@@ -127,6 +127,9 @@ func (sdb *stateDB) Start(ctx context.Context) error {
 		if sdb.protocolView, err = sdb.registry.StartAll(ctx, sdb); err != nil {
 			return err
 		}
+		if err = verifyProtocolViewOnStart(ctx, sdb.cfg.Chain, sdb.registry, sdb, sdb.protocolView); err != nil {
+			return err
+		}
 	case db.ErrNotExist:
 		sdb.currentChainHeight = 0
 		if err = sdb.dao.Put(AccountKVNamespace, []byte(CurrentHeightKey), byteutil.Uint64ToBytes(0)); err != nil {
@@ -196,7 +199,9 @@ func (sdb *stateDB) newWorkingSet(ctx context.Context, height uint64) (*workingS
 		return nil, err
 	}
 
-	return newWorkingSet(height, store), nil
+	ws := newWorkingSet(height, store)
+	ws.parallel = sdb.cfg.Chain.EnableParallelExecution
+	return ws, nil
 }
 
 func (sdb *stateDB) Register(p protocol.Protocol) error {
@@ -270,6 +275,7 @@ func (sdb *stateDB) SimulateExecution(
 ) ([]byte, *action.Receipt, error) {
 	ctx, span := tracer.NewSpan(ctx, "stateDB.SimulateExecution")
 	defer span.End()
+	ctx = protocol.WithRegistry(ctx, sdb.registry)
 
 	sdb.mutex.RLock()
 	currHeight := sdb.currentChainHeight
@@ -282,6 +288,17 @@ func (sdb *stateDB) SimulateExecution(
 	return evm.SimulateExecution(ctx, ws, caller, ex)
 }
 
+// SimulationWorkingSet returns a working set on top of the state at tip height to run simulations
+func (sdb *stateDB) SimulationWorkingSet(ctx context.Context, height uint64) (protocol.StateManager, error) {
+	sdb.mutex.RLock()
+	currHeight := sdb.currentChainHeight
+	sdb.mutex.RUnlock()
+	if height != currHeight {
+		return nil, errors.Wrap(ErrNotSupported, "state db does not support archive mode")
+	}
+	return sdb.newWorkingSet(ctx, currHeight+1)
+}
+
 // ReadContractStorage reads contract's storage
 func (sdb *stateDB) ReadContractStorage(ctx context.Context, contract address.Address, key []byte) ([]byte, error) {
 	sdb.mutex.RLock()
@@ -300,6 +317,9 @@ func (sdb *stateDB) PutBlock(ctx context.Context, blk *block.Block) error {
 	timer := sdb.timerFactory.NewTimer("Commit")
 	sdb.mutex.Unlock()
 	defer timer.End()
+	if err := sdb.checkFinalized(blk); err != nil {
+		return err
+	}
 	producer := blk.PublicKey().Address()
 	if producer == nil {
 		return errors.New("failed to get address")
@@ -308,13 +328,18 @@ func (sdb *stateDB) PutBlock(ctx context.Context, blk *block.Block) error {
 	ctx = protocol.WithBlockCtx(
 		protocol.WithRegistry(ctx, sdb.registry),
 		protocol.BlockCtx{
-			BlockHeight:    blk.Height(),
-			BlockTimeStamp: blk.Timestamp(),
-			GasLimit:       g.BlockGasLimitByHeight(blk.Height()),
-			Producer:       producer,
+			BlockHeight:     blk.Height(),
+			BlockTimeStamp:  blk.Timestamp(),
+			GasLimit:        g.BlockGasLimitByHeight(blk.Height()),
+			Producer:        producer,
+			RandomnessProof: blk.RandomnessProof(),
 		},
 	)
 	ctx = protocol.WithFeatureCtx(ctx)
+	if sdb.cfg.Chain.VerifyProtocolViewAtEpoch {
+		// verified once the block is committed and the lock is released
+		defer verifyProtocolViewAtEpoch(ctx, sdb.cfg.Chain, sdb.registry, sdb, sdb.protocolView)
+	}
 	key := generateWorkingSetCacheKey(blk.Header, blk.Header.ProducerAddress())
 	ws, isExist, err := sdb.getFromWorkingSets(ctx, key)
 	if err != nil {
@@ -327,7 +352,7 @@ func (sdb *stateDB) PutBlock(ctx context.Context, blk *block.Block) error {
 			err = ws.Process(ctx, blk.RunnableActions().Actions())
 		}
 		if err != nil {
-			log.L().Error("Failed to update state.", zap.Error(err))
+			log.Logger("statefactory").Error("Failed to update state.", zap.Error(err))
 			return err
 		}
 	}
@@ -350,10 +375,24 @@ func (sdb *stateDB) PutBlock(ctx context.Context, blk *block.Block) error {
 	if err := ws.Commit(ctx); err != nil {
 		return err
 	}
+	// the cached workingsets are all on top of the previous height, which cannot be committed anymore
+	sdb.workingsets.Clear()
 	sdb.currentChainHeight = h
 	return nil
 }
 
+// checkFinalized refuses the block at or below the current height, whose states are final
+func (sdb *stateDB) checkFinalized(blk *block.Block) error {
+	sdb.mutex.RLock()
+	height := sdb.currentChainHeight
+	sdb.mutex.RUnlock()
+	if blk.Height() > height {
+		return nil
+	}
+	log.Logger("statefactory").Error("Refused to overwrite the states of a finalized block.", zap.Uint64("height", blk.Height()), zap.Uint64("currentHeight", height))
+	return errors.Wrapf(block.ErrFinalizedHeight, "block %d is at or below the current height %d", blk.Height(), height)
+}
+
 func (sdb *stateDB) DeleteTipBlock(_ context.Context, _ *block.Block) error {
 	return errors.Wrap(ErrNotSupported, "cannot delete tip block from state db")
 }
@@ -405,6 +444,38 @@ func (sdb *stateDB) StatesAtHeight(height uint64, opts ...protocol.StateOption)
 	return nil, errors.Wrap(ErrNotSupported, "state db does not support archive mode")
 }
 
+// EarliestStateHeight returns the earliest height whose state can be queried
+func (sdb *stateDB) EarliestStateHeight() uint64 {
+	sdb.mutex.RLock()
+	defer sdb.mutex.RUnlock()
+	return sdb.currentChainHeight
+}
+
+// WorkingSetAtHeight returns a read-only working set at height -- archive mode
+func (sdb *stateDB) WorkingSetAtHeight(context.Context, uint64) (protocol.StateManager, error) {
+	return nil, errors.Wrap(ErrNotSupported, "state db does not support archive mode")
+}
+
+// StateReaderAtHeight returns a read-only state reader at height -- archive mode
+func (sdb *stateDB) StateReaderAtHeight(context.Context, uint64) (protocol.StateReader, error) {
+	return nil, errors.Wrap(ErrNotSupported, "state db does not support archive mode")
+}
+
+// ReplayBlock replays a block on the state of its parent -- archive mode
+func (sdb *stateDB) ReplayBlock(context.Context, *block.Block, int, func(context.Context, int) (context.Context, error)) ([]*action.Receipt, error) {
+	return nil, errors.Wrap(ErrNotSupported, "state db does not support archive mode")
+}
+
+// IterateAccounts iterates the accounts at height -- archive mode
+func (sdb *stateDB) IterateAccounts(context.Context, uint64, []byte, []byte, uint64, func(address.Address, *state.Account) error) ([]byte, error) {
+	return nil, errors.Wrap(ErrNotSupported, "state db does not support archive mode")
+}
+
+// IterateContractStorage iterates the contract storage at height -- archive mode
+func (sdb *stateDB) IterateContractStorage(context.Context, uint64, address.Address, []byte, uint64, func([]byte, []byte) error) ([]byte, error) {
+	return nil, errors.Wrap(ErrNotSupported, "state db does not support archive mode")
+}
+
 // ReadView reads the view
 func (sdb *stateDB) ReadView(name string) (interface{}, error) {
 	return sdb.protocolView.Read(name)
@@ -462,16 +533,20 @@ func (sdb *stateDB) createGenesisStates(ctx context.Context) error {
 
 // getFromWorkingSets returns (workingset, true) if it exists in a cache, otherwise generates new workingset and return (ws, false)
 func (sdb *stateDB) getFromWorkingSets(ctx context.Context, key hash.Hash256) (*workingSet, bool, error) {
+	sdb.mutex.RLock()
+	currHeight := sdb.currentChainHeight
+	sdb.mutex.RUnlock()
 	if data, ok := sdb.workingsets.Get(key); ok {
-		if ws, ok := data.(*workingSet); ok {
-			// if it is already validated, return workingset
+		ws, ok := data.(*workingSet)
+		if !ok {
+			return nil, false, errors.New("type assertion failed to be WorkingSet")
+		}
+		// if it is already validated on top of the current height, return workingset
+		if ws.height == currHeight+1 {
 			return ws, true, nil
 		}
-		return nil, false, errors.New("type assertion failed to be WorkingSet")
+		sdb.workingsets.Remove(key)
 	}
-	sdb.mutex.RLock()
-	currHeight := sdb.currentChainHeight
-	sdb.mutex.RUnlock()
 	tx, err := sdb.newWorkingSet(ctx, currHeight+1)
 	return tx, false, err
 }
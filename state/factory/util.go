@@ -12,14 +12,18 @@ import (
 	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/pkg/errors"
+	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/db/trie"
 	"github.com/iotexproject/iotex-core/db/trie/mptrie"
+	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/state"
 )
 
@@ -197,7 +201,7 @@ func readStates(kvStore db.KVStore, namespace string, keys [][]byte) ([][]byte,
 	return ks, values, nil
 }
 
-func newTwoLayerTrie(ns string, dao db.KVStore, rootKey string, create bool) (trie.TwoLayerTrie, error) {
+func newTwoLayerTrie(ns string, dao db.KVStore, rootKey string, create bool, opts ...mptrie.Option) (trie.TwoLayerTrie, error) {
 	dbForTrie, err := trie.NewKVStore(ns, dao)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create db for trie")
@@ -213,5 +217,29 @@ func newTwoLayerTrie(ns string, dao db.KVStore, rootKey string, create bool) (tr
 	default:
 		return nil, err
 	}
-	return mptrie.NewTwoLayerTrie(dbForTrie, rootKey), nil
+	return mptrie.NewTwoLayerTrie(dbForTrie, rootKey, opts...), nil
+}
+
+// verifyProtocolViewOnStart verifies the views of the protocols loaded on start against the states, per the config
+func verifyProtocolViewOnStart(ctx context.Context, cfg blockchain.Config, reg *protocol.Registry, sr protocol.StateReader, view protocol.View) error {
+	if cfg.VerifyProtocolViewOnStart == "" {
+		return nil
+	}
+	return reg.VerifyAll(ctx, sr, view, cfg.VerifyProtocolViewOnStart == blockchain.ProtocolViewRepair)
+}
+
+// verifyProtocolViewAtEpoch verifies the views of the protocols after the first block of an epoch is committed, a
+// mismatch is logged rather than failing the block which has been committed
+func verifyProtocolViewAtEpoch(ctx context.Context, cfg blockchain.Config, reg *protocol.Registry, sr protocol.StateReader, view protocol.View) {
+	height, err := sr.Height()
+	if err != nil {
+		return
+	}
+	rp := rolldpos.FindProtocol(reg)
+	if rp == nil || height != rp.GetEpochHeight(rp.GetEpochNum(height)) {
+		return
+	}
+	if err := reg.VerifyAll(ctx, sr, view, cfg.VerifyProtocolViewOnStart == blockchain.ProtocolViewRepair); err != nil {
+		log.L().Error("Protocol view mismatches states.", zap.Uint64("height", height), zap.Error(err))
+	}
 }
@@ -7,6 +7,7 @@ package factory
 
 import (
 	"context"
+	"encoding/hex"
 	"sort"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -21,6 +22,8 @@ import (
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/actpool/actioniterator"
 	"github.com/iotexproject/iotex-core/blockchain/block"
@@ -54,6 +57,10 @@ type (
 		finalized bool
 		dock      protocol.Dock
 		receipts  []*action.Receipt
+		// parallel runs the transfers of a block touching disjoint accounts in parallel
+		parallel bool
+		// systemLogs are the logs emitted out of any action, pending for the first system action of the block
+		systemLogs []*action.Log
 	}
 )
 
@@ -102,6 +109,16 @@ func (ws *workingSet) validate(ctx context.Context) error {
 func (ws *workingSet) runActions(
 	ctx context.Context,
 	elps []*action.SealedEnvelope,
+) ([]*action.Receipt, error) {
+	if ws.parallel {
+		return ws.runActionsInParallel(ctx, elps)
+	}
+	return ws.runActionsSerially(ctx, elps)
+}
+
+func (ws *workingSet) runActionsSerially(
+	ctx context.Context,
+	elps []*action.SealedEnvelope,
 ) ([]*action.Receipt, error) {
 	// Handle actions
 	receipts := make([]*action.Receipt, 0)
@@ -135,12 +152,13 @@ func withActionCtx(ctx context.Context, selp *action.SealedEnvelope) (context.Co
 		return nil, err
 	}
 	actionCtx.GasPrice = selp.GasPrice()
-	intrinsicGas, err := selp.IntrinsicGas()
+	intrinsicGas, err := action.IntrinsicGasBySchedule(selp.Action(), protocol.DataCostSchedule(ctx))
 	if err != nil {
 		return nil, err
 	}
 	actionCtx.IntrinsicGas = intrinsicGas
 	actionCtx.Nonce = selp.Nonce()
+	actionCtx.GasPayer = selp.GasPayer()
 
 	return protocol.WithActionCtx(ctx, actionCtx), nil
 }
@@ -182,11 +200,41 @@ func (ws *workingSet) runAction(
 		return nil, errors.Wrapf(err, "Failed to get hash")
 	}
 	defer ws.ResetSnapshots()
+	fCtx := protocol.MustGetFeatureCtx(ctx)
+	var snapshot int
+	if fCtx.IncludeFailedActions {
+		snapshot = ws.Snapshot()
+	}
 	if err := ws.freshAccountConversion(ctx, &actCtx); err != nil {
 		return nil, err
 	}
+	for _, p := range reg.All() {
+		if ph, ok := p.(protocol.PreActionHandler); ok {
+			if err := ph.PreHandle(ctx, selp.Action(), ws); err != nil {
+				return nil, errors.Wrapf(err, "failed to prepare the states for action %x", selpHash)
+			}
+		}
+	}
+	// a failed action keeps the changes of the pre-handlers, while a skipped one reverts them all
+	var prepared int
+	if fCtx.IncludeFailedActions {
+		prepared = ws.Snapshot()
+	}
 	for _, actionHandler := range reg.All() {
 		receipt, err := actionHandler.Handle(ctx, selp.Action(), ws)
+		if status, ok := protocol.ExecutionFailureStatus(err); fCtx.IncludeFailedActions && ok {
+			log.L().Debug("Action failed in execution", zap.String("actionHash", hex.EncodeToString(selpHash[:])), zap.Error(err))
+			if err := ws.Revert(prepared); err != nil {
+				return nil, errors.Wrapf(err, "failed to revert the changes of action %x", selpHash)
+			}
+			receipt, err = ws.settleFailedAction(ctx, selp, status)
+		}
+		if fCtx.IncludeFailedActions && protocol.IsAdmissionError(err) {
+			// the action is skipped, so none of its changes is kept
+			if revertErr := ws.Revert(snapshot); revertErr != nil {
+				return nil, errors.Wrapf(revertErr, "failed to revert the changes of action %x", selpHash)
+			}
+		}
 		if err != nil {
 			return nil, errors.Wrapf(
 				err,
@@ -195,12 +243,71 @@ func (ws *workingSet) runAction(
 			)
 		}
 		if receipt != nil {
+			if actCtx.GasPayer != nil {
+				receipt.SetGasPayer(actCtx.GasPayer.String())
+			}
+			if len(ws.systemLogs) > 0 && action.IsSystemAction(selp) {
+				ws.attachSystemLogs(receipt)
+			}
 			return receipt, nil
 		}
 	}
 	return nil, errors.New("receipt is empty")
 }
 
+// EmitSystemLogs keeps the logs emitted out of any action, until they are attached to the receipt of the first system
+// action of the block. The logs are dropped if the block has no system action
+func (ws *workingSet) EmitSystemLogs(logs ...*action.Log) {
+	ws.systemLogs = append(ws.systemLogs, logs...)
+}
+
+func (ws *workingSet) attachSystemLogs(receipt *action.Receipt) {
+	for _, l := range ws.systemLogs {
+		l.ActionHash = receipt.ActionHash
+	}
+	receipt.AddLogs(ws.systemLogs...)
+	ws.systemLogs = nil
+}
+
+// settleFailedAction charges the intrinsic gas of an action failed in execution and updates the nonce of the sender,
+// the action is included in the block with a receipt of the status
+func (ws *workingSet) settleFailedAction(ctx context.Context, selp *action.SealedEnvelope, status uint64) (*action.Receipt, error) {
+	var (
+		actCtx = protocol.MustGetActionCtx(ctx)
+		blkCtx = protocol.MustGetBlockCtx(ctx)
+	)
+	gasFee, baseFee, err := protocol.SplitGas(ctx, selp.Envelope, actCtx.IntrinsicGas)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to split gas")
+	}
+	depositLog, err := rewarding.DepositGas(ctx, ws, gasFee, protocol.BurnGasOption(baseFee), protocol.PayerOption(actCtx.GasPayer))
+	if err != nil {
+		// the balance not enough for the gas fails the admission of the action
+		return nil, protocol.NewAdmissionError(errors.Wrap(err, "failed to deposit gas"))
+	}
+	accountCreationOpts := []state.AccountCreationOption{}
+	if protocol.MustGetFeatureCtx(ctx).CreateLegacyNonceAccount {
+		accountCreationOpts = append(accountCreationOpts, state.LegacyNonceAccountTypeOption())
+	}
+	acc, err := accountutil.LoadAccount(ws, actCtx.Caller, accountCreationOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := acc.SetPendingNonce(actCtx.Nonce + 1); err != nil {
+		return nil, errors.Wrap(err, "failed to set nonce")
+	}
+	if err := accountutil.StoreAccount(ws, actCtx.Caller, acc); err != nil {
+		return nil, errors.Wrap(err, "failed to update nonce")
+	}
+	r := &action.Receipt{
+		Status:      status,
+		BlockHeight: blkCtx.BlockHeight,
+		ActionHash:  actCtx.ActionHash,
+		GasConsumed: actCtx.IntrinsicGas,
+	}
+	return r.AddTransactionLogs(depositLog...), nil
+}
+
 func validateChainID(ctx context.Context, chainID uint32) error {
 	blkChainCtx := protocol.MustGetBlockchainCtx(ctx)
 	featureCtx := protocol.MustGetFeatureCtx(ctx)
@@ -258,6 +365,16 @@ func (ws *workingSet) ResetSnapshots() {
 	ws.store.ResetSnapshots()
 }
 
+// Defer defers the change to the replay of the action if the working set is an overlay running a partition of the
+// actions in parallel, otherwise the change is applied right away
+func (ws *workingSet) Defer(f func(protocol.StateManager) error) error {
+	if store, ok := ws.store.(*parallelStore); ok {
+		store.deferChange(f)
+		return nil
+	}
+	return f(ws)
+}
+
 // freshAccountConversion happens between UseZeroNonceForFreshAccount height
 // and RefactorFreshAccountConversion height
 func (ws *workingSet) freshAccountConversion(ctx context.Context, actCtx *protocol.ActionCtx) error {
@@ -423,24 +540,27 @@ func (ws *workingSet) validateNonceSkipSystemAction(ctx context.Context, blk *bl
 	return ws.checkNonceContinuity(ctx, accountNonceMap)
 }
 
+// pendingNonce returns the nonce the next action of the account must have in the working set
+func (ws *workingSet) pendingNonce(ctx context.Context, srcAddr string) (uint64, error) {
+	addr, _ := address.FromString(srcAddr)
+	confirmedState, err := accountutil.AccountState(ctx, ws, addr)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get the confirmed nonce of address %s", srcAddr)
+	}
+	if protocol.MustGetFeatureCtx(ctx).UseZeroNonceForFreshAccount {
+		return confirmedState.PendingNonceConsideringFreshAccount(), nil
+	}
+	return confirmedState.PendingNonce(), nil
+}
+
 func (ws *workingSet) checkNonceContinuity(ctx context.Context, accountNonceMap map[string][]uint64) error {
-	var (
-		pendingNonce uint64
-		useZeroNonce = protocol.MustGetFeatureCtx(ctx).UseZeroNonceForFreshAccount
-	)
 	// Verify each account's Nonce
 	for srcAddr, receivedNonces := range accountNonceMap {
-		addr, _ := address.FromString(srcAddr)
-		confirmedState, err := accountutil.AccountState(ctx, ws, addr)
+		pendingNonce, err := ws.pendingNonce(ctx, srcAddr)
 		if err != nil {
-			return errors.Wrapf(err, "failed to get the confirmed nonce of address %s", srcAddr)
+			return err
 		}
 		sort.Slice(receivedNonces, func(i, j int) bool { return receivedNonces[i] < receivedNonces[j] })
-		if useZeroNonce {
-			pendingNonce = confirmedState.PendingNonceConsideringFreshAccount()
-		} else {
-			pendingNonce = confirmedState.PendingNonce()
-		}
 		for i, nonce := range receivedNonces {
 			if nonce != pendingNonce+uint64(i) {
 				return errors.Wrapf(
@@ -466,6 +586,7 @@ func (ws *workingSet) process(ctx context.Context, actions []*action.SealedEnvel
 		return err
 	}
 
+	ctx = withEpochCtx(ctx)
 	reg := protocol.MustGetRegistry(ctx)
 	for _, act := range actions {
 		ctxWithActionContext, err := withActionCtx(ctx, act)
@@ -480,6 +601,53 @@ func (ws *workingSet) process(ctx context.Context, actions []*action.SealedEnvel
 			}
 		}
 	}
+	if err := ws.createPreStates(ctx); err != nil {
+		return err
+	}
+
+	receipts, err := ws.runActions(ctx, actions)
+	if err != nil {
+		return err
+	}
+	ws.receipts = receipts
+	return ws.finalize()
+}
+
+// replay runs the actions again without validating or finalizing them, the context to run each action is returned by
+// actionCtx
+func (ws *workingSet) replay(ctx context.Context, actions []*action.SealedEnvelope, actionCtx func(context.Context, int) (context.Context, error)) ([]*action.Receipt, error) {
+	if err := ws.validate(ctx); err != nil {
+		return nil, err
+	}
+	ctx = withEpochCtx(ctx)
+	if err := ws.createPreStates(ctx); err != nil {
+		return nil, err
+	}
+	receipts := make([]*action.Receipt, 0, len(actions))
+	for i, selp := range actions {
+		ctxWithActionContext, err := withActionCtx(ctx, selp)
+		if err != nil {
+			return nil, err
+		}
+		if ctxWithActionContext, err = actionCtx(ctxWithActionContext, i); err != nil {
+			return nil, err
+		}
+		receipt, err := ws.runAction(ctxWithActionContext, selp)
+		if err != nil {
+			return nil, errors.Wrap(err, "error when run action")
+		}
+		receipts = append(receipts, receipt)
+	}
+	if protocol.MustGetFeatureCtx(ctx).CorrectTxLogIndex {
+		updateReceiptIndex(receipts)
+	}
+	return receipts, nil
+}
+
+// createPreStates creates the preliminary states of the protocols, and then calls their epoch hooks at the boundary of
+// an epoch, which are part of the states of the block, so a block skipping a hook fails the validation
+func (ws *workingSet) createPreStates(ctx context.Context) error {
+	reg := protocol.MustGetRegistry(ctx)
 	for _, p := range reg.All() {
 		if pp, ok := p.(protocol.PreStatesCreator); ok {
 			if err := pp.CreatePreStates(ctx, ws); err != nil {
@@ -487,16 +655,20 @@ func (ws *workingSet) process(ctx context.Context, actions []*action.SealedEnvel
 			}
 		}
 	}
+	return reg.RunEpochHooks(ctx, ws)
+}
 
-	receipts, err := ws.runActions(ctx, actions)
-	if err != nil {
-		return err
+// withEpochCtx adds the epoch of the block into the context, if the chain runs in epochs
+func withEpochCtx(ctx context.Context) context.Context {
+	rp := rolldpos.FindProtocol(protocol.MustGetRegistry(ctx))
+	if rp == nil {
+		return ctx
 	}
-	ws.receipts = receipts
-	return ws.finalize()
+	return protocol.WithEpochCtx(ctx, rp.GetEpochCtx(protocol.MustGetBlockCtx(ctx).BlockHeight))
 }
 
 func (ws *workingSet) generateSystemActions(ctx context.Context) ([]action.Envelope, error) {
+	ctx = withEpochCtx(ctx)
 	reg := protocol.MustGetRegistry(ctx)
 	postSystemActions := []action.Envelope{}
 	for _, p := range reg.All() {
@@ -550,13 +722,9 @@ func (ws *workingSet) pickAndRunActions(
 	receipts := make([]*action.Receipt, 0)
 	executedActions := make([]*action.SealedEnvelope, 0)
 	reg := protocol.MustGetRegistry(ctx)
-
-	for _, p := range reg.All() {
-		if pp, ok := p.(protocol.PreStatesCreator); ok {
-			if err := pp.CreatePreStates(ctx, ws); err != nil {
-				return nil, err
-			}
-		}
+	ctx = withEpochCtx(ctx)
+	if err := ws.createPreStates(ctx); err != nil {
+		return nil, err
 	}
 
 	// initial action iterator
@@ -566,14 +734,46 @@ func (ws *workingSet) pickAndRunActions(
 		fCtx                = protocol.MustGetFeatureCtx(ctx)
 	)
 	if ap != nil {
-		actionIterator := actioniterator.NewActionIterator(ap.PendingActionMap())
-		for {
-			nextAction, ok := actionIterator.Next()
-			if !ok {
-				break
+		policy, ok := actpool.GetActionSelectionPolicy(ctx)
+		if !ok {
+			policy = actpool.NewDefaultSelectionPolicy()
+		}
+		var (
+			pending     = ap.PendingActionMap()
+			noncePolicy = ap.NoncePolicy()
+			// the nonce each sender's next action must have, the actions of a sender are skipped once one of them
+			// is not run, so that the block has no nonce gap whatever the policy selects
+			nextNonces = make(map[string]uint64, len(pending))
+		)
+		for sender, acts := range pending {
+			if len(acts) == 0 {
+				continue
 			}
-			if nextAction.GasLimit() > blkCtx.GasLimit {
-				actionIterator.PopAccount()
+			// the actions are packed from the nonce in the state by the nonce policy of the pool
+			nonce, err := ws.pendingNonce(ctx, sender)
+			if err != nil {
+				return nil, err
+			}
+			if acts = noncePolicy.Packable(nonce, acts); len(acts) == 0 {
+				delete(pending, sender)
+				continue
+			}
+			pending[sender] = acts
+			nextNonces[sender] = nonce
+		}
+		deadline, _ := ctx.Deadline()
+		skipped := make(map[string]struct{})
+		for _, nextAction := range policy.Select(actioniterator.NewActionIterator(pending), blkCtx.GasLimit, deadline) {
+			caller := nextAction.SenderAddress()
+			if caller == nil {
+				return nil, errors.New("failed to get address")
+			}
+			sender := caller.String()
+			if _, ok := skipped[sender]; ok {
+				continue
+			}
+			if nonce, ok := nextNonces[sender]; !ok || nextAction.Nonce() != nonce || nextAction.GasLimit() > blkCtx.GasLimit {
+				skipped[sender] = struct{}{}
 				continue
 			}
 			actionCtx, err := withActionCtx(ctxWithBlockContext, nextAction)
@@ -587,12 +787,8 @@ func (ws *workingSet) pickAndRunActions(
 				}
 			}
 			if err != nil {
-				caller := nextAction.SenderAddress()
-				if caller == nil {
-					return nil, errors.New("failed to get address")
-				}
 				ap.DeleteAction(caller)
-				actionIterator.PopAccount()
+				skipped[sender] = struct{}{}
 				continue
 			}
 			receipt, err := ws.runAction(actionCtx, nextAction)
@@ -600,25 +796,27 @@ func (ws *workingSet) pickAndRunActions(
 			case nil:
 				// do nothing
 			case action.ErrGasLimit:
-				actionIterator.PopAccount()
+				skipped[sender] = struct{}{}
 				continue
 			case action.ErrChainID, errUnfoldTxContainer, errDeployerNotWhitelisted:
-				if caller := nextAction.SenderAddress(); caller != nil {
-					ap.DeleteAction(caller)
-				}
-				actionIterator.PopAccount()
+				ap.DeleteAction(caller)
+				skipped[sender] = struct{}{}
 				continue
 			default:
-				if caller := nextAction.SenderAddress(); caller != nil {
+				if fCtx.IncludeFailedActions && protocol.IsAdmissionError(err) {
+					// the failures in execution are included with the receipts, only the actions not admitted are skipped
 					ap.DeleteAction(caller)
+					skipped[sender] = struct{}{}
+					continue
 				}
-				actionIterator.PopAccount()
+				ap.DeleteAction(caller)
 				nextActionHash, hashErr := nextAction.Hash()
 				if hashErr != nil {
 					return nil, errors.Wrapf(hashErr, "Failed to get hash for %x", nextActionHash)
 				}
 				return nil, errors.Wrapf(err, "Failed to update state changes for selp %x", nextActionHash)
 			}
+			nextNonces[sender]++
 			blkCtx.GasLimit -= receipt.GasConsumed
 			ctxWithBlockContext = protocol.WithBlockCtx(ctx, blkCtx)
 			receipts = append(receipts, receipt)
@@ -685,7 +883,7 @@ func (ws *workingSet) ValidateBlock(ctx context.Context, blk *block.Block) error
 		}
 	}
 	if err := ws.process(ctx, blk.RunnableActions().Actions()); err != nil {
-		log.L().Error("Failed to update state.", zap.Uint64("height", ws.height), zap.Error(err))
+		log.Logger("statefactory").Error("Failed to update state.", zap.Uint64("height", ws.height), zap.Error(err))
 		return err
 	}
 
@@ -741,5 +939,8 @@ func (ws *workingSet) CreateBuilder(
 		blkBuilder.SetGasUsed(calculateGasUsed(ws.receipts))
 		blkBuilder.SetBaseFee(block.CalcBaseFee(g.Blockchain, &bcCtx.Tip))
 	}
+	if fCtx.EnableRandomnessBeacon {
+		blkBuilder.SetRandomnessProof(blkCtx.RandomnessProof)
+	}
 	return blkBuilder, nil
 }
@@ -8,9 +8,11 @@ package factory
 import (
 	"context"
 	"math/big"
+	"strconv"
 	"testing"
 	"time"
 
+	"github.com/golang/mock/gomock"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
@@ -19,12 +21,16 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/account"
 	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/actpool/actioniterator"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/test/mock/mock_actpool"
 	"github.com/iotexproject/iotex-core/testutil"
 )
 
@@ -357,6 +363,175 @@ func TestWorkingSet_ValidateBlock_SystemAction(t *testing.T) {
 	})
 }
 
+func TestWorkingSet_EmitSystemLogs(t *testing.T) {
+	r := require.New(t)
+	ws := newFactoryWorkingSet(t)
+	var _ protocol.SystemLogEmitter = ws
+	logs := []*action.Log{
+		{Address: "io1", BlockHeight: 1},
+		{Address: "io2", BlockHeight: 1},
+	}
+	ws.EmitSystemLogs(logs[0])
+	ws.EmitSystemLogs(logs[1])
+	receipt := &action.Receipt{ActionHash: hash.Hash256b([]byte("grant"))}
+	ws.attachSystemLogs(receipt)
+	r.Equal(logs, receipt.Logs())
+	for _, l := range receipt.Logs() {
+		r.Equal(receipt.ActionHash, l.ActionHash)
+	}
+	// the logs are attached once
+	r.Empty(ws.systemLogs)
+}
+
+// epochHookProtocol writes the height of the last block of an epoch into the state
+type epochHookProtocol struct {
+	protocol.Protocol
+}
+
+func (p *epochHookProtocol) Handle(context.Context, action.Action, protocol.StateManager) (*action.Receipt, error) {
+	return nil, nil
+}
+
+func (p *epochHookProtocol) PostEpochEnd(ctx context.Context, sm protocol.StateManager) error {
+	epochCtx := protocol.MustGetEpochCtx(ctx)
+	_, err := sm.PutState(&testString{strconv.FormatUint(epochCtx.EpochLastHeight, 10)},
+		protocol.NamespaceOption("epoch"), protocol.KeyOption([]byte("lastHeight")))
+	return err
+}
+
+func TestWorkingSet_ValidateBlock_EpochHooks(t *testing.T) {
+	require := require.New(t)
+	cfg := Config{
+		Chain:   blockchain.DefaultConfig,
+		Genesis: genesis.TestDefault(),
+	}
+	newFactory := func(hook bool) Factory {
+		registry := protocol.NewRegistry()
+		require.NoError(account.NewProtocol(rewarding.DepositGas).Register(registry))
+		// every block is the first and the last block of an epoch
+		require.NoError(rolldpos.NewProtocol(1, 1, 1).Register(registry))
+		if hook {
+			require.NoError(registry.Register("epochhook", &epochHookProtocol{}))
+		}
+		f, err := NewFactory(cfg, db.NewMemKVStore(), RegistryOption(registry))
+		require.NoError(err)
+		return f
+	}
+	var (
+		proposer  = newFactory(true)
+		skipper   = newFactory(false)
+		validator = newFactory(true)
+		ctx       = protocol.WithBlockCtx(genesis.WithGenesisContext(context.Background(), cfg.Genesis), protocol.BlockCtx{})
+	)
+	for _, f := range []Factory{proposer, skipper, validator} {
+		require.NoError(f.Start(ctx))
+		defer func(f Factory) {
+			require.NoError(f.Stop(ctx))
+		}(f)
+	}
+
+	zctx := protocol.WithBlockCtx(context.Background(),
+		protocol.BlockCtx{
+			BlockHeight: uint64(1),
+			Producer:    identityset.Address(27),
+			GasLimit:    testutil.TestGasLimit * 100000,
+		})
+	zctx = genesis.WithGenesisContext(zctx, cfg.Genesis)
+	zctx = protocol.WithFeatureCtx(protocol.WithBlockchainCtx(zctx, protocol.BlockchainCtx{
+		ChainID: 1,
+	}))
+	mint := func(f Factory) *block.Block {
+		builder, err := f.NewBlockBuilder(zctx, nil, nil)
+		require.NoError(err)
+		blk, err := builder.SignAndBuild(identityset.PrivateKey(27))
+		require.NoError(err)
+		return &blk
+	}
+	require.NoError(validator.Validate(zctx, mint(proposer)))
+	// the block skipping the hook has a different state
+	require.ErrorIs(validator.Validate(zctx, mint(skipper)), block.ErrDeltaStateMismatch)
+}
+
+// recordingSelectionPolicy records the nonces of the actions offered to the selection of each sender, and selects
+// them by the default policy
+type recordingSelectionPolicy struct {
+	offered map[string][]uint64
+}
+
+func (p *recordingSelectionPolicy) Select(it actioniterator.ActionIterator, gasBudget uint64, deadline time.Time) []*action.SealedEnvelope {
+	acts := make(map[string][]*action.SealedEnvelope)
+	for act, ok := it.Next(); ok; act, ok = it.Next() {
+		sender := act.SenderAddress().String()
+		acts[sender] = append(acts[sender], act)
+		p.offered[sender] = append(p.offered[sender], act.Nonce())
+	}
+	return actpool.NewDefaultSelectionPolicy().Select(actioniterator.NewActionIterator(acts), gasBudget, deadline)
+}
+
+func TestWorkingSet_PickAndRunActions_NoncePolicy(t *testing.T) {
+	require := require.New(t)
+	a := identityset.Address(28).String()
+	b := identityset.Address(30).String()
+	for _, tc := range []struct {
+		policy  string
+		offered map[string][]uint64
+	}{
+		// the strict policy stops at the gap, while the tolerant one offers the action beyond it
+		{actpool.NoncePolicyStrict, map[string][]uint64{a: {1, 2}}},
+		{actpool.NoncePolicyTolerant, map[string][]uint64{a: {1, 2, 4}}},
+	} {
+		t.Run(tc.policy, func(t *testing.T) {
+			cfg := DefaultConfig
+			cfg.Genesis.InitBalanceMap = map[string]string{a: "100", b: "100"}
+			registry := protocol.NewRegistry()
+			sf, err := NewFactory(cfg, db.NewMemKVStore(), RegistryOption(registry))
+			require.NoError(err)
+			require.NoError(account.NewProtocol(rewarding.DepositGas).Register(registry))
+			ctx := protocol.WithBlockCtx(genesis.WithGenesisContext(context.Background(), cfg.Genesis), protocol.BlockCtx{})
+			require.NoError(sf.Start(ctx))
+			defer func() {
+				require.NoError(sf.Stop(ctx))
+			}()
+
+			tsf, err := action.NewTransfer(2, big.NewInt(1), a, nil, testutil.TestGasLimit, big.NewInt(0))
+			require.NoError(err)
+			elp := (&action.EnvelopeBuilder{}).SetNonce(2).SetGasLimit(testutil.TestGasLimit).SetAction(tsf).Build()
+			ahead, err := action.Sign(elp, identityset.PrivateKey(30))
+			require.NoError(err)
+			noncePolicy, err := actpool.NewNoncePolicy(tc.policy, actpool.DefaultConfig.MaxNumActsPerAcct)
+			require.NoError(err)
+			ap := mock_actpool.NewMockActPool(gomock.NewController(t))
+			ap.EXPECT().PendingActionMap().Return(map[string][]*action.SealedEnvelope{
+				a: {makeTransferAction(t, 1), makeTransferAction(t, 2), makeTransferAction(t, 4)},
+				// the first action is ahead of the nonce in the state, none of the actions is packable
+				b: {ahead},
+			}).Times(1)
+			ap.EXPECT().NoncePolicy().Return(noncePolicy).Times(1)
+			selection := &recordingSelectionPolicy{offered: make(map[string][]uint64)}
+			ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+				BlockHeight: 1,
+				Producer:    identityset.Address(27),
+				GasLimit:    cfg.Genesis.BlockGasLimit,
+			})
+			ctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{})))
+			ctx = actpool.WithActionSelectionPolicy(ctx, selection)
+			blkBuilder, err := sf.NewBlockBuilder(ctx, ap, nil)
+			require.NoError(err)
+			blk, err := blkBuilder.SignAndBuild(identityset.PrivateKey(27))
+			require.NoError(err)
+			require.Equal(tc.offered, selection.offered)
+			// the block has no gap under either policy
+			nonces := make(map[string][]uint64)
+			for _, selp := range blk.Actions {
+				sender := selp.SenderAddress().String()
+				nonces[sender] = append(nonces[sender], selp.Nonce())
+			}
+			require.Equal(map[string][]uint64{a: {1, 2}}, nonces)
+			require.NoError(sf.Validate(ctx, &blk))
+		})
+	}
+}
+
 func makeTransferAction(t *testing.T, nonce uint64) *action.SealedEnvelope {
 	tsf, err := action.NewTransfer(
 		uint64(nonce),
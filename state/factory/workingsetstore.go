@@ -6,18 +6,18 @@
 package factory
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
 	"github.com/iotexproject/go-pkgs/hash"
-	"go.uber.org/zap"
-
 	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/batch"
 	"github.com/iotexproject/iotex-core/db/trie"
-	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/db/trie/mptrie"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/state"
 )
@@ -41,10 +41,29 @@ type (
 		readBuffer bool
 	}
 	factoryWorkingSetStore struct {
-		view      protocol.View
-		flusher   db.KVStoreFlusher
-		tlt       trie.TwoLayerTrie
-		trieRoots map[int][]byte
+		view    protocol.View
+		flusher db.KVStoreFlusher
+		tlt     trie.TwoLayerTrie
+		// journal records the trie changes since the oldest live snapshot, which are undone on revert
+		journal   []trieJournalEntry
+		trieMarks []trieMark
+		// buffer is scanned on Finalize to journal the stale trie nodes, nil if history retention is disabled
+		buffer   batch.CachedBatch
+		readOnly bool
+	}
+
+	// trieJournalEntry records the value of a key in the trie before a change
+	trieJournalEntry struct {
+		nsHash hash.Hash160
+		key    []byte
+		value  []byte
+		exists bool
+	}
+
+	// trieMark is the size of the trie journal at the time of a snapshot
+	trieMark struct {
+		snapshot int
+		size     int
 	}
 )
 
@@ -56,17 +75,41 @@ func newStateDBWorkingSetStore(view protocol.View, flusher db.KVStoreFlusher, re
 	}
 }
 
-func newFactoryWorkingSetStore(view protocol.View, flusher db.KVStoreFlusher) (workingSetStore, error) {
-	tlt, err := newTwoLayerTrie(ArchiveTrieNamespace, flusher.KVStoreWithBuffer(), ArchiveTrieRootKey, true)
+func newFactoryWorkingSetStore(view protocol.View, flusher db.KVStoreFlusher, trieOpts ...mptrie.Option) (workingSetStore, error) {
+	return newFactoryWorkingSetStoreWithRoot(view, flusher, ArchiveTrieRootKey, true, trieOpts...)
+}
+
+// newRetainingFactoryWorkingSetStore creates a working set store which journals the trie nodes
+// becoming stale in each height, so that they could be pruned once out of the retention window
+func newRetainingFactoryWorkingSetStore(view protocol.View, flusher db.KVStoreFlusher, buffer batch.CachedBatch, trieOpts ...mptrie.Option) (workingSetStore, error) {
+	store, err := newFactoryWorkingSetStoreWithRoot(view, flusher, ArchiveTrieRootKey, true, trieOpts...)
+	if err != nil {
+		return nil, err
+	}
+	store.buffer = buffer
+	return store, nil
+}
+
+// newFactoryWorkingSetStoreAtHeight creates a working set store on top of the trie of a history height
+func newFactoryWorkingSetStoreAtHeight(view protocol.View, flusher db.KVStoreFlusher, height uint64, trieOpts ...mptrie.Option) (workingSetStore, error) {
+	store, err := newFactoryWorkingSetStoreWithRoot(view, flusher, fmt.Sprintf("%s-%d", ArchiveTrieRootKey, height), false, trieOpts...)
+	if err != nil {
+		return nil, err
+	}
+	store.readOnly = true
+	return store, nil
+}
+
+func newFactoryWorkingSetStoreWithRoot(view protocol.View, flusher db.KVStoreFlusher, rootKey string, create bool, trieOpts ...mptrie.Option) (*factoryWorkingSetStore, error) {
+	tlt, err := newTwoLayerTrie(ArchiveTrieNamespace, flusher.KVStoreWithBuffer(), rootKey, create, trieOpts...)
 	if err != nil {
 		return nil, err
 	}
 
 	return &factoryWorkingSetStore{
-		flusher:   flusher,
-		view:      view,
-		tlt:       tlt,
-		trieRoots: make(map[int][]byte),
+		flusher: flusher,
+		view:    view,
+		tlt:     tlt,
 	}, nil
 }
 
@@ -167,15 +210,23 @@ func (store *factoryWorkingSetStore) Get(ns string, key []byte) ([]byte, error)
 func (store *factoryWorkingSetStore) Put(ns string, key []byte, value []byte) error {
 	store.flusher.KVStoreWithBuffer().MustPut(ns, key, value)
 	nsHash := hash.Hash160b([]byte(ns))
+	legacyKey := toLegacyKey(key)
+	if err := store.record(nsHash, legacyKey); err != nil {
+		return err
+	}
 
-	return store.tlt.Upsert(nsHash[:], toLegacyKey(key), value)
+	return store.tlt.Upsert(nsHash[:], legacyKey, value)
 }
 
 func (store *factoryWorkingSetStore) Delete(ns string, key []byte) error {
 	store.flusher.KVStoreWithBuffer().MustDelete(ns, key)
 	nsHash := hash.Hash160b([]byte(ns))
+	legacyKey := toLegacyKey(key)
+	if err := store.record(nsHash, legacyKey); err != nil {
+		return err
+	}
 
-	err := store.tlt.Delete(nsHash[:], toLegacyKey(key))
+	err := store.tlt.Delete(nsHash[:], legacyKey)
 	if errors.Cause(err) == trie.ErrNotExist {
 		return errors.Wrapf(state.ErrStateNotExist, "key %x doesn't exist in namespace %x", key, nsHash)
 	}
@@ -203,37 +254,120 @@ func (store *factoryWorkingSetStore) Finalize(h uint64) error {
 		[]byte(fmt.Sprintf("%s-%d", ArchiveTrieRootKey, h)),
 		rootHash,
 	)
+	if store.buffer != nil {
+		return store.journalStaleNodes(h)
+	}
+	return nil
+}
+
+// journalStaleNodes records the trie nodes deleted at height h, and clears the stale mark of the nodes
+// written again at height h, such that a node is pruned only if it has not been revived since
+func (store *factoryWorkingSetStore) journalStaleNodes(h uint64) error {
+	var (
+		size     = store.buffer.Size()
+		lastOps  = make(map[string]batch.WriteType, size)
+		nodeKeys = make([]string, 0, size)
+	)
+	for i := 0; i < size; i++ {
+		wi, err := store.buffer.Entry(i)
+		if err != nil {
+			return err
+		}
+		if wi.Namespace() != ArchiveTrieNamespace || bytes.HasPrefix(wi.Key(), []byte(ArchiveTrieRootKey)) {
+			continue
+		}
+		k := string(wi.Key())
+		if _, ok := lastOps[k]; !ok {
+			nodeKeys = append(nodeKeys, k)
+		}
+		lastOps[k] = wi.WriteType()
+	}
+	kvb := store.flusher.KVStoreWithBuffer()
+	hb := byteutil.Uint64ToBytesBigEndian(h)
+	for _, k := range nodeKeys {
+		switch lastOps[k] {
+		case batch.Delete:
+			kvb.MustPut(ArchiveStaleHeightNamespace, append(hb[:8:8], k...), []byte{})
+			kvb.MustPut(ArchiveStaleNodeNamespace, []byte(k), hb)
+		case batch.Put:
+			kvb.MustDelete(ArchiveStaleNodeNamespace, []byte(k))
+		}
+	}
 	return nil
 }
 
 func (store *factoryWorkingSetStore) Commit() error {
+	if store.readOnly {
+		return errors.New("cannot commit a read-only working set store")
+	}
 	_dbBatchSizelMtc.WithLabelValues().Set(float64(store.flusher.KVStoreWithBuffer().Size()))
 	return store.flusher.Flush()
 }
 
-func (store *factoryWorkingSetStore) Snapshot() int {
-	rh, err := store.tlt.RootHash()
-	if err != nil {
-		log.L().Panic("failed to do snapshot", zap.Error(err))
+// record saves the value of the key into journal before it is changed, so that the change can be undone
+// on revert. There is nothing to revert to if no snapshot is taken
+func (store *factoryWorkingSetStore) record(nsHash hash.Hash160, key []byte) error {
+	if len(store.trieMarks) == 0 {
+		return nil
 	}
+	value, err := store.tlt.Get(nsHash[:], key)
+	switch errors.Cause(err) {
+	case nil:
+	case trie.ErrNotExist:
+		value = nil
+	default:
+		return err
+	}
+	store.journal = append(store.journal, trieJournalEntry{
+		nsHash: nsHash,
+		key:    key,
+		value:  value,
+		exists: err == nil,
+	})
+	return nil
+}
+
+func (store *factoryWorkingSetStore) Snapshot() int {
 	s := store.flusher.KVStoreWithBuffer().Snapshot()
-	store.trieRoots[s] = rh
+	// a snapshot number could be reused after revert
+	for len(store.trieMarks) > 0 && store.trieMarks[len(store.trieMarks)-1].snapshot >= s {
+		store.trieMarks = store.trieMarks[:len(store.trieMarks)-1]
+	}
+	store.trieMarks = append(store.trieMarks, trieMark{snapshot: s, size: len(store.journal)})
 	return s
 }
 
 func (store *factoryWorkingSetStore) RevertSnapshot(snapshot int) error {
-	if err := store.flusher.KVStoreWithBuffer().RevertSnapshot(snapshot); err != nil {
-		return err
+	i := len(store.trieMarks) - 1
+	for i >= 0 && store.trieMarks[i].snapshot > snapshot {
+		i--
 	}
-	root, ok := store.trieRoots[snapshot]
-	if !ok {
-		// this should not happen, b/c we save the trie root on a successful return of Snapshot(), but check anyway
-		return errors.Wrapf(trie.ErrInvalidTrie, "failed to get trie root for snapshot = %d", snapshot)
+	if i < 0 || store.trieMarks[i].snapshot != snapshot {
+		// this should not happen, b/c we save the journal size on a successful return of Snapshot(), but check anyway
+		return errors.Wrapf(trie.ErrInvalidTrie, "failed to get trie journal for snapshot = %d", snapshot)
 	}
-	return store.tlt.SetRootHash(root[:])
+	// undo the trie changes before reverting the buffer, which the trie nodes are read from. The trie is
+	// flushed into the buffer only on Finalize, so the nodes it refers to are not reverted from the buffer
+	size := store.trieMarks[i].size
+	for j := len(store.journal) - 1; j >= size; j-- {
+		entry := &store.journal[j]
+		var err error
+		if entry.exists {
+			err = store.tlt.Upsert(entry.nsHash[:], entry.key, entry.value)
+		} else if err = store.tlt.Delete(entry.nsHash[:], entry.key); errors.Cause(err) == trie.ErrNotExist {
+			err = nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to revert trie to snapshot = %d", snapshot)
+		}
+	}
+	store.journal = store.journal[:size]
+	store.trieMarks = store.trieMarks[:i+1]
+	return store.flusher.KVStoreWithBuffer().RevertSnapshot(snapshot)
 }
 
 func (store *factoryWorkingSetStore) ResetSnapshots() {
 	store.flusher.KVStoreWithBuffer().ResetSnapshots()
-	store.trieRoots = make(map[int][]byte)
+	store.journal = nil
+	store.trieMarks = nil
 }
@@ -9,6 +9,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/hex"
+	"strconv"
 	"testing"
 
 	"github.com/iotexproject/iotex-core/action/protocol"
@@ -110,5 +111,95 @@ func TestStateDBWorkingSetStore(t *testing.T) {
 }
 
 func TestFactoryWorkingSetStore(t *testing.T) {
-	// TODO: add unit test for factory working set store
+	require := require.New(t)
+	ctx := context.Background()
+	namespace := "namespace"
+	key1 := []byte("key1")
+	value1 := []byte("value1")
+	key2 := []byte("key2")
+	value2 := []byte("value2")
+	newStore := func() workingSetStore {
+		flusher, err := db.NewKVStoreFlusher(db.NewMemKVStore(), batch.NewCachedBatch())
+		require.NoError(err)
+		store, err := newFactoryWorkingSetStore(protocol.View{}, flusher)
+		require.NoError(err)
+		require.NoError(store.Start(ctx))
+		return store
+	}
+	expected := newStore()
+	require.NoError(expected.Put(namespace, key1, value1))
+	require.NoError(expected.Finalize(1))
+
+	store := newStore()
+	require.NoError(store.Put(namespace, key1, value1))
+	sn1 := store.Snapshot()
+	require.NoError(store.Put(namespace, key2, value2))
+	require.NoError(store.Put(namespace, key1, value2))
+	sn2 := store.Snapshot()
+	require.NoError(store.Delete(namespace, key1))
+	require.Error(store.Delete(namespace, []byte("key3")))
+	_, err := store.Get(namespace, key1)
+	require.Error(err)
+
+	require.NoError(store.RevertSnapshot(sn2))
+	valueInStore, err := store.Get(namespace, key1)
+	require.NoError(err)
+	require.Equal(value2, valueInStore)
+	valueInStore, err = store.Get(namespace, key2)
+	require.NoError(err)
+	require.Equal(value2, valueInStore)
+	require.NoError(store.RevertSnapshot(sn1))
+	valueInStore, err = store.Get(namespace, key1)
+	require.NoError(err)
+	require.Equal(value1, valueInStore)
+	_, err = store.Get(namespace, key2)
+	require.Error(err)
+	// snapshot 2 is discarded by reverting to snapshot 1
+	require.Error(store.RevertSnapshot(sn2))
+	require.NoError(store.RevertSnapshot(sn1))
+	store.ResetSnapshots()
+	require.Error(store.RevertSnapshot(sn1))
+	require.NoError(store.Finalize(1))
+	// the trie is the same as the one never changed after snapshot 1
+	require.Equal(expected.Digest(), store.Digest())
+	require.NoError(store.Commit())
+	require.NoError(store.Stop(ctx))
+	require.NoError(expected.Stop(ctx))
+}
+
+func BenchmarkFactoryWorkingSetStore_NestedSnapshots(b *testing.B) {
+	ctx := context.Background()
+	value := make([]byte, 32)
+	for _, dirty := range []int{1000, 10000, 100000} {
+		flusher, err := db.NewKVStoreFlusher(db.NewMemKVStore(), batch.NewCachedBatch())
+		require.NoError(b, err)
+		store, err := newFactoryWorkingSetStore(protocol.View{}, flusher)
+		require.NoError(b, err)
+		require.NoError(b, store.Start(ctx))
+		for i := 0; i < dirty; i++ {
+			require.NoError(b, store.Put("dirty", byteutil.Uint64ToBytes(uint64(i)), value))
+		}
+		keys := make([][]byte, 1000)
+		for i := range keys {
+			keys[i] = byteutil.Uint64ToBytes(uint64(dirty + i))
+		}
+		b.Run(strconv.Itoa(dirty), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				sn := make([]int, len(keys))
+				for i, k := range keys {
+					sn[i] = store.Snapshot()
+					if err := store.Put("nested", k, value); err != nil {
+						b.Fatal(err)
+					}
+				}
+				for i := len(keys) - 1; i >= 0; i-- {
+					if err := store.RevertSnapshot(sn[i]); err != nil {
+						b.Fatal(err)
+					}
+				}
+				store.ResetSnapshots()
+			}
+		})
+	}
 }
@@ -12,6 +12,7 @@ import (
 	hash "github.com/iotexproject/go-pkgs/hash"
 	address "github.com/iotexproject/iotex-address/address"
 	action "github.com/iotexproject/iotex-core/action"
+	actpool "github.com/iotexproject/iotex-core/actpool"
 	block "github.com/iotexproject/iotex-core/blockchain/block"
 )
 
@@ -68,6 +69,20 @@ func (mr *MockActPoolMockRecorder) AddActionEnvelopeValidators(arg0 ...interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddActionEnvelopeValidators", reflect.TypeOf((*MockActPool)(nil).AddActionEnvelopeValidators), arg0...)
 }
 
+// Check mocks base method.
+func (m *MockActPool) Check(ctx context.Context, act *action.SealedEnvelope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, act)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockActPoolMockRecorder) Check(ctx, act interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockActPool)(nil).Check), ctx, act)
+}
+
 // DeleteAction mocks base method.
 func (m *MockActPool) DeleteAction(arg0 address.Address) {
 	m.ctrl.T.Helper()
@@ -137,6 +152,37 @@ func (mr *MockActPoolMockRecorder) GetGasSize() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGasSize", reflect.TypeOf((*MockActPool)(nil).GetGasSize))
 }
 
+// GetNonceDetail mocks base method.
+func (m *MockActPool) GetNonceDetail(addr string) (*actpool.NonceDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNonceDetail", addr)
+	ret0, _ := ret[0].(*actpool.NonceDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNonceDetail indicates an expected call of GetNonceDetail.
+func (mr *MockActPoolMockRecorder) GetNonceDetail(addr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNonceDetail", reflect.TypeOf((*MockActPool)(nil).GetNonceDetail), addr)
+}
+
+// GetPendingActionInfo mocks base method.
+func (m *MockActPool) GetPendingActionInfo(hash hash.Hash256) (*action.SealedEnvelope, *actpool.PendingActionInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingActionInfo", hash)
+	ret0, _ := ret[0].(*action.SealedEnvelope)
+	ret1, _ := ret[1].(*actpool.PendingActionInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPendingActionInfo indicates an expected call of GetPendingActionInfo.
+func (mr *MockActPoolMockRecorder) GetPendingActionInfo(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingActionInfo", reflect.TypeOf((*MockActPool)(nil).GetPendingActionInfo), hash)
+}
+
 // GetPendingNonce mocks base method.
 func (m *MockActPool) GetPendingNonce(addr string) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -180,6 +226,20 @@ func (mr *MockActPoolMockRecorder) GetUnconfirmedActs(addr interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnconfirmedActs", reflect.TypeOf((*MockActPool)(nil).GetUnconfirmedActs), addr)
 }
 
+// NoncePolicy mocks base method.
+func (m *MockActPool) NoncePolicy() actpool.NoncePolicy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NoncePolicy")
+	ret0, _ := ret[0].(actpool.NoncePolicy)
+	return ret0
+}
+
+// NoncePolicy indicates an expected call of NoncePolicy.
+func (mr *MockActPoolMockRecorder) NoncePolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NoncePolicy", reflect.TypeOf((*MockActPool)(nil).NoncePolicy))
+}
+
 // PendingActionMap mocks base method.
 func (m *MockActPool) PendingActionMap() map[string][]*action.SealedEnvelope {
 	m.ctrl.T.Helper()
@@ -220,6 +280,20 @@ func (mr *MockActPoolMockRecorder) Reset() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockActPool)(nil).Reset))
 }
 
+// Status mocks base method.
+func (m *MockActPool) Status() *actpool.ActPoolStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status")
+	ret0, _ := ret[0].(*actpool.ActPoolStatus)
+	return ret0
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockActPoolMockRecorder) Status() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockActPool)(nil).Status))
+}
+
 // Validate mocks base method.
 func (m *MockActPool) Validate(arg0 context.Context, arg1 *action.SealedEnvelope) error {
 	m.ctrl.T.Helper()
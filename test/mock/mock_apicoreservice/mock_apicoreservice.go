@@ -10,15 +10,24 @@ import (
 	reflect "reflect"
 	time "time"
 
+	common "github.com/ethereum/go-ethereum/common"
 	tracers "github.com/ethereum/go-ethereum/eth/tracers"
 	gomock "github.com/golang/mock/gomock"
 	hash "github.com/iotexproject/go-pkgs/hash"
 	address "github.com/iotexproject/iotex-address/address"
 	action "github.com/iotexproject/iotex-core/action"
+	protocol "github.com/iotexproject/iotex-core/action/protocol"
+	evm "github.com/iotexproject/iotex-core/action/protocol/execution/evm"
+	rewarding "github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	staking "github.com/iotexproject/iotex-core/action/protocol/staking"
+	actpool "github.com/iotexproject/iotex-core/actpool"
 	logfilter "github.com/iotexproject/iotex-core/api/logfilter"
 	apitypes "github.com/iotexproject/iotex-core/api/types"
 	block "github.com/iotexproject/iotex-core/blockchain/block"
 	genesis "github.com/iotexproject/iotex-core/blockchain/genesis"
+	blockindex "github.com/iotexproject/iotex-core/blockindex"
+	blocksync "github.com/iotexproject/iotex-core/blocksync"
+	gasstation "github.com/iotexproject/iotex-core/gasstation"
 	iotexapi "github.com/iotexproject/iotex-proto/golang/iotexapi"
 	iotextypes "github.com/iotexproject/iotex-proto/golang/iotextypes"
 )
@@ -62,6 +71,35 @@ func (mr *MockCoreServiceMockRecorder) Account(addr interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Account", reflect.TypeOf((*MockCoreService)(nil).Account), addr)
 }
 
+// AccountNonceDetail mocks base method.
+func (m *MockCoreService) AccountNonceDetail(arg0 address.Address) (*apitypes.AccountNonceDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccountNonceDetail", arg0)
+	ret0, _ := ret[0].(*apitypes.AccountNonceDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AccountNonceDetail indicates an expected call of AccountNonceDetail.
+func (mr *MockCoreServiceMockRecorder) AccountNonceDetail(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccountNonceDetail", reflect.TypeOf((*MockCoreService)(nil).AccountNonceDetail), arg0)
+}
+
+// ActPoolStatus mocks base method.
+func (m *MockCoreService) ActPoolStatus() *actpool.ActPoolStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActPoolStatus")
+	ret0, _ := ret[0].(*actpool.ActPoolStatus)
+	return ret0
+}
+
+// ActPoolStatus indicates an expected call of ActPoolStatus.
+func (mr *MockCoreServiceMockRecorder) ActPoolStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActPoolStatus", reflect.TypeOf((*MockCoreService)(nil).ActPoolStatus))
+}
+
 // Action mocks base method.
 func (m *MockCoreService) Action(actionHash string, checkPending bool) (*iotexapi.ActionInfo, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +132,36 @@ func (mr *MockCoreServiceMockRecorder) ActionByActionHash(h interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionByActionHash", reflect.TypeOf((*MockCoreService)(nil).ActionByActionHash), h)
 }
 
+// ActionInclusionProof mocks base method.
+func (m *MockCoreService) ActionInclusionProof(h hash.Hash256) (*block.ActionInclusionProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionInclusionProof", h)
+	ret0, _ := ret[0].(*block.ActionInclusionProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionInclusionProof indicates an expected call of ActionInclusionProof.
+func (mr *MockCoreServiceMockRecorder) ActionInclusionProof(h interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionInclusionProof", reflect.TypeOf((*MockCoreService)(nil).ActionInclusionProof), h)
+}
+
+// ActionWithStatusByHash mocks base method.
+func (m *MockCoreService) ActionWithStatusByHash(h hash.Hash256) (*apitypes.ActionWithStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionWithStatusByHash", h)
+	ret0, _ := ret[0].(*apitypes.ActionWithStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionWithStatusByHash indicates an expected call of ActionWithStatusByHash.
+func (mr *MockCoreServiceMockRecorder) ActionWithStatusByHash(h interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionWithStatusByHash", reflect.TypeOf((*MockCoreService)(nil).ActionWithStatusByHash), h)
+}
+
 // Actions mocks base method.
 func (m *MockCoreService) Actions(start, count uint64) ([]*iotexapi.ActionInfo, error) {
 	m.ctrl.T.Helper()
@@ -124,6 +192,21 @@ func (mr *MockCoreServiceMockRecorder) ActionsByAddress(addr, start, count inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionsByAddress", reflect.TypeOf((*MockCoreService)(nil).ActionsByAddress), addr, start, count)
 }
 
+// ActionsByAddressWithFilter mocks base method.
+func (m *MockCoreService) ActionsByAddressWithFilter(addr address.Address, filter *blockindex.ActionFilter, start, count uint64) ([]*iotexapi.ActionInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionsByAddressWithFilter", addr, filter, start, count)
+	ret0, _ := ret[0].([]*iotexapi.ActionInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionsByAddressWithFilter indicates an expected call of ActionsByAddressWithFilter.
+func (mr *MockCoreServiceMockRecorder) ActionsByAddressWithFilter(addr, filter, start, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionsByAddressWithFilter", reflect.TypeOf((*MockCoreService)(nil).ActionsByAddressWithFilter), addr, filter, start, count)
+}
+
 // ActionsInActPool mocks base method.
 func (m *MockCoreService) ActionsInActPool(actHashes []string) ([]*action.SealedEnvelope, error) {
 	m.ctrl.T.Helper()
@@ -199,6 +282,67 @@ func (mr *MockCoreServiceMockRecorder) BlockHashByBlockHeight(blkHeight interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockHashByBlockHeight", reflect.TypeOf((*MockCoreService)(nil).BlockHashByBlockHeight), blkHeight)
 }
 
+// BlockHeightByTimestamp mocks base method.
+func (m *MockCoreService) BlockHeightByTimestamp(ts time.Time, before bool) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockHeightByTimestamp", ts, before)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockHeightByTimestamp indicates an expected call of BlockHeightByTimestamp.
+func (mr *MockCoreServiceMockRecorder) BlockHeightByTimestamp(ts, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockHeightByTimestamp", reflect.TypeOf((*MockCoreService)(nil).BlockHeightByTimestamp), ts, before)
+}
+
+// BlockMetasByTimestampRange mocks base method.
+func (m *MockCoreService) BlockMetasByTimestampRange(start, end time.Time, offset, count uint64) ([]*iotextypes.BlockMeta, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockMetasByTimestampRange", start, end, offset, count)
+	ret0, _ := ret[0].([]*iotextypes.BlockMeta)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BlockMetasByTimestampRange indicates an expected call of BlockMetasByTimestampRange.
+func (mr *MockCoreServiceMockRecorder) BlockMetasByTimestampRange(start, end, offset, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockMetasByTimestampRange", reflect.TypeOf((*MockCoreService)(nil).BlockMetasByTimestampRange), start, end, offset, count)
+}
+
+// CancelPendingAction mocks base method.
+func (m *MockCoreService) CancelPendingAction(sender address.Address, nonce uint64, gasPriceBump *big.Int) (*apitypes.CancelAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelPendingAction", sender, nonce, gasPriceBump)
+	ret0, _ := ret[0].(*apitypes.CancelAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelPendingAction indicates an expected call of CancelPendingAction.
+func (mr *MockCoreServiceMockRecorder) CancelPendingAction(sender, nonce, gasPriceBump interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelPendingAction", reflect.TypeOf((*MockCoreService)(nil).CancelPendingAction), sender, nonce, gasPriceBump)
+}
+
+// CandidateHistory mocks base method.
+func (m *MockCoreService) CandidateHistory(candidate address.Address, startEpoch, endEpoch uint64) ([]*staking.CandidateHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CandidateHistory", candidate, startEpoch, endEpoch)
+	ret0, _ := ret[0].([]*staking.CandidateHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CandidateHistory indicates an expected call of CandidateHistory.
+func (mr *MockCoreServiceMockRecorder) CandidateHistory(candidate, startEpoch, endEpoch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CandidateHistory", reflect.TypeOf((*MockCoreService)(nil).CandidateHistory), candidate, startEpoch, endEpoch)
+}
+
 // ChainID mocks base method.
 func (m *MockCoreService) ChainID() uint32 {
 	m.ctrl.T.Helper()
@@ -243,6 +387,51 @@ func (mr *MockCoreServiceMockRecorder) ChainMeta() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainMeta", reflect.TypeOf((*MockCoreService)(nil).ChainMeta))
 }
 
+// ContractStats mocks base method.
+func (m *MockCoreService) ContractStats(contract address.Address, fromDay, toDay uint64) ([]*blockindex.ContractDayStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContractStats", contract, fromDay, toDay)
+	ret0, _ := ret[0].([]*blockindex.ContractDayStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ContractStats indicates an expected call of ContractStats.
+func (mr *MockCoreServiceMockRecorder) ContractStats(contract, fromDay, toDay interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContractStats", reflect.TypeOf((*MockCoreService)(nil).ContractStats), contract, fromDay, toDay)
+}
+
+// ContractsCreatedByBlock mocks base method.
+func (m *MockCoreService) ContractsCreatedByBlock(blockHeight uint64) ([]*apitypes.CreatedContract, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContractsCreatedByBlock", blockHeight)
+	ret0, _ := ret[0].([]*apitypes.CreatedContract)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ContractsCreatedByBlock indicates an expected call of ContractsCreatedByBlock.
+func (mr *MockCoreServiceMockRecorder) ContractsCreatedByBlock(blockHeight interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContractsCreatedByBlock", reflect.TypeOf((*MockCoreService)(nil).ContractsCreatedByBlock), blockHeight)
+}
+
+// ConvertAddress mocks base method.
+func (m *MockCoreService) ConvertAddress(arg0 string) (*apitypes.AddressInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConvertAddress", arg0)
+	ret0, _ := ret[0].(*apitypes.AddressInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConvertAddress indicates an expected call of ConvertAddress.
+func (mr *MockCoreServiceMockRecorder) ConvertAddress(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConvertAddress", reflect.TypeOf((*MockCoreService)(nil).ConvertAddress), arg0)
+}
+
 // EVMNetworkID mocks base method.
 func (m *MockCoreService) EVMNetworkID() uint32 {
 	m.ctrl.T.Helper()
@@ -257,6 +446,20 @@ func (mr *MockCoreServiceMockRecorder) EVMNetworkID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EVMNetworkID", reflect.TypeOf((*MockCoreService)(nil).EVMNetworkID))
 }
 
+// EarliestStateHeight mocks base method.
+func (m *MockCoreService) EarliestStateHeight() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EarliestStateHeight")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// EarliestStateHeight indicates an expected call of EarliestStateHeight.
+func (mr *MockCoreServiceMockRecorder) EarliestStateHeight() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EarliestStateHeight", reflect.TypeOf((*MockCoreService)(nil).EarliestStateHeight))
+}
+
 // ElectionBuckets mocks base method.
 func (m *MockCoreService) ElectionBuckets(epochNum uint64) ([]*iotextypes.ElectionBucket, error) {
 	m.ctrl.T.Helper()
@@ -289,6 +492,21 @@ func (mr *MockCoreServiceMockRecorder) EpochMeta(epochNum interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EpochMeta", reflect.TypeOf((*MockCoreService)(nil).EpochMeta), epochNum)
 }
 
+// EpochRanking mocks base method.
+func (m *MockCoreService) EpochRanking(epoch uint64) ([]*staking.CandidateHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EpochRanking", epoch)
+	ret0, _ := ret[0].([]*staking.CandidateHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EpochRanking indicates an expected call of EpochRanking.
+func (mr *MockCoreServiceMockRecorder) EpochRanking(epoch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EpochRanking", reflect.TypeOf((*MockCoreService)(nil).EpochRanking), epoch)
+}
+
 // EstimateExecutionGasConsumption mocks base method.
 func (m *MockCoreService) EstimateExecutionGasConsumption(ctx context.Context, sc *action.Execution, callerAddr address.Address) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -349,6 +567,38 @@ func (mr *MockCoreServiceMockRecorder) EstimateMigrateStakeGasConsumption(arg0,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateMigrateStakeGasConsumption", reflect.TypeOf((*MockCoreService)(nil).EstimateMigrateStakeGasConsumption), arg0, arg1, arg2)
 }
 
+// FeeHistory mocks base method.
+func (m *MockCoreService) FeeHistory(blocks, newest uint64, rewardPercentiles []float64) (uint64, [][]*big.Int, []*big.Int, []float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FeeHistory", blocks, newest, rewardPercentiles)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].([][]*big.Int)
+	ret2, _ := ret[2].([]*big.Int)
+	ret3, _ := ret[3].([]float64)
+	ret4, _ := ret[4].(error)
+	return ret0, ret1, ret2, ret3, ret4
+}
+
+// FeeHistory indicates an expected call of FeeHistory.
+func (mr *MockCoreServiceMockRecorder) FeeHistory(blocks, newest, rewardPercentiles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FeeHistory", reflect.TypeOf((*MockCoreService)(nil).FeeHistory), blocks, newest, rewardPercentiles)
+}
+
+// FinalizedHeight mocks base method.
+func (m *MockCoreService) FinalizedHeight() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinalizedHeight")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// FinalizedHeight indicates an expected call of FinalizedHeight.
+func (mr *MockCoreServiceMockRecorder) FinalizedHeight() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinalizedHeight", reflect.TypeOf((*MockCoreService)(nil).FinalizedHeight))
+}
+
 // Genesis mocks base method.
 func (m *MockCoreService) Genesis() genesis.Genesis {
 	m.ctrl.T.Helper()
@@ -363,6 +613,21 @@ func (mr *MockCoreServiceMockRecorder) Genesis() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Genesis", reflect.TypeOf((*MockCoreService)(nil).Genesis))
 }
 
+// GrantRewardsByEpoch mocks base method.
+func (m *MockCoreService) GrantRewardsByEpoch(epoch uint64) ([]*blockindex.SystemAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantRewardsByEpoch", epoch)
+	ret0, _ := ret[0].([]*blockindex.SystemAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantRewardsByEpoch indicates an expected call of GrantRewardsByEpoch.
+func (mr *MockCoreServiceMockRecorder) GrantRewardsByEpoch(epoch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantRewardsByEpoch", reflect.TypeOf((*MockCoreService)(nil).GrantRewardsByEpoch), epoch)
+}
+
 // LogsInBlockByHash mocks base method.
 func (m *MockCoreService) LogsInBlockByHash(filter *logfilter.LogFilter, blockHash hash.Hash256) ([]*action.Log, error) {
 	m.ctrl.T.Helper()
@@ -379,9 +644,9 @@ func (mr *MockCoreServiceMockRecorder) LogsInBlockByHash(filter, blockHash inter
 }
 
 // LogsInRange mocks base method.
-func (m *MockCoreService) LogsInRange(filter *logfilter.LogFilter, start, end, paginationSize uint64) ([]*action.Log, []hash.Hash256, error) {
+func (m *MockCoreService) LogsInRange(ctx context.Context, filter *logfilter.LogFilter, start, end, paginationSize uint64) ([]*action.Log, []hash.Hash256, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LogsInRange", filter, start, end, paginationSize)
+	ret := m.ctrl.Call(m, "LogsInRange", ctx, filter, start, end, paginationSize)
 	ret0, _ := ret[0].([]*action.Log)
 	ret1, _ := ret[1].([]hash.Hash256)
 	ret2, _ := ret[2].(error)
@@ -389,9 +654,38 @@ func (m *MockCoreService) LogsInRange(filter *logfilter.LogFilter, start, end, p
 }
 
 // LogsInRange indicates an expected call of LogsInRange.
-func (mr *MockCoreServiceMockRecorder) LogsInRange(filter, start, end, paginationSize interface{}) *gomock.Call {
+func (mr *MockCoreServiceMockRecorder) LogsInRange(ctx, filter, start, end, paginationSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogsInRange", reflect.TypeOf((*MockCoreService)(nil).LogsInRange), ctx, filter, start, end, paginationSize)
+}
+
+// LogsPage mocks base method.
+func (m *MockCoreService) LogsPage(ctx context.Context, filter *logfilter.LogFilter, start, end uint64, cursor *apitypes.LogCursor, descending bool, limit uint64) (*apitypes.LogsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogsPage", ctx, filter, start, end, cursor, descending, limit)
+	ret0, _ := ret[0].(*apitypes.LogsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LogsPage indicates an expected call of LogsPage.
+func (mr *MockCoreServiceMockRecorder) LogsPage(ctx, filter, start, end, cursor, descending, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogsPage", reflect.TypeOf((*MockCoreService)(nil).LogsPage), ctx, filter, start, end, cursor, descending, limit)
+}
+
+// NetworkIdentity mocks base method.
+func (m *MockCoreService) NetworkIdentity() *apitypes.NetworkIdentity {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetworkIdentity")
+	ret0, _ := ret[0].(*apitypes.NetworkIdentity)
+	return ret0
+}
+
+// NetworkIdentity indicates an expected call of NetworkIdentity.
+func (mr *MockCoreServiceMockRecorder) NetworkIdentity() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogsInRange", reflect.TypeOf((*MockCoreService)(nil).LogsInRange), filter, start, end, paginationSize)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkIdentity", reflect.TypeOf((*MockCoreService)(nil).NetworkIdentity))
 }
 
 // PendingActionByActionHash mocks base method.
@@ -424,6 +718,52 @@ func (mr *MockCoreServiceMockRecorder) PendingNonce(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingNonce", reflect.TypeOf((*MockCoreService)(nil).PendingNonce), arg0)
 }
 
+// ProtocolParameters mocks base method.
+func (m *MockCoreService) ProtocolParameters(height uint64) ([]protocol.Parameter, []protocol.Parameter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProtocolParameters", height)
+	ret0, _ := ret[0].([]protocol.Parameter)
+	ret1, _ := ret[1].([]protocol.Parameter)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ProtocolParameters indicates an expected call of ProtocolParameters.
+func (mr *MockCoreServiceMockRecorder) ProtocolParameters(height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProtocolParameters", reflect.TypeOf((*MockCoreService)(nil).ProtocolParameters), height)
+}
+
+// RawBlockByHash mocks base method.
+func (m *MockCoreService) RawBlockByHash(h hash.Hash256, parts apitypes.RawBlockParts) (*apitypes.RawBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RawBlockByHash", h, parts)
+	ret0, _ := ret[0].(*apitypes.RawBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RawBlockByHash indicates an expected call of RawBlockByHash.
+func (mr *MockCoreServiceMockRecorder) RawBlockByHash(h, parts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RawBlockByHash", reflect.TypeOf((*MockCoreService)(nil).RawBlockByHash), h, parts)
+}
+
+// RawBlockByHeight mocks base method.
+func (m *MockCoreService) RawBlockByHeight(height uint64, parts apitypes.RawBlockParts) (*apitypes.RawBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RawBlockByHeight", height, parts)
+	ret0, _ := ret[0].(*apitypes.RawBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RawBlockByHeight indicates an expected call of RawBlockByHeight.
+func (mr *MockCoreServiceMockRecorder) RawBlockByHeight(height, parts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RawBlockByHeight", reflect.TypeOf((*MockCoreService)(nil).RawBlockByHeight), height, parts)
+}
+
 // RawBlocks mocks base method.
 func (m *MockCoreService) RawBlocks(startHeight, count uint64, withReceipts, withTransactionLogs bool) ([]*iotexapi.BlockInfo, error) {
 	m.ctrl.T.Helper()
@@ -455,6 +795,21 @@ func (mr *MockCoreServiceMockRecorder) ReadContract(ctx, callerAddr, sc interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadContract", reflect.TypeOf((*MockCoreService)(nil).ReadContract), ctx, callerAddr, sc)
 }
 
+// RawHeaders mocks base method.
+func (m *MockCoreService) RawHeaders(start uint64, count uint64) ([]*apitypes.RawBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RawHeaders", start, count)
+	ret0, _ := ret[0].([]*apitypes.RawBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RawHeaders indicates an expected call of RawHeaders.
+func (mr *MockCoreServiceMockRecorder) RawHeaders(start, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RawHeaders", reflect.TypeOf((*MockCoreService)(nil).RawHeaders), start, count)
+}
+
 // ReadContractStorage mocks base method.
 func (m *MockCoreService) ReadContractStorage(ctx context.Context, addr address.Address, key []byte) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -529,6 +884,21 @@ func (mr *MockCoreServiceMockRecorder) SendAction(ctx, in interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAction", reflect.TypeOf((*MockCoreService)(nil).SendAction), ctx, in)
 }
 
+// SendActionWithStatus mocks base method.
+func (m *MockCoreService) SendActionWithStatus(ctx context.Context, in *iotextypes.Action) (*apitypes.SentAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendActionWithStatus", ctx, in)
+	ret0, _ := ret[0].(*apitypes.SentAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendActionWithStatus indicates an expected call of SendActionWithStatus.
+func (mr *MockCoreServiceMockRecorder) SendActionWithStatus(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendActionWithStatus", reflect.TypeOf((*MockCoreService)(nil).SendActionWithStatus), ctx, in)
+}
+
 // ServerMeta mocks base method.
 func (m *MockCoreService) ServerMeta() (string, string, string, string, string) {
 	m.ctrl.T.Helper()
@@ -547,6 +917,21 @@ func (mr *MockCoreServiceMockRecorder) ServerMeta() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServerMeta", reflect.TypeOf((*MockCoreService)(nil).ServerMeta))
 }
 
+// SimulateBatch mocks base method.
+func (m *MockCoreService) SimulateBatch(ctx context.Context, height uint64, calls []*apitypes.SimulateCall, overrides map[common.Address]*evm.StateOverride, continueOnFailure bool) ([]*apitypes.SimulateResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SimulateBatch", ctx, height, calls, overrides, continueOnFailure)
+	ret0, _ := ret[0].([]*apitypes.SimulateResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SimulateBatch indicates an expected call of SimulateBatch.
+func (mr *MockCoreServiceMockRecorder) SimulateBatch(ctx, height, calls, overrides, continueOnFailure interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulateBatch", reflect.TypeOf((*MockCoreService)(nil).SimulateBatch), ctx, height, calls, overrides, continueOnFailure)
+}
+
 // SimulateExecution mocks base method.
 func (m *MockCoreService) SimulateExecution(arg0 context.Context, arg1 address.Address, arg2 *action.Execution) ([]byte, *action.Receipt, error) {
 	m.ctrl.T.Helper()
@@ -591,6 +976,20 @@ func (mr *MockCoreServiceMockRecorder) Stop(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockCoreService)(nil).Stop), ctx)
 }
 
+// StreamLogsInRange mocks base method.
+func (m *MockCoreService) StreamLogsInRange(ctx context.Context, filter *logfilter.LogFilter, start, end uint64, send func(*apitypes.LogsPage) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamLogsInRange", ctx, filter, start, end, send)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamLogsInRange indicates an expected call of StreamLogsInRange.
+func (mr *MockCoreServiceMockRecorder) StreamLogsInRange(ctx, filter, start, end, send interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamLogsInRange", reflect.TypeOf((*MockCoreService)(nil).StreamLogsInRange), ctx, filter, start, end, send)
+}
+
 // SuggestGasPrice mocks base method.
 func (m *MockCoreService) SuggestGasPrice() (uint64, error) {
 	m.ctrl.T.Helper()
@@ -606,14 +1005,42 @@ func (mr *MockCoreServiceMockRecorder) SuggestGasPrice() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestGasPrice", reflect.TypeOf((*MockCoreService)(nil).SuggestGasPrice))
 }
 
+// SuggestGasPrices mocks base method.
+func (m *MockCoreService) SuggestGasPrices() (*gasstation.GasPrices, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestGasPrices")
+	ret0, _ := ret[0].(*gasstation.GasPrices)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestGasPrices indicates an expected call of SuggestGasPrices.
+func (mr *MockCoreServiceMockRecorder) SuggestGasPrices() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestGasPrices", reflect.TypeOf((*MockCoreService)(nil).SuggestGasPrices))
+}
+
+// SuggestGasTipCap mocks base method.
+func (m *MockCoreService) SuggestGasTipCap() (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestGasTipCap")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestGasTipCap indicates an expected call of SuggestGasTipCap.
+func (mr *MockCoreServiceMockRecorder) SuggestGasTipCap() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestGasTipCap", reflect.TypeOf((*MockCoreService)(nil).SuggestGasTipCap))
+}
+
 // SyncingProgress mocks base method.
-func (m *MockCoreService) SyncingProgress() (uint64, uint64, uint64) {
+func (m *MockCoreService) SyncingProgress() blocksync.SyncProgress {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SyncingProgress")
-	ret0, _ := ret[0].(uint64)
-	ret1, _ := ret[1].(uint64)
-	ret2, _ := ret[2].(uint64)
-	return ret0, ret1, ret2
+	ret0, _ := ret[0].(blocksync.SyncProgress)
+	return ret0
 }
 
 // SyncingProgress indicates an expected call of SyncingProgress.
@@ -622,6 +1049,21 @@ func (mr *MockCoreServiceMockRecorder) SyncingProgress() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncingProgress", reflect.TypeOf((*MockCoreService)(nil).SyncingProgress))
 }
 
+// SystemActionsByHeight mocks base method.
+func (m *MockCoreService) SystemActionsByHeight(height uint64) ([]*blockindex.SystemAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SystemActionsByHeight", height)
+	ret0, _ := ret[0].([]*blockindex.SystemAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SystemActionsByHeight indicates an expected call of SystemActionsByHeight.
+func (mr *MockCoreServiceMockRecorder) SystemActionsByHeight(height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SystemActionsByHeight", reflect.TypeOf((*MockCoreService)(nil).SystemActionsByHeight), height)
+}
+
 // TipHeight mocks base method.
 func (m *MockCoreService) TipHeight() uint64 {
 	m.ctrl.T.Helper()
@@ -636,6 +1078,83 @@ func (mr *MockCoreServiceMockRecorder) TipHeight() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TipHeight", reflect.TypeOf((*MockCoreService)(nil).TipHeight))
 }
 
+// TokenTransfersByAddress mocks base method.
+func (m *MockCoreService) TokenTransfersByAddress(addr address.Address, query *blockindex.TokenTransferQuery) ([]*blockindex.TokenTransfer, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TokenTransfersByAddress", addr, query)
+	ret0, _ := ret[0].([]*blockindex.TokenTransfer)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TokenTransfersByAddress indicates an expected call of TokenTransfersByAddress.
+func (mr *MockCoreServiceMockRecorder) TokenTransfersByAddress(addr, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TokenTransfersByAddress", reflect.TypeOf((*MockCoreService)(nil).TokenTransfersByAddress), addr, query)
+}
+
+// TokenTransfersByContract mocks base method.
+func (m *MockCoreService) TokenTransfersByContract(token address.Address, query *blockindex.TokenTransferQuery) ([]*blockindex.TokenTransfer, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TokenTransfersByContract", token, query)
+	ret0, _ := ret[0].([]*blockindex.TokenTransfer)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TokenTransfersByContract indicates an expected call of TokenTransfersByContract.
+func (mr *MockCoreServiceMockRecorder) TokenTransfersByContract(token, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TokenTransfersByContract", reflect.TypeOf((*MockCoreService)(nil).TokenTransfersByContract), token, query)
+}
+
+// TopContracts mocks base method.
+func (m *MockCoreService) TopContracts(fromDay, toDay uint64, order blockindex.ContractStatsOrder, limit uint64) ([]*blockindex.ContractStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopContracts", fromDay, toDay, order, limit)
+	ret0, _ := ret[0].([]*blockindex.ContractStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopContracts indicates an expected call of TopContracts.
+func (mr *MockCoreServiceMockRecorder) TopContracts(fromDay, toDay, order, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopContracts", reflect.TypeOf((*MockCoreService)(nil).TopContracts), fromDay, toDay, order, limit)
+}
+
+// TotalSupply mocks base method.
+func (m *MockCoreService) TotalSupply(ctx context.Context, height uint64) (*rewarding.Supply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TotalSupply", ctx, height)
+	ret0, _ := ret[0].(*rewarding.Supply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TotalSupply indicates an expected call of TotalSupply.
+func (mr *MockCoreServiceMockRecorder) TotalSupply(ctx, height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TotalSupply", reflect.TypeOf((*MockCoreService)(nil).TotalSupply), ctx, height)
+}
+
+// TraceBlockByNumber mocks base method.
+func (m *MockCoreService) TraceBlockByNumber(ctx context.Context, height uint64, config *tracers.TraceConfig) ([]*apitypes.TxTrace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TraceBlockByNumber", ctx, height, config)
+	ret0, _ := ret[0].([]*apitypes.TxTrace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TraceBlockByNumber indicates an expected call of TraceBlockByNumber.
+func (mr *MockCoreServiceMockRecorder) TraceBlockByNumber(ctx, height, config interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TraceBlockByNumber", reflect.TypeOf((*MockCoreService)(nil).TraceBlockByNumber), ctx, height, config)
+}
+
 // TraceCall mocks base method.
 func (m *MockCoreService) TraceCall(ctx context.Context, callerAddr address.Address, blkNumOrHash any, contractAddress string, nonce uint64, amount *big.Int, gasLimit uint64, data []byte, config *tracers.TraceConfig) ([]byte, *action.Receipt, any, error) {
 	m.ctrl.T.Helper()
@@ -713,6 +1232,38 @@ func (mr *MockCoreServiceMockRecorder) TransactionLogByBlockHeight(blockHeight i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransactionLogByBlockHeight", reflect.TypeOf((*MockCoreService)(nil).TransactionLogByBlockHeight), blockHeight)
 }
 
+// TransactionLogsByBlockHeightRange mocks base method.
+func (m *MockCoreService) TransactionLogsByBlockHeightRange(start, count uint64, recipients []address.Address) ([]*apitypes.BlockTransactionLogs, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransactionLogsByBlockHeightRange", start, count, recipients)
+	ret0, _ := ret[0].([]*apitypes.BlockTransactionLogs)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TransactionLogsByBlockHeightRange indicates an expected call of TransactionLogsByBlockHeightRange.
+func (mr *MockCoreServiceMockRecorder) TransactionLogsByBlockHeightRange(start, count, recipients interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransactionLogsByBlockHeightRange", reflect.TypeOf((*MockCoreService)(nil).TransactionLogsByBlockHeightRange), start, count, recipients)
+}
+
+// TransfersByRecipientAndMemo mocks base method.
+func (m *MockCoreService) TransfersByRecipientAndMemo(recipient address.Address, memo string, query *blockindex.MemoTransferQuery) ([]*blockindex.MemoTransfer, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransfersByRecipientAndMemo", recipient, memo, query)
+	ret0, _ := ret[0].([]*blockindex.MemoTransfer)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TransfersByRecipientAndMemo indicates an expected call of TransfersByRecipientAndMemo.
+func (mr *MockCoreServiceMockRecorder) TransfersByRecipientAndMemo(recipient, memo, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransfersByRecipientAndMemo", reflect.TypeOf((*MockCoreService)(nil).TransfersByRecipientAndMemo), recipient, memo, query)
+}
+
 // UnconfirmedActionsByAddress mocks base method.
 func (m *MockCoreService) UnconfirmedActionsByAddress(address string, start, count uint64) ([]*iotexapi.ActionInfo, error) {
 	m.ctrl.T.Helper()
@@ -728,6 +1279,21 @@ func (mr *MockCoreServiceMockRecorder) UnconfirmedActionsByAddress(address, star
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnconfirmedActionsByAddress", reflect.TypeOf((*MockCoreService)(nil).UnconfirmedActionsByAddress), address, start, count)
 }
 
+// ValidateAction mocks base method.
+func (m *MockCoreService) ValidateAction(ctx context.Context, in *iotextypes.Action) (*apitypes.ActionValidation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateAction", ctx, in)
+	ret0, _ := ret[0].(*apitypes.ActionValidation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateAction indicates an expected call of ValidateAction.
+func (mr *MockCoreServiceMockRecorder) ValidateAction(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAction", reflect.TypeOf((*MockCoreService)(nil).ValidateAction), ctx, in)
+}
+
 // MockintrinsicGasCalculator is a mock of intrinsicGasCalculator interface.
 type MockintrinsicGasCalculator struct {
 	ctrl     *gomock.Controller
@@ -111,6 +111,21 @@ func (mr *MockIndexerMockRecorder) GetActionsByAddress(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActionsByAddress", reflect.TypeOf((*MockIndexer)(nil).GetActionsByAddress), arg0, arg1, arg2)
 }
 
+// GetActionsByAddressWithFilter mocks base method.
+func (m *MockIndexer) GetActionsByAddressWithFilter(arg0 hash.Hash160, arg1 *blockindex.ActionFilter, arg2, arg3 uint64) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActionsByAddressWithFilter", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActionsByAddressWithFilter indicates an expected call of GetActionsByAddressWithFilter.
+func (mr *MockIndexerMockRecorder) GetActionsByAddressWithFilter(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActionsByAddressWithFilter", reflect.TypeOf((*MockIndexer)(nil).GetActionsByAddressWithFilter), arg0, arg1, arg2, arg3)
+}
+
 // GetBlockHash mocks base method.
 func (m *MockIndexer) GetBlockHash(height uint64) (hash.Hash256, error) {
 	m.ctrl.T.Helper()
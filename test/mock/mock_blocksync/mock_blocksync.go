@@ -10,6 +10,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	block "github.com/iotexproject/iotex-core/blockchain/block"
+	blocksync "github.com/iotexproject/iotex-core/blocksync"
 	peer "github.com/libp2p/go-libp2p/core/peer"
 )
 
@@ -106,6 +107,20 @@ func (mr *MockBlockSyncMockRecorder) Stop(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockBlockSync)(nil).Stop), arg0)
 }
 
+// SyncProgress mocks base method.
+func (m *MockBlockSync) SyncProgress() blocksync.SyncProgress {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncProgress")
+	ret0, _ := ret[0].(blocksync.SyncProgress)
+	return ret0
+}
+
+// SyncProgress indicates an expected call of SyncProgress.
+func (mr *MockBlockSyncMockRecorder) SyncProgress() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncProgress", reflect.TypeOf((*MockBlockSync)(nil).SyncProgress))
+}
+
 // SyncStatus mocks base method.
 func (m *MockBlockSync) SyncStatus() (uint64, uint64, uint64, string) {
 	m.ctrl.T.Helper()
@@ -10,6 +10,7 @@ import (
 
 	types "github.com/ethereum/go-ethereum/core/types"
 	gomock "github.com/golang/mock/gomock"
+	address "github.com/iotexproject/iotex-address/address"
 	action "github.com/iotexproject/iotex-core/action"
 	iotextypes "github.com/iotexproject/iotex-proto/golang/iotextypes"
 )
@@ -109,6 +110,20 @@ func (mr *MockEnvelopeMockRecorder) GasFeeCap() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GasFeeCap", reflect.TypeOf((*MockEnvelope)(nil).GasFeeCap))
 }
 
+// GasPayer mocks base method.
+func (m *MockEnvelope) GasPayer() address.Address {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GasPayer")
+	ret0, _ := ret[0].(address.Address)
+	return ret0
+}
+
+// GasPayer indicates an expected call of GasPayer.
+func (mr *MockEnvelopeMockRecorder) GasPayer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GasPayer", reflect.TypeOf((*MockEnvelope)(nil).GasPayer))
+}
+
 // GasLimit mocks base method.
 func (m *MockEnvelope) GasLimit() uint64 {
 	m.ctrl.T.Helper()
@@ -151,6 +166,20 @@ func (mr *MockEnvelopeMockRecorder) GasTipCap() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GasTipCap", reflect.TypeOf((*MockEnvelope)(nil).GasTipCap))
 }
 
+// HashVersion mocks base method.
+func (m *MockEnvelope) HashVersion() action.HashVersion {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HashVersion")
+	ret0, _ := ret[0].(action.HashVersion)
+	return ret0
+}
+
+// HashVersion indicates an expected call of HashVersion.
+func (mr *MockEnvelopeMockRecorder) HashVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashVersion", reflect.TypeOf((*MockEnvelope)(nil).HashVersion))
+}
+
 // IntrinsicGas mocks base method.
 func (m *MockEnvelope) IntrinsicGas() (uint64, error) {
 	m.ctrl.T.Helper()
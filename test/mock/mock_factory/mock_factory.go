@@ -54,6 +54,20 @@ func (mr *MockFactoryMockRecorder) DeleteTipBlock(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTipBlock", reflect.TypeOf((*MockFactory)(nil).DeleteTipBlock), arg0, arg1)
 }
 
+// EarliestStateHeight mocks base method.
+func (m *MockFactory) EarliestStateHeight() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EarliestStateHeight")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// EarliestStateHeight indicates an expected call of EarliestStateHeight.
+func (mr *MockFactoryMockRecorder) EarliestStateHeight() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EarliestStateHeight", reflect.TypeOf((*MockFactory)(nil).EarliestStateHeight))
+}
+
 // Height mocks base method.
 func (m *MockFactory) Height() (uint64, error) {
 	m.ctrl.T.Helper()
@@ -69,6 +83,36 @@ func (mr *MockFactoryMockRecorder) Height() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Height", reflect.TypeOf((*MockFactory)(nil).Height))
 }
 
+// IterateAccounts mocks base method.
+func (m *MockFactory) IterateAccounts(arg0 context.Context, arg1 uint64, arg2, arg3 []byte, arg4 uint64, arg5 func(address.Address, *state.Account) error) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IterateAccounts", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IterateAccounts indicates an expected call of IterateAccounts.
+func (mr *MockFactoryMockRecorder) IterateAccounts(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IterateAccounts", reflect.TypeOf((*MockFactory)(nil).IterateAccounts), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// IterateContractStorage mocks base method.
+func (m *MockFactory) IterateContractStorage(arg0 context.Context, arg1 uint64, arg2 address.Address, arg3 []byte, arg4 uint64, arg5 func([]byte, []byte) error) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IterateContractStorage", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IterateContractStorage indicates an expected call of IterateContractStorage.
+func (mr *MockFactoryMockRecorder) IterateContractStorage(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IterateContractStorage", reflect.TypeOf((*MockFactory)(nil).IterateContractStorage), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // NewBlockBuilder mocks base method.
 func (m *MockFactory) NewBlockBuilder(arg0 context.Context, arg1 actpool.ActPool, arg2 func(action.Envelope) (*action.SealedEnvelope, error)) (*block.Builder, error) {
 	m.ctrl.T.Helper()
@@ -142,6 +186,21 @@ func (mr *MockFactoryMockRecorder) Register(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockFactory)(nil).Register), arg0)
 }
 
+// ReplayBlock mocks base method.
+func (m *MockFactory) ReplayBlock(arg0 context.Context, arg1 *block.Block, arg2 int, arg3 func(context.Context, int) (context.Context, error)) ([]*action.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayBlock", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*action.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplayBlock indicates an expected call of ReplayBlock.
+func (mr *MockFactoryMockRecorder) ReplayBlock(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayBlock", reflect.TypeOf((*MockFactory)(nil).ReplayBlock), arg0, arg1, arg2, arg3)
+}
+
 // SimulateExecution mocks base method.
 func (m *MockFactory) SimulateExecution(arg0 context.Context, arg1 address.Address, arg2 *action.Execution) ([]byte, *action.Receipt, error) {
 	m.ctrl.T.Helper()
@@ -158,6 +217,21 @@ func (mr *MockFactoryMockRecorder) SimulateExecution(arg0, arg1, arg2 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulateExecution", reflect.TypeOf((*MockFactory)(nil).SimulateExecution), arg0, arg1, arg2)
 }
 
+// SimulationWorkingSet mocks base method.
+func (m *MockFactory) SimulationWorkingSet(arg0 context.Context, arg1 uint64) (protocol.StateManager, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SimulationWorkingSet", arg0, arg1)
+	ret0, _ := ret[0].(protocol.StateManager)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SimulationWorkingSet indicates an expected call of SimulationWorkingSet.
+func (mr *MockFactoryMockRecorder) SimulationWorkingSet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulationWorkingSet", reflect.TypeOf((*MockFactory)(nil).SimulationWorkingSet), arg0, arg1)
+}
+
 // Start mocks base method.
 func (m *MockFactory) Start(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -211,6 +285,21 @@ func (mr *MockFactoryMockRecorder) StateAtHeight(arg0, arg1 interface{}, arg2 ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateAtHeight", reflect.TypeOf((*MockFactory)(nil).StateAtHeight), varargs...)
 }
 
+// StateReaderAtHeight mocks base method.
+func (m *MockFactory) StateReaderAtHeight(arg0 context.Context, arg1 uint64) (protocol.StateReader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateReaderAtHeight", arg0, arg1)
+	ret0, _ := ret[0].(protocol.StateReader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateReaderAtHeight indicates an expected call of StateReaderAtHeight.
+func (mr *MockFactoryMockRecorder) StateReaderAtHeight(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateReaderAtHeight", reflect.TypeOf((*MockFactory)(nil).StateReaderAtHeight), arg0, arg1)
+}
+
 // States mocks base method.
 func (m *MockFactory) States(arg0 ...protocol.StateOption) (uint64, state.Iterator, error) {
 	m.ctrl.T.Helper()
@@ -278,3 +367,18 @@ func (mr *MockFactoryMockRecorder) Validate(arg0, arg1 interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockFactory)(nil).Validate), arg0, arg1)
 }
+
+// WorkingSetAtHeight mocks base method.
+func (m *MockFactory) WorkingSetAtHeight(arg0 context.Context, arg1 uint64) (protocol.StateManager, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkingSetAtHeight", arg0, arg1)
+	ret0, _ := ret[0].(protocol.StateManager)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkingSetAtHeight indicates an expected call of WorkingSetAtHeight.
+func (mr *MockFactoryMockRecorder) WorkingSetAtHeight(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkingSetAtHeight", reflect.TypeOf((*MockFactory)(nil).WorkingSetAtHeight), arg0, arg1)
+}
@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	p2p "github.com/iotexproject/iotex-core/p2p"
 	peer "github.com/libp2p/go-libp2p/core/peer"
 	proto "google.golang.org/protobuf/proto"
 )
@@ -65,6 +66,20 @@ func (mr *MocktransmitterMockRecorder) Info() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*Mocktransmitter)(nil).Info))
 }
 
+// KnownPeers mocks base method.
+func (m *Mocktransmitter) KnownPeers() []*p2p.PeerRecord {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KnownPeers")
+	ret0, _ := ret[0].([]*p2p.PeerRecord)
+	return ret0
+}
+
+// KnownPeers indicates an expected call of KnownPeers.
+func (mr *MocktransmitterMockRecorder) KnownPeers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KnownPeers", reflect.TypeOf((*Mocktransmitter)(nil).KnownPeers))
+}
+
 // UnicastOutbound mocks base method.
 func (m *Mocktransmitter) UnicastOutbound(arg0 context.Context, arg1 peer.AddrInfo, arg2 proto.Message) error {
 	m.ctrl.T.Helper()
@@ -20,9 +20,12 @@ func PathOfTempFile(dirName string) (string, error) {
 	return tempFile.Name(), tempFile.Close()
 }
 
-// CleanupPath detects the existence of test DB file and removes it if found
+// CleanupPath detects the existence of test DB file and removes it if found, along with the genesis stamp of the
+// store next to it
 func CleanupPath(path string) {
-	if fileutil.FileExists(path) && os.RemoveAll(path) != nil {
-		panic("Fail to remove testDB file")
+	for _, p := range []string{path, path + ".genesis"} {
+		if fileutil.FileExists(p) && os.RemoveAll(p) != nil {
+			panic("Fail to remove testDB file")
+		}
 	}
 }
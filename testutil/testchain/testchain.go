@@ -0,0 +1,423 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+// Package testchain builds an in-memory blockchain with the account, execution, staking, rewarding and poll
+// protocols for tests, and mints blocks of the given actions at deterministic timestamps.
+package testchain
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/execution"
+	"github.com/iotexproject/iotex-core/action/protocol/poll"
+	"github.com/iotexproject/iotex-core/action/protocol/randomness"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/blockchain/filedao"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/util/blockutil"
+	"github.com/iotexproject/iotex-core/state/factory"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+const (
+	// GasLimit is the gas limit of the actions signed by the chain
+	GasLimit = uint64(10000000)
+)
+
+type (
+	bucket struct {
+		staker    crypto.PrivateKey
+		candidate string
+		amount    *big.Int
+		duration  uint32
+		autoStake bool
+	}
+
+	// Builder builds a test chain
+	Builder struct {
+		t        *testing.T
+		genesis  genesis.Genesis
+		chain    blockchain.Config
+		actpool  actpool.Config
+		producer crypto.PrivateKey
+		buckets  []*bucket
+		gasPrice *big.Int
+	}
+
+	// Chain is a test chain, which mints a block of the given actions at a time
+	Chain struct {
+		t        *testing.T
+		genesis  genesis.Genesis
+		producer crypto.PrivateKey
+		bc       blockchain.Blockchain
+		sf       factory.Factory
+		dao      blockdao.BlockDAO
+		ap       actpool.ActPool
+		registry *protocol.Registry
+		nonces   map[string]uint64
+		gasPrice *big.Int
+	}
+)
+
+// NewBuilder returns a builder of a chain on the test default genesis, where the identities are funded, the first
+// of them are the delegates, and the identity 0 produces the blocks
+func NewBuilder(t *testing.T) *Builder {
+	chainCfg := blockchain.DefaultConfig
+	producer := identityset.PrivateKey(0)
+	chainCfg.ProducerPrivKey = hex.EncodeToString(producer.Bytes())
+	apCfg := actpool.DefaultConfig
+	apCfg.MinGasPriceStr = "0"
+	return &Builder{
+		t:        t,
+		genesis:  genesis.TestDefault(),
+		chain:    chainCfg,
+		actpool:  apCfg,
+		producer: producer,
+		gasPrice: big.NewInt(0),
+	}
+}
+
+// Genesis modifies the genesis
+func (b *Builder) Genesis(fn func(*genesis.Genesis)) *Builder {
+	fn(&b.genesis)
+	return b
+}
+
+// Producer sets the producer of the blocks
+func (b *Builder) Producer(sk crypto.PrivateKey) *Builder {
+	b.producer = sk
+	b.chain.ProducerPrivKey = hex.EncodeToString(sk.Bytes())
+	return b
+}
+
+// Delegates sets the lifelong delegates, whose operator and reward addresses are the same
+func (b *Builder) Delegates(votes *big.Int, sks ...crypto.PrivateKey) *Builder {
+	b.genesis.Delegates = b.genesis.Delegates[:0]
+	for _, sk := range sks {
+		addr := sk.PublicKey().Address().String()
+		b.genesis.Delegates = append(b.genesis.Delegates, genesis.Delegate{
+			OperatorAddrStr: addr,
+			RewardAddrStr:   addr,
+			VotesStr:        votes.String(),
+		})
+	}
+	b.genesis.NumDelegates = uint64(len(sks))
+	if b.genesis.NumCandidateDelegates < b.genesis.NumDelegates {
+		b.genesis.NumCandidateDelegates = b.genesis.NumDelegates
+	}
+	return b
+}
+
+// GasPrice sets the gas price the actions are signed with, which is 0 by default
+func (b *Builder) GasPrice(price *big.Int) *Builder {
+	b.gasPrice = price
+	return b
+}
+
+// Fund sets the initial balance of the address
+func (b *Builder) Fund(addr address.Address, amount *big.Int) *Builder {
+	b.genesis.InitBalanceMap[addr.String()] = amount.String()
+	return b
+}
+
+// Candidate bootstraps a staking candidate in the genesis, whose owner, operator and reward address are the same
+func (b *Builder) Candidate(name string, owner address.Address, selfStake *big.Int) *Builder {
+	b.genesis.BootstrapCandidates = append(b.genesis.BootstrapCandidates, genesis.BootstrapCandidate{
+		OwnerAddress:      owner.String(),
+		OperatorAddress:   owner.String(),
+		RewardAddress:     owner.String(),
+		Name:              name,
+		SelfStakingTokens: selfStake.String(),
+	})
+	return b
+}
+
+// Bucket creates a stake bucket voting for the candidate in the first block
+func (b *Builder) Bucket(staker crypto.PrivateKey, candidate string, amount *big.Int, duration uint32, autoStake bool) *Builder {
+	b.buckets = append(b.buckets, &bucket{
+		staker:    staker,
+		candidate: candidate,
+		amount:    amount,
+		duration:  duration,
+		autoStake: autoStake,
+	})
+	return b
+}
+
+// Build builds and starts the chain, which is stopped at the end of the test
+func (b *Builder) Build() *Chain {
+	r := require.New(b.t)
+	registry := protocol.NewRegistry()
+	sf, err := factory.NewFactory(factory.GenerateConfig(b.chain, b.genesis), db.NewMemKVStore(), factory.RegistryOption(registry))
+	r.NoError(err)
+	ap, err := actpool.NewActPool(b.genesis, sf, b.actpool)
+	r.NoError(err)
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(sf, accountutil.AccountState))
+	store, err := filedao.NewFileDAOInMemForTest()
+	r.NoError(err)
+	dao := blockdao.NewBlockDAOWithIndexersAndCache(store, []blockdao.BlockIndexer{sf}, 16)
+	bc := blockchain.NewBlockchain(
+		b.chain,
+		b.genesis,
+		dao,
+		factory.NewMinter(sf, ap),
+		blockchain.BlockValidatorOption(block.NewValidator(
+			sf,
+			protocol.NewGenericValidator(sf, accountutil.AccountState),
+		)),
+	)
+	r.NoError(bc.AddSubscriber(ap))
+	g := b.genesis
+	// the time of a future block is predicted as the node does, which the fork times of the evm rely on
+	btc, err := blockutil.NewBlockTimeCalculator(func(uint64) time.Duration { return g.BlockInterval }, bc.TipHeight, func(height uint64) (time.Time, error) {
+		blk, err := dao.GetBlockByHeight(height)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return blk.Timestamp(), nil
+	})
+	r.NoError(err)
+	getBlockTime := btc.CalculateBlockTime
+	stakingProtocol, err := staking.NewProtocol(
+		staking.HelperCtx{
+			DepositGas:    rewarding.DepositGas,
+			BlockInterval: func(uint64) time.Duration { return g.BlockInterval },
+		},
+		&staking.BuilderConfig{
+			Staking: g.Staking,
+			Revise: staking.ReviseConfig{
+				VoteWeight:                  g.VoteWeightCalConsts,
+				ReviseHeights:               []uint64{g.GreenlandBlockHeight, g.HawaiiBlockHeight},
+				CorrectCandsHeight:          g.OkhotskBlockHeight,
+				SelfStakeBucketReviseHeight: g.UpernavikBlockHeight,
+			},
+		},
+		nil,
+		nil,
+		nil,
+	)
+	r.NoError(err)
+	for _, p := range []protocol.Protocol{
+		account.NewProtocol(rewarding.DepositGas),
+		execution.NewProtocol(dao.GetBlockHash, rewarding.DepositGas, getBlockTime),
+		rolldpos.NewProtocol(
+			g.NumCandidateDelegates,
+			g.NumDelegates,
+			g.NumSubEpochs,
+			rolldpos.EnableDardanellesSubEpoch(g.DardanellesBlockHeight, g.DardanellesNumSubEpochs),
+		),
+		poll.NewLifeLongDelegatesProtocol(g.Delegates),
+		rewarding.NewProtocol(g.Rewarding),
+		randomness.NewProtocol(),
+		stakingProtocol,
+	} {
+		r.NoError(p.Register(registry))
+	}
+	ctx := context.Background()
+	r.NoError(bc.Start(ctx))
+	b.t.Cleanup(func() {
+		r.NoError(bc.Stop(ctx))
+	})
+	c := &Chain{
+		t:        b.t,
+		genesis:  g,
+		producer: b.producer,
+		bc:       bc,
+		sf:       sf,
+		dao:      dao,
+		ap:       ap,
+		registry: registry,
+		nonces:   make(map[string]uint64),
+		gasPrice: b.gasPrice,
+	}
+	if len(b.buckets) > 0 {
+		acts := make([]*action.SealedEnvelope, 0, len(b.buckets))
+		for _, bkt := range b.buckets {
+			selp, err := action.SignedCreateStake(c.Nonce(bkt.staker.PublicKey().Address()), bkt.candidate,
+				bkt.amount.String(), bkt.duration, bkt.autoStake, nil, GasLimit, c.gasPrice, bkt.staker, c.chainIDOption())
+			r.NoError(err)
+			acts = append(acts, selp)
+		}
+		c.MintBlock(acts...)
+		for _, selp := range acts {
+			c.RequireReceiptStatus(selp, iotextypes.ReceiptStatus_Success)
+		}
+	}
+	return c
+}
+
+// Genesis returns the genesis of the chain
+func (c *Chain) Genesis() genesis.Genesis {
+	return c.genesis
+}
+
+// Blockchain returns the blockchain
+func (c *Chain) Blockchain() blockchain.Blockchain {
+	return c.bc
+}
+
+// StateFactory returns the state factory
+func (c *Chain) StateFactory() factory.Factory {
+	return c.sf
+}
+
+// BlockDAO returns the block dao
+func (c *Chain) BlockDAO() blockdao.BlockDAO {
+	return c.dao
+}
+
+// ActPool returns the actpool
+func (c *Chain) ActPool() actpool.ActPool {
+	return c.ap
+}
+
+// Registry returns the protocol registry
+func (c *Chain) Registry() *protocol.Registry {
+	return c.registry
+}
+
+// Producer returns the private key of the block producer
+func (c *Chain) Producer() crypto.PrivateKey {
+	return c.producer
+}
+
+// TipHeight returns the tip height
+func (c *Chain) TipHeight() uint64 {
+	return c.bc.TipHeight()
+}
+
+// Context returns the context to read the states at the tip
+func (c *Chain) Context() context.Context {
+	return protocol.WithReadCtx(context.Background(), c.genesis, c.registry, c.bc.TipHeight())
+}
+
+// Nonce returns the nonce for the next action of the address, and counts it as used
+func (c *Chain) Nonce(addr address.Address) uint64 {
+	nonce, ok := c.nonces[addr.String()]
+	if !ok {
+		var err error
+		nonce, err = c.ap.GetPendingNonce(addr.String())
+		require.NoError(c.t, err)
+	}
+	c.nonces[addr.String()] = nonce + 1
+	return nonce
+}
+
+// Transfer signs a transfer of the sender with the next nonce
+func (c *Chain) Transfer(sender crypto.PrivateKey, recipient address.Address, amount *big.Int) *action.SealedEnvelope {
+	selp, err := action.SignedTransfer(recipient.String(), sender, c.Nonce(sender.PublicKey().Address()), amount, nil,
+		GasLimit, c.gasPrice, c.chainIDOption())
+	require.NoError(c.t, err)
+	return selp
+}
+
+// Sign signs the envelope built by fn from the next nonce of the signer, the gas limit and the gas price
+func (c *Chain) Sign(signer crypto.PrivateKey, fn func(nonce, gasLimit uint64, gasPrice *big.Int) (*action.SealedEnvelope, error)) *action.SealedEnvelope {
+	selp, err := fn(c.Nonce(signer.PublicKey().Address()), GasLimit, c.gasPrice)
+	require.NoError(c.t, err)
+	return selp
+}
+
+// ChainID returns the chain id to sign the actions with
+func (c *Chain) ChainID() uint32 {
+	return c.bc.ChainID()
+}
+
+func (c *Chain) chainIDOption() action.SignedActionOption {
+	return action.WithChainID(c.bc.ChainID())
+}
+
+// MintBlock mints and commits a block of the actions, whose timestamp is the block interval after the tip
+func (c *Chain) MintBlock(acts ...*action.SealedEnvelope) *block.Block {
+	r := require.New(c.t)
+	ctx := context.Background()
+	for _, selp := range acts {
+		r.NoError(c.ap.Add(ctx, selp))
+	}
+	height := c.bc.TipHeight() + 1
+	blk, err := c.bc.MintNewBlock(time.Unix(c.genesis.Timestamp, 0).Add(time.Duration(height) * c.genesis.BlockInterval))
+	r.NoError(err)
+	r.NoError(c.bc.CommitBlock(blk))
+	return blk
+}
+
+// MintBlocks mints n empty blocks
+func (c *Chain) MintBlocks(n int) {
+	for i := 0; i < n; i++ {
+		c.MintBlock()
+	}
+}
+
+// Receipt returns the receipt of the action
+func (c *Chain) Receipt(selp *action.SealedEnvelope) *action.Receipt {
+	r := require.New(c.t)
+	h, err := selp.Hash()
+	r.NoError(err)
+	receipt, err := c.receipt(h)
+	r.NoError(err)
+	return receipt
+}
+
+func (c *Chain) receipt(h hash.Hash256) (*action.Receipt, error) {
+	for height := c.bc.TipHeight(); height > 0; height-- {
+		receipts, err := c.dao.GetReceipts(height)
+		if err != nil {
+			return nil, err
+		}
+		for _, receipt := range receipts {
+			if receipt.ActionHash == h {
+				return receipt, nil
+			}
+		}
+	}
+	return nil, db.ErrNotExist
+}
+
+// RequireReceiptStatus asserts the receipt status of the action
+func (c *Chain) RequireReceiptStatus(selp *action.SealedEnvelope, status iotextypes.ReceiptStatus) {
+	receipt := c.Receipt(selp)
+	require.Equalf(c.t, uint64(status), receipt.Status, "revert msg: %s", receipt.ExecutionRevertMsg())
+}
+
+// Balance returns the balance of the address
+func (c *Chain) Balance(addr address.Address) *big.Int {
+	state, err := accountutil.AccountState(c.Context(), c.sf, addr)
+	require.NoError(c.t, err)
+	return state.Balance
+}
+
+// RequireBalance asserts the balance of the address
+func (c *Chain) RequireBalance(addr address.Address, expected *big.Int) {
+	require.Equalf(c.t, expected.String(), c.Balance(addr).String(), "balance of %s", addr.String())
+}
+
+// ReadState reads the state of the protocol at the tip
+func (c *Chain) ReadState(protocolID string, method []byte, args ...[]byte) ([]byte, error) {
+	p, ok := c.registry.Find(protocolID)
+	require.Truef(c.t, ok, "protocol %s isn't registered", protocolID)
+	data, _, err := p.ReadState(c.Context(), c.sf, method, args...)
+	return data, err
+}
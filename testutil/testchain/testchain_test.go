@@ -0,0 +1,115 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package testchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestChain(t *testing.T) {
+	r := require.New(t)
+	selfStake := unit.ConvertIotxToRau(1200000)
+	stake := unit.ConvertIotxToRau(100)
+	chain := NewBuilder(t).
+		Delegates(unit.ConvertIotxToRau(10), identityset.PrivateKey(0), identityset.PrivateKey(1), identityset.PrivateKey(2)).
+		Fund(identityset.Address(30), unit.ConvertIotxToRau(1000)).
+		Candidate("cand1", identityset.Address(1), selfStake).
+		Bucket(identityset.PrivateKey(30), "cand1", stake, 1, false).
+		Build()
+	r.EqualValues(1, chain.TipHeight())
+	r.Equal(new(big.Int).Sub(unit.ConvertIotxToRau(1000), stake), chain.Balance(identityset.Address(30)))
+
+	amount := big.NewInt(10)
+	for i := 0; i < 10; i++ {
+		selp := chain.Transfer(identityset.PrivateKey(30), identityset.Address(31), amount)
+		blk := chain.MintBlock(selp)
+		r.EqualValues(i+2, blk.Height())
+		r.Equal(chain.Genesis().Timestamp+int64(blk.Height())*int64(chain.Genesis().BlockInterval.Seconds()), blk.Timestamp().Unix())
+		chain.RequireReceiptStatus(selp, iotextypes.ReceiptStatus_Success)
+	}
+	chain.RequireBalance(identityset.Address(30), new(big.Int).Sub(unit.ConvertIotxToRau(1000), new(big.Int).Add(stake, big.NewInt(100))))
+
+	method, err := proto.Marshal(&iotexapi.ReadStakingDataMethod{
+		Method: iotexapi.ReadStakingDataMethod_CANDIDATE_BY_NAME,
+	})
+	r.NoError(err)
+	arg, err := proto.Marshal(&iotexapi.ReadStakingDataRequest{
+		Request: &iotexapi.ReadStakingDataRequest_CandidateByName_{
+			CandidateByName: &iotexapi.ReadStakingDataRequest_CandidateByName{CandName: "cand1"},
+		},
+	})
+	r.NoError(err)
+	data, err := chain.ReadState("staking", method, arg)
+	r.NoError(err)
+	cand := &iotextypes.CandidateV2{}
+	r.NoError(proto.Unmarshal(data, cand))
+	r.Equal(identityset.Address(1).String(), cand.OwnerAddress)
+	r.Equal(selfStake.String(), cand.SelfStakingTokens)
+}
+
+func TestChain_Isolation(t *testing.T) {
+	r := require.New(t)
+	// two chains of different genesis params live in the same process
+	type params struct {
+		initBalance, blockReward, selfStake *big.Int
+	}
+	cases := []params{
+		{unit.ConvertIotxToRau(1000000), unit.ConvertIotxToRau(16), unit.ConvertIotxToRau(1200000)},
+		{unit.ConvertIotxToRau(2000000), unit.ConvertIotxToRau(8), unit.ConvertIotxToRau(2400000)},
+	}
+	chains := make([]*Chain, len(cases))
+	for i, c := range cases {
+		chains[i] = NewBuilder(t).
+			Genesis(func(g *genesis.Genesis) {
+				g.Rewarding.InitBalanceStr = c.initBalance.String()
+				g.Rewarding.BlockRewardStr = c.blockReward.String()
+				g.Rewarding.DardanellesBlockRewardStr = c.blockReward.String()
+			}).
+			Delegates(unit.ConvertIotxToRau(10), identityset.PrivateKey(0), identityset.PrivateKey(1)).
+			Candidate("cand1", identityset.Address(1), c.selfStake).
+			Build()
+	}
+	method, err := proto.Marshal(&iotexapi.ReadStakingDataMethod{
+		Method: iotexapi.ReadStakingDataMethod_CANDIDATE_BY_NAME,
+	})
+	r.NoError(err)
+	arg, err := proto.Marshal(&iotexapi.ReadStakingDataRequest{
+		Request: &iotexapi.ReadStakingDataRequest_CandidateByName_{
+			CandidateByName: &iotexapi.ReadStakingDataRequest_CandidateByName{CandName: "cand1"},
+		},
+	})
+	r.NoError(err)
+	for round := 0; round < 3; round++ {
+		for i, chain := range chains {
+			chain.MintBlock()
+			rp := rewarding.FindProtocol(chain.Registry())
+			r.NotNil(rp)
+			reward, err := rp.BlockReward(chain.Context(), chain.StateFactory())
+			r.NoError(err)
+			r.Equal(cases[i].blockReward, reward)
+			data, err := chain.ReadState("rewarding", []byte("TotalBalance"))
+			r.NoError(err)
+			r.Equal(cases[i].initBalance.String(), string(data))
+			data, err = chain.ReadState("staking", method, arg)
+			r.NoError(err)
+			cand := &iotextypes.CandidateV2{}
+			r.NoError(proto.Unmarshal(data, cand))
+			r.Equal(cases[i].selfStake.String(), cand.SelfStakingTokens)
+		}
+	}
+	r.Equal(chains[0].TipHeight(), chains[1].TipHeight())
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/iotexproject/iotex-core/blockchain/backup"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/tools/iomigrater/common"
+)
+
+// Multi-language support
+var (
+	backupCmdShorts = map[string]string{
+		"english": "Sub-Command for taking an online backup of IoTeX node dbs.",
+		"chinese": "在线备份IoTeX节点 db 的子命令",
+	}
+	backupCmdLongs = map[string]string{
+		"english": "Sub-Command for taking an online backup of IoTeX node dbs through the admin port of the node, which waits until the backup is done.",
+		"chinese": "通过节点的管理端口在线备份IoTeX节点 db 的子命令，等待备份完成。",
+	}
+	backupCmdUse = map[string]string{
+		"english": "backup",
+		"chinese": "backup",
+	}
+	backupFlagEndpointUse = map[string]string{
+		"english": "The admin endpoint of the node.",
+		"chinese": "节点的管理端口地址。",
+	}
+	backupFlagNameUse = map[string]string{
+		"english": "The name of the backup, which is a directory under the backup dir of the node.",
+		"chinese": "备份的名称，即节点备份目录下的子目录。",
+	}
+	restoreBackupCmdShorts = map[string]string{
+		"english": "Sub-Command for restoring an online backup of IoTeX node dbs.",
+		"chinese": "恢复IoTeX节点 db 在线备份的子命令",
+	}
+	restoreBackupCmdLongs = map[string]string{
+		"english": "Sub-Command for restoring an online backup of IoTeX node dbs into the db paths in the config, which should not exist.",
+		"chinese": "将IoTeX节点 db 在线备份恢复到配置中的 db 路径的子命令，这些路径不能已存在。",
+	}
+	restoreBackupCmdUse = map[string]string{
+		"english": "restore-backup",
+		"chinese": "restore-backup",
+	}
+	restoreBackupFlagDirUse = map[string]string{
+		"english": "The directory of the backup.",
+		"chinese": "备份所在的目录。",
+	}
+	restoreBackupFlagConfigUse = map[string]string{
+		"english": "The config file of the node.",
+		"chinese": "节点的配置文件。",
+	}
+)
+
+var (
+	// Backup Used to Sub command.
+	Backup = &cobra.Command{
+		Use:   common.TranslateInLang(backupCmdUse),
+		Short: common.TranslateInLang(backupCmdShorts),
+		Long:  common.TranslateInLang(backupCmdLongs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return takeBackup()
+		},
+	}
+
+	// RestoreBackup Used to Sub command.
+	RestoreBackup = &cobra.Command{
+		Use:   common.TranslateInLang(restoreBackupCmdUse),
+		Short: common.TranslateInLang(restoreBackupCmdShorts),
+		Long:  common.TranslateInLang(restoreBackupCmdLongs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return restoreBackup()
+		},
+	}
+)
+
+var (
+	adminEndpoint = "http://localhost:9009"
+	backupName    = ""
+	backupDir     = ""
+	configPath    = ""
+)
+
+type backupStatus struct {
+	Dir     string `json:"dir"`
+	Height  uint64 `json:"height"`
+	Root    string `json:"root"`
+	Running bool   `json:"running"`
+	Error   string `json:"error"`
+}
+
+func init() {
+	Backup.PersistentFlags().StringVarP(&adminEndpoint, "admin-endpoint", "e", "http://localhost:9009", common.TranslateInLang(backupFlagEndpointUse))
+	Backup.PersistentFlags().StringVarP(&backupName, "name", "m", "", common.TranslateInLang(backupFlagNameUse))
+	RestoreBackup.PersistentFlags().StringVarP(&backupDir, "backup-dir", "d", "", common.TranslateInLang(restoreBackupFlagDirUse))
+	RestoreBackup.PersistentFlags().StringVarP(&configPath, "config-path", "c", "", common.TranslateInLang(restoreBackupFlagConfigUse))
+}
+
+func takeBackup() error {
+	if backupName == "" {
+		return fmt.Errorf("--name is empty")
+	}
+	endpoint := strings.TrimSuffix(adminEndpoint, "/") + "/backup"
+	status, err := getBackupStatus(endpoint + "?name=" + url.QueryEscape(backupName))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Taking backup into %s\n", status.Dir)
+	for status.Running {
+		time.Sleep(2 * time.Second)
+		if status, err = getBackupStatus(endpoint); err != nil {
+			return err
+		}
+	}
+	if status.Error != "" {
+		return errors.Errorf("failed to take backup: %s", status.Error)
+	}
+	fmt.Printf("Backup is taken at height %d, state root %s\n", status.Height, status.Root)
+	return nil
+}
+
+func getBackupStatus(endpoint string) (*backupStatus, error) {
+	resp, err := http.Get(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("admin endpoint returns status %s", resp.Status)
+	}
+	status := &backupStatus{}
+	if err := json.Unmarshal(data, status); err != nil {
+		return nil, errors.Wrap(err, "failed to parse backup status")
+	}
+	return status, nil
+}
+
+func restoreBackup() error {
+	if backupDir == "" {
+		return fmt.Errorf("--backup-dir is empty")
+	}
+	if configPath == "" {
+		return fmt.Errorf("--config-path is empty")
+	}
+	cfg, err := config.New([]string{configPath}, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to load config")
+	}
+	manifest, err := backup.Restore(backupDir, backup.StorePaths(cfg.Chain), cfg.DB, block.NewDeserializer(cfg.Chain.EVMNetworkID))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Backup at height %d is restored\n", manifest.Height)
+	return nil
+}
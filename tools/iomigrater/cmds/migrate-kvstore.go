@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/tools/iomigrater/common"
+)
+
+// Multi-language support
+var (
+	migrateKVStoreCmdShorts = map[string]string{
+		"english": "Sub-Command for migration IoTeX bolt db file into pebble db.",
+		"chinese": "将IoTeX bolt db 文件迁移到 pebble db 的子命令",
+	}
+	migrateKVStoreCmdLongs = map[string]string{
+		"english": "Sub-Command for migration IoTeX bolt db file, e.g., the trie db or an index db, into pebble db.",
+		"chinese": "将IoTeX bolt db 文件（例如状态 db 或索引 db）迁移到 pebble db 的子命令",
+	}
+	migrateKVStoreCmdUse = map[string]string{
+		"english": "migrate-kvstore",
+		"chinese": "migrate-kvstore",
+	}
+	migrateKVStoreFlagBoltFileUse = map[string]string{
+		"english": "The bolt db file you want to migrate.",
+		"chinese": "您要迁移的 bolt db 文件。",
+	}
+	migrateKVStoreFlagPebbleDirUse = map[string]string{
+		"english": "The pebble db directory you want to migrate to, which should not exist.",
+		"chinese": "您要迁移到的 pebble db 目录，该目录不能已存在。",
+	}
+	migrateKVStoreFlagBatchSizeUse = map[string]string{
+		"english": "The number of records written into pebble db in a batch.",
+		"chinese": "每批写入 pebble db 的记录数。",
+	}
+)
+
+var (
+	// MigrateKVStore Used to Sub command.
+	MigrateKVStore = &cobra.Command{
+		Use:   common.TranslateInLang(migrateKVStoreCmdUse),
+		Short: common.TranslateInLang(migrateKVStoreCmdShorts),
+		Long:  common.TranslateInLang(migrateKVStoreCmdLongs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return migrateKVStore()
+		},
+	}
+)
+
+var (
+	boltFile  = ""
+	pebbleDir = ""
+	batchSize = 10000
+)
+
+func init() {
+	MigrateKVStore.PersistentFlags().StringVarP(&boltFile, "bolt-file", "o", "", common.TranslateInLang(migrateKVStoreFlagBoltFileUse))
+	MigrateKVStore.PersistentFlags().StringVarP(&pebbleDir, "pebble-dir", "n", "", common.TranslateInLang(migrateKVStoreFlagPebbleDirUse))
+	MigrateKVStore.PersistentFlags().IntVarP(&batchSize, "batch-size", "s", 10000, common.TranslateInLang(migrateKVStoreFlagBatchSizeUse))
+}
+
+func migrateKVStore() (err error) {
+	// Check flags
+	if boltFile == "" {
+		return fmt.Errorf("--bolt-file is empty")
+	}
+	if pebbleDir == "" {
+		return fmt.Errorf("--pebble-dir is empty")
+	}
+	if _, err := os.Stat(boltFile); err != nil {
+		return errors.Wrapf(err, "failed to find bolt db file %s", boltFile)
+	}
+	if _, err := os.Stat(pebbleDir); !os.IsNotExist(err) {
+		return fmt.Errorf("the pebble db directory %s already exists", pebbleDir)
+	}
+
+	cfg := db.DefaultConfig
+	cfg.DbPath = boltFile
+	cfg.ReadOnly = true
+	src := db.NewBoltDB(cfg)
+	cfg.DbPath = pebbleDir
+	cfg.ReadOnly = false
+	dst := db.NewPebbleDB(cfg)
+
+	ctx := context.Background()
+	if err := src.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start the bolt db file: %v", err)
+	}
+	defer func() {
+		if e := src.Stop(ctx); e != nil && err == nil {
+			err = e
+		}
+	}()
+	if err := dst.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start the pebble db: %v", err)
+	}
+	defer func() {
+		if e := dst.Stop(ctx); e != nil && err == nil {
+			err = e
+		}
+	}()
+
+	return db.CopyKVStore(src, dst, batchSize, func(ns string, count uint64) {
+		fmt.Printf("Migrated %d records, in bucket %s.\n", count, ns)
+	})
+}
@@ -47,6 +47,9 @@ func Execute() {
 func init() {
 	RootCmd.AddCommand(cmd.CheckHeight)
 	RootCmd.AddCommand(cmd.MigrateDb)
+	RootCmd.AddCommand(cmd.MigrateKVStore)
+	RootCmd.AddCommand(cmd.Backup)
+	RootCmd.AddCommand(cmd.RestoreBackup)
 
 	RootCmd.HelpFunc()
 }
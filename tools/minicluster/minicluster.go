@@ -28,7 +28,6 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
 	"github.com/iotexproject/iotex-core/blockchain"
-	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/probe"
@@ -397,14 +396,7 @@ func main() {
 		for i := 0; i < _numNodes; i++ {
 			registries[i] = svrs[i].ChainService(configs[i].Chain.ID).Registry()
 
-			ctx := protocol.WithBlockCtx(context.Background(), protocol.BlockCtx{
-				BlockHeight: bcHeights[i],
-			})
-			ctx = genesis.WithGenesisContext(
-				protocol.WithRegistry(ctx, registries[i]),
-				chains[i].Genesis(),
-			)
-			ctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
+			ctx := protocol.WithReadCtx(context.Background(), chains[i].Genesis(), registries[i], bcHeights[i])
 
 			rp := rewarding.FindProtocol(registries[i])
 			if rp == nil {
@@ -0,0 +1,129 @@
+// Copyright (c) 2024 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+// This is a tool to bootstrap a new node from a state snapshot, instead of replaying all the blocks.
+// It rebuilds the state db from the snapshot, and initializes the chain db with the block at snapshot
+// height, so that the node syncs the blocks after the snapshot height once started. The indexers built
+// from the blocks, e.g., the ones of the gateway, are not included, and should be disabled on the node.
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	glog "log"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/filedao"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/state/factory"
+)
+
+/**
+ * snapshotDir is the directory of the snapshot to import
+ * blockHash is the trusted hash of the block at snapshot height
+ * overwritePath is the path to the config file which overwrite default values
+ * secretPath is the path to the  config file store secret values
+ */
+var (
+	_snapshotDir   string
+	_blockHash     string
+	_genesisPath   string
+	_overwritePath string
+	_secretPath    string
+	_plugins       strs
+)
+
+type strs []string
+
+func (ss *strs) String() string {
+	return strings.Join(*ss, ",")
+}
+
+func (ss *strs) Set(str string) error {
+	*ss = append(*ss, str)
+	return nil
+}
+
+func init() {
+	flag.StringVar(&_snapshotDir, "snapshot-dir", "", "Snapshot directory")
+	flag.StringVar(&_blockHash, "block-hash", "", "Trusted hash of the block at snapshot height")
+	flag.StringVar(&_genesisPath, "genesis-path", "", "Genesis path")
+	flag.StringVar(&_overwritePath, "config-path", "", "Config path")
+	flag.StringVar(&_secretPath, "secret-path", "", "Secret path")
+	flag.Var(&_plugins, "plugin", "Plugin of the node")
+	flag.Usage = func() {
+		_, _ = fmt.Fprintf(os.Stderr,
+			"usage: snapshotimporter -config-path=[string]\n -snapshot-dir=[string]\n -block-hash=[string]\n")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+	flag.Parse()
+}
+
+func main() {
+	genesisCfg, err := genesis.New(_genesisPath)
+	if err != nil {
+		glog.Fatalln("Failed to new genesis config.", zap.Error(err))
+	}
+	cfg, err := config.New([]string{_overwritePath, _secretPath}, _plugins)
+	if err != nil {
+		glog.Fatalln("Failed to new config.", zap.Error(err))
+	}
+	cfg.Genesis = genesisCfg
+
+	if err := importSnapshot(cfg, _snapshotDir, _blockHash); err != nil {
+		log.L().Fatal("Failed to import snapshot.", zap.Error(err))
+	}
+	log.S().Infof("Success to import snapshot %s", _snapshotDir)
+}
+
+func importSnapshot(cfg config.Config, dir string, trustedHash string) error {
+	if cfg.Chain.EnableTrielessStateDB {
+		return errors.New("importing snapshot requires the trie-based state db")
+	}
+	deser := block.NewDeserializer(cfg.Chain.EVMNetworkID)
+	blk, err := factory.ReadSnapshotBlock(dir, deser)
+	if err != nil {
+		return errors.Wrap(err, "failed to read the block at snapshot height")
+	}
+	h := blk.Block.HashBlock()
+	if hex.EncodeToString(h[:]) != strings.TrimPrefix(trustedHash, "0x") {
+		return errors.Errorf("block hash %x doesn't match the trusted hash %s", h, trustedHash)
+	}
+
+	// rebuild the state db
+	ctx := context.Background()
+	factoryDBCfg := cfg.DB
+	factoryDBCfg.DBType = cfg.Chain.FactoryDBType
+	dao, err := db.CreateKVStore(factoryDBCfg, cfg.Chain.TrieDBPath)
+	if err != nil {
+		return err
+	}
+	if err := dao.Start(ctx); err != nil {
+		return err
+	}
+	if err := factory.ImportSnapshot(ctx, dao, dir, &blk.Block.Header); err != nil {
+		return err
+	}
+	if err := dao.Stop(ctx); err != nil {
+		return err
+	}
+
+	// initialize the chain db with the block at snapshot height
+	blk.Block.Receipts = blk.Receipts
+	chainDBCfg := cfg.DB
+	chainDBCfg.DbPath = cfg.Chain.ChainDBPath
+	return filedao.InitFileDAOWithBlock(chainDBCfg, deser, blk.Block)
+}
@@ -3,11 +3,13 @@
 // or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
 // This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
 
-// This is a recovery tool that recovers a corrupted or missing state database.
+// This is a recovery tool that rolls back the chain db, the indexers and the state db of a stopped node to a
+// target height, the states are then replayed on the next start of the node.
 // To use, run "make recover"
 package main
 
 import (
+	"bufio"
 	"context"
 	"flag"
 	"fmt"
@@ -17,12 +19,10 @@ import (
 
 	"go.uber.org/zap"
 
-	"github.com/iotexproject/iotex-core/blockchain/blockdao"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/server/itx"
-	"github.com/iotexproject/iotex-core/state/factory"
 )
 
 // recoveryHeight is the blockchain height being recovered to
@@ -31,11 +31,14 @@ var recoveryHeight int
 /**
  * overwritePath is the path to the config file which overwrite default values
  * secretPath is the path to the  config file store secret values
+ * snapshotDir is the state snapshot to replay the states from, instead of genesis
  */
 var (
 	genesisPath    string
 	_overwritePath string
 	_secretPath    string
+	_snapshotDir   string
+	_assumeYes     bool
 	_plugins       strs
 )
 
@@ -56,9 +59,11 @@ func init() {
 	flag.StringVar(&_secretPath, "secret-path", "", "Secret path")
 	flag.Var(&_plugins, "plugin", "Plugin of the node")
 	flag.IntVar(&recoveryHeight, "recovery-height", 0, "Recovery height")
+	flag.StringVar(&_snapshotDir, "snapshot-dir", "", "State snapshot at or below the recovery height to replay the states from")
+	flag.BoolVar(&_assumeYes, "yes", false, "Roll back without confirmation")
 	flag.Usage = func() {
 		_, _ = fmt.Fprintf(os.Stderr,
-			"usage: recover -config-path=[string]\n -recovery-height=[int]\n")
+			"usage: recover -config-path=[string]\n -recovery-height=[int]\n -snapshot-dir=[string]\n")
 		flag.PrintDefaults()
 		os.Exit(2)
 	}
@@ -86,44 +91,30 @@ func main() {
 		log.L().Fatal("Failed to create server.", zap.Error(err))
 	}
 
-	// recover chain and state
-	bc := svr.ChainService(cfg.Chain.ID).Blockchain()
-	sf := svr.ChainService(cfg.Chain.ID).StateFactory()
-	dao := svr.ChainService(cfg.Chain.ID).BlockDAO()
-	if err := bc.Start(context.Background()); err == nil {
-		log.L().Info("State DB status is normal.")
+	// roll back chain and state
+	cs := svr.ChainService(cfg.Chain.ID)
+	ctx := context.Background()
+	plan, err := cs.PlanRollback(ctx, uint64(recoveryHeight), _snapshotDir)
+	if err != nil {
+		log.L().Fatal("Failed to plan the rollback.", zap.Error(err))
+	}
+	fmt.Println(plan)
+	if !_assumeYes && !confirm() {
+		log.L().Info("Rollback is canceled.")
+		return
 	}
-	defer func() {
-		if err := bc.Stop(context.Background()); err != nil {
-			log.L().Fatal("Failed to stop blockchain")
-		}
-	}()
-	if err := recoverChainAndState(dao, sf, cfg, uint64(recoveryHeight)); err != nil {
+	if err := cs.Rollback(ctx, plan); err != nil {
 		log.L().Fatal("Failed to recover chain and state.", zap.Error(err))
-	} else {
-		log.S().Infof("Success to recover chain and state to target height %d", recoveryHeight)
 	}
+	log.S().Infof("Success to recover chain and state to target height %d, start the node to replay the states", recoveryHeight)
 }
 
-// recoverChainAndState recovers the chain to target height and refresh state db if necessary
-func recoverChainAndState(dao blockdao.BlockDAO, sf factory.Factory, cfg config.Config, targetHeight uint64) error {
-	// TODO: not all indexers could be reverted, e.g., state factory. Thus, dao.DeleteTipBlockToTarget will always
-	// return error. Therefore, it is not hard to tell that this tool is not working. The right way is to revert indexer
-	// one by one. Before we implement it in that way, comment out the following code.
-	/*
-		if err := dao.DeleteBlockToTarget(targetHeight); err != nil {
-			return errors.Wrapf(err, "failed to recover blockchain to target height %d", targetHeight)
-		}
-		stateHeight, err := sf.Height()
-		if err != nil {
-			return err
-		}
-		if targetHeight < stateHeight {
-			// delete existing state DB (build from scratch)
-			if fileutil.FileExists(cfg.Chain.TrieDBPath) && os.Remove(cfg.Chain.TrieDBPath) != nil {
-				return errors.New("failed to delete existing state DB")
-			}
-		}
-	*/
-	panic("not implemented")
+func confirm() bool {
+	fmt.Print("Continue? [y/N] ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes"
 }